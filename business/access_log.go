@@ -0,0 +1,197 @@
+package business
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nitishm/engarde/pkg/parser"
+)
+
+// AccessLogFields are the structured fields of a proxy access log entry, the fields the entries can be filtered by
+type AccessLogFields struct {
+	// Duration of the request, in millis
+	Duration      int    `json:"duration"`
+	ResponseCode  int    `json:"responseCode"`
+	ResponseFlags string `json:"responseFlags,omitempty"`
+	UpstreamHost  string `json:"upstreamHost,omitempty"`
+}
+
+// Access log filter fields
+const (
+	AccessLogFilterDuration      = "duration"
+	AccessLogFilterResponseCode  = "response_code"
+	AccessLogFilterResponseFlags = "response_flags"
+	AccessLogFilterUpstreamHost  = "upstream_host"
+)
+
+// accessLogFilterOperators are the supported operators, the two-character operators first as they are matched in order
+var accessLogFilterOperators = []string{">=", "<=", "!=", "=", ">", "<"}
+
+// AccessLogFilter is a condition on a field of the proxy access log entries, i.e. response_code>=500
+type AccessLogFilter struct {
+	Field    string
+	Operator string
+	Value    string
+	number   int
+}
+
+// jsonAccessLogFields maps the keys of the Istio JSON access log encoding (meshConfig.accessLogEncoding: JSON) to
+// the fields of the access log parsed from the default text format.
+var jsonAccessLogFields = map[string]func(al *parser.AccessLog, value string){
+	"authority":                         func(al *parser.AccessLog, v string) { al.Authority = v },
+	"bytes_received":                    func(al *parser.AccessLog, v string) { al.BytesReceived = v },
+	"bytes_sent":                        func(al *parser.AccessLog, v string) { al.BytesSent = v },
+	"downstream_local_address":          func(al *parser.AccessLog, v string) { al.DownstreamLocal = v },
+	"downstream_remote_address":         func(al *parser.AccessLog, v string) { al.DownstreamRemote = v },
+	"duration":                          func(al *parser.AccessLog, v string) { al.Duration = v },
+	"method":                            func(al *parser.AccessLog, v string) { al.Method = v },
+	"path":                              func(al *parser.AccessLog, v string) { al.UriPath, al.UriParam, _ = strings.Cut(v, "?") },
+	"protocol":                          func(al *parser.AccessLog, v string) { al.Protocol = v },
+	"request_id":                        func(al *parser.AccessLog, v string) { al.RequestId = v },
+	"requested_server_name":             func(al *parser.AccessLog, v string) { al.RequestedServer = v },
+	"response_code":                     func(al *parser.AccessLog, v string) { al.StatusCode = v },
+	"response_flags":                    func(al *parser.AccessLog, v string) { al.ResponseFlags = v },
+	"route_name":                        func(al *parser.AccessLog, v string) { al.RouteName = v },
+	"start_time":                        func(al *parser.AccessLog, v string) { al.Timestamp = v },
+	"upstream_cluster":                  func(al *parser.AccessLog, v string) { al.UpstreamCluster = v },
+	"upstream_host":                     func(al *parser.AccessLog, v string) { al.UpstreamService = v },
+	"upstream_local_address":            func(al *parser.AccessLog, v string) { al.UpstreamLocal = v },
+	"upstream_service_time":             func(al *parser.AccessLog, v string) { al.UpstreamServiceTime = v },
+	"upstream_transport_failure_reason": func(al *parser.AccessLog, v string) { al.UpstreamFailureReason = v },
+	"user_agent":                        func(al *parser.AccessLog, v string) { al.UserAgent = v },
+	"x_forwarded_for":                   func(al *parser.AccessLog, v string) { al.ForwardedFor = v },
+}
+
+// parseAccessLog parses a proxy access log message, in the JSON encoding or in the default text format
+func parseAccessLog(message string, engardeParser *parser.Parser) (*parser.AccessLog, error) {
+	if strings.HasPrefix(message, "{") {
+		if al := parseJSONAccessLog(message); al != nil {
+			return al, nil
+		}
+	}
+	return engardeParser.Parse(message)
+}
+
+// parseJSONAccessLog returns the access log of a JSON encoded message, nil if the message is not an access log
+func parseJSONAccessLog(message string) *parser.AccessLog {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(message)))
+	decoder.UseNumber()
+	fields := map[string]interface{}{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil
+	}
+	if _, ok := fields["response_code"]; !ok {
+		return nil
+	}
+
+	al := &parser.AccessLog{}
+	for key, value := range fields {
+		if set, ok := jsonAccessLogFields[key]; ok && value != nil {
+			set(al, fmt.Sprint(value))
+		}
+	}
+	return al
+}
+
+// newAccessLogFields returns the structured fields of an access log, the unset numbers are 0
+func newAccessLogFields(al *parser.AccessLog) *AccessLogFields {
+	fields := &AccessLogFields{
+		ResponseFlags: al.ResponseFlags,
+		UpstreamHost:  al.UpstreamService,
+	}
+	fields.Duration, _ = strconv.Atoi(al.Duration)
+	fields.ResponseCode, _ = strconv.Atoi(al.StatusCode)
+	return fields
+}
+
+// ParseAccessLogFilters parses a comma-separated list of access log filters, all of them must match an entry
+// (i.e. "response_code>=500,upstream_host!=-"). Numeric fields (duration, response_code) support the =, !=, <,
+// <=, > and >= operators, string fields (response_flags, upstream_host) support = and !=.
+func ParseAccessLogFilters(expr string) ([]AccessLogFilter, error) {
+	filters := []AccessLogFilter{}
+	for _, condition := range strings.Split(expr, ",") {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+		filter := AccessLogFilter{}
+		for _, op := range accessLogFilterOperators {
+			if i := strings.Index(condition, op); i > 0 {
+				filter = AccessLogFilter{
+					Field:    strings.TrimSpace(condition[:i]),
+					Operator: op,
+					Value:    strings.TrimSpace(condition[i+len(op):]),
+				}
+				break
+			}
+		}
+		switch filter.Field {
+		case AccessLogFilterDuration, AccessLogFilterResponseCode:
+			number, err := strconv.Atoi(filter.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid access log filter [%s]: %s is not a number", condition, filter.Value)
+			}
+			filter.number = number
+		case AccessLogFilterResponseFlags, AccessLogFilterUpstreamHost:
+			if filter.Operator != "=" && filter.Operator != "!=" {
+				return nil, fmt.Errorf("invalid access log filter [%s]: %s only supports = and !=", condition, filter.Field)
+			}
+		default:
+			return nil, fmt.Errorf("invalid access log filter [%s]: expecting a condition on %s, %s, %s or %s", condition,
+				AccessLogFilterDuration, AccessLogFilterResponseCode, AccessLogFilterResponseFlags, AccessLogFilterUpstreamHost)
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// matches returns true if the access log fields satisfy the filter
+func (f AccessLogFilter) matches(fields *AccessLogFields) bool {
+	var cmp int
+	switch f.Field {
+	case AccessLogFilterDuration:
+		cmp = fields.Duration - f.number
+	case AccessLogFilterResponseCode:
+		cmp = fields.ResponseCode - f.number
+	case AccessLogFilterResponseFlags:
+		cmp = strings.Compare(fields.ResponseFlags, f.Value)
+	case AccessLogFilterUpstreamHost:
+		cmp = strings.Compare(fields.UpstreamHost, f.Value)
+	}
+
+	switch f.Operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// matchesAccessLogFilters returns true if the entry satisfies every filter. Entries which are not access logs
+// never match a filter.
+func matchesAccessLogFilters(entry *LogEntry, filters []AccessLogFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	if entry.AccessLogFields == nil {
+		return false
+	}
+	for _, f := range filters {
+		if !f.matches(entry.AccessLogFields) {
+			return false
+		}
+	}
+	return true
+}
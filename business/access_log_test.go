@@ -0,0 +1,92 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/nitishm/engarde/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestParseAccessLogFilters(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	filters, err := ParseAccessLogFilters("response_code>=500, upstream_host!=-,duration<100")
+	require.NoError(err)
+	require.Len(filters, 3)
+	assert.Equal(AccessLogFilterResponseCode, filters[0].Field)
+	assert.Equal(">=", filters[0].Operator)
+	assert.Equal("500", filters[0].Value)
+	assert.Equal(AccessLogFilterUpstreamHost, filters[1].Field)
+	assert.Equal("!=", filters[1].Operator)
+	assert.Equal("-", filters[1].Value)
+	assert.Equal("<", filters[2].Operator)
+
+	fields := &AccessLogFields{ResponseCode: 503, Duration: 12, ResponseFlags: "UF", UpstreamHost: "10.244.0.8:9080"}
+	assert.True(matchesAccessLogFilters(&LogEntry{AccessLogFields: fields}, filters))
+	fields.ResponseCode = 200
+	assert.False(matchesAccessLogFilters(&LogEntry{AccessLogFields: fields}, filters))
+	assert.False(matchesAccessLogFilters(&LogEntry{Message: "not an access log"}, filters))
+	assert.True(matchesAccessLogFilters(&LogEntry{Message: "not an access log"}, nil))
+
+	for _, invalid := range []string{"status>=500", "response_code>=5xx", "upstream_host>10", "response_code"} {
+		_, err := ParseAccessLogFilters(invalid)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestParseJSONAccessLog(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	al, err := parseAccessLog(`{"authority":"details:9080","bytes_received":0,"bytes_sent":178,"duration":3,"method":"GET","path":"/details/0?x=1","protocol":"HTTP/1.1","request_id":"e6d6f0b5","response_code":503,"response_flags":"UF","start_time":"2024-05-01T10:00:00.123Z","upstream_cluster":"inbound|9080||","upstream_host":"10.244.0.8:9080","upstream_service_time":null}`,
+		parser.New(parser.IstioProxyAccessLogsPattern))
+	require.NoError(err)
+	assert.Equal("503", al.StatusCode)
+	assert.Equal("/details/0", al.UriPath)
+	assert.Equal("x=1", al.UriParam)
+	assert.Equal("2024-05-01T10:00:00.123Z", al.Timestamp)
+	assert.Empty(al.UpstreamServiceTime)
+
+	fields := newAccessLogFields(al)
+	assert.Equal(&AccessLogFields{Duration: 3, ResponseCode: 503, ResponseFlags: "UF", UpstreamHost: "10.244.0.8:9080"}, fields)
+
+	assert.Nil(parseJSONAccessLog(`{"level":"info","msg":"not an access log"}`))
+}
+
+func TestGetPodLogsAccessLogFilter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	k8s := newContainerLogStreamer()
+	k8s.logs["istio-proxy"] += `2018-01-02T03:34:32+00:00 {"duration":12,"method":"GET","path":"/details/2","response_code":503,"response_flags":"UF","start_time":"2018-01-02T03:34:32.000Z","upstream_host":"10.244.0.8:9080"}
+`
+	conf := config.NewConfig()
+	config.Set(conf)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	opts, err := svc.BuildLogOptionsCriteria("istio-proxy", "", models.LogTypeProxy, "", "", "", "response_code>=500")
+	require.NoError(err)
+	podLogs := callStreamPodLogs(svc, "Namespace", "details-v1-3618568057-dnkjp", opts)
+
+	require.Len(podLogs.Entries, 1)
+	assert.Equal("/details/2", podLogs.Entries[0].AccessLog.UriPath)
+	assert.Equal(&AccessLogFields{Duration: 12, ResponseCode: 503, ResponseFlags: "UF", UpstreamHost: "10.244.0.8:9080"}, podLogs.Entries[0].AccessLogFields)
+
+	// the application logs are not access logs
+	opts, err = svc.BuildLogOptionsCriteria("details,istio-proxy", "", "", "", "", "", "response_code=200")
+	require.NoError(err)
+	podLogs = callStreamPodLogs(svc, "Namespace", "details-v1-3618568057-dnkjp", opts)
+	require.Len(podLogs.Entries, 2)
+	for _, entry := range podLogs.Entries {
+		assert.Equal("istio-proxy", entry.Container)
+	}
+
+	_, err = svc.BuildLogOptionsCriteria("", "", "", "", "", "", "code>=500")
+	assert.Error(err)
+}
@@ -0,0 +1,97 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/store"
+	"github.com/kiali/kiali/util/httputil"
+)
+
+const addonProbeTimeout = 10 * time.Second
+
+type addonProbeResult struct {
+	status    kubernetes.ComponentStatus
+	checkedAt time.Time
+}
+
+// addonProbeResults are the last probes of the addons, keyed by name and url, reused for the addon_status_ttl since
+// the mesh page refreshes the status periodically.
+var addonProbeResults = store.New[string, addonProbeResult]()
+
+// probeAddon returns the status of an addon from its reachability probe, which returns the version of the addon when
+// it is detected. A reachable addon slower than the addon_latency_threshold is degraded.
+func probeAddon(name, url string, isCore bool, probe func() (string, error)) kubernetes.ComponentStatus {
+	conf := config.Get().ExternalServices.Istio.ComponentStatuses
+	key := name + "|" + url
+	if result, found := addonProbeResults.Get(key); found && time.Since(result.checkedAt) < time.Duration(conf.AddonStatusTTL)*time.Second {
+		return result.status
+	}
+
+	start := time.Now()
+	version, err := probe()
+	latency := time.Since(start)
+
+	status := kubernetes.ComponentStatus{Name: name, Status: kubernetes.ComponentHealthy, IsCore: isCore}
+	threshold := time.Duration(conf.AddonLatencyThreshold) * time.Millisecond
+	switch {
+	case err != nil:
+		log.Tracef("addon health check failed: name=[%v], url=[%v], err=[%v]", name, url, err)
+		status.Status = kubernetes.ComponentUnreachable
+		status.Reasons = []string{err.Error()}
+	case threshold > 0 && latency > threshold:
+		status.Status = kubernetes.ComponentDegraded
+		status.Reasons = []string{fmt.Sprintf("reachable in %dms, above the %dms threshold", latency.Milliseconds(), threshold.Milliseconds())}
+		fallthrough
+	default:
+		status.LatencyMs = latency.Milliseconds()
+		status.Version = version
+	}
+
+	addonProbeResults.Set(key, addonProbeResult{status: status, checkedAt: time.Now()})
+	return status
+}
+
+// httpAddonProbe returns the probe of an addon answering its health check url without an error status. The version
+// is read from the version url, when set, and the version detection never fails the probe.
+func httpAddonProbe(healthCheckUrl, versionUrl string, auth *config.Auth) func() (string, error) {
+	return func() (string, error) {
+		_, statusCode, _, err := httputil.HttpGet(healthCheckUrl, auth, addonProbeTimeout, nil, nil)
+		if err != nil {
+			return "", err
+		}
+		if statusCode > 399 {
+			return "", fmt.Errorf("health check returned status %d", statusCode)
+		}
+		if versionUrl == "" {
+			return "", nil
+		}
+		return addonVersion(versionUrl, auth), nil
+	}
+}
+
+// addonVersion returns the version of the addon answered by the version url: the version field of the Grafana health
+// api, or of the data of the Prometheus build info api. Empty when the version is unknown.
+func addonVersion(versionUrl string, auth *config.Auth) string {
+	body, statusCode, _, err := httputil.HttpGet(versionUrl, auth, addonProbeTimeout, nil, nil)
+	if err != nil || statusCode != 200 {
+		return ""
+	}
+	var info struct {
+		Version string `json:"version"`
+		Data    struct {
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return ""
+	}
+	if info.Version != "" {
+		return info.Version
+	}
+	return info.Data.Version
+}
@@ -0,0 +1,57 @@
+package business
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestProbeAddon(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.ComponentStatuses.AddonLatencyThreshold = 50
+	config.Set(conf)
+
+	healthChecks := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/-/healthy":
+			healthChecks++
+		case "/slow/-/healthy":
+			time.Sleep(100 * time.Millisecond)
+		case "/api/v1/status/buildinfo":
+			_, _ = w.Write([]byte(`{"status": "success", "data": {"version": "2.51.2"}}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+
+	auth := &config.Auth{}
+	status := probeAddon("prometheus", testServer.URL+"/-/healthy", true, httpAddonProbe(testServer.URL+"/-/healthy", testServer.URL+"/api/v1/status/buildinfo", auth))
+	assert.Equal(kubernetes.ComponentHealthy, status.Status)
+	assert.Equal("2.51.2", status.Version)
+	assert.True(status.IsCore)
+
+	// Probes are reused until they expire
+	probeAddon("prometheus", testServer.URL+"/-/healthy", true, httpAddonProbe(testServer.URL+"/-/healthy", "", auth))
+	assert.Equal(1, healthChecks)
+
+	status = probeAddon("grafana", testServer.URL+"/slow/-/healthy", false, httpAddonProbe(testServer.URL+"/slow/-/healthy", testServer.URL+"/api/health", auth))
+	assert.Equal(kubernetes.ComponentDegraded, status.Status)
+	assert.GreaterOrEqual(status.LatencyMs, int64(100))
+	assert.Empty(status.Version)
+	assert.Len(status.Reasons, 1)
+
+	status = probeAddon("tracing", testServer.URL+"/down", false, httpAddonProbe(testServer.URL+"/down", "", auth))
+	assert.Equal(kubernetes.ComponentUnreachable, status.Status)
+	assert.Equal([]string{"health check returned status 503"}, status.Reasons)
+	assert.Zero(status.LatencyMs)
+}
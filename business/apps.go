@@ -17,6 +17,7 @@ import (
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
 
 func NewAppService(businessLayer *Layer, conf *config.Config, prom prometheus.ClientInterface, grafana *grafana.Service, userClients map[string]kubernetes.ClientInterface) AppService {
@@ -39,9 +40,11 @@ type AppService struct {
 }
 
 type AppCriteria struct {
-	Namespace             string
-	Cluster               string
-	AppName               string
+	Namespace string
+	Cluster   string
+	AppName   string
+	// AggregateClusters merges the same app of every cluster into one entry, see aggregateClusterApps
+	AggregateClusters     bool
 	IncludeIstioResources bool
 	IncludeHealth         bool
 	RateInterval          string
@@ -206,6 +209,8 @@ func (in *AppService) GetAppList(ctx context.Context, criteria AppCriteria) (mod
 		observability.Attribute("queryTime", criteria.QueryTime),
 	)
 	defer end()
+	promtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GetAppList", criteria.Cluster, criteria.Namespace)
+	defer promtimer.ObserveDuration()
 
 	appList := &models.AppList{
 		Namespace: models.Namespace{Name: criteria.Namespace},
@@ -346,9 +351,110 @@ func (in *AppService) GetAppList(ctx context.Context, criteria AppCriteria) (mod
 		}
 	}
 
+	if criteria.AggregateClusters {
+		appList.Apps = aggregateClusterApps(appList.Apps, criteria.IncludeHealth)
+	}
+
 	return *appList, nil
 }
 
+// aggregateClusterApps merges the apps with the same name and namespace in several clusters into one app, with
+// the details of every cluster as its members. The merged app is only in a cluster when all of its members are,
+// its health combines the workloads and requests of the members, and its health status is the worst of the
+// members (when the health is included). The apps are sorted by name.
+func aggregateClusterApps(apps []models.AppListItem, includeHealth bool) []models.AppListItem {
+	members := map[string][]models.AppListItem{}
+	keys := []string{}
+	for _, app := range apps {
+		key := app.Namespace + "/" + app.Name
+		if _, ok := members[key]; !ok {
+			keys = append(keys, key)
+		}
+		members[key] = append(members[key], app)
+	}
+	sort.Strings(keys)
+
+	aggregated := make([]models.AppListItem, 0, len(keys))
+	for _, key := range keys {
+		clusterApps := members[key]
+		sort.Slice(clusterApps, func(i, j int) bool {
+			return clusterApps[i].Cluster < clusterApps[j].Cluster
+		})
+
+		app := models.AppListItem{
+			Name:         clusterApps[0].Name,
+			Namespace:    clusterApps[0].Namespace,
+			IstioSidecar: true,
+			IstioAmbient: true,
+			Health:       models.EmptyAppHealth(),
+			Clusters:     make([]models.AppClusterMember, 0, len(clusterApps)),
+		}
+		if len(clusterApps) == 1 {
+			app.Cluster = clusterApps[0].Cluster
+		}
+		appLabels := make(map[string][]string)
+		references := []*models.IstioValidationKey{}
+		statuses := []string{}
+		for _, m := range clusterApps {
+			member := models.AppClusterMember{
+				Cluster:      m.Cluster,
+				IstioSidecar: m.IstioSidecar,
+				IstioAmbient: m.IstioAmbient,
+				Labels:       m.Labels,
+				Health:       m.Health,
+			}
+			if includeHealth {
+				member.HealthStatus = m.Health.Status()
+				statuses = append(statuses, member.HealthStatus)
+			}
+			app.Clusters = append(app.Clusters, member)
+
+			app.IstioSidecar = app.IstioSidecar && m.IstioSidecar
+			app.IstioAmbient = app.IstioAmbient && m.IstioAmbient
+			for k, v := range m.Labels {
+				for _, value := range strings.Split(v, ",") {
+					joinMap(appLabels, map[string]string{k: value})
+				}
+			}
+			references = append(references, m.IstioReferences...)
+			mergeAppHealth(&app.Health, m.Health)
+		}
+		app.Labels = buildFinalLabels(appLabels)
+		app.IstioReferences = FilterUniqueIstioReferences(references)
+		if includeHealth {
+			app.HealthStatus = models.WorstHealthStatus(statuses...)
+		}
+		aggregated = append(aggregated, app)
+	}
+	return aggregated
+}
+
+// mergeAppHealth adds the workloads and the request rates of the health of an app member. The thresholds and
+// annotations are the ones of the first member.
+func mergeAppHealth(health *models.AppHealth, member models.AppHealth) {
+	health.WorkloadStatuses = append(health.WorkloadStatuses, member.WorkloadStatuses...)
+	for _, requests := range []struct {
+		to   map[string]map[string]float64
+		from map[string]map[string]float64
+	}{
+		{to: health.Requests.Inbound, from: member.Requests.Inbound},
+		{to: health.Requests.Outbound, from: member.Requests.Outbound},
+	} {
+		for protocol, codes := range requests.from {
+			if _, ok := requests.to[protocol]; !ok {
+				requests.to[protocol] = make(map[string]float64)
+			}
+			for code, rate := range codes {
+				requests.to[protocol][code] += rate
+			}
+		}
+	}
+	if len(health.Requests.Thresholds) == 0 && len(member.Requests.Thresholds) > 0 {
+		health.Requests.Thresholds = member.Requests.Thresholds
+		health.Requests.HealthAnnotations = member.Requests.HealthAnnotations
+	}
+}
+
 // GetApp is the API handler to fetch the details for a given namespace and app name
 func (in *AppService) GetAppDetails(ctx context.Context, criteria AppCriteria) (models.App, error) {
 	var end observability.EndFunc
@@ -361,6 +467,8 @@ func (in *AppService) GetAppDetails(ctx context.Context, criteria AppCriteria) (
 		observability.Attribute("queryTime", criteria.QueryTime),
 	)
 	defer end()
+	promtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GetAppDetails", criteria.Cluster, criteria.Namespace)
+	defer promtimer.ObserveDuration()
 
 	appInstance := &models.App{Namespace: models.Namespace{Name: criteria.Namespace}, Name: criteria.AppName, Health: models.EmptyAppHealth(), Cluster: criteria.Cluster}
 	ns, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, criteria.Namespace, criteria.Cluster)
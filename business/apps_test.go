@@ -14,6 +14,7 @@ import (
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus/prometheustest"
 )
 
@@ -220,3 +221,52 @@ func TestJoinMap(t *testing.T) {
 	assert.Equal("val2", labels["key2"])
 	assert.Equal("al4,val4", labels["key3"])
 }
+
+func TestAggregateClusterApps(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	eastHealth := models.EmptyAppHealth()
+	eastHealth.WorkloadStatuses = []*models.WorkloadStatus{
+		{Name: "reviews-v1", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: 1},
+	}
+	eastHealth.Requests.Inbound = map[string]map[string]float64{"http": {"200": 1}}
+	westHealth := models.EmptyAppHealth()
+	westHealth.WorkloadStatuses = []*models.WorkloadStatus{
+		{Name: "reviews-v2", DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 1, SyncedProxies: 1},
+	}
+	westHealth.Requests.Inbound = map[string]map[string]float64{"http": {"200": 2}}
+
+	apps := []models.AppListItem{
+		{Name: "reviews", Namespace: "bookinfo", Cluster: "west", IstioSidecar: true, Labels: map[string]string{"app": "reviews", "version": "v2"}, Health: westHealth},
+		{Name: "ratings", Namespace: "bookinfo", Cluster: "east", IstioSidecar: true, Labels: map[string]string{"app": "ratings"}, Health: models.EmptyAppHealth()},
+		{Name: "reviews", Namespace: "bookinfo", Cluster: "east", IstioSidecar: false, Labels: map[string]string{"app": "reviews", "version": "v1,v3"}, Health: eastHealth},
+	}
+
+	aggregated := aggregateClusterApps(apps, true)
+	require.Len(aggregated, 2)
+
+	ratings := aggregated[0]
+	assert.Equal("ratings", ratings.Name)
+	assert.Equal("east", ratings.Cluster)
+	assert.True(ratings.IstioSidecar)
+	assert.Equal(models.HealthStatusNA, ratings.HealthStatus)
+	require.Len(ratings.Clusters, 1)
+
+	reviews := aggregated[1]
+	assert.Equal("reviews", reviews.Name)
+	assert.Empty(reviews.Cluster)
+	assert.False(reviews.IstioSidecar)
+	assert.Equal(map[string]string{"app": "reviews", "version": "v1,v2,v3"}, reviews.Labels)
+	assert.Len(reviews.Health.WorkloadStatuses, 2)
+	assert.Equal(3.0, reviews.Health.Requests.Inbound["http"]["200"])
+	assert.Equal(models.HealthStatusDegraded, reviews.HealthStatus)
+
+	require.Len(reviews.Clusters, 2)
+	assert.Equal("east", reviews.Clusters[0].Cluster)
+	assert.Equal(models.HealthStatusHealthy, reviews.Clusters[0].HealthStatus)
+	assert.Equal("west", reviews.Clusters[1].Cluster)
+	assert.Equal(models.HealthStatusDegraded, reviews.Clusters[1].HealthStatus)
+	// the members keep the health of their cluster
+	assert.Equal(1.0, reviews.Clusters[0].Health.Requests.Inbound["http"]["200"])
+}
@@ -6,7 +6,10 @@ import (
 
 type contextKey string
 
-var ContextKeyAuthInfo contextKey = "authInfo"
+var (
+	ContextKeyAuthInfo contextKey = "authInfo"
+	ContextKeyUser     contextKey = "user"
+)
 
 func SetAuthInfoContext(ctx context.Context, value interface{}) context.Context {
 	return context.WithValue(ctx, ContextKeyAuthInfo, value)
@@ -15,3 +18,14 @@ func SetAuthInfoContext(ctx context.Context, value interface{}) context.Context
 func GetAuthInfoContext(ctx context.Context) interface{} {
 	return ctx.Value(ContextKeyAuthInfo)
 }
+
+// SetUserContext sets the name of the user of the authenticated session, empty when the strategy doesn't identify the users.
+func SetUserContext(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, ContextKeyUser, user)
+}
+
+// GetUserContext returns the name of the user of the authenticated session, set by the authentication handler.
+func GetUserContext(ctx context.Context) string {
+	user, _ := ctx.Value(ContextKeyUser).(string)
+	return user
+}
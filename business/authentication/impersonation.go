@@ -0,0 +1,97 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	auth_v1 "k8s.io/api/authorization/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+// The headers of the requests of a user impersonating another user and groups, when the impersonation is enabled.
+// They are distinct from the Kubernetes impersonation headers, which are set by the proxy with the header strategy.
+const (
+	ImpersonateUserHeader  = "Kiali-Impersonate-User"
+	ImpersonateGroupHeader = "Kiali-Impersonate-Group"
+)
+
+const serviceAccountUserPrefix = "system:serviceaccount:"
+
+// ImpersonationError is returned when the user is not allowed to impersonate the requested user or groups.
+type ImpersonationError struct {
+	msg string
+}
+
+func (in *ImpersonationError) Error() string {
+	return in.msg
+}
+
+func IsImpersonationError(err error) bool {
+	_, isImpersonationError := err.(*ImpersonationError)
+	return isImpersonationError
+}
+
+// ImpersonationRequested returns whether the request asks to impersonate a user or groups.
+func ImpersonationRequested(r *http.Request) bool {
+	return r.Header.Get(ImpersonateUserHeader) != "" || len(r.Header.Values(ImpersonateGroupHeader)) > 0
+}
+
+// Impersonate returns a copy of the auth info of the user impersonating the user and groups requested in the headers
+// of the request. The permission of the user to impersonate them is checked with SelfSubjectAccessReviews, using the
+// client of the user on the home cluster. The API servers check it again on each request made with the auth info,
+// as the user is the one impersonating. The clusters reached with the Kiali SA token, that do not trust the issuer
+// of an OpenID user, refuse the impersonation since the Kiali SA would be the one impersonating.
+func Impersonate(ctx context.Context, r *http.Request, client kubernetes.ClientInterface, authInfo *api.AuthInfo) (*api.AuthInfo, error) {
+	user := r.Header.Get(ImpersonateUserHeader)
+	groups := r.Header.Values(ImpersonateGroupHeader)
+	if user == "" {
+		// Kubernetes does not allow to impersonate groups without a user
+		return nil, &ImpersonationError{msg: fmt.Sprintf("the [%s] header is required to impersonate groups", ImpersonateUserHeader)}
+	}
+
+	if err := checkImpersonation(ctx, client, userResourceAttributes(user)); err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if err := checkImpersonation(ctx, client, &auth_v1.ResourceAttributes{Verb: "impersonate", Resource: "groups", Name: group}); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Debugf("Impersonating user [%s] and groups %v", user, groups)
+	impersonated := authInfo.DeepCopy()
+	impersonated.Impersonate = user
+	impersonated.ImpersonateGroups = groups
+	impersonated.ImpersonateUserExtra = nil
+	return impersonated, nil
+}
+
+// userResourceAttributes returns the attributes checked to impersonate a user. The users of the service accounts
+// are impersonated with the serviceaccounts resource of their namespace.
+func userResourceAttributes(user string) *auth_v1.ResourceAttributes {
+	if serviceAccount, isServiceAccount := strings.CutPrefix(user, serviceAccountUserPrefix); isServiceAccount {
+		if namespace, name, found := strings.Cut(serviceAccount, ":"); found {
+			return &auth_v1.ResourceAttributes{Verb: "impersonate", Resource: "serviceaccounts", Namespace: namespace, Name: name}
+		}
+	}
+	return &auth_v1.ResourceAttributes{Verb: "impersonate", Resource: "users", Name: user}
+}
+
+func checkImpersonation(ctx context.Context, client kubernetes.ClientInterface, attributes *auth_v1.ResourceAttributes) error {
+	review, err := client.Kube().AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &auth_v1.SelfSubjectAccessReview{
+		Spec: auth_v1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+	}, meta_v1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !review.Status.Allowed {
+		return &ImpersonationError{msg: fmt.Sprintf("the user is not allowed to impersonate %s [%s]", strings.TrimSuffix(attributes.Resource, "s"), attributes.Name)}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package authentication
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auth_v1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+// allowImpersonation makes the fake client allow the user to impersonate only the listed names
+func allowImpersonation(t *testing.T, k8s *kubetest.FakeK8sClient, allowed ...string) *[]auth_v1.ResourceAttributes {
+	t.Helper()
+	reviewed := []auth_v1.ResourceAttributes{}
+	k8s.KubeClientset.(*kubefake.Clientset).PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*auth_v1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		reviewed = append(reviewed, *attributes)
+		for _, name := range allowed {
+			review.Status.Allowed = review.Status.Allowed || (attributes.Verb == "impersonate" && attributes.Name == name)
+		}
+		return true, review, nil
+	})
+	return &reviewed
+}
+
+func TestImpersonateChecksTheUserAndGroups(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	k8s := kubetest.NewFakeK8sClient()
+	reviewed := allowImpersonation(t, k8s, "jdoe", "developers")
+
+	r := httptest.NewRequest("GET", "/api/namespaces", nil)
+	r.Header.Set(ImpersonateUserHeader, "jdoe")
+	r.Header.Add(ImpersonateGroupHeader, "developers")
+	require.True(ImpersonationRequested(r))
+
+	authInfo := &api.AuthInfo{Token: "admin-token"}
+	impersonated, err := Impersonate(context.TODO(), r, k8s, authInfo)
+	require.NoError(err)
+	assert.Equal("admin-token", impersonated.Token)
+	assert.Equal("jdoe", impersonated.Impersonate)
+	assert.Equal([]string{"developers"}, impersonated.ImpersonateGroups)
+	assert.Empty(authInfo.Impersonate)
+	assert.Equal([]auth_v1.ResourceAttributes{
+		{Verb: "impersonate", Resource: "users", Name: "jdoe"},
+		{Verb: "impersonate", Resource: "groups", Name: "developers"},
+	}, *reviewed)
+
+	// Not allowed to impersonate the group
+	r.Header.Add(ImpersonateGroupHeader, "admins")
+	_, err = Impersonate(context.TODO(), r, k8s, authInfo)
+	assert.True(IsImpersonationError(err))
+}
+
+func TestImpersonateServiceAccount(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	k8s := kubetest.NewFakeK8sClient()
+	reviewed := allowImpersonation(t, k8s, "bookinfo-reviews")
+
+	r := httptest.NewRequest("GET", "/api/namespaces", nil)
+	r.Header.Set(ImpersonateUserHeader, "system:serviceaccount:bookinfo:bookinfo-reviews")
+	_, err := Impersonate(context.TODO(), r, k8s, &api.AuthInfo{Token: "admin-token"})
+	require.NoError(err)
+	assert.Equal([]auth_v1.ResourceAttributes{
+		{Verb: "impersonate", Resource: "serviceaccounts", Namespace: "bookinfo", Name: "bookinfo-reviews"},
+	}, *reviewed)
+}
+
+func TestImpersonateGroupsNeedsAUser(t *testing.T) {
+	k8s := kubetest.NewFakeK8sClient()
+	allowImpersonation(t, k8s, "developers")
+
+	r := httptest.NewRequest("GET", "/api/namespaces", nil)
+	r.Header.Add(ImpersonateGroupHeader, "developers")
+	require.True(t, ImpersonationRequested(r))
+
+	_, err := Impersonate(context.TODO(), r, k8s, &api.AuthInfo{Token: "admin-token"})
+	assert.True(t, IsImpersonationError(err))
+}
@@ -0,0 +1,27 @@
+package authentication
+
+import (
+	"context"
+
+	auth_v1 "k8s.io/api/authorization/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// IsKialiAdmin returns whether the user of the client is allowed to exec into the pods of the Kiali namespace. These
+// users could already collect the profiles and the diagnostics of the Kiali server from its pods.
+func IsKialiAdmin(ctx context.Context, client kubernetes.ClientInterface, kialiNamespace string) (bool, error) {
+	review, err := client.Kube().AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &auth_v1.SelfSubjectAccessReview{
+		Spec: auth_v1.SelfSubjectAccessReviewSpec{ResourceAttributes: &auth_v1.ResourceAttributes{
+			Namespace:   kialiNamespace,
+			Verb:        "create",
+			Resource:    "pods",
+			Subresource: "exec",
+		}},
+	}, meta_v1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
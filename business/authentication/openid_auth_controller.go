@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-jose/go-jose"
@@ -36,27 +37,38 @@ const (
 	// is used to mitigate replay attacks.
 	OpenIdNonceCookieName = config.TokenCookieName + "-openid-nonce"
 
+	// OpenIdIssuerCookieName is the cookie name used to store the issuer the user
+	// is authenticating with, when it is one of the additional issuers.
+	OpenIdIssuerCookieName = config.TokenCookieName + "-openid-issuer"
+
 	// OpenIdServerCAFile is a certificate file used to connect to the OpenID server.
 	// This is for cases when the authentication server is using TLS with a self-signed
 	// certificate.
 	OpenIdServerCAFile = "/kiali-cabundle/openid-server-ca.crt"
 )
 
-// cachedOpenIdKeySet stores the metadata obtained from the /.well-known/openid-configuration
-// endpoint of the OpenId server. Once the metadata is obtained for the first time, subsequent
+// openIdTokenRefreshMargin is how long before its expiration the token of a session is refreshed.
+const openIdTokenRefreshMargin = time.Minute
+
+// cachedOpenIdMetadata stores the metadata obtained from the /.well-known/openid-configuration
+// endpoint of the OpenId servers, keyed by issuer. Once the metadata is obtained for the first time, subsequent
 // retrievals are served from this cached value rather than doing another request to the
 // metadata endpoint of the OpenId server.
-var cachedOpenIdMetadata *openIdMetadata
+var cachedOpenIdMetadata map[string]*openIdMetadata
 
 // cachedOpenIdKeySet stores the public key sets used for verification of the received
-// id_tokens from the OpenId server. Its purpose is to prevent repeated queries to the JWKS
+// id_tokens from the OpenId servers, keyed by issuer. Its purpose is to prevent repeated queries to the JWKS
 // endpoint of the OpenId server. However, since the keys can rotate, this is refreshed
 // each time an id_token is signed with a key that is not present in the cached key set.
-var cachedOpenIdKeySet *jose.JSONWebKeySet
+var cachedOpenIdKeySet map[string]*jose.JSONWebKeySet
+
+// openIdCacheLock guards the cachedOpenIdMetadata and cachedOpenIdKeySet maps.
+var openIdCacheLock sync.RWMutex
 
 // openIdFlightGroup is used to synchronize different threads of different HTTP requests so
 // that only one request active to the metadata or jwks endpoints of the OpenId server. This
-// prevents fetching the same data twice at the same time.
+// prevents fetching the same data twice at the same time. It also prevents refreshing the
+// token of a session twice, which would fail with the IdPs rotating the refresh tokens.
 var openIdFlightGroup singleflight.Group
 
 // openIdMetadata is a helper struct to parse the response from the metadata
@@ -84,8 +96,24 @@ type oidcSessionPayload struct {
 
 	// Token is the string provided by the OpenId server. It can be the id_token or
 	// the access_token, depending on the Kiali configuration. If RBAC is enabled,
-	// this is the token that can be used against the Kubernetes API.
+	// this is the token that can be used against the Kubernetes API. Empty if the
+	// token is refreshed, the token then being kept in the Kiali cache.
 	Token string `json:"token,omitempty"`
+
+	// Issuer is the additional issuer the user logged in with. Empty for the issuer_uri of the config.
+	Issuer string `json:"issuer,omitempty"`
+
+	// SessionId is the key of the tokens of the session in the Kiali cache. Empty if the
+	// token is not refreshed.
+	SessionId string `json:"sessionId,omitempty"`
+}
+
+// openIdTokenResponse is the response of the token endpoint of the OpenId server.
+type openIdTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	IdToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 // badOidcRequest is a helper type implementing Go's error interface. It's used to assist in
@@ -191,6 +219,11 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 		return nil, nil
 	}
 
+	// The token of the sessions outliving it is kept and refreshed in the Kiali cache
+	if len(sPayload.SessionId) != 0 && !c.refreshSession(&sPayload) {
+		return nil, nil
+	}
+
 	// The OpenId token must be present in the session
 	if len(sPayload.Token) == 0 {
 		log.Warning("Session is invalid: the OIDC token is absent")
@@ -222,10 +255,10 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 		}
 	}
 
-	var token string
+	var authInfo *api.AuthInfo
 	if !c.conf.Auth.OpenId.DisableRBAC {
 		// If RBAC is ENABLED, check that the user has privileges on the cluster.
-		authInfo := &api.AuthInfo{Token: sPayload.Token}
+		authInfo = openIdAuthInfo(c.conf, sPayload.Token, sPayload.Issuer, sPayload.Subject)
 		userClients, err := c.clientFactory.GetClients(authInfo)
 		if err != nil {
 			log.Warningf("Could not get the business layer!!: %v", err)
@@ -238,27 +271,89 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 			log.Warningf("Token error!: %v", err)
 			return nil, nil
 		}
-
-		token = sPayload.Token
 	} else {
 		// If RBAC is off, it's assumed that the kubernetes cluster will reject the OpenId token.
 		// Instead, we use the Kiali token and this has the side effect that all users will share the
 		// same privileges.
-		token = c.clientFactory.GetSAHomeClusterClient().GetToken()
+		authInfo = &api.AuthInfo{Token: c.clientFactory.GetSAHomeClusterClient().GetToken()}
 	}
 
 	// Internal header used to propagate the subject of the request for audit purposes
-	r.Header.Add("Kiali-User", sPayload.Subject)
+	r.Header.Set("Kiali-User", sPayload.Subject)
 
 	return &UserSessionData{
 		ExpiresOn: sData.ExpiresOn,
 		Username:  sPayload.Subject,
-		AuthInfo:  &api.AuthInfo{Token: token},
+		AuthInfo:  authInfo,
 	}, nil
 }
 
+// refreshSession sets the token of a session from the Kiali cache, renewing it with the refresh token when it
+// is about to expire. It returns false if the session is no longer valid.
+func (c OpenIdAuthController) refreshSession(sPayload *oidcSessionPayload) bool {
+	session, found := c.kialiCache.GetAuthSession(sPayload.SessionId)
+	if !found {
+		// Kiali restarted, or the session was started with another replica of Kiali
+		log.Debugf("Session is invalid: the tokens of the session are not in the cache")
+		return false
+	}
+	sPayload.Token = session.Token
+	if util.Clock.Now().Add(openIdTokenRefreshMargin).Before(session.TokenExpiresOn) {
+		return true
+	}
+
+	refreshed, err, _ := openIdFlightGroup.Do("refresh-"+sPayload.SessionId, func() (interface{}, error) {
+		// Another request may have refreshed the token in the meantime
+		if current, found := c.kialiCache.GetAuthSession(sPayload.SessionId); found && current != session {
+			return current, nil
+		}
+
+		flow := openidFlowHelper{
+			IssuerUri:     session.Issuer,
+			RefreshToken:  session.RefreshToken,
+			Subject:       sPayload.Subject,
+			kialiCache:    c.kialiCache,
+			clientFactory: c.clientFactory,
+			conf:          c.conf,
+		}
+		flow.
+			refreshOpenIdToken().
+			parseRefreshedOpenIdToken(sPayload.Subject).
+			checkUserPrivileges()
+		if flow.Error != nil {
+			return nil, flow.Error
+		}
+
+		newSession := &cache.AuthSession{
+			ExpiresOn:      session.ExpiresOn,
+			Issuer:         session.Issuer,
+			RefreshToken:   flow.RefreshToken,
+			Token:          buildSessionPayload(&flow).Token,
+			TokenExpiresOn: flow.tokenExpiresOn(),
+		}
+		c.kialiCache.SetAuthSession(sPayload.SessionId, newSession)
+		return newSession, nil
+	})
+	if err != nil {
+		log.Warningf("Could not refresh the OpenId token of the session: %v", err)
+		if util.Clock.Now().Before(session.TokenExpiresOn) {
+			return true
+		}
+		c.kialiCache.RemoveAuthSession(sPayload.SessionId)
+		return false
+	}
+
+	sPayload.Token = refreshed.(*cache.AuthSession).Token
+	return true
+}
+
 // TerminateSession unconditionally terminates any existing session without any validation.
+// The server side data of the session is dropped.
 func (c OpenIdAuthController) TerminateSession(r *http.Request, w http.ResponseWriter) error {
+	sPayload := oidcSessionPayload{}
+	if sData, err := c.SessionStore.ReadSession(r, w, &sPayload); err == nil && sData != nil && len(sPayload.SessionId) != 0 {
+		c.kialiCache.RemoveAuthSession(sPayload.SessionId)
+	}
 	c.SessionStore.TerminateSession(r, w)
 	return nil
 }
@@ -324,8 +419,18 @@ func (c OpenIdAuthController) redirectToAuthServerHandler(w http.ResponseWriter,
 		return
 	}
 
+	// The user may authenticate with one of the additional issuers
+	issuer, found := c.conf.Auth.OpenId.GetIssuer(r.URL.Query().Get("issuer"))
+	if !found {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("The OpenID issuer is not trusted"))
+		return
+	}
+	isAdditionalIssuer := issuer.IssuerUri != c.conf.Auth.OpenId.IssuerUri
+
 	// Kiali only supports the authorization code flow.
-	if !isOpenIdCodeFlowPossible(c.conf) {
+	if !isOpenIdCodeFlowPossible(c.conf, issuer.IssuerUri) {
 		w.Header().Set("Content-Type", "text/plain")
 		w.WriteHeader(http.StatusNotImplemented)
 		_, _ = w.Write([]byte("Cannot start authentication because it is not possible to use OpenId's authorization code flow. Check Kiali logs for more details."))
@@ -337,8 +442,8 @@ func (c OpenIdAuthController) redirectToAuthServerHandler(w http.ResponseWriter,
 
 	// Determine authorization endpoint
 	authorizationEndpoint := c.conf.Auth.OpenId.AuthorizationEndpoint
-	if len(authorizationEndpoint) == 0 {
-		openIdMetadata, err := getOpenIdMetadata(c.conf)
+	if len(authorizationEndpoint) == 0 || isAdditionalIssuer {
+		openIdMetadata, err := getOpenIdMetadata(c.conf, issuer.IssuerUri)
 		if err != nil {
 			w.Header().Set("Content-Type", "text/plain")
 			w.WriteHeader(http.StatusInternalServerError)
@@ -373,6 +478,14 @@ func (c OpenIdAuthController) redirectToAuthServerHandler(w http.ResponseWriter,
 	}
 	http.SetCookie(w, &nonceCookie)
 
+	// Remember the additional issuer, to exchange the authorization code with it in the callback
+	if isAdditionalIssuer {
+		issuerCookie := nonceCookie
+		issuerCookie.Name = OpenIdIssuerCookieName
+		issuerCookie.Value = issuer.IssuerUri
+		http.SetCookie(w, &issuerCookie)
+	}
+
 	// Instead of sending the nonce code to the IdP, send a cryptographic hash.
 	// This way, if an attacker manages to steal the id_token returned by the IdP, he still
 	// needs to craft the cookie (which is hopefully very, very hard to do).
@@ -396,7 +509,7 @@ func (c OpenIdAuthController) redirectToAuthServerHandler(w http.ResponseWriter,
 	responseType := "code" // Request for the "authorization code" flow
 	redirectUri := fmt.Sprintf("%s?client_id=%s&response_type=%s&redirect_uri=%s&scope=%s&nonce=%s&state=%s",
 		authorizationEndpoint,
-		url.QueryEscape(c.conf.Auth.OpenId.ClientId),
+		url.QueryEscape(issuer.ClientId),
 		responseType,
 		url.QueryEscape(guessedKialiURL),
 		url.QueryEscape(scopes),
@@ -427,6 +540,9 @@ type openidFlowHelper struct {
 	// configured to use it instead of the id_token.
 	AccessToken string
 
+	// AccessTokenExpiresOn is the expiration time of the access_token, when the OpenId server provides it.
+	AccessTokenExpiresOn time.Time
+
 	// Code is the authorization code provided during the callback of the authorization code flow.
 	Code string
 
@@ -449,6 +565,12 @@ type openidFlowHelper struct {
 	// IdTokenPayload holds the claims part of the id_token.
 	IdTokenPayload map[string]interface{}
 
+	// IssuerUri is the additional issuer the user is authenticating with. Empty for the issuer_uri of the config.
+	IssuerUri string
+
+	// RefreshToken is the token provided by the OpenId server to renew the id_token and the access_token.
+	RefreshToken string
+
 	// State is the code used to mitigate CSRF attacks.
 	State string
 
@@ -494,6 +616,13 @@ func (p *openidFlowHelper) callbackCleanup(r *http.Request, w http.ResponseWrite
 	}
 	http.SetCookie(w, &deleteNonceCookie)
 
+	// Delete the issuer cookie, if the user authenticated with an additional issuer.
+	if _, err := r.Cookie(OpenIdIssuerCookieName); err == nil {
+		deleteIssuerCookie := deleteNonceCookie
+		deleteIssuerCookie.Name = OpenIdIssuerCookieName
+		http.SetCookie(w, &deleteIssuerCookie)
+	}
+
 	return p
 }
 
@@ -518,6 +647,18 @@ func (p *openidFlowHelper) extractOpenIdCallbackParams(r *http.Request) *openidF
 		copy(p.NonceHash, hash[:])
 	}
 
+	// Get the additional issuer the user is authenticating with
+	if issuerCookie, cookieErr := r.Cookie(OpenIdIssuerCookieName); cookieErr == nil {
+		if _, found := p.conf.Auth.OpenId.GetIssuer(issuerCookie.Value); !found {
+			p.Error = &AuthenticationFailureError{
+				HttpStatus: http.StatusBadRequest,
+				Reason:     fmt.Sprintf("the OpenId issuer [%s] is not trusted", issuerCookie.Value),
+			}
+			return p
+		}
+		p.IssuerUri = issuerCookie.Value
+	}
+
 	// Parse/fetch received form data
 	err = r.ParseForm()
 	if err != nil {
@@ -618,7 +759,8 @@ func (p *openidFlowHelper) checkUserPrivileges() *openidFlowHelper {
 			apiToken = p.AccessToken
 			p.UseAccessToken = true
 		}
-		httpStatus, errMsg, detailedError := verifyOpenIdUserAccess(apiToken, p.clientFactory, p.kialiCache, p.conf)
+		authInfo := openIdAuthInfo(p.conf, apiToken, p.IssuerUri, p.Subject)
+		httpStatus, errMsg, detailedError := verifyOpenIdUserAccess(authInfo, p.clientFactory, p.kialiCache, p.conf)
 		if httpStatus != http.StatusOK {
 			p.Error = &AuthenticationFailureError{
 				HttpStatus: httpStatus,
@@ -640,7 +782,34 @@ func (p *openidFlowHelper) createSession(r *http.Request, w http.ResponseWriter,
 	}
 
 	sPayload := buildSessionPayload(p)
-	err := sessionStore.CreateSession(r, w, config.AuthStrategyOpenId, p.ExpiresOn, sPayload)
+	expiresOn := p.ExpiresOn
+
+	// With a refresh token, the session lasts as configured for the login token, the token of
+	// the user being refreshed as needed. The tokens are only kept server side, the cookie only
+	// carries the id of the session.
+	if p.conf.Auth.OpenId.RefreshTokens && len(p.RefreshToken) != 0 {
+		sessionId, err := util.CryptoRandomString(32)
+		if err != nil {
+			p.Error = fmt.Errorf("failed to generate the session id: %w", err)
+			return nil
+		}
+
+		sessionExpiresOn := util.Clock.Now().Add(time.Duration(p.conf.LoginToken.ExpirationSeconds) * time.Second)
+		if sessionExpiresOn.After(expiresOn) {
+			expiresOn = sessionExpiresOn
+		}
+		sPayload.SessionId = sessionId
+		p.kialiCache.SetAuthSession(sessionId, &cache.AuthSession{
+			ExpiresOn:      expiresOn,
+			Issuer:         p.IssuerUri,
+			RefreshToken:   p.RefreshToken,
+			Token:          sPayload.Token,
+			TokenExpiresOn: p.tokenExpiresOn(),
+		})
+		sPayload.Token = ""
+	}
+
+	err := sessionStore.CreateSession(r, w, config.AuthStrategyOpenId, expiresOn, sPayload)
 	if err != nil {
 		p.Error = err
 	}
@@ -648,6 +817,14 @@ func (p *openidFlowHelper) createSession(r *http.Request, w http.ResponseWriter,
 	return sPayload
 }
 
+// tokenExpiresOn returns the expiration time of the token stored in the session.
+func (p *openidFlowHelper) tokenExpiresOn() time.Time {
+	if p.UseAccessToken && !p.AccessTokenExpiresOn.IsZero() {
+		return p.AccessTokenExpiresOn
+	}
+	return p.ExpiresOn
+}
+
 // parseOpenIdToken parses the OpenId id_token which is a JWT. This is to extract it's claims
 // and be able to process them in later steps of the authentication flow.
 func (p *openidFlowHelper) parseOpenIdToken() *openidFlowHelper {
@@ -773,79 +950,142 @@ func (p *openidFlowHelper) requestOpenIdToken(redirect_uri string) *openidFlowHe
 		return p
 	}
 
-	oidcMeta, err := getOpenIdMetadata(p.conf)
+	// Exchange authorization code for a token
+	requestParams := url.Values{}
+	requestParams.Set("code", p.Code)
+	requestParams.Set("grant_type", "authorization_code")
+	requestParams.Set("redirect_uri", redirect_uri)
+
+	tokenResponse, err := p.requestTokenEndpoint(requestParams)
 	if err != nil {
 		p.Error = err
 		return p
 	}
 
-	cfg := p.conf.Auth.OpenId
+	if len(tokenResponse.IdToken) == 0 {
+		p.Error = errors.New("the IdP did not provide an id_token")
+		return p
+	}
 
-	httpClient, err := createHttpClient(p.conf, oidcMeta.TokenURL)
-	if err != nil {
-		p.Error = fmt.Errorf("failure when creating http client to request open id token: %w", err)
+	p.setTokens(tokenResponse)
+	return p
+}
+
+// refreshOpenIdToken makes a request to the OpenId server to renew the tokens of the user with the refresh token.
+func (p *openidFlowHelper) refreshOpenIdToken() *openidFlowHelper {
+	// Do nothing if there was an error in previous flow steps.
+	if p.Error != nil {
 		return p
 	}
 
-	// Exchange authorization code for a token
 	requestParams := url.Values{}
-	requestParams.Set("code", p.Code)
-	requestParams.Set("grant_type", "authorization_code")
-	requestParams.Set("redirect_uri", redirect_uri)
-	if len(cfg.ClientSecret) == 0 {
-		requestParams.Set("client_id", cfg.ClientId)
+	requestParams.Set("grant_type", "refresh_token")
+	requestParams.Set("refresh_token", p.RefreshToken)
+
+	tokenResponse, err := p.requestTokenEndpoint(requestParams)
+	if err != nil {
+		p.Error = err
+		return p
+	}
+
+	// The IdP may keep the same refresh token
+	if len(tokenResponse.RefreshToken) == 0 {
+		tokenResponse.RefreshToken = p.RefreshToken
+	}
+	p.setTokens(tokenResponse)
+	return p
+}
+
+// parseRefreshedOpenIdToken parses the id_token provided when refreshing the tokens of a session, which must belong
+// to the user of the session. An IdP may not provide an id_token on refresh, which is only acceptable if the access_token
+// is used against the cluster API.
+func (p *openidFlowHelper) parseRefreshedOpenIdToken(subject string) *openidFlowHelper {
+	// Do nothing if there was an error in previous flow steps.
+	if p.Error != nil {
+		return p
+	}
+
+	if len(p.IdToken) == 0 {
+		if p.conf.Auth.OpenId.ApiToken != "access_token" || p.conf.Auth.OpenId.DisableRBAC {
+			p.Error = errors.New("the IdP did not provide an id_token when refreshing the token")
+		} else if p.AccessTokenExpiresOn.IsZero() {
+			p.Error = errors.New("the IdP did not provide the expiration of the refreshed access_token")
+		}
+		return p
+	}
+
+	p.parseOpenIdToken()
+	if p.Error == nil && p.Subject != subject {
+		p.Error = fmt.Errorf("the refreshed id_token belongs to another user [%s]", p.Subject)
+	}
+	return p
+}
+
+// requestTokenEndpoint posts a request to the token endpoint of the issuer the user is authenticating with.
+func (p *openidFlowHelper) requestTokenEndpoint(requestParams url.Values) (*openIdTokenResponse, error) {
+	issuer, found := p.conf.Auth.OpenId.GetIssuer(p.IssuerUri)
+	if !found {
+		return nil, fmt.Errorf("the OpenId issuer [%s] is not trusted", p.IssuerUri)
+	}
+
+	oidcMeta, err := getOpenIdMetadata(p.conf, issuer.IssuerUri)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := createHttpClient(p.conf, oidcMeta.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("failure when creating http client to request open id token: %w", err)
+	}
+
+	if len(issuer.ClientSecret) == 0 {
+		requestParams.Set("client_id", issuer.ClientId)
 	}
 
 	tokenRequest, err := http.NewRequest(http.MethodPost, oidcMeta.TokenURL, strings.NewReader(requestParams.Encode()))
 	if err != nil {
-		p.Error = fmt.Errorf("failure when creating the token request: %w", err)
-		return p
+		return nil, fmt.Errorf("failure when creating the token request: %w", err)
 	}
 
-	if len(cfg.ClientSecret) > 0 {
-		tokenRequest.SetBasicAuth(url.QueryEscape(cfg.ClientId), url.QueryEscape(cfg.ClientSecret))
+	if len(issuer.ClientSecret) > 0 {
+		tokenRequest.SetBasicAuth(url.QueryEscape(issuer.ClientId), url.QueryEscape(issuer.ClientSecret))
 	}
 
 	tokenRequest.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	response, err := httpClient.Do(tokenRequest)
 	if err != nil {
-		p.Error = fmt.Errorf("failure when requesting token from IdP: %w", err)
-		return p
+		return nil, fmt.Errorf("failure when requesting token from IdP: %w", err)
 	}
 
 	defer response.Body.Close()
 	rawTokenResponse, err := io.ReadAll(response.Body)
 	if err != nil {
-		p.Error = fmt.Errorf("failed to read token response from IdP: %w", err)
-		return p
+		return nil, fmt.Errorf("failed to read token response from IdP: %w", err)
 	}
 
 	if response.StatusCode != 200 {
 		log.Debugf("OpenId token request failed with response: %s", string(rawTokenResponse))
-		p.Error = fmt.Errorf("request failed (HTTP response status = %s)", response.Status)
-		return p
+		return nil, fmt.Errorf("request failed (HTTP response status = %s)", response.Status)
 	}
 
 	// Parse token response
-	var tokenResponse struct {
-		IdToken     string `json:"id_token"`
-		AccessToken string `json:"access_token"`
-	}
-
+	var tokenResponse openIdTokenResponse
 	err = json.Unmarshal(rawTokenResponse, &tokenResponse)
 	if err != nil {
-		p.Error = fmt.Errorf("cannot parse OpenId token response: %w", err)
-		return p
+		return nil, fmt.Errorf("cannot parse OpenId token response: %w", err)
 	}
 
-	if len(tokenResponse.IdToken) == 0 {
-		p.Error = errors.New("the IdP did not provide an id_token")
-		return p
-	}
+	return &tokenResponse, nil
+}
 
+// setTokens stores the tokens of a response of the token endpoint.
+func (p *openidFlowHelper) setTokens(tokenResponse *openIdTokenResponse) {
 	p.IdToken = tokenResponse.IdToken
 	p.AccessToken = tokenResponse.AccessToken
-	return p
+	p.RefreshToken = tokenResponse.RefreshToken
+	if tokenResponse.ExpiresIn > 0 {
+		p.AccessTokenExpiresOn = util.Clock.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
 }
 
 // buildSessionPayload returns a struct that should be used as a payload for a call to SessionPersistor.CreateSession.
@@ -859,6 +1099,29 @@ func buildSessionPayload(openIdParams *openidFlowHelper) *oidcSessionPayload {
 	return &oidcSessionPayload{
 		Token:   token,
 		Subject: openIdParams.Subject,
+		Issuer:  openIdParams.IssuerUri,
+	}
+}
+
+// openIdAuthInfo returns the auth info of a user with the token of an issuer, an empty issuer being the
+// issuer_uri of the config. With additional issuers, the issuer and the user are recorded so that the
+// clusters trusting another issuer are reached impersonating the user.
+func openIdAuthInfo(conf *config.Config, token string, issuerUri string, subject string) *api.AuthInfo {
+	if len(conf.Auth.OpenId.AdditionalIssuers) == 0 {
+		return &api.AuthInfo{Token: token}
+	}
+	if len(issuerUri) == 0 {
+		issuerUri = conf.Auth.OpenId.IssuerUri
+	}
+	return &api.AuthInfo{
+		Token: token,
+		AuthProvider: &api.AuthProviderConfig{
+			Name: kubernetes.OpenIdAuthProvider,
+			Config: map[string]string{
+				kubernetes.OpenIdIssuerKey:  issuerUri,
+				kubernetes.OpenIdSubjectKey: subject,
+			},
+		},
 	}
 }
 
@@ -947,7 +1210,7 @@ func createHttpClient(conf *config.Config, toUrl string) (*http.Client, error) {
 
 // isOpenIdCodeFlowPossible determines if the "authorization code" flow can be used
 // to do user authentication.
-func isOpenIdCodeFlowPossible(conf *config.Config) bool {
+func isOpenIdCodeFlowPossible(conf *config.Config, issuerUri string) bool {
 	// Kiali's signing key length must be 16, 24 or 32 bytes in order to be able to use
 	// encoded cookies.
 	switch len(getSigningKey(conf)) {
@@ -958,7 +1221,7 @@ func isOpenIdCodeFlowPossible(conf *config.Config) bool {
 	}
 
 	// IdP provider's metadata must list "code" in it's supported response types
-	metadata, err := getOpenIdMetadata(conf)
+	metadata, err := getOpenIdMetadata(conf, issuerUri)
 	if err != nil {
 		// On error, just inform that code flow is not possible
 		log.Warningf("Error when fetching OpenID provider's metadata: %s", err.Error())
@@ -1007,7 +1270,7 @@ func getConfiguredOpenIdScopes(conf *config.Config) []string {
 // refreshed as needed, when the requested keyId is not available in the cached key set.
 //
 // See also getOpenIdJwks, validateOpenIdTokenInHouse.
-func getJwkFromKeySet(conf *config.Config, keyId string) (*jose.JSONWebKey, error) {
+func getJwkFromKeySet(conf *config.Config, issuerUri string, keyId string) (*jose.JSONWebKey, error) {
 	// Helper function to find a key with a certain key id in a key-set.
 	findJwkFunc := func(kid string, jwks *jose.JSONWebKeySet) *jose.JSONWebKey {
 		for _, key := range jwks.Keys {
@@ -1018,9 +1281,12 @@ func getJwkFromKeySet(conf *config.Config, keyId string) (*jose.JSONWebKey, erro
 		return nil
 	}
 
-	if cachedOpenIdKeySet != nil {
+	openIdCacheLock.RLock()
+	cachedKeySet := cachedOpenIdKeySet[issuerUri]
+	openIdCacheLock.RUnlock()
+	if cachedKeySet != nil {
 		// If key-set is cached, try to find the key in the cached key-set
-		foundKey := findJwkFunc(keyId, cachedOpenIdKeySet)
+		foundKey := findJwkFunc(keyId, cachedKeySet)
 		if foundKey != nil {
 			return foundKey, nil
 		}
@@ -1028,7 +1294,7 @@ func getJwkFromKeySet(conf *config.Config, keyId string) (*jose.JSONWebKey, erro
 
 	// If key-set is not cached, or if the requested key was not found in the
 	// cached key-set, then fetch/refresh the key-set from the OpenId provider
-	keySet, err := getOpenIdJwks(conf)
+	keySet, err := getOpenIdJwks(conf, issuerUri)
 	if err != nil {
 		return nil, err
 	}
@@ -1042,9 +1308,9 @@ func getJwkFromKeySet(conf *config.Config, keyId string) (*jose.JSONWebKey, erro
 
 // getOpenIdJwks fetches the currently published key set from the OpenId server.
 // It's better to use the getJwkFromKeySet function rather than this one.
-func getOpenIdJwks(conf *config.Config) (*jose.JSONWebKeySet, error) {
-	fetchedKeySet, fetchError, _ := openIdFlightGroup.Do("jwks", func() (interface{}, error) {
-		oidcMetadata, err := getOpenIdMetadata(conf)
+func getOpenIdJwks(conf *config.Config, issuerUri string) (*jose.JSONWebKeySet, error) {
+	fetchedKeySet, fetchError, _ := openIdFlightGroup.Do("jwks-"+issuerUri, func() (interface{}, error) {
+		oidcMetadata, err := getOpenIdMetadata(conf, issuerUri)
 		if err != nil {
 			return nil, err
 		}
@@ -1079,8 +1345,14 @@ func getOpenIdJwks(conf *config.Config) (*jose.JSONWebKeySet, error) {
 			return nil, fmt.Errorf("cannot parse OpenId JWKS document: %s", err.Error())
 		}
 
-		cachedOpenIdKeySet = &oidcKeys // Store the keyset in a "cache"
-		return cachedOpenIdKeySet, nil
+		// Store the keyset in a "cache"
+		openIdCacheLock.Lock()
+		if cachedOpenIdKeySet == nil {
+			cachedOpenIdKeySet = map[string]*jose.JSONWebKeySet{}
+		}
+		cachedOpenIdKeySet[issuerUri] = &oidcKeys
+		openIdCacheLock.Unlock()
+		return &oidcKeys, nil
 	})
 
 	if fetchError != nil {
@@ -1090,21 +1362,22 @@ func getOpenIdJwks(conf *config.Config) (*jose.JSONWebKeySet, error) {
 	return fetchedKeySet.(*jose.JSONWebKeySet), nil
 }
 
-// getOpenIdMetadata fetches the OpenId metadata using the configured Issuer URI and
+// getOpenIdMetadata fetches the OpenId metadata of a trusted Issuer URI by
 // downloading the metadata from the well-known path '/.well-known/openid-configuration'. Some
 // validations are performed and the parsed metadata is returned. Since the metadata should be
 // rare to change, the retrieved metadata is cached on first call and subsequent calls return
 // the cached metadata.
-func getOpenIdMetadata(conf *config.Config) (*openIdMetadata, error) {
-	if cachedOpenIdMetadata != nil {
-		return cachedOpenIdMetadata, nil
+func getOpenIdMetadata(conf *config.Config, issuerUri string) (*openIdMetadata, error) {
+	openIdCacheLock.RLock()
+	cachedMetadata := cachedOpenIdMetadata[issuerUri]
+	openIdCacheLock.RUnlock()
+	if cachedMetadata != nil {
+		return cachedMetadata, nil
 	}
 
-	fetchedMetadata, fetchError, _ := openIdFlightGroup.Do("metadata", func() (interface{}, error) {
-		cfg := conf.Auth.OpenId
-
+	fetchedMetadata, fetchError, _ := openIdFlightGroup.Do("metadata-"+issuerUri, func() (interface{}, error) {
 		// Remove trailing slash from issuer URI, if needed
-		trimmedIssuerUri := strings.TrimRight(cfg.IssuerUri, "/")
+		trimmedIssuerUri := strings.TrimRight(issuerUri, "/")
 
 		httpClient, err := createHttpClient(conf, trimmedIssuerUri)
 		if err != nil {
@@ -1136,8 +1409,8 @@ func getOpenIdMetadata(conf *config.Config) (*openIdMetadata, error) {
 		}
 
 		// Validate issuer == issuerUri
-		if metadata.Issuer != cfg.IssuerUri {
-			return nil, fmt.Errorf("mismatch between the configured issuer_uri (%s) and the exposed Issuer URI in OpenId provider metadata (%s)", cfg.IssuerUri, metadata.Issuer)
+		if metadata.Issuer != issuerUri {
+			return nil, fmt.Errorf("mismatch between the configured issuer_uri (%s) and the exposed Issuer URI in OpenId provider metadata (%s)", issuerUri, metadata.Issuer)
 		}
 
 		// Validate there is an authorization endpoint
@@ -1164,8 +1437,13 @@ func getOpenIdMetadata(conf *config.Config) (*openIdMetadata, error) {
 		}
 
 		// Return parsed metadata
-		cachedOpenIdMetadata = &metadata
-		return cachedOpenIdMetadata, nil
+		openIdCacheLock.Lock()
+		if cachedOpenIdMetadata == nil {
+			cachedOpenIdMetadata = map[string]*openIdMetadata{}
+		}
+		cachedOpenIdMetadata[issuerUri] = &metadata
+		openIdCacheLock.Unlock()
+		return &metadata, nil
 	})
 
 	if fetchError != nil {
@@ -1264,12 +1542,19 @@ func verifyAudienceClaim(openIdParams *openidFlowHelper, oidCfg config.OpenIdCon
 // If the claims look OK, the signature is checked against the key sets published by
 // the OpenId server.
 func validateOpenIdTokenInHouse(openIdParams *openidFlowHelper) error {
-	oidCfg := openIdParams.conf.Auth.OpenId
-	oidMetadata, err := getOpenIdMetadata(openIdParams.conf)
+	issuer, found := openIdParams.conf.Auth.OpenId.GetIssuer(openIdParams.IssuerUri)
+	if !found {
+		return fmt.Errorf("the OpenId issuer [%s] is not trusted", openIdParams.IssuerUri)
+	}
+	oidMetadata, err := getOpenIdMetadata(openIdParams.conf, issuer.IssuerUri)
 	if err != nil {
 		return err
 	}
 
+	// The token is targeted to the client registered with its issuer
+	oidCfg := openIdParams.conf.Auth.OpenId
+	oidCfg.ClientId = issuer.ClientId
+
 	// Check iss claim matches fetched metadata at discovery
 	if issuerClaim, ok := openIdParams.IdTokenPayload["iss"].(string); !ok || issuerClaim != oidMetadata.Issuer {
 		return fmt.Errorf("the OpenId token has unexpected issuer claim; got iss = '%s'", issuerClaim)
@@ -1339,7 +1624,7 @@ func validateOpenIdTokenInHouse(openIdParams *openidFlowHelper) error {
 				return errors.New("an unsigned OpenId token is not acceptable")
 			}
 
-			matchingKey, findKeyErr := getJwkFromKeySet(openIdParams.conf, kidHeader)
+			matchingKey, findKeyErr := getJwkFromKeySet(openIdParams.conf, issuer.IssuerUri, kidHeader)
 			if findKeyErr != nil {
 				return fmt.Errorf("something went wrong when trying to find the key that signed the OpenId token: %w", findKeyErr)
 			}
@@ -1359,8 +1644,7 @@ func validateOpenIdTokenInHouse(openIdParams *openidFlowHelper) error {
 
 // verifyOpenIdUserAccess checks that the provided token has enough privileges on the cluster to
 // allow a login to Kiali.
-func verifyOpenIdUserAccess(token string, clientFactory kubernetes.ClientFactory, kialiCache cache.KialiCache, conf *config.Config) (int, string, error) {
-	authInfo := &api.AuthInfo{Token: token}
+func verifyOpenIdUserAccess(authInfo *api.AuthInfo, clientFactory kubernetes.ClientFactory, kialiCache cache.KialiCache, conf *config.Config) (int, string, error) {
 	userClients, err := clientFactory.GetClients(authInfo)
 	if err != nil {
 		return http.StatusInternalServerError, "Unable to create a Kubernetes client from the auth token", err
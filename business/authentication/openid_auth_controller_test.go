@@ -3,6 +3,7 @@ package authentication
 import (
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
@@ -1124,3 +1125,224 @@ func TestOpenIdCodeFlowShouldRejectMissingNonceInToken(t *testing.T) {
 	assert.Equal(t, "/kiali-test/?"+q.Encode(), response.Header.Get("Location"))
 	assert.Equal(t, http.StatusFound, response.StatusCode)
 }
+
+// unsignedTestToken builds a JWT with the given claims, for the tests not verifying the signature of the tokens.
+func unsignedTestToken(t *testing.T, claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	assert.Nil(t, err)
+	return "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}
+
+func TestOpenIdSessionIsRefreshedWithRefreshToken(t *testing.T) {
+	cachedOpenIdMetadata = nil
+	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: clockTime}
+
+	refreshedToken := unsignedTestToken(t, map[string]interface{}{
+		"sub": "jdoe@domain.com",
+		"exp": clockTime.Add(time.Hour).Unix(),
+	})
+	refreshFails := false
+	var oidcMetadata []byte
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/openid-configuration" {
+			w.WriteHeader(200)
+			_, _ = w.Write(oidcMetadata)
+		}
+		if r.URL.Path == "/token" {
+			_ = r.ParseForm()
+			switch r.Form.Get("grant_type") {
+			case "authorization_code":
+				w.WriteHeader(200)
+				_, _ = w.Write([]byte("{ \"id_token\": \"" + openIdTestToken + "\", \"refresh_token\": \"refresh-1\" }"))
+			case "refresh_token":
+				assert.Equal(t, "refresh-1", r.Form.Get("refresh_token"))
+				assert.Equal(t, "kiali-client", r.Form.Get("client_id"))
+				if refreshFails {
+					w.WriteHeader(400)
+					return
+				}
+				w.WriteHeader(200)
+				_, _ = w.Write([]byte("{ \"id_token\": \"" + refreshedToken + "\", \"refresh_token\": \"refresh-1\" }"))
+			}
+		}
+	}))
+	defer testServer.Close()
+
+	oidcMeta := openIdMetadata{
+		Issuer:                 testServer.URL,
+		AuthURL:                testServer.URL + "/auth",
+		TokenURL:               testServer.URL + "/token",
+		ScopesSupported:        []string{"openid"},
+		ResponseTypesSupported: []string{"code"},
+	}
+	oidcMetadata, err := json.Marshal(oidcMeta)
+	assert.Nil(t, err)
+
+	conf := config.NewConfig()
+	conf.Server.WebRoot = "/kiali-test"
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.LoginToken.ExpirationSeconds = 3600
+	conf.Auth.Strategy = config.AuthStrategyOpenId
+	conf.Auth.OpenId.IssuerUri = testServer.URL
+	conf.Auth.OpenId.ClientId = "kiali-client"
+	conf.Auth.OpenId.RefreshTokens = true
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Foo"}})
+	mockClientFactory := kubetest.NewK8SClientFactoryMock(k8s)
+	kialiCache := cache.NewTestingCacheWithFactory(t, mockClientFactory, *conf)
+
+	stateHash := sha256.Sum224([]byte(fmt.Sprintf("%s+%s+%s", "nonceString", clockTime.UTC().Format("060102150405"), getSigningKey(conf))))
+	uri := fmt.Sprintf("https://kiali.io:44/api/authenticate?code=f0code&state=%x-%s", stateHash, clockTime.UTC().Format("060102150405"))
+	request := httptest.NewRequest(http.MethodGet, uri, nil)
+	request.AddCookie(&http.Cookie{
+		Name:  OpenIdNonceCookieName,
+		Value: "nonceString",
+	})
+
+	controller := NewOpenIdAuthController(NewCookieSessionPersistor(conf), kialiCache, mockClientFactory, conf)
+
+	rr := httptest.NewRecorder()
+	controller.GetAuthCallbackHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Failf(t, "Callback function shouldn't have been called.", "")
+	})).ServeHTTP(rr, request)
+
+	// The session outlives the id_token
+	response := rr.Result()
+	assert.Len(t, response.Cookies(), 2)
+	sessionCookie := response.Cookies()[1]
+	assert.Equal(t, AESSessionCookieName, sessionCookie.Name)
+	assert.Equal(t, clockTime.Add(time.Hour), sessionCookie.Expires)
+
+	// Only the id of the session travels in the cookie
+	request = httptest.NewRequest(http.MethodGet, "https://kiali.io:44/api/namespaces", nil)
+	request.AddCookie(sessionCookie)
+	sPayload := oidcSessionPayload{}
+	_, err = controller.SessionStore.ReadSession(request, httptest.NewRecorder(), &sPayload)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, sPayload.SessionId)
+	assert.Empty(t, sPayload.Token)
+
+	// Once the id_token expired, it is refreshed
+	util.Clock = util.ClockMock{Time: clockTime.Add(10 * time.Minute)}
+	request = httptest.NewRequest(http.MethodGet, "https://kiali.io:44/api/namespaces", nil)
+	request.AddCookie(sessionCookie)
+	rr = httptest.NewRecorder()
+	session, err := controller.ValidateSession(request, rr)
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+	assert.Equal(t, "jdoe@domain.com", session.Username)
+	assert.Equal(t, refreshedToken, session.AuthInfo.Token)
+	assert.Equal(t, clockTime.Add(time.Hour), session.ExpiresOn)
+
+	// The refreshed token is kept server side, the cookie is unchanged
+	assert.Empty(t, rr.Result().Cookies())
+
+	// The session ends when the refreshed token expired and cannot be refreshed anymore
+	refreshFails = true
+	util.Clock = util.ClockMock{Time: clockTime.Add(time.Hour - time.Second)}
+	request = httptest.NewRequest(http.MethodGet, "https://kiali.io:44/api/namespaces", nil)
+	request.AddCookie(sessionCookie)
+	session, err = controller.ValidateSession(request, httptest.NewRecorder())
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+
+	util.Clock = util.ClockMock{Time: clockTime.Add(time.Hour + time.Second)}
+	session, err = controller.ValidateSession(request, httptest.NewRecorder())
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+}
+
+func TestOpenIdAuthenticationWithAdditionalIssuer(t *testing.T) {
+	cachedOpenIdMetadata = nil
+	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: clockTime}
+
+	var oidcMetadata []byte
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/openid-configuration" {
+			w.WriteHeader(200)
+			_, _ = w.Write(oidcMetadata)
+		}
+		if r.URL.Path == "/token" {
+			_ = r.ParseForm()
+			assert.Equal(t, "f0code", r.Form.Get("code"))
+			assert.Equal(t, "kiali-west", r.Form.Get("client_id"))
+
+			w.WriteHeader(200)
+			_, _ = w.Write([]byte("{ \"id_token\": \"" + openIdTestToken + "\" }"))
+		}
+	}))
+	defer testServer.Close()
+
+	oidcMeta := openIdMetadata{
+		Issuer:                 testServer.URL,
+		AuthURL:                testServer.URL + "/auth",
+		TokenURL:               testServer.URL + "/token",
+		ScopesSupported:        []string{"openid"},
+		ResponseTypesSupported: []string{"code"},
+	}
+	oidcMetadata, err := json.Marshal(oidcMeta)
+	assert.Nil(t, err)
+
+	conf := config.NewConfig()
+	conf.Server.WebRoot = "/kiali-test"
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.Auth.Strategy = config.AuthStrategyOpenId
+	conf.Auth.OpenId.IssuerUri = "https://east.example.com"
+	conf.Auth.OpenId.ClientId = "kiali-east"
+	conf.Auth.OpenId.AdditionalIssuers = []config.OpenIdIssuerConfig{
+		{IssuerUri: testServer.URL, ClientId: "kiali-west", Clusters: []string{"west"}},
+	}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Foo"}})
+	mockClientFactory := kubetest.NewK8SClientFactoryMock(k8s)
+	kialiCache := cache.NewTestingCacheWithFactory(t, mockClientFactory, *conf)
+	controller := NewOpenIdAuthController(NewCookieSessionPersistor(conf), kialiCache, mockClientFactory, conf)
+
+	// The redirection goes to the additional issuer
+	request := httptest.NewRequest(http.MethodGet, "https://kiali.io:44/api/auth/openid_redirect?issuer="+url.QueryEscape(testServer.URL), nil)
+	rr := httptest.NewRecorder()
+	controller.redirectToAuthServerHandler(rr, request)
+	response := rr.Result()
+	assert.Equal(t, http.StatusFound, response.StatusCode)
+	assert.True(t, strings.HasPrefix(response.Header.Get("Location"), testServer.URL+"/auth?client_id=kiali-west&"))
+	assert.Len(t, response.Cookies(), 2)
+	assert.Equal(t, OpenIdIssuerCookieName, response.Cookies()[1].Name)
+	assert.Equal(t, testServer.URL, response.Cookies()[1].Value)
+
+	// An unknown issuer is rejected
+	request = httptest.NewRequest(http.MethodGet, "https://kiali.io:44/api/auth/openid_redirect?issuer=https://unknown.example.com", nil)
+	rr = httptest.NewRecorder()
+	controller.redirectToAuthServerHandler(rr, request)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	// The code is exchanged with the additional issuer
+	stateHash := sha256.Sum224([]byte(fmt.Sprintf("%s+%s+%s", "nonceString", clockTime.UTC().Format("060102150405"), getSigningKey(conf))))
+	uri := fmt.Sprintf("https://kiali.io:44/api/authenticate?code=f0code&state=%x-%s", stateHash, clockTime.UTC().Format("060102150405"))
+	request = httptest.NewRequest(http.MethodGet, uri, nil)
+	request.AddCookie(&http.Cookie{Name: OpenIdNonceCookieName, Value: "nonceString"})
+	request.AddCookie(&http.Cookie{Name: OpenIdIssuerCookieName, Value: testServer.URL})
+	rr = httptest.NewRecorder()
+	controller.GetAuthCallbackHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Failf(t, "Callback function shouldn't have been called.", "")
+	})).ServeHTTP(rr, request)
+
+	response = rr.Result()
+	assert.Equal(t, "/kiali-test/", response.Header.Get("Location"))
+	assert.Len(t, response.Cookies(), 3)
+	assert.Equal(t, OpenIdIssuerCookieName, response.Cookies()[1].Name)
+	assert.Equal(t, AESSessionCookieName, response.Cookies()[2].Name)
+
+	// The user clients know the issuer of the token
+	request = httptest.NewRequest(http.MethodGet, "https://kiali.io:44/api/namespaces", nil)
+	request.AddCookie(response.Cookies()[2])
+	session, err := controller.ValidateSession(request, httptest.NewRecorder())
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+	assert.Equal(t, openIdTestToken, session.AuthInfo.Token)
+	assert.Equal(t, testServer.URL, session.AuthInfo.AuthProvider.Config["idp-issuer-url"])
+	assert.Equal(t, "jdoe@domain.com", session.AuthInfo.AuthProvider.Config["subject"])
+}
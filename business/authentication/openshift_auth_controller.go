@@ -228,7 +228,7 @@ func (o OpenshiftAuthController) ValidateSession(r *http.Request, w http.Respons
 	user, err := o.openshiftOAuth.GetUserInfo(r.Context(), token)
 	if err == nil {
 		// Internal header used to propagate the subject of the request for audit purposes
-		r.Header.Add("Kiali-User", user.Name)
+		r.Header.Set("Kiali-User", user.Name)
 		return &UserSessionData{
 			ExpiresOn: expires,
 			Username:  user.Name,
@@ -141,7 +141,7 @@ func (c tokenAuthController) ValidateSession(r *http.Request, w http.ResponseWri
 	}
 
 	// If we are here, the session looks valid. Return the session details.
-	r.Header.Add("Kiali-User", extractSubjectFromK8sToken(sPayload.Token)) // Internal header used to propagate the subject of the request for audit purposes
+	r.Header.Set("Kiali-User", extractSubjectFromK8sToken(sPayload.Token)) // Internal header used to propagate the subject of the request for audit purposes
 	return &UserSessionData{
 		ExpiresOn: sData.ExpiresOn,
 		Username:  extractSubjectFromK8sToken(sPayload.Token),
@@ -0,0 +1,104 @@
+package business
+
+import (
+	"context"
+	"sort"
+
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetAuthorizationCoverage reports, for every workload of the namespace, whether it is covered by an ALLOW
+// policy, allowed by default because no ALLOW policy applies to it, or unreachable because of a deny-all.
+func (in *IstioConfigService) GetAuthorizationCoverage(ctx context.Context, cluster, namespace string) (*models.AuthorizationCoverage, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetAuthorizationCoverage",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	workloads, err := in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	istioConfigList, err := in.GetIstioConfigList(ctx, cluster, IstioConfigCriteria{IncludeAuthorizationPolicies: true})
+	if err != nil {
+		return nil, err
+	}
+
+	rbacDetails := kubernetes.RBACDetails{}
+	for _, ap := range istioConfigList.AuthorizationPolicies {
+		if ap.Namespace == namespace || config.IsRootNamespace(ap.Namespace) {
+			rbacDetails.AuthorizationPolicies = append(rbacDetails.AuthorizationPolicies, ap)
+		}
+	}
+
+	coverage := &models.AuthorizationCoverage{
+		Cluster:   cluster,
+		Namespace: namespace,
+		Workloads: []models.WorkloadAuthorizationCoverage{},
+	}
+	for _, wk := range workloads {
+		policies := rbacDetails.WorkloadAuthorizationPolicies(namespace, wk.Labels)
+
+		wkCoverage := models.WorkloadAuthorizationCoverage{
+			Name:     wk.Name,
+			Coverage: authorizationCoverage(policies),
+			Policies: []models.IstioValidationKey{},
+		}
+		for _, ap := range policies {
+			ref := models.BuildKey(kubernetes.AuthorizationPoliciesType, ap.Name, ap.Namespace)
+			ref.Cluster = cluster
+			wkCoverage.Policies = append(wkCoverage.Policies, ref)
+		}
+		coverage.Workloads = append(coverage.Workloads, wkCoverage)
+	}
+
+	sort.Slice(coverage.Workloads, func(i, j int) bool {
+		return coverage.Workloads[i].Name < coverage.Workloads[j].Name
+	})
+
+	return coverage, nil
+}
+
+// authorizationCoverage evaluates the policies that apply to a workload the way istio does:
+// DENY policies first, then ALLOW policies. A workload is unreachable when a DENY policy has
+// a rule matching every request or when all its ALLOW policies have no rules (allow nothing).
+func authorizationCoverage(policies []*security_v1beta.AuthorizationPolicy) string {
+	allowPolicies, allowRules := 0, 0
+	for _, ap := range policies {
+		switch ap.Spec.Action {
+		case api_security_v1beta1.AuthorizationPolicy_DENY:
+			for _, rule := range ap.Spec.Rules {
+				if isMatchAllRule(rule) {
+					return models.AuthorizationDenyAll
+				}
+			}
+		case api_security_v1beta1.AuthorizationPolicy_ALLOW:
+			allowPolicies++
+			allowRules += len(ap.Spec.Rules)
+		}
+	}
+
+	switch {
+	case allowPolicies == 0:
+		return models.AuthorizationDefaultAllow
+	case allowRules == 0:
+		return models.AuthorizationDenyAll
+	default:
+		return models.AuthorizationAllowed
+	}
+}
+
+// isMatchAllRule returns true when the rule has no conditions and so matches any request.
+func isMatchAllRule(rule *api_security_v1beta1.Rule) bool {
+	return rule != nil && len(rule.From) == 0 && len(rule.To) == 0 && len(rule.When) == 0
+}
@@ -0,0 +1,91 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestAuthorizationCoverageEvaluation(t *testing.T) {
+	assert := assert.New(t)
+
+	allowGet := data.CreateAuthorizationPolicy([]string{"bookinfo"}, []string{"GET"}, []string{"*"}, nil)
+	allowNothing := data.CreateEmptyAuthorizationPolicy("allow-nothing", "bookinfo")
+	denyAll := data.CreateEmptyAuthorizationPolicy("deny-all", "bookinfo")
+	denyAll.Spec.Action = api_security_v1beta1.AuthorizationPolicy_DENY
+	denyAll.Spec.Rules = []*api_security_v1beta1.Rule{{}}
+	denyGet := data.CreateAuthorizationPolicy([]string{"bookinfo"}, []string{"GET"}, []string{"*"}, nil)
+	denyGet.Spec.Action = api_security_v1beta1.AuthorizationPolicy_DENY
+
+	assert.Equal(models.AuthorizationDefaultAllow, authorizationCoverage(nil))
+	assert.Equal(models.AuthorizationDefaultAllow, authorizationCoverage([]*security_v1beta1.AuthorizationPolicy{denyGet}))
+	assert.Equal(models.AuthorizationAllowed, authorizationCoverage([]*security_v1beta1.AuthorizationPolicy{allowGet}))
+	assert.Equal(models.AuthorizationAllowed, authorizationCoverage([]*security_v1beta1.AuthorizationPolicy{allowGet, allowNothing}))
+	assert.Equal(models.AuthorizationDenyAll, authorizationCoverage([]*security_v1beta1.AuthorizationPolicy{allowNothing}))
+	assert.Equal(models.AuthorizationDenyAll, authorizationCoverage([]*security_v1beta1.AuthorizationPolicy{allowGet, denyAll}))
+}
+
+func TestGetAuthorizationCoverage(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	deployment := func(name, app string) *apps_v1.Deployment {
+		dep := fakeDeploymentWithStatus(name, map[string]string{"app": app}, apps_v1.DeploymentStatus{})
+		dep.Namespace = "bookinfo"
+		return dep
+	}
+
+	// The mesh-wide policy denies everything to ratings, productpage is allowed by its own policy.
+	meshDenyRatings := data.CreateAuthorizationPolicyWithMetaAndSelector("deny-ratings", "istio-system", map[string]string{"app": "ratings"})
+	meshDenyRatings.Spec.Action = api_security_v1beta1.AuthorizationPolicy_DENY
+	meshDenyRatings.Spec.Rules = []*api_security_v1beta1.Rule{{}}
+	allowProductpage := data.CreateAuthorizationPolicy([]string{"istio-system"}, []string{"GET"}, []string{"*"}, map[string]string{"app": "productpage"})
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		deployment("productpage-v1", "productpage"),
+		deployment("ratings-v1", "ratings"),
+		deployment("details-v1", "details"),
+		meshDenyRatings,
+		allowProductpage,
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	istioConfig := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	coverage, err := istioConfig.GetAuthorizationCoverage(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo")
+	require.NoError(err)
+	require.Len(coverage.Workloads, 3)
+
+	assert.Equal("details-v1", coverage.Workloads[0].Name)
+	assert.Equal(models.AuthorizationDefaultAllow, coverage.Workloads[0].Coverage)
+	assert.Empty(coverage.Workloads[0].Policies)
+
+	assert.Equal("productpage-v1", coverage.Workloads[1].Name)
+	assert.Equal(models.AuthorizationAllowed, coverage.Workloads[1].Coverage)
+	require.Len(coverage.Workloads[1].Policies, 1)
+	assert.Equal("auth-policy", coverage.Workloads[1].Policies[0].Name)
+
+	assert.Equal("ratings-v1", coverage.Workloads[2].Name)
+	assert.Equal(models.AuthorizationDenyAll, coverage.Workloads[2].Coverage)
+	require.Len(coverage.Workloads[2].Policies, 1)
+	assert.Equal("istio-system", coverage.Workloads[2].Policies[0].Namespace)
+}
@@ -0,0 +1,454 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+const (
+	// The envoy admin endpoint listing the certificates loaded by the proxy.
+	envoyCertsPath = "/certs"
+	envoyAdminPort = 15000
+	// certificateExpirationsInterval is how often the expiration of the certificates is exported as Kiali metrics.
+	certificateExpirationsInterval = 10 * time.Minute
+)
+
+// Keys of the TLS certificate in the secrets referenced by the gateways.
+var gatewayCertKeys = []string{"tls.crt", "cert"}
+
+// envoyCerts is the response of the envoy /certs admin endpoint.
+type envoyCerts struct {
+	Certificates []struct {
+		CertChain []envoyCertDetails `json:"cert_chain"`
+	} `json:"certificates"`
+}
+
+type envoyCertDetails struct {
+	SubjectAltNames []struct {
+		URI string `json:"uri"`
+		DNS string `json:"dns"`
+	} `json:"subject_alt_names"`
+	ValidFrom      time.Time `json:"valid_from"`
+	ExpirationTime time.Time `json:"expiration_time"`
+}
+
+// CertificateService inventories the certificates relevant to the mesh.
+type CertificateService struct {
+	businessLayer  *Layer
+	conf           *config.Config
+	kialiCache     cache.KialiCache
+	kialiSAClients map[string]kubernetes.ClientInterface
+	userClients    map[string]kubernetes.ClientInterface
+}
+
+// GetCertificateInventory lists the roots and intermediates of every controlplane, the TLS certificates
+// of the gateways and a sample of the workload certificates, one proxy per namespace, read from envoy.
+func (in *CertificateService) GetCertificateInventory(ctx context.Context) (*models.CertificateInventory, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetCertificateInventory",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &models.CertificateInventory{
+		ExpirationWarningDays: in.conf.KialiFeatureFlags.CertificatesInformationIndicators.ExpirationWarningDays,
+		Certificates:          []models.MeshCertificate{},
+	}
+
+	// Different revisions usually share the istiod namespace and so the CA.
+	seen := map[string]bool{}
+	for _, cp := range mesh.ControlPlanes {
+		key := cp.Cluster.Name + "/" + cp.IstiodNamespace
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		certs, err := in.getControlPlaneCertificates(cp.Cluster.Name, cp.IstiodNamespace)
+		if err != nil {
+			return nil, err
+		}
+		inventory.Certificates = append(inventory.Certificates, certs...)
+	}
+
+	clusters := make([]string, 0, len(in.userClients))
+	for cluster := range in.userClients {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	for _, cluster := range clusters {
+		certs, err := in.getGatewayCertificates(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		inventory.Certificates = append(inventory.Certificates, certs...)
+
+		certs, err = in.getWorkloadCertificates(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		inventory.Certificates = append(inventory.Certificates, certs...)
+	}
+
+	now := time.Now()
+	warnBefore := time.Duration(inventory.ExpirationWarningDays) * 24 * time.Hour
+	for i := range inventory.Certificates {
+		inventory.Certificates[i].ComputeStatus(now, warnBefore)
+	}
+
+	return inventory, nil
+}
+
+// StartCertificateExpirations exports the expiration of every certificate of the inventory as a Kiali metric, then
+// refreshes it periodically until the context is cancelled. The inventory is done with the Kiali service account so
+// that the metric does not depend on who looks at the certificates, or when.
+func StartCertificateExpirations(ctx context.Context, conf *config.Config, kialiCache cache.KialiCache, clientFactory kubernetes.ClientFactory) {
+	go func() {
+		for {
+			// The clients change as clusters are added and removed.
+			saClients := clientFactory.GetSAClients()
+			layer := newLayer(saClients, saClients, nil, nil, kialiCache, conf, nil)
+			refreshCertificateExpirations(ctx, &layer.Certificates)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(certificateExpirationsInterval):
+			}
+		}
+	}()
+}
+
+// refreshCertificateExpirations replaces the certificate expiration metrics with the ones of a new inventory.
+// They are kept as they are when the inventory fails.
+func refreshCertificateExpirations(ctx context.Context, certificates *CertificateService) {
+	inventory, err := certificates.GetCertificateInventory(ctx)
+	if err != nil {
+		log.Errorf("Unable to export the expiration of the mesh certificates: %s", err)
+		return
+	}
+
+	internalmetrics.ResetMeshCertificateExpirations()
+	for _, cert := range inventory.Certificates {
+		if !cert.NotAfter.IsZero() {
+			internalmetrics.SetMeshCertificateExpiration(cert.Cluster, cert.Kind, cert.Namespace, cert.Name, cert.NotAfter)
+		}
+	}
+}
+
+// getControlPlaneCertificates returns the roots of the trust bundle distributed by istiod and
+// the CA chain istiod signs the workload certificates with, when the secret can be read.
+func (in *CertificateService) getControlPlaneCertificates(cluster, namespace string) ([]models.MeshCertificate, error) {
+	certs := []models.MeshCertificate{}
+
+	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	root := models.MeshCertificate{
+		Kind:       models.CertificateKindRoot,
+		Cluster:    cluster,
+		Namespace:  namespace,
+		Name:       IstioCARootCertConfigMap,
+		Source:     models.CertificateSourceConfigMap,
+		Accessible: true,
+	}
+	configMap, err := kubeCache.GetConfigMap(namespace, IstioCARootCertConfigMap)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		root.Error = err.Error()
+		certs = append(certs, root)
+	} else {
+		certs = append(certs, certificatesFromBundle(root, []byte(configMap.Data[istioRootCert]))...)
+	}
+
+	client, ok := in.userClients[cluster]
+	if !ok {
+		return certs, nil
+	}
+
+	for _, secretName := range []string{UserProvidedCASecret, IstioDefaultCASecret} {
+		ca := models.MeshCertificate{
+			Kind:       models.CertificateKindIntermediate,
+			Cluster:    cluster,
+			Namespace:  namespace,
+			Name:       secretName,
+			Source:     models.CertificateSourceSecret,
+			Accessible: true,
+		}
+
+		secret, err := client.GetSecret(namespace, secretName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if !errors.IsForbidden(err) {
+				return nil, err
+			}
+			ca.Accessible = false
+			certs = append(certs, ca)
+			continue
+		}
+
+		chain := secret.Data[CAChainCert]
+		if len(chain) == 0 {
+			chain = secret.Data[CACert]
+		}
+		certs = append(certs, certificatesFromBundle(ca, chain)...)
+		// istiod only uses the first CA secret found.
+		break
+	}
+
+	return certs, nil
+}
+
+// getGatewayCertificates returns the certificates of the TLS secrets referenced by the
+// Istio and K8s Gateways of the cluster, along with the gateways referencing them.
+func (in *CertificateService) getGatewayCertificates(ctx context.Context, cluster string) ([]models.MeshCertificate, error) {
+	criteria := IstioConfigCriteria{
+		IncludeGateways:    true,
+		IncludeK8sGateways: true,
+	}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, cluster, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	// Secrets referenced by the gateways, in order of appearance.
+	type gatewaySecret struct {
+		namespace  string
+		name       string
+		references []models.IstioValidationKey
+	}
+	secrets := []*gatewaySecret{}
+	index := map[string]*gatewaySecret{}
+	addReference := func(namespace, name string, ref models.IstioValidationKey) {
+		key := namespace + "/" + name
+		secret, found := index[key]
+		if !found {
+			secret = &gatewaySecret{namespace: namespace, name: name}
+			index[key] = secret
+			secrets = append(secrets, secret)
+		}
+		ref.Cluster = cluster
+		secret.references = append(secret.references, ref)
+	}
+
+	for _, gw := range istioConfigList.Gateways {
+		for _, server := range gw.Spec.Servers {
+			if server.Tls == nil || server.Tls.CredentialName == "" {
+				continue
+			}
+			// The secret lives in the namespace of the gateway workload, usually the namespace of the Gateway.
+			addReference(gw.Namespace, server.Tls.CredentialName, models.BuildKey(kubernetes.GatewayType, gw.Name, gw.Namespace))
+		}
+	}
+
+	for _, gw := range istioConfigList.K8sGateways {
+		for _, listener := range gw.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, ref := range listener.TLS.CertificateRefs {
+				if ref.Kind != nil && *ref.Kind != "Secret" {
+					continue
+				}
+				namespace := gw.Namespace
+				if ref.Namespace != nil {
+					namespace = string(*ref.Namespace)
+				}
+				addReference(namespace, string(ref.Name), models.BuildKey(kubernetes.K8sGatewayType, gw.Name, gw.Namespace))
+			}
+		}
+	}
+
+	certs := []models.MeshCertificate{}
+	client, ok := in.userClients[cluster]
+	if !ok {
+		return certs, nil
+	}
+
+	for _, gs := range secrets {
+		namespace, name := gs.namespace, gs.name
+		cert := models.MeshCertificate{
+			Kind:         models.CertificateKindGateway,
+			Cluster:      cluster,
+			Namespace:    namespace,
+			Name:         name,
+			Source:       models.CertificateSourceSecret,
+			ReferencedBy: gs.references,
+			Accessible:   true,
+		}
+
+		secret, err := client.GetSecret(namespace, name)
+		if err != nil {
+			switch {
+			case errors.IsForbidden(err):
+				cert.Accessible = false
+			case errors.IsNotFound(err):
+				cert.Error = fmt.Sprintf("secret [%s/%s] not found", namespace, name)
+			default:
+				return nil, err
+			}
+			certs = append(certs, cert)
+			continue
+		}
+
+		var data []byte
+		for _, certKey := range gatewayCertKeys {
+			if data = secret.Data[certKey]; len(data) > 0 {
+				break
+			}
+		}
+		// Only the leaf certificate of the gateway is relevant.
+		certs = append(certs, certificatesFromBundle(cert, data)[0])
+	}
+
+	return certs, nil
+}
+
+// getWorkloadCertificates samples the workload certificate of one running proxy per namespace.
+// Workload certificates are short lived and rotated by the proxies themselves so a sample is
+// enough to tell whether istiod is issuing them properly.
+func (in *CertificateService) getWorkloadCertificates(ctx context.Context, cluster string) ([]models.MeshCertificate, error) {
+	certs := []models.MeshCertificate{}
+
+	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := in.kialiSAClients[cluster]
+	if !ok {
+		return certs, nil
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ns := range namespaces {
+		pods, err := kubeCache.GetPods(ns.Name, "")
+		if err != nil {
+			return nil, err
+		}
+
+		pod := sampleProxyPod(pods, in.conf.ExternalServices.Istio.IstioSidecarAnnotation)
+		if pod == nil {
+			continue
+		}
+
+		cert := models.MeshCertificate{
+			Kind:       models.CertificateKindWorkload,
+			Cluster:    cluster,
+			Namespace:  ns.Name,
+			Name:       pod.Name,
+			Source:     models.CertificateSourceProxy,
+			Accessible: true,
+		}
+
+		resp, err := client.ForwardGetRequest(ns.Name, pod.Name, envoyAdminPort, envoyCertsPath)
+		if err != nil {
+			log.Debugf("Unable to get the certificates of proxy [%s/%s] in cluster [%s]: %s", ns.Name, pod.Name, cluster, err)
+			cert.Error = err.Error()
+			certs = append(certs, cert)
+			continue
+		}
+
+		if err := parseEnvoyWorkloadCert(resp, &cert); err != nil {
+			cert.Error = err.Error()
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+// sampleProxyPod returns the first running pod with a sidecar, if any.
+func sampleProxyPod(pods []core_v1.Pod, sidecarAnnotation string) *core_v1.Pod {
+	for i := range pods {
+		if pods[i].Status.Phase != core_v1.PodRunning {
+			continue
+		}
+		if _, found := pods[i].Annotations[sidecarAnnotation]; found {
+			return &pods[i]
+		}
+	}
+	return nil
+}
+
+// parseEnvoyWorkloadCert fills the certificate with the leaf of the workload cert chain loaded by envoy.
+func parseEnvoyWorkloadCert(body []byte, cert *models.MeshCertificate) error {
+	var certs envoyCerts
+	if err := json.Unmarshal(body, &certs); err != nil {
+		return fmt.Errorf("unable to parse the proxy certificates: %s", err)
+	}
+
+	for _, c := range certs.Certificates {
+		if len(c.CertChain) == 0 {
+			continue
+		}
+
+		leaf := c.CertChain[0]
+		for _, san := range leaf.SubjectAltNames {
+			if san.URI != "" {
+				cert.Identities = append(cert.Identities, san.URI)
+			}
+			if san.DNS != "" {
+				cert.Identities = append(cert.Identities, san.DNS)
+			}
+		}
+		cert.NotBefore = leaf.ValidFrom
+		cert.NotAfter = leaf.ExpirationTime
+		return nil
+	}
+
+	return fmt.Errorf("no workload certificate loaded by the proxy")
+}
+
+// certificatesFromBundle returns one certificate per cert of the PEM bundle, based on the given template.
+func certificatesFromBundle(template models.MeshCertificate, bundle []byte) []models.MeshCertificate {
+	validities, err := parseCertValidities(bundle)
+	if err != nil {
+		template.Error = err.Error()
+		return []models.MeshCertificate{template}
+	}
+
+	certs := make([]models.MeshCertificate, 0, len(validities))
+	for _, validity := range validities {
+		cert := template
+		cert.Subject = validity.Subject
+		cert.Issuer = validity.Issuer
+		cert.NotBefore = validity.NotBefore
+		cert.NotAfter = validity.NotAfter
+		// A CA chain ends with the self-signed root.
+		if template.Kind == models.CertificateKindIntermediate && validity.Subject == validity.Issuer {
+			cert.Kind = models.CertificateKindRoot
+		}
+		certs = append(certs, cert)
+	}
+	return certs
+}
@@ -0,0 +1,147 @@
+package business
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+const envoyCertsResponse = `{
+  "certificates": [
+    {
+      "ca_cert": [{"path": "<inline>", "expiration_time": "2034-01-01T00:00:00Z"}],
+      "cert_chain": [
+        {
+          "path": "<inline>",
+          "subject_alt_names": [{"uri": "spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage"}],
+          "valid_from": "2024-01-01T00:00:00Z",
+          "expiration_time": "2024-01-02T00:00:00Z"
+        }
+      ]
+    }
+  ]
+}`
+
+func TestParseEnvoyWorkloadCert(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cert := models.MeshCertificate{}
+	require.NoError(parseEnvoyWorkloadCert([]byte(envoyCertsResponse), &cert))
+	assert.Equal([]string{"spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage"}, cert.Identities)
+	assert.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), cert.NotBefore)
+	assert.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), cert.NotAfter)
+
+	// Proxies without a workload certificate only list the roots.
+	assert.Error(parseEnvoyWorkloadCert([]byte(`{"certificates": [{"ca_cert": [{"path": "<inline>"}]}]}`), &models.MeshCertificate{}))
+}
+
+func TestGetCertificateInventory(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	kubernetes.SetConfig(t, *conf)
+
+	now := time.Now()
+	gatewayCert := fakeRootCert(t, "bookinfo.example.com", now.Add(10*24*time.Hour))
+
+	gateway := &networking_v1beta1.Gateway{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo-gateway", Namespace: "bookinfo"},
+		Spec: api_networking_v1beta1.Gateway{
+			Servers: []*api_networking_v1beta1.Server{
+				{Tls: &api_networking_v1beta1.ServerTLSSettings{CredentialName: "bookinfo-cert"}},
+				{Tls: &api_networking_v1beta1.ServerTLSSettings{CredentialName: "missing-cert"}},
+			},
+		},
+	}
+	productpage := &core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "productpage-v1-123",
+			Namespace:   "bookinfo",
+			Annotations: map[string]string{conf.ExternalServices.Istio.IstioSidecarAnnotation: "{}"},
+		},
+		Status: core_v1.PodStatus{Phase: core_v1.PodRunning},
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		fakeIstioConfigMap("default"),
+		fakeRootCertConfigMap(fakeRootCert(t, "root-1", now.Add(365*24*time.Hour))),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo-cert", Namespace: "bookinfo"},
+			Data:       map[string][]byte{"tls.crt": gatewayCert},
+		},
+		gateway,
+		productpage,
+	)
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	envoy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(envoyCertsResponse))
+	}))
+	t.Cleanup(envoy.Close)
+	fakeForwarder := &fakeForwarder{ClientInterface: k8s, testURL: envoy.URL}
+
+	SetupBusinessLayer(t, fakeForwarder, *conf)
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: fakeForwarder}
+	layer := NewWithBackends(k8sclients, k8sclients, nil, nil)
+
+	inventory, err := layer.Certificates.GetCertificateInventory(context.TODO())
+	require.NoError(err)
+	assert.Equal(30, inventory.ExpirationWarningDays)
+
+	certs := map[string]models.MeshCertificate{}
+	for _, cert := range inventory.Certificates {
+		certs[cert.Kind+"/"+cert.Namespace+"/"+cert.Name] = cert
+	}
+	require.Len(certs, 4)
+
+	root := certs["Root/istio-system/"+IstioCARootCertConfigMap]
+	assert.Equal("CN=root-1", root.Subject)
+	assert.Equal(models.CertificateValid, root.Status)
+
+	gw := certs["Gateway/bookinfo/bookinfo-cert"]
+	assert.Equal(models.CertificateExpiring, gw.Status)
+	assert.Equal([]models.IstioValidationKey{{ObjectType: kubernetes.GatewayType, Name: "bookinfo-gateway", Namespace: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName}}, gw.ReferencedBy)
+
+	missing := certs["Gateway/bookinfo/missing-cert"]
+	assert.Equal(models.CertificateUnknown, missing.Status)
+	assert.NotEmpty(missing.Error)
+
+	workload := certs["Workload/bookinfo/productpage-v1-123"]
+	assert.Equal(models.CertificateSourceProxy, workload.Source)
+	assert.Equal(models.CertificateExpired, workload.Status)
+
+	// Listing the certificates does not export their expiration, the periodic refresh does.
+	internalmetrics.ResetMeshCertificateExpirations()
+	_, err = layer.Certificates.GetCertificateInventory(context.TODO())
+	require.NoError(err)
+	assert.Zero(testutil.CollectAndCount(internalmetrics.Metrics.MeshCertificateExpiration))
+
+	refreshCertificateExpirations(context.TODO(), &layer.Certificates)
+	// The missing gateway certificate has no expiration
+	assert.Equal(3, testutil.CollectAndCount(internalmetrics.Metrics.MeshCertificateExpiration))
+	gatewayExpiration := internalmetrics.Metrics.MeshCertificateExpiration.WithLabelValues(conf.KubernetesConfig.ClusterName, models.CertificateKindGateway, "bookinfo", "bookinfo-cert")
+	assert.Equal(float64(gw.NotAfter.Unix()), testutil.ToFloat64(gatewayExpiration))
+}
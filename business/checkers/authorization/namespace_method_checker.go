@@ -17,6 +17,8 @@ var methodMatcher = regexp.MustCompile(`^((\/[a-zA-Z\.]+)+)(\/[a-zA-Z]+)$`)
 type NamespaceMethodChecker struct {
 	AuthorizationPolicy *security_v1beta.AuthorizationPolicy
 	Namespaces          models.NamespaceNames
+	// TenantScoped namespaces hide the namespaces of the other tenants, which are then not reported as not found
+	TenantScoped bool
 }
 
 func (ap NamespaceMethodChecker) Check() ([]*models.IstioCheck, bool) {
@@ -60,7 +62,7 @@ func (ap NamespaceMethodChecker) validateFromField(ruleIdx int, from []*api_secu
 		}
 
 		for i, n := range f.Source.Namespaces {
-			if !ap.Namespaces.Includes(n) {
+			if !ap.TenantScoped && !ap.Namespaces.Includes(n) {
 				valid = true
 				path := fmt.Sprintf("spec/rules[%d]/from[%d]/source/namespaces[%d]", ruleIdx, fromIdx, i)
 				validation := models.Build("authorizationpolicy.source.namespacenotfound", path)
@@ -0,0 +1,127 @@
+package authorization
+
+import (
+	"fmt"
+	"strings"
+
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// RequestAuthnChecker validates that the rules relying on the request authentication (request principals or
+// request.auth conditions) apply to workloads with a RequestAuthentication. Otherwise, the request is never
+// authenticated and the rule never matches.
+type RequestAuthnChecker struct {
+	AuthorizationPolicy    *security_v1beta.AuthorizationPolicy
+	RequestAuthentications []*security_v1beta.RequestAuthentication
+	WorkloadsPerNamespace  map[string]models.WorkloadList
+}
+
+func (rc RequestAuthnChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+
+	paths := RequestAuthPaths(rc.AuthorizationPolicy)
+	if len(paths) == 0 || rc.hasRequestAuthentication() {
+		return checks, true
+	}
+
+	for _, path := range paths {
+		validation := models.Build("authorizationpolicy.requestauth.notfound", path)
+		checks = append(checks, &validation)
+	}
+	return checks, true
+}
+
+// hasRequestAuthentication returns true when a RequestAuthentication applies to any of the workloads of the policy.
+// It is also true when the workloads of the policy are unknown.
+func (rc RequestAuthnChecker) hasRequestAuthentication() bool {
+	ap := rc.AuthorizationPolicy
+	if ap.Spec.TargetRef != nil {
+		return rc.hasTargetRefRequestAuthentication()
+	}
+
+	var selector labels.Selector = labels.Everything()
+	if ap.Spec.Selector != nil && len(ap.Spec.Selector.MatchLabels) > 0 {
+		selector = labels.SelectorFromSet(ap.Spec.Selector.MatchLabels)
+	}
+
+	namespaces := []string{ap.Namespace}
+	if config.IsRootNamespace(ap.Namespace) {
+		namespaces = []string{}
+		for namespace := range rc.WorkloadsPerNamespace {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	found := false
+	for _, namespace := range namespaces {
+		workloads, ok := rc.WorkloadsPerNamespace[namespace]
+		if !ok {
+			return true
+		}
+		for _, wl := range workloads.Workloads {
+			if !selector.Matches(labels.Set(wl.Labels)) {
+				continue
+			}
+			found = true
+			if len(kubernetes.FilterWorkloadRequestAuthentications(rc.RequestAuthentications, namespace, wl.Labels)) > 0 {
+				return true
+			}
+		}
+	}
+
+	// Policies without workloads are already reported
+	return !found
+}
+
+// hasTargetRefRequestAuthentication returns true when a RequestAuthentication of the policy namespace or of the
+// root namespace targets the same object (e.g. a waypoint) than the policy.
+func (rc RequestAuthnChecker) hasTargetRefRequestAuthentication() bool {
+	ap := rc.AuthorizationPolicy
+	for _, ra := range rc.RequestAuthentications {
+		if ra.Namespace != ap.Namespace && !config.IsRootNamespace(ra.Namespace) {
+			continue
+		}
+		target := ra.Spec.TargetRef
+		if target != nil && target.Kind == ap.Spec.TargetRef.Kind && target.Group == ap.Spec.TargetRef.Group && target.Name == ap.Spec.TargetRef.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestAuthPaths returns the paths of the policy fields relying on the request authentication:
+// the request principals of the sources and the request.auth conditions.
+func RequestAuthPaths(ap *security_v1beta.AuthorizationPolicy) []string {
+	paths := []string{}
+
+	for ruleIdx, rule := range ap.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+
+		for fromIdx, from := range rule.From {
+			if from == nil || from.Source == nil {
+				continue
+			}
+			if len(from.Source.RequestPrincipals) > 0 {
+				paths = append(paths, fmt.Sprintf("spec/rules[%d]/from[%d]/source/requestPrincipals", ruleIdx, fromIdx))
+			}
+			if len(from.Source.NotRequestPrincipals) > 0 {
+				paths = append(paths, fmt.Sprintf("spec/rules[%d]/from[%d]/source/notRequestPrincipals", ruleIdx, fromIdx))
+			}
+		}
+
+		for whenIdx, when := range rule.When {
+			if when != nil && strings.HasPrefix(when.Key, "request.auth.") {
+				paths = append(paths, fmt.Sprintf("spec/rules[%d]/when[%d]/key", ruleIdx, whenIdx))
+			}
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,143 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_security_v1beta "istio.io/api/security/v1beta1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestRequestAuthnFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals, valid := RequestAuthnChecker{
+		AuthorizationPolicy: authPolicyWithRequestAuth(),
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{
+			data.CreateRequestAuthentication("jwt", "bookinfo", data.CreateOneLabelSelector("details"),
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+		},
+		WorkloadsPerNamespace: requestAuthnWorkloads(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestRequestAuthnNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals, valid := RequestAuthnChecker{
+		AuthorizationPolicy: authPolicyWithRequestAuth(),
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{
+			data.CreateRequestAuthentication("jwt", "bookinfo", data.CreateOneLabelSelector("reviews"),
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+			data.CreateRequestAuthentication("jwt", "default", data.CreateOneLabelSelector("details"),
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+		},
+		WorkloadsPerNamespace: requestAuthnWorkloads(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.requestauth.notfound", vals[0]))
+	assert.Equal("spec/rules[0]/from[0]/source/requestPrincipals", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.requestauth.notfound", vals[1]))
+	assert.Equal("spec/rules[0]/when[1]/key", vals[1].Path)
+}
+
+func TestRequestAuthnFromRootNamespace(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals, valid := RequestAuthnChecker{
+		AuthorizationPolicy: authPolicyWithRequestAuth(),
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{
+			data.CreateRequestAuthentication("jwt", "istio-system", nil,
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+		},
+		WorkloadsPerNamespace: requestAuthnWorkloads(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestPolicyWithoutRequestAuth(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals, valid := RequestAuthnChecker{
+		AuthorizationPolicy:   authPolicyWithPrincipals([]string{"cluster.local/ns/bookinfo/sa/default"}),
+		WorkloadsPerNamespace: requestAuthnWorkloads(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func authPolicyWithRequestAuth() *security_v1beta.AuthorizationPolicy {
+	ap := data.CreateAuthorizationPolicy([]string{}, []string{}, []string{}, data.CreateOneLabelSelector("details"))
+	ap.Spec.Rules = []*api_security_v1beta.Rule{
+		{
+			From: []*api_security_v1beta.Rule_From{
+				{Source: &api_security_v1beta.Source{RequestPrincipals: []string{"issuer-foo/*"}}},
+			},
+			When: []*api_security_v1beta.Condition{
+				{Key: "request.headers[foo]", Values: []string{"bar"}},
+				{Key: "request.auth.claims[groups]", Values: []string{"admins"}},
+			},
+		},
+	}
+	return ap
+}
+
+func requestAuthnWorkloads() map[string]models.WorkloadList {
+	return map[string]models.WorkloadList{
+		"bookinfo": data.CreateWorkloadList("bookinfo",
+			data.CreateWorkloadListItem("details", data.CreateOneLabelSelector("details")),
+			data.CreateWorkloadListItem("reviews", data.CreateOneLabelSelector("reviews")),
+		),
+		"istio-system": data.CreateWorkloadList("istio-system"),
+	}
+}
+
+func TestRequestAuthnWithTargetRef(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ap := authPolicyWithRequestAuth()
+	ap.Spec.Selector = nil
+	ap.Spec.TargetRef = &api_v1beta1.PolicyTargetReference{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "waypoint"}
+
+	// A RequestAuthentication selecting the workloads does not apply to the waypoint
+	bySelector := data.CreateRequestAuthentication("jwt", "bookinfo", data.CreateOneLabelSelector("details"),
+		data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json"))
+	vals, valid := RequestAuthnChecker{
+		AuthorizationPolicy:    ap,
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{bySelector},
+		WorkloadsPerNamespace:  requestAuthnWorkloads(),
+	}.Check()
+	assert.True(valid)
+	assert.Len(vals, 2)
+
+	byTargetRef := data.CreateRequestAuthentication("jwt-waypoint", "bookinfo", nil,
+		data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json"))
+	byTargetRef.Spec.TargetRef = &api_v1beta1.PolicyTargetReference{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "waypoint"}
+	vals, valid = RequestAuthnChecker{
+		AuthorizationPolicy:    ap,
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{bySelector, byTargetRef},
+		WorkloadsPerNamespace:  requestAuthnWorkloads(),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+}
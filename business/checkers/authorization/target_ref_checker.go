@@ -0,0 +1,68 @@
+package authorization
+
+import (
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// TargetRefChecker validates the targetRef of ambient policies: it can't be combined with a selector and
+// it has to point to an existing K8s Gateway (e.g. a waypoint), Service or GatewayClass.
+type TargetRefChecker struct {
+	AuthorizationPolicy *security_v1beta.AuthorizationPolicy
+	K8sGateways         []*k8s_networking_v1.Gateway
+	RegistryServices    []*kubernetes.RegistryService
+}
+
+func (tc TargetRefChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+	targetRef := tc.AuthorizationPolicy.Spec.TargetRef
+	if targetRef == nil {
+		return checks, true
+	}
+
+	if tc.AuthorizationPolicy.Spec.Selector != nil {
+		validation := models.Build("authorizationpolicy.targetref.selector", "spec/targetRef")
+		checks = append(checks, &validation)
+	}
+
+	namespace := tc.AuthorizationPolicy.Namespace
+	if targetRef.Namespace != "" {
+		namespace = targetRef.Namespace
+	}
+
+	found := true
+	switch {
+	case targetRef.Kind == kubernetes.K8sActualGatewayType && targetRef.Group == kubernetes.K8sNetworkingGroupVersionV1.Group:
+		found = tc.hasK8sGateway(func(gw *k8s_networking_v1.Gateway) bool {
+			return gw.Name == targetRef.Name && gw.Namespace == namespace
+		})
+	case targetRef.Kind == kubernetes.K8sGatewayClassType && targetRef.Group == kubernetes.K8sNetworkingGroupVersionV1.Group:
+		found = tc.hasK8sGateway(func(gw *k8s_networking_v1.Gateway) bool {
+			return string(gw.Spec.GatewayClassName) == targetRef.Name
+		})
+	case targetRef.Kind == kubernetes.ServiceType && (targetRef.Group == "" || targetRef.Group == "core"):
+		// Services are only known when the registry is available
+		if len(tc.RegistryServices) > 0 {
+			found = kubernetes.HasMatchingRegistryService(namespace, kubernetes.GetHost(targetRef.Name, namespace, []string{namespace}).String(), tc.RegistryServices)
+		}
+	}
+
+	if !found {
+		validation := models.Build("authorizationpolicy.targetref.notfound", "spec/targetRef/name")
+		checks = append(checks, &validation)
+	}
+
+	return checks, len(checks) == 0
+}
+
+func (tc TargetRefChecker) hasK8sGateway(matches func(gw *k8s_networking_v1.Gateway) bool) bool {
+	for _, gw := range tc.K8sGateways {
+		if matches(gw) {
+			return true
+		}
+	}
+	return false
+}
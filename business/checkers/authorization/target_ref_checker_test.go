@@ -0,0 +1,91 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func targetRefGateways() []*k8s_networking_v1.Gateway {
+	waypoint := data.CreateEmptyK8sGateway("waypoint", "bookinfo")
+	waypoint.Spec.GatewayClassName = "istio-waypoint"
+	return []*k8s_networking_v1.Gateway{waypoint}
+}
+
+func TestTargetRefFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	for _, ap := range []struct{ kind, group, name string }{
+		{"Gateway", "gateway.networking.k8s.io", "waypoint"},
+		{"GatewayClass", "gateway.networking.k8s.io", "istio-waypoint"},
+		{"Service", "", "reviews"},
+	} {
+		vals, valid := TargetRefChecker{
+			AuthorizationPolicy: data.CreateAuthorizationPolicyWithTargetRef("policy", "bookinfo", ap.group, ap.kind, ap.name),
+			K8sGateways:         targetRefGateways(),
+			RegistryServices:    data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+		}.Check()
+
+		assert.True(valid, ap.kind)
+		assert.Empty(vals, ap.kind)
+	}
+}
+
+func TestTargetRefNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	for _, ap := range []struct{ kind, group, name string }{
+		{"Gateway", "gateway.networking.k8s.io", "ingress"},
+		{"GatewayClass", "gateway.networking.k8s.io", "istio"},
+		{"Service", "", "ratings"},
+	} {
+		vals, valid := TargetRefChecker{
+			AuthorizationPolicy: data.CreateAuthorizationPolicyWithTargetRef("policy", "bookinfo", ap.group, ap.kind, ap.name),
+			K8sGateways:         targetRefGateways(),
+			RegistryServices:    data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+		}.Check()
+
+		assert.False(valid, ap.kind)
+		assert.Len(vals, 1, ap.kind)
+		assert.Equal(models.WarningSeverity, vals[0].Severity)
+		assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.targetref.notfound", vals[0]))
+		assert.Equal("spec/targetRef/name", vals[0].Path)
+	}
+}
+
+func TestTargetRefWithSelector(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ap := data.CreateAuthorizationPolicyWithTargetRef("policy", "bookinfo", kubernetes.K8sNetworkingGroupVersionV1.Group, kubernetes.K8sActualGatewayType, "waypoint")
+	ap.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "reviews"}}
+
+	vals, valid := TargetRefChecker{AuthorizationPolicy: ap, K8sGateways: targetRefGateways()}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.targetref.selector", vals[0]))
+}
+
+func TestTargetRefWithoutRegistry(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals, valid := TargetRefChecker{
+		AuthorizationPolicy: data.CreateAuthorizationPolicyWithTargetRef("policy", "bookinfo", "", "Service", "ratings"),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
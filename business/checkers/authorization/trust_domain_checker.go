@@ -0,0 +1,77 @@
+package authorization
+
+import (
+	"fmt"
+
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// TrustDomainChecker validates that the principals of an AuthorizationPolicy belong to a trust domain of the mesh.
+type TrustDomainChecker struct {
+	AuthorizationPolicy *security_v1beta.AuthorizationPolicy
+	TrustDomains        models.MeshTrustDomains
+}
+
+// Principal is a principal referenced by an AuthorizationPolicy along with its path in the policy.
+type Principal struct {
+	Path  string
+	Value string
+}
+
+// Check warns about the principals of trust domains the mesh does not know. Federated meshes legitimately
+// reference foreign trust domains, so the policy is never marked invalid.
+func (tc TrustDomainChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+
+	for _, principal := range PolicyPrincipals(tc.AuthorizationPolicy) {
+		switch _, status := tc.TrustDomains.PrincipalTrustDomain(principal.Value); status {
+		case models.TrustDomainUnknown:
+			validation := models.Build("authorizationpolicy.source.unknowntrustdomain", principal.Path)
+			checks = append(checks, &validation)
+		case models.TrustDomainStale:
+			validation := models.Build("authorizationpolicy.source.staletrustdomain", principal.Path)
+			checks = append(checks, &validation)
+		}
+	}
+
+	return checks, true
+}
+
+// PolicyPrincipals returns the principals referenced by the sources and the source.principal conditions of the policy.
+func PolicyPrincipals(ap *security_v1beta.AuthorizationPolicy) []Principal {
+	principals := []Principal{}
+
+	for ruleIdx, rule := range ap.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+
+		for fromIdx, from := range rule.From {
+			if from == nil || from.Source == nil {
+				continue
+			}
+			for i, p := range from.Source.Principals {
+				principals = append(principals, Principal{Path: fmt.Sprintf("spec/rules[%d]/from[%d]/source/principals[%d]", ruleIdx, fromIdx, i), Value: p})
+			}
+			for i, p := range from.Source.NotPrincipals {
+				principals = append(principals, Principal{Path: fmt.Sprintf("spec/rules[%d]/from[%d]/source/notPrincipals[%d]", ruleIdx, fromIdx, i), Value: p})
+			}
+		}
+
+		for whenIdx, when := range rule.When {
+			if when == nil || when.Key != "source.principal" {
+				continue
+			}
+			for i, p := range when.Values {
+				principals = append(principals, Principal{Path: fmt.Sprintf("spec/rules[%d]/when[%d]/values[%d]", ruleIdx, whenIdx, i), Value: p})
+			}
+			for i, p := range when.NotValues {
+				principals = append(principals, Principal{Path: fmt.Sprintf("spec/rules[%d]/when[%d]/notValues[%d]", ruleIdx, whenIdx, i), Value: p})
+			}
+		}
+	}
+
+	return principals
+}
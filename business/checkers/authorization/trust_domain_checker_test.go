@@ -0,0 +1,62 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_security_v1beta "istio.io/api/security/v1beta1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestPrincipalsInMeshTrustDomain(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := TrustDomainChecker{
+		AuthorizationPolicy: authPolicyWithPrincipals([]string{"cluster.local/ns/bookinfo/sa/default", "spiffe://cluster.local/ns/bookinfo/sa/test", "*", "*/ns/bookinfo/sa/test"}),
+		TrustDomains:        models.MeshTrustDomains{TrustDomains: []string{"cluster.local"}},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestPrincipalsInStaleTrustDomain(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := TrustDomainChecker{
+		AuthorizationPolicy: authPolicyWithPrincipals([]string{"new.domain/ns/bookinfo/sa/default", "cluster.local/ns/bookinfo/sa/test"}),
+		TrustDomains:        models.MeshTrustDomains{TrustDomains: []string{"new.domain"}, Aliases: []string{"cluster.local"}},
+	}.Check()
+
+	// Stale trust domains still work thanks to the alias
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.source.staletrustdomain", vals[0]))
+	assert.Equal("spec/rules[0]/from[0]/source/principals[1]", vals[0].Path)
+}
+
+func TestPrincipalsInUnknownTrustDomain(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := authPolicyWithPrincipals([]string{"old.domain/ns/bookinfo/sa/default"})
+	policy.Spec.Rules[0].When = []*api_security_v1beta.Condition{
+		{Key: "request.headers[foo]", Values: []string{"old.domain/ns/bookinfo/sa/default"}},
+		{Key: "source.principal", NotValues: []string{"cluster.local/ns/bookinfo/sa/test", "old.domain/ns/bookinfo/sa/test"}},
+	}
+
+	vals, valid := TrustDomainChecker{
+		AuthorizationPolicy: policy,
+		TrustDomains:        models.MeshTrustDomains{TrustDomains: []string{"cluster.local"}},
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.source.unknowntrustdomain", vals[0]))
+	assert.Equal("spec/rules[0]/from[0]/source/principals[0]", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.source.unknowntrustdomain", vals[1]))
+	assert.Equal("spec/rules[0]/when[1]/notValues[1]", vals[1].Path)
+}
@@ -3,6 +3,7 @@ package checkers
 import (
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kiali/kiali/business/checkers/authorization"
 	"github.com/kiali/kiali/business/checkers/common"
@@ -13,16 +14,20 @@ import (
 const AuthorizationPolicyCheckerType = "authorizationpolicy"
 
 type AuthorizationPolicyChecker struct {
-	Cluster               string
-	MtlsDetails           kubernetes.MTLSDetails
-	Namespaces            models.Namespaces
-	PolicyAllowAny        bool
-	RegistryServices      []*kubernetes.RegistryService
-	ServiceAccounts       map[string][]string
-	ServiceEntries        []*networking_v1beta1.ServiceEntry
-	AuthorizationPolicies []*security_v1beta.AuthorizationPolicy
-	VirtualServices       []*networking_v1beta1.VirtualService
-	WorkloadsPerNamespace map[string]models.WorkloadList
+	Cluster                string
+	K8sGateways            []*k8s_networking_v1.Gateway
+	MtlsDetails            kubernetes.MTLSDetails
+	Namespaces             models.Namespaces
+	PolicyAllowAny         bool
+	RegistryServices       []*kubernetes.RegistryService
+	RequestAuthentications []*security_v1beta.RequestAuthentication
+	ServiceAccounts        map[string][]string
+	ServiceEntries         []*networking_v1beta1.ServiceEntry
+	TenantScoped           bool
+	TrustDomains           models.MeshTrustDomains
+	AuthorizationPolicies  []*security_v1beta.AuthorizationPolicy
+	VirtualServices        []*networking_v1beta1.VirtualService
+	WorkloadsPerNamespace  map[string]models.WorkloadList
 }
 
 func (a AuthorizationPolicyChecker) Check() models.IstioValidations {
@@ -54,10 +59,17 @@ func (a AuthorizationPolicyChecker) runChecks(authPolicy *security_v1beta.Author
 	}
 	enabledCheckers := []Checker{
 		common.SelectorNoWorkloadFoundChecker(AuthorizationPolicyCheckerType, matchLabels, a.WorkloadsPerNamespace),
-		authorization.NamespaceMethodChecker{AuthorizationPolicy: authPolicy, Namespaces: a.Namespaces.GetNames()},
+		authorization.NamespaceMethodChecker{AuthorizationPolicy: authPolicy, Namespaces: a.Namespaces.GetNames(), TenantScoped: a.TenantScoped},
 		authorization.NoHostChecker{AuthorizationPolicy: authPolicy, Namespaces: a.Namespaces,
 			ServiceEntries: serviceHosts, VirtualServices: a.VirtualServices, RegistryServices: a.RegistryServices, PolicyAllowAny: a.PolicyAllowAny},
 		authorization.PrincipalsChecker{Cluster: a.Cluster, AuthorizationPolicy: authPolicy, ServiceAccounts: a.ServiceAccounts},
+		authorization.RequestAuthnChecker{AuthorizationPolicy: authPolicy, RequestAuthentications: a.RequestAuthentications, WorkloadsPerNamespace: a.WorkloadsPerNamespace},
+		authorization.TargetRefChecker{AuthorizationPolicy: authPolicy, K8sGateways: a.K8sGateways, RegistryServices: a.RegistryServices},
+	}
+
+	// Trust domains are only known once the mesh has been discovered.
+	if len(a.TrustDomains.TrustDomains) > 0 {
+		enabledCheckers = append(enabledCheckers, authorization.TrustDomainChecker{AuthorizationPolicy: authPolicy, TrustDomains: a.TrustDomains})
 	}
 
 	for _, checker := range enabledCheckers {
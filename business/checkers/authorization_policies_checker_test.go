@@ -0,0 +1,52 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestAuthorizationPolicyTrustDomains(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	policy := data.CreateAuthorizationPolicyWithPrincipals("auth-policy", "bookinfo", []string{"remote.domain/ns/bookinfo/sa/default"})
+	key := models.IstioValidationKey{ObjectType: AuthorizationPolicyCheckerType, Name: "auth-policy", Namespace: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName}
+	check := func(trustDomains models.MeshTrustDomains) *models.IstioValidation {
+		vals := AuthorizationPolicyChecker{
+			AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{policy},
+			Cluster:               conf.KubernetesConfig.ClusterName,
+			Namespaces:            models.Namespaces{{Name: "bookinfo"}},
+			ServiceAccounts:       map[string][]string{},
+			TrustDomains:          trustDomains,
+		}.Check()
+		require.Contains(vals, key)
+		return vals[key]
+	}
+
+	unknownTrustDomain := func(validation *models.IstioValidation) *models.IstioCheck {
+		for _, c := range validation.Checks {
+			if c.Code == models.Build("authorizationpolicy.source.unknowntrustdomain", "").Code {
+				return c
+			}
+		}
+		return nil
+	}
+
+	// A foreign trust domain is only a warning, federated meshes reference them
+	unknown := unknownTrustDomain(check(models.MeshTrustDomains{TrustDomains: []string{"cluster.local"}}))
+	require.NotNil(unknown)
+	assert.Equal(models.WarningSeverity, unknown.Severity)
+
+	// The trust domain validation is skipped when the mesh could not be discovered
+	assert.Nil(unknownTrustDomain(check(models.MeshTrustDomains{})))
+}
@@ -9,12 +9,14 @@ import (
 type ExportToNamespaceChecker struct {
 	ExportTo   []string
 	Namespaces models.Namespaces
+	// TenantScoped namespaces hide the namespaces of the other tenants, which are then not reported as not found
+	TenantScoped bool
 }
 
 func (p ExportToNamespaceChecker) Check() ([]*models.IstioCheck, bool) {
 	validations := make([]*models.IstioCheck, 0)
 
-	if len(p.ExportTo) > 0 {
+	if len(p.ExportTo) > 0 && !p.TenantScoped {
 		for nsIndex, namespace := range p.ExportTo {
 			if namespace != "." && namespace != "*" && !p.Namespaces.Includes(namespace) {
 				validation := models.Build("generic.exportto.namespacenotfound",
@@ -51,6 +51,19 @@ func TestSEAllNamespaces(t *testing.T) {
 	assertIstioObjectValid("se_exportto_all_valid.yaml", "ServiceEntry", t)
 }
 
+func TestExportToNamespaceOfOtherTenant(t *testing.T) {
+	assert := assert.New(t)
+
+	// The namespaces of the other tenants are hidden, they are not reported as not found
+	validations, valid := ExportToNamespaceChecker{
+		ExportTo:     []string{"bookinfo", "tenant-b"},
+		Namespaces:   models.Namespaces{models.Namespace{Name: "bookinfo"}},
+		TenantScoped: true,
+	}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
 func assertIstioObjectValid(scenario string, objectType string, t *testing.T) {
 	assert := assert.New(t)
 
@@ -16,6 +16,7 @@ type DestinationRulesChecker struct {
 	MTLSDetails      kubernetes.MTLSDetails
 	ServiceEntries   []*networking_v1beta1.ServiceEntry
 	Namespaces       models.Namespaces
+	TenantScoped     bool
 	Cluster          string
 }
 
@@ -63,9 +64,10 @@ func (in DestinationRulesChecker) runChecks(destinationRule *networking_v1beta1.
 	enabledCheckers := []Checker{
 		destinationrules.DisabledNamespaceWideMTLSChecker{DestinationRule: destinationRule, MTLSDetails: in.MTLSDetails},
 		destinationrules.DisabledMeshWideMTLSChecker{DestinationRule: destinationRule, MeshPeerAuthns: in.MTLSDetails.MeshPeerAuthentications},
+		destinationrules.LocalityLbChecker{DestinationRule: destinationRule},
 	}
 	if !in.Namespaces.IsNamespaceAmbient(destinationRule.Namespace, in.Cluster) {
-		enabledCheckers = append(enabledCheckers, common.ExportToNamespaceChecker{ExportTo: destinationRule.Spec.ExportTo, Namespaces: in.Namespaces})
+		enabledCheckers = append(enabledCheckers, common.ExportToNamespaceChecker{ExportTo: destinationRule.Spec.ExportTo, Namespaces: in.Namespaces, TenantScoped: in.TenantScoped})
 	}
 
 	enabledCheckers = append(enabledCheckers, destinationrules.NamespaceWideMTLSChecker{DestinationRule: destinationRule, MTLSDetails: in.MTLSDetails})
@@ -0,0 +1,52 @@
+package destinationrules
+
+import (
+	"fmt"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// LocalityLbChecker verifies that the traffic policies with a locality failover have an outlier detection: the
+// unhealthy endpoints are only ejected, and the requests failed over, with it. A subset inherits the outlier
+// detection of the DestinationRule.
+type LocalityLbChecker struct {
+	DestinationRule *networking_v1beta1.DestinationRule
+}
+
+func (l LocalityLbChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	trafficPolicy := l.DestinationRule.Spec.TrafficPolicy
+	hasOutlierDetection := trafficPolicy != nil && trafficPolicy.OutlierDetection != nil
+	if trafficPolicy != nil && hasLocalityFailover(trafficPolicy) && !hasOutlierDetection {
+		validation := models.Build("destinationrules.localitylb.nooutlierdetection", "spec/trafficPolicy/loadBalancer/localityLbSetting")
+		validations = append(validations, &validation)
+	}
+
+	for i, subset := range l.DestinationRule.Spec.Subsets {
+		if subset == nil || subset.TrafficPolicy == nil {
+			continue
+		}
+		if hasLocalityFailover(subset.TrafficPolicy) && subset.TrafficPolicy.OutlierDetection == nil && !hasOutlierDetection {
+			validation := models.Build("destinationrules.localitylb.nooutlierdetection", fmt.Sprintf("spec/subsets[%d]/trafficPolicy/loadBalancer/localityLbSetting", i))
+			validations = append(validations, &validation)
+		}
+	}
+
+	return validations, len(validations) == 0
+}
+
+// hasLocalityFailover returns true when the load balancer of the traffic policy fails over to other localities
+func hasLocalityFailover(trafficPolicy *api_networking_v1beta1.TrafficPolicy) bool {
+	if trafficPolicy.LoadBalancer == nil || trafficPolicy.LoadBalancer.LocalityLbSetting == nil {
+		return false
+	}
+	setting := trafficPolicy.LoadBalancer.LocalityLbSetting
+	if setting.Enabled != nil && !setting.Enabled.Value {
+		return false
+	}
+	return len(setting.Failover) > 0 || len(setting.FailoverPriority) > 0
+}
@@ -0,0 +1,71 @@
+package destinationrules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func localityFailoverPolicy() *api_networking_v1beta1.TrafficPolicy {
+	return &api_networking_v1beta1.TrafficPolicy{
+		LoadBalancer: &api_networking_v1beta1.LoadBalancerSettings{
+			LocalityLbSetting: &api_networking_v1beta1.LocalityLoadBalancerSetting{
+				Failover: []*api_networking_v1beta1.LocalityLoadBalancerSetting_Failover{{From: "us-east", To: "us-west"}},
+			},
+		},
+	}
+}
+
+func TestLocalityFailoverWithoutOutlierDetection(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	dr := data.AddTrafficPolicyToDestinationRule(localityFailoverPolicy(), data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"))
+	subset := data.CreateSubset("v1", "v1")
+	subset.TrafficPolicy = localityFailoverPolicy()
+	dr = data.AddSubsetToDestinationRule(subset, dr)
+
+	vals, valid := LocalityLbChecker{DestinationRule: dr}.Check()
+
+	tb := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	tb.AssertValidationsPresent(2, false)
+	tb.AssertValidationAt(0, models.WarningSeverity, "spec/trafficPolicy/loadBalancer/localityLbSetting", "destinationrules.localitylb.nooutlierdetection")
+	tb.AssertValidationAt(1, models.WarningSeverity, "spec/subsets[0]/trafficPolicy/loadBalancer/localityLbSetting", "destinationrules.localitylb.nooutlierdetection")
+}
+
+func TestLocalityFailoverWithOutlierDetection(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	// the subset inherits the outlier detection of the DestinationRule
+	trafficPolicy := localityFailoverPolicy()
+	trafficPolicy.OutlierDetection = &api_networking_v1beta1.OutlierDetection{}
+	dr := data.AddTrafficPolicyToDestinationRule(trafficPolicy, data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"))
+	subset := data.CreateSubset("v1", "v1")
+	subset.TrafficPolicy = localityFailoverPolicy()
+	dr = data.AddSubsetToDestinationRule(subset, dr)
+
+	vals, valid := LocalityLbChecker{DestinationRule: dr}.Check()
+	assert.True(t, valid)
+	assert.Empty(t, vals)
+
+	// a distribution without failover does not need it
+	dr = data.AddTrafficPolicyToDestinationRule(&api_networking_v1beta1.TrafficPolicy{
+		LoadBalancer: &api_networking_v1beta1.LoadBalancerSettings{
+			LocalityLbSetting: &api_networking_v1beta1.LocalityLoadBalancerSetting{
+				Distribute: []*api_networking_v1beta1.LocalityLoadBalancerSetting_Distribute{
+					{From: "us-east/*", To: map[string]uint32{"us-east/*": 80, "us-west/*": 20}},
+				},
+			},
+		},
+	}, data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"))
+	vals, valid = LocalityLbChecker{DestinationRule: dr}.Check()
+	assert.True(t, valid)
+	assert.Empty(t, vals)
+}
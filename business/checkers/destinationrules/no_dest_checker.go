@@ -81,11 +81,13 @@ func (n NoDestinationChecker) hasMatchingWorkload(host kubernetes.Host, subsetLa
 	localSvc, localNs := kubernetes.ParseTwoPartHost(host)
 
 	var selectors map[string]string
+	var workloadEntryLabels []map[string]string
 
 	// Find the correct service
 	for _, s := range n.RegistryServices {
 		if s.Attributes.Name == localSvc && s.Attributes.Namespace == localNs {
 			selectors = s.Attributes.LabelSelectors
+			workloadEntryLabels = s.WorkloadEntryLabels
 			break
 		}
 	}
@@ -93,6 +95,13 @@ func (n NoDestinationChecker) hasMatchingWorkload(host kubernetes.Host, subsetLa
 	subsetLabelSet := labels.Set(subsetLabels)
 	subsetSelector := labels.SelectorFromSet(subsetLabelSet)
 
+	// Check the WorkloadEntries selected by the service
+	for _, weLabels := range workloadEntryLabels {
+		if subsetSelector.Matches(labels.Set(weLabels)) {
+			return true
+		}
+	}
+
 	// Check workloads
 	if len(selectors) != 0 {
 		selector := labels.SelectorFromSet(labels.Set(selectors))
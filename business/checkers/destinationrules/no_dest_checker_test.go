@@ -251,6 +251,28 @@ func TestNoMatchingSubset(t *testing.T) {
 	assert.Equal("spec/subsets[0]", vals[0].Path)
 }
 
+func TestSubsetMatchingWorkloadEntry(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	// reviews v2 only runs on a WorkloadEntry selected by the service
+	registryServices := data.CreateFakeRegistryServicesLabels("reviews", "test-namespace")
+	registryServices[0].WorkloadEntryLabels = []map[string]string{appVersionLabel("reviews", "v2")}
+	vals, valid := NoDestinationChecker{
+		WorkloadsPerNamespace: map[string]models.WorkloadList{
+			"test-namespace": data.CreateWorkloadList("test-namespace",
+				data.CreateWorkloadListItem("reviews", appVersionLabel("reviews", "v1"))),
+		},
+		RegistryServices: registryServices,
+		DestinationRule:  data.CreateTestDestinationRule("test-namespace", "name", "reviews"),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
 func TestNoMatchingSubsetWithMoreLabels(t *testing.T) {
 	conf := config.NewConfig()
 	config.Set(conf)
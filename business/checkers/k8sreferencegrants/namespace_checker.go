@@ -11,12 +11,14 @@ import (
 type NamespaceChecker struct {
 	Namespaces     models.Namespaces
 	ReferenceGrant k8s_networking_v1beta1.ReferenceGrant
+	// TenantScoped namespaces hide the namespaces of the other tenants, which are then not reported as not found
+	TenantScoped bool
 }
 
 func (in NamespaceChecker) Check() ([]*models.IstioCheck, bool) {
 	validations := make([]*models.IstioCheck, 0)
 
-	if len(in.ReferenceGrant.Spec.From) > 0 {
+	if len(in.ReferenceGrant.Spec.From) > 0 && !in.TenantScoped {
 		for nsIndex, from := range in.ReferenceGrant.Spec.From {
 			if !in.Namespaces.Includes(string(from.Namespace)) {
 				validation := models.Build("k8sreferencegrants.from.namespacenotfound",
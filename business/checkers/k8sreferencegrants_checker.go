@@ -13,6 +13,7 @@ type K8sReferenceGrantChecker struct {
 	Cluster            string
 	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
 	Namespaces         models.Namespaces
+	TenantScoped       bool
 }
 
 // Check runs checks for the all namespaces actions as well as for the single namespace validations
@@ -42,6 +43,7 @@ func (in K8sReferenceGrantChecker) runChecks(rg *k8s_networking_v1beta1.Referenc
 		k8sreferencegrants.NamespaceChecker{
 			Namespaces:     in.Namespaces,
 			ReferenceGrant: *rg,
+			TenantScoped:   in.TenantScoped,
 		},
 	}
 
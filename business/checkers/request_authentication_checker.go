@@ -4,12 +4,15 @@ import (
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 
 	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/business/checkers/requestauthentications"
 	"github.com/kiali/kiali/models"
 )
 
 const RequestAuthenticationCheckerType = "requestauthentication"
 
 type RequestAuthenticationChecker struct {
+	// JwksProbe checks that a jwksUri can be reached. The jwksUris are not probed when nil.
+	JwksProbe              func(uri string) error
 	RequestAuthentications []*security_v1beta.RequestAuthentication
 	WorkloadsPerNamespace  map[string]models.WorkloadList
 	Cluster                string
@@ -19,6 +22,7 @@ func (m RequestAuthenticationChecker) Check() models.IstioValidations {
 	validations := models.IstioValidations{}
 
 	validations.MergeValidations(common.RequestAuthenticationMultiMatchChecker(m.Cluster, RequestAuthenticationCheckerType, m.RequestAuthentications, m.WorkloadsPerNamespace).Check())
+	validations.MergeValidations(requestauthentications.DuplicatedIssuerChecker{Cluster: m.Cluster, ObjectType: RequestAuthenticationCheckerType, RequestAuthentications: m.RequestAuthentications, WorkloadsPerNamespace: m.WorkloadsPerNamespace}.Check())
 
 	for _, peerAuthn := range m.RequestAuthentications {
 		validations.MergeValidations(m.runChecks(peerAuthn))
@@ -37,6 +41,7 @@ func (m RequestAuthenticationChecker) runChecks(requestAuthn *security_v1beta.Re
 	}
 	enabledCheckers := []Checker{
 		common.SelectorNoWorkloadFoundChecker(RequestAuthenticationCheckerType, matchLabels, m.WorkloadsPerNamespace),
+		requestauthentications.JwksUriChecker{RequestAuthentication: requestAuthn, Probe: m.JwksProbe},
 	}
 
 	for _, checker := range enabledCheckers {
@@ -0,0 +1,77 @@
+package requestauthentications
+
+import (
+	"fmt"
+
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// DuplicatedIssuerChecker validates that the JWT rules applying to a same workload don't share an issuer,
+// as Istio combines all the RequestAuthentications of a workload and only one of the rules would be used.
+type DuplicatedIssuerChecker struct {
+	Cluster                string
+	ObjectType             string
+	RequestAuthentications []*security_v1beta.RequestAuthentication
+	WorkloadsPerNamespace  map[string]models.WorkloadList
+}
+
+type jwtRule struct {
+	requestAuthn *security_v1beta.RequestAuthentication
+	index        int
+}
+
+func (d DuplicatedIssuerChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for namespace, workloads := range d.WorkloadsPerNamespace {
+		for _, wl := range workloads.Workloads {
+			issuers := map[string][]jwtRule{}
+			for _, ra := range kubernetes.FilterWorkloadRequestAuthentications(d.RequestAuthentications, namespace, wl.Labels) {
+				for i, rule := range ra.Spec.JwtRules {
+					if rule == nil || rule.Issuer == "" {
+						continue
+					}
+					issuers[rule.Issuer] = append(issuers[rule.Issuer], jwtRule{requestAuthn: ra, index: i})
+				}
+			}
+
+			for _, rules := range issuers {
+				if len(rules) < 2 {
+					continue
+				}
+				for _, rule := range rules {
+					key := d.key(rule.requestAuthn)
+					references := []models.IstioValidationKey{}
+					for _, other := range rules {
+						if otherKey := d.key(other.requestAuthn); otherKey != key {
+							references = append(references, otherKey)
+						}
+					}
+					validations.MergeValidations(createError(key, rule.index, references))
+				}
+			}
+		}
+	}
+
+	return validations
+}
+
+func (d DuplicatedIssuerChecker) key(ra *security_v1beta.RequestAuthentication) models.IstioValidationKey {
+	return models.IstioValidationKey{ObjectType: d.ObjectType, Name: ra.Name, Namespace: ra.Namespace, Cluster: d.Cluster}
+}
+
+func createError(key models.IstioValidationKey, ruleIndex int, references []models.IstioValidationKey) models.IstioValidations {
+	check := models.Build("requestauthentications.jwtrules.duplicatedissuer", fmt.Sprintf("spec/jwtRules[%d]/issuer", ruleIndex))
+	return models.IstioValidations{key: &models.IstioValidation{
+		Cluster:    key.Cluster,
+		Name:       key.Name,
+		Namespace:  key.Namespace,
+		ObjectType: key.ObjectType,
+		Valid:      check.Severity != models.ErrorSeverity,
+		Checks:     []*models.IstioCheck{&check},
+		References: references,
+	}}
+}
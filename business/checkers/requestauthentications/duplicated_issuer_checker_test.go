@@ -0,0 +1,78 @@
+package requestauthentications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestDuplicatedIssuerOnSameWorkload(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := DuplicatedIssuerChecker{
+		Cluster:    config.DefaultClusterID,
+		ObjectType: "requestauthentication",
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{
+			data.CreateRequestAuthentication("namespace-wide", "bookinfo", nil,
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+			data.CreateRequestAuthentication("reviews", "bookinfo", data.CreateOneLabelSelector("reviews"),
+				data.CreateJWTRule("issuer-bar", "https://example.com/jwks.json"),
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+		},
+		WorkloadsPerNamespace: workloadsPerNamespace(),
+	}.Check()
+
+	assert.Len(vals, 2)
+
+	nsWide := vals[models.IstioValidationKey{ObjectType: "requestauthentication", Name: "namespace-wide", Namespace: "bookinfo", Cluster: config.DefaultClusterID}]
+	assert.NotNil(nsWide)
+	assert.True(nsWide.Valid)
+	assert.Len(nsWide.Checks, 1)
+	assert.Equal(models.WarningSeverity, nsWide.Checks[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("requestauthentications.jwtrules.duplicatedissuer", nsWide.Checks[0]))
+	assert.Equal("spec/jwtRules[0]/issuer", nsWide.Checks[0].Path)
+	assert.Len(nsWide.References, 1)
+	assert.Equal("reviews", nsWide.References[0].Name)
+
+	reviews := vals[models.IstioValidationKey{ObjectType: "requestauthentication", Name: "reviews", Namespace: "bookinfo", Cluster: config.DefaultClusterID}]
+	assert.NotNil(reviews)
+	assert.Len(reviews.Checks, 1)
+	assert.Equal("spec/jwtRules[1]/issuer", reviews.Checks[0].Path)
+	assert.Len(reviews.References, 1)
+	assert.Equal("namespace-wide", reviews.References[0].Name)
+}
+
+func TestSameIssuerOnDifferentWorkloads(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := DuplicatedIssuerChecker{
+		Cluster:    config.DefaultClusterID,
+		ObjectType: "requestauthentication",
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{
+			data.CreateRequestAuthentication("details", "bookinfo", data.CreateOneLabelSelector("details"),
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+			data.CreateRequestAuthentication("reviews", "bookinfo", data.CreateOneLabelSelector("reviews"),
+				data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")),
+		},
+		WorkloadsPerNamespace: workloadsPerNamespace(),
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func workloadsPerNamespace() map[string]models.WorkloadList {
+	return map[string]models.WorkloadList{
+		"bookinfo": data.CreateWorkloadList("bookinfo",
+			data.CreateWorkloadListItem("details", data.CreateOneLabelSelector("details")),
+			data.CreateWorkloadListItem("reviews", data.CreateOneLabelSelector("reviews")),
+		),
+	}
+}
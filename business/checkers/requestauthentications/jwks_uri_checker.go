@@ -0,0 +1,42 @@
+package requestauthentications
+
+import (
+	"fmt"
+	"net/url"
+
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// JwksUriChecker validates the jwksUri of the JWT rules. When a Probe is given, it is also used to check that
+// the jwksUri can be reached.
+type JwksUriChecker struct {
+	RequestAuthentication *security_v1beta.RequestAuthentication
+	Probe                 func(uri string) error
+}
+
+func (j JwksUriChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	for i, rule := range j.RequestAuthentication.Spec.JwtRules {
+		if rule == nil || rule.JwksUri == "" {
+			continue
+		}
+
+		path := fmt.Sprintf("spec/jwtRules[%d]/jwksUri", i)
+		if uri, err := url.Parse(rule.JwksUri); err != nil || (uri.Scheme != "http" && uri.Scheme != "https") || uri.Host == "" {
+			validation := models.Build("requestauthentications.jwtrules.invalidjwksuri", path)
+			checks = append(checks, &validation)
+			valid = false
+			continue
+		}
+
+		if j.Probe != nil && j.Probe(rule.JwksUri) != nil {
+			validation := models.Build("requestauthentications.jwtrules.jwksuriunreachable", path)
+			checks = append(checks, &validation)
+		}
+	}
+
+	return checks, valid
+}
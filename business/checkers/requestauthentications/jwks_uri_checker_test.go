@@ -0,0 +1,66 @@
+package requestauthentications
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestValidJwksUri(t *testing.T) {
+	assert := assert.New(t)
+
+	probed := []string{}
+	vals, valid := JwksUriChecker{
+		RequestAuthentication: data.CreateRequestAuthentication("jwt", "bookinfo", nil,
+			data.CreateJWTRule("issuer-foo", "https://example.com/.well-known/jwks.json"),
+			data.CreateJWTRule("issuer-bar", "")),
+		Probe: func(uri string) error {
+			probed = append(probed, uri)
+			return nil
+		},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+	assert.Equal([]string{"https://example.com/.well-known/jwks.json"}, probed)
+}
+
+func TestInvalidJwksUri(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := JwksUriChecker{
+		RequestAuthentication: data.CreateRequestAuthentication("jwt", "bookinfo", nil,
+			data.CreateJWTRule("issuer-foo", "https://example.com/.well-known/jwks.json"),
+			data.CreateJWTRule("issuer-bar", "example.com/jwks.json")),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("requestauthentications.jwtrules.invalidjwksuri", vals[0]))
+	assert.Equal("spec/jwtRules[1]/jwksUri", vals[0].Path)
+}
+
+func TestUnreachableJwksUri(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := JwksUriChecker{
+		RequestAuthentication: data.CreateRequestAuthentication("jwt", "bookinfo", nil,
+			data.CreateJWTRule("issuer-foo", "https://example.com/.well-known/jwks.json")),
+		Probe: func(uri string) error {
+			return fmt.Errorf("connection refused")
+		},
+	}.Check()
+
+	// The jwksUri might only be reachable from the mesh
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.InfoSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("requestauthentications.jwtrules.jwksuriunreachable", vals[0]))
+	assert.Equal("spec/jwtRules[0]/jwksUri", vals[0].Path)
+}
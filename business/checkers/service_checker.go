@@ -1,21 +1,32 @@
 package checkers
 
 import (
+	osroutes_v1 "github.com/openshift/api/route/v1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/kiali/kiali/business/checkers/services"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 )
 
 const ServiceCheckerType = "service"
 
 type ServiceChecker struct {
-	Services    []v1.Service
-	Deployments []apps_v1.Deployment
-	Pods        []core_v1.Pod
-	Cluster     string
+	Services        []v1.Service
+	Deployments     []apps_v1.Deployment
+	Pods            []core_v1.Pod
+	Routes          []osroutes_v1.Route
+	Gateways        []*networking_v1beta1.Gateway
+	VirtualServices []*networking_v1beta1.VirtualService
+	// Network is the network of the cluster of the services
+	Network string
+	// RemoteNetworks are the networks of the remote clusters with the same services, by namespace/name
+	RemoteNetworks   map[string][]string
+	EastWestGateways []models.EastWestGateway
+	Cluster          string
 }
 
 func (sc ServiceChecker) Check() models.IstioValidations {
@@ -33,6 +44,14 @@ func (sc ServiceChecker) runSingleChecks(service v1.Service) models.IstioValidat
 
 	enabledCheckers := []Checker{
 		services.PortMappingChecker{Service: service, Deployments: sc.Deployments, Pods: sc.Pods},
+		services.HealthAnnotationChecker{Service: service},
+	}
+	if routes := kubernetes.FilterOpenShiftRoutesByService(sc.Routes, service.Namespace, service.Name); len(routes) > 0 {
+		enabledCheckers = append(enabledCheckers, services.OpenShiftRouteChecker{Service: service, Routes: routes, Gateways: sc.Gateways, VirtualServices: sc.VirtualServices})
+	}
+
+	if remoteNetworks := sc.RemoteNetworks[service.Namespace+"/"+service.Name]; len(remoteNetworks) > 0 {
+		enabledCheckers = append(enabledCheckers, services.EastWestGatewayChecker{Network: sc.Network, RemoteNetworks: remoteNetworks, EastWestGateways: sc.EastWestGateways})
 	}
 
 	for _, checker := range enabledCheckers {
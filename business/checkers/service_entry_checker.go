@@ -14,6 +14,7 @@ type ServiceEntryChecker struct {
 	ServiceEntries  []*networking_v1beta1.ServiceEntry
 	Namespaces      models.Namespaces
 	WorkloadEntries []*networking_v1beta1.WorkloadEntry
+	TenantScoped    bool
 	Cluster         string
 }
 
@@ -36,7 +37,7 @@ func (s ServiceEntryChecker) runSingleChecks(se *networking_v1beta1.ServiceEntry
 		serviceentries.HasMatchingWorkloadEntryAddress{ServiceEntry: se, WorkloadEntries: workloadEntriesMap},
 	}
 	if !s.Namespaces.IsNamespaceAmbient(se.Namespace, s.Cluster) {
-		enabledCheckers = append(enabledCheckers, common.ExportToNamespaceChecker{ExportTo: se.Spec.ExportTo, Namespaces: s.Namespaces})
+		enabledCheckers = append(enabledCheckers, common.ExportToNamespaceChecker{ExportTo: se.Spec.ExportTo, Namespaces: s.Namespaces, TenantScoped: s.TenantScoped})
 	}
 
 	for _, checker := range enabledCheckers {
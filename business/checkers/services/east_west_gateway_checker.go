@@ -0,0 +1,37 @@
+package services
+
+import (
+	"github.com/kiali/kiali/models"
+)
+
+// EastWestGatewayChecker reports a service imported from the clusters of a remote network when that network has no
+// east-west gateway reachable from the network of the service: the requests to the remote endpoints fail.
+type EastWestGatewayChecker struct {
+	Network          string
+	RemoteNetworks   []string
+	EastWestGateways []models.EastWestGateway
+}
+
+func (e EastWestGatewayChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for _, network := range e.RemoteNetworks {
+		if network == e.Network || e.hasReachableGateway(network) {
+			continue
+		}
+		validation := models.Build("service.eastwestgateway.notfound", "metadata/name")
+		validations = append(validations, &validation)
+		break
+	}
+
+	return validations, true
+}
+
+func (e EastWestGatewayChecker) hasReachableGateway(network string) bool {
+	for _, gw := range e.EastWestGateways {
+		if gw.Network == network && gw.IsReachable() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestEastWestGatewayOfRemoteNetwork(t *testing.T) {
+	assert := assert.New(t)
+
+	reachable := models.EastWestGateway{Cluster: "west", Network: "network-west", Addresses: []string{"10.0.0.1"}}
+	vals, valid := EastWestGatewayChecker{Network: "network-east", RemoteNetworks: []string{"network-west"}, EastWestGateways: []models.EastWestGateway{reachable}}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	// Same network, no gateway needed
+	vals, _ = EastWestGatewayChecker{Network: "network-east", RemoteNetworks: []string{"network-east"}}.Check()
+	assert.Empty(vals)
+}
+
+func TestEastWestGatewayNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	// No gateway on the remote network
+	vals, valid := EastWestGatewayChecker{Network: "network-east", RemoteNetworks: []string{"network-west"}}.Check()
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("metadata/name", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("service.eastwestgateway.notfound", vals[0]))
+
+	// The gateway of the remote network has no external address
+	unreachable := models.EastWestGateway{Cluster: "west", Network: "network-west"}
+	vals, _ = EastWestGatewayChecker{Network: "network-east", RemoteNetworks: []string{"network-west"}, EastWestGateways: []models.EastWestGateway{unreachable}}.Check()
+	assert.Len(vals, 1)
+
+	// The gateway exposes another network
+	other := models.EastWestGateway{Cluster: "central", Network: "network-central", Addresses: []string{"10.0.0.2"}}
+	vals, _ = EastWestGatewayChecker{Network: "network-east", RemoteNetworks: []string{"network-west"}, EastWestGateways: []models.EastWestGateway{other}}.Check()
+	assert.Len(vals, 1)
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// HealthAnnotationChecker reports the malformed rules of the health annotations of a service, which are ignored, and
+// the rules of a port the service does not expose.
+type HealthAnnotationChecker struct {
+	Service v1.Service
+}
+
+func (h HealthAnnotationChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	rules, errors := models.ParseHealthRules(h.Service.Annotations)
+	reported := map[models.AnnotationKey]bool{}
+	for _, err := range errors {
+		if reported[err.Annotation] {
+			continue
+		}
+		reported[err.Annotation] = true
+		validation := models.Build("service.health.annotation.invalid", fmt.Sprintf("metadata/annotations/%s", err.Annotation))
+		validations = append(validations, &validation)
+	}
+
+	ports := map[int32]bool{}
+	for _, sp := range h.Service.Spec.Ports {
+		ports[sp.Port] = true
+	}
+	for _, rule := range rules {
+		if rule.Port != 0 && !ports[rule.Port] {
+			validation := models.Build("service.health.annotation.port.notfound", fmt.Sprintf("metadata/annotations/%s", models.RulesHealthAnnotation))
+			validations = append(validations, &validation)
+		}
+	}
+
+	return validations, true
+}
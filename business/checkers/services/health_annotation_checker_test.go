@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestHealthAnnotationValid(t *testing.T) {
+	assert := assert.New(t)
+
+	service := getService(9080, "http", nil, "test-namespace", "app")
+	service.Annotations = map[string]string{
+		string(models.RulesHealthAnnotation): `[{"port": 9080, "protocol": "http", "code": "4xx", "degraded": 10, "failure": 20}]`,
+	}
+
+	vals, valid := HealthAnnotationChecker{Service: service}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestHealthAnnotationMalformed(t *testing.T) {
+	assert := assert.New(t)
+
+	service := getService(9080, "http", nil, "test-namespace", "app")
+	service.Annotations = map[string]string{
+		string(models.RateHealthAnnotation): "5XX,a,20,http;4XX,30,20,http",
+	}
+
+	vals, valid := HealthAnnotationChecker{Service: service}.Check()
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("metadata/annotations/health.kiali.io/rate", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("service.health.annotation.invalid", vals[0]))
+}
+
+func TestHealthAnnotationPortNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	service := getService(9080, "http", nil, "test-namespace", "app")
+	service.Annotations = map[string]string{
+		string(models.RulesHealthAnnotation): `[{"port": 8080, "code": "5xx", "degraded": 10, "failure": 20}]`,
+	}
+
+	vals, valid := HealthAnnotationChecker{Service: service}.Check()
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal("metadata/annotations/health.kiali.io/rules", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("service.health.annotation.port.notfound", vals[0]))
+}
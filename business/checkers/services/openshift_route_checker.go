@@ -0,0 +1,179 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	osroutes_v1 "github.com/openshift/api/route/v1"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/models"
+)
+
+// OpenShiftRouteChecker reports the OpenShift Routes of a service targeting a port the service does not expose, and
+// the Routes whose TLS termination does not fit the Istio Gateway behind the service: the router sends plain text to
+// the service with the edge termination, and TLS with the passthrough and reencrypt terminations.
+type OpenShiftRouteChecker struct {
+	Service         v1.Service
+	Routes          []*osroutes_v1.Route
+	Gateways        []*networking_v1beta1.Gateway
+	VirtualServices []*networking_v1beta1.VirtualService
+}
+
+func (o OpenShiftRouteChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	gateways := o.serviceGateways()
+	for _, route := range o.Routes {
+		portIndexes := o.targetPortIndexes(route)
+		if len(portIndexes) == 0 {
+			validation := models.Build("service.route.port.notfound", "spec/ports")
+			validations = append(validations, &validation)
+			continue
+		}
+
+		for _, i := range portIndexes {
+			servers := gatewayServers(gateways, o.Service.Spec.Ports[i].Port)
+			if len(servers) == 0 {
+				continue
+			}
+			path := fmt.Sprintf("spec/ports[%d]", i)
+			compatible := compatibleServers(servers, route)
+			if len(compatible) == 0 {
+				validation := models.Build("service.route.tls.mismatch", path)
+				validations = append(validations, &validation)
+				continue
+			}
+			if isPassthrough(route) && !o.hasPassthroughVirtualService(compatible, route.Spec.Host) {
+				validation := models.Build("service.route.passthrough.virtualservice.notfound", path)
+				validations = append(validations, &validation)
+			}
+		}
+	}
+
+	return validations, true
+}
+
+// serviceGateways returns the Gateways configuring the workloads selected by the service
+func (o OpenShiftRouteChecker) serviceGateways() []*networking_v1beta1.Gateway {
+	gateways := []*networking_v1beta1.Gateway{}
+	if len(o.Service.Spec.Selector) == 0 {
+		return gateways
+	}
+	for _, gw := range o.Gateways {
+		if len(gw.Spec.Selector) > 0 && labels.SelectorFromSet(gw.Spec.Selector).Matches(labels.Set(o.Service.Spec.Selector)) {
+			gateways = append(gateways, gw)
+		}
+	}
+	return gateways
+}
+
+// targetPortIndexes returns the indexes of the service ports the route sends traffic to: all the ports when the route
+// has no target port, the port with the name or the target port of the route otherwise.
+func (o OpenShiftRouteChecker) targetPortIndexes(route *osroutes_v1.Route) []int {
+	indexes := []int{}
+	for i, sp := range o.Service.Spec.Ports {
+		if route.Spec.Port == nil || matchesTargetPort(sp, route.Spec.Port.TargetPort) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func matchesTargetPort(sp v1.ServicePort, targetPort intstr.IntOrString) bool {
+	if targetPort.Type == intstr.String {
+		return sp.Name == targetPort.StrVal || (sp.TargetPort.Type == intstr.String && sp.TargetPort.StrVal == targetPort.StrVal)
+	}
+	if sp.TargetPort.Type == intstr.Int && sp.TargetPort.IntVal != 0 {
+		return sp.TargetPort.IntVal == targetPort.IntVal || sp.Port == targetPort.IntVal
+	}
+	return sp.Port == targetPort.IntVal
+}
+
+type gatewayServer struct {
+	gateway *networking_v1beta1.Gateway
+	server  *api_networking_v1beta1.Server
+}
+
+// gatewayServers returns the servers of the gateways listening on the service port
+func gatewayServers(gateways []*networking_v1beta1.Gateway, port int32) []gatewayServer {
+	servers := []gatewayServer{}
+	for _, gw := range gateways {
+		for _, server := range gw.Spec.Servers {
+			if server != nil && server.Port != nil && int32(server.Port.Number) == port {
+				servers = append(servers, gatewayServer{gateway: gw, server: server})
+			}
+		}
+	}
+	return servers
+}
+
+// compatibleServers returns the servers expecting plain text or TLS as the router sends it for the route. The TCP
+// servers accept both.
+func compatibleServers(servers []gatewayServer, route *osroutes_v1.Route) []gatewayServer {
+	sendsTLS := route.Spec.TLS != nil && (route.Spec.TLS.Termination == osroutes_v1.TLSTerminationPassthrough || route.Spec.TLS.Termination == osroutes_v1.TLSTerminationReencrypt)
+	compatible := []gatewayServer{}
+	for _, gs := range servers {
+		protocol := strings.ToUpper(gs.server.Port.Protocol)
+		expectsTLS := protocol == "HTTPS" || protocol == "TLS"
+		if protocol == "TCP" || expectsTLS == sendsTLS {
+			compatible = append(compatible, gs)
+		}
+	}
+	return compatible
+}
+
+func isPassthrough(route *osroutes_v1.Route) bool {
+	return route.Spec.TLS != nil && route.Spec.TLS.Termination == osroutes_v1.TLSTerminationPassthrough
+}
+
+// hasPassthroughVirtualService returns whether the TLS traffic of the host reaches a VirtualService, when the servers
+// pass it through: it is routed by the tls routes of the VirtualServices of the gateway matching the SNI host.
+func (o OpenShiftRouteChecker) hasPassthroughVirtualService(servers []gatewayServer, host string) bool {
+	for _, gs := range servers {
+		if gs.server.Tls == nil || gs.server.Tls.Mode != api_networking_v1beta1.ServerTLSSettings_PASSTHROUGH {
+			// The gateway terminates the TLS connection, the http routes apply
+			return true
+		}
+		for _, vs := range o.VirtualServices {
+			if !bindsGateway(vs, gs.gateway) {
+				continue
+			}
+			for _, tlsRoute := range vs.Spec.Tls {
+				for _, match := range tlsRoute.GetMatch() {
+					for _, sniHost := range match.SniHosts {
+						if matchesHost(sniHost, host) {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func bindsGateway(vs *networking_v1beta1.VirtualService, gw *networking_v1beta1.Gateway) bool {
+	for _, name := range vs.Spec.Gateways {
+		namespace := vs.Namespace
+		if ns, gwName, found := strings.Cut(name, "/"); found {
+			namespace, name = ns, gwName
+		}
+		if namespace == gw.Namespace && name == gw.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesHost returns whether a host matches a host pattern, which can start with a * wildcard
+func matchesHost(pattern, host string) bool {
+	if pattern == "*" || pattern == host {
+		return true
+	}
+	return strings.HasPrefix(pattern, "*.") && strings.HasSuffix(host, pattern[1:])
+}
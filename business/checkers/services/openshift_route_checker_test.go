@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+
+	osroutes_v1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func route(targetPort *intstr.IntOrString, termination osroutes_v1.TLSTerminationType) *osroutes_v1.Route {
+	r := &osroutes_v1.Route{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "service1", Namespace: "test-namespace"},
+		Spec: osroutes_v1.RouteSpec{
+			Host: "bookinfo.apps.example.com",
+			To:   osroutes_v1.RouteTargetReference{Kind: "Service", Name: "service1"},
+		},
+	}
+	if targetPort != nil {
+		r.Spec.Port = &osroutes_v1.RoutePort{TargetPort: *targetPort}
+	}
+	if termination != "" {
+		r.Spec.TLS = &osroutes_v1.TLSConfig{Termination: termination}
+	}
+	return r
+}
+
+func gatewayOfService(port uint32, protocol string) *networking_v1beta1.Gateway {
+	return data.AddServerToGateway(data.CreateServer([]string{"*"}, port, "port", protocol),
+		data.CreateEmptyGateway("gateway", "test-namespace", map[string]string{"dep": "one"}))
+}
+
+func TestOpenShiftRouteTargetPort(t *testing.T) {
+	assert := assert.New(t)
+
+	service := getService(9080, "http", nil, "test-namespace", "app")
+	byName := intstr.FromString("http")
+	byNumber := intstr.FromInt32(9080)
+	vals, valid := OpenShiftRouteChecker{Service: service, Routes: []*osroutes_v1.Route{route(nil, ""), route(&byName, ""), route(&byNumber, "")}}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	notFound := intstr.FromString("https")
+	vals, valid = OpenShiftRouteChecker{Service: service, Routes: []*osroutes_v1.Route{route(&notFound, "")}}.Check()
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("spec/ports", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("service.route.port.notfound", vals[0]))
+}
+
+func TestOpenShiftRouteTLSTermination(t *testing.T) {
+	assert := assert.New(t)
+
+	service := getService(443, "https", nil, "test-namespace", "app")
+	gateways := []*networking_v1beta1.Gateway{gatewayOfService(443, "HTTPS")}
+
+	// The router sends TLS to the HTTPS server
+	vals, _ := OpenShiftRouteChecker{Service: service, Routes: []*osroutes_v1.Route{route(nil, osroutes_v1.TLSTerminationReencrypt)}, Gateways: gateways}.Check()
+	assert.Empty(vals)
+
+	// The router sends plain text to the HTTPS server
+	vals, _ = OpenShiftRouteChecker{Service: service, Routes: []*osroutes_v1.Route{route(nil, osroutes_v1.TLSTerminationEdge)}, Gateways: gateways}.Check()
+	assert.Len(vals, 1)
+	assert.Equal("spec/ports[0]", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("service.route.tls.mismatch", vals[0]))
+
+	// The router sends TLS to the HTTP server
+	vals, _ = OpenShiftRouteChecker{Service: service, Routes: []*osroutes_v1.Route{route(nil, osroutes_v1.TLSTerminationPassthrough)}, Gateways: []*networking_v1beta1.Gateway{gatewayOfService(443, "HTTP")}}.Check()
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("service.route.tls.mismatch", vals[0]))
+
+	// Not a gateway of the service
+	other := data.AddServerToGateway(data.CreateServer([]string{"*"}, 443, "port", "HTTP"),
+		data.CreateEmptyGateway("other", "test-namespace", map[string]string{"istio": "egressgateway"}))
+	vals, _ = OpenShiftRouteChecker{Service: service, Routes: []*osroutes_v1.Route{route(nil, osroutes_v1.TLSTerminationPassthrough)}, Gateways: []*networking_v1beta1.Gateway{other}}.Check()
+	assert.Empty(vals)
+}
+
+func TestOpenShiftRoutePassthroughVirtualService(t *testing.T) {
+	assert := assert.New(t)
+
+	service := getService(443, "tls", nil, "test-namespace", "app")
+	gateway := gatewayOfService(443, "TLS")
+	gateway.Spec.Servers[0].Tls = &api_networking_v1beta1.ServerTLSSettings{Mode: api_networking_v1beta1.ServerTLSSettings_PASSTHROUGH}
+	routes := []*osroutes_v1.Route{route(nil, osroutes_v1.TLSTerminationPassthrough)}
+
+	vals, _ := OpenShiftRouteChecker{Service: service, Routes: routes, Gateways: []*networking_v1beta1.Gateway{gateway}}.Check()
+	assert.Len(vals, 1)
+	assert.Equal("spec/ports[0]", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("service.route.passthrough.virtualservice.notfound", vals[0]))
+
+	tlsRoute := data.CreateTlsRoute("productpage", "", 100)
+	tlsRoute.Match = []*api_networking_v1beta1.TLSMatchAttributes{{SniHosts: []string{"*.apps.example.com"}}}
+	vs := data.AddGatewaysToVirtualService([]string{"test-namespace/gateway"},
+		data.AddTlsRoutesToVirtualService(tlsRoute, data.CreateEmptyVirtualService("bookinfo", "bookinfo", []string{"*.apps.example.com"})))
+	vals, _ = OpenShiftRouteChecker{Service: service, Routes: routes, Gateways: []*networking_v1beta1.Gateway{gateway}, VirtualServices: []*networking_v1beta1.VirtualService{vs}}.Check()
+	assert.Empty(vals)
+}
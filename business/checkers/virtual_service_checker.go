@@ -15,6 +15,7 @@ type VirtualServiceChecker struct {
 	Cluster          string
 	VirtualServices  []*networking_v1beta1.VirtualService
 	DestinationRules []*networking_v1beta1.DestinationRule
+	TenantScoped     bool
 }
 
 // An Object Checker runs all checkers for an specific object type (i.e.: pod, route rule,...)
@@ -66,7 +67,7 @@ func (in VirtualServiceChecker) runChecks(virtualService *networking_v1beta1.Vir
 		virtualservices.SubsetPresenceChecker{Namespaces: in.Namespaces.GetNames(), VirtualService: virtualService, DestinationRules: in.DestinationRules},
 	}
 	if !in.Namespaces.IsNamespaceAmbient(virtualService.Namespace, in.Cluster) {
-		enabledCheckers = append(enabledCheckers, common.ExportToNamespaceChecker{ExportTo: virtualService.Spec.ExportTo, Namespaces: in.Namespaces})
+		enabledCheckers = append(enabledCheckers, common.ExportToNamespaceChecker{ExportTo: virtualService.Spec.ExportTo, Namespaces: in.Namespaces, TenantScoped: in.TenantScoped})
 	}
 
 	for _, checker := range enabledCheckers {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 
 	"github.com/kiali/kiali/kubernetes"
@@ -43,6 +44,20 @@ func (n NoHostChecker) Check() ([]*models.IstioCheck, bool) {
 					}
 				}
 			}
+			for _, mirror := range httpMirrors(k, httpRoute) {
+				if mirror.destination.Host == "" {
+					continue
+				}
+				fqdn := kubernetes.GetHost(mirror.destination.Host, namespace, n.Namespaces.GetNames())
+				if !n.checkDestination(fqdn.String(), namespace) {
+					validation := models.Build("virtualservices.nohost.hostnotfound", mirror.path+"/host")
+					if n.PolicyAllowAny {
+						validation.Severity = models.WarningSeverity
+					}
+					validations = append(validations, &validation)
+					valid = false
+				}
+			}
 		}
 	}
 
@@ -111,3 +126,23 @@ func (n NoHostChecker) checkDestination(sHost string, itemNamespace string) bool
 	// i.e. Multi-cluster or Federation validations
 	return kubernetes.HasMatchingRegistryService(itemNamespace, sHost, n.RegistryServices)
 }
+
+// mirrorDestination is a mirror destination of an http route and its path
+type mirrorDestination struct {
+	path        string
+	destination *api_networking_v1beta1.Destination
+}
+
+// httpMirrors returns the mirror destinations of an http route: the mirror and the mirrors policies
+func httpMirrors(routeIdx int, httpRoute *api_networking_v1beta1.HTTPRoute) []mirrorDestination {
+	mirrors := []mirrorDestination{}
+	if httpRoute.Mirror != nil {
+		mirrors = append(mirrors, mirrorDestination{path: fmt.Sprintf("spec/http[%d]/mirror", routeIdx), destination: httpRoute.Mirror})
+	}
+	for i, mirror := range httpRoute.Mirrors {
+		if mirror != nil && mirror.Destination != nil {
+			mirrors = append(mirrors, mirrorDestination{path: fmt.Sprintf("spec/http[%d]/mirrors[%d]/destination", routeIdx, i), destination: mirror.Destination})
+		}
+	}
+	return mirrors
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 
 	"github.com/kiali/kiali/config"
@@ -93,6 +94,33 @@ func TestNoValidHost(t *testing.T) {
 	assert.Equal("spec/tcp[0]/route[0]/destination/host", vals[0].Path)
 }
 
+func TestNoValidMirrorHost(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	virtualService := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "v1", -1),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+	)
+	virtualService.Spec.Http[0].Mirror = &api_networking_v1beta1.Destination{Host: "reviews-shadow"}
+	virtualService.Spec.Http[0].Mirrors = []*api_networking_v1beta1.HTTPMirrorPolicy{
+		{Destination: &api_networking_v1beta1.Destination{Host: "reviews"}},
+		{Destination: &api_networking_v1beta1.Destination{Host: "ratings"}},
+	}
+
+	vals, valid := NoHostChecker{
+		RegistryServices: data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+		VirtualService:   virtualService,
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 2)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.nohost.hostnotfound", vals[0]))
+	assert.Equal("spec/http[0]/mirror/host", vals[0].Path)
+	assert.Equal("spec/http[0]/mirrors[1]/destination/host", vals[1].Path)
+}
+
 func TestNoValidExportedHost(t *testing.T) {
 	conf := config.NewConfig()
 	config.Set(conf)
@@ -41,6 +41,15 @@ func (checker SubsetPresenceChecker) Check() ([]*models.IstioCheck, bool) {
 				validations = append(validations, &validation)
 			}
 		}
+		for _, mirror := range httpMirrors(routeIdx, httpRoute) {
+			if mirror.destination.Host == "" || mirror.destination.Subset == "" {
+				continue
+			}
+			if !checker.subsetPresent(mirror.destination.Host, mirror.destination.Subset) {
+				validation := models.Build("virtualservices.subsetpresent.subsetnotfound", mirror.path)
+				validations = append(validations, &validation)
+			}
+		}
 	}
 
 	for routeIdx, tcpRoute := range checker.VirtualService.Spec.Tcp {
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
 	"github.com/kiali/kiali/tests/testutils/validations"
 )
 
@@ -48,6 +52,37 @@ func TestCheckerWithSubsetsMatchingSVCNSHostname(t *testing.T) {
 	testNoSubsetPresenceValidationsFound("subset-presence-matching-subsets-half-fqdn.yaml", t)
 }
 
+func TestMirrorSubsetNotFound(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	virtualService := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "v1", -1),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+	)
+	virtualService.Spec.Http[0].Mirror = &api_networking_v1beta1.Destination{Host: "reviews", Subset: "v3"}
+	destinationRule := data.AddSubsetToDestinationRule(data.CreateSubset("v1", "v1"),
+		data.AddSubsetToDestinationRule(data.CreateSubset("v2", "v2"),
+			data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews")))
+
+	vals, valid := SubsetPresenceChecker{
+		Namespaces:       []string{"bookinfo"},
+		DestinationRules: []*networking_v1beta1.DestinationRule{destinationRule},
+		VirtualService:   virtualService,
+	}.Check()
+
+	tb := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	tb.AssertValidationsPresent(1, true)
+	tb.AssertValidationAt(0, models.WarningSeverity, "spec/http[0]/mirror", "virtualservices.subsetpresent.subsetnotfound")
+
+	virtualService.Spec.Http[0].Mirror.Subset = "v2"
+	vals, _ = SubsetPresenceChecker{
+		Namespaces:       []string{"bookinfo"},
+		DestinationRules: []*networking_v1beta1.DestinationRule{destinationRule},
+		VirtualService:   virtualService,
+	}.Check()
+	assert.Empty(t, vals)
+}
+
 func TestSubsetsNotFound(t *testing.T) {
 	testSubsetPresenceValidationsFound("subset-presence-no-matching-subsets-1.yaml", t)
 }
@@ -0,0 +1,22 @@
+package workloads
+
+import (
+	"github.com/kiali/kiali/models"
+)
+
+// ProxyConcurrencyChecker warns when the proxies of a workload run far more, or far fewer, Envoy worker threads
+// than the cores of their CPU limit.
+type ProxyConcurrencyChecker struct {
+	Workload models.WorkloadListItem
+}
+
+func (pcc ProxyConcurrencyChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	if pcc.Workload.ProxyConcurrencyMismatch() {
+		check := models.Build("workload.proxy.concurrencymismatch", "workload")
+		checks = append(checks, &check)
+	}
+
+	return checks, valid
+}
@@ -0,0 +1,69 @@
+package workloads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func proxyWorkload(cpuLimit string, args ...string) models.WorkloadListItem {
+	proxy := core_v1.Container{Name: models.IstioProxy, Args: args}
+	if cpuLimit != "" {
+		proxy.Resources.Limits = core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse(cpuLimit)}
+	}
+	pod := &models.Pod{}
+	pod.Parse(&core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1-1234"},
+		Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Name: "reviews"}, proxy}},
+	})
+
+	workload := models.WorkloadListItem{}
+	workload.ParseWorkload(&models.Workload{WorkloadListItem: models.WorkloadListItem{Name: "reviews-v1"}, Pods: models.Pods{pod}})
+	return workload
+}
+
+func TestProxyConcurrencyMismatch(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	for _, workload := range []models.WorkloadListItem{
+		proxyWorkload("500m", "proxy", "sidecar", "--concurrency", "8"),
+		proxyWorkload("8", "proxy", "sidecar", "--concurrency=2"),
+		proxyWorkload("1", "proxy", "sidecar", "--concurrency", "0"),
+	} {
+		vals, valid := ProxyConcurrencyChecker{Workload: workload}.Check()
+		assert.True(valid)
+		assert.Len(vals, 1)
+		assert.Equal(models.WarningSeverity, vals[0].Severity)
+		assert.NoError(validations.ConfirmIstioCheckMessage("workload.proxy.concurrencymismatch", vals[0]))
+	}
+}
+
+func TestProxyConcurrencyMatch(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	for _, workload := range []models.WorkloadListItem{
+		proxyWorkload("500m", "proxy", "sidecar", "--concurrency", "2"),
+		proxyWorkload("4", "proxy", "sidecar", "--concurrency", "2"),
+		// pilot-agent derives the concurrency from the limit
+		proxyWorkload("8", "proxy", "sidecar"),
+		// No limit to match
+		proxyWorkload("", "proxy", "sidecar", "--concurrency", "0"),
+	} {
+		vals, valid := ProxyConcurrencyChecker{Workload: workload}.Check()
+		assert.True(valid)
+		assert.Empty(vals)
+	}
+}
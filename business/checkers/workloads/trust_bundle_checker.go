@@ -0,0 +1,31 @@
+package workloads
+
+import (
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// TrustBundleChecker warns when the root certificates trusted by the proxy of a workload are about to expire.
+type TrustBundleChecker struct {
+	Workload    models.WorkloadListItem
+	TrustBundle *kubernetes.TrustBundleStatus
+}
+
+func (tbc TrustBundleChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	if tbc.TrustBundle == nil || !tbc.Workload.IstioSidecar {
+		return checks, valid
+	}
+
+	warningDays := config.Get().KialiFeatureFlags.CertificatesInformationIndicators.ExpirationWarningDays
+	if tbc.TrustBundle.ExpiresBefore(time.Now().AddDate(0, 0, warningDays)) {
+		check := models.Build("workload.trustbundle.expiring", "workload")
+		checks = append(checks, &check)
+	}
+
+	return checks, valid
+}
@@ -0,0 +1,42 @@
+package workloads
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestTrustBundleAboutToExpire(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	expiring := &kubernetes.TrustBundleStatus{Roots: []kubernetes.CertValidity{{Subject: "CN=root", NotAfter: time.Now().AddDate(0, 0, 5)}}}
+	valid := &kubernetes.TrustBundleStatus{Roots: []kubernetes.CertValidity{{Subject: "CN=root", NotAfter: time.Now().AddDate(1, 0, 0)}}}
+	workload := models.WorkloadListItem{Name: "reviews-v1", IstioSidecar: true}
+
+	vals, isValid := TrustBundleChecker{Workload: workload, TrustBundle: expiring}.Check()
+	assert.True(isValid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("workload.trustbundle.expiring", vals[0]))
+
+	vals, isValid = TrustBundleChecker{Workload: workload, TrustBundle: valid}.Check()
+	assert.True(isValid)
+	assert.Empty(vals)
+
+	// Workloads without proxy don't use the trust bundle.
+	workload.IstioSidecar = false
+	vals, _ = TrustBundleChecker{Workload: workload, TrustBundle: expiring}.Check()
+	assert.Empty(vals)
+
+	vals, _ = TrustBundleChecker{Workload: workload}.Check()
+	assert.Empty(vals)
+}
@@ -4,7 +4,7 @@ import (
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	"k8s.io/apimachinery/pkg/labels"
 
-	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 )
 
@@ -27,27 +27,9 @@ func (ucw UncoveredWorkloadChecker) Check() ([]*models.IstioCheck, bool) {
 	return checks, valid
 }
 
-func (ucw UncoveredWorkloadChecker) hasCoveringAuthPolicy(wlSelector labels.Labels) bool {
-
-	// for each authorization policy, if the authorization policy namespace is wide mesh (istio root ns) then check for selector restrictions
-	// if it has a specific namespace , check for same namespace, then check for selector restrictions
-	// else workload not covered (false)
-	for _, ap := range ucw.AuthorizationPolicies {
-		apNamespace := ap.Namespace
-		apLabels := map[string]string{}
-		if ap.Spec.Selector != nil {
-			apLabels = ap.Spec.Selector.MatchLabels
-		}
-		var apSelector labels.Selector
-		if len(apLabels) > 0 {
-			apSelector = labels.SelectorFromSet(apLabels)
-		}
-
-		if config.IsRootNamespace(apNamespace) || apNamespace == ucw.Namespace {
-			if apSelector == nil || apSelector.Matches(wlSelector) {
-				return true
-			}
-		}
-	}
-	return false
+func (ucw UncoveredWorkloadChecker) hasCoveringAuthPolicy(wlSelector labels.Set) bool {
+	// a workload is covered by the policies of its namespace or of the istio root namespace (mesh wide)
+	// that either have no selector or a selector matching the workload
+	rbacDetails := kubernetes.RBACDetails{AuthorizationPolicies: ucw.AuthorizationPolicies}
+	return len(rbacDetails.WorkloadAuthorizationPolicies(ucw.Namespace, wlSelector)) > 0
 }
@@ -4,6 +4,7 @@ import (
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 
 	"github.com/kiali/kiali/business/checkers/workloads"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 )
 
@@ -12,6 +13,7 @@ const WorkloadCheckerType = "workload"
 type WorkloadChecker struct {
 	AuthorizationPolicies []*security_v1beta1.AuthorizationPolicy
 	WorkloadsPerNamespace map[string]models.WorkloadList
+	TrustBundle           *kubernetes.TrustBundleStatus
 	Cluster               string
 }
 
@@ -34,6 +36,8 @@ func (w WorkloadChecker) runChecks(workload models.WorkloadListItem, namespace s
 
 	enabledCheckers := []Checker{
 		workloads.UncoveredWorkloadChecker{Workload: workload, Namespace: namespace, AuthorizationPolicies: w.AuthorizationPolicies},
+		workloads.TrustBundleChecker{Workload: workload, TrustBundle: w.TrustBundle},
+		workloads.ProxyConcurrencyChecker{Workload: workload},
 	}
 
 	for _, checker := range enabledCheckers {
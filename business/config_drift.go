@@ -0,0 +1,161 @@
+package business
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetConfigDriftReport compares the Istio objects with the same namespace and name across the clusters running a
+// controlplane, each controlplane reading the config of its own cluster in a multi-primary mesh. The specs are
+// compared by the hash of their normalized JSON, so that the field order and the formatting are not drifts.
+func (in *IstioConfigService) GetConfigDriftReport(ctx context.Context) (*models.ConfigDriftReport, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetConfigDriftReport",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ConfigDriftReport{Clusters: []string{}, Drifts: []models.ConfigDrift{}}
+	for _, cp := range mesh.ControlPlanes {
+		if _, ok := in.userClients[cp.Cluster.Name]; ok && !slices.Contains(report.Clusters, cp.Cluster.Name) {
+			report.Clusters = append(report.Clusters, cp.Cluster.Name)
+		}
+	}
+	slices.Sort(report.Clusters)
+	if len(report.Clusters) < 2 {
+		return report, nil
+	}
+
+	// Spec hashes by object and cluster, and the namespaces of every cluster.
+	hashes := map[models.IstioValidationKey]map[string]string{}
+	namespaces := map[string]map[string]bool{}
+	for _, cluster := range report.Clusters {
+		clusterNamespaces, err := in.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		namespaces[cluster] = map[string]bool{}
+		for _, ns := range clusterNamespaces {
+			namespaces[cluster][ns.Name] = true
+		}
+
+		istioConfigList, err := in.GetIstioConfigList(ctx, cluster, ParseIstioConfigCriteria("", "", ""))
+		if err != nil {
+			return nil, err
+		}
+		for key, spec := range istioConfigSpecs(*istioConfigList) {
+			hash, err := specHash(spec)
+			if err != nil {
+				log.FromContext(ctx).Debug().Msgf("Spec of %s %s/%s of cluster %s not compared: %s", key.ObjectType, key.Namespace, key.Name, cluster, err)
+				continue
+			}
+			if hashes[key] == nil {
+				hashes[key] = map[string]string{}
+			}
+			hashes[key][cluster] = hash
+		}
+	}
+
+	for key, clusterHashes := range hashes {
+		drift := models.ConfigDrift{
+			ObjectType:      key.ObjectType,
+			Namespace:       key.Namespace,
+			Name:            key.Name,
+			SpecHashes:      clusterHashes,
+			MissingClusters: []string{},
+		}
+		distinct := map[string]bool{}
+		for _, cluster := range report.Clusters {
+			if hash, found := clusterHashes[cluster]; found {
+				distinct[hash] = true
+			} else if namespaces[cluster][key.Namespace] {
+				drift.MissingClusters = append(drift.MissingClusters, cluster)
+			}
+		}
+		if len(distinct) > 1 || len(drift.MissingClusters) > 0 {
+			report.Drifts = append(report.Drifts, drift)
+		}
+	}
+	slices.SortFunc(report.Drifts, func(a, b models.ConfigDrift) int {
+		return strings.Compare(a.ObjectType+"/"+a.Namespace+"/"+a.Name, b.ObjectType+"/"+b.Namespace+"/"+b.Name)
+	})
+
+	return report, nil
+}
+
+// specHash returns the hash of the spec marshalled to JSON and normalized: the keys of the objects are sorted and the
+// formatting is dropped.
+func specHash(spec any) (string, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	var normalized any
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return "", err
+	}
+	if raw, err = json.Marshal(normalized); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+type namedObject interface {
+	GetNamespace() string
+	GetName() string
+}
+
+func addSpecs[T namedObject](specs map[models.IstioValidationKey]any, objectType string, objects []T, spec func(T) any) {
+	for _, object := range objects {
+		specs[models.IstioValidationKey{ObjectType: objectType, Namespace: object.GetNamespace(), Name: object.GetName()}] = spec(object)
+	}
+}
+
+// istioConfigSpecs returns the specs of the objects of the list, by resource type, namespace and name.
+func istioConfigSpecs(list models.IstioConfigList) map[models.IstioValidationKey]any {
+	specs := map[models.IstioValidationKey]any{}
+	addSpecs(specs, kubernetes.AuthorizationPolicies, list.AuthorizationPolicies, func(o *security_v1beta1.AuthorizationPolicy) any { return &o.Spec })
+	addSpecs(specs, kubernetes.DestinationRules, list.DestinationRules, func(o *networking_v1beta1.DestinationRule) any { return &o.Spec })
+	addSpecs(specs, kubernetes.EnvoyFilters, list.EnvoyFilters, func(o *networking_v1alpha3.EnvoyFilter) any { return &o.Spec })
+	addSpecs(specs, kubernetes.Gateways, list.Gateways, func(o *networking_v1beta1.Gateway) any { return &o.Spec })
+	addSpecs(specs, kubernetes.K8sGateways, list.K8sGateways, func(o *k8s_networking_v1.Gateway) any { return &o.Spec })
+	addSpecs(specs, kubernetes.K8sGRPCRoutes, list.K8sGRPCRoutes, func(o *k8s_networking_v1.GRPCRoute) any { return &o.Spec })
+	addSpecs(specs, kubernetes.K8sHTTPRoutes, list.K8sHTTPRoutes, func(o *k8s_networking_v1.HTTPRoute) any { return &o.Spec })
+	addSpecs(specs, kubernetes.K8sReferenceGrants, list.K8sReferenceGrants, func(o *k8s_networking_v1beta1.ReferenceGrant) any { return &o.Spec })
+	addSpecs(specs, kubernetes.K8sTCPRoutes, list.K8sTCPRoutes, func(o *k8s_networking_v1alpha2.TCPRoute) any { return &o.Spec })
+	addSpecs(specs, kubernetes.K8sTLSRoutes, list.K8sTLSRoutes, func(o *k8s_networking_v1alpha2.TLSRoute) any { return &o.Spec })
+	addSpecs(specs, kubernetes.PeerAuthentications, list.PeerAuthentications, func(o *security_v1beta1.PeerAuthentication) any { return &o.Spec })
+	addSpecs(specs, kubernetes.RequestAuthentications, list.RequestAuthentications, func(o *security_v1beta1.RequestAuthentication) any { return &o.Spec })
+	addSpecs(specs, kubernetes.ServiceEntries, list.ServiceEntries, func(o *networking_v1beta1.ServiceEntry) any { return &o.Spec })
+	addSpecs(specs, kubernetes.Sidecars, list.Sidecars, func(o *networking_v1beta1.Sidecar) any { return &o.Spec })
+	addSpecs(specs, kubernetes.Telemetries, list.Telemetries, func(o *telemetry_v1alpha1.Telemetry) any { return &o.Spec })
+	addSpecs(specs, kubernetes.VirtualServices, list.VirtualServices, func(o *networking_v1beta1.VirtualService) any { return &o.Spec })
+	addSpecs(specs, kubernetes.WasmPlugins, list.WasmPlugins, func(o *extentions_v1alpha1.WasmPlugin) any { return &o.Spec })
+	addSpecs(specs, kubernetes.WorkloadEntries, list.WorkloadEntries, func(o *networking_v1beta1.WorkloadEntry) any { return &o.Spec })
+	addSpecs(specs, kubernetes.WorkloadGroups, list.WorkloadGroups, func(o *networking_v1beta1.WorkloadGroup) any { return &o.Spec })
+	return specs
+}
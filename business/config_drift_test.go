@@ -0,0 +1,74 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetConfigDriftReport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	kubernetes.SetConfig(t, *conf)
+
+	primary := func(cluster string, objects ...runtime.Object) kubernetes.ClientInterface {
+		objects = append(objects,
+			fakeIstiodDeployment(cluster, false),
+			fakeIstioConfigMap("default"),
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		)
+		return kubetest.NewFakeK8sClient(objects...)
+	}
+	reviews := func(subset string) runtime.Object {
+		return data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", subset, 100),
+			data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}))
+	}
+	clients := map[string]kubernetes.ClientInterface{
+		"east": primary("east",
+			reviews("v2"),
+			data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"),
+			data.CreateEmptyDestinationRule("bookinfo", "ratings", "ratings"),
+		),
+		"west": primary("west",
+			reviews("v1"),
+			data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"),
+		),
+	}
+	factory := kubetest.NewK8SClientFactoryMock(nil)
+	factory.SetClients(clients)
+	kialiCache = cache.NewTestingCacheWithFactory(t, factory, *conf)
+
+	report, err := NewWithBackends(clients, clients, nil, nil).IstioConfig.GetConfigDriftReport(context.TODO())
+	require.NoError(err)
+	assert.Equal([]string{"east", "west"}, report.Clusters)
+
+	// The reviews DestinationRule is the same in both clusters
+	require.Len(report.Drifts, 2)
+
+	ratings := report.Drifts[0]
+	assert.Equal(kubernetes.DestinationRules, ratings.ObjectType)
+	assert.Equal("ratings", ratings.Name)
+	assert.Contains(ratings.SpecHashes, "east")
+	assert.Equal([]string{"west"}, ratings.MissingClusters)
+
+	vs := report.Drifts[1]
+	assert.Equal(kubernetes.VirtualServices, vs.ObjectType)
+	assert.Equal("reviews", vs.Name)
+	assert.NotEqual(vs.SpecHashes["east"], vs.SpecHashes["west"])
+	assert.Empty(vs.MissingClusters)
+}
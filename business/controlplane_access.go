@@ -0,0 +1,226 @@
+package business
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util/httputil"
+)
+
+const (
+	istiodRequestTimeout = 30 * time.Second
+	// istiodProxyConfigAttempts is how many times the config of a proxy is requested through the istiod url, which
+	// may be load balanced over istiods the proxy is not connected to.
+	istiodProxyConfigAttempts = 5
+)
+
+// istiodAccessMode tells how the istiod debug endpoints are reached: by port forwarding to
+// every istiod pod, through the istiod service of the controlplane or through a remote url.
+func (p *controlPlaneMonitor) istiodAccessMode() string {
+	return p.conf.ExternalServices.Istio.Registry.GetAccessMode()
+}
+
+// istiodServiceName returns the name of the service istio creates for the istiod pods of a revision.
+func istiodServiceName(revision string) string {
+	if revision == "" || revision == "default" {
+		return "istiod"
+	}
+	return "istiod-" + revision
+}
+
+// istiodDebugURL returns the url of the debug path for the service and url access modes.
+func (p *controlPlaneMonitor) istiodDebugURL(revision, namespace, debugPath string) string {
+	registry := p.conf.ExternalServices.Istio.Registry
+	if p.istiodAccessMode() == config.IstiodAccessModeURL {
+		return joinURL(registry.IstiodURL, debugPath)
+	}
+
+	scheme := "http"
+	if registry.ClientCertFile != "" || registry.Auth.CAFile != "" || registry.Auth.InsecureSkipVerify {
+		scheme = "https"
+	}
+	base := fmt.Sprintf("%s://%s.%s.svc:%d", scheme, istiodServiceName(revision), namespace, p.conf.ExternalServices.Istio.IstiodPodMonitoringPort)
+	return joinURL(base, debugPath)
+}
+
+// remoteIstiodStatus checks that the istiod debug endpoints can be reached for the service and url access modes.
+func (p *controlPlaneMonitor) remoteIstiodStatus(revision, namespace string) kubernetes.IstioComponentStatus {
+	name := p.conf.ExternalServices.Istio.Registry.IstiodURL
+	if p.istiodAccessMode() == config.IstiodAccessModeService {
+		name = istiodServiceName(revision)
+	} else {
+		namespace = ""
+	}
+
+	// Being able to hit /debug doesn't necessarily mean we are authorized to hit the others.
+	if _, err := p.getRequest(p.istiodDebugURL(revision, namespace, "/debug")); err != nil {
+		log.Warningf("Kiali can't connect to remote Istiod: %s", err)
+		return kubernetes.IstioComponentStatus{{Name: name, Namespace: namespace, Status: kubernetes.ComponentUnreachable, IsCore: true}}
+	}
+	return kubernetes.IstioComponentStatus{{Name: name, Namespace: namespace, Status: kubernetes.ComponentHealthy, IsCore: true}}
+}
+
+// GetProxyConfigDump returns the config istiod pushes to a proxy. Only the istiod the proxy is connected to answers
+// for it, so the request is not load balanced over the istiods of the revision: it is forwarded to the pilot pod or,
+// in the service access mode, sent to each istiod pod IP until one answers. The istiod url can't address a pod, the
+// request is retried while it lands on an istiod that doesn't know the proxy.
+func (p *controlPlaneMonitor) GetProxyConfigDump(client kubernetes.ClientInterface, controlPlane *models.ControlPlane, pilot string, proxyID string) ([]byte, error) {
+	debugPath := "/debug/config_dump?proxyID=" + url.QueryEscape(proxyID)
+	switch p.istiodAccessMode() {
+	case config.IstiodAccessModePortForward:
+		return client.ForwardGetRequest(controlPlane.IstiodNamespace, pilot, p.conf.ExternalServices.Istio.IstiodPodMonitoringPort, debugPath)
+	case config.IstiodAccessModeService:
+		return p.getFromIstiodPods(client, controlPlane.Revision, controlPlane.IstiodNamespace, debugPath)
+	}
+
+	var err error
+	for i := 0; i < istiodProxyConfigAttempts; i++ {
+		var resp []byte
+		if resp, err = p.getRequest(p.istiodDebugURL(controlPlane.Revision, controlPlane.IstiodNamespace, debugPath)); err == nil {
+			return resp, nil
+		}
+		var statusErr *istiodStatusError
+		if !errors.As(err, &statusErr) || statusErr.statusCode != http.StatusNotFound {
+			break
+		}
+	}
+	return nil, err
+}
+
+// getFromIstiodPods gets the debug path from the istiod pods of a revision, addressed by their IP, and returns the
+// first answer. The server certificate is verified against the name of the istiod service.
+func (p *controlPlaneMonitor) getFromIstiodPods(client kubernetes.ClientInterface, revision string, namespace string, debugPath string) ([]byte, error) {
+	kubeCache, err := p.cache.GetKubeCache(client.ClusterInfo().Name)
+	if err != nil {
+		return nil, err
+	}
+	istiods, err := kubeCache.GetPods(namespace, "app=istiod")
+	if err != nil {
+		return nil, err
+	}
+
+	serviceURL, err := url.Parse(p.istiodDebugURL(revision, namespace, debugPath))
+	if err != nil {
+		return nil, err
+	}
+	var errs []string
+	for _, istiod := range istiods {
+		if istiod.Labels[IstioRevisionLabel] != revision || istiod.Status.PodIP == "" {
+			continue
+		}
+		podURL := *serviceURL
+		podURL.Host = net.JoinHostPort(istiod.Status.PodIP, serviceURL.Port())
+		resp, err := p.getRequestWithServerName(podURL.String(), serviceURL.Hostname())
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", istiod.Name, err))
+	}
+
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no istiod pod of revision [%s] found in namespace [%s]", revision, namespace)
+	}
+	return nil, errors.New(strings.Join(errs, "; "))
+}
+
+// istiodHTTPClient returns the client configured with the auth and mTLS client certificate of the istio registry,
+// that verifies the server certificate against a server name, empty for the host of the url. The clients are kept
+// per server name. The client certificate is loaded on each TLS handshake so that a rotated certificate is used
+// without restarting Kiali.
+func (p *controlPlaneMonitor) istiodHTTPClient(serverName string) (*http.Client, error) {
+	registry := p.conf.ExternalServices.Istio.Registry
+	if registry == nil {
+		return http.DefaultClient, nil
+	}
+	if client, found := p.istiodClients.Get(serverName); found {
+		return client, nil
+	}
+
+	transportConfig := &http.Transport{}
+	transport, err := httputil.CreateTransport(&registry.Auth, transportConfig, istiodRequestTimeout, nil)
+	if err != nil {
+		return nil, err
+	}
+	if registry.ClientCertFile != "" {
+		if transportConfig.TLSClientConfig == nil {
+			transportConfig.TLSClientConfig = &tls.Config{}
+		}
+		certFile, keyFile := registry.ClientCertFile, registry.ClientKeyFile
+		transportConfig.TLSClientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load the istiod client certificate: %s", err)
+			}
+			return &cert, nil
+		}
+	}
+	if serverName != "" && transportConfig.TLSClientConfig != nil {
+		transportConfig.TLSClientConfig.ServerName = serverName
+	}
+
+	client := &http.Client{Transport: transport}
+	p.istiodClients.Set(serverName, client)
+	return client, nil
+}
+
+// istiodStatusError is the error of a request that istiod answered with a status other than 200.
+type istiodStatusError struct {
+	statusCode int
+	status     string
+	body       []byte
+}
+
+func (e *istiodStatusError) Error() string {
+	return fmt.Sprintf("bad response when getting config from remote istiod. Status: %s. Body: %s", e.status, e.body)
+}
+
+// getRequest gets the url with the auth and mTLS client certificate configured for the istio registry.
+func (p *controlPlaneMonitor) getRequest(url string) ([]byte, error) {
+	return p.getRequestWithServerName(url, "")
+}
+
+// getRequestWithServerName gets the url like getRequest, verifying the server certificate against a server name
+// other than the host of the url.
+func (p *controlPlaneMonitor) getRequestWithServerName(url string, serverName string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), istiodRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.istiodHTTPClient(serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response body when getting config from remote istiod. Err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &istiodStatusError{statusCode: resp.StatusCode, status: resp.Status, body: body}
+	}
+
+	return body, err
+}
@@ -0,0 +1,103 @@
+package business
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+const (
+	// IstioCARootCertConfigMap is the configmap istiod distributes the workload trust bundle with.
+	IstioCARootCertConfigMap = "istio-ca-root-cert"
+	istioRootCert            = "root-cert.pem"
+)
+
+// parseCertValidities returns the validity of every cert of a PEM encoded bundle, in order.
+func parseCertValidities(bundle []byte) ([]kubernetes.CertValidity, error) {
+	var certs []kubernetes.CertValidity
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate: %s", err)
+		}
+		certs = append(certs, kubernetes.CertValidity{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		})
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+
+	return certs, nil
+}
+
+// getTrustBundleStatus reads the trust bundle distributed to the workloads from the istio-ca-root-cert
+// configmap of the istiod namespace. The istiod CA chain is read from the first of the secrets that
+// Kiali is allowed to read, when the certificates information indicators are enabled.
+func (p *controlPlaneMonitor) getTrustBundleStatus(client kubernetes.ClientInterface, namespace string) (*kubernetes.TrustBundleStatus, error) {
+	configMap, err := client.GetConfigMap(namespace, IstioCARootCertConfigMap)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := parseCertValidities([]byte(configMap.Data[istioRootCert]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust bundle in configmap [%s/%s]: %s", namespace, IstioCARootCertConfigMap, err)
+	}
+	status := &kubernetes.TrustBundleStatus{Roots: roots}
+
+	certsConf := p.conf.KialiFeatureFlags.CertificatesInformationIndicators
+	if !certsConf.Enabled {
+		return status, nil
+	}
+
+	for _, secretName := range certsConf.Secrets {
+		secret, err := client.GetSecret(namespace, secretName)
+		if err != nil {
+			if !errors.IsNotFound(err) && !errors.IsForbidden(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		chain := secret.Data[CAChainCert]
+		if len(chain) == 0 {
+			chain = secret.Data[CACert]
+		}
+		if status.IstiodChain, err = parseCertValidities(chain); err != nil {
+			return nil, fmt.Errorf("invalid CA certificate in secret [%s/%s]: %s", namespace, secretName, err)
+		}
+		break
+	}
+
+	return status, nil
+}
+
+// trustBundleWarnings returns the root of trust rotation warnings of the cluster from its last known trust bundle status.
+func (p *controlPlaneMonitor) trustBundleWarnings(cluster string) []string {
+	trustBundle := p.cache.GetTrustBundleStatus(cluster)
+	if trustBundle == nil {
+		return nil
+	}
+
+	warnBefore := time.Duration(p.conf.KialiFeatureFlags.CertificatesInformationIndicators.ExpirationWarningDays) * 24 * time.Hour
+	return trustBundle.RotationWarnings(time.Now(), warnBefore)
+}
@@ -0,0 +1,151 @@
+package business
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+// fakeRootCert returns a PEM encoded self-signed CA cert valid until notAfter.
+func fakeRootCert(t *testing.T, commonName string, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notAfter.AddDate(-1, 0, 0),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func fakeRootCertConfigMap(roots ...[]byte) *core_v1.ConfigMap {
+	var bundle []byte
+	for _, root := range roots {
+		bundle = append(bundle, root...)
+	}
+	return &core_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{Name: IstioCARootCertConfigMap, Namespace: "istio-system"},
+		Data:       map[string]string{istioRootCert: string(bundle)},
+	}
+}
+
+func TestParseCertValidities(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	expiration := time.Now().AddDate(1, 0, 0).Truncate(time.Second).UTC()
+	bundle := append(fakeRootCert(t, "root-1", expiration), fakeRootCert(t, "root-2", expiration)...)
+
+	certs, err := parseCertValidities(bundle)
+	require.NoError(err)
+	require.Len(certs, 2)
+	assert.Equal("CN=root-1", certs[0].Subject)
+	assert.Equal("CN=root-1", certs[0].Issuer)
+	assert.Equal(expiration, certs[0].NotAfter)
+	assert.Equal("CN=root-2", certs[1].Subject)
+
+	_, err = parseCertValidities([]byte("not a cert"))
+	assert.Error(err)
+}
+
+func TestTrustBundleRotationWarnings(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	oldRoot := kubernetes.CertValidity{Subject: "CN=old", Issuer: "CN=old", NotAfter: now.Add(10*24*time.Hour + time.Hour)}
+	newRoot := kubernetes.CertValidity{Subject: "CN=new", Issuer: "CN=new", NotAfter: now.AddDate(10, 0, 0)}
+	warnBefore := 30 * 24 * time.Hour
+
+	trustBundle := kubernetes.TrustBundleStatus{Roots: []kubernetes.CertValidity{newRoot}, IstiodChain: []kubernetes.CertValidity{newRoot}}
+	assert.Empty(trustBundle.RotationWarnings(now, warnBefore))
+	assert.False(trustBundle.ExpiresBefore(now.Add(warnBefore)))
+
+	// Root rotation in progress. The bundle only expires once every root does.
+	trustBundle.Roots = []kubernetes.CertValidity{oldRoot, newRoot}
+	assert.Equal([]string{
+		"root certificate [CN=old] expires in 10 days",
+		"2 root certificates in the trust bundle, a root rotation is in progress",
+	}, trustBundle.RotationWarnings(now, warnBefore))
+	assert.False(trustBundle.ExpiresBefore(now.Add(warnBefore)))
+
+	// istiod already switched to the new root but the workloads don't trust it yet.
+	trustBundle.Roots = []kubernetes.CertValidity{oldRoot}
+	assert.Equal([]string{
+		"root certificate [CN=old] expires in 10 days",
+		"istiod signs workload certificates with [CN=new] which is not trusted by the trust bundle",
+	}, trustBundle.RotationWarnings(now, warnBefore))
+	assert.True(trustBundle.ExpiresBefore(now.Add(warnBefore)))
+}
+
+func TestCanConnectToIstiodReportsExpiringRootCert(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	kubernetes.SetConfig(t, *conf)
+
+	expiration := time.Now().Add(10*24*time.Hour + time.Hour)
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		fakeIstioConfigMap("default"),
+		fakeRootCertConfigMap(fakeRootCert(t, "root-1", expiration)),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+	)
+	// RefreshIstioCache relies on this being set.
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	testServer := istiodTestServer(t)
+	fakeForwarder := &fakeForwarder{
+		ClientInterface: k8s,
+		testURL:         testServer.URL,
+	}
+
+	cache := SetupBusinessLayer(t, fakeForwarder, *conf)
+
+	cf := kubetest.NewK8SClientFactoryMock(fakeForwarder)
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = fakeForwarder
+	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
+	cpm := NewControlPlaneMonitor(cache, cf, *conf, &mesh)
+
+	require.NoError(cpm.RefreshIstioCache(context.TODO()))
+
+	trustBundle := cache.GetTrustBundleStatus(conf.KubernetesConfig.ClusterName)
+	require.NotNil(trustBundle)
+	require.Len(trustBundle.Roots, 1)
+	assert.Empty(trustBundle.IstiodChain)
+
+	status, err := cpm.CanConnectToIstiod(fakeForwarder)
+	require.NoError(err)
+	require.Len(status, 1)
+	assert.Equal(kubernetes.ComponentHealthy, status[0].Status)
+	assert.Empty(status[0].Reasons)
+	assert.Equal([]string{"root certificate [CN=root-1] expires in 10 days"}, status[0].Warnings)
+}
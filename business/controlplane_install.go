@@ -0,0 +1,121 @@
+package business
+
+import (
+	"context"
+	"strings"
+
+	apps_v1 "k8s.io/api/apps/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	helmChartLabel           = "helm.sh/chart"
+	helmReleaseAnnotation    = "meta.helm.sh/release-name"
+	istioctlComponentLabel   = "operator.istio.io/component"
+	istioctlOwnerLabel       = "install.operator.istio.io/owning-resource"
+	istioctlVersionLabel     = "operator.istio.io/version"
+	k8sManagedByLabel        = "app.kubernetes.io/managed-by"
+	k8sVersionLabel          = "app.kubernetes.io/version"
+	sailManagedByLabel       = "managed-by"
+	sailManagedByValue       = "sail-operator"
+	sailIstioRevisionKind    = "IstioRevision"
+	sailOperatorAPIGroup     = "sailoperator.io"
+	helmManagedByValue       = "Helm"
+	istiodImageDefaultSuffix = "-distroless"
+)
+
+// discoverInstall finds out how an istiod deployment was installed, from its ownership labels, and compares the
+// version it was installed at with the version of its image. The version of a Sail operator controlplane comes from
+// its IstioRevision, a nil client skips it.
+func discoverInstall(ctx context.Context, client kubernetes.ClientInterface, istiod *apps_v1.Deployment) *models.ControlPlaneInstall {
+	install := &models.ControlPlaneInstall{Method: models.InstallMethodUnknown}
+
+	switch {
+	case isSailOperatorManaged(istiod):
+		install.Method = models.InstallMethodSailOperator
+		install.Owner = sailIstioRevisionName(istiod)
+		if client != nil {
+			revision, err := client.GetIstioRevision(ctx, install.Owner)
+			if err != nil {
+				log.Debugf("Unable to get the IstioRevision [%s] of controlplane [%s/%s]. Err: %s", install.Owner, istiod.Namespace, istiod.Name, err)
+			} else {
+				install.DesiredVersion = normalizeIstioVersion(revision.Spec.Version)
+			}
+		}
+	case istiod.Labels[k8sManagedByLabel] == helmManagedByValue || istiod.Annotations[helmReleaseAnnotation] != "":
+		install.Method = models.InstallMethodHelm
+		install.Owner = istiod.Annotations[helmReleaseAnnotation]
+		if chart := istiod.Labels[helmChartLabel]; chart != "" {
+			// The chart label is <chart name>-<chart version>, e.g. istiod-1.24.1
+			install.DesiredVersion = normalizeIstioVersion(strings.TrimPrefix(chart, "istiod-"))
+		} else {
+			install.DesiredVersion = normalizeIstioVersion(istiod.Labels[k8sVersionLabel])
+		}
+	case istiod.Labels[istioctlComponentLabel] != "" || istiod.Labels[istioctlOwnerLabel] != "":
+		install.Method = models.InstallMethodIstioctl
+		install.Owner = istiod.Labels[istioctlOwnerLabel]
+		install.DesiredVersion = normalizeIstioVersion(istiod.Labels[istioctlVersionLabel])
+	}
+
+	if containers := istiod.Spec.Template.Spec.Containers; len(containers) > 0 {
+		install.ImageVersion = imageVersion(containers[0].Image)
+	}
+
+	// Development versions (e.g. master) don't match any image tag
+	if isReleaseVersion(install.DesiredVersion) && isReleaseVersion(install.ImageVersion) {
+		install.VersionSkew = install.DesiredVersion != install.ImageVersion
+	}
+
+	return install
+}
+
+// isSailOperatorManaged returns true when istiod is owned by an IstioRevision, or labeled as managed by the Sail
+// operator.
+func isSailOperatorManaged(istiod *apps_v1.Deployment) bool {
+	for _, owner := range istiod.OwnerReferences {
+		if owner.Kind == sailIstioRevisionKind && strings.HasPrefix(owner.APIVersion, sailOperatorAPIGroup+"/") {
+			return true
+		}
+	}
+	return istiod.Labels[sailManagedByLabel] == sailManagedByValue
+}
+
+// sailIstioRevisionName returns the name of the IstioRevision owning istiod, which is the revision of istiod when
+// there is no owner reference.
+func sailIstioRevisionName(istiod *apps_v1.Deployment) string {
+	for _, owner := range istiod.OwnerReferences {
+		if owner.Kind == sailIstioRevisionKind && strings.HasPrefix(owner.APIVersion, sailOperatorAPIGroup+"/") {
+			return owner.Name
+		}
+	}
+	if revision := istiod.Labels[IstioRevisionLabel]; revision != "" {
+		return revision
+	}
+	return "default"
+}
+
+// imageVersion returns the tag of an image without its variant, e.g. 1.24.1 for docker.io/istio/pilot:1.24.1-distroless.
+// It returns an empty string for an image referenced by digest or without tag.
+func imageVersion(image string) string {
+	if strings.Contains(image, "@") {
+		return ""
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	idx := strings.LastIndex(name, ":")
+	if idx == -1 {
+		return ""
+	}
+	return normalizeIstioVersion(strings.TrimSuffix(name[idx+1:], istiodImageDefaultSuffix))
+}
+
+// normalizeIstioVersion drops the v prefix of the versions of the Sail operator, e.g. v1.24.1.
+func normalizeIstioVersion(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
+
+func isReleaseVersion(version string) bool {
+	return version != "" && version[0] >= '0' && version[0] <= '9'
+}
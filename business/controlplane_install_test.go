@@ -0,0 +1,80 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func installedIstiod(image string, labels, annotations map[string]string, owners ...meta_v1.OwnerReference) *apps_v1.Deployment {
+	return &apps_v1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "istiod",
+			Namespace:       "istio-system",
+			Labels:          labels,
+			Annotations:     annotations,
+			OwnerReferences: owners,
+		},
+		Spec: apps_v1.DeploymentSpec{
+			Template: core_v1.PodTemplateSpec{
+				Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "discovery", Image: image}}},
+			},
+		},
+	}
+}
+
+func TestDiscoverInstall(t *testing.T) {
+	k8s := kubetest.NewFakeK8sClient()
+	k8s.IstioRevisions = []*kubernetes.IstioRevision{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "default-v1-24-1"}, Spec: kubernetes.IstioRevisionSpec{Version: "v1.24.1"}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "canary"}, Spec: kubernetes.IstioRevisionSpec{Version: "v1.24.2"}},
+	}
+
+	cases := map[string]struct {
+		istiod   *apps_v1.Deployment
+		expected models.ControlPlaneInstall
+	}{
+		"Sail operator owner": {
+			istiod: installedIstiod("docker.io/istio/pilot:1.24.1", nil, nil,
+				meta_v1.OwnerReference{APIVersion: "sailoperator.io/v1", Kind: "IstioRevision", Name: "default-v1-24-1"}),
+			expected: models.ControlPlaneInstall{Method: models.InstallMethodSailOperator, Owner: "default-v1-24-1", DesiredVersion: "1.24.1", ImageVersion: "1.24.1"},
+		},
+		"Sail operator label with a stuck rollout": {
+			istiod:   installedIstiod("gcr.io/istio-release/pilot:1.24.1-distroless", map[string]string{"managed-by": "sail-operator", IstioRevisionLabel: "canary"}, nil),
+			expected: models.ControlPlaneInstall{Method: models.InstallMethodSailOperator, Owner: "canary", DesiredVersion: "1.24.2", ImageVersion: "1.24.1", VersionSkew: true},
+		},
+		"Sail operator without its revision": {
+			istiod:   installedIstiod("docker.io/istio/pilot:1.24.1", map[string]string{"managed-by": "sail-operator"}, nil),
+			expected: models.ControlPlaneInstall{Method: models.InstallMethodSailOperator, Owner: "default", ImageVersion: "1.24.1"},
+		},
+		"Helm with an overridden image": {
+			istiod: installedIstiod("registry.local:5000/istio/pilot:1.23.0",
+				map[string]string{"app.kubernetes.io/managed-by": "Helm", "helm.sh/chart": "istiod-1.24.1"},
+				map[string]string{"meta.helm.sh/release-name": "istiod"}),
+			expected: models.ControlPlaneInstall{Method: models.InstallMethodHelm, Owner: "istiod", DesiredVersion: "1.24.1", ImageVersion: "1.23.0", VersionSkew: true},
+		},
+		"Istioctl with an image digest": {
+			istiod: installedIstiod("docker.io/istio/pilot@sha256:0123456789abcdef",
+				map[string]string{"operator.istio.io/component": "Pilot", "operator.istio.io/version": "1.22.3", "install.operator.istio.io/owning-resource": "installed-state"}, nil),
+			expected: models.ControlPlaneInstall{Method: models.InstallMethodIstioctl, Owner: "installed-state", DesiredVersion: "1.22.3"},
+		},
+		"Unknown": {
+			istiod:   installedIstiod("docker.io/istio/pilot:1.24.1", map[string]string{"app": "istiod"}, nil),
+			expected: models.ControlPlaneInstall{Method: models.InstallMethodUnknown, ImageVersion: "1.24.1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, *discoverInstall(context.TODO(), k8s, tc.istiod))
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package business
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+// The metrics endpoint is served on the istiod monitoring port alongside the debug endpoints.
+const istiodMetricsPath = "/metrics"
+
+// istiodResourceUsageThreshold is the fraction of its cpu/memory limits above which istiod is reported unhealthy.
+const istiodResourceUsageThreshold = 0.9
+
+// istiodErrorRateThreshold is the rate, per second, above which the xDS errors, the xDS rejects or the
+// injection failures of istiod are reported unhealthy. Occasional errors are expected e.g. while a proxy
+// restarts, a sustained rate is not.
+const istiodErrorRateThreshold = 0.1
+
+// istiodMetrics are the key metrics scraped from a single istiod pod.
+type istiodMetrics struct {
+	scrapedAt time.Time
+
+	// Counters as reported by istiod.
+	cpuSeconds        float64
+	injectionFailures float64
+	xdsInternalErrors float64
+	xdsPushErrors     float64
+	xdsRejects        float64
+
+	// Gauges.
+	memoryBytes float64
+}
+
+// istiodMetricsWindow holds the last two scrapes of an istiod pod so that counters can be
+// turned into the increase observed during the last polling interval.
+type istiodMetricsWindow struct {
+	previous *istiodMetrics
+	current  *istiodMetrics
+}
+
+func istiodMetricsKey(cluster, revision, pod string) string {
+	return controlPlaneKey(cluster, revision) + "/" + pod
+}
+
+func parseIstiodMetrics(body []byte, scrapedAt time.Time) (*istiodMetrics, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return &istiodMetrics{
+		scrapedAt:         scrapedAt,
+		cpuSeconds:        sumMetricFamily(families["process_cpu_seconds_total"]),
+		injectionFailures: sumMetricFamily(families["sidecar_injection_failure_total"]),
+		xdsInternalErrors: sumMetricFamily(families["pilot_total_xds_internal_errors"]),
+		xdsPushErrors:     sumMetricFamily(families["pilot_xds_push_context_errors"]),
+		xdsRejects:        sumMetricFamily(families["pilot_total_xds_rejects"]),
+		memoryBytes:       sumMetricFamily(families["process_resident_memory_bytes"]),
+	}, nil
+}
+
+// sumMetricFamily adds up the values of all the series of the family e.g. pushes of every xDS type.
+func sumMetricFamily(family *dto.MetricFamily) float64 {
+	var sum float64
+	for _, m := range family.GetMetric() {
+		switch {
+		case m.Counter != nil:
+			sum += m.GetCounter().GetValue()
+		case m.Gauge != nil:
+			sum += m.GetGauge().GetValue()
+		case m.Untyped != nil:
+			sum += m.GetUntyped().GetValue()
+		}
+	}
+	return sum
+}
+
+// increase returns how much a counter grew between two scrapes, accounting for counter resets.
+func increase(current, previous float64) float64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
+
+// unhealthyReasons explains why an istiod pod should be considered unhealthy based on its metrics.
+// Counters are only evaluated once there are two scrapes to compare, as rates over the interval.
+// Resource usage is compared against the limits of the discovery container, when set.
+func (w istiodMetricsWindow) unhealthyReasons(limits corev1.ResourceList) []string {
+	var reasons []string
+	current, previous := w.current, w.previous
+	if current == nil {
+		return reasons
+	}
+
+	if previous != nil && current.scrapedAt.After(previous.scrapedAt) {
+		elapsed := current.scrapedAt.Sub(previous.scrapedAt)
+		window := elapsed.Round(time.Second)
+
+		if errs := increase(current.xdsPushErrors, previous.xdsPushErrors) + increase(current.xdsInternalErrors, previous.xdsInternalErrors); errs/elapsed.Seconds() > istiodErrorRateThreshold {
+			reasons = append(reasons, fmt.Sprintf("%.0f xDS push errors in the last %s", errs, window))
+		}
+		if rejects := increase(current.xdsRejects, previous.xdsRejects); rejects/elapsed.Seconds() > istiodErrorRateThreshold {
+			reasons = append(reasons, fmt.Sprintf("%.0f xDS configs rejected by proxies in the last %s", rejects, window))
+		}
+		if failures := increase(current.injectionFailures, previous.injectionFailures); failures/elapsed.Seconds() > istiodErrorRateThreshold {
+			reasons = append(reasons, fmt.Sprintf("%.0f sidecar injection failures in the last %s", failures, window))
+		}
+
+		if cpuLimit, found := limits[corev1.ResourceCPU]; found && !cpuLimit.IsZero() {
+			cores := increase(current.cpuSeconds, previous.cpuSeconds) / elapsed.Seconds()
+			if usage := cores / cpuLimit.AsApproximateFloat64(); usage > istiodResourceUsageThreshold {
+				reasons = append(reasons, fmt.Sprintf("CPU usage at %.0f%% of the limit", usage*100))
+			}
+		}
+	}
+
+	if memoryLimit, found := limits[corev1.ResourceMemory]; found && !memoryLimit.IsZero() {
+		if usage := current.memoryBytes / memoryLimit.AsApproximateFloat64(); usage > istiodResourceUsageThreshold {
+			reasons = append(reasons, fmt.Sprintf("memory usage at %.0f%% of the limit", usage*100))
+		}
+	}
+
+	return reasons
+}
+
+// scrapeIstiodMetrics scrapes the metrics of every healthy istiod pod of the revision
+// and keeps the last two scrapes of each pod. Pods that are gone are forgotten.
+func (p *controlPlaneMonitor) scrapeIstiodMetrics(client kubernetes.ClientInterface, revision string, namespace string, now time.Time) error {
+	// Metrics are only scraped from the istiod pods.
+	if p.istiodAccessMode() != config.IstiodAccessModePortForward {
+		return nil
+	}
+
+	cluster := client.ClusterInfo().Name
+	result, err := p.getIstiodDebugStatus(client, revision, namespace, istiodMetricsPath)
+	if err != nil {
+		return err
+	}
+
+	scraped := map[string]bool{}
+	for pod, body := range result {
+		metrics, err := parseIstiodMetrics(body, now)
+		if err != nil {
+			log.Debugf("Unable to parse metrics of istiod [%s] in cluster [%s]: %s", pod, cluster, err)
+			continue
+		}
+
+		key := istiodMetricsKey(cluster, revision, pod)
+		scraped[key] = true
+		window, _ := p.istiodMetrics.Get(key)
+		p.istiodMetrics.Set(key, istiodMetricsWindow{previous: window.current, current: metrics})
+	}
+
+	prefix := controlPlaneKey(cluster, revision) + "/"
+	for _, key := range p.istiodMetrics.Keys() {
+		if strings.HasPrefix(key, prefix) && !scraped[key] {
+			p.istiodMetrics.Remove(key)
+		}
+	}
+
+	return nil
+}
+
+// istiodUnhealthyReasons returns why the istiod pod is unhealthy according to its last scraped metrics, if at all.
+func (p *controlPlaneMonitor) istiodUnhealthyReasons(cluster, revision string, pod *corev1.Pod) []string {
+	window, found := p.istiodMetrics.Get(istiodMetricsKey(cluster, revision, pod.Name))
+	if !found {
+		return nil
+	}
+
+	var limits corev1.ResourceList
+	for _, container := range pod.Spec.Containers {
+		if container.Name == "discovery" {
+			limits = container.Resources.Limits
+			break
+		}
+	}
+
+	return window.unhealthyReasons(limits)
+}
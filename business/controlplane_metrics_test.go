@@ -0,0 +1,128 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestParseIstiodMetrics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Now()
+	metrics, err := parseIstiodMetrics(kubernetes.ReadFile(t, "../tests/data/registry/istiod-metrics.txt"), now)
+	require.NoError(err)
+
+	assert.Equal(now, metrics.scrapedAt)
+	assert.Equal(42.5, metrics.cpuSeconds)
+	assert.Equal(float64(3), metrics.injectionFailures)
+	assert.Equal(float64(1), metrics.xdsInternalErrors)
+	assert.Equal(float64(0), metrics.xdsPushErrors)
+	assert.Equal(float64(3), metrics.xdsRejects)
+	assert.Equal(float64(125829120), metrics.memoryBytes)
+}
+
+func TestIstiodUnhealthyReasons(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	previous := &istiodMetrics{scrapedAt: now.Add(-20 * time.Second), cpuSeconds: 10, xdsRejects: 2, injectionFailures: 5}
+	current := &istiodMetrics{scrapedAt: now, cpuSeconds: 29, xdsRejects: 6, injectionFailures: 5, memoryBytes: 500 * 1024 * 1024}
+
+	limits := core_v1.ResourceList{
+		core_v1.ResourceCPU:    resource.MustParse("1"),
+		core_v1.ResourceMemory: resource.MustParse("512Mi"),
+	}
+
+	// A single scrape can only tell about the current memory usage.
+	assert.Equal([]string{"memory usage at 98% of the limit"}, istiodMetricsWindow{current: current}.unhealthyReasons(limits))
+
+	assert.Equal([]string{
+		"4 xDS configs rejected by proxies in the last 20s",
+		"CPU usage at 95% of the limit",
+		"memory usage at 98% of the limit",
+	}, istiodMetricsWindow{previous: previous, current: current}.unhealthyReasons(limits))
+
+	// Without limits only the errors count.
+	assert.Equal([]string{"4 xDS configs rejected by proxies in the last 20s"}, istiodMetricsWindow{previous: previous, current: current}.unhealthyReasons(nil))
+
+	// Errors below the rate threshold are not reported.
+	occasional := &istiodMetrics{scrapedAt: now, xdsRejects: 4, injectionFailures: 6}
+	assert.Empty(istiodMetricsWindow{previous: previous, current: occasional}.unhealthyReasons(nil))
+
+	// Counter resets are not reported as negative increases.
+	restarted := &istiodMetrics{scrapedAt: now, xdsRejects: 0}
+	assert.Empty(istiodMetricsWindow{previous: previous, current: restarted}.unhealthyReasons(nil))
+}
+
+func TestCanConnectToIstiodReportsUnhealthyMetrics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		fakeIstioConfigMap("default"),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+	)
+	// RefreshIstioCache relies on this being set.
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	testServer := istiodTestServer(t)
+	fakeForwarder := &fakeForwarder{
+		ClientInterface: k8s,
+		testURL:         testServer.URL,
+	}
+
+	cache := SetupBusinessLayer(t, fakeForwarder, *conf)
+
+	cf := kubetest.NewK8SClientFactoryMock(fakeForwarder)
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = fakeForwarder
+	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
+	cpm := NewControlPlaneMonitor(cache, cf, *conf, &mesh)
+
+	// The first scrape has nothing to compare the counters with.
+	require.NoError(cpm.RefreshIstioCache(context.TODO()))
+	status, err := cpm.CanConnectToIstiod(fakeForwarder)
+	require.NoError(err)
+	require.Len(status, 1)
+	assert.Equal(kubernetes.ComponentHealthy, status[0].Status)
+	assert.Empty(status[0].Reasons)
+
+	// Fake an older scrape with fewer injection failures.
+	key := istiodMetricsKey(conf.KubernetesConfig.ClusterName, "default", "istiod-123")
+	window, found := cpm.istiodMetrics.Get(key)
+	require.True(found)
+	older := *window.current
+	older.scrapedAt = older.scrapedAt.Add(-10 * time.Second)
+	older.injectionFailures = 0
+	cpm.istiodMetrics.Set(key, istiodMetricsWindow{previous: &older, current: window.current})
+
+	status, err = cpm.CanConnectToIstiod(fakeForwarder)
+	require.NoError(err)
+	require.Len(status, 1)
+	assert.Equal(kubernetes.ComponentUnhealthy, status[0].Status)
+	assert.Equal([]string{"3 sidecar injection failures in the last 10s"}, status[0].Reasons)
+
+	// An unhealthy istiod is still scraped so that it can recover.
+	require.NoError(cpm.RefreshIstioCache(context.TODO()))
+	refreshed, found := cpm.istiodMetrics.Get(key)
+	require.True(found)
+	assert.Same(window.current, refreshed.previous)
+}
@@ -5,14 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"hash/fnv"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/kiali/kiali/config"
@@ -20,6 +26,7 @@ import (
 	"github.com/kiali/kiali/kubernetes/cache"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/store"
 )
 
 // ControlPlaneMonitor is an interface for the control plane monitor.
@@ -28,25 +35,72 @@ import (
 type ControlPlaneMonitor interface {
 	PollIstiodForProxyStatus(ctx context.Context)
 	CanConnectToIstiod(client kubernetes.ClientInterface) (kubernetes.IstioComponentStatus, error)
-	CanConnectToIstiodForRevision(client kubernetes.ClientInterface, revision string) (kubernetes.IstioComponentStatus, error)
+	CanConnectToIstiodForRevision(client kubernetes.ClientInterface, revision string, namespace string) (kubernetes.IstioComponentStatus, error)
 	// RefreshIstioCache should update the kiali cache's istio related stores.
 	RefreshIstioCache(ctx context.Context) error
+	// NotifyConfigChange should be called after istio config was written through Kiali for the cluster.
+	NotifyConfigChange(cluster string)
+	// RefreshControlPlane should update the kiali cache with the latest info of a single controlplane.
+	RefreshControlPlane(ctx context.Context, cluster string, revision string) error
+	// GetProxyConfigDump returns the config that the istiod of a controlplane pushes to a proxy.
+	GetProxyConfigDump(client kubernetes.ClientInterface, controlPlane *models.ControlPlane, pilot string, proxyID string) ([]byte, error)
 }
 
 func NewControlPlaneMonitor(cache cache.KialiCache, clientFactory kubernetes.ClientFactory, conf config.Config, meshService *MeshService) *controlPlaneMonitor {
 	return &controlPlaneMonitor{
-		cache:           cache,
-		clientFactory:   clientFactory,
-		conf:            conf,
-		pollingInterval: time.Duration(conf.ExternalServices.Istio.IstiodPollingIntervalSeconds) * time.Second,
-		meshService:     meshService,
+		cache:              cache,
+		clientFactory:      clientFactory,
+		conf:               conf,
+		pollingInterval:    time.Duration(conf.ExternalServices.Istio.IstiodPollingIntervalSeconds) * time.Second,
+		meshService:        meshService,
+		configWrites:       store.New[string, time.Time](),
+		istiodMetrics:      store.New[string, istiodMetricsWindow](),
+		istiodClients:      store.New[string, *http.Client](),
+		schedules:          make(map[string]*controlPlaneSchedule),
+		proxyStatus:        make(map[string][]*kubernetes.ProxyStatus),
+		configDistribution: make(map[string][]*kubernetes.ConfigDistribution),
+		registryStatus:     make(map[string]*kubernetes.RegistryStatus),
+		trustBundles:       make(map[string]*kubernetes.TrustBundleStatus),
 	}
 }
 
+const (
+	// maxPollingBackoffFactor caps how much the polling interval of a control plane
+	// grows while the proxy status it reports stays the same.
+	maxPollingBackoffFactor = 4
+	// pollingJitterFactor is the max fraction of the interval added on top of it
+	// so that the control planes are not all hit at the same time.
+	pollingJitterFactor = 0.1
+	// configWriteSpeedupFactor is how much faster the control planes of a cluster
+	// are polled after an istio config write performed through Kiali.
+	configWriteSpeedupFactor = 4
+	// configWriteSpeedupDuration is how long the faster polling lasts after a config write.
+	configWriteSpeedupDuration = time.Minute
+	// configDistributionConcurrency caps the requests made in parallel to the istiods of a revision
+	// while scraping the distribution of the configs.
+	configDistributionConcurrency = 5
+)
+
+// controlPlaneSchedule holds the adaptive polling state of a single control plane.
+type controlPlaneSchedule struct {
+	// interval is the current polling interval without jitter. It starts at the
+	// configured polling interval and backs off while the proxy status is unchanged.
+	interval time.Duration
+	lastPoll time.Time
+	nextPoll time.Time
+	// syncHash is the hash of the last syncz payload scraped from the control plane.
+	syncHash uint64
+}
+
+func controlPlaneKey(cluster, revision string) string {
+	return cluster + "/" + revision
+}
+
 // controlPlaneMonitor will periodically scrape the debug endpoint(s) of istiod.
 // It scrapes a single pod from each controlplane. The list of controlplanes
 // comes from the kialiCache. It will update the kialiCache with the info
-// that it scrapes.
+// that it scrapes. Each controlplane is polled on its own jittered schedule
+// that slows down while its proxy status is unchanged.
 type controlPlaneMonitor struct {
 	// Where we store the proxy status.
 	cache cache.KialiCache
@@ -57,33 +111,147 @@ type controlPlaneMonitor struct {
 	conf            config.Config
 	meshService     *MeshService
 	pollingInterval time.Duration
+
+	// configWrites holds the time of the last istio config write per cluster.
+	configWrites store.Store[string, time.Time]
+	// istiodMetrics holds the last scraped metrics per istiod pod.
+	istiodMetrics store.Store[string, istiodMetricsWindow]
+	// istiodClients holds the clients of the service and url access modes per TLS server name.
+	istiodClients store.Store[string, *http.Client]
+
+	// refreshLock serializes refreshes and guards the polling state below. The last
+	// results of every controlplane are kept so that a refresh that polls only some
+	// of them can still replace the whole kialiCache stores.
+	refreshLock        sync.Mutex
+	schedules          map[string]*controlPlaneSchedule
+	proxyStatus        map[string][]*kubernetes.ProxyStatus
+	configDistribution map[string][]*kubernetes.ConfigDistribution
+	registryStatus     map[string]*kubernetes.RegistryStatus
+	trustBundles       map[string]*kubernetes.TrustBundleStatus
 }
 
 // RefreshIstioCache will scrape the debug endpoint(s) of istiod a single time
 // and update the kialiCache. The proxy status and the registry services are
 // scraped from the debug endpoint.
 func (p *controlPlaneMonitor) RefreshIstioCache(ctx context.Context) error {
-	log.Debug("Scraping istiod for debug info")
+	_, err := p.refresh(ctx, func(cluster, key string, now time.Time) bool { return true })
+	return err
+}
+
+// RefreshControlPlane scrapes the debug endpoint(s) of a single controlplane right away
+// instead of waiting for it to be polled. Returns a NotFound error when the mesh
+// has no such controlplane.
+func (p *controlPlaneMonitor) RefreshControlPlane(ctx context.Context, cluster string, revision string) error {
+	refreshKey := controlPlaneKey(cluster, revision)
+	refreshed, err := p.refresh(ctx, func(_, key string, _ time.Time) bool { return key == refreshKey })
+	if err != nil {
+		return err
+	}
+
+	if refreshed == 0 {
+		return kubernetes.NewNotFound(revision, "Kiali", "ControlPlane")
+	}
+
+	return nil
+}
+
+// NotifyConfigChange speeds up the polling of the cluster's controlplanes for a while
+// so that the proxy status reflects the istio config written through Kiali sooner.
+func (p *controlPlaneMonitor) NotifyConfigChange(cluster string) {
+	p.configWrites.Set(cluster, time.Now())
+}
+
+// isDue returns true when the controlplane should be polled. Must be called with the refreshLock held.
+func (p *controlPlaneMonitor) isDue(cluster, key string, now time.Time) bool {
+	schedule, found := p.schedules[key]
+	if !found {
+		return true
+	}
+
+	if !now.Before(schedule.nextPoll) {
+		return true
+	}
+
+	if lastWrite, found := p.configWrites.Get(cluster); found && now.Sub(lastWrite) < configWriteSpeedupDuration {
+		return now.Sub(schedule.lastPoll) >= p.pollingInterval/configWriteSpeedupFactor
+	}
+
+	return false
+}
+
+// scheduleNextPoll resets the controlplane to the configured interval when its proxy status changed and
+// doubles it otherwise. Must be called with the refreshLock held.
+func (p *controlPlaneMonitor) scheduleNextPoll(key string, changed bool, now time.Time) {
+	schedule, found := p.schedules[key]
+	if !found {
+		schedule = &controlPlaneSchedule{}
+		p.schedules[key] = schedule
+	}
+
+	if !found || changed {
+		schedule.interval = p.pollingInterval
+	} else {
+		schedule.interval = min(schedule.interval*2, p.pollingInterval*maxPollingBackoffFactor)
+	}
+	schedule.lastPoll = now
+	schedule.nextPoll = now.Add(wait.Jitter(schedule.interval, pollingJitterFactor))
+}
+
+// refresh scrapes the controlplanes selected by shouldRefresh and updates the kialiCache
+// with the latest results of every controlplane. Returns how many controlplanes were selected.
+func (p *controlPlaneMonitor) refresh(ctx context.Context, shouldRefresh func(cluster, key string, now time.Time) bool) (int, error) {
+	p.refreshLock.Lock()
+	defer p.refreshLock.Unlock()
+
 	ctx, cancel := context.WithTimeout(ctx, p.pollingInterval)
 	defer cancel()
 
 	mesh, err := p.meshService.GetMesh(ctx)
 	if err != nil {
-		return fmt.Errorf("unable to get mesh when refreshing istio cache: %s", err)
+		return 0, fmt.Errorf("unable to get mesh when refreshing istio cache: %s", err)
 	}
 
 	// Get the list of controlplanes we are polling.
+	now := time.Now()
+	currentControlPlanes := map[string]bool{}
+	currentClusters := map[string]bool{}
 	revisionsPerCluster := map[string][]models.ControlPlane{}
+	selected := 0
 	for _, controlPlane := range mesh.ControlPlanes {
 		clusterName := controlPlane.Cluster.Name
-		revisionsPerCluster[clusterName] = append(revisionsPerCluster[clusterName], controlPlane)
+		key := controlPlaneKey(clusterName, controlPlane.Revision)
+		currentControlPlanes[key] = true
+		currentClusters[clusterName] = true
+		if shouldRefresh(clusterName, key, now) {
+			revisionsPerCluster[clusterName] = append(revisionsPerCluster[clusterName], controlPlane)
+			selected++
+		}
+	}
+
+	// Forget about the controlplanes and clusters that are gone.
+	for key := range p.schedules {
+		if !currentControlPlanes[key] {
+			delete(p.schedules, key)
+			delete(p.proxyStatus, key)
+			delete(p.configDistribution, key)
+		}
+	}
+	for cluster := range p.registryStatus {
+		if !currentClusters[cluster] {
+			delete(p.registryStatus, cluster)
+			delete(p.trustBundles, cluster)
+		}
+	}
+
+	if selected == 0 {
+		return 0, nil
 	}
 
+	log.Debug("Scraping istiod for debug info")
+
 	// Proxy status endpoint has unique results per controlplane whereas services/config are duplicated across
 	// all controlplanes for that cluster so we'll get the proxy status per controlplane e.g. from both istiod-rev-1
 	// and istiod-rev-2 but the services will only be gotten from one of the istiods.
-	var proxyStatus []*kubernetes.ProxyStatus
-	registryStatus := make(map[string]*kubernetes.RegistryStatus)
 	for cluster, controlPlanes := range revisionsPerCluster {
 		client := p.clientFactory.GetSAClient(cluster)
 		if client == nil {
@@ -96,12 +264,31 @@ func (p *controlPlaneMonitor) RefreshIstioCache(ctx context.Context) error {
 		interval := p.pollingInterval / 2
 
 		for _, controlPlane := range controlPlanes {
-			pstatus, err := p.getProxyStatusWithRetry(ctx, interval, client, controlPlane.Revision, controlPlane.IstiodNamespace)
+			key := controlPlaneKey(cluster, controlPlane.Revision)
+			pstatus, syncHash, err := p.getProxyStatusWithRetry(ctx, interval, client, controlPlane.Revision, controlPlane.IstiodNamespace)
 			if err != nil {
 				log.Warningf("Unable to get proxy status from istiod for revision: [%s] and cluster: [%s]. Proxy status may be stale: %s", controlPlane.Revision, client.ClusterInfo().Name, err)
+				// Errors are likely transient so try again soon.
+				p.scheduleNextPoll(key, true, now)
 				continue
 			}
-			proxyStatus = append(proxyStatus, pstatus...)
+			changed := p.schedules[key] == nil || p.schedules[key].syncHash != syncHash
+			p.scheduleNextPoll(key, changed, now)
+			p.schedules[key].syncHash = syncHash
+			p.proxyStatus[key] = pstatus
+
+			if err := p.scrapeIstiodMetrics(client, controlPlane.Revision, controlPlane.IstiodNamespace, now); err != nil {
+				log.Debugf("Unable to scrape metrics from istiod for revision: [%s] and cluster: [%s]: %s", controlPlane.Revision, client.ClusterInfo().Name, err)
+			}
+
+			if p.conf.ExternalServices.Istio.IstiodConfigDistributionEnabled {
+				distribution, err := p.getConfigDistribution(ctx, client, controlPlane.Revision, controlPlane.IstiodNamespace, p.configDistribution[key])
+				if err != nil {
+					log.Warningf("Unable to get config distribution from istiod for revision: [%s] and cluster: [%s]. Config distribution may be stale: %s", controlPlane.Revision, client.ClusterInfo().Name, err)
+					continue
+				}
+				p.configDistribution[key] = distribution
+			}
 		}
 
 		// Services can just be done once per cluster since these are shared across revisions
@@ -109,6 +296,20 @@ func (p *controlPlaneMonitor) RefreshIstioCache(ctx context.Context) error {
 		if len(controlPlanes) > 0 {
 			// Since it doesn't matter what revision we choose, just choose the first one.
 			controlPlane := controlPlanes[0]
+
+			// The trust bundle is shared by all the revisions of the cluster too.
+			trustBundle, err := p.getTrustBundleStatus(client, controlPlane.IstiodNamespace)
+			if err != nil {
+				log.Debugf("Unable to get trust bundle status for cluster: [%s]: %s", client.ClusterInfo().Name, err)
+			} else {
+				p.trustBundles[cluster] = trustBundle
+			}
+
+			// The host catalog replaces the registry services of all the clusters below.
+			if p.conf.ExternalServices.Istio.HostCatalogEnabled {
+				continue
+			}
+
 			status := &kubernetes.RegistryStatus{}
 			services, err := p.getServicesWithRetry(ctx, interval, client, controlPlane.Revision, controlPlane.IstiodNamespace)
 			if err != nil {
@@ -116,14 +317,33 @@ func (p *controlPlaneMonitor) RefreshIstioCache(ctx context.Context) error {
 				continue
 			}
 			status.Services = services
-			registryStatus[cluster] = status
+			p.registryStatus[cluster] = status
 		}
 	}
 
-	p.cache.SetRegistryStatus(registryStatus)
+	if p.conf.ExternalServices.Istio.HostCatalogEnabled {
+		if err := p.refreshHostCatalog(currentClusters); err != nil {
+			log.Warningf("Unable to build the host catalog. Registry services may be stale: %s", err)
+		}
+	}
+
+	var proxyStatus []*kubernetes.ProxyStatus
+	for _, pstatus := range p.proxyStatus {
+		proxyStatus = append(proxyStatus, pstatus...)
+	}
+
+	p.cache.SetRegistryStatus(maps.Clone(p.registryStatus))
+	p.cache.SetTrustBundleStatus(maps.Clone(p.trustBundles))
 	p.cache.SetPodProxyStatus(proxyStatus)
+	if p.conf.ExternalServices.Istio.IstiodConfigDistributionEnabled {
+		var configDistribution []*kubernetes.ConfigDistribution
+		for _, distribution := range p.configDistribution {
+			configDistribution = append(configDistribution, distribution...)
+		}
+		p.cache.SetConfigDistribution(configDistribution)
+	}
 
-	return nil
+	return selected, nil
 }
 
 func (p *controlPlaneMonitor) PollIstiodForProxyStatus(ctx context.Context) {
@@ -135,14 +355,18 @@ func (p *controlPlaneMonitor) PollIstiodForProxyStatus(ctx context.Context) {
 		log.Errorf("Unable to refresh istio cache: %s", err)
 	}
 
+	// Wake up often enough to honor the faster polling after config writes. Only the
+	// controlplanes that are due get polled on each tick.
+	tick := p.pollingInterval / configWriteSpeedupFactor
+
 	go func() {
 		for {
 			select {
 			case <-ctx.Done():
 				log.Debug("Stopping polling for istiod(s) proxy status")
 				return
-			case <-time.After(p.pollingInterval):
-				if err := p.RefreshIstioCache(ctx); err != nil {
+			case <-time.After(tick):
+				if _, err := p.refresh(ctx, p.isDue); err != nil {
 					log.Errorf("Unable to refresh istio cache: %s", err)
 				}
 			}
@@ -150,15 +374,16 @@ func (p *controlPlaneMonitor) PollIstiodForProxyStatus(ctx context.Context) {
 	}()
 }
 
-func (p *controlPlaneMonitor) getProxyStatusWithRetry(ctx context.Context, interval time.Duration, client kubernetes.ClientInterface, revision string, namespace string) ([]*kubernetes.ProxyStatus, error) {
+func (p *controlPlaneMonitor) getProxyStatusWithRetry(ctx context.Context, interval time.Duration, client kubernetes.ClientInterface, revision string, namespace string) ([]*kubernetes.ProxyStatus, uint64, error) {
 	var (
 		proxyStatus []*kubernetes.ProxyStatus
+		syncHash    uint64
 		err         error
 	)
 	retryErr := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
 		log.Tracef("Getting proxy status from istiod in cluster [%s] for revision [%s]", client.ClusterInfo().Name, revision)
 		var err error
-		proxyStatus, err = p.getProxyStatus(client, revision, namespace)
+		proxyStatus, syncHash, err = p.getProxyStatus(client, revision, namespace)
 		if err != nil {
 			return false, nil
 		}
@@ -167,10 +392,10 @@ func (p *controlPlaneMonitor) getProxyStatusWithRetry(ctx context.Context, inter
 	})
 	if retryErr != nil {
 		log.Warningf("Error getting proxy status from istiod. Proxy status may be stale. Err: %v", err)
-		return nil, err
+		return nil, 0, retryErr
 	}
 
-	return proxyStatus, nil
+	return proxyStatus, syncHash, nil
 }
 
 func (p *controlPlaneMonitor) getServicesWithRetry(ctx context.Context, interval time.Duration, client kubernetes.ClientInterface, revision string, namespace string) ([]*kubernetes.RegistryService, error) {
@@ -202,37 +427,19 @@ func joinURL(base, path string) string {
 	return base + "/" + path
 }
 
-func getRequest(url string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := http.DefaultClient.Do(req)
+func (p *controlPlaneMonitor) getIstiodDebugStatus(client kubernetes.ClientInterface, revision string, namespace string, debugPath string) (map[string][]byte, error) {
+	istiods, err := p.reachableIstiods(client, revision, namespace)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body when getting config from remote istiod. Err: %s", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad response when getting config from remote istiod. Status: %s. Body: %s", resp.Status, body)
-	}
-
-	return body, err
+	return p.forwardIstiodDebugRequest(client, istiods, debugPath)
 }
 
-func (p *controlPlaneMonitor) getIstiodDebugStatus(client kubernetes.ClientInterface, revision string, namespace string, debugPath string) (map[string][]byte, error) {
+// reachableIstiods returns the istiods of the revision Kiali can port forward to.
+func (p *controlPlaneMonitor) reachableIstiods(client kubernetes.ClientInterface, revision string, namespace string) (kubernetes.IstioComponentStatus, error) {
 	// Check if the kube-api has proxy access to pods in the istio-system
 	// https://github.com/kiali/kiali/issues/3494#issuecomment-772486224
-	status, err := p.canConnectToIstiodForRevision(client, revision, namespace)
+	status, err := p.CanConnectToIstiodForRevision(client, revision, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to Istiod pods on cluster [%s] for revision [%s]: %s", client.ClusterInfo().Name, revision, err.Error())
 	}
@@ -249,13 +456,19 @@ func (p *controlPlaneMonitor) getIstiodDebugStatus(client kubernetes.ClientInter
 			"Make sure your Kubernetes API server has access to the Istio control plane through 8080 port")
 	}
 
+	// An istiod reported unhealthy because of its metrics is still serving, keep scraping it
+	// or its metrics would never be refreshed.
 	var healthyIstiods kubernetes.IstioComponentStatus
 	for _, istiod := range status {
-		if istiod.Status == kubernetes.ComponentHealthy {
+		if istiod.Status != kubernetes.ComponentUnreachable {
 			healthyIstiods = append(healthyIstiods, istiod)
 		}
 	}
+	return healthyIstiods, nil
+}
 
+// forwardIstiodDebugRequest calls the debug endpoint of each of the istiods through a port forward.
+func (p *controlPlaneMonitor) forwardIstiodDebugRequest(client kubernetes.ClientInterface, healthyIstiods kubernetes.IstioComponentStatus, debugPath string) (map[string][]byte, error) {
 	wg := sync.WaitGroup{}
 	wg.Add(len(healthyIstiods))
 	errChan := make(chan error, len(healthyIstiods))
@@ -304,28 +517,15 @@ func (p *controlPlaneMonitor) getIstiodDebugStatus(client kubernetes.ClientInter
 	}
 }
 
-// CanConnectToIstiod checks if Kiali can reach the istiod pod(s) via port
+// CanConnectToIstiodForRevision checks if Kiali can reach the istiod pod(s) via port
 // fowarding through the k8s api server or via http if the registry is
 // configured with a remote url. An error does not indicate that istiod
 // cannot be reached. The kubernetes.IstioComponentStatus must be checked.
-func (p *controlPlaneMonitor) CanConnectToIstiodForRevision(client kubernetes.ClientInterface, revision string) (kubernetes.IstioComponentStatus, error) {
-	return p.canConnectToIstiodForRevision(client, revision, p.conf.IstioNamespace)
-}
-
-// CanConnectToIstiod checks if Kiali can reach the istiod pod(s) via port
-// fowarding through the k8s api server or via http if the registry is
-// configured with a remote url. An error does not indicate that istiod
-// cannot be reached. The kubernetes.IstioComponentStatus must be checked.
-func (p *controlPlaneMonitor) canConnectToIstiodForRevision(client kubernetes.ClientInterface, revision string, namespace string) (kubernetes.IstioComponentStatus, error) {
-	if p.conf.ExternalServices.Istio.Registry != nil && p.conf.ExternalServices.Istio.Registry.IstiodURL != "" {
-		istiodURL := p.conf.ExternalServices.Istio.Registry.IstiodURL
-		// Being able to hit /debug doesn't necessarily mean we are authorized to hit the others.
-		url := joinURL(istiodURL, "/debug")
-		if _, err := getRequest(url); err != nil {
-			log.Warningf("Kiali can't connect to remote Istiod: %s", err)
-			return kubernetes.IstioComponentStatus{{Name: istiodURL, Status: kubernetes.ComponentUnreachable, IsCore: true}}, nil
-		}
-		return kubernetes.IstioComponentStatus{{Name: istiodURL, Status: kubernetes.ComponentHealthy, IsCore: true}}, nil
+// The istiods are looked up in the namespace of their controlplane, an
+// empty revision selects the istiods without a revision label.
+func (p *controlPlaneMonitor) CanConnectToIstiodForRevision(client kubernetes.ClientInterface, revision string, namespace string) (kubernetes.IstioComponentStatus, error) {
+	if p.istiodAccessMode() != config.IstiodAccessModePortForward {
+		return p.remoteIstiodStatus(revision, namespace), nil
 	}
 
 	kubeCache, err := p.cache.GetKubeCache(client.ClusterInfo().Name)
@@ -333,12 +533,12 @@ func (p *controlPlaneMonitor) canConnectToIstiodForRevision(client kubernetes.Cl
 		return nil, err
 	}
 
-	podLabels := map[string]string{
-		"app":              "istiod",
-		IstioRevisionLabel: revision,
+	selector := labels.Set{"app": "istiod"}.AsSelector()
+	revisionRequirement, err := istiodRevisionRequirement(revision)
+	if err != nil {
+		return nil, err
 	}
-
-	istiods, err := kubeCache.GetPods(namespace, labels.Set(podLabels).String())
+	istiods, err := kubeCache.GetPods(namespace, selector.Add(*revisionRequirement).String())
 	if err != nil {
 		return nil, err
 	}
@@ -355,10 +555,12 @@ func (p *controlPlaneMonitor) canConnectToIstiodForRevision(client kubernetes.Cl
 	syncChan := make(chan kubernetes.ComponentStatus, len(healthyIstiods))
 
 	for _, istiod := range healthyIstiods {
-		go func(name, namespace string) {
+		go func(istiod *corev1.Pod) {
 			defer wg.Done()
 
+			name, namespace := istiod.Name, istiod.Namespace
 			status := kubernetes.ComponentHealthy
+			var reasons, warnings []string
 			// The 8080 port is not accessible from outside of the pod. However, it is used for kubernetes to do the live probes.
 			// Using the proxy method to make sure that K8s API has access to the Istio Control Plane namespace.
 			// By proxying one Istiod, we ensure that the following connection is allowed:
@@ -368,6 +570,12 @@ func (p *controlPlaneMonitor) canConnectToIstiodForRevision(client kubernetes.Cl
 			if err != nil {
 				log.Warningf("Unable to get ready status of istiod: %s/%s. Err: %s", namespace, name, err)
 				status = kubernetes.ComponentUnreachable
+			} else {
+				if reasons = p.istiodUnhealthyReasons(client.ClusterInfo().Name, revision, istiod); len(reasons) > 0 {
+					status = kubernetes.ComponentUnhealthy
+				}
+				// A root of trust about to expire or being rotated does not stop istiod from serving.
+				warnings = p.trustBundleWarnings(client.ClusterInfo().Name)
 			}
 
 			syncChan <- kubernetes.ComponentStatus{
@@ -375,8 +583,10 @@ func (p *controlPlaneMonitor) canConnectToIstiodForRevision(client kubernetes.Cl
 				Namespace: namespace,
 				Status:    status,
 				IsCore:    true,
+				Reasons:   reasons,
+				Warnings:  warnings,
 			}
-		}(istiod.Name, istiod.Namespace)
+		}(istiod)
 	}
 
 	wg.Wait()
@@ -390,15 +600,8 @@ func (p *controlPlaneMonitor) canConnectToIstiodForRevision(client kubernetes.Cl
 }
 
 func (p *controlPlaneMonitor) CanConnectToIstiod(client kubernetes.ClientInterface) (kubernetes.IstioComponentStatus, error) {
-	if p.conf.ExternalServices.Istio.Registry != nil && p.conf.ExternalServices.Istio.Registry.IstiodURL != "" {
-		istiodURL := p.conf.ExternalServices.Istio.Registry.IstiodURL
-		// Being able to hit /debug doesn't necessarily mean we are authorized to hit the others.
-		url := joinURL(istiodURL, "/debug")
-		if _, err := getRequest(url); err != nil {
-			log.Warningf("Kiali can't connect to remote Istiod: %s", err)
-			return kubernetes.IstioComponentStatus{{Name: istiodURL, Status: kubernetes.ComponentUnreachable, IsCore: true}}, nil
-		}
-		return kubernetes.IstioComponentStatus{{Name: istiodURL, Status: kubernetes.ComponentHealthy, IsCore: true}}, nil
+	if p.istiodAccessMode() == config.IstiodAccessModeURL {
+		return p.remoteIstiodStatus("", p.conf.IstioNamespace), nil
 	}
 
 	kubeCache, err := p.cache.GetKubeCache(client.ClusterInfo().Name)
@@ -412,7 +615,16 @@ func (p *controlPlaneMonitor) CanConnectToIstiod(client kubernetes.ClientInterfa
 		return nil, err
 	}
 
-	return p.CanConnectToIstiodForRevision(client, istiod.Labels[IstioRevisionLabel])
+	return p.CanConnectToIstiodForRevision(client, istiod.Labels[IstioRevisionLabel], p.conf.IstioNamespace)
+}
+
+// istiodRevisionRequirement selects the istiods of a revision: labeled with it or, for an empty revision, without a
+// revision label.
+func istiodRevisionRequirement(revision string) (*labels.Requirement, error) {
+	if revision == "" {
+		return labels.NewRequirement(IstioRevisionLabel, selection.DoesNotExist, nil)
+	}
+	return labels.NewRequirement(IstioRevisionLabel, selection.Equals, []string{revision})
 }
 
 func parseProxyStatus(statuses map[string][]byte) ([]*kubernetes.ProxyStatus, error) {
@@ -431,50 +643,243 @@ func parseProxyStatus(statuses map[string][]byte) ([]*kubernetes.ProxyStatus, er
 	return fullStatus, nil
 }
 
-func (p *controlPlaneMonitor) getProxyStatus(client kubernetes.ClientInterface, revision string, namespace string) ([]*kubernetes.ProxyStatus, error) {
-	const synczPath = "/debug/syncz"
-	var result map[string][]byte
-
-	if externalConf := p.conf.ExternalServices.Istio.Registry; externalConf != nil && externalConf.IstiodURL != "" {
-		url := joinURL(externalConf.IstiodURL, synczPath)
-		r, err := getRequest(url)
+// getIstiodDebugEndpoint fetches the given debug path from either the istiod service or remote
+// istiod url, depending on the access mode, or from every healthy istiod pod of the revision.
+// The results are key'd off the pilot that served them.
+func (p *controlPlaneMonitor) getIstiodDebugEndpoint(client kubernetes.ClientInterface, revision string, namespace string, debugPath string) (map[string][]byte, error) {
+	if p.istiodAccessMode() != config.IstiodAccessModePortForward {
+		r, err := p.getRequest(p.istiodDebugURL(revision, namespace, debugPath))
 		if err != nil {
-			log.Errorf("Failed to get Istiod info from remote endpoint %s error: %s", synczPath, err)
+			log.Errorf("Failed to get Istiod info from remote endpoint %s error: %s", debugPath, err)
 			return nil, err
 		}
-		result = map[string][]byte{"remote": r}
-	} else {
-		debugStatus, err := p.getIstiodDebugStatus(client, revision, namespace, synczPath)
-		if err != nil {
-			log.Errorf("Failed to call Istiod endpoint %s error: %s", synczPath, err)
-			return nil, err
-		}
-		result = debugStatus
+		return map[string][]byte{"remote": r}, nil
+	}
+
+	debugStatus, err := p.getIstiodDebugStatus(client, revision, namespace, debugPath)
+	if err != nil {
+		log.Errorf("Failed to call Istiod endpoint %s error: %s", debugPath, err)
+		return nil, err
+	}
+	return debugStatus, nil
+}
+
+// getProxyStatus returns the parsed syncz payload along with a hash of it
+// that tells whether anything changed since the previous poll.
+func (p *controlPlaneMonitor) getProxyStatus(client kubernetes.ClientInterface, revision string, namespace string) ([]*kubernetes.ProxyStatus, uint64, error) {
+	const synczPath = "/debug/syncz"
+	result, err := p.getIstiodDebugEndpoint(client, revision, namespace, synczPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	proxyStatus, err := parseProxyStatus(result)
+	if err != nil {
+		return nil, 0, err
 	}
-	return parseProxyStatus(result)
+	return proxyStatus, hashDebugResult(result), nil
+}
+
+// hashDebugResult hashes the payloads returned by each pilot in a stable order.
+func hashDebugResult(result map[string][]byte) uint64 {
+	pilots := maps.Keys(result)
+	sort.Strings(pilots)
+
+	h := fnv.New64a()
+	for _, pilot := range pilots {
+		h.Write([]byte(pilot))
+		h.Write(result[pilot])
+	}
+	return h.Sum64()
 }
 
 func (p *controlPlaneMonitor) getRegistryServices(client kubernetes.ClientInterface, revision string, namespace string) ([]*kubernetes.RegistryService, error) {
 	const registryzPath = "/debug/registryz"
-	var result map[string][]byte
+	result, err := p.getIstiodDebugEndpoint(client, revision, namespace, registryzPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseRegistryServices(result)
+}
+
+// distributedConfig identifies an Istio config whose distribution is tracked by istiod.
+type distributedConfig struct {
+	objectType string
+	gvk        schema.GroupVersionKind
+	namespace  string
+	name       string
+}
+
+// istiodResourceID returns the key istiod uses to track the config in its ledger:
+// <group>/<version>/<kind>/<namespace>/<name>
+func (d distributedConfig) istiodResourceID() string {
+	return strings.Join([]string{d.gvk.Group, d.gvk.Version, d.gvk.Kind, d.namespace, d.name}, "/")
+}
+
+// listDistributedConfigs returns the Istio configs of the cluster that istiod pushes to the proxies.
+// Only networking configs are considered since these are the ones that end up in the xDS resources.
+func listDistributedConfigs(kubeCache cache.KubeCache) ([]distributedConfig, error) {
+	var configs []distributedConfig
+	add := func(objectType string, gv schema.GroupVersion, kind string, meta meta_v1.ObjectMeta) {
+		configs = append(configs, distributedConfig{
+			objectType: objectType,
+			gvk:        gv.WithKind(kind),
+			namespace:  meta.Namespace,
+			name:       meta.Name,
+		})
+	}
+
+	drs, err := kubeCache.GetDestinationRules(meta_v1.NamespaceAll, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, dr := range drs {
+		add(kubernetes.DestinationRules, kubernetes.NetworkingGroupVersionV1Beta1, kubernetes.DestinationRuleType, dr.ObjectMeta)
+	}
+
+	gws, err := kubeCache.GetGateways(meta_v1.NamespaceAll, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, gw := range gws {
+		add(kubernetes.Gateways, kubernetes.NetworkingGroupVersionV1Beta1, kubernetes.GatewayType, gw.ObjectMeta)
+	}
+
+	ses, err := kubeCache.GetServiceEntries(meta_v1.NamespaceAll, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, se := range ses {
+		add(kubernetes.ServiceEntries, kubernetes.NetworkingGroupVersionV1Beta1, kubernetes.ServiceEntryType, se.ObjectMeta)
+	}
+
+	sidecars, err := kubeCache.GetSidecars(meta_v1.NamespaceAll, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range sidecars {
+		add(kubernetes.Sidecars, kubernetes.NetworkingGroupVersionV1Beta1, kubernetes.SidecarType, sc.ObjectMeta)
+	}
 
-	if externalConf := p.conf.ExternalServices.Istio.Registry; externalConf != nil && externalConf.IstiodURL != "" {
-		url := joinURL(externalConf.IstiodURL, registryzPath)
-		r, err := getRequest(url)
+	vss, err := kubeCache.GetVirtualServices(meta_v1.NamespaceAll, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, vs := range vss {
+		add(kubernetes.VirtualServices, kubernetes.NetworkingGroupVersionV1Beta1, kubernetes.VirtualServiceType, vs.ObjectMeta)
+	}
+
+	return configs, nil
+}
+
+// getConfigDistribution scrapes the distribution state of every networking config of the cluster
+// from the istiods of the given revision. istiod only answers for a single resource per request
+// so this is opt-in through the IstiodConfigDistributionEnabled setting. The previous distribution
+// of the controlplane is kept for the configs that couldn't be scraped.
+func (p *controlPlaneMonitor) getConfigDistribution(ctx context.Context, client kubernetes.ClientInterface, revision string, namespace string, previous []*kubernetes.ConfigDistribution) ([]*kubernetes.ConfigDistribution, error) {
+	const configDistributionPath = "/debug/config_distribution"
+	cluster := client.ClusterInfo().Name
+
+	kubeCache, err := p.cache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := listDistributedConfigs(kubeCache)
+	if err != nil {
+		return nil, err
+	}
+
+	// The connectivity to the istiods is checked once for all the configs.
+	fetch := func(debugPath string) (map[string][]byte, error) {
+		r, err := p.getRequest(p.istiodDebugURL(revision, namespace, debugPath))
 		if err != nil {
-			log.Errorf("Failed to get Istiod info from remote endpoint %s error: %s", registryzPath, err)
 			return nil, err
 		}
-		result = map[string][]byte{"remote": r}
-	} else {
-		debugStatus, err := p.getIstiodDebugStatus(client, revision, namespace, registryzPath)
+		return map[string][]byte{"remote": r}, nil
+	}
+	if p.istiodAccessMode() == config.IstiodAccessModePortForward {
+		istiods, err := p.reachableIstiods(client, revision, namespace)
 		if err != nil {
-			log.Errorf("Failed to call Istiod endpoint %s error: %s", registryzPath, err)
 			return nil, err
 		}
-		result = debugStatus
+		fetch = func(debugPath string) (map[string][]byte, error) {
+			return p.forwardIstiodDebugRequest(client, istiods, debugPath)
+		}
 	}
-	return parseRegistryServices(result)
+
+	// A resource istiod fails to answer for keeps its previous distribution until the next poll.
+	results := make([]*kubernetes.ConfigDistribution, len(configs))
+	sem := make(chan struct{}, configDistributionConcurrency)
+	wg := sync.WaitGroup{}
+	for i, cfg := range configs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, cfg distributedConfig) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			debugPath := configDistributionPath + "?" + url.Values{"resource": []string{cfg.istiodResourceID()}}.Encode()
+			result, err := fetch(debugPath)
+			if err != nil {
+				log.Debugf("Unable to get the config distribution of [%s] from istiod: %s", cfg.istiodResourceID(), err)
+				return
+			}
+
+			proxies, err := parseConfigDistribution(result)
+			if err != nil {
+				log.Debugf("Unable to parse the config distribution of [%s]: %s", cfg.istiodResourceID(), err)
+				return
+			}
+
+			results[i] = &kubernetes.ConfigDistribution{
+				Cluster:    cluster,
+				Namespace:  cfg.namespace,
+				ObjectType: cfg.objectType,
+				Name:       cfg.name,
+				Proxies:    proxies,
+			}
+		}(i, cfg)
+	}
+	wg.Wait()
+
+	previousDistribution := make(map[distributedConfig]*kubernetes.ConfigDistribution, len(previous))
+	for _, d := range previous {
+		previousDistribution[distributedConfig{objectType: d.ObjectType, namespace: d.Namespace, name: d.Name}] = d
+	}
+
+	var distribution []*kubernetes.ConfigDistribution
+	for i, d := range results {
+		if d == nil {
+			d = previousDistribution[distributedConfig{objectType: configs[i].objectType, namespace: configs[i].namespace, name: configs[i].name}]
+		}
+		if d != nil {
+			distribution = append(distribution, d)
+		}
+	}
+
+	return distribution, ctx.Err()
+}
+
+func parseConfigDistribution(distributions map[string][]byte) ([]*kubernetes.SyncedVersions, error) {
+	var fullDistribution []*kubernetes.SyncedVersions
+	for pilot, distribution := range distributions {
+		var sv []*kubernetes.SyncedVersions
+		if err := json.Unmarshal(distribution, &sv); err != nil {
+			return nil, err
+		}
+		for _, s := range sv {
+			s.Pilot = pilot
+		}
+		fullDistribution = append(fullDistribution, sv...)
+	}
+	return fullDistribution, nil
 }
 
 func parseRegistryServices(registries map[string][]byte) ([]*kubernetes.RegistryService, error) {
@@ -2,18 +2,31 @@ package business
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kiali/kiali/config"
@@ -70,6 +83,10 @@ func istiodTestServer(t *testing.T) *httptest.Server {
 			file = "../tests/data/registry/registry-registryz.json"
 		case "/debug/syncz":
 			file = "../tests/data/registry/registry-syncz.json"
+		case "/debug/config_distribution":
+			file = "../tests/data/registry/registry-config-distribution.json"
+		case "/metrics":
+			file = "../tests/data/registry/istiod-metrics.txt"
 		case "/debug":
 			w.WriteHeader(http.StatusOK)
 			return
@@ -182,7 +199,7 @@ func TestConnectToIstiodWithRevisionExternalURL(t *testing.T) {
 	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
 	cpm := NewControlPlaneMonitor(cache, cf, *conf, &mesh)
 
-	status, err := cpm.CanConnectToIstiodForRevision(k8s, "default")
+	status, err := cpm.CanConnectToIstiodForRevision(k8s, "default", "istio-system")
 	require.NoError(err)
 	require.Len(status, 1)
 	assert.Equal(kubernetes.ComponentHealthy, status[0].Status)
@@ -346,6 +363,161 @@ trustDomain: cluster.local
 	assert.Equal("Kubernetes", podProxyStatus.ClusterID)
 }
 
+func TestRefreshIstioCacheScrapesConfigDistribution(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	conf.ExternalServices.Istio.IstiodConfigDistributionEnabled = true
+	kubernetes.SetConfig(t, *conf)
+
+	vs := &networking_v1beta1.VirtualService{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", ResourceVersion: "1234"},
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		fakeIstioConfigMap("default"),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		vs,
+	)
+	// RefreshIstioCache relies on this being set.
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	testServer := istiodTestServer(t)
+	fakeForwarder := &fakeForwarder{
+		ClientInterface: k8s,
+		testURL:         testServer.URL,
+	}
+
+	cache := SetupBusinessLayer(t, fakeForwarder, *conf)
+
+	cf := kubetest.NewK8SClientFactoryMock(fakeForwarder)
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = fakeForwarder
+	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
+	cpm := NewControlPlaneMonitor(cache, cf, *conf, &mesh)
+
+	require.NoError(cpm.RefreshIstioCache(context.TODO()))
+
+	distribution := cache.GetConfigDistribution("Kubernetes", "bookinfo", kubernetes.VirtualServices, "reviews")
+	require.NotNil(distribution)
+	require.Len(distribution.Proxies, 2)
+
+	var notAcked []string
+	for _, proxy := range distribution.Proxies {
+		assert.Equal("istiod-123", proxy.Pilot)
+		if !proxy.HasAcked(vs.ResourceVersion) {
+			notAcked = append(notAcked, proxy.ProxyID)
+		}
+	}
+	assert.Equal([]string{"details-v1-7d4d9d5fcb-2dqrx.bookinfo"}, notAcked)
+}
+
+func TestGetConfigDistributionSkipsFailedResources(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+		&networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"}},
+		&networking_v1beta1.DestinationRule{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+	)
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	var readyChecks atomic.Int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ready":
+			readyChecks.Add(1)
+		case "/debug/config_distribution":
+			if strings.HasSuffix(r.URL.Query().Get("resource"), "/ratings") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write(kubernetes.ReadFile(t, "../tests/data/registry/registry-config-distribution.json"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+	fakeForwarder := &fakeForwarder{ClientInterface: k8s, testURL: testServer.URL}
+
+	cache := SetupBusinessLayer(t, fakeForwarder, *conf)
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: fakeForwarder}
+	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
+	cpm := NewControlPlaneMonitor(cache, kubetest.NewK8SClientFactoryMock(fakeForwarder), *conf, &mesh)
+
+	distribution, err := cpm.getConfigDistribution(context.TODO(), fakeForwarder, "default", "istio-system", nil)
+	require.NoError(err)
+
+	// The ratings VirtualService istiod failed to answer for is skipped.
+	var names []string
+	for _, d := range distribution {
+		names = append(names, d.ObjectType+"/"+d.Name)
+	}
+	assert.ElementsMatch([]string{kubernetes.VirtualServices + "/reviews", kubernetes.DestinationRules + "/reviews"}, names)
+	assert.Equal(int32(1), readyChecks.Load())
+
+	// Its last known distribution is kept instead.
+	ratings := &kubernetes.ConfigDistribution{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo", ObjectType: kubernetes.VirtualServices, Name: "ratings"}
+	distribution, err = cpm.getConfigDistribution(context.TODO(), fakeForwarder, "default", "istio-system", append(distribution, ratings))
+	require.NoError(err)
+	require.Len(distribution, 3)
+	assert.Contains(distribution, ratings)
+}
+
+func TestRefreshControlPlane(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		fakeIstioConfigMap("default"),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+	)
+	// RefreshIstioCache relies on this being set.
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	testServer := istiodTestServer(t)
+	fakeForwarder := &fakeForwarder{
+		ClientInterface: k8s,
+		testURL:         testServer.URL,
+	}
+
+	cache := SetupBusinessLayer(t, fakeForwarder, *conf)
+
+	cf := kubetest.NewK8SClientFactoryMock(fakeForwarder)
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = fakeForwarder
+	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
+	cpm := NewControlPlaneMonitor(cache, cf, *conf, &mesh)
+
+	err := cpm.RefreshControlPlane(context.TODO(), conf.KubernetesConfig.ClusterName, "canary")
+	require.Error(err)
+	assert.True(errors.IsNotFound(err))
+	assert.Nil(cache.GetRegistryStatus(conf.KubernetesConfig.ClusterName))
+
+	require.NoError(cpm.RefreshControlPlane(context.TODO(), conf.KubernetesConfig.ClusterName, "default"))
+	require.NotNil(cache.GetRegistryStatus(conf.KubernetesConfig.ClusterName))
+	assert.NotNil(cache.GetPodProxyStatus("Kubernetes", "beta", "b-client-8b97458bb-tghx9"))
+}
+
 func TestCancelingContextEndsPolling(t *testing.T) {
 	assert := assert.New(t)
 
@@ -474,15 +646,210 @@ func TestRefreshIstioCacheWithMultipleRevisions(t *testing.T) {
 	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
 	cpm := NewControlPlaneMonitor(cache, cf, *conf, &mesh)
 
-	defaultStatuses, err := cpm.CanConnectToIstiodForRevision(fakeForwarder, "default")
+	defaultStatuses, err := cpm.CanConnectToIstiodForRevision(fakeForwarder, "default", "istio-system")
 	require.NoError(err)
 	for _, status := range defaultStatuses {
 		require.NotEqual(istiod_1_19_pod.Name, status.Name)
 	}
 
-	istiod_1_19_statuses, err := cpm.CanConnectToIstiodForRevision(fakeForwarder, "1-19-0")
+	istiod_1_19_statuses, err := cpm.CanConnectToIstiodForRevision(fakeForwarder, "1-19-0", "istio-system")
 	require.NoError(err)
 	for _, status := range istiod_1_19_statuses {
 		require.NotEqual(istiod_1_19_pod.Name, status.Name)
 	}
 }
+
+func TestScheduleNextPollBacksOffWhileProxyStatusUnchanged(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	cpm := NewControlPlaneMonitor(nil, nil, *conf, nil)
+	key := controlPlaneKey("east", "default")
+	now := time.Now()
+
+	cpm.scheduleNextPoll(key, true, now)
+	require.Equal(cpm.pollingInterval, cpm.schedules[key].interval)
+
+	cpm.scheduleNextPoll(key, false, now)
+	require.Equal(2*cpm.pollingInterval, cpm.schedules[key].interval)
+
+	// Backoff is capped.
+	for i := 0; i < 5; i++ {
+		cpm.scheduleNextPoll(key, false, now)
+	}
+	maxInterval := maxPollingBackoffFactor * cpm.pollingInterval
+	require.Equal(maxInterval, cpm.schedules[key].interval)
+
+	// Jitter only ever delays the next poll.
+	nextPoll := cpm.schedules[key].nextPoll
+	require.False(nextPoll.Before(now.Add(maxInterval)))
+	require.False(nextPoll.After(now.Add(maxInterval + time.Duration(pollingJitterFactor*float64(maxInterval)))))
+
+	// Any change goes back to the configured interval.
+	cpm.scheduleNextPoll(key, true, now)
+	require.Equal(cpm.pollingInterval, cpm.schedules[key].interval)
+}
+
+func TestNotifyConfigChangeSpeedsUpPolling(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	cpm := NewControlPlaneMonitor(nil, nil, *conf, nil)
+	eastKey := controlPlaneKey("east", "default")
+	westKey := controlPlaneKey("west", "default")
+	now := time.Now()
+
+	// Controlplanes that were never polled are always due.
+	require.True(cpm.isDue("east", eastKey, now))
+
+	cpm.scheduleNextPoll(eastKey, true, now)
+	cpm.scheduleNextPoll(westKey, true, now)
+	later := now.Add(cpm.pollingInterval / configWriteSpeedupFactor)
+	require.False(cpm.isDue("east", eastKey, later))
+	require.False(cpm.isDue("west", westKey, later))
+
+	cpm.NotifyConfigChange("east")
+	require.True(cpm.isDue("east", eastKey, later))
+	require.False(cpm.isDue("west", westKey, later))
+
+	// Without further writes the speed up eventually wears off.
+	afterSpeedup := now.Add(configWriteSpeedupDuration + time.Second)
+	cpm.scheduleNextPoll(eastKey, false, afterSpeedup)
+	require.False(cpm.isDue("east", eastKey, afterSpeedup.Add(cpm.pollingInterval/configWriteSpeedupFactor)))
+}
+
+func TestIstiodDebugURL(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.Registry = &config.RegistryConfig{AccessMode: config.IstiodAccessModeService}
+	cpm := NewControlPlaneMonitor(nil, nil, *conf, nil)
+
+	assert.Equal("http://istiod.istio-system.svc:15014/debug/syncz", cpm.istiodDebugURL("default", "istio-system", "/debug/syncz"))
+	assert.Equal("http://istiod-1-19-0.istio-system.svc:15014/debug/syncz", cpm.istiodDebugURL("1-19-0", "istio-system", "/debug/syncz"))
+
+	conf.ExternalServices.Istio.Registry.ClientCertFile = "/kiali-istiod-certs/tls.crt"
+	conf.ExternalServices.Istio.Registry.ClientKeyFile = "/kiali-istiod-certs/tls.key"
+	cpm = NewControlPlaneMonitor(nil, nil, *conf, nil)
+	assert.Equal("https://istiod.istio-system.svc:15014/debug/syncz", cpm.istiodDebugURL("default", "istio-system", "/debug/syncz"))
+
+	conf.ExternalServices.Istio.Registry = &config.RegistryConfig{IstiodURL: "https://istiod.example.com/"}
+	cpm = NewControlPlaneMonitor(nil, nil, *conf, nil)
+	assert.Equal("https://istiod.example.com/debug/syncz", cpm.istiodDebugURL("default", "istio-system", "/debug/syncz"))
+}
+
+func TestIstiodHTTPClientReloadsClientCert(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	peerCerts := [][]byte{}
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peerCerts = append(peerCerts, r.TLS.PeerCertificates[0].Raw)
+		// A new connection, and handshake, for each request
+		w.Header().Set("Connection", "close")
+	}))
+	testServer.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	testServer.StartTLS()
+	t.Cleanup(testServer.Close)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.Registry = &config.RegistryConfig{
+		AccessMode: config.IstiodAccessModeURL,
+		Auth:       config.Auth{InsecureSkipVerify: true},
+		IstiodURL:  testServer.URL,
+	}
+	conf.ExternalServices.Istio.Registry.ClientCertFile, conf.ExternalServices.Istio.Registry.ClientKeyFile = writeClientCert(t)
+	cpm := NewControlPlaneMonitor(nil, nil, *conf, nil)
+
+	_, err := cpm.getRequest(testServer.URL)
+	require.NoError(err)
+
+	// The certificate is rotated in place
+	rotatedCert, rotatedKey := writeClientCert(t)
+	for src, dst := range map[string]string{rotatedCert: conf.ExternalServices.Istio.Registry.ClientCertFile, rotatedKey: conf.ExternalServices.Istio.Registry.ClientKeyFile} {
+		content, err := os.ReadFile(src)
+		require.NoError(err)
+		require.NoError(os.WriteFile(dst, content, 0o600))
+	}
+	_, err = cpm.getRequest(testServer.URL)
+	require.NoError(err)
+
+	require.Len(peerCerts, 2)
+	assert.NotEqual(peerCerts[0], peerCerts[1])
+	rotated, err := tls.LoadX509KeyPair(rotatedCert, rotatedKey)
+	require.NoError(err)
+	assert.Equal(rotated.Certificate[0], peerCerts[1])
+
+	// The client is built once per monitor and server name
+	client, err := cpm.istiodHTTPClient("")
+	require.NoError(err)
+	sameClient, err := cpm.istiodHTTPClient("")
+	require.NoError(err)
+	assert.Same(client, sameClient)
+	otherClient, err := NewControlPlaneMonitor(nil, nil, *conf, nil).istiodHTTPClient("")
+	require.NoError(err)
+	assert.NotSame(client, otherClient)
+}
+
+// writeClientCert writes a self-signed client certificate and its key to files.
+func writeClientCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kiali"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(t.TempDir(), "tls.crt")
+	keyFile := filepath.Join(t.TempDir(), "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestConnectToIstiodExternalURLWithMTLS(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	testServer.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	testServer.StartTLS()
+	t.Cleanup(testServer.Close)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.Registry = &config.RegistryConfig{
+		AccessMode: config.IstiodAccessModeURL,
+		Auth:       config.Auth{InsecureSkipVerify: true},
+		IstiodURL:  testServer.URL,
+	}
+
+	k8s := kubetest.NewFakeK8sClient(runningIstiodPod())
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	cf := kubetest.NewK8SClientFactoryMock(k8s)
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
+
+	// Without a client certificate the handshake fails.
+	cpm := NewControlPlaneMonitor(cache, cf, *conf, &mesh)
+	status, err := cpm.CanConnectToIstiod(k8s)
+	require.NoError(err)
+	require.Len(status, 1)
+	assert.Equal(kubernetes.ComponentUnreachable, status[0].Status)
+
+	conf.ExternalServices.Istio.Registry.ClientCertFile, conf.ExternalServices.Istio.Registry.ClientKeyFile = writeClientCert(t)
+	cpm = NewControlPlaneMonitor(cache, cf, *conf, &mesh)
+	status, err = cpm.CanConnectToIstiod(k8s)
+	require.NoError(err)
+	require.Len(status, 1)
+	assert.Equal(kubernetes.ComponentHealthy, status[0].Status)
+}
@@ -3,6 +3,7 @@ package business
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -17,8 +18,12 @@ import (
 
 const defaultNamespaceLabel = "namespace"
 
+// dashboardVariableRef matches the references to template variables: $name or ${name}
+var dashboardVariableRef = regexp.MustCompile(`\$\{(\w+)\}|\$(\w+)`)
+
 // DashboardsService deals with fetching dashboards from config
 type DashboardsService struct {
+	cluster         string
 	conf            *config.Config
 	dashboards      map[string]dashboards.MonitoringDashboard
 	globalNamespace string
@@ -27,6 +32,10 @@ type DashboardsService struct {
 	promClient      prometheus.ClientInterface
 	promConfig      config.PrometheusConfig
 
+	// Clients of the clusters having their own Prometheus, by cluster name
+	clusterPromClients     map[string]prometheus.ClientInterface
+	clusterPromClientsLock sync.Mutex
+
 	CustomEnabled bool
 }
 
@@ -53,18 +62,50 @@ func NewDashboardsService(conf *config.Config, grafana *grafana.Service, namespa
 		builtInDashboards = dashboards.AddMonitoringDashboards(builtInDashboards, wkDashboards)
 	}
 
+	cluster := ""
+	if namespace != nil {
+		cluster = namespace.Cluster
+	}
+
 	return &DashboardsService{
-		conf:            conf,
-		CustomEnabled:   customEnabled,
-		grafana:         grafana,
-		promConfig:      prom,
-		globalNamespace: conf.Deployment.Namespace,
-		namespaceLabel:  nsLabel,
-		dashboards:      builtInDashboards.OrganizeByName(),
+		cluster:            cluster,
+		conf:               conf,
+		CustomEnabled:      customEnabled,
+		grafana:            grafana,
+		promConfig:         prom,
+		globalNamespace:    conf.Deployment.Namespace,
+		namespaceLabel:     nsLabel,
+		dashboards:         builtInDashboards.OrganizeByName(),
+		clusterPromClients: map[string]prometheus.ClientInterface{},
+	}
+}
+
+// promConfigFor returns the Prometheus config of the custom dashboards of the cluster, and whether
+// the cluster overrides the default one.
+func (in *DashboardsService) promConfigFor(cluster string) (config.PrometheusConfig, bool) {
+	if in.CustomEnabled && cluster != "" {
+		if prom, ok := in.conf.ExternalServices.CustomDashboards.ClusterPrometheus[cluster]; ok && prom.URL != "" {
+			return prom, true
+		}
 	}
+	return in.promConfig, false
 }
 
-func (in *DashboardsService) prom() (prometheus.ClientInterface, error) {
+func (in *DashboardsService) prom(cluster string) (prometheus.ClientInterface, error) {
+	if promConfig, override := in.promConfigFor(cluster); override {
+		in.clusterPromClientsLock.Lock()
+		defer in.clusterPromClientsLock.Unlock()
+		if client, ok := in.clusterPromClients[cluster]; ok {
+			return client, nil
+		}
+		client, err := prometheus.NewClientForConfig(promConfig)
+		if err != nil {
+			return nil, fmt.Errorf("cannot initialize Prometheus Client of cluster [%s]: %v", cluster, err)
+		}
+		in.clusterPromClients[cluster] = client
+		return client, nil
+	}
+
 	// Lazy init
 	if in.promClient == nil {
 		client, err := prometheus.NewClientForConfig(in.promConfig)
@@ -134,17 +175,36 @@ func (in *DashboardsService) resolveReferences(dashboard *dashboards.MonitoringD
 
 // GetDashboard returns a dashboard filled-in with target data
 func (in *DashboardsService) GetDashboard(ctx context.Context, params models.DashboardQuery, template string) (*models.MonitoringDashboard, error) {
-	promClient, err := in.prom()
+	cluster := params.Cluster
+	if cluster == "" {
+		cluster = in.cluster
+	}
+	promClient, err := in.prom(cluster)
 	if err != nil {
 		return nil, err
 	}
+	promConfig, _ := in.promConfigFor(cluster)
 
 	dashboard, err := in.loadAndResolveDashboardResource(template, map[string]bool{})
 	if err != nil {
 		return nil, err
 	}
 
-	filters := in.buildLabelsQueryString(params.Namespace, params.LabelsFilters)
+	variables := dashboardVariables(dashboard, params)
+	labelsFilters := params.LabelsFilters
+	if len(dashboard.Variables) > 0 {
+		labelsFilters = make(map[string]string, len(params.LabelsFilters)+len(dashboard.Variables))
+		for k, v := range params.LabelsFilters {
+			labelsFilters[k] = v
+		}
+		for _, variable := range dashboard.Variables {
+			if variable.Label != "" && variables[variable.Name] != "" {
+				labelsFilters[variable.Label] = variables[variable.Name]
+			}
+		}
+	}
+
+	filters := in.buildLabelsQueryString(params.Namespace, labelsFilters, promConfig.QueryScope)
 	aggLabels := append(params.AdditionalLabels, models.ConvertAggregations(*dashboard)...)
 	if len(aggLabels) == 0 {
 		// Prevent null in json
@@ -185,7 +245,13 @@ func (in *DashboardsService) GetDashboard(ctx context.Context, params models.Das
 			metrics := chart.GetMetrics()
 			for _, ref := range metrics {
 				var converted []models.Metric
-				var err error
+				metricName, err := expandDashboardVariables(ref.MetricName, variables)
+				if err != nil {
+					filledCharts[idx].Error = err.Error()
+					continue
+				}
+				ref.MetricName = metricName
+
 				if chart.DataType == dashboards.Raw {
 					aggregator := params.RawDataAggregator
 					if chart.Aggregator != "" {
@@ -214,7 +280,7 @@ func (in *DashboardsService) GetDashboard(ctx context.Context, params models.Das
 	var externalLinks []models.ExternalLink
 	go func() {
 		defer wg.Done()
-		links, _, err := in.grafana.Links(ctx, dashboard.ExternalLinks)
+		links, _, err := in.grafana.Links(ctx, cluster, dashboard.ExternalLinks)
 		if err != nil {
 			log.Errorf("Error while getting Grafana links: %v", err)
 		}
@@ -281,10 +347,11 @@ func (in *DashboardsService) buildRuntimesList(templatesNames []string) []models
 }
 
 func (in *DashboardsService) fetchDashboardMetricNames(namespace string, labelsFilters map[string]string) []string {
-	promClient, err := in.prom()
+	promClient, err := in.prom(in.cluster)
 	if err != nil {
 		return []string{}
 	}
+	promConfig, _ := in.promConfigFor(in.cluster)
 
 	// Get the list of metrics that we look for to determine which dashboards can be used.
 	// Some dashboards cannot be discovered using metric lookups - ignore those.
@@ -295,7 +362,7 @@ func (in *DashboardsService) fetchDashboardMetricNames(namespace string, labelsF
 		}
 	}
 
-	labels := in.buildLabelsQueryString(namespace, labelsFilters)
+	labels := in.buildLabelsQueryString(namespace, labelsFilters, promConfig.QueryScope)
 	metrics, err := promClient.GetMetricsForLabels(discoverOnMetrics, labels)
 	if err != nil {
 		log.Errorf("custom dashboard discovery failed, cannot load metrics for labels [%s]: %v", labels, err)
@@ -378,7 +445,7 @@ func addDashboardToRuntimes(dashboard *dashboards.MonitoringDashboard, runtimes
 	return runtimes
 }
 
-func (in *DashboardsService) buildLabelsQueryString(namespace string, labelsFilters map[string]string) string {
+func (in *DashboardsService) buildLabelsQueryString(namespace string, labelsFilters map[string]string, queryScope map[string]string) string {
 	namespaceLabel := in.namespaceLabel
 	if namespaceLabel == "" {
 		namespaceLabel = defaultNamespaceLabel
@@ -387,7 +454,7 @@ func (in *DashboardsService) buildLabelsQueryString(namespace string, labelsFilt
 	for k, v := range labelsFilters {
 		labels += fmt.Sprintf(`,%s="%s"`, prometheus.SanitizeLabelName(k), v)
 	}
-	for labelName, labelValue := range in.promConfig.QueryScope {
+	for labelName, labelValue := range queryScope {
 		labels += fmt.Sprintf(`,%s="%s"`, prometheus.SanitizeLabelName(labelName), labelValue)
 	}
 
@@ -395,6 +462,53 @@ func (in *DashboardsService) buildLabelsQueryString(namespace string, labelsFilt
 	return labels
 }
 
+// dashboardVariables returns the values of the template variables of the dashboard. Values not provided
+// by the query are taken from the namespace / workload being displayed, or from the variable default.
+func dashboardVariables(dashboard *dashboards.MonitoringDashboard, params models.DashboardQuery) map[string]string {
+	values := make(map[string]string, len(dashboard.Variables))
+	for _, variable := range dashboard.Variables {
+		value := params.Variables[variable.Name]
+		if value == "" {
+			switch variable.Name {
+			case "namespace":
+				value = params.Namespace
+			case "workload":
+				value = params.Workload
+			}
+		}
+		if value == "" {
+			value = variable.Default
+		}
+		values[variable.Name] = value
+	}
+	return values
+}
+
+// expandDashboardVariables substitutes the references to the template variables in a metric name.
+// References to undeclared variables are left untouched.
+func expandDashboardVariables(metricName string, values map[string]string) (string, error) {
+	if len(values) == 0 {
+		return metricName, nil
+	}
+	var missing []string
+	expanded := dashboardVariableRef.ReplaceAllStringFunc(metricName, func(ref string) string {
+		groups := dashboardVariableRef.FindStringSubmatch(ref)
+		name := groups[1] + groups[2]
+		value, declared := values[name]
+		if !declared {
+			return ref
+		}
+		if value == "" {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no value for dashboard variables %v", missing)
+	}
+	return expanded, nil
+}
+
 type istioChart struct {
 	models.Chart
 	refName string
@@ -104,6 +104,76 @@ func TestGetDashboardFromKialiNamespace(t *testing.T) {
 	assert.Equal("Dashboard 1", dashboard.Title)
 }
 
+func TestGetDashboardWithVariables(t *testing.T) {
+	assert := assert.New(t)
+
+	dashboard := fakeDashboard("1")
+	dashboard.Variables = []dashboards.MonitoringDashboardVariable{
+		{Name: "workload"},
+		{Name: "pod", Label: "pod"},
+		{Name: "percentile", Default: "0.99"},
+	}
+	dashboard.Items[0].Chart.Metrics[0].MetricName = "my_metric_${workload}_total"
+	dashboard.Items[1].Chart.Metrics[0].MetricName = "my_metric_$percentile"
+	service, prom := setupService("my-namespace", []dashboards.MonitoringDashboard{*dashboard})
+
+	expectedLabels := `{namespace="my-namespace",pod="reviews-1"}`
+	query := models.DashboardQuery{
+		Namespace: "my-namespace",
+		Variables: map[string]string{"pod": "reviews-1"},
+		Workload:  "reviews",
+	}
+	query.FillDefaults()
+	prom.MockMetric("my_metric_reviews_total", expectedLabels, &query.RangeQuery, 10)
+	prom.MockHistogram("my_metric_0.99", expectedLabels, &query.RangeQuery, 11, 12)
+
+	result, err := service.GetDashboard(context.Background(), query, "dashboard1")
+
+	assert.Nil(err)
+	assert.Len(result.Charts, 2)
+	assert.Empty(result.Charts[0].Error)
+	assert.Len(result.Charts[0].Metrics, 1)
+	assert.Empty(result.Charts[1].Error)
+	assert.Len(result.Charts[1].Metrics, 2)
+
+	// Without a value, the charts using the variable fail
+	query.Workload = ""
+	result, err = service.GetDashboard(context.Background(), query, "dashboard1")
+
+	assert.Nil(err)
+	assert.Equal("no value for dashboard variables [workload]", result.Charts[0].Error)
+	assert.Empty(result.Charts[1].Error)
+}
+
+func TestGetDashboardFromClusterPrometheus(t *testing.T) {
+	assert := assert.New(t)
+
+	service, prom := setupService("my-namespace", []dashboards.MonitoringDashboard{*fakeDashboard("1")})
+	service.CustomEnabled = true
+	service.conf.ExternalServices.CustomDashboards.ClusterPrometheus = map[string]config.PrometheusConfig{
+		"east": {URL: "http://prometheus.east:9090", QueryScope: map[string]string{"mesh_id": "east"}},
+	}
+	eastProm := new(pmock.PromClientMock)
+	service.clusterPromClients["east"] = eastProm
+
+	expectedLabels := `{namespace="my-namespace",mesh_id="east"}`
+	query := models.DashboardQuery{
+		Cluster:   "east",
+		Namespace: "my-namespace",
+	}
+	query.FillDefaults()
+	eastProm.MockMetric("my_metric_1_1", expectedLabels, &query.RangeQuery, 10)
+	eastProm.MockHistogram("my_metric_1_2", expectedLabels, &query.RangeQuery, 11, 12)
+
+	result, err := service.GetDashboard(context.Background(), query, "dashboard1")
+
+	assert.Nil(err)
+	assert.Len(result.Charts, 2)
+	assert.Len(result.Charts[0].Metrics, 1)
+	eastProm.AssertNumberOfCalls(t, "FetchRateRange", 1)
+	prom.AssertNotCalled(t, "FetchRateRange")
+}
+
 func TestGetComposedDashboard(t *testing.T) {
 	assert := assert.New(t)
 
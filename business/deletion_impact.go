@@ -0,0 +1,86 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// deletionBehaviors are the mesh behaviors lost when an object of the resource type is deleted.
+// Types not listed (e.g. telemetries, wasmplugins) don't change routing, mTLS or authz.
+var deletionBehaviors = map[string][]string{
+	kubernetes.DestinationRules:       {models.BehaviorRouting, models.BehaviorMTLS},
+	kubernetes.EnvoyFilters:           {models.BehaviorRouting},
+	kubernetes.Gateways:               {models.BehaviorRouting},
+	kubernetes.ServiceEntries:         {models.BehaviorRouting},
+	kubernetes.Sidecars:               {models.BehaviorRouting},
+	kubernetes.VirtualServices:        {models.BehaviorRouting},
+	kubernetes.WorkloadEntries:        {models.BehaviorRouting},
+	kubernetes.WorkloadGroups:         {models.BehaviorRouting},
+	kubernetes.K8sGateways:            {models.BehaviorRouting},
+	kubernetes.K8sGRPCRoutes:          {models.BehaviorRouting},
+	kubernetes.K8sHTTPRoutes:          {models.BehaviorRouting},
+	kubernetes.K8sReferenceGrants:     {models.BehaviorRouting},
+	kubernetes.K8sTCPRoutes:           {models.BehaviorRouting},
+	kubernetes.K8sTLSRoutes:           {models.BehaviorRouting},
+	kubernetes.AuthorizationPolicies:  {models.BehaviorAuthz},
+	kubernetes.PeerAuthentications:    {models.BehaviorMTLS},
+	kubernetes.RequestAuthentications: {models.BehaviorAuthz},
+}
+
+// GetDeletionImpact reports the services, workloads and configs that would lose routing, mTLS or authz behavior
+// if the object was deleted. It is based on the references of the object and the objects referencing it.
+func (in *IstioConfigService) GetDeletionImpact(ctx context.Context, cluster, namespace string, gvk schema.GroupVersionKind, name string) (*models.DeletionImpact, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetDeletionImpact",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("gvk", gvk.String()),
+		observability.Attribute("name", name),
+	)
+	defer end()
+
+	resourceType, found := kubernetes.ResourceTypeForGVK(gvk)
+	if !found {
+		return nil, fmt.Errorf("object kind not managed: %s", gvk.String())
+	}
+
+	// Checks the access to the namespace and collects the configs referencing the object
+	referenceImpact, err := in.businessLayer.Validations.GetReferenceImpact(ctx, cluster, namespace, resourceType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := in.businessLayer.Validations.getReferenceIndex(ctx, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &models.DeletionImpact{
+		Object:    referenceImpact.Object,
+		Behaviors: append([]string{}, deletionBehaviors[resourceType]...),
+		Services:  []models.ServiceReference{},
+		Workloads: []models.WorkloadReference{},
+		Configs:   referenceImpact.ReferencedBy,
+	}
+
+	key := models.IstioReferenceKey{ObjectType: models.ObjectTypeSingular[resourceType], Namespace: namespace, Name: name}
+	if references, found := index.References[key]; found {
+		references = filterReferences(references, namespaces)
+		impact.Services = append(impact.Services, references.ServiceReferences...)
+		impact.Workloads = append(impact.Workloads, references.WorkloadReferences...)
+	}
+
+	return impact, nil
+}
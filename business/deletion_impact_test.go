@@ -0,0 +1,44 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+func TestGetDeletionImpact(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(t, fakeIstioConfigList(), []string{"product", "product2"})
+	gvk, _ := kubernetes.GVKForResourceType(kubernetes.VirtualServices)
+
+	impact, err := vs.businessLayer.IstioConfig.GetDeletionImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "test", gvk, "product-vs")
+	require.NoError(err)
+
+	assert.Equal(models.IstioReference{ObjectType: "virtualservice", Name: "product-vs", Namespace: "test"}, impact.Object)
+	assert.Equal([]string{models.BehaviorRouting}, impact.Behaviors)
+	assert.Equal([]models.ServiceReference{{Name: "product", Namespace: "test"}, {Name: "product2", Namespace: "test"}}, impact.Services)
+	assert.Empty(impact.Workloads)
+	assert.Equal([]models.IstioReference{{ObjectType: "destinationrule", Name: "product-dr", Namespace: "test"}}, impact.Configs)
+}
+
+func TestGetDeletionImpactUnknownKind(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(t, fakeIstioConfigList(), []string{"product"})
+	gvk, _ := kubernetes.GVKForResourceType(kubernetes.VirtualServices)
+	gvk.Kind = "Deployment"
+
+	_, err := vs.businessLayer.IstioConfig.GetDeletionImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "test", gvk, "product-vs")
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+package business
+
+import (
+	"context"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// Istio defaults of the meshConfig values resolved for the proxies.
+const (
+	defaultAccessLogEncoding        = "TEXT"
+	defaultProtocolDetectionTimeout = "0s"
+	defaultTraceSampling            = 1.0
+)
+
+// proxyConfigAnnotation overrides the defaultConfig of the meshConfig for the proxy of a pod.
+const proxyConfigAnnotation = "proxy.istio.io/config"
+
+// effectiveMeshConfig are the meshConfig fields resolved for the proxies.
+type effectiveMeshConfig struct {
+	AccessLogEncoding     string      `yaml:"accessLogEncoding,omitempty"`
+	AccessLogFile         string      `yaml:"accessLogFile,omitempty"`
+	AccessLogFormat       string      `yaml:"accessLogFormat,omitempty"`
+	DefaultConfig         proxyConfig `yaml:"defaultConfig,omitempty"`
+	EnableTracing         *bool       `yaml:"enableTracing,omitempty"`
+	OutboundTrafficPolicy struct {
+		Mode string `yaml:"mode,omitempty"`
+	} `yaml:"outboundTrafficPolicy,omitempty"`
+	ProtocolDetectionTimeout string `yaml:"protocolDetectionTimeout,omitempty"`
+}
+
+// proxyConfig are the ProxyConfig fields resolved for the proxies, from the defaultConfig of the meshConfig or
+// from the proxy.istio.io/config annotation of a pod.
+type proxyConfig struct {
+	Tracing struct {
+		Sampling *float64 `yaml:"sampling,omitempty"`
+	} `yaml:"tracing,omitempty"`
+}
+
+// GetEffectiveMeshConfig resolves the meshConfig values the proxies of a namespace, or of one of its workloads
+// when workload is set, run with. The values come from the configmap of the revision injecting the proxies,
+// the outbound traffic policy can be overridden by the Sidecar applying to them and the trace sampling by the
+// proxy.istio.io/config annotation of the pods.
+func (in *IstioConfigService) GetEffectiveMeshConfig(ctx context.Context, cluster, namespace, workload string) (*models.EffectiveMeshConfig, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetEffectiveMeshConfig",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	ns, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := in.businessLayer.Mesh.namespaceRevision(*ns)
+	workloadLabels := map[string]string{}
+	annotation := ""
+	if workload != "" {
+		wk, err := in.businessLayer.Workload.GetWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload})
+		if err != nil {
+			return nil, err
+		}
+		workloadLabels = wk.Labels
+		if podRevision := wk.Labels[in.config.IstioLabels.InjectionLabelRev]; podRevision != "" {
+			revision = podRevision
+		}
+		// The pods share the template of the workload, the injector labels them with the revision.
+		if len(wk.Pods) > 0 {
+			if podRevision := wk.Pods[0].Labels[in.config.IstioLabels.InjectionLabelRev]; podRevision != "" {
+				revision = podRevision
+			}
+			annotation = wk.Pods[0].Annotations[proxyConfigAnnotation]
+		}
+	}
+	if revision == "" {
+		revision = "default"
+	}
+
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	controlPlane := revisionControlPlane(mesh, cluster, revision)
+	if controlPlane == nil {
+		// The revision can be a tag
+		if tagged, ok := mesh.RevisionTags[cluster][revision]; ok {
+			controlPlane = revisionControlPlane(mesh, cluster, tagged)
+		}
+	}
+	if controlPlane == nil {
+		return nil, kubernetes.NewNotFound(revision, "Kiali", "ControlPlane")
+	}
+
+	kubeCache, err := in.kialiCache.GetKubeCache(controlPlane.Cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	configMapName := IstioConfigMapName(in.config, controlPlane.Revision)
+	configMap, err := kubeCache.GetConfigMap(controlPlane.IstiodNamespace, configMapName)
+	if err != nil {
+		return nil, err
+	}
+	meshConfig := effectiveMeshConfig{}
+	if err := yaml.Unmarshal([]byte(configMap.Data["mesh"]), &meshConfig); err != nil {
+		return nil, err
+	}
+
+	effective := &models.EffectiveMeshConfig{
+		Cluster:                  cluster,
+		Namespace:                namespace,
+		Workload:                 workload,
+		Revision:                 controlPlane.Revision,
+		ConfigMap:                controlPlane.IstiodNamespace + "/" + configMapName,
+		OutboundTrafficPolicy:    meshConfigValue(meshConfig.OutboundTrafficPolicy.Mode, AllowAny),
+		ProtocolDetectionTimeout: meshConfigValue(meshConfig.ProtocolDetectionTimeout, defaultProtocolDetectionTimeout),
+		AccessLogFile:            meshConfigValue(meshConfig.AccessLogFile, ""),
+		AccessLogEncoding:        meshConfigValue(meshConfig.AccessLogEncoding, defaultAccessLogEncoding),
+		AccessLogFormat:          meshConfigValue(meshConfig.AccessLogFormat, ""),
+		TraceSampling:            traceSampling(meshConfig, annotation),
+	}
+
+	sidecar, err := in.workloadSidecar(cluster, namespace, workloadLabels)
+	if err != nil {
+		return nil, err
+	}
+	if sidecar != nil && sidecar.Spec.OutboundTrafficPolicy != nil {
+		reference := models.BuildKey(kubernetes.SidecarType, sidecar.Name, sidecar.Namespace)
+		effective.OutboundTrafficPolicy = models.EffectiveMeshConfigValue{
+			Value:     sidecar.Spec.OutboundTrafficPolicy.GetMode().String(),
+			Source:    models.MeshConfigSourceSidecar,
+			Reference: &reference,
+		}
+	}
+
+	return effective, nil
+}
+
+// revisionControlPlane returns the controlplane of the revision managing the cluster, if any.
+func revisionControlPlane(mesh *models.Mesh, cluster, revision string) *models.ControlPlane {
+	for i := range mesh.ControlPlanes {
+		controlPlane := &mesh.ControlPlanes[i]
+		if controlPlane.Revision != revision && !(revision == "default" && controlPlane.Revision == "") {
+			continue
+		}
+		managedClusters := append([]*kubernetes.Cluster{controlPlane.Cluster}, controlPlane.ManagedClusters...)
+		for _, managed := range managedClusters {
+			if managed.Name == cluster {
+				return controlPlane
+			}
+		}
+	}
+	return nil
+}
+
+// workloadSidecar returns the Sidecar applying to a workload with the labels: the one of its namespace selecting
+// it, else the one of its namespace without selector, else the one of the root namespace without selector.
+func (in *IstioConfigService) workloadSidecar(cluster, namespace string, workloadLabels map[string]string) (*networking_v1beta1.Sidecar, error) {
+	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+	sidecars, err := kubeCache.GetSidecars(namespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workloadLabels) > 0 {
+		for _, sc := range sidecars {
+			if selector := sc.Spec.WorkloadSelector; selector != nil && len(selector.Labels) > 0 &&
+				labels.SelectorFromSet(selector.Labels).Matches(labels.Set(workloadLabels)) {
+				return sc, nil
+			}
+		}
+	}
+
+	rootNamespace := in.config.ExternalServices.Istio.RootNamespace
+	if namespace != rootNamespace {
+		rootSidecars, err := kubeCache.GetSidecars(rootNamespace, "")
+		if err != nil {
+			return nil, err
+		}
+		sidecars = append(sidecars, rootSidecars...)
+	}
+	for _, sc := range sidecars {
+		if sc.Spec.WorkloadSelector == nil {
+			return sc, nil
+		}
+	}
+	return nil, nil
+}
+
+// meshConfigValue is the value set in the meshConfig, or the Istio default when it is not set.
+func meshConfigValue(value, defaultValue string) models.EffectiveMeshConfigValue {
+	if value == "" {
+		return models.EffectiveMeshConfigValue{Value: defaultValue, Source: models.MeshConfigSourceDefault}
+	}
+	return models.EffectiveMeshConfigValue{Value: value, Source: models.MeshConfigSourceMeshConfig}
+}
+
+// traceSampling resolves the percentage of the requests traced: none when the meshConfig disables the tracing,
+// else the sampling of the proxy.istio.io/config annotation, of the defaultConfig of the meshConfig or the default.
+func traceSampling(meshConfig effectiveMeshConfig, annotation string) models.EffectiveMeshConfigValue {
+	formatSampling := func(sampling float64) string {
+		return strconv.FormatFloat(sampling, 'f', -1, 64)
+	}
+
+	if meshConfig.EnableTracing != nil && !*meshConfig.EnableTracing {
+		return models.EffectiveMeshConfigValue{Value: formatSampling(0), Source: models.MeshConfigSourceMeshConfig}
+	}
+	if annotation != "" {
+		podConfig := proxyConfig{}
+		if err := yaml.Unmarshal([]byte(annotation), &podConfig); err != nil {
+			log.Debugf("Unable to parse the %s annotation. Err: %s", proxyConfigAnnotation, err)
+		} else if podConfig.Tracing.Sampling != nil {
+			return models.EffectiveMeshConfigValue{Value: formatSampling(*podConfig.Tracing.Sampling), Source: models.MeshConfigSourceAnnotation}
+		}
+	}
+	if sampling := meshConfig.DefaultConfig.Tracing.Sampling; sampling != nil {
+		return models.EffectiveMeshConfigValue{Value: formatSampling(*sampling), Source: models.MeshConfigSourceMeshConfig}
+	}
+	return models.EffectiveMeshConfigValue{Value: formatSampling(defaultTraceSampling), Source: models.MeshConfigSourceDefault}
+}
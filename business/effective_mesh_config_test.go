@@ -0,0 +1,103 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestGetEffectiveMeshConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	kubernetes.SetConfig(t, *conf)
+
+	const meshConfig = `accessLogFile: /dev/stdout
+accessLogEncoding: JSON
+defaultConfig:
+  tracing:
+    sampling: 10
+outboundTrafficPolicy:
+  mode: REGISTRY_ONLY
+`
+	sidecar := &networking_v1beta1.Sidecar{ObjectMeta: meta_v1.ObjectMeta{Name: "details", Namespace: "Namespace"}}
+	sidecar.Spec.WorkloadSelector = &api_networking_v1beta1.WorkloadSelector{Labels: map[string]string{"app": "details"}}
+	sidecar.Spec.OutboundTrafficPolicy = &api_networking_v1beta1.OutboundTrafficPolicy{Mode: api_networking_v1beta1.OutboundTrafficPolicy_ALLOW_ANY}
+
+	kubeObjs := []runtime.Object{
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, false),
+		&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}, Data: map[string]string{"mesh": meshConfig}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace", Labels: map[string]string{"istio-injection": "enabled"}}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "canary", Labels: map[string]string{"istio.io/rev": "canary"}}},
+		&FakeDepSyncedWithRS()[0],
+		sidecar,
+	}
+	for _, o := range FakeRSSyncedWithPods() {
+		kubeObjs = append(kubeObjs, &o)
+	}
+	for _, o := range FakePodsSyncedWithDeployments() {
+		o.Labels = map[string]string{"app": "details", "version": "v1", "istio.io/rev": "default"}
+		o.Annotations[proxyConfigAnnotation] = "tracing:\n  sampling: 100\n"
+		kubeObjs = append(kubeObjs, &o)
+	}
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	istioConfig := NewWithBackends(clients, clients, nil, nil).IstioConfig
+
+	effective, err := istioConfig.GetEffectiveMeshConfig(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "")
+	require.NoError(err)
+	assert.Equal("default", effective.Revision)
+	assert.Equal("istio-system/istio", effective.ConfigMap)
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "REGISTRY_ONLY", Source: models.MeshConfigSourceMeshConfig}, effective.OutboundTrafficPolicy)
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "0s", Source: models.MeshConfigSourceDefault}, effective.ProtocolDetectionTimeout)
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "/dev/stdout", Source: models.MeshConfigSourceMeshConfig}, effective.AccessLogFile)
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "JSON", Source: models.MeshConfigSourceMeshConfig}, effective.AccessLogEncoding)
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "", Source: models.MeshConfigSourceDefault}, effective.AccessLogFormat)
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "10", Source: models.MeshConfigSourceMeshConfig}, effective.TraceSampling)
+
+	// The Sidecar selecting the workload and the annotation of its pods override the meshConfig
+	effective, err = istioConfig.GetEffectiveMeshConfig(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1")
+	require.NoError(err)
+	assert.Equal("details-v1", effective.Workload)
+	assert.Equal(models.MeshConfigSourceSidecar, effective.OutboundTrafficPolicy.Source)
+	assert.Equal("ALLOW_ANY", effective.OutboundTrafficPolicy.Value)
+	require.NotNil(effective.OutboundTrafficPolicy.Reference)
+	assert.Equal(models.BuildKey(kubernetes.SidecarType, "details", "Namespace"), *effective.OutboundTrafficPolicy.Reference)
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "100", Source: models.MeshConfigSourceAnnotation}, effective.TraceSampling)
+
+	// No controlplane runs the revision of the namespace
+	_, err = istioConfig.GetEffectiveMeshConfig(context.TODO(), conf.KubernetesConfig.ClusterName, "canary", "")
+	require.Error(err)
+	assert.True(api_errors.IsNotFound(err))
+}
+
+func TestTraceSampling(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "1", Source: models.MeshConfigSourceDefault}, traceSampling(effectiveMeshConfig{}, ""))
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "1", Source: models.MeshConfigSourceDefault}, traceSampling(effectiveMeshConfig{}, "not: [valid"))
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "0.5", Source: models.MeshConfigSourceAnnotation}, traceSampling(effectiveMeshConfig{}, `{"tracing":{"sampling":0.5}}`))
+
+	disabled := false
+	assert.Equal(models.EffectiveMeshConfigValue{Value: "0", Source: models.MeshConfigSourceMeshConfig}, traceSampling(effectiveMeshConfig{EnableTracing: &disabled}, "tracing:\n  sampling: 100\n"))
+}
@@ -0,0 +1,234 @@
+package business
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// Characters not allowed in the name of a suggested ServiceEntry.
+var invalidServiceEntryNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// EgressService reports how the traffic leaves the mesh.
+type EgressService struct {
+	businessLayer *Layer
+	conf          *config.Config
+	kialiCache    cache.KialiCache
+	prom          prometheus.ClientInterface
+}
+
+// GetEgressReport evaluates the outbound traffic policy of every controlplane, lists the Sidecars restricting
+// the egress of their workloads and the external destinations that are reached through the PassthroughCluster
+// because they are not in the service registry, suggesting the ServiceEntries that would register them.
+// Only the Sidecars and sources in namespaces accessible to the user are reported.
+func (in *EgressService) GetEgressReport(ctx context.Context, rateInterval string, queryTime time.Time) (*models.EgressReport, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetEgressReport",
+		observability.Attribute("package", "business"),
+		observability.Attribute("rateInterval", rateInterval),
+		observability.Attribute("queryTime", queryTime),
+	)
+	defer end()
+
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.EgressReport{
+		ControlPlanes:        []models.EgressControlPlane{},
+		RateInterval:         rateInterval,
+		Sidecars:             []models.EgressSidecar{},
+		ExternalDestinations: []models.ExternalDestination{},
+	}
+	for _, controlPlane := range mesh.ControlPlanes {
+		report.ControlPlanes = append(report.ControlPlanes, models.EgressControlPlane{
+			Cluster:               controlPlane.Cluster.Name,
+			Revision:              controlPlane.Revision,
+			OutboundTrafficPolicy: controlPlane.Config.OutboundTrafficPolicy.Mode,
+		})
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accessible := map[string]bool{}
+	for _, namespace := range namespaces {
+		accessible[namespace.Cluster+"/"+namespace.Name] = true
+
+		kubeCache, err := in.kialiCache.GetKubeCache(namespace.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		sidecars, err := kubeCache.GetSidecars(namespace.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, sidecar := range sidecars {
+			if egressSidecar, restricts := restrictedEgress(sidecar); restricts {
+				egressSidecar.Cluster = namespace.Cluster
+				report.Sidecars = append(report.Sidecars, egressSidecar)
+			}
+		}
+	}
+
+	rates, err := in.prom.GetPassthroughRequestRates(rateInterval, queryTime)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations := map[string]*models.ExternalDestination{}
+	protocols := map[string]string{}
+	for _, sample := range rates {
+		source := models.ExternalDestinationSource{
+			Cluster:   string(sample.Metric["source_cluster"]),
+			Namespace: string(sample.Metric["source_workload_namespace"]),
+			Workload:  string(sample.Metric["source_workload"]),
+			Rate:      float64(sample.Value),
+		}
+		if !accessible[source.Cluster+"/"+source.Namespace] {
+			continue
+		}
+
+		// destination_service holds the requested host when the destination is not in the registry.
+		host := string(sample.Metric["destination_service"])
+		destination, found := destinations[host]
+		if !found {
+			destination = &models.ExternalDestination{Host: host, Sources: []models.ExternalDestinationSource{}}
+			destinations[host] = destination
+			protocols[host] = string(sample.Metric["request_protocol"])
+		}
+		destination.Rate += source.Rate
+
+		merged := false
+		for i := range destination.Sources {
+			s := &destination.Sources[i]
+			if s.Cluster == source.Cluster && s.Namespace == source.Namespace && s.Workload == source.Workload {
+				s.Rate += source.Rate
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			destination.Sources = append(destination.Sources, source)
+		}
+	}
+
+	for host, destination := range destinations {
+		sort.Slice(destination.Sources, func(i, j int) bool {
+			return destination.Sources[i].Rate > destination.Sources[j].Rate
+		})
+		destination.SuggestedServiceEntry = in.suggestServiceEntry(host, protocols[host], destination.Sources)
+		report.ExternalDestinations = append(report.ExternalDestinations, *destination)
+	}
+	sort.Slice(report.ExternalDestinations, func(i, j int) bool {
+		return report.ExternalDestinations[i].Rate > report.ExternalDestinations[j].Rate
+	})
+
+	return report, nil
+}
+
+// restrictedEgress tells whether the Sidecar restricts the egress of its workloads: either its outbound
+// traffic policy is REGISTRY_ONLY or its egress listeners don't make every host of the mesh visible.
+func restrictedEgress(sidecar *networking_v1beta1.Sidecar) (models.EgressSidecar, bool) {
+	egressSidecar := models.EgressSidecar{
+		Name:      sidecar.Name,
+		Namespace: sidecar.Namespace,
+		Hosts:     []string{},
+	}
+	if sidecar.Spec.WorkloadSelector != nil {
+		egressSidecar.WorkloadSelector = sidecar.Spec.WorkloadSelector.Labels
+	}
+
+	restricts := false
+	if sidecar.Spec.OutboundTrafficPolicy != nil {
+		egressSidecar.OutboundTrafficPolicy = sidecar.Spec.OutboundTrafficPolicy.GetMode().String()
+		restricts = sidecar.Spec.OutboundTrafficPolicy.GetMode() == api_networking_v1beta1.OutboundTrafficPolicy_REGISTRY_ONLY
+	}
+
+	allHosts := false
+	for _, egress := range sidecar.Spec.Egress {
+		if egress == nil {
+			continue
+		}
+		for _, host := range egress.Hosts {
+			egressSidecar.Hosts = append(egressSidecar.Hosts, host)
+			allHosts = allHosts || host == "*/*"
+		}
+	}
+	if len(egressSidecar.Hosts) > 0 && !allHosts {
+		restricts = true
+	}
+
+	return egressSidecar, restricts
+}
+
+// suggestServiceEntry builds a ServiceEntry registering the external host. It is created in the namespace of
+// the sources when they all belong to the same one and only exported to it, otherwise in the istio namespace.
+func (in *EgressService) suggestServiceEntry(host, protocol string, sources []models.ExternalDestinationSource) *networking_v1beta1.ServiceEntry {
+	hostname, port := host, uint32(80)
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		if number, err := strconv.ParseUint(p, 10, 32); err == nil {
+			hostname, port = h, uint32(number)
+		}
+	}
+	// Hosts of a ServiceEntry must be DNS names.
+	if hostname == "" || hostname == "unknown" || net.ParseIP(hostname) != nil {
+		return nil
+	}
+
+	portProtocol := "HTTP"
+	if strings.EqualFold(protocol, "grpc") {
+		portProtocol = "GRPC"
+	}
+
+	se := &networking_v1beta1.ServiceEntry{
+		TypeMeta: meta_v1.TypeMeta{
+			Kind:       kubernetes.ServiceEntryType,
+			APIVersion: kubernetes.ApiNetworkingVersionV1Beta1,
+		},
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      strings.Trim(invalidServiceEntryNameChars.ReplaceAllString(strings.ToLower(hostname), "-"), "-"),
+			Namespace: in.conf.IstioNamespace,
+		},
+		Spec: api_networking_v1beta1.ServiceEntry{
+			Hosts:      []string{hostname},
+			Location:   api_networking_v1beta1.ServiceEntry_MESH_EXTERNAL,
+			Resolution: api_networking_v1beta1.ServiceEntry_DNS,
+			Ports: []*api_networking_v1beta1.ServicePort{
+				{Number: port, Name: strings.ToLower(portProtocol), Protocol: portProtocol},
+			},
+		},
+	}
+
+	namespace := ""
+	for _, source := range sources {
+		if namespace != "" && namespace != source.Namespace {
+			return se
+		}
+		namespace = source.Namespace
+	}
+	if namespace != "" {
+		se.Namespace = namespace
+		se.Spec.ExportTo = []string{"."}
+	}
+
+	return se
+}
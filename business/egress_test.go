@@ -0,0 +1,133 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func TestRestrictedEgress(t *testing.T) {
+	assert := assert.New(t)
+
+	sidecar := &networking_v1beta1.Sidecar{ObjectMeta: meta_v1.ObjectMeta{Name: "default", Namespace: "bookinfo"}}
+	_, restricts := restrictedEgress(sidecar)
+	assert.False(restricts)
+
+	sidecar.Spec.Egress = []*api_networking_v1beta1.IstioEgressListener{{Hosts: []string{"./*", "*/*"}}}
+	_, restricts = restrictedEgress(sidecar)
+	assert.False(restricts)
+
+	sidecar.Spec.OutboundTrafficPolicy = &api_networking_v1beta1.OutboundTrafficPolicy{Mode: api_networking_v1beta1.OutboundTrafficPolicy_REGISTRY_ONLY}
+	egressSidecar, restricts := restrictedEgress(sidecar)
+	assert.True(restricts)
+	assert.Equal("REGISTRY_ONLY", egressSidecar.OutboundTrafficPolicy)
+
+	sidecar.Spec.OutboundTrafficPolicy = nil
+	sidecar.Spec.Egress = []*api_networking_v1beta1.IstioEgressListener{{Hosts: []string{"./*", "istio-system/*"}}}
+	egressSidecar, restricts = restrictedEgress(sidecar)
+	assert.True(restricts)
+	assert.Equal([]string{"./*", "istio-system/*"}, egressSidecar.Hosts)
+}
+
+func TestGetEgressReport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	kubernetes.SetConfig(t, *conf)
+
+	restricting := &networking_v1beta1.Sidecar{ObjectMeta: meta_v1.ObjectMeta{Name: "restricted", Namespace: "bookinfo"}}
+	restricting.Spec.Egress = []*api_networking_v1beta1.IstioEgressListener{{Hosts: []string{"./*"}}}
+	open := &networking_v1beta1.Sidecar{ObjectMeta: meta_v1.ObjectMeta{Name: "open", Namespace: "travels"}}
+	open.Spec.Egress = []*api_networking_v1beta1.IstioEgressListener{{Hosts: []string{"*/*"}}}
+
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		fakeIstioConfigMap("default"),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+		restricting,
+		open,
+	)
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+	SetupBusinessLayer(t, k8s, *conf)
+
+	sample := func(namespace, workload, host string, rate float64) *model.Sample {
+		return &model.Sample{
+			Metric: model.Metric{
+				"source_cluster":            model.LabelValue(conf.KubernetesConfig.ClusterName),
+				"source_workload_namespace": model.LabelValue(namespace),
+				"source_workload":           model.LabelValue(workload),
+				"destination_service":       model.LabelValue(host),
+				"request_protocol":          "http",
+			},
+			Value: model.SampleValue(rate),
+		}
+	}
+	queryTime := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetPassthroughRequestRates", "10m", queryTime).Return(model.Vector{
+		sample("bookinfo", "productpage-v1", "httpbin.org", 1),
+		sample("bookinfo", "productpage-v1", "httpbin.org", 0.5),
+		sample("bookinfo", "productpage-v1", "api.example.com:8080", 2),
+		sample("travels", "cars-v1", "api.example.com:8080", 1),
+		sample("travels", "cars-v1", "10.0.0.1", 0.2),
+		// Namespaces unknown to the user are not reported.
+		sample("private", "secret-v1", "private.example.com", 5),
+	}, nil)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(k8sclients, k8sclients, prom, nil)
+
+	report, err := layer.Egress.GetEgressReport(context.TODO(), "10m", queryTime)
+	require.NoError(err)
+
+	require.Len(report.ControlPlanes, 1)
+	assert.Equal(AllowAny, report.ControlPlanes[0].OutboundTrafficPolicy)
+
+	require.Len(report.Sidecars, 1)
+	assert.Equal("restricted", report.Sidecars[0].Name)
+	assert.Equal(conf.KubernetesConfig.ClusterName, report.Sidecars[0].Cluster)
+
+	require.Len(report.ExternalDestinations, 3)
+
+	shared := report.ExternalDestinations[0]
+	assert.Equal("api.example.com:8080", shared.Host)
+	assert.Equal(float64(3), shared.Rate)
+	assert.Equal("productpage-v1", shared.Sources[0].Workload)
+	require.NotNil(shared.SuggestedServiceEntry)
+	assert.Equal("api-example-com", shared.SuggestedServiceEntry.Name)
+	assert.Equal(conf.IstioNamespace, shared.SuggestedServiceEntry.Namespace)
+	assert.Equal([]string{"api.example.com"}, shared.SuggestedServiceEntry.Spec.Hosts)
+	assert.Equal(uint32(8080), shared.SuggestedServiceEntry.Spec.Ports[0].Number)
+
+	httpbin := report.ExternalDestinations[1]
+	assert.Equal("httpbin.org", httpbin.Host)
+	assert.Equal(1.5, httpbin.Rate)
+	assert.Equal([]models.ExternalDestinationSource{{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo", Workload: "productpage-v1", Rate: 1.5}}, httpbin.Sources)
+	require.NotNil(httpbin.SuggestedServiceEntry)
+	assert.Equal("bookinfo", httpbin.SuggestedServiceEntry.Namespace)
+	assert.Equal([]string{"."}, httpbin.SuggestedServiceEntry.Spec.ExportTo)
+	assert.Equal(uint32(80), httpbin.SuggestedServiceEntry.Spec.Ports[0].Number)
+
+	// IPs can't be registered as hosts.
+	assert.Equal("10.0.0.1", report.ExternalDestinations[2].Host)
+	assert.Nil(report.ExternalDestinations[2].SuggestedServiceEntry)
+}
@@ -0,0 +1,294 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const (
+	// EventsConfigMap is the configmap of the Kiali namespace storing the events recorded by Kiali, one key per
+	// namespace.
+	EventsConfigMap = "kiali-events"
+	// maxEventsPerNamespace bounds the events kept per namespace, the oldest ones are dropped first.
+	maxEventsPerNamespace = 100
+	// maxEventMessageLength bounds the messages of the markers.
+	maxEventMessageLength = 1024
+	// deploymentRevisionAnnotation is the revision of a deployment that a replicaset was created for.
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// EventsCriteria selects the events of a namespace in a time range. App, Workload and Service narrow the events
+// down to a target; the events of the whole namespace are always selected.
+type EventsCriteria struct {
+	Cluster   string
+	Namespace string
+	App       string
+	Workload  string
+	Service   string
+	From      time.Time
+	To        time.Time
+}
+
+// matches returns true when the event is selected by the criteria.
+func (c EventsCriteria) matches(event models.KialiEvent) bool {
+	if event.Cluster != "" && event.Cluster != c.Cluster {
+		return false
+	}
+	if event.Time.Before(c.From) || event.Time.After(c.To) {
+		return false
+	}
+	if event.App == "" && event.Workload == "" && event.Service == "" {
+		return true
+	}
+	switch {
+	case c.Workload != "":
+		return event.Workload == c.Workload
+	case c.App != "":
+		return event.App == c.App
+	case c.Service != "":
+		return event.Service == c.Service
+	}
+	return true
+}
+
+// EventService records and queries the events overlaid on the metrics. The deploys are the replicasets created
+// by the deployments, from the cache. The config changes made through Kiali and the markers of the users are
+// stored in a configmap of the Kiali namespace in the home cluster, read and written with the Kiali service account.
+type EventService struct {
+	conf           *config.Config
+	kialiCache     cache.KialiCache
+	kialiSAClients map[string]kubernetes.ClientInterface
+}
+
+func (in *EventService) client() (kubernetes.ClientInterface, error) {
+	client, ok := in.kialiSAClients[in.conf.KubernetesConfig.ClusterName]
+	if !ok {
+		return nil, fmt.Errorf("client for the home cluster [%s] not found", in.conf.KubernetesConfig.ClusterName)
+	}
+	return client, nil
+}
+
+// storedEvents returns the events stored for a namespace and the configmap storing them, nil when no event was
+// ever stored.
+func (in *EventService) storedEvents(namespace string) ([]models.KialiEvent, *core_v1.ConfigMap, error) {
+	client, err := in.client()
+	if err != nil {
+		return nil, nil, err
+	}
+	configMap, err := client.GetConfigMap(in.conf.Deployment.Namespace, EventsConfigMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	events := []models.KialiEvent{}
+	if data := configMap.Data[namespace]; data != "" {
+		if err := json.Unmarshal([]byte(data), &events); err != nil {
+			log.Errorf("Ignoring invalid events of namespace [%s] of configmap [%s/%s]: %s", namespace, configMap.Namespace, configMap.Name, err)
+		}
+	}
+	return events, configMap, nil
+}
+
+// GetEvents returns the events selected by the criteria, the oldest first.
+func (in *EventService) GetEvents(ctx context.Context, criteria EventsCriteria) ([]models.KialiEvent, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "GetEvents",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("namespace", criteria.Namespace),
+	)
+	defer end()
+
+	deploys, err := in.deployEvents(criteria)
+	if err != nil {
+		return nil, err
+	}
+	stored, _, err := in.storedEvents(criteria.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []models.KialiEvent{}
+	for _, event := range append(deploys, stored...) {
+		if criteria.matches(event) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, nil
+}
+
+// deployEvents returns a deploy event per replicaset created by a deployment of the namespace. The deploys of a
+// service are the deploys of the workloads it selects.
+func (in *EventService) deployEvents(criteria EventsCriteria) ([]models.KialiEvent, error) {
+	kubeCache, err := in.kialiCache.GetKubeCache(criteria.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	replicaSets, err := kubeCache.GetReplicaSets(criteria.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var selector labels.Selector
+	if criteria.Service != "" {
+		svc, err := kubeCache.GetService(criteria.Namespace, criteria.Service)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return nil, nil
+		}
+		selector = labels.SelectorFromSet(svc.Spec.Selector)
+	}
+
+	events := []models.KialiEvent{}
+	for _, rs := range replicaSets {
+		owner := meta_v1.GetControllerOf(&rs)
+		if owner == nil || owner.Kind != "Deployment" {
+			continue
+		}
+		event := models.KialiEvent{
+			Type:       models.EventTypeDeploy,
+			Time:       rs.CreationTimestamp.Time,
+			Cluster:    criteria.Cluster,
+			Namespace:  rs.Namespace,
+			App:        rs.Spec.Template.Labels[in.conf.IstioLabels.AppLabelName],
+			Workload:   owner.Name,
+			ObjectType: "ReplicaSet",
+			ObjectName: rs.Name,
+			Message:    fmt.Sprintf("Rollout of revision %s of deployment %s", rs.Annotations[deploymentRevisionAnnotation], owner.Name),
+		}
+		if selector != nil {
+			if !selector.Matches(labels.Set(rs.Spec.Template.Labels)) {
+				continue
+			}
+			event.Service = criteria.Service
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// RecordEvent stores an event. Concurrent recordings are retried on conflicts.
+func (in *EventService) RecordEvent(ctx context.Context, event models.KialiEvent) error {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "RecordEvent",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", event.Namespace),
+		observability.Attribute("type", event.Type),
+	)
+	defer end()
+
+	if event.Namespace == "" {
+		return errors.NewBadRequest("the event has no namespace")
+	}
+	if strings.TrimSpace(event.Message) == "" || len(event.Message) > maxEventMessageLength {
+		return errors.NewBadRequest(fmt.Sprintf("the message of the event is required and can't be longer than %d characters", maxEventMessageLength))
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	event.Time = event.Time.UTC()
+
+	client, err := in.client()
+	if err != nil {
+		return err
+	}
+	configMaps := client.Kube().CoreV1().ConfigMaps(in.conf.Deployment.Namespace)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		events, configMap, err := in.storedEvents(event.Namespace)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Time.Before(events[j].Time)
+		})
+		if len(events) > maxEventsPerNamespace {
+			events = events[len(events)-maxEventsPerNamespace:]
+		}
+		data, err := json.Marshal(events)
+		if err != nil {
+			return err
+		}
+
+		if configMap == nil {
+			configMap = &core_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      EventsConfigMap,
+					Namespace: in.conf.Deployment.Namespace,
+					Labels:    map[string]string{"app.kubernetes.io/part-of": "kiali"},
+				},
+				Data: map[string]string{event.Namespace: string(data)},
+			}
+			_, err = configMaps.Create(ctx, configMap, meta_v1.CreateOptions{})
+			if errors.IsAlreadyExists(err) {
+				// Created concurrently, retried as a conflict
+				return errors.NewConflict(core_v1.Resource("configmaps"), EventsConfigMap, err)
+			}
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[event.Namespace] = string(data)
+		_, err = configMaps.Update(ctx, configMap, meta_v1.UpdateOptions{})
+		return err
+	})
+}
+
+// AddMarker records a marker added by a user.
+func (in *EventService) AddMarker(ctx context.Context, marker models.KialiEvent, user string) (*models.KialiEvent, error) {
+	if err := checkViewOnlyMode(in.conf, "Adding a marker"); err != nil {
+		return nil, err
+	}
+
+	marker.Type = models.EventTypeMarker
+	marker.User = user
+	marker.ObjectType, marker.ObjectName = "", ""
+	if marker.Time.IsZero() {
+		marker.Time = time.Now().UTC()
+	}
+	if err := in.RecordEvent(ctx, marker); err != nil {
+		return nil, err
+	}
+	return &marker, nil
+}
+
+// RecordConfigChange records a change of the Istio config or of a workload made by a user through Kiali. Failing
+// to record it doesn't fail the change, the error is only logged.
+func (in *EventService) RecordConfigChange(ctx context.Context, change models.KialiEvent, action, user string) {
+	change.Type = models.EventTypeConfig
+	change.User = user
+	change.Message = fmt.Sprintf("%s of %s %s", action, change.ObjectType, change.ObjectName)
+	if err := in.RecordEvent(ctx, change); err != nil {
+		log.Errorf("Unable to record the %s event of %s [%s/%s]: %s", strings.ToLower(action), change.ObjectType, change.Namespace, change.ObjectName, err)
+	}
+}
@@ -0,0 +1,140 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeRolloutReplicaSet(name, deployment, revision string, created time.Time, labels map[string]string) *apps_v1.ReplicaSet {
+	controller := true
+	return &apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              name,
+			Namespace:         "bookinfo",
+			CreationTimestamp: meta_v1.NewTime(created),
+			Annotations:       map[string]string{deploymentRevisionAnnotation: revision},
+			OwnerReferences:   []meta_v1.OwnerReference{{Kind: "Deployment", Name: deployment, Controller: &controller}},
+		},
+		Spec: apps_v1.ReplicaSetSpec{
+			Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+		},
+	}
+}
+
+func TestEvents(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.Namespace = "istio-system"
+	kubernetes.SetConfig(t, *conf)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+		},
+		fakeRolloutReplicaSet("reviews-v1-1", "reviews-v1", "1", now.Add(-2*time.Hour), map[string]string{"app": "reviews", "version": "v1"}),
+		fakeRolloutReplicaSet("reviews-v1-2", "reviews-v1", "2", now.Add(-20*time.Minute), map[string]string{"app": "reviews", "version": "v1"}),
+		fakeRolloutReplicaSet("details-v1-1", "details-v1", "1", now.Add(-10*time.Minute), map[string]string{"app": "details", "version": "v1"}),
+	)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	svc := EventService{conf: conf, kialiCache: cache, kialiSAClients: map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}}
+
+	ctx := context.TODO()
+	cluster := conf.KubernetesConfig.ClusterName
+	svc.RecordConfigChange(ctx, models.KialiEvent{Cluster: cluster, Namespace: "bookinfo", ObjectType: "virtualservices", ObjectName: "reviews"}, "Update", "alice")
+	marker, err := svc.AddMarker(ctx, models.KialiEvent{Cluster: cluster, Namespace: "bookinfo", Workload: "details-v1", Time: now.Add(-5 * time.Minute), Message: "Load test"}, "bob")
+	require.NoError(err)
+	assert.Equal(models.EventTypeMarker, marker.Type)
+	assert.Equal("bob", marker.User)
+
+	criteria := EventsCriteria{Cluster: cluster, Namespace: "bookinfo", From: now.Add(-30 * time.Minute), To: now.Add(time.Minute)}
+
+	// The whole namespace, the first rollout of reviews being out of range
+	events, err := svc.GetEvents(ctx, criteria)
+	require.NoError(err)
+	require.Len(events, 4)
+	assert.Equal(models.KialiEvent{
+		Type:       models.EventTypeDeploy,
+		Time:       now.Add(-20 * time.Minute),
+		Cluster:    cluster,
+		Namespace:  "bookinfo",
+		App:        "reviews",
+		Workload:   "reviews-v1",
+		ObjectType: "ReplicaSet",
+		ObjectName: "reviews-v1-2",
+		Message:    "Rollout of revision 2 of deployment reviews-v1",
+	}, events[0])
+	assert.Equal("details-v1", events[1].Workload)
+	assert.Equal("Load test", events[2].Message)
+	assert.Equal(models.EventTypeConfig, events[3].Type)
+	assert.Equal("Update of virtualservices reviews", events[3].Message)
+	assert.Equal("alice", events[3].User)
+
+	// A service gets the deploys of the workloads it selects and the events of the whole namespace
+	serviceCriteria := criteria
+	serviceCriteria.Service = "reviews"
+	events, err = svc.GetEvents(ctx, serviceCriteria)
+	require.NoError(err)
+	require.Len(events, 2)
+	assert.Equal("reviews-v1-2", events[0].ObjectName)
+	assert.Equal("reviews", events[0].Service)
+	assert.Equal(models.EventTypeConfig, events[1].Type)
+
+	// The metrics service returns the events over the range of its query
+	q := models.IstioMetricsQuery{Cluster: cluster, Namespace: "bookinfo", Workload: "details-v1"}
+	q.Start, q.End = criteria.From, criteria.To
+	events, err = NewMetricsService(nil).WithEvents(&svc).GetEvents(ctx, q)
+	require.NoError(err)
+	require.Len(events, 3)
+	assert.Equal(models.EventTypeDeploy, events[0].Type)
+	assert.Equal(models.EventTypeMarker, events[1].Type)
+	assert.Equal(models.EventTypeConfig, events[2].Type)
+
+	events, err = NewMetricsService(nil).GetEvents(ctx, q)
+	require.NoError(err)
+	assert.Empty(events)
+}
+
+func TestRecordEventsKeepsTheLatest(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient()
+	svc := EventService{conf: conf, kialiSAClients: map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}}
+
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxEventsPerNamespace+5; i++ {
+		require.NoError(svc.RecordEvent(context.TODO(), models.KialiEvent{Type: models.EventTypeMarker, Namespace: "bookinfo", Time: start.Add(time.Duration(i) * time.Minute), Message: "marker"}))
+	}
+
+	events, _, err := svc.storedEvents("bookinfo")
+	require.NoError(err)
+	require.Len(events, maxEventsPerNamespace)
+	require.Equal(start.Add(5*time.Minute), events[0].Time)
+
+	err = svc.RecordEvent(context.TODO(), models.KialiEvent{Type: models.EventTypeMarker, Namespace: "bookinfo"})
+	require.True(errors.IsBadRequest(err))
+
+	conf.Deployment.ViewOnlyMode = true
+	_, err = svc.AddMarker(context.TODO(), models.KialiEvent{Namespace: "bookinfo", Message: "marker"}, "alice")
+	require.True(IsReadOnlyError(err))
+}
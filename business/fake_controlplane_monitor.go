@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
 )
 
 // FakeControlPlaneMonitor is used for testing and implements ControlPlaneMonitor.
 type FakeControlPlaneMonitor struct {
-	status kubernetes.IstioComponentStatus
+	status          kubernetes.IstioComponentStatus
+	proxyConfigDump []byte
 }
 
 func (f *FakeControlPlaneMonitor) PollIstiodForProxyStatus(ctx context.Context) {}
@@ -16,10 +18,21 @@ func (f *FakeControlPlaneMonitor) CanConnectToIstiod(client kubernetes.ClientInt
 	return f.status, nil
 }
 
-func (f *FakeControlPlaneMonitor) CanConnectToIstiodForRevision(client kubernetes.ClientInterface, revision string) (kubernetes.IstioComponentStatus, error) {
+func (f *FakeControlPlaneMonitor) CanConnectToIstiodForRevision(client kubernetes.ClientInterface, revision string, namespace string) (kubernetes.IstioComponentStatus, error) {
 	return f.status, nil
 }
 func (f *FakeControlPlaneMonitor) RefreshIstioCache(ctx context.Context) error { return nil }
+func (f *FakeControlPlaneMonitor) NotifyConfigChange(cluster string)           {}
+func (f *FakeControlPlaneMonitor) RefreshControlPlane(ctx context.Context, cluster string, revision string) error {
+	return nil
+}
+
+func (f *FakeControlPlaneMonitor) GetProxyConfigDump(client kubernetes.ClientInterface, controlPlane *models.ControlPlane, pilot string, proxyID string) ([]byte, error) {
+	if f.proxyConfigDump == nil {
+		return nil, kubernetes.NewNotFound(proxyID, "Kiali", "ProxyConfig")
+	}
+	return f.proxyConfigDump, nil
+}
 
 // Interface guard
 var _ ControlPlaneMonitor = &FakeControlPlaneMonitor{}
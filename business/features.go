@@ -0,0 +1,30 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/config"
+)
+
+// FeatureDisabledError is returned by the business layer when the feature of the call is in the disabled features of
+// the config, letting the operators allow the views but only some of the changes.
+type FeatureDisabledError struct {
+	msg string
+}
+
+func (in *FeatureDisabledError) Error() string {
+	return in.msg
+}
+
+func IsFeatureDisabledError(err error) bool {
+	_, isFeatureDisabledError := err.(*FeatureDisabledError)
+	return isFeatureDisabledError
+}
+
+// checkFeatureEnabled returns a FeatureDisabledError when the feature is disabled in the config
+func checkFeatureEnabled(conf *config.Config, feature config.FeatureName) error {
+	if conf.KialiFeatureFlags.IsFeatureDisabled(feature) {
+		return &FeatureDisabledError{msg: fmt.Sprintf("The [%s] feature is disabled", feature)}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestDisabledWriteFeatures(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KialiFeatureFlags.DisabledFeatures = []string{string(config.FeatureIstioConfigEdit), string(config.FeatureWizards)}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		data.CreateEmptyVirtualService("reviews", "test", []string{"reviews"}),
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "test"}},
+	)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+	cluster := conf.KubernetesConfig.ClusterName
+	ctx := context.TODO()
+
+	_, err := layer.IstioConfig.UpdateIstioConfigDetail(ctx, cluster, "test", kubernetes.VirtualServices, "reviews", "{}")
+	assert.True(IsFeatureDisabledError(err))
+	assert.Contains(err.Error(), "istio-config-edit")
+	err = layer.IstioConfig.DeleteIstioConfigDetail(ctx, cluster, "test", kubernetes.VirtualServices, "reviews")
+	assert.True(IsFeatureDisabledError(err))
+	_, err = layer.IstioConfig.GenerateLocalityLoadBalancing(ctx, cluster, "test", "reviews", models.LocalityLoadBalancing{})
+	assert.True(IsFeatureDisabledError(err))
+
+	// The features not disabled are allowed
+	_, err = layer.Svc.UpdateService(ctx, cluster, "test", "reviews", "60s", time.Now(), "{}", "")
+	assert.False(IsFeatureDisabledError(err))
+	err = layer.ProxyLogging.SetLogLevel(cluster, "test", "reviews-v1", "debug")
+	assert.False(IsFeatureDisabledError(err))
+
+	// The views are not changed by the disabled changes
+	_, err = layer.IstioConfig.GetIstioConfigDetails(ctx, cluster, "test", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+}
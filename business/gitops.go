@@ -0,0 +1,28 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/models"
+)
+
+// GitOpsManagedError is returned when Kiali refuses to change a resource managed by a GitOps tool, as the
+// change would drift from the Git repository of the resource or be reverted by the tool.
+type GitOpsManagedError struct {
+	msg string
+}
+
+func (in *GitOpsManagedError) Error() string {
+	return in.msg
+}
+
+func IsGitOpsManagedError(err error) bool {
+	_, isGitOpsManagedError := err.(*GitOpsManagedError)
+	return isGitOpsManagedError
+}
+
+func newGitOpsManagedError(kind, namespace, name string, managedBy *models.ManagedBy) error {
+	return &GitOpsManagedError{
+		msg: fmt.Sprintf("%s [%s/%s] is managed by %s, change it in its Git repository", kind, namespace, name, managedBy),
+	}
+}
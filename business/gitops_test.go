@@ -0,0 +1,58 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestBlockGitOpsManagedEdits(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KialiFeatureFlags.GitOps.BlockManagedEdits = true
+	config.Set(conf)
+
+	managed := data.CreateEmptyVirtualService("reviews", "test", []string{"reviews"})
+	managed.Labels = map[string]string{"argocd.argoproj.io/instance": "bookinfo"}
+	unmanaged := data.CreateEmptyVirtualService("ratings", "test", []string{"ratings"})
+	k8s := kubetest.NewFakeK8sClient(managed, unmanaged, &osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "test"}})
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	details, err := layer.IstioConfig.GetIstioConfigDetails(context.TODO(), cluster, "test", kubernetes.VirtualServices, "reviews")
+	require.NoError(err)
+	assert.Equal(&models.ManagedBy{Tool: models.GitOpsArgoCD, Kind: "Application", Name: "bookinfo"}, details.ManagedBy)
+
+	_, err = layer.IstioConfig.UpdateIstioConfigDetail(context.TODO(), cluster, "test", kubernetes.VirtualServices, "reviews", `{"spec":{"hosts":["reviews-v2"]}}`)
+	require.Error(err)
+	assert.True(IsGitOpsManagedError(err))
+	assert.Contains(err.Error(), "ArgoCD Application [bookinfo]")
+
+	err = layer.IstioConfig.DeleteIstioConfigDetail(context.TODO(), cluster, "test", kubernetes.VirtualServices, "reviews")
+	assert.True(IsGitOpsManagedError(err))
+
+	_, err = layer.IstioConfig.UpdateIstioConfigDetail(context.TODO(), cluster, "test", kubernetes.VirtualServices, "ratings", `{"spec":{"hosts":["ratings-v2"]}}`)
+	require.NoError(err)
+
+	// the edits are allowed when they are not blocked
+	conf.KialiFeatureFlags.GitOps.BlockManagedEdits = false
+	config.Set(conf)
+	layer = NewWithBackends(clients, clients, nil, nil)
+	_, err = layer.IstioConfig.UpdateIstioConfigDetail(context.TODO(), cluster, "test", kubernetes.VirtualServices, "reviews", `{"spec":{"hosts":["reviews-v2"]}}`)
+	require.NoError(err)
+}
@@ -0,0 +1,208 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GraphViewsConfigMap is the configmap of the Kiali namespace storing the saved graph views, one key per view.
+const GraphViewsConfigMap = "kiali-graph-views"
+
+// The names of the views are the keys of the configmap.
+var graphViewNameRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]{1,253}$`)
+
+// GraphViewService stores the named graph views shared by the users. Views are stored in a configmap of
+// the Kiali namespace in the home cluster, read and written with the Kiali service account.
+type GraphViewService struct {
+	conf           *config.Config
+	kialiSAClients map[string]kubernetes.ClientInterface
+}
+
+func (in *GraphViewService) client() (kubernetes.ClientInterface, error) {
+	client, ok := in.kialiSAClients[in.conf.KubernetesConfig.ClusterName]
+	if !ok {
+		return nil, fmt.Errorf("client for the home cluster [%s] not found", in.conf.KubernetesConfig.ClusterName)
+	}
+	return client, nil
+}
+
+// getConfigMap returns the configmap storing the views, nil when no view was ever saved.
+func (in *GraphViewService) getConfigMap() (*core_v1.ConfigMap, error) {
+	client, err := in.client()
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := client.GetConfigMap(in.conf.Deployment.Namespace, GraphViewsConfigMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return configMap, nil
+}
+
+// ListGraphViews returns the saved graph views, sorted by name.
+func (in *GraphViewService) ListGraphViews(ctx context.Context) ([]models.GraphView, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "ListGraphViews",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	configMap, err := in.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+
+	views := []models.GraphView{}
+	if configMap == nil {
+		return views, nil
+	}
+	for name, data := range configMap.Data {
+		view := models.GraphView{}
+		if err := json.Unmarshal([]byte(data), &view); err != nil {
+			log.Errorf("Ignoring invalid graph view [%s] of configmap [%s/%s]: %s", name, configMap.Namespace, configMap.Name, err)
+			continue
+		}
+		view.Name = name
+		views = append(views, view)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+	return views, nil
+}
+
+// GetGraphView returns the saved graph view of the given name.
+func (in *GraphViewService) GetGraphView(ctx context.Context, name string) (*models.GraphView, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "GetGraphView",
+		observability.Attribute("package", "business"),
+		observability.Attribute("name", name),
+	)
+	defer end()
+
+	configMap, err := in.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	if configMap == nil || configMap.Data[name] == "" {
+		return nil, kubernetes.NewNotFound(name, "kiali.io", "graphviews")
+	}
+
+	view := &models.GraphView{}
+	if err := json.Unmarshal([]byte(configMap.Data[name]), view); err != nil {
+		return nil, fmt.Errorf("invalid graph view [%s]: %s", name, err)
+	}
+	view.Name = name
+	return view, nil
+}
+
+// SaveGraphView creates or replaces a graph view. Concurrent updates of the views are detected by
+// the resource version of the configmap and returned as conflicts.
+func (in *GraphViewService) SaveGraphView(ctx context.Context, view models.GraphView, user string) (*models.GraphView, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "SaveGraphView",
+		observability.Attribute("package", "business"),
+		observability.Attribute("name", view.Name),
+	)
+	defer end()
+
+	if err := checkViewOnlyMode(in.conf, "Saving a graph view"); err != nil {
+		return nil, err
+	}
+
+	if !graphViewNameRegexp.MatchString(view.Name) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid graph view name [%s]: only alphanumeric characters, '-', '_' and '.' are allowed", view.Name))
+	}
+	if len(view.Namespaces) == 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("graph view [%s] has no namespaces", view.Name))
+	}
+
+	view.UpdatedBy = user
+	view.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	data, err := json.Marshal(view)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := in.client()
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := in.getConfigMap()
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps := client.Kube().CoreV1().ConfigMaps(in.conf.Deployment.Namespace)
+	if configMap == nil {
+		configMap = &core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      GraphViewsConfigMap,
+				Namespace: in.conf.Deployment.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/part-of": "kiali"},
+			},
+			Data: map[string]string{view.Name: string(data)},
+		}
+		_, err = configMaps.Create(ctx, configMap, meta_v1.CreateOptions{})
+	} else {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[view.Name] = string(data)
+		_, err = configMaps.Update(ctx, configMap, meta_v1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// DeleteGraphView deletes the graph view of the given name.
+func (in *GraphViewService) DeleteGraphView(ctx context.Context, name string) error {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "DeleteGraphView",
+		observability.Attribute("package", "business"),
+		observability.Attribute("name", name),
+	)
+	defer end()
+
+	if err := checkViewOnlyMode(in.conf, "Deleting a graph view"); err != nil {
+		return err
+	}
+
+	client, err := in.client()
+	if err != nil {
+		return err
+	}
+	configMap, err := in.getConfigMap()
+	if err != nil {
+		return err
+	}
+	if configMap == nil {
+		return kubernetes.NewNotFound(name, "kiali.io", "graphviews")
+	}
+	if _, found := configMap.Data[name]; !found {
+		return kubernetes.NewNotFound(name, "kiali.io", "graphviews")
+	}
+
+	delete(configMap.Data, name)
+	_, err = client.Kube().CoreV1().ConfigMaps(in.conf.Deployment.Namespace).Update(ctx, configMap, meta_v1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,100 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func newGraphViewService(t *testing.T) GraphViewService {
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.Deployment.Namespace = "istio-system"
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient()
+	return GraphViewService{
+		conf:           conf,
+		kialiSAClients: map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s},
+	}
+}
+
+func TestGraphViewsCRUD(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx := context.TODO()
+	svc := newGraphViewService(t)
+
+	views, err := svc.ListGraphViews(ctx)
+	require.NoError(err)
+	assert.Empty(views)
+
+	saved, err := svc.SaveGraphView(ctx, models.GraphView{
+		Name:           "bookinfo-security",
+		Namespaces:     []string{"bookinfo"},
+		GraphType:      "versionedApp",
+		Appenders:      []string{"securityPolicy"},
+		DisplayOptions: map[string]string{"edgeLabels": "responseTime"},
+		HiddenNodes:    []string{"app=details"},
+	}, "alice")
+	require.NoError(err)
+	assert.Equal("alice", saved.UpdatedBy)
+	assert.NotEmpty(saved.UpdatedAt)
+
+	_, err = svc.SaveGraphView(ctx, models.GraphView{Name: "all", Namespaces: []string{"bookinfo", "travels"}}, "bob")
+	require.NoError(err)
+
+	views, err = svc.ListGraphViews(ctx)
+	require.NoError(err)
+	require.Len(views, 2)
+	assert.Equal("all", views[0].Name)
+	assert.Equal("bookinfo-security", views[1].Name)
+
+	view, err := svc.GetGraphView(ctx, "bookinfo-security")
+	require.NoError(err)
+	assert.Equal([]string{"bookinfo"}, view.Namespaces)
+	assert.Equal("responseTime", view.DisplayOptions["edgeLabels"])
+	assert.Equal([]string{"app=details"}, view.HiddenNodes)
+
+	// Saving again replaces the view
+	_, err = svc.SaveGraphView(ctx, models.GraphView{Name: "all", Namespaces: []string{"travels"}}, "carol")
+	require.NoError(err)
+	view, err = svc.GetGraphView(ctx, "all")
+	require.NoError(err)
+	assert.Equal([]string{"travels"}, view.Namespaces)
+	assert.Equal("carol", view.UpdatedBy)
+
+	require.NoError(svc.DeleteGraphView(ctx, "all"))
+	views, err = svc.ListGraphViews(ctx)
+	require.NoError(err)
+	require.Len(views, 1)
+	assert.Equal("bookinfo-security", views[0].Name)
+}
+
+func TestGraphViewsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.TODO()
+	svc := newGraphViewService(t)
+
+	_, err := svc.GetGraphView(ctx, "missing")
+	assert.True(errors.IsNotFound(err))
+
+	err = svc.DeleteGraphView(ctx, "missing")
+	assert.True(errors.IsNotFound(err))
+
+	_, err = svc.SaveGraphView(ctx, models.GraphView{Name: "bad/name", Namespaces: []string{"bookinfo"}}, "alice")
+	assert.True(errors.IsBadRequest(err))
+
+	_, err = svc.SaveGraphView(ctx, models.GraphView{Name: "empty"}, "alice")
+	assert.True(errors.IsBadRequest(err))
+}
@@ -8,17 +8,22 @@ import (
 	"github.com/prometheus/common/model"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/util"
 )
 
 // HealthService deals with fetching health from various sources and convert to kiali model
 type HealthService struct {
 	prom          prometheus.ClientInterface
+	breaker       *circuitBreaker
 	businessLayer *Layer
 	userClients   map[string]kubernetes.ClientInterface
+	// requestRates are the request rates of the namespaces queried in batch, by namespace
+	requestRates map[string]model.Vector
 }
 
 type NamespaceHealthCriteria struct {
@@ -30,7 +35,7 @@ type NamespaceHealthCriteria struct {
 }
 
 // Annotation Filter for Health
-var HealthAnnotation = []models.AnnotationKey{models.RateHealthAnnotation}
+var HealthAnnotation = []models.AnnotationKey{models.RateHealthAnnotation, models.RulesHealthAnnotation}
 
 // GetServiceHealth returns a service health (service request error rate)
 func (in *HealthService) GetServiceHealth(ctx context.Context, namespace, cluster, service, rateInterval string, queryTime time.Time, svc *models.Service) (models.ServiceHealth, error) {
@@ -45,6 +50,7 @@ func (in *HealthService) GetServiceHealth(ctx context.Context, namespace, cluste
 	defer end()
 
 	rqHealth, err := in.getServiceRequestsHealth(namespace, cluster, service, rateInterval, queryTime, svc)
+	in.applyThresholds(namespace, &rqHealth)
 	return models.ServiceHealth{Requests: rqHealth}, err
 }
 
@@ -85,7 +91,8 @@ func (in *HealthService) getAppHealth(namespace, cluster, app, rateInterval stri
 	}
 
 	// Deployment status
-	health.WorkloadStatuses = ws.CastWorkloadStatuses()
+	health.WorkloadStatuses = ws.CastWorkloadStatusesSince(healthWindowStart(rateInterval, queryTime))
+	in.applyThresholds(namespace, &health.Requests)
 
 	return health, errRate
 }
@@ -104,16 +111,19 @@ func (in *HealthService) GetWorkloadHealth(ctx context.Context, namespace, clust
 
 	// Perf: do not bother fetching request rate if workload has no sidecar
 	if !w.IstioSidecar && !w.IsGateway() {
+		rate := models.NewEmptyRequestHealth()
+		in.applyThresholds(namespace, &rate)
 		return models.WorkloadHealth{
-			WorkloadStatus: w.CastWorkloadStatus(),
-			Requests:       models.NewEmptyRequestHealth(),
+			WorkloadStatus: w.CastWorkloadStatusSince(healthWindowStart(rateInterval, queryTime)),
+			Requests:       rate,
 		}, nil
 	}
 
 	// Add Telemetry info
 	rate, err := in.getWorkloadRequestsHealth(namespace, cluster, workload, rateInterval, queryTime, w)
+	in.applyThresholds(namespace, &rate)
 	return models.WorkloadHealth{
-		WorkloadStatus: w.CastWorkloadStatus(),
+		WorkloadStatus: w.CastWorkloadStatusSince(healthWindowStart(rateInterval, queryTime)),
 		Requests:       rate,
 	}, err
 }
@@ -149,33 +159,28 @@ func (in *HealthService) getNamespaceAppHealth(appEntities namespaceApps, criter
 	queryTime := criteria.QueryTime
 	rateInterval := criteria.RateInterval
 	cluster := criteria.Cluster
-	allHealth := make(models.NamespaceAppHealth)
-
-	// Perf: do not bother fetching request rate if no workloads or no workload has sidecar
-	sidecarPresent := false
-	var appSidecars = make(map[string]bool)
 
 	// Prepare all data
-	for app, entities := range appEntities {
-		if app != "" {
-			h := models.EmptyAppHealth()
-			allHealth[app] = &h
-			if entities != nil {
-				h.WorkloadStatuses = entities.Workloads.CastWorkloadStatuses()
-				for _, w := range entities.Workloads {
-					if w.IstioSidecar || w.IsGateway() {
-						sidecarPresent = true
-						appSidecars[app] = true
-						break
-					}
-				}
-			}
-		}
-	}
+	allHealth, appSidecars := newNamespaceAppHealth(appEntities, healthWindowStart(rateInterval, queryTime))
 
-	if sidecarPresent && criteria.IncludeMetrics {
+	// Perf: do not bother fetching request rate if no workloads or no workload has sidecar
+	if len(appSidecars) > 0 && criteria.IncludeMetrics {
 		// Fetch services requests rates
-		rates, err := in.prom.GetAllRequestRates(namespace, cluster, rateInterval, queryTime)
+		apps := make([]string, 0, len(allHealth))
+		for app := range allHealth {
+			apps = append(apps, app)
+		}
+		// Apps health is matched by canonical service
+		var rates model.Vector
+		queried, err := in.queryRates(func() (err error) {
+			rates, err = in.getAllRequestRates(namespace, cluster, "canonical_service", apps, rateInterval, queryTime)
+			return err
+		})
+		if !queried || err != nil {
+			for _, health := range allHealth {
+				health.Requests.MetricsUnavailable = true
+			}
+		}
 		if err != nil {
 			return allHealth, errors.NewServiceUnavailable(err.Error())
 		}
@@ -183,6 +188,10 @@ func (in *HealthService) getNamespaceAppHealth(appEntities namespaceApps, criter
 		fillAppRequestRates(allHealth, rates, appSidecars)
 	}
 
+	for _, health := range allHealth {
+		in.applyThresholds(namespace, &health.Requests)
+	}
+
 	return allHealth, nil
 }
 
@@ -232,31 +241,27 @@ func (in *HealthService) getNamespaceServiceHealth(services *models.ServiceList,
 	rateInterval := criteria.RateInterval
 	cluster := criteria.Cluster
 
-	allHealth := make(models.NamespaceServiceHealth)
-
 	// Prepare all data (note that it's important to provide data for all services, even those which may not have any health, for overview cards)
-	if services != nil {
-		for _, service := range services.Services {
-			h := models.EmptyServiceHealth()
-			h.Requests.HealthAnnotations = service.HealthAnnotations
-			allHealth[service.Name] = &h
-		}
-	}
+	allHealth := newNamespaceServiceHealth(services)
 
 	if criteria.IncludeMetrics {
 		// Fetch services requests rates
-		rates, _ := in.prom.GetNamespaceServicesRequestRates(namespace, cluster, rateInterval, queryTime)
-		// Fill with collected request rates
-		lblDestSvc := model.LabelName("destination_service_name")
-		for _, sample := range rates {
-			service := string(sample.Metric[lblDestSvc])
-			if health, ok := allHealth[service]; ok {
-				health.Requests.AggregateInbound(sample)
+		var rates model.Vector
+		queried, err := in.queryRates(func() (err error) {
+			rates, err = in.getNamespaceServicesRequestRates(namespace, cluster, rateInterval, queryTime)
+			return err
+		})
+		if !queried || err != nil {
+			for _, health := range allHealth {
+				health.Requests.MetricsUnavailable = true
 			}
 		}
-		for _, health := range allHealth {
-			health.Requests.CombineReporters()
-		}
+		// Fill with collected request rates
+		fillServiceRequestRates(allHealth, rates)
+	}
+
+	for _, health := range allHealth {
+		in.applyThresholds(namespace, &health.Requests)
 	}
 	return allHealth
 }
@@ -294,36 +299,337 @@ func (in *HealthService) GetNamespaceWorkloadHealth(ctx context.Context, criteri
 }
 
 func (in *HealthService) getNamespaceWorkloadHealth(ws models.Workloads, criteria NamespaceHealthCriteria) (models.NamespaceWorkloadHealth, error) {
-	// Perf: do not bother fetching request rate if no workloads or no workload has sidecar
-	hasSidecar := false
 	namespace := criteria.Namespace
 	rateInterval := criteria.RateInterval
 	queryTime := criteria.QueryTime
 	cluster := criteria.Cluster
-	var wlSidecars = make(map[string]bool)
 
+	allHealth, wlSidecars := newNamespaceWorkloadHealth(ws, healthWindowStart(rateInterval, queryTime))
+
+	// Perf: do not bother fetching request rate if no workloads or no workload has sidecar
+	if len(wlSidecars) > 0 && criteria.IncludeMetrics {
+		// Fetch services requests rates
+		workloads := make([]string, 0, len(allHealth))
+		for workload := range allHealth {
+			workloads = append(workloads, workload)
+		}
+		var rates model.Vector
+		queried, err := in.queryRates(func() (err error) {
+			rates, err = in.getAllRequestRates(namespace, cluster, "workload", workloads, rateInterval, queryTime)
+			return err
+		})
+		if !queried || err != nil {
+			for _, health := range allHealth {
+				health.Requests.MetricsUnavailable = true
+			}
+		}
+		if err != nil {
+			return allHealth, errors.NewServiceUnavailable(err.Error())
+		}
+		// Fill with collected request rates
+		fillWorkloadRequestRates(allHealth, rates, wlSidecars)
+	}
+
+	for _, health := range allHealth {
+		in.applyThresholds(namespace, &health.Requests)
+	}
+
+	return allHealth, nil
+}
+
+// GetNamespaceHealthBundle returns the health of all the apps, services and workloads of a namespace in one pass: the
+// workloads are fetched once, and the request rates of the namespace are queried once and shared by the three
+// health types. The rates are not shared when the health queries are sharded, as the shards depend on the items.
+func (in *HealthService) GetNamespaceHealthBundle(ctx context.Context, cluster, namespace string, criteria NamespaceHealthCriteria) (*models.NamespaceHealthBundle, error) {
+	criteria.Cluster = cluster
+	criteria.Namespace = namespace
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceHealthBundle",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("rateInterval", criteria.RateInterval),
+		observability.Attribute("queryTime", criteria.QueryTime),
+	)
+	defer end()
+
+	if _, ok := in.userClients[cluster]; !ok {
+		return nil, fmt.Errorf("Cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+
+	if _, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	ws, err := in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	services, err := in.businessLayer.Svc.GetServiceList(ctx, ServiceCriteria{
+		Cluster:                cluster,
+		Namespace:              namespace,
+		IncludeHealth:          false,
+		IncludeIstioResources:  false,
+		IncludeOnlyDefinitions: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The health of the apps only depends on their workloads
+	appEntities := make(namespaceApps)
+	appLabel := config.Get().IstioLabels.AppLabelName
+	for _, w := range ws {
+		castAppDetails(appLabel, appEntities, nil, w, cluster)
+	}
+
+	if !criteria.IncludeMetrics || config.Get().ExternalServices.Prometheus.HealthQuerySharding.Enabled {
+		bundle := &models.NamespaceHealthBundle{ServiceHealth: in.getNamespaceServiceHealth(services, criteria)}
+		if bundle.AppHealth, err = in.getNamespaceAppHealth(appEntities, criteria); err != nil {
+			return bundle, err
+		}
+		bundle.WorkloadHealth, err = in.getNamespaceWorkloadHealth(ws, criteria)
+		return bundle, err
+	}
+
+	since := healthWindowStart(criteria.RateInterval, criteria.QueryTime)
+	appHealth, appSidecars := newNamespaceAppHealth(appEntities, since)
+	serviceHealth := newNamespaceServiceHealth(services)
+	workloadHealth, wlSidecars := newNamespaceWorkloadHealth(ws, since)
+	bundle := &models.NamespaceHealthBundle{AppHealth: appHealth, ServiceHealth: serviceHealth, WorkloadHealth: workloadHealth}
+
+	// Perf: do not bother fetching request rate if there is nothing to fill
+	if len(serviceHealth) > 0 || len(wlSidecars) > 0 {
+		var rates model.Vector
+		queried, err := in.queryRates(func() (err error) {
+			rates, err = in.getAllRequestRates(namespace, cluster, "", nil, criteria.RateInterval, criteria.QueryTime)
+			return err
+		})
+		if !queried || err != nil {
+			for _, health := range appHealth {
+				health.Requests.MetricsUnavailable = true
+			}
+			for _, health := range serviceHealth {
+				health.Requests.MetricsUnavailable = true
+			}
+			for _, health := range workloadHealth {
+				health.Requests.MetricsUnavailable = true
+			}
+		}
+		if err != nil {
+			return bundle, errors.NewServiceUnavailable(err.Error())
+		}
+		fillAppRequestRates(appHealth, rates, appSidecars)
+		fillServiceRequestRates(serviceHealth, namespaceServicesRates(rates, namespace, cluster))
+		fillWorkloadRequestRates(workloadHealth, rates, wlSidecars)
+	}
+
+	for _, health := range appHealth {
+		in.applyThresholds(namespace, &health.Requests)
+	}
+	for _, health := range serviceHealth {
+		in.applyThresholds(namespace, &health.Requests)
+	}
+	for _, health := range workloadHealth {
+		in.applyThresholds(namespace, &health.Requests)
+	}
+	return bundle, nil
+}
+
+// namespaceServicesRates keeps the request rates to the services of the namespace, out of the request rates of the
+// namespace: the same rates as GetNamespaceServicesRequestRates, except the requests coming from the same namespace
+// of other clusters, which the request rates of the namespace do not include.
+func namespaceServicesRates(rates model.Vector, namespace, cluster string) model.Vector {
+	lblDestSvcNs := model.LabelName("destination_service_namespace")
+	lblDestCluster := model.LabelName("destination_cluster")
+	servicesRates := model.Vector{}
+	for _, sample := range rates {
+		if string(sample.Metric[lblDestSvcNs]) == namespace && string(sample.Metric[lblDestCluster]) == cluster {
+			servicesRates = append(servicesRates, sample)
+		}
+	}
+	return servicesRates
+}
+
+// GetNamespacesHealth returns the health of several namespaces, of the given type: "app", "service", "workload" or "all".
+// Unless the health queries are sharded, the request rates of the namespaces sharing the cluster, the rate interval and
+// the query time are queried in batches, instead of a set of queries per namespace.
+func (in *HealthService) GetNamespacesHealth(ctx context.Context, healthType string, criteria []NamespaceHealthCriteria) (models.ClustersNamespaceHealth, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespacesHealth",
+		observability.Attribute("package", "business"),
+		observability.Attribute("healthType", healthType),
+		observability.Attribute("namespaces", len(criteria)),
+	)
+	defer end()
+
+	result := models.ClustersNamespaceHealth{
+		AppHealth:      map[string]*models.NamespaceAppHealth{},
+		WorkloadHealth: map[string]*models.NamespaceWorkloadHealth{},
+		ServiceHealth:  map[string]*models.NamespaceServiceHealth{},
+	}
+
+	type batchKey struct {
+		cluster        string
+		includeMetrics bool
+		queryTime      time.Time
+		rateInterval   string
+	}
+	batches := map[batchKey][]string{}
+	for _, c := range criteria {
+		key := batchKey{cluster: c.Cluster, includeMetrics: c.IncludeMetrics, queryTime: c.QueryTime, rateInterval: c.RateInterval}
+		batches[key] = append(batches[key], c.Namespace)
+	}
+
+	promConf := config.Get().ExternalServices.Prometheus
+	services := make(map[batchKey]*HealthService, len(batches))
+	for key, namespaces := range batches {
+		batched := *in
+		if key.includeMetrics && len(namespaces) > 1 && promConf.HealthQueryBatching.Enabled && !promConf.HealthQuerySharding.Enabled {
+			var rates map[string]model.Vector
+			queried, err := in.queryRates(func() (err error) {
+				rates, err = in.prom.GetNamespacesRequestRates(namespaces, key.cluster, key.rateInterval, key.queryTime)
+				return err
+			})
+			if err != nil {
+				return result, errors.NewServiceUnavailable(err.Error())
+			}
+			if queried {
+				batched.requestRates = rates
+			}
+		}
+		services[key] = &batched
+	}
+
+	for _, c := range criteria {
+		hs := services[batchKey{cluster: c.Cluster, includeMetrics: c.IncludeMetrics, queryTime: c.QueryTime, rateInterval: c.RateInterval}]
+		switch healthType {
+		case "app":
+			health, err := hs.GetNamespaceAppHealth(ctx, c)
+			if err != nil {
+				return result, err
+			}
+			result.AppHealth[c.Namespace] = &health
+		case "service":
+			health, err := hs.GetNamespaceServiceHealth(ctx, c)
+			if err != nil {
+				return result, err
+			}
+			result.ServiceHealth[c.Namespace] = &health
+		case "workload":
+			health, err := hs.GetNamespaceWorkloadHealth(ctx, c)
+			if err != nil {
+				return result, err
+			}
+			result.WorkloadHealth[c.Namespace] = &health
+		case "all":
+			bundle, err := hs.GetNamespaceHealthBundle(ctx, c.Cluster, c.Namespace, c)
+			if err != nil {
+				return result, err
+			}
+			result.AppHealth[c.Namespace] = &bundle.AppHealth
+			result.ServiceHealth[c.Namespace] = &bundle.ServiceHealth
+			result.WorkloadHealth[c.Namespace] = &bundle.WorkloadHealth
+		default:
+			return result, errors.NewBadRequest(fmt.Sprintf("unknown health type [%s]", healthType))
+		}
+	}
+	return result, nil
+}
+
+// queryRates runs a query of request rates through the Prometheus circuit breaker. While the breaker is open, the
+// query is not run and false is returned: the health is computed without the request rates then.
+func (in *HealthService) queryRates(query func() error) (bool, error) {
+	conf := config.Get().ExternalServices.Prometheus.CircuitBreaker
+	if !in.breaker.allow(conf) {
+		return false, nil
+	}
+	err := query()
+	in.breaker.record(conf, err)
+	return true, err
+}
+
+// getAllRequestRates fetches the request rates of the namespace, split in shards of the given items when configured.
+func (in *HealthService) getAllRequestRates(namespace, cluster, itemLabelSuffix string, items []string, rateInterval string, queryTime time.Time) (model.Vector, error) {
+	if rates, ok := in.requestRates[namespace]; ok {
+		return rates, nil
+	}
+	if config.Get().ExternalServices.Prometheus.HealthQuerySharding.Enabled {
+		return in.prom.GetAllRequestRatesSharded(namespace, cluster, itemLabelSuffix, items, rateInterval, queryTime)
+	}
+	return in.prom.GetAllRequestRates(namespace, cluster, rateInterval, queryTime)
+}
+
+// getNamespaceServicesRequestRates fetches the request rates to the services of the namespace, out of the request rates
+// of the namespace when queried in batch.
+func (in *HealthService) getNamespaceServicesRequestRates(namespace, cluster, rateInterval string, queryTime time.Time) (model.Vector, error) {
+	if rates, ok := in.requestRates[namespace]; ok {
+		return namespaceServicesRates(rates, namespace, cluster), nil
+	}
+	return in.prom.GetNamespaceServicesRequestRates(namespace, cluster, rateInterval, queryTime)
+}
+
+// newNamespaceAppHealth returns the health of the apps without request rates, and the apps with a sidecar.
+func newNamespaceAppHealth(appEntities namespaceApps, since time.Time) (models.NamespaceAppHealth, map[string]bool) {
+	allHealth := make(models.NamespaceAppHealth)
+	appSidecars := make(map[string]bool)
+	for app, entities := range appEntities {
+		if app != "" {
+			h := models.EmptyAppHealth()
+			allHealth[app] = &h
+			if entities != nil {
+				h.WorkloadStatuses = entities.Workloads.CastWorkloadStatusesSince(since)
+				for _, w := range entities.Workloads {
+					if w.IstioSidecar || w.IsGateway() {
+						appSidecars[app] = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return allHealth, appSidecars
+}
+
+// newNamespaceServiceHealth returns the health of the services without request rates.
+func newNamespaceServiceHealth(services *models.ServiceList) models.NamespaceServiceHealth {
+	allHealth := make(models.NamespaceServiceHealth)
+	if services != nil {
+		for _, service := range services.Services {
+			h := models.EmptyServiceHealth()
+			h.Requests.HealthAnnotations = service.HealthAnnotations
+			allHealth[service.Name] = &h
+		}
+	}
+	return allHealth
+}
+
+// newNamespaceWorkloadHealth returns the health of the workloads without request rates, and the workloads with a sidecar.
+func newNamespaceWorkloadHealth(ws models.Workloads, since time.Time) (models.NamespaceWorkloadHealth, map[string]bool) {
 	allHealth := make(models.NamespaceWorkloadHealth)
+	wlSidecars := make(map[string]bool)
 	for _, w := range ws {
 		allHealth[w.Name] = models.EmptyWorkloadHealth()
 		allHealth[w.Name].Requests.HealthAnnotations = models.GetHealthAnnotation(w.HealthAnnotations, HealthAnnotation)
-		allHealth[w.Name].WorkloadStatus = w.CastWorkloadStatus()
+		allHealth[w.Name].WorkloadStatus = w.CastWorkloadStatusSince(since)
 		if w.IstioSidecar || w.IsGateway() {
-			hasSidecar = true
 			wlSidecars[w.Name] = true
 		}
 	}
+	return allHealth, wlSidecars
+}
 
-	if hasSidecar && criteria.IncludeMetrics {
-		// Fetch services requests rates
-		rates, err := in.prom.GetAllRequestRates(namespace, cluster, rateInterval, queryTime)
-		if err != nil {
-			return allHealth, errors.NewServiceUnavailable(err.Error())
+// fillServiceRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
+func fillServiceRequestRates(allHealth models.NamespaceServiceHealth, rates model.Vector) {
+	lblDestSvc := model.LabelName("destination_service_name")
+	for _, sample := range rates {
+		service := string(sample.Metric[lblDestSvc])
+		if health, ok := allHealth[service]; ok {
+			health.Requests.AggregateInbound(sample)
 		}
-		// Fill with collected request rates
-		fillWorkloadRequestRates(allHealth, rates, wlSidecars)
 	}
-
-	return allHealth, nil
+	for _, health := range allHealth {
+		health.Requests.CombineReporters()
+	}
 }
 
 // fillAppRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
@@ -378,7 +684,14 @@ func (in *HealthService) getServiceRequestsHealth(namespace, cluster, service, r
 		// Telemetry doesn't collect a namespace
 		namespace = "unknown"
 	}
-	inbound, err := in.prom.GetServiceRequestRates(namespace, cluster, service, rateInterval, queryTime)
+	var inbound model.Vector
+	queried, err := in.queryRates(func() (err error) {
+		inbound, err = in.prom.GetServiceRequestRates(namespace, cluster, service, rateInterval, queryTime)
+		return err
+	})
+	if !queried || err != nil {
+		rqHealth.MetricsUnavailable = true
+	}
 	if err != nil {
 		return rqHealth, errors.NewServiceUnavailable(err.Error())
 	}
@@ -393,7 +706,14 @@ func (in *HealthService) getServiceRequestsHealth(namespace, cluster, service, r
 func (in *HealthService) getAppRequestsHealth(namespace, cluster, app, rateInterval string, queryTime time.Time) (models.RequestHealth, error) {
 	rqHealth := models.NewEmptyRequestHealth()
 
-	inbound, outbound, err := in.prom.GetAppRequestRates(namespace, cluster, app, rateInterval, queryTime)
+	var inbound, outbound model.Vector
+	queried, err := in.queryRates(func() (err error) {
+		inbound, outbound, err = in.prom.GetAppRequestRates(namespace, cluster, app, rateInterval, queryTime)
+		return err
+	})
+	if !queried || err != nil {
+		rqHealth.MetricsUnavailable = true
+	}
 	if err != nil {
 		return rqHealth, errors.NewServiceUnavailable(err.Error())
 	}
@@ -407,10 +727,27 @@ func (in *HealthService) getAppRequestsHealth(namespace, cluster, app, rateInter
 	return rqHealth, nil
 }
 
+// healthWindowStart returns when the health window of the rate interval starts. The pod conditions
+// are still evaluated at query time when the interval can't be parsed.
+func healthWindowStart(rateInterval string, queryTime time.Time) time.Time {
+	since, err := util.GetStartTimeForRateInterval(queryTime, rateInterval)
+	if err != nil {
+		return queryTime
+	}
+	return since
+}
+
 func (in *HealthService) getWorkloadRequestsHealth(namespace, cluster, workload, rateInterval string, queryTime time.Time, w *models.Workload) (models.RequestHealth, error) {
 	rqHealth := models.NewEmptyRequestHealth()
 	// @TODO include w.Cluster into query
-	inbound, outbound, err := in.prom.GetWorkloadRequestRates(namespace, cluster, workload, rateInterval, queryTime)
+	var inbound, outbound model.Vector
+	queried, err := in.queryRates(func() (err error) {
+		inbound, outbound, err = in.prom.GetWorkloadRequestRates(namespace, cluster, workload, rateInterval, queryTime)
+		return err
+	})
+	if !queried || err != nil {
+		rqHealth.MetricsUnavailable = true
+	}
 	if err != nil {
 		return rqHealth, err
 	}
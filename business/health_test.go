@@ -401,6 +401,42 @@ func TestGetNamespaceApplicationsHealthMultiCluster(t *testing.T) {
 	assert.NotContains(applicationsHealth["httpbin"].Requests.Inbound["http"], "500")
 }
 
+func TestGetNamespaceApplicationsHealthSharded(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.ExternalServices.Prometheus.HealthQuerySharding.Enabled = true
+	config.Set(conf)
+
+	clientFactory := kubetest.NewK8SClientFactoryMock(nil)
+	clients := map[string]kubernetes.ClientInterface{
+		conf.KubernetesConfig.ClusterName: kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+			&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "httpbin", Namespace: "tutorial"}},
+			&core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "httpbin", Namespace: "tutorial", Labels: map[string]string{"app": "httpbin", "version": "v1"}, Annotations: kubetest.FakeIstioAnnotations()}, Status: core_v1.PodStatus{Phase: core_v1.PodRunning}},
+		),
+	}
+	clientFactory.SetClients(clients)
+	cache := cache.NewTestingCacheWithFactory(t, clientFactory, *conf)
+	kialiCache = cache
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRatesSharded", "tutorial", conf.KubernetesConfig.ClusterName, "canonical_service", []string{"httpbin"}, "1m", mock.AnythingOfType("time.Time")).Return(serviceRates, nil)
+
+	layer := NewWithBackends(clients, clients, prom, nil)
+
+	hs := HealthService{prom: prom, businessLayer: layer, userClients: clients}
+
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC), IncludeMetrics: true}
+
+	applicationsHealth, err := hs.GetNamespaceAppHealth(context.TODO(), criteria)
+
+	assert.Nil(err)
+	assert.Len(applicationsHealth, 1)
+	assert.Contains(applicationsHealth["httpbin"].Requests.Inbound["http"], "200")
+	prom.AssertNumberOfCalls(t, "GetAllRequestRates", 0)
+}
+
 func TestGetNamespaceWorkloadsHealthMultiCluster(t *testing.T) {
 	assert := assert.New(t)
 
@@ -439,6 +475,137 @@ func TestGetNamespaceWorkloadsHealthMultiCluster(t *testing.T) {
 	assert.NotContains(workloadsHealth["httpbin"].Requests.Inbound["http"], "500")
 }
 
+func TestGetNamespaceHealthBundle(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "httpbin", Namespace: "tutorial"}},
+		&core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "httpbin", Namespace: "tutorial", Labels: map[string]string{"app": "httpbin", "version": "v1"}, Annotations: kubetest.FakeIstioAnnotations()}, Status: core_v1.PodStatus{Phase: core_v1.PodRunning}},
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	rates := model.Vector{
+		&model.Sample{
+			Metric: model.Metric{
+				"destination_canonical_service": "httpbin",
+				"destination_workload":          "httpbin",
+				"destination_service_name":      "httpbin",
+				"destination_service_namespace": "tutorial",
+				"destination_cluster":           model.LabelValue(cluster),
+				"source_canonical_service":      "reviews",
+				"source_workload":               "reviews-v1",
+				"request_protocol":              "http",
+				"response_code":                 "200",
+				"reporter":                      "destination",
+			},
+			Value: model.SampleValue(5),
+		},
+		&model.Sample{
+			Metric: model.Metric{
+				"destination_canonical_service": "ratings",
+				"destination_workload":          "ratings-v1",
+				"destination_service_name":      "ratings",
+				"destination_service_namespace": "bookinfo",
+				"destination_cluster":           model.LabelValue(cluster),
+				"source_canonical_service":      "httpbin",
+				"source_workload":               "httpbin",
+				"request_protocol":              "http",
+				"response_code":                 "500",
+				"reporter":                      "source",
+			},
+			Value: model.SampleValue(2),
+		},
+	}
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "tutorial", cluster, "1m", mock.AnythingOfType("time.Time")).Return(rates, nil)
+
+	clients := map[string]kubernetes.ClientInterface{cluster: k8s}
+	hs := NewWithBackends(clients, clients, prom, nil).Health
+
+	criteria := NamespaceHealthCriteria{RateInterval: "1m", QueryTime: time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC), IncludeMetrics: true}
+	bundle, err := hs.GetNamespaceHealthBundle(context.TODO(), cluster, "tutorial", criteria)
+	require.NoError(err)
+
+	require.Contains(bundle.AppHealth, "httpbin")
+	assert.Equal(map[string]map[string]float64{"http": {"200": 5}}, bundle.AppHealth["httpbin"].Requests.Inbound)
+	require.Contains(bundle.WorkloadHealth, "httpbin")
+	assert.Equal(map[string]map[string]float64{"http": {"200": 5}}, bundle.WorkloadHealth["httpbin"].Requests.Inbound)
+	require.Contains(bundle.ServiceHealth, "httpbin")
+	// The requests to the services of other namespaces are not part of the service health
+	assert.Equal(map[string]map[string]float64{"http": {"200": 5}}, bundle.ServiceHealth["httpbin"].Requests.Inbound)
+
+	// The request rates are queried once for the three health types
+	prom.AssertNumberOfCalls(t, "GetAllRequestRates", 1)
+	prom.AssertNumberOfCalls(t, "GetNamespaceServicesRequestRates", 0)
+}
+
+func TestGetNamespacesHealthBatched(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	pod := func(namespace string) *core_v1.Pod {
+		return &core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "httpbin", Namespace: namespace, Labels: map[string]string{"app": "httpbin", "version": "v1"}, Annotations: kubetest.FakeIstioAnnotations()}, Status: core_v1.PodStatus{Phase: core_v1.PodRunning}}
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+		pod("tutorial"),
+		pod("bookinfo"),
+		pod("travels"),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	rate := func(code string) model.Vector {
+		return model.Vector{&model.Sample{
+			Metric: model.Metric{
+				"destination_canonical_service": "httpbin",
+				"destination_workload":          "httpbin",
+				"source_canonical_service":      "reviews",
+				"source_workload":               "reviews-v1",
+				"request_protocol":              "http",
+				"response_code":                 model.LabelValue(code),
+				"reporter":                      "destination",
+			},
+			Value: model.SampleValue(1),
+		}}
+	}
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetNamespacesRequestRates", []string{"tutorial", "bookinfo"}, cluster, "1m", queryTime).Return(map[string]model.Vector{"tutorial": rate("200"), "bookinfo": rate("500")}, nil)
+	// A namespace with another rate interval is not part of the batch
+	prom.On("GetAllRequestRates", "travels", cluster, "30s", queryTime).Return(rate("404"), nil)
+
+	clients := map[string]kubernetes.ClientInterface{cluster: k8s}
+	hs := NewWithBackends(clients, clients, prom, nil).Health
+
+	health, err := hs.GetNamespacesHealth(context.TODO(), "workload", []NamespaceHealthCriteria{
+		{Namespace: "tutorial", Cluster: cluster, RateInterval: "1m", QueryTime: queryTime, IncludeMetrics: true},
+		{Namespace: "bookinfo", Cluster: cluster, RateInterval: "1m", QueryTime: queryTime, IncludeMetrics: true},
+		{Namespace: "travels", Cluster: cluster, RateInterval: "30s", QueryTime: queryTime, IncludeMetrics: true},
+	})
+	require.NoError(err)
+	require.Len(health.WorkloadHealth, 3)
+	assert.Equal(map[string]map[string]float64{"http": {"200": 1}}, (*health.WorkloadHealth["tutorial"])["httpbin"].Requests.Inbound)
+	assert.Equal(map[string]map[string]float64{"http": {"500": 1}}, (*health.WorkloadHealth["bookinfo"])["httpbin"].Requests.Inbound)
+	assert.Equal(map[string]map[string]float64{"http": {"404": 1}}, (*health.WorkloadHealth["travels"])["httpbin"].Requests.Inbound)
+
+	prom.AssertNumberOfCalls(t, "GetNamespacesRequestRates", 1)
+	prom.AssertNumberOfCalls(t, "GetAllRequestRates", 1)
+}
+
 var (
 	sampleReviewsToHttpbin200 = model.Sample{
 		Metric: model.Metric{
@@ -0,0 +1,49 @@
+package business
+
+import (
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// healthProtocols are the protocols the error rate thresholds are resolved for.
+var healthProtocols = []string{"grpc", "http", "tcp"}
+
+// applyThresholds resolves the error rate threshold of every protocol for the requests. The rules of the health
+// annotations of the service/workload take precedence over the thresholds of the namespace, then the global ones.
+// The rules are kept with the requests, to check the classes of response codes they count as errors.
+func (in *HealthService) applyThresholds(namespace string, rqHealth *models.RequestHealth) {
+	healthConfig := config.Get().HealthConfig
+	rules, errors := models.ParseHealthRules(rqHealth.HealthAnnotations)
+	for _, err := range errors {
+		log.Debugf("Ignoring %s", err.Error())
+	}
+	namespaceThresholds, hasNamespaceThresholds := healthConfig.NamespaceThresholds[namespace]
+
+	rqHealth.Rules = rules
+	rqHealth.Thresholds = make(map[string]models.HealthThreshold, len(healthProtocols))
+	for _, protocol := range healthProtocols {
+		if threshold, found := annotationThreshold(rules, protocol); found {
+			rqHealth.Thresholds[protocol] = models.HealthThreshold{Degraded: threshold.Degraded, Failure: threshold.Failure, Source: models.HealthThresholdSourceAnnotation}
+			continue
+		}
+		if threshold := namespaceThresholds.ForProtocol(protocol); hasNamespaceThresholds && threshold.IsSet() {
+			rqHealth.Thresholds[protocol] = models.HealthThreshold{Degraded: threshold.Degraded, Failure: threshold.Failure, Source: models.HealthThresholdSourceNamespace}
+			continue
+		}
+		if threshold := healthConfig.Thresholds.ForProtocol(protocol); threshold.IsSet() {
+			rqHealth.Thresholds[protocol] = models.HealthThreshold{Degraded: threshold.Degraded, Failure: threshold.Failure, Source: models.HealthThresholdSourceGlobal}
+		}
+	}
+}
+
+// annotationThreshold returns the threshold of the first rule of all the ports applying to the protocol.
+func annotationThreshold(rules []models.HealthRule, protocol string) (config.HealthThreshold, bool) {
+	for _, rule := range rules {
+		if rule.Port != 0 || !rule.MatchesProtocol(protocol) {
+			continue
+		}
+		return config.HealthThreshold{Degraded: rule.Degraded, Failure: rule.Failure}, true
+	}
+	return config.HealthThreshold{}, false
+}
@@ -0,0 +1,54 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestApplyThresholds(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.HealthConfig.NamespaceThresholds = map[string]config.HealthThresholds{
+		"bookinfo": {HTTP: config.HealthThreshold{Degraded: 1, Failure: 5}},
+	}
+	config.Set(conf)
+
+	hs := HealthService{}
+
+	// Only global thresholds
+	rqHealth := models.NewEmptyRequestHealth()
+	hs.applyThresholds("default", &rqHealth)
+	assert.Equal(map[string]models.HealthThreshold{
+		"grpc": {Degraded: 10, Failure: 20, Source: models.HealthThresholdSourceGlobal},
+		"http": {Degraded: 10, Failure: 20, Source: models.HealthThresholdSourceGlobal},
+		"tcp":  {Degraded: 10, Failure: 20, Source: models.HealthThresholdSourceGlobal},
+	}, rqHealth.Thresholds)
+
+	// Namespace thresholds override the global ones of the same protocol
+	rqHealth = models.NewEmptyRequestHealth()
+	hs.applyThresholds("bookinfo", &rqHealth)
+	assert.Equal(models.HealthThreshold{Degraded: 1, Failure: 5, Source: models.HealthThresholdSourceNamespace}, rqHealth.Thresholds["http"])
+	assert.Equal(models.HealthThresholdSourceGlobal, rqHealth.Thresholds["grpc"].Source)
+
+	// Annotations override everything
+	rqHealth = models.NewEmptyRequestHealth()
+	rqHealth.HealthAnnotations[string(models.RateHealthAnnotation)] = "5XX,2,3,http|grpc,inbound;4XX,20,30,http,inbound"
+	hs.applyThresholds("bookinfo", &rqHealth)
+	assert.Equal(models.HealthThreshold{Degraded: 2, Failure: 3, Source: models.HealthThresholdSourceAnnotation}, rqHealth.Thresholds["http"])
+	assert.Equal(models.HealthThreshold{Degraded: 2, Failure: 3, Source: models.HealthThresholdSourceAnnotation}, rqHealth.Thresholds["grpc"])
+	assert.Equal(models.HealthThresholdSourceGlobal, rqHealth.Thresholds["tcp"].Source)
+
+	// The v2 rules take precedence over the rate annotation, the rules of a port do not set the thresholds
+	rqHealth = models.NewEmptyRequestHealth()
+	rqHealth.HealthAnnotations[string(models.RateHealthAnnotation)] = "5XX,2,3,http|grpc,inbound"
+	rqHealth.HealthAnnotations[string(models.RulesHealthAnnotation)] = `[{"port": 9080, "code": "5xx", "degraded": 1, "failure": 2}, {"protocol": "http", "code": "4xx", "degraded": 30, "failure": 40}]`
+	hs.applyThresholds("bookinfo", &rqHealth)
+	assert.Equal(models.HealthThreshold{Degraded: 30, Failure: 40, Source: models.HealthThresholdSourceAnnotation}, rqHealth.Thresholds["http"])
+	assert.Equal(models.HealthThresholdSourceGlobal, rqHealth.Thresholds["grpc"].Source)
+	assert.Len(rqHealth.Rules, 2)
+}
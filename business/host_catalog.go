@@ -0,0 +1,190 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+)
+
+const (
+	// serviceExportToAnnotation sets the exportTo of a Kubernetes Service, comma separated.
+	serviceExportToAnnotation = "networking.istio.io/exportTo"
+	// hostCatalogPilot is set as the Pilot of the registry services built by the host catalog.
+	hostCatalogPilot = "kiali-host-catalog"
+)
+
+// buildHostCatalog builds the hosts known by the mesh from the Services, ServiceEntries and WorkloadEntries of
+// all the clusters, in the shape of the registry services that istiod used to serve at /debug/registryz.
+// Like istiod does, the Services with the same name and namespace in several clusters are a single host with
+// an address per cluster. Every cluster of the mesh gets the same catalog.
+func buildHostCatalog(kubeCaches map[string]cache.KubeCache, identityDomain string) ([]*kubernetes.RegistryService, error) {
+	k8sHosts := map[string]*kubernetes.RegistryService{}
+	var seHosts []*kubernetes.RegistryService
+
+	// Sorted so that the merged attributes of a Service don't depend on the map order
+	clusters := make([]string, 0, len(kubeCaches))
+	for cluster := range kubeCaches {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	for _, cluster := range clusters {
+		kubeCache := kubeCaches[cluster]
+
+		services, err := kubeCache.GetServices(meta_v1.NamespaceAll, "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the services of cluster [%s]: %s", cluster, err)
+		}
+		workloadEntries, err := kubeCache.GetWorkloadEntries(meta_v1.NamespaceAll, "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the workload entries of cluster [%s]: %s", cluster, err)
+		}
+		weLabels := map[string][]map[string]string{}
+		for _, we := range workloadEntries {
+			weLabels[we.Namespace] = append(weLabels[we.Namespace], we.Spec.Labels)
+		}
+
+		for i := range services {
+			svc := &services[i]
+			hostname := fmt.Sprintf("%s.%s.%s", svc.Name, svc.Namespace, identityDomain)
+			rSvc, found := k8sHosts[hostname]
+			if !found {
+				rSvc = newKubernetesRegistryService(svc, hostname)
+				k8sHosts[hostname] = rSvc
+			}
+			rSvc.ClusterVIPs12.Addresses[cluster] = serviceAddresses(svc)
+			rSvc.WorkloadEntryLabels = append(rSvc.WorkloadEntryLabels, selectedLabels(svc.Spec.Selector, weLabels[svc.Namespace])...)
+		}
+
+		serviceEntries, err := kubeCache.GetServiceEntries(meta_v1.NamespaceAll, "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the service entries of cluster [%s]: %s", cluster, err)
+		}
+		for _, se := range serviceEntries {
+			var selector map[string]string
+			if se.Spec.WorkloadSelector != nil {
+				selector = se.Spec.WorkloadSelector.Labels
+			}
+			for _, host := range se.Spec.Hosts {
+				rSvc := &kubernetes.RegistryService{Pilot: hostCatalogPilot}
+				rSvc.Hostname = host
+				rSvc.Attributes.ServiceRegistry = "External"
+				rSvc.Attributes.Name = host
+				rSvc.Attributes.Namespace = se.Namespace
+				rSvc.Attributes.Labels = se.Labels
+				rSvc.Attributes.LabelSelectors = selector
+				rSvc.Attributes.ExportTo = exportToSet(se.Spec.ExportTo)
+				for _, port := range se.Spec.Ports {
+					addPort(rSvc, port.Name, int(port.Number), port.Protocol)
+				}
+				if len(se.Spec.Addresses) > 0 {
+					rSvc.ClusterVIPs12.Addresses = map[string][]string{cluster: se.Spec.Addresses}
+				}
+				rSvc.WorkloadEntryLabels = selectedLabels(selector, weLabels[se.Namespace])
+				seHosts = append(seHosts, rSvc)
+			}
+		}
+	}
+
+	catalog := make([]*kubernetes.RegistryService, 0, len(k8sHosts)+len(seHosts))
+	for _, rSvc := range k8sHosts {
+		catalog = append(catalog, rSvc)
+	}
+	catalog = append(catalog, seHosts...)
+	sort.SliceStable(catalog, func(i, j int) bool {
+		if catalog[i].Hostname != catalog[j].Hostname {
+			return catalog[i].Hostname < catalog[j].Hostname
+		}
+		return catalog[i].Attributes.Namespace < catalog[j].Attributes.Namespace
+	})
+	return catalog, nil
+}
+
+func newKubernetesRegistryService(svc *core_v1.Service, hostname string) *kubernetes.RegistryService {
+	rSvc := &kubernetes.RegistryService{Pilot: hostCatalogPilot}
+	rSvc.Hostname = hostname
+	rSvc.Attributes.ServiceRegistry = "Kubernetes"
+	rSvc.Attributes.Name = svc.Name
+	rSvc.Attributes.Namespace = svc.Namespace
+	rSvc.Attributes.Labels = svc.Labels
+	rSvc.Attributes.LabelSelectors = svc.Spec.Selector
+	if exportTo, ok := svc.Annotations[serviceExportToAnnotation]; ok {
+		rSvc.Attributes.ExportTo = exportToSet(strings.Split(exportTo, ","))
+	}
+	for _, port := range svc.Spec.Ports {
+		protocol := string(port.Protocol)
+		if port.AppProtocol != nil {
+			protocol = *port.AppProtocol
+		}
+		addPort(rSvc, port.Name, int(port.Port), protocol)
+	}
+	rSvc.ClusterVIPs12.Addresses = map[string][]string{}
+	return rSvc
+}
+
+func addPort(rSvc *kubernetes.RegistryService, name string, number int, protocol string) {
+	rSvc.Ports = append(rSvc.Ports, struct {
+		Name     string `json:"name,omitempty"`
+		Port     int    `json:"port"`
+		Protocol string `json:"protocol,omitempty"`
+	}{Name: name, Port: number, Protocol: protocol})
+}
+
+// serviceAddresses returns the cluster IPs of the service, none for a headless service.
+func serviceAddresses(svc *core_v1.Service) []string {
+	addresses := []string{}
+	for _, ip := range svc.Spec.ClusterIPs {
+		if ip != "" && ip != core_v1.ClusterIPNone {
+			addresses = append(addresses, ip)
+		}
+	}
+	return addresses
+}
+
+func exportToSet(exportTo []string) map[string]struct{} {
+	if len(exportTo) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(exportTo))
+	for _, ns := range exportTo {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			set[ns] = struct{}{}
+		}
+	}
+	return set
+}
+
+// selectedLabels returns the label sets matched by the selector. An empty selector selects nothing.
+func selectedLabels(selector map[string]string, labelSets []map[string]string) []map[string]string {
+	if len(selector) == 0 {
+		return nil
+	}
+	var selected []map[string]string
+	s := labels.SelectorFromSet(selector)
+	for _, labelSet := range labelSets {
+		if s.Matches(labels.Set(labelSet)) {
+			selected = append(selected, labelSet)
+		}
+	}
+	return selected
+}
+
+// refreshHostCatalog replaces the registry status of the clusters with the host catalog. Must be called with the
+// refreshLock held.
+func (p *controlPlaneMonitor) refreshHostCatalog(clusters map[string]bool) error {
+	catalog, err := buildHostCatalog(p.cache.GetKubeCaches(), p.conf.ExternalServices.Istio.IstioIdentityDomain)
+	if err != nil {
+		return err
+	}
+	for cluster := range clusters {
+		p.registryStatus[cluster] = &kubernetes.RegistryStatus{Services: catalog}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func hostCatalogObjects() []runtime.Object {
+	return []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        "reviews",
+				Namespace:   "bookinfo",
+				Annotations: map[string]string{serviceExportToAnnotation: ".,istio-system"},
+			},
+			Spec: core_v1.ServiceSpec{
+				ClusterIPs: []string{"10.0.0.1"},
+				Selector:   map[string]string{"app": "reviews"},
+				Ports:      []core_v1.ServicePort{{Name: "http", Port: 9080, Protocol: core_v1.ProtocolTCP}},
+			},
+		},
+		&networking_v1beta1.ServiceEntry{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "external", Namespace: "bookinfo"},
+			Spec: api_networking_v1beta1.ServiceEntry{
+				Hosts:            []string{"api.example.com", "vm.bookinfo.internal"},
+				Ports:            []*api_networking_v1beta1.ServicePort{{Name: "https", Number: 443, Protocol: "HTTPS"}},
+				WorkloadSelector: &api_networking_v1beta1.WorkloadSelector{Labels: map[string]string{"app": "vm"}},
+			},
+		},
+		&networking_v1beta1.WorkloadEntry{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-vm", Namespace: "bookinfo"},
+			Spec: api_networking_v1beta1.WorkloadEntry{
+				Address: "192.168.1.10",
+				Labels:  map[string]string{"app": "reviews", "version": "v3"},
+			},
+		},
+		&networking_v1beta1.WorkloadEntry{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "vm", Namespace: "bookinfo"},
+			Spec: api_networking_v1beta1.WorkloadEntry{
+				Address: "192.168.1.11",
+				Labels:  map[string]string{"app": "vm"},
+			},
+		},
+	}
+}
+
+func TestBuildHostCatalog(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient(hostCatalogObjects()...)
+	kialiCache := cache.NewTestingCache(t, k8s, *conf)
+
+	catalog, err := buildHostCatalog(kialiCache.GetKubeCaches(), conf.ExternalServices.Istio.IstioIdentityDomain)
+	require.NoError(err)
+	require.Len(catalog, 3)
+
+	se := catalog[0]
+	assert.Equal("api.example.com", se.Hostname)
+	assert.Equal("External", se.Attributes.ServiceRegistry)
+	assert.Equal("bookinfo", se.Attributes.Namespace)
+	assert.Empty(se.Attributes.ExportTo)
+	assert.Equal(443, se.Ports[0].Port)
+	assert.Equal([]map[string]string{{"app": "vm"}}, se.WorkloadEntryLabels)
+
+	svc := catalog[1]
+	assert.Equal("reviews.bookinfo.svc.cluster.local", svc.Hostname)
+	assert.Equal("Kubernetes", svc.Attributes.ServiceRegistry)
+	assert.Equal("reviews", svc.Attributes.Name)
+	assert.Equal(map[string]string{"app": "reviews"}, svc.Attributes.LabelSelectors)
+	assert.Equal(map[string][]string{conf.KubernetesConfig.ClusterName: {"10.0.0.1"}}, svc.ClusterVIPs12.Addresses)
+	assert.Equal([]map[string]string{{"app": "reviews", "version": "v3"}}, svc.WorkloadEntryLabels)
+
+	// exportTo semantics apply to the catalog
+	assert.True(kubernetes.HasMatchingRegistryService("bookinfo", svc.Hostname, catalog))
+	assert.True(kubernetes.HasMatchingRegistryService("istio-system", svc.Hostname, catalog))
+	assert.False(kubernetes.HasMatchingRegistryService("travels", svc.Hostname, catalog))
+	assert.True(kubernetes.HasMatchingRegistryService("travels", "vm.bookinfo.internal", catalog))
+}
+
+func TestRefreshIstioCacheWithHostCatalog(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	conf.ExternalServices.Istio.HostCatalogEnabled = true
+	kubernetes.SetConfig(t, *conf)
+
+	objects := append(hostCatalogObjects(),
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": "rootNamespace: istio-system\ntrustDomain: cluster.local\n"},
+		},
+	)
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	testServer := istiodTestServer(t)
+	fakeForwarder := &fakeForwarder{
+		ClientInterface: k8s,
+		testURL:         testServer.URL,
+	}
+
+	kialiCache := SetupBusinessLayer(t, fakeForwarder, *conf)
+
+	cf := kubetest.NewK8SClientFactoryMock(fakeForwarder)
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: fakeForwarder}
+	mesh := NewWithBackends(k8sclients, k8sclients, nil, nil).Mesh
+	cpm := NewControlPlaneMonitor(kialiCache, cf, *conf, &mesh)
+
+	require.NoError(cpm.RefreshIstioCache(context.TODO()))
+
+	// The registry services come from the catalog instead of /debug/registryz
+	registryStatus := kialiCache.GetRegistryStatus(conf.KubernetesConfig.ClusterName)
+	require.NotNil(registryStatus)
+	assert.Len(registryStatus.Services, 3)
+	for _, rSvc := range registryStatus.Services {
+		assert.Equal(hostCatalogPilot, rSvc.Pilot)
+	}
+}
@@ -13,7 +13,9 @@ import (
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	api_types "k8s.io/apimachinery/pkg/types"
 	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
 	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -31,6 +33,7 @@ const allResources string = "*"
 
 type IstioConfigService struct {
 	userClients         map[string]kubernetes.ClientInterface
+	kialiSAClients      map[string]kubernetes.ClientInterface
 	config              config.Config
 	kialiCache          cache.KialiCache
 	businessLayer       *Layer
@@ -59,6 +62,8 @@ type IstioConfigCriteria struct {
 	IncludeTelemetry              bool
 	LabelSelector                 string
 	WorkloadSelector              string
+	// ValidationSeverity keeps only the objects whose most severe validation check has this severity, when set
+	ValidationSeverity models.SeverityLevel
 }
 
 func (icc IstioConfigCriteria) Include(resource string) bool {
@@ -602,9 +607,71 @@ func (in *IstioConfigService) GetIstioConfigDetails(ctx context.Context, cluster
 
 	wg.Wait()
 
+	if err == nil {
+		if objectMeta, metaErr := meta.Accessor(istioConfigDetailsObject(istioConfigDetail)); metaErr == nil {
+			istioConfigDetail.ManagedBy = models.GetManagedBy(objectMeta.GetLabels(), objectMeta.GetAnnotations())
+		}
+	}
+
+	if err == nil && in.config.ExternalServices.Istio.IstiodConfigDistributionEnabled {
+		istioConfigDetail.Distribution = in.getConfigDistribution(cluster, &istioConfigDetail)
+	}
+
 	return istioConfigDetail, err
 }
 
+// getConfigDistribution compares the versions acked by the proxies, as last scraped from istiod,
+// with the current version of the config. Returns nil when istiod did not report on the config.
+func (in *IstioConfigService) getConfigDistribution(cluster string, details *models.IstioConfigDetails) *models.ConfigDistribution {
+	var meta meta_v1.ObjectMeta
+	switch details.ObjectType {
+	case kubernetes.DestinationRules:
+		meta = details.DestinationRule.ObjectMeta
+	case kubernetes.Gateways:
+		meta = details.Gateway.ObjectMeta
+	case kubernetes.ServiceEntries:
+		meta = details.ServiceEntry.ObjectMeta
+	case kubernetes.Sidecars:
+		meta = details.Sidecar.ObjectMeta
+	case kubernetes.VirtualServices:
+		meta = details.VirtualService.ObjectMeta
+	default:
+		return nil
+	}
+
+	distribution := in.kialiCache.GetConfigDistribution(cluster, meta.Namespace, details.ObjectType, meta.Name)
+	if distribution == nil {
+		return nil
+	}
+
+	result := &models.ConfigDistribution{
+		Proxies:        len(distribution.Proxies),
+		NotDistributed: []string{},
+	}
+	for _, proxy := range distribution.Proxies {
+		if !proxy.HasAcked(meta.ResourceVersion) {
+			result.NotDistributed = append(result.NotDistributed, proxy.ProxyID)
+		}
+	}
+	return result
+}
+
+// checkGitOpsManaged returns a GitOpsManagedError when the edits of the resources managed by a GitOps tool are
+// blocked and the object is managed
+func (in *IstioConfigService) checkGitOpsManaged(ctx context.Context, cluster, namespace, resourceType, name string) error {
+	if !in.config.KialiFeatureFlags.GitOps.BlockManagedEdits {
+		return nil
+	}
+	details, err := in.GetIstioConfigDetails(ctx, cluster, namespace, resourceType, name)
+	if err != nil {
+		return err
+	}
+	if details.ManagedBy != nil {
+		return newGitOpsManagedError(kubernetes.PluralType[resourceType], namespace, name, details.ManagedBy)
+	}
+	return nil
+}
+
 // GetIstioAPI provides the Kubernetes API that manages this Istio resource type
 // or empty string if it's not managed
 func GetIstioAPI(resourceType string) bool {
@@ -616,11 +683,22 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(ctx context.Context, clust
 	var err error
 	delOpts := meta_v1.DeleteOptions{}
 
+	if err := checkViewOnlyMode(&in.config, "Deleting the Istio config"); err != nil {
+		return err
+	}
+	if err := checkFeatureEnabled(&in.config, config.FeatureIstioConfigEdit); err != nil {
+		return err
+	}
+
 	userClient := in.userClients[cluster]
 	if userClient == nil {
 		return fmt.Errorf("K8s Client [%s] is not found or is not accessible for Kiali", cluster)
 	}
 
+	if err := in.checkGitOpsManaged(ctx, cluster, namespace, resourceType, name); err != nil {
+		return err
+	}
+
 	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
 	if err != nil {
 		return err
@@ -677,6 +755,7 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(ctx context.Context, clust
 		if err := in.controlPlaneMonitor.RefreshIstioCache(ctx); err != nil {
 			log.Errorf("Error while refreshing Istio cache: %s", err)
 		}
+		in.controlPlaneMonitor.NotifyConfigChange(cluster)
 	}
 
 	// We need to refresh the kube cache though at least until waiting for the object to be updated is implemented.
@@ -694,11 +773,22 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(ctx context.Context, clust
 	patchType := api_types.MergePatchType
 	bytePatch := []byte(jsonPatch)
 
+	if err := checkViewOnlyMode(&in.config, "Updating the Istio config"); err != nil {
+		return istioConfigDetail, err
+	}
+	if err := checkFeatureEnabled(&in.config, config.FeatureIstioConfigEdit); err != nil {
+		return istioConfigDetail, err
+	}
+
 	userClient := in.userClients[cluster]
 	if userClient == nil {
 		return istioConfigDetail, fmt.Errorf("K8s Client [%s] is not found or is not accessible for Kiali", cluster)
 	}
 
+	if err := in.checkGitOpsManaged(ctx, cluster, namespace, resourceType, name); err != nil {
+		return istioConfigDetail, err
+	}
+
 	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
 	if err != nil {
 		return istioConfigDetail, nil
@@ -770,6 +860,10 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(ctx context.Context, clust
 		return istioConfigDetail, err
 	}
 
+	if in.config.ExternalServices.Istio.IstioAPIEnabled {
+		in.controlPlaneMonitor.NotifyConfigChange(cluster)
+	}
+
 	// We need to refresh the kube cache though at least until waiting for the object to be updated is implemented.
 	kubeCache.Refresh(namespace)
 
@@ -783,6 +877,13 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 
 	createOpts := meta_v1.CreateOptions{}
 
+	if err := checkViewOnlyMode(&in.config, "Creating the Istio config"); err != nil {
+		return istioConfigDetail, err
+	}
+	if err := checkFeatureEnabled(&in.config, config.FeatureIstioConfigEdit); err != nil {
+		return istioConfigDetail, err
+	}
+
 	userClient := in.userClients[cluster]
 	if userClient == nil {
 		return istioConfigDetail, fmt.Errorf("K8s Client [%s] is not found or is not accessible for Kiali", cluster)
@@ -915,6 +1016,7 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		if err := in.controlPlaneMonitor.RefreshIstioCache(ctx); err != nil {
 			log.Errorf("Error while refreshing Istio cache: %s", err)
 		}
+		in.controlPlaneMonitor.NotifyConfigChange(cluster)
 	}
 	// We need to refresh the kube cache though at least until waiting for the object to be updated is implemented.
 	kubeCache.Refresh(namespace)
@@ -1175,3 +1277,46 @@ func ParseIstioConfigCriteria(objects, labelSelector, workloadSelector string) I
 	}
 	return criteria
 }
+
+// ParseIstioConfigGVKs returns the resource types (e.g. virtualservices) of a comma-separated list of Istio and
+// Gateway API kinds, as group/version/kind or group/kind (e.g. networking.istio.io/v1beta1/VirtualService).
+func ParseIstioConfigGVKs(gvks string) ([]string, error) {
+	resourceTypes := []string{}
+	for _, gvk := range strings.Split(gvks, ",") {
+		gvk = strings.TrimSpace(gvk)
+		if gvk == "" {
+			continue
+		}
+		parts := strings.Split(gvk, "/")
+		var resourceGVK schema.GroupVersionKind
+		switch len(parts) {
+		case 2:
+			resourceGVK = schema.GroupVersionKind{Group: parts[0], Kind: parts[1]}
+		case 3:
+			resourceGVK = schema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]}
+		default:
+			return nil, fmt.Errorf("invalid kind [%s]: expecting group/version/kind or group/kind", gvk)
+		}
+		resourceType, found := kubernetes.ResourceTypeForGVK(resourceGVK)
+		if !found {
+			return nil, fmt.Errorf("invalid kind [%s]: not an Istio or Gateway API kind", gvk)
+		}
+		if !checkType(resourceTypes, resourceType) {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	return resourceTypes, nil
+}
+
+// ParseValidationSeverity returns the validation severity of a validation status filter: error(s) or warning(s)
+func ParseValidationSeverity(status string) (models.SeverityLevel, error) {
+	switch strings.ToLower(status) {
+	case "":
+		return "", nil
+	case "error", "errors":
+		return models.ErrorSeverity, nil
+	case "warning", "warnings":
+		return models.WarningSeverity, nil
+	}
+	return "", fmt.Errorf("invalid validation status [%s]: expecting errors or warnings", status)
+}
@@ -0,0 +1,112 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// Annotations of the Gateway API CRDs with the release they are part of
+const (
+	gatewayAPIBundleVersionAnnotation = "gateway.networking.k8s.io/bundle-version"
+	gatewayAPIChannelAnnotation       = "gateway.networking.k8s.io/channel"
+)
+
+// GetIstioConfigSchemas returns the schemas of the Istio and Gateway API kinds of the cluster, read from their CRDs
+// with the Kiali service account, and the APIs and features available in the cluster. The schemas are cached, they
+// expire after a while so that CRD upgrades are eventually served.
+func (in *IstioConfigService) GetIstioConfigSchemas(ctx context.Context, cluster string) (*models.IstioConfigSchemas, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "GetIstioConfigSchemas",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+	)
+	defer end()
+
+	if schemas, found := in.kialiCache.GetIstioConfigSchemas(cluster); found {
+		return schemas, nil
+	}
+
+	client, found := in.kialiSAClients[cluster]
+	if !found {
+		client, found = in.userClients[cluster]
+		if !found {
+			return nil, fmt.Errorf("K8s Client [%s] is not found or is not accessible for Kiali", cluster)
+		}
+	}
+
+	schemas := &models.IstioConfigSchemas{
+		Cluster: cluster,
+		Features: models.IstioConfigFeatures{
+			IstioAPI:      client.IsIstioAPI(),
+			GatewayAPI:    client.IsGatewayAPI(),
+			ExpGatewayAPI: client.IsExpGatewayAPI(),
+			Ambient:       in.kialiCache.IsAmbientEnabled(cluster),
+		},
+		Schemas: []models.IstioConfigSchema{},
+	}
+	if serverVersion, err := client.GetServerVersion(); err == nil {
+		schemas.KubernetesVersion = serverVersion.GitVersion
+	} else {
+		log.Debugf("Unable to get the Kubernetes version of cluster [%s]: %s", cluster, err)
+	}
+
+	resourceTypes := make([]string, 0, len(kubernetes.ResourceTypesToAPI))
+	for resourceType := range kubernetes.ResourceTypesToAPI {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		gvk, _ := kubernetes.GVKForResourceType(resourceType)
+		schema := models.IstioConfigSchema{
+			ObjectType: resourceType,
+			Group:      gvk.Group,
+			Kind:       gvk.Kind,
+			Versions:   []models.IstioConfigSchemaVersion{},
+		}
+
+		crd, err := client.GetCustomResourceDefinition(crdName(resourceType, gvk.Group))
+		if err != nil {
+			if api_errors.IsNotFound(err) {
+				schemas.Schemas = append(schemas.Schemas, schema)
+				continue
+			}
+			return nil, err
+		}
+
+		schema.Installed = true
+		schema.BundleVersion = crd.Annotations[gatewayAPIBundleVersionAnnotation]
+		schema.Channel = crd.Annotations[gatewayAPIChannelAnnotation]
+		for _, v := range crd.Spec.Versions {
+			version := models.IstioConfigSchemaVersion{
+				Name:       v.Name,
+				Served:     v.Served,
+				Storage:    v.Storage,
+				Deprecated: v.Deprecated,
+			}
+			if v.Schema != nil {
+				version.Schema = v.Schema.OpenAPIV3Schema
+			}
+			schema.Versions = append(schema.Versions, version)
+		}
+		schemas.Schemas = append(schemas.Schemas, schema)
+	}
+
+	in.kialiCache.SetIstioConfigSchemas(cluster, schemas)
+	return schemas, nil
+}
+
+// crdName returns the name of the CRD of a resource type: the plural of the kind and its group, i.e.
+// virtualservices.networking.istio.io or httproutes.gateway.networking.k8s.io
+func crdName(resourceType, group string) string {
+	return strings.TrimPrefix(resourceType, "k8s") + "." + group
+}
@@ -0,0 +1,100 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+type crdClient struct {
+	crds  map[string]*kubernetes.CustomResourceDefinition
+	calls int
+	kubernetes.ClientInterface
+}
+
+func (c *crdClient) GetCustomResourceDefinition(name string) (*kubernetes.CustomResourceDefinition, error) {
+	c.calls++
+	if crd, found := c.crds[name]; found {
+		return crd, nil
+	}
+	return nil, api_errors.NewNotFound(schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}, name)
+}
+
+func TestGetIstioConfigSchemas(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vsCRD := &kubernetes.CustomResourceDefinition{}
+	vsCRD.Name = "virtualservices.networking.istio.io"
+	vsCRD.Spec.Versions = []kubernetes.CustomResourceDefinitionVersion{
+		{Name: "v1", Served: true, Storage: true, Schema: &kubernetes.CustomResourceValidation{OpenAPIV3Schema: json.RawMessage(`{"type":"object"}`)}},
+		{Name: "v1alpha3", Served: true, Deprecated: true},
+	}
+	httpRouteCRD := &kubernetes.CustomResourceDefinition{}
+	httpRouteCRD.Name = "httproutes.gateway.networking.k8s.io"
+	httpRouteCRD.Annotations = map[string]string{
+		gatewayAPIBundleVersionAnnotation: "v1.1.0",
+		gatewayAPIChannelAnnotation:       "standard",
+	}
+	httpRouteCRD.Spec.Versions = []kubernetes.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}}
+
+	k8s := &crdClient{
+		crds: map[string]*kubernetes.CustomResourceDefinition{
+			vsCRD.Name:        vsCRD,
+			httpRouteCRD.Name: httpRouteCRD,
+		},
+		ClientInterface: kubetest.NewFakeK8sClient(),
+	}
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+
+	schemas, err := layer.IstioConfig.GetIstioConfigSchemas(context.TODO(), conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.Equal(conf.KubernetesConfig.ClusterName, schemas.Cluster)
+	require.Len(schemas.Schemas, len(kubernetes.ResourceTypesToAPI))
+
+	installed := 0
+	for _, s := range schemas.Schemas {
+		switch s.ObjectType {
+		case kubernetes.VirtualServices:
+			installed++
+			assert.True(s.Installed)
+			assert.Equal("VirtualService", s.Kind)
+			require.Len(s.Versions, 2)
+			assert.JSONEq(`{"type":"object"}`, string(s.Versions[0].Schema))
+			assert.True(s.Versions[1].Deprecated)
+			assert.Nil(s.Versions[1].Schema)
+		case kubernetes.K8sHTTPRoutes:
+			installed++
+			assert.True(s.Installed)
+			assert.Equal("v1.1.0", s.BundleVersion)
+			assert.Equal("standard", s.Channel)
+		default:
+			assert.False(s.Installed, s.ObjectType)
+			assert.Empty(s.Versions, s.ObjectType)
+		}
+	}
+	assert.Equal(2, installed)
+
+	// the schemas are cached
+	calls := k8s.calls
+	_, err = layer.IstioConfig.GetIstioConfigSchemas(context.TODO(), conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.Equal(calls, k8s.calls)
+
+	_, err = layer.IstioConfig.GetIstioConfigSchemas(context.TODO(), "unknown")
+	assert.Error(err)
+}
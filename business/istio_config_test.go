@@ -2,6 +2,7 @@ package business
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -96,6 +97,41 @@ func TestParseListParams(t *testing.T) {
 	assert.False(t, criteria.IncludeServiceEntries)
 }
 
+func TestParseIstioConfigGVKs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	resourceTypes, err := ParseIstioConfigGVKs("networking.istio.io/v1beta1/VirtualService, gateway.networking.k8s.io/HTTPRoute,networking.istio.io/v1alpha3/VirtualService")
+	require.NoError(err)
+	assert.Equal([]string{kubernetes.VirtualServices, kubernetes.K8sHTTPRoutes}, resourceTypes)
+
+	criteria := ParseIstioConfigCriteria(strings.Join(resourceTypes, ","), "", "")
+	assert.True(criteria.IncludeVirtualServices)
+	assert.True(criteria.IncludeK8sHTTPRoutes)
+	assert.False(criteria.IncludeGateways)
+
+	for _, invalid := range []string{"VirtualService", "apps/v1/Deployment", "networking.istio.io/v1beta1/VirtualService/extra"} {
+		_, err := ParseIstioConfigGVKs(invalid)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestParseValidationSeverity(t *testing.T) {
+	assert := assert.New(t)
+
+	severity, err := ParseValidationSeverity("errors")
+	assert.NoError(err)
+	assert.Equal(models.ErrorSeverity, severity)
+	severity, err = ParseValidationSeverity("Warning")
+	assert.NoError(err)
+	assert.Equal(models.WarningSeverity, severity)
+	severity, err = ParseValidationSeverity("")
+	assert.NoError(err)
+	assert.Empty(severity)
+	_, err = ParseValidationSeverity("valid")
+	assert.Error(err)
+}
+
 func TestGetIstioConfigList(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
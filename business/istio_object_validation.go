@@ -0,0 +1,345 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// dryRunClient is the part of the typed clients of the Istio and Gateway API resources used by the dry runs
+type dryRunClient[T runtime.Object] interface {
+	Create(ctx context.Context, obj T, opts meta_v1.CreateOptions) (T, error)
+	Update(ctx context.Context, obj T, opts meta_v1.UpdateOptions) (T, error)
+}
+
+// dryRun submits the object to the API server without persisting it, as a create or as an update when the
+// object already exists, so that it goes through the schema validation and the admission webhooks.
+func dryRun[T runtime.Object](ctx context.Context, client dryRunClient[T], obj T) error {
+	_, err := client.Create(ctx, obj, meta_v1.CreateOptions{DryRun: []string{meta_v1.DryRunAll}})
+	if api_errors.IsAlreadyExists(err) {
+		_, err = client.Update(ctx, obj, meta_v1.UpdateOptions{DryRun: []string{meta_v1.DryRunAll}})
+	}
+	return err
+}
+
+// newIstioObject returns an empty object of a resource type which can be created from Kiali
+func newIstioObject(resourceType string) (runtime.Object, error) {
+	switch resourceType {
+	case kubernetes.AuthorizationPolicies:
+		return &security_v1beta1.AuthorizationPolicy{}, nil
+	case kubernetes.DestinationRules:
+		return &networking_v1beta1.DestinationRule{}, nil
+	case kubernetes.EnvoyFilters:
+		return &networking_v1alpha3.EnvoyFilter{}, nil
+	case kubernetes.Gateways:
+		return &networking_v1beta1.Gateway{}, nil
+	case kubernetes.K8sGateways:
+		return &k8s_networking_v1.Gateway{}, nil
+	case kubernetes.K8sHTTPRoutes:
+		return &k8s_networking_v1.HTTPRoute{}, nil
+	case kubernetes.K8sReferenceGrants:
+		return &k8s_networking_v1beta1.ReferenceGrant{}, nil
+	case kubernetes.PeerAuthentications:
+		return &security_v1beta1.PeerAuthentication{}, nil
+	case kubernetes.RequestAuthentications:
+		return &security_v1beta1.RequestAuthentication{}, nil
+	case kubernetes.ServiceEntries:
+		return &networking_v1beta1.ServiceEntry{}, nil
+	case kubernetes.Sidecars:
+		return &networking_v1beta1.Sidecar{}, nil
+	case kubernetes.Telemetries:
+		return &v1alpha1.Telemetry{}, nil
+	case kubernetes.VirtualServices:
+		return &networking_v1beta1.VirtualService{}, nil
+	case kubernetes.WasmPlugins:
+		return &extentions_v1alpha1.WasmPlugin{}, nil
+	case kubernetes.WorkloadEntries:
+		return &networking_v1beta1.WorkloadEntry{}, nil
+	case kubernetes.WorkloadGroups:
+		return &networking_v1beta1.WorkloadGroup{}, nil
+	}
+	return nil, fmt.Errorf("object type not found: %v", resourceType)
+}
+
+// ValidateIstioObject validates an Istio object before it is persisted, without persisting it: the object is
+// submitted to a server-side dry run (schema validation and admission webhooks, as a create or as an update of
+// an existing object) and, when accepted, run through the Kiali checkers of its type along with the rest of the
+// config of its namespace. An error is only returned when the object can't be validated.
+func (in *IstioConfigService) ValidateIstioObject(ctx context.Context, cluster string, obj runtime.Object) (*models.IstioObjectValidation, error) {
+	userClient := in.userClients[cluster]
+	if userClient == nil {
+		return nil, fmt.Errorf("K8s Client [%s] is not found or is not accessible for Kiali", cluster)
+	}
+	objectMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, api_errors.NewBadRequest(err.Error())
+	}
+	namespace, name := objectMeta.GetNamespace(), objectMeta.GetName()
+
+	var resourceType string
+	switch o := obj.(type) {
+	case *security_v1beta1.AuthorizationPolicy:
+		resourceType = kubernetes.AuthorizationPolicies
+		err = dryRun(ctx, userClient.Istio().SecurityV1beta1().AuthorizationPolicies(namespace), o)
+	case *networking_v1beta1.DestinationRule:
+		resourceType = kubernetes.DestinationRules
+		err = dryRun(ctx, userClient.Istio().NetworkingV1beta1().DestinationRules(namespace), o)
+	case *networking_v1alpha3.EnvoyFilter:
+		resourceType = kubernetes.EnvoyFilters
+		err = dryRun(ctx, userClient.Istio().NetworkingV1alpha3().EnvoyFilters(namespace), o)
+	case *networking_v1beta1.Gateway:
+		resourceType = kubernetes.Gateways
+		err = dryRun(ctx, userClient.Istio().NetworkingV1beta1().Gateways(namespace), o)
+	case *k8s_networking_v1.Gateway:
+		resourceType = kubernetes.K8sGateways
+		err = dryRun(ctx, userClient.GatewayAPI().GatewayV1().Gateways(namespace), o)
+	case *k8s_networking_v1.HTTPRoute:
+		resourceType = kubernetes.K8sHTTPRoutes
+		err = dryRun(ctx, userClient.GatewayAPI().GatewayV1().HTTPRoutes(namespace), o)
+	case *k8s_networking_v1beta1.ReferenceGrant:
+		resourceType = kubernetes.K8sReferenceGrants
+		err = dryRun(ctx, userClient.GatewayAPI().GatewayV1beta1().ReferenceGrants(namespace), o)
+	case *security_v1beta1.PeerAuthentication:
+		resourceType = kubernetes.PeerAuthentications
+		err = dryRun(ctx, userClient.Istio().SecurityV1beta1().PeerAuthentications(namespace), o)
+	case *security_v1beta1.RequestAuthentication:
+		resourceType = kubernetes.RequestAuthentications
+		err = dryRun(ctx, userClient.Istio().SecurityV1beta1().RequestAuthentications(namespace), o)
+	case *networking_v1beta1.ServiceEntry:
+		resourceType = kubernetes.ServiceEntries
+		err = dryRun(ctx, userClient.Istio().NetworkingV1beta1().ServiceEntries(namespace), o)
+	case *networking_v1beta1.Sidecar:
+		resourceType = kubernetes.Sidecars
+		err = dryRun(ctx, userClient.Istio().NetworkingV1beta1().Sidecars(namespace), o)
+	case *v1alpha1.Telemetry:
+		resourceType = kubernetes.Telemetries
+		err = dryRun(ctx, userClient.Istio().TelemetryV1alpha1().Telemetries(namespace), o)
+	case *networking_v1beta1.VirtualService:
+		resourceType = kubernetes.VirtualServices
+		err = dryRun(ctx, userClient.Istio().NetworkingV1beta1().VirtualServices(namespace), o)
+	case *extentions_v1alpha1.WasmPlugin:
+		resourceType = kubernetes.WasmPlugins
+		err = dryRun(ctx, userClient.Istio().ExtensionsV1alpha1().WasmPlugins(namespace), o)
+	case *networking_v1beta1.WorkloadEntry:
+		resourceType = kubernetes.WorkloadEntries
+		err = dryRun(ctx, userClient.Istio().NetworkingV1beta1().WorkloadEntries(namespace), o)
+	case *networking_v1beta1.WorkloadGroup:
+		resourceType = kubernetes.WorkloadGroups
+		err = dryRun(ctx, userClient.Istio().NetworkingV1beta1().WorkloadGroups(namespace), o)
+	default:
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("object type not supported: %T", obj))
+	}
+
+	validation := &models.IstioObjectValidation{
+		AdmissionErrors: []models.AdmissionError{},
+		Checks:          []*models.IstioCheck{},
+	}
+	if err != nil {
+		admissionErrors, ok := dryRunAdmissionErrors(err)
+		if !ok {
+			return nil, err
+		}
+		validation.AdmissionErrors = admissionErrors
+		return validation, nil
+	}
+
+	validations, _, err := in.businessLayer.Validations.getIstioObjectValidations(ctx, cluster, namespace, resourceType, name, obj)
+	if err != nil {
+		return nil, err
+	}
+	validation.Valid = true
+	for _, v := range validations {
+		validation.Checks = append(validation.Checks, v.Checks...)
+		validation.Valid = validation.Valid && v.Severity() != models.ErrorSeverity
+	}
+	return validation, nil
+}
+
+// dryRunAdmissionErrors returns the errors of an object rejected by the API server: the invalid fields of the
+// object or the denial of an admission webhook. It returns false when the error is not a rejection of the object.
+func dryRunAdmissionErrors(err error) ([]models.AdmissionError, bool) {
+	var statusErr api_errors.APIStatus
+	if !errors.As(err, &statusErr) || !(api_errors.IsInvalid(err) || api_errors.IsBadRequest(err)) {
+		return nil, false
+	}
+	status := statusErr.Status()
+	admissionErrors := []models.AdmissionError{}
+	if status.Details != nil {
+		for _, cause := range status.Details.Causes {
+			admissionErrors = append(admissionErrors, models.AdmissionError{
+				Field:   cause.Field,
+				Message: cause.Message,
+				Reason:  string(cause.Type),
+			})
+		}
+	}
+	if len(admissionErrors) == 0 {
+		admissionErrors = append(admissionErrors, models.AdmissionError{Message: status.Message, Reason: string(status.Reason)})
+	}
+	return admissionErrors, true
+}
+
+// ValidateIstioConfigCreate validates an object of the resource type in the namespace before it is created
+func (in *IstioConfigService) ValidateIstioConfigCreate(ctx context.Context, cluster, namespace, resourceType string, body []byte) (*models.IstioObjectValidation, error) {
+	obj, err := newIstioObject(resourceType)
+	if err != nil {
+		return nil, api_errors.NewBadRequest(err.Error())
+	}
+	if err := json.Unmarshal(body, obj); err != nil {
+		return nil, api_errors.NewBadRequest(err.Error())
+	}
+	objectMeta, _ := meta.Accessor(obj)
+	objectMeta.SetNamespace(namespace)
+	return in.ValidateIstioObject(ctx, cluster, obj)
+}
+
+// ValidateIstioConfigUpdate validates an object of the resource type in the namespace before it is updated with
+// the JSON merge patch: the validated object is the current object with the patch applied.
+func (in *IstioConfigService) ValidateIstioConfigUpdate(ctx context.Context, cluster, namespace, resourceType, name, jsonPatch string) (*models.IstioObjectValidation, error) {
+	details, err := in.GetIstioConfigDetails(ctx, cluster, namespace, resourceType, name)
+	if err != nil {
+		return nil, err
+	}
+	current, err := json.Marshal(istioConfigDetailsObject(details))
+	if err != nil {
+		return nil, err
+	}
+	patched, err := jsonpatch.MergePatch(current, []byte(jsonPatch))
+	if err != nil {
+		return nil, api_errors.NewBadRequest(err.Error())
+	}
+
+	obj, err := newIstioObject(resourceType)
+	if err != nil {
+		return nil, api_errors.NewBadRequest(err.Error())
+	}
+	if err := json.Unmarshal(patched, obj); err != nil {
+		return nil, api_errors.NewBadRequest(err.Error())
+	}
+	return in.ValidateIstioObject(ctx, cluster, obj)
+}
+
+// istioConfigDetailsObject returns the object of the details, nil for an unknown type
+func istioConfigDetailsObject(details models.IstioConfigDetails) runtime.Object {
+	switch details.ObjectType {
+	case kubernetes.AuthorizationPolicies:
+		return details.AuthorizationPolicy
+	case kubernetes.DestinationRules:
+		return details.DestinationRule
+	case kubernetes.EnvoyFilters:
+		return details.EnvoyFilter
+	case kubernetes.Gateways:
+		return details.Gateway
+	case kubernetes.K8sGateways:
+		return details.K8sGateway
+	case kubernetes.K8sGRPCRoutes:
+		return details.K8sGRPCRoute
+	case kubernetes.K8sHTTPRoutes:
+		return details.K8sHTTPRoute
+	case kubernetes.K8sReferenceGrants:
+		return details.K8sReferenceGrant
+	case kubernetes.K8sTCPRoutes:
+		return details.K8sTCPRoute
+	case kubernetes.K8sTLSRoutes:
+		return details.K8sTLSRoute
+	case kubernetes.PeerAuthentications:
+		return details.PeerAuthentication
+	case kubernetes.RequestAuthentications:
+		return details.RequestAuthentication
+	case kubernetes.ServiceEntries:
+		return details.ServiceEntry
+	case kubernetes.Sidecars:
+		return details.Sidecar
+	case kubernetes.Telemetries:
+		return details.Telemetry
+	case kubernetes.VirtualServices:
+		return details.VirtualService
+	case kubernetes.WasmPlugins:
+		return details.WasmPlugin
+	case kubernetes.WorkloadEntries:
+		return details.WorkloadEntry
+	case kubernetes.WorkloadGroups:
+		return details.WorkloadGroup
+	}
+	return nil
+}
+
+// addCandidateObject replaces the object with the same name and namespace as the candidate in the config, or adds
+// the candidate when there is no such object
+func addCandidateObject(candidate runtime.Object, istioConfigList *models.IstioConfigList, mtlsDetails *kubernetes.MTLSDetails, rbacDetails *kubernetes.RBACDetails) {
+	switch o := candidate.(type) {
+	case *security_v1beta1.AuthorizationPolicy:
+		istioConfigList.AuthorizationPolicies = replaceObject(istioConfigList.AuthorizationPolicies, o)
+		rbacDetails.AuthorizationPolicies = replaceObject(rbacDetails.AuthorizationPolicies, o)
+	case *networking_v1beta1.DestinationRule:
+		istioConfigList.DestinationRules = replaceObject(istioConfigList.DestinationRules, o)
+		mtlsDetails.DestinationRules = replaceObject(mtlsDetails.DestinationRules, o)
+	case *networking_v1alpha3.EnvoyFilter:
+		istioConfigList.EnvoyFilters = replaceObject(istioConfigList.EnvoyFilters, o)
+	case *networking_v1beta1.Gateway:
+		istioConfigList.Gateways = replaceObject(istioConfigList.Gateways, o)
+	case *k8s_networking_v1.Gateway:
+		istioConfigList.K8sGateways = replaceObject(istioConfigList.K8sGateways, o)
+	case *k8s_networking_v1.HTTPRoute:
+		istioConfigList.K8sHTTPRoutes = replaceObject(istioConfigList.K8sHTTPRoutes, o)
+	case *k8s_networking_v1beta1.ReferenceGrant:
+		istioConfigList.K8sReferenceGrants = replaceObject(istioConfigList.K8sReferenceGrants, o)
+	case *security_v1beta1.PeerAuthentication:
+		istioConfigList.PeerAuthentications = replaceObject(istioConfigList.PeerAuthentications, o)
+		mtlsDetails.PeerAuthentications = replaceObject(mtlsDetails.PeerAuthentications, o)
+	case *security_v1beta1.RequestAuthentication:
+		istioConfigList.RequestAuthentications = replaceObject(istioConfigList.RequestAuthentications, o)
+	case *networking_v1beta1.ServiceEntry:
+		istioConfigList.ServiceEntries = replaceObject(istioConfigList.ServiceEntries, o)
+	case *networking_v1beta1.Sidecar:
+		istioConfigList.Sidecars = replaceObject(istioConfigList.Sidecars, o)
+	case *v1alpha1.Telemetry:
+		istioConfigList.Telemetries = replaceObject(istioConfigList.Telemetries, o)
+	case *networking_v1beta1.VirtualService:
+		istioConfigList.VirtualServices = replaceObject(istioConfigList.VirtualServices, o)
+	case *extentions_v1alpha1.WasmPlugin:
+		istioConfigList.WasmPlugins = replaceObject(istioConfigList.WasmPlugins, o)
+	case *networking_v1beta1.WorkloadEntry:
+		istioConfigList.WorkloadEntries = replaceObject(istioConfigList.WorkloadEntries, o)
+	case *networking_v1beta1.WorkloadGroup:
+		istioConfigList.WorkloadGroups = replaceObject(istioConfigList.WorkloadGroups, o)
+	}
+}
+
+// replaceObject returns a copy of the objects with the object replacing the one with the same name and namespace,
+// or appended when there is no such object. The objects are not modified, they may be shared with the cache.
+func replaceObject[T runtime.Object](objects []T, obj T) []T {
+	replaced := make([]T, 0, len(objects)+1)
+	objMeta, _ := meta.Accessor(obj)
+	found := false
+	for _, existing := range objects {
+		existingMeta, err := meta.Accessor(existing)
+		if err == nil && existingMeta.GetName() == objMeta.GetName() && existingMeta.GetNamespace() == objMeta.GetNamespace() {
+			replaced = append(replaced, obj)
+			found = true
+			continue
+		}
+		replaced = append(replaced, existing)
+	}
+	if !found {
+		replaced = append(replaced, obj)
+	}
+	return replaced
+}
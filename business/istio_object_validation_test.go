@@ -0,0 +1,75 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestValidateIstioObject(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(t, fakeIstioConfigList(),
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "customer.test.svc.cluster.local"})
+	istioConfig := vs.businessLayer.IstioConfig
+
+	candidate := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("product", "v1", -1),
+		data.CreateEmptyVirtualService("new-vs", "test", []string{"product"}))
+	validation, err := istioConfig.ValidateIstioObject(context.TODO(), conf.KubernetesConfig.ClusterName, candidate)
+	require.NoError(err)
+	assert.True(validation.Valid)
+	assert.Empty(validation.AdmissionErrors)
+
+	// the same object bound to a missing gateway, validated as an update
+	candidate = data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("product", "v1", -1),
+		data.CreateEmptyVirtualService("new-vs", "test", []string{"product"}))
+	candidate.Spec.Gateways = []string{"missing"}
+	validation, err = istioConfig.ValidateIstioObject(context.TODO(), conf.KubernetesConfig.ClusterName, candidate)
+	require.NoError(err)
+	assert.False(validation.Valid)
+	codes := []string{}
+	for _, check := range validation.Checks {
+		codes = append(codes, check.Code)
+	}
+	assert.Contains(codes, "KIA1102")
+
+	_, err = istioConfig.ValidateIstioObject(context.TODO(), "unknown", candidate)
+	assert.Error(err)
+}
+
+func TestDryRunAdmissionErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	invalid := api_errors.NewInvalid(schema.GroupKind{Group: "networking.istio.io", Kind: "VirtualService"}, "reviews", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "http").Index(0).Child("route"), 90, "total destination weight 90 != 100"),
+	})
+	admissionErrors, ok := dryRunAdmissionErrors(invalid)
+	assert.True(ok)
+	assert.Equal([]models.AdmissionError{{Field: "spec.http[0].route", Message: "Invalid value: 90: total destination weight 90 != 100", Reason: "FieldValueInvalid"}}, admissionErrors)
+
+	denied := api_errors.NewBadRequest(`admission webhook "validation.istio.io" denied the request: configuration is invalid`)
+	admissionErrors, ok = dryRunAdmissionErrors(denied)
+	assert.True(ok)
+	assert.Len(admissionErrors, 1)
+	assert.Contains(admissionErrors[0].Message, "denied the request")
+
+	_, ok = dryRunAdmissionErrors(api_errors.NewForbidden(schema.GroupResource{Resource: kubernetes.VirtualServices}, "reviews", errors.New("no access")))
+	assert.False(ok)
+	_, ok = dryRunAdmissionErrors(errors.New("connection refused"))
+	assert.False(ok)
+}
@@ -3,22 +3,28 @@ package business
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
-	"github.com/kiali/kiali/util/httputil"
 )
 
-func NewIstioStatusService(userClients map[string]kubernetes.ClientInterface, businessLayer *Layer, cpm ControlPlaneMonitor) IstioStatusService {
+func NewIstioStatusService(userClients map[string]kubernetes.ClientInterface, cache cache.KialiCache, businessLayer *Layer, cpm ControlPlaneMonitor) IstioStatusService {
 	return IstioStatusService{
 		userClients:         userClients,
+		kialiCache:          cache,
 		businessLayer:       businessLayer,
 		controlPlaneMonitor: cpm,
 	}
@@ -27,6 +33,7 @@ func NewIstioStatusService(userClients map[string]kubernetes.ClientInterface, bu
 // SvcService deals with fetching istio/kubernetes services related content and convert to kiali model
 type IstioStatusService struct {
 	userClients         map[string]kubernetes.ClientInterface
+	kialiCache          cache.KialiCache
 	businessLayer       *Layer
 	controlPlaneMonitor ControlPlaneMonitor
 }
@@ -72,9 +79,169 @@ func (iss *IstioStatusService) getIstioComponentStatus(ctx context.Context, clus
 		return kubernetes.IstioComponentStatus{}, err
 	}
 
+	deploymentStatus.Merge(iss.getNodeAgentStatus(ctx, cluster))
 	return deploymentStatus.Merge(istiodStatus), nil
 }
 
+// nodeAgent is an istio component deployed by a DaemonSet on every node, found by the labels of its pods.
+type nodeAgent struct {
+	name     string
+	selector map[string]string
+	// redirectsTraffic is set for the agents redirecting the traffic of the pods of their node. The pods
+	// scheduled before the agent is ready on the node miss the redirection.
+	redirectsTraffic bool
+}
+
+var nodeAgents = []nodeAgent{
+	{name: "istio-cni-node", selector: map[string]string{"k8s-app": "istio-cni-node"}, redirectsTraffic: true},
+	{name: "ztunnel", selector: map[string]string{"app": "ztunnel"}},
+}
+
+// getNodeAgentStatus returns the status of the istio-cni and ztunnel DaemonSets of the cluster, with the readiness of
+// their pod on every node. The agents that are not installed are not reported.
+func (iss *IstioStatusService) getNodeAgentStatus(ctx context.Context, cluster string) kubernetes.IstioComponentStatus {
+	statuses := kubernetes.IstioComponentStatus{}
+	kubeCache, err := iss.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		log.Debugf("Unable to get the node agents of cluster [%s]: %s", cluster, err)
+		return statuses
+	}
+
+	for _, agent := range nodeAgents {
+		daemonSets, err := kubeCache.GetDaemonSetsWithSelector(metav1.NamespaceAll, agent.selector)
+		if err != nil {
+			log.Debugf("Unable to get the %s DaemonSets of cluster [%s]: %s", agent.name, cluster, err)
+			continue
+		}
+		for _, ds := range daemonSets {
+			pods, err := kubeCache.GetPods(ds.Namespace, labels.Set(agent.selector).String())
+			if err != nil {
+				log.Debugf("Unable to get the pods of DaemonSet [%s/%s] of cluster [%s]: %s", ds.Namespace, ds.Name, cluster, err)
+				continue
+			}
+			status := nodeAgentStatus(ds, pods)
+			if agent.redirectsTraffic {
+				iss.addEarlyPods(ctx, cluster, kubeCache, &status, pods)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses
+}
+
+// nodeAgentStatus returns the status of a node agent DaemonSet, healthy when its pod is ready on every node.
+func nodeAgentStatus(ds *apps_v1.DaemonSet, pods []core_v1.Pod) kubernetes.ComponentStatus {
+	status := kubernetes.ComponentStatus{
+		Name:      ds.Name,
+		Namespace: ds.Namespace,
+		Status:    kubernetes.ComponentHealthy,
+		IsCore:    true,
+		Nodes:     []kubernetes.NodeAgentStatus{},
+	}
+	desired, ready := ds.Status.DesiredNumberScheduled, ds.Status.NumberReady
+	switch {
+	case desired == 0:
+		status.Status = kubernetes.ComponentNotReady
+	case ready < desired:
+		status.Status = kubernetes.ComponentUnhealthy
+		status.Reasons = append(status.Reasons, fmt.Sprintf("ready on %d of %d nodes", ready, desired))
+	}
+
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		node := kubernetes.NodeAgentStatus{Node: pod.Spec.NodeName, Status: kubernetes.ComponentNotReady}
+		if _, isReady := podReadySince(pod); isReady {
+			node.Status = kubernetes.ComponentHealthy
+		}
+		status.Nodes = append(status.Nodes, node)
+	}
+	slices.SortFunc(status.Nodes, func(a, b kubernetes.NodeAgentStatus) int {
+		return strings.Compare(a.Node, b.Node)
+	})
+
+	return status
+}
+
+// addEarlyPods flags the nodes where pods were scheduled while the pod of the node agent was starting, between its
+// creation and its readiness. Their traffic may not be redirected and their init may fail. The pods scheduled before
+// the creation of the agent pod, like the pods of the node when the agent is restarted, are not flagged.
+func (iss *IstioStatusService) addEarlyPods(ctx context.Context, cluster string, kubeCache cache.KubeCache, status *kubernetes.ComponentStatus, agentPods []core_v1.Pod) {
+	type startWindow struct{ created, ready time.Time }
+	windows := map[string]startWindow{}
+	for _, pod := range agentPods {
+		if readySince, isReady := podReadySince(pod); isReady && pod.Spec.NodeName != "" {
+			windows[pod.Spec.NodeName] = startWindow{created: pod.CreationTimestamp.Time, ready: readySince}
+		}
+	}
+	if len(windows) == 0 {
+		return
+	}
+
+	namespaces, err := iss.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+	if err != nil {
+		log.Debugf("Unable to check the pods scheduled before %s was ready in cluster [%s]: %s", status.Name, cluster, err)
+		return
+	}
+
+	earlyPods := map[string][]string{}
+	for _, ns := range namespaces {
+		pods, err := kubeCache.GetPods(ns.Name, "")
+		if err != nil {
+			log.Debugf("Unable to check the pods of namespace [%s] scheduled before %s was ready in cluster [%s]: %s", ns.Name, status.Name, cluster, err)
+			continue
+		}
+		for _, pod := range pods {
+			window, found := windows[pod.Spec.NodeName]
+			if !found || pod.Spec.HostNetwork || isDaemonSetPod(pod) {
+				continue
+			}
+			scheduled, isScheduled := podConditionSince(pod, core_v1.PodScheduled)
+			if isScheduled && !scheduled.Before(window.created) && scheduled.Before(window.ready) {
+				earlyPods[pod.Spec.NodeName] = append(earlyPods[pod.Spec.NodeName], pod.Namespace+"/"+pod.Name)
+			}
+		}
+	}
+
+	for i := range status.Nodes {
+		node := &status.Nodes[i]
+		if pods := earlyPods[node.Node]; len(pods) > 0 {
+			slices.Sort(pods)
+			node.EarlyPods = pods
+			status.Reasons = append(status.Reasons, fmt.Sprintf("%d pods scheduled on node %s before %s was ready", len(pods), node.Node, status.Name))
+			if status.Status == kubernetes.ComponentHealthy {
+				status.Status = kubernetes.ComponentDegraded
+			}
+		}
+	}
+}
+
+// podReadySince returns since when the pod is ready.
+func podReadySince(pod core_v1.Pod) (time.Time, bool) {
+	return podConditionSince(pod, core_v1.PodReady)
+}
+
+// podConditionSince returns since when the condition of the pod is true.
+func podConditionSince(pod core_v1.Pod, conditionType core_v1.PodConditionType) (time.Time, bool) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == core_v1.ConditionTrue {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func isDaemonSetPod(pod core_v1.Pod) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == kubernetes.DaemonSetType {
+			return true
+		}
+	}
+	return false
+}
+
 func (iss *IstioStatusService) getComponentNamespacesWorkloads(ctx context.Context, cluster string) ([]*models.Workload, error) {
 	var wg sync.WaitGroup
 
@@ -234,8 +401,8 @@ func (iss *IstioStatusService) getAddonComponentStatus() kubernetes.IstioCompone
 
 	ics := kubernetes.IstioComponentStatus{}
 
-	go getAddonStatus("prometheus", true, extServices.Prometheus.IsCore, &extServices.Prometheus.Auth, extServices.Prometheus.URL, extServices.Prometheus.HealthCheckUrl, staChan, &wg)
-	go getAddonStatus("grafana", extServices.Grafana.Enabled, extServices.Grafana.IsCore, &extServices.Grafana.Auth, extServices.Grafana.InClusterURL, extServices.Grafana.HealthCheckUrl, staChan, &wg)
+	go getAddonStatus("prometheus", true, extServices.Prometheus.IsCore, &extServices.Prometheus.Auth, extServices.Prometheus.URL, extServices.Prometheus.HealthCheckUrl, extServices.Prometheus.URL+"/api/v1/status/buildinfo", staChan, &wg)
+	go getAddonStatus("grafana", extServices.Grafana.Enabled, extServices.Grafana.IsCore, &extServices.Grafana.Auth, extServices.Grafana.InClusterURL, extServices.Grafana.HealthCheckUrl, extServices.Grafana.InClusterURL+"/api/health", staChan, &wg)
 	go iss.getTracingStatus("tracing", extServices.Tracing.Enabled, extServices.Tracing.IsCore, staChan, &wg)
 
 	// Custom dashboards may use the main Prometheus config
@@ -243,7 +410,7 @@ func (iss *IstioStatusService) getAddonComponentStatus() kubernetes.IstioCompone
 	if customProm.URL == "" {
 		customProm = extServices.Prometheus
 	}
-	go getAddonStatus("custom dashboards", extServices.CustomDashboards.Enabled, extServices.CustomDashboards.IsCore, &customProm.Auth, customProm.URL, customProm.HealthCheckUrl, staChan, &wg)
+	go getAddonStatus("custom dashboards", extServices.CustomDashboards.Enabled, extServices.CustomDashboards.IsCore, &customProm.Auth, customProm.URL, customProm.HealthCheckUrl, customProm.URL+"/api/v1/status/buildinfo", staChan, &wg)
 
 	wg.Wait()
 
@@ -255,7 +422,7 @@ func (iss *IstioStatusService) getAddonComponentStatus() kubernetes.IstioCompone
 	return ics
 }
 
-func getAddonStatus(name string, enabled bool, isCore bool, auth *config.Auth, url string, healthCheckUrl string, staChan chan<- kubernetes.IstioComponentStatus, wg *sync.WaitGroup) {
+func getAddonStatus(name string, enabled bool, isCore bool, auth *config.Auth, url string, healthCheckUrl string, versionUrl string, staChan chan<- kubernetes.IstioComponentStatus, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	// When the addOn is disabled, don't perform any check
@@ -276,21 +443,8 @@ func getAddonStatus(name string, enabled bool, isCore bool, auth *config.Auth, u
 		auth.Token = token
 	}
 
-	status := kubernetes.ComponentHealthy
 	// Call the addOn service endpoint to find out whether is reachable or not
-	_, statusCode, _, err := httputil.HttpGet(url, auth, 10*time.Second, nil, nil)
-	if err != nil || statusCode > 399 {
-		log.Tracef("addon health check failed: name=[%v], url=[%v], code=[%v]", name, url, statusCode)
-		status = kubernetes.ComponentUnreachable
-	}
-
-	staChan <- kubernetes.IstioComponentStatus{
-		kubernetes.ComponentStatus{
-			Name:   name,
-			Status: status,
-			IsCore: isCore,
-		},
-	}
+	staChan <- kubernetes.IstioComponentStatus{probeAddon(name, url, isCore, httpAddonProbe(url, versionUrl, auth))}
 }
 
 func (iss *IstioStatusService) getTracingStatus(name string, enabled bool, isCore bool, staChan chan<- kubernetes.IstioComponentStatus, wg *sync.WaitGroup) {
@@ -300,19 +454,170 @@ func (iss *IstioStatusService) getTracingStatus(name string, enabled bool, isCor
 		return
 	}
 
-	status := kubernetes.ComponentHealthy
+	tracingUrl := config.Get().ExternalServices.Tracing.InClusterURL
+	status := probeAddon(name, tracingUrl, isCore, func() (string, error) {
+		accessible, err := iss.businessLayer.Tracing.GetStatus()
+		if !accessible {
+			log.Errorf("Error fetching availability of the tracing service: %v", err)
+			if err == nil {
+				err = fmt.Errorf("the tracing service is not accessible")
+			}
+			return "", err
+		}
+		return "", nil
+	})
+
+	staChan <- kubernetes.IstioComponentStatus{status}
+}
+
+// GetMeshComponentStatus returns the status of the controlplanes, gateways and waypoints
+// grouped per mesh and revision along with an overall health grade for each mesh.
+func (iss *IstioStatusService) GetMeshComponentStatus(ctx context.Context) (*models.MeshComponentStatus, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetMeshComponentStatus",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
 
-	accessible, err := iss.businessLayer.Tracing.GetStatus()
-	if !accessible {
-		log.Errorf("Error fetching availability of the tracing service: %v", err)
-		status = kubernetes.ComponentUnreachable
+	mesh, err := iss.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	staChan <- kubernetes.IstioComponentStatus{
-		kubernetes.ComponentStatus{
-			Name:   name,
-			Status: status,
-			IsCore: isCore,
-		},
+	meshes := map[string]*models.MeshStatus{}
+	meshIDs := []string{}
+	// Indexed by controlplane key so that gateways and waypoints can be assigned to their revision.
+	controlPlanes := map[string]*models.ControlPlaneComponentStatus{}
+	// The first controlplane found for every managed cluster. Proxies that are not labeled
+	// with a known revision are assigned to it.
+	clusterControlPlanes := map[string]*models.ControlPlaneComponentStatus{}
+
+	for _, cp := range mesh.ControlPlanes {
+		revision := cp.Revision
+		if revision == "" {
+			revision = "default"
+		}
+
+		status := &models.ControlPlaneComponentStatus{
+			Cluster:   cp.Cluster.Name,
+			Revision:  revision,
+			Istiod:    kubernetes.IstioComponentStatus{},
+			Gateways:  kubernetes.IstioComponentStatus{},
+			Waypoints: kubernetes.IstioComponentStatus{},
+		}
+
+		if client, ok := iss.userClients[cp.Cluster.Name]; ok {
+			istiodStatus, err := iss.controlPlaneMonitor.CanConnectToIstiodForRevision(client, cp.Revision, cp.IstiodNamespace)
+			if err != nil {
+				log.Debugf("Unable to get the status of istiod [%s] in cluster [%s]: %s", revision, cp.Cluster.Name, err)
+			}
+			status.Istiod = istiodStatus
+		}
+		if len(status.Istiod) == 0 {
+			status.Istiod = kubernetes.IstioComponentStatus{{
+				Name:      cp.IstiodName,
+				Namespace: cp.IstiodNamespace,
+				Status:    kubernetes.ComponentUnreachable,
+				IsCore:    true,
+			}}
+		}
+
+		controlPlanes[controlPlaneKey(cp.Cluster.Name, revision)] = status
+		for _, managed := range cp.ManagedClusters {
+			if _, found := clusterControlPlanes[managed.Name]; !found {
+				clusterControlPlanes[managed.Name] = status
+			}
+		}
+
+		meshID := controlPlaneMeshID(cp)
+		ms, found := meshes[meshID]
+		if !found {
+			ms = &models.MeshStatus{MeshID: meshID}
+			meshes[meshID] = ms
+			meshIDs = append(meshIDs, meshID)
+		}
+		ms.ControlPlanes = append(ms.ControlPlanes, *status)
+	}
+
+	// Gateways and waypoints run in the dataplane clusters, not necessarily where their controlplane is.
+	for cluster := range clusterControlPlanes {
+		proxies, err := iss.getMeshProxyWorkloads(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, wl := range proxies {
+			revision := wl.Labels[IstioRevisionLabel]
+			if revision == "" {
+				revision = "default"
+			}
+
+			cpStatus, found := controlPlanes[controlPlaneKey(cluster, revision)]
+			if !found {
+				cpStatus = clusterControlPlanes[cluster]
+			}
+
+			component := kubernetes.ComponentStatus{
+				Name:      wl.Name,
+				Namespace: wl.Namespace,
+				Status:    GetWorkloadStatus(*wl),
+			}
+			if wl.IsGateway() {
+				cpStatus.Gateways = append(cpStatus.Gateways, component)
+			} else {
+				cpStatus.Waypoints = append(cpStatus.Waypoints, component)
+			}
+		}
+	}
+
+	result := &models.MeshComponentStatus{
+		Meshes: []models.MeshStatus{},
+		Addons: iss.getAddonComponentStatus(),
+	}
+	for _, meshID := range meshIDs {
+		ms := meshes[meshID]
+		// Refresh the copies with the gateways and waypoints found above.
+		for i := range ms.ControlPlanes {
+			ms.ControlPlanes[i] = *controlPlanes[controlPlaneKey(ms.ControlPlanes[i].Cluster, ms.ControlPlanes[i].Revision)]
+		}
+		ms.ComputeGrade()
+		result.Meshes = append(result.Meshes, *ms)
+	}
+
+	return result, nil
+}
+
+// getMeshProxyWorkloads returns the gateway and waypoint workloads of every accessible namespace of the cluster.
+func (iss *IstioStatusService) getMeshProxyWorkloads(ctx context.Context, cluster string) ([]*models.Workload, error) {
+	namespaces, err := iss.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	proxies := []*models.Workload{}
+	for _, ns := range namespaces {
+		workloads, err := iss.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, ns.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, wl := range workloads {
+			if wl.IsGateway() || wl.Labels[config.WaypointLabel] == config.WaypointLabelValue {
+				proxies = append(proxies, wl)
+			}
+		}
+	}
+
+	return proxies, nil
+}
+
+// controlPlaneMeshID returns the mesh ID configured for the controlplane.
+// Istio defaults the mesh ID to the trust domain when it is not set.
+func controlPlaneMeshID(cp models.ControlPlane) string {
+	if meshID := cp.Config.DefaultConfig.MeshId; meshID != "" {
+		return meshID
+	}
+	if cp.Config.TrustDomain != "" {
+		return cp.Config.TrustDomain
 	}
+	return "cluster.local"
 }
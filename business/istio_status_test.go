@@ -5,12 +5,15 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apps_v1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -796,6 +799,62 @@ func TestDaemonSetComponentUnhealthy(t *testing.T) {
 	assert.Equal(1, *promCalls)
 }
 
+func TestNodeAgentStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cniStarted := time.Now().Add(-time.Hour).Truncate(time.Second)
+	podOnNode := func(name, namespace, node string, labels map[string]string, conditions ...v1.PodCondition) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels, CreationTimestamp: meta_v1.NewTime(cniStarted)},
+			Spec:       v1.PodSpec{NodeName: node},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, Conditions: conditions},
+		}
+	}
+	condition := func(conditionType v1.PodConditionType, since time.Time) v1.PodCondition {
+		return v1.PodCondition{Type: conditionType, Status: v1.ConditionTrue, LastTransitionTime: meta_v1.NewTime(since)}
+	}
+	cniLabels := map[string]string{"k8s-app": "istio-cni-node"}
+
+	objects := []runtime.Object{
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		fakeDeploymentWithStatus("istiod", map[string]string{"app": "istiod", "istio": "pilot"}, healthyStatus),
+		fakeDaemonSetWithStatus("istio-cni-node", cniLabels, apps_v1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberReady: 1}),
+		podOnNode("istio-cni-node-a", "istio-system", "node-a", cniLabels, condition(v1.PodReady, cniStarted.Add(time.Minute))),
+		podOnNode("istio-cni-node-b", "istio-system", "node-b", cniLabels),
+		// Scheduled while istio-cni was starting on its node
+		podOnNode("productpage", "bookinfo", "node-a", nil, condition(v1.PodScheduled, cniStarted.Add(30*time.Second))),
+		// Scheduled before istio-cni was restarted on its node
+		podOnNode("reviews", "bookinfo", "node-a", nil, condition(v1.PodScheduled, cniStarted.Add(-time.Hour))),
+		// Scheduled once istio-cni was ready on its node
+		podOnNode("ratings", "bookinfo", "node-a", nil, condition(v1.PodScheduled, cniStarted.Add(2*time.Minute))),
+	}
+	k8s, _, _ := mockAddOnsCalls(t, objects, true, false)
+
+	conf := config.Get()
+	conf.ExternalServices.Istio.ComponentStatuses = config.ComponentStatuses{
+		Enabled:    true,
+		Components: []config.ComponentStatus{{AppLabel: "istiod", IsCore: true}},
+	}
+	config.Set(conf)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	iss := NewWithBackends(clients, clients, nil, mockJaeger()).IstioStatus
+
+	icsl, err := iss.GetStatus(context.TODO(), conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assertComponent(assert, icsl, "istio-cni-node", kubernetes.ComponentUnhealthy, true)
+	assertNotPresent(assert, icsl, "ztunnel")
+
+	cni := icsl[slices.IndexFunc(icsl, func(c kubernetes.ComponentStatus) bool { return c.Name == "istio-cni-node" })]
+	assert.Equal([]kubernetes.NodeAgentStatus{
+		{Node: "node-a", Status: kubernetes.ComponentHealthy, EarlyPods: []string{"bookinfo/productpage"}},
+		{Node: "node-b", Status: kubernetes.ComponentNotReady},
+	}, cni.Nodes)
+	assert.Equal([]string{"ready on 1 of 2 nodes", "1 pods scheduled on node node-a before istio-cni-node was ready"}, cni.Reasons)
+}
+
 func assertComponent(assert *assert.Assertions, icsl kubernetes.IstioComponentStatus, name string, status string, isCore bool) {
 	componentFound := false
 	for _, ics := range icsl {
@@ -994,3 +1053,54 @@ func addonAddMockUrls(baseUrl string, conf *config.Config, overrideUrl bool) *co
 	}
 	return conf
 }
+
+// An istiod installed without a revision label, outside of the configured istio namespace, is reached in the
+// namespace of its controlplane.
+func TestMeshComponentStatusIstiodWithoutRevision(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	addons := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(addons.Close)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.URL = addons.URL
+	conf.ExternalServices.Grafana.Enabled = false
+	conf.ExternalServices.Tracing.Enabled = false
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	kubernetes.SetConfig(t, *conf)
+
+	istiod := fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, false)
+	istiod.Namespace = "istio-control"
+	delete(istiod.Labels, IstioRevisionLabel)
+	istiodPod := runningIstiodPod()
+	istiodPod.Namespace = "istio-control"
+	delete(istiodPod.Labels, IstioRevisionLabel)
+	istioConfigMap := fakeIstioConfigMap("default")
+	istioConfigMap.Namespace = "istio-control"
+	k8s := &fakeForwarder{
+		ClientInterface: kubetest.NewFakeK8sClient(
+			&v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-control"}},
+			istiod,
+			istiodPod,
+			istioConfigMap,
+		),
+		testURL: istiodTestServer(t).URL,
+	}
+
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	WithControlPlaneMonitor(NewControlPlaneMonitor(cache, kubetest.NewK8SClientFactoryMock(k8s), *conf, nil))
+
+	status, err := NewWithBackends(clients, clients, nil, nil).IstioStatus.GetMeshComponentStatus(context.TODO())
+	require.NoError(err)
+	require.Len(status.Meshes, 1)
+	require.Len(status.Meshes[0].ControlPlanes, 1)
+
+	controlPlane := status.Meshes[0].ControlPlanes[0]
+	assert.Equal("default", controlPlane.Revision)
+	require.Len(controlPlane.Istiod, 1)
+	assert.Equal("istiod-123", controlPlane.Istiod[0].Name)
+	assert.Equal("istio-control", controlPlane.Istiod[0].Namespace)
+	assert.Equal(kubernetes.ComponentHealthy, controlPlane.Istiod[0].Status)
+}
@@ -10,9 +10,9 @@ import (
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/kiali/kiali/business/checkers"
-	"github.com/kiali/kiali/business/references"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
@@ -116,7 +116,8 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster,
 		}
 	}
 
-	objectCheckers := in.getAllObjectCheckers(istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, namespaces, registryServices, cluster, serviceAccounts)
+	tenantScoped := in.businessLayer.Namespace.IsTenantScoped(ctx, cluster)
+	objectCheckers := in.getAllObjectCheckers(ctx, istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, namespaces, registryServices, cluster, serviceAccounts, tenantScoped)
 
 	// Get group validations for same kind istio objects
 	validations := runObjectCheckers(objectCheckers)
@@ -135,21 +136,21 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster,
 	return validations, nil
 }
 
-func (in *IstioValidationsService) getAllObjectCheckers(istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces []models.Namespace, registryServices []*kubernetes.RegistryService, cluster string, serviceAccounts map[string][]string) []ObjectChecker {
+func (in *IstioValidationsService) getAllObjectCheckers(ctx context.Context, istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces []models.Namespace, registryServices []*kubernetes.RegistryService, cluster string, serviceAccounts map[string][]string, tenantScoped bool) []ObjectChecker {
 	return []ObjectChecker{
 		checkers.NoServiceChecker{Namespaces: namespaces, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, AuthorizationDetails: &rbacDetails, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny(), Cluster: cluster},
-		checkers.VirtualServiceChecker{Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules, Cluster: cluster},
-		checkers.DestinationRulesChecker{Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, MTLSDetails: mtlsDetails, ServiceEntries: istioConfigList.ServiceEntries, Cluster: cluster},
+		checkers.VirtualServiceChecker{Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules, Cluster: cluster, TenantScoped: tenantScoped},
+		checkers.DestinationRulesChecker{Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, MTLSDetails: mtlsDetails, ServiceEntries: istioConfigList.ServiceEntries, Cluster: cluster, TenantScoped: tenantScoped},
 		checkers.GatewayChecker{Gateways: istioConfigList.Gateways, WorkloadsPerNamespace: workloadsPerNamespace, IsGatewayToNamespace: in.isGatewayToNamespace(), Cluster: cluster},
 		checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace, Cluster: cluster},
-		checkers.ServiceEntryChecker{ServiceEntries: istioConfigList.ServiceEntries, Namespaces: namespaces, WorkloadEntries: istioConfigList.WorkloadEntries, Cluster: cluster},
-		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny(), Cluster: cluster, ServiceAccounts: serviceAccounts},
+		checkers.ServiceEntryChecker{ServiceEntries: istioConfigList.ServiceEntries, Namespaces: namespaces, WorkloadEntries: istioConfigList.WorkloadEntries, Cluster: cluster, TenantScoped: tenantScoped},
+		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, K8sGateways: istioConfigList.K8sGateways, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny(), Cluster: cluster, ServiceAccounts: serviceAccounts, TrustDomains: in.meshTrustDomains(ctx), RequestAuthentications: istioConfigList.RequestAuthentications, TenantScoped: tenantScoped},
 		checkers.SidecarChecker{Sidecars: istioConfigList.Sidecars, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, Cluster: cluster},
-		checkers.RequestAuthenticationChecker{RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace, Cluster: cluster},
-		checkers.WorkloadChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, WorkloadsPerNamespace: workloadsPerNamespace, Cluster: cluster},
+		checkers.RequestAuthenticationChecker{RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace, Cluster: cluster, JwksProbe: in.jwksProbe()},
+		checkers.WorkloadChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, WorkloadsPerNamespace: workloadsPerNamespace, TrustBundle: kialiCache.GetTrustBundleStatus(cluster), Cluster: cluster},
 		checkers.K8sGatewayChecker{K8sGateways: istioConfigList.K8sGateways, Cluster: cluster, GatewayClasses: in.businessLayer.IstioConfig.GatewayAPIClasses(cluster)},
 		checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, RegistryServices: registryServices, Cluster: cluster},
-		checkers.K8sReferenceGrantChecker{K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, Cluster: cluster},
+		checkers.K8sReferenceGrantChecker{K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, Cluster: cluster, TenantScoped: tenantScoped},
 		checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces},
 		checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces},
 	}
@@ -157,6 +158,13 @@ func (in *IstioValidationsService) getAllObjectCheckers(istioConfigList models.I
 
 // GetIstioObjectValidations validates a single Istio object of the given type with the given name found in the given namespace.
 func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context, cluster, namespace string, objectType string, object string) (models.IstioValidations, models.IstioReferencesMap, error) {
+	return in.getIstioObjectValidations(ctx, cluster, namespace, objectType, object, nil)
+}
+
+// getIstioObjectValidations validates an Istio object of the namespace. When a candidate object is given, it
+// replaces the persisted object with the same name (or is added to the config) before running the checkers, so
+// that an object can be validated before it is created or updated. The references of a candidate are not built.
+func (in *IstioValidationsService) getIstioObjectValidations(ctx context.Context, cluster, namespace string, objectType string, object string, candidate runtime.Object) (models.IstioValidations, models.IstioReferencesMap, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "GetIstioObjectValidations",
 		observability.Attribute("package", "business"),
@@ -176,7 +184,6 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 	var serviceAccounts map[string][]string
 	var err error
 	var objectCheckers []ObjectChecker
-	var referenceChecker ReferenceChecker
 	istioReferences := models.IstioReferencesMap{}
 
 	istioApiEnabled := config.Get().ExternalServices.Istio.IstioAPIEnabled
@@ -191,6 +198,9 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 	timer := internalmetrics.GetSingleValidationProcessingTimePrometheusTimer(namespace, objectType, object)
 	defer timer.ObserveDuration()
 
+	// Read before fetching the config so that changes made meanwhile invalidate the reference index.
+	configVersion := in.configVersion(cluster)
+
 	wg := sync.WaitGroup{}
 	errChan := make(chan error, 1)
 
@@ -209,6 +219,11 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 
 	wg.Wait()
 
+	if candidate != nil {
+		addCandidateObject(candidate, &istioConfigList, &mtlsDetails, &rbacDetails)
+	}
+
+	tenantScoped := in.businessLayer.Namespace.IsTenantScoped(ctx, cluster)
 	noServiceChecker := checkers.NoServiceChecker{Cluster: cluster, Namespaces: namespaces, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, AuthorizationDetails: &rbacDetails, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny()}
 
 	switch objectType {
@@ -216,46 +231,40 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		objectCheckers = []ObjectChecker{
 			checkers.GatewayChecker{Cluster: cluster, Gateways: istioConfigList.Gateways, WorkloadsPerNamespace: workloadsPerNamespace, IsGatewayToNamespace: in.isGatewayToNamespace()},
 		}
-		referenceChecker = references.GatewayReferences{Gateways: istioConfigList.Gateways, VirtualServices: istioConfigList.VirtualServices, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.VirtualServices:
-		virtualServiceChecker := checkers.VirtualServiceChecker{Cluster: cluster, Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules}
+		virtualServiceChecker := checkers.VirtualServiceChecker{Cluster: cluster, Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules, TenantScoped: tenantScoped}
 		objectCheckers = []ObjectChecker{noServiceChecker, virtualServiceChecker}
-		referenceChecker = references.VirtualServiceReferences{Namespace: namespace, Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules, AuthorizationPolicies: rbacDetails.AuthorizationPolicies}
 	case kubernetes.DestinationRules:
-		destinationRulesChecker := checkers.DestinationRulesChecker{Cluster: cluster, Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, MTLSDetails: mtlsDetails, ServiceEntries: istioConfigList.ServiceEntries}
+		destinationRulesChecker := checkers.DestinationRulesChecker{Cluster: cluster, Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, MTLSDetails: mtlsDetails, ServiceEntries: istioConfigList.ServiceEntries, TenantScoped: tenantScoped}
 		objectCheckers = []ObjectChecker{noServiceChecker, destinationRulesChecker}
-		referenceChecker = references.DestinationRuleReferences{Namespace: namespace, Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, VirtualServices: istioConfigList.VirtualServices, WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices}
 	case kubernetes.ServiceEntries:
-		serviceEntryChecker := checkers.ServiceEntryChecker{Cluster: cluster, ServiceEntries: istioConfigList.ServiceEntries, Namespaces: namespaces, WorkloadEntries: istioConfigList.WorkloadEntries}
+		serviceEntryChecker := checkers.ServiceEntryChecker{Cluster: cluster, ServiceEntries: istioConfigList.ServiceEntries, Namespaces: namespaces, WorkloadEntries: istioConfigList.WorkloadEntries, TenantScoped: tenantScoped}
 		objectCheckers = []ObjectChecker{serviceEntryChecker}
-		referenceChecker = references.ServiceEntryReferences{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, ServiceEntries: istioConfigList.ServiceEntries, Sidecars: istioConfigList.Sidecars, RegistryServices: registryServices}
 	case kubernetes.Sidecars:
 		sidecarsChecker := checkers.SidecarChecker{
 			Cluster: cluster, Sidecars: istioConfigList.Sidecars, Namespaces: namespaces,
 			WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices,
 		}
 		objectCheckers = []ObjectChecker{sidecarsChecker}
-		referenceChecker = references.SidecarReferences{Sidecars: istioConfigList.Sidecars, Namespace: namespace, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.AuthorizationPolicies:
 		authPoliciesChecker := checkers.AuthorizationPolicyChecker{
 			AuthorizationPolicies: rbacDetails.AuthorizationPolicies,
 			Cluster:               cluster, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, ServiceAccounts: serviceAccounts,
 			WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny(),
+			TrustDomains: in.meshTrustDomains(ctx), RequestAuthentications: istioConfigList.RequestAuthentications, K8sGateways: istioConfigList.K8sGateways,
+			TenantScoped: tenantScoped,
 		}
 		objectCheckers = []ObjectChecker{authPoliciesChecker}
-		referenceChecker = references.AuthorizationPolicyReferences{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.PeerAuthentications:
 		// Validations on PeerAuthentications
 		peerAuthnChecker := checkers.PeerAuthenticationChecker{Cluster: cluster, PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace}
 		objectCheckers = []ObjectChecker{peerAuthnChecker}
-		referenceChecker = references.PeerAuthReferences{MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.WorkloadEntries:
 		// Validation on WorkloadEntries are not yet in place
 	case kubernetes.WorkloadGroups:
 		// Validation on WorkloadGroups are not yet in place
 	case kubernetes.RequestAuthentications:
-		// Validation on RequestAuthentications are not yet in place
-		requestAuthnChecker := checkers.RequestAuthenticationChecker{Cluster: cluster, RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace}
+		requestAuthnChecker := checkers.RequestAuthenticationChecker{Cluster: cluster, RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace, JwksProbe: in.jwksProbe()}
 		objectCheckers = []ObjectChecker{requestAuthnChecker}
 	case kubernetes.EnvoyFilters:
 		// Validation on EnvoyFilters are not yet in place
@@ -268,16 +277,14 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		objectCheckers = []ObjectChecker{
 			checkers.K8sGatewayChecker{Cluster: cluster, K8sGateways: istioConfigList.K8sGateways, GatewayClasses: in.businessLayer.IstioConfig.GatewayAPIClasses(cluster)},
 		}
-		referenceChecker = references.K8sGatewayReferences{K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes}
 	case kubernetes.K8sGRPCRoutes:
 		// Validation on K8sGRPCRoutes is not expected
 	case kubernetes.K8sHTTPRoutes:
 		httpRouteChecker := checkers.K8sHTTPRouteChecker{Cluster: cluster, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, RegistryServices: registryServices}
 		objectCheckers = []ObjectChecker{noServiceChecker, httpRouteChecker}
-		referenceChecker = references.K8sHTTPRouteReferences{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, Namespaces: namespaces, K8sReferenceGrants: istioConfigList.K8sReferenceGrants}
 	case kubernetes.K8sReferenceGrants:
 		objectCheckers = []ObjectChecker{
-			checkers.K8sReferenceGrantChecker{Cluster: cluster, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces},
+			checkers.K8sReferenceGrantChecker{Cluster: cluster, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, TenantScoped: tenantScoped},
 		}
 	case kubernetes.K8sTCPRoutes:
 		// Validation on K8sTCPRoutes is not expected
@@ -294,8 +301,17 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		}
 	}
 
-	if referenceChecker != nil {
-		istioReferences = runObjectReferenceChecker(referenceChecker)
+	// The reference index is cached for the persisted config, it must not include a candidate
+	if candidate == nil {
+		index, found := kialiCache.GetReferenceIndex(cluster, namespace)
+		if !found {
+			index = in.buildReferenceIndex(configVersion, istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, namespaces, registryServices, namespace)
+			kialiCache.SetReferenceIndex(cluster, namespace, index)
+		}
+		key := models.IstioReferenceKey{ObjectType: models.ObjectTypeSingular[objectType], Namespace: namespace, Name: object}
+		if references, found := index.References[key]; found {
+			istioReferences[key] = filterReferences(references, namespaces)
+		}
 	}
 
 	if objectCheckers == nil {
@@ -468,9 +484,13 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 		IncludeSidecars:               true,
 		IncludeRequestAuthentications: true,
 		IncludeWorkloadEntries:        true,
+		IncludeWorkloadGroups:         true,
+		IncludeWasmPlugins:            true,
+		IncludeTelemetry:              true,
 		IncludeAuthorizationPolicies:  true,
 		IncludePeerAuthentications:    true,
 		IncludeK8sHTTPRoutes:          true,
+		IncludeK8sGRPCRoutes:          true,
 		IncludeK8sGateways:            true,
 		IncludeK8sReferenceGrants:     true,
 	}
@@ -503,6 +523,9 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 	// All K8sHTTPRoutes
 	rValue.K8sHTTPRoutes = append(rValue.K8sHTTPRoutes, istioConfigList.K8sHTTPRoutes...)
 
+	// All K8sGRPCRoutes
+	rValue.K8sGRPCRoutes = append(rValue.K8sGRPCRoutes, istioConfigList.K8sGRPCRoutes...)
+
 	// All K8sReferenceGrants
 	rValue.K8sReferenceGrants = append(rValue.K8sReferenceGrants, istioConfigList.K8sReferenceGrants...)
 
@@ -515,6 +538,15 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 	// All WorkloadEntries
 	rValue.WorkloadEntries = append(rValue.WorkloadEntries, istioConfigList.WorkloadEntries...)
 
+	// All WorkloadGroups
+	rValue.WorkloadGroups = append(rValue.WorkloadGroups, istioConfigList.WorkloadGroups...)
+
+	// All WasmPlugins
+	rValue.WasmPlugins = append(rValue.WasmPlugins, istioConfigList.WasmPlugins...)
+
+	// All Telemetries
+	rValue.Telemetries = append(rValue.Telemetries, istioConfigList.Telemetries...)
+
 	in.filterPeerAuths(namespace, mtlsDetails, istioConfigList.PeerAuthentications)
 
 	in.filterAuthPolicies(namespace, rbacDetails, istioConfigList.AuthorizationPolicies)
@@ -586,20 +618,9 @@ func (in *IstioValidationsService) isExportedObjectIncluded(exportTo []string, a
 	// Ambient mode namespace does not support ExportTo, so export only to own namespace
 	if in.businessLayer.IstioConfig.IsAmbientEnabled(cluster) && allNamespaces.IsNamespaceAmbient(objectNamespace, cluster) {
 		return objectNamespace == exportedNamespace
-	} else {
-		if len(exportTo) > 0 {
-			for _, exportToNs := range exportTo {
-				// take only namespaces where it is exported to, or if it is exported to all namespaces, or export to own namespace
-				if checkExportTo(exportToNs, exportedNamespace, objectNamespace, allNamespaces) {
-					return true
-				}
-			}
-		} else {
-			// no exportTo field, means object exported to all namespaces
-			return true
-		}
 	}
-	return false
+	// when exported to non-existing namespace, consider it to show validation error
+	return kubernetes.IsExportedTo(exportTo, objectNamespace, exportedNamespace) || isExportedToUnknownNamespace(exportTo, allNamespaces)
 }
 
 func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kubernetes.MTLSDetails, cluster string, errChan chan error, wg *sync.WaitGroup) {
@@ -646,6 +667,29 @@ func (in *IstioValidationsService) isGatewayToNamespace() bool {
 	return false
 }
 
+// meshTrustDomains returns the trust domains of the controlplanes of the mesh. They are empty when the mesh
+// cannot be discovered, the AuthorizationPolicy checker then skips the trust domain validation.
+func (in *IstioValidationsService) meshTrustDomains(ctx context.Context) models.MeshTrustDomains {
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		log.Errorf("Error getting mesh config: %s", err)
+		return models.MeshTrustDomains{}
+	}
+
+	return mesh.TrustDomains()
+}
+
+// jwksProbe returns the probe of the jwksUris of the RequestAuthentications, nil when probing is disabled.
+// It never blocks, the jwksUris are probed in the background.
+func (in *IstioValidationsService) jwksProbe() func(string) error {
+	if !config.Get().KialiFeatureFlags.Validations.ProbeJwksUri {
+		return nil
+	}
+	return func(uri string) error {
+		return jwksProbes.probe(kialiCache, uri)
+	}
+}
+
 func (in *IstioValidationsService) isPolicyAllowAny() bool {
 	allowAny := false
 	if in.businessLayer != nil {
@@ -658,8 +702,12 @@ func (in *IstioValidationsService) isPolicyAllowAny() bool {
 	return allowAny
 }
 
-func checkExportTo(exportToNs string, namespace string, ownNs string, allNamespaces models.Namespaces) bool {
-	// check if namespaces where it is exported to, or if it is exported to all namespaces, or export to own namespace
-	// when exported to non-existing namespace, consider it to show validation error
-	return exportToNs == "*" || exportToNs == namespace || (exportToNs == "." && ownNs == namespace) || (exportToNs != "." && exportToNs != "*" && !allNamespaces.Includes(exportToNs))
+// isExportedToUnknownNamespace returns true when the exportTo names a namespace that doesn't exist.
+func isExportedToUnknownNamespace(exportTo []string, allNamespaces models.Namespaces) bool {
+	for _, exportToNs := range exportTo {
+		if exportToNs != "." && exportToNs != "*" && exportToNs != "~" && !allNamespaces.Includes(exportToNs) {
+			return true
+		}
+	}
+	return false
 }
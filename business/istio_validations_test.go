@@ -176,6 +176,8 @@ func TestFilterExportToNamespacesVS(t *testing.T) {
 	currentIstioObjects = append(currentIstioObjects, vs3toall)
 	vs3towrong := loadVirtualService("vs_bookinfo3_to_wrong.yaml", t)
 	currentIstioObjects = append(currentIstioObjects, vs3towrong)
+	vs1tonone := loadVirtualService("vs_bookinfo1_to_none.yaml", t)
+	currentIstioObjects = append(currentIstioObjects, vs1tonone)
 	v := mockEmptyValidationService(t)
 	filteredVSs := v.filterVSExportToNamespaces(models.Namespaces{models.Namespace{Name: "bookinfo"}, models.Namespace{Name: "bookinfo2"}, models.Namespace{Name: "bookinfo3"}, models.Namespace{Name: "default"}}, "bookinfo", "", currentIstioObjects)
 	var expectedVS []*networking_v1beta1.VirtualService
@@ -405,6 +407,27 @@ func TestGetVSReferences(t *testing.T) {
 	assert.Equal(references.ServiceReferences[1].Namespace, "test")
 }
 
+func TestGetReferenceImpact(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(t, fakeIstioConfigList(), []string{"product", "product2"})
+
+	impact, err := vs.GetReferenceImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "test", "services", "product")
+	require.NoError(err)
+	assert.Equal(models.IstioReference{ObjectType: models.ReferenceTypeService, Name: "product", Namespace: "test"}, impact.Object)
+	assert.Contains(impact.ReferencedBy, models.IstioReference{ObjectType: "virtualservice", Name: "product-vs", Namespace: "test"})
+
+	impact, err = vs.GetReferenceImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "test", kubernetes.VirtualServices, "product-vs")
+	require.NoError(err)
+	assert.Equal([]models.IstioReference{{ObjectType: "destinationrule", Name: "product-dr", Namespace: "test"}}, impact.ReferencedBy)
+
+	_, err = vs.GetReferenceImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "test", "wrong", "product")
+	assert.Error(err)
+}
+
 func TestGetVSReferencesNotExisting(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
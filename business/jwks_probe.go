@@ -0,0 +1,92 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+)
+
+const (
+	// jwksProbeConcurrency bounds the number of jwksUris probed at the same time.
+	jwksProbeConcurrency = 4
+	jwksProbeTimeout     = 3 * time.Second
+)
+
+var (
+	jwksProbeClient = &http.Client{Timeout: jwksProbeTimeout}
+	jwksProbes      = newJwksProber(jwksProbeConcurrency)
+)
+
+// jwksProber probes the jwksUris in the background so that the validations never wait for them.
+// The results are kept in the Kiali cache until they expire, the jwksUri is then probed again.
+type jwksProber struct {
+	inFlight map[string]bool
+	lock     sync.Mutex
+	slots    chan struct{}
+}
+
+func newJwksProber(concurrency int) *jwksProber {
+	return &jwksProber{
+		inFlight: map[string]bool{},
+		slots:    make(chan struct{}, concurrency),
+	}
+}
+
+// probe returns the error of the last probe of the jwksUri. It returns nil and schedules a probe when the
+// jwksUri was not probed yet, the result is then reported by the next validations.
+func (p *jwksProber) probe(kialiCache cache.KialiCache, uri string) error {
+	if probeErr, found := kialiCache.GetJwksProbe(uri); found {
+		return probeErr
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.inFlight[uri] {
+		return nil
+	}
+	p.inFlight[uri] = true
+
+	go func() {
+		p.slots <- struct{}{}
+		defer func() {
+			<-p.slots
+			p.lock.Lock()
+			delete(p.inFlight, uri)
+			p.lock.Unlock()
+		}()
+
+		err := headJwksUri(uri)
+		if err != nil {
+			log.Debugf("Unable to reach jwksUri [%s]: %s", uri, err)
+		}
+		kialiCache.SetJwksProbe(uri, err)
+	}()
+
+	return nil
+}
+
+// headJwksUri checks that the jwksUri answers a HEAD request without an error status.
+func headJwksUri(uri string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), jwksProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := jwksProbeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("jwksUri [%s] returned status %d", uri, resp.StatusCode)
+	}
+	return nil
+}
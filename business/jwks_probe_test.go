@@ -0,0 +1,54 @@
+package business
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestJwksProber(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+	kialiCache := SetupBusinessLayer(t, kubetest.NewFakeK8sClient(), *conf)
+
+	var requests atomic.Int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(http.MethodHead, r.Method)
+		if r.URL.Path != "/jwks.json" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+
+	prober := newJwksProber(1)
+	probed := func(uri string) func() bool {
+		return func() bool {
+			_, found := kialiCache.GetJwksProbe(uri)
+			return found
+		}
+	}
+
+	// Nothing is reported until the background probe completes
+	assert.NoError(prober.probe(kialiCache, testServer.URL+"/jwks.json"))
+	assert.NoError(prober.probe(kialiCache, testServer.URL+"/missing.json"))
+	require.Eventually(probed(testServer.URL+"/jwks.json"), 5*time.Second, 10*time.Millisecond)
+	require.Eventually(probed(testServer.URL+"/missing.json"), 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(prober.probe(kialiCache, testServer.URL+"/jwks.json"))
+	assert.Error(prober.probe(kialiCache, testServer.URL+"/missing.json"))
+
+	// Results are reused until they expire
+	assert.Equal(int32(2), requests.Load())
+}
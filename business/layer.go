@@ -1,12 +1,15 @@
 package business
 
 import (
+	"sync"
+
 	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/grafana"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/tracing"
 )
@@ -16,6 +19,10 @@ import (
 // needs to be saved across layers is saved in the Kiali Cache.
 type Layer struct {
 	App            AppService
+	Certificates   CertificateService
+	Egress         EgressService
+	Events         EventService
+	GraphViews     GraphViewService
 	Health         HealthService
 	IstioConfig    IstioConfigService
 	IstioStatus    IstioStatusService
@@ -23,8 +30,11 @@ type Layer struct {
 	Tracing        TracingService
 	Mesh           MeshService
 	Namespace      NamespaceService
+	Preferences    PreferencesService
 	ProxyLogging   ProxyLoggingService
 	ProxyStatus    ProxyStatusService
+	ProxyTap       ProxyTapService
+	RBAC           RBACSummarizer
 	RegistryStatus RegistryStatusService
 	Svc            SvcService
 	TLS            TLSService
@@ -38,8 +48,11 @@ var (
 	grafanaService      *grafana.Service
 	kialiCache          cache.KialiCache
 	poller              ControlPlaneMonitor
+	prometheusBreaker   *circuitBreaker
 	prometheusClient    prometheus.ClientInterface
+	prometheusLock      sync.RWMutex
 	tracingClientLoader func() tracing.ClientInterface
+	unsubscribeConfig   func()
 )
 
 // Start sets the globals necessary for the business layer.
@@ -56,8 +69,36 @@ func Start(
 	grafanaService = grafana
 	kialiCache = cache
 	poller = controlPlaneMonitor
+	prometheusBreaker = newCircuitBreaker()
 	prometheusClient = prom
 	tracingClientLoader = traceClientLoader
+
+	if unsubscribeConfig != nil {
+		unsubscribeConfig()
+	}
+	unsubscribeConfig = config.Subscribe(reloadBackends)
+}
+
+// reloadBackends recreates the shared Prometheus client and reloads the Grafana service when their urls changed in
+// a reloaded config. The business layers created afterwards use them.
+func reloadBackends(old, new config.Config) {
+	if old.ExternalServices.Prometheus.URL != new.ExternalServices.Prometheus.URL {
+		prom, err := prometheus.NewClientForConfig(new.ExternalServices.Prometheus)
+		if err != nil {
+			log.Errorf("Cannot create the Prometheus client of the reloaded url [%s], keeping the current client: %v", new.ExternalServices.Prometheus.URL, err)
+		} else {
+			prometheusLock.Lock()
+			prometheusClient = prom
+			prometheusLock.Unlock()
+			prometheusBreaker.reset()
+			log.Infof("Prometheus url changed to [%s]", new.ExternalServices.Prometheus.URL)
+		}
+	}
+
+	oldGrafana, newGrafana := old.ExternalServices.Grafana, new.ExternalServices.Grafana
+	if grafanaService != nil && (oldGrafana.URL != newGrafana.URL || oldGrafana.InClusterURL != newGrafana.InClusterURL) {
+		grafanaService.Reload(&new)
+	}
 }
 
 // Get the business.Layer
@@ -75,8 +116,12 @@ func Get(authInfo *api.AuthInfo) (*Layer, error) {
 		traceClient = tracingClientLoader()
 	}
 
+	prometheusLock.RLock()
+	prom := prometheusClient
+	prometheusLock.RUnlock()
+
 	kialiSAClient := clientFactory.GetSAClients()
-	return NewWithBackends(userClients, kialiSAClient, prometheusClient, traceClient), nil
+	return NewWithBackends(userClients, kialiSAClient, prom, traceClient), nil
 }
 
 // SetWithBackends allows for specifying the ClientFactory and Prometheus clients to be used.
@@ -108,22 +153,29 @@ func newLayer(
 
 	// TODO: Modify the k8s argument to other services to pass the whole k8s map if needed
 	temporaryLayer.App = NewAppService(temporaryLayer, conf, prom, grafana, userClients)
-	temporaryLayer.Health = HealthService{prom: prom, businessLayer: temporaryLayer, userClients: userClients}
-	temporaryLayer.IstioConfig = IstioConfigService{config: *conf, userClients: userClients, kialiCache: cache, businessLayer: temporaryLayer, controlPlaneMonitor: poller}
-	temporaryLayer.IstioStatus = NewIstioStatusService(userClients, temporaryLayer, poller)
+	temporaryLayer.Health = HealthService{prom: prom, breaker: prometheusBreaker, businessLayer: temporaryLayer, userClients: userClients}
+	temporaryLayer.IstioConfig = IstioConfigService{config: *conf, userClients: userClients, kialiSAClients: kialiSAClients, kialiCache: cache, businessLayer: temporaryLayer, controlPlaneMonitor: poller}
+	temporaryLayer.IstioStatus = NewIstioStatusService(userClients, cache, temporaryLayer, poller)
 	temporaryLayer.IstioCerts = IstioCertsService{k8s: userClients[homeClusterName], businessLayer: temporaryLayer}
 	temporaryLayer.Namespace = NewNamespaceService(userClients, kialiSAClients, cache, conf)
 	temporaryLayer.Mesh = NewMeshService(kialiSAClients, cache, temporaryLayer.Namespace, *conf)
-	temporaryLayer.ProxyStatus = ProxyStatusService{kialiSAClients: kialiSAClients, kialiCache: cache, businessLayer: temporaryLayer}
+	temporaryLayer.ProxyStatus = ProxyStatusService{kialiSAClients: kialiSAClients, kialiCache: cache, businessLayer: temporaryLayer, controlPlaneMonitor: poller}
 	// Out of order because it relies on ProxyStatus
-	temporaryLayer.ProxyLogging = ProxyLoggingService{userClients: userClients, proxyStatus: &temporaryLayer.ProxyStatus}
+	temporaryLayer.ProxyLogging = ProxyLoggingService{businessLayer: temporaryLayer, conf: conf, kialiCache: cache, kialiSAClients: kialiSAClients, userClients: userClients, proxyStatus: &temporaryLayer.ProxyStatus}
+	temporaryLayer.ProxyTap = ProxyTapService{conf: conf, userClients: userClients}
+	temporaryLayer.RBAC = RBACSummarizer{businessLayer: temporaryLayer, kialiCache: cache, userClients: userClients}
 	temporaryLayer.RegistryStatus = RegistryStatusService{kialiCache: cache}
-	temporaryLayer.TLS = TLSService{userClients: userClients, kialiCache: cache, businessLayer: temporaryLayer}
+	temporaryLayer.TLS = TLSService{userClients: userClients, kialiCache: cache, businessLayer: temporaryLayer, prom: prom}
 	temporaryLayer.Svc = SvcService{config: *conf, kialiCache: cache, businessLayer: temporaryLayer, prom: prom, userClients: userClients}
+	temporaryLayer.Certificates = CertificateService{businessLayer: temporaryLayer, conf: conf, kialiCache: cache, kialiSAClients: kialiSAClients, userClients: userClients}
+	temporaryLayer.GraphViews = GraphViewService{conf: conf, kialiSAClients: kialiSAClients}
+	temporaryLayer.Preferences = PreferencesService{conf: conf, kialiSAClients: kialiSAClients}
+	temporaryLayer.Events = EventService{conf: conf, kialiCache: cache, kialiSAClients: kialiSAClients}
+	temporaryLayer.Egress = EgressService{businessLayer: temporaryLayer, conf: conf, kialiCache: cache, prom: prom}
 	temporaryLayer.Validations = IstioValidationsService{userClients: userClients, businessLayer: temporaryLayer}
 	temporaryLayer.Workload = *NewWorkloadService(userClients, prom, cache, temporaryLayer, conf, grafana)
 
-	temporaryLayer.Tracing = NewTracingService(conf, traceClient, &temporaryLayer.Svc, &temporaryLayer.Workload)
+	temporaryLayer.Tracing = NewTracingService(conf, traceClient, kialiSAClients, &temporaryLayer.Svc, &temporaryLayer.Workload)
 	return temporaryLayer
 }
 
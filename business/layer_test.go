@@ -0,0 +1,43 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func TestReloadBackends(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Grafana.InClusterURL = ""
+	conf.ExternalServices.Grafana.URL = "http://grafana.example.com"
+	config.Set(conf)
+	t.Cleanup(func() { config.Set(config.NewConfig()) })
+
+	originalPrometheusClient, originalGrafanaService := prometheusClient, grafanaService
+	t.Cleanup(func() {
+		prometheusClient, grafanaService = originalPrometheusClient, originalGrafanaService
+	})
+	prom := new(prometheustest.PromClientMock)
+	prometheusClient = prom
+	grafanaService = grafana.NewService(conf, kubetest.NewFakeK8sClient())
+
+	// Unrelated changes keep the backends
+	reloaded := *conf
+	reloaded.Deployment.LogLevel = "debug"
+	reloadBackends(*conf, reloaded)
+	require.Same(prom, prometheusClient)
+
+	reloaded.ExternalServices.Prometheus.URL = "http://thanos.monitoring:9090"
+	reloaded.ExternalServices.Grafana.URL = "http://grafana.monitoring.example.com"
+	reloadBackends(*conf, reloaded)
+	require.NotSame(prom, prometheusClient)
+	require.Equal("http://grafana.monitoring.example.com", grafanaService.URL(context.Background()))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"golang.org/x/exp/maps"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/istio"
@@ -29,7 +31,9 @@ const (
 	IstiodScopeGatewayEnvKey       = "PILOT_SCOPE_GATEWAY_TO_NAMESPACE"
 	IstioInjectionLabel            = "istio-injection"
 	IstioRevisionLabel             = "istio.io/rev"
+	IstioTagLabel                  = "istio.io/tag"
 	IstioControlPlaneClustersLabel = "topology.istio.io/controlPlaneClusters"
+	IstioNetworkLabel              = "topology.istio.io/network"
 )
 
 // gets the mesh configuration for a controlplane from a variety of sources.
@@ -44,8 +48,19 @@ func getControlPlaneConfiguration(kubeCache cache.KubeCache, namespace string, n
 		return nil, err
 	}
 
+	meshConfig := meshTrafficPolicyConfig{}
+	if err := yaml.Unmarshal([]byte(cfg.Data["mesh"]), &meshConfig); err != nil {
+		return nil, err
+	}
+
+	outboundTrafficPolicy := models.OutboundPolicy{Mode: AllowAny}
+	if meshConfig.OutboundTrafficPolicy.Mode != "" {
+		outboundTrafficPolicy.Mode = meshConfig.OutboundTrafficPolicy.Mode
+	}
+
 	return &models.ControlPlaneConfiguration{
-		IstioMeshConfig: *istioConfigMapInfo,
+		IstioMeshConfig:       *istioConfigMapInfo,
+		OutboundTrafficPolicy: outboundTrafficPolicy,
 	}, nil
 }
 
@@ -118,7 +133,7 @@ func (in *MeshService) GetMesh(ctx context.Context) (*models.Mesh, error) {
 		return nil, fmt.Errorf("unable to get mesh clusters: %w", err)
 	}
 
-	mesh := &models.Mesh{}
+	mesh := &models.Mesh{RevisionTags: map[string]map[string]string{}}
 	var remoteClusters []*kubernetes.Cluster
 	for _, cluster := range clusters {
 		// We can't get anything from an inaccessible cluster.
@@ -126,6 +141,8 @@ func (in *MeshService) GetMesh(ctx context.Context) (*models.Mesh, error) {
 			continue
 		}
 
+		mesh.RevisionTags[cluster.Name] = in.revisionTags(ctx, cluster.Name)
+
 		cluster := cluster
 		kubeCache, err := in.kialiCache.GetKubeCache(cluster.Name)
 		if err != nil {
@@ -174,6 +191,8 @@ func (in *MeshService) GetMesh(ctx context.Context) (*models.Mesh, error) {
 				}
 			}
 
+			controlPlane.Install = discoverInstall(ctx, in.kialiSAClients[cluster.Name], &istiod)
+
 			// If the cluster id set on the controlplane matches the cluster's id then it manages the cluster it is deployed on.
 			if controlPlane.ID == cluster.Name {
 				controlPlane.ManagedClusters = append(controlPlane.ManagedClusters, &cluster)
@@ -275,11 +294,70 @@ func (in *MeshService) GetMesh(ctx context.Context) (*models.Mesh, error) {
 		}
 	}
 
+	mesh.EastWestGateways = in.discoverEastWestGateways(clusters)
+
 	in.kialiCache.SetMesh(mesh)
 
 	return mesh, nil
 }
 
+// discoverEastWestGateways returns the east-west gateways of the accessible clusters, whose services match the
+// east_west_gateway_selector. A gateway exposes the network of its network label, or else the network of its cluster.
+func (in *MeshService) discoverEastWestGateways(clusters []kubernetes.Cluster) []models.EastWestGateway {
+	gateways := []models.EastWestGateway{}
+	selector := in.conf.ExternalServices.Istio.EastWestGatewaySelector
+	if selector == "" {
+		return gateways
+	}
+
+	for _, cluster := range clusters {
+		if !cluster.Accessible {
+			continue
+		}
+
+		kubeCache, err := in.kialiCache.GetKubeCache(cluster.Name)
+		if err != nil {
+			log.Debugf("Unable to discover the east-west gateways of cluster [%s]. Err: %s", cluster.Name, err)
+			continue
+		}
+
+		services, err := kubeCache.GetServices(metav1.NamespaceAll, selector)
+		if err != nil {
+			log.Warningf("Unable to discover the east-west gateways of cluster [%s]. Err: %s", cluster.Name, err)
+			continue
+		}
+
+		for _, svc := range services {
+			gateway := models.EastWestGateway{
+				Addresses: []string{},
+				Cluster:   cluster.Name,
+				Name:      svc.Name,
+				Namespace: svc.Namespace,
+				Network:   svc.Labels[IstioNetworkLabel],
+				Ports:     []int32{},
+			}
+			if gateway.Network == "" {
+				gateway.Network = cluster.Network
+			}
+			for _, ingress := range svc.Status.LoadBalancer.Ingress {
+				if ingress.IP != "" {
+					gateway.Addresses = append(gateway.Addresses, ingress.IP)
+				} else if ingress.Hostname != "" {
+					gateway.Addresses = append(gateway.Addresses, ingress.Hostname)
+				}
+			}
+			gateway.Addresses = append(gateway.Addresses, svc.Spec.ExternalIPs...)
+			for _, port := range svc.Spec.Ports {
+				gateway.Ports = append(gateway.Ports, port.Port)
+			}
+			log.Debugf("Found east-west gateway [%s/%s] of network [%s] on cluster [%s].", svc.Namespace, svc.Name, gateway.Network, cluster.Name)
+			gateways = append(gateways, gateway)
+		}
+	}
+
+	return gateways
+}
+
 // IstioConfigMapName guesses the istio configmap name.
 func IstioConfigMapName(conf config.Config, revision string) string {
 	// If the config map name is explicitly set and it's not the default value, we should always use that.
@@ -534,7 +612,7 @@ func (in *MeshService) resolveNetwork(clusterName string) string {
 	// in remote clusters, we don't have privileges to query config maps, so it's not possible to fetch
 	// the sidecar injector config map. However, Istio docs say that the Istio namespace must be labeled with
 	// the network ID. We use that label to retrieve the network ID.
-	network, ok := istioNamespace.Labels["topology.istio.io/network"]
+	network, ok := istioNamespace.Labels[IstioNetworkLabel]
 	if !ok {
 		log.Debugf("Istio namespace [%s] in cluster [%s] does not have network label", in.conf.IstioNamespace, clusterName)
 		return ""
@@ -651,6 +729,208 @@ func (in *MeshService) CanaryUpgradeStatus() (*models.CanaryUpgradeStatus, error
 	return status, nil
 }
 
+// RevisionUpgradeProgress reports, for every dataplane cluster, the namespaces and workloads tagged with each revision
+// and the proxies connected to each revision according to the istiod proxy status. Proxies whose namespace was moved to
+// another revision but still connected to the old one need a restart and namespaces tagged with a revision that
+// no controlplane serves won't get any proxy injected at all.
+func (in *MeshService) RevisionUpgradeProgress(ctx context.Context) (*models.RevisionUpgradeProgress, error) {
+	mesh, err := in.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := in.namespaceService.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revisions served per dataplane cluster and revision of each istiod pod so that
+	// the proxy status, which only tells the istiod pod, can be mapped to a revision.
+	servedRevisions := map[string]map[string]bool{}
+	pilotRevisions := map[string]string{}
+	for _, controlPlane := range mesh.ControlPlanes {
+		managedClusters := append([]*kubernetes.Cluster{controlPlane.Cluster}, controlPlane.ManagedClusters...)
+		for _, cluster := range managedClusters {
+			if servedRevisions[cluster.Name] == nil {
+				servedRevisions[cluster.Name] = map[string]bool{}
+			}
+			servedRevisions[cluster.Name][controlPlane.Revision] = true
+		}
+
+		kubeCache, err := in.kialiCache.GetKubeCache(controlPlane.Cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		istiods, err := kubeCache.GetPods(controlPlane.IstiodNamespace, labels.Set{"app": "istiod", IstioRevisionLabel: controlPlane.Revision}.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, istiod := range istiods {
+			pilotRevisions[istiod.Name] = controlPlane.Revision
+		}
+	}
+
+	progress := &models.RevisionUpgradeProgress{
+		Revisions:                 []models.RevisionUsage{},
+		PendingRestart:            []models.ProxyRevision{},
+		UnknownRevisionNamespaces: []models.NamespaceRevision{},
+	}
+	usages := map[string]*models.RevisionUsage{}
+	usageWorkloads := map[*models.RevisionUsage]map[string]bool{}
+	usage := func(cluster, revision string) *models.RevisionUsage {
+		key := cluster + "/" + revision
+		if usages[key] == nil {
+			usages[key] = &models.RevisionUsage{Cluster: cluster, Revision: revision, Namespaces: []string{}}
+		}
+		return usages[key]
+	}
+
+	// Revision tags point to a revision, they are resolved before flagging a revision as unknown.
+	resolve := func(cluster, revision string) string {
+		if revision == "" || servedRevisions[cluster][revision] {
+			return revision
+		}
+		if tagged, ok := mesh.RevisionTags[cluster][revision]; ok {
+			return tagged
+		}
+		return revision
+	}
+
+	for _, namespace := range namespaces {
+		revision := resolve(namespace.Cluster, in.namespaceRevision(namespace))
+		if revision != "" {
+			if !servedRevisions[namespace.Cluster][revision] {
+				progress.UnknownRevisionNamespaces = append(progress.UnknownRevisionNamespaces, models.NamespaceRevision{Cluster: namespace.Cluster, Namespace: namespace.Name, Revision: revision})
+			} else {
+				u := usage(namespace.Cluster, revision)
+				u.Namespaces = append(u.Namespaces, namespace.Name)
+			}
+		}
+
+		kubeCache, err := in.kialiCache.GetKubeCache(namespace.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		pods, err := kubeCache.GetPods(namespace.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		replicaSets, err := kubeCache.GetReplicaSets(namespace.Name)
+		if err != nil {
+			return nil, err
+		}
+		replicaSetControllers := map[string]string{}
+		for i := range replicaSets {
+			if controller := metav1.GetControllerOf(&replicaSets[i]); controller != nil {
+				replicaSetControllers[replicaSets[i].Name] = controller.Name
+			}
+		}
+		for i := range pods {
+			pod := &pods[i]
+
+			// Pods can also be tagged themselves.
+			expectedRevision := revision
+			if podRevision := pod.Labels[in.conf.IstioLabels.InjectionLabelRev]; podRevision != "" {
+				expectedRevision = resolve(namespace.Cluster, podRevision)
+			}
+			// The istiod pods are labeled with their own revision.
+			if expectedRevision != "" && servedRevisions[namespace.Cluster][expectedRevision] && pod.Labels["app"] != "istiod" {
+				u := usage(namespace.Cluster, expectedRevision)
+				if usageWorkloads[u] == nil {
+					usageWorkloads[u] = map[string]bool{}
+				}
+				usageWorkloads[u][namespace.Name+"/"+podWorkloadName(pod, replicaSetControllers)] = true
+			}
+
+			if _, injected := pod.Annotations[in.conf.ExternalServices.Istio.IstioSidecarAnnotation]; !injected {
+				continue
+			}
+
+			proxyStatus := in.kialiCache.GetPodProxyStatus(namespace.Cluster, namespace.Name, pod.Name)
+			if proxyStatus == nil {
+				continue
+			}
+			connectedRevision, found := pilotRevisions[proxyStatus.Pilot]
+			if !found {
+				continue
+			}
+			usage(namespace.Cluster, connectedRevision).Proxies++
+
+			if expectedRevision != "" && expectedRevision != connectedRevision && servedRevisions[namespace.Cluster][expectedRevision] {
+				progress.PendingRestart = append(progress.PendingRestart, models.ProxyRevision{
+					Cluster:          namespace.Cluster,
+					Namespace:        namespace.Name,
+					Pod:              pod.Name,
+					Revision:         connectedRevision,
+					ExpectedRevision: expectedRevision,
+				})
+			}
+		}
+	}
+
+	for _, u := range usages {
+		sort.Strings(u.Namespaces)
+		u.Workloads = len(usageWorkloads[u])
+		progress.Revisions = append(progress.Revisions, *u)
+	}
+	sort.Slice(progress.Revisions, func(i, j int) bool {
+		if progress.Revisions[i].Cluster != progress.Revisions[j].Cluster {
+			return progress.Revisions[i].Cluster < progress.Revisions[j].Cluster
+		}
+		return progress.Revisions[i].Revision < progress.Revisions[j].Revision
+	})
+
+	return progress, nil
+}
+
+// podWorkloadName returns the name of the workload of a pod: the controller of its replicaset, its own controller
+// or the pod itself when it has none.
+func podWorkloadName(pod *core_v1.Pod, replicaSetControllers map[string]string) string {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return pod.Name
+	}
+	if name, ok := replicaSetControllers[controller.Name]; ok && controller.Kind == "ReplicaSet" {
+		return name
+	}
+	return controller.Name
+}
+
+// namespaceRevision returns the revision the namespace is tagged with, if any. Namespaces
+// with the injection label enabled are injected by the default revision.
+func (in *MeshService) namespaceRevision(namespace models.Namespace) string {
+	if revision := namespace.Labels[in.conf.IstioLabels.InjectionLabelRev]; revision != "" {
+		return revision
+	}
+	if namespace.Labels[in.conf.IstioLabels.InjectionLabelName] == "enabled" {
+		return "default"
+	}
+	return ""
+}
+
+// revisionTags returns the revision each revision tag of the cluster points to, read from the injection webhooks
+// istioctl creates for the tags. The "default" tag is the revision injecting the namespaces with the injection
+// label enabled. The webhooks are not in the kube cache, the tags are cached with the mesh.
+func (in *MeshService) revisionTags(ctx context.Context, cluster string) map[string]string {
+	tags := map[string]string{}
+	client, ok := in.kialiSAClients[cluster]
+	if !ok {
+		return tags
+	}
+	webhooks, err := client.Kube().AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: IstioTagLabel})
+	if err != nil {
+		log.Debugf("Unable to get the revision tags of cluster [%s]: %s", cluster, err)
+		return tags
+	}
+	for _, webhook := range webhooks.Items {
+		tag, revision := webhook.Labels[IstioTagLabel], webhook.Labels[IstioRevisionLabel]
+		if tag != "" && revision != "" {
+			tags[tag] = revision
+		}
+	}
+	return tags
+}
+
 // Checks if a cluster exist
 func (in *MeshService) IsValidCluster(cluster string) bool {
 	_, exists := in.kialiSAClients[cluster]
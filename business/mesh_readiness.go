@@ -0,0 +1,239 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/util/mtls"
+)
+
+// GetNamespaceMeshReadiness evaluates the checklist of the onboarding of a namespace into the mesh: the enrollment
+// of its workloads, the telemetry, the default deny policy, mTLS and the sidecar configuration. The failed checks
+// come with a hint to fix them.
+func (in *IstioConfigService) GetNamespaceMeshReadiness(ctx context.Context, cluster, namespace string) (*models.MeshReadiness, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceMeshReadiness",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	ns, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	istioConfigList, err := in.GetIstioConfigList(ctx, cluster, IstioConfigCriteria{
+		IncludeAuthorizationPolicies: true,
+		IncludeDestinationRules:      true,
+		IncludePeerAuthentications:   true,
+		IncludeSidecars:              true,
+		IncludeTelemetry:             true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rootNamespace := in.config.ExternalServices.Istio.RootNamespace
+	readiness := &models.MeshReadiness{
+		Cluster:   cluster,
+		Namespace: namespace,
+		Ready:     true,
+		Checks: []models.MeshReadinessCheck{
+			in.enrollmentCheck(ns),
+			telemetryCheck(istioConfigList.Telemetries, namespace, rootNamespace),
+			defaultDenyCheck(istioConfigList.AuthorizationPolicies, namespace, rootNamespace),
+		},
+	}
+
+	mtlsCheck, err := in.mtlsCheck(ctx, cluster, namespace, istioConfigList)
+	if err != nil {
+		return nil, err
+	}
+	readiness.Checks = append(readiness.Checks, mtlsCheck)
+
+	if ns.IsAmbient {
+		readiness.Checks = append(readiness.Checks, models.MeshReadinessCheck{
+			ID:      models.MeshReadinessSidecarConfig,
+			Status:  models.MeshReadinessSkip,
+			Message: "The workloads of an ambient namespace have no sidecar",
+		})
+	} else {
+		readiness.Checks = append(readiness.Checks, sidecarConfigCheck(istioConfigList.Sidecars, namespace, rootNamespace))
+	}
+
+	for _, check := range readiness.Checks {
+		if check.Status == models.MeshReadinessFail {
+			readiness.Ready = false
+		}
+	}
+	return readiness, nil
+}
+
+// enrollmentCheck passes when the namespace is labeled for the sidecar injection or enrolled into ambient.
+func (in *IstioConfigService) enrollmentCheck(ns *models.Namespace) models.MeshReadinessCheck {
+	istioLabels := in.config.IstioLabels
+	check := models.MeshReadinessCheck{ID: models.MeshReadinessEnrollment, Status: models.MeshReadinessPass}
+	switch {
+	case ns.IsAmbient:
+		check.Message = "The namespace is enrolled into ambient"
+	case ns.Labels[istioLabels.InjectionLabelName] == "enabled":
+		check.Message = fmt.Sprintf("The namespace is labeled for the sidecar injection with %s=enabled", istioLabels.InjectionLabelName)
+	case ns.Labels[istioLabels.InjectionLabelRev] != "":
+		check.Message = fmt.Sprintf("The namespace is labeled for the sidecar injection of revision %s", ns.Labels[istioLabels.InjectionLabelRev])
+	default:
+		check.Status = models.MeshReadinessFail
+		check.Message = "The namespace is neither labeled for the sidecar injection nor enrolled into ambient"
+		check.Hint = fmt.Sprintf("Label the namespace with %s=enabled, or %s=%s for ambient, then restart its workloads",
+			istioLabels.InjectionLabelName, istioLabels.AmbientNamespaceLabel, istioLabels.AmbientNamespaceLabelValue)
+	}
+	return check
+}
+
+// telemetryCheck fails when the Telemetry of the namespace, or the mesh-wide one when the namespace has none,
+// disables all the metrics. Without Telemetry the default Prometheus metrics are reported.
+func telemetryCheck(telemetries []*telemetry_v1alpha1.Telemetry, namespace, rootNamespace string) models.MeshReadinessCheck {
+	check := models.MeshReadinessCheck{
+		ID:      models.MeshReadinessTelemetry,
+		Status:  models.MeshReadinessPass,
+		Message: "No Telemetry applies to the namespace, the default metrics are reported",
+	}
+
+	telemetry := namespaceWideTelemetry(telemetries, namespace)
+	if telemetry == nil && namespace != rootNamespace {
+		telemetry = namespaceWideTelemetry(telemetries, rootNamespace)
+	}
+	if telemetry == nil {
+		return check
+	}
+
+	check.References = []models.IstioValidationKey{models.BuildKey(kubernetes.TelemetryType, telemetry.Name, telemetry.Namespace)}
+	if telemetryDisablesMetrics(telemetry) {
+		check.Status = models.MeshReadinessFail
+		check.Message = fmt.Sprintf("The Telemetry %s/%s disables the metrics of the namespace", telemetry.Namespace, telemetry.Name)
+		check.Hint = "Remove the override disabling all the metrics from the Telemetry, or add a Telemetry to the namespace enabling them"
+	} else {
+		check.Message = fmt.Sprintf("The metrics of the namespace are configured by the Telemetry %s/%s", telemetry.Namespace, telemetry.Name)
+	}
+	return check
+}
+
+// namespaceWideTelemetry returns the Telemetry of a namespace without selector, if any.
+func namespaceWideTelemetry(telemetries []*telemetry_v1alpha1.Telemetry, namespace string) *telemetry_v1alpha1.Telemetry {
+	for _, telemetry := range telemetries {
+		if telemetry.Namespace == namespace && telemetry.Spec.GetSelector() == nil && telemetry.Spec.GetTargetRef() == nil {
+			return telemetry
+		}
+	}
+	return nil
+}
+
+// telemetryDisablesMetrics returns true when an override disables all the metrics, of the clients and the servers.
+func telemetryDisablesMetrics(telemetry *telemetry_v1alpha1.Telemetry) bool {
+	for _, metrics := range telemetry.Spec.GetMetrics() {
+		for _, override := range metrics.GetOverrides() {
+			if !override.GetDisabled().GetValue() {
+				continue
+			}
+			match := override.GetMatch()
+			if match == nil || (match.GetCustomMetric() == "" && match.GetMetric() == api_telemetry_v1alpha1.MetricSelector_ALL_METRICS &&
+				match.GetMode() == api_telemetry_v1alpha1.WorkloadMode_CLIENT_AND_SERVER) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// defaultDenyCheck passes when an AuthorizationPolicy without selector of the namespace or of the root namespace
+// denies all the requests, so that only the requests explicitly allowed reach the workloads.
+func defaultDenyCheck(policies []*security_v1beta1.AuthorizationPolicy, namespace, rootNamespace string) models.MeshReadinessCheck {
+	check := models.MeshReadinessCheck{ID: models.MeshReadinessDefaultDeny}
+	for _, ap := range policies {
+		if (ap.Namespace != namespace && ap.Namespace != rootNamespace) || ap.Spec.Selector != nil || ap.Spec.TargetRef != nil {
+			continue
+		}
+		if authorizationCoverage([]*security_v1beta1.AuthorizationPolicy{ap}) == models.AuthorizationDenyAll {
+			check.Status = models.MeshReadinessPass
+			check.Message = fmt.Sprintf("The AuthorizationPolicy %s/%s denies the requests by default", ap.Namespace, ap.Name)
+			check.References = append(check.References, models.BuildKey(kubernetes.AuthorizationPoliciesType, ap.Name, ap.Namespace))
+		}
+	}
+	if check.Status == "" {
+		check.Status = models.MeshReadinessFail
+		check.Message = "No AuthorizationPolicy denies the requests by default, every request is allowed unless denied"
+		check.Hint = fmt.Sprintf("Add an AuthorizationPolicy without selector nor rules to namespace %s to allow nothing, then allow the expected requests", namespace)
+	}
+	return check
+}
+
+// mtlsCheck passes when mTLS is enabled for the namespace, by its own configuration or by the mesh-wide one.
+func (in *IstioConfigService) mtlsCheck(ctx context.Context, cluster, namespace string, istioConfigList *models.IstioConfigList) (models.MeshReadinessCheck, error) {
+	nss, err := in.businessLayer.TLS.getNamespaces(ctx, cluster)
+	if err != nil {
+		return models.MeshReadinessCheck{}, err
+	}
+	drs := kubernetes.FilterByNamespaces(istioConfigList.DestinationRules, nss)
+	autoMtlsEnabled := in.businessLayer.TLS.hasAutoMTLSEnabled(cluster)
+
+	nsPas := kubernetes.FilterByNamespace(istioConfigList.PeerAuthentications, namespace)
+	if config.IsRootNamespace(namespace) {
+		nsPas = []*security_v1beta1.PeerAuthentication{}
+	}
+	nsStatus := mtls.MtlsStatus{PeerAuthentications: nsPas, DestinationRules: drs, AutoMtlsEnabled: autoMtlsEnabled}
+	meshStatus := mtls.MtlsStatus{
+		PeerAuthentications: kubernetes.FilterByNamespace(istioConfigList.PeerAuthentications, in.config.ExternalServices.Istio.RootNamespace),
+		DestinationRules:    drs,
+		AutoMtlsEnabled:     autoMtlsEnabled,
+	}
+	status := nsStatus.OverallMtlsStatus(nsStatus.NamespaceMtlsStatus(namespace), meshStatus.MeshMtlsStatus())
+
+	check := models.MeshReadinessCheck{ID: models.MeshReadinessMTLS}
+	switch status {
+	case mtls.MTLSEnabled:
+		check.Status = models.MeshReadinessPass
+		check.Message = "mTLS is enforced for the namespace"
+	case mtls.MTLSDisabled:
+		check.Status = models.MeshReadinessFail
+		check.Message = "mTLS is disabled for the namespace"
+		check.Hint = fmt.Sprintf("Set the mTLS mode of the PeerAuthentications of namespace %s to STRICT", namespace)
+	default:
+		check.Status = models.MeshReadinessFail
+		check.Message = "mTLS is not enforced for the namespace, the workloads accept plain text requests"
+		check.Hint = fmt.Sprintf("Add a PeerAuthentication without selector to namespace %s with the mTLS mode STRICT", namespace)
+	}
+	for _, pa := range nsPas {
+		check.References = append(check.References, models.BuildKey(kubernetes.PeerAuthenticationsType, pa.Name, pa.Namespace))
+	}
+	return check, nil
+}
+
+// sidecarConfigCheck passes when a Sidecar without selector of the namespace, or the default one of the root
+// namespace, limits the configuration pushed to the proxies of the namespace.
+func sidecarConfigCheck(sidecars []*networking_v1beta1.Sidecar, namespace, rootNamespace string) models.MeshReadinessCheck {
+	check := models.MeshReadinessCheck{ID: models.MeshReadinessSidecarConfig}
+	for _, ns := range []string{namespace, rootNamespace} {
+		for _, sc := range sidecars {
+			if sc.Namespace == ns && sc.Spec.WorkloadSelector == nil {
+				check.Status = models.MeshReadinessPass
+				check.Message = fmt.Sprintf("The Sidecar %s/%s limits the configuration of the proxies of the namespace", sc.Namespace, sc.Name)
+				check.References = []models.IstioValidationKey{models.BuildKey(kubernetes.SidecarType, sc.Name, sc.Namespace)}
+				return check
+			}
+		}
+	}
+	check.Status = models.MeshReadinessFail
+	check.Message = "No Sidecar limits the configuration of the proxies, they receive the configuration of the whole mesh"
+	check.Hint = fmt.Sprintf("Add a Sidecar without selector to namespace %s with the egress hosts its workloads call, like ./* and %s/*", namespace, rootNamespace)
+	return check
+}
@@ -0,0 +1,107 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func meshReadinessStatuses(readiness *models.MeshReadiness) map[string]string {
+	statuses := map[string]string{}
+	for _, check := range readiness.Checks {
+		statuses[check.ID] = check.Status
+	}
+	return statuses
+}
+
+func TestGetNamespaceMeshReadiness(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	disableMetrics := &telemetry_v1alpha1.Telemetry{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "mesh-default", Namespace: "istio-system"},
+		Spec: api_telemetry_v1alpha1.Telemetry{
+			Metrics: []*api_telemetry_v1alpha1.Metrics{{
+				Overrides: []*api_telemetry_v1alpha1.MetricsOverrides{{Disabled: wrapperspb.Bool(true)}},
+			}},
+		},
+	}
+	legacyTelemetry := &telemetry_v1alpha1.Telemetry{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "metrics", Namespace: "legacy"},
+		Spec: api_telemetry_v1alpha1.Telemetry{
+			Metrics: []*api_telemetry_v1alpha1.Metrics{{
+				Providers: []*api_telemetry_v1alpha1.ProviderRef{{Name: "prometheus"}},
+			}},
+		},
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "legacy"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels", Labels: map[string]string{"istio.io/dataplane-mode": "ambient"}}},
+		data.CreateEmptyAuthorizationPolicy("allow-nothing", "bookinfo"),
+		data.CreateEmptyPeerAuthentication("default", "bookinfo", data.CreateMTLS("STRICT")),
+		data.CreateSidecar("default", "bookinfo"),
+		data.AddSelectorToSidecar(map[string]string{"app": "reviews"}, data.CreateSidecar("reviews", "legacy")),
+		disableMetrics,
+		legacyTelemetry,
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	istioConfig := NewWithBackends(clients, clients, nil, nil).IstioConfig
+
+	readiness, err := istioConfig.GetNamespaceMeshReadiness(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo")
+	require.NoError(err)
+	assert.False(readiness.Ready)
+	assert.Equal(map[string]string{
+		models.MeshReadinessEnrollment:    models.MeshReadinessPass,
+		models.MeshReadinessTelemetry:     models.MeshReadinessFail,
+		models.MeshReadinessDefaultDeny:   models.MeshReadinessPass,
+		models.MeshReadinessMTLS:          models.MeshReadinessPass,
+		models.MeshReadinessSidecarConfig: models.MeshReadinessPass,
+	}, meshReadinessStatuses(readiness))
+	require.Len(readiness.Checks, 5)
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(kubernetes.TelemetryType, "mesh-default", "istio-system")}, readiness.Checks[1].References)
+	assert.NotEmpty(readiness.Checks[1].Hint)
+
+	// The Telemetry of the namespace overrides the mesh-wide one, the Sidecar with selector does not apply to the namespace
+	readiness, err = istioConfig.GetNamespaceMeshReadiness(context.TODO(), conf.KubernetesConfig.ClusterName, "legacy")
+	require.NoError(err)
+	assert.False(readiness.Ready)
+	assert.Equal(map[string]string{
+		models.MeshReadinessEnrollment:    models.MeshReadinessFail,
+		models.MeshReadinessTelemetry:     models.MeshReadinessPass,
+		models.MeshReadinessDefaultDeny:   models.MeshReadinessFail,
+		models.MeshReadinessMTLS:          models.MeshReadinessFail,
+		models.MeshReadinessSidecarConfig: models.MeshReadinessFail,
+	}, meshReadinessStatuses(readiness))
+	for _, check := range readiness.Checks {
+		if check.Status == models.MeshReadinessFail {
+			assert.NotEmpty(check.Hint, check.ID)
+		}
+	}
+
+	readiness, err = istioConfig.GetNamespaceMeshReadiness(context.TODO(), conf.KubernetesConfig.ClusterName, "travels")
+	require.NoError(err)
+	statuses := meshReadinessStatuses(readiness)
+	assert.Equal(models.MeshReadinessPass, statuses[models.MeshReadinessEnrollment])
+	assert.Equal(models.MeshReadinessSkip, statuses[models.MeshReadinessSidecarConfig])
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
+	admissionregistration_v1 "k8s.io/api/admissionregistration/v1"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -22,6 +23,7 @@ import (
 	"github.com/kiali/kiali/kubernetes/cache"
 	"github.com/kiali/kiali/kubernetes/kubetest"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util"
 )
 
 func TestGetClustersResolvesTheKialiCluster(t *testing.T) {
@@ -627,6 +629,7 @@ trustDomain: cluster.local
 	require.Len(mesh.ControlPlanes, 1)
 	require.True(*mesh.ControlPlanes[0].Config.EnableAutoMtls)
 	require.Len(mesh.ControlPlanes[0].ManagedClusters, 1)
+	require.Equal(models.InstallMethodUnknown, mesh.ControlPlanes[0].Install.Method)
 }
 
 func TestGetMeshMultipleRevisions(t *testing.T) {
@@ -720,6 +723,108 @@ trustDomain: cluster.local
 	require.True(*mesh.ControlPlanes[1].Config.EnableAutoMtls)
 }
 
+func TestRevisionUpgradeProgress(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	kubernetes.SetConfig(t, *conf)
+
+	istiodDeployment := func(revision string) *apps_v1.Deployment {
+		return &apps_v1.Deployment{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "istiod-" + revision,
+				Namespace: "istio-system",
+				Labels:    map[string]string{"app": "istiod", business.IstioRevisionLabel: revision},
+			},
+		}
+	}
+	istiodPod := func(revision string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "istiod-" + revision + "-abc",
+				Namespace: "istio-system",
+				Labels:    map[string]string{"app": "istiod", business.IstioRevisionLabel: revision},
+			},
+		}
+	}
+	injectedPod := func(name, namespace string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Annotations: map[string]string{conf.ExternalServices.Istio.IstioSidecarAnnotation: "{}"},
+			},
+		}
+	}
+	configMap := func(revision string) *core_v1.ConfigMap {
+		return &core_v1.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{Name: "istio-" + revision, Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": "rootNamespace: istio-system"},
+		}
+	}
+	controller := func(kind, name string) []v1.OwnerReference {
+		return []v1.OwnerReference{{Kind: kind, Name: name, Controller: util.AsPtr(true)}}
+	}
+	deploymentPod := func(name, namespace, replicaSet string) *core_v1.Pod {
+		return &core_v1.Pod{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace, OwnerReferences: controller("ReplicaSet", replicaSet)}}
+	}
+	revisionTag := func(tag, revision string) *admissionregistration_v1.MutatingWebhookConfiguration {
+		return &admissionregistration_v1.MutatingWebhookConfiguration{
+			ObjectMeta: v1.ObjectMeta{
+				Name:   "istio-revision-tag-" + tag,
+				Labels: map[string]string{business.IstioTagLabel: tag, business.IstioRevisionLabel: revision},
+			},
+		}
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio.io/rev": "1-19-0"}}},
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "travel", Labels: map[string]string{"istio.io/rev": "1-20-0"}}},
+		istiodDeployment("1-18-0"),
+		istiodDeployment("1-19-0"),
+		istiodPod("1-18-0"),
+		istiodPod("1-19-0"),
+		configMap("1-18-0"),
+		configMap("1-19-0"),
+		injectedPod("details-v1-123", "bookinfo"),
+		injectedPod("reviews-v1-123", "bookinfo"),
+		// Namespaces injected through revision tags
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "legacy", Labels: map[string]string{"istio-injection": "enabled"}}},
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "prod", Labels: map[string]string{"istio.io/rev": "prod-stable"}}},
+		revisionTag("default", "1-18-0"),
+		revisionTag("prod-stable", "1-19-0"),
+		// Workloads not restarted since their namespace was tagged
+		&apps_v1.ReplicaSet{ObjectMeta: v1.ObjectMeta{Name: "productpage-v1-abc", Namespace: "legacy", OwnerReferences: controller("Deployment", "productpage-v1")}},
+		deploymentPod("productpage-v1-abc-1", "legacy", "productpage-v1-abc"),
+		deploymentPod("productpage-v1-abc-2", "legacy", "productpage-v1-abc"),
+	)
+
+	cache := business.SetupBusinessLayer(t, k8s, *conf)
+	cache.SetPodProxyStatus([]*kubernetes.ProxyStatus{
+		{Pilot: "istiod-1-18-0-abc", SyncStatus: kubernetes.SyncStatus{ClusterID: "east", ProxyID: "details-v1-123.bookinfo"}},
+		{Pilot: "istiod-1-19-0-abc", SyncStatus: kubernetes.SyncStatus{ClusterID: "east", ProxyID: "reviews-v1-123.bookinfo"}},
+	})
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := business.NewWithBackends(clients, clients, nil, nil).Mesh
+	progress, err := svc.RevisionUpgradeProgress(context.TODO())
+	require.NoError(err)
+
+	assert.Equal([]models.RevisionUsage{
+		{Cluster: "east", Revision: "1-18-0", Namespaces: []string{"legacy"}, Proxies: 1, Workloads: 1},
+		{Cluster: "east", Revision: "1-19-0", Namespaces: []string{"bookinfo", "prod"}, Proxies: 1, Workloads: 2},
+	}, progress.Revisions)
+	assert.Equal([]models.ProxyRevision{
+		{Cluster: "east", Namespace: "bookinfo", Pod: "details-v1-123", Revision: "1-18-0", ExpectedRevision: "1-19-0"},
+	}, progress.PendingRestart)
+	assert.Equal([]models.NamespaceRevision{
+		{Cluster: "east", Namespace: "travel", Revision: "1-20-0"},
+	}, progress.UnknownRevisionNamespaces)
+}
+
 func TestGetMeshRemoteClusters(t *testing.T) {
 	require := require.New(t)
 	conf := config.NewConfig()
@@ -928,6 +1033,73 @@ trustDomain: cluster.local
 	require.Equal("west", westControlPlane.ManagedClusters[0].Name)
 }
 
+func TestGetMeshEastWestGateways(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	kubernetes.SetConfig(t, *conf)
+
+	istioConfigMap := &core_v1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "istio",
+			Namespace: "istio-system",
+		},
+		Data: map[string]string{"mesh": "rootNamespace: istio-system\n"},
+	}
+	eastWestGateway := func(labels map[string]string, ingress ...core_v1.LoadBalancerIngress) *core_v1.Service {
+		return &core_v1.Service{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "istio-eastwestgateway",
+				Namespace: "istio-system",
+				Labels:    labels,
+			},
+			Spec: core_v1.ServiceSpec{
+				Ports: []core_v1.ServicePort{{Name: "tls", Port: 15443}},
+				Type:  core_v1.ServiceTypeLoadBalancer,
+			},
+			Status: core_v1.ServiceStatus{LoadBalancer: core_v1.LoadBalancerStatus{Ingress: ingress}},
+		}
+	}
+	eastClient := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "istio-system", Labels: map[string]string{business.IstioNetworkLabel: "network-east"}}},
+		fakeIstiodDeployment("east", false),
+		istioConfigMap,
+		eastWestGateway(map[string]string{"istio": "eastwestgateway"}, core_v1.LoadBalancerIngress{IP: "10.0.0.1"}),
+	)
+	westClient := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "istio-system", Labels: map[string]string{business.IstioNetworkLabel: "network-west"}}},
+		fakeIstiodDeployment("west", false),
+		istioConfigMap,
+		eastWestGateway(map[string]string{"istio": "eastwestgateway", business.IstioNetworkLabel: "network-west-2"}),
+	)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: eastClient, "west": westClient}
+	factory := kubetest.NewK8SClientFactoryMock(nil)
+	factory.SetClients(clients)
+	business.WithKialiCache(cache.NewTestingCacheWithFactory(t, factory, *conf))
+
+	svc := business.NewWithBackends(clients, clients, nil, nil).Mesh
+	mesh, err := svc.GetMesh(context.TODO())
+	require.NoError(err)
+	require.Len(mesh.EastWestGateways, 2)
+
+	eastGateway := business.FindOrFail(t, mesh.EastWestGateways, func(gw models.EastWestGateway) bool {
+		return gw.Cluster == "east"
+	})
+	westGateway := business.FindOrFail(t, mesh.EastWestGateways, func(gw models.EastWestGateway) bool {
+		return gw.Cluster == "west"
+	})
+
+	require.Equal("network-east", eastGateway.Network)
+	require.Equal([]string{"10.0.0.1"}, eastGateway.Addresses)
+	require.Equal([]int32{15443}, eastGateway.Ports)
+	require.True(eastGateway.IsReachable())
+
+	// The network label of the gateway takes precedence over the network of its cluster
+	require.Equal("network-west-2", westGateway.Network)
+	require.False(westGateway.IsReachable())
+}
+
 func TestGetMeshMultiplePrimariesWithRemotes(t *testing.T) {
 	require := require.New(t)
 	conf := config.NewConfig()
@@ -1,7 +1,9 @@
 package business
 
 import (
+	"context"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -13,6 +15,12 @@ import (
 // MetricsService deals with fetching metrics from prometheus
 type MetricsService struct {
 	prom prometheus.ClientInterface
+	// events overlaid on the metrics, none when nil
+	events *EventService
+
+	// warnings returned by Prometheus along with the fetched metrics
+	warnings      []string
+	warningsMutex sync.Mutex
 }
 
 // NewMetricsService initializes this business service
@@ -20,7 +28,61 @@ func NewMetricsService(prom prometheus.ClientInterface) *MetricsService {
 	return &MetricsService{prom: prom}
 }
 
+// WithEvents returns the service returning the events of the given event service along with the metrics.
+func (in *MetricsService) WithEvents(events *EventService) *MetricsService {
+	in.events = events
+	return in
+}
+
+// GetEvents returns the events to overlay on the metrics of the query: the deploys, the config changes made
+// through Kiali and the markers of the users of the queried target, over the range of the query.
+func (in *MetricsService) GetEvents(ctx context.Context, q models.IstioMetricsQuery) ([]models.KialiEvent, error) {
+	if in.events == nil {
+		return []models.KialiEvent{}, nil
+	}
+	return in.events.GetEvents(ctx, EventsCriteria{
+		Cluster:   q.Cluster,
+		Namespace: q.Namespace,
+		App:       q.App,
+		Workload:  q.Workload,
+		Service:   q.Service,
+		From:      q.Start,
+		To:        q.End,
+	})
+}
+
+// Warnings returns the distinct warnings returned by Prometheus for the metrics fetched so far by the service,
+// e.g. when the backend could only return partial data.
+func (in *MetricsService) Warnings() []string {
+	in.warningsMutex.Lock()
+	defer in.warningsMutex.Unlock()
+	return append([]string{}, in.warnings...)
+}
+
+func (in *MetricsService) addWarnings(metrics ...prometheus.Metric) {
+	in.warningsMutex.Lock()
+	defer in.warningsMutex.Unlock()
+	for _, metric := range metrics {
+		for _, warning := range metric.Warnings {
+			if !slices.Contains(in.warnings, warning) {
+				in.warnings = append(in.warnings, warning)
+			}
+		}
+	}
+}
+
+func (in *MetricsService) addHistogramWarnings(histo prometheus.Histogram) {
+	for _, metric := range histo {
+		in.addWarnings(metric)
+	}
+}
+
+// GetMetrics returns the Istio metrics of the query. When Prometheus has no Istio metrics but the span metrics of
+// the traces, the request count, error count and duration are computed from the spans instead.
 func (in *MetricsService) GetMetrics(q models.IstioMetricsQuery, scaler func(n string) float64) (models.MetricsMap, error) {
+	if in.useSpanMetrics() {
+		return in.fetchSpanMetrics(q, scaler)
+	}
 	lb := createMetricsLabelsBuilder(&q)
 	grouping := strings.Join(q.ByLabels, ",")
 	return in.fetchAllMetrics(q, lb, grouping, scaler)
@@ -70,7 +132,7 @@ func (in *MetricsService) fetchAllMetrics(q models.IstioMetricsQuery, lb *Metric
 	labelsError := lb.BuildForErrors()
 
 	var wg sync.WaitGroup
-	fetchRate := func(p8sFamilyName string, metric *prometheus.Metric, lbl []string) {
+	fetchRate := func(p8sFamilyName string, metric *prometheus.Metric, lbl []string, grouping string) {
 		defer wg.Done()
 		m := in.prom.FetchRateRange(p8sFamilyName, lbl, grouping, &q.RangeQuery)
 		*metric = m
@@ -112,7 +174,7 @@ func (in *MetricsService) fetchAllMetrics(q models.IstioMetricsQuery, lb *Metric
 				go fetchHisto(istioMetric.istioName, &result.histo)
 			} else {
 				labelsToUse := istioMetric.labelsToUse(labels, labelsError)
-				go fetchRate(istioMetric.istioName, &result.metric, labelsToUse)
+				go fetchRate(istioMetric.istioName, &result.metric, labelsToUse, istioMetric.groupingToUse(grouping, q.ByFlags))
 			}
 		}
 	}
@@ -132,11 +194,13 @@ func (in *MetricsService) fetchAllMetrics(q models.IstioMetricsQuery, lb *Metric
 			var converted []models.Metric
 			var err error
 			if result.definition.isHisto {
+				in.addHistogramWarnings(result.histo)
 				converted, err = models.ConvertHistogram(result.definition.kialiName, result.histo, conversionParams)
 				if err != nil {
 					return nil, err
 				}
 			} else {
+				in.addWarnings(result.metric)
 				converted, err = models.ConvertMetric(result.definition.kialiName, result.metric, conversionParams)
 				if err != nil {
 					return nil, err
@@ -255,6 +319,7 @@ func (in *MetricsService) GetControlPlaneMetrics(q models.IstioMetricsQuery, sca
 	metrics := make(models.MetricsMap)
 
 	h := in.prom.FetchHistogramRange("pilot_proxy_convergence_time", "", "", &q.RangeQuery)
+	in.addHistogramWarnings(h)
 	var err error
 	converted, err := models.ConvertHistogram("pilot_proxy_convergence_time", h, models.ConversionParams{Scale: 1})
 	if err != nil {
@@ -263,6 +328,7 @@ func (in *MetricsService) GetControlPlaneMetrics(q models.IstioMetricsQuery, sca
 	metrics["pilot_proxy_convergence_time"] = append(metrics["pilot_proxy_convergence_time"], converted...)
 
 	metric := in.prom.FetchRateRange("container_cpu_usage_seconds_total", []string{`{pod=~"istiod-.*|istio-pilot-.*"}`}, "", &q.RangeQuery)
+	in.addWarnings(metric)
 	converted, err = models.ConvertMetric("container_cpu_usage_seconds_total", metric, models.ConversionParams{Scale: 1})
 	if err != nil {
 		return nil, err
@@ -270,6 +336,7 @@ func (in *MetricsService) GetControlPlaneMetrics(q models.IstioMetricsQuery, sca
 	metrics["container_cpu_usage_seconds_total"] = append(metrics["container_cpu_usage_seconds_total"], converted...)
 
 	metric = in.prom.FetchRateRange("process_cpu_seconds_total", []string{`{app="istiod"}`}, "", &q.RangeQuery)
+	in.addWarnings(metric)
 	converted, err = models.ConvertMetric("process_cpu_seconds_total", metric, models.ConversionParams{Scale: 1})
 	if err != nil {
 		return nil, err
@@ -277,6 +344,7 @@ func (in *MetricsService) GetControlPlaneMetrics(q models.IstioMetricsQuery, sca
 	metrics["process_cpu_seconds_total"] = append(metrics["process_cpu_seconds_total"], converted...)
 
 	metric = in.prom.FetchRange("container_memory_working_set_bytes", `{container="discovery", pod=~"istiod-.*|istio-pilot-.*"}`, "", "", &q.RangeQuery)
+	in.addWarnings(metric)
 	converted, err = models.ConvertMetric("container_memory_working_set_bytes", metric, models.ConversionParams{Scale: 0.000001})
 	if err != nil {
 		return nil, err
@@ -284,6 +352,7 @@ func (in *MetricsService) GetControlPlaneMetrics(q models.IstioMetricsQuery, sca
 	metrics["container_memory_working_set_bytes"] = append(metrics["container_memory_working_set_bytes"], converted...)
 
 	metric = in.prom.FetchRange("process_resident_memory_bytes", `{app="istiod"}`, "", "", &q.RangeQuery)
+	in.addWarnings(metric)
 	converted, err = models.ConvertMetric("process_resident_memory_bytes", metric, models.ConversionParams{Scale: 0.000001})
 	if err != nil {
 		return nil, err
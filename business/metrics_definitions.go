@@ -1,10 +1,17 @@
 package business
 
+import "strings"
+
+// responseFlagsLabel is the label of the Envoy response flags e.g. UH (no healthy upstream) or UF (upstream connection failure).
+// It is "-" when no flag is set, e.g. for 503s returned by the application itself.
+const responseFlagsLabel = "response_flags"
+
 type istioMetric struct {
-	kialiName      string
-	istioName      string
-	isHisto        bool
-	useErrorLabels bool
+	kialiName        string
+	istioName        string
+	isHisto          bool
+	useErrorLabels   bool
+	hasResponseFlags bool
 }
 
 var istioMetrics = []istioMetric{
@@ -19,15 +26,17 @@ var istioMetrics = []istioMetric{
 		isHisto:   false,
 	},
 	{
-		kialiName: "request_count",
-		istioName: "istio_requests_total",
-		isHisto:   false,
+		kialiName:        "request_count",
+		istioName:        "istio_requests_total",
+		isHisto:          false,
+		hasResponseFlags: true,
 	},
 	{
-		kialiName:      "request_error_count",
-		istioName:      "istio_requests_total",
-		isHisto:        false,
-		useErrorLabels: true,
+		kialiName:        "request_error_count",
+		istioName:        "istio_requests_total",
+		isHisto:          false,
+		useErrorLabels:   true,
+		hasResponseFlags: true,
 	},
 	{
 		kialiName: "request_duration_millis",
@@ -82,3 +91,19 @@ func (in *istioMetric) labelsToUse(labels string, labelsError []string) []string
 	}
 	return []string{labels}
 }
+
+// groupingToUse returns the grouping of the metric, adding the response flags when requested and supported by the metric.
+func (in *istioMetric) groupingToUse(grouping string, byFlags bool) string {
+	if !byFlags || !in.hasResponseFlags {
+		return grouping
+	}
+	if grouping == "" {
+		return responseFlagsLabel
+	}
+	for _, label := range strings.Split(grouping, ",") {
+		if label == responseFlagsLabel {
+			return grouping
+		}
+	}
+	return grouping + "," + responseFlagsLabel
+}
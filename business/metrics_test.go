@@ -7,6 +7,7 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/models"
@@ -276,6 +277,115 @@ func TestGetAppMetricsUnavailable(t *testing.T) {
 	assertEmptyHisto(assert, rqSizeIn, "0.99")
 }
 
+func TestGetMetricsWarnings(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	partial := "partial response: store unavailable"
+	prom := new(prometheustest.PromClientMock)
+	prom.On("FetchRateRange", "istio_requests_total", mock.Anything, "", mock.Anything).Return(prometheus.Metric{Matrix: model.Matrix{}, Warnings: []string{partial}})
+	prom.On("FetchHistogramRange", "istio_request_bytes", mock.Anything, "", mock.Anything).Return(prometheus.Histogram{
+		"avg":  prometheus.Metric{Matrix: model.Matrix{}, Warnings: []string{partial}},
+		"0.99": prometheus.Metric{Matrix: model.Matrix{}},
+	})
+	srv := NewMetricsService(prom)
+
+	q := models.IstioMetricsQuery{
+		Namespace: "bookinfo",
+		App:       "productpage",
+	}
+	q.FillDefaults()
+	q.Filters = []string{"request_count", "request_size"}
+	_, err := srv.GetMetrics(q, nil)
+
+	assert.NoError(err)
+	// Warnings are reported once
+	assert.Equal([]string{partial}, srv.Warnings())
+}
+
+func TestGetMetricsFromSpanMetrics(t *testing.T) {
+	assert := assert.New(t)
+	srv, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf := config.NewConfig()
+	conf.ExternalServices.Tracing.Provider = config.TempoProvider
+	config.Set(conf)
+	spanMetricsDetection.expiresAt = time.Time{}
+	t.Cleanup(func() { spanMetricsDetection.expiresAt = time.Time{} })
+
+	// Prometheus has the span metrics only
+	api.MockTime(`count({__name__=~"istio_requests_total|traces_spanmetrics_calls_total"}) by (__name__)`, model.Vector{
+		&model.Sample{Metric: model.Metric{"__name__": "traces_spanmetrics_calls_total"}, Value: 12},
+	})
+	labels := `span_kind="SPAN_KIND_SERVER",service="productpage.bookinfo"`
+	api.MockRange("sum(rate(traces_spanmetrics_calls_total{"+labels+"}[5m]))", 2.5)
+	api.MockRange("sum(rate(traces_spanmetrics_calls_total{"+labels+`,status_code="STATUS_CODE_ERROR"}[5m]))`, 0.5)
+	api.MockHistoRange("traces_spanmetrics_latency", "{"+labels+"}[5m]", 0.35, 0.2, 0.3, 0.8)
+
+	q := models.IstioMetricsQuery{
+		Namespace: "bookinfo",
+		App:       "productpage",
+	}
+	q.FillDefaults()
+	q.Direction = "inbound"
+	q.RateInterval = "5m"
+	q.Quantiles = []string{"0.99"}
+	metrics, err := srv.GetMetrics(q, nil)
+
+	assert.NoError(err)
+	assert.Len(metrics, 3)
+	assert.Equal(2.5, float64(metrics["request_count"][0].Datapoints[0].Value))
+	assert.Equal(0.5, float64(metrics["request_error_count"][0].Datapoints[0].Value))
+	// seconds to milliseconds
+	assertHisto(assert, metrics["request_duration_millis"], "0.99", 800)
+	assert.Equal([]string{spanMetricsWarning}, srv.Warnings())
+}
+
+func TestGetMetricsByFlags(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	prom := new(prometheustest.PromClientMock)
+	prom.On("FetchRateRange", "istio_requests_total", mock.Anything, "response_flags", mock.Anything).Return(prometheus.Metric{Matrix: model.Matrix{
+		&model.SampleStream{Metric: model.Metric{"response_flags": "UH"}, Values: []model.SamplePair{{Timestamp: 0, Value: 1.5}}},
+		&model.SampleStream{Metric: model.Metric{"response_flags": "-"}, Values: []model.SamplePair{{Timestamp: 0, Value: 0.5}}},
+	}})
+	prom.On("FetchHistogramRange", "istio_request_bytes", mock.Anything, "", mock.Anything).Return(prometheus.Histogram{})
+	srv := NewMetricsService(prom)
+
+	q := models.IstioMetricsQuery{
+		Namespace: "bookinfo",
+		Service:   "productpage",
+		ByFlags:   true,
+	}
+	q.FillDefaults()
+	q.Filters = []string{"request_count", "request_size"}
+	metrics, err := srv.GetMetrics(q, nil)
+
+	assert.NoError(err)
+	assert.Len(metrics["request_count"], 2)
+	assert.Equal("UH", metrics["request_count"][0].Labels["response_flags"])
+	assert.Equal("-", metrics["request_count"][1].Labels["response_flags"])
+	// Only the request counts are grouped by flags
+	prom.AssertCalled(t, "FetchHistogramRange", "istio_request_bytes", mock.Anything, "", mock.Anything)
+}
+
+func TestGroupingToUse(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := istioMetric{kialiName: "request_count", hasResponseFlags: true}
+	tcp := istioMetric{kialiName: "tcp_sent"}
+
+	assert.Equal("response_flags", requests.groupingToUse("", true))
+	assert.Equal("response_code,response_flags", requests.groupingToUse("response_code", true))
+	assert.Equal("response_flags,response_code", requests.groupingToUse("response_flags,response_code", true))
+	assert.Equal("response_code", requests.groupingToUse("response_code", false))
+	assert.Equal("response_code", tcp.groupingToUse("response_code", true))
+}
+
 func TestGetNamespaceMetrics(t *testing.T) {
 	assert := assert.New(t)
 	srv, api, err := setupMocked()
@@ -0,0 +1,256 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetNamespaceAccessAudit reports for each cluster which namespaces are visible to the user and which are filtered out,
+// with the decisions of the filters of GetNamespaces: the accessible namespaces, the includes, the member roll, the
+// discovery selectors, the excludes and the RBAC of the user. The namespaces of the clusters are listed with the Kiali
+// service account.
+func (in *NamespaceService) GetNamespaceAccessAudit(ctx context.Context) ([]models.NamespaceAccessAudit, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceAccessAudit",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	selectors := []labels.Selector{}
+	for _, selector := range in.getDiscoverySelectors() {
+		ls, err := meta_v1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing discovery selectors filter, invalid discovery selector: %v", err)
+		}
+		selectors = append(selectors, ls)
+	}
+
+	visible, err := in.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visibleByCluster := map[string]map[string]bool{}
+	for _, ns := range visible {
+		if visibleByCluster[ns.Cluster] == nil {
+			visibleByCluster[ns.Cluster] = map[string]bool{}
+		}
+		visibleByCluster[ns.Cluster][ns.Name] = true
+	}
+
+	clusters := in.GetClusterList()
+	sort.Strings(clusters)
+	audits := make([]models.NamespaceAccessAudit, 0, len(clusters))
+	for _, cluster := range clusters {
+		audits = append(audits, in.auditClusterNamespaces(ctx, cluster, visibleByCluster[cluster], selectors))
+	}
+	return audits, nil
+}
+
+func (in *NamespaceService) auditClusterNamespaces(ctx context.Context, cluster string, visible map[string]bool, selectors []labels.Selector) models.NamespaceAccessAudit {
+	audit := models.NamespaceAccessAudit{Cluster: cluster, Visible: []models.NamespaceAccess{}, Filtered: []models.NamespaceAccess{}}
+
+	namespaces, err := in.listNamespacesUsingKialiSA(cluster)
+	if err != nil {
+		audit.Error = err.Error()
+	}
+	listed := map[string]bool{}
+	for _, ns := range namespaces {
+		listed[ns.Name] = true
+	}
+	// The namespaces visible to the user are audited even when the service account could not list them
+	for name := range visible {
+		if !listed[name] {
+			namespaces = append(namespaces, core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: name}})
+		}
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+
+	var members []string
+	if smmr := in.getMemberRoll(ctx, cluster); smmr != nil {
+		members = smmr.MemberNamespaces()
+	}
+
+	for _, ns := range namespaces {
+		access := models.NamespaceAccess{Name: ns.Name, Reasons: in.namespaceFilterReasons(ns, selectors, members)}
+		if visible[ns.Name] {
+			access.Reasons = append(access.Reasons, models.NamespaceAccessReason{
+				Filter: models.NamespaceFilterRBAC, Allowed: true, Message: "readable with the token of the user",
+			})
+			audit.Visible = append(audit.Visible, access)
+			continue
+		}
+
+		allowed := true
+		for _, reason := range access.Reasons {
+			allowed = allowed && reason.Allowed
+		}
+		// When the config keeps the namespace, the user cannot read it
+		if allowed {
+			access.Reasons = append(access.Reasons, in.userAccessReason(ctx, cluster, ns.Name))
+		}
+		audit.Filtered = append(audit.Filtered, access)
+	}
+	return audit
+}
+
+// listNamespacesUsingKialiSA lists all the namespaces of the cluster, or the accessible namespaces when the service
+// account cannot list them all
+func (in *NamespaceService) listNamespacesUsingKialiSA(cluster string) ([]core_v1.Namespace, error) {
+	saClient, ok := in.kialiSAClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("client for cluster [%s] not found", cluster)
+	}
+	namespaces, err := saClient.GetNamespaces("")
+	if err == nil {
+		return namespaces, nil
+	}
+	if _, queryAllNamespaces := in.isAccessibleNamespaces["**"]; queryAllNamespaces {
+		return nil, err
+	}
+
+	namespaces = []core_v1.Namespace{}
+	for _, name := range in.conf.Deployment.AccessibleNamespaces {
+		ns, err := saClient.GetNamespace(name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return namespaces, err
+		}
+		namespaces = append(namespaces, *ns)
+	}
+	return namespaces, nil
+}
+
+// namespaceFilterReasons returns the decisions of the filters of the config for a namespace. The members are the
+// namespaces of the member roll of the control plane, nil when it has none.
+func (in *NamespaceService) namespaceFilterReasons(ns core_v1.Namespace, selectors []labels.Selector, members []string) []models.NamespaceAccessReason {
+	reasons := []models.NamespaceAccessReason{}
+	reason := func(filter string, allowed bool, format string, args ...interface{}) {
+		reasons = append(reasons, models.NamespaceAccessReason{Filter: filter, Allowed: allowed, Message: fmt.Sprintf(format, args...)})
+	}
+	isControlPlane := ns.Name == in.conf.IstioNamespace
+	_, queryAllNamespaces := in.isAccessibleNamespaces["**"]
+
+	// accessible namespaces
+	if queryAllNamespaces {
+		reason(models.NamespaceFilterAccessible, true, "all the namespaces are accessible, deployment.accessible_namespaces is [**]")
+	} else if in.isAccessibleNamespace(ns.Name) {
+		reason(models.NamespaceFilterAccessible, true, "listed in deployment.accessible_namespaces")
+	} else {
+		reason(models.NamespaceFilterAccessible, false, "not listed in deployment.accessible_namespaces %v", in.conf.Deployment.AccessibleNamespaces)
+	}
+
+	// includes, ignored when the accessible namespaces are a list, except the label selector of the projects
+	labelSelectorInclude := in.conf.API.Namespaces.LabelSelectorInclude
+	includes := in.conf.API.Namespaces.Include
+	matchesLabelSelectorInclude := false
+	if labelSelectorInclude != "" {
+		if selector, err := labels.Parse(labelSelectorInclude); err == nil {
+			matchesLabelSelectorInclude = selector.Matches(labels.Set(ns.Labels))
+		}
+	}
+	switch {
+	case queryAllNamespaces && isControlPlane:
+		reason(models.NamespaceFilterInclude, true, "the control plane namespace is always included")
+	case queryAllNamespaces && (labelSelectorInclude != "" || len(includes) > 0):
+		if matchesLabelSelectorInclude {
+			reason(models.NamespaceFilterInclude, true, "matches api.namespaces.label_selector_include [%s]", labelSelectorInclude)
+		} else if pattern := matchingPattern(includes, ns.Name); pattern != "" {
+			reason(models.NamespaceFilterInclude, true, "matches api.namespaces.include [%s]", pattern)
+		} else {
+			reason(models.NamespaceFilterInclude, false, "matches neither api.namespaces.label_selector_include [%s] nor api.namespaces.include %v", labelSelectorInclude, includes)
+		}
+	case !queryAllNamespaces && in.hasProjects && labelSelectorInclude != "" && matchesLabelSelectorInclude:
+		reason(models.NamespaceFilterInclude, true, "matches api.namespaces.label_selector_include [%s]", labelSelectorInclude)
+	case !queryAllNamespaces && in.hasProjects && labelSelectorInclude != "":
+		reason(models.NamespaceFilterInclude, false, "does not match api.namespaces.label_selector_include [%s]", labelSelectorInclude)
+	}
+
+	// member roll
+	if members != nil {
+		switch {
+		case isControlPlane:
+			reason(models.NamespaceFilterMemberRoll, true, "the control plane namespace is always a member")
+		case slices.Contains(members, ns.Name):
+			reason(models.NamespaceFilterMemberRoll, true, "member of the ServiceMeshMemberRoll of the control plane")
+		default:
+			reason(models.NamespaceFilterMemberRoll, false, "not a member of the ServiceMeshMemberRoll of the control plane")
+		}
+	}
+
+	// discovery selectors
+	if len(selectors) > 0 {
+		selected := false
+		for _, selector := range selectors {
+			selected = selected || selector.Matches(labels.Set(ns.Labels))
+		}
+		switch {
+		case isControlPlane:
+			reason(models.NamespaceFilterDiscoverySelectors, true, "the control plane namespace is always selected")
+		case selected:
+			reason(models.NamespaceFilterDiscoverySelectors, true, "selected by the discovery selectors of the mesh config")
+		default:
+			reason(models.NamespaceFilterDiscoverySelectors, false, "not selected by the discovery selectors of the mesh config")
+		}
+	}
+
+	// excludes, never applied to the control plane namespace
+	labelSelectorExclude := in.conf.API.Namespaces.LabelSelectorExclude
+	if !isControlPlane && (len(in.conf.API.Namespaces.Exclude) > 0 || labelSelectorExclude != "") {
+		excludeName, excludeValue, _ := strings.Cut(labelSelectorExclude, "=")
+		if pattern := matchingPattern(in.conf.API.Namespaces.Exclude, ns.Name); pattern != "" {
+			reason(models.NamespaceFilterExclude, false, "matches api.namespaces.exclude [%s]", pattern)
+		} else if labelSelectorExclude != "" && ns.Labels[excludeName] == excludeValue {
+			reason(models.NamespaceFilterExclude, false, "matches api.namespaces.label_selector_exclude [%s]", labelSelectorExclude)
+		} else {
+			reason(models.NamespaceFilterExclude, true, "not excluded")
+		}
+	}
+	return reasons
+}
+
+// userAccessReason explains why the user cannot see a namespace kept by the config
+func (in *NamespaceService) userAccessReason(ctx context.Context, cluster, namespace string) models.NamespaceAccessReason {
+	var err error
+	if userClient, ok := in.userClients[cluster]; !ok {
+		err = fmt.Errorf("no client of the user for cluster [%s]", cluster)
+	} else if in.hasProjects {
+		_, err = userClient.GetProject(ctx, namespace)
+	} else {
+		_, err = userClient.GetNamespace(namespace)
+	}
+
+	reason := models.NamespaceAccessReason{Filter: models.NamespaceFilterRBAC, Allowed: false}
+	switch {
+	case errors.IsForbidden(err):
+		reason.Message = "the user is not allowed to read the namespace"
+	case err != nil:
+		reason.Message = "the namespace could not be read with the token of the user: " + err.Error()
+	default:
+		reason.Message = "readable with the token of the user, but missing from the cached namespaces of the user"
+	}
+	return reason
+}
+
+// matchingPattern returns the first of the regular expressions matching the name, empty when none
+func matchingPattern(patterns []string, name string) string {
+	for _, pattern := range patterns {
+		if match, _ := regexp.MatchString(pattern, name); match {
+			return pattern
+		}
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+// rbacFake is the client of a user not allowed to read the hidden namespace
+type rbacFake struct {
+	kubernetes.ClientInterface
+	hidden string
+}
+
+func (f *rbacFake) GetNamespace(namespace string) (*core_v1.Namespace, error) {
+	if namespace == f.hidden {
+		return nil, errors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, namespace, nil)
+	}
+	return f.ClientInterface.GetNamespace(namespace)
+}
+
+func (f *rbacFake) GetNamespaces(labelSelector string) ([]core_v1.Namespace, error) {
+	namespaces, err := f.ClientInterface.GetNamespaces(labelSelector)
+	visible := []core_v1.Namespace{}
+	for _, ns := range namespaces {
+		if ns.Name != f.hidden {
+			visible = append(visible, ns)
+		}
+	}
+	return visible, err
+}
+
+func filterReasons(access []models.NamespaceAccess) map[string][]models.NamespaceAccessReason {
+	reasons := map[string][]models.NamespaceAccessReason{}
+	for _, ns := range access {
+		reasons[ns.Name] = ns.Reasons
+	}
+	return reasons
+}
+
+func TestGetNamespaceAccessAudit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.API.Namespaces.Exclude = []string{"^kube-"}
+	config.Set(conf)
+
+	discovery := map[string]string{"istio-discovery": "enabled"}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Labels: discovery}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "kube-system", Labels: discovery}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "legacy"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "payments", Labels: discovery}},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": "discoverySelectors:\n- matchLabels:\n    istio-discovery: enabled\n"},
+		},
+	)
+	k8s.OpenShift = false
+	SetWithBackends(kubetest.NewK8SClientFactoryMock(k8s), nil)
+	kialiCache := cache.NewTestingCache(t, k8s, *conf)
+
+	cluster := conf.KubernetesConfig.ClusterName
+	userClients := map[string]kubernetes.ClientInterface{cluster: &rbacFake{ClientInterface: k8s, hidden: "payments"}}
+	saClients := map[string]kubernetes.ClientInterface{cluster: k8s}
+	nsservice := NewNamespaceService(userClients, saClients, kialiCache, conf)
+
+	audits, err := nsservice.GetNamespaceAccessAudit(context.TODO())
+	require.NoError(err)
+	require.Len(audits, 1)
+	audit := audits[0]
+	assert.Equal(cluster, audit.Cluster)
+	assert.Empty(audit.Error)
+
+	visible := filterReasons(audit.Visible)
+	assert.Len(visible, 2)
+	assert.Contains(visible, "istio-system")
+	assert.Contains(visible, "bookinfo")
+	for _, reason := range visible["bookinfo"] {
+		assert.True(reason.Allowed, reason.Filter)
+	}
+
+	filtered := filterReasons(audit.Filtered)
+	assert.Len(filtered, 3)
+	assert.Contains(filtered["kube-system"], models.NamespaceAccessReason{
+		Filter: models.NamespaceFilterExclude, Allowed: false, Message: "matches api.namespaces.exclude [^kube-]",
+	})
+	assert.Contains(filtered["legacy"], models.NamespaceAccessReason{
+		Filter: models.NamespaceFilterDiscoverySelectors, Allowed: false, Message: "not selected by the discovery selectors of the mesh config",
+	})
+	// The namespace kept by the config is hidden by the RBAC of the user
+	assert.Contains(filtered["payments"], models.NamespaceAccessReason{
+		Filter: models.NamespaceFilterRBAC, Allowed: false, Message: "the user is not allowed to read the namespace",
+	})
+}
+
+func TestGetNamespaceAccessAuditAccessibleNamespaces(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"bookinfo", "missing"}
+	config.Set(conf)
+
+	k8s := setupNamespaceServiceWithNs()
+	SetWithBackends(kubetest.NewK8SClientFactoryMock(k8s), nil)
+	nsservice := setupNamespaceService(t, k8s, conf)
+
+	audits, err := nsservice.GetNamespaceAccessAudit(context.TODO())
+	require.NoError(err)
+	require.Len(audits, 1)
+
+	visible := filterReasons(audits[0].Visible)
+	assert.Len(visible, 1)
+	assert.Contains(visible["bookinfo"], models.NamespaceAccessReason{
+		Filter: models.NamespaceFilterAccessible, Allowed: true, Message: "listed in deployment.accessible_namespaces",
+	})
+
+	filtered := filterReasons(audits[0].Filtered)
+	assert.Contains(filtered["alpha"], models.NamespaceAccessReason{
+		Filter: models.NamespaceFilterAccessible, Allowed: false, Message: "not listed in deployment.accessible_namespaces [bookinfo missing]",
+	})
+	// The user access is only checked for the namespaces kept by the config
+	for _, reason := range filtered["alpha"] {
+		assert.NotEqual(models.NamespaceFilterRBAC, reason.Filter)
+	}
+}
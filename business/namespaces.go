@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 
@@ -94,7 +95,7 @@ func (in *NamespaceService) GetNamespaces(ctx context.Context) ([]models.Namespa
 	clustersToCheck := make(map[string]kubernetes.ClientInterface)
 	namespaces := []models.Namespace{}
 	for cluster, client := range in.userClients {
-		cachedNamespaces, found := in.kialiCache.GetNamespaces(cluster, client.GetToken())
+		cachedNamespaces, found := in.kialiCache.GetNamespaces(cluster, clientIdentity(client))
 		if !found {
 			clustersToCheck[cluster] = client
 		} else {
@@ -107,27 +108,7 @@ func (in *NamespaceService) GetNamespaces(ctx context.Context) ([]models.Namespa
 		return namespaces, nil
 	}
 
-	var discoverySelectors []*meta_v1.LabelSelector
-	homeClusterCache, err := in.kialiCache.GetKubeCache(in.conf.KubernetesConfig.ClusterName)
-	if err != nil {
-		log.Errorf("Will not process discoverySelectors due to a failure to get the Kiali cache: %v", err)
-	} else {
-		// determine what the discoverySelectors are by examining the Istio ConfigMap
-		if icm, err := homeClusterCache.GetConfigMap(in.conf.IstioNamespace, IstioConfigMapName(*in.conf, "")); err == nil {
-			if ic, err2 := kubernetes.GetIstioConfigMap(icm); err2 == nil {
-				discoverySelectors = ic.DiscoverySelectors
-			} else {
-				log.Errorf("Will not process discoverySelectors due to a failure to get the Istio ConfigMap: %v", err2)
-			}
-		} else {
-			log.Errorf("Will not process discoverySelectors due to a failure to parse the Istio ConfigMap: %v", err)
-		}
-	}
-	if len(discoverySelectors) > 0 {
-		log.Tracef("Istio discovery selectors: %+v", discoverySelectors)
-	} else {
-		log.Tracef("No Istio discovery selectors defined.")
-	}
+	discoverySelectors := in.getDiscoverySelectors()
 
 	// Let's explain the four different filters along with accessible namespaces (aka AN).
 	//
@@ -198,7 +179,7 @@ func (in *NamespaceService) GetNamespaces(ctx context.Context) ([]models.Namespa
 				if error != nil {
 					resultsCh <- result{cluster: c, ns: nil, err: error}
 				} else {
-					resultsCh <- result{cluster: c, ns: list, err: nil}
+					resultsCh <- result{cluster: c, ns: in.filterMemberNamespaces(ctx, c, list), err: nil}
 				}
 			}(cluster)
 		}
@@ -289,12 +270,83 @@ func (in *NamespaceService) GetNamespaces(ctx context.Context) ([]models.Namespa
 		namespacesPerCluster[ns.Cluster] = append(namespacesPerCluster[ns.Cluster], ns)
 	}
 	for cluster, ns := range namespacesPerCluster {
-		in.kialiCache.SetNamespaces(in.userClients[cluster].GetToken(), ns)
+		in.kialiCache.SetNamespaces(clientIdentity(in.userClients[cluster]), ns)
 	}
 
 	return resultns, nil
 }
 
+// getDiscoverySelectors returns the discovery selectors of the Istio ConfigMap of the home cluster, if any
+func (in *NamespaceService) getDiscoverySelectors() []*meta_v1.LabelSelector {
+	var discoverySelectors []*meta_v1.LabelSelector
+	homeClusterCache, err := in.kialiCache.GetKubeCache(in.conf.KubernetesConfig.ClusterName)
+	if err != nil {
+		log.Errorf("Will not process discoverySelectors due to a failure to get the Kiali cache: %v", err)
+	} else {
+		// determine what the discoverySelectors are by examining the Istio ConfigMap
+		if icm, err := homeClusterCache.GetConfigMap(in.conf.IstioNamespace, IstioConfigMapName(*in.conf, "")); err == nil {
+			if ic, err2 := kubernetes.GetIstioConfigMap(icm); err2 == nil {
+				discoverySelectors = ic.DiscoverySelectors
+			} else {
+				log.Errorf("Will not process discoverySelectors due to a failure to get the Istio ConfigMap: %v", err2)
+			}
+		} else {
+			log.Errorf("Will not process discoverySelectors due to a failure to parse the Istio ConfigMap: %v", err)
+		}
+	}
+	if len(discoverySelectors) > 0 {
+		log.Tracef("Istio discovery selectors: %+v", discoverySelectors)
+	} else {
+		log.Tracef("No Istio discovery selectors defined.")
+	}
+	return discoverySelectors
+}
+
+// getMemberRoll returns the member roll of the control plane of the cluster, nil when the control plane is not a
+// multitenant OpenShift Service Mesh 2 one. OpenShift Service Mesh 3 scopes the control planes with discovery
+// selectors instead.
+func (in *NamespaceService) getMemberRoll(ctx context.Context, cluster string) *kubernetes.ServiceMeshMemberRoll {
+	saClient, ok := in.kialiSAClients[cluster]
+	if !ok || !saClient.IsOpenShift() {
+		return nil
+	}
+	smmr, err := saClient.GetServiceMeshMemberRoll(ctx, in.conf.IstioNamespace)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Will not process the member roll of cluster [%s] due to a failure to get it: %v", cluster, err)
+		}
+		return nil
+	}
+	log.Tracef("Members of the member roll of cluster [%s]: %v", cluster, smmr.MemberNamespaces())
+	return smmr
+}
+
+// filterMemberNamespaces keeps the namespaces of a cluster which are members of the member roll of its control plane,
+// when it has one. The control plane namespace is always kept.
+func (in *NamespaceService) filterMemberNamespaces(ctx context.Context, cluster string, namespaces []models.Namespace) []models.Namespace {
+	smmr := in.getMemberRoll(ctx, cluster)
+	if smmr == nil {
+		return namespaces
+	}
+	members := map[string]bool{in.conf.IstioNamespace: true}
+	for _, member := range smmr.MemberNamespaces() {
+		members[member] = true
+	}
+	filtered := make([]models.Namespace, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if members[ns.Name] {
+			filtered = append(filtered, ns)
+		}
+	}
+	return filtered
+}
+
+// IsTenantScoped returns whether the namespaces of the cluster are scoped to the tenant of its control plane, by a
+// member roll or by discovery selectors. The namespaces of the other tenants are then intentionally invisible.
+func (in *NamespaceService) IsTenantScoped(ctx context.Context, cluster string) bool {
+	return len(in.getDiscoverySelectors()) > 0 || in.getMemberRoll(ctx, cluster) != nil
+}
+
 func (in *NamespaceService) getNamespacesByCluster(ctx context.Context, cluster string) ([]models.Namespace, error) {
 	configObject := in.conf
 
@@ -565,7 +617,7 @@ func (in *NamespaceService) GetClusterNamespace(ctx context.Context, namespace s
 	}
 
 	// Cache already has included/excluded namespaces applied
-	if ns, found := in.kialiCache.GetNamespace(cluster, client.GetToken(), namespace); found {
+	if ns, found := in.kialiCache.GetNamespace(cluster, clientIdentity(client), namespace); found {
 		return &ns, nil
 	}
 
@@ -581,6 +633,10 @@ func (in *NamespaceService) GetClusterNamespace(ctx context.Context, namespace s
 		return nil, &AccessibleNamespaceError{msg: "Namespace [" + namespace + "] is excluded for Kiali"}
 	}
 
+	if smmr := in.getMemberRoll(ctx, cluster); smmr != nil && namespace != in.conf.IstioNamespace && !slices.Contains(smmr.MemberNamespaces(), namespace) {
+		return nil, &AccessibleNamespaceError{msg: "Namespace [" + namespace + "] is not a member of the mesh of the control plane"}
+	}
+
 	var result models.Namespace
 	if in.hasProjects {
 		project, err := client.GetProject(ctx, namespace)
@@ -613,6 +669,10 @@ func (in *NamespaceService) UpdateNamespace(ctx context.Context, namespace strin
 	)
 	defer end()
 
+	if err := checkViewOnlyMode(in.conf, "Updating the namespace"); err != nil {
+		return nil, err
+	}
+
 	// A first check to run the accessible/excluded logic and not run the Update operation on filtered namespaces
 	_, err := in.GetClusterNamespace(ctx, namespace, cluster)
 	if err != nil {
@@ -648,7 +708,7 @@ func (in *NamespaceService) getNamespacesUsingKialiSA(cluster string, labelSelec
 	// Check if we already are using the Kiali ServiceAccount token. If we are, no need to do further processing, since
 	// this would just circle back to the same results.
 	kialiToken := in.kialiSAClients[cluster].GetToken()
-	if in.userClients[cluster].GetToken() == kialiToken {
+	if clientIdentity(in.userClients[cluster]) == kialiToken {
 		return nil, forwardedError
 	}
 
@@ -673,3 +733,13 @@ func (in *NamespaceService) getNamespacesUsingKialiSA(cluster string, labelSelec
 	// Return the list of namespaces where the user has the 'get namespace' read privilege.
 	return namespaces, nil
 }
+
+// clientIdentity returns the identity the namespaces of a user are cached with: the token of the client and, when the
+// client impersonates a user, the impersonated user and groups, since they see other namespaces than the token.
+func clientIdentity(client kubernetes.ClientInterface) string {
+	identity := client.GetToken()
+	if restConfig := client.ClusterInfo().ClientConfig; restConfig != nil && restConfig.Impersonate.UserName != "" {
+		identity += "|" + restConfig.Impersonate.UserName + "|" + strings.Join(restConfig.Impersonate.Groups, ",")
+	}
+	return identity
+}
@@ -11,6 +11,7 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -192,6 +193,56 @@ func TestAmbientProject(t *testing.T) {
 	assert.False(t, ns2.IsAmbient)
 }
 
+func TestGetNamespacesOfMemberRoll(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "tenant-b"}},
+	)
+	k8s.OpenShift = true
+	k8s.MemberRoll = &kubernetes.ServiceMeshMemberRoll{
+		ObjectMeta: meta_v1.ObjectMeta{Name: kubernetes.ServiceMeshMemberRollName, Namespace: "istio-system"},
+		Spec:       kubernetes.ServiceMeshMemberRollSpec{Members: []string{"bookinfo", "pending"}},
+		Status:     kubernetes.ServiceMeshMemberRollStatus{ConfiguredMembers: []string{"bookinfo"}},
+	}
+	SetWithBackends(kubetest.NewK8SClientFactoryMock(k8s), nil)
+
+	nsservice := setupNamespaceService(t, k8s, conf)
+	assert.True(nsservice.IsTenantScoped(context.TODO(), conf.KubernetesConfig.ClusterName))
+
+	namespaces, err := nsservice.GetNamespaces(context.TODO())
+	require.NoError(err)
+	names := []string{}
+	for _, ns := range namespaces {
+		names = append(names, ns.Name)
+	}
+	assert.ElementsMatch([]string{"istio-system", "bookinfo"}, names)
+
+	_, err = nsservice.GetClusterNamespace(context.TODO(), "tenant-b", conf.KubernetesConfig.ClusterName)
+	assert.True(IsAccessibleError(err))
+}
+
+func TestGetNamespacesWithoutMemberRoll(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := setupAmbientProjectWithNs().(*kubetest.FakeK8sClient)
+	SetWithBackends(kubetest.NewK8SClientFactoryMock(k8s), nil)
+
+	nsservice := setupNamespaceService(t, k8s, conf)
+	assert.False(t, nsservice.IsTenantScoped(context.TODO(), conf.KubernetesConfig.ClusterName))
+
+	namespaces, err := nsservice.GetNamespaces(context.TODO())
+	require.NoError(t, err)
+	assert.Len(t, namespaces, 3)
+}
+
 // Update namespaces
 func TestUpdateNamespaces(t *testing.T) {
 	conf := config.NewConfig()
@@ -367,6 +418,37 @@ func TestGetNamespacesDifferentTokens(t *testing.T) {
 	assert.Equal("east", namespace.Cluster)
 }
 
+func TestGetNamespacesCachedPerImpersonatedUser(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.CacheTokenNamespaceDuration = 600000
+	config.Set(conf)
+
+	admin := setupNamespaceServiceWithNs()
+	admin.Token = "admin-token"
+	cache := cache.NewTestingCache(t, admin, *conf)
+	cache.SetNamespaces(
+		admin.GetToken(),
+		[]models.Namespace{{Name: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName}, {Name: "alpha", Cluster: conf.KubernetesConfig.ClusterName}},
+	)
+
+	// The admin impersonating a user does not see the namespaces cached for the admin
+	impersonating := setupNamespaceServiceWithNs()
+	impersonating.Token = "admin-token"
+	impersonating.KubeClusterInfo = kubernetes.ClusterInfo{ClientConfig: &rest.Config{Impersonate: rest.ImpersonationConfig{UserName: "jdoe"}}}
+	require.NotEqual(clientIdentity(admin), clientIdentity(impersonating))
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: &rbacFake{ClientInterface: impersonating, hidden: "alpha"}}
+	nsservice := NewNamespaceService(clients, clients, cache, conf)
+	_, err := nsservice.GetClusterNamespace(context.TODO(), "alpha", conf.KubernetesConfig.ClusterName)
+	require.Error(err)
+
+	namespace, err := nsservice.GetClusterNamespace(context.TODO(), "bookinfo", conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	require.Equal("bookinfo", namespace.Name)
+}
+
 type forbiddenFake struct{ kubernetes.ClientInterface }
 
 func (f *forbiddenFake) GetNamespace(namespace string) (*core_v1.Namespace, error) {
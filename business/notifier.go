@@ -0,0 +1,278 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayapischeme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	// notifierQueueSize bounds the changes waiting to be notified, the changes beyond are dropped.
+	notifierQueueSize = 1000
+	notifierTimeout   = 5 * time.Second
+)
+
+type clusterObjectChange struct {
+	cluster string
+	change  cache.ObjectChange
+	time    time.Time
+}
+
+// Notifier posts the changes of the Istio and Gateway API objects seen by the kube caches to the Slack and webhook
+// targets of the config. The changes of an object are notified once per dedup interval, and the notifications
+// beyond the rate limit of a target are dropped.
+type Notifier struct {
+	client   *http.Client
+	conf     config.Notifications
+	changes  chan clusterObjectChange
+	kinds    map[string]bool
+	limiters []*rate.Limiter
+	// notified is the last time an object change was notified, by object key and action. Only used by the
+	// goroutine sending the notifications.
+	notified   map[string]time.Time
+	namespaces map[string]bool
+}
+
+// NewNotifier creates a notifier of the changes matching the config.
+func NewNotifier(conf config.Notifications) *Notifier {
+	n := &Notifier{
+		client:     &http.Client{Timeout: notifierTimeout},
+		conf:       conf,
+		changes:    make(chan clusterObjectChange, notifierQueueSize),
+		kinds:      map[string]bool{},
+		limiters:   make([]*rate.Limiter, len(conf.Targets)),
+		notified:   map[string]time.Time{},
+		namespaces: map[string]bool{},
+	}
+	for _, kind := range conf.Kinds {
+		n.kinds[kind] = true
+	}
+	for _, namespace := range conf.Namespaces {
+		n.namespaces[namespace] = true
+	}
+	for i := range conf.Targets {
+		if conf.RateLimit > 0 {
+			n.limiters[i] = rate.NewLimiter(rate.Limit(float64(conf.RateLimit)/60), conf.RateLimit)
+		}
+	}
+	return n
+}
+
+// Watch notifies the object changes of the kube cache of a cluster. The returned function stops watching it.
+func (n *Notifier) Watch(cluster string, kubeCache cache.KubeCache) (remove func()) {
+	return kubeCache.AddObjectChangeListener(func(change cache.ObjectChange) {
+		select {
+		case n.changes <- clusterObjectChange{cluster: cluster, change: change, time: time.Now()}:
+		default:
+			log.Warningf("Dropping the notification of a config change of cluster [%s], too many changes are pending", cluster)
+		}
+	})
+}
+
+// Start sends the notifications until the context is cancelled.
+func (n *Notifier) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change := <-n.changes:
+				if notification := n.notification(change); notification != nil {
+					n.notify(ctx, *notification)
+				}
+			}
+		}
+	}()
+}
+
+// notification returns the notification of a change, nil when the change is filtered out, deduplicated or when an
+// update doesn't change the spec, the labels or the annotations of the object (e.g. a status update).
+func (n *Notifier) notification(change clusterObjectChange) *models.ConfigChangeNotification {
+	obj, ok := change.change.Object.(meta_v1.Object)
+	if !ok {
+		return nil
+	}
+	kind := objectKind(change.change.Object)
+	if kind == "" || (len(n.kinds) > 0 && !n.kinds[kind]) || (len(n.namespaces) > 0 && !n.namespaces[obj.GetNamespace()]) {
+		return nil
+	}
+
+	notification := &models.ConfigChangeNotification{
+		Action:    change.change.Type,
+		Cluster:   change.cluster,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Time:      change.time.UTC(),
+	}
+	if change.change.Type != cache.ObjectDeleted {
+		notification.User = lastManager(obj)
+	}
+	if change.change.Type == cache.ObjectUpdated {
+		notification.ChangedFields = changedFields(change.change.Old, change.change.Object)
+		if len(notification.ChangedFields) == 0 {
+			return nil
+		}
+	}
+
+	key := strings.Join([]string{notification.Cluster, kind, notification.Namespace, notification.Name, notification.Action}, "/")
+	dedupInterval := time.Duration(n.conf.DedupInterval) * time.Second
+	if last, found := n.notified[key]; found && change.time.Sub(last) < dedupInterval {
+		log.Debugf("Not notifying the change of %s [%s/%s] again, notified at %s", kind, notification.Namespace, notification.Name, last)
+		return nil
+	}
+	for k, last := range n.notified {
+		if change.time.Sub(last) >= dedupInterval {
+			delete(n.notified, k)
+		}
+	}
+	n.notified[key] = change.time
+
+	return notification
+}
+
+// notify posts the notification to the targets within their rate limit.
+func (n *Notifier) notify(ctx context.Context, notification models.ConfigChangeNotification) {
+	for i, target := range n.conf.Targets {
+		if n.limiters[i] != nil && !n.limiters[i].Allow() {
+			log.Warningf("Dropping the notification of the change of %s [%s/%s] to target [%s], rate limit reached", notification.Kind, notification.Namespace, notification.Name, target.Name)
+			continue
+		}
+		if err := n.post(ctx, target, notification); err != nil {
+			log.Errorf("Unable to notify target [%s] of the change of %s [%s/%s]: %s", target.Name, notification.Kind, notification.Namespace, notification.Name, err)
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, target config.NotificationTarget, notification models.ConfigChangeNotification) error {
+	var payload interface{} = notification
+	if target.Type == config.NotificationTargetSlack {
+		payload = map[string]string{"text": slackMessage(notification)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifierTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range target.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// slackMessage formats a notification as a Slack message, e.g.
+// "VirtualService bookinfo/reviews Updated in cluster east by kubectl-edit, changed fields: spec.http"
+func slackMessage(notification models.ConfigChangeNotification) string {
+	msg := fmt.Sprintf("%s *%s/%s* %s in cluster %s", notification.Kind, notification.Namespace, notification.Name, notification.Action, notification.Cluster)
+	if notification.User != "" {
+		msg += " by " + notification.User
+	}
+	if len(notification.ChangedFields) > 0 {
+		msg += ", changed fields: " + strings.Join(notification.ChangedFields, ", ")
+	}
+	return msg + " at " + notification.Time.Format(time.RFC3339)
+}
+
+// objectKind returns the kind of an Istio or Gateway API object, empty for the other objects. The objects of the
+// informers don't have their type meta set.
+func objectKind(obj runtime.Object) string {
+	for _, scheme := range []*runtime.Scheme{istioscheme.Scheme, gatewayapischeme.Scheme} {
+		if gvks, _, err := scheme.ObjectKinds(obj); err == nil && len(gvks) > 0 {
+			return gvks[0].Kind
+		}
+	}
+	return ""
+}
+
+// lastManager returns the field manager of the latest write of the object.
+func lastManager(obj meta_v1.Object) string {
+	var manager string
+	var latest time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && !entry.Time.Time.Before(latest) {
+			latest = entry.Time.Time
+			manager = entry.Manager
+		}
+	}
+	return manager
+}
+
+// changedFields returns the changed fields of the spec, one level down, and whether the labels or the annotations
+// changed, sorted.
+func changedFields(old, new runtime.Object) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	oldContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(old)
+	if err != nil {
+		return nil
+	}
+	newContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(new)
+	if err != nil {
+		return nil
+	}
+
+	fields := []string{}
+	oldSpec, _ := oldContent["spec"].(map[string]interface{})
+	newSpec, _ := newContent["spec"].(map[string]interface{})
+	for _, field := range unionKeys(oldSpec, newSpec) {
+		if !reflect.DeepEqual(oldSpec[field], newSpec[field]) {
+			fields = append(fields, "spec."+field)
+		}
+	}
+	oldMeta, _ := oldContent["metadata"].(map[string]interface{})
+	newMeta, _ := newContent["metadata"].(map[string]interface{})
+	for _, field := range []string{"annotations", "labels"} {
+		if !reflect.DeepEqual(oldMeta[field], newMeta[field]) {
+			fields = append(fields, "metadata."+field)
+		}
+	}
+	return fields
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := []string{}
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, found := a[k]; !found {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,126 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/models"
+)
+
+func TestNotifierNotification(t *testing.T) {
+	assert := assert.New(t)
+
+	notifier := NewNotifier(config.Notifications{
+		DedupInterval: 60,
+		Kinds:         []string{"VirtualService"},
+		Namespaces:    []string{"bookinfo"},
+	})
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	vs := &networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{
+		Name:      "reviews",
+		Namespace: "bookinfo",
+		ManagedFields: []meta_v1.ManagedFieldsEntry{
+			{Manager: "kubectl-client-side-apply", Time: &meta_v1.Time{Time: now.Add(-time.Hour)}},
+			{Manager: "kubectl-edit", Time: &meta_v1.Time{Time: now.Add(-time.Minute)}},
+		},
+	}}
+	updated := vs.DeepCopy()
+	updated.Spec.Hosts = []string{"reviews"}
+	updated.Labels = map[string]string{"version": "v2"}
+
+	notification := notifier.notification(clusterObjectChange{cluster: "east", change: cache.ObjectChange{Type: cache.ObjectUpdated, Old: vs, Object: updated}, time: now})
+	assert.Equal(&models.ConfigChangeNotification{
+		Action:        cache.ObjectUpdated,
+		Cluster:       "east",
+		Kind:          "VirtualService",
+		Namespace:     "bookinfo",
+		Name:          "reviews",
+		User:          "kubectl-edit",
+		Time:          now,
+		ChangedFields: []string{"spec.hosts", "metadata.labels"},
+	}, notification)
+
+	// Deduplicated within the dedup interval
+	assert.Nil(notifier.notification(clusterObjectChange{cluster: "east", change: cache.ObjectChange{Type: cache.ObjectUpdated, Old: vs, Object: updated}, time: now.Add(30 * time.Second)}))
+	assert.NotNil(notifier.notification(clusterObjectChange{cluster: "east", change: cache.ObjectChange{Type: cache.ObjectUpdated, Old: vs, Object: updated}, time: now.Add(90 * time.Second)}))
+	// A deletion is a different change, without user
+	deleted := notifier.notification(clusterObjectChange{cluster: "east", change: cache.ObjectChange{Type: cache.ObjectDeleted, Object: updated}, time: now.Add(90 * time.Second)})
+	assert.NotNil(deleted)
+	assert.Empty(deleted.User)
+
+	// Status updates are not config changes
+	statusUpdate := updated.DeepCopy()
+	statusUpdate.Status.ObservedGeneration = 2
+	assert.Nil(notifier.notification(clusterObjectChange{cluster: "west", change: cache.ObjectChange{Type: cache.ObjectUpdated, Old: updated, Object: statusUpdate}, time: now}))
+
+	// Filtered out kinds and namespaces
+	other := vs.DeepCopy()
+	other.Namespace = "istio-system"
+	assert.Nil(notifier.notification(clusterObjectChange{cluster: "east", change: cache.ObjectChange{Type: cache.ObjectAdded, Object: other}, time: now}))
+	dr := &networking_v1beta1.DestinationRule{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}}
+	assert.Nil(notifier.notification(clusterObjectChange{cluster: "east", change: cache.ObjectChange{Type: cache.ObjectAdded, Object: dr}, time: now}))
+	// Only Istio and Gateway API objects are notified
+	assert.Nil(NewNotifier(config.Notifications{}).notification(clusterObjectChange{cluster: "east", change: cache.ObjectChange{Type: cache.ObjectAdded, Object: &core_v1.ConfigMap{}}, time: now}))
+}
+
+func TestNotifierNotify(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var lock sync.Mutex
+	received := map[string][]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		body := map[string]interface{}{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		body["authorization"] = r.Header.Get("Authorization")
+		received[r.URL.Path] = append(received[r.URL.Path], body)
+	}))
+	t.Cleanup(server.Close)
+
+	notifier := NewNotifier(config.Notifications{
+		RateLimit: 2,
+		Targets: []config.NotificationTarget{
+			{Name: "slack", Type: config.NotificationTargetSlack, URL: server.URL + "/slack"},
+			{Name: "hook", Type: config.NotificationTargetWebhook, URL: server.URL + "/hook", Headers: map[string]string{"Authorization": "Bearer token"}},
+		},
+	})
+	notification := models.ConfigChangeNotification{
+		Action:        cache.ObjectUpdated,
+		Cluster:       "east",
+		Kind:          "VirtualService",
+		Namespace:     "bookinfo",
+		Name:          "reviews",
+		User:          "kubectl-edit",
+		Time:          time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		ChangedFields: []string{"spec.http"},
+	}
+	// The third notification is beyond the rate limit
+	for i := 0; i < 3; i++ {
+		notifier.notify(context.TODO(), notification)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(received["/slack"], 2)
+	assert.Equal("VirtualService *bookinfo/reviews* Updated in cluster east by kubectl-edit, changed fields: spec.http at 2024-01-15T10:00:00Z", received["/slack"][0]["text"])
+	require.Len(received["/hook"], 2)
+	assert.Equal("reviews", received["/hook"][0]["name"])
+	assert.Equal([]interface{}{"spec.http"}, received["/hook"][0]["changedFields"])
+	assert.Equal("Bearer token", received["/hook"][0]["authorization"])
+}
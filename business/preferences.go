@@ -0,0 +1,187 @@
+package business
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const (
+	// PreferencesConfigMapPrefix prefixes the configmaps of the Kiali namespace storing the preferences of the
+	// users, one configmap per user named after the hash of the user.
+	PreferencesConfigMapPrefix = "kiali-user-preferences-"
+	// PreferencesLabel labels the configmaps storing the preferences of the users.
+	PreferencesLabel = "kiali.io/user-preferences"
+	// anonymousUser owns the preferences when the auth strategy doesn't identify the users.
+	anonymousUser = "anonymous"
+)
+
+// The names of the namespace sets are displayed in the namespace selector.
+var namespaceSetNameRegexp = regexp.MustCompile(`^[-._ a-zA-Z0-9]{1,63}$`)
+
+// PreferencesService stores the preferences of the users. The preferences of a user are stored in a configmap of
+// the Kiali namespace in the home cluster, one key per preference, read and written with the Kiali service account.
+// Preferences don't change the mesh, they can be saved in view-only mode.
+type PreferencesService struct {
+	conf           *config.Config
+	kialiSAClients map[string]kubernetes.ClientInterface
+}
+
+func (in *PreferencesService) client() (kubernetes.ClientInterface, error) {
+	client, ok := in.kialiSAClients[in.conf.KubernetesConfig.ClusterName]
+	if !ok {
+		return nil, fmt.Errorf("client for the home cluster [%s] not found", in.conf.KubernetesConfig.ClusterName)
+	}
+	return client, nil
+}
+
+// preferencesConfigMapName returns the name of the configmap of a user. Users are hashed to keep the names valid
+// and to not disclose them.
+func preferencesConfigMapName(user string) string {
+	if user == "" {
+		user = anonymousUser
+	}
+	hash := sha256.Sum256([]byte(user))
+	return PreferencesConfigMapPrefix + hex.EncodeToString(hash[:16])
+}
+
+// getConfigMap returns the configmap storing the preferences of a user, nil when they were never saved.
+func (in *PreferencesService) getConfigMap(user string) (*core_v1.ConfigMap, error) {
+	client, err := in.client()
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := client.GetConfigMap(in.conf.Deployment.Namespace, preferencesConfigMapName(user))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return configMap, nil
+}
+
+// GetPreferences returns the preferences of a user, empty when they were never saved.
+func (in *PreferencesService) GetPreferences(ctx context.Context, user string) (*models.UserPreferences, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "GetPreferences",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	configMap, err := in.getConfigMap(user)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := &models.UserPreferences{}
+	if configMap == nil {
+		return preferences, nil
+	}
+	// Each preference is decoded on its own so that an invalid one doesn't lose the others.
+	for key, value := range configMap.Data {
+		data, _ := json.Marshal(map[string]json.RawMessage{key: json.RawMessage(value)})
+		if err := json.Unmarshal(data, preferences); err != nil {
+			log.Errorf("Ignoring invalid preference [%s] of configmap [%s/%s]: %s", key, configMap.Namespace, configMap.Name, err)
+		}
+	}
+	return preferences, nil
+}
+
+// SavePreferences replaces the preferences of a user. Concurrent updates of the preferences are detected by
+// the resource version of the configmap and returned as conflicts.
+func (in *PreferencesService) SavePreferences(ctx context.Context, user string, preferences models.UserPreferences) (*models.UserPreferences, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "SavePreferences",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	for name, namespaces := range preferences.NamespaceSets {
+		if !namespaceSetNameRegexp.MatchString(name) {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid namespace set name [%s]: only alphanumeric characters, spaces, '-', '_' and '.' are allowed", name))
+		}
+		if len(namespaces) == 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("namespace set [%s] has no namespaces", name))
+		}
+	}
+	if preferences.Duration < 0 || preferences.RefreshInterval < 0 {
+		return nil, errors.NewBadRequest("the duration and the refresh interval can't be negative")
+	}
+
+	preferences.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	encoded, err := json.Marshal(preferences)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(encoded, &values); err != nil {
+		return nil, err
+	}
+	data := make(map[string]string, len(values))
+	for key, value := range values {
+		data[key] = string(value)
+	}
+
+	client, err := in.client()
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := in.getConfigMap(user)
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps := client.Kube().CoreV1().ConfigMaps(in.conf.Deployment.Namespace)
+	if configMap == nil {
+		configMap = &core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      preferencesConfigMapName(user),
+				Namespace: in.conf.Deployment.Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/part-of": "kiali", PreferencesLabel: "true"},
+			},
+			Data: data,
+		}
+		_, err = configMaps.Create(ctx, configMap, meta_v1.CreateOptions{})
+	} else {
+		configMap.Data = data
+		_, err = configMaps.Update(ctx, configMap, meta_v1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &preferences, nil
+}
+
+// DeletePreferences resets the preferences of a user.
+func (in *PreferencesService) DeletePreferences(ctx context.Context, user string) error {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "DeletePreferences",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	client, err := in.client()
+	if err != nil {
+		return err
+	}
+	err = client.Kube().CoreV1().ConfigMaps(in.conf.Deployment.Namespace).Delete(ctx, preferencesConfigMapName(user), meta_v1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
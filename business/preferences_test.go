@@ -0,0 +1,97 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestPreferencesPerUser(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.Namespace = "istio-system"
+	conf.Deployment.ViewOnlyMode = true
+	kubernetes.SetConfig(t, *conf)
+
+	ctx := context.TODO()
+	k8s := kubetest.NewFakeK8sClient()
+	svc := PreferencesService{conf: conf, kialiSAClients: map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}}
+
+	preferences, err := svc.GetPreferences(ctx, "alice")
+	require.NoError(err)
+	assert.Equal(&models.UserPreferences{}, preferences)
+
+	saved, err := svc.SavePreferences(ctx, "alice", models.UserPreferences{
+		NamespaceSets: map[string][]string{"Book info": {"bookinfo", "bookinfo-db"}},
+		Duration:      600,
+		GraphSettings: map[string]string{"edgeLabels": "responseTime"},
+	})
+	require.NoError(err)
+	assert.NotEmpty(saved.UpdatedAt)
+
+	// One configmap per user, one key per preference
+	configMap, err := k8s.GetConfigMap("istio-system", preferencesConfigMapName("alice"))
+	require.NoError(err)
+	assert.Equal("true", configMap.Labels[PreferencesLabel])
+	assert.NotContains(configMap.Name, "alice")
+	assert.Equal("600", configMap.Data["duration"])
+	assert.NotContains(configMap.Data, "refreshInterval")
+
+	// An invalid preference doesn't lose the others
+	configMap.Data["graphSettings"] = "not json"
+	_, err = k8s.Kube().CoreV1().ConfigMaps("istio-system").Update(ctx, configMap, meta_v1.UpdateOptions{})
+	require.NoError(err)
+
+	preferences, err = svc.GetPreferences(ctx, "alice")
+	require.NoError(err)
+	assert.Equal(map[string][]string{"Book info": {"bookinfo", "bookinfo-db"}}, preferences.NamespaceSets)
+	assert.Equal(int64(600), preferences.Duration)
+	assert.Empty(preferences.GraphSettings)
+
+	// Other users don't see them
+	preferences, err = svc.GetPreferences(ctx, "bob")
+	require.NoError(err)
+	assert.Empty(preferences.NamespaceSets)
+
+	// Replacing the preferences drops the unset ones
+	_, err = svc.SavePreferences(ctx, "alice", models.UserPreferences{RefreshInterval: 15000})
+	require.NoError(err)
+	preferences, err = svc.GetPreferences(ctx, "alice")
+	require.NoError(err)
+	assert.Zero(preferences.Duration)
+	assert.Equal(int64(15000), preferences.RefreshInterval)
+
+	require.NoError(svc.DeletePreferences(ctx, "alice"))
+	require.NoError(svc.DeletePreferences(ctx, "alice"))
+	preferences, err = svc.GetPreferences(ctx, "alice")
+	require.NoError(err)
+	assert.Equal(&models.UserPreferences{}, preferences)
+}
+
+func TestSaveInvalidPreferences(t *testing.T) {
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient()
+	svc := PreferencesService{conf: conf, kialiSAClients: map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}}
+
+	for _, preferences := range []models.UserPreferences{
+		{NamespaceSets: map[string][]string{"bookinfo/prod": {"bookinfo"}}},
+		{NamespaceSets: map[string][]string{"empty": {}}},
+		{Duration: -1},
+	} {
+		_, err := svc.SavePreferences(context.TODO(), "alice", preferences)
+		assert.True(t, errors.IsBadRequest(err))
+	}
+}
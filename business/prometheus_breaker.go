@@ -0,0 +1,85 @@
+package business
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+)
+
+// circuitBreaker stops the queries to an unreachable Prometheus once they failed a number of times in a row, so that
+// every item of a list does not wait for its own query to fail. While open, a single query is let through at every
+// probe interval to find out whether Prometheus is back, which closes the breaker.
+// A nil circuitBreaker lets all the queries through.
+type circuitBreaker struct {
+	failures int
+	mutex    sync.Mutex
+	now      func() time.Time
+	// openedAt is when the breaker opened or when its last probe failed, zero when closed
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{now: time.Now}
+}
+
+// allow returns whether a query can be sent, which is the recovery probe when the breaker is open
+func (cb *circuitBreaker) allow(conf config.CircuitBreaker) bool {
+	if cb == nil || !conf.Enabled {
+		return true
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.openedAt.IsZero() {
+		return true
+	}
+	if cb.probing || cb.now().Sub(cb.openedAt) < time.Duration(conf.ProbeInterval)*time.Second {
+		return false
+	}
+	cb.probing = true
+	return true
+}
+
+// record counts the result of a query let through by allow
+func (cb *circuitBreaker) record(conf config.CircuitBreaker, err error) {
+	if cb == nil || !conf.Enabled {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	wasOpen := !cb.openedAt.IsZero()
+	cb.probing = false
+	if err == nil {
+		cb.failures = 0
+		cb.openedAt = time.Time{}
+		if wasOpen {
+			log.Infof("Prometheus is reachable again, the health includes the request rates")
+		}
+		return
+	}
+
+	cb.failures++
+	if wasOpen {
+		cb.openedAt = cb.now()
+		log.Debugf("Prometheus is still unreachable: %v", err)
+	} else if cb.failures >= conf.FailureThreshold {
+		cb.openedAt = cb.now()
+		log.Warningf("Prometheus failed %d queries in a row, the health is computed without request rates until it is reachable again, probed every %ds: %v", cb.failures, conf.ProbeInterval, err)
+	}
+}
+
+// reset closes the breaker, when Prometheus changed
+func (cb *circuitBreaker) reset() {
+	if cb == nil {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.failures = 0
+	cb.openedAt = time.Time{}
+	cb.probing = false
+}
@@ -0,0 +1,112 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.CircuitBreaker{Enabled: true, FailureThreshold: 2, ProbeInterval: 30}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb := &circuitBreaker{now: func() time.Time { return now }}
+	failure := errors.New("connection refused")
+
+	// A success resets the failures
+	cb.record(conf, failure)
+	cb.record(conf, nil)
+	cb.record(conf, failure)
+	assert.True(cb.allow(conf))
+
+	// Opens after the failures in a row
+	cb.record(conf, failure)
+	assert.False(cb.allow(conf))
+
+	// A single probe once the interval elapsed, which fails
+	now = now.Add(30 * time.Second)
+	assert.True(cb.allow(conf))
+	assert.False(cb.allow(conf))
+	cb.record(conf, failure)
+	assert.False(cb.allow(conf))
+
+	// The next probe succeeds and closes the breaker
+	now = now.Add(30 * time.Second)
+	assert.True(cb.allow(conf))
+	cb.record(conf, nil)
+	assert.True(cb.allow(conf))
+	assert.True(cb.allow(conf))
+
+	// Reset closes the breaker
+	cb.record(conf, failure)
+	cb.record(conf, failure)
+	assert.False(cb.allow(conf))
+	cb.reset()
+	assert.True(cb.allow(conf))
+
+	// Disabled or nil breakers let all the queries through
+	cb.record(conf, failure)
+	cb.record(conf, failure)
+	assert.True(cb.allow(config.CircuitBreaker{}))
+	var nilBreaker *circuitBreaker
+	nilBreaker.record(conf, failure)
+	assert.True(nilBreaker.allow(conf))
+}
+
+func TestGetServiceHealthPrometheusDown(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.CircuitBreaker.FailureThreshold = 2
+	config.Set(conf)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetServiceRequestRates", "ns", conf.KubernetesConfig.ClusterName, "httpbin", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(model.Vector{}, errors.New("connection refused")).Times(3)
+	prom.MockServiceRequestRates("ns", conf.KubernetesConfig.ClusterName, "httpbin", serviceRates)
+	hs := HealthService{prom: prom, breaker: &circuitBreaker{now: func() time.Time { return now }}}
+
+	svc := models.Service{}
+	svc.Name = "httpbin"
+	getHealth := func() (models.ServiceHealth, error) {
+		return hs.GetServiceHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "httpbin", "1m", now, &svc)
+	}
+
+	// The failed queries are reported until the breaker opens
+	for i := 0; i < 2; i++ {
+		health, err := getHealth()
+		require.Error(err)
+		require.True(health.Requests.MetricsUnavailable)
+	}
+
+	// Prometheus is not queried anymore, the health is unknown
+	health, err := getHealth()
+	require.NoError(err)
+	require.True(health.Requests.MetricsUnavailable)
+	prom.AssertNumberOfCalls(t, "GetServiceRequestRates", 2)
+
+	// The recovery probe fails, then the next one succeeds
+	now = now.Add(time.Duration(conf.ExternalServices.Prometheus.CircuitBreaker.ProbeInterval) * time.Second)
+	_, err = getHealth()
+	require.Error(err)
+	now = now.Add(time.Duration(conf.ExternalServices.Prometheus.CircuitBreaker.ProbeInterval) * time.Second)
+	health, err = getHealth()
+	require.NoError(err)
+	require.False(health.Requests.MetricsUnavailable)
+	require.NotEmpty(health.Requests.Inbound)
+
+	health, err = getHealth()
+	require.NoError(err)
+	require.False(health.Requests.MetricsUnavailable)
+	prom.AssertNumberOfCalls(t, "GetServiceRequestRates", 5)
+}
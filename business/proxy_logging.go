@@ -1,9 +1,37 @@
 package business
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const (
+	// ProxyLogLevelResetsConfigMap is the configmap of the Kiali namespace storing the pending resets of the proxy
+	// log levels, one key per workload, so that they survive the restarts of Kiali.
+	ProxyLogLevelResetsConfigMap = "kiali-proxy-log-level-resets"
+	// MaxProxyLogLevelTTL bounds the TTL of the proxy log levels of the workloads.
+	MaxProxyLogLevelTTL = 24 * time.Hour
+	// proxyLogLevelResetInterval is how often the due resets are done.
+	proxyLogLevelResetInterval = 10 * time.Second
+	// defaultProxyLogLevel is the log level of the proxies injected by Istio.
+	defaultProxyLogLevel    = "warning"
+	proxyLogLevelAnnotation = "sidecar.istio.io/logLevel"
 )
 
 // ValidProxyLogLevels are the application log levels supported by the envoy admin interface.
@@ -22,12 +50,23 @@ func IsValidProxyLogLevel(level string) bool {
 
 // ProxyLoggingService is a thin layer over the kube interface for proxy logging functions.
 type ProxyLoggingService struct {
-	userClients map[string]kubernetes.ClientInterface
-	proxyStatus *ProxyStatusService
+	businessLayer  *Layer
+	conf           *config.Config
+	kialiCache     cache.KialiCache
+	kialiSAClients map[string]kubernetes.ClientInterface
+	userClients    map[string]kubernetes.ClientInterface
+	proxyStatus    *ProxyStatusService
 }
 
 // SetLogLevel sets the pod's proxy log level.
 func (in *ProxyLoggingService) SetLogLevel(cluster, namespace, pod, level string) error {
+	if err := checkViewOnlyMode(in.conf, "Changing the proxy log level"); err != nil {
+		return err
+	}
+	if err := checkFeatureEnabled(in.conf, config.FeatureProxyLogLevel); err != nil {
+		return err
+	}
+
 	client, ok := in.userClients[cluster]
 	if !ok {
 		return fmt.Errorf("user client for cluster [%s] not found", cluster)
@@ -40,3 +79,238 @@ func (in *ProxyLoggingService) SetLogLevel(cluster, namespace, pod, level string
 
 	return client.SetProxyLogLevel(namespace, pod, level)
 }
+
+// SetWorkloadLogLevel sets the proxy log level of all the pods of a workload. With a TTL, the log level of each
+// proxy is reset to the level it ran with before, or its configured level when that can't be read, once the TTL
+// expires: the reset is tracked in the cache and stored in a configmap so that a restart of Kiali doesn't lose it.
+// Setting the level again before the reset keeps the levels to reset to and postpones the reset.
+func (in *ProxyLoggingService) SetWorkloadLogLevel(ctx context.Context, cluster, namespace, workload, level string, ttl time.Duration) (*models.WorkloadProxyLogLevel, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "SetWorkloadLogLevel",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+		observability.Attribute("level", level),
+	)
+	defer end()
+
+	if err := checkViewOnlyMode(in.conf, "Changing the proxy log level"); err != nil {
+		return nil, err
+	}
+	if err := checkFeatureEnabled(in.conf, config.FeatureProxyLogLevel); err != nil {
+		return nil, err
+	}
+	if !IsValidProxyLogLevel(level) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("%s is an invalid log level. Valid log levels are: %s", level, strings.Join(ValidProxyLogLevels, ", ")))
+	}
+	if ttl < 0 || ttl > MaxProxyLogLevelTTL {
+		return nil, errors.NewBadRequest(fmt.Sprintf("the ttl of the log level must be positive and can't be longer than %s", MaxProxyLogLevelTTL))
+	}
+
+	client, ok := in.userClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("user client for cluster [%s] not found", cluster)
+	}
+	wk, err := in.businessLayer.Workload.GetWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.WorkloadProxyLogLevel{Level: level, Pods: []string{}}
+	levels := map[string]string{}
+	// On a failure the pods already changed still get their reset, the error is returned once it is stored.
+	var setErr error
+	for _, pod := range wk.Pods {
+		if !pod.HasIstioSidecar() {
+			continue
+		}
+		p, err := client.GetPod(namespace, pod.Name)
+		if err != nil {
+			setErr = err
+			break
+		}
+		current, err := client.GetProxyLogLevel(namespace, pod.Name)
+		if err != nil {
+			log.Debugf("Unable to get the proxy log level of pod [%s/%s], resetting to its configured level: %s", namespace, pod.Name, err)
+			current = configuredProxyLogLevel(p)
+		}
+		if err := client.SetProxyLogLevel(namespace, pod.Name, level); err != nil {
+			setErr = err
+			break
+		}
+		result.Pods = append(result.Pods, pod.Name)
+		levels[pod.Name] = current
+	}
+	if ttl == 0 || len(levels) == 0 {
+		if setErr != nil {
+			return nil, setErr
+		}
+		return result, nil
+	}
+
+	reset := &models.ProxyLogLevelReset{Cluster: cluster, Namespace: namespace, Workload: workload, Levels: levels, ResetAt: time.Now().Add(ttl).UTC()}
+	if pending, found := in.kialiCache.GetProxyLogLevelReset(reset.Key()); found {
+		for pod, level := range pending.Levels {
+			reset.Levels[pod] = level
+		}
+	}
+	if err := storeProxyLogLevelReset(ctx, in.conf, in.kialiSAClients, reset.Key(), reset); err != nil {
+		return nil, err
+	}
+	in.kialiCache.SetProxyLogLevelReset(reset)
+	result.Reset = reset
+
+	if setErr != nil {
+		return nil, setErr
+	}
+	return result, nil
+}
+
+// configuredProxyLogLevel returns the log level the proxy of a pod starts with, from the --proxyLogLevel argument
+// set by the injection, or the logLevel annotation. The levels of the loggers (e.g. misc:error) are ignored.
+func configuredProxyLogLevel(pod *core_v1.Pod) string {
+	configured := pod.Annotations[proxyLogLevelAnnotation]
+	containers := append(append([]core_v1.Container{}, pod.Spec.Containers...), pod.Spec.InitContainers...)
+	for _, c := range containers {
+		if c.Name != models.IstioProxy {
+			continue
+		}
+		for _, arg := range c.Args {
+			if value, found := strings.CutPrefix(arg, "--proxyLogLevel="); found {
+				configured = value
+			}
+		}
+	}
+	for _, level := range strings.Split(configured, ",") {
+		if IsValidProxyLogLevel(level) {
+			return level
+		}
+	}
+	return defaultProxyLogLevel
+}
+
+// storeProxyLogLevelReset stores the reset of a workload in the configmap of the resets, a nil reset removes it.
+// It uses the client of the home cluster.
+func storeProxyLogLevelReset(ctx context.Context, conf *config.Config, kialiSAClients map[string]kubernetes.ClientInterface, key string, reset *models.ProxyLogLevelReset) error {
+	client, ok := kialiSAClients[conf.KubernetesConfig.ClusterName]
+	if !ok {
+		return fmt.Errorf("client for the home cluster [%s] not found", conf.KubernetesConfig.ClusterName)
+	}
+	var data []byte
+	if reset != nil {
+		var err error
+		if data, err = json.Marshal(reset); err != nil {
+			return err
+		}
+	}
+	configMaps := client.Kube().CoreV1().ConfigMaps(conf.Deployment.Namespace)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(ctx, ProxyLogLevelResetsConfigMap, meta_v1.GetOptions{})
+		if errors.IsNotFound(err) {
+			if reset == nil {
+				return nil
+			}
+			configMap = &core_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      ProxyLogLevelResetsConfigMap,
+					Namespace: conf.Deployment.Namespace,
+					Labels:    map[string]string{"app.kubernetes.io/part-of": "kiali"},
+				},
+				Data: map[string]string{key: string(data)},
+			}
+			_, err = configMaps.Create(ctx, configMap, meta_v1.CreateOptions{})
+			if errors.IsAlreadyExists(err) {
+				// Created concurrently, retried as a conflict
+				return errors.NewConflict(core_v1.Resource("configmaps"), ProxyLogLevelResetsConfigMap, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		if reset == nil {
+			if _, found := configMap.Data[key]; !found {
+				return nil
+			}
+			delete(configMap.Data, key)
+		} else {
+			configMap.Data[key] = string(data)
+		}
+		_, err = configMaps.Update(ctx, configMap, meta_v1.UpdateOptions{})
+		return err
+	})
+}
+
+// StartProxyLogLevelResets restores in the cache the pending resets stored before Kiali started, then resets the
+// proxy log levels whose TTL expired until the context is cancelled. The resets use the Kiali service account.
+func StartProxyLogLevelResets(ctx context.Context, conf *config.Config, kialiCache cache.KialiCache, kialiSAClients map[string]kubernetes.ClientInterface) {
+	if client, ok := kialiSAClients[conf.KubernetesConfig.ClusterName]; ok {
+		configMap, err := client.Kube().CoreV1().ConfigMaps(conf.Deployment.Namespace).Get(ctx, ProxyLogLevelResetsConfigMap, meta_v1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+		case err != nil:
+			log.Errorf("Unable to restore the pending resets of the proxy log levels: %s", err)
+		default:
+			for key, data := range configMap.Data {
+				reset := &models.ProxyLogLevelReset{}
+				if err := json.Unmarshal([]byte(data), reset); err != nil {
+					log.Errorf("Ignoring the invalid proxy log level reset [%s] of configmap [%s/%s]: %s", key, configMap.Namespace, configMap.Name, err)
+					continue
+				}
+				kialiCache.SetProxyLogLevelReset(reset)
+			}
+		}
+	}
+
+	go func() {
+		for {
+			resetProxyLogLevels(ctx, conf, kialiCache, kialiSAClients, time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(proxyLogLevelResetInterval):
+			}
+		}
+	}()
+}
+
+// resetProxyLogLevels resets the proxy log levels whose TTL expired. A reset is done once: the proxies of the pods
+// gone in between, or failing to reset, are only logged.
+func resetProxyLogLevels(ctx context.Context, conf *config.Config, kialiCache cache.KialiCache, kialiSAClients map[string]kubernetes.ClientInterface, now time.Time) {
+	for _, reset := range kialiCache.GetProxyLogLevelResets() {
+		if reset.ResetAt.After(now) {
+			continue
+		}
+		client, ok := kialiSAClients[reset.Cluster]
+		if !ok {
+			log.Errorf("Unable to reset the proxy log level of workload [%s/%s]: client for cluster [%s] not found", reset.Namespace, reset.Workload, reset.Cluster)
+		} else {
+			pods := make([]string, 0, len(reset.Levels))
+			for pod := range reset.Levels {
+				pods = append(pods, pod)
+			}
+			sort.Strings(pods)
+			for _, pod := range pods {
+				err := client.SetProxyLogLevel(reset.Namespace, pod, reset.Levels[pod])
+				if err != nil {
+					if _, getErr := client.GetPod(reset.Namespace, pod); errors.IsNotFound(getErr) {
+						continue
+					}
+					log.Errorf("Unable to reset the proxy log level of pod [%s/%s] of cluster [%s]: %s", reset.Namespace, pod, reset.Cluster, err)
+				}
+			}
+			log.Infof("Reset the proxy log level of workload [%s/%s] of cluster [%s]", reset.Namespace, reset.Workload, reset.Cluster)
+		}
+
+		if err := storeProxyLogLevelReset(ctx, conf, kialiSAClients, reset.Key(), nil); err != nil {
+			log.Errorf("Unable to remove the proxy log level reset of workload [%s/%s] of cluster [%s]: %s", reset.Namespace, reset.Workload, reset.Cluster, err)
+		}
+		kialiCache.RemoveProxyLogLevelReset(reset.Key())
+	}
+}
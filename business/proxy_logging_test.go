@@ -0,0 +1,218 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+type proxyLogLevelClient struct {
+	kubernetes.ClientInterface
+	lock   sync.Mutex
+	levels map[string]string
+	// failAfter fails the changes of the log level once that many were done, when set.
+	failAfter int
+	changes   int
+}
+
+func (c *proxyLogLevelClient) GetProxyLogLevel(namespace, pod string) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	level, found := c.levels[namespace+"/"+pod]
+	if !found {
+		return "", fmt.Errorf("proxy of pod [%s/%s] not reachable", namespace, pod)
+	}
+	return level, nil
+}
+
+func (c *proxyLogLevelClient) SetProxyLogLevel(namespace, pod, level string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.failAfter > 0 && c.changes >= c.failAfter {
+		return fmt.Errorf("proxy of pod [%s/%s] not reachable", namespace, pod)
+	}
+	c.changes++
+	c.levels[namespace+"/"+pod] = level
+	return nil
+}
+
+func TestSetWorkloadLogLevelWithTTL(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	kubernetes.SetConfig(t, *conf)
+
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: conf.Deployment.Namespace}},
+		&FakeDepSyncedWithRS()[0],
+	}
+	for _, o := range FakeRSSyncedWithPods() {
+		kubeObjs = append(kubeObjs, &o)
+	}
+	for _, o := range FakePodsSyncedWithDeployments() {
+		o.Labels = map[string]string{"app": "details", "version": "v1"}
+		o.Spec.Containers[1].Args = []string{"proxy", "sidecar", "--proxyLogLevel=error,misc:error"}
+		kubeObjs = append(kubeObjs, &o)
+	}
+	fake := kubetest.NewFakeK8sClient(kubeObjs...)
+	kialiCache := SetupBusinessLayer(t, fake, *conf)
+
+	k8s := &proxyLogLevelClient{ClientInterface: fake, levels: map[string]string{}}
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+
+	result, err := layer.ProxyLogging.SetWorkloadLogLevel(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", "debug", 10*time.Minute)
+	require.NoError(err)
+	assert.Equal([]string{"details-v1-3618568057-dnkjp"}, result.Pods)
+	assert.Equal(map[string]string{"Namespace/details-v1-3618568057-dnkjp": "debug"}, k8s.levels)
+	require.NotNil(result.Reset)
+	assert.Equal(map[string]string{"details-v1-3618568057-dnkjp": "error"}, result.Reset.Levels)
+
+	// Tracked in the cache and stored for the restarts
+	reset, found := kialiCache.GetProxyLogLevelReset(result.Reset.Key())
+	require.True(found)
+	assert.Equal(result.Reset, reset)
+	configMap, err := fake.Kube().CoreV1().ConfigMaps(conf.Deployment.Namespace).Get(context.TODO(), ProxyLogLevelResetsConfigMap, meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Contains(configMap.Data, result.Reset.Key())
+
+	// Setting the level again keeps the levels to reset to
+	_, err = layer.ProxyLogging.SetWorkloadLogLevel(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", "trace", 10*time.Minute)
+	require.NoError(err)
+	reset, _ = kialiCache.GetProxyLogLevelReset(result.Reset.Key())
+	assert.Equal(map[string]string{"details-v1-3618568057-dnkjp": "error"}, reset.Levels)
+
+	// Not due yet
+	resetProxyLogLevels(context.TODO(), conf, kialiCache, clients, time.Now())
+	assert.Equal("trace", k8s.levels["Namespace/details-v1-3618568057-dnkjp"])
+
+	resetProxyLogLevels(context.TODO(), conf, kialiCache, clients, time.Now().Add(time.Hour))
+	assert.Equal("error", k8s.levels["Namespace/details-v1-3618568057-dnkjp"])
+	assert.Empty(kialiCache.GetProxyLogLevelResets())
+	configMap, err = fake.Kube().CoreV1().ConfigMaps(conf.Deployment.Namespace).Get(context.TODO(), ProxyLogLevelResetsConfigMap, meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Empty(configMap.Data)
+
+	// The level the proxy runs with is restored rather than the configured one
+	k8s.levels["Namespace/details-v1-3618568057-dnkjp"] = "info"
+	result, err = layer.ProxyLogging.SetWorkloadLogLevel(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", "debug", 10*time.Minute)
+	require.NoError(err)
+	assert.Equal(map[string]string{"details-v1-3618568057-dnkjp": "info"}, result.Reset.Levels)
+	resetProxyLogLevels(context.TODO(), conf, kialiCache, clients, time.Now().Add(time.Hour))
+	assert.Equal("info", k8s.levels["Namespace/details-v1-3618568057-dnkjp"])
+
+	_, err = layer.ProxyLogging.SetWorkloadLogLevel(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", "debug", 48*time.Hour)
+	assert.True(api_errors.IsBadRequest(err))
+	_, err = layer.ProxyLogging.SetWorkloadLogLevel(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", "verbose", 0)
+	assert.True(api_errors.IsBadRequest(err))
+}
+
+func TestSetWorkloadLogLevelStoresResetOfChangedPodsOnFailure(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	kubernetes.SetConfig(t, *conf)
+
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: conf.Deployment.Namespace}},
+		&FakeDepSyncedWithRS()[0],
+	}
+	for _, o := range FakeRSSyncedWithPods() {
+		kubeObjs = append(kubeObjs, &o)
+	}
+	pod := FakePodsSyncedWithDeployments()[0]
+	pod.Labels = map[string]string{"app": "details", "version": "v1"}
+	for _, name := range []string{"details-v1-3618568057-aaaaa", "details-v1-3618568057-bbbbb"} {
+		p := pod.DeepCopy()
+		p.Name = name
+		kubeObjs = append(kubeObjs, p)
+	}
+	fake := kubetest.NewFakeK8sClient(kubeObjs...)
+	kialiCache := SetupBusinessLayer(t, fake, *conf)
+
+	k8s := &proxyLogLevelClient{ClientInterface: fake, levels: map[string]string{}, failAfter: 1}
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+
+	_, err := layer.ProxyLogging.SetWorkloadLogLevel(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", "debug", 10*time.Minute)
+	require.Error(err)
+
+	// The pod changed before the failure is reset to its configured level, its proxy level could not be read
+	require.Len(k8s.levels, 1)
+	resets := kialiCache.GetProxyLogLevelResets()
+	require.Len(resets, 1)
+	assert.Len(resets[0].Levels, 1)
+	for pod, level := range resets[0].Levels {
+		assert.Equal("debug", k8s.levels["Namespace/"+pod])
+		assert.Equal("warning", level)
+	}
+	configMap, err := fake.Kube().CoreV1().ConfigMaps(conf.Deployment.Namespace).Get(context.TODO(), ProxyLogLevelResetsConfigMap, meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Contains(configMap.Data, resets[0].Key())
+}
+
+func TestStartProxyLogLevelResetsRestoresPendingResets(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	configMap := &core_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{Name: ProxyLogLevelResetsConfigMap, Namespace: conf.Deployment.Namespace},
+		Data: map[string]string{
+			"east.bookinfo.reviews-v1": `{"cluster":"east","namespace":"bookinfo","workload":"reviews-v1","levels":{"reviews-v1-abcde":"warning"},"resetAt":"2100-01-31T10:15:00Z"}`,
+			"invalid":                  `{`,
+		},
+	}
+	fake := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: conf.Deployment.Namespace}}, configMap)
+	kialiCache := SetupBusinessLayer(t, fake, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: fake}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	StartProxyLogLevelResets(ctx, conf, kialiCache, clients)
+
+	resets := kialiCache.GetProxyLogLevelResets()
+	require.Len(resets, 1)
+	assert.Equal(&models.ProxyLogLevelReset{
+		Cluster:   "east",
+		Namespace: "bookinfo",
+		Workload:  "reviews-v1",
+		Levels:    map[string]string{"reviews-v1-abcde": "warning"},
+		ResetAt:   time.Date(2100, 1, 31, 10, 15, 0, 0, time.UTC),
+	}, resets[0])
+}
+
+func TestConfiguredProxyLogLevel(t *testing.T) {
+	assert := assert.New(t)
+
+	pod := &core_v1.Pod{Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "istio-proxy"}}}}
+	assert.Equal("warning", configuredProxyLogLevel(pod))
+
+	pod.Annotations = map[string]string{"sidecar.istio.io/logLevel": "info"}
+	assert.Equal("info", configuredProxyLogLevel(pod))
+
+	pod.Spec.Containers[0].Args = []string{"--proxyLogLevel=misc:error,debug"}
+	assert.Equal("debug", configuredProxyLogLevel(pod))
+}
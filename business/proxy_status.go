@@ -2,7 +2,9 @@ package business
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/cache"
@@ -10,9 +12,10 @@ import (
 )
 
 type ProxyStatusService struct {
-	kialiCache     cache.KialiCache
-	kialiSAClients map[string]kubernetes.ClientInterface
-	businessLayer  *Layer
+	kialiCache          cache.KialiCache
+	kialiSAClients      map[string]kubernetes.ClientInterface
+	businessLayer       *Layer
+	controlPlaneMonitor ControlPlaneMonitor
 }
 
 func (in *ProxyStatusService) GetPodProxyStatus(cluster, ns, pod string) *models.ProxyStatus {
@@ -106,3 +109,179 @@ func buildDump(dump *kubernetes.ConfigDump, resource string, namespaces []models
 
 	return response, err
 }
+
+// GetProxyStatusDiff compares the config that istiod pushes to the proxy of a pod with the config the proxy
+// is actually running to tell what specifically is out of sync. Endpoints are not part of the config dumps
+// so EDS is only reported through its nonces.
+func (in *ProxyStatusService) GetProxyStatusDiff(ctx context.Context, cluster, namespace, pod string) (*models.ProxyStatusDiff, error) {
+	if _, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	ps := in.kialiCache.GetPodProxyStatus(cluster, namespace, pod)
+	if ps == nil {
+		return nil, kubernetes.NewNotFound(pod, "Kiali", "ProxyStatus")
+	}
+
+	proxyClient, ok := in.kialiSAClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster [%s] not found", cluster)
+	}
+
+	controlPlanes, err := in.pilotControlPlanes(ctx, ps.Pilot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the istiod the proxy is connected to answers for it, the first controlplane that does is kept.
+	var resp []byte
+	for _, controlPlane := range controlPlanes {
+		istiodClient, ok := in.kialiSAClients[controlPlane.Cluster.Name]
+		if !ok {
+			err = fmt.Errorf("cluster [%s] not found", controlPlane.Cluster.Name)
+			continue
+		}
+		if resp, err = in.controlPlaneMonitor.GetProxyConfigDump(istiodClient, &controlPlane, ps.Pilot, pod+"."+namespace); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the config of proxy [%s.%s] from istiod [%s]: %s", pod, namespace, ps.Pilot, err)
+	}
+	istiodDump := &kubernetes.ConfigDump{}
+	if err := json.Unmarshal(resp, istiodDump); err != nil {
+		return nil, fmt.Errorf("unable to parse the config of proxy [%s.%s] from istiod [%s]: %s", pod, namespace, ps.Pilot, err)
+	}
+
+	proxyDump, err := proxyClient.GetConfigDump(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffConfigDumps(ps, istiodDump, proxyDump)
+}
+
+// pilotControlPlanes returns the controlplane of the istiod pod that pushes the config of a proxy. When istiod is
+// reached through its service or url, the pilot is not a pod and every controlplane of the mesh is returned.
+func (in *ProxyStatusService) pilotControlPlanes(ctx context.Context, pilot string) ([]models.ControlPlane, error) {
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, controlPlane := range mesh.ControlPlanes {
+		kubeCache, err := in.kialiCache.GetKubeCache(controlPlane.Cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		istiods, err := kubeCache.GetPods(controlPlane.IstiodNamespace, "app=istiod")
+		if err != nil {
+			return nil, err
+		}
+		for _, istiod := range istiods {
+			if istiod.Name == pilot {
+				return []models.ControlPlane{controlPlane}, nil
+			}
+		}
+	}
+
+	if len(mesh.ControlPlanes) == 0 {
+		return nil, kubernetes.NewNotFound(pilot, "Kiali", "Istiod")
+	}
+	return mesh.ControlPlanes, nil
+}
+
+func diffConfigDumps(ps *kubernetes.ProxyStatus, istiodDump, proxyDump *kubernetes.ConfigDump) (*models.ProxyStatusDiff, error) {
+	istiodResources, err := dynamicResourceNames(istiodDump)
+	if err != nil {
+		return nil, err
+	}
+	proxyResources, err := dynamicResourceNames(proxyDump)
+	if err != nil {
+		return nil, err
+	}
+
+	xdsDiff := func(xdsType, sent, acked string) models.XdsDiff {
+		missing, unexpected := diffNames(istiodResources[xdsType], proxyResources[xdsType])
+		return models.XdsDiff{
+			Type:       xdsType,
+			Status:     xdsStatus(sent, acked),
+			SentNonce:  sent,
+			AckedNonce: acked,
+			Missing:    missing,
+			Unexpected: unexpected,
+		}
+	}
+
+	return &models.ProxyStatusDiff{
+		Pilot: ps.Pilot,
+		Types: []models.XdsDiff{
+			xdsDiff("CDS", ps.ClusterSent, ps.ClusterAcked),
+			xdsDiff("LDS", ps.ListenerSent, ps.ListenerAcked),
+			xdsDiff("RDS", ps.RouteSent, ps.RouteAcked),
+			xdsDiff("EDS", ps.EndpointSent, ps.EndpointAcked),
+		},
+	}, nil
+}
+
+// dynamicResourceNames returns the names of the clusters, listeners and routes pushed through xDS by xDS type.
+// Static resources come from the bootstrap config and are never pushed by istiod.
+func dynamicResourceNames(dump *kubernetes.ConfigDump) (map[string][]string, error) {
+	names := map[string][]string{}
+
+	clusters, err := dump.GetClusters()
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters.DynamicClusters {
+		names["CDS"] = append(names["CDS"], cluster.Cluster.Name)
+	}
+
+	listeners, err := dump.GetListeners()
+	if err != nil {
+		return nil, err
+	}
+	for _, listener := range listeners.DynamicListeners {
+		names["LDS"] = append(names["LDS"], listener.Name)
+	}
+
+	routes, err := dump.GetRoutes()
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range routes.DynamicRouteConfigs {
+		if route.RouteConfig != nil {
+			names["RDS"] = append(names["RDS"], route.RouteConfig.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// diffNames returns the sorted names only expected and the sorted names only actually present.
+func diffNames(expected, actual []string) ([]string, []string) {
+	expectedSet := make(map[string]bool, len(expected))
+	for _, name := range expected {
+		expectedSet[name] = true
+	}
+	actualSet := make(map[string]bool, len(actual))
+	for _, name := range actual {
+		actualSet[name] = true
+	}
+
+	missing, unexpected := []string{}, []string{}
+	for name := range expectedSet {
+		if !actualSet[name] {
+			missing = append(missing, name)
+		}
+	}
+	for name := range actualSet {
+		if !expectedSet[name] {
+			unexpected = append(unexpected, name)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unexpected)
+
+	return missing, unexpected
+}
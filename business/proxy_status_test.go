@@ -0,0 +1,211 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeConfigDump(t *testing.T, clusters, listeners, routes []string) *kubernetes.ConfigDump {
+	t.Helper()
+
+	dump := &kubernetes.ConfigDump{}
+	require.NoError(t, json.Unmarshal(fakeConfigDumpJSON(t, clusters, listeners, routes), dump))
+	return dump
+}
+
+func fakeConfigDumpJSON(t *testing.T, clusters, listeners, routes []string) []byte {
+	t.Helper()
+
+	dynamicClusters := []map[string]interface{}{}
+	for _, name := range clusters {
+		dynamicClusters = append(dynamicClusters, map[string]interface{}{"cluster": map[string]interface{}{"name": name}})
+	}
+	dynamicListeners := []map[string]interface{}{}
+	for _, name := range listeners {
+		dynamicListeners = append(dynamicListeners, map[string]interface{}{"name": name})
+	}
+	dynamicRoutes := []map[string]interface{}{}
+	for _, name := range routes {
+		dynamicRoutes = append(dynamicRoutes, map[string]interface{}{"route_config": map[string]interface{}{"name": name}})
+	}
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"configs": []map[string]interface{}{
+			{
+				"@type":                   "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+				"static_clusters":         []map[string]interface{}{{"cluster": map[string]interface{}{"name": "prometheus_stats"}}},
+				"dynamic_active_clusters": dynamicClusters,
+			},
+			{"@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump", "dynamic_listeners": dynamicListeners},
+			{"@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump", "dynamic_route_configs": dynamicRoutes},
+		},
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+func TestDiffConfigDumps(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ps := &kubernetes.ProxyStatus{
+		Pilot: "istiod-123",
+		SyncStatus: kubernetes.SyncStatus{
+			ClusterSent:   "c2",
+			ClusterAcked:  "c1",
+			ListenerSent:  "l1",
+			ListenerAcked: "l1",
+			RouteSent:     "r1",
+		},
+	}
+	istiodDump := fakeConfigDump(t,
+		[]string{"outbound|9080||reviews.bookinfo.svc.cluster.local", "outbound|9080||ratings.bookinfo.svc.cluster.local"},
+		[]string{"0.0.0.0_9080"},
+		[]string{"9080"},
+	)
+	proxyDump := fakeConfigDump(t,
+		[]string{"outbound|9080||reviews.bookinfo.svc.cluster.local", "outbound|9080||details.bookinfo.svc.cluster.local"},
+		[]string{"0.0.0.0_9080"},
+		[]string{},
+	)
+
+	diff, err := diffConfigDumps(ps, istiodDump, proxyDump)
+	require.NoError(err)
+
+	assert.Equal("istiod-123", diff.Pilot)
+	assert.Equal([]models.XdsDiff{
+		{Type: "CDS", Status: "Stale", SentNonce: "c2", AckedNonce: "c1", Missing: []string{"outbound|9080||ratings.bookinfo.svc.cluster.local"}, Unexpected: []string{"outbound|9080||details.bookinfo.svc.cluster.local"}},
+		{Type: "LDS", Status: "Synced", SentNonce: "l1", AckedNonce: "l1", Missing: []string{}, Unexpected: []string{}},
+		{Type: "RDS", Status: "Stale (Never Acknowledged)", SentNonce: "r1", Missing: []string{"9080"}, Unexpected: []string{}},
+		{Type: "EDS", Status: "NOT_SENT", Missing: []string{}, Unexpected: []string{}},
+	}, diff.Types)
+}
+
+type configDumpClient struct {
+	kubernetes.ClientInterface
+	dump *kubernetes.ConfigDump
+}
+
+func (c *configDumpClient) GetConfigDump(namespace, podName string) (*kubernetes.ConfigDump, error) {
+	return c.dump, nil
+}
+
+// istiodProxyConfigServer serves the config of a proxy after answering 404 to the first requests, like istiods
+// the proxy is not connected to behind a load balancer.
+func istiodProxyConfigServer(t *testing.T, notConnected int) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	proxyIDs := []string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/debug/config_dump" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		proxyIDs = append(proxyIDs, r.URL.Query().Get("proxyID"))
+		if len(proxyIDs) <= notConnected {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(fakeConfigDumpJSON(t, []string{"outbound|9080||reviews"}, []string{"virtualInbound"}, []string{"9080"}))
+	}))
+	t.Cleanup(testServer.Close)
+	return testServer, &proxyIDs
+}
+
+// getProxyStatusDiff gets the diff of the reviews-v1-123 proxy, which has not received the listener yet. Istiod is
+// reached through the controlplane monitor of the config, the fake client can't port forward.
+func getProxyStatusDiff(t *testing.T, conf *config.Config, istiods ...runtime.Object) (*models.ProxyStatusDiff, error) {
+	t.Helper()
+
+	kubernetes.SetConfig(t, *conf)
+	objects := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}},
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, false),
+	}
+	k8s := kubetest.NewFakeK8sClient(append(objects, istiods...)...)
+	kialiCache := SetupBusinessLayer(t, k8s, *conf)
+	WithControlPlaneMonitor(NewControlPlaneMonitor(kialiCache, nil, *conf, nil))
+	kialiCache.SetPodProxyStatus([]*kubernetes.ProxyStatus{{
+		Pilot:      "remote",
+		SyncStatus: kubernetes.SyncStatus{ClusterID: conf.KubernetesConfig.ClusterName, ProxyID: "reviews-v1-123.bookinfo", ClusterSent: "c1", ClusterAcked: "c1"},
+	}})
+
+	proxyClient := &configDumpClient{ClientInterface: k8s, dump: fakeConfigDump(t, []string{"outbound|9080||reviews"}, nil, []string{"9080"})}
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: proxyClient}
+	return NewWithBackends(clients, clients, nil, nil).ProxyStatus.GetProxyStatusDiff(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1-123")
+}
+
+func assertMissingListener(t *testing.T, diff *models.ProxyStatusDiff) {
+	t.Helper()
+
+	missing := map[string][]string{}
+	for _, xds := range diff.Types {
+		missing[xds.Type] = xds.Missing
+	}
+	assert.Equal(t, []string{"virtualInbound"}, missing["LDS"])
+	assert.Empty(t, missing["CDS"])
+}
+
+func TestGetProxyStatusDiffFromIstiodURL(t *testing.T) {
+	require := require.New(t)
+
+	// The url is load balanced over two istiods the proxy is not connected to before reaching its own
+	testServer, proxyIDs := istiodProxyConfigServer(t, 2)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.Registry = &config.RegistryConfig{
+		AccessMode: config.IstiodAccessModeURL,
+		IstiodURL:  testServer.URL,
+	}
+
+	diff, err := getProxyStatusDiff(t, conf, runningIstiodPod())
+	require.NoError(err)
+
+	assert.Equal(t, []string{"reviews-v1-123.bookinfo", "reviews-v1-123.bookinfo", "reviews-v1-123.bookinfo"}, *proxyIDs)
+	assert.Equal(t, "remote", diff.Pilot)
+	assertMissingListener(t, diff)
+}
+
+func TestGetProxyStatusDiffFromIstiodPods(t *testing.T) {
+	require := require.New(t)
+
+	testServer, proxyIDs := istiodProxyConfigServer(t, 1)
+	serverURL, err := url.Parse(testServer.URL)
+	require.NoError(err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(err)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.Registry = &config.RegistryConfig{AccessMode: config.IstiodAccessModeService}
+	conf.ExternalServices.Istio.IstiodPodMonitoringPort = port
+
+	// The request is sent to each istiod pod instead of the load balanced service until one knows the proxy
+	istiodPod := func(name string) *core_v1.Pod {
+		pod := runningIstiodPod()
+		pod.Name = name
+		pod.Status.PodIP = serverURL.Hostname()
+		return pod
+	}
+	diff, err := getProxyStatusDiff(t, conf, istiodPod("istiod-1"), istiodPod("istiod-2"))
+	require.NoError(err)
+
+	assert.Len(t, *proxyIDs, 2)
+	assertMissingListener(t, diff)
+}
@@ -0,0 +1,411 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const (
+	// MaxProxyTapDuration bounds the duration of a capture, the admin connection of the proxy is held meanwhile.
+	MaxProxyTapDuration = 60 * time.Second
+	// maxProxyTapTraces bounds the traces returned by a capture.
+	maxProxyTapTraces = 500
+	// proxyTapConfigID is the id of the tap filter added to the proxy, matched by the tap requests.
+	proxyTapConfigID = "kiali-tap"
+	// proxyTapLabel is the label added to the captured pod for the duration of the capture, selecting its proxy only.
+	proxyTapLabel = "kiali.io/proxy-tap"
+	// proxyTapReadyTimeout is how long the tap filter has to reach the proxy.
+	proxyTapReadyTimeout  = 15 * time.Second
+	proxyTapRetryInterval = time.Second
+	// proxyTapSweepInterval is how often the tap EnvoyFilters and pod labels left by an interrupted capture are removed.
+	proxyTapSweepInterval = 5 * time.Minute
+	// proxyTapMaxAge is the age after which a tap EnvoyFilter outlived any capture.
+	proxyTapMaxAge = MaxProxyTapDuration + proxyTapReadyTimeout + time.Minute
+	// proxyTapUnknownConfigID is the error of the admin endpoint while the tap filter is not in the proxy config.
+	proxyTapUnknownConfigID = "Unknown config id"
+)
+
+// proxyTapRedactedHeaders carry credentials and are not returned.
+var proxyTapRedactedHeaders = map[string]bool{
+	"authorization":       true,
+	"cookie":              true,
+	"proxy-authorization": true,
+	"set-cookie":          true,
+}
+
+// ProxyTapCriteria selects the pod whose requests are captured, for how long, and optionally the status of the
+// captured responses.
+type ProxyTapCriteria struct {
+	Cluster   string
+	Namespace string
+	Pod       string
+	Duration  time.Duration
+	// Status is a prefix of the status of the captured responses, e.g. 503 or 5 for all the server errors
+	Status string
+}
+
+// ProxyTapService captures the requests going through the proxy of a pod with the Envoy tap filter, without
+// exec'ing into the pod. The filter is added by an EnvoyFilter for the duration of the capture only, created and
+// deleted with the permissions of the user. The EnvoyFilter selects a label added to the pod meanwhile: the other
+// replicas of the workload don't get the listener update and drain the inserting of the filter causes.
+type ProxyTapService struct {
+	conf        *config.Config
+	userClients map[string]kubernetes.ClientInterface
+}
+
+// Capture enables the tap filter on the proxy of a pod and returns the metadata of the requests it captured during
+// the duration of the criteria.
+func (in *ProxyTapService) Capture(ctx context.Context, criteria ProxyTapCriteria) (*models.ProxyTapCapture, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "Capture",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("namespace", criteria.Namespace),
+		observability.Attribute("pod", criteria.Pod),
+	)
+	defer end()
+
+	if err := checkViewOnlyMode(in.conf, "Capturing the requests of a proxy"); err != nil {
+		return nil, err
+	}
+	if criteria.Duration <= 0 || criteria.Duration > MaxProxyTapDuration {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("the duration of the capture must be positive and can't be longer than %s", MaxProxyTapDuration))
+	}
+
+	client, ok := in.userClients[criteria.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("user client for cluster [%s] not found", criteria.Cluster)
+	}
+	pod, err := client.GetPod(criteria.Namespace, criteria.Pod)
+	if err != nil {
+		return nil, err
+	}
+	p := models.Pod{}
+	p.Parse(pod)
+	if !p.HasIstioSidecar() {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("pod [%s] of namespace [%s] has no proxy", criteria.Pod, criteria.Namespace))
+	}
+
+	tapLabel := proxyTapLabelValue(criteria.Pod)
+	envoyFilter, err := proxyTapEnvoyFilter(criteria.Namespace, criteria.Pod, tapLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pod is only labeled while the EnvoyFilter exists, for the sweep of the captures interrupted by a restart
+	envoyFilters := client.Istio().NetworkingV1alpha3().EnvoyFilters(criteria.Namespace)
+	if _, err := envoyFilters.Create(ctx, envoyFilter, meta_v1.CreateOptions{}); err != nil {
+		if api_errors.IsAlreadyExists(err) {
+			return nil, api_errors.NewConflict(networking_v1alpha3.Resource("envoyfilters"), envoyFilter.Name, fmt.Errorf("a capture of pod [%s] is already running", criteria.Pod))
+		}
+		return nil, err
+	}
+	defer func() {
+		// Deleted even when the request is cancelled
+		if err := envoyFilters.Delete(context.Background(), envoyFilter.Name, meta_v1.DeleteOptions{}); err != nil && !api_errors.IsNotFound(err) {
+			log.Errorf("Unable to delete the tap EnvoyFilter [%s/%s]: %s", criteria.Namespace, envoyFilter.Name, err)
+		}
+	}()
+
+	pods := client.Kube().CoreV1().Pods(criteria.Namespace)
+	if _, err := pods.Patch(ctx, criteria.Pod, types.MergePatchType, proxyTapLabelPatch(tapLabel), meta_v1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// Removed even when the request is cancelled
+		if _, err := pods.Patch(context.Background(), criteria.Pod, types.MergePatchType, proxyTapLabelPatch(nil), meta_v1.PatchOptions{}); err != nil && !api_errors.IsNotFound(err) {
+			log.Errorf("Unable to remove the tap label of pod [%s/%s]: %s", criteria.Namespace, criteria.Pod, err)
+		}
+	}()
+
+	tapRequest, err := json.Marshal(map[string]interface{}{
+		"config_id": proxyTapConfigID,
+		"tap_config": map[string]interface{}{
+			"match": map[string]interface{}{"any_match": true},
+			"output_config": map[string]interface{}{
+				"sinks": []interface{}{map[string]interface{}{"format": "JSON_BODY_AS_STRING", "streaming_admin": map[string]interface{}{}}},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The tap filter reaches the proxy once istiod pushed the EnvoyFilter
+	var output []byte
+	deadline := time.Now().Add(proxyTapReadyTimeout)
+	for {
+		output, err = client.TapProxy(criteria.Namespace, criteria.Pod, tapRequest, criteria.Duration)
+		if err == nil || !strings.Contains(err.Error(), proxyTapUnknownConfigID) || time.Now().After(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(proxyTapRetryInterval):
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	capture := &models.ProxyTapCapture{
+		Cluster:   criteria.Cluster,
+		Namespace: criteria.Namespace,
+		Pod:       criteria.Pod,
+		Duration:  int(criteria.Duration.Seconds()),
+		Traces:    []models.ProxyTapTrace{},
+	}
+	traces, err := parseProxyTapTraces(output)
+	if err != nil {
+		log.Debugf("Ignoring the traces of pod [%s/%s] after an invalid trace: %s", criteria.Namespace, criteria.Pod, err)
+	}
+	for _, trace := range traces {
+		if !strings.HasPrefix(trace.Status, criteria.Status) {
+			continue
+		}
+		if len(capture.Traces) == maxProxyTapTraces {
+			capture.Truncated = true
+			break
+		}
+		capture.Traces = append(capture.Traces, trace)
+	}
+	return capture, nil
+}
+
+// StartProxyTapSweeps periodically removes the tap EnvoyFilters and pod labels of the captures interrupted by a
+// restart of Kiali, until the context is cancelled. They are removed with the Kiali service account.
+func StartProxyTapSweeps(ctx context.Context, conf *config.Config, kialiSAClients map[string]kubernetes.ClientInterface) {
+	// No capture is done in view-only mode
+	if conf.Deployment.ViewOnlyMode {
+		return
+	}
+
+	go func() {
+		for {
+			sweepProxyTaps(ctx, kialiSAClients, time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(proxyTapSweepInterval):
+			}
+		}
+	}()
+}
+
+// sweepProxyTaps deletes the tap EnvoyFilters older than any capture and removes the tap label of the pods without
+// a running capture. The pods are listed before the EnvoyFilters: a pod labeled meanwhile has its EnvoyFilter listed.
+func sweepProxyTaps(ctx context.Context, kialiSAClients map[string]kubernetes.ClientInterface, now time.Time) {
+	listOptions := meta_v1.ListOptions{LabelSelector: proxyTapLabel}
+	for cluster, client := range kialiSAClients {
+		pods, err := client.Kube().CoreV1().Pods(meta_v1.NamespaceAll).List(ctx, listOptions)
+		if err != nil {
+			log.Debugf("Unable to list the tapped pods of cluster [%s]: %s", cluster, err)
+			continue
+		}
+		envoyFilters, err := client.Istio().NetworkingV1alpha3().EnvoyFilters(meta_v1.NamespaceAll).List(ctx, listOptions)
+		if err != nil {
+			log.Debugf("Unable to list the tap EnvoyFilters of cluster [%s]: %s", cluster, err)
+			continue
+		}
+
+		running := map[string]bool{}
+		for _, ef := range envoyFilters.Items {
+			if now.Sub(ef.CreationTimestamp.Time) < proxyTapMaxAge {
+				running[ef.Namespace+"/"+ef.Labels[proxyTapLabel]] = true
+				continue
+			}
+			if err := client.Istio().NetworkingV1alpha3().EnvoyFilters(ef.Namespace).Delete(ctx, ef.Name, meta_v1.DeleteOptions{}); err != nil && !api_errors.IsNotFound(err) {
+				log.Errorf("Unable to delete the tap EnvoyFilter [%s/%s] of cluster [%s]: %s", ef.Namespace, ef.Name, cluster, err)
+				continue
+			}
+			log.Infof("Deleted the tap EnvoyFilter [%s/%s] of cluster [%s] left by an interrupted capture", ef.Namespace, ef.Name, cluster)
+		}
+
+		for _, pod := range pods.Items {
+			if running[pod.Namespace+"/"+pod.Labels[proxyTapLabel]] {
+				continue
+			}
+			if _, err := client.Kube().CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, proxyTapLabelPatch(nil), meta_v1.PatchOptions{}); err != nil && !api_errors.IsNotFound(err) {
+				log.Errorf("Unable to remove the tap label of pod [%s/%s] of cluster [%s]: %s", pod.Namespace, pod.Name, cluster, err)
+				continue
+			}
+			log.Infof("Removed the tap label of pod [%s/%s] of cluster [%s] left by an interrupted capture", pod.Namespace, pod.Name, cluster)
+		}
+	}
+}
+
+// proxyTapLabelPatch returns the merge patch setting the tap label of a pod, or removing it when the value is nil.
+func proxyTapLabelPatch(value interface{}) []byte {
+	patch, _ := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{proxyTapLabel: value}}})
+	return patch
+}
+
+// proxyTapLabelValue is the value of the tap label of a pod: its name, or a hash of it when it is too long for a
+// label value.
+func proxyTapLabelValue(pod string) string {
+	if len(validation.IsValidLabelValue(pod)) == 0 {
+		return pod
+	}
+	sum := sha256.Sum256([]byte(pod))
+	return hex.EncodeToString(sum[:16])
+}
+
+// proxyTapEnvoyFilter returns the EnvoyFilter adding the tap filter, controlled by the admin endpoint, before the
+// router of the http listeners of the proxy selected by the tap label of the pod. The EnvoyFilter has the tap label
+// too, for the sweep.
+func proxyTapEnvoyFilter(namespace, pod string, tapLabel string) (*networking_v1alpha3.EnvoyFilter, error) {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"name": "envoy.filters.http.tap",
+		"typed_config": map[string]interface{}{
+			"@type": "type.googleapis.com/envoy.extensions.filters.http.tap.v3.Tap",
+			"common_config": map[string]interface{}{
+				"admin_config": map[string]interface{}{"config_id": proxyTapConfigID},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := "kiali-tap-" + pod
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return &networking_v1alpha3.EnvoyFilter{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "kiali", proxyTapLabel: tapLabel},
+		},
+		Spec: api_networking_v1alpha3.EnvoyFilter{
+			WorkloadSelector: &api_networking_v1alpha3.WorkloadSelector{Labels: map[string]string{proxyTapLabel: tapLabel}},
+			ConfigPatches: []*api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{{
+				ApplyTo: api_networking_v1alpha3.EnvoyFilter_HTTP_FILTER,
+				Match: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+					ObjectTypes: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+						Listener: &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{
+							FilterChain: &api_networking_v1alpha3.EnvoyFilter_ListenerMatch_FilterChainMatch{
+								Filter: &api_networking_v1alpha3.EnvoyFilter_ListenerMatch_FilterMatch{
+									Name:      "envoy.filters.network.http_connection_manager",
+									SubFilter: &api_networking_v1alpha3.EnvoyFilter_ListenerMatch_SubFilterMatch{Name: "envoy.filters.http.router"},
+								},
+							},
+						},
+					},
+				},
+				Patch: &api_networking_v1alpha3.EnvoyFilter_Patch{
+					Operation: api_networking_v1alpha3.EnvoyFilter_Patch_INSERT_BEFORE,
+					Value:     value,
+				},
+			}},
+		},
+	}, nil
+}
+
+type proxyTapHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type proxyTapMessage struct {
+	Headers  []proxyTapHeader `json:"headers"`
+	Trailers []proxyTapHeader `json:"trailers"`
+}
+
+type proxyTapAddress struct {
+	SocketAddress *struct {
+		Address   string `json:"address"`
+		PortValue int    `json:"port_value"`
+	} `json:"socket_address"`
+}
+
+func (a *proxyTapAddress) String() string {
+	if a == nil || a.SocketAddress == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", a.SocketAddress.Address, a.SocketAddress.PortValue)
+}
+
+// proxyTapTraceWrapper is a trace of the streaming admin sink of the Envoy tap, in JSON.
+type proxyTapTraceWrapper struct {
+	HttpBufferedTrace *struct {
+		Request              proxyTapMessage `json:"request"`
+		Response             proxyTapMessage `json:"response"`
+		DownstreamConnection *struct {
+			LocalAddress  *proxyTapAddress `json:"local_address"`
+			RemoteAddress *proxyTapAddress `json:"remote_address"`
+		} `json:"downstream_connection"`
+	} `json:"http_buffered_trace"`
+}
+
+// parseProxyTapTraces parses the traces streamed by the admin endpoint, one JSON object after the other. The
+// traces parsed before an error are returned with it, the last one can be cut by the end of the capture.
+func parseProxyTapTraces(output []byte) ([]models.ProxyTapTrace, error) {
+	traces := []models.ProxyTapTrace{}
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		wrapper := proxyTapTraceWrapper{}
+		if err := decoder.Decode(&wrapper); err != nil {
+			if err == io.EOF {
+				return traces, nil
+			}
+			return traces, err
+		}
+		buffered := wrapper.HttpBufferedTrace
+		if buffered == nil {
+			continue
+		}
+
+		trace := models.ProxyTapTrace{
+			RequestHeaders:  proxyTapHeaders(buffered.Request.Headers),
+			ResponseHeaders: proxyTapHeaders(buffered.Response.Headers),
+		}
+		if len(buffered.Response.Trailers) > 0 {
+			trace.ResponseTrailers = proxyTapHeaders(buffered.Response.Trailers)
+		}
+		trace.Method = trace.RequestHeaders[":method"]
+		trace.Authority = trace.RequestHeaders[":authority"]
+		trace.Path = trace.RequestHeaders[":path"]
+		trace.Status = trace.ResponseHeaders[":status"]
+		if conn := buffered.DownstreamConnection; conn != nil {
+			trace.SourceAddress = conn.RemoteAddress.String()
+			trace.DestinationAddress = conn.LocalAddress.String()
+		}
+		traces = append(traces, trace)
+	}
+}
+
+func proxyTapHeaders(headers []proxyTapHeader) map[string]string {
+	result := make(map[string]string, len(headers))
+	for _, header := range headers {
+		name := strings.ToLower(header.Key)
+		if proxyTapRedactedHeaders[name] {
+			result[name] = "xxx"
+			continue
+		}
+		result[name] = header.Value
+	}
+	return result
+}
@@ -0,0 +1,193 @@
+package business
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+const proxyTapOutput = `{
+ "http_buffered_trace": {
+  "request": {
+   "headers": [
+    {"key": ":authority", "value": "reviews:9080"},
+    {"key": ":path", "value": "/reviews/0"},
+    {"key": ":method", "value": "GET"},
+    {"key": "authorization", "value": "Bearer secret"}
+   ]
+  },
+  "response": {
+   "headers": [
+    {"key": ":status", "value": "503"},
+    {"key": "server", "value": "envoy"}
+   ]
+  },
+  "downstream_connection": {
+   "local_address": {"socket_address": {"address": "10.244.0.15", "port_value": 9080}},
+   "remote_address": {"socket_address": {"address": "10.244.0.12", "port_value": 41234}}
+  }
+ }
+}
+{
+ "http_buffered_trace": {
+  "request": {"headers": [{"key": ":path", "value": "/health"}, {"key": ":method", "value": "GET"}]},
+  "response": {"headers": [{"key": ":status", "value": "200"}]}
+ }
+}
+{"http_buffered_trace": {"request": {"headers": [`
+
+type proxyTapClient struct {
+	kubernetes.ClientInterface
+	envoyFilters []string
+	// The labels of the captured pod during the capture
+	podLabels map[string]string
+	selectors []map[string]string
+}
+
+func (c *proxyTapClient) TapProxy(namespace, pod string, tapRequest []byte, duration time.Duration) ([]byte, error) {
+	list, err := c.Istio().NetworkingV1alpha3().EnvoyFilters(namespace).List(context.TODO(), meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ef := range list.Items {
+		c.envoyFilters = append(c.envoyFilters, ef.Name)
+		c.selectors = append(c.selectors, ef.Spec.WorkloadSelector.Labels)
+	}
+	p, err := c.Kube().CoreV1().Pods(namespace).Get(context.TODO(), pod, meta_v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.podLabels = p.Labels
+	return []byte(proxyTapOutput), nil
+}
+
+func TestProxyTapCapture(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	pod := &core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "reviews-v1-545db77b95-abcde",
+			Namespace:   "bookinfo",
+			Labels:      map[string]string{"app": "reviews", "version": "v1"},
+			Annotations: kubetest.FakeIstioAnnotations(),
+		},
+		Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "reviews"}, {Name: "istio-proxy"}}},
+	}
+	noProxy := &core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo", Labels: map[string]string{"app": "ratings"}}}
+	k8s := &proxyTapClient{ClientInterface: kubetest.NewFakeK8sClient(pod, noProxy)}
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	service := ProxyTapService{conf: conf, userClients: clients}
+
+	criteria := ProxyTapCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo", Pod: pod.Name, Duration: 5 * time.Second}
+	capture, err := service.Capture(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(capture.Traces, 2)
+	assert.Equal(models.ProxyTapTrace{
+		Method:             "GET",
+		Authority:          "reviews:9080",
+		Path:               "/reviews/0",
+		Status:             "503",
+		SourceAddress:      "10.244.0.12:41234",
+		DestinationAddress: "10.244.0.15:9080",
+		RequestHeaders:     map[string]string{":authority": "reviews:9080", ":path": "/reviews/0", ":method": "GET", "authorization": "xxx"},
+		ResponseHeaders:    map[string]string{":status": "503", "server": "envoy"},
+	}, capture.Traces[0])
+	assert.Equal(5, capture.Duration)
+
+	// The tap filter is added during the capture only, to the proxy of the pod only
+	assert.Equal([]string{"kiali-tap-" + pod.Name}, k8s.envoyFilters)
+	assert.Equal([]map[string]string{{proxyTapLabel: pod.Name}}, k8s.selectors)
+	assert.Equal(pod.Name, k8s.podLabels[proxyTapLabel])
+	list, err := k8s.Istio().NetworkingV1alpha3().EnvoyFilters("bookinfo").List(context.TODO(), meta_v1.ListOptions{})
+	require.NoError(err)
+	assert.Empty(list.Items)
+	captured, err := k8s.Kube().CoreV1().Pods("bookinfo").Get(context.TODO(), pod.Name, meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal(pod.Labels, captured.Labels)
+
+	criteria.Status = "5"
+	capture, err = service.Capture(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(capture.Traces, 1)
+	assert.Equal("/reviews/0", capture.Traces[0].Path)
+
+	criteria.Duration = 2 * time.Minute
+	_, err = service.Capture(context.TODO(), criteria)
+	assert.True(api_errors.IsBadRequest(err))
+
+	criteria.Duration = time.Second
+	criteria.Pod = "ratings"
+	_, err = service.Capture(context.TODO(), criteria)
+	assert.True(api_errors.IsBadRequest(err))
+}
+
+func TestSweepProxyTaps(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	now := time.Now()
+	envoyFilter := func(pod string, created time.Time) *networking_v1alpha3.EnvoyFilter {
+		ef, err := proxyTapEnvoyFilter("bookinfo", pod, proxyTapLabelValue(pod))
+		require.NoError(err)
+		ef.CreationTimestamp = meta_v1.NewTime(created)
+		return ef
+	}
+	tappedPod := func(name string) *core_v1.Pod {
+		return &core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: map[string]string{"app": "reviews", proxyTapLabel: proxyTapLabelValue(name)}}}
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		// Interrupted capture
+		envoyFilter("reviews-v1", now.Add(-10*time.Minute)),
+		tappedPod("reviews-v1"),
+		// Running capture
+		envoyFilter("reviews-v2", now.Add(-10*time.Second)),
+		tappedPod("reviews-v2"),
+		// Capture interrupted after its EnvoyFilter was deleted
+		tappedPod("reviews-v3"),
+	)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+
+	sweepProxyTaps(context.TODO(), clients, now)
+
+	list, err := k8s.Istio().NetworkingV1alpha3().EnvoyFilters("bookinfo").List(context.TODO(), meta_v1.ListOptions{})
+	require.NoError(err)
+	require.Len(list.Items, 1)
+	assert.Equal("kiali-tap-reviews-v2", list.Items[0].Name)
+
+	for pod, tapped := range map[string]bool{"reviews-v1": false, "reviews-v2": true, "reviews-v3": false} {
+		p, err := k8s.Kube().CoreV1().Pods("bookinfo").Get(context.TODO(), pod, meta_v1.GetOptions{})
+		require.NoError(err)
+		_, found := p.Labels[proxyTapLabel]
+		assert.Equal(tapped, found, pod)
+		assert.Equal("reviews", p.Labels["app"])
+	}
+}
+
+func TestProxyTapLabelValue(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("reviews-v1-545db77b95-abcde", proxyTapLabelValue("reviews-v1-545db77b95-abcde"))
+	long := proxyTapLabelValue(strings.Repeat("a", 64))
+	assert.Len(long, 32)
+	assert.NotEqual(long, proxyTapLabelValue(strings.Repeat("b", 64)))
+}
@@ -0,0 +1,263 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbac_v1 "k8s.io/api/rbac/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business/checkers/authorization"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// meshCoreResources are the core resources relevant to the mesh: the credentials of the gateways and the tokens
+// of the service accounts.
+var meshCoreResources = map[string]bool{
+	"*":                     true,
+	"secrets":               true,
+	"serviceaccounts":       true,
+	"serviceaccounts/token": true,
+}
+
+// RBACSummarizer summarizes the service accounts of the workloads: the role bindings granting them access to the
+// resources relevant to the mesh, and the AuthorizationPolicies matching their SPIFFE identity. The role bindings
+// are listed with the permissions of the user.
+type RBACSummarizer struct {
+	businessLayer *Layer
+	kialiCache    cache.KialiCache
+	userClients   map[string]kubernetes.ClientInterface
+}
+
+// SummarizeServiceAccounts returns the summary of the service accounts of a namespace, in the order of the names.
+func (in *RBACSummarizer) SummarizeServiceAccounts(ctx context.Context, cluster, namespace string, serviceAccounts []string) ([]models.ServiceAccountSummary, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "SummarizeServiceAccounts",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	client, ok := in.userClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("user client for cluster [%s] not found", cluster)
+	}
+	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	trustDomains := models.MeshTrustDomains{}
+	if mesh, err := in.businessLayer.Mesh.GetMesh(ctx); err == nil {
+		trustDomains = mesh.TrustDomains()
+	} else {
+		log.Debugf("Unable to get the trust domains of the mesh for the service accounts of namespace [%s]: %s", namespace, err)
+	}
+	if len(trustDomains.TrustDomains) == 0 {
+		trustDomains.TrustDomains = []string{"cluster.local"}
+	}
+
+	policies, err := kubeCache.GetAuthorizationPolicies(meta_v1.NamespaceAll, "")
+	if err != nil {
+		return nil, err
+	}
+
+	bindings, err := in.meshRoleBindings(ctx, client, namespace)
+	if err != nil {
+		if !api_errors.IsForbidden(err) {
+			return nil, err
+		}
+		log.Debugf("Not listing the role bindings of the service accounts of namespace [%s]: %s", namespace, err)
+		bindings = nil
+	}
+
+	// Pods without service account run with the default one
+	names := map[string]bool{}
+	for _, name := range serviceAccounts {
+		if name == "" {
+			name = "default"
+		}
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	summaries := []models.ServiceAccountSummary{}
+	for _, name := range sorted {
+		summary := models.ServiceAccountSummary{
+			Name:                  name,
+			AuthorizationPolicies: []models.PrincipalReference{},
+		}
+		identities := []string{}
+		for _, trustDomain := range append(append([]string{}, trustDomains.TrustDomains...), trustDomains.Aliases...) {
+			identities = append(identities, fmt.Sprintf("%s/ns/%s/sa/%s", trustDomain, namespace, name))
+		}
+		summary.Identity = "spiffe://" + identities[0]
+
+		for _, policy := range policies {
+			for _, principal := range authorization.PolicyPrincipals(policy) {
+				for _, identity := range identities {
+					if principalMatches(principal.Value, identity) {
+						summary.AuthorizationPolicies = append(summary.AuthorizationPolicies, models.PrincipalReference{
+							Policy:    models.IstioValidationKey{ObjectType: kubernetes.AuthorizationPoliciesType, Name: policy.Name, Namespace: policy.Namespace, Cluster: cluster},
+							Path:      principal.Path,
+							Principal: principal.Value,
+						})
+						break
+					}
+				}
+			}
+		}
+
+		if bindings != nil {
+			summary.RoleBindings = []models.RoleBindingSummary{}
+			for _, binding := range bindings {
+				if bindsServiceAccount(binding.subjects, namespace, name) {
+					summary.RoleBindings = append(summary.RoleBindings, binding.summary)
+				}
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+type meshRoleBinding struct {
+	subjects []rbac_v1.Subject
+	summary  models.RoleBindingSummary
+}
+
+// meshRoleBindings returns the role bindings of the namespace and the cluster role bindings whose role grants access
+// to resources relevant to the mesh.
+func (in *RBACSummarizer) meshRoleBindings(ctx context.Context, client kubernetes.ClientInterface, namespace string) ([]meshRoleBinding, error) {
+	rbac := client.Kube().RbacV1()
+	roleBindings, err := rbac.RoleBindings(namespace).List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleBindings, err := rbac.ClusterRoleBindings().List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	rules := map[string][]rbac_v1.PolicyRule{}
+	roleRules := func(roleRef rbac_v1.RoleRef, namespace string) []rbac_v1.PolicyRule {
+		key := roleRef.Kind + "/" + namespace + "/" + roleRef.Name
+		if cached, found := rules[key]; found {
+			return cached
+		}
+		var policyRules []rbac_v1.PolicyRule
+		if roleRef.Kind == "ClusterRole" {
+			if role, err := rbac.ClusterRoles().Get(ctx, roleRef.Name, meta_v1.GetOptions{}); err == nil {
+				policyRules = role.Rules
+			} else {
+				log.Debugf("Unable to get the ClusterRole [%s]: %s", roleRef.Name, err)
+			}
+		} else {
+			if role, err := rbac.Roles(namespace).Get(ctx, roleRef.Name, meta_v1.GetOptions{}); err == nil {
+				policyRules = role.Rules
+			} else {
+				log.Debugf("Unable to get the Role [%s/%s]: %s", namespace, roleRef.Name, err)
+			}
+		}
+		rules[key] = policyRules
+		return policyRules
+	}
+
+	bindings := []meshRoleBinding{}
+	for _, rb := range roleBindings.Items {
+		if resources := meshResources(roleRules(rb.RoleRef, rb.Namespace)); len(resources) > 0 {
+			subjects := make([]rbac_v1.Subject, 0, len(rb.Subjects))
+			for _, subject := range rb.Subjects {
+				// The service accounts of a RoleBinding default to its namespace
+				if subject.Kind == rbac_v1.ServiceAccountKind && subject.Namespace == "" {
+					subject.Namespace = rb.Namespace
+				}
+				subjects = append(subjects, subject)
+			}
+			bindings = append(bindings, meshRoleBinding{
+				subjects: subjects,
+				summary:  models.RoleBindingSummary{Kind: "RoleBinding", Name: rb.Name, Namespace: rb.Namespace, RoleKind: rb.RoleRef.Kind, RoleName: rb.RoleRef.Name, MeshResources: resources},
+			})
+		}
+	}
+	for _, crb := range clusterRoleBindings.Items {
+		if resources := meshResources(roleRules(crb.RoleRef, "")); len(resources) > 0 {
+			bindings = append(bindings, meshRoleBinding{
+				subjects: crb.Subjects,
+				summary:  models.RoleBindingSummary{Kind: "ClusterRoleBinding", Name: crb.Name, RoleKind: crb.RoleRef.Kind, RoleName: crb.RoleRef.Name, MeshResources: resources},
+			})
+		}
+	}
+	return bindings, nil
+}
+
+// meshResources returns the resources relevant to the mesh the rules grant access to: the Istio and Gateway API
+// resources, the secrets and the service accounts, and the wildcards covering them.
+func meshResources(rules []rbac_v1.PolicyRule) []string {
+	resources := []string{}
+	for _, rule := range rules {
+		verbs := strings.Join(rule.Verbs, ",")
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				relevant := group == "*" || strings.HasSuffix(group, "istio.io") || group == "gateway.networking.k8s.io" ||
+					(group == "" && meshCoreResources[resource])
+				if !relevant {
+					continue
+				}
+				if group == "" {
+					resources = append(resources, verbs+" "+resource)
+				} else {
+					resources = append(resources, verbs+" "+group+"/"+resource)
+				}
+			}
+		}
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// bindsServiceAccount returns true when a subject is the service account, or a group of service accounts it is
+// part of.
+func bindsServiceAccount(subjects []rbac_v1.Subject, namespace, name string) bool {
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbac_v1.ServiceAccountKind:
+			if subject.Namespace == namespace && subject.Name == name {
+				return true
+			}
+		case rbac_v1.GroupKind:
+			if subject.Name == "system:serviceaccounts" || subject.Name == "system:serviceaccounts:"+namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// principalMatches returns true when a principal of an AuthorizationPolicy matches an identity, with the exact,
+// prefix, suffix and presence matches of Istio. The spiffe:// scheme is optional.
+func principalMatches(principal, identity string) bool {
+	principal = strings.TrimPrefix(principal, "spiffe://")
+	switch {
+	case principal == "*":
+		return true
+	case strings.HasPrefix(principal, "*"):
+		return strings.HasSuffix(identity, strings.TrimPrefix(principal, "*"))
+	case strings.HasSuffix(principal, "*"):
+		return strings.HasPrefix(identity, strings.TrimSuffix(principal, "*"))
+	default:
+		return principal == identity
+	}
+}
@@ -0,0 +1,127 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	rbac_v1 "k8s.io/api/rbac/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakePrincipalsPolicy(name, namespace string, principals ...string) *security_v1beta1.AuthorizationPolicy {
+	return &security_v1beta1.AuthorizationPolicy{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: api_security_v1beta1.AuthorizationPolicy{
+			Rules: []*api_security_v1beta1.Rule{{
+				From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{Principals: principals}}},
+			}},
+		},
+	}
+}
+
+func TestSummarizeServiceAccounts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&rbac_v1.Role{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "read-secrets", Namespace: "bookinfo"},
+			Rules:      []rbac_v1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets", "configmaps"}, Verbs: []string{"get", "list"}}},
+		},
+		&rbac_v1.Role{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "read-configmaps", Namespace: "bookinfo"},
+			Rules:      []rbac_v1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}}},
+		},
+		&rbac_v1.RoleBinding{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-secrets", Namespace: "bookinfo"},
+			Subjects:   []rbac_v1.Subject{{Kind: rbac_v1.ServiceAccountKind, Name: "reviews"}},
+			RoleRef:    rbac_v1.RoleRef{Kind: "Role", Name: "read-secrets"},
+		},
+		&rbac_v1.RoleBinding{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-configmaps", Namespace: "bookinfo"},
+			Subjects:   []rbac_v1.Subject{{Kind: rbac_v1.ServiceAccountKind, Name: "reviews"}},
+			RoleRef:    rbac_v1.RoleRef{Kind: "Role", Name: "read-configmaps"},
+		},
+		&rbac_v1.ClusterRole{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio-reader"},
+			Rules:      []rbac_v1.PolicyRule{{APIGroups: []string{"networking.istio.io"}, Resources: []string{"virtualservices"}, Verbs: []string{"get"}}},
+		},
+		&rbac_v1.ClusterRoleBinding{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "all-istio-reader"},
+			Subjects:   []rbac_v1.Subject{{Kind: rbac_v1.GroupKind, Name: "system:serviceaccounts"}},
+			RoleRef:    rbac_v1.RoleRef{Kind: "ClusterRole", Name: "istio-reader"},
+		},
+		fakePrincipalsPolicy("allow-reviews", "bookinfo", "cluster.local/ns/bookinfo/sa/reviews"),
+		fakePrincipalsPolicy("allow-any-reviews", "istio-system", "*/sa/reviews"),
+		fakePrincipalsPolicy("allow-ratings", "bookinfo", "cluster.local/ns/bookinfo/sa/ratings"),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	rbac := NewWithBackends(clients, clients, nil, nil).RBAC
+
+	summaries, err := rbac.SummarizeServiceAccounts(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", []string{"reviews", "", "reviews"})
+	require.NoError(err)
+	require.Len(summaries, 2)
+
+	// Pods without service account run with the default one
+	assert.Equal("default", summaries[0].Name)
+	assert.Equal("spiffe://cluster.local/ns/bookinfo/sa/default", summaries[0].Identity)
+	assert.Empty(summaries[0].AuthorizationPolicies)
+	require.Len(summaries[0].RoleBindings, 1)
+	assert.Equal("all-istio-reader", summaries[0].RoleBindings[0].Name)
+
+	reviews := summaries[1]
+	assert.Equal("reviews", reviews.Name)
+	assert.Equal("spiffe://cluster.local/ns/bookinfo/sa/reviews", reviews.Identity)
+	assert.ElementsMatch([]models.RoleBindingSummary{
+		{Kind: "RoleBinding", Name: "reviews-secrets", Namespace: "bookinfo", RoleKind: "Role", RoleName: "read-secrets", MeshResources: []string{"get,list secrets"}},
+		{Kind: "ClusterRoleBinding", Name: "all-istio-reader", RoleKind: "ClusterRole", RoleName: "istio-reader", MeshResources: []string{"get networking.istio.io/virtualservices"}},
+	}, reviews.RoleBindings)
+	require.Len(reviews.AuthorizationPolicies, 2)
+	policies := []string{}
+	for _, reference := range reviews.AuthorizationPolicies {
+		assert.Equal("spec/rules[0]/from[0]/source/principals[0]", reference.Path)
+		policies = append(policies, reference.Policy.Namespace+"/"+reference.Policy.Name)
+	}
+	assert.ElementsMatch([]string{"bookinfo/allow-reviews", "istio-system/allow-any-reviews"}, policies)
+}
+
+func TestPrincipalMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	identity := "cluster.local/ns/bookinfo/sa/reviews"
+	assert.True(principalMatches("cluster.local/ns/bookinfo/sa/reviews", identity))
+	assert.True(principalMatches("spiffe://cluster.local/ns/bookinfo/sa/reviews", identity))
+	assert.True(principalMatches("*", identity))
+	assert.True(principalMatches("*/sa/reviews", identity))
+	assert.True(principalMatches("cluster.local/ns/bookinfo/*", identity))
+	assert.False(principalMatches("cluster.local/ns/bookinfo/sa/ratings", identity))
+	assert.False(principalMatches("cluster.local/ns/default/*", identity))
+}
+
+func TestMeshResources(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"* */*", "create serviceaccounts/token", "get security.istio.io/authorizationpolicies"}, meshResources([]rbac_v1.PolicyRule{
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+		{APIGroups: []string{"security.istio.io"}, Resources: []string{"authorizationpolicies"}, Verbs: []string{"get"}},
+		{APIGroups: []string{""}, Resources: []string{"serviceaccounts/token", "pods"}, Verbs: []string{"create"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get"}},
+	}))
+	assert.Empty(meshResources(nil))
+}
@@ -0,0 +1,174 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kiali/kiali/business/references"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetReferenceImpact returns the Istio objects referencing an object, that is, what could break if it was deleted.
+// The object can be an Istio object, a service or a workload.
+func (in *IstioValidationsService) GetReferenceImpact(ctx context.Context, cluster, namespace, objectType, object string) (*models.ReferenceImpact, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetReferenceImpact",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("objectType", objectType),
+		observability.Attribute("object", object),
+	)
+	defer end()
+
+	referenceType, found := models.ObjectTypeSingular[objectType]
+	if !found {
+		return nil, fmt.Errorf("object type not found: %v", objectType)
+	}
+
+	if _, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := in.getReferenceIndex(ctx, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &models.ReferenceImpact{
+		Object:       models.IstioReference{ObjectType: referenceType, Name: object, Namespace: namespace},
+		ReferencedBy: []models.IstioReference{},
+	}
+	accessible := namespaceNames(namespaces)
+	for _, ref := range index.ReferencedBy[models.IstioReferenceKey{ObjectType: referenceType, Name: object, Namespace: namespace}] {
+		if accessible[ref.Namespace] {
+			impact.ReferencedBy = append(impact.ReferencedBy, ref)
+		}
+	}
+
+	return impact, nil
+}
+
+// getReferenceIndex returns the reference index of the namespace, fetching the config to rebuild it when it is stale.
+func (in *IstioValidationsService) getReferenceIndex(ctx context.Context, cluster, namespace string) (*models.ReferenceIndex, error) {
+	if index, found := kialiCache.GetReferenceIndex(cluster, namespace); found {
+		return index, nil
+	}
+
+	var istioConfigList models.IstioConfigList
+	var namespaces models.Namespaces
+	var workloadsPerNamespace map[string]models.WorkloadList
+	var mtlsDetails kubernetes.MTLSDetails
+	var rbacDetails kubernetes.RBACDetails
+	var registryServices []*kubernetes.RegistryService
+
+	configVersion := in.configVersion(cluster)
+
+	wg := sync.WaitGroup{}
+	errChan := make(chan error, 1)
+
+	wg.Add(3)
+	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, cluster, namespace, errChan, &wg)
+	go in.fetchAllWorkloads(ctx, &workloadsPerNamespace, cluster, &namespaces, errChan, &wg)
+	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, cluster, errChan, &wg)
+
+	if config.Get().ExternalServices.Istio.IstioAPIEnabled {
+		criteria := RegistryCriteria{AllNamespaces: true, Cluster: cluster}
+		registryServices = in.businessLayer.RegistryStatus.GetRegistryServices(criteria)
+	}
+
+	wg.Wait()
+	close(errChan)
+	for e := range errChan {
+		if e != nil {
+			return nil, e
+		}
+	}
+
+	index := in.buildReferenceIndex(configVersion, istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, namespaces, registryServices, namespace)
+	kialiCache.SetReferenceIndex(cluster, namespace, index)
+	return index, nil
+}
+
+// buildReferenceIndex runs the reference checkers of all the Istio object types and indexes their results.
+func (in *IstioValidationsService) buildReferenceIndex(configVersion uint64, istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces models.Namespaces, registryServices []*kubernetes.RegistryService, namespace string) *models.ReferenceIndex {
+	istioReferences := models.IstioReferencesMap{}
+	for _, referenceChecker := range in.getAllReferenceCheckers(istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, namespaces, registryServices, namespace) {
+		istioReferences.MergeReferencesMap(runObjectReferenceChecker(referenceChecker))
+	}
+	return models.NewReferenceIndex(configVersion, istioReferences)
+}
+
+func (in *IstioValidationsService) getAllReferenceCheckers(istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces models.Namespaces, registryServices []*kubernetes.RegistryService, namespace string) []ReferenceChecker {
+	return []ReferenceChecker{
+		references.GatewayReferences{Gateways: istioConfigList.Gateways, VirtualServices: istioConfigList.VirtualServices, WorkloadsPerNamespace: workloadsPerNamespace},
+		references.VirtualServiceReferences{Namespace: namespace, Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules, AuthorizationPolicies: rbacDetails.AuthorizationPolicies},
+		references.DestinationRuleReferences{Namespace: namespace, Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, VirtualServices: istioConfigList.VirtualServices, WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices},
+		references.ServiceEntryReferences{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, ServiceEntries: istioConfigList.ServiceEntries, Sidecars: istioConfigList.Sidecars, RegistryServices: registryServices, WorkloadEntries: istioConfigList.WorkloadEntries, WorkloadGroups: istioConfigList.WorkloadGroups},
+		references.SidecarReferences{Sidecars: istioConfigList.Sidecars, Namespace: namespace, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, WorkloadsPerNamespace: workloadsPerNamespace},
+		references.AuthorizationPolicyReferences{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, K8sGateways: istioConfigList.K8sGateways, Namespace: namespace, Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, WorkloadsPerNamespace: workloadsPerNamespace},
+		references.PeerAuthReferences{MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace},
+		references.WorkloadEntryReferences{ServiceEntries: istioConfigList.ServiceEntries, WorkloadEntries: istioConfigList.WorkloadEntries, WorkloadGroups: istioConfigList.WorkloadGroups},
+		references.WorkloadGroupReferences{ServiceEntries: istioConfigList.ServiceEntries, WorkloadEntries: istioConfigList.WorkloadEntries, WorkloadGroups: istioConfigList.WorkloadGroups},
+		references.RequestAuthnReferences{RequestAuthentications: istioConfigList.RequestAuthentications, AuthorizationPolicies: rbacDetails.AuthorizationPolicies, WorkloadsPerNamespace: workloadsPerNamespace},
+		references.WasmPluginReferences{WasmPlugins: istioConfigList.WasmPlugins, WorkloadsPerNamespace: workloadsPerNamespace},
+		references.TelemetryReferences{Telemetries: istioConfigList.Telemetries, WorkloadsPerNamespace: workloadsPerNamespace},
+		references.K8sGatewayReferences{K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes},
+		references.K8sHTTPRouteReferences{K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, Namespaces: namespaces, K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
+		references.K8sGRPCRouteReferences{K8sGateways: istioConfigList.K8sGateways, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, Namespaces: namespaces, K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
+	}
+}
+
+// configVersion returns the version of the cached config of the cluster the reference index is built from.
+func (in *IstioValidationsService) configVersion(cluster string) uint64 {
+	kubeCache, err := kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		log.Debugf("Unable to get the config version of cluster [%s]: %s", cluster, err)
+		return 0
+	}
+	return kubeCache.ConfigVersion()
+}
+
+// filterReferences removes the references to the namespaces not accessible to the user, the index being shared by all the users.
+func filterReferences(istioReferences *models.IstioReferences, namespaces models.Namespaces) *models.IstioReferences {
+	accessible := namespaceNames(namespaces)
+	filtered := &models.IstioReferences{
+		ObjectReferences:   []models.IstioReference{},
+		ServiceReferences:  []models.ServiceReference{},
+		WorkloadReferences: []models.WorkloadReference{},
+	}
+	for _, ref := range istioReferences.ObjectReferences {
+		if accessible[ref.Namespace] {
+			filtered.ObjectReferences = append(filtered.ObjectReferences, ref)
+		}
+	}
+	for _, ref := range istioReferences.ServiceReferences {
+		if accessible[ref.Namespace] {
+			filtered.ServiceReferences = append(filtered.ServiceReferences, ref)
+		}
+	}
+	for _, ref := range istioReferences.WorkloadReferences {
+		if accessible[ref.Namespace] {
+			filtered.WorkloadReferences = append(filtered.WorkloadReferences, ref)
+		}
+	}
+	return filtered
+}
+
+func namespaceNames(namespaces models.Namespaces) map[string]bool {
+	names := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		names[ns.Name] = true
+	}
+	return names
+}
@@ -5,6 +5,7 @@ import (
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 
 	"k8s.io/apimachinery/pkg/labels"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -13,6 +14,7 @@ import (
 
 type AuthorizationPolicyReferences struct {
 	AuthorizationPolicies []*security_v1beta.AuthorizationPolicy
+	K8sGateways           []*k8s_networking_v1.Gateway
 	Namespace             string
 	Namespaces            models.Namespaces
 	ServiceEntries        []*networking_v1beta1.ServiceEntry
@@ -52,6 +54,13 @@ func (n AuthorizationPolicyReferences) References() models.IstioReferencesMap {
 			}
 		}
 		references.WorkloadReferences = append(references.WorkloadReferences, n.getWorkloadReferences(ap)...)
+
+		// Ambient policies attached to a waypoint (K8s Gateway), to all the Gateways of a class or to a Service
+		gatewayRefs, gatewayWorkloadRefs := getTargetRefGatewayReferences(ap.Spec.TargetRef, namespace, n.K8sGateways, n.WorkloadsPerNamespace)
+		_, targetServiceRefs := getTargetRefReferences(ap.Spec.TargetRef, namespace)
+		references.ObjectReferences = append(references.ObjectReferences, gatewayRefs...)
+		references.ServiceReferences = append(references.ServiceReferences, targetServiceRefs...)
+		references.WorkloadReferences = append(references.WorkloadReferences, gatewayWorkloadRefs...)
 		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
 	}
 
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/models"
@@ -129,3 +130,47 @@ func fakeServiceEntry() *networking_v1beta1.ServiceEntry {
 	}
 	return &serviceEntry
 }
+
+func TestAuthPolicyTargetRefReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	waypoint := data.CreateEmptyK8sGateway("waypoint", "bookinfo")
+	waypoint.Spec.GatewayClassName = "istio-waypoint"
+	otherWaypoint := data.CreateEmptyK8sGateway("waypoint", "bookinfo2")
+	otherWaypoint.Spec.GatewayClassName = "istio-waypoint"
+
+	apReferences := AuthorizationPolicyReferences{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{
+			data.CreateAuthorizationPolicyWithTargetRef("to-waypoint", "bookinfo", "gateway.networking.k8s.io", "Gateway", "waypoint"),
+			data.CreateAuthorizationPolicyWithTargetRef("to-class", "istio-system", "gateway.networking.k8s.io", "GatewayClass", "istio-waypoint"),
+			data.CreateAuthorizationPolicyWithTargetRef("to-service", "bookinfo", "", "Service", "reviews"),
+		},
+		K8sGateways: []*k8s_networking_v1.Gateway{waypoint, otherWaypoint, data.CreateEmptyK8sGateway("ingress", "bookinfo")},
+		WorkloadsPerNamespace: map[string]models.WorkloadList{
+			"bookinfo": data.CreateWorkloadList("bookinfo",
+				data.CreateWorkloadListItem("waypoint", map[string]string{"gateway.networking.k8s.io/gateway-name": "waypoint"}),
+				data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews"}),
+			),
+		},
+	}
+	references := apReferences.References()
+
+	toWaypoint := references[models.IstioReferenceKey{ObjectType: "authorizationpolicy", Namespace: "bookinfo", Name: "to-waypoint"}]
+	assert.Equal([]models.IstioReference{{Name: "waypoint", Namespace: "bookinfo", ObjectType: "k8sgateway"}}, toWaypoint.ObjectReferences)
+	assert.Equal([]models.WorkloadReference{{Name: "waypoint", Namespace: "bookinfo"}}, toWaypoint.WorkloadReferences)
+	assert.Empty(toWaypoint.ServiceReferences)
+
+	toClass := references[models.IstioReferenceKey{ObjectType: "authorizationpolicy", Namespace: "istio-system", Name: "to-class"}]
+	assert.Equal([]models.IstioReference{
+		{Name: "waypoint", Namespace: "bookinfo", ObjectType: "k8sgateway"},
+		{Name: "waypoint", Namespace: "bookinfo2", ObjectType: "k8sgateway"},
+	}, toClass.ObjectReferences)
+	assert.Equal([]models.WorkloadReference{{Name: "waypoint", Namespace: "bookinfo"}}, toClass.WorkloadReferences)
+
+	toService := references[models.IstioReferenceKey{ObjectType: "authorizationpolicy", Namespace: "bookinfo", Name: "to-service"}]
+	assert.Equal([]models.ServiceReference{{Name: "reviews", Namespace: "bookinfo"}}, toService.ServiceReferences)
+	assert.Empty(toService.ObjectReferences)
+	assert.Empty(toService.WorkloadReferences)
+}
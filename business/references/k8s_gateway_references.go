@@ -9,6 +9,7 @@ import (
 
 type K8sGatewayReferences struct {
 	K8sGateways   []*k8s_networking_v1.Gateway
+	K8sGRPCRoutes []*k8s_networking_v1.GRPCRoute
 	K8sHTTPRoutes []*k8s_networking_v1.HTTPRoute
 }
 
@@ -39,5 +40,14 @@ func (g K8sGatewayReferences) getConfigReferences(gw *k8s_networking_v1.Gateway)
 		}
 	}
 
+	for _, rt := range g.K8sGRPCRoutes {
+		for _, pr := range rt.Spec.ParentRefs {
+			if string(pr.Name) == gw.Name && isK8sGatewayParentRef(pr) {
+				ref := models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.K8sGRPCRoutes]}
+				result = append(result, ref)
+			}
+		}
+	}
+
 	return result
 }
@@ -0,0 +1,196 @@
+package references
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// Helpers shared by the references of the K8s Gateway API routes (HTTPRoute, GRPCRoute).
+// Besides the gateways and services a route points to, they resolve the objects granting
+// the route permission to use them across namespaces:
+// - the ReferenceGrants in the namespace of a backend allowing routes from the route namespace.
+// - the allowedRoutes of the listeners of a Gateway living in a different namespace.
+
+func isK8sGatewayParentRef(parentRef k8s_networking_v1.ParentReference) bool {
+	// Kind and Group default to a Gateway API Gateway
+	if parentRef.Kind != nil && string(*parentRef.Kind) != kubernetes.K8sActualGatewayType {
+		return false
+	}
+	if parentRef.Group != nil && string(*parentRef.Group) != kubernetes.K8sNetworkingGroupVersionV1.Group {
+		return false
+	}
+	return string(parentRef.Name) != ""
+}
+
+func isK8sServiceBackendRef(backendRef k8s_networking_v1.BackendObjectReference) bool {
+	// Kind defaults to Service
+	if backendRef.Kind != nil && string(*backendRef.Kind) != kubernetes.ServiceType {
+		return false
+	}
+	return backendRef.Group == nil || string(*backendRef.Group) == "" || string(*backendRef.Group) == "core"
+}
+
+func backendRefNamespace(backendRef k8s_networking_v1.BackendObjectReference, routeNamespace string) string {
+	if backendRef.Namespace != nil && string(*backendRef.Namespace) != "" {
+		return string(*backendRef.Namespace)
+	}
+	return routeNamespace
+}
+
+func getK8sBackendServiceReferences(backendRefs []k8s_networking_v1.BackendObjectReference, routeNamespace string, namespaces models.Namespaces) []models.ServiceReference {
+	keys := make(map[string]bool)
+	result := make([]models.ServiceReference, 0)
+
+	for _, ref := range backendRefs {
+		// Backends without a Kind were never referenced, keep it that way
+		if ref.Kind == nil || !isK8sServiceBackendRef(ref) {
+			continue
+		}
+		fqdn := kubernetes.GetHost(string(ref.Name), backendRefNamespace(ref, routeNamespace), namespaces.GetNames())
+		// filter unique references
+		if !fqdn.IsWildcard() && !keys[fqdn.Service+"."+fqdn.Namespace] {
+			result = append(result, models.ServiceReference{Name: fqdn.Service, Namespace: fqdn.Namespace})
+			keys[fqdn.Service+"."+fqdn.Namespace] = true
+		}
+	}
+	return result
+}
+
+// getK8sParentGatewayReferences returns the Gateways the route is attached to. A Gateway in another
+// namespace is only referenced when one of its listeners admits the route, unless it is unknown.
+func getK8sParentGatewayReferences(parentRefs []k8s_networking_v1.ParentReference, routeNamespace, routeKind string, gateways []*k8s_networking_v1.Gateway, namespaces models.Namespaces) []models.IstioReference {
+	keys := make(map[string]bool)
+	result := make([]models.IstioReference, 0)
+
+	for _, parentRef := range parentRefs {
+		if !isK8sGatewayParentRef(parentRef) {
+			continue
+		}
+		namespace := routeNamespace
+		if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+			namespace = string(*parentRef.Namespace)
+		}
+		if namespace != routeNamespace {
+			if gw := findK8sGateway(gateways, string(parentRef.Name), namespace); gw != nil && !admitsK8sRoute(gw, parentRef.SectionName, routeNamespace, routeKind, namespaces) {
+				continue
+			}
+		}
+		gw := getK8sGatewayReference(string(parentRef.Name), namespace)
+		// filter unique references
+		if !keys[gw.Name+"."+gw.Namespace+"/"+gw.ObjectType] {
+			result = append(result, gw)
+			keys[gw.Name+"."+gw.Namespace+"/"+gw.ObjectType] = true
+		}
+	}
+	return result
+}
+
+// getK8sReferenceGrantReferences returns the ReferenceGrants authorizing the route to use its backends in other namespaces.
+func getK8sReferenceGrantReferences(backendRefs []k8s_networking_v1.BackendObjectReference, routeNamespace, routeKind string, grants []*k8s_networking_v1beta1.ReferenceGrant) []models.IstioReference {
+	keys := make(map[string]bool)
+	result := make([]models.IstioReference, 0)
+
+	for _, ref := range backendRefs {
+		namespace := backendRefNamespace(ref, routeNamespace)
+		if namespace == routeNamespace || !isK8sServiceBackendRef(ref) {
+			continue
+		}
+		for _, rGrant := range grants {
+			if rGrant.Namespace != namespace || keys[rGrant.Name+"."+rGrant.Namespace] {
+				continue
+			}
+			if grantsFromK8sRoute(rGrant, routeNamespace, routeKind) && grantsToK8sService(rGrant, string(ref.Name)) {
+				result = append(result, getK8sGrantReference(rGrant.Name, rGrant.Namespace))
+				keys[rGrant.Name+"."+rGrant.Namespace] = true
+			}
+		}
+	}
+	return result
+}
+
+func grantsFromK8sRoute(rGrant *k8s_networking_v1beta1.ReferenceGrant, routeNamespace, routeKind string) bool {
+	for _, from := range rGrant.Spec.From {
+		if string(from.Namespace) == routeNamespace && string(from.Kind) == routeKind && string(from.Group) == kubernetes.K8sNetworkingGroupVersionV1.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func grantsToK8sService(rGrant *k8s_networking_v1beta1.ReferenceGrant, service string) bool {
+	for _, to := range rGrant.Spec.To {
+		if string(to.Kind) != kubernetes.ServiceType || (string(to.Group) != "" && string(to.Group) != "core") {
+			continue
+		}
+		if to.Name == nil || string(*to.Name) == "" || string(*to.Name) == service {
+			return true
+		}
+	}
+	return false
+}
+
+func findK8sGateway(gateways []*k8s_networking_v1.Gateway, name, namespace string) *k8s_networking_v1.Gateway {
+	for _, gw := range gateways {
+		if gw.Name == name && gw.Namespace == namespace {
+			return gw
+		}
+	}
+	return nil
+}
+
+// admitsK8sRoute checks whether any listener of the gateway (or the one named by the parentRef) allows routes of the given kind from the route namespace.
+func admitsK8sRoute(gw *k8s_networking_v1.Gateway, sectionName *k8s_networking_v1.SectionName, routeNamespace, routeKind string, namespaces models.Namespaces) bool {
+	for _, listener := range gw.Spec.Listeners {
+		if sectionName != nil && *sectionName != listener.Name {
+			continue
+		}
+		if listenerAllowsNamespace(listener, gw.Namespace, routeNamespace, namespaces) && listenerAllowsKind(listener, routeKind) {
+			return true
+		}
+	}
+	return false
+}
+
+func listenerAllowsNamespace(listener k8s_networking_v1.Listener, gatewayNamespace, routeNamespace string, namespaces models.Namespaces) bool {
+	// Only routes from the Gateway namespace are allowed by default
+	from := k8s_networking_v1.NamespacesFromSame
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil && listener.AllowedRoutes.Namespaces.From != nil {
+		from = *listener.AllowedRoutes.Namespaces.From
+	}
+
+	switch from {
+	case k8s_networking_v1.NamespacesFromAll:
+		return true
+	case k8s_networking_v1.NamespacesFromSelector:
+		selector, err := meta_v1.LabelSelectorAsSelector(listener.AllowedRoutes.Namespaces.Selector)
+		if err != nil {
+			return false
+		}
+		for _, ns := range namespaces {
+			if ns.Name == routeNamespace {
+				return selector.Matches(labels.Set(ns.Labels))
+			}
+		}
+		return false
+	default:
+		return gatewayNamespace == routeNamespace
+	}
+}
+
+func listenerAllowsKind(listener k8s_networking_v1.Listener, routeKind string) bool {
+	// Without kinds, the listener protocol decides which routes are allowed
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return true
+	}
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if string(kind.Kind) == routeKind && (kind.Group == nil || string(*kind.Group) == kubernetes.K8sNetworkingGroupVersionV1.Group) {
+			return true
+		}
+	}
+	return false
+}
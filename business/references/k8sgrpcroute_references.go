@@ -0,0 +1,46 @@
+package references
+
+import (
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sGRPCRouteReferences struct {
+	K8sGateways        []*k8s_networking_v1.Gateway
+	K8sGRPCRoutes      []*k8s_networking_v1.GRPCRoute
+	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
+	Namespaces         models.Namespaces
+}
+
+func (n K8sGRPCRouteReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, rt := range n.K8sGRPCRoutes {
+		key := models.IstioReferenceKey{Namespace: rt.Namespace, Name: rt.Name, ObjectType: models.ObjectTypeSingular[kubernetes.K8sGRPCRoutes]}
+		references := &models.IstioReferences{}
+		references.ServiceReferences = getK8sBackendServiceReferences(grpcRouteBackendRefs(rt), rt.Namespace, n.Namespaces)
+		references.ObjectReferences = n.getConfigReferences(rt)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
+
+func (n K8sGRPCRouteReferences) getConfigReferences(rt *k8s_networking_v1.GRPCRoute) []models.IstioReference {
+	result := getK8sParentGatewayReferences(rt.Spec.ParentRefs, rt.Namespace, kubernetes.K8sActualGRPCRouteType, n.K8sGateways, n.Namespaces)
+	result = append(result, getK8sReferenceGrantReferences(grpcRouteBackendRefs(rt), rt.Namespace, kubernetes.K8sActualGRPCRouteType, n.K8sReferenceGrants)...)
+	return result
+}
+
+func grpcRouteBackendRefs(rt *k8s_networking_v1.GRPCRoute) []k8s_networking_v1.BackendObjectReference {
+	backendRefs := make([]k8s_networking_v1.BackendObjectReference, 0)
+	for _, grpcRoute := range rt.Spec.Rules {
+		for _, ref := range grpcRoute.BackendRefs {
+			backendRefs = append(backendRefs, ref.BackendObjectReference)
+		}
+	}
+	return backendRefs
+}
@@ -0,0 +1,56 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestK8sGRPCRouteReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	route := data.AddBackendRefToGRPCRoute("ratings", "bookinfo2", data.AddBackendRefToGRPCRoute("reviews", "", data.CreateGRPCRoute("route1", "bookinfo", "gatewayapi")))
+
+	routeReferences := K8sGRPCRouteReferences{
+		Namespaces:    models.Namespaces{{Name: "bookinfo"}, {Name: "bookinfo2"}},
+		K8sGRPCRoutes: []*k8s_networking_v1.GRPCRoute{route},
+		K8sReferenceGrants: []*k8s_networking_v1beta1.ReferenceGrant{
+			data.CreateReferenceGrant("http-only", "bookinfo2", "bookinfo"),
+			data.CreateReferenceGrantByKind("grpc", "bookinfo2", "bookinfo", kubernetes.K8sActualGRPCRouteType),
+		},
+	}
+	references := routeReferences.References()[models.IstioReferenceKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: "route1"}]
+
+	assert.Equal([]models.ServiceReference{
+		{Name: "reviews", Namespace: "bookinfo"},
+		{Name: "ratings", Namespace: "bookinfo2"},
+	}, references.ServiceReferences)
+	assert.Equal([]models.IstioReference{
+		{Name: "gatewayapi", Namespace: "bookinfo", ObjectType: "k8sgateway"},
+		{Name: "grpc", Namespace: "bookinfo2", ObjectType: "k8sreferencegrant"},
+	}, references.ObjectReferences)
+}
+
+func TestK8sGatewayGRPCRouteReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")
+	gatewayReferences := K8sGatewayReferences{
+		K8sGateways:   []*k8s_networking_v1.Gateway{gw},
+		K8sGRPCRoutes: []*k8s_networking_v1.GRPCRoute{data.CreateGRPCRoute("route1", "bookinfo", gw.Name)},
+	}
+	references := gatewayReferences.References()[models.IstioReferenceKey{ObjectType: "k8sgateway", Namespace: "bookinfo", Name: "gatewayapi"}]
+
+	assert.Equal([]models.IstioReference{{Name: "route1", Namespace: "bookinfo", ObjectType: "k8sgrpcroute"}}, references.ObjectReferences)
+}
@@ -9,6 +9,7 @@ import (
 )
 
 type K8sHTTPRouteReferences struct {
+	K8sGateways        []*k8s_networking_v1.Gateway
 	K8sHTTPRoutes      []*k8s_networking_v1.HTTPRoute
 	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
 	Namespaces         models.Namespaces
@@ -20,7 +21,7 @@ func (n K8sHTTPRouteReferences) References() models.IstioReferencesMap {
 	for _, rt := range n.K8sHTTPRoutes {
 		key := models.IstioReferenceKey{Namespace: rt.Namespace, Name: rt.Name, ObjectType: models.ObjectTypeSingular[kubernetes.K8sHTTPRoutes]}
 		references := &models.IstioReferences{}
-		references.ServiceReferences = n.getServiceReferences(rt)
+		references.ServiceReferences = getK8sBackendServiceReferences(httpRouteBackendRefs(rt), rt.Namespace, n.Namespaces)
 		references.ObjectReferences = n.getConfigReferences(rt)
 		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
 	}
@@ -28,81 +29,20 @@ func (n K8sHTTPRouteReferences) References() models.IstioReferencesMap {
 	return result
 }
 
-func (n K8sHTTPRouteReferences) getServiceReferences(rt *k8s_networking_v1.HTTPRoute) []models.ServiceReference {
-	keys := make(map[string]bool)
-	allServices := make([]models.ServiceReference, 0)
-	result := make([]models.ServiceReference, 0)
-
-	for _, httpRoute := range rt.Spec.Rules {
-		for _, ref := range httpRoute.BackendRefs {
-			if ref.Kind == nil || string(*ref.Kind) != "Service" {
-				continue
-			}
-			namespace := rt.Namespace
-			if ref.Namespace != nil && string(*ref.Namespace) != "" {
-				namespace = string(*ref.Namespace)
-			}
-			fqdn := kubernetes.GetHost(string(ref.Name), namespace, n.Namespaces.GetNames())
-			if !fqdn.IsWildcard() {
-				allServices = append(allServices, models.ServiceReference{Name: fqdn.Service, Namespace: fqdn.Namespace})
-			}
-		}
-	}
-
-	// filter unique references
-	for _, sv := range allServices {
-		if !keys[sv.Name+"."+sv.Namespace] {
-			result = append(result, sv)
-			keys[sv.Name+"."+sv.Namespace] = true
-		}
-	}
-	return result
-}
-
 func (n K8sHTTPRouteReferences) getConfigReferences(rt *k8s_networking_v1.HTTPRoute) []models.IstioReference {
-	keys := make(map[string]bool)
-	result := make([]models.IstioReference, 0)
-	allGateways := getAllK8sGateways(rt)
-	// filter unique references
-	for _, gw := range allGateways {
-		if !keys[gw.Name+"."+gw.Namespace+"/"+gw.ObjectType] {
-			result = append(result, gw)
-			keys[gw.Name+"."+gw.Namespace+"/"+gw.ObjectType] = true
-		}
-	}
-	result = append(result, n.getAllK8sReferenceGrants(rt)...)
+	result := getK8sParentGatewayReferences(rt.Spec.ParentRefs, rt.Namespace, kubernetes.K8sActualHTTPRouteType, n.K8sGateways, n.Namespaces)
+	result = append(result, getK8sReferenceGrantReferences(httpRouteBackendRefs(rt), rt.Namespace, kubernetes.K8sActualHTTPRouteType, n.K8sReferenceGrants)...)
 	return result
 }
 
-func getAllK8sGateways(rt *k8s_networking_v1.HTTPRoute) []models.IstioReference {
-	allGateways := make([]models.IstioReference, 0)
-
-	if len(rt.Spec.ParentRefs) > 0 {
-		for _, parentRef := range rt.Spec.ParentRefs {
-			if string(parentRef.Name) != "" && string(*parentRef.Kind) == kubernetes.K8sActualGatewayType && string(*parentRef.Group) == kubernetes.K8sNetworkingGroupVersionV1.Group {
-				namespace := rt.Namespace
-				if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
-					namespace = string(*parentRef.Namespace)
-				}
-				allGateways = append(allGateways, getK8sGatewayReference(string(parentRef.Name), namespace))
-			}
-		}
-	}
-
-	return allGateways
-}
-
-func (n K8sHTTPRouteReferences) getAllK8sReferenceGrants(rt *k8s_networking_v1.HTTPRoute) []models.IstioReference {
-	allGrants := make([]models.IstioReference, 0)
-	for _, rGrant := range n.K8sReferenceGrants {
-		if len(rGrant.Spec.From) > 0 &&
-			string(rGrant.Spec.From[0].Namespace) == rt.Namespace &&
-			string(rGrant.Spec.From[0].Kind) == kubernetes.K8sActualHTTPRouteType {
-			allGrants = append(allGrants, getK8sGrantReference(rGrant.Name, rGrant.Namespace))
+func httpRouteBackendRefs(rt *k8s_networking_v1.HTTPRoute) []k8s_networking_v1.BackendObjectReference {
+	backendRefs := make([]k8s_networking_v1.BackendObjectReference, 0)
+	for _, httpRoute := range rt.Spec.Rules {
+		for _, ref := range httpRoute.BackendRefs {
+			backendRefs = append(backendRefs, ref.BackendObjectReference)
 		}
 	}
-
-	return allGrants
+	return backendRefs
 }
 
 func getK8sGatewayReference(gateway string, namespace string) models.IstioReference {
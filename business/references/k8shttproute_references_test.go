@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/tests/data"
 )
@@ -21,7 +22,7 @@ func prepareTestForK8sHTTPRoute(route *k8s_networking_v1.HTTPRoute) models.Istio
 			{Name: "bookinfo3"},
 		},
 		K8sHTTPRoutes:      []*k8s_networking_v1.HTTPRoute{route},
-		K8sReferenceGrants: []*k8s_networking_v1beta1.ReferenceGrant{data.CreateReferenceGrant("rg", route.Namespace, "bookinfo")},
+		K8sReferenceGrants: []*k8s_networking_v1beta1.ReferenceGrant{data.CreateReferenceGrant("rg", "bookinfo2", route.Namespace)},
 	}
 	return *routeReferences.References()[models.IstioReferenceKey{ObjectType: "k8shttproute", Namespace: route.Namespace, Name: route.Name}]
 }
@@ -47,9 +48,9 @@ func TestK8sHTTPRouteReferences(t *testing.T) {
 	assert.Equal(references.ObjectReferences[0].Name, "gatewayapi")
 	assert.Equal(references.ObjectReferences[0].Namespace, "bookinfo")
 	assert.Equal(references.ObjectReferences[0].ObjectType, "k8sgateway")
-	// Reference Grant
+	// Reference Grant authorizing the backend in bookinfo2
 	assert.Equal(references.ObjectReferences[1].Name, "rg")
-	assert.Equal(references.ObjectReferences[1].Namespace, "bookinfo")
+	assert.Equal(references.ObjectReferences[1].Namespace, "bookinfo2")
 	assert.Equal(references.ObjectReferences[1].ObjectType, "k8sreferencegrant")
 }
 
@@ -62,3 +63,70 @@ func TestK8sHTTPRouteNoReferences(t *testing.T) {
 	references := prepareTestForK8sHTTPRoute(data.CreateEmptyHTTPRoute("route1", "bookinfo", []string{"details"}))
 	assert.Empty(references.ServiceReferences)
 }
+
+func TestK8sHTTPRouteReferenceGrantChain(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	route := data.AddBackendRefToHTTPRoute("reviews", "bookinfo2", data.CreateHTTPRoute("route1", "bookinfo", "gatewayapi", []string{"bookinfo"}))
+
+	routeReferences := K8sHTTPRouteReferences{
+		Namespaces:    models.Namespaces{{Name: "bookinfo"}, {Name: "bookinfo2"}},
+		K8sHTTPRoutes: []*k8s_networking_v1.HTTPRoute{route},
+		K8sReferenceGrants: []*k8s_networking_v1beta1.ReferenceGrant{
+			// Grants in the route namespace don't authorize anything
+			data.CreateReferenceGrant("local", "bookinfo", "bookinfo"),
+			// Grants for other namespaces or route kinds don't authorize the route
+			data.CreateReferenceGrant("other-ns", "bookinfo2", "default"),
+			data.CreateReferenceGrantByKind("grpc-only", "bookinfo2", "bookinfo", kubernetes.K8sActualGRPCRouteType),
+			data.CreateReferenceGrant("allowed", "bookinfo2", "bookinfo"),
+		},
+	}
+	references := routeReferences.References()[models.IstioReferenceKey{ObjectType: "k8shttproute", Namespace: "bookinfo", Name: "route1"}]
+
+	assert.Equal([]models.IstioReference{
+		{Name: "gatewayapi", Namespace: "bookinfo", ObjectType: "k8sgateway"},
+		{Name: "allowed", Namespace: "bookinfo2", ObjectType: "k8sreferencegrant"},
+	}, references.ObjectReferences)
+}
+
+func TestK8sHTTPRouteCrossNamespaceGateways(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	route := data.AddParentRefToHTTPRoute("shared", "infra", data.AddParentRefToHTTPRoute("private", "infra", data.CreateEmptyHTTPRoute("route1", "bookinfo", []string{"bookinfo"})))
+
+	routeReferences := K8sHTTPRouteReferences{
+		Namespaces:    models.Namespaces{{Name: "bookinfo"}, {Name: "infra"}},
+		K8sHTTPRoutes: []*k8s_networking_v1.HTTPRoute{route},
+		K8sGateways: []*k8s_networking_v1.Gateway{
+			// Listeners only admit routes of their own namespace by default
+			data.AddListenerToK8sGateway(data.CreateListener("http", "bookinfo.com", 80, "HTTP"), data.CreateEmptyK8sGateway("private", "infra")),
+			data.AddListenerToK8sGateway(data.AllowRoutesFromAllNamespaces(data.CreateListener("http", "bookinfo.com", 80, "HTTP")), data.CreateEmptyK8sGateway("shared", "infra")),
+		},
+	}
+	references := routeReferences.References()[models.IstioReferenceKey{ObjectType: "k8shttproute", Namespace: "bookinfo", Name: "route1"}]
+
+	assert.Equal([]models.IstioReference{{Name: "shared", Namespace: "infra", ObjectType: "k8sgateway"}}, references.ObjectReferences)
+}
+
+func TestListenerAllowsNamespaceSelector(t *testing.T) {
+	assert := assert.New(t)
+
+	from := k8s_networking_v1.NamespacesFromSelector
+	listener := data.CreateListener("http", "bookinfo.com", 80, "HTTP")
+	listener.AllowedRoutes = &k8s_networking_v1.AllowedRoutes{Namespaces: &k8s_networking_v1.RouteNamespaces{
+		From:     &from,
+		Selector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"shared-gateway-access": "true"}},
+	}}
+	namespaces := models.Namespaces{
+		{Name: "bookinfo", Labels: map[string]string{"shared-gateway-access": "true"}},
+		{Name: "default"},
+	}
+
+	assert.True(listenerAllowsNamespace(listener, "infra", "bookinfo", namespaces))
+	assert.False(listenerAllowsNamespace(listener, "infra", "default", namespaces))
+	assert.False(listenerAllowsNamespace(listener, "infra", "unknown", namespaces))
+}
@@ -0,0 +1,83 @@
+package references
+
+import (
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// getSelectorWorkloadReferences returns the workloads of the namespace matching the workload selector of a policy.
+func getSelectorWorkloadReferences(selector *api_v1beta1.WorkloadSelector, namespace string, workloadsPerNamespace map[string]models.WorkloadList) []models.WorkloadReference {
+	result := make([]models.WorkloadReference, 0)
+	if selector == nil || len(selector.MatchLabels) == 0 {
+		return result
+	}
+
+	labelSelector := labels.SelectorFromSet(selector.MatchLabels)
+	for _, wl := range workloadsPerNamespace[namespace].Workloads {
+		if labelSelector.Matches(labels.Set(wl.Labels)) {
+			result = append(result, models.WorkloadReference{Name: wl.Name, Namespace: namespace})
+		}
+	}
+	return result
+}
+
+// getTargetRefReferences returns the K8s Gateway or the Service the targetRef of a policy points to.
+func getTargetRefReferences(targetRef *api_v1beta1.PolicyTargetReference, namespace string) ([]models.IstioReference, []models.ServiceReference) {
+	objectRefs, serviceRefs := make([]models.IstioReference, 0), make([]models.ServiceReference, 0)
+	if targetRef == nil || targetRef.Name == "" {
+		return objectRefs, serviceRefs
+	}
+
+	if targetRef.Namespace != "" {
+		namespace = targetRef.Namespace
+	}
+	switch {
+	case targetRef.Kind == kubernetes.K8sActualGatewayType && targetRef.Group == kubernetes.K8sNetworkingGroupVersionV1.Group:
+		objectRefs = append(objectRefs, models.IstioReference{Name: targetRef.Name, Namespace: namespace, ObjectType: models.ObjectTypeSingular[kubernetes.K8sGateways]})
+	case targetRef.Kind == "Service" && (targetRef.Group == "" || targetRef.Group == "core"):
+		serviceRefs = append(serviceRefs, models.ServiceReference{Name: targetRef.Name, Namespace: namespace})
+	}
+	return objectRefs, serviceRefs
+}
+
+// getTargetRefGatewayReferences returns the K8s Gateways a targetRef applies to, either the Gateway itself
+// or all the Gateways of a GatewayClass, along with the workloads deployed for them (e.g. waypoint proxies).
+func getTargetRefGatewayReferences(targetRef *api_v1beta1.PolicyTargetReference, namespace string, k8sGateways []*k8s_networking_v1.Gateway, workloadsPerNamespace map[string]models.WorkloadList) ([]models.IstioReference, []models.WorkloadReference) {
+	objectRefs, workloadRefs := make([]models.IstioReference, 0), make([]models.WorkloadReference, 0)
+	if targetRef == nil || targetRef.Name == "" || targetRef.Group != kubernetes.K8sNetworkingGroupVersionV1.Group {
+		return objectRefs, workloadRefs
+	}
+
+	if targetRef.Namespace != "" {
+		namespace = targetRef.Namespace
+	}
+	switch targetRef.Kind {
+	case kubernetes.K8sActualGatewayType:
+		objectRefs = append(objectRefs, models.IstioReference{Name: targetRef.Name, Namespace: namespace, ObjectType: models.ObjectTypeSingular[kubernetes.K8sGateways]})
+		workloadRefs = append(workloadRefs, getK8sGatewayWorkloadReferences(targetRef.Name, namespace, workloadsPerNamespace)...)
+	case kubernetes.K8sGatewayClassType:
+		// GatewayClasses are cluster scoped
+		for _, gw := range k8sGateways {
+			if string(gw.Spec.GatewayClassName) == targetRef.Name {
+				objectRefs = append(objectRefs, models.IstioReference{Name: gw.Name, Namespace: gw.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.K8sGateways]})
+				workloadRefs = append(workloadRefs, getK8sGatewayWorkloadReferences(gw.Name, gw.Namespace, workloadsPerNamespace)...)
+			}
+		}
+	}
+	return objectRefs, workloadRefs
+}
+
+// getK8sGatewayWorkloadReferences returns the workloads deployed for the K8s Gateway.
+func getK8sGatewayWorkloadReferences(gateway, namespace string, workloadsPerNamespace map[string]models.WorkloadList) []models.WorkloadReference {
+	result := make([]models.WorkloadReference, 0)
+	for _, wl := range workloadsPerNamespace[namespace].Workloads {
+		if wl.Labels[kubernetes.K8sGatewayNameLabel] == gateway {
+			result = append(result, models.WorkloadReference{Name: wl.Name, Namespace: namespace})
+		}
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package references
+
+import (
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers/authorization"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type RequestAuthnReferences struct {
+	AuthorizationPolicies  []*security_v1beta.AuthorizationPolicy
+	RequestAuthentications []*security_v1beta.RequestAuthentication
+	WorkloadsPerNamespace  map[string]models.WorkloadList
+}
+
+func (n RequestAuthnReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, ra := range n.RequestAuthentications {
+		key := models.IstioReferenceKey{Namespace: ra.Namespace, Name: ra.Name, ObjectType: models.ObjectTypeSingular[kubernetes.RequestAuthentications]}
+		references := &models.IstioReferences{}
+		references.ObjectReferences = n.getConfigReferences(ra)
+		references.WorkloadReferences = n.getWorkloadReferences(ra)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
+
+// getConfigReferences returns the AuthorizationPolicies relying on the request authentication of the workloads of the RequestAuthentication.
+func (n RequestAuthnReferences) getConfigReferences(ra *security_v1beta.RequestAuthentication) []models.IstioReference {
+	keys := make(map[string]bool)
+	result := make([]models.IstioReference, 0)
+	rbacDetails := kubernetes.RBACDetails{AuthorizationPolicies: n.AuthorizationPolicies}
+
+	for _, wls := range n.WorkloadsPerNamespace {
+		for _, wl := range wls.Workloads {
+			if len(kubernetes.FilterWorkloadRequestAuthentications([]*security_v1beta.RequestAuthentication{ra}, wls.Namespace, wl.Labels)) == 0 {
+				continue
+			}
+			for _, ap := range rbacDetails.WorkloadAuthorizationPolicies(wls.Namespace, wl.Labels) {
+				if keys[ap.Name+"."+ap.Namespace] || len(authorization.RequestAuthPaths(ap)) == 0 {
+					continue
+				}
+				keys[ap.Name+"."+ap.Namespace] = true
+				result = append(result, models.IstioReference{Name: ap.Name, Namespace: ap.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.AuthorizationPolicies]})
+			}
+		}
+	}
+	return result
+}
+
+func (n RequestAuthnReferences) getWorkloadReferences(ra *security_v1beta.RequestAuthentication) []models.WorkloadReference {
+	result := make([]models.WorkloadReference, 0)
+	if ra.Spec.Selector != nil {
+		selector := labels.SelectorFromSet(ra.Spec.Selector.MatchLabels)
+
+		// RequestAuthn searches Workloads from own namespace, or from all namespaces when it is in root namespace
+		for _, wls := range n.WorkloadsPerNamespace {
+			if !config.IsRootNamespace(ra.Namespace) && wls.Namespace != ra.Namespace {
+				continue
+			}
+			for _, wl := range wls.Workloads {
+				if selector.Matches(labels.Set(wl.Labels)) {
+					result = append(result, models.WorkloadReference{Name: wl.Name, Namespace: wls.Namespace})
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_security_v1beta "istio.io/api/security/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func prepareTestForRequestAuthn(ra *security_v1beta.RequestAuthentication) models.IstioReferences {
+	requestPrincipalsPolicy := data.CreateAuthorizationPolicyWithPrincipals("jwt-policy", "bookinfo", []string{})
+	requestPrincipalsPolicy.Spec.Rules[0].From[0].Source.RequestPrincipals = []string{"issuer-foo/*"}
+
+	claimsPolicy := data.CreateAuthorizationPolicyWithPrincipals("claims-policy", "bookinfo", []string{})
+	claimsPolicy.Spec.Selector = nil
+	claimsPolicy.Spec.Rules[0].When = []*api_security_v1beta.Condition{{Key: "request.auth.claims[groups]", Values: []string{"admins"}}}
+
+	raReferences := RequestAuthnReferences{
+		RequestAuthentications: []*security_v1beta.RequestAuthentication{ra},
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{
+			data.CreateAuthorizationPolicyWithPrincipals("principals-policy", "bookinfo", []string{"cluster.local/ns/bookinfo/sa/default"}),
+			requestPrincipalsPolicy,
+			claimsPolicy,
+		},
+		WorkloadsPerNamespace: map[string]models.WorkloadList{
+			"istio-system": data.CreateWorkloadList("istio-system",
+				data.CreateWorkloadListItem("grafana", map[string]string{"app": "grafana"})),
+			"bookinfo": data.CreateWorkloadList("bookinfo",
+				data.CreateWorkloadListItem("details", map[string]string{"app": "details"})),
+		},
+	}
+	return *raReferences.References()[models.IstioReferenceKey{ObjectType: "requestauthentication", Namespace: ra.Namespace, Name: ra.Name}]
+}
+
+func TestRequestAuthnReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	references := prepareTestForRequestAuthn(data.CreateRequestAuthentication("jwt", "bookinfo", map[string]string{"app": "details"},
+		data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")))
+
+	assert.Empty(references.ServiceReferences)
+
+	// Check Workload references
+	assert.Len(references.WorkloadReferences, 1)
+	assert.Equal("details", references.WorkloadReferences[0].Name)
+	assert.Equal("bookinfo", references.WorkloadReferences[0].Namespace)
+
+	// Only the policies relying on the request authentication are referenced
+	assert.ElementsMatch([]models.IstioReference{
+		{Name: "jwt-policy", Namespace: "bookinfo", ObjectType: "authorizationpolicy"},
+		{Name: "claims-policy", Namespace: "bookinfo", ObjectType: "authorizationpolicy"},
+	}, references.ObjectReferences)
+}
+
+func TestMeshRequestAuthnReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	references := prepareTestForRequestAuthn(data.CreateRequestAuthentication("jwt", "istio-system", nil,
+		data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")))
+
+	// Mesh-wide RequestAuthentications don't reference workloads
+	assert.Empty(references.WorkloadReferences)
+	assert.Len(references.ObjectReferences, 2)
+}
+
+func TestRequestAuthnNoReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	references := prepareTestForRequestAuthn(data.CreateRequestAuthentication("jwt", "bookinfo", map[string]string{"app": "reviews"},
+		data.CreateJWTRule("issuer-foo", "https://example.com/jwks.json")))
+
+	assert.Empty(references.WorkloadReferences)
+	assert.Empty(references.ObjectReferences)
+}
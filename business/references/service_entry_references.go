@@ -16,6 +16,8 @@ type ServiceEntryReferences struct {
 	AuthorizationPolicies []*security_v1beta.AuthorizationPolicy
 	DestinationRules      []*networking_v1beta1.DestinationRule
 	RegistryServices      []*kubernetes.RegistryService
+	WorkloadEntries       []*networking_v1beta1.WorkloadEntry
+	WorkloadGroups        []*networking_v1beta1.WorkloadGroup
 }
 
 func (n ServiceEntryReferences) References() models.IstioReferencesMap {
@@ -73,6 +75,23 @@ func (n ServiceEntryReferences) getConfigReferences(se *networking_v1beta1.Servi
 		}
 	}
 	result = append(result, n.getAuthPoliciesReferences(se)...)
+	result = append(result, n.getWorkloadReferences(se)...)
+	return result
+}
+
+// getWorkloadReferences returns the WorkloadEntries and WorkloadGroups backing the ServiceEntry.
+func (n ServiceEntryReferences) getWorkloadReferences(se *networking_v1beta1.ServiceEntry) []models.IstioReference {
+	result := make([]models.IstioReference, 0)
+	for _, we := range n.WorkloadEntries {
+		if selectsWorkloadLabels(se, we.Namespace, we.Spec.Labels) {
+			result = append(result, models.IstioReference{Name: we.Name, Namespace: we.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.WorkloadEntries]})
+		}
+	}
+	for _, wg := range n.WorkloadGroups {
+		if selectsWorkloadLabels(se, wg.Namespace, workloadGroupLabels(wg)) {
+			result = append(result, models.IstioReference{Name: wg.Name, Namespace: wg.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.WorkloadGroups]})
+		}
+	}
 	return result
 }
 
@@ -0,0 +1,27 @@
+package references
+
+import (
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type TelemetryReferences struct {
+	Telemetries           []*v1alpha1.Telemetry
+	WorkloadsPerNamespace map[string]models.WorkloadList
+}
+
+func (n TelemetryReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, t := range n.Telemetries {
+		key := models.IstioReferenceKey{Namespace: t.Namespace, Name: t.Name, ObjectType: models.ObjectTypeSingular[kubernetes.Telemetries]}
+		references := &models.IstioReferences{}
+		references.WorkloadReferences = getSelectorWorkloadReferences(t.Spec.Selector, t.Namespace, n.WorkloadsPerNamespace)
+		references.ObjectReferences, references.ServiceReferences = getTargetRefReferences(t.Spec.TargetRef, t.Namespace)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
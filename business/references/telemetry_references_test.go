@@ -0,0 +1,81 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func prepareTestForTelemetry(t *v1alpha1.Telemetry) models.IstioReferences {
+	telemetryReferences := TelemetryReferences{
+		Telemetries:           []*v1alpha1.Telemetry{t},
+		WorkloadsPerNamespace: policyTargetWorkloads(),
+	}
+	return *telemetryReferences.References()[models.IstioReferenceKey{ObjectType: "telemetry", Namespace: t.Namespace, Name: t.Name}]
+}
+
+func TestTelemetrySelectorReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	telemetry := &v1alpha1.Telemetry{}
+	telemetry.Name = "reviews-tracing"
+	telemetry.Namespace = "bookinfo"
+	telemetry.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "reviews"}}
+
+	references := prepareTestForTelemetry(telemetry)
+
+	assert.Empty(references.ServiceReferences)
+	assert.Empty(references.ObjectReferences)
+	assert.ElementsMatch([]models.WorkloadReference{
+		{Name: "reviews-v1", Namespace: "bookinfo"},
+		{Name: "reviews-v2", Namespace: "bookinfo"},
+	}, references.WorkloadReferences)
+}
+
+func TestTelemetryTargetRefReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	telemetry := &v1alpha1.Telemetry{}
+	telemetry.Name = "gateway-logging"
+	telemetry.Namespace = "bookinfo"
+	telemetry.Spec.TargetRef = &api_v1beta1.PolicyTargetReference{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "bookinfo-gateway"}
+
+	references := prepareTestForTelemetry(telemetry)
+
+	assert.Empty(references.ServiceReferences)
+	assert.Empty(references.WorkloadReferences)
+	assert.Equal([]models.IstioReference{{Name: "bookinfo-gateway", Namespace: "bookinfo", ObjectType: "k8sgateway"}}, references.ObjectReferences)
+}
+
+func TestTelemetryNamespaceWideReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	telemetry := &v1alpha1.Telemetry{}
+	telemetry.Name = "mesh-default"
+	telemetry.Namespace = "istio-system"
+
+	references := prepareTestForTelemetry(telemetry)
+
+	assert.Empty(references.ServiceReferences)
+	assert.Empty(references.WorkloadReferences)
+	assert.Empty(references.ObjectReferences)
+}
+
+func policyTargetWorkloads() map[string]models.WorkloadList {
+	return map[string]models.WorkloadList{
+		"bookinfo": data.CreateWorkloadList("bookinfo",
+			data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+			data.CreateWorkloadListItem("reviews-v2", map[string]string{"app": "reviews", "version": "v2"}),
+			data.CreateWorkloadListItem("details-v1", map[string]string{"app": "details", "version": "v1"}),
+		),
+	}
+}
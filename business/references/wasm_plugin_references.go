@@ -0,0 +1,27 @@
+package references
+
+import (
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type WasmPluginReferences struct {
+	WasmPlugins           []*extensions_v1alpha1.WasmPlugin
+	WorkloadsPerNamespace map[string]models.WorkloadList
+}
+
+func (n WasmPluginReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, wp := range n.WasmPlugins {
+		key := models.IstioReferenceKey{Namespace: wp.Namespace, Name: wp.Name, ObjectType: models.ObjectTypeSingular[kubernetes.WasmPlugins]}
+		references := &models.IstioReferences{}
+		references.WorkloadReferences = getSelectorWorkloadReferences(wp.Spec.Selector, wp.Namespace, n.WorkloadsPerNamespace)
+		references.ObjectReferences, references.ServiceReferences = getTargetRefReferences(wp.Spec.TargetRef, wp.Namespace)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	extensions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func prepareTestForWasmPlugin(wp *extensions_v1alpha1.WasmPlugin) models.IstioReferences {
+	wasmPluginReferences := WasmPluginReferences{
+		WasmPlugins:           []*extensions_v1alpha1.WasmPlugin{wp},
+		WorkloadsPerNamespace: policyTargetWorkloads(),
+	}
+	return *wasmPluginReferences.References()[models.IstioReferenceKey{ObjectType: models.ObjectTypeSingular["wasmplugins"], Namespace: wp.Namespace, Name: wp.Name}]
+}
+
+func TestWasmPluginSelectorReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	wp := &extensions_v1alpha1.WasmPlugin{}
+	wp.Name = "details-auth"
+	wp.Namespace = "bookinfo"
+	wp.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "details"}}
+
+	references := prepareTestForWasmPlugin(wp)
+
+	assert.Empty(references.ServiceReferences)
+	assert.Empty(references.ObjectReferences)
+	assert.Equal([]models.WorkloadReference{{Name: "details-v1", Namespace: "bookinfo"}}, references.WorkloadReferences)
+}
+
+func TestWasmPluginTargetRefReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	wp := &extensions_v1alpha1.WasmPlugin{}
+	wp.Name = "reviews-auth"
+	wp.Namespace = "bookinfo"
+	wp.Spec.TargetRef = &api_v1beta1.PolicyTargetReference{Kind: "Service", Name: "reviews"}
+
+	references := prepareTestForWasmPlugin(wp)
+
+	assert.Empty(references.WorkloadReferences)
+	assert.Empty(references.ObjectReferences)
+	assert.Equal([]models.ServiceReference{{Name: "reviews", Namespace: "bookinfo"}}, references.ServiceReferences)
+}
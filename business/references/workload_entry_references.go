@@ -0,0 +1,66 @@
+package references
+
+import (
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type WorkloadEntryReferences struct {
+	ServiceEntries  []*networking_v1beta1.ServiceEntry
+	WorkloadEntries []*networking_v1beta1.WorkloadEntry
+	WorkloadGroups  []*networking_v1beta1.WorkloadGroup
+}
+
+func (n WorkloadEntryReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, we := range n.WorkloadEntries {
+		key := models.IstioReferenceKey{Namespace: we.Namespace, Name: we.Name, ObjectType: models.ObjectTypeSingular[kubernetes.WorkloadEntries]}
+		references := &models.IstioReferences{}
+		references.ObjectReferences = n.getConfigReferences(we)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
+
+// getConfigReferences returns the ServiceEntries selecting the WorkloadEntry and the WorkloadGroup that registered it.
+func (n WorkloadEntryReferences) getConfigReferences(we *networking_v1beta1.WorkloadEntry) []models.IstioReference {
+	result := make([]models.IstioReference, 0)
+	for _, se := range n.ServiceEntries {
+		if selectsWorkloadLabels(se, we.Namespace, we.Spec.Labels) {
+			result = append(result, models.IstioReference{Name: se.Name, Namespace: se.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.ServiceEntries]})
+		}
+	}
+	for _, wg := range n.WorkloadGroups {
+		if isOwnedByWorkloadGroup(we, wg) {
+			result = append(result, models.IstioReference{Name: wg.Name, Namespace: wg.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.WorkloadGroups]})
+		}
+	}
+	return result
+}
+
+// selectsWorkloadLabels returns true when the workloadSelector of the ServiceEntry selects the labels of a workload
+// of the namespace. A ServiceEntry only selects workloads of its own namespace.
+func selectsWorkloadLabels(se *networking_v1beta1.ServiceEntry, namespace string, workloadLabels map[string]string) bool {
+	if se.Namespace != namespace || se.Spec.WorkloadSelector == nil || len(se.Spec.WorkloadSelector.Labels) == 0 {
+		return false
+	}
+	return labels.SelectorFromSet(se.Spec.WorkloadSelector.Labels).Matches(labels.Set(workloadLabels))
+}
+
+// isOwnedByWorkloadGroup returns true when the WorkloadEntry was auto-registered from the WorkloadGroup.
+func isOwnedByWorkloadGroup(we *networking_v1beta1.WorkloadEntry, wg *networking_v1beta1.WorkloadGroup) bool {
+	if we.Namespace != wg.Namespace {
+		return false
+	}
+	for _, owner := range we.OwnerReferences {
+		if owner.Kind == kubernetes.WorkloadGroupType && owner.Name == wg.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func prepareTestForWorkloadEntry(we *networking_v1beta1.WorkloadEntry) models.IstioReferences {
+	weReferences := WorkloadEntryReferences{
+		ServiceEntries:  vmServiceEntries(),
+		WorkloadEntries: []*networking_v1beta1.WorkloadEntry{we},
+		WorkloadGroups:  []*networking_v1beta1.WorkloadGroup{vmWorkloadGroup("ratings-vm", "bookinfo", "ratings")},
+	}
+	return *weReferences.References()[models.IstioReferenceKey{ObjectType: "workloadentry", Namespace: we.Namespace, Name: we.Name}]
+}
+
+func TestWorkloadEntryReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	references := prepareTestForWorkloadEntry(vmWorkloadEntry("ratings-10.0.0.1", "bookinfo", "ratings", "ratings-vm"))
+
+	assert.Empty(references.ServiceReferences)
+	assert.Empty(references.WorkloadReferences)
+
+	// Check SE and WorkloadGroup references
+	assert.Len(references.ObjectReferences, 2)
+	assert.Equal(models.IstioReference{Name: "ratings-vm", Namespace: "bookinfo", ObjectType: "serviceentry"}, references.ObjectReferences[0])
+	assert.Equal(models.IstioReference{Name: "ratings-vm", Namespace: "bookinfo", ObjectType: "workloadgroup"}, references.ObjectReferences[1])
+}
+
+func TestWorkloadEntryNoReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	// Manually created and in a different namespace than the ServiceEntry selecting its labels
+	references := prepareTestForWorkloadEntry(vmWorkloadEntry("ratings-10.0.0.1", "bookinfo2", "ratings", ""))
+
+	assert.Empty(references.ObjectReferences)
+}
+
+func TestServiceEntryWorkloadEntriesReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	seReferences := ServiceEntryReferences{
+		ServiceEntries: vmServiceEntries(),
+		WorkloadEntries: []*networking_v1beta1.WorkloadEntry{
+			vmWorkloadEntry("ratings-10.0.0.1", "bookinfo", "ratings", "ratings-vm"),
+			vmWorkloadEntry("details-10.0.0.2", "bookinfo", "details", ""),
+		},
+		WorkloadGroups: []*networking_v1beta1.WorkloadGroup{vmWorkloadGroup("ratings-vm", "bookinfo", "ratings")},
+	}
+	references := seReferences.References()[models.IstioReferenceKey{ObjectType: "serviceentry", Namespace: "bookinfo", Name: "ratings-vm"}]
+
+	assert.Len(references.ObjectReferences, 2)
+	assert.Equal(models.IstioReference{Name: "ratings-10.0.0.1", Namespace: "bookinfo", ObjectType: "workloadentry"}, references.ObjectReferences[0])
+	assert.Equal(models.IstioReference{Name: "ratings-vm", Namespace: "bookinfo", ObjectType: "workloadgroup"}, references.ObjectReferences[1])
+
+	// ServiceEntries without workloadSelector don't select WorkloadEntries
+	references = seReferences.References()[models.IstioReferenceKey{ObjectType: "serviceentry", Namespace: "bookinfo", Name: "external"}]
+	assert.Empty(references.ObjectReferences)
+}
+
+func vmServiceEntries() []*networking_v1beta1.ServiceEntry {
+	se := data.CreateEmptyMeshInternalServiceEntry("ratings-vm", "bookinfo", []string{"ratings.bookinfo.svc.cluster.local"})
+	se.Spec.WorkloadSelector = &api_networking_v1beta1.WorkloadSelector{Labels: map[string]string{"app": "ratings"}}
+	return []*networking_v1beta1.ServiceEntry{
+		se,
+		data.CreateEmptyMeshExternalServiceEntry("external", "bookinfo", []string{"www.example.com"}),
+	}
+}
+
+func vmWorkloadEntry(name, namespace, app, workloadGroup string) *networking_v1beta1.WorkloadEntry {
+	we := &networking_v1beta1.WorkloadEntry{}
+	we.Name = name
+	we.Namespace = namespace
+	we.Spec.Address = "10.0.0.1"
+	we.Spec.Labels = map[string]string{"app": app}
+	if workloadGroup != "" {
+		we.OwnerReferences = []meta_v1.OwnerReference{{Kind: "WorkloadGroup", Name: workloadGroup}}
+	}
+	return we
+}
+
+func vmWorkloadGroup(name, namespace, app string) *networking_v1beta1.WorkloadGroup {
+	wg := &networking_v1beta1.WorkloadGroup{}
+	wg.Name = name
+	wg.Namespace = namespace
+	wg.Spec.Metadata = &api_networking_v1beta1.WorkloadGroup_ObjectMeta{Labels: map[string]string{"app": app}}
+	return wg
+}
@@ -0,0 +1,59 @@
+package references
+
+import (
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type WorkloadGroupReferences struct {
+	ServiceEntries  []*networking_v1beta1.ServiceEntry
+	WorkloadEntries []*networking_v1beta1.WorkloadEntry
+	WorkloadGroups  []*networking_v1beta1.WorkloadGroup
+}
+
+func (n WorkloadGroupReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, wg := range n.WorkloadGroups {
+		key := models.IstioReferenceKey{Namespace: wg.Namespace, Name: wg.Name, ObjectType: models.ObjectTypeSingular[kubernetes.WorkloadGroups]}
+		references := &models.IstioReferences{}
+		references.ObjectReferences = n.getConfigReferences(wg)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
+
+// getConfigReferences returns the ServiceEntries selecting the workloads of the WorkloadGroup and the WorkloadEntries registered from it.
+func (n WorkloadGroupReferences) getConfigReferences(wg *networking_v1beta1.WorkloadGroup) []models.IstioReference {
+	result := make([]models.IstioReference, 0)
+	for _, se := range n.ServiceEntries {
+		if selectsWorkloadLabels(se, wg.Namespace, workloadGroupLabels(wg)) {
+			result = append(result, models.IstioReference{Name: se.Name, Namespace: se.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.ServiceEntries]})
+		}
+	}
+	for _, we := range n.WorkloadEntries {
+		if isOwnedByWorkloadGroup(we, wg) {
+			result = append(result, models.IstioReference{Name: we.Name, Namespace: we.Namespace, ObjectType: models.ObjectTypeSingular[kubernetes.WorkloadEntries]})
+		}
+	}
+	return result
+}
+
+// workloadGroupLabels returns the labels of the WorkloadEntries generated from the WorkloadGroup.
+func workloadGroupLabels(wg *networking_v1beta1.WorkloadGroup) map[string]string {
+	result := map[string]string{}
+	if wg.Spec.Template != nil {
+		for k, v := range wg.Spec.Template.Labels {
+			result[k] = v
+		}
+	}
+	if wg.Spec.Metadata != nil {
+		for k, v := range wg.Spec.Metadata.Labels {
+			result[k] = v
+		}
+	}
+	return result
+}
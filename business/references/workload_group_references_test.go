@@ -0,0 +1,35 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestWorkloadGroupReferences(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	wgReferences := WorkloadGroupReferences{
+		ServiceEntries: vmServiceEntries(),
+		WorkloadEntries: []*networking_v1beta1.WorkloadEntry{
+			vmWorkloadEntry("ratings-10.0.0.1", "bookinfo", "ratings", "ratings-vm"),
+			vmWorkloadEntry("ratings-10.0.0.2", "bookinfo", "ratings", ""),
+			vmWorkloadEntry("ratings-10.0.0.3", "bookinfo2", "ratings", "ratings-vm"),
+		},
+		WorkloadGroups: []*networking_v1beta1.WorkloadGroup{vmWorkloadGroup("ratings-vm", "bookinfo", "ratings")},
+	}
+	references := wgReferences.References()[models.IstioReferenceKey{ObjectType: "workloadgroup", Namespace: "bookinfo", Name: "ratings-vm"}]
+
+	assert.Empty(references.ServiceReferences)
+	assert.Empty(references.WorkloadReferences)
+
+	// Only the WorkloadEntries registered from the group are referenced
+	assert.Len(references.ObjectReferences, 2)
+	assert.Equal(models.IstioReference{Name: "ratings-vm", Namespace: "bookinfo", ObjectType: "serviceentry"}, references.ObjectReferences[0])
+	assert.Equal(models.IstioReference{Name: "ratings-10.0.0.1", Namespace: "bookinfo", ObjectType: "workloadentry"}, references.ObjectReferences[1])
+}
@@ -0,0 +1,101 @@
+package business
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/kiali/kiali/kubernetes/cache"
+)
+
+// ResourcesVersion identifies the state of the cached objects the lists of the namespaces of a cluster are built
+// from: the Istio config of the cluster and, when includeWorkloads is set, the services, workloads and pods of the
+// namespaces. It changes whenever one of them is added, updated or deleted so it is cheap to compare before
+// building a list.
+func (in *NamespaceService) ResourcesVersion(cluster string, namespaces []string, includeWorkloads bool) (string, error) {
+	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		return "", err
+	}
+
+	sorted := append([]string{}, namespaces...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s:%d\n", cluster, kubeCache.ConfigVersion())
+	for _, namespace := range sorted {
+		fmt.Fprintf(h, "%s\n", namespace)
+		if !includeWorkloads {
+			continue
+		}
+		versions, err := namespaceResourceVersions(kubeCache, namespace)
+		if err != nil {
+			return "", err
+		}
+		for _, version := range versions {
+			fmt.Fprintf(h, "%s\n", version)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:16]), nil
+}
+
+// namespaceResourceVersions returns the sorted resourceVersions of the cached services, workloads and pods of a namespace.
+func namespaceResourceVersions(kubeCache cache.KubeCache, namespace string) ([]string, error) {
+	versions := []string{}
+	add := func(kind, name, resourceVersion string) {
+		versions = append(versions, kind+"/"+name+"="+resourceVersion)
+	}
+
+	services, err := kubeCache.GetServices(namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range services {
+		add("Service", s.Name, s.ResourceVersion)
+	}
+
+	deployments, err := kubeCache.GetDeployments(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments {
+		add("Deployment", d.Name, d.ResourceVersion)
+	}
+
+	replicaSets, err := kubeCache.GetReplicaSets(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range replicaSets {
+		add("ReplicaSet", rs.Name, rs.ResourceVersion)
+	}
+
+	statefulSets, err := kubeCache.GetStatefulSets(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, ss := range statefulSets {
+		add("StatefulSet", ss.Name, ss.ResourceVersion)
+	}
+
+	daemonSets, err := kubeCache.GetDaemonSets(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range daemonSets {
+		add("DaemonSet", ds.Name, ds.ResourceVersion)
+	}
+
+	pods, err := kubeCache.GetPods(namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pods {
+		add("Pod", p.Name, p.ResourceVersion)
+	}
+
+	sort.Strings(versions)
+	return versions, nil
+}
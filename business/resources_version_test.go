@@ -0,0 +1,42 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestResourcesVersion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	version := func(podVersion string, namespaces []string, includeWorkloads bool) string {
+		k8s := kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			&core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo", ResourceVersion: podVersion}},
+		)
+		SetupBusinessLayer(t, k8s, *conf)
+		clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+		v, err := NewWithBackends(clients, clients, nil, nil).Namespace.ResourcesVersion(conf.KubernetesConfig.ClusterName, namespaces, includeWorkloads)
+		require.NoError(err)
+		return v
+	}
+
+	v1 := version("1", []string{"bookinfo"}, true)
+	assert.Equal(v1, version("1", []string{"bookinfo"}, true))
+	assert.NotEqual(v1, version("2", []string{"bookinfo"}, true))
+	assert.NotEqual(v1, version("1", []string{"bookinfo", "travels"}, true))
+
+	// The pods are not part of the version of the Istio config
+	assert.Equal(version("1", []string{"bookinfo"}, false), version("2", []string{"bookinfo"}, false))
+}
@@ -6,10 +6,12 @@ import (
 	"sync"
 	"time"
 
+	osroutes_v1 "github.com/openshift/api/route/v1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/business/checkers"
@@ -20,6 +22,7 @@ import (
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
 
 // SvcService deals with fetching istio/kubernetes services related content and convert to kiali model
@@ -58,6 +61,8 @@ func (in *SvcService) GetServiceList(ctx context.Context, criteria ServiceCriter
 		observability.Attribute("queryTime", criteria.QueryTime),
 	)
 	defer end()
+	promtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GetServiceList", criteria.Cluster, criteria.Namespace)
+	defer promtimer.ObserveDuration()
 
 	serviceList := models.ServiceList{
 		Services:    []models.ServiceOverview{},
@@ -80,7 +85,7 @@ func (in *SvcService) GetServiceList(ctx context.Context, criteria ServiceCriter
 
 			if errors.IsNotFound(err) || errors.IsForbidden(err) {
 				// If a cluster is not found or not accessible, then we skip it
-				log.Debugf("Error while accessing to cluster [%s]: %s", cluster, err.Error())
+				log.FromContext(ctx).Debug().Msgf("Error while accessing to cluster [%s]: %s", cluster, err.Error())
 				continue
 			}
 
@@ -94,7 +99,7 @@ func (in *SvcService) GetServiceList(ctx context.Context, criteria ServiceCriter
 				return nil, err
 			}
 
-			log.Errorf("Unable to get services list from cluster: %s. Err: %s. Skipping", cluster, err)
+			log.FromContext(ctx).Error().Msgf("Unable to get services list from cluster: %s. Err: %s. Skipping", cluster, err)
 			continue
 		}
 
@@ -127,13 +132,13 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 		if selector, err := labels.ConvertSelectorToLabelsMap(criteria.ServiceSelector); err == nil {
 			selectorLabels = selector
 		} else {
-			log.Warningf("Services not filtered. Selector %s not valid", criteria.ServiceSelector)
+			log.FromContext(ctx).Warn().Msgf("Services not filtered. Selector %s not valid", criteria.ServiceSelector)
 		}
 	}
 
 	svcs, err = kubeCache.GetServicesBySelectorLabels(criteria.Namespace, selectorLabels)
 	if err != nil {
-		log.Errorf("Error fetching Services per namespace %s: %s", criteria.Namespace, err)
+		log.FromContext(ctx).Error().Msgf("Error fetching Services per namespace %s: %s", criteria.Namespace, err)
 		return nil, err
 	}
 
@@ -149,7 +154,7 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 	if !criteria.IncludeOnlyDefinitions {
 		pods, err = kubeCache.GetPods(criteria.Namespace, "")
 		if err != nil {
-			log.Errorf("Error fetching Pods per namespace %s: %s", criteria.Namespace, err)
+			log.FromContext(ctx).Error().Msgf("Error fetching Pods per namespace %s: %s", criteria.Namespace, err)
 			return nil, err
 		}
 	}
@@ -157,7 +162,7 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 	if !criteria.IncludeOnlyDefinitions {
 		deployments, err = kubeCache.GetDeployments(criteria.Namespace)
 		if err != nil {
-			log.Errorf("Error fetching Deployments per namespace %s: %s", criteria.Namespace, err)
+			log.FromContext(ctx).Error().Msgf("Error fetching Deployments per namespace %s: %s", criteria.Namespace, err)
 			return nil, err
 		}
 	}
@@ -176,14 +181,21 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 		}
 		istioConfigs, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, cluster, istioCriteria)
 		if err != nil {
-			log.Errorf("Error fetching IstioConfigList per cluster %s per namespace %s: %s", cluster, criteria.Namespace, err)
+			log.FromContext(ctx).Error().Msgf("Error fetching IstioConfigList per cluster %s per namespace %s: %s", cluster, criteria.Namespace, err)
 			return nil, err
 		}
 		istioConfigList = *istioConfigs
 	}
 
+	var routeConfig serviceRouteConfig
+	var networkConfig serviceNetworkConfig
+	if !criteria.IncludeOnlyDefinitions {
+		routeConfig = in.getServiceRouteConfig(ctx, kubeCache, cluster, criteria.Namespace)
+		networkConfig = in.getServiceNetworkConfig(ctx, cluster, svcs)
+	}
+
 	// Convert to Kiali model
-	services := in.buildServiceList(cluster, criteria.Namespace, svcs, rSvcs, pods, deployments, istioConfigList, criteria)
+	services := in.buildServiceList(cluster, criteria.Namespace, svcs, rSvcs, pods, deployments, istioConfigList, routeConfig, networkConfig, criteria)
 
 	// Check if we need to add health
 
@@ -192,7 +204,7 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 			// TODO: Fix health for multi-cluster
 			services.Services[i].Health, err = in.businessLayer.Health.GetServiceHealth(ctx, criteria.Namespace, sv.Cluster, sv.Name, criteria.RateInterval, criteria.QueryTime, sv.ParseToService())
 			if err != nil {
-				log.Errorf("Error fetching health per service %s: %s", sv.Name, err)
+				log.FromContext(ctx).Error().Msgf("Error fetching health per service %s: %s", sv.Name, err)
 			}
 		}
 	}
@@ -203,7 +215,7 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 func getVSKialiScenario(vs []*networking_v1beta1.VirtualService) string {
 	scenario := ""
 	for _, v := range vs {
-		if scenario, ok := v.Labels["kiali_wizard"]; ok {
+		if scenario, ok := v.Labels[KialiWizardLabel]; ok {
 			return scenario
 		}
 	}
@@ -213,18 +225,18 @@ func getVSKialiScenario(vs []*networking_v1beta1.VirtualService) string {
 func getDRKialiScenario(dr []*networking_v1beta1.DestinationRule) string {
 	scenario := ""
 	for _, d := range dr {
-		if scenario, ok := d.Labels["kiali_wizard"]; ok {
+		if scenario, ok := d.Labels[KialiWizardLabel]; ok {
 			return scenario
 		}
 	}
 	return scenario
 }
 
-func (in *SvcService) buildServiceList(cluster string, namespace string, svcs []core_v1.Service, rSvcs []*kubernetes.RegistryService, pods []core_v1.Pod, deployments []apps_v1.Deployment, istioConfigList models.IstioConfigList, criteria ServiceCriteria) *models.ServiceList {
+func (in *SvcService) buildServiceList(cluster string, namespace string, svcs []core_v1.Service, rSvcs []*kubernetes.RegistryService, pods []core_v1.Pod, deployments []apps_v1.Deployment, istioConfigList models.IstioConfigList, routeConfig serviceRouteConfig, networkConfig serviceNetworkConfig, criteria ServiceCriteria) *models.ServiceList {
 	services := []models.ServiceOverview{}
 	validations := models.IstioValidations{}
 	if !criteria.IncludeOnlyDefinitions {
-		validations = in.getServiceValidations(svcs, deployments, pods)
+		validations = in.getServiceValidations(svcs, deployments, pods, routeConfig, networkConfig)
 	}
 
 	kubernetesServices := in.buildKubernetesServices(svcs, pods, istioConfigList, criteria.IncludeOnlyDefinitions)
@@ -253,6 +265,7 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 		hasSidecar := true
 		hasAmbient := false
 		svcReferences := make([]*models.IstioValidationKey, 0)
+		svcConfigs := make([]meta_v1.Object, 0)
 
 		if !onlyDefinitions {
 			sPods := kubernetes.FilterPodsByService(&item, pods)
@@ -270,10 +283,12 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 			for _, vs := range svcVirtualServices {
 				ref := models.BuildKey(vs.Kind, vs.Name, vs.Namespace)
 				svcReferences = append(svcReferences, &ref)
+				svcConfigs = append(svcConfigs, vs)
 			}
 			for _, dr := range svcDestinationRules {
 				ref := models.BuildKey(dr.Kind, dr.Name, dr.Namespace)
 				svcReferences = append(svcReferences, &ref)
+				svcConfigs = append(svcConfigs, dr)
 			}
 			for _, gw := range svcGateways {
 				ref := models.BuildKey(gw.Kind, gw.Name, gw.Namespace)
@@ -288,6 +303,7 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 				// Should be K8s type to generate correct link
 				ref := models.BuildKey(kubernetes.K8sHTTPRouteType, route.Name, route.Namespace)
 				svcReferences = append(svcReferences, &ref)
+				svcConfigs = append(svcConfigs, route)
 			}
 			svcReferences = FilterUniqueIstioReferences(svcReferences)
 			kialiWizard = getVSKialiScenario(svcVirtualServices)
@@ -314,6 +330,7 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 			KialiWizard:            kialiWizard,
 			ServiceRegistry:        "Kubernetes",
 		}
+		services[i].SetTimestamps(item.CreationTimestamp.Time, svcConfigs)
 	}
 	return services
 }
@@ -380,17 +397,21 @@ func (in *SvcService) buildRegistryServices(rSvcs []*kubernetes.RegistryService,
 		svcVirtualServices := kubernetes.FilterVirtualServicesByHostname(istioConfigList.VirtualServices, item.Hostname)
 		svcGateways := kubernetes.FilterGatewaysByVirtualServices(istioConfigList.Gateways, svcVirtualServices)
 		svcReferences := make([]*models.IstioValidationKey, 0)
+		svcConfigs := make([]meta_v1.Object, 0)
 		for _, se := range svcServiceEntries {
 			ref := models.BuildKey(se.Kind, se.Name, se.Namespace)
 			svcReferences = append(svcReferences, &ref)
+			svcConfigs = append(svcConfigs, se)
 		}
 		for _, vs := range svcVirtualServices {
 			ref := models.BuildKey(vs.Kind, vs.Name, vs.Namespace)
 			svcReferences = append(svcReferences, &ref)
+			svcConfigs = append(svcConfigs, vs)
 		}
 		for _, dr := range svcDestinationRules {
 			ref := models.BuildKey(dr.Kind, dr.Name, dr.Namespace)
 			svcReferences = append(svcReferences, &ref)
+			svcConfigs = append(svcConfigs, dr)
 		}
 		for _, gw := range svcGateways {
 			ref := models.BuildKey(gw.Kind, gw.Name, gw.Namespace)
@@ -410,6 +431,7 @@ func (in *SvcService) buildRegistryServices(rSvcs []*kubernetes.RegistryService,
 			IstioReferences:   svcReferences,
 			ServiceRegistry:   item.Attributes.ServiceRegistry,
 		}
+		service.SetTimestamps(time.Time{}, svcConfigs)
 		services = append(services, service)
 	}
 	return services
@@ -427,6 +449,8 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 		observability.Attribute("queryTime", queryTime),
 	)
 	defer end()
+	promtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GetServiceDetails", cluster, namespace)
+	defer promtimer.ObserveDuration()
 
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
@@ -476,7 +500,7 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 			var err2 error
 			ws, err2 = in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, namespace, labelsSelector)
 			if err2 != nil {
-				log.Errorf("Error fetching Workloads per namespace %s and service %s: %s", namespace, service, err2)
+				log.FromContext(ctx).Error().Msgf("Error fetching Workloads per namespace %s and service %s: %s", namespace, service, err2)
 				errChan <- err2
 			}
 		}(ctx)
@@ -496,7 +520,7 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 		var err2 error
 		eps, err2 = kubeCache.GetEndpoints(namespace, service)
 		if err2 != nil && !errors.IsNotFound(err2) {
-			log.Errorf("Error fetching Endpoints namespace %s and service %s: %s", namespace, service, err2)
+			log.FromContext(ctx).Error().Msgf("Error fetching Endpoints namespace %s and service %s: %s", namespace, service, err2)
 			errChan <- err2
 		}
 	}(ctx)
@@ -538,11 +562,25 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 		}
 		istioConfigList, err2 = in.businessLayer.IstioConfig.GetIstioConfigListForNamespace(ctx, cluster, namespace, criteria)
 		if err2 != nil {
-			log.Errorf("Error fetching IstioConfigList per namespace %s: %s", namespace, err2)
+			log.FromContext(ctx).Error().Msgf("Error fetching IstioConfigList per namespace %s: %s", namespace, err2)
 			errChan <- err2
 		}
 	}(ctx)
 
+	var routes []osroutes_v1.Route
+	if userClient, ok := in.userClients[cluster]; ok && userClient.IsOpenShift() {
+		wg.Add(1)
+		go func(ctx context.Context) {
+			defer wg.Done()
+			var err2 error
+			// The Routes are references of the service, the details are returned without them when they cannot be read
+			routes, err2 = userClient.GetRoutes(ctx, namespace)
+			if err2 != nil {
+				log.FromContext(ctx).Debug().Msgf("Error fetching Routes per namespace %s: %s", namespace, err2)
+			}
+		}(ctx)
+	}
+
 	var vsCreate, vsUpdate, vsDelete bool
 	wg.Add(1)
 	go func() {
@@ -623,6 +661,7 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 	s.VirtualServices = kubernetes.FilterAutogeneratedVirtualServices(kubernetes.FilterVirtualServicesByService(istioConfigList.VirtualServices, namespace, service))
 	s.DestinationRules = kubernetes.FilterDestinationRulesByService(istioConfigList.DestinationRules, namespace, service)
 	s.K8sHTTPRoutes = kubernetes.FilterK8sHTTPRoutesByService(istioConfigList.K8sHTTPRoutes, istioConfigList.K8sReferenceGrants, namespace, service)
+	s.OpenShiftRoutes = kubernetes.FilterOpenShiftRoutesByService(routes, namespace, service)
 	if s.Service.Type == "External" || s.Service.Type == "Federation" {
 		// On ServiceEntries cases the Service name is the hostname
 		s.ServiceEntries = kubernetes.FilterServiceEntriesByHostname(istioConfigList.ServiceEntries, s.Service.Name)
@@ -645,6 +684,13 @@ func (in *SvcService) UpdateService(ctx context.Context, cluster, namespace, ser
 	)
 	defer end()
 
+	if err := checkViewOnlyMode(&in.config, "Updating the service"); err != nil {
+		return nil, err
+	}
+	if err := checkFeatureEnabled(&in.config, config.FeatureServicePatch); err != nil {
+		return nil, err
+	}
+
 	// Identify controller and apply patch to workload
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
@@ -657,6 +703,16 @@ func (in *SvcService) UpdateService(ctx context.Context, cluster, namespace, ser
 		return nil, fmt.Errorf("cluster: %s not found", cluster)
 	}
 
+	if in.config.KialiFeatureFlags.GitOps.BlockManagedEdits {
+		svc, err := in.GetService(ctx, cluster, namespace, service)
+		if err != nil {
+			return nil, err
+		}
+		if svc.ManagedBy != nil {
+			return nil, newGitOpsManagedError("Service", namespace, service, svc.ManagedBy)
+		}
+	}
+
 	if err := userClient.UpdateService(namespace, service, jsonPatch, patchType); err != nil {
 		return nil, err
 	}
@@ -722,16 +778,107 @@ func (in *SvcService) GetService(ctx context.Context, cluster, namespace, servic
 	return svc, nil
 }
 
-func (in *SvcService) getServiceValidations(services []core_v1.Service, deployments []apps_v1.Deployment, pods []core_v1.Pod) models.IstioValidations {
+func (in *SvcService) getServiceValidations(services []core_v1.Service, deployments []apps_v1.Deployment, pods []core_v1.Pod, routeConfig serviceRouteConfig, networkConfig serviceNetworkConfig) models.IstioValidations {
 	validations := checkers.ServiceChecker{
-		Services:    services,
-		Deployments: deployments,
-		Pods:        pods,
+		Services:         services,
+		Deployments:      deployments,
+		Pods:             pods,
+		Routes:           routeConfig.routes,
+		Gateways:         routeConfig.gateways,
+		VirtualServices:  routeConfig.virtualServices,
+		Network:          networkConfig.network,
+		RemoteNetworks:   networkConfig.remoteNetworks,
+		EastWestGateways: networkConfig.eastWestGateways,
 	}.Check()
 
 	return validations
 }
 
+// serviceRouteConfig are the OpenShift Routes of a namespace, with the Gateways and VirtualServices of the cluster
+// when there are Routes, to validate the Routes of the services.
+type serviceRouteConfig struct {
+	routes          []osroutes_v1.Route
+	gateways        []*networking_v1beta1.Gateway
+	virtualServices []*networking_v1beta1.VirtualService
+}
+
+// getServiceRouteConfig returns the OpenShift Routes of the namespace, read with the client of the user. The Routes
+// are not validated when they cannot be read.
+func (in *SvcService) getServiceRouteConfig(ctx context.Context, kubeCache cache.KubeCache, cluster, namespace string) serviceRouteConfig {
+	routeConfig := serviceRouteConfig{}
+	userClient, ok := in.userClients[cluster]
+	if !ok || !userClient.IsOpenShift() {
+		return routeConfig
+	}
+	routes, err := userClient.GetRoutes(ctx, namespace)
+	if err != nil {
+		log.FromContext(ctx).Debug().Msgf("Routes of namespace %s not validated, unable to read them: %s", namespace, err)
+		return routeConfig
+	}
+	routeConfig.routes = routes
+	if len(routes) == 0 || !in.config.ExternalServices.Istio.IstioAPIEnabled {
+		return routeConfig
+	}
+	if routeConfig.gateways, err = kubeCache.GetGateways(meta_v1.NamespaceAll, ""); err != nil {
+		log.FromContext(ctx).Debug().Msgf("Gateways of the Routes of namespace %s not validated: %s", namespace, err)
+	}
+	if routeConfig.virtualServices, err = kubeCache.GetVirtualServices(meta_v1.NamespaceAll, ""); err != nil {
+		log.FromContext(ctx).Debug().Msgf("VirtualServices of the Routes of namespace %s not validated: %s", namespace, err)
+	}
+	return routeConfig
+}
+
+// serviceNetworkConfig are the networks of the remote clusters with the same services as a cluster, from where the
+// services import endpoints, with the east-west gateways of the mesh exposing the endpoints to the other networks.
+type serviceNetworkConfig struct {
+	network          string
+	remoteNetworks   map[string][]string
+	eastWestGateways []models.EastWestGateway
+}
+
+// getServiceNetworkConfig returns the networks of the accessible remote clusters with the same services, when they
+// are not on the network of the cluster. The services are not validated against the remote networks otherwise.
+func (in *SvcService) getServiceNetworkConfig(ctx context.Context, cluster string, svcs []core_v1.Service) serviceNetworkConfig {
+	networkConfig := serviceNetworkConfig{remoteNetworks: map[string][]string{}}
+	clusters, err := in.businessLayer.Mesh.GetClusters()
+	if err != nil || len(clusters) < 2 {
+		return networkConfig
+	}
+	for _, c := range clusters {
+		if c.Name == cluster {
+			networkConfig.network = c.Network
+		}
+	}
+
+	for _, c := range clusters {
+		if c.Name == cluster || !c.Accessible || c.Network == networkConfig.network {
+			continue
+		}
+		remoteCache, err := in.kialiCache.GetKubeCache(c.Name)
+		if err != nil {
+			continue
+		}
+		for _, svc := range svcs {
+			if _, err := remoteCache.GetService(svc.Namespace, svc.Name); err == nil {
+				key := svc.Namespace + "/" + svc.Name
+				networkConfig.remoteNetworks[key] = append(networkConfig.remoteNetworks[key], c.Network)
+			}
+		}
+	}
+	if len(networkConfig.remoteNetworks) == 0 {
+		return networkConfig
+	}
+
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		log.FromContext(ctx).Debug().Msgf("Services of cluster %s not validated against the east-west gateways: %s", cluster, err)
+		networkConfig.remoteNetworks = map[string][]string{}
+		return networkConfig
+	}
+	networkConfig.eastWestGateways = mesh.EastWestGateways
+	return networkConfig
+}
+
 // GetServiceAppName returns the "Application" name (app label) that relates to a service
 // This label is taken from the service selector, which means it is assumed that pods are selected using that label
 func (in *SvcService) GetServiceAppName(ctx context.Context, cluster, namespace, service string) (string, error) {
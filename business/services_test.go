@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	osproject_v1 "github.com/openshift/api/project/v1"
+	osroutes_v1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -14,6 +16,7 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/kiali/kiali/config"
@@ -308,3 +311,113 @@ func TestMultiClusterGetServiceAppName(t *testing.T) {
 
 	assert.Equal("ratings", s)
 }
+
+func TestServiceOpenShiftRoutes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	targetPort := intstr.FromString("https")
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "productpage", Namespace: "bookinfo"},
+			Spec:       core_v1.ServiceSpec{Ports: []core_v1.ServicePort{{Name: "http", Port: 9080}}},
+		},
+		&osroutes_v1.Route{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "productpage", Namespace: "bookinfo"},
+			Spec: osroutes_v1.RouteSpec{
+				To:   osroutes_v1.RouteTargetReference{Kind: "Service", Name: "productpage"},
+				Port: &osroutes_v1.RoutePort{TargetPort: targetPort},
+			},
+		},
+		&osroutes_v1.Route{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+			Spec:       osroutes_v1.RouteSpec{To: osroutes_v1.RouteTargetReference{Kind: "Service", Name: "reviews"}},
+		},
+	)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+
+	prom, err := prometheus.NewClient()
+	require.NoError(err)
+	promMock := new(prometheustest.PromAPIMock)
+	promMock.SpyArgumentsAndReturnEmpty(func(mock.Arguments) {})
+	prom.Inject(promMock)
+	svc := NewWithBackends(clients, clients, prom, nil).Svc
+
+	// The Route targets a port the Service does not expose
+	serviceList, err := svc.GetServiceList(context.TODO(), ServiceCriteria{Namespace: "bookinfo"})
+	require.NoError(err)
+	codes := []string{}
+	for key, validation := range serviceList.Validations {
+		for _, check := range validation.Checks {
+			codes = append(codes, key.Name+"/"+check.Code)
+		}
+	}
+	assert.Equal([]string{"productpage/KIA0704"}, codes)
+
+	// The Routes of the Service are references of the Service
+	details, err := svc.GetServiceDetails(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "productpage", "60s", time.Now())
+	require.NoError(err)
+	require.Len(details.OpenShiftRoutes, 1)
+	assert.Equal("productpage", details.OpenShiftRoutes[0].Name)
+}
+
+func TestServiceEastWestGatewayValidations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	istioNamespace := func(network string) *core_v1.Namespace {
+		return &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system", Labels: map[string]string{IstioNetworkLabel: network}}}
+	}
+	productpage := &core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "productpage", Namespace: "bookinfo"}}
+	clientFactory := kubetest.NewK8SClientFactoryMock(nil)
+	clients := map[string]kubernetes.ClientInterface{
+		conf.KubernetesConfig.ClusterName: kubetest.NewFakeK8sClient(
+			istioNamespace("network-east"),
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			productpage.DeepCopy(),
+			&core_v1.Service{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "istio-eastwestgateway", Namespace: "istio-system", Labels: map[string]string{"istio": "eastwestgateway"}},
+				Spec:       core_v1.ServiceSpec{ExternalIPs: []string{"10.0.0.1"}},
+			},
+		),
+		"west": kubetest.NewFakeK8sClient(
+			istioNamespace("network-west"),
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			productpage.DeepCopy(),
+		),
+	}
+	clientFactory.SetClients(clients)
+	kialiCache = cache.NewTestingCacheWithFactory(t, clientFactory, *conf)
+
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+
+	// The endpoints of the west cluster are not exposed to the east network
+	serviceList, err := svc.GetServiceList(context.TODO(), ServiceCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo"})
+	require.NoError(err)
+	codes := []string{}
+	for key, validation := range serviceList.Validations {
+		for _, check := range validation.Checks {
+			codes = append(codes, key.Name+"/"+check.Code)
+		}
+	}
+	assert.Equal([]string{"productpage/KIA0707"}, codes)
+
+	// The endpoints of the east cluster are exposed by its east-west gateway
+	serviceList, err = svc.GetServiceList(context.TODO(), ServiceCriteria{Cluster: "west", Namespace: "bookinfo"})
+	require.NoError(err)
+	for _, validation := range serviceList.Validations {
+		assert.Empty(validation.Checks)
+	}
+}
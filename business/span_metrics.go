@@ -0,0 +1,125 @@
+package business
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tracing"
+)
+
+// The RED metrics generated from the spans of the traces by the Tempo metrics generator, in seconds
+const (
+	spanMetricsCalls   = "traces_spanmetrics_calls_total"
+	spanMetricsLatency = "traces_spanmetrics_latency"
+
+	spanMetricsWarning = "Istio metrics are not available, the metrics are computed from the spans of the traces"
+)
+
+// How long the detection of the metrics of Prometheus is reused
+const spanMetricsDetectionTTL = time.Minute
+
+var spanMetricsDetection struct {
+	sync.Mutex
+	useSpanMetrics bool
+	expiresAt      time.Time
+}
+
+// useSpanMetrics returns true when the metrics must be computed from the spans of the traces: the tracing backend
+// is Tempo and Prometheus has the span metrics of its metrics generator but not the Istio standard metrics. The
+// detection is cached for a minute.
+func (in *MetricsService) useSpanMetrics() bool {
+	tracingConfig := config.Get().ExternalServices.Tracing
+	if !tracingConfig.Enabled || tracingConfig.Provider != config.TempoProvider {
+		return false
+	}
+
+	spanMetricsDetection.Lock()
+	defer spanMetricsDetection.Unlock()
+	if time.Now().Before(spanMetricsDetection.expiresAt) {
+		return spanMetricsDetection.useSpanMetrics
+	}
+
+	istioMetric := "istio_requests_total"
+	names, err := in.prom.GetMetricsForLabels([]string{istioMetric, spanMetricsCalls}, fmt.Sprintf(`{__name__=~"%s|%s"}`, istioMetric, spanMetricsCalls))
+	if err != nil {
+		// Not cached, Prometheus may be back on the next request
+		log.Debugf("Unable to detect the span metrics: %s", err)
+		return false
+	}
+	spanMetricsDetection.useSpanMetrics = !slices.Contains(names, istioMetric) && slices.Contains(names, spanMetricsCalls)
+	spanMetricsDetection.expiresAt = time.Now().Add(spanMetricsDetectionTTL)
+	return spanMetricsDetection.useSpanMetrics
+}
+
+// fetchSpanMetrics returns the request count, error count and duration of an app, service or workload, or of every
+// service of the namespace, computed from the span metrics. The spans have none of the labels of the Istio metrics,
+// so the metrics are not grouped.
+func (in *MetricsService) fetchSpanMetrics(q models.IstioMetricsQuery, scaler func(n string) float64) (models.MetricsMap, error) {
+	in.warningsMutex.Lock()
+	if !slices.Contains(in.warnings, spanMetricsWarning) {
+		in.warnings = append(in.warnings, spanMetricsWarning)
+	}
+	in.warningsMutex.Unlock()
+
+	spanKind := "SPAN_KIND_CLIENT"
+	if q.Direction == "inbound" {
+		spanKind = "SPAN_KIND_SERVER"
+	}
+	labels := fmt.Sprintf(`span_kind="%s"`, spanKind)
+	name := q.App
+	if name == "" {
+		name = q.Service
+	}
+	if name == "" {
+		name = q.Workload
+	}
+	if name != "" {
+		labels += fmt.Sprintf(`,service="%s"`, tracing.BuildTracingServiceName(q.Namespace, name))
+	} else if q.Namespace != "" && config.Get().ExternalServices.Tracing.NamespaceSelector {
+		labels += fmt.Sprintf(`,service=~".+\\.%s"`, regexp.QuoteMeta(q.Namespace))
+	}
+	errorLabels := labels + `,status_code="STATUS_CODE_ERROR"`
+
+	doFetch := func(kialiName string) bool {
+		return len(q.Filters) == 0 || slices.Contains(q.Filters, kialiName)
+	}
+	scale := func(kialiName string, unitScale float64) models.ConversionParams {
+		if scaler != nil {
+			if s := scaler(kialiName); s != 0.0 {
+				unitScale *= s
+			}
+		}
+		return models.ConversionParams{Scale: unitScale}
+	}
+
+	metrics := make(models.MetricsMap)
+	for kialiName, lbl := range map[string]string{"request_count": labels, "request_error_count": errorLabels} {
+		if !doFetch(kialiName) {
+			continue
+		}
+		metric := in.prom.FetchRateRange(spanMetricsCalls, []string{"{" + lbl + "}"}, "", &q.RangeQuery)
+		in.addWarnings(metric)
+		converted, err := models.ConvertMetric(kialiName, metric, scale(kialiName, 1.0))
+		if err != nil {
+			return nil, err
+		}
+		metrics[kialiName] = converted
+	}
+	if kialiName := "request_duration_millis"; doFetch(kialiName) {
+		histo := in.prom.FetchHistogramRange(spanMetricsLatency, "{"+labels+"}", "", &q.RangeQuery)
+		in.addHistogramWarnings(histo)
+		// The latency is in seconds
+		converted, err := models.ConvertHistogram(kialiName, histo, scale(kialiName, 1000.0))
+		if err != nil {
+			return nil, err
+		}
+		metrics[kialiName] = converted
+	}
+	return metrics, nil
+}
@@ -2,8 +2,12 @@ package business
 
 import (
 	"context"
+	"sort"
+	"time"
 
+	"github.com/prometheus/common/model"
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -11,6 +15,7 @@ import (
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/util/mtls"
 )
 
@@ -19,6 +24,7 @@ type TLSService struct {
 	kialiCache      cache.KialiCache
 	businessLayer   *Layer
 	enabledAutoMtls *bool
+	prom            prometheus.ClientInterface
 }
 
 const (
@@ -165,6 +171,103 @@ func (in *TLSService) ClusterWideNSmTLSStatus(ctx context.Context, nss []string,
 	return result, nil
 }
 
+// WorkloadWidemTLSStatus returns the mTLS status of a workload. Workloads without a PeerAuthentication
+// of their own inherit the status of their namespace or, failing that, of the mesh.
+func (in *TLSService) WorkloadWidemTLSStatus(ctx context.Context, cluster, namespace, workload string) (models.MTLSStatus, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "WorkloadWidemTLSStatus",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	wk, err := in.businessLayer.Workload.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload})
+	if err != nil {
+		return models.MTLSStatus{}, err
+	}
+
+	resolver, err := in.newWorkloadmTLSResolver(ctx, cluster)
+	if err != nil {
+		return models.MTLSStatus{}, err
+	}
+
+	return resolver.status(namespace, wk.Name, wk.Labels), nil
+}
+
+// workloadmTLSResolver holds the config needed to resolve the mTLS status of the workloads of a cluster.
+type workloadmTLSResolver struct {
+	autoMtlsEnabled  bool
+	cluster          string
+	istioConfigList  *models.IstioConfigList
+	namespaces       []string
+	registryServices []*kubernetes.RegistryService
+}
+
+func (in *TLSService) newWorkloadmTLSResolver(ctx context.Context, cluster string) (*workloadmTLSResolver, error) {
+	nss, err := in.getNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := IstioConfigCriteria{
+		IncludeDestinationRules:    true,
+		IncludePeerAuthentications: true,
+	}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, cluster, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workloadmTLSResolver{
+		autoMtlsEnabled:  in.hasAutoMTLSEnabled(cluster),
+		cluster:          cluster,
+		istioConfigList:  istioConfigList,
+		namespaces:       nss,
+		registryServices: in.businessLayer.RegistryStatus.GetRegistryServices(RegistryCriteria{AllNamespaces: true, Cluster: cluster}),
+	}, nil
+}
+
+func (r *workloadmTLSResolver) status(namespace, workload string, wkLabels map[string]string) models.MTLSStatus {
+	drs := kubernetes.FilterByNamespaces(r.istioConfigList.DestinationRules, r.namespaces)
+	nsPas := kubernetes.FilterByNamespace(r.istioConfigList.PeerAuthentications, namespace)
+	if config.IsRootNamespace(namespace) {
+		nsPas = []*security_v1beta1.PeerAuthentication{}
+	}
+
+	mtlsStatus := mtls.MtlsStatus{
+		PeerAuthentications: nsPas,
+		DestinationRules:    drs,
+		MatchingLabels:      labels.Set(wkLabels),
+		AutoMtlsEnabled:     r.autoMtlsEnabled,
+		AllowPermissive:     false,
+		RegistryServices:    r.registryServices,
+	}
+
+	var status string
+	if mtlsStatus.HasWorkloadMtlsDefinition() {
+		status = mtlsStatus.WorkloadMtlsStatus(namespace)
+	} else {
+		meshStatus := mtls.MtlsStatus{
+			PeerAuthentications: kubernetes.FilterByNamespace(r.istioConfigList.PeerAuthentications, config.Get().ExternalServices.Istio.RootNamespace),
+			DestinationRules:    drs,
+			AutoMtlsEnabled:     r.autoMtlsEnabled,
+			AllowPermissive:     false,
+		}
+		status = mtlsStatus.OverallMtlsStatus(mtlsStatus.NamespaceMtlsStatus(namespace), meshStatus.MeshMtlsStatus())
+	}
+
+	return models.MTLSStatus{
+		Status:          status,
+		AutoMTLSEnabled: r.autoMtlsEnabled,
+		Cluster:         r.cluster,
+		Namespace:       namespace,
+		Workload:        workload,
+		PortLevel:       mtlsStatus.WorkloadPortMtlsStatus(),
+	}
+}
+
 func (in *TLSService) getNamespaces(ctx context.Context, cluster string) ([]string, error) {
 	nss, nssErr := in.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
 	if nssErr != nil {
@@ -202,3 +305,126 @@ func (in *TLSService) hasAutoMTLSEnabled(cluster string) bool {
 	in.enabledAutoMtls = &autoMtls
 	return autoMtls
 }
+
+// NamespacePermissiveTraffic reports, for every workload of the namespace that still accepts plaintext traffic,
+// how much of its inbound requests and TCP traffic is actually plaintext according to the connection_security_policy
+// reported by the destination proxies. Workloads that received traffic during the interval, none of it in plaintext,
+// can be moved to STRICT. Without any traffic there is nothing to tell.
+func (in *TLSService) NamespacePermissiveTraffic(ctx context.Context, cluster, namespace, rateInterval string, queryTime time.Time) (*models.PermissiveTrafficReport, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "NamespacePermissiveTraffic",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	workloads, err := in.businessLayer.Workload.GetWorkloadList(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, IncludeIstioResources: true})
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := in.prom.GetNamespaceInboundRequestRates(namespace, cluster, rateInterval, queryTime)
+	if err != nil {
+		return nil, err
+	}
+	// A workload may only receive TCP traffic, istio_requests_total doesn't tell about it.
+	tcpConnections, tcpBytes, err := in.prom.GetNamespaceInboundTCPRates(namespace, cluster, rateInterval, queryTime)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PermissiveTrafficReport{
+		Cluster:      cluster,
+		Namespace:    namespace,
+		RateInterval: rateInterval,
+		Workloads:    []models.PermissiveWorkloadTraffic{},
+	}
+
+	for _, wl := range workloads.Workloads {
+		if !wl.IstioSidecar && !wl.IstioAmbient {
+			continue
+		}
+		if wl.MTLSStatus == nil || wl.MTLSStatus.Status == MTLSEnabled || wl.MTLSStatus.Status == MTLSDisabled {
+			continue
+		}
+
+		traffic := models.PermissiveWorkloadTraffic{
+			Name:             wl.Name,
+			MTLSStatus:       wl.MTLSStatus.Status,
+			PlaintextSources: []models.PlaintextSource{},
+		}
+		sources := map[string]*models.PlaintextSource{}
+		plaintextSource := func(sample *model.Sample) *models.PlaintextSource {
+			srcNamespace, srcWorkload := string(sample.Metric["source_workload_namespace"]), string(sample.Metric["source_workload"])
+			key := srcNamespace + "/" + srcWorkload
+			if _, found := sources[key]; !found {
+				sources[key] = &models.PlaintextSource{Namespace: srcNamespace, Workload: srcWorkload}
+			}
+			return sources[key]
+		}
+		isInbound := func(sample *model.Sample) bool {
+			return string(sample.Metric["destination_workload"]) == wl.Name
+		}
+		isMTLS := func(sample *model.Sample) bool {
+			return string(sample.Metric["connection_security_policy"]) == "mutual_tls"
+		}
+
+		hasTraffic := false
+		for _, sample := range rates {
+			if !isInbound(sample) {
+				continue
+			}
+
+			hasTraffic = true
+			rate := float64(sample.Value)
+			if isMTLS(sample) {
+				traffic.MTLSRate += rate
+				continue
+			}
+			traffic.PlaintextRate += rate
+			plaintextSource(sample).Rate += rate
+		}
+		for _, sample := range tcpBytes {
+			if !isInbound(sample) {
+				continue
+			}
+
+			hasTraffic = true
+			rate := float64(sample.Value)
+			if isMTLS(sample) {
+				traffic.MTLSTCPRate += rate
+				continue
+			}
+			traffic.PlaintextTCPRate += rate
+			plaintextSource(sample).TCPRate += rate
+		}
+		// A plaintext connection is reported even when nothing was sent over it yet.
+		for _, sample := range tcpConnections {
+			if !isInbound(sample) {
+				continue
+			}
+
+			hasTraffic = true
+			if !isMTLS(sample) {
+				plaintextSource(sample)
+			}
+		}
+
+		for _, source := range sources {
+			traffic.PlaintextSources = append(traffic.PlaintextSources, *source)
+		}
+		sort.Slice(traffic.PlaintextSources, func(i, j int) bool {
+			if traffic.PlaintextSources[i].Rate != traffic.PlaintextSources[j].Rate {
+				return traffic.PlaintextSources[i].Rate > traffic.PlaintextSources[j].Rate
+			}
+			return traffic.PlaintextSources[i].TCPRate > traffic.PlaintextSources[j].TCPRate
+		})
+		traffic.NoTraffic = !hasTraffic
+		traffic.ReadyForStrict = hasTraffic && len(traffic.PlaintextSources) == 0
+
+		report.Workloads = append(report.Workloads, traffic)
+	}
+
+	return report, nil
+}
@@ -3,12 +3,16 @@ package business
 import (
 	"context"
 	"testing"
+	"time"
 
 	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	api_security_v1beta1 "istio.io/api/security/v1beta1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -16,6 +20,8 @@ import (
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
 	"github.com/kiali/kiali/tests/data"
 	"github.com/kiali/kiali/util"
 )
@@ -242,6 +248,21 @@ func TestNamespaceHasDestinationRuleDisabled(t *testing.T) {
 	testNamespaceScenario(MTLSEnabled, []*networking_v1beta1.DestinationRule{}, ps, true, t)
 }
 
+func TestNamespaceDestinationRuleNotExported(t *testing.T) {
+	ps := fakeStrictPeerAuthn("default", "bookinfo")
+	dr := data.AddTrafficPolicyToDestinationRule(data.CreateMTLSTrafficPolicyForDestinationRules(),
+		data.CreateEmptyDestinationRule("foo", "allow-mtls", "*.bookinfo.svc.cluster.local"))
+
+	testNamespaceScenario(MTLSEnabled, []*networking_v1beta1.DestinationRule{dr}, ps, false, t)
+
+	// The DR is only visible from its own namespace so it does not enable mTLS towards bookinfo
+	dr.Spec.ExportTo = []string{"."}
+	testNamespaceScenario(MTLSPartiallyEnabled, []*networking_v1beta1.DestinationRule{dr}, ps, false, t)
+
+	dr.Spec.ExportTo = []string{".", "bookinfo"}
+	testNamespaceScenario(MTLSEnabled, []*networking_v1beta1.DestinationRule{dr}, ps, false, t)
+}
+
 func TestNamespaceHasNoDestinationRulesNoPolicy(t *testing.T) {
 	var drs []*networking_v1beta1.DestinationRule
 	var ps []*security_v1beta1.PeerAuthentication
@@ -424,3 +445,160 @@ func fakeMeshPeerAuthenticationWithMtlsMode(name, mTLSmode string) []*security_v
 func fakeMeshPeerAuthentication(name string, mtls *api_security_v1beta1.PeerAuthentication_MutualTLS) []*security_v1beta1.PeerAuthentication {
 	return []*security_v1beta1.PeerAuthentication{data.CreateEmptyMeshPeerAuthentication(name, mtls)}
 }
+
+func TestWorkloadWidemTLSStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.ClusterWideAccess = true
+	kubernetes.SetConfig(t, *conf)
+
+	// productpage disables mTLS except on its 9080 port. reviews inherits the namespace-wide STRICT mode.
+	workloadPA := data.CreateEmptyPeerAuthenticationWithSelector("productpage", "bookinfo", data.CreateOneLabelSelector("productpage"))
+	workloadPA.Spec.Mtls = data.CreateMTLS("DISABLE")
+	workloadPA.Spec.PortLevelMtls = map[uint32]*api_security_v1beta1.PeerAuthentication_MutualTLS{
+		9080: data.CreateMTLS("STRICT"),
+	}
+
+	productpage := fakeDeploymentWithStatus("productpage-v1", map[string]string{"app": "productpage"}, apps_v1.DeploymentStatus{})
+	productpage.Namespace = "bookinfo"
+	reviews := fakeDeploymentWithStatus("reviews-v1", map[string]string{"app": "reviews"}, apps_v1.DeploymentStatus{})
+	reviews.Namespace = "bookinfo"
+
+	objs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		productpage,
+		reviews,
+		workloadPA,
+	}
+	objs = append(objs, kubernetes.ToRuntimeObjects(fakeStrictPeerAuthn("default", "bookinfo"))...)
+
+	k8s := kubetest.NewFakeK8sClient(objs...)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = k8s
+
+	tlsService := NewWithBackends(k8sclients, k8sclients, nil, nil).TLS
+	tlsService.enabledAutoMtls = util.AsPtr(true)
+
+	status, err := tlsService.WorkloadWidemTLSStatus(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "productpage-v1")
+	require.NoError(err)
+	assert.Equal("productpage-v1", status.Workload)
+	assert.Equal(MTLSDisabled, status.Status)
+	assert.Equal(map[uint32]string{9080: MTLSEnabled}, status.PortLevel)
+
+	status, err = tlsService.WorkloadWidemTLSStatus(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal(MTLSEnabled, status.Status)
+	assert.Empty(status.PortLevel)
+
+	_, err = tlsService.WorkloadWidemTLSStatus(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "details-v1")
+	assert.Error(err)
+}
+
+func TestNamespacePermissiveTraffic(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.ClusterWideAccess = true
+	kubernetes.SetConfig(t, *conf)
+
+	fakePod := func(name, app string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: map[string]string{"app": app}, Annotations: kubetest.FakeIstioAnnotations()},
+			Status:     core_v1.PodStatus{Phase: core_v1.PodRunning},
+		}
+	}
+
+	// productpage already enforces STRICT, the other workloads are PERMISSIVE by default.
+	workloadPA := data.CreateEmptyPeerAuthenticationWithSelector("productpage", "bookinfo", data.CreateOneLabelSelector("productpage"))
+	workloadPA.Spec.Mtls = data.CreateMTLS("STRICT")
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		fakePod("productpage-v1", "productpage"),
+		fakePod("reviews-v1", "reviews"),
+		fakePod("details-v1", "details"),
+		fakePod("mysql-v1", "mysql"),
+		fakePod("ratings-v1", "ratings"),
+		workloadPA,
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	sample := func(reporter, srcNamespace, srcWorkload, destWorkload, securityPolicy string, rate float64) *model.Sample {
+		return &model.Sample{
+			Metric: model.Metric{
+				"reporter":                       model.LabelValue(reporter),
+				"source_workload_namespace":      model.LabelValue(srcNamespace),
+				"source_workload":                model.LabelValue(srcWorkload),
+				"destination_workload_namespace": "bookinfo",
+				"destination_workload":           model.LabelValue(destWorkload),
+				"connection_security_policy":     model.LabelValue(securityPolicy),
+			},
+			Value: model.SampleValue(rate),
+		}
+	}
+	queryTime := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetNamespaceInboundRequestRates", "bookinfo", conf.KubernetesConfig.ClusterName, "10m", queryTime).Return(model.Vector{
+		sample("destination", "unknown", "unknown", "productpage-v1", "none", 4),
+		sample("destination", "bookinfo", "productpage-v1", "reviews-v1", "mutual_tls", 2),
+		sample("destination", "legacy", "ratings-v1", "reviews-v1", "none", 0.5),
+		sample("destination", "unknown", "unknown", "reviews-v1", "none", 1),
+		sample("destination", "bookinfo", "productpage-v1", "details-v1", "mutual_tls", 3),
+	}, nil)
+	// mysql only receives TCP traffic, some in plaintext, and ratings receives no traffic at all.
+	prom.On("GetNamespaceInboundTCPRates", "bookinfo", conf.KubernetesConfig.ClusterName, "10m", queryTime).Return(model.Vector{
+		sample("destination", "legacy", "batch", "mysql-v1", "none", 0.1),
+		sample("destination", "bookinfo", "reviews-v1", "mysql-v1", "mutual_tls", 0.2),
+	}, model.Vector{
+		sample("destination", "bookinfo", "reviews-v1", "mysql-v1", "mutual_tls", 2048),
+	}, nil)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(k8sclients, k8sclients, prom, nil)
+	layer.TLS.enabledAutoMtls = util.AsPtr(true)
+
+	report, err := layer.TLS.NamespacePermissiveTraffic(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "10m", queryTime)
+	require.NoError(err)
+	assert.Equal("10m", report.RateInterval)
+
+	workloads := map[string]models.PermissiveWorkloadTraffic{}
+	for _, wl := range report.Workloads {
+		workloads[wl.Name] = wl
+	}
+	require.Len(workloads, 4)
+	assert.NotContains(workloads, "productpage-v1")
+
+	reviews := workloads["reviews-v1"]
+	assert.Equal(MTLSNotEnabled, reviews.MTLSStatus)
+	assert.Equal(float64(2), reviews.MTLSRate)
+	assert.Equal(1.5, reviews.PlaintextRate)
+	assert.Equal([]models.PlaintextSource{
+		{Namespace: "unknown", Workload: "unknown", Rate: 1},
+		{Namespace: "legacy", Workload: "ratings-v1", Rate: 0.5},
+	}, reviews.PlaintextSources)
+	assert.False(reviews.ReadyForStrict)
+
+	details := workloads["details-v1"]
+	assert.Equal(float64(3), details.MTLSRate)
+	assert.Empty(details.PlaintextSources)
+	assert.False(details.NoTraffic)
+	assert.True(details.ReadyForStrict)
+
+	// A plaintext TCP connection is reported even when nothing was received over it.
+	mysql := workloads["mysql-v1"]
+	assert.Equal(float64(2048), mysql.MTLSTCPRate)
+	assert.Zero(mysql.PlaintextTCPRate)
+	assert.Equal([]models.PlaintextSource{{Namespace: "legacy", Workload: "batch"}}, mysql.PlaintextSources)
+	assert.False(mysql.ReadyForStrict)
+
+	ratings := workloads["ratings-v1"]
+	assert.True(ratings.NoTraffic)
+	assert.False(ratings.ReadyForStrict)
+}
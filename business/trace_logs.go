@@ -0,0 +1,199 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/observability"
+	jaegerModels "github.com/kiali/kiali/tracing/jaeger/model/json"
+)
+
+const (
+	// The logs are fetched around the spans of the pods, as the proxies log the requests once completed and the
+	// clocks of the nodes may differ
+	traceLogsMargin = 2 * time.Second
+	// The max number of entries of a trace logs
+	traceLogsMaxLines = 1000
+)
+
+// TraceLogs are the log entries of the pods of the spans of a trace, logged during their spans, interleaved by
+// timestamp
+type TraceLogs struct {
+	TraceID        string          `json:"traceID"`
+	Entries        []TraceLogEntry `json:"entries"`
+	LinesTruncated bool            `json:"linesTruncated,omitempty"`
+}
+
+// TraceLogEntry is a log entry of a pod of a trace
+type TraceLogEntry struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	LogEntry
+}
+
+// tracePod is a pod of the spans of a trace, with the time window of its spans
+type tracePod struct {
+	namespace string
+	name      string
+	start     time.Time
+	end       time.Time
+}
+
+// GetLogsForTrace returns the logs of the containers of the pods of the spans of a trace, logged during the spans
+// of each pod. The pods are identified by the node_id tag of the spans of the proxies, or by their hostname. It
+// returns nil when the trace is not found.
+func (in *TracingService) GetLogsForTrace(ctx context.Context, cluster, traceID string) (*TraceLogs, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "GetLogsForTrace",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("traceID", traceID),
+	)
+	defer end()
+
+	trace, err := in.GetTraceDetail(cluster, traceID)
+	if err != nil {
+		return nil, err
+	}
+	if trace == nil {
+		// Trace not found
+		return nil, nil
+	}
+
+	userClient, ok := in.workload.userClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("user client for cluster [%s] not found", cluster)
+	}
+
+	traceLogs := &TraceLogs{TraceID: traceID, Entries: []TraceLogEntry{}}
+	for _, pod := range tracePods(&trace.Data) {
+		k8sPod, err := userClient.GetPod(pod.namespace, pod.name)
+		if err != nil {
+			// The pod may be gone since the trace
+			log.Debugf("Unable to get pod [%s/%s] of trace [%s]: %s", pod.namespace, pod.name, traceID, err)
+			continue
+		}
+		since := pod.start.Add(-traceLogsMargin)
+		until := pod.end.Add(traceLogsMargin)
+		for _, container := range k8sPod.Spec.Containers {
+			opts := &LogOptions{}
+			opts.Container = container.Name
+			opts.Timestamps = true
+			opts.SinceTime = &meta_v1.Time{Time: since}
+			logsReader, err := userClient.StreamPodLogs(pod.namespace, pod.name, &opts.PodLogOptions)
+			if err != nil {
+				log.Debugf("Unable to get the logs of container [%s] of pod [%s/%s]: %s", container.Name, pod.namespace, pod.name, err)
+				continue
+			}
+
+			entries := make(chan *LogEntry)
+			done := make(chan struct{})
+			go readContainerLogs(logsReader, container.Name, container.Name == "istio-proxy", nil, entries, done)
+			for entry := range entries {
+				if entry.OriginalTime.After(until) {
+					break
+				}
+				if entry.OriginalTime.Before(since) {
+					continue
+				}
+				traceLogs.Entries = append(traceLogs.Entries, TraceLogEntry{Namespace: pod.namespace, Pod: pod.name, LogEntry: *entry})
+			}
+			close(done)
+			if err := logsReader.Close(); err != nil {
+				log.Errorf("Error when closing the connection streaming logs of a pod: %s", err.Error())
+			}
+		}
+	}
+
+	sort.SliceStable(traceLogs.Entries, func(i, j int) bool {
+		return traceLogs.Entries[i].OriginalTime.Before(traceLogs.Entries[j].OriginalTime)
+	})
+	if len(traceLogs.Entries) > traceLogsMaxLines {
+		traceLogs.Entries = traceLogs.Entries[:traceLogsMaxLines]
+		traceLogs.LinesTruncated = true
+	}
+	return traceLogs, nil
+}
+
+// tracePods returns the pods of the spans of a trace, sorted by name, with the time window of their spans
+func tracePods(trace *jaegerModels.Trace) []*tracePod {
+	pods := map[string]*tracePod{}
+	for _, span := range trace.Spans {
+		if span.Process == nil {
+			if process, ok := trace.Processes[span.ProcessID]; ok {
+				span.Process = &process
+			}
+		}
+		namespace, name := spanPod(&span)
+		if name == "" || namespace == "" {
+			continue
+		}
+		start := time.UnixMicro(int64(span.StartTime))
+		end := start.Add(time.Duration(span.Duration) * time.Microsecond)
+		key := namespace + "/" + name
+		if pod, ok := pods[key]; ok {
+			if start.Before(pod.start) {
+				pod.start = start
+			}
+			if end.After(pod.end) {
+				pod.end = end
+			}
+		} else {
+			pods[key] = &tracePod{namespace: namespace, name: name, start: start, end: end}
+		}
+	}
+
+	sorted := make([]*tracePod, 0, len(pods))
+	for _, pod := range pods {
+		sorted = append(sorted, pod)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].namespace != sorted[j].namespace {
+			return sorted[i].namespace < sorted[j].namespace
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+// spanPod returns the namespace and the name of the pod of a span. For envoy spans, the node_id tag is like
+// sidecar~172.17.0.20~reviews-v1-6d8996bff-ztg6z.bookinfo~bookinfo.svc.cluster.local. Otherwise, the pod is the
+// hostname of the span or of its process, in the namespace of the service name (app.namespace).
+func spanPod(span *jaegerModels.Span) (string, string) {
+	hostname := ""
+	tags := span.Tags
+	if span.Process != nil {
+		tags = append(append([]jaegerModels.KeyValue{}, span.Tags...), span.Process.Tags...)
+	}
+	for _, tag := range tags {
+		v, ok := tag.Value.(string)
+		if !ok {
+			continue
+		}
+		switch tag.Key {
+		case "node_id":
+			parts := strings.Split(v, "~")
+			if len(parts) >= 3 {
+				if name, namespace, found := strings.Cut(parts[2], "."); found {
+					return namespace, name
+				}
+			}
+		case "hostname":
+			if hostname == "" {
+				hostname = v
+			}
+		}
+	}
+	if hostname != "" && span.Process != nil {
+		if _, namespace, found := strings.Cut(span.Process.ServiceName, "."); found {
+			return namespace, hostname
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,94 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/tracing/jaeger/model"
+	jaegerModels "github.com/kiali/kiali/tracing/jaeger/model/json"
+	"github.com/kiali/kiali/tracing/tracingtest"
+)
+
+func TestGetLogsForTrace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	pod := &core_v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "details-v1-3618568057-dnkjp", Namespace: "Namespace"},
+		Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Name: "details"}, {Name: "istio-proxy"}}},
+	}
+	k8s := &containerLogStreamer{
+		logs:            newContainerLogStreamer().logs,
+		ClientInterface: kubetest.NewFakeK8sClient(&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}}, pod),
+	}
+
+	// The logs are kept from 03:34:29.5 to 03:34:33.5, with the margin
+	start := time.Date(2018, 1, 2, 3, 34, 31, 500000000, time.UTC)
+	trace := jaegerModels.Trace{TraceID: "t1", Spans: []jaegerModels.Span{
+		{
+			SpanID:    "s1",
+			StartTime: uint64(start.UnixMicro()),
+			Process:   &jaegerModels.Process{ServiceName: "details.Namespace"},
+			Tags:      []jaegerModels.KeyValue{{Key: "node_id", Value: "sidecar~10.244.0.8~details-v1-3618568057-dnkjp.Namespace~Namespace.svc.cluster.local"}},
+		},
+		{
+			// The pod is gone
+			SpanID:    "s2",
+			StartTime: uint64(start.UnixMicro()),
+			Process:   &jaegerModels.Process{ServiceName: "reviews.Namespace", Tags: []jaegerModels.KeyValue{{Key: "hostname", Value: "reviews-v1-6d8996bff-ztg6z"}}},
+		},
+	}}
+	tracingClient := new(tracingtest.TracingClientMock)
+	tracingClient.On("GetTraceDetail", "t1").Return(&model.TracingSingleTrace{Data: trace}, nil)
+	tracingClient.On("GetTraceDetail", "t2").Return((*model.TracingSingleTrace)(nil), nil)
+
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, tracingClient)
+
+	traceLogs, err := layer.Tracing.GetLogsForTrace(context.TODO(), conf.KubernetesConfig.ClusterName, "t1")
+	require.NoError(err)
+	require.Len(traceLogs.Entries, 3)
+	assert.False(traceLogs.LinesTruncated)
+	for i, container := range []string{"details", "istio-proxy", "details"} {
+		assert.Equal(container, traceLogs.Entries[i].Container)
+		assert.Equal("Namespace", traceLogs.Entries[i].Namespace)
+		assert.Equal("details-v1-3618568057-dnkjp", traceLogs.Entries[i].Pod)
+	}
+	assert.Equal("2018-01-02 03:34:30.000", traceLogs.Entries[0].Timestamp)
+	assert.NotNil(traceLogs.Entries[1].AccessLog)
+
+	traceLogs, err = layer.Tracing.GetLogsForTrace(context.TODO(), conf.KubernetesConfig.ClusterName, "t2")
+	require.NoError(err)
+	assert.Nil(traceLogs)
+}
+
+func TestSpanPod(t *testing.T) {
+	assert := assert.New(t)
+
+	namespace, name := spanPod(&jaegerModels.Span{Tags: []jaegerModels.KeyValue{{Key: "node_id", Value: "sidecar~172.17.0.20~reviews-v1-6d8996bff-ztg6z.bookinfo~bookinfo.svc.cluster.local"}}})
+	assert.Equal("bookinfo", namespace)
+	assert.Equal("reviews-v1-6d8996bff-ztg6z", name)
+
+	namespace, name = spanPod(&jaegerModels.Span{Tags: []jaegerModels.KeyValue{{Key: "hostname", Value: "reviews-v1-6d8996bff-ztg6z"}}, Process: &jaegerModels.Process{ServiceName: "reviews.bookinfo"}})
+	assert.Equal("bookinfo", namespace)
+	assert.Equal("reviews-v1-6d8996bff-ztg6z", name)
+
+	// Without the namespace of the service name
+	namespace, name = spanPod(&jaegerModels.Span{Tags: []jaegerModels.KeyValue{{Key: "hostname", Value: "reviews-v1-6d8996bff-ztg6z"}}, Process: &jaegerModels.Process{ServiceName: "reviews"}})
+	assert.Empty(namespace)
+	assert.Empty(name)
+}
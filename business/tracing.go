@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
@@ -21,26 +22,69 @@ type (
 )
 
 type TracingService struct {
-	conf     *config.Config
-	svc      *SvcService
-	tracing  tracing.ClientInterface
-	workload *WorkloadService
+	conf           *config.Config
+	kialiSAClients map[string]kubernetes.ClientInterface
+	svc            *SvcService
+	tracing        tracing.ClientInterface
+	workload       *WorkloadService
 }
 
-func NewTracingService(conf *config.Config, tracing tracing.ClientInterface, svcService *SvcService, workloadService *WorkloadService) TracingService {
+// The clients of the clusters overriding the tracing backend, shared by the requests
+var (
+	clusterTracingClients     = map[string]tracing.ClientInterface{}
+	clusterTracingClientsLock sync.Mutex
+)
+
+func NewTracingService(conf *config.Config, tracing tracing.ClientInterface, kialiSAClients map[string]kubernetes.ClientInterface, svcService *SvcService, workloadService *WorkloadService) TracingService {
 	return TracingService{
-		conf:     conf,
-		svc:      svcService,
-		tracing:  tracing,
-		workload: workloadService,
+		conf:           conf,
+		kialiSAClients: kialiSAClients,
+		svc:            svcService,
+		tracing:        tracing,
+		workload:       workloadService,
 	}
 }
 
-func (in *TracingService) client() (tracing.ClientInterface, error) {
-	if !in.conf.ExternalServices.Tracing.Enabled {
+// tracingConfig returns the tracing config of the cluster, and whether the cluster overrides the default one
+func (in *TracingService) tracingConfig(cluster string) (config.TracingConfig, bool) {
+	if override, ok := in.conf.ExternalServices.Tracing.ClusterTracing[cluster]; ok && cluster != "" {
+		if override.QueryTimeout == 0 {
+			override.QueryTimeout = in.conf.ExternalServices.Tracing.QueryTimeout
+		}
+		if override.GrpcPort == 0 {
+			override.GrpcPort = in.conf.ExternalServices.Tracing.GrpcPort
+		}
+		return override, true
+	}
+	return in.conf.ExternalServices.Tracing, false
+}
+
+// client returns the tracing client of the cluster: the client of its own backend when the cluster overrides the
+// tracing backend, the default client otherwise
+func (in *TracingService) client(cluster string) (tracing.ClientInterface, error) {
+	tracingConfig, override := in.tracingConfig(cluster)
+	if !tracingConfig.Enabled {
 		return nil, fmt.Errorf("Tracing is not enabled")
 	}
 
+	if override {
+		clusterTracingClientsLock.Lock()
+		defer clusterTracingClientsLock.Unlock()
+		if client, ok := clusterTracingClients[cluster]; ok {
+			return client, nil
+		}
+		token := ""
+		if saClient, ok := in.kialiSAClients[in.conf.KubernetesConfig.ClusterName]; ok {
+			token = saClient.GetToken()
+		}
+		client, err := tracing.NewClientForConfig(context.Background(), in.conf, tracingConfig, token)
+		if err != nil {
+			return nil, fmt.Errorf("cannot initialize the Tracing client of cluster [%s]: %v", cluster, err)
+		}
+		clusterTracingClients[cluster] = client
+		return client, nil
+	}
+
 	if in.tracing == nil {
 		return nil, fmt.Errorf("Tracing client is not initialized")
 	}
@@ -50,14 +94,16 @@ func (in *TracingService) client() (tracing.ClientInterface, error) {
 
 func (in *TracingService) getFilteredSpans(ns, app string, query models.TracingQuery, filter SpanFilter) ([]model.TracingSpan, error) {
 	// This is info needed for Tempo as it is not in the results by default
-	if in.conf.ExternalServices.Tracing.Provider == config.TempoProvider {
+	conf := *in.conf
+	conf.ExternalServices.Tracing, _ = in.tracingConfig(query.Cluster)
+	if conf.ExternalServices.Tracing.Provider == config.TempoProvider {
 		query.Tags["http.method"] = ".*"
 	}
 	r, err := in.GetAppTraces(ns, app, query)
 	if err != nil {
 		return []model.TracingSpan{}, err
 	}
-	spans := tracesToSpans(app, r, filter, in.conf)
+	spans := tracesToSpans(app, r, filter, &conf)
 	return spans, nil
 }
 
@@ -136,7 +182,7 @@ func wkdSpanFilter(ns, workload string) SpanFilter {
 }
 
 func (in *TracingService) GetAppTraces(ns, app string, query models.TracingQuery) (*model.TracingResponse, error) {
-	client, err := in.client()
+	client, err := in.client(query.Cluster)
 	if err != nil {
 		return nil, err
 	}
@@ -236,8 +282,125 @@ func (in *TracingService) GetWorkloadTraces(ctx context.Context, ns, workload st
 	return r, err
 }
 
+// GetServiceTracingName returns the name of the spans of an app, service or workload in the tracing backend and the
+// app the traces are looked up with: the app of the workloads, suffixed with the namespace when the tracing service
+// names are namespaced.
+func (in *TracingService) GetServiceTracingName(ctx context.Context, endpoint models.TracingEdgeEndpoint) (tracingName string, app string, err error) {
+	switch endpoint.Kind {
+	case models.TracingEdgeApp:
+		app = endpoint.Name
+	case models.TracingEdgeService:
+		app, err = in.svc.GetServiceAppName(ctx, endpoint.Cluster, endpoint.Namespace, endpoint.Name)
+	case models.TracingEdgeWorkload:
+		app, err = in.workload.GetWorkloadAppName(ctx, endpoint.Cluster, endpoint.Namespace, endpoint.Name)
+	default:
+		err = fmt.Errorf("invalid node kind [%s], it must be app, service or workload", endpoint.Kind)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return tracing.BuildTracingServiceName(endpoint.Namespace, app), app, nil
+}
+
+// GetEdgeTraces returns the traces crossing a graph edge: the traces of the destination which also are traces of the
+// source, where a span of the destination is a child of a span of the source. Tempo only returns the matching spans
+// of the traces, so a trace without span of the source is kept when both lookups return it.
+func (in *TracingService) GetEdgeTraces(ctx context.Context, source, dest models.TracingEdgeEndpoint, query models.TracingQuery) (*model.TracingResponse, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetEdgeTraces",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", query.Cluster),
+		observability.Attribute("sourceNamespace", source.Namespace),
+		observability.Attribute("source", source.Name),
+		observability.Attribute("destNamespace", dest.Namespace),
+		observability.Attribute("dest", dest.Name),
+	)
+	defer end()
+
+	sourceName, sourceApp, err := in.GetServiceTracingName(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	destName, destApp, err := in.GetServiceTracingName(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceTraces, err := in.GetAppTraces(source.Namespace, sourceApp, query)
+	if err != nil {
+		return nil, err
+	}
+	destTraces, err := in.GetAppTraces(dest.Namespace, destApp, query)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceTraceIDs := map[jaegerModels.TraceID]bool{}
+	for _, trace := range sourceTraces.Data {
+		sourceTraceIDs[trace.TraceID] = true
+	}
+	var destFilter SpanFilter
+	switch dest.Kind {
+	case models.TracingEdgeService:
+		if destApp != dest.Name {
+			destFilter = operationSpanFilter(dest.Namespace, dest.Name)
+		}
+	case models.TracingEdgeWorkload:
+		destFilter = wkdSpanFilter(dest.Namespace, dest.Name)
+	}
+
+	r := &model.TracingResponse{TracingServiceName: destName, Errors: append(sourceTraces.Errors, destTraces.Errors...)}
+	for _, trace := range destTraces.Data {
+		if query.Limit > 0 && len(r.Data) >= query.Limit {
+			break
+		}
+		if sourceTraceIDs[trace.TraceID] && crossesEdge(&trace, sourceName, destName, destFilter) {
+			r.Data = append(r.Data, trace)
+		}
+	}
+	return r, nil
+}
+
+// crossesEdge returns true when a span of the destination, matching the filter, is a child of a span of the source
+// or when the trace has no span of the source
+func crossesEdge(trace *jaegerModels.Trace, sourceName, destName string, destFilter SpanFilter) bool {
+	serviceName := func(span *jaegerModels.Span) string {
+		if span.Process != nil {
+			return span.Process.ServiceName
+		}
+		return trace.Processes[span.ProcessID].ServiceName
+	}
+
+	sourceSpans := map[jaegerModels.SpanID]bool{}
+	for _, span := range trace.Spans {
+		if serviceName(&span) == sourceName {
+			sourceSpans[span.SpanID] = true
+		}
+	}
+	for _, span := range trace.Spans {
+		if serviceName(&span) != destName {
+			continue
+		}
+		if process, ok := trace.Processes[span.ProcessID]; ok && span.Process == nil {
+			span.Process = &process
+		}
+		if destFilter != nil && !destFilter(&span) {
+			continue
+		}
+		if len(sourceSpans) == 0 {
+			return true
+		}
+		for _, ref := range span.References {
+			if ref.RefType == jaegerModels.ChildOf && sourceSpans[ref.SpanID] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (in *TracingService) getAppTracesSlicedInterval(ns, app string, query models.TracingQuery) (*model.TracingResponse, error) {
-	client, err := in.client()
+	client, err := in.client(query.Cluster)
 	if err != nil {
 		return nil, err
 	}
@@ -283,24 +446,25 @@ func (in *TracingService) getAppTracesSlicedInterval(ns, app string, query model
 	return merged, err
 }
 
-func (in *TracingService) GetTraceDetail(traceID string) (trace *model.TracingSingleTrace, err error) {
-	client, err := in.client()
+func (in *TracingService) GetTraceDetail(cluster, traceID string) (trace *model.TracingSingleTrace, err error) {
+	client, err := in.client(cluster)
 	if err != nil {
 		return nil, err
 	}
 	return client.GetTraceDetail(traceID)
 }
 
-func (in *TracingService) GetErrorTraces(ns, app string, duration time.Duration) (errorTraces int, err error) {
-	client, err := in.client()
+func (in *TracingService) GetErrorTraces(cluster, ns, app string, duration time.Duration) (errorTraces int, err error) {
+	client, err := in.client(cluster)
 	if err != nil {
 		return 0, err
 	}
 	return client.GetErrorTraces(ns, app, duration)
 }
 
+// GetStatus returns whether the default tracing backend is accessible
 func (in *TracingService) GetStatus() (accessible bool, err error) {
-	client, err := in.client()
+	client, err := in.client("")
 	if err != nil {
 		return false, err
 	}
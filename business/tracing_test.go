@@ -1,13 +1,20 @@
 package business
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/tracing/jaeger/model"
 	jaegerModels "github.com/kiali/kiali/tracing/jaeger/model/json"
+	"github.com/kiali/kiali/tracing/tracingtest"
 )
 
 var trace1 = jaegerModels.Trace{
@@ -161,3 +168,96 @@ func TestTracesToSpanWithWorkloadFilter(t *testing.T) {
 	assert.Equal("t2_process_2", string(spans[0].ProcessID))
 	assert.Equal("t2_process_3", string(spans[1].ProcessID))
 }
+
+func TestGetEdgeTraces(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	processes := map[jaegerModels.ProcessID]jaegerModels.Process{
+		"p1": {ServiceName: "productpage.bookinfo"},
+		"p2": {ServiceName: "reviews.bookinfo"},
+	}
+	childOf := func(spanID jaegerModels.SpanID) []jaegerModels.Reference {
+		return []jaegerModels.Reference{{RefType: jaegerModels.ChildOf, SpanID: spanID}}
+	}
+	// productpage calls reviews
+	crossing := jaegerModels.Trace{TraceID: "t1", Processes: processes, Spans: []jaegerModels.Span{
+		{SpanID: "s1", ProcessID: "p1"},
+		{SpanID: "s2", ProcessID: "p2", References: childOf("s1")},
+	}}
+	// productpage and reviews are both called by another service
+	notCrossing := jaegerModels.Trace{TraceID: "t2", Processes: processes, Spans: []jaegerModels.Span{
+		{SpanID: "s1", ProcessID: "p1", References: childOf("s0")},
+		{SpanID: "s2", ProcessID: "p2", References: childOf("s0")},
+	}}
+	// Tempo only returns the spans of reviews
+	partial := jaegerModels.Trace{TraceID: "t3", Spans: []jaegerModels.Span{
+		{SpanID: "s2", Process: &jaegerModels.Process{ServiceName: "reviews.bookinfo"}, References: childOf("s1")},
+	}}
+	reviewsOnly := jaegerModels.Trace{TraceID: "t4", Processes: processes, Spans: []jaegerModels.Span{
+		{SpanID: "s2", ProcessID: "p2"},
+	}}
+
+	q := models.TracingQuery{Limit: 100}
+	tracingClient := new(tracingtest.TracingClientMock)
+	tracingClient.On("GetAppTraces", "bookinfo", "productpage", q).Return(&model.TracingResponse{Data: []jaegerModels.Trace{crossing, notCrossing, partial}}, nil)
+	tracingClient.On("GetAppTraces", "bookinfo", "reviews", q).Return(&model.TracingResponse{Data: []jaegerModels.Trace{crossing, notCrossing, partial, reviewsOnly}}, nil)
+
+	k8s := kubetest.NewFakeK8sClient()
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, tracingClient)
+
+	source := models.TracingEdgeEndpoint{Namespace: "bookinfo", Kind: models.TracingEdgeApp, Name: "productpage"}
+	dest := models.TracingEdgeEndpoint{Namespace: "bookinfo", Kind: models.TracingEdgeApp, Name: "reviews"}
+	r, err := layer.Tracing.GetEdgeTraces(context.TODO(), source, dest, q)
+	require.NoError(err)
+	assert.Equal("reviews.bookinfo", r.TracingServiceName)
+	require.Len(r.Data, 2)
+	assert.Equal(jaegerModels.TraceID("t1"), r.Data[0].TraceID)
+	assert.Equal(jaegerModels.TraceID("t3"), r.Data[1].TraceID)
+
+	dest.Kind = "pod"
+	_, err = layer.Tracing.GetEdgeTraces(context.TODO(), source, dest, q)
+	assert.Error(err)
+}
+
+func TestTracingClientPerCluster(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	east := conf.ExternalServices.Tracing
+	east.InClusterURL = "http://tracing.east:16685/jaeger"
+	conf.ExternalServices.Tracing.ClusterTracing = map[string]config.TracingConfig{"east": east}
+	config.Set(conf)
+
+	defaultClient := new(tracingtest.TracingClientMock)
+	defaultClient.On("GetAppTraces", "bookinfo", "reviews", mock.Anything).Return(&model.TracingResponse{TracingServiceName: "default"}, nil)
+	eastClient := new(tracingtest.TracingClientMock)
+	eastClient.On("GetAppTraces", "bookinfo", "reviews", mock.Anything).Return(&model.TracingResponse{TracingServiceName: "east"}, nil)
+	clusterTracingClients["east"] = eastClient
+	t.Cleanup(func() { delete(clusterTracingClients, "east") })
+
+	k8s := kubetest.NewFakeK8sClient()
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, defaultClient)
+
+	r, err := layer.Tracing.GetAppTraces("bookinfo", "reviews", models.TracingQuery{Cluster: "east"})
+	require.NoError(err)
+	assert.Equal("east", r.TracingServiceName)
+
+	r, err = layer.Tracing.GetAppTraces("bookinfo", "reviews", models.TracingQuery{Cluster: conf.KubernetesConfig.ClusterName})
+	require.NoError(err)
+	assert.Equal("default", r.TracingServiceName)
+
+	// The tracing backend of a cluster can be disabled
+	east.Enabled = false
+	conf.ExternalServices.Tracing.ClusterTracing["east"] = east
+	_, err = layer.Tracing.GetAppTraces("bookinfo", "reviews", models.TracingQuery{Cluster: "east"})
+	assert.Error(err)
+}
@@ -0,0 +1,64 @@
+package business
+
+import (
+	"context"
+
+	"github.com/kiali/kiali/business/checkers/authorization"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetTrustDomainReport lists the principals referenced by the AuthorizationPolicies of the mesh along with
+// whether their trust domain is one of the mesh, one only kept as an alias after a trust domain migration
+// or a trust domain the mesh doesn't know about.
+func (in *MeshService) GetTrustDomainReport(ctx context.Context) (*models.TrustDomainReport, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetTrustDomainReport",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	mesh, err := in.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := in.namespaceService.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.TrustDomainReport{
+		MeshTrustDomains: mesh.TrustDomains(),
+		Principals:       []models.PrincipalTrustDomain{},
+	}
+
+	for _, namespace := range namespaces {
+		kubeCache, err := in.kialiCache.GetKubeCache(namespace.Cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		policies, err := kubeCache.GetAuthorizationPolicies(namespace.Name, "")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policy := range policies {
+			key := models.IstioValidationKey{ObjectType: kubernetes.AuthorizationPoliciesType, Name: policy.Name, Namespace: policy.Namespace, Cluster: namespace.Cluster}
+			for _, principal := range authorization.PolicyPrincipals(policy) {
+				trustDomain, status := report.PrincipalTrustDomain(principal.Value)
+				report.Principals = append(report.Principals, models.PrincipalTrustDomain{
+					Policy:      key,
+					Path:        principal.Path,
+					Principal:   principal.Value,
+					TrustDomain: trustDomain,
+					Status:      status,
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,64 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetTrustDomainReport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "Kubernetes"
+	kubernetes.SetConfig(t, *conf)
+
+	// The mesh was migrated from the default trust domain.
+	istioConfigMap := fakeIstioConfigMap("default")
+	istioConfigMap.Data["mesh"] = "trustDomain: new.domain\ntrustDomainAliases:\n- cluster.local\n"
+
+	k8s := kubetest.NewFakeK8sClient(
+		runningIstiodPod(),
+		fakeIstiodDeployment(conf.KubernetesConfig.ClusterName, true),
+		istioConfigMap,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		data.CreateAuthorizationPolicyWithPrincipals("allow-productpage", "bookinfo", []string{
+			"new.domain/ns/bookinfo/sa/productpage",
+			"cluster.local/ns/bookinfo/sa/productpage",
+			"old.domain/ns/bookinfo/sa/productpage",
+		}),
+	)
+	k8s.KubeClusterInfo.Name = conf.KubernetesConfig.ClusterName
+
+	SetupBusinessLayer(t, k8s, *conf)
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(k8sclients, k8sclients, nil, nil)
+
+	report, err := layer.Mesh.GetTrustDomainReport(context.TODO())
+	require.NoError(err)
+	assert.Equal([]string{"new.domain"}, report.TrustDomains)
+	assert.Equal([]string{"cluster.local"}, report.Aliases)
+
+	require.Len(report.Principals, 3)
+	policy := models.IstioValidationKey{ObjectType: kubernetes.AuthorizationPoliciesType, Name: "allow-productpage", Namespace: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName}
+	for _, principal := range report.Principals {
+		assert.Equal(policy, principal.Policy)
+	}
+	assert.Equal(models.TrustDomainCurrent, report.Principals[0].Status)
+	assert.Equal("cluster.local", report.Principals[1].TrustDomain)
+	assert.Equal(models.TrustDomainStale, report.Principals[1].Status)
+	assert.Equal("spec/rules[0]/from[0]/source/principals[2]", report.Principals[2].Path)
+	assert.Equal(models.TrustDomainUnknown, report.Principals[2].Status)
+}
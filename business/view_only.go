@@ -0,0 +1,30 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/config"
+)
+
+// ReadOnlyError is returned by the changes of the business layer when Kiali is deployed in view-only mode, whatever
+// the permissions of the user. The handlers check the mode too, this protects the other users of the business layer.
+type ReadOnlyError struct {
+	msg string
+}
+
+func (in *ReadOnlyError) Error() string {
+	return in.msg
+}
+
+func IsReadOnlyError(err error) bool {
+	_, isReadOnlyError := err.(*ReadOnlyError)
+	return isReadOnlyError
+}
+
+// checkViewOnlyMode returns a ReadOnlyError for the change described by the action when Kiali is in view-only mode
+func checkViewOnlyMode(conf *config.Config, action string) error {
+	if conf.Deployment.ViewOnlyMode {
+		return &ReadOnlyError{msg: fmt.Sprintf("%s is not allowed in view-only mode", action)}
+	}
+	return nil
+}
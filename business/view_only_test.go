@@ -0,0 +1,60 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestViewOnlyModeBlocksChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.ViewOnlyMode = true
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		data.CreateEmptyVirtualService("reviews", "test", []string{"reviews"}),
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "test"}},
+	)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+	cluster := conf.KubernetesConfig.ClusterName
+	ctx := context.TODO()
+
+	_, err := layer.IstioConfig.CreateIstioConfigDetail(ctx, cluster, "test", kubernetes.VirtualServices, []byte("{}"))
+	assert.True(IsReadOnlyError(err))
+	_, err = layer.IstioConfig.UpdateIstioConfigDetail(ctx, cluster, "test", kubernetes.VirtualServices, "reviews", "{}")
+	assert.True(IsReadOnlyError(err))
+	err = layer.IstioConfig.DeleteIstioConfigDetail(ctx, cluster, "test", kubernetes.VirtualServices, "reviews")
+	assert.True(IsReadOnlyError(err))
+	_, err = layer.Svc.UpdateService(ctx, cluster, "test", "reviews", "60s", time.Now(), "{}", "")
+	assert.True(IsReadOnlyError(err))
+	_, err = layer.Workload.UpdateWorkload(ctx, cluster, "test", "reviews-v1", "", false, "{}", "")
+	assert.True(IsReadOnlyError(err))
+	_, err = layer.Namespace.UpdateNamespace(ctx, "test", "{}", cluster)
+	assert.True(IsReadOnlyError(err))
+	err = layer.ProxyLogging.SetLogLevel(cluster, "test", "reviews-v1", "debug")
+	assert.True(IsReadOnlyError(err))
+	_, err = layer.GraphViews.SaveGraphView(ctx, models.GraphView{Name: "bookinfo", Namespaces: []string{"test"}}, "admin")
+	assert.True(IsReadOnlyError(err))
+	err = layer.GraphViews.DeleteGraphView(ctx, "bookinfo")
+	assert.True(IsReadOnlyError(err))
+	assert.Contains(err.Error(), "view-only mode")
+
+	// The object was not deleted
+	_, err = layer.IstioConfig.GetIstioConfigDetails(ctx, cluster, "test", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+}
@@ -0,0 +1,86 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetWaypointTraffic breaks down the requests proxied by a waypoint per enrolled service, from the telemetry the
+// waypoint reports, to tell which of the services behind it generate errors. Only the services of namespaces
+// accessible to the user are reported.
+func (in *WorkloadService) GetWaypointTraffic(ctx context.Context, cluster, namespace, waypoint, rateInterval string, queryTime time.Time) (*models.WaypointTraffic, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetWaypointTraffic",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("waypoint", waypoint),
+		observability.Attribute("rateInterval", rateInterval),
+		observability.Attribute("queryTime", queryTime),
+	)
+	defer end()
+
+	workload, err := in.GetWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: waypoint})
+	if err != nil {
+		return nil, err
+	}
+	if workload.Labels[config.WaypointLabel] != config.WaypointLabelValue {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("workload [%s] of namespace [%s] is not a waypoint proxy", waypoint, namespace))
+	}
+
+	rates, err := in.prom.GetWaypointRequestRates(namespace, cluster, waypoint, rateInterval, queryTime)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	accessible := map[string]bool{}
+	for _, ns := range namespaces {
+		accessible[ns.Name] = true
+	}
+
+	traffic := &models.WaypointTraffic{
+		Cluster:      cluster,
+		Namespace:    namespace,
+		Waypoint:     waypoint,
+		RateInterval: rateInterval,
+		Services:     []models.WaypointServiceTraffic{},
+	}
+	services := map[string]*models.WaypointServiceTraffic{}
+	for _, sample := range rates {
+		svcNamespace, svcName := string(sample.Metric["destination_service_namespace"]), string(sample.Metric["destination_service_name"])
+		if !accessible[svcNamespace] {
+			continue
+		}
+		key := svcNamespace + "/" + svcName
+		service, found := services[key]
+		if !found {
+			service = models.NewWaypointServiceTraffic(svcName, svcNamespace)
+			services[key] = service
+		}
+		service.AddSample(sample)
+	}
+
+	for _, service := range services {
+		traffic.Services = append(traffic.Services, *service)
+	}
+	sort.Slice(traffic.Services, func(i, j int) bool {
+		if traffic.Services[i].ErrorRate != traffic.Services[j].ErrorRate {
+			return traffic.Services[i].ErrorRate > traffic.Services[j].ErrorRate
+		}
+		return traffic.Services[i].Rate > traffic.Services[j].Rate
+	})
+
+	return traffic, nil
+}
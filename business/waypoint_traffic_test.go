@@ -0,0 +1,97 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func TestGetWaypointTraffic(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.ClusterWideAccess = true
+	kubernetes.SetConfig(t, *conf)
+
+	fakePod := func(name string, labels map[string]string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: labels},
+			Status:     core_v1.PodStatus{Phase: core_v1.PodRunning},
+		}
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		fakePod("waypoint", map[string]string{"app": "waypoint", config.WaypointLabel: config.WaypointLabelValue}),
+		fakePod("reviews-v1", map[string]string{"app": "reviews"}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	sample := func(svcNamespace, svc, protocol, code string, rate float64) *model.Sample {
+		return &model.Sample{
+			Metric: model.Metric{
+				"reporter":                      "waypoint",
+				"source_workload_namespace":     "bookinfo",
+				"source_workload":               "waypoint",
+				"destination_service_namespace": model.LabelValue(svcNamespace),
+				"destination_service_name":      model.LabelValue(svc),
+				"request_protocol":              model.LabelValue(protocol),
+				"response_code":                 model.LabelValue(code),
+			},
+			Value: model.SampleValue(rate),
+		}
+	}
+	queryTime := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetWaypointRequestRates", "bookinfo", conf.KubernetesConfig.ClusterName, "waypoint", "10m", queryTime).Return(model.Vector{
+		sample("bookinfo", "details", "http", "200", 3),
+		sample("bookinfo", "reviews", "http", "200", 2),
+		sample("bookinfo", "reviews", "http", "503", 1),
+		sample("bookinfo", "reviews", "http", "0", 0.5),
+		sample("bookinfo", "reviews", "http", "404", 0.5),
+		// Not accessible to the user
+		sample("private", "secrets", "http", "500", 10),
+	}, nil)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	layer := NewWithBackends(k8sclients, k8sclients, prom, nil)
+
+	traffic, err := layer.Workload.GetWaypointTraffic(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "waypoint", "10m", queryTime)
+	require.NoError(err)
+	assert.Equal("waypoint", traffic.Waypoint)
+	assert.Equal("10m", traffic.RateInterval)
+	require.Len(traffic.Services, 2)
+
+	// The erroneous service first
+	reviews := traffic.Services[0]
+	assert.Equal("reviews", reviews.Name)
+	assert.Equal(float64(4), reviews.Rate)
+	assert.Equal(1.5, reviews.ErrorRate)
+	assert.Equal(map[string]map[string]float64{"http": {"200": 2, "503": 1, "-": 0.5, "404": 0.5}}, reviews.Requests)
+
+	assert.Equal(models.WaypointServiceTraffic{
+		Name:      "details",
+		Namespace: "bookinfo",
+		Rate:      3,
+		Requests:  map[string]map[string]float64{"http": {"200": 3}},
+	}, traffic.Services[1])
+
+	// Only waypoints proxy traffic
+	_, err = layer.Workload.GetWaypointTraffic(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1", "10m", queryTime)
+	assert.True(api_errors.IsBadRequest(err))
+}
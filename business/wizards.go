@@ -0,0 +1,390 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// Kiali wizards label the objects they generate with their scenario
+const (
+	KialiWizardLabel            = "kiali_wizard"
+	WizardLocalityLoadBalancing = "locality_load_balancing"
+	WizardTrafficMirroring      = "traffic_mirroring"
+)
+
+// Labels of the nodes with their locality
+const (
+	nodeRegionLabel  = "topology.kubernetes.io/region"
+	nodeZoneLabel    = "topology.kubernetes.io/zone"
+	nodeSubZoneLabel = "topology.istio.io/subzone"
+)
+
+// TrafficMirroringCriteria is the mirror target of the traffic mirroring wizard of a service: a subset (version)
+// of the service or another host.
+type TrafficMirroringCriteria struct {
+	Cluster     string
+	Namespace   string
+	ServiceName string
+	// Subset is the version of the service the requests are mirrored to, it receives no live traffic
+	Subset string
+	// Host is a service of the mesh or a ServiceEntry host the requests are mirrored to
+	Host string
+	// Port of the host, the port of the request when unset
+	Port uint32
+	// Percentage of the requests which are mirrored, all of them when unset
+	Percentage float64
+}
+
+// GenerateTrafficMirroring generates the VirtualService and the DestinationRule which route the requests of a
+// service to its workloads and mirror them to the target of the criteria. The DestinationRule defines a subset
+// per version of the service workloads. When the target is a subset, the live traffic is evenly routed to the
+// other subsets. The target must exist: a subset of the service, a service of the cluster or a ServiceEntry host.
+func (in *IstioConfigService) GenerateTrafficMirroring(ctx context.Context, criteria TrafficMirroringCriteria) (*models.WizardScenario, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GenerateTrafficMirroring",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("namespace", criteria.Namespace),
+		observability.Attribute("service", criteria.ServiceName),
+	)
+	defer end()
+
+	if err := checkFeatureEnabled(&in.config, config.FeatureWizards); err != nil {
+		return nil, err
+	}
+	if (criteria.Subset == "") == (criteria.Host == "") {
+		return nil, api_errors.NewBadRequest("traffic mirroring requires either a subset or a host to mirror to")
+	}
+	if criteria.Percentage < 0 || criteria.Percentage > 100 {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("invalid mirror percentage [%v], it must be between 0 and 100", criteria.Percentage))
+	}
+
+	versions, err := in.serviceVersions(ctx, criteria.Cluster, criteria.Namespace, criteria.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceHost := kubernetes.ParseHost(criteria.ServiceName, criteria.Namespace).String()
+	routeSubsets := versions
+	mirror := &api_networking_v1beta1.Destination{}
+	if criteria.Subset != "" {
+		routeSubsets = []string{}
+		found := false
+		for _, version := range versions {
+			if version == criteria.Subset {
+				found = true
+			} else {
+				routeSubsets = append(routeSubsets, version)
+			}
+		}
+		if !found {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("mirror subset [%s] is not a version of the workloads of service [%s]", criteria.Subset, criteria.ServiceName))
+		}
+		if len(routeSubsets) == 0 {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("service [%s] has no other version than the mirror subset [%s] to route the requests to", criteria.ServiceName, criteria.Subset))
+		}
+		mirror.Host = serviceHost
+		mirror.Subset = criteria.Subset
+	} else {
+		if err := in.checkMirrorHost(ctx, criteria); err != nil {
+			return nil, err
+		}
+		// The live traffic goes to the service, the subsets are kept for later routing
+		routeSubsets = []string{""}
+		mirror.Host = criteria.Host
+		if criteria.Port > 0 {
+			mirror.Port = &api_networking_v1beta1.PortSelector{Number: criteria.Port}
+		}
+	}
+
+	dr := in.wizardDestinationRule(WizardTrafficMirroring, criteria.Namespace, criteria.ServiceName, versions)
+
+	httpRoute := &api_networking_v1beta1.HTTPRoute{Mirror: mirror}
+	for i, subset := range routeSubsets {
+		// The remainder of the weights goes to the first subset
+		weight := 100 / int32(len(routeSubsets))
+		if i == 0 {
+			weight += 100 % int32(len(routeSubsets))
+		}
+		httpRoute.Route = append(httpRoute.Route, &api_networking_v1beta1.HTTPRouteDestination{
+			Destination: &api_networking_v1beta1.Destination{Host: serviceHost, Subset: subset},
+			Weight:      weight,
+		})
+	}
+	if criteria.Percentage > 0 {
+		httpRoute.MirrorPercentage = &api_networking_v1beta1.Percent{Value: criteria.Percentage}
+	}
+
+	vs := &networking_v1beta1.VirtualService{}
+	vs.Kind = kubernetes.VirtualServiceType
+	vs.APIVersion = kubernetes.ApiNetworkingVersionV1Beta1
+	vs.Name = criteria.ServiceName
+	vs.Namespace = criteria.Namespace
+	vs.Labels = map[string]string{KialiWizardLabel: WizardTrafficMirroring}
+	vs.Spec.Hosts = []string{criteria.ServiceName}
+	vs.Spec.Http = []*api_networking_v1beta1.HTTPRoute{httpRoute}
+
+	return &models.WizardScenario{
+		Scenario:        WizardTrafficMirroring,
+		VirtualService:  vs,
+		DestinationRule: dr,
+	}, nil
+}
+
+// checkMirrorHost returns a bad request error when the mirror host is neither a service of the cluster nor a
+// ServiceEntry host
+func (in *IstioConfigService) checkMirrorHost(ctx context.Context, criteria TrafficMirroringCriteria) error {
+	namespaces, err := in.businessLayer.Namespace.GetClusterNamespaces(ctx, criteria.Cluster)
+	if err != nil {
+		return err
+	}
+	host := kubernetes.GetHost(criteria.Host, criteria.Namespace, models.Namespaces(namespaces).GetNames())
+	if host.CompleteInput {
+		if _, err := in.businessLayer.Svc.GetService(ctx, criteria.Cluster, host.Namespace, host.Service); err != nil {
+			if api_errors.IsNotFound(err) {
+				return api_errors.NewBadRequest(fmt.Sprintf("mirror host [%s] is not a service of the cluster", criteria.Host))
+			}
+			return err
+		}
+		return nil
+	}
+
+	istioConfigList, err := in.GetIstioConfigList(ctx, criteria.Cluster, IstioConfigCriteria{IncludeServiceEntries: true})
+	if err != nil {
+		return err
+	}
+	for seHost := range kubernetes.ServiceEntryHostnames(istioConfigList.ServiceEntries) {
+		if seHost == criteria.Host || kubernetes.HostWithinWildcardHost(criteria.Host, seHost) {
+			return nil
+		}
+	}
+	return api_errors.NewBadRequest(fmt.Sprintf("mirror host [%s] is not defined by any ServiceEntry", criteria.Host))
+}
+
+// serviceVersions returns the sorted versions of the workloads of a service, from their version label. It returns
+// an error when the service is not found.
+func (in *IstioConfigService) serviceVersions(ctx context.Context, cluster, namespace, service string) ([]string, error) {
+	svc, err := in.businessLayer.Svc.GetService(ctx, cluster, namespace, service)
+	if err != nil {
+		return nil, err
+	}
+	versions := []string{}
+	if len(svc.Selectors) == 0 {
+		return versions, nil
+	}
+	workloads, err := in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, namespace, labels.Set(svc.Selectors).String())
+	if err != nil {
+		return nil, err
+	}
+	versionLabelName := in.config.IstioLabels.VersionLabelName
+	seen := map[string]bool{}
+	for _, w := range workloads {
+		if version, ok := w.Labels[versionLabelName]; ok && version != "" && !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+// wizardDestinationRule returns the DestinationRule of a service generated by a wizard, with a subset per version
+func (in *IstioConfigService) wizardDestinationRule(scenario, namespace, service string, versions []string) *networking_v1beta1.DestinationRule {
+	dr := &networking_v1beta1.DestinationRule{}
+	dr.Kind = kubernetes.DestinationRuleType
+	dr.APIVersion = kubernetes.ApiNetworkingVersionV1Beta1
+	dr.Name = service
+	dr.Namespace = namespace
+	dr.Labels = map[string]string{KialiWizardLabel: scenario}
+	dr.Spec.Host = kubernetes.ParseHost(service, namespace).String()
+	for _, version := range versions {
+		dr.Spec.Subsets = append(dr.Spec.Subsets, &api_networking_v1beta1.Subset{
+			Name:   version,
+			Labels: map[string]string{in.config.IstioLabels.VersionLabelName: version},
+		})
+	}
+	return dr
+}
+
+// GetLocalities returns the localities of the nodes of every cluster, from their topology labels. The nodes
+// without region are ignored, as well as the clusters whose nodes can't be read.
+func (in *IstioConfigService) GetLocalities(ctx context.Context) []models.ClusterLocalities {
+	clusters := make([]string, 0, len(in.kialiSAClients))
+	for cluster := range in.kialiSAClients {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	clusterLocalities := []models.ClusterLocalities{}
+	for _, cluster := range clusters {
+		nodes, err := in.kialiSAClients[cluster].GetNodes()
+		if err != nil {
+			log.Warningf("Unable to get the nodes of cluster [%s]: %s", cluster, err)
+			continue
+		}
+		localities := map[string]*models.Locality{}
+		for _, node := range nodes {
+			locality := models.Locality{
+				Region:  node.Labels[nodeRegionLabel],
+				Zone:    node.Labels[nodeZoneLabel],
+				SubZone: node.Labels[nodeSubZoneLabel],
+			}
+			if locality.Region == "" {
+				continue
+			}
+			if _, found := localities[locality.String()]; !found {
+				localities[locality.String()] = &locality
+			}
+			localities[locality.String()].Nodes++
+		}
+		cl := models.ClusterLocalities{Cluster: cluster, Localities: []models.Locality{}}
+		for _, locality := range localities {
+			cl.Localities = append(cl.Localities, *locality)
+		}
+		sort.Slice(cl.Localities, func(i, j int) bool {
+			return cl.Localities[i].String() < cl.Localities[j].String()
+		})
+		clusterLocalities = append(clusterLocalities, cl)
+	}
+	return clusterLocalities
+}
+
+// GenerateLocalityLoadBalancing generates the DestinationRule which balances the requests of a service across the
+// localities of the mesh. Without distribution nor failover, every region fails over to the next region of the
+// mesh. The localities must be localities of the nodes of the mesh and a failover requires an outlier detection.
+func (in *IstioConfigService) GenerateLocalityLoadBalancing(ctx context.Context, cluster, namespace, service string, lb models.LocalityLoadBalancing) (*models.WizardScenario, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GenerateLocalityLoadBalancing",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("service", service),
+	)
+	defer end()
+
+	if err := checkFeatureEnabled(&in.config, config.FeatureWizards); err != nil {
+		return nil, err
+	}
+	if len(lb.Distribute) > 0 && len(lb.Failover) > 0 {
+		return nil, api_errors.NewBadRequest("locality distribution and failover are mutually exclusive")
+	}
+
+	versions, err := in.serviceVersions(ctx, cluster, namespace, service)
+	if err != nil {
+		return nil, err
+	}
+
+	localities := []models.Locality{}
+	for _, cl := range in.GetLocalities(ctx) {
+		localities = append(localities, cl.Localities...)
+	}
+	if len(lb.Distribute) == 0 && len(lb.Failover) == 0 {
+		lb.Failover = regionsFailover(localities)
+		if len(lb.Failover) == 0 {
+			return nil, api_errors.NewBadRequest("locality failover requires nodes in at least two regions")
+		}
+	}
+	if err := validateLocalityLoadBalancing(lb, localities); err != nil {
+		return nil, api_errors.NewBadRequest(err.Error())
+	}
+
+	dr := in.wizardDestinationRule(WizardLocalityLoadBalancing, namespace, service, versions)
+	dr.Spec.TrafficPolicy = &api_networking_v1beta1.TrafficPolicy{
+		LoadBalancer: &api_networking_v1beta1.LoadBalancerSettings{
+			LocalityLbSetting: &api_networking_v1beta1.LocalityLoadBalancerSetting{
+				Distribute: lb.Distribute,
+				Failover:   lb.Failover,
+				Enabled:    &wrapperspb.BoolValue{Value: true},
+			},
+		},
+		OutlierDetection: lb.OutlierDetection,
+	}
+
+	return &models.WizardScenario{
+		Scenario:        WizardLocalityLoadBalancing,
+		DestinationRule: dr,
+	}, nil
+}
+
+// regionsFailover returns the failover of every region to the next region, in alphabetical order
+func regionsFailover(localities []models.Locality) []*api_networking_v1beta1.LocalityLoadBalancerSetting_Failover {
+	regions := []string{}
+	for _, locality := range localities {
+		if !slices.Contains(regions, locality.Region) {
+			regions = append(regions, locality.Region)
+		}
+	}
+	if len(regions) < 2 {
+		return nil
+	}
+	sort.Strings(regions)
+	failover := []*api_networking_v1beta1.LocalityLoadBalancerSetting_Failover{}
+	for i, region := range regions {
+		failover = append(failover, &api_networking_v1beta1.LocalityLoadBalancerSetting_Failover{
+			From: region,
+			To:   regions[(i+1)%len(regions)],
+		})
+	}
+	return failover
+}
+
+// validateLocalityLoadBalancing returns an error when a locality of the load balancing matches no locality of the
+// nodes, when the weights of a distribution don't sum to 100 or when a failover has no outlier detection
+func validateLocalityLoadBalancing(lb models.LocalityLoadBalancing, localities []models.Locality) error {
+	exists := func(locality string) bool {
+		for _, l := range localities {
+			if l.Matches(locality) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, distribute := range lb.Distribute {
+		if distribute == nil {
+			continue
+		}
+		if !exists(distribute.From) {
+			return fmt.Errorf("locality [%s] matches no node of the mesh", distribute.From)
+		}
+		total := uint32(0)
+		for to, weight := range distribute.To {
+			if !exists(to) {
+				return fmt.Errorf("locality [%s] matches no node of the mesh", to)
+			}
+			total += weight
+		}
+		if total != 100 {
+			return fmt.Errorf("the weights of the distribution from [%s] sum to %d instead of 100", distribute.From, total)
+		}
+	}
+
+	for _, failover := range lb.Failover {
+		if failover == nil {
+			continue
+		}
+		for _, region := range []string{failover.From, failover.To} {
+			if !exists(region) {
+				return fmt.Errorf("region [%s] matches no node of the mesh", region)
+			}
+		}
+	}
+	if len(lb.Failover) > 0 && lb.OutlierDetection == nil {
+		return fmt.Errorf("locality failover requires an outlier detection, the unhealthy endpoints are not ejected without it")
+	}
+	return nil
+}
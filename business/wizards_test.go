@@ -0,0 +1,181 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestGenerateTrafficMirroring(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	reviews := kubetest.FakeService("ns", "reviews")
+	ratings := kubetest.FakeService("ns", "ratings")
+	se := &networking_v1beta1.ServiceEntry{ObjectMeta: meta_v1.ObjectMeta{Name: "external", Namespace: "ns"}}
+	se.Spec.Hosts = []string{"*.example.com"}
+	objects := []runtime.Object{&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}}, &reviews, &ratings, se}
+	for _, pod := range kubetest.FakePodList() {
+		objects = append(objects, pod.DeepCopy())
+	}
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	istioConfig := NewWithBackends(clients, clients, nil, nil).IstioConfig
+	criteria := TrafficMirroringCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "ns", ServiceName: "reviews"}
+
+	// mirror to a subset: the live traffic goes to the other subsets
+	criteria.Subset = "v2"
+	criteria.Percentage = 10
+	scenario, err := istioConfig.GenerateTrafficMirroring(context.TODO(), criteria)
+	require.NoError(err)
+	assert.Equal(WizardTrafficMirroring, scenario.Scenario)
+	assert.Equal(WizardTrafficMirroring, scenario.VirtualService.Labels[KialiWizardLabel])
+	require.Len(scenario.DestinationRule.Spec.Subsets, 2)
+	assert.Equal("v1", scenario.DestinationRule.Spec.Subsets[0].Name)
+	assert.Equal("reviews.ns.svc.cluster.local", scenario.DestinationRule.Spec.Host)
+	require.Len(scenario.VirtualService.Spec.Http, 1)
+	route := scenario.VirtualService.Spec.Http[0]
+	require.Len(route.Route, 1)
+	assert.Equal("v1", route.Route[0].Destination.Subset)
+	assert.Equal(int32(100), route.Route[0].Weight)
+	assert.Equal("reviews.ns.svc.cluster.local", route.Mirror.Host)
+	assert.Equal("v2", route.Mirror.Subset)
+	assert.Equal(10.0, route.MirrorPercentage.Value)
+
+	criteria.Subset = "v3"
+	_, err = istioConfig.GenerateTrafficMirroring(context.TODO(), criteria)
+	assert.True(api_errors.IsBadRequest(err))
+
+	// mirror to another service or to a ServiceEntry host
+	criteria.Subset = ""
+	criteria.Percentage = 0
+	criteria.Host = "ratings"
+	criteria.Port = 9080
+	scenario, err = istioConfig.GenerateTrafficMirroring(context.TODO(), criteria)
+	require.NoError(err)
+	route = scenario.VirtualService.Spec.Http[0]
+	require.Len(route.Route, 1)
+	assert.Empty(route.Route[0].Destination.Subset)
+	assert.Equal("ratings", route.Mirror.Host)
+	assert.Equal(uint32(9080), route.Mirror.Port.Number)
+	assert.Nil(route.MirrorPercentage)
+
+	criteria.Host = "shadow.example.com"
+	_, err = istioConfig.GenerateTrafficMirroring(context.TODO(), criteria)
+	require.NoError(err)
+
+	for _, host := range []string{"details", "shadow.example.org"} {
+		criteria.Host = host
+		_, err = istioConfig.GenerateTrafficMirroring(context.TODO(), criteria)
+		assert.True(api_errors.IsBadRequest(err), host)
+	}
+
+	criteria.Subset = "v1"
+	_, err = istioConfig.GenerateTrafficMirroring(context.TODO(), criteria)
+	assert.True(api_errors.IsBadRequest(err))
+}
+
+func fakeNode(name, region, zone string) *core_v1.Node {
+	node := &core_v1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: map[string]string{}}}
+	if region != "" {
+		node.Labels[nodeRegionLabel] = region
+	}
+	if zone != "" {
+		node.Labels[nodeZoneLabel] = zone
+	}
+	return node
+}
+
+func TestGenerateLocalityLoadBalancing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	reviews := kubetest.FakeService("ns", "reviews")
+	objects := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+		&reviews,
+		fakeNode("node-1", "us-east1", "us-east1-b"),
+		fakeNode("node-2", "us-east1", "us-east1-b"),
+		fakeNode("node-3", "us-west1", "us-west1-a"),
+		fakeNode("node-4", "", ""),
+	}
+	for _, pod := range kubetest.FakePodList() {
+		objects = append(objects, pod.DeepCopy())
+	}
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	istioConfig := NewWithBackends(clients, clients, nil, nil).IstioConfig
+	cluster := conf.KubernetesConfig.ClusterName
+
+	localities := istioConfig.GetLocalities(context.TODO())
+	require.Len(localities, 1)
+	assert.Equal(cluster, localities[0].Cluster)
+	require.Len(localities[0].Localities, 2)
+	assert.Equal("us-east1/us-east1-b", localities[0].Localities[0].String())
+	assert.Equal(2, localities[0].Localities[0].Nodes)
+	assert.Equal("us-west1/us-west1-a", localities[0].Localities[1].String())
+
+	// every region fails over to the next one
+	outlierDetection := &api_networking_v1beta1.OutlierDetection{ConsecutiveGatewayErrors: &wrapperspb.UInt32Value{Value: 5}}
+	scenario, err := istioConfig.GenerateLocalityLoadBalancing(context.TODO(), cluster, "ns", "reviews", models.LocalityLoadBalancing{OutlierDetection: outlierDetection})
+	require.NoError(err)
+	assert.Equal(WizardLocalityLoadBalancing, scenario.Scenario)
+	assert.Nil(scenario.VirtualService)
+	dr := scenario.DestinationRule
+	assert.Equal(WizardLocalityLoadBalancing, dr.Labels[KialiWizardLabel])
+	require.Len(dr.Spec.Subsets, 2)
+	lbSetting := dr.Spec.TrafficPolicy.LoadBalancer.LocalityLbSetting
+	require.Len(lbSetting.Failover, 2)
+	assert.Equal("us-east1", lbSetting.Failover[0].From)
+	assert.Equal("us-west1", lbSetting.Failover[0].To)
+	assert.Equal("us-west1", lbSetting.Failover[1].From)
+	assert.Equal("us-east1", lbSetting.Failover[1].To)
+	assert.Equal(outlierDetection, dr.Spec.TrafficPolicy.OutlierDetection)
+
+	// a failover requires an outlier detection
+	_, err = istioConfig.GenerateLocalityLoadBalancing(context.TODO(), cluster, "ns", "reviews", models.LocalityLoadBalancing{})
+	assert.True(api_errors.IsBadRequest(err))
+
+	distribute := models.LocalityLoadBalancing{
+		Distribute: []*api_networking_v1beta1.LocalityLoadBalancerSetting_Distribute{
+			{From: "us-east1/*", To: map[string]uint32{"us-east1/us-east1-b/*": 80, "us-west1/*": 20}},
+		},
+	}
+	scenario, err = istioConfig.GenerateLocalityLoadBalancing(context.TODO(), cluster, "ns", "reviews", distribute)
+	require.NoError(err)
+	require.Len(scenario.DestinationRule.Spec.TrafficPolicy.LoadBalancer.LocalityLbSetting.Distribute, 1)
+	assert.Nil(scenario.DestinationRule.Spec.TrafficPolicy.OutlierDetection)
+
+	distribute.Distribute[0].To["us-west1/*"] = 30
+	_, err = istioConfig.GenerateLocalityLoadBalancing(context.TODO(), cluster, "ns", "reviews", distribute)
+	assert.True(api_errors.IsBadRequest(err))
+
+	distribute.Distribute[0].To = map[string]uint32{"eu-west1/*": 100}
+	_, err = istioConfig.GenerateLocalityLoadBalancing(context.TODO(), cluster, "ns", "reviews", distribute)
+	assert.True(api_errors.IsBadRequest(err))
+
+	_, err = istioConfig.GenerateLocalityLoadBalancing(context.TODO(), cluster, "ns", "unknown", distribute)
+	assert.True(api_errors.IsNotFound(err))
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
 
 func NewWorkloadService(
@@ -98,12 +99,15 @@ type AccessLogEntry struct {
 
 // LogEntry holds a single log entry
 type LogEntry struct {
+	Container     string            `json:"container,omitempty"`
 	Message       string            `json:"message,omitempty"`
 	Severity      string            `json:"severity,omitempty"`
 	OriginalTime  time.Time         `json:"-"`
 	Timestamp     string            `json:"timestamp,omitempty"`
 	TimestampUnix int64             `json:"timestampUnix,omitempty"`
 	AccessLog     *parser.AccessLog `json:"accessLog,omitempty"`
+	// AccessLogFields are the structured fields of the access log
+	AccessLogFields *AccessLogFields `json:"accessLogFields,omitempty"`
 }
 
 type filterOpts struct {
@@ -115,13 +119,21 @@ type filterOpts struct {
 
 // LogOptions holds query parameter values
 type LogOptions struct {
-	Duration *time.Duration
-	LogType  models.LogType
-	MaxLines *int
+	// AccessLogFilters select the proxy access log entries, the other entries are discarded when set.
+	AccessLogFilters []AccessLogFilter
+	// Containers streamed together, interleaved by timestamp. Overrides PodLogOptions.Container when set.
+	Containers []string
+	Duration   *time.Duration
+	LogType    models.LogType
+	MaxLines   *int
 	core_v1.PodLogOptions
 	filter filterOpts
 }
 
+// logsKeepAlive is the interval of the keep-alive comments sent while following logs, they keep the connection
+// open through proxies and detect the clients gone away
+const logsKeepAlive = 15 * time.Second
+
 // Matches an ISO8601 full date
 var severityRegexp = regexp.MustCompile(`(?i)ERROR|WARN|DEBUG|TRACE`)
 
@@ -139,10 +151,11 @@ func (in *WorkloadService) isWorkloadValid(workloadType string) bool {
 }
 
 // @TODO do validations per cluster
-func (in *WorkloadService) getWorkloadValidations(authpolicies []*security_v1beta1.AuthorizationPolicy, workloadsPerNamespace map[string]models.WorkloadList) models.IstioValidations {
+func (in *WorkloadService) getWorkloadValidations(authpolicies []*security_v1beta1.AuthorizationPolicy, workloadsPerNamespace map[string]models.WorkloadList, cluster string) models.IstioValidations {
 	validations := checkers.WorkloadChecker{
 		AuthorizationPolicies: authpolicies,
 		WorkloadsPerNamespace: workloadsPerNamespace,
+		TrustBundle:           in.cache.GetTrustBundleStatus(cluster),
 	}.Check()
 
 	return validations
@@ -161,6 +174,8 @@ func (in *WorkloadService) GetWorkloadList(ctx context.Context, criteria Workloa
 		observability.Attribute("queryTime", criteria.QueryTime),
 	)
 	defer end()
+	promtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GetWorkloadList", criteria.Cluster, criteria.Namespace)
+	defer promtimer.ObserveDuration()
 
 	namespace := criteria.Namespace
 	cluster := criteria.Cluster
@@ -230,6 +245,14 @@ func (in *WorkloadService) GetWorkloadList(ctx context.Context, criteria Workloa
 		return *workloadList, err
 	}
 
+	var mtlsResolver *workloadmTLSResolver
+	if criteria.IncludeIstioResources {
+		mtlsResolver, err = in.businessLayer.TLS.newWorkloadmTLSResolver(ctx, cluster)
+		if err != nil {
+			log.Errorf("Error fetching mTLS config for namespace %s: %s", namespace, err)
+		}
+	}
+
 	for _, w := range ws {
 		wItem := &models.WorkloadListItem{Health: *models.EmptyWorkloadHealth()}
 		wItem.ParseWorkload(w)
@@ -237,6 +260,10 @@ func (in *WorkloadService) GetWorkloadList(ctx context.Context, criteria Workloa
 			wSelector := labels.Set(wItem.Labels).AsSelector().String()
 			wItem.IstioReferences = FilterUniqueIstioReferences(FilterWorkloadReferences(wSelector, istioConfigList))
 		}
+		if mtlsResolver != nil {
+			mtlsStatus := mtlsResolver.status(namespace, wItem.Name, wItem.Labels)
+			wItem.MTLSStatus = &mtlsStatus
+		}
 		if criteria.IncludeHealth {
 			wItem.Health, err = in.businessLayer.Health.GetWorkloadHealth(ctx, namespace, cluster, wItem.Name, criteria.RateInterval, criteria.QueryTime, w)
 			if err != nil {
@@ -248,12 +275,12 @@ func (in *WorkloadService) GetWorkloadList(ctx context.Context, criteria Workloa
 		workloadList.Workloads = append(workloadList.Workloads, *wItem)
 	}
 
-	for _, istioConfigList := range istioConfigMap {
+	for cluster, istioConfigList := range istioConfigMap {
 		// @TODO multi cluster validations
 		authpolicies := istioConfigList.AuthorizationPolicies
 		allWorkloads := map[string]models.WorkloadList{}
 		allWorkloads[namespace] = *workloadList
-		validations := in.getWorkloadValidations(authpolicies, allWorkloads)
+		validations := in.getWorkloadValidations(authpolicies, allWorkloads, cluster)
 		validations.StripIgnoredChecks()
 		workloadList.Validations = workloadList.Validations.MergeValidations(validations)
 	}
@@ -365,6 +392,8 @@ func (in *WorkloadService) GetWorkload(ctx context.Context, criteria WorkloadCri
 		observability.Attribute("queryTime", criteria.QueryTime),
 	)
 	defer end()
+	promtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GetWorkload", criteria.Cluster, criteria.Namespace)
+	defer promtimer.ObserveDuration()
 
 	ns, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, criteria.Namespace, criteria.Cluster)
 	if err != nil {
@@ -377,6 +406,10 @@ func (in *WorkloadService) GetWorkload(ctx context.Context, criteria WorkloadCri
 		return nil, err2
 	}
 
+	if in.config.ExternalServices.MetricsServer.Enabled {
+		in.setResourceUsage(criteria.Cluster, criteria.Namespace, workload)
+	}
+
 	var runtimes []models.Runtime
 	wg := sync.WaitGroup{}
 	wg.Add(1)
@@ -388,6 +421,20 @@ func (in *WorkloadService) GetWorkload(ctx context.Context, criteria WorkloadCri
 		runtimes = NewDashboardsService(in.config, in.grafana, ns, workload).GetCustomDashboardRefs(criteria.Namespace, app, version, workload.Pods)
 	}()
 
+	if serviceAccounts := workload.Pods.ServiceAccounts(); len(serviceAccounts) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The summary is optional: the workload details are still returned without it
+			summaries, err := in.businessLayer.RBAC.SummarizeServiceAccounts(ctx, criteria.Cluster, criteria.Namespace, serviceAccounts)
+			if err != nil {
+				log.Debugf("Unable to summarize the service accounts of workload [%s/%s]: %s", criteria.Namespace, criteria.WorkloadName, err)
+				return
+			}
+			workload.ServiceAccounts = summaries
+		}()
+	}
+
 	if criteria.IncludeServices {
 		var services *models.ServiceList
 		var err error
@@ -412,6 +459,30 @@ func (in *WorkloadService) GetWorkload(ctx context.Context, criteria WorkloadCri
 	return workload, nil
 }
 
+// setResourceUsage sets the current resource usage of the workload and its pods, from metrics-server. The usage
+// is optional: it is left unset when the metrics are not available.
+func (in *WorkloadService) setResourceUsage(cluster, namespace string, workload *models.Workload) {
+	client, ok := in.userClients[cluster]
+	if !ok {
+		return
+	}
+	metrics, err := client.GetPodMetrics(namespace)
+	if err != nil {
+		log.Debugf("Resource usage of the pods of namespace [%s] is not available: %s", namespace, err)
+		return
+	}
+	podMetrics := make(map[string]*kubernetes.PodMetrics, len(metrics))
+	for i := range metrics {
+		podMetrics[metrics[i].Name] = &metrics[i]
+	}
+	for _, pod := range workload.Pods {
+		if m, ok := podMetrics[pod.Name]; ok {
+			pod.SetResourceUsage(m)
+		}
+	}
+	workload.SetResourceUsage()
+}
+
 func (in *WorkloadService) UpdateWorkload(ctx context.Context, cluster string, namespace string, workloadName string, workloadType string, includeServices bool, jsonPatch string, patchType string) (*models.Workload, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "UpdateWorkload",
@@ -426,6 +497,10 @@ func (in *WorkloadService) UpdateWorkload(ctx context.Context, cluster string, n
 	)
 	defer end()
 
+	if err := checkViewOnlyMode(in.config, "Updating the workload"); err != nil {
+		return nil, err
+	}
+
 	// Identify controller and apply patch to workload
 	err := in.updateWorkload(ctx, cluster, namespace, workloadName, workloadType, jsonPatch, patchType)
 	if err != nil {
@@ -462,12 +537,35 @@ func (in *WorkloadService) GetPod(cluster, namespace, name string) (*models.Pod,
 	return &pod, nil
 }
 
-func (in *WorkloadService) BuildLogOptionsCriteria(container, duration string, logType models.LogType, sinceTime, maxLines string) (*LogOptions, error) {
+// BuildLogOptionsCriteria returns the log options of the query parameters. The container may be a comma-separated
+// list of containers to stream together, and the filter a comma-separated list of access log filters.
+func (in *WorkloadService) BuildLogOptionsCriteria(container, duration string, logType models.LogType, sinceTime, maxLines, follow, filter string) (*LogOptions, error) {
 	opts := &LogOptions{}
 	opts.PodLogOptions = core_v1.PodLogOptions{Timestamps: true}
 
 	if container != "" {
-		opts.Container = container
+		containers := strings.Split(container, ",")
+		if len(containers) > 1 {
+			opts.Containers = containers
+		} else {
+			opts.Container = container
+		}
+	}
+
+	if follow != "" {
+		isFollow, err := strconv.ParseBool(follow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid follow [%s]: %v", follow, err)
+		}
+		opts.Follow = isFollow
+	}
+
+	if filter != "" {
+		filters, err := ParseAccessLogFilters(filter)
+		if err != nil {
+			return nil, err
+		}
+		opts.AccessLogFilters = filters
 	}
 
 	if duration != "" {
@@ -543,7 +641,7 @@ func parseLogLine(line string, isProxy bool, engardeParser *parser.Parser) *LogE
 	// If this is an istio access log, then parse it out. Prefer the access log time over the k8s time
 	// as it is the actual time as opposed to the k8s store time.
 	if isProxy {
-		al, err := engardeParser.Parse(entry.Message)
+		al, err := parseAccessLog(entry.Message, engardeParser)
 		// engardeParser.Parse will not throw errors even if no fields
 		// were parsed out. Checking here that some fields were actually
 		// set before setting the AccessLog to an empty object. See issue #4346.
@@ -560,6 +658,7 @@ func parseLogLine(line string, isProxy bool, engardeParser *parser.Parser) *LogE
 			}
 		} else {
 			entry.AccessLog = al
+			entry.AccessLogFields = newAccessLogFields(al)
 			t, err := time.Parse(time.RFC3339, al.Timestamp)
 			if err == nil {
 				parsedTimestamp = t
@@ -1290,7 +1389,126 @@ func (in *WorkloadService) fetchWorkloadsFromCluster(ctx context.Context, cluste
 			ws = append(ws, w)
 		}
 	}
-	return ws, nil
+	return groupWorkloadRevisions(ws, repset), nil
+}
+
+// workloadRevisionOwner returns the name and type of the logical workload of a ReplicaSet managed as one of its
+// revisions: the controlling Argo Rollout, or the workload named by the owner annotation. Empty otherwise.
+func workloadRevisionOwner(rs *apps_v1.ReplicaSet) (string, string) {
+	ref := meta_v1.GetControllerOf(&rs.ObjectMeta)
+	if owner, ok := rs.Annotations[models.WorkloadOwnerAnnotation]; ok && owner != "" {
+		if ref != nil {
+			return owner, ref.Kind
+		}
+		return owner, kubernetes.ReplicaSetType
+	}
+	if ref != nil && ref.Kind == kubernetes.RolloutType {
+		return ref.Name, ref.Kind
+	}
+	return "", ""
+}
+
+// groupWorkloadRevisions replaces the ReplicaSet workloads managed as revisions of a logical workload (see
+// workloadRevisionOwner) by a single workload, named after the owner, combining the pods and replicas of its
+// revisions. The labels and annotations are taken from the newest revision. Istio telemetry reports the pods of
+// these ReplicaSets with the owner name, so health and metrics are those of the logical workload.
+func groupWorkloadRevisions(ws models.Workloads, repset []apps_v1.ReplicaSet) models.Workloads {
+	type owner struct{ name, kind string }
+	owners := map[string]owner{}
+	rsByName := make(map[string]*apps_v1.ReplicaSet, len(repset))
+	for i := range repset {
+		rsByName[repset[i].Name] = &repset[i]
+		if name, kind := workloadRevisionOwner(&repset[i]); name != "" {
+			owners[repset[i].Name] = owner{name: name, kind: kind}
+		}
+	}
+	if len(owners) == 0 {
+		return ws
+	}
+	names := make(map[string]bool, len(ws))
+	for _, w := range ws {
+		names[w.Name] = true
+	}
+
+	grouped := models.Workloads{}
+	positions := map[owner]int{}
+	revisions := map[owner]models.Workloads{}
+	for _, w := range ws {
+		o, ok := owners[w.Name]
+		// a workload already named after the owner is not replaced
+		if !ok || w.Type != kubernetes.ReplicaSetType || names[o.name] {
+			grouped = append(grouped, w)
+			continue
+		}
+		if _, exist := positions[o]; !exist {
+			positions[o] = len(grouped)
+			grouped = append(grouped, nil)
+		}
+		revisions[o] = append(revisions[o], w)
+	}
+	for o, i := range positions {
+		grouped[i] = mergeWorkloadRevisions(o.name, o.kind, revisions[o], rsByName)
+	}
+	return grouped
+}
+
+// mergeWorkloadRevisions returns the logical workload of the revisions, sorted from the oldest to the newest
+func mergeWorkloadRevisions(name, workloadType string, revs models.Workloads, rsByName map[string]*apps_v1.ReplicaSet) *models.Workload {
+	sort.SliceStable(revs, func(i, j int) bool {
+		return rsByName[revs[i].Name].CreationTimestamp.Before(&rsByName[revs[j].Name].CreationTimestamp)
+	})
+
+	newest := revs[len(revs)-1]
+	w := *newest
+	w.Name = name
+	w.Type = workloadType
+	w.CreatedAt = revs[0].CreatedAt
+	w.Pods = models.Pods{}
+	w.DesiredReplicas, w.CurrentReplicas, w.AvailableReplicas = 0, 0, 0
+	w.Revisions = make([]models.WorkloadRevision, 0, len(revs))
+	for _, rev := range revs {
+		rs := rsByName[rev.Name]
+		revision := rs.Annotations[models.RolloutRevisionAnnotation]
+		if revision == "" {
+			revision = rs.Labels[apps_v1.DefaultDeploymentUniqueLabelKey]
+		}
+		if revision == "" {
+			revision = rs.Name
+		}
+		w.Revisions = append(w.Revisions, models.WorkloadRevision{
+			Name:              rev.Name,
+			Revision:          revision,
+			CreatedAt:         rev.CreatedAt,
+			Labels:            rev.Labels,
+			PodCount:          len(rev.Pods),
+			DesiredReplicas:   rev.DesiredReplicas,
+			CurrentReplicas:   rev.CurrentReplicas,
+			AvailableReplicas: rev.AvailableReplicas,
+		})
+		w.Pods = append(w.Pods, rev.Pods...)
+		w.DesiredReplicas += rev.DesiredReplicas
+		w.CurrentReplicas += rev.CurrentReplicas
+		w.AvailableReplicas += rev.AvailableReplicas
+	}
+	w.IstioSidecar = w.HasIstioSidecar()
+	w.IstioAmbient = w.HasIstioAmbient()
+	return &w
+}
+
+// fetchGroupedWorkload returns the logical workload grouping ReplicaSet revisions, see groupWorkloadRevisions
+func (in *WorkloadService) fetchGroupedWorkload(ctx context.Context, criteria WorkloadCriteria) (*models.Workload, error) {
+	ws, err := in.fetchWorkloadsFromCluster(ctx, criteria.Cluster, criteria.Namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range ws {
+		if w.Name == criteria.WorkloadName && len(w.Revisions) > 0 {
+			w.Cluster = criteria.Cluster
+			w.Namespace = criteria.Namespace
+			return w, nil
+		}
+	}
+	return nil, kubernetes.NewNotFound(criteria.WorkloadName, "Kiali", "Workload")
 }
 
 func (in *WorkloadService) fetchWorkload(ctx context.Context, criteria WorkloadCriteria) (*models.Workload, error) {
@@ -1322,6 +1540,11 @@ func (in *WorkloadService) fetchWorkload(ctx context.Context, criteria WorkloadC
 		return nil, err
 	}
 
+	// The revisions of a Rollout are grouped from the ReplicaSet workloads
+	if criteria.WorkloadType == kubernetes.RolloutType {
+		return in.fetchGroupedWorkload(ctx, criteria)
+	}
+
 	// Flag used for custom controllers
 	// i.e. a third party framework creates its own "Deployment" controller with extra features
 	// on this case, Kiali will collect basic info from the ReplicaSet controller
@@ -1879,6 +2102,12 @@ func (in *WorkloadService) fetchWorkload(ctx context.Context, criteria WorkloadC
 			return &w, nil
 		}
 	}
+	// The workload may be a logical workload grouping ReplicaSet revisions
+	if criteria.WorkloadType == "" {
+		if w, err := in.fetchGroupedWorkload(ctx, criteria); err == nil {
+			return w, nil
+		}
+	}
 	return wl, kubernetes.NewNotFound(criteria.WorkloadName, "Kiali", "Workload")
 }
 
@@ -2170,6 +2399,10 @@ func (in *WorkloadService) streamParsedLogs(cluster, namespace string, names []s
 				continue
 			}
 
+			if !matchesAccessLogFilters(entry, opts.AccessLogFilters) {
+				continue
+			}
+
 			// If we are past the requested time window then stop processing
 			if startTime == nil {
 				startTime = &entry.OriginalTime
@@ -2239,8 +2472,9 @@ func (in *WorkloadService) streamParsedLogs(cluster, namespace string, names []s
 	return nil
 }
 
-// StreamPodLogs streams pod logs to an HTTP Response given the provided options
-func (in *WorkloadService) StreamPodLogs(cluster, namespace, name string, opts *LogOptions, w http.ResponseWriter) error {
+// StreamPodLogs streams pod logs to an HTTP Response given the provided options. Following the logs stops
+// when the context is done.
+func (in *WorkloadService) StreamPodLogs(ctx context.Context, cluster, namespace, name string, opts *LogOptions, w http.ResponseWriter) error {
 	names := []string{}
 	if opts.LogType == models.LogTypeZtunnel {
 		// First, get ztunnel namespace and containers
@@ -2261,10 +2495,243 @@ func (in *WorkloadService) StreamPodLogs(cluster, namespace, name string, opts *
 		// They should be all in the same ns
 		return in.streamParsedLogs(cluster, pods[0].Namespace, names, opts, w)
 	}
+	if len(opts.Containers) > 1 || opts.Follow {
+		return in.streamContainersLogs(ctx, cluster, namespace, name, opts, w)
+	}
+	if len(opts.Containers) == 1 {
+		opts.Container = opts.Containers[0]
+	}
 	names = append(names, name)
 	return in.streamParsedLogs(cluster, namespace, names, opts, w)
 }
 
+// streamContainersLogs streams the logs of one or several containers of a pod. The entries of the containers
+// are interleaved by timestamp and tagged with their container. When following, the entries are sent as
+// Server-Sent Events as soon as they are logged, until the client goes away. Otherwise they are sent as a
+// PodLog JSON document, like streamParsedLogs.
+func (in *WorkloadService) streamContainersLogs(ctx context.Context, cluster, namespace, name string, opts *LogOptions, w http.ResponseWriter) error {
+	userClient, ok := in.userClients[cluster]
+	if !ok {
+		return fmt.Errorf("user client for cluster [%s] not found", cluster)
+	}
+
+	containers := opts.Containers
+	if len(containers) == 0 {
+		containers = []string{opts.Container}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	streams := make([]chan *LogEntry, 0, len(containers))
+	for _, container := range containers {
+		k8sOpts := opts.PodLogOptions
+		k8sOpts.Container = container
+		if opts.Follow && opts.MaxLines != nil && k8sOpts.SinceTime == nil {
+			// when following, the max lines are the last lines logged before following
+			tailLines := int64(*opts.MaxLines)
+			k8sOpts.TailLines = &tailLines
+		}
+		logsReader, err := userClient.StreamPodLogs(namespace, name, &k8sOpts)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if e := logsReader.Close(); e != nil {
+				log.Errorf("Error when closing the connection streaming logs of a pod: %s", e.Error())
+			}
+		}()
+
+		isProxy := container == models.IstioProxy || (opts.LogType == models.LogTypeProxy && len(containers) == 1)
+		entries := make(chan *LogEntry)
+		streams = append(streams, entries)
+		go readContainerLogs(logsReader, container, isProxy, opts.AccessLogFilters, entries, done)
+	}
+
+	if opts.Follow {
+		return followLogs(ctx, streams, w)
+	}
+	return writeInterleavedLogs(streams, opts, w)
+}
+
+// readContainerLogs parses the log lines of a container and sends the entries, until the end of the stream or
+// until done is closed. The entries not matching the access log filters are discarded. The entries channel is
+// closed on return.
+func readContainerLogs(reader io.Reader, container string, isProxy bool, filters []AccessLogFilter, entries chan<- *LogEntry, done <-chan struct{}) {
+	defer close(entries)
+
+	var engardeParser *parser.Parser
+	if isProxy {
+		engardeParser = parser.New(parser.IstioProxyAccessLogsPattern)
+	}
+	bufferedReader := bufio.NewReader(reader)
+	for {
+		line, readErr := bufferedReader.ReadString('\n')
+		if len(line) > 0 {
+			if entry := parseLogLine(line, isProxy, engardeParser); entry != nil && matchesAccessLogFilters(entry, filters) {
+				entry.Container = container
+				select {
+				case entries <- entry:
+				case <-done:
+					return
+				}
+			}
+		}
+		if readErr != nil {
+			return
+		}
+	}
+}
+
+// writeInterleavedLogs writes the entries of the containers, merged by timestamp (the entries of every container
+// are ordered), as a PodLog JSON document. The max lines and duration options apply to the merged entries.
+func writeInterleavedLogs(streams []chan *LogEntry, opts *LogOptions, w http.ResponseWriter) error {
+	var endTime *time.Time
+	if opts.SinceTime != nil && opts.Duration != nil {
+		end := opts.SinceTime.Add(*opts.Duration)
+		endTime = &end
+	}
+
+	// As in streamParsedLogs, the entries are written as they are merged, errors can only truncate the document.
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write([]byte("{\"entries\":[")); err != nil {
+		return err
+	}
+
+	heads := make([]*LogEntry, len(streams))
+	linesWritten := 0
+	truncated := false
+	for {
+		next := -1
+		for i, stream := range streams {
+			if heads[i] == nil && stream != nil {
+				if entry, ok := <-stream; ok {
+					heads[i] = entry
+				} else {
+					streams[i] = nil
+				}
+			}
+			if heads[i] != nil && (next == -1 || heads[i].OriginalTime.Before(heads[next].OriginalTime)) {
+				next = i
+			}
+		}
+		if next == -1 {
+			break
+		}
+		entry := heads[next]
+		heads[next] = nil
+
+		if opts.Duration != nil {
+			if endTime == nil {
+				end := entry.OriginalTime.Add(*opts.Duration)
+				endTime = &end
+			}
+			if entry.OriginalTime.After(*endTime) {
+				break
+			}
+		}
+		if opts.MaxLines != nil && linesWritten >= *opts.MaxLines {
+			truncated = true
+			break
+		}
+
+		response, err := json.Marshal(entry)
+		if err != nil {
+			log.Errorf("Error when marshalling JSON while streaming pod logs: %s", err.Error())
+			return nil
+		}
+		if linesWritten > 0 {
+			response = append([]byte{','}, response...)
+		}
+		if _, err := w.Write(response); err != nil {
+			log.Errorf("Error when writing a processed log entry while streaming pod logs: %s", err.Error())
+			return nil
+		}
+		linesWritten++
+	}
+
+	outro := "]}"
+	if truncated {
+		outro = "], \"linesTruncated\": true}"
+	}
+	if _, err := w.Write([]byte(outro)); err != nil {
+		log.Errorf("Error when writing the outro of the JSON document while streaming pod logs: %s", err.Error())
+	}
+	return nil
+}
+
+// followLogs sends the entries of the containers as Server-Sent Events, as they are received, with keep-alive
+// comments in between. It returns when every stream ended (an "end" event is sent) or when the client is gone,
+// that is when the context is done. The write timeout of the server doesn't apply to the events.
+func followLogs(ctx context.Context, streams []chan *LogEntry, w http.ResponseWriter) error {
+	entries := make(chan *LogEntry)
+	wg := sync.WaitGroup{}
+	for _, stream := range streams {
+		wg.Add(1)
+		go func(stream <-chan *LogEntry) {
+			defer wg.Done()
+			for entry := range stream {
+				entries <- entry
+			}
+		}(stream)
+	}
+	go func() {
+		wg.Wait()
+		close(entries)
+	}()
+	defer func() {
+		// unblock the fan-in until the readers stop
+		go func() {
+			for range entries {
+			}
+		}()
+	}()
+
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		log.Debugf("Unable to clear the write deadline while following pod logs: %s", err.Error())
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	send := func(event string) bool {
+		if _, err := w.Write([]byte(event)); err != nil {
+			log.Debugf("Stop following pod logs: %s", err.Error())
+			return false
+		}
+		// Not every writer can flush, a client gone fails the writes anyway.
+		_ = controller.Flush()
+		return true
+	}
+
+	keepAlive := time.NewTicker(logsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debugf("Stop following pod logs: %s", ctx.Err())
+			return nil
+		case entry, ok := <-entries:
+			if !ok {
+				send("event: end\ndata: {}\n\n")
+				return nil
+			}
+			response, err := json.Marshal(entry)
+			if err != nil {
+				log.Errorf("Error when marshalling JSON while following pod logs: %s", err.Error())
+				return nil
+			}
+			if !send(fmt.Sprintf("data: %s\n\n", response)) {
+				return nil
+			}
+		case <-keepAlive.C:
+			if !send(": keep-alive\n\n") {
+				return nil
+			}
+		}
+	}
+}
+
 // AND filter
 func filterMatches(line string, filter filterOpts) bool {
 	if (strings.Contains(line, filter.destNs) && strings.Contains(line, filter.destWk)) || (strings.Contains(line, filter.srcNs) && strings.Contains(line, filter.srcWk)) {
@@ -3,6 +3,7 @@ package business
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http/httptest"
 	"strings"
@@ -14,9 +15,11 @@ import (
 	"github.com/stretchr/testify/require"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kiali/kiali/config"
@@ -38,7 +41,7 @@ func setupWorkloadService(k8s kubernetes.ClientInterface, conf *config.Config) W
 func callStreamPodLogs(svc WorkloadService, namespace, podName string, opts *LogOptions) PodLog {
 	w := httptest.NewRecorder()
 
-	_ = svc.StreamPodLogs(svc.config.KubernetesConfig.ClusterName, namespace, podName, opts, w)
+	_ = svc.StreamPodLogs(context.TODO(), svc.config.KubernetesConfig.ClusterName, namespace, podName, opts, w)
 
 	response := w.Result()
 	body, _ := io.ReadAll(response.Body)
@@ -425,6 +428,65 @@ func TestGetWorkloadFromDeployment(t *testing.T) {
 	assert.Equal(true, workload.VersionLabel)
 }
 
+type podMetricsClient struct {
+	metrics []kubernetes.PodMetrics
+	kubernetes.ClientInterface
+}
+
+func (c *podMetricsClient) GetPodMetrics(namespace string) ([]kubernetes.PodMetrics, error) {
+	return c.metrics, nil
+}
+
+func TestGetWorkloadResourceUsage(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.ExternalServices.MetricsServer.Enabled = true
+	kubernetes.SetConfig(t, *conf)
+
+	kubeObjs := []runtime.Object{
+		&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}},
+		&FakeDepSyncedWithRS()[0],
+	}
+	for _, o := range FakeRSSyncedWithPods() {
+		kubeObjs = append(kubeObjs, &o)
+	}
+	for _, o := range FakePodsSyncedWithDeployments() {
+		o.Labels = map[string]string{"app": "details", "version": "v1"}
+		kubeObjs = append(kubeObjs, &o)
+	}
+	fake := kubetest.NewFakeK8sClient(kubeObjs...)
+	fake.OpenShift = true
+	k8s := &podMetricsClient{
+		metrics: []kubernetes.PodMetrics{{
+			ObjectMeta: v1.ObjectMeta{Name: "details-v1-3618568057-dnkjp", Namespace: "Namespace"},
+			Timestamp:  v1.Now(),
+			Containers: []kubernetes.ContainerMetrics{
+				{Name: "details", Usage: core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("100m"), core_v1.ResourceMemory: resource.MustParse("64Mi")}},
+				{Name: "istio-proxy", Usage: core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("50m"), core_v1.ResourceMemory: resource.MustParse("32Mi")}},
+			},
+		}},
+		ClientInterface: fake,
+	}
+	SetupBusinessLayer(t, fake, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	criteria := WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "Namespace", WorkloadName: "details-v1", WorkloadType: "", IncludeServices: false}
+	workload, err := svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+
+	require.Len(workload.Pods, 1)
+	require.NotNil(workload.Pods[0].ResourceUsage)
+	assert.InDelta(0.15, workload.Pods[0].ResourceUsage.CPU, 0.001)
+	require.NotNil(workload.ResourceUsage)
+	assert.Equal(int64(96*1024*1024), workload.ResourceUsage.Memory)
+	// no resource requests nor limits
+	assert.Nil(workload.ResourceUsage.CPURequestUtilization)
+	assert.Nil(workload.ResourceUsage.MemoryLimitUtilization)
+}
+
 func TestGetWorkloadWithInvalidWorkloadType(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -993,6 +1055,199 @@ func TestGetWorkloadListRSOwnedByCustom(t *testing.T) {
 	assert.NotNil(workload)
 }
 
+func TestGetWorkloadListRolloutRevisions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+
+	rollout := &v1.ObjectMeta{Name: "reviews", UID: types.UID("rollout-reviews")}
+	rolloutRef := v1.NewControllerRef(rollout, schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: kubernetes.RolloutType})
+	now := time.Now()
+
+	kubeObjs := []runtime.Object{
+		&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}},
+	}
+	for i, rev := range []struct {
+		name     string
+		hash     string
+		revision string
+		replicas int32
+		created  time.Time
+	}{
+		{name: "reviews-5d8f9", hash: "5d8f9", revision: "1", replicas: 2, created: now.Add(-time.Hour)},
+		{name: "reviews-7c6b4", hash: "7c6b4", revision: "2", replicas: 1, created: now},
+	} {
+		labels := map[string]string{"app": "reviews", "version": "v" + rev.revision, apps_v1.DefaultDeploymentUniqueLabelKey: rev.hash}
+		rs := &apps_v1.ReplicaSet{
+			ObjectMeta: v1.ObjectMeta{
+				Name:              rev.name,
+				Namespace:         "Namespace",
+				UID:               types.UID(rev.name),
+				CreationTimestamp: v1.NewTime(rev.created),
+				Labels:            labels,
+				Annotations:       map[string]string{models.RolloutRevisionAnnotation: rev.revision},
+				OwnerReferences:   []v1.OwnerReference{*rolloutRef},
+			},
+			Spec: apps_v1.ReplicaSetSpec{
+				Replicas: &rev.replicas,
+				Selector: &v1.LabelSelector{MatchLabels: labels},
+				Template: core_v1.PodTemplateSpec{ObjectMeta: v1.ObjectMeta{Labels: labels}},
+			},
+			Status: apps_v1.ReplicaSetStatus{Replicas: rev.replicas, AvailableReplicas: rev.replicas},
+		}
+		kubeObjs = append(kubeObjs, rs)
+		for p := int32(0); p < rev.replicas; p++ {
+			kubeObjs = append(kubeObjs, &core_v1.Pod{
+				ObjectMeta: v1.ObjectMeta{
+					Name:            fmt.Sprintf("%s-%d%d", rev.name, i, p),
+					Namespace:       "Namespace",
+					Labels:          labels,
+					OwnerReferences: []v1.OwnerReference{*v1.NewControllerRef(rs, apps_v1.SchemeGroupVersion.WithKind(kubernetes.ReplicaSetType))},
+				},
+			})
+		}
+	}
+
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	criteria := WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "Namespace", IncludeIstioResources: false, IncludeHealth: false}
+	workloadList, err := svc.GetWorkloadList(context.TODO(), criteria)
+	require.NoError(err)
+
+	require.Len(workloadList.Workloads, 1)
+	w := workloadList.Workloads[0]
+	assert.Equal("reviews", w.Name)
+	assert.Equal(kubernetes.RolloutType, w.Type)
+	assert.Equal(3, w.PodCount)
+	assert.Equal("v2", w.Labels["version"])
+	require.Len(w.Revisions, 2)
+	assert.Equal("reviews-5d8f9", w.Revisions[0].Name)
+	assert.Equal("1", w.Revisions[0].Revision)
+	assert.Equal(2, w.Revisions[0].PodCount)
+	assert.Equal("2", w.Revisions[1].Revision)
+	assert.Equal(int32(1), w.Revisions[1].AvailableReplicas)
+
+	criteria = WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "Namespace", WorkloadName: "reviews", WorkloadType: kubernetes.RolloutType}
+	workload, err := svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	assert.Equal(int32(3), workload.DesiredReplicas)
+	assert.Len(workload.Pods, 3)
+	assert.Len(workload.Revisions, 2)
+}
+
+type containerLogStreamer struct {
+	logs map[string]string
+	kubernetes.ClientInterface
+}
+
+func (l *containerLogStreamer) StreamPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(l.logs[opts.Container])), nil
+}
+
+func newContainerLogStreamer() *containerLogStreamer {
+	return &containerLogStreamer{
+		logs: map[string]string{
+			"details": `2018-01-02T03:34:28+00:00 INFO #1 Log Message
+2018-01-02T03:34:30+00:00 WARN #2 Log Message
+2018-01-02T03:34:33+00:00 #3 Log Message
+`,
+			"istio-proxy": `2018-01-02T03:34:29+00:00 [2018-01-02T03:34:29.000Z] "GET /details/0 HTTP/1.1" 200 - via_upstream - "-" 0 178 1 0 "-" "curl" "e6d6f0b5" "details:9080" "10.244.0.8:9080" inbound|9080|| 127.0.0.6:50461 10.244.0.8:9080 10.244.0.10:55498 outbound_.9080_._.details.bookinfo.svc.cluster.local default
+2018-01-02T03:34:31+00:00 [2018-01-02T03:34:31.000Z] "GET /details/1 HTTP/1.1" 200 - via_upstream - "-" 0 178 1 0 "-" "curl" "e6d6f0b6" "details:9080" "10.244.0.8:9080" inbound|9080|| 127.0.0.6:50461 10.244.0.8:9080 10.244.0.10:55498 outbound_.9080_._.details.bookinfo.svc.cluster.local default
+`,
+		},
+		ClientInterface: kubetest.NewFakeK8sClient(&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}}),
+	}
+}
+
+func TestGetPodLogsContainers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	k8s := newContainerLogStreamer()
+	conf := config.NewConfig()
+	config.Set(conf)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	maxLines := 4
+	podLogs := callStreamPodLogs(svc, "Namespace", "details-v1-3618568057-dnkjp", &LogOptions{Containers: []string{"details", "istio-proxy"}, MaxLines: &maxLines})
+
+	require.Len(podLogs.Entries, 4)
+	assert.True(podLogs.LinesTruncated)
+	for i, container := range []string{"details", "istio-proxy", "details", "istio-proxy"} {
+		assert.Equal(container, podLogs.Entries[i].Container)
+	}
+	assert.Equal("INFO #1 Log Message", podLogs.Entries[0].Message)
+	require.NotNil(podLogs.Entries[1].AccessLog)
+	assert.Equal("GET", podLogs.Entries[1].AccessLog.Method)
+	assert.Equal("WARN #2 Log Message", podLogs.Entries[2].Message)
+	assert.Nil(podLogs.Entries[2].AccessLog)
+}
+
+func TestFollowPodLogsContainers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	k8s := newContainerLogStreamer()
+	conf := config.NewConfig()
+	config.Set(conf)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	opts, err := svc.BuildLogOptionsCriteria("details,istio-proxy", "", "", "", "", "true", "")
+	require.NoError(err)
+	require.True(opts.Follow)
+	require.Equal([]string{"details", "istio-proxy"}, opts.Containers)
+
+	w := httptest.NewRecorder()
+	require.NoError(svc.StreamPodLogs(context.TODO(), svc.config.KubernetesConfig.ClusterName, "Namespace", "details-v1-3618568057-dnkjp", opts, w))
+	assert.Equal("text/event-stream", w.Header().Get("Content-Type"))
+
+	events := strings.Split(strings.TrimSpace(w.Body.String()), "\n\n")
+	require.Len(events, 6)
+	containers := map[string]int{}
+	for _, event := range events[:5] {
+		require.True(strings.HasPrefix(event, "data: "), event)
+		entry := LogEntry{}
+		require.NoError(json.Unmarshal([]byte(strings.TrimPrefix(event, "data: ")), &entry))
+		containers[entry.Container]++
+	}
+	assert.Equal(map[string]int{"details": 3, "istio-proxy": 2}, containers)
+	assert.Equal("event: end\ndata: {}", events[5])
+}
+
+func TestFollowLogsStopsWhenTheContextIsDone(t *testing.T) {
+	require := require.New(t)
+
+	// A stream that doesn't end, as when the container keeps running.
+	stream := make(chan *LogEntry)
+	t.Cleanup(func() { close(stream) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	followed := make(chan error)
+	go func() {
+		followed <- followLogs(ctx, []chan *LogEntry{stream}, w)
+	}()
+	stream <- &LogEntry{Message: "first"}
+	cancel()
+
+	select {
+	case err := <-followed:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("following the logs did not stop when the context was done")
+	}
+	assert.NotContains(t, w.Body.String(), "event: end")
+}
+
 func TestGetPodLogsWithoutAccessLogs(t *testing.T) {
 	assert := assert.New(t)
 
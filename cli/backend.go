@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/client"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	graphapi "github.com/kiali/kiali/graph/api"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// backend is what the commands read: the API of a Kiali server or the cluster
+type backend interface {
+	// Validations returns the validations of the Istio config of the namespaces, of all of them when empty
+	Validations(ctx context.Context, cluster string, namespaces []string) (models.IstioValidations, error)
+	// Graph returns the traffic graph of the namespaces, in the cytoscape format of the UI
+	Graph(ctx context.Context, namespaces []string, graphType, duration string) (interface{}, error)
+	MeshStatus(ctx context.Context, cluster string) (kubernetes.IstioComponentStatus, error)
+	// Services returns the services of the namespaces, of all of them when empty
+	Services(ctx context.Context, cluster string, namespaces []string) ([]models.ServiceOverview, error)
+}
+
+// apiBackend reads the API of a running Kiali server
+type apiBackend struct {
+	client *client.Client
+}
+
+func (b *apiBackend) Validations(ctx context.Context, cluster string, namespaces []string) (models.IstioValidations, error) {
+	query := url.Values{"validate": []string{"true"}}
+	if cluster != "" {
+		query.Set("clusterName", cluster)
+	}
+	if len(namespaces) == 0 {
+		list, err := b.client.IstioConfigListAll(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return list.IstioValidations, nil
+	}
+
+	validations := models.IstioValidations{}
+	for _, namespace := range namespaces {
+		list, err := b.client.IstioConfigList(ctx, namespace, query)
+		if err != nil {
+			return nil, err
+		}
+		validations = validations.MergeValidations(list.IstioValidations)
+	}
+	return validations, nil
+}
+
+func (b *apiBackend) Graph(ctx context.Context, namespaces []string, graphType, duration string) (interface{}, error) {
+	return b.client.GraphNamespaces(ctx, graphQuery(namespaces, graphType, duration))
+}
+
+func (b *apiBackend) MeshStatus(ctx context.Context, cluster string) (kubernetes.IstioComponentStatus, error) {
+	query := url.Values{}
+	if cluster != "" {
+		query.Set("clusterName", cluster)
+	}
+	return b.client.IstioStatus(ctx, query)
+}
+
+func (b *apiBackend) Services(ctx context.Context, cluster string, namespaces []string) ([]models.ServiceOverview, error) {
+	query := url.Values{"health": []string{"false"}}
+	if cluster != "" {
+		query.Set("clusterName", cluster)
+	}
+	if len(namespaces) > 0 {
+		query.Set("namespaces", strings.Join(namespaces, ","))
+	}
+	services, err := b.client.ClustersServices(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return services.Services, nil
+}
+
+// clusterBackend reads the cluster with the business layer, as the Kiali server does, with the Kiali service account
+// of the cluster or of the kube config.
+type clusterBackend struct {
+	authInfo *api.AuthInfo
+	cache    cache.KialiCache
+	conf     *config.Config
+	layer    *business.Layer
+}
+
+// newClusterBackend starts the cache and the business layer of the Kiali config
+func newClusterBackend(configFile string) (*clusterBackend, error) {
+	conf := config.NewConfig()
+	if configFile != "" {
+		var err error
+		if conf, err = config.LoadFromFile(configFile); err != nil {
+			return nil, err
+		}
+	}
+	config.Set(conf)
+
+	clientFactory, err := kubernetes.GetClientFactory()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the client factory: %w", err)
+	}
+	kialiCache, err := cache.NewKialiCache(clientFactory, *conf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize the Kiali cache: %w", err)
+	}
+	prom, err := prometheus.NewClient()
+	if err != nil {
+		kialiCache.Stop()
+		return nil, fmt.Errorf("unable to create the Prometheus client: %w", err)
+	}
+
+	namespaceService := business.NewNamespaceService(clientFactory.GetSAClients(), clientFactory.GetSAClients(), kialiCache, conf)
+	meshService := business.NewMeshService(clientFactory.GetSAClients(), kialiCache, namespaceService, *conf)
+	cpm := business.NewControlPlaneMonitor(kialiCache, clientFactory, *conf, &meshService)
+	business.Start(clientFactory, cpm, kialiCache, prom, nil, nil)
+
+	authInfo := &api.AuthInfo{Token: clientFactory.GetSAHomeClusterClient().GetToken()}
+	layer, err := business.Get(authInfo)
+	if err != nil {
+		kialiCache.Stop()
+		return nil, err
+	}
+	return &clusterBackend{authInfo: authInfo, cache: kialiCache, conf: conf, layer: layer}, nil
+}
+
+func (b *clusterBackend) Stop() {
+	b.cache.Stop()
+}
+
+func (b *clusterBackend) cluster(cluster string) string {
+	if cluster == "" {
+		return b.conf.KubernetesConfig.ClusterName
+	}
+	return cluster
+}
+
+func (b *clusterBackend) Validations(ctx context.Context, cluster string, namespaces []string) (models.IstioValidations, error) {
+	cluster = b.cluster(cluster)
+	if len(namespaces) == 0 {
+		return b.layer.Validations.GetValidations(ctx, cluster, "", "", "")
+	}
+
+	validations := models.IstioValidations{}
+	for _, namespace := range namespaces {
+		nsValidations, err := b.layer.Validations.GetValidations(ctx, cluster, namespace, "", "")
+		if err != nil {
+			return nil, err
+		}
+		validations = validations.MergeValidations(nsValidations)
+	}
+	return validations, nil
+}
+
+// Graph builds the graph as the API handler does, from the options of a request
+func (b *clusterBackend) Graph(ctx context.Context, namespaces []string, graphType, duration string) (graphConfig interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if response, ok := r.(graph.Response); ok {
+				err = fmt.Errorf("%s", response.Message)
+				return
+			}
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	r, err := http.NewRequestWithContext(authentication.SetAuthInfoContext(ctx, b.authInfo), http.MethodGet,
+		"/api/namespaces/graph?"+graphQuery(namespaces, graphType, duration).Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	code, graphConfig := graphapi.GraphNamespaces(r.Context(), b.layer, graph.NewOptions(r))
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unable to build the graph (%d)", code)
+	}
+	return graphConfig, nil
+}
+
+func (b *clusterBackend) MeshStatus(ctx context.Context, cluster string) (kubernetes.IstioComponentStatus, error) {
+	return b.layer.IstioStatus.GetStatus(ctx, b.cluster(cluster))
+}
+
+func (b *clusterBackend) Services(ctx context.Context, cluster string, namespaces []string) ([]models.ServiceOverview, error) {
+	cluster = b.cluster(cluster)
+	if len(namespaces) == 0 {
+		clusterNamespaces, err := b.layer.Namespace.GetClusterNamespaces(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range clusterNamespaces {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	services := []models.ServiceOverview{}
+	for _, namespace := range namespaces {
+		list, err := b.layer.Svc.GetServiceList(ctx, business.ServiceCriteria{
+			Cluster: cluster, Namespace: namespace, IncludeIstioResources: true, IncludeOnlyDefinitions: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, list.Services...)
+	}
+	return services, nil
+}
+
+func graphQuery(namespaces []string, graphType, duration string) url.Values {
+	return url.Values{
+		"namespaces": []string{strings.Join(namespaces, ",")},
+		"graphType":  []string{graphType},
+		"duration":   []string{duration},
+	}
+}
@@ -0,0 +1,345 @@
+// Package cli implements the command line mode of the kiali binary: commands reading the validations, the graph, the
+// mesh status and the services of the mesh, from the API of a running Kiali server or directly from the cluster with the
+// business layer of the server. The exit code of the validate command lets CI pipelines gate on the validation errors.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog"
+	zl "github.com/rs/zerolog/log"
+
+	"github.com/kiali/kiali/client"
+	"github.com/kiali/kiali/models"
+)
+
+// The exit codes of the commands
+const (
+	exitOK = 0
+	// The validate command found validations of the failing severity
+	exitFailed = 1
+	// The command could not run: bad usage, unreachable server or cluster...
+	exitError = 2
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// options are the flags common to all the commands
+type options struct {
+	server     string
+	token      string
+	configFile string
+	cluster    string
+	namespaces string
+	output     string
+}
+
+func (o *options) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.server, "server", "", "URL of the Kiali server to query. When empty, the cluster of the kube config is read directly.")
+	flags.StringVar(&o.token, "token", os.Getenv("KIALI_TOKEN"), "Bearer token of the Kiali server, the KIALI_TOKEN environment variable by default.")
+	flags.StringVar(&o.configFile, "config", "", "Path to the Kiali YAML configuration file, when reading the cluster directly.")
+	flags.StringVar(&o.cluster, "cluster", "", "Cluster of the namespaces, the home cluster when empty.")
+	flags.StringVar(&o.namespaces, "namespaces", "", "Comma separated list of namespaces, all the accessible namespaces when empty.")
+	flags.StringVar(&o.output, "output", outputText, "Output format: text or json.")
+}
+
+func (o *options) namespaceList() []string {
+	namespaces := []string{}
+	for _, namespace := range strings.Split(o.namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// command is a command of the CLI, named by one or more words
+type command struct {
+	name  string
+	usage string
+	// flags registers the flags of the command, besides the common ones, and returns its run function
+	flags func(flags *flag.FlagSet, opts *options) func(ctx context.Context, b backend, stdout io.Writer) (int, error)
+}
+
+var commands = []command{
+	{
+		name:  "validate",
+		usage: "Validate the Istio config of the namespaces. Exits with 1 when validations of the failing severity are found.",
+		flags: func(flags *flag.FlagSet, opts *options) func(context.Context, backend, io.Writer) (int, error) {
+			failOn := flags.String("fail-on", string(models.ErrorSeverity), "Lowest severity failing the command: error or warning.")
+			return func(ctx context.Context, b backend, stdout io.Writer) (int, error) {
+				return validate(ctx, b, opts, models.SeverityLevel(*failOn), stdout)
+			}
+		},
+	},
+	{
+		name:  "graph",
+		usage: "Print the traffic graph of the namespaces, in JSON.",
+		flags: func(flags *flag.FlagSet, opts *options) func(context.Context, backend, io.Writer) (int, error) {
+			graphType := flags.String("graph-type", "versionedApp", "Graph type: app, service, versionedApp or workload.")
+			duration := flags.String("duration", "10m", "Duration of the traffic of the graph.")
+			return func(ctx context.Context, b backend, stdout io.Writer) (int, error) {
+				if len(opts.namespaceList()) == 0 {
+					return exitError, errors.New("the -namespaces flag is required")
+				}
+				graph, err := b.Graph(ctx, opts.namespaceList(), *graphType, *duration)
+				if err != nil {
+					return exitError, err
+				}
+				return exitOK, writeJSON(stdout, graph)
+			}
+		},
+	},
+	{
+		name:  "mesh status",
+		usage: "Print the status of the components of the mesh.",
+		flags: func(flags *flag.FlagSet, opts *options) func(context.Context, backend, io.Writer) (int, error) {
+			return func(ctx context.Context, b backend, stdout io.Writer) (int, error) {
+				return meshStatus(ctx, b, opts, stdout)
+			}
+		},
+	},
+	{
+		name:  "services list",
+		usage: "List the services of the namespaces.",
+		flags: func(flags *flag.FlagSet, opts *options) func(context.Context, backend, io.Writer) (int, error) {
+			return func(ctx context.Context, b backend, stdout io.Writer) (int, error) {
+				return listServices(ctx, b, opts, stdout)
+			}
+		},
+	},
+}
+
+// newBackend returns the backend of the options, with its stop function
+var newBackend = func(opts *options) (backend, func(), error) {
+	if opts.server != "" {
+		return &apiBackend{client: client.New(opts.server, opts.token, nil)}, func() {}, nil
+	}
+	b, err := newClusterBackend(opts.configFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, b.Stop, nil
+}
+
+// IsCommand tells if the first argument of the kiali binary is a command of the CLI rather than a flag of the server
+func IsCommand(arg string) bool {
+	if arg == "help" {
+		return true
+	}
+	for _, cmd := range commands {
+		if strings.Fields(cmd.name)[0] == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the command of the arguments and returns the exit code of the binary
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	initLogger(stderr)
+
+	if len(args) > 0 && args[0] == "help" {
+		usage(stdout)
+		return exitOK
+	}
+	cmd, args := findCommand(args)
+	if cmd == nil {
+		usage(stderr)
+		return exitError
+	}
+
+	flags := flag.NewFlagSet("kiali "+cmd.name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: kiali %s [flags]\n\n%s\n\n", cmd.name, cmd.usage)
+		flags.PrintDefaults()
+	}
+	opts := &options{}
+	opts.register(flags)
+	run := cmd.flags(flags, opts)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+	if opts.output != outputText && opts.output != outputJSON {
+		fmt.Fprintf(stderr, "Error: unknown output format [%s]\n", opts.output)
+		return exitError
+	}
+
+	b, stop, err := newBackend(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return exitError
+	}
+	defer stop()
+
+	code, err := run(ctx, b, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+	}
+	return code
+}
+
+// findCommand returns the command named by the longest prefix of the arguments, with the rest of the arguments
+func findCommand(args []string) (*command, []string) {
+	var found *command
+	var rest []string
+	for i := range commands {
+		words := strings.Fields(commands[i].name)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != commands[i].name {
+			continue
+		}
+		if found == nil || len(words) > len(strings.Fields(found.name)) {
+			found = &commands[i]
+			rest = args[len(words):]
+		}
+	}
+	return found, rest
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: kiali <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.usage)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'kiali <command> -h' for the flags of a command. Without a command, kiali starts the server.")
+}
+
+// initLogger sends the logs of the business layer to stderr, keeping stdout for the output of the commands, and only
+// logs the warnings unless the LOG_LEVEL environment variable asks for more.
+func initLogger(stderr io.Writer) {
+	zl.Logger = zl.Output(zerolog.ConsoleWriter{Out: stderr, TimeFormat: zerolog.TimeFieldFormat, NoColor: true})
+	if _, ok := os.LookupEnv("LOG_LEVEL"); !ok {
+		zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func validate(ctx context.Context, b backend, opts *options, failOn models.SeverityLevel, stdout io.Writer) (int, error) {
+	if failOn != models.ErrorSeverity && failOn != models.WarningSeverity {
+		return exitError, fmt.Errorf("unknown severity [%s]", failOn)
+	}
+	validations, err := b.Validations(ctx, opts.cluster, opts.namespaceList())
+	if err != nil {
+		return exitError, err
+	}
+
+	keys := make([]models.IstioValidationKey, 0, len(validations))
+	for key := range validations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ObjectType != b.ObjectType {
+			return a.ObjectType < b.ObjectType
+		}
+		return a.Name < b.Name
+	})
+
+	errorCount, warningCount := 0, 0
+	if opts.output == outputJSON {
+		// The list is stable for the pipelines parsing it, unlike the map
+		list := make([]*models.IstioValidation, 0, len(keys))
+		for _, key := range keys {
+			list = append(list, validations[key])
+		}
+		if err := writeJSON(stdout, list); err != nil {
+			return exitError, err
+		}
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	if opts.output == outputText {
+		fmt.Fprintln(tw, "SEVERITY\tCLUSTER\tNAMESPACE\tOBJECT\tCODE\tMESSAGE")
+	}
+	for _, key := range keys {
+		for _, check := range validations[key].Checks {
+			switch check.Severity {
+			case models.ErrorSeverity:
+				errorCount++
+			case models.WarningSeverity:
+				warningCount++
+			default:
+				continue
+			}
+			if opts.output == outputText {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s/%s\t%s\t%s\n", check.Severity, key.Cluster, key.Namespace,
+					key.ObjectType, key.Name, check.Code, check.Message)
+			}
+		}
+	}
+	if opts.output == outputText {
+		tw.Flush()
+		fmt.Fprintf(stdout, "\n%d errors, %d warnings in %d objects\n", errorCount, warningCount, len(keys))
+	}
+
+	if errorCount > 0 || (failOn == models.WarningSeverity && warningCount > 0) {
+		return exitFailed, nil
+	}
+	return exitOK, nil
+}
+
+func meshStatus(ctx context.Context, b backend, opts *options, stdout io.Writer) (int, error) {
+	status, err := b.MeshStatus(ctx, opts.cluster)
+	if err != nil {
+		return exitError, err
+	}
+	if opts.output == outputJSON {
+		return exitOK, writeJSON(stdout, status)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSTATUS\tCORE")
+	for _, component := range status {
+		fmt.Fprintf(tw, "%s\t%s\t%t\n", component.Name, component.Status, component.IsCore)
+	}
+	return exitOK, tw.Flush()
+}
+
+func listServices(ctx context.Context, b backend, opts *options, stdout io.Writer) (int, error) {
+	services, err := b.Services(ctx, opts.cluster, opts.namespaceList())
+	if err != nil {
+		return exitError, err
+	}
+	if opts.output == outputJSON {
+		return exitOK, writeJSON(stdout, services)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tCLUSTER\tSIDECAR")
+	for _, service := range services {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", service.Namespace, service.Name, service.Cluster, service.IstioSidecar)
+	}
+	return exitOK, tw.Flush()
+}
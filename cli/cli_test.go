@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type fakeBackend struct {
+	validations models.IstioValidations
+	status      kubernetes.IstioComponentStatus
+	services    []models.ServiceOverview
+	err         error
+
+	namespaces []string
+}
+
+func (b *fakeBackend) Validations(ctx context.Context, cluster string, namespaces []string) (models.IstioValidations, error) {
+	b.namespaces = namespaces
+	return b.validations, b.err
+}
+
+func (b *fakeBackend) Graph(ctx context.Context, namespaces []string, graphType, duration string) (interface{}, error) {
+	b.namespaces = namespaces
+	return map[string]string{"graphType": graphType, "duration": duration}, b.err
+}
+
+func (b *fakeBackend) MeshStatus(ctx context.Context, cluster string) (kubernetes.IstioComponentStatus, error) {
+	return b.status, b.err
+}
+
+func (b *fakeBackend) Services(ctx context.Context, cluster string, namespaces []string) ([]models.ServiceOverview, error) {
+	b.namespaces = namespaces
+	return b.services, b.err
+}
+
+func setupBackend(t *testing.T, b backend) {
+	original := newBackend
+	newBackend = func(*options) (backend, func(), error) { return b, func() {}, nil }
+	t.Cleanup(func() { newBackend = original })
+}
+
+func run(args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := Run(context.Background(), args, stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func fakeValidations() models.IstioValidations {
+	key := models.IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo", Cluster: "east"}
+	return models.IstioValidations{
+		key: &models.IstioValidation{
+			Name: "reviews", Namespace: "bookinfo", Cluster: "east", ObjectType: "virtualservice",
+			Checks: []*models.IstioCheck{{Code: "KIA1102", Message: "VirtualService is pointing to a non-existent gateway", Severity: models.WarningSeverity}},
+		},
+	}
+}
+
+func TestIsCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsCommand("validate"))
+	assert.True(IsCommand("mesh"))
+	assert.True(IsCommand("help"))
+	assert.False(IsCommand("-config"))
+	assert.False(IsCommand("status"))
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &fakeBackend{validations: fakeValidations()}
+	setupBackend(t, b)
+
+	code, stdout, _ := run("validate", "-namespaces", "bookinfo, travels")
+	assert.Equal(exitOK, code)
+	assert.Equal([]string{"bookinfo", "travels"}, b.namespaces)
+	assert.Contains(stdout, "warning   east     bookinfo   virtualservice/reviews  KIA1102")
+	assert.Contains(stdout, "0 errors, 1 warnings in 1 objects")
+
+	// The warnings only fail the command when asked
+	code, _, _ = run("validate", "-fail-on", "warning")
+	assert.Equal(exitFailed, code)
+
+	b.validations[models.IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo", Cluster: "east"}].Checks[0].Severity = models.ErrorSeverity
+	code, stdout, _ = run("validate", "-output", "json")
+	assert.Equal(exitFailed, code)
+	var validations []models.IstioValidation
+	require.NoError(t, json.Unmarshal([]byte(stdout), &validations))
+	require.Len(t, validations, 1)
+	assert.Equal("reviews", validations[0].Name)
+
+	code, _, stderr := run("validate", "-fail-on", "info")
+	assert.Equal(exitError, code)
+	assert.Contains(stderr, "unknown severity")
+}
+
+func TestCommandErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	setupBackend(t, &fakeBackend{err: errors.New("connection refused")})
+
+	code, _, stderr := run("services", "list")
+	assert.Equal(exitError, code)
+	assert.Contains(stderr, "connection refused")
+
+	code, _, stderr = run("graph")
+	assert.Equal(exitError, code)
+	assert.Contains(stderr, "-namespaces flag is required")
+
+	code, _, stderr = run("mesh")
+	assert.Equal(exitError, code)
+	assert.Contains(stderr, "Usage: kiali <command>")
+
+	code, _, stderr = run("mesh", "status", "-output", "yaml")
+	assert.Equal(exitError, code)
+	assert.Contains(stderr, "unknown output format")
+
+	code, _, stderr = run("services", "list", "-h")
+	assert.Equal(exitOK, code)
+	assert.Contains(stderr, "Usage: kiali services list [flags]")
+}
+
+func TestMeshStatusAndServices(t *testing.T) {
+	assert := assert.New(t)
+
+	setupBackend(t, &fakeBackend{
+		status:   kubernetes.IstioComponentStatus{{Name: "istiod", Status: kubernetes.ComponentHealthy, IsCore: true}},
+		services: []models.ServiceOverview{{Name: "reviews", Namespace: "bookinfo", Cluster: "east", IstioSidecar: true}},
+	})
+
+	code, stdout, _ := run("mesh", "status")
+	assert.Equal(exitOK, code)
+	assert.Contains(stdout, "istiod  Healthy  true")
+
+	code, stdout, _ = run("services", "list")
+	assert.Equal(exitOK, code)
+	assert.Contains(stdout, "bookinfo   reviews  east     true")
+
+	code, stdout, _ = run("graph", "-namespaces", "bookinfo", "-graph-type", "workload")
+	assert.Equal(exitOK, code)
+	assert.JSONEq(`{"graphType": "workload", "duration": "10m"}`, stdout)
+}
+
+func TestAPIBackend(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal("/api/namespaces/bookinfo/istio", r.URL.Path)
+		assert.Equal("true", r.URL.Query().Get("validate"))
+		list := models.IstioConfigList{IstioValidations: fakeValidations()}
+		require.NoError(json.NewEncoder(w).Encode(list))
+	}))
+	t.Cleanup(ts.Close)
+
+	code, stdout, stderr := run("validate", "-server", ts.URL, "-token", "secret", "-namespaces", "bookinfo", "-fail-on", "warning")
+	assert.Equal(exitFailed, code, stderr)
+	assert.Contains(stdout, "virtualservice/reviews")
+}
+
+func TestHelp(t *testing.T) {
+	code, stdout, _ := run("help")
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout, "services list")
+}
@@ -0,0 +1,85 @@
+// Package client is a typed Go client of the Kiali API, for the tools and operators integrating with Kiali. Its
+// methods are generated by tools/cmd/openapi from the swagger annotations of the routes and the models of their
+// responses.
+//
+// NOTE! The Kiali API is not for public use and can change from version to version with no guarantee of backwards
+// compatibility. Use the client of the version of the Kiali server.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client calls the API of a Kiali server
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// APIError is the error response of the Kiali API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kiali API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// New returns a client of the Kiali server at a base URL, including its web root (i.e. https://kiali.example.com/kiali),
+// authenticated with a bearer token when set. The default HTTP client is used when httpClient is nil.
+func New(baseURL, token string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// do calls the API and decodes its JSON response in out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error != "" {
+			apiErr.Message = errorResponse.Error
+		}
+		return apiErr
+	}
+	return json.Unmarshal(body, out)
+}
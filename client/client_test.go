@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCallsAPI(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/kiali/api/namespaces/bookinfo/services/reviews", r.URL.Path)
+		assert.Equal("east", r.URL.Query().Get("clusterName"))
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"cluster":"east","service":{"name":"reviews","namespace":"bookinfo"}}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/kiali/", "token", nil)
+	details, err := c.ServiceDetails(context.Background(), "bookinfo", "reviews", url.Values{"clusterName": []string{"east"}})
+	require.NoError(err)
+	assert.Equal("reviews", details.Service.Name)
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"no access to namespace bookinfo"}`))
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL, "", nil).NamespaceList(context.Background(), nil)
+	require.Error(t, err)
+	apiErr, ok := err.(*APIError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, "no access to namespace bookinfo", apiErr.Message)
+}
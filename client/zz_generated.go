@@ -0,0 +1,626 @@
+// Code generated by tools/cmd/openapi. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/status"
+	"github.com/kiali/kiali/tracing/jaeger/model"
+	jaegerModels "github.com/kiali/kiali/tracing/jaeger/model/json"
+)
+
+// Root calls GET /api.
+func (c *Client) Root(ctx context.Context, query url.Values) (*status.StatusInfo, error) {
+	var out status.StatusInfo
+	err := c.do(ctx, "GET", "/api", query, &out)
+	return &out, err
+}
+
+// Authenticate calls GET /api/authenticate.
+func (c *Client) Authenticate(ctx context.Context, query url.Values) (*authentication.UserSessionData, error) {
+	var out authentication.UserSessionData
+	err := c.do(ctx, "GET", "/api/authenticate", query, &out)
+	return &out, err
+}
+
+// Status calls GET /api/status.
+func (c *Client) Status(ctx context.Context, query url.Values) (*status.StatusInfo, error) {
+	var out status.StatusInfo
+	err := c.do(ctx, "GET", "/api/status", query, &out)
+	return &out, err
+}
+
+// Config calls GET /api/config.
+func (c *Client) Config(ctx context.Context, query url.Values) (*status.StatusInfo, error) {
+	var out status.StatusInfo
+	err := c.do(ctx, "GET", "/api/config", query, &out)
+	return &out, err
+}
+
+// Crippled calls GET /api/crippled.
+func (c *Client) Crippled(ctx context.Context, query url.Values) (*status.StatusInfo, error) {
+	var out status.StatusInfo
+	err := c.do(ctx, "GET", "/api/crippled", query, &out)
+	return &out, err
+}
+
+// IstioConfigPermissions calls GET /api/istio/permissions.
+func (c *Client) IstioConfigPermissions(ctx context.Context, query url.Values) (models.IstioConfigPermissions, error) {
+	var out models.IstioConfigPermissions
+	err := c.do(ctx, "GET", "/api/istio/permissions", query, &out)
+	return out, err
+}
+
+// IstioConfigSchemas calls GET /api/istio/schemas.
+func (c *Client) IstioConfigSchemas(ctx context.Context, query url.Values) (*models.IstioConfigSchemas, error) {
+	var out models.IstioConfigSchemas
+	err := c.do(ctx, "GET", "/api/istio/schemas", query, &out)
+	return &out, err
+}
+
+// IstioLocalities calls GET /api/istio/localities.
+func (c *Client) IstioLocalities(ctx context.Context, query url.Values) ([]models.ClusterLocalities, error) {
+	var out []models.ClusterLocalities
+	err := c.do(ctx, "GET", "/api/istio/localities", query, &out)
+	return out, err
+}
+
+// IstioConfigList calls GET /api/namespaces/{namespace}/istio, with the optional query parameters validate, validationStatus, gvks.
+func (c *Client) IstioConfigList(ctx context.Context, namespace string, query url.Values) (*models.IstioConfigList, error) {
+	var out models.IstioConfigList
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/istio", query, &out)
+	return &out, err
+}
+
+// AuthorizationCoverage calls GET /api/namespaces/{namespace}/authorization/coverage.
+func (c *Client) AuthorizationCoverage(ctx context.Context, namespace string, query url.Values) (*models.AuthorizationCoverage, error) {
+	var out models.AuthorizationCoverage
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/authorization/coverage", query, &out)
+	return &out, err
+}
+
+// IstioConfigListAll calls GET /api/istio/config.
+func (c *Client) IstioConfigListAll(ctx context.Context, query url.Values) (*models.IstioConfigList, error) {
+	var out models.IstioConfigList
+	err := c.do(ctx, "GET", "/api/istio/config", query, &out)
+	return &out, err
+}
+
+// IstioConfigDetails calls GET /api/namespaces/{namespace}/istio/{object_type}/{object}, with the optional query parameters validate.
+func (c *Client) IstioConfigDetails(ctx context.Context, namespace string, object_type string, object string, query url.Values) (*models.IstioConfigDetails, error) {
+	var out models.IstioConfigDetails
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/istio/"+url.PathEscape(object_type)+"/"+url.PathEscape(object), query, &out)
+	return &out, err
+}
+
+// IstioConfigImpact calls GET /api/namespaces/{namespace}/istio/{object_type}/{object}/impact.
+func (c *Client) IstioConfigImpact(ctx context.Context, namespace string, object_type string, object string, query url.Values) (*models.ReferenceImpact, error) {
+	var out models.ReferenceImpact
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/istio/"+url.PathEscape(object_type)+"/"+url.PathEscape(object)+"/impact", query, &out)
+	return &out, err
+}
+
+// IstioConfigDeletionImpact calls GET /api/namespaces/{namespace}/istio/{object_type}/{object}/deletion_impact.
+func (c *Client) IstioConfigDeletionImpact(ctx context.Context, namespace string, object_type string, object string, query url.Values) (*models.DeletionImpact, error) {
+	var out models.DeletionImpact
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/istio/"+url.PathEscape(object_type)+"/"+url.PathEscape(object)+"/deletion_impact", query, &out)
+	return &out, err
+}
+
+// ClustersServices calls GET /api/clusters/services.
+func (c *Client) ClustersServices(ctx context.Context, query url.Values) (*models.ClusterServices, error) {
+	var out models.ClusterServices
+	err := c.do(ctx, "GET", "/api/clusters/services", query, &out)
+	return &out, err
+}
+
+// ServiceDetails calls GET /api/namespaces/{namespace}/services/{service}, with the optional query parameters validate.
+func (c *Client) ServiceDetails(ctx context.Context, namespace string, service string, query url.Values) (*models.ServiceDetails, error) {
+	var out models.ServiceDetails
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/services/"+url.PathEscape(service), query, &out)
+	return &out, err
+}
+
+// ServiceTrafficMirroring calls GET /api/namespaces/{namespace}/services/{service}/wizards/traffic_mirroring, with the optional query parameters subset, host, port, percentage.
+func (c *Client) ServiceTrafficMirroring(ctx context.Context, namespace string, service string, query url.Values) (*models.WizardScenario, error) {
+	var out models.WizardScenario
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/services/"+url.PathEscape(service)+"/wizards/traffic_mirroring", query, &out)
+	return &out, err
+}
+
+// AppSpans calls GET /api/namespaces/{namespace}/apps/{app}/spans.
+func (c *Client) AppSpans(ctx context.Context, namespace string, app string, query url.Values) ([]model.TracingSpan, error) {
+	var out []model.TracingSpan
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/apps/"+url.PathEscape(app)+"/spans", query, &out)
+	return out, err
+}
+
+// WorkloadSpans calls GET /api/namespaces/{namespace}/workloads/{workload}/spans.
+func (c *Client) WorkloadSpans(ctx context.Context, namespace string, workload string, query url.Values) ([]model.TracingSpan, error) {
+	var out []model.TracingSpan
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/spans", query, &out)
+	return out, err
+}
+
+// ServiceSpans calls GET /api/namespaces/{namespace}/services/{service}/spans.
+func (c *Client) ServiceSpans(ctx context.Context, namespace string, service string, query url.Values) ([]model.TracingSpan, error) {
+	var out []model.TracingSpan
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/services/"+url.PathEscape(service)+"/spans", query, &out)
+	return out, err
+}
+
+// AppTraces calls GET /api/namespaces/{namespace}/apps/{app}/traces.
+func (c *Client) AppTraces(ctx context.Context, namespace string, app string, query url.Values) ([]jaegerModels.Trace, error) {
+	var out []jaegerModels.Trace
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/apps/"+url.PathEscape(app)+"/traces", query, &out)
+	return out, err
+}
+
+// EdgeTraces calls GET /api/traces/edge, with the optional query parameters sourceNamespace, sourceKind, sourceName, sourceCluster, destNamespace, destKind, destName, destCluster, startMicros, endMicros.
+func (c *Client) EdgeTraces(ctx context.Context, query url.Values) ([]jaegerModels.Trace, error) {
+	var out []jaegerModels.Trace
+	err := c.do(ctx, "GET", "/api/traces/edge", query, &out)
+	return out, err
+}
+
+// ServiceTraces calls GET /api/namespaces/{namespace}/services/{service}/traces.
+func (c *Client) ServiceTraces(ctx context.Context, namespace string, service string, query url.Values) ([]jaegerModels.Trace, error) {
+	var out []jaegerModels.Trace
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/services/"+url.PathEscape(service)+"/traces", query, &out)
+	return out, err
+}
+
+// WorkloadTraces calls GET /api/namespaces/{namespace}/workloads/{workload}/traces.
+func (c *Client) WorkloadTraces(ctx context.Context, namespace string, workload string, query url.Values) ([]jaegerModels.Trace, error) {
+	var out []jaegerModels.Trace
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/traces", query, &out)
+	return out, err
+}
+
+// ErrorTraces calls GET /api/namespaces/{namespace}/apps/{app}/errortraces.
+func (c *Client) ErrorTraces(ctx context.Context, namespace string, app string, query url.Values) (int, error) {
+	var out int
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/apps/"+url.PathEscape(app)+"/errortraces", query, &out)
+	return out, err
+}
+
+// TracesDetails calls GET /api/traces/{traceID}.
+func (c *Client) TracesDetails(ctx context.Context, traceID string, query url.Values) (*model.TracingSingleTrace, error) {
+	var out model.TracingSingleTrace
+	err := c.do(ctx, "GET", "/api/traces/"+url.PathEscape(traceID), query, &out)
+	return &out, err
+}
+
+// TraceLogs calls GET /api/traces/{traceID}/logs.
+func (c *Client) TraceLogs(ctx context.Context, traceID string, query url.Values) (*business.TraceLogs, error) {
+	var out business.TraceLogs
+	err := c.do(ctx, "GET", "/api/traces/"+url.PathEscape(traceID)+"/logs", query, &out)
+	return &out, err
+}
+
+// ClustersWorkloads calls GET /api/clusters/workloads.
+func (c *Client) ClustersWorkloads(ctx context.Context, query url.Values) (*models.ClusterWorkloads, error) {
+	var out models.ClusterWorkloads
+	err := c.do(ctx, "GET", "/api/clusters/workloads", query, &out)
+	return &out, err
+}
+
+// WorkloadDetails calls GET /api/namespaces/{namespace}/workloads/{workload}.
+func (c *Client) WorkloadDetails(ctx context.Context, namespace string, workload string, query url.Values) (*models.Workload, error) {
+	var out models.Workload
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload), query, &out)
+	return &out, err
+}
+
+// ClustersApps calls GET /api/clusters/apps.
+func (c *Client) ClustersApps(ctx context.Context, query url.Values) (*models.ClusterApps, error) {
+	var out models.ClusterApps
+	err := c.do(ctx, "GET", "/api/clusters/apps", query, &out)
+	return &out, err
+}
+
+// AppDetails calls GET /api/namespaces/{namespace}/apps/{app}.
+func (c *Client) AppDetails(ctx context.Context, namespace string, app string, query url.Values) (*models.App, error) {
+	var out models.App
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/apps/"+url.PathEscape(app), query, &out)
+	return &out, err
+}
+
+// NamespaceList calls GET /api/namespaces.
+func (c *Client) NamespaceList(ctx context.Context, query url.Values) ([]models.Namespace, error) {
+	var out []models.Namespace
+	err := c.do(ctx, "GET", "/api/namespaces", query, &out)
+	return out, err
+}
+
+// NamespaceAccessAudit calls GET /api/namespaces/access.
+func (c *Client) NamespaceAccessAudit(ctx context.Context, query url.Values) ([]models.NamespaceAccessAudit, error) {
+	var out []models.NamespaceAccessAudit
+	err := c.do(ctx, "GET", "/api/namespaces/access", query, &out)
+	return out, err
+}
+
+// NamespaceMeshReadiness calls GET /api/namespaces/{namespace}/readiness.
+func (c *Client) NamespaceMeshReadiness(ctx context.Context, namespace string, query url.Values) (*models.MeshReadiness, error) {
+	var out models.MeshReadiness
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/readiness", query, &out)
+	return &out, err
+}
+
+// NamespaceEffectiveMeshConfig calls GET /api/namespaces/{namespace}/meshconfig.
+func (c *Client) NamespaceEffectiveMeshConfig(ctx context.Context, namespace string, query url.Values) (*models.EffectiveMeshConfig, error) {
+	var out models.EffectiveMeshConfig
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/meshconfig", query, &out)
+	return &out, err
+}
+
+// NamespaceInfo calls GET /api/namespaces/{namespace}/info.
+func (c *Client) NamespaceInfo(ctx context.Context, namespace string, query url.Values) ([]models.Namespace, error) {
+	var out []models.Namespace
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/info", query, &out)
+	return out, err
+}
+
+// ServiceMetrics calls GET /api/namespaces/{namespace}/services/{service}/metrics, with the optional query parameters avg, byFlags, byLabels[], direction, duration, filters[], quantiles[], rateFunc, rateInterval, requestProtocol, reporter, step, version.
+func (c *Client) ServiceMetrics(ctx context.Context, namespace string, service string, query url.Values) (models.MetricsMap, error) {
+	var out models.MetricsMap
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/services/"+url.PathEscape(service)+"/metrics", query, &out)
+	return out, err
+}
+
+// AggregateMetrics calls GET /api/namespaces/{namespace}/aggregates/{aggregate}/{aggregateValue}/metrics, with the optional query parameters avg, byFlags, byLabels[], direction, duration, filters[], quantiles[], rateFunc, rateInterval, requestProtocol, reporter, step, version.
+func (c *Client) AggregateMetrics(ctx context.Context, namespace string, aggregate string, aggregateValue string, query url.Values) (models.MetricsMap, error) {
+	var out models.MetricsMap
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/aggregates/"+url.PathEscape(aggregate)+"/"+url.PathEscape(aggregateValue)+"/metrics", query, &out)
+	return out, err
+}
+
+// AppMetrics calls GET /api/namespaces/{namespace}/apps/{app}/metrics, with the optional query parameters avg, byFlags, byLabels[], direction, duration, filters[], quantiles[], rateFunc, rateInterval, requestProtocol, reporter, step, version.
+func (c *Client) AppMetrics(ctx context.Context, namespace string, app string, query url.Values) (models.MetricsMap, error) {
+	var out models.MetricsMap
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/apps/"+url.PathEscape(app)+"/metrics", query, &out)
+	return out, err
+}
+
+// WorkloadMetrics calls GET /api/namespaces/{namespace}/workloads/{workload}/metrics, with the optional query parameters avg, byFlags, byLabels[], direction, duration, filters[], quantiles[], rateFunc, rateInterval, requestProtocol, reporter, step, version.
+func (c *Client) WorkloadMetrics(ctx context.Context, namespace string, workload string, query url.Values) (models.MetricsMap, error) {
+	var out models.MetricsMap
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/metrics", query, &out)
+	return out, err
+}
+
+// WaypointTraffic calls GET /api/namespaces/{namespace}/workloads/{workload}/waypoint/traffic.
+func (c *Client) WaypointTraffic(ctx context.Context, namespace string, workload string, query url.Values) (*models.WaypointTraffic, error) {
+	var out models.WaypointTraffic
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/waypoint/traffic", query, &out)
+	return &out, err
+}
+
+// ServiceDashboard calls GET /api/namespaces/{namespace}/services/{service}/dashboard, with the optional query parameters avg, byFlags, byLabels[], direction, duration, quantiles[], rateFunc, rateInterval, requestProtocol, reporter, step.
+func (c *Client) ServiceDashboard(ctx context.Context, namespace string, service string, query url.Values) (*models.MonitoringDashboard, error) {
+	var out models.MonitoringDashboard
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/services/"+url.PathEscape(service)+"/dashboard", query, &out)
+	return &out, err
+}
+
+// AppDashboard calls GET /api/namespaces/{namespace}/apps/{app}/dashboard, with the optional query parameters avg, byFlags, byLabels[], direction, duration, quantiles[], rateFunc, rateInterval, requestProtocol, reporter, step.
+func (c *Client) AppDashboard(ctx context.Context, namespace string, app string, query url.Values) (*models.MonitoringDashboard, error) {
+	var out models.MonitoringDashboard
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/apps/"+url.PathEscape(app)+"/dashboard", query, &out)
+	return &out, err
+}
+
+// WorkloadDashboard calls GET /api/namespaces/{namespace}/workloads/{workload}/dashboard, with the optional query parameters avg, byFlags, byLabels[], direction, duration, quantiles[], rateFunc, rateInterval, requestProtocol, reporter, step.
+func (c *Client) WorkloadDashboard(ctx context.Context, namespace string, workload string, query url.Values) (*models.MonitoringDashboard, error) {
+	var out models.MonitoringDashboard
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/dashboard", query, &out)
+	return &out, err
+}
+
+// CustomDashboard calls GET /api/namespaces/{namespace}/customdashboard/{dashboard}, with the optional query parameters additionalLabels, avg, byLabels[], duration, labelsFilters, quantiles[], rateFunc, rateInterval, step, variables.
+func (c *Client) CustomDashboard(ctx context.Context, namespace string, dashboard string, query url.Values) (*models.MonitoringDashboard, error) {
+	var out models.MonitoringDashboard
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/customdashboard/"+url.PathEscape(dashboard), query, &out)
+	return &out, err
+}
+
+// NamespaceMetrics calls GET /api/namespaces/{namespace}/metrics.
+func (c *Client) NamespaceMetrics(ctx context.Context, namespace string, query url.Values) (models.MetricsMap, error) {
+	var out models.MetricsMap
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/metrics", query, &out)
+	return out, err
+}
+
+// NamespaceEvents calls GET /api/namespaces/{namespace}/events, with the optional query parameters app, workload, service, duration.
+func (c *Client) NamespaceEvents(ctx context.Context, namespace string, query url.Values) ([]models.KialiEvent, error) {
+	var out []models.KialiEvent
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/events", query, &out)
+	return out, err
+}
+
+// ClustersHealth calls GET /api/clusters/health.
+func (c *Client) ClustersHealth(ctx context.Context, query url.Values) (*models.ClustersNamespaceHealth, error) {
+	var out models.ClustersNamespaceHealth
+	err := c.do(ctx, "GET", "/api/clusters/health", query, &out)
+	return &out, err
+}
+
+// NamespaceValidationSummary calls GET /api/namespaces/{namespace}/validations.
+func (c *Client) NamespaceValidationSummary(ctx context.Context, namespace string, query url.Values) (*models.IstioValidationSummary, error) {
+	var out models.IstioValidationSummary
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/validations", query, &out)
+	return &out, err
+}
+
+// ConfigValidationSummary calls GET /api/istio/validations.
+func (c *Client) ConfigValidationSummary(ctx context.Context, query url.Values) (*models.IstioValidationSummary, error) {
+	var out models.IstioValidationSummary
+	err := c.do(ctx, "GET", "/api/istio/validations", query, &out)
+	return &out, err
+}
+
+// Mesh calls GET /api/mesh.
+func (c *Client) Mesh(ctx context.Context, query url.Values) (*models.Mesh, error) {
+	var out models.Mesh
+	err := c.do(ctx, "GET", "/api/mesh", query, &out)
+	return &out, err
+}
+
+// MeshTls calls GET /api/mesh/tls.
+func (c *Client) MeshTls(ctx context.Context, query url.Values) (*models.MTLSStatus, error) {
+	var out models.MTLSStatus
+	err := c.do(ctx, "GET", "/api/mesh/tls", query, &out)
+	return &out, err
+}
+
+// NamespaceTls calls GET /api/namespaces/{namespace}/tls.
+func (c *Client) NamespaceTls(ctx context.Context, namespace string, query url.Values) (*models.MTLSStatus, error) {
+	var out models.MTLSStatus
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/tls", query, &out)
+	return &out, err
+}
+
+// NamespacePermissiveTraffic calls GET /api/namespaces/{namespace}/tls/permissive.
+func (c *Client) NamespacePermissiveTraffic(ctx context.Context, namespace string, query url.Values) (*models.PermissiveTrafficReport, error) {
+	var out models.PermissiveTrafficReport
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/tls/permissive", query, &out)
+	return &out, err
+}
+
+// WorkloadEffectiveMeshConfig calls GET /api/namespaces/{namespace}/workloads/{workload}/meshconfig.
+func (c *Client) WorkloadEffectiveMeshConfig(ctx context.Context, namespace string, workload string, query url.Values) (*models.EffectiveMeshConfig, error) {
+	var out models.EffectiveMeshConfig
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/meshconfig", query, &out)
+	return &out, err
+}
+
+// WorkloadTls calls GET /api/namespaces/{namespace}/workloads/{workload}/tls.
+func (c *Client) WorkloadTls(ctx context.Context, namespace string, workload string, query url.Values) (*models.MTLSStatus, error) {
+	var out models.MTLSStatus
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/tls", query, &out)
+	return &out, err
+}
+
+// ClusterTls calls GET /api/clusters/tls.
+func (c *Client) ClusterTls(ctx context.Context, query url.Values) ([]models.MTLSStatus, error) {
+	var out []models.MTLSStatus
+	err := c.do(ctx, "GET", "/api/clusters/tls", query, &out)
+	return out, err
+}
+
+// IstioStatus calls GET /api/istio/status.
+func (c *Client) IstioStatus(ctx context.Context, query url.Values) (kubernetes.IstioComponentStatus, error) {
+	var out kubernetes.IstioComponentStatus
+	err := c.do(ctx, "GET", "/api/istio/status", query, &out)
+	return out, err
+}
+
+// MeshComponentStatus calls GET /api/mesh/status.
+func (c *Client) MeshComponentStatus(ctx context.Context, query url.Values) (*models.MeshComponentStatus, error) {
+	var out models.MeshComponentStatus
+	err := c.do(ctx, "GET", "/api/mesh/status", query, &out)
+	return &out, err
+}
+
+// IstioCerts calls GET /api/istio/certs.
+func (c *Client) IstioCerts(ctx context.Context, query url.Values) ([]models.CertInfo, error) {
+	var out []models.CertInfo
+	err := c.do(ctx, "GET", "/api/istio/certs", query, &out)
+	return out, err
+}
+
+// GraphNamespaces calls GET /api/namespaces/graph, with the optional query parameters aggregateProtocol, appenders, boxBy, compareTime, duration, format, graphType, includeIdleEdges, injectServiceNodes, namespaces, queryTime, rateGrpc, rateHttp, rateTcp, responseTime, throughput.
+func (c *Client) GraphNamespaces(ctx context.Context, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/namespaces/graph", query, &out)
+	return &out, err
+}
+
+// GraphAggregate calls GET /api/namespaces/{namespace}/aggregates/{aggregate}/{aggregateValue}/graph, with the optional query parameters clusterName, aggregateProtocol, format.
+func (c *Client) GraphAggregate(ctx context.Context, namespace string, aggregate string, aggregateValue string, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/aggregates/"+url.PathEscape(aggregate)+"/"+url.PathEscape(aggregateValue)+"/graph", query, &out)
+	return &out, err
+}
+
+// GraphAggregateByService calls GET /api/namespaces/{namespace}/aggregates/{aggregate}/{aggregateValue}/{service}/graph, with the optional query parameters clusterName, aggregateProtocol, format.
+func (c *Client) GraphAggregateByService(ctx context.Context, namespace string, aggregate string, aggregateValue string, service string, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/aggregates/"+url.PathEscape(aggregate)+"/"+url.PathEscape(aggregateValue)+"/"+url.PathEscape(service)+"/graph", query, &out)
+	return &out, err
+}
+
+// GraphAppVersion calls GET /api/namespaces/{namespace}/applications/{app}/versions/{version}/graph, with the optional query parameters clusterName, aggregateProtocol, appenders, boxBy, compareTime, duration, format, graphType, includeIdleEdges, injectServiceNodes, queryTime, rateGrpc, rateHttp, rateTcp, responseTime, throughput.
+func (c *Client) GraphAppVersion(ctx context.Context, namespace string, app string, version string, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/applications/"+url.PathEscape(app)+"/versions/"+url.PathEscape(version)+"/graph", query, &out)
+	return &out, err
+}
+
+// GraphApp calls GET /api/namespaces/{namespace}/applications/{app}/graph, with the optional query parameters clusterName, aggregateProtocol, appenders, boxBy, compareTime, duration, format, graphType, includeIdleEdges, injectServiceNodes, queryTime, rateGrpc, rateHttp, rateTcp, responseTime, throughput.
+func (c *Client) GraphApp(ctx context.Context, namespace string, app string, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/applications/"+url.PathEscape(app)+"/graph", query, &out)
+	return &out, err
+}
+
+// GraphService calls GET /api/namespaces/{namespace}/services/{service}/graph, with the optional query parameters clusterName, aggregateProtocol, appenders, boxBy, compareTime, duration, format, graphType, queryTime, rateGrpc, rateHttp, rateTcp, responseTime, throughput.
+func (c *Client) GraphService(ctx context.Context, namespace string, service string, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/services/"+url.PathEscape(service)+"/graph", query, &out)
+	return &out, err
+}
+
+// GraphWorkload calls GET /api/namespaces/{namespace}/workloads/{workload}/graph, with the optional query parameters clusterName, aggregateProtocol, appenders, boxBy, compareTime, duration, format, graphType, includeIdleEdges, injectServiceNodes, queryTime, rateGrpc, rateHttp, rateTcp, responseTime, throughput.
+func (c *Client) GraphWorkload(ctx context.Context, namespace string, workload string, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/workloads/"+url.PathEscape(workload)+"/graph", query, &out)
+	return &out, err
+}
+
+// GraphViewsList calls GET /api/graph/views.
+func (c *Client) GraphViewsList(ctx context.Context, query url.Values) ([]models.GraphView, error) {
+	var out []models.GraphView
+	err := c.do(ctx, "GET", "/api/graph/views", query, &out)
+	return out, err
+}
+
+// GraphViewGet calls GET /api/graph/views/{name}.
+func (c *Client) GraphViewGet(ctx context.Context, name string, query url.Values) (*models.GraphView, error) {
+	var out models.GraphView
+	err := c.do(ctx, "GET", "/api/graph/views/"+url.PathEscape(name), query, &out)
+	return &out, err
+}
+
+// PreferencesGet calls GET /api/preferences.
+func (c *Client) PreferencesGet(ctx context.Context, query url.Values) (*models.UserPreferences, error) {
+	var out models.UserPreferences
+	err := c.do(ctx, "GET", "/api/preferences", query, &out)
+	return &out, err
+}
+
+// MeshGraph calls GET /api/mesh/graph.
+func (c *Client) MeshGraph(ctx context.Context, query url.Values) (*cytoscape.Config, error) {
+	var out cytoscape.Config
+	err := c.do(ctx, "GET", "/api/mesh/graph", query, &out)
+	return &out, err
+}
+
+// GrafanaURL calls GET /api/grafana.
+func (c *Client) GrafanaURL(ctx context.Context, query url.Values) (*models.GrafanaInfo, error) {
+	var out models.GrafanaInfo
+	err := c.do(ctx, "GET", "/api/grafana", query, &out)
+	return &out, err
+}
+
+// TracingURL calls GET /api/tracing.
+func (c *Client) TracingURL(ctx context.Context, query url.Values) (*models.TracingInfo, error) {
+	var out models.TracingInfo
+	err := c.do(ctx, "GET", "/api/tracing", query, &out)
+	return &out, err
+}
+
+// PodDetails calls GET /api/namespaces/{namespace}/pods/{pod}.
+func (c *Client) PodDetails(ctx context.Context, namespace string, pod string, query url.Values) (*models.Workload, error) {
+	var out models.Workload
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/pods/"+url.PathEscape(pod), query, &out)
+	return &out, err
+}
+
+// PodLogs calls GET /api/namespaces/{namespace}/pods/{pod}/logs, with the optional query parameters container, sinceTime, duration, follow, filter.
+func (c *Client) PodLogs(ctx context.Context, namespace string, pod string, query url.Values) (*models.Workload, error) {
+	var out models.Workload
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/pods/"+url.PathEscape(pod)+"/logs", query, &out)
+	return &out, err
+}
+
+// PodProxyDump calls GET /api/namespaces/{namespace}/pods/{pod}/config_dump.
+func (c *Client) PodProxyDump(ctx context.Context, namespace string, pod string, query url.Values) (*models.EnvoyProxyDump, error) {
+	var out models.EnvoyProxyDump
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/pods/"+url.PathEscape(pod)+"/config_dump", query, &out)
+	return &out, err
+}
+
+// PodProxyResource calls GET /api/namespaces/{namespace}/pods/{pod}/config_dump/{resource}.
+func (c *Client) PodProxyResource(ctx context.Context, namespace string, pod string, resource string, query url.Values) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/pods/"+url.PathEscape(pod)+"/config_dump/"+url.PathEscape(resource), query, &out)
+	return out, err
+}
+
+// PodProxyStatusDiff calls GET /api/namespaces/{namespace}/pods/{pod}/proxy_status/diff.
+func (c *Client) PodProxyStatusDiff(ctx context.Context, namespace string, pod string, query url.Values) (*models.ProxyStatusDiff, error) {
+	var out models.ProxyStatusDiff
+	err := c.do(ctx, "GET", "/api/namespaces/"+url.PathEscape(namespace)+"/pods/"+url.PathEscape(pod)+"/proxy_status/diff", query, &out)
+	return &out, err
+}
+
+// ClustersMetrics calls GET /api/clusters/metrics.
+func (c *Client) ClustersMetrics(ctx context.Context, query url.Values) (models.MetricsMap, error) {
+	var out models.MetricsMap
+	err := c.do(ctx, "GET", "/api/clusters/metrics", query, &out)
+	return out, err
+}
+
+// OutboundTrafficPolicyMode calls GET /api/mesh/outbound_traffic_policy/mode.
+func (c *Client) OutboundTrafficPolicyMode(ctx context.Context, query url.Values) (*models.OutboundPolicy, error) {
+	var out models.OutboundPolicy
+	err := c.do(ctx, "GET", "/api/mesh/outbound_traffic_policy/mode", query, &out)
+	return &out, err
+}
+
+// IstiodResourceThresholds calls GET /api/mesh/resources/thresholds.
+func (c *Client) IstiodResourceThresholds(ctx context.Context, query url.Values) (*models.IstiodThresholds, error) {
+	var out models.IstiodThresholds
+	err := c.do(ctx, "GET", "/api/mesh/resources/thresholds", query, &out)
+	return &out, err
+}
+
+// IstiodCanariesStatus calls GET /api/mesh/canaries/status.
+func (c *Client) IstiodCanariesStatus(ctx context.Context, query url.Values) (*models.CanaryUpgradeStatus, error) {
+	var out models.CanaryUpgradeStatus
+	err := c.do(ctx, "GET", "/api/mesh/canaries/status", query, &out)
+	return &out, err
+}
+
+// RevisionUpgradeProgress calls GET /api/mesh/canaries/progress.
+func (c *Client) RevisionUpgradeProgress(ctx context.Context, query url.Values) (*models.RevisionUpgradeProgress, error) {
+	var out models.RevisionUpgradeProgress
+	err := c.do(ctx, "GET", "/api/mesh/canaries/progress", query, &out)
+	return &out, err
+}
+
+// MeshCertificates calls GET /api/mesh/certificates.
+func (c *Client) MeshCertificates(ctx context.Context, query url.Values) (*models.CertificateInventory, error) {
+	var out models.CertificateInventory
+	err := c.do(ctx, "GET", "/api/mesh/certificates", query, &out)
+	return &out, err
+}
+
+// MeshTrustDomains calls GET /api/mesh/trustdomains.
+func (c *Client) MeshTrustDomains(ctx context.Context, query url.Values) (*models.TrustDomainReport, error) {
+	var out models.TrustDomainReport
+	err := c.do(ctx, "GET", "/api/mesh/trustdomains", query, &out)
+	return &out, err
+}
+
+// MeshConfigDrift calls GET /api/mesh/drift.
+func (c *Client) MeshConfigDrift(ctx context.Context, query url.Values) (*models.ConfigDriftReport, error) {
+	var out models.ConfigDriftReport
+	err := c.do(ctx, "GET", "/api/mesh/drift", query, &out)
+	return &out, err
+}
+
+// MeshEgress calls GET /api/mesh/egress.
+func (c *Client) MeshEgress(ctx context.Context, query url.Values) (*models.EgressReport, error) {
+	var out models.EgressReport
+	err := c.do(ctx, "GET", "/api/mesh/egress", query, &out)
+	return &out, err
+}
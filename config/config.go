@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"regexp"
 	"sort"
@@ -77,6 +78,14 @@ const (
 	TempoProvider  TracingProvider = "tempo"
 )
 
+// MetricsProvider is the kind of metrics store queried through the Prometheus client
+type MetricsProvider string
+
+const (
+	OTelProvider       MetricsProvider = "otel"
+	PrometheusProvider MetricsProvider = "prometheus"
+)
+
 // TracingCollectorType is the type of collector that Kiali will export traces to.
 // These are traces that kiali generates for itself.
 type TracingCollectorType string
@@ -92,11 +101,16 @@ type FeatureName string
 
 const (
 	FeatureLogView FeatureName = "logs-tab"
+	// The changes of the objects, allowed separately to the operators keeping the other features
+	FeatureIstioConfigEdit FeatureName = "istio-config-edit"
+	FeatureProxyLogLevel   FeatureName = "proxy-loglevel"
+	FeatureServicePatch    FeatureName = "service-patch"
+	FeatureWizards         FeatureName = "wizards"
 )
 
 func (fn FeatureName) IsValid() error {
 	switch fn {
-	case FeatureLogView:
+	case FeatureLogView, FeatureIstioConfigEdit, FeatureProxyLogLevel, FeatureServicePatch, FeatureWizards:
 		return nil
 	}
 	return fmt.Errorf("Invalid feature name: %v", fn)
@@ -161,6 +175,7 @@ type Server struct {
 	Observability              Observability `yaml:"observability,omitempty"`
 	Port                       int           `yaml:",omitempty"`
 	Profiler                   Profiler      `yaml:"profiler,omitempty"`
+	RateLimit                  RateLimit     `yaml:"rate_limit,omitempty"`
 	StaticContentRootDirectory string        `yaml:"static_content_root_directory,omitempty"`
 	WebFQDN                    string        `yaml:"web_fqdn,omitempty"`
 	WebPort                    string        `yaml:"web_port,omitempty"`
@@ -195,35 +210,107 @@ type ThanosProxy struct {
 	ScrapeInterval  string `yaml:"scrape_interval,omitempty"`
 }
 
+// GraphCache describes the caching of the namespace graphs. A graph is served as is to the same graph requests
+// (same namespaces, duration, appenders and other options) for Duration seconds. Later on, as long as it ended
+// at most RefreshWindow seconds ago, it is refreshed by querying only the recent traffic, since the end of the
+// cached graph, instead of the whole graph duration. The traffic leaving the time window is approximated.
+type GraphCache struct {
+	Duration      int  `yaml:"duration,omitempty"` // Seconds a generated graph is served as is
+	Enabled       bool `yaml:"enabled,omitempty"`
+	RefreshWindow int  `yaml:"refresh_window,omitempty"` // Maximum seconds of recent traffic queried to refresh a cached graph, 0 to never refresh
+}
+
+// CircuitBreaker describes when the health stops querying an unreachable Prometheus, and how often it probes whether
+// Prometheus is back meanwhile.
+type CircuitBreaker struct {
+	Enabled          bool `yaml:"enabled,omitempty"`
+	FailureThreshold int  `yaml:"failure_threshold,omitempty"` // Number of queries failed in a row opening the breaker
+	ProbeInterval    int  `yaml:"probe_interval,omitempty"`    // Seconds between the recovery probes while open
+}
+
+// HealthQueryBatching describes how the health queries of several namespaces are batched into a few queries
+// matching the namespaces with a regex, the results being split per namespace.
+type HealthQueryBatching struct {
+	BatchSize int  `yaml:"batch_size,omitempty"` // Maximum number of namespaces per batch
+	Enabled   bool `yaml:"enabled,omitempty"`
+}
+
+// HealthQuerySharding describes how the namespace-wide health queries are split into smaller queries
+// restricted to a few apps (or workloads) each, to keep their cardinality low on big meshes.
+type HealthQuerySharding struct {
+	Concurrency int  `yaml:"concurrency,omitempty"` // Maximum number of shards queried at the same time
+	Enabled     bool `yaml:"enabled,omitempty"`
+	ShardSize   int  `yaml:"shard_size,omitempty"` // Maximum number of apps (or workloads) per shard
+}
+
+// PrometheusQueryHints describes the query parameters understood by Prometheus-compatible backends (e.g. Thanos, Mimir).
+// Empty values are not sent, leaving the defaults of the backend.
+type PrometheusQueryHints struct {
+	Dedup               string            `yaml:"dedup,omitempty"`                 // Deduplicate the series of replicas: "true" or "false"
+	ExtraParams         map[string]string `yaml:"extra_params,omitempty"`          // Any other parameter sent with the queries
+	MaxSourceResolution string            `yaml:"max_source_resolution,omitempty"` // Coarsest downsampled resolution to read e.g. "5m", "1h" or "auto"
+	PartialResponse     string            `yaml:"partial_response,omitempty"`      // Return the available data when a store is unavailable: "true" or "false"
+}
+
+// OTelMetricsConfig describes how the metrics of an OpenTelemetry (OTLP) metrics store, queried through its
+// PromQL-compatible API, are named compared to the Prometheus names used by Kiali.
+type OTelMetricsConfig struct {
+	LabelNames   map[string]string `yaml:"label_names,omitempty"`   // Prometheus label name -> label name in the store e.g. "destination_workload": "destination.workload"
+	MetricNames  map[string]string `yaml:"metric_names,omitempty"`  // Prometheus metric name -> metric name in the store, for the metrics not only differing by the prefix
+	MetricPrefix string            `yaml:"metric_prefix,omitempty"` // Prefix of the metric names in the store e.g. the namespace of the collector exporter
+}
+
 // PrometheusConfig describes configuration of the Prometheus component
 type PrometheusConfig struct {
-	Auth            Auth              `yaml:"auth,omitempty"`
-	CacheDuration   int               `yaml:"cache_duration,omitempty"`   // Cache duration per query expressed in seconds
-	CacheEnabled    bool              `yaml:"cache_enabled,omitempty"`    // Enable cache for Prometheus queries
-	CacheExpiration int               `yaml:"cache_expiration,omitempty"` // Global cache expiration expressed in seconds
-	CustomHeaders   map[string]string `yaml:"custom_headers,omitempty"`
-	HealthCheckUrl  string            `yaml:"health_check_url,omitempty"`
-	IsCore          bool              `yaml:"is_core,omitempty"`
-	QueryScope      map[string]string `yaml:"query_scope,omitempty"`
-	ThanosProxy     ThanosProxy       `yaml:"thanos_proxy,omitempty"`
-	URL             string            `yaml:"url,omitempty"`
+	Auth                Auth                `yaml:"auth,omitempty"`
+	CacheDuration       int                 `yaml:"cache_duration,omitempty"`   // Cache duration per query expressed in seconds
+	CacheEnabled        bool                `yaml:"cache_enabled,omitempty"`    // Enable cache for Prometheus queries
+	CacheExpiration     int                 `yaml:"cache_expiration,omitempty"` // Global cache expiration expressed in seconds
+	CircuitBreaker      CircuitBreaker      `yaml:"circuit_breaker,omitempty"`
+	CustomHeaders       map[string]string   `yaml:"custom_headers,omitempty"`
+	GraphCache          GraphCache          `yaml:"graph_cache,omitempty"`
+	HealthCheckUrl      string              `yaml:"health_check_url,omitempty"`
+	HealthQueryBatching HealthQueryBatching `yaml:"health_query_batching,omitempty"`
+	HealthQuerySharding HealthQuerySharding `yaml:"health_query_sharding,omitempty"`
+	// HealthRecordingRules maps a rate interval (e.g. "5m") to the recording rule precomputing
+	// rate(istio_requests_total[<interval>]) without aggregating its labels, to be queried instead by the health.
+	// Only the health reads it, the other request rates are always computed from the raw counters.
+	HealthRecordingRules map[string]string    `yaml:"health_recording_rules,omitempty"`
+	IsCore               bool                 `yaml:"is_core,omitempty"`
+	OTel                 OTelMetricsConfig    `yaml:"otel,omitempty"`
+	Provider             MetricsProvider      `yaml:"provider,omitempty"` // Kind of metrics store: "prometheus" or "otel" (OpenTelemetry store with a PromQL-compatible API)
+	QueryHints           PrometheusQueryHints `yaml:"query_hints,omitempty"`
+	QueryScope           map[string]string    `yaml:"query_scope,omitempty"`
+	ThanosProxy          ThanosProxy          `yaml:"thanos_proxy,omitempty"`
+	URL                  string               `yaml:"url,omitempty"`
 }
 
 // CustomDashboardsConfig describes configuration specific to Custom Dashboards
 type CustomDashboardsConfig struct {
-	DiscoveryEnabled       string           `yaml:"discovery_enabled,omitempty"`
-	DiscoveryAutoThreshold int              `yaml:"discovery_auto_threshold,omitempty"`
-	Enabled                bool             `yaml:"enabled,omitempty"`
-	IsCore                 bool             `yaml:"is_core,omitempty"`
-	NamespaceLabel         string           `yaml:"namespace_label,omitempty"`
-	Prometheus             PrometheusConfig `yaml:"prometheus,omitempty"`
+	// ClusterPrometheus overrides the Prometheus used by the custom dashboards of a given cluster, keyed by cluster name.
+	ClusterPrometheus      map[string]PrometheusConfig `yaml:"cluster_prometheus,omitempty"`
+	DiscoveryEnabled       string                      `yaml:"discovery_enabled,omitempty"`
+	DiscoveryAutoThreshold int                         `yaml:"discovery_auto_threshold,omitempty"`
+	Enabled                bool                        `yaml:"enabled,omitempty"`
+	IsCore                 bool                        `yaml:"is_core,omitempty"`
+	NamespaceLabel         string                      `yaml:"namespace_label,omitempty"`
+	Prometheus             PrometheusConfig            `yaml:"prometheus,omitempty"`
+}
+
+// MetricsServerConfig describes configuration used to fetch the current CPU and memory usage of the pods from
+// the metrics.k8s.io API, served by metrics-server
+type MetricsServerConfig struct {
+	Enabled bool `yaml:"enabled"`
 }
 
 // GrafanaConfig describes configuration used for Grafana links
 type GrafanaConfig struct {
 	Auth           Auth                     `yaml:"auth"`
+	ClusterGrafana map[string]GrafanaConfig `yaml:"cluster_grafana,omitempty"` // Overrides the Grafana linked for a cluster, keyed by cluster name
+	DashboardTags  []string                 `yaml:"dashboard_tags,omitempty"`  // The dashboards with any of these tags are linked too
 	Dashboards     []GrafanaDashboardConfig `yaml:"dashboards"`
-	Enabled        bool                     `yaml:"enabled"` // Enable or disable Grafana support in Kiali
+	DatasourceUID  string                   `yaml:"datasource_uid,omitempty"` // Set as the datasource variable of the links
+	Enabled        bool                     `yaml:"enabled"`                  // Enable or disable Grafana support in Kiali
 	HealthCheckUrl string                   `yaml:"health_check_url,omitempty"`
 	InClusterURL   string                   `yaml:"in_cluster_url"`
 	IsCore         bool                     `yaml:"is_core,omitempty"`
@@ -250,44 +337,82 @@ type TempoConfig struct {
 
 // TracingConfig describes configuration used for tracing links
 type TracingConfig struct {
-	Auth                 Auth              `yaml:"auth"`
-	CustomHeaders        map[string]string `yaml:"custom_headers,omitempty"`
-	Enabled              bool              `yaml:"enabled"` // Enable Tracing in Kiali
-	HealthCheckUrl       string            `yaml:"health_check_url,omitempty"`
-	GrpcPort             int               `yaml:"grpc_port,omitempty"`
-	InClusterURL         string            `yaml:"in_cluster_url"`
-	IsCore               bool              `yaml:"is_core,omitempty"`
-	Provider             TracingProvider   `yaml:"provider,omitempty"` // jaeger | tempo
-	TempoConfig          TempoConfig       `yaml:"tempo_config,omitempty"`
-	NamespaceSelector    bool              `yaml:"namespace_selector"`
-	QueryScope           map[string]string `yaml:"query_scope,omitempty"`
-	QueryTimeout         int               `yaml:"query_timeout,omitempty"`
-	URL                  string            `yaml:"url"`
-	UseGRPC              bool              `yaml:"use_grpc"`
-	WhiteListIstioSystem []string          `yaml:"whitelist_istio_system"`
-}
-
-// RegistryConfig contains configuration for connecting to an external istiod.
-// This is used when Kiali should connect to the istiod via a url instead of port forwarding.
+	Auth                 Auth                     `yaml:"auth"`
+	ClusterTracing       map[string]TracingConfig `yaml:"cluster_tracing,omitempty"` // Overrides the tracing backend of a cluster whose spans go to another collector, keyed by cluster name
+	CustomHeaders        map[string]string        `yaml:"custom_headers,omitempty"`
+	Enabled              bool                     `yaml:"enabled"` // Enable Tracing in Kiali
+	HealthCheckUrl       string                   `yaml:"health_check_url,omitempty"`
+	GrpcPort             int                      `yaml:"grpc_port,omitempty"`
+	InClusterURL         string                   `yaml:"in_cluster_url"`
+	IsCore               bool                     `yaml:"is_core,omitempty"`
+	Provider             TracingProvider          `yaml:"provider,omitempty"` // jaeger | tempo
+	TempoConfig          TempoConfig              `yaml:"tempo_config,omitempty"`
+	NamespaceSelector    bool                     `yaml:"namespace_selector"`
+	QueryScope           map[string]string        `yaml:"query_scope,omitempty"`
+	QueryTimeout         int                      `yaml:"query_timeout,omitempty"`
+	URL                  string                   `yaml:"url"`
+	UseGRPC              bool                     `yaml:"use_grpc"`
+	WhiteListIstioSystem []string                 `yaml:"whitelist_istio_system"`
+}
+
+// Istiod debug access modes
+const (
+	IstiodAccessModePortForward = "portForward"
+	IstiodAccessModeService     = "service"
+	IstiodAccessModeURL         = "url"
+)
+
+// RegistryConfig defines how Kiali reaches the debug endpoints of istiod.
 type RegistryConfig struct {
-	IstiodURL string `yaml:"istiod_url"`
-	// TODO: Support auth options
+	// AccessMode is one of portForward, service or url. portForward proxies the requests to every istiod pod through
+	// the kube API server. service sends them to the istiod service of each controlplane and url to IstiodURL.
+	// When not set, url is used if IstiodURL is set and portForward otherwise.
+	AccessMode string `yaml:"access_mode,omitempty"`
+	// Auth is used by the service and url access modes.
+	Auth Auth `yaml:"auth,omitempty"`
+	// ClientCertFile and ClientKeyFile are the client certificate presented to istiod for mTLS in the service and url access modes.
+	ClientCertFile string `yaml:"client_cert_file,omitempty"`
+	ClientKeyFile  string `yaml:"client_key_file,omitempty"`
+	IstiodURL      string `yaml:"istiod_url"`
+}
+
+// GetAccessMode returns the configured access mode or the one implied by IstiodURL.
+func (rc *RegistryConfig) GetAccessMode() string {
+	if rc == nil {
+		return IstiodAccessModePortForward
+	}
+	if rc.AccessMode != "" {
+		return rc.AccessMode
+	}
+	if rc.IstiodURL != "" {
+		return IstiodAccessModeURL
+	}
+	return IstiodAccessModePortForward
 }
 
 // IstioConfig describes configuration used for istio links
 type IstioConfig struct {
-	ComponentStatuses                 ComponentStatuses   `yaml:"component_status,omitempty"`
-	ConfigMapName                     string              `yaml:"config_map_name,omitempty"`
-	EnvoyAdminLocalPort               int                 `yaml:"envoy_admin_local_port,omitempty"`
-	GatewayAPIClasses                 []GatewayAPIClass   `yaml:"gateway_api_classes,omitempty"`
+	ComponentStatuses ComponentStatuses `yaml:"component_status,omitempty"`
+	ConfigMapName     string            `yaml:"config_map_name,omitempty"`
+	// EastWestGatewaySelector is the label selector of the services of the east-west gateways, which expose the
+	// services of their cluster to the other networks of the mesh.
+	EastWestGatewaySelector string            `yaml:"east_west_gateway_selector,omitempty"`
+	EnvoyAdminLocalPort     int               `yaml:"envoy_admin_local_port,omitempty"`
+	GatewayAPIClasses       []GatewayAPIClass `yaml:"gateway_api_classes,omitempty"`
+	// HostCatalogEnabled builds the registry services from the Services, ServiceEntries and WorkloadEntries of the
+	// clusters instead of scraping istiod's /debug/registryz, which recent Istio versions don't serve anymore.
+	HostCatalogEnabled                bool                `yaml:"host_catalog_enabled,omitempty"`
 	IstioAPIEnabled                   bool                `yaml:"istio_api_enabled"`
 	IstioCanaryRevision               IstioCanaryRevision `yaml:"istio_canary_revision,omitempty"`
 	IstioIdentityDomain               string              `yaml:"istio_identity_domain,omitempty"`
 	IstioInjectionAnnotation          string              `yaml:"istio_injection_annotation,omitempty"`
 	IstioSidecarInjectorConfigMapName string              `yaml:"istio_sidecar_injector_config_map_name,omitempty"`
 	IstioSidecarAnnotation            string              `yaml:"istio_sidecar_annotation,omitempty"`
-	IstiodDeploymentName              string              `yaml:"istiod_deployment_name,omitempty"`
-	IstiodPodMonitoringPort           int                 `yaml:"istiod_pod_monitoring_port,omitempty"`
+	// IstiodConfigDistributionEnabled enables scraping istiod's /debug/config_distribution endpoint
+	// for the per-resource sync state. Requires PILOT_ENABLE_CONFIG_DISTRIBUTION_TRACKING on istiod.
+	IstiodConfigDistributionEnabled bool   `yaml:"istiod_config_distribution_enabled,omitempty"`
+	IstiodDeploymentName            string `yaml:"istiod_deployment_name,omitempty"`
+	IstiodPodMonitoringPort         int    `yaml:"istiod_pod_monitoring_port,omitempty"`
 	// IstiodPollingIntervalSeconds is how often in seconds Kiali will poll istiod(s) for
 	// proxy status and registry services. Polling is not performed if IstioAPIEnabled is false.
 	IstiodPollingIntervalSeconds int             `yaml:"istiod_polling_interval_seconds,omitempty"`
@@ -302,8 +427,13 @@ type IstioCanaryRevision struct {
 }
 
 type ComponentStatuses struct {
-	Enabled    bool              `yaml:"enabled,omitempty"`
-	Components []ComponentStatus `yaml:"components,omitempty"`
+	// AddonLatencyThreshold is the latency, in milliseconds, above which a reachable addon is degraded.
+	// Zero disables it.
+	AddonLatencyThreshold int `yaml:"addon_latency_threshold,omitempty"`
+	// AddonStatusTTL is the duration, in seconds, the reachability probes of the addons are cached.
+	AddonStatusTTL int               `yaml:"addon_status_ttl,omitempty"`
+	Enabled        bool              `yaml:"enabled,omitempty"`
+	Components     []ComponentStatus `yaml:"components,omitempty"`
 }
 
 type ComponentStatus struct {
@@ -325,6 +455,7 @@ type ExternalServices struct {
 	Istio            IstioConfig            `yaml:"istio,omitempty"`
 	Prometheus       PrometheusConfig       `yaml:"prometheus,omitempty"`
 	CustomDashboards CustomDashboardsConfig `yaml:"custom_dashboards,omitempty"`
+	MetricsServer    MetricsServerConfig    `yaml:"metrics_server,omitempty"`
 	Tracing          TracingConfig          `yaml:"tracing,omitempty"`
 }
 
@@ -367,6 +498,9 @@ type KubernetesConfig struct {
 	// Kiali cache list of namespaces per user, this is typically short lived cache compared with the duration of the
 	// namespace cache defined by previous CacheDuration parameter
 	CacheTokenNamespaceDuration int `yaml:"cache_token_namespace_duration,omitempty"`
+	// ClusterClients tunes the clients of some clusters, keyed by cluster name. A slow managed cluster may need other
+	// settings than the cluster Kiali is running in. The settings not set for a cluster default to the ones above.
+	ClusterClients map[string]KubernetesClientConfig `yaml:"cluster_clients,omitempty"`
 	// ClusterName is the name of the kubernetes cluster that Kiali is running in.
 	// If empty, then it will default to 'Kubernetes'.
 	ClusterName string `yaml:"cluster_name,omitempty"`
@@ -378,6 +512,28 @@ type KubernetesConfig struct {
 	QPS              float32  `yaml:"qps,omitempty"`
 }
 
+// KubernetesClientConfig tunes the clients of the user and of the Kiali service account for a cluster
+type KubernetesClientConfig struct {
+	Burst int `yaml:"burst,omitempty"`
+	// ProxyURL is the URL of the HTTP proxy to reach the API server of the cluster
+	ProxyURL string  `yaml:"proxy_url,omitempty"`
+	QPS      float32 `yaml:"qps,omitempty"`
+	// Timeout of the requests to the API server, expressed in seconds. Zero means no timeout.
+	Timeout int `yaml:"timeout,omitempty"`
+}
+
+// ClientConfig returns the tuning of the clients of a cluster, the qps and burst default to the ones of all the clusters
+func (kc KubernetesConfig) ClientConfig(cluster string) KubernetesClientConfig {
+	clientConfig := kc.ClusterClients[cluster]
+	if clientConfig.QPS == 0 {
+		clientConfig.QPS = kc.QPS
+	}
+	if clientConfig.Burst == 0 {
+		clientConfig.Burst = kc.Burst
+	}
+	return clientConfig
+}
+
 // ApiConfig contains API specific configuration.
 type ApiConfig struct {
 	Namespaces ApiNamespacesConfig
@@ -393,9 +549,16 @@ type ApiNamespacesConfig struct {
 
 // AuthConfig provides details on how users are to authenticate
 type AuthConfig struct {
-	OpenId    OpenIdConfig    `yaml:"openid,omitempty"`
-	OpenShift OpenShiftConfig `yaml:"openshift,omitempty"`
-	Strategy  string          `yaml:"strategy,omitempty"`
+	Impersonation ImpersonationConfig `yaml:"impersonation,omitempty"`
+	OpenId        OpenIdConfig        `yaml:"openid,omitempty"`
+	OpenShift     OpenShiftConfig     `yaml:"openshift,omitempty"`
+	Strategy      string              `yaml:"strategy,omitempty"`
+}
+
+// ImpersonationConfig lets the users allowed to impersonate other users and groups in the cluster (typically the
+// cluster admins) see Kiali as another user would see it, to troubleshoot its access
+type ImpersonationConfig struct {
+	Enabled bool `yaml:"enabled"`
 }
 
 // OpenShiftConfig contains specific configuration for authentication when on OpenShift
@@ -403,24 +566,66 @@ type OpenShiftConfig struct {
 	CAFile string `yaml:"ca_file,omitempty"`
 }
 
-// OpenIdConfig contains specific configuration for authentication using an OpenID provider
+// OpenIdConfig contains specific configuration for authentication using an OpenID provider.
+// With refresh_tokens, the tokens of the sessions are kept in the memory of the Kiali server and the session
+// cookie only carries the id of the session. The sessions are not shared between replicas and are lost when
+// Kiali restarts, the users then having to log in again: only enable it with a single replica of Kiali, or
+// with sticky sessions on the Kiali route or ingress.
 type OpenIdConfig struct {
-	AdditionalRequestParams map[string]string `yaml:"additional_request_params,omitempty"`
-	AllowedDomains          []string          `yaml:"allowed_domains,omitempty"`
-	ApiProxy                string            `yaml:"api_proxy,omitempty"`
-	ApiProxyCAData          string            `yaml:"api_proxy_ca_data,omitempty"`
-	ApiToken                string            `yaml:"api_token,omitempty"`
-	AuthenticationTimeout   int               `yaml:"authentication_timeout,omitempty"`
-	AuthorizationEndpoint   string            `yaml:"authorization_endpoint,omitempty"`
-	ClientId                string            `yaml:"client_id,omitempty"`
-	ClientSecret            string            `yaml:"client_secret,omitempty"`
-	DisableRBAC             bool              `yaml:"disable_rbac,omitempty"`
-	HTTPProxy               string            `yaml:"http_proxy,omitempty"`
-	HTTPSProxy              string            `yaml:"https_proxy,omitempty"`
-	InsecureSkipVerifyTLS   bool              `yaml:"insecure_skip_verify_tls,omitempty"`
-	IssuerUri               string            `yaml:"issuer_uri,omitempty"`
-	Scopes                  []string          `yaml:"scopes,omitempty"`
-	UsernameClaim           string            `yaml:"username_claim,omitempty"`
+	AdditionalIssuers       []OpenIdIssuerConfig `yaml:"additional_issuers,omitempty"`
+	AdditionalRequestParams map[string]string    `yaml:"additional_request_params,omitempty"`
+	AllowedDomains          []string             `yaml:"allowed_domains,omitempty"`
+	ApiProxy                string               `yaml:"api_proxy,omitempty"`
+	ApiProxyCAData          string               `yaml:"api_proxy_ca_data,omitempty"`
+	ApiToken                string               `yaml:"api_token,omitempty"`
+	AuthenticationTimeout   int                  `yaml:"authentication_timeout,omitempty"`
+	AuthorizationEndpoint   string               `yaml:"authorization_endpoint,omitempty"`
+	ClientId                string               `yaml:"client_id,omitempty"`
+	ClientSecret            string               `yaml:"client_secret,omitempty"`
+	DisableRBAC             bool                 `yaml:"disable_rbac,omitempty"`
+	HTTPProxy               string               `yaml:"http_proxy,omitempty"`
+	HTTPSProxy              string               `yaml:"https_proxy,omitempty"`
+	InsecureSkipVerifyTLS   bool                 `yaml:"insecure_skip_verify_tls,omitempty"`
+	IssuerUri               string               `yaml:"issuer_uri,omitempty"`
+	RefreshTokens           bool                 `yaml:"refresh_tokens,omitempty"`
+	Scopes                  []string             `yaml:"scopes,omitempty"`
+	UsernameClaim           string               `yaml:"username_claim,omitempty"`
+}
+
+// OpenIdIssuerConfig is an OpenID provider trusted in addition to the one of the issuer_uri, for the
+// clusters whose API server is integrated with a different provider. The token of a user is only sent to
+// the clusters mapped to its issuer, the other clusters are accessed with the Kiali service account
+// impersonating the user.
+type OpenIdIssuerConfig struct {
+	ClientId     string   `yaml:"client_id,omitempty"`
+	ClientSecret string   `yaml:"client_secret,omitempty"`
+	Clusters     []string `yaml:"clusters,omitempty"`
+	IssuerUri    string   `yaml:"issuer_uri,omitempty"`
+}
+
+// GetIssuer returns the config of a trusted issuer, the issuer_uri being the default issuer.
+func (oc OpenIdConfig) GetIssuer(issuerUri string) (OpenIdIssuerConfig, bool) {
+	if issuerUri == "" || issuerUri == oc.IssuerUri {
+		return OpenIdIssuerConfig{ClientId: oc.ClientId, ClientSecret: oc.ClientSecret, IssuerUri: oc.IssuerUri}, true
+	}
+	for _, issuer := range oc.AdditionalIssuers {
+		if issuer.IssuerUri == issuerUri {
+			return issuer, true
+		}
+	}
+	return OpenIdIssuerConfig{}, false
+}
+
+// IssuerForCluster returns the issuer trusted by the API server of the cluster.
+func (oc OpenIdConfig) IssuerForCluster(cluster string) string {
+	for _, issuer := range oc.AdditionalIssuers {
+		for _, c := range issuer.Clusters {
+			if c == cluster {
+				return issuer.IssuerUri
+			}
+		}
+	}
+	return oc.IssuerUri
 }
 
 // DeploymentConfig provides details on how Kiali was deployed.
@@ -428,8 +633,10 @@ type DeploymentConfig struct {
 	AccessibleNamespaces []string `yaml:"accessible_namespaces"`
 	ClusterWideAccess    bool     `yaml:"cluster_wide_access,omitempty"`
 	InstanceName         string   `yaml:"instance_name"`
-	Namespace            string   `yaml:"namespace,omitempty"` // Kiali deployment namespace
-	ViewOnlyMode         bool     `yaml:"view_only_mode,omitempty"`
+	// LogLevel overrides the LOG_LEVEL environment variable. Changes are applied without restarting the pod.
+	LogLevel     string `yaml:"log_level,omitempty"`
+	Namespace    string `yaml:"namespace,omitempty"` // Kiali deployment namespace
+	ViewOnlyMode bool   `yaml:"view_only_mode,omitempty"`
 	// RemoteSecretPath is used to identify the remote cluster Kiali will connect to as its "local cluster".
 	// This is to support installing Kiali in the control plane, but observing only the data plane in the remote cluster.
 	// Experimental feature. See: https://github.com/kiali/kiali/issues/3002
@@ -518,14 +725,18 @@ type UIDefaults struct {
 
 // Validations defines default settings configured for the Validations subsystem
 type Validations struct {
-	Ignore                   []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
-	SkipWildcardGatewayHosts bool     `yaml:"skip_wildcard_gateway_hosts,omitempty"`
+	Ignore []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+	// ProbeJwksUri enables a HEAD request to the jwksUri of the RequestAuthentications to report the unreachable ones.
+	ProbeJwksUri             bool `yaml:"probe_jwks_uri,omitempty"`
+	SkipWildcardGatewayHosts bool `yaml:"skip_wildcard_gateway_hosts,omitempty"`
 }
 
 // CertificatesInformationIndicators defines configuration to enable the feature and to grant read permissions to a list of secrets
 type CertificatesInformationIndicators struct {
-	Enabled bool     `yaml:"enabled,omitempty" json:"enabled"`
-	Secrets []string `yaml:"secrets,omitempty" json:"secrets,omitempty"`
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled"`
+	// ExpirationWarningDays is how many days before the mesh root certificate expires to start warning about it.
+	ExpirationWarningDays int      `yaml:"expiration_warning_days,omitempty" json:"expirationWarningDays,omitempty"`
+	Secrets               []string `yaml:"secrets,omitempty" json:"secrets,omitempty"`
 }
 
 // Clustering defines configuration around multi-cluster functionality.
@@ -550,6 +761,13 @@ type FeatureFlagClustering struct {
 	EnableExecProvider bool `yaml:"enable_exec_provider,omitempty" json:"enable_exec_provider"`
 }
 
+// FeatureFlagGitOps defines how Kiali handles the resources managed by a GitOps tool (Argo CD, Flux).
+// When edits are blocked, Kiali refuses to patch or delete a managed resource, which would otherwise be
+// reverted by the tool or drift from its Git repository.
+type FeatureFlagGitOps struct {
+	BlockManagedEdits bool `yaml:"block_managed_edits,omitempty" json:"blockManagedEdits"`
+}
+
 // KialiURL defines a cluster name, namespace and instance name properties to URL.
 type KialiURL struct {
 	ClusterName  string `yaml:"cluster_name,omitempty"`
@@ -563,6 +781,7 @@ type KialiFeatureFlags struct {
 	CertificatesInformationIndicators CertificatesInformationIndicators `yaml:"certificates_information_indicators,omitempty" json:"certificatesInformationIndicators"`
 	Clustering                        FeatureFlagClustering             `yaml:"clustering,omitempty" json:"clustering,omitempty"`
 	DisabledFeatures                  []string                          `yaml:"disabled_features,omitempty" json:"disabledFeatures,omitempty"`
+	GitOps                            FeatureFlagGitOps                 `yaml:"gitops,omitempty" json:"gitops"`
 	IstioAnnotationAction             bool                              `yaml:"istio_annotation_action,omitempty" json:"istioAnnotationAction"`
 	IstioInjectionAction              bool                              `yaml:"istio_injection_action,omitempty" json:"istioInjectionAction"`
 	IstioUpgradeAction                bool                              `yaml:"istio_upgrade_action,omitempty" json:"istioUpgradeAction"`
@@ -570,6 +789,36 @@ type KialiFeatureFlags struct {
 	Validations                       Validations                       `yaml:"validations,omitempty" json:"validations,omitempty"`
 }
 
+// Notifications describes the targets notified of the changes of the Istio and Gateway API config, as seen by the
+// informers of the cache: who changed which object, when, and a summary of the changed fields.
+type Notifications struct {
+	// DedupInterval is the number of seconds during which the further changes of a notified object are not notified
+	DedupInterval int  `yaml:"dedup_interval,omitempty"`
+	Enabled       bool `yaml:"enabled,omitempty"`
+	// Kinds of the notified objects, e.g. VirtualService. All the Istio and Gateway API kinds when empty
+	Kinds []string `yaml:"kinds,omitempty"`
+	// Namespaces of the notified objects, all the namespaces when empty
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	// RateLimit is the maximum number of notifications sent to a target per minute, the others are dropped
+	RateLimit int                  `yaml:"rate_limit,omitempty"`
+	Targets   []NotificationTarget `yaml:"targets,omitempty"`
+}
+
+const (
+	NotificationTargetSlack   = "slack"
+	NotificationTargetWebhook = "webhook"
+)
+
+// NotificationTarget is a Slack incoming webhook, posted a message, or a generic webhook, posted the JSON change.
+type NotificationTarget struct {
+	// Headers of the requests, e.g. an Authorization header for a webhook
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Name    string            `yaml:"name,omitempty"`
+	// Type of the target: slack or webhook
+	Type string `yaml:"type,omitempty"`
+	URL  string `yaml:"url,omitempty"`
+}
+
 // Tolerance config
 type Tolerance struct {
 	Code      string  `yaml:"code,omitempty" json:"code"`
@@ -587,16 +836,69 @@ type Rate struct {
 	Tolerance []Tolerance `yaml:"tolerance,omitempty" json:"tolerance"`
 }
 
+// HealthThreshold are the error rate percentages from which the requests are degraded or failing.
+// A threshold without values is unset.
+type HealthThreshold struct {
+	Degraded float32 `yaml:"degraded,omitempty" json:"degraded"`
+	Failure  float32 `yaml:"failure,omitempty" json:"failure"`
+}
+
+// IsSet returns true when the threshold has any value.
+func (ht HealthThreshold) IsSet() bool {
+	return ht.Degraded > 0 || ht.Failure > 0
+}
+
+// HealthThresholds are the error rate thresholds per protocol
+type HealthThresholds struct {
+	GRPC HealthThreshold `yaml:"grpc,omitempty" json:"grpc"`
+	HTTP HealthThreshold `yaml:"http,omitempty" json:"http"`
+	TCP  HealthThreshold `yaml:"tcp,omitempty" json:"tcp"`
+}
+
+// ForProtocol returns the threshold of the protocol: grpc, http or tcp.
+func (hts HealthThresholds) ForProtocol(protocol string) HealthThreshold {
+	switch protocol {
+	case "grpc":
+		return hts.GRPC
+	case "http":
+		return hts.HTTP
+	case "tcp":
+		return hts.TCP
+	default:
+		return HealthThreshold{}
+	}
+}
+
 // HealthConfig rates
 type HealthConfig struct {
 	Rate []Rate `yaml:"rate,omitempty" json:"rate,omitempty"`
+
+	// Thresholds are the default error rate thresholds per protocol.
+	Thresholds HealthThresholds `yaml:"thresholds,omitempty" json:"thresholds"`
+
+	// NamespaceThresholds override the default thresholds for the requests of a namespace, keyed by namespace name.
+	// Rate annotations of services and workloads take precedence over both.
+	NamespaceThresholds map[string]HealthThresholds `yaml:"namespace_thresholds,omitempty" json:"namespaceThresholds,omitempty"`
 }
 
 // Profiler provides settings about the profiler that can be used to debug the Kiali server internals.
+// When enabled, the pprof endpoints and the runtime, goroutine and cache diagnostics are served under /debug to the
+// users allowed to exec into the Kiali pods.
 type Profiler struct {
 	Enabled bool `yaml:"enabled,omitempty"`
 }
 
+// RateLimit limits the API requests of each user, identified by its token, so that the refreshes of many
+// dashboards do not overload Prometheus and the Kubernetes API servers. The requests over the limits get a 429.
+type RateLimit struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// The sustained requests per second of a user and how many more requests a user can burst
+	RequestsPerSecond float64 `yaml:"requests_per_second,omitempty"`
+	Burst             int     `yaml:"burst,omitempty"`
+	// The concurrent expensive requests (graph, validations, metrics) of a user
+	MaxConcurrentExpensive int `yaml:"max_concurrent_expensive,omitempty"`
+}
+
 // Config defines full YAML configuration.
 type Config struct {
 	AdditionalDisplayDetails []AdditionalDisplayItem             `yaml:"additional_display_details,omitempty"`
@@ -615,6 +917,7 @@ type Config struct {
 	KialiFeatureFlags        KialiFeatureFlags                   `yaml:"kiali_feature_flags,omitempty"`
 	KubernetesConfig         KubernetesConfig                    `yaml:"kubernetes_config,omitempty"`
 	LoginToken               LoginToken                          `yaml:"login_token,omitempty"`
+	Notifications            Notifications                       `yaml:"notifications,omitempty"`
 	Server                   Server                              `yaml:",omitempty"`
 }
 
@@ -637,8 +940,12 @@ func NewConfig() (c *Config) {
 			},
 		},
 		Auth: AuthConfig{
+			Impersonation: ImpersonationConfig{
+				Enabled: false,
+			},
 			Strategy: "token",
 			OpenId: OpenIdConfig{
+				AdditionalIssuers:       []OpenIdIssuerConfig{},
 				AdditionalRequestParams: map[string]string{},
 				AllowedDomains:          []string{},
 				ApiProxy:                "",
@@ -651,6 +958,7 @@ func NewConfig() (c *Config) {
 				DisableRBAC:             false,
 				InsecureSkipVerifyTLS:   false,
 				IssuerUri:               "",
+				RefreshTokens:           false,
 				Scopes:                  []string{"openid", "profile", "email"},
 				UsernameClaim:           "sub",
 			},
@@ -687,7 +995,9 @@ func NewConfig() (c *Config) {
 			},
 			Istio: IstioConfig{
 				ComponentStatuses: ComponentStatuses{
-					Enabled: true,
+					AddonLatencyThreshold: 500,
+					AddonStatusTTL:        30,
+					Enabled:               true,
 					Components: []ComponentStatus{
 						{
 							AppLabel: "istio-egressgateway",
@@ -707,6 +1017,7 @@ func NewConfig() (c *Config) {
 					},
 				},
 				ConfigMapName:                     "istio",
+				EastWestGatewaySelector:           "istio=eastwestgateway",
 				EnvoyAdminLocalPort:               15000,
 				IstioAPIEnabled:                   true,
 				IstioIdentityDomain:               "svc.cluster.local",
@@ -729,8 +1040,36 @@ func NewConfig() (c *Config) {
 				CacheEnabled:  true,
 				// Prom Cache expires and it forces to repopulate cache
 				CacheExpiration: 300,
-				CustomHeaders:   map[string]string{},
-				QueryScope:      map[string]string{},
+				CircuitBreaker: CircuitBreaker{
+					Enabled:          true,
+					FailureThreshold: 3,
+					ProbeInterval:    30,
+				},
+				CustomHeaders: map[string]string{},
+				GraphCache: GraphCache{
+					Duration:      30,
+					Enabled:       false,
+					RefreshWindow: 120,
+				},
+				HealthQueryBatching: HealthQueryBatching{
+					BatchSize: 25,
+					Enabled:   true,
+				},
+				HealthQuerySharding: HealthQuerySharding{
+					Concurrency: 4,
+					Enabled:     false,
+					ShardSize:   20,
+				},
+				HealthRecordingRules: map[string]string{},
+				OTel: OTelMetricsConfig{
+					LabelNames:  map[string]string{},
+					MetricNames: map[string]string{},
+				},
+				Provider: PrometheusProvider,
+				QueryHints: PrometheusQueryHints{
+					ExtraParams: map[string]string{},
+				},
+				QueryScope: map[string]string{},
 				ThanosProxy: ThanosProxy{
 					Enabled:         false,
 					RetentionPeriod: "7d",
@@ -757,6 +1096,13 @@ func NewConfig() (c *Config) {
 				WhiteListIstioSystem: []string{"jaeger-query", "istio-ingressgateway"},
 			},
 		},
+		HealthConfig: HealthConfig{
+			Thresholds: HealthThresholds{
+				GRPC: HealthThreshold{Degraded: 10, Failure: 20},
+				HTTP: HealthThreshold{Degraded: 10, Failure: 20},
+				TCP:  HealthThreshold{Degraded: 10, Failure: 20},
+			},
+		},
 		IstioLabels: IstioLabels{
 			AmbientNamespaceLabel:      "istio.io/dataplane-mode",
 			AmbientNamespaceLabelValue: "ambient",
@@ -769,13 +1115,17 @@ func NewConfig() (c *Config) {
 		},
 		KialiFeatureFlags: KialiFeatureFlags{
 			CertificatesInformationIndicators: CertificatesInformationIndicators{
-				Enabled: true,
-				Secrets: []string{"cacerts", "istio-ca-secret"},
+				Enabled:               true,
+				ExpirationWarningDays: 30,
+				Secrets:               []string{"cacerts", "istio-ca-secret"},
 			},
 			Clustering: FeatureFlagClustering{
 				EnableExecProvider: false,
 			},
-			DisabledFeatures:      []string{},
+			DisabledFeatures: []string{},
+			GitOps: FeatureFlagGitOps{
+				BlockManagedEdits: false,
+			},
 			IstioAnnotationAction: true,
 			IstioInjectionAction:  true,
 			IstioUpgradeAction:    false,
@@ -863,6 +1213,7 @@ func NewConfig() (c *Config) {
 			Burst:                       200,
 			CacheDuration:               5 * 60,
 			CacheTokenNamespaceDuration: 10,
+			ClusterClients:              map[string]KubernetesClientConfig{},
 			ClusterName:                 "", // leave this unset as a flag that we need to fetch the information
 			ExcludeWorkloads:            []string{"CronJob", "DeploymentConfig", "Job", "ReplicationController"},
 			QPS:                         175,
@@ -871,6 +1222,14 @@ func NewConfig() (c *Config) {
 			ExpirationSeconds: 24 * 3600,
 			SigningKey:        "kiali",
 		},
+		Notifications: Notifications{
+			DedupInterval: 60,
+			Enabled:       false,
+			Kinds:         []string{},
+			Namespaces:    []string{},
+			RateLimit:     30,
+			Targets:       []NotificationTarget{},
+		},
 		Server: Server{
 			AuditLog:    true,
 			GzipEnabled: true,
@@ -893,7 +1252,13 @@ func NewConfig() (c *Config) {
 					SamplingRate: 0.5,
 				},
 			},
-			Port:                       20001,
+			Port: 20001,
+			RateLimit: RateLimit{
+				Enabled:                false,
+				RequestsPerSecond:      10,
+				Burst:                  50,
+				MaxConcurrentExpensive: 5,
+			},
 			StaticContentRootDirectory: "/opt/kiali/console",
 			WebFQDN:                    "",
 			WebRoot:                    "/",
@@ -989,9 +1354,73 @@ func (conf Config) Obfuscate() (obf Config) {
 	obf.ExternalServices.Grafana.Auth.Obfuscate()
 	obf.ExternalServices.Prometheus.Auth.Obfuscate()
 	obf.ExternalServices.Tracing.Auth.Obfuscate()
+	if len(obf.ExternalServices.Tracing.ClusterTracing) > 0 {
+		clusterTracing := make(map[string]TracingConfig, len(obf.ExternalServices.Tracing.ClusterTracing))
+		for cluster, tracing := range obf.ExternalServices.Tracing.ClusterTracing {
+			tracing.Auth.Obfuscate()
+			clusterTracing[cluster] = tracing
+		}
+		obf.ExternalServices.Tracing.ClusterTracing = clusterTracing
+	}
+	if len(obf.ExternalServices.Grafana.ClusterGrafana) > 0 {
+		clusterGrafana := make(map[string]GrafanaConfig, len(obf.ExternalServices.Grafana.ClusterGrafana))
+		for cluster, grafana := range obf.ExternalServices.Grafana.ClusterGrafana {
+			grafana.Auth.Obfuscate()
+			clusterGrafana[cluster] = grafana
+		}
+		obf.ExternalServices.Grafana.ClusterGrafana = clusterGrafana
+	}
+	if len(obf.ExternalServices.CustomDashboards.ClusterPrometheus) > 0 {
+		clusterProm := make(map[string]PrometheusConfig, len(obf.ExternalServices.CustomDashboards.ClusterPrometheus))
+		for cluster, prom := range obf.ExternalServices.CustomDashboards.ClusterPrometheus {
+			prom.Auth.Obfuscate()
+			clusterProm[cluster] = prom
+		}
+		obf.ExternalServices.CustomDashboards.ClusterPrometheus = clusterProm
+	}
+	if len(obf.KubernetesConfig.ClusterClients) > 0 {
+		clusterClients := make(map[string]KubernetesClientConfig, len(obf.KubernetesConfig.ClusterClients))
+		for cluster, clientConfig := range obf.KubernetesConfig.ClusterClients {
+			// The url of the proxy may hold its credentials
+			if proxyURL, err := url.Parse(clientConfig.ProxyURL); err == nil {
+				clientConfig.ProxyURL = proxyURL.Redacted()
+			}
+			clusterClients[cluster] = clientConfig
+		}
+		obf.KubernetesConfig.ClusterClients = clusterClients
+	}
+	if obf.ExternalServices.Istio.Registry != nil {
+		registry := *obf.ExternalServices.Istio.Registry
+		registry.Auth.Obfuscate()
+		obf.ExternalServices.Istio.Registry = &registry
+	}
+	if len(obf.Notifications.Targets) > 0 {
+		// The urls of the webhooks and the headers are credentials
+		targets := make([]NotificationTarget, len(obf.Notifications.Targets))
+		for i, target := range obf.Notifications.Targets {
+			target.URL = "xxx"
+			if len(target.Headers) > 0 {
+				headers := make(map[string]string, len(target.Headers))
+				for name := range target.Headers {
+					headers[name] = "xxx"
+				}
+				target.Headers = headers
+			}
+			targets[i] = target
+		}
+		obf.Notifications.Targets = targets
+	}
 	obf.Identity.Obfuscate()
 	obf.LoginToken.Obfuscate()
 	obf.Auth.OpenId.ClientSecret = "xxx"
+	if len(obf.Auth.OpenId.AdditionalIssuers) > 0 {
+		issuers := make([]OpenIdIssuerConfig, len(obf.Auth.OpenId.AdditionalIssuers))
+		copy(issuers, obf.Auth.OpenId.AdditionalIssuers)
+		for i := range issuers {
+			issuers[i].ClientSecret = "xxx"
+		}
+		obf.Auth.OpenId.AdditionalIssuers = issuers
+	}
 	return
 }
 
@@ -1171,8 +1600,12 @@ func IsRootNamespace(namespace string) bool {
 
 // IsFeatureDisabled will return true if the named feature is to be disabled.
 func IsFeatureDisabled(featureName FeatureName) bool {
-	cfg := Get()
-	for _, f := range cfg.KialiFeatureFlags.DisabledFeatures {
+	return Get().KialiFeatureFlags.IsFeatureDisabled(featureName)
+}
+
+// IsFeatureDisabled will return true if the named feature is in the disabled features of the flags.
+func (kff KialiFeatureFlags) IsFeatureDisabled(featureName FeatureName) bool {
+	for _, f := range kff.DisabledFeatures {
 		if f == string(featureName) {
 			return true
 		}
@@ -1225,6 +1658,50 @@ func Validate(cfg Config) error {
 		return fmt.Errorf("Invalid authentication strategy [%v]", auth.Strategy)
 	}
 
+	// Impersonation needs the token of the user, to check that the user is allowed to impersonate
+	if auth.Impersonation.Enabled {
+		if auth.Strategy == AuthStrategyAnonymous || auth.Strategy == AuthStrategyHeader ||
+			(auth.Strategy == AuthStrategyOpenId && auth.OpenId.DisableRBAC) {
+			return fmt.Errorf("error in configuration options for the auth impersonation. Impersonation is not supported with the [%s] strategy, or with openid when RBAC is disabled", auth.Strategy)
+		}
+	}
+
+	// Check the additional OpenID issuers, a cluster trusts a single issuer
+	issuerOfCluster := map[string]string{}
+	for _, issuer := range auth.OpenId.AdditionalIssuers {
+		if issuer.IssuerUri == "" || issuer.ClientId == "" {
+			return fmt.Errorf("error in configuration options for the openid additional issuers. The issuer uri and the client id are required")
+		}
+		for _, cluster := range issuer.Clusters {
+			if other, found := issuerOfCluster[cluster]; found {
+				return fmt.Errorf("error in configuration options for the openid additional issuers. Cluster [%s] is mapped to issuers [%s] and [%s]", cluster, other, issuer.IssuerUri)
+			}
+			issuerOfCluster[cluster] = issuer.IssuerUri
+		}
+	}
+
+	// Check the tuning of the clients of the clusters
+	for cluster, clientConfig := range cfg.KubernetesConfig.ClusterClients {
+		if clientConfig.QPS < 0 || clientConfig.Burst < 0 || clientConfig.Timeout < 0 {
+			return fmt.Errorf("error in configuration options for the clients of cluster [%s]. The qps, burst and timeout must not be negative", cluster)
+		}
+		if clientConfig.ProxyURL != "" {
+			if proxyURL, err := url.Parse(clientConfig.ProxyURL); err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+				return fmt.Errorf("error in configuration options for the clients of cluster [%s]. Invalid proxy url [%s]", cluster, clientConfig.ProxyURL)
+			}
+		}
+	}
+
+	// Check the targets of the config change notifications
+	for _, target := range cfg.Notifications.Targets {
+		if target.Type != NotificationTargetSlack && target.Type != NotificationTargetWebhook {
+			return fmt.Errorf("error in configuration options for the notification target [%s]. Invalid type [%s]", target.Name, target.Type)
+		}
+		if targetURL, err := url.Parse(target.URL); err != nil || targetURL.Scheme == "" || targetURL.Host == "" {
+			return fmt.Errorf("error in configuration options for the notification target [%s]. Invalid url", target.Name)
+		}
+	}
+
 	// Check the ciphering key for sessions
 	signingKey := cfg.LoginToken.SigningKey
 	if err := ValidateSigningKey(signingKey, auth.Strategy); err != nil {
@@ -1259,6 +1736,29 @@ func Validate(cfg Config) error {
 		return fmt.Errorf("error in configuration options for the external services tracing provider. Invalid provider type [%s]", cfgTracing.Provider)
 	}
 
+	// Check the metrics provider
+	switch cfg.ExternalServices.Prometheus.Provider {
+	case "", PrometheusProvider, OTelProvider:
+	default:
+		return fmt.Errorf("error in configuration options for the external services prometheus provider. Invalid provider type [%s]", cfg.ExternalServices.Prometheus.Provider)
+	}
+
+	// Check the istiod debug access
+	if registry := cfg.ExternalServices.Istio.Registry; registry != nil {
+		switch registry.GetAccessMode() {
+		case IstiodAccessModePortForward, IstiodAccessModeService:
+		case IstiodAccessModeURL:
+			if registry.IstiodURL == "" {
+				return fmt.Errorf("error in configuration options for the istio registry. The istiod url is required with the [%s] access mode", IstiodAccessModeURL)
+			}
+		default:
+			return fmt.Errorf("error in configuration options for the istio registry. Invalid access mode [%s]", registry.AccessMode)
+		}
+		if (registry.ClientCertFile == "") != (registry.ClientKeyFile == "") {
+			return fmt.Errorf("error in configuration options for the istio registry. Both the client cert and key files are required for mTLS")
+		}
+	}
+
 	return nil
 }
 
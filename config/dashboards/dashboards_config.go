@@ -30,6 +30,15 @@ type MonitoringDashboard struct {
 	Items         []MonitoringDashboardItem         `yaml:"items"`
 	ExternalLinks []MonitoringDashboardExternalLink `yaml:"externalLinks"`
 	Rows          int                               `yaml:"rows"`
+	Variables     []MonitoringDashboardVariable     `yaml:"variables"`
+}
+
+// MonitoringDashboardVariable is a template variable of a dashboard, substituted server-side.
+// Its value is taken from the query, or from the namespace / workload being displayed for the variables of the same name.
+type MonitoringDashboardVariable struct {
+	Name    string `yaml:"name"`    // The variable is referenced as $name or ${name} in the metric names of the charts
+	Label   string `yaml:"label"`   // When set, series are filtered on this label with the value of the variable
+	Default string `yaml:"default"` // Value used when none is provided. Ex: "0.99" for a "percentile" variable
 }
 
 type MonitoringDashboardItem struct {
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/log"
+)
+
+// Subscribers notified when the global Config is reloaded, keyed by subscription id.
+var (
+	subscribers      = map[int]func(old, new Config){}
+	subscribersMutex sync.Mutex
+	nextSubscriberID int
+)
+
+// Subscribe registers a function called with the previous and the new Config every time the reloadable settings of
+// the global Config change. It returns the function removing the subscription.
+// The subscribers are called sequentially, out of the lock of the global Config, so they can call Get.
+func Subscribe(fn func(old, new Config)) (unsubscribe func()) {
+	subscribersMutex.Lock()
+	defer subscribersMutex.Unlock()
+	id := nextSubscriberID
+	nextSubscriberID++
+	subscribers[id] = fn
+	return func() {
+		subscribersMutex.Lock()
+		defer subscribersMutex.Unlock()
+		delete(subscribers, id)
+	}
+}
+
+// Reload applies the settings of conf that can change without a restart to the global Config: the log level, the
+// health config, the feature flags and the urls of the external services. The other settings are kept, a restart is
+// needed to apply them. The subscribers are notified when any reloadable setting changed, which is returned.
+func Reload(conf *Config) (bool, error) {
+	if err := validateReloadable(*conf); err != nil {
+		return false, err
+	}
+
+	rwMutex.Lock()
+	old := configuration
+	updated := old
+	applyReloadable(&updated, *conf)
+	// The default rates are added to the configured ones, as by Set
+	updated.HealthConfig.Rate = append([]Rate{}, conf.HealthConfig.Rate...)
+	updated.AddHealthDefault()
+
+	var oldSettings, newSettings Config
+	applyReloadable(&oldSettings, old)
+	applyReloadable(&newSettings, updated)
+	if reflect.DeepEqual(oldSettings, newSettings) {
+		rwMutex.Unlock()
+		return false, nil
+	}
+	configuration = updated
+	rwMutex.Unlock()
+
+	subscribersMutex.Lock()
+	notify := make([]func(old, new Config), 0, len(subscribers))
+	for _, fn := range subscribers {
+		notify = append(notify, fn)
+	}
+	subscribersMutex.Unlock()
+
+	for _, fn := range notify {
+		fn(old, updated)
+	}
+	return true, nil
+}
+
+// applyReloadable copies the settings that can change without a restart from src to dst.
+// The clustering of the feature flags is deprecated in favor of the clustering section, which is not reloadable.
+func applyReloadable(dst *Config, src Config) {
+	dst.Deployment.LogLevel = src.Deployment.LogLevel
+	dst.HealthConfig = src.HealthConfig
+
+	clustering := dst.KialiFeatureFlags.Clustering
+	dst.KialiFeatureFlags = src.KialiFeatureFlags
+	dst.KialiFeatureFlags.Clustering = clustering
+
+	dstServices, srcServices := &dst.ExternalServices, src.ExternalServices
+	dstServices.CustomDashboards.Prometheus.URL = srcServices.CustomDashboards.Prometheus.URL
+	dstServices.Grafana.HealthCheckUrl = srcServices.Grafana.HealthCheckUrl
+	dstServices.Grafana.InClusterURL = srcServices.Grafana.InClusterURL
+	dstServices.Grafana.URL = srcServices.Grafana.URL
+	dstServices.Prometheus.HealthCheckUrl = srcServices.Prometheus.HealthCheckUrl
+	dstServices.Prometheus.URL = srcServices.Prometheus.URL
+	dstServices.Tracing.HealthCheckUrl = srcServices.Tracing.HealthCheckUrl
+	dstServices.Tracing.InClusterURL = srcServices.Tracing.InClusterURL
+	dstServices.Tracing.URL = srcServices.Tracing.URL
+}
+
+// validateReloadable returns an error when a reloadable setting of conf is invalid, the whole reload is rejected then.
+func validateReloadable(conf Config) error {
+	for _, fn := range conf.KialiFeatureFlags.DisabledFeatures {
+		if err := FeatureName(fn).IsValid(); err != nil {
+			return err
+		}
+	}
+
+	services := conf.ExternalServices
+	urls := map[string]string{
+		"custom_dashboards.prometheus.url": services.CustomDashboards.Prometheus.URL,
+		"grafana.health_check_url":         services.Grafana.HealthCheckUrl,
+		"grafana.in_cluster_url":           services.Grafana.InClusterURL,
+		"grafana.url":                      services.Grafana.URL,
+		"prometheus.health_check_url":      services.Prometheus.HealthCheckUrl,
+		"prometheus.url":                   services.Prometheus.URL,
+		"tracing.health_check_url":         services.Tracing.HealthCheckUrl,
+		"tracing.in_cluster_url":           services.Tracing.InClusterURL,
+		"tracing.url":                      services.Tracing.URL,
+	}
+	for name, value := range urls {
+		if value == "" {
+			continue
+		}
+		if _, err := url.Parse(value); err != nil {
+			return fmt.Errorf("invalid external_services.%s [%s]: %v", name, value, err)
+		}
+	}
+	return nil
+}
+
+// WatchFile reloads the config file every time its content changes, until the context is done. The ConfigMap of the
+// config is mounted as the file and the kubelet updates it in place, so the file is polled at the interval.
+func WatchFile(ctx context.Context, filename string, interval time.Duration) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		log.Warningf("Cannot read the config file [%s], its changes will not be reloaded: %v", filename, err)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err := os.ReadFile(filename)
+			if err != nil {
+				log.Warningf("Cannot read the config file [%s]: %v", filename, err)
+				continue
+			}
+			if bytes.Equal(current, content) {
+				continue
+			}
+			content = current
+
+			conf, err := Unmarshal(string(current))
+			if err != nil {
+				log.Errorf("The config file [%s] changed but cannot be parsed, keeping the current config: %v", filename, err)
+				continue
+			}
+			changed, err := Reload(conf)
+			if err != nil {
+				log.Errorf("The config file [%s] changed but is invalid, keeping the current config: %v", filename, err)
+				continue
+			}
+			if changed {
+				log.Infof("Reloaded the config file [%s]. Changes of the settings other than the log level, the health config, the feature flags and the external services urls need a restart", filename)
+			}
+		}
+	}()
+}
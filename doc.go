@@ -1,8 +1,10 @@
 package main
 
 import (
+	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/business/authentication"
 	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/handlers"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/status"
@@ -57,19 +59,20 @@ type ClusterParam struct {
 	//
 	// in: query
 	// required: false
-	Name string `json:"container"`
+	Name string `json:"clusterName"`
 }
 
 // swagger:parameters podLogs
 type ContainerParam struct {
 	// The pod container name. Optional for single-container pod. Otherwise required.
+	// A comma-separated list of containers streams their logs together, interleaved by timestamp.
 	//
 	// in: query
 	// required: false
 	Name string `json:"container"`
 }
 
-// swagger:parameters podProxyLogging
+// swagger:parameters podProxyLogging workloadProxyLogging
 type LoggingParam struct {
 	// The log level for the pod's proxy.
 	//
@@ -78,7 +81,30 @@ type LoggingParam struct {
 	Level ProxyLogLevel `json:"level"`
 }
 
-// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging
+// swagger:parameters workloadProxyLogging
+type LoggingTTLParam struct {
+	// How long the log level is kept before the proxies are reset to their configured log level, e.g. 15m. Kept until changed when missing.
+	//
+	// in: query
+	// required: false
+	Name string `json:"ttl"`
+}
+
+// swagger:parameters podProxyTap
+type ProxyTapParams struct {
+	// The duration of the capture, in seconds. Defaults to 10, at most 60.
+	//
+	// in: query
+	// required: false
+	Duration int `json:"duration"`
+	// A prefix of the status of the captured responses, e.g. 503, or 5 for all the server errors.
+	//
+	// in: query
+	// required: false
+	Status string `json:"status"`
+}
+
+// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate serviceTrafficMirroring serviceLocalityLoadBalancing appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging namespaceEvents namespaceMarkerAdd workloadProxyLogging podProxyTap namespaceMeshReadiness namespaceEffectiveMeshConfig workloadEffectiveMeshConfig
 type NamespaceParam struct {
 	// The namespace name.
 	//
@@ -115,7 +141,58 @@ type ValidateParam struct {
 	Name string `json:"validate"`
 }
 
-// swagger:parameters podDetails podLogs podProxyDump podProxyResource podProxyLogging
+// swagger:parameters istioConfigList
+type ValidationStatusParam struct {
+	// Only return the objects with validation errors (errors) or with validation warnings but no errors (warnings)
+	//
+	// in: query
+	// required: false
+	Name string `json:"validationStatus"`
+}
+
+// swagger:parameters istioConfigList
+type GVKsParam struct {
+	// Comma-separated list of kinds to return, as group/version/kind or group/kind (e.g. networking.istio.io/v1beta1/VirtualService)
+	//
+	// in: query
+	// required: false
+	Name string `json:"gvks"`
+}
+
+// swagger:parameters istioConfigCreate istioConfigCreateSubtype istioConfigUpdate istioConfigUpdateSubtype
+type DryRunParam struct {
+	// Only validate the object, with a server-side dry run and the Kiali checks, without persisting it
+	//
+	// in: query
+	// required: false
+	Name bool `json:"dryRun"`
+}
+
+// swagger:parameters serviceTrafficMirroring
+type TrafficMirroringParams struct {
+	// The subset (version) of the service the requests are mirrored to, exclusive with host
+	//
+	// in: query
+	// required: false
+	Subset string `json:"subset"`
+	// The service or ServiceEntry host the requests are mirrored to, exclusive with subset
+	//
+	// in: query
+	// required: false
+	Host string `json:"host"`
+	// The port of the mirror host
+	//
+	// in: query
+	// required: false
+	Port uint32 `json:"port"`
+	// The percentage of the requests which are mirrored, all of them by default
+	//
+	// in: query
+	// required: false
+	Percentage float64 `json:"percentage"`
+}
+
+// swagger:parameters podDetails podLogs podProxyDump podProxyResource podProxyLogging podProxyTap
 type PodParam struct {
 	// The pod name.
 	//
@@ -133,7 +210,61 @@ type ResourceParam struct {
 	Name string `json:"resource"`
 }
 
-// swagger:parameters serviceDetails serviceUpdate serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces
+// swagger:parameters edgeTraces
+type EdgeTracesParams struct {
+	// The namespace of the source node of the edge
+	//
+	// in: query
+	// required: true
+	SourceNamespace string `json:"sourceNamespace"`
+	// The kind of the source node: app, service or workload
+	//
+	// in: query
+	// required: true
+	SourceKind string `json:"sourceKind"`
+	// The name of the source node
+	//
+	// in: query
+	// required: true
+	SourceName string `json:"sourceName"`
+	// The cluster of the source node, the cluster of the query by default
+	//
+	// in: query
+	// required: false
+	SourceCluster string `json:"sourceCluster"`
+	// The namespace of the destination node of the edge
+	//
+	// in: query
+	// required: true
+	DestNamespace string `json:"destNamespace"`
+	// The kind of the destination node: app, service or workload
+	//
+	// in: query
+	// required: true
+	DestKind string `json:"destKind"`
+	// The name of the destination node
+	//
+	// in: query
+	// required: true
+	DestName string `json:"destName"`
+	// The cluster of the destination node, the cluster of the query by default
+	//
+	// in: query
+	// required: false
+	DestCluster string `json:"destCluster"`
+	// The start of the time window, in microseconds since epoch
+	//
+	// in: query
+	// required: false
+	StartMicros string `json:"startMicros"`
+	// The end of the time window, in microseconds since epoch
+	//
+	// in: query
+	// required: false
+	EndMicros string `json:"endMicros"`
+}
+
+// swagger:parameters serviceDetails serviceUpdate serviceTrafficMirroring serviceLocalityLoadBalancing serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces
 type ServiceParam struct {
 	// The service name.
 	//
@@ -161,7 +292,27 @@ type DurationLogParam struct {
 	Name string `json:"duration"`
 }
 
-// swagger:parameters traceDetails
+// swagger:parameters podLogs
+type FollowLogParam struct {
+	// Follow the logs: the log entries are streamed as Server-Sent Events as soon as they are logged. `maxLines`
+	// is then the number of lines logged before following, per container.
+	//
+	// in: query
+	// required: false
+	Name bool `json:"follow"`
+}
+
+// swagger:parameters podLogs
+type AccessLogFilterParam struct {
+	// Comma-separated list of conditions on the proxy access log entries, i.e. `response_code>=500`. The fields are
+	// duration (millis), response_code, response_flags and upstream_host. Other log entries are discarded.
+	//
+	// in: query
+	// required: false
+	Name string `json:"filter"`
+}
+
+// swagger:parameters traceDetails traceLogs
 type TraceIDParam struct {
 	// The trace ID.
 	//
@@ -179,7 +330,7 @@ type DashboardParam struct {
 	Name string `json:"dashboard"`
 }
 
-// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces
+// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces workloadProxyLogging workloadEffectiveMeshConfig
 type WorkloadParam struct {
 	// The workload name.
 	//
@@ -193,9 +344,18 @@ type WorkloadParam struct {
 // - keep this alphabetized
 /////////////////////
 
+// swagger:parameters graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespaces graphService graphWorkload
+type AggregateProtocolParam struct {
+	// Restrict the aggregateNode appender to the requests of one protocol, e.g. grpc to aggregate gRPC requests by method. Available protocols: [grpc, http].
+	//
+	// in: query
+	// required: false
+	Name string `json:"aggregateProtocol"`
+}
+
 // swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
 type AppendersParam struct {
-	// Comma-separated list of Appenders to run. Available appenders: [aggregateNode, deadNode, healthConfig, idleNode, istio, responseTime, securityPolicy, serviceEntry, sidecarsCheck, throughput].
+	// Comma-separated list of Appenders to run. Available appenders: [aggregateNode, deadNode, healthConfig, idleNode, istio, policyCheck, responseTime, securityPolicy, serviceEntry, sidecarsCheck, throughput].
 	//
 	// in: query
 	// required: false
@@ -212,6 +372,15 @@ type BoxByParam struct {
 	Name string `json:"boxBy"`
 }
 
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+type CompareTimeParam struct {
+	// Unix time (seconds) ending a previous time range of the same duration. When set, nodes and edges are annotated with the changes since that range: new, removed, request and error rate deltas.
+	//
+	// in: query
+	// required: false
+	Name string `json:"compareTime"`
+}
+
 // swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
 type DurationGraphParam struct {
 	// Query time-range duration (Golang string duration).
@@ -222,6 +391,15 @@ type DurationGraphParam struct {
 	Name string `json:"duration"`
 }
 
+// swagger:parameters graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespaces graphService graphWorkload
+type GraphFormatParam struct {
+	// Export format replacing the default (cytoscape) format: dot for Graphviz DOT (text/vnd.graphviz), otel-servicegraph for the OpenTelemetry service graph model (Grafana node graph conventions). Available formats: [dot, otel-servicegraph].
+	//
+	// in: query
+	// required: false
+	Name string `json:"format"`
+}
+
 // swagger:parameters graphNamespaces graphService graphWorkload
 type GraphTypeParam struct {
 	// Graph type. Available graph types: [app, service, versionedApp, workload].
@@ -241,6 +419,80 @@ type AppGraphTypeParam struct {
 	Name string `json:"graphType"`
 }
 
+// swagger:parameters serviceLocalityLoadBalancing
+type LocalityLoadBalancingBodyParam struct {
+	// The distribution or the failover of the requests across the localities, every region fails over to the next
+	// one when both are empty.
+	//
+	// in: body
+	// required: true
+	Body models.LocalityLoadBalancing
+}
+
+// swagger:parameters compositeQuery
+type CompositeQueryBodyParam struct {
+	// The queries by alias, with their selected fields.
+	//
+	// in: body
+	// required: true
+	Body handlers.CompositeQueryRequest
+}
+
+// swagger:parameters graphViewSave
+type GraphViewBodyParam struct {
+	// The graph view to save.
+	//
+	// in: body
+	// required: true
+	Body models.GraphView
+}
+
+// swagger:parameters namespaceEvents
+type EventsTargetParam struct {
+	// Narrows the events down to an app.
+	//
+	// in: query
+	// required: false
+	App string `json:"app"`
+	// Narrows the events down to a workload.
+	//
+	// in: query
+	// required: false
+	Workload string `json:"workload"`
+	// Narrows the events down to a service.
+	//
+	// in: query
+	// required: false
+	Service string `json:"service"`
+}
+
+// swagger:parameters namespaceMarkerAdd
+type MarkerBodyParam struct {
+	// The marker to add, with its message and optional targets.
+	//
+	// in: body
+	// required: true
+	Body models.KialiEvent
+}
+
+// swagger:parameters preferencesSave
+type PreferencesBodyParam struct {
+	// The preferences of the user.
+	//
+	// in: body
+	// required: true
+	Body models.UserPreferences
+}
+
+// swagger:parameters graphViewGet graphViewSave graphViewDelete
+type GraphViewNameParam struct {
+	// The graph view name.
+	//
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
 // swagger:parameters graphApp graphAppVersion graphNamespaces graphWorkload
 type IncludeIdleEdges struct {
 	// Flag for including edges that have no request traffic for the time period.
@@ -354,6 +606,16 @@ type AvgParam struct {
 	Name bool `json:"avg"`
 }
 
+// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics appDashboard serviceDashboard workloadDashboard
+type ByFlagsParam struct {
+	// Flag for grouping the request counts by Envoy response flags (UH, UF, NR...), to distinguish proxy failures from application errors.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"byFlags"`
+}
+
 // swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type ByLabelsParam struct {
 	// List of labels to use for grouping metrics (via Prometheus 'by' clause).
@@ -374,7 +636,7 @@ type DirectionParam struct {
 	Name string `json:"direction"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard namespaceEvents
 type DurationParam struct {
 	// Duration of the query period, in seconds.
 	//
@@ -464,6 +726,16 @@ type StepParam struct {
 	Name int `json:"step"`
 }
 
+// swagger:parameters customDashboard
+type VariablesParam struct {
+	// In custom dashboards, values of the dashboard template variables, formatted as key:value pairs. Ex: "pod:foo-1,percentile:0.99".
+	//
+	// in: query
+	// required: false
+	//
+	Name string `json:"variables"`
+}
+
 // swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics
 type VersionParam struct {
 	// Filters metrics by the specified version.
@@ -581,6 +853,62 @@ type GraphResponse struct {
 	Body cytoscape.Config
 }
 
+// HTTP status code 200 and the saved graph views in data
+// swagger:response graphViewsResponse
+type GraphViewsResponse struct {
+	// in:body
+	Body []models.GraphView
+}
+
+// HTTP status code 200 and the events of a namespace in data
+// swagger:response eventsResponse
+type EventsResponse struct {
+	// in:body
+	Body []models.KialiEvent
+}
+
+// HTTP status code 200 and the added marker in data
+// swagger:response markerResponse
+type MarkerResponse struct {
+	// in:body
+	Body models.KialiEvent
+}
+
+// HTTP status code 200 and the pods whose proxy log level was set in data
+// swagger:response workloadProxyLogLevelResponse
+type WorkloadProxyLogLevelResponse struct {
+	// in:body
+	Body models.WorkloadProxyLogLevel
+}
+
+// HTTP status code 200 and the requests captured by the proxy in data
+// swagger:response proxyTapResponse
+type ProxyTapResponse struct {
+	// in:body
+	Body models.ProxyTapCapture
+}
+
+// HTTP status code 200 and the preferences of the user in data
+// swagger:response preferencesResponse
+type PreferencesResponse struct {
+	// in:body
+	Body models.UserPreferences
+}
+
+// HTTP status code 200 and GraphView model in data
+// swagger:response graphViewResponse
+type GraphViewResponse struct {
+	// in:body
+	Body models.GraphView
+}
+
+// HTTP status code 200 and the results of the queries by alias
+// swagger:response compositeQueryResponse
+type CompositeQueryResponse struct {
+	// in:body
+	Body map[string]handlers.QueryResult
+}
+
 // HTTP status code 200 and IstioConfigList model in data
 // swagger:response istioConfigList
 type IstioConfigResponse struct {
@@ -588,25 +916,25 @@ type IstioConfigResponse struct {
 	Body models.IstioConfigList
 }
 
-// Listing all services in the namespace
+// Listing all services of the namespaces of a cluster
 // swagger:response serviceListResponse
 type ServiceListResponse struct {
 	// in:body
-	Body models.ServiceList
+	Body models.ClusterServices
 }
 
-// Listing all workloads in the namespace
+// Listing all workloads of the namespaces of a cluster
 // swagger:response workloadListResponse
 type WorkloadListResponse struct {
 	// in:body
-	Body models.WorkloadList
+	Body models.ClusterWorkloads
 }
 
-// Listing all apps in the namespace
+// Listing all apps of the namespaces of a cluster
 // swagger:response appListResponse
 type AppListResponse struct {
 	// in:body
-	Body models.AppList
+	Body models.ClusterApps
 }
 
 // namespaceAppHealthResponse is a map of app name x health
@@ -630,6 +958,13 @@ type ServiceDetailsResponse struct {
 	Body models.ServiceDetails
 }
 
+// The Istio config generated by a Kiali wizard
+// swagger:response wizardScenarioResponse
+type WizardScenarioResponse struct {
+	// in:body
+	Body models.WizardScenario
+}
+
 // Listing all the information related to a Trace
 // swagger:response traceDetailsResponse
 type TraceDetailsResponse struct {
@@ -637,6 +972,20 @@ type TraceDetailsResponse struct {
 	Body []jaegerModels.Trace
 }
 
+// A single trace
+// swagger:response traceResponse
+type TraceResponse struct {
+	// in:body
+	Body model.TracingSingleTrace
+}
+
+// The log entries of the pods of the spans of a trace
+// swagger:response traceLogsResponse
+type TraceLogsResponse struct {
+	// in:body
+	Body business.TraceLogs
+}
+
 // Number of traces in error
 // swagger:response errorTracesResponse
 type ErrorTracesResponse struct {
@@ -679,6 +1028,20 @@ type IstioConfigDetailsResponse struct {
 	Body models.IstioConfigDetails
 }
 
+// Istio objects referencing an specific object
+// swagger:response referenceImpactResponse
+type ReferenceImpactResponse struct {
+	// in:body
+	Body models.ReferenceImpact
+}
+
+// Services, workloads and configs affected by the deletion of an specific object
+// swagger:response deletionImpactResponse
+type DeletionImpactResponse struct {
+	// in:body
+	Body models.DeletionImpact
+}
+
 // Detailed information of an specific app
 // swagger:response appDetails
 type AppDetailsResponse struct {
@@ -693,6 +1056,13 @@ type NamespaceListResponse struct {
 	Body []models.Namespace
 }
 
+// The access of the user to the namespaces, per cluster
+// swagger:response namespaceAccessAuditResponse
+type NamespaceAccessAuditResponse struct {
+	// in:body
+	Body []models.NamespaceAccessAudit
+}
+
 // Return all the descriptor data related to Grafana
 // swagger:response grafanaInfoResponse
 type GrafanaInfoResponse struct {
@@ -728,6 +1098,48 @@ type NamespaceTlsResponse struct {
 	Body models.MTLSStatus
 }
 
+// Return the plaintext traffic received by the workloads of a Namespace accepting it
+// swagger:response permissiveTrafficResponse
+type PermissiveTrafficResponse struct {
+	// in:body
+	Body models.PermissiveTrafficReport
+}
+
+// Return the requests proxied by a waypoint, per enrolled service
+// swagger:response waypointTrafficResponse
+type WaypointTrafficResponse struct {
+	// in:body
+	Body models.WaypointTraffic
+}
+
+// Return how the workloads of a namespace are covered by the AuthorizationPolicies
+// swagger:response authorizationCoverageResponse
+type AuthorizationCoverageResponse struct {
+	// in:body
+	Body models.AuthorizationCoverage
+}
+
+// Return the checklist of the onboarding of a namespace into the mesh
+// swagger:response namespaceMeshReadinessResponse
+type NamespaceMeshReadinessResponse struct {
+	// in:body
+	Body models.MeshReadiness
+}
+
+// Return the meshConfig values the proxies of a namespace or of a workload run with
+// swagger:response effectiveMeshConfigResponse
+type EffectiveMeshConfigResponse struct {
+	// in:body
+	Body models.EffectiveMeshConfig
+}
+
+// Return the mTLS status of a specific Workload
+// swagger:response workloadTlsResponse
+type WorkloadTlsResponse struct {
+	// in:body
+	Body models.MTLSStatus
+}
+
 // Return the validation status of a specific Namespace
 // swagger:response namespaceValidationSummaryResponse
 type NamespaceValidationSummaryResponse struct {
@@ -768,6 +1180,20 @@ type swaggIstioConfigPermissions struct {
 	Body models.IstioConfigPermissions
 }
 
+// Return the schemas of the Istio and Gateway API kinds of a cluster
+// swagger:response istioConfigSchemas
+type swaggIstioConfigSchemas struct {
+	// in:body
+	Body models.IstioConfigSchemas
+}
+
+// Return the localities of the nodes of every cluster
+// swagger:response istioLocalities
+type swaggIstioLocalities struct {
+	// in:body
+	Body []models.ClusterLocalities
+}
+
 // Return a list of Istio components along its status
 // swagger:response istioStatusResponse
 type IstioStatusResponse struct {
@@ -775,6 +1201,34 @@ type IstioStatusResponse struct {
 	Body kubernetes.IstioComponentStatus
 }
 
+// Return the status of the istio components grouped per mesh
+// swagger:response meshComponentStatusResponse
+type MeshComponentStatusResponse struct {
+	// in: body
+	Body models.MeshComponentStatus
+}
+
+// Return the trust domains of the mesh and the principals referenced by the authorization policies
+// swagger:response trustDomainReportResponse
+type TrustDomainReportResponse struct {
+	// in: body
+	Body models.TrustDomainReport
+}
+
+// Return the Istio objects whose spec differs between the controlplane clusters of the mesh
+// swagger:response configDriftReportResponse
+type ConfigDriftReportResponse struct {
+	// in: body
+	Body models.ConfigDriftReport
+}
+
+// Return the outbound traffic policies, the Sidecars restricting egress and the unregistered external destinations
+// swagger:response egressReportResponse
+type EgressReportResponse struct {
+	// in: body
+	Body models.EgressReport
+}
+
 // Return a list of certificates information
 // swagger:response certsInfoResponse
 type CertsInfoResponse struct {
@@ -782,6 +1236,69 @@ type CertsInfoResponse struct {
 	Body []models.CertInfo
 }
 
+// Return the inventory of the certificates used by the mesh
+// swagger:response certificateInventory
+type CertificateInventoryResponse struct {
+	// in: body
+	Body models.CertificateInventory
+}
+
+// Return the health of the namespaces of the clusters
+// swagger:response clustersNamespaceHealthResponse
+type ClustersNamespaceHealthResponse struct {
+	// in: body
+	Body models.ClustersNamespaceHealth
+}
+
+// Return the mTLS status of the namespaces of a cluster
+// swagger:response clusterTlsResponse
+type ClusterTlsResponse struct {
+	// in: body
+	Body []models.MTLSStatus
+}
+
+// Return the canary upgrade status of the controlplanes
+// swagger:response istiodCanariesStatus
+type IstiodCanariesStatusResponse struct {
+	// in: body
+	Body models.CanaryUpgradeStatus
+}
+
+// Return the resource thresholds of istiod
+// swagger:response istiodResourceThresholds
+type IstiodResourceThresholdsResponse struct {
+	// in: body
+	Body models.IstiodThresholds
+}
+
+// Return the mesh: its controlplanes and the clusters they manage
+// swagger:response meshResponse
+type MeshResponse struct {
+	// in: body
+	Body models.Mesh
+}
+
+// Return the outbound traffic policy of the mesh
+// swagger:response outboundTrafficPolicyResponse
+type OutboundTrafficPolicyResponse struct {
+	// in: body
+	Body models.OutboundPolicy
+}
+
+// Return the differences between the config of a proxy and the config istiod pushes to it
+// swagger:response proxyStatusDiff
+type ProxyStatusDiffResponse struct {
+	// in: body
+	Body models.ProxyStatusDiff
+}
+
+// Return the progress of the revision upgrade of the namespaces and of the proxies
+// swagger:response revisionUpgradeProgress
+type RevisionUpgradeProgressResponse struct {
+	// in: body
+	Body models.RevisionUpgradeProgress
+}
+
 // Posted parameters for a metrics stats query
 // swagger:parameters metricsStats
 type MetricsStatsQueryBody struct {
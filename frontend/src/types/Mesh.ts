@@ -27,6 +27,7 @@ export type MeshClusters = MeshCluster[];
 export enum MeshInfraType {
   CLUSTER = 'cluster',
   DATAPLANE = 'dataplane',
+  EAST_WEST_GATEWAY = 'eastWestGateway',
   GRAFANA = 'grafana',
   ISTIOD = 'istiod',
   KIALI = 'kiali',
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -22,6 +23,7 @@ import (
 // Service provides discovery and info about Grafana.
 type Service struct {
 	conf                *config.Config
+	confLock            sync.RWMutex
 	homeClusterSAClient kubernetes.ClientInterface
 	routeLock           sync.RWMutex
 	routeURL            *string
@@ -42,8 +44,25 @@ func NewService(conf *config.Config, homeClusterSAClient kubernetes.ClientInterf
 	return s
 }
 
+// Reload replaces the config of the service when it is reloaded. The route of the in cluster url is discovered again.
+func (s *Service) Reload(conf *config.Config) {
+	s.confLock.Lock()
+	s.conf = conf
+	s.confLock.Unlock()
+
+	s.routeLock.Lock()
+	s.routeURL = nil
+	s.routeLock.Unlock()
+}
+
+func (s *Service) config() *config.Config {
+	s.confLock.RLock()
+	defer s.confLock.RUnlock()
+	return s.conf
+}
+
 func (s *Service) URL(ctx context.Context) string {
-	grafanaConf := s.conf.ExternalServices.Grafana
+	grafanaConf := s.config().ExternalServices.Grafana
 
 	// If Grafana is disabled in the configuration return an empty string and avoid discovery
 	if !grafanaConf.Enabled {
@@ -68,7 +87,7 @@ func (s *Service) discover(ctx context.Context) string {
 	defer s.routeLock.Unlock()
 	// Try to get service and namespace from in-cluster URL, to discover route
 	routeURL := ""
-	if inClusterURL := s.conf.ExternalServices.Grafana.InClusterURL; inClusterURL != "" {
+	if inClusterURL := s.config().ExternalServices.Grafana.InClusterURL; inClusterURL != "" {
 		parsedURL, err := url.Parse(inClusterURL)
 		if err == nil {
 			parts := strings.Split(parsedURL.Hostname(), ".")
@@ -110,18 +129,42 @@ func (s *Service) discoverServiceURL(ctx context.Context, ns, service string) (u
 	return
 }
 
+// configFor returns the Grafana config of a cluster: its override, inheriting the dashboards and the datasource of
+// the Grafana config when unset, and true, or the Grafana config and false.
+func (s *Service) configFor(cluster string) (config.GrafanaConfig, bool) {
+	grafanaConfig := s.config().ExternalServices.Grafana
+	clusterConfig, ok := grafanaConfig.ClusterGrafana[cluster]
+	if !ok {
+		return grafanaConfig, false
+	}
+	clusterConfig.Enabled = grafanaConfig.Enabled
+	if len(clusterConfig.Dashboards) == 0 {
+		clusterConfig.Dashboards = grafanaConfig.Dashboards
+	}
+	if len(clusterConfig.DashboardTags) == 0 {
+		clusterConfig.DashboardTags = grafanaConfig.DashboardTags
+	}
+	if clusterConfig.DatasourceUID == "" {
+		clusterConfig.DatasourceUID = grafanaConfig.DatasourceUID
+	}
+	return clusterConfig, true
+}
+
 type DashboardSupplierFunc func(string, string, *config.Auth) ([]byte, int, error)
 
 var DashboardSupplier = findDashboard
 
-// GetGrafanaInfo returns the Grafana URL and other info, the HTTP status code (int) and eventually an error
-func (s *Service) Info(ctx context.Context, dashboardSupplier DashboardSupplierFunc) (*models.GrafanaInfo, int, error) {
-	grafanaConfig := s.conf.ExternalServices.Grafana
+// DashboardTagSupplier searches the dashboards of a tag
+var DashboardTagSupplier DashboardSupplierFunc = findDashboardsByTag
+
+// GetGrafanaInfo returns the Grafana URL and other info of a cluster, the HTTP status code (int) and eventually an error
+func (s *Service) Info(ctx context.Context, cluster string, dashboardSupplier DashboardSupplierFunc) (*models.GrafanaInfo, int, error) {
+	grafanaConfig, _ := s.configFor(cluster)
 	if !grafanaConfig.Enabled {
 		return nil, http.StatusNoContent, nil
 	}
 
-	conn, code, err := s.getGrafanaConnectionInfo(ctx)
+	conn, code, err := s.getGrafanaConnectionInfo(ctx, cluster)
 	if err != nil {
 		return nil, code, err
 	}
@@ -149,6 +192,16 @@ func (s *Service) Info(ctx context.Context, dashboardSupplier DashboardSupplierF
 		}
 	}
 
+	tagLinks, err := getTaggedDashboardLinks(grafanaConfig.DashboardTags, conn, DashboardTagSupplier)
+	if err != nil {
+		return nil, http.StatusServiceUnavailable, err
+	}
+	for _, tagLink := range tagLinks {
+		if !slices.ContainsFunc(links, func(link models.ExternalLink) bool { return link.URL == tagLink.URL }) {
+			links = append(links, tagLink)
+		}
+	}
+
 	grafanaInfo := models.GrafanaInfo{
 		ExternalLinks: links,
 	}
@@ -156,14 +209,15 @@ func (s *Service) Info(ctx context.Context, dashboardSupplier DashboardSupplierF
 	return &grafanaInfo, http.StatusOK, nil
 }
 
-// GetGrafanaLinks returns the links to Grafana dashboards and other info, the HTTP status code (int) and eventually an error
-func (s *Service) Links(ctx context.Context, linksSpec []dashboards.MonitoringDashboardExternalLink) ([]models.ExternalLink, int, error) {
-	grafanaConfig := s.conf.ExternalServices.Grafana
+// GetGrafanaLinks returns the links to the Grafana dashboards of a cluster and other info, the HTTP status code (int)
+// and eventually an error
+func (s *Service) Links(ctx context.Context, cluster string, linksSpec []dashboards.MonitoringDashboardExternalLink) ([]models.ExternalLink, int, error) {
+	grafanaConfig, _ := s.configFor(cluster)
 	if !grafanaConfig.Enabled {
 		return nil, 0, nil
 	}
 
-	connectionInfo, code, err := s.getGrafanaConnectionInfo(ctx)
+	connectionInfo, code, err := s.getGrafanaConnectionInfo(ctx, cluster)
 	if err != nil {
 		return nil, code, err
 	}
@@ -204,12 +258,16 @@ type grafanaConnectionInfo struct {
 	auth              *config.Auth
 }
 
-func (s *Service) getGrafanaConnectionInfo(ctx context.Context) (grafanaConnectionInfo, int, error) {
-	externalURL := s.URL(ctx)
+func (s *Service) getGrafanaConnectionInfo(ctx context.Context, cluster string) (grafanaConnectionInfo, int, error) {
+	cfg, isClusterConfig := s.configFor(cluster)
+	// The URL of the Grafana of a cluster is not discovered
+	externalURL := cfg.URL
+	if !isClusterConfig {
+		externalURL = s.URL(ctx)
+	}
 	if externalURL == "" {
 		return grafanaConnectionInfo{}, http.StatusServiceUnavailable, errors.New("grafana URL is not set in Kiali configuration")
 	}
-	cfg := s.conf.ExternalServices.Grafana
 
 	// Check if URL is valid
 	_, err := url.ParseRequestURI(externalURL)
@@ -231,6 +289,14 @@ func (s *Service) getGrafanaConnectionInfo(ctx context.Context) (grafanaConnecti
 	if len(urlParts) > 1 {
 		externalURLParams = "?" + urlParts[1]
 	}
+	if cfg.DatasourceUID != "" {
+		param := "var-datasource=" + url.QueryEscape(cfg.DatasourceUID)
+		if externalURLParams == "" {
+			externalURLParams = "?" + param
+		} else {
+			externalURLParams += "&" + param
+		}
+	}
 
 	return grafanaConnectionInfo{
 		baseExternalURL:   externalURL,
@@ -278,15 +344,49 @@ func getDashboardPath(name string, conn grafanaConnectionInfo, dashboardSupplier
 		return "", nil
 	}
 
-	fullPath := dashPath.(string)
+	return conn.dashboardURL(dashPath.(string)), nil
+}
+
+// dashboardURL returns the external URL of the path of a dashboard
+func (conn grafanaConnectionInfo) dashboardURL(fullPath string) string {
 	if fullPath != "" {
 		// Dashboard path might be an absolute URL (hence starting with cfg.URL) or a relative one, depending on grafana's "GF_SERVER_SERVE_FROM_SUB_PATH"
 		if !strings.HasPrefix(fullPath, conn.baseExternalURL) {
 			fullPath = strings.TrimSuffix(conn.baseExternalURL, "/") + "/" + strings.TrimPrefix(fullPath, "/")
 		}
 	}
+	return fullPath + conn.externalURLParams
+}
 
-	return fullPath + conn.externalURLParams, nil
+// getTaggedDashboardLinks returns the links to the dashboards with any of the tags, named by their title
+func getTaggedDashboardLinks(tags []string, conn grafanaConnectionInfo, tagSupplier DashboardSupplierFunc) ([]models.ExternalLink, error) {
+	links := []models.ExternalLink{}
+	for _, tag := range tags {
+		body, code, err := tagSupplier(conn.inClusterURL, url.QueryEscape(tag), conn.auth)
+		if err != nil {
+			return nil, err
+		}
+		if code != http.StatusOK {
+			return nil, fmt.Errorf("error from Grafana (%d) when searching the dashboards of tag '%s'", code, tag)
+		}
+		var found []struct {
+			Title string `json:"title"`
+			URL   string `json:"url"`
+		}
+		if err := json.Unmarshal(body, &found); err != nil {
+			return nil, err
+		}
+		for _, dashboard := range found {
+			if dashboard.URL == "" {
+				continue
+			}
+			dashboardURL := conn.dashboardURL(dashboard.URL)
+			if !slices.ContainsFunc(links, func(link models.ExternalLink) bool { return link.URL == dashboardURL }) {
+				links = append(links, models.ExternalLink{URL: dashboardURL, Name: dashboard.Title})
+			}
+		}
+	}
+	return links, nil
 }
 
 func findDashboard(url, searchPattern string, auth *config.Auth) ([]byte, int, error) {
@@ -298,3 +398,13 @@ func findDashboard(url, searchPattern string, auth *config.Auth) ([]byte, int, e
 	resp, code, _, err := httputil.HttpGet(query, auth, time.Second*10, nil, nil)
 	return resp, code, err
 }
+
+func findDashboardsByTag(url, tag string, auth *config.Auth) ([]byte, int, error) {
+	urlParts := strings.Split(url, "?")
+	query := strings.TrimSuffix(urlParts[0], "/") + "/api/search?type=dash-db&tag=" + tag
+	if len(urlParts) > 1 {
+		query = query + "&" + urlParts[1]
+	}
+	resp, code, _, err := httputil.HttpGet(query, auth, time.Second*10, nil, nil)
+	return resp, code, err
+}
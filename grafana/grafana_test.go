@@ -39,6 +39,7 @@ func TestGetGrafanaInfoDisabled(t *testing.T) {
 
 	info, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(genDashboard("/some_path"), 200, "whatever", t),
 	)
 	assert.Nil(t, err)
@@ -56,6 +57,7 @@ func TestGetGrafanaInfoExternal(t *testing.T) {
 
 	info, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(genDashboard("/some_path"), 200, "http://grafana-external:3001", t),
 	)
 
@@ -65,6 +67,21 @@ func TestGetGrafanaInfoExternal(t *testing.T) {
 	assert.Equal(t, "http://grafana-external:3001/some_path", info.ExternalLinks[0].URL)
 }
 
+func TestGrafanaReload(t *testing.T) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Grafana.InClusterURL = ""
+	conf.ExternalServices.Grafana.URL = "http://grafana-external:3001"
+
+	grafana := grafana.NewService(conf, kubetest.NewFakeK8sClient())
+	assert.Equal(t, "http://grafana-external:3001", grafana.URL(context.Background()))
+
+	reloaded := *conf
+	reloaded.ExternalServices.Grafana.URL = "http://grafana.example.com"
+	grafana.Reload(&reloaded)
+	assert.Equal(t, "http://grafana.example.com", grafana.URL(context.Background()))
+	assert.Equal(t, "http://grafana-external:3001", conf.ExternalServices.Grafana.URL)
+}
+
 func TestGetGrafanaInfoInCluster(t *testing.T) {
 	conf := config.NewConfig()
 	conf.ExternalServices.Grafana.URL = "http://grafana-external:3001"
@@ -75,6 +92,7 @@ func TestGetGrafanaInfoInCluster(t *testing.T) {
 
 	info, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(genDashboard("/some_path"), 200, "http://grafana.istio-system:3001", t),
 	)
 
@@ -94,6 +112,7 @@ func TestGetGrafanaInfoGetError(t *testing.T) {
 
 	_, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(anError, 401, "http://grafana-external:3001", t),
 	)
 
@@ -111,6 +130,7 @@ func TestGetGrafanaInfoInvalidDashboard(t *testing.T) {
 
 	_, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier("unexpected response", 200, "http://grafana-external:3001", t),
 	)
 
@@ -129,6 +149,7 @@ func TestGetGrafanaInfoWithoutLeadingSlashPath(t *testing.T) {
 
 	info, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(genDashboard("some_path"), 200, "http://grafana-external:3001", t),
 	)
 
@@ -148,6 +169,7 @@ func TestGetGrafanaInfoWithTrailingSlashURL(t *testing.T) {
 
 	info, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(genDashboard("/some_path"), 200, "http://grafana-external:3001/", t),
 	)
 
@@ -167,6 +189,7 @@ func TestGetGrafanaInfoWithQueryParams(t *testing.T) {
 
 	info, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(genDashboard("/some_path"), 200, "http://grafana-external:3001/?orgId=1", t),
 	)
 
@@ -187,6 +210,7 @@ func TestGetGrafanaInfoWithAbsoluteDashboardURL(t *testing.T) {
 
 	info, code, err := grafana.Info(
 		context.Background(),
+		"",
 		buildDashboardSupplier(genDashboard("/system/grafana/some_path"), 200, "http://grafana.istio-system:3001", t),
 	)
 	assert.Nil(t, err)
@@ -202,3 +226,73 @@ func buildDashboardSupplier(jSon interface{}, code int, expectURL string, t *tes
 		return bytes, code, err
 	}
 }
+
+func TestGetGrafanaInfoOfCluster(t *testing.T) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Grafana.InClusterURL = ""
+	conf.ExternalServices.Grafana.URL = "http://grafana-external:3001"
+	conf.ExternalServices.Grafana.Dashboards = dashboardsConfig
+	conf.ExternalServices.Grafana.DatasourceUID = "prometheus"
+	conf.ExternalServices.Grafana.ClusterGrafana = map[string]config.GrafanaConfig{
+		"west": {
+			InClusterURL:  "http://grafana.istio-system:3001",
+			URL:           "http://grafana-west:3001?orgId=2",
+			DatasourceUID: "prometheus-west",
+		},
+	}
+
+	grafana := grafana.NewService(conf, kubetest.NewFakeK8sClient())
+
+	info, code, err := grafana.Info(
+		context.Background(),
+		"west",
+		buildDashboardSupplier(genDashboard("/some_path"), 200, "http://grafana.istio-system:3001", t),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, info.ExternalLinks, 1)
+	assert.Equal(t, "http://grafana-west:3001/some_path?orgId=2&var-datasource=prometheus-west", info.ExternalLinks[0].URL)
+
+	info, code, err = grafana.Info(
+		context.Background(),
+		"east",
+		buildDashboardSupplier(genDashboard("/some_path"), 200, "http://grafana-external:3001", t),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, info.ExternalLinks, 1)
+	assert.Equal(t, "http://grafana-external:3001/some_path?var-datasource=prometheus", info.ExternalLinks[0].URL)
+}
+
+func TestGetGrafanaInfoWithDashboardTags(t *testing.T) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Grafana.InClusterURL = ""
+	conf.ExternalServices.Grafana.URL = "http://grafana-external:3001"
+	conf.ExternalServices.Grafana.Dashboards = dashboardsConfig
+	conf.ExternalServices.Grafana.DashboardTags = []string{"istio", "mesh"}
+
+	tagSupplier := grafana.DashboardTagSupplier
+	defer func() { grafana.DashboardTagSupplier = tagSupplier }()
+	grafana.DashboardTagSupplier = func(_, tag string, _ *config.Auth) ([]byte, int, error) {
+		found := map[string][]map[string]string{
+			"istio": {{"title": "My Dashboard", "url": "/some_path"}, {"title": "Istio Mesh", "url": "/mesh_path"}},
+			"mesh":  {{"title": "Istio Mesh", "url": "/mesh_path"}},
+		}
+		bytes, err := json.Marshal(found[tag])
+		return bytes, 200, err
+	}
+
+	service := grafana.NewService(conf, kubetest.NewFakeK8sClient())
+
+	info, code, err := service.Info(
+		context.Background(),
+		"",
+		buildDashboardSupplier(genDashboard("/some_path"), 200, "http://grafana-external:3001", t),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, info.ExternalLinks, 2)
+	assert.Equal(t, "My Dashboard", info.ExternalLinks[0].Name)
+	assert.Equal(t, "Istio Mesh", info.ExternalLinks[1].Name)
+	assert.Equal(t, "http://grafana-external:3001/mesh_path", info.ExternalLinks[1].URL)
+}
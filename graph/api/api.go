@@ -6,8 +6,11 @@ import (
 	"net/http"
 
 	"github.com/kiali/kiali/business"
+	kialiConfig "github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/graph/config/dot"
+	"github.com/kiali/kiali/graph/config/servicegraph"
 	"github.com/kiali/kiali/graph/telemetry/istio"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/observability"
@@ -25,6 +28,8 @@ func GraphNamespaces(ctx context.Context, business *business.Layer, o graph.Opti
 	// time how long it takes to generate this graph
 	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
 	defer promtimer.ObserveDuration()
+	methodtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GraphNamespaces", "", graphNamespace(o))
+	defer methodtimer.ObserveDuration()
 
 	switch o.TelemetryVendor {
 	case graph.VendorIstio:
@@ -41,6 +46,17 @@ func GraphNamespaces(ctx context.Context, business *business.Layer, o graph.Opti
 	return code, config
 }
 
+// graphNamespace returns the namespace of a graph of a single namespace, empty for the graphs of many namespaces
+func graphNamespace(o graph.Options) string {
+	if len(o.Namespaces) != 1 {
+		return ""
+	}
+	for name := range o.Namespaces {
+		return name
+	}
+	return ""
+}
+
 // graphNamespacesIstio provides a test hook that accepts mock clients
 func graphNamespacesIstio(ctx context.Context, business *business.Layer, prom *prometheus.Client, o graph.Options) (code int, config interface{}) {
 
@@ -49,7 +65,28 @@ func graphNamespacesIstio(ctx context.Context, business *business.Layer, prom *p
 	globalInfo.Business = business
 	globalInfo.Context = ctx
 
-	trafficMap := istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+	var trafficMap graph.TrafficMap
+	// the diff mode annotates the traffic map, it can not use the shared traffic maps of the cache
+	if cacheConf := kialiConfig.Get().ExternalServices.Prometheus.GraphCache; cacheConf.Enabled && o.TelemetryOptions.CompareTime == 0 {
+		trafficMap = namespacesGraphCache.trafficMap(o.TelemetryOptions, cacheConf, func(to graph.TelemetryOptions) graph.TrafficMap {
+			return istio.BuildNamespacesTrafficMap(ctx, to, prom, globalInfo)
+		})
+	} else {
+		trafficMap = istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+	}
+
+	if o.TelemetryOptions.CompareTime != 0 {
+		compareOptions := o.CompareOptions()
+		compareTrafficMap := graph.NewTrafficMap()
+		if len(compareOptions.Namespaces) > 0 {
+			compareGlobalInfo := graph.NewAppenderGlobalInfo()
+			compareGlobalInfo.Business = business
+			compareGlobalInfo.Context = ctx
+			compareTrafficMap = istio.BuildNamespacesTrafficMap(ctx, compareOptions.TelemetryOptions, prom, compareGlobalInfo)
+		}
+		graph.DiffTrafficMaps(trafficMap, compareTrafficMap)
+	}
+
 	code, config = generateGraph(trafficMap, o)
 
 	return code, config
@@ -64,6 +101,8 @@ func GraphNode(ctx context.Context, business *business.Layer, o graph.Options) (
 	// time how long it takes to generate this graph
 	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
 	defer promtimer.ObserveDuration()
+	methodtimer := internalmetrics.GetBusinessMethodTimePrometheusTimer(ctx, "GraphNode", o.NodeOptions.Cluster, o.NodeOptions.Namespace)
+	defer methodtimer.ObserveDuration()
 
 	switch o.TelemetryVendor {
 	case graph.VendorIstio:
@@ -88,6 +127,19 @@ func graphNodeIstio(ctx context.Context, business *business.Layer, client *prome
 	globalInfo.Context = ctx
 
 	trafficMap, _ := istio.BuildNodeTrafficMap(o.TelemetryOptions, client, globalInfo)
+
+	if o.TelemetryOptions.CompareTime != 0 {
+		compareOptions := o.CompareOptions()
+		compareTrafficMap := graph.NewTrafficMap()
+		if len(compareOptions.Namespaces) > 0 {
+			compareGlobalInfo := graph.NewAppenderGlobalInfo()
+			compareGlobalInfo.Business = business
+			compareGlobalInfo.Context = ctx
+			compareTrafficMap, _ = istio.BuildNodeTrafficMap(compareOptions.TelemetryOptions, client, compareGlobalInfo)
+		}
+		graph.DiffTrafficMaps(trafficMap, compareTrafficMap)
+	}
+
 	code, config = generateGraph(trafficMap, o)
 
 	return code, config
@@ -100,8 +152,12 @@ func generateGraph(trafficMap graph.TrafficMap, o graph.Options) (int, interface
 	defer promtimer.ObserveDuration()
 
 	var vendorConfig interface{}
-	switch o.ConfigVendor {
-	case graph.VendorCytoscape:
+	switch {
+	case o.Format == graph.FormatDot:
+		vendorConfig = dot.NewConfig(trafficMap, o.ConfigOptions)
+	case o.Format == graph.FormatOTelServiceGraph:
+		vendorConfig = servicegraph.NewConfig(trafficMap, o.ConfigOptions)
+	case o.ConfigVendor == graph.VendorCytoscape:
 		vendorConfig = cytoscape.NewConfig(trafficMap, o.ConfigOptions)
 	default:
 		graph.Error(fmt.Sprintf("ConfigVendor [%s] not supported", o.ConfigVendor))
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/log"
+)
+
+// minGraphRefreshWindow is the shortest recent traffic queried to refresh a cached graph, rates need at least two
+// samples (assuming the default 15s scrape interval). A cached graph is regenerated when requested earlier.
+const minGraphRefreshWindow = 30 * time.Second
+
+// graphCacheEntry holds the traffic maps cached for the graph requests sharing a key
+type graphCacheEntry struct {
+	base       graph.TrafficMap // the traffic map of the whole graph duration, ending at baseTime
+	baseTime   int64            // unix time in seconds
+	created    time.Time
+	served     graph.TrafficMap // the last traffic map returned, base or a refresh of base, ending at servedTime
+	servedTime int64            // unix time in seconds
+}
+
+// graphCache caches the traffic maps of the namespace graphs, see config.GraphCache
+type graphCache struct {
+	entries map[string]*graphCacheEntry
+	lock    sync.Mutex
+}
+
+var namespacesGraphCache = newGraphCache()
+
+func newGraphCache() *graphCache {
+	return &graphCache{entries: map[string]*graphCacheEntry{}}
+}
+
+// graphCacheKey returns the key of the graph requests returning the same graph at the same time: the requested
+// options (all but the query time) and the namespaces accessible to the user.
+func graphCacheKey(o graph.TelemetryOptions) string {
+	params := make(map[string][]string, len(o.Params))
+	for k, v := range o.Params {
+		if k != "queryTime" {
+			params[k] = v
+		}
+	}
+	accessibleNamespaces := make([]string, 0, len(o.AccessibleNamespaces))
+	for k := range o.AccessibleNamespaces {
+		accessibleNamespaces = append(accessibleNamespaces, k)
+	}
+	sort.Strings(accessibleNamespaces)
+	namespaces := make([]string, 0, len(o.Namespaces))
+	for name, ns := range o.Namespaces {
+		namespaces = append(namespaces, fmt.Sprintf("%s=%v", name, ns.Duration))
+	}
+	sort.Strings(namespaces)
+
+	return fmt.Sprintf("%s|%s|%v|%s|%s", o.GraphType, o.Duration, params, strings.Join(namespaces, ","), strings.Join(accessibleNamespaces, ","))
+}
+
+// trafficMap returns the traffic map of the graph requested by o, from the cache when possible. Otherwise the
+// traffic map is built and cached, for the whole graph duration or only the recent traffic of a cached graph.
+// The returned traffic map is shared and must not be altered.
+func (c *graphCache) trafficMap(o graph.TelemetryOptions, conf config.GraphCache, build func(graph.TelemetryOptions) graph.TrafficMap) graph.TrafficMap {
+	key := graphCacheKey(o)
+	queryTime := o.QueryTime
+
+	c.lock.Lock()
+	entry, found := c.entries[key]
+	c.lock.Unlock()
+
+	if found {
+		if queryTime >= entry.servedTime && time.Duration(queryTime-entry.servedTime)*time.Second < time.Duration(conf.Duration)*time.Second {
+			log.Tracef("Graph cache hit [%s]", key)
+			return entry.served
+		}
+		if shift := time.Duration(queryTime-entry.baseTime) * time.Second; c.canRefresh(o, conf, shift) {
+			log.Tracef("Graph cache refresh [%s] [%v]", key, shift)
+			recentOptions := o
+			recentOptions.Duration = shift
+			recentOptions.Namespaces = graph.NewNamespaceInfoMap()
+			for name, ns := range o.Namespaces {
+				ns.Duration = shift
+				recentOptions.Namespaces[name] = ns
+			}
+			trafficMap := graph.ShiftTrafficMap(entry.base, build(recentOptions), o.Duration, shift)
+
+			c.lock.Lock()
+			c.entries[key] = &graphCacheEntry{
+				base:       entry.base,
+				baseTime:   entry.baseTime,
+				created:    entry.created,
+				served:     trafficMap,
+				servedTime: queryTime,
+			}
+			c.lock.Unlock()
+			return trafficMap
+		}
+	}
+
+	trafficMap := build(o)
+
+	c.lock.Lock()
+	c.purge(conf)
+	c.entries[key] = &graphCacheEntry{
+		base:       trafficMap,
+		baseTime:   queryTime,
+		created:    time.Now(),
+		served:     trafficMap,
+		servedTime: queryTime,
+	}
+	c.lock.Unlock()
+	return trafficMap
+}
+
+// canRefresh returns true if the cached graph ending shift before the requested graph can be refreshed with
+// the recent traffic. The recent traffic must be a small part of the graph duration, and every namespace must
+// cover the whole graph duration (the traffic of the namespaces created since then is not weighted the same).
+func (c *graphCache) canRefresh(o graph.TelemetryOptions, conf config.GraphCache, shift time.Duration) bool {
+	if shift < minGraphRefreshWindow || shift > time.Duration(conf.RefreshWindow)*time.Second || 2*shift > o.Duration {
+		return false
+	}
+	for _, ns := range o.Namespaces {
+		if ns.Duration != o.Duration {
+			return false
+		}
+	}
+	return true
+}
+
+// purge removes the entries too old to be served or refreshed, must be called with the lock held
+func (c *graphCache) purge(conf config.GraphCache) {
+	maxAge := time.Duration(conf.Duration) * time.Second
+	if refreshWindow := time.Duration(conf.RefreshWindow) * time.Second; refreshWindow > maxAge {
+		maxAge = refreshWindow
+	}
+	for key, entry := range c.entries {
+		if time.Since(entry.created) > maxAge {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+)
+
+func TestGraphCache(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.GraphCache{Duration: 30, Enabled: true, RefreshWindow: 120}
+	cache := newGraphCache()
+	now := time.Now().Unix()
+
+	options := func(queryTime int64, params url.Values) graph.TelemetryOptions {
+		o := graph.TelemetryOptions{
+			Namespaces: graph.NamespaceInfoMap{"bookinfo": {Name: "bookinfo", Duration: 10 * time.Minute}},
+		}
+		o.Params = params
+		o.Duration = 10 * time.Minute
+		o.GraphType = graph.GraphTypeWorkload
+		o.QueryTime = queryTime
+		return o
+	}
+
+	builds := []graph.TelemetryOptions{}
+	build := func(o graph.TelemetryOptions) graph.TrafficMap {
+		builds = append(builds, o)
+		n, err := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews", "reviews", "v1", graph.GraphTypeWorkload)
+		require.NoError(err)
+		n.Metadata[graph.MetadataKey("httpIn")] = float64(len(builds))
+		return graph.TrafficMap{n.ID: n}
+	}
+
+	params := url.Values{"namespaces": {"bookinfo"}, "queryTime": {"1"}}
+	first := cache.trafficMap(options(now, params), conf, build)
+	require.Len(builds, 1)
+
+	// served from the cache, the query time is not part of the key
+	cached := cache.trafficMap(options(now+10, url.Values{"namespaces": {"bookinfo"}, "queryTime": {"2"}}), conf, build)
+	require.Len(builds, 1)
+	assert.Equal(first, cached)
+
+	// other options
+	cache.trafficMap(options(now+10, url.Values{"namespaces": {"bookinfo"}, "appenders": {"idleNode"}}), conf, build)
+	require.Len(builds, 2)
+
+	// refreshed with the recent traffic only
+	refreshed := cache.trafficMap(options(now+60, params), conf, build)
+	require.Len(builds, 3)
+	assert.Equal(60*time.Second, builds[2].Duration)
+	assert.Equal(60*time.Second, builds[2].Namespaces["bookinfo"].Duration)
+	for _, n := range refreshed {
+		// 1 rps for 9m, 3 rps for 1m
+		assert.InDelta(1.2, n.Metadata[graph.MetadataKey("httpIn")], 0.001)
+	}
+
+	// too late to refresh, the whole graph is built again
+	cache.trafficMap(options(now+300, params), conf, build)
+	require.Len(builds, 4)
+	assert.Equal(10*time.Minute, builds[3].Duration)
+}
@@ -57,6 +57,16 @@ type ProtocolTraffic struct {
 	Responses Responses         `json:"responses,omitempty"` // see comment above
 }
 
+// DiffInfo compares a node or edge with the previous time window when the graph is requested with a compareTime
+type DiffInfo struct {
+	// Status is set when the node or edge is found in only one time window: new | removed
+	Status string `json:"status,omitempty"`
+	// ErrDelta is the change of the error rate (grpc and http), in requests per second
+	ErrDelta string `json:"errDelta"`
+	// RpsDelta is the change of the request rate (grpc and http), in requests per second
+	RpsDelta string `json:"rpsDelta"`
+}
+
 // GWInfo contains the resolved gateway configuration if the node represents an Istio gateway
 type GWInfo struct {
 	// IngressInfo contains the resolved gateway configuration if the node represents an Istio ingress gateway
@@ -97,6 +107,7 @@ type NodeData struct {
 	Service               string              `json:"service,omitempty"`               // requested service for NodeTypeService
 	Aggregate             string              `json:"aggregate,omitempty"`             // set like "<aggregate>=<aggregateVal>"
 	DestServices          []graph.ServiceName `json:"destServices,omitempty"`          // requested services for [dest] node
+	Diff                  *DiffInfo           `json:"diff,omitempty"`                  // changes since the compareTime window
 	Labels                map[string]string   `json:"labels,omitempty"`                // k8s labels associated with the node
 	Traffic               []ProtocolTraffic   `json:"traffic,omitempty"`               // traffic rates for all detected protocols
 	HealthData            interface{}         `json:"healthData"`                      // data to calculate health status from configurations
@@ -111,6 +122,7 @@ type NodeData struct {
 	HasTrafficShifting    bool                `json:"hasTrafficShifting,omitempty"`    // true (vs has traffic shifting) | false
 	HasVS                 *VSInfo             `json:"hasVS,omitempty"`                 // it can be empty if there is a VS without hostnames
 	HasWorkloadEntry      []graph.WEInfo      `json:"hasWorkloadEntry,omitempty"`      // static workload entry information | empty if there are no workload entries
+	IsAmbient             bool                `json:"isAmbient,omitempty"`             // true (captured by ztunnel, without sidecar) | false
 	IsBox                 string              `json:"isBox,omitempty"`                 // set for NodeTypeBox, current values: [ 'app', 'cluster', 'namespace' ]
 	IsDead                bool                `json:"isDead,omitempty"`                // true (has no pods) | false
 	IsGateway             *GWInfo             `json:"isGateway,omitempty"`             // Istio ingress/egress gateway information
@@ -122,6 +134,7 @@ type NodeData struct {
 	IsRoot                bool                `json:"isRoot,omitempty"`                // true | false
 	IsServiceEntry        *graph.SEInfo       `json:"isServiceEntry,omitempty"`        // set static service entry information
 	IsWaypoint            bool                `json:"isWaypoint,omitempty"`            // true | false
+	IsZtunnel             bool                `json:"isZtunnel,omitempty"`             // true | false
 }
 
 type EdgeData struct {
@@ -131,12 +144,15 @@ type EdgeData struct {
 	Target string `json:"target"` // child node ID
 
 	// App Fields (not required by Cytoscape)
-	DestPrincipal   string          `json:"destPrincipal,omitempty"`   // principal used for the edge destination
-	IsMTLS          string          `json:"isMTLS,omitempty"`          // set to the percentage of traffic using a mutual TLS connection
-	ResponseTime    string          `json:"responseTime,omitempty"`    // in millis
-	SourcePrincipal string          `json:"sourcePrincipal,omitempty"` // principal used for the edge source
-	Throughput      string          `json:"throughput,omitempty"`      // in bytes/sec (request or response, depends on client request)
-	Traffic         ProtocolTraffic `json:"traffic,omitempty"`         // traffic rates for the edge protocol
+	AmbientLayer    string             `json:"ambientLayer,omitempty"`    // ambient layer carrying the traffic: L4 (ztunnel) | L7 (waypoint)
+	DestPrincipal   string             `json:"destPrincipal,omitempty"`   // principal used for the edge destination
+	Diff            *DiffInfo          `json:"diff,omitempty"`            // changes since the compareTime window
+	IsBlocked       *graph.BlockedInfo `json:"isBlocked,omitempty"`       // set when the edge traffic would be blocked by the current policies
+	IsMTLS          string             `json:"isMTLS,omitempty"`          // set to the percentage of traffic using a mutual TLS connection
+	ResponseTime    string             `json:"responseTime,omitempty"`    // in millis
+	SourcePrincipal string             `json:"sourcePrincipal,omitempty"` // principal used for the edge source
+	Throughput      string             `json:"throughput,omitempty"`      // in bytes/sec (request or response, depends on client request)
+	Traffic         ProtocolTraffic    `json:"traffic,omitempty"`         // traffic rates for the edge protocol
 }
 
 type NodeWrapper struct {
@@ -153,10 +169,11 @@ type Elements struct {
 }
 
 type Config struct {
-	Timestamp int64    `json:"timestamp"`
-	Duration  int64    `json:"duration"`
-	GraphType string   `json:"graphType"`
-	Elements  Elements `json:"elements"`
+	Timestamp        int64    `json:"timestamp"`
+	CompareTimestamp int64    `json:"compareTimestamp,omitempty"`
+	Duration         int64    `json:"duration"`
+	GraphType        string   `json:"graphType"`
+	Elements         Elements `json:"elements"`
 }
 
 func nodeHash(id string) string {
@@ -231,10 +248,11 @@ func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) (result Confi
 
 	elements := Elements{nodes, edges}
 	result = Config{
-		Duration:  int64(o.Duration.Seconds()),
-		Timestamp: o.QueryTime,
-		GraphType: o.GraphType,
-		Elements:  elements,
+		Duration:         int64(o.Duration.Seconds()),
+		Timestamp:        o.QueryTime,
+		CompareTimestamp: o.CompareTime,
+		GraphType:        o.GraphType,
+		Elements:         elements,
 	}
 	return result
 }
@@ -358,6 +376,14 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			nd.IsWaypoint = val.(bool)
 		}
 
+		// check if node is part of the ambient data plane
+		if val, ok := n.Metadata[graph.IsAmbient]; ok {
+			nd.IsAmbient = val.(bool)
+		}
+		if val, ok := n.Metadata[graph.IsZtunnel]; ok {
+			nd.IsZtunnel = val.(bool)
+		}
+
 		if val, ok := n.Metadata[graph.HasMirroring]; ok {
 			nd.HasMirroring = val.(bool)
 		}
@@ -407,6 +433,10 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			}
 		}
 
+		if val, ok := n.Metadata[graph.Diff]; ok {
+			nd.Diff = newDiffInfo(val.(*graph.DiffInfo))
+		}
+
 		// node may be an aggregate
 		if n.NodeType == graph.NodeTypeAggregate {
 			nd.Aggregate = fmt.Sprintf("%s=%s", n.Metadata[graph.Aggregate].(string), n.Metadata[graph.AggregateValue].(string))
@@ -434,6 +464,15 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 					Protocol: protocol,
 				},
 			}
+			if e.Metadata[graph.AmbientLayer] != nil {
+				ed.AmbientLayer = e.Metadata[graph.AmbientLayer].(string)
+			}
+			if e.Metadata[graph.Diff] != nil {
+				ed.Diff = newDiffInfo(e.Metadata[graph.Diff].(*graph.DiffInfo))
+			}
+			if e.Metadata[graph.IsBlocked] != nil {
+				ed.IsBlocked = e.Metadata[graph.IsBlocked].(*graph.BlockedInfo)
+			}
 			if e.Metadata[graph.DestPrincipal] != nil {
 				ed.DestPrincipal = e.Metadata[graph.DestPrincipal].(string)
 			}
@@ -558,6 +597,14 @@ func addEdgeTelemetry(e *graph.Edge, ed *EdgeData) {
 	}
 }
 
+func newDiffInfo(diff *graph.DiffInfo) *DiffInfo {
+	return &DiffInfo{
+		Status:   diff.Status,
+		ErrDelta: fmt.Sprintf("%.2f", diff.ErrDelta),
+		RpsDelta: fmt.Sprintf("%.2f", diff.RpsDelta),
+	}
+}
+
 func getRate(md graph.Metadata, k graph.MetadataKey) float64 {
 	if rate, ok := md[k]; ok {
 		return rate.(float64)
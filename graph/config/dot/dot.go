@@ -0,0 +1,115 @@
+// Package dot provides the export of the graph in the Graphviz DOT language, to be rendered or processed
+// by graphviz pipelines.
+//
+// DOT reference: https://graphviz.org/doc/info/lang.html
+//
+// Nodes are shaped after the node type (box: workload, ellipse: app, triangle: service, diamond: aggregate,
+// unknown and outside nodes are dashed), edges are labeled with their rate and, for request protocols, their
+// error percentage. Edges with errors are red. When the graph is boxed by namespace each namespace is rendered
+// as a cluster subgraph.
+package dot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kiali/kiali/graph"
+)
+
+// Config is the DOT representation of a graph
+type Config string
+
+// NewConfig returns the DOT representation of the traffic map
+func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) Config {
+	ids := make([]string, 0, len(trafficMap))
+	for id := range trafficMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var b strings.Builder
+	b.WriteString("digraph kiali {\n")
+	fmt.Fprintf(&b, "  label=%s;\n", quote(fmt.Sprintf("%s graph, %v ending at %d", o.GraphType, o.Duration, o.QueryTime)))
+	b.WriteString("  node [fontname=\"sans-serif\"];\n")
+	b.WriteString("  edge [fontname=\"sans-serif\" fontsize=10];\n")
+
+	if strings.Contains(o.BoxBy, graph.BoxByNamespace) {
+		namespaces := map[string][]string{}
+		names := []string{}
+		for _, id := range ids {
+			key := fmt.Sprintf("%s/%s", trafficMap[id].Cluster, trafficMap[id].Namespace)
+			if _, ok := namespaces[key]; !ok {
+				names = append(names, key)
+			}
+			namespaces[key] = append(namespaces[key], id)
+		}
+		sort.Strings(names)
+		for i, key := range names {
+			fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+			fmt.Fprintf(&b, "    label=%s;\n", quote(key))
+			for _, id := range namespaces[key] {
+				writeNode(&b, "    ", trafficMap[id])
+			}
+			b.WriteString("  }\n")
+		}
+	} else {
+		for _, id := range ids {
+			writeNode(&b, "  ", trafficMap[id])
+		}
+	}
+
+	for _, id := range ids {
+		edges := append([]*graph.Edge{}, trafficMap[id].Edges...)
+		sort.SliceStable(edges, func(i, j int) bool {
+			return edges[i].Dest.ID < edges[j].Dest.ID
+		})
+		for _, e := range edges {
+			writeEdge(&b, e)
+		}
+	}
+	b.WriteString("}\n")
+
+	return Config(b.String())
+}
+
+func writeNode(b *strings.Builder, indent string, n *graph.Node) {
+	attrs := []string{
+		"label=" + quote(fmt.Sprintf("%s\n%s", n.Name(), n.Namespace)),
+	}
+	switch n.NodeType {
+	case graph.NodeTypeAggregate:
+		attrs = append(attrs, "shape=diamond")
+	case graph.NodeTypeService:
+		attrs = append(attrs, "shape=triangle")
+	case graph.NodeTypeWorkload:
+		attrs = append(attrs, "shape=box")
+	default:
+		attrs = append(attrs, "shape=ellipse")
+	}
+	if n.NodeType == graph.NodeTypeUnknown || n.Metadata[graph.IsOutside] == true || n.Metadata[graph.IsInaccessible] == true {
+		attrs = append(attrs, "style=dashed")
+	}
+	fmt.Fprintf(b, "%s%s [%s];\n", indent, quote(n.ID), strings.Join(attrs, " "))
+}
+
+func writeEdge(b *strings.Builder, e *graph.Edge) {
+	attrs := []string{}
+	if protocol, rate, ok := graph.EdgeRate(e); ok {
+		label := fmt.Sprintf("%s %.2f %s", protocol.Name, rate, protocol.UnitShort)
+		if reqRate, errRate := graph.EdgeRequestRates(e); reqRate > 0 && errRate > 0 {
+			label = fmt.Sprintf("%s\n%.1f%% err", label, 100*errRate/reqRate)
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		}
+		attrs = append(attrs, "label="+quote(label))
+	}
+	fmt.Fprintf(b, "  %s -> %s [%s];\n", quote(e.Source.ID), quote(e.Dest.ID), strings.Join(attrs, " "))
+}
+
+// quote returns the DOT quoted string, newlines are rendered as centered line breaks
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,50 @@
+package dot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func TestNewConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	productpage, err := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+	require.NoError(err)
+	reviews, err := graph.NewNode("east", "bookinfo", "reviews", "", "", "", "", graph.GraphTypeWorkload)
+	require.NoError(err)
+	trafficMap := graph.TrafficMap{productpage.ID: productpage, reviews.ID: reviews}
+
+	e := productpage.AddEdge(reviews)
+	e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	graph.AddToMetadata(graph.HTTP.Name, 9, "200", "-", "reviews", productpage.Metadata, reviews.Metadata, e.Metadata)
+	graph.AddToMetadata(graph.HTTP.Name, 1, "503", "-", "reviews", productpage.Metadata, reviews.Metadata, e.Metadata)
+
+	config := string(NewConfig(trafficMap, graph.ConfigOptions{
+		BoxBy: graph.BoxByNamespace,
+		CommonOptions: graph.CommonOptions{
+			Duration:  10 * time.Minute,
+			GraphType: graph.GraphTypeWorkload,
+			QueryTime: 1700000000,
+		},
+	}))
+
+	assert.Contains(config, "digraph kiali {\n")
+	assert.Contains(config, `label="workload graph, 10m0s ending at 1700000000";`)
+	assert.Contains(config, "  subgraph cluster_0 {\n    label=\"east/bookinfo\";\n")
+	assert.Contains(config, `"`+productpage.ID+`" [label="productpage-v1\nbookinfo" shape=box];`)
+	assert.Contains(config, `"`+reviews.ID+`" [label="reviews\nbookinfo" shape=triangle];`)
+	assert.Contains(config, `"`+productpage.ID+`" -> "`+reviews.ID+`" [color=red fontcolor=red label="http 10.00 rps\n10.0% err"];`)
+}
+
+func TestQuote(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`"reviews"`, quote("reviews"))
+	assert.Equal(`"a \"b\" \\ c\nd"`, quote("a \"b\" \\ c\nd"))
+}
@@ -0,0 +1,121 @@
+// Package servicegraph provides the export of the graph in the OpenTelemetry service graph model, the model of
+// the OpenTelemetry Collector servicegraph connector: edges between a client and a server, with their request
+// and failed request rates, and a connection type ("virtual_node" when one side is not part of the mesh).
+//
+// The fields of the nodes and edges follow the data frame conventions of the Grafana node graph panel (id, title,
+// subtitle, mainstat, secondarystat, arc__*), so the export can be visualized by a service graph panel as is.
+//
+// Grafana node graph: https://grafana.com/docs/grafana/latest/panels-visualizations/visualizations/node-graph/
+package servicegraph
+
+import (
+	"sort"
+
+	"github.com/kiali/kiali/graph"
+)
+
+const connectionTypeVirtualNode = "virtual_node"
+
+type Node struct {
+	ID            string  `json:"id"`
+	Title         string  `json:"title"`           // node name
+	Subtitle      string  `json:"subtitle"`        // node namespace
+	MainStat      float64 `json:"mainstat"`        // incoming requests per second (grpc, http)
+	SecondaryStat float64 `json:"secondarystat"`   // incoming failed requests per second (grpc, http)
+	ArcFailed     float64 `json:"arc__failed"`     // ratio of failed incoming requests
+	ArcSuccess    float64 `json:"arc__success"`    // ratio of successful incoming requests, 1 without incoming requests
+	Cluster       string  `json:"detail__cluster"` // node cluster
+	NodeType      string  `json:"detail__nodeType"`
+}
+
+type Edge struct {
+	ID             string  `json:"id"`
+	Source         string  `json:"source"`
+	Target         string  `json:"target"`
+	Client         string  `json:"client"`                    // source node name
+	Server         string  `json:"server"`                    // destination node name
+	ConnectionType string  `json:"connection_type,omitempty"` // virtual_node when the client or the server is not part of the mesh
+	MainStat       float64 `json:"mainstat"`                  // rate, in requests per second (grpc, http) or bytes per second (tcp)
+	SecondaryStat  float64 `json:"secondarystat"`             // failed requests per second (grpc, http)
+	Protocol       string  `json:"detail__protocol"`
+	ResponseTime   float64 `json:"detail__responseTime,omitempty"` // in millis
+}
+
+type Config struct {
+	Timestamp int64   `json:"timestamp"`
+	Duration  int64   `json:"duration"`
+	GraphType string  `json:"graphType"`
+	Nodes     []*Node `json:"nodes"`
+	Edges     []*Edge `json:"edges"`
+}
+
+// NewConfig returns the OpenTelemetry service graph representation of the traffic map
+func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) Config {
+	config := Config{
+		Timestamp: o.QueryTime,
+		Duration:  int64(o.Duration.Seconds()),
+		GraphType: o.GraphType,
+		Nodes:     []*Node{},
+		Edges:     []*Edge{},
+	}
+
+	for _, n := range trafficMap {
+		reqRate, errRate := graph.NodeRequestRates(n)
+		node := &Node{
+			ID:            n.ID,
+			Title:         n.Name(),
+			Subtitle:      n.Namespace,
+			MainStat:      reqRate,
+			SecondaryStat: errRate,
+			ArcSuccess:    1.0,
+			Cluster:       n.Cluster,
+			NodeType:      n.NodeType,
+		}
+		if reqRate > 0 {
+			node.ArcFailed = errRate / reqRate
+			node.ArcSuccess = 1.0 - node.ArcFailed
+		}
+		config.Nodes = append(config.Nodes, node)
+
+		for _, e := range n.Edges {
+			edge := &Edge{
+				ID:     e.Source.ID + " " + e.Dest.ID,
+				Source: e.Source.ID,
+				Target: e.Dest.ID,
+				Client: e.Source.Name(),
+				Server: e.Dest.Name(),
+			}
+			if protocol, rate, ok := graph.EdgeRate(e); ok {
+				edge.ID += " " + protocol.Name
+				edge.MainStat = rate
+				edge.Protocol = protocol.Name
+				_, edge.SecondaryStat = graph.EdgeRequestRates(e)
+			}
+			if responseTime, ok := e.Metadata[graph.ResponseTime].(float64); ok {
+				edge.ResponseTime = responseTime
+			}
+			if isVirtualNode(e.Source) || isVirtualNode(e.Dest) {
+				edge.ConnectionType = connectionTypeVirtualNode
+			}
+			config.Edges = append(config.Edges, edge)
+		}
+	}
+
+	sort.Slice(config.Nodes, func(i, j int) bool {
+		return config.Nodes[i].ID < config.Nodes[j].ID
+	})
+	sort.Slice(config.Edges, func(i, j int) bool {
+		return config.Edges[i].ID < config.Edges[j].ID
+	})
+
+	return config
+}
+
+// isVirtualNode returns true for the nodes outside of the mesh: unknown sources, service entries and egress clusters
+func isVirtualNode(n *graph.Node) bool {
+	if n.NodeType == graph.NodeTypeUnknown || n.Metadata[graph.IsEgressCluster] == true {
+		return true
+	}
+	_, isServiceEntry := n.Metadata[graph.IsServiceEntry]
+	return isServiceEntry
+}
@@ -0,0 +1,78 @@
+package servicegraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func TestNewConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	unknown, err := graph.NewNode(graph.Unknown, graph.Unknown, "", graph.Unknown, graph.Unknown, graph.Unknown, graph.Unknown, graph.GraphTypeVersionedApp)
+	require.NoError(err)
+	productpage, err := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	require.NoError(err)
+	reviews, err := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v2", "reviews", "v2", graph.GraphTypeVersionedApp)
+	require.NoError(err)
+	trafficMap := graph.TrafficMap{unknown.ID: unknown, productpage.ID: productpage, reviews.ID: reviews}
+
+	e := unknown.AddEdge(productpage)
+	e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	graph.AddToMetadata(graph.HTTP.Name, 10, "200", "-", "productpage", unknown.Metadata, productpage.Metadata, e.Metadata)
+	e = productpage.AddEdge(reviews)
+	e.Metadata[graph.ProtocolKey] = graph.GRPC.Name
+	e.Metadata[graph.ResponseTime] = 25.0
+	graph.AddToMetadata(graph.GRPC.Name, 6, "0", "-", "reviews", productpage.Metadata, reviews.Metadata, e.Metadata)
+	graph.AddToMetadata(graph.GRPC.Name, 2, "14", "-", "reviews", productpage.Metadata, reviews.Metadata, e.Metadata)
+
+	config := NewConfig(trafficMap, graph.ConfigOptions{
+		CommonOptions: graph.CommonOptions{
+			Duration:  10 * time.Minute,
+			GraphType: graph.GraphTypeVersionedApp,
+			QueryTime: 1700000000,
+		},
+	})
+
+	assert.Equal(int64(1700000000), config.Timestamp)
+	assert.Equal(int64(600), config.Duration)
+	require.Len(config.Nodes, 3)
+	require.Len(config.Edges, 2)
+
+	nodes := map[string]*Node{}
+	for _, n := range config.Nodes {
+		nodes[n.ID] = n
+	}
+	assert.Equal("reviews:v2", nodes[reviews.ID].Title)
+	assert.Equal("bookinfo", nodes[reviews.ID].Subtitle)
+	assert.Equal(8.0, nodes[reviews.ID].MainStat)
+	assert.Equal(2.0, nodes[reviews.ID].SecondaryStat)
+	assert.Equal(0.25, nodes[reviews.ID].ArcFailed)
+	assert.Equal(0.75, nodes[reviews.ID].ArcSuccess)
+	assert.Equal(1.0, nodes[unknown.ID].ArcSuccess)
+
+	for _, e := range config.Edges {
+		switch e.Source {
+		case unknown.ID:
+			assert.Equal(graph.Unknown, e.Client)
+			assert.Equal("productpage:v1", e.Server)
+			assert.Equal(connectionTypeVirtualNode, e.ConnectionType)
+			assert.Equal(10.0, e.MainStat)
+			assert.Equal(0.0, e.SecondaryStat)
+		case productpage.ID:
+			assert.Equal("reviews:v2", e.Server)
+			assert.Empty(e.ConnectionType)
+			assert.Equal(graph.GRPC.Name, e.Protocol)
+			assert.Equal(8.0, e.MainStat)
+			assert.Equal(2.0, e.SecondaryStat)
+			assert.Equal(25.0, e.ResponseTime)
+		default:
+			assert.Fail("unexpected edge", e.ID)
+		}
+	}
+}
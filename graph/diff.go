@@ -0,0 +1,146 @@
+package graph
+
+// Diff.go compares the traffic map of a graph with the traffic map of a previous time window. Every node
+// and edge of the graph is annotated with a DiffInfo. Nodes and edges only found in the previous time
+// window are added to the graph, flagged as removed.
+
+// Values of the DiffInfo status
+const (
+	DiffStatusNew     = "new"     // only found in the current time window
+	DiffStatusRemoved = "removed" // only found in the previous time window
+)
+
+// DiffInfo compares a node or an edge with the previous time window. The deltas are the current
+// rates minus the previous rates, only request protocols (grpc, http) are accounted.
+type DiffInfo struct {
+	Status   string  // DiffStatusNew | DiffStatusRemoved, empty when found in both time windows
+	ErrDelta float64 // error requests per second
+	RpsDelta float64 // requests per second, incoming requests for a node
+}
+
+func diffEdgeKey(e *Edge) string {
+	protocol, _ := e.Metadata[ProtocolKey].(string)
+	return e.Source.ID + " " + e.Dest.ID + " " + protocol
+}
+
+// DiffTrafficMaps annotates the nodes and edges of trafficMap with the changes since previousTrafficMap,
+// adding the nodes and edges that were removed since then.
+func DiffTrafficMaps(trafficMap, previousTrafficMap TrafficMap) {
+	previousEdges := map[string]*Edge{}
+	for _, e := range previousTrafficMap.Edges() {
+		previousEdges[diffEdgeKey(e)] = e
+	}
+	currentEdges := map[string]bool{}
+
+	for id, n := range trafficMap {
+		reqRate, errRate := NodeRequestRates(n)
+		if previous, found := previousTrafficMap[id]; found {
+			previousReqRate, previousErrRate := NodeRequestRates(previous)
+			n.Metadata[Diff] = &DiffInfo{RpsDelta: reqRate - previousReqRate, ErrDelta: errRate - previousErrRate}
+		} else {
+			n.Metadata[Diff] = &DiffInfo{Status: DiffStatusNew, RpsDelta: reqRate, ErrDelta: errRate}
+		}
+
+		for _, e := range n.Edges {
+			key := diffEdgeKey(e)
+			currentEdges[key] = true
+			reqRate, errRate := EdgeRequestRates(e)
+			if previous, found := previousEdges[key]; found {
+				previousReqRate, previousErrRate := EdgeRequestRates(previous)
+				e.Metadata[Diff] = &DiffInfo{RpsDelta: reqRate - previousReqRate, ErrDelta: errRate - previousErrRate}
+			} else {
+				e.Metadata[Diff] = &DiffInfo{Status: DiffStatusNew, RpsDelta: reqRate, ErrDelta: errRate}
+			}
+		}
+	}
+
+	for id, previous := range previousTrafficMap {
+		if _, found := trafficMap[id]; found {
+			continue
+		}
+		reqRate, errRate := NodeRequestRates(previous)
+		n := &Node{
+			ID:        previous.ID,
+			NodeType:  previous.NodeType,
+			Cluster:   previous.Cluster,
+			Namespace: previous.Namespace,
+			Workload:  previous.Workload,
+			App:       previous.App,
+			Version:   previous.Version,
+			Service:   previous.Service,
+			Edges:     []*Edge{},
+			Metadata:  removedMetadata(previous.Metadata),
+		}
+		n.Metadata[Diff] = &DiffInfo{Status: DiffStatusRemoved, RpsDelta: -reqRate, ErrDelta: -errRate}
+		trafficMap[id] = n
+	}
+
+	for key, previous := range previousEdges {
+		if currentEdges[key] {
+			continue
+		}
+		reqRate, errRate := EdgeRequestRates(previous)
+		e := trafficMap[previous.Source.ID].AddEdge(trafficMap[previous.Dest.ID])
+		if protocol, ok := previous.Metadata[ProtocolKey]; ok {
+			e.Metadata[ProtocolKey] = protocol
+		}
+		e.Metadata[Diff] = &DiffInfo{Status: DiffStatusRemoved, RpsDelta: -reqRate, ErrDelta: -errRate}
+	}
+}
+
+// removedMetadata returns the metadata of a removed node, without the traffic of the previous time window.
+func removedMetadata(metadata Metadata) Metadata {
+	removed := NewMetadata()
+	for k, v := range metadata {
+		removed[k] = v
+	}
+	for _, p := range Protocols {
+		for _, r := range p.NodeRates {
+			delete(removed, r.Name)
+		}
+	}
+	return removed
+}
+
+// NodeRequestRates returns the incoming request rate and error rate of the node, request protocols (grpc, http) only
+func NodeRequestRates(n *Node) (reqRate, errRate float64) {
+	for _, p := range Protocols {
+		if p.UnitShort != rps {
+			continue
+		}
+		for _, r := range p.NodeRates {
+			switch {
+			case r.IsIn:
+				reqRate += diffRate(n.Metadata, r.Name)
+			case r.IsErr:
+				errRate += diffRate(n.Metadata, r.Name)
+			}
+		}
+	}
+	return reqRate, errRate
+}
+
+// EdgeRequestRates returns the request rate and error rate of the edge, zero for a non-request protocol (tcp)
+func EdgeRequestRates(e *Edge) (reqRate, errRate float64) {
+	for _, p := range Protocols {
+		if p.UnitShort != rps || e.Metadata[ProtocolKey] != p.Name {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			switch {
+			case r.IsTotal:
+				reqRate += diffRate(e.Metadata, r.Name)
+			case r.IsErr:
+				errRate += diffRate(e.Metadata, r.Name)
+			}
+		}
+	}
+	return reqRate, errRate
+}
+
+func diffRate(md Metadata, k MetadataKey) float64 {
+	if rate, ok := md[k].(float64); ok {
+		return rate
+	}
+	return 0.0
+}
@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDiffTestNode(t *testing.T, workload string, inRate float64) *Node {
+	n, err := NewNode("east", "bookinfo", "", "bookinfo", workload, workload, "v1", GraphTypeWorkload)
+	require.NoError(t, err)
+	if inRate > 0 {
+		n.Metadata[httpIn] = inRate
+	}
+	return n
+}
+
+func addDiffTestEdge(source, dest *Node, rate, rate5xx float64) {
+	e := source.AddEdge(dest)
+	e.Metadata[ProtocolKey] = HTTP.Name
+	e.Metadata[http] = rate
+	e.Metadata[http5xx] = rate5xx
+	e.Metadata[httpResponses] = Responses{}
+}
+
+func TestDiffTrafficMaps(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// previous: productpage -> reviews -> ratings
+	previous := NewTrafficMap()
+	productpage := newDiffTestNode(t, "productpage", 0)
+	reviews := newDiffTestNode(t, "reviews", 10)
+	ratings := newDiffTestNode(t, "ratings", 4)
+	addDiffTestEdge(productpage, reviews, 10, 1)
+	addDiffTestEdge(reviews, ratings, 4, 0)
+	for _, n := range []*Node{productpage, reviews, ratings} {
+		previous[n.ID] = n
+	}
+
+	// current: productpage -> reviews -> details, ratings is gone
+	current := NewTrafficMap()
+	productpage = newDiffTestNode(t, "productpage", 0)
+	reviews = newDiffTestNode(t, "reviews", 15)
+	details := newDiffTestNode(t, "details", 2)
+	addDiffTestEdge(productpage, reviews, 15, 3)
+	addDiffTestEdge(reviews, details, 2, 0)
+	for _, n := range []*Node{productpage, reviews, details} {
+		current[n.ID] = n
+	}
+
+	DiffTrafficMaps(current, previous)
+
+	require.Len(current, 4)
+	assert.Equal(&DiffInfo{RpsDelta: 5}, current[reviews.ID].Metadata[Diff])
+	assert.Equal(&DiffInfo{Status: DiffStatusNew, RpsDelta: 2}, current[details.ID].Metadata[Diff])
+
+	removed := current[ratings.ID]
+	require.NotNil(removed)
+	assert.Equal("ratings", removed.Workload)
+	assert.Equal(&DiffInfo{Status: DiffStatusRemoved, RpsDelta: -4}, removed.Metadata[Diff])
+	assert.NotContains(removed.Metadata, MetadataKey(httpIn))
+
+	require.Len(current[productpage.ID].Edges, 1)
+	assert.Equal(&DiffInfo{RpsDelta: 5, ErrDelta: 2}, current[productpage.ID].Edges[0].Metadata[Diff])
+
+	require.Len(current[reviews.ID].Edges, 2)
+	for _, e := range current[reviews.ID].Edges {
+		switch e.Dest.ID {
+		case details.ID:
+			assert.Equal(&DiffInfo{Status: DiffStatusNew, RpsDelta: 2}, e.Metadata[Diff])
+		case ratings.ID:
+			assert.Same(removed, e.Dest)
+			assert.Equal(HTTP.Name, e.Metadata[ProtocolKey])
+			assert.Equal(&DiffInfo{Status: DiffStatusRemoved, RpsDelta: -4}, e.Metadata[Diff])
+		default:
+			assert.Fail("unexpected edge", e.Dest.ID)
+		}
+	}
+}
+
+func TestCompareOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	o := Options{
+		TelemetryOptions: TelemetryOptions{
+			AccessibleNamespaces: AccessibleNamespaces{
+				"east:bookinfo": {Cluster: "east", Name: "bookinfo", CreationTimestamp: now.Add(-48 * time.Hour)},
+				"east:travels":  {Cluster: "east", Name: "travels", CreationTimestamp: now.Add(-24*time.Hour - 5*time.Minute)},
+				"east:shop":     {Cluster: "east", Name: "shop", CreationTimestamp: now.Add(-time.Hour)},
+			},
+			Namespaces: NamespaceInfoMap{
+				"bookinfo": {Name: "bookinfo", Duration: 10 * time.Minute},
+				"travels":  {Name: "travels", Duration: 10 * time.Minute},
+				"shop":     {Name: "shop", Duration: 10 * time.Minute},
+			},
+			CommonOptions: CommonOptions{
+				CompareTime: now.Add(-24 * time.Hour).Unix(),
+				Duration:    10 * time.Minute,
+				QueryTime:   now.Unix(),
+			},
+		},
+	}
+	o.ConfigOptions.CommonOptions = o.TelemetryOptions.CommonOptions
+
+	compareOptions := o.CompareOptions()
+
+	assert.Equal(o.TelemetryOptions.CompareTime, compareOptions.TelemetryOptions.QueryTime)
+	assert.Equal(o.TelemetryOptions.CompareTime, compareOptions.ConfigOptions.QueryTime)
+	assert.Equal(10*time.Minute, compareOptions.Namespaces["bookinfo"].Duration)
+	assert.InDelta(5*time.Minute, compareOptions.Namespaces["travels"].Duration, float64(time.Second))
+	assert.NotContains(compareOptions.Namespaces, "shop")
+
+	// the requested options are left untouched
+	assert.Len(o.Namespaces, 3)
+	assert.Equal(now.Unix(), o.TelemetryOptions.QueryTime)
+}
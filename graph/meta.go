@@ -15,8 +15,10 @@ func NewMetadata() Metadata {
 const (
 	Aggregate             MetadataKey = "aggregate" // the prom attribute used for aggregation
 	AggregateValue        MetadataKey = "aggregateValue"
+	AmbientLayer          MetadataKey = "ambientLayer" // the ambient data plane layer carrying the edge traffic: L4 (ztunnel) or L7 (waypoint)
 	DestPrincipal         MetadataKey = "destPrincipal"
 	DestServices          MetadataKey = "destServices"
+	Diff                  MetadataKey = "diff" // *DiffInfo, set when the graph is compared with a previous time window
 	HealthData            MetadataKey = "healthData"
 	HealthDataApp         MetadataKey = "healthDataApp" // for storing app health on versioned app nodes
 	HasCB                 MetadataKey = "hasCB"
@@ -29,6 +31,8 @@ const (
 	HasRequestTimeout     MetadataKey = "hasRequestTimeout"
 	HasVS                 MetadataKey = "hasVS"
 	HasWorkloadEntry      MetadataKey = "hasWorkloadEntry"
+	IsAmbient             MetadataKey = "isAmbient" // Identifies a node captured by ztunnel, without sidecar
+	IsBlocked             MetadataKey = "isBlocked" // *BlockedInfo, the edge traffic would be blocked by the current policies
 	IsDead                MetadataKey = "isDead"
 	IsEgressCluster       MetadataKey = "isEgressCluster"  // PassthroughCluster or BlackHoleCluster
 	IsEgressGateway       MetadataKey = "isEgressGateway"  // Identifies a node that is an Istio egress gateway
@@ -44,6 +48,7 @@ const (
 	IsRoot                MetadataKey = "isRoot"
 	IsServiceEntry        MetadataKey = "isServiceEntry"
 	IsWaypoint            MetadataKey = "isWaypoint"
+	IsZtunnel             MetadataKey = "isZtunnel"
 	Labels                MetadataKey = "labels"
 	ProtocolKey           MetadataKey = "protocol"
 	ResponseTime          MetadataKey = "responseTime"
@@ -51,6 +56,19 @@ const (
 	Throughput            MetadataKey = "throughput"
 )
 
+// Values of the AmbientLayer edge metadata
+const (
+	AmbientLayerL4 = "L4" // traffic tunneled by ztunnel, only TCP telemetry is available
+	AmbientLayerL7 = "L7" // traffic going through a waypoint proxy
+)
+
+// Values of the BlockedInfo reason
+const (
+	BlockedReasonDeny          = "deny"          // a DENY authorization policy matches the requests
+	BlockedReasonNotAllowed    = "notAllowed"    // ALLOW authorization policies apply but none matches the requests
+	BlockedReasonSidecarEgress = "sidecarEgress" // the Sidecar of the source does not expose the destination
+)
+
 // DestServicesMetadata key=Service.Key()
 type DestServicesMetadata map[string]ServiceName
 
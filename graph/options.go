@@ -29,6 +29,12 @@ const (
 	defaultTelemetryVendor string = VendorIstio
 )
 
+// The supported export formats, replacing the config vendor format
+const (
+	FormatDot              string = "dot"               // Graphviz DOT
+	FormatOTelServiceGraph string = "otel-servicegraph" // OpenTelemetry service graph, as consumed by the Grafana node graph panel
+)
+
 const (
 	BoxByApp                  string = "app"
 	BoxByCluster              string = "cluster"
@@ -68,15 +74,17 @@ type NodeOptions struct {
 
 // CommonOptions are those supplied to Telemetry and Config Vendors
 type CommonOptions struct {
-	Duration  time.Duration
-	GraphType string
-	Params    url.Values // make available the raw query params for vendor-specific handling
-	QueryTime int64      // unix time in seconds
+	CompareTime int64 // unix time in seconds, when set the graph is compared with the graph ending at this time
+	Duration    time.Duration
+	GraphType   string
+	Params      url.Values // make available the raw query params for vendor-specific handling
+	QueryTime   int64      // unix time in seconds
 }
 
 // ConfigOptions are those supplied to Config Vendors
 type ConfigOptions struct {
-	BoxBy string
+	BoxBy  string
+	Format string // FormatDot | FormatOTelServiceGraph, empty for the config vendor format
 	CommonOptions
 }
 
@@ -141,6 +149,7 @@ func NewOptions(r *net_http.Request) Options {
 
 	// query params
 	params := r.URL.Query()
+	var compareTime int64
 	var duration model.Duration
 	var includeIdleEdges bool
 	var injectServiceNodes bool
@@ -149,8 +158,10 @@ func NewOptions(r *net_http.Request) Options {
 	boxBy := params.Get("boxBy")
 	// @TODO requires refactoring to use clusterNameFromQuery
 	cluster := params.Get("clusterName")
+	compareTimeString := params.Get("compareTime")
 	configVendor := params.Get("configVendor")
 	durationString := params.Get("duration")
+	format := params.Get("format")
 	graphType := params.Get("graphType")
 	includeIdleEdgesString := params.Get("includeIdleEdges")
 	injectServiceNodesString := params.Get("injectServiceNodes")
@@ -176,6 +187,9 @@ func NewOptions(r *net_http.Request) Options {
 	} else if configVendor != VendorCytoscape {
 		BadRequest(fmt.Sprintf("Invalid configVendor [%s]", configVendor))
 	}
+	if format != "" && format != FormatDot && format != FormatOTelServiceGraph {
+		BadRequest(fmt.Sprintf("Invalid format [%s], expecting one of (%s, %s)", format, FormatDot, FormatOTelServiceGraph))
+	}
 	if durationString == "" {
 		duration, _ = model.ParseDuration(defaultDuration)
 	} else {
@@ -242,6 +256,13 @@ func NewOptions(r *net_http.Request) Options {
 			BadRequest(fmt.Sprintf("Invalid queryTime [%s]", queryTimeString))
 		}
 	}
+	if compareTimeString != "" {
+		var compareTimeErr error
+		compareTime, compareTimeErr = strconv.ParseInt(compareTimeString, 10, 64)
+		if compareTimeErr != nil || compareTime <= 0 || compareTime >= queryTime {
+			BadRequest(fmt.Sprintf("Invalid compareTime [%s], it must precede the queryTime", compareTimeString))
+		}
+	}
 	if telemetryVendor == "" {
 		telemetryVendor = defaultTelemetryVendor
 	} else if telemetryVendor != VendorIstio {
@@ -358,12 +379,14 @@ func NewOptions(r *net_http.Request) Options {
 		ConfigVendor:    configVendor,
 		TelemetryVendor: telemetryVendor,
 		ConfigOptions: ConfigOptions{
-			BoxBy: boxBy,
+			BoxBy:  boxBy,
+			Format: format,
 			CommonOptions: CommonOptions{
-				Duration:  time.Duration(duration),
-				GraphType: graphType,
-				Params:    params,
-				QueryTime: queryTime,
+				CompareTime: compareTime,
+				Duration:    time.Duration(duration),
+				GraphType:   graphType,
+				Params:      params,
+				QueryTime:   queryTime,
 			},
 		},
 		TelemetryOptions: TelemetryOptions{
@@ -374,10 +397,11 @@ func NewOptions(r *net_http.Request) Options {
 			Namespaces:           namespaceMap,
 			Rates:                rates,
 			CommonOptions: CommonOptions{
-				Duration:  time.Duration(duration),
-				GraphType: graphType,
-				Params:    params,
-				QueryTime: queryTime,
+				CompareTime: compareTime,
+				Duration:    time.Duration(duration),
+				GraphType:   graphType,
+				Params:      params,
+				QueryTime:   queryTime,
 			},
 			NodeOptions: NodeOptions{
 				Aggregate:      aggregate,
@@ -395,6 +419,40 @@ func NewOptions(r *net_http.Request) Options {
 	return options
 }
 
+// CompareOptions returns the options of the graph to compare with, ending at CompareTime. The namespaces
+// that did not exist yet at CompareTime are removed, the durations of the others are made safe.
+func (o Options) CompareOptions() Options {
+	compareTime := o.TelemetryOptions.CompareTime
+	compareOptions := o
+	compareOptions.ConfigOptions.QueryTime = compareTime
+	compareOptions.TelemetryOptions.QueryTime = compareTime
+	compareOptions.TelemetryOptions.Namespaces = NewNamespaceInfoMap()
+
+	endTime := time.Unix(compareTime, 0)
+	for name, namespaceInfo := range o.TelemetryOptions.Namespaces {
+		var earliestCreationTimestamp *time.Time
+		for _, an := range o.AccessibleNamespaces {
+			if name == an.Name && (nil == earliestCreationTimestamp || earliestCreationTimestamp.After(an.CreationTimestamp)) {
+				earliestCreationTimestamp = &an.CreationTimestamp
+			}
+		}
+		safeDuration := o.TelemetryOptions.Duration
+		if earliestCreationTimestamp != nil && !earliestCreationTimestamp.IsZero() {
+			if nsLifetime := endTime.Sub(*earliestCreationTimestamp); nsLifetime < safeDuration {
+				safeDuration = nsLifetime
+			}
+		}
+		if safeDuration <= 0 {
+			log.Debugf("Namespace [%s] did not exist at compareTime [%v]", name, endTime)
+			continue
+		}
+		namespaceInfo.Duration = safeDuration
+		compareOptions.TelemetryOptions.Namespaces[name] = namespaceInfo
+	}
+
+	return compareOptions
+}
+
 // GetGraphKind will return the kind of graph represented by the options.
 func (o *TelemetryOptions) GetGraphKind() string {
 	if o.NodeOptions.App != "" ||
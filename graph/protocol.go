@@ -226,6 +226,26 @@ func IsGRPCErr(code string) bool {
 	return code != "0" && code != ""
 }
 
+// EdgeRate returns the protocol of the edge and its total rate, in the protocol unit. It returns false if the
+// edge protocol is not set.
+func EdgeRate(e *Edge) (Protocol, float64, bool) {
+	for _, p := range Protocols {
+		if e.Metadata[ProtocolKey] != p.Name {
+			continue
+		}
+		rate := 0.0
+		for _, r := range p.EdgeRates {
+			if r.IsTotal {
+				if val, ok := e.Metadata[r.Name].(float64); ok {
+					rate += val
+				}
+			}
+		}
+		return p, rate, true
+	}
+	return Protocol{}, 0.0, false
+}
+
 // AddOutgoingEdgeToMetadata updates the source node's outgoing traffic with the outgoing edge traffic value
 func AddOutgoingEdgeToMetadata(sourceMetadata, edgeMetadata Metadata) {
 	if val, valOk := edgeMetadata[grpc]; valOk {
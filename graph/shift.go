@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"time"
+)
+
+// Shift.go moves the time window of a traffic map forward without querying the whole window again. The traffic
+// of the new time window is approximated from the traffic of the previous time window and the traffic of the
+// recent sub-window (the time between the end of the previous window and the end of the new one):
+//
+//	rate(new window) = rate(previous window) * (duration - shift) / duration + rate(recent sub-window) * shift / duration
+//
+// The traffic leaving the time window is assumed to be at the average rate of the previous window. The appender
+// data of the previous window (response times, security, health...) is kept, nodes and edges only found in the
+// recent sub-window are added with their own appender data.
+
+// ShiftTrafficMap returns the traffic map of the time window ending shift later than the time window of trafficMap,
+// given recentTrafficMap, the traffic map of the recent sub-window. Both traffic maps are left untouched.
+func ShiftTrafficMap(trafficMap, recentTrafficMap TrafficMap, duration, shift time.Duration) TrafficMap {
+	weight := float64(duration-shift) / float64(duration)
+	recentWeight := float64(shift) / float64(duration)
+
+	shifted := NewTrafficMap()
+	for id, n := range trafficMap {
+		shifted[id] = shiftNode(n, weight)
+	}
+	for id, n := range recentTrafficMap {
+		if sn, found := shifted[id]; found {
+			addNodeRates(sn.Metadata, n.Metadata, recentWeight)
+		} else {
+			shifted[id] = shiftNode(n, recentWeight)
+		}
+	}
+
+	shiftedEdges := map[string]*Edge{}
+	shiftEdges := func(tm TrafficMap, w float64) {
+		for _, n := range tm {
+			for _, e := range n.Edges {
+				key := diffEdgeKey(e)
+				if se, found := shiftedEdges[key]; found {
+					addEdgeRates(se.Metadata, e.Metadata, w)
+					continue
+				}
+				source := shiftedNode(shifted, e.Source, w)
+				se := source.AddEdge(shiftedNode(shifted, e.Dest, w))
+				for k, v := range e.Metadata {
+					se.Metadata[k] = v
+				}
+				scaleEdgeRates(se.Metadata, w)
+				shiftedEdges[key] = se
+			}
+		}
+	}
+	shiftEdges(trafficMap, weight)
+	shiftEdges(recentTrafficMap, recentWeight)
+
+	// a node idle in the previous time window may have traffic in the recent sub-window
+	for _, n := range shifted {
+		if n.Metadata[IsIdle] == true {
+			if in, out := nodeTraffic(n); in > 0 || out > 0 {
+				delete(n.Metadata, IsIdle)
+			}
+		}
+	}
+
+	return shifted
+}
+
+// shiftedNode returns the shifted node of n, added to the shifted traffic map if needed (an edge destination
+// is not always a node of the traffic map)
+func shiftedNode(shifted TrafficMap, n *Node, weight float64) *Node {
+	if sn, found := shifted[n.ID]; found {
+		return sn
+	}
+	sn := shiftNode(n, weight)
+	shifted[n.ID] = sn
+	return sn
+}
+
+// shiftNode returns a copy of n, without edges, with its rates scaled by weight
+func shiftNode(n *Node, weight float64) *Node {
+	sn := &Node{
+		ID:        n.ID,
+		NodeType:  n.NodeType,
+		Cluster:   n.Cluster,
+		Namespace: n.Namespace,
+		Workload:  n.Workload,
+		App:       n.App,
+		Version:   n.Version,
+		Service:   n.Service,
+		Edges:     []*Edge{},
+		Metadata:  NewMetadata(),
+	}
+	for k, v := range n.Metadata {
+		sn.Metadata[k] = v
+	}
+	for _, p := range Protocols {
+		for _, r := range p.NodeRates {
+			if val, ok := sn.Metadata[r.Name].(float64); ok {
+				sn.Metadata[r.Name] = val * weight
+			}
+		}
+	}
+	return sn
+}
+
+func addNodeRates(md, recent Metadata, weight float64) {
+	for _, p := range Protocols {
+		for _, r := range p.NodeRates {
+			if val, ok := recent[r.Name].(float64); ok {
+				addToMetadataValue(md, r.Name, val*weight)
+			}
+		}
+	}
+}
+
+// scaleEdgeRates scales the rates and the responses of the edge, the responses are copied to not alter the original ones
+func scaleEdgeRates(md Metadata, weight float64) {
+	for _, p := range Protocols {
+		for _, r := range p.EdgeRates {
+			if val, ok := md[r.Name].(float64); ok {
+				md[r.Name] = val * weight
+			}
+		}
+		if responses, ok := md[p.EdgeResponses].(Responses); ok {
+			delete(md, p.EdgeResponses)
+			addScaledResponses(md, p.EdgeResponses, responses, weight)
+		}
+	}
+}
+
+func addEdgeRates(md, recent Metadata, weight float64) {
+	for _, p := range Protocols {
+		for _, r := range p.EdgeRates {
+			if val, ok := recent[r.Name].(float64); ok {
+				addToMetadataValue(md, r.Name, val*weight)
+			}
+		}
+		if responses, ok := recent[p.EdgeResponses].(Responses); ok {
+			addScaledResponses(md, p.EdgeResponses, responses, weight)
+		}
+	}
+}
+
+func addScaledResponses(md Metadata, k MetadataKey, responses Responses, weight float64) {
+	scaled, ok := md[k].(Responses)
+	if !ok {
+		scaled = Responses{}
+		md[k] = scaled
+	}
+	for code, detail := range responses {
+		scaledDetail, ok := scaled[code]
+		if !ok {
+			scaledDetail = &ResponseDetail{Flags: ResponseFlags{}, Hosts: ResponseHosts{}}
+			scaled[code] = scaledDetail
+		}
+		for flags, val := range detail.Flags {
+			scaledDetail.Flags[flags] += val * weight
+		}
+		for host, val := range detail.Hosts {
+			scaledDetail.Hosts[host] += val * weight
+		}
+	}
+}
+
+// nodeTraffic returns the incoming and outgoing rates of the node, all protocols together
+func nodeTraffic(n *Node) (in, out float64) {
+	for _, p := range Protocols {
+		for _, r := range p.NodeRates {
+			switch {
+			case r.IsIn:
+				in += diffRate(n.Metadata, r.Name)
+			case r.IsOut:
+				out += diffRate(n.Metadata, r.Name)
+			}
+		}
+	}
+	return in, out
+}
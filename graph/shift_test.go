@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftTrafficMap(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// previous 10m: productpage -> reviews, reviews is idle
+	previous := NewTrafficMap()
+	productpage := newDiffTestNode(t, "productpage", 0)
+	reviews := newDiffTestNode(t, "reviews", 10)
+	ratings := newDiffTestNode(t, "ratings", 0)
+	ratings.Metadata[IsIdle] = true
+	addDiffTestEdge(productpage, reviews, 10, 2)
+	productpage.Edges[0].Metadata[httpResponses] = Responses{"200": &ResponseDetail{Flags: ResponseFlags{"-": 8}, Hosts: ResponseHosts{"reviews": 8}}}
+	productpage.Edges[0].Metadata[ResponseTime] = 20.0
+	for _, n := range []*Node{productpage, reviews, ratings} {
+		previous[n.ID] = n
+	}
+
+	// recent 2m: productpage -> reviews -> ratings
+	recent := NewTrafficMap()
+	recentProductpage := newDiffTestNode(t, "productpage", 0)
+	recentReviews := newDiffTestNode(t, "reviews", 20)
+	recentRatings := newDiffTestNode(t, "ratings", 5)
+	addDiffTestEdge(recentProductpage, recentReviews, 20, 0)
+	recentProductpage.Edges[0].Metadata[httpResponses] = Responses{"200": &ResponseDetail{Flags: ResponseFlags{"-": 20}, Hosts: ResponseHosts{"reviews": 20}}}
+	recentProductpage.Edges[0].Metadata[ResponseTime] = 10.0
+	addDiffTestEdge(recentReviews, recentRatings, 5, 0)
+	for _, n := range []*Node{recentProductpage, recentReviews, recentRatings} {
+		recent[n.ID] = n
+	}
+
+	shifted := ShiftTrafficMap(previous, recent, 10*time.Minute, 2*time.Minute)
+
+	require.Len(shifted, 3)
+	assert.InDelta(12.0, shifted[reviews.ID].Metadata[httpIn], 0.001)
+	assert.InDelta(1.0, shifted[ratings.ID].Metadata[httpIn], 0.001)
+	assert.NotContains(shifted[ratings.ID].Metadata, IsIdle)
+
+	require.Len(shifted[productpage.ID].Edges, 1)
+	e := shifted[productpage.ID].Edges[0]
+	assert.Same(shifted[reviews.ID], e.Dest)
+	assert.InDelta(12.0, e.Metadata[http], 0.001)
+	assert.InDelta(1.6, e.Metadata[http5xx], 0.001)
+	assert.InDelta(10.4, e.Metadata[httpResponses].(Responses)["200"].Flags["-"], 0.001)
+	assert.Equal(20.0, e.Metadata[ResponseTime])
+
+	require.Len(shifted[reviews.ID].Edges, 1)
+	e = shifted[reviews.ID].Edges[0]
+	assert.Same(shifted[ratings.ID], e.Dest)
+	assert.InDelta(1.0, e.Metadata[http], 0.001)
+
+	// the original traffic maps are left untouched
+	assert.Equal(10.0, previous[reviews.ID].Metadata[httpIn])
+	assert.Equal(8.0, productpage.Edges[0].Metadata[httpResponses].(Responses)["200"].Flags["-"])
+	assert.Equal(true, ratings.Metadata[IsIdle])
+	assert.Len(reviews.Edges, 0)
+	assert.Equal(20.0, recent[reviews.ID].Metadata[httpIn])
+}
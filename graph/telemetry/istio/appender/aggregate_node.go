@@ -19,13 +19,17 @@ const (
 // AggregateNodeAppender is responsible for injecting aggregate nodes into the graph to gain
 // visibility into traffic aggregations for a user-specfied metric attribute.
 // Note: Aggregate Nodes are supported only on Requests traffic (not TCP or gRPC-message traffic)
+// When Protocol is set only the requests of that protocol are aggregated. For example, with Protocol "grpc"
+// and a request_operation classification set to the gRPC method, gRPC services get a node per method, each
+// with its own gRPC response codes.
 type AggregateNodeAppender struct {
 	Aggregate          string
 	AggregateValue     string
 	GraphType          string
 	InjectServiceNodes bool
 	Namespaces         map[string]graph.NamespaceInfo
-	QueryTime          int64 // unix time in seconds
+	Protocol           string // grpc | http, empty for every request protocol
+	QueryTime          int64  // unix time in seconds
 	Rates              graph.RequestedRates
 	Service            string
 }
@@ -55,6 +59,9 @@ func (a AggregateNodeAppender) AppendGraph(trafficMap graph.TrafficMap, globalIn
 	if a.Rates.Grpc != graph.RateRequests && a.Rates.Http != graph.RateRequests {
 		return
 	}
+	if (a.Protocol == graph.GRPC.Name && a.Rates.Grpc != graph.RateRequests) || (a.Protocol == graph.HTTP.Name && a.Rates.Http != graph.RateRequests) {
+		return
+	}
 
 	if globalInfo.PromClient == nil {
 		var err error
@@ -70,8 +77,9 @@ func (a AggregateNodeAppender) AppendGraph(trafficMap graph.TrafficMap, globalIn
 }
 
 func (a AggregateNodeAppender) appendGraph(trafficMap graph.TrafficMap, namespace string, client *prometheus.Client) {
-	log.Tracef("Resolving request aggregates for namespace=[%s], aggregate=[%s]", namespace, a.Aggregate)
+	log.Tracef("Resolving request aggregates for namespace=[%s], aggregate=[%s], protocol=[%s]", namespace, a.Aggregate, a.Protocol)
 	duration := a.Namespaces[namespace].Duration
+	protocolFragment := AggregateProtocolFragment(a.Protocol)
 
 	// query prometheus for aggregate info in two queries (assume aggregation is typically request classification, so use dest telemetry):
 	//   note1: we want to only match the aggregate when it is set and not "unknown".  But in Prometheus a negative test on an unset label
@@ -80,11 +88,12 @@ func (a AggregateNodeAppender) appendGraph(trafficMap graph.TrafficMap, namespac
 	//      see them and it will just increase the graph density.  To change that behavior remove the "> 0" conditions.
 	// 1) query for requests originating from a workload outside the namespace.
 	groupBy := fmt.Sprintf("source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol,response_code,grpc_response_status,response_flags,%s", a.Aggregate)
-	httpQuery := fmt.Sprintf(`sum(rate(%s{reporter=~"destination|waypoint",source_workload_namespace!="%s",destination_service_namespace="%v",%s!="unknown"}[%vs])) by (%s) > 0`,
+	httpQuery := fmt.Sprintf(`sum(rate(%s{reporter=~"destination|waypoint",source_workload_namespace!="%s",destination_service_namespace="%v",%s!="unknown"%s}[%vs])) by (%s) > 0`,
 		"istio_requests_total",
 		namespace,
 		namespace,
 		a.Aggregate,
+		protocolFragment,
 		int(duration.Seconds()), // range duration for the query
 		groupBy)
 	query := httpQuery
@@ -92,10 +101,11 @@ func (a AggregateNodeAppender) appendGraph(trafficMap graph.TrafficMap, namespac
 	a.injectAggregates(trafficMap, &vector)
 
 	// 2) query for requests originating from a workload inside of the namespace
-	httpQuery = fmt.Sprintf(`sum(rate(%s{reporter=~"destination|waypoint",source_workload_namespace="%s",%s!="unknown"}[%vs])) by (%s) > 0`,
+	httpQuery = fmt.Sprintf(`sum(rate(%s{reporter=~"destination|waypoint",source_workload_namespace="%s",%s!="unknown"%s}[%vs])) by (%s) > 0`,
 		"istio_requests_total",
 		namespace,
 		a.Aggregate,
+		protocolFragment,
 		int(duration.Seconds()), // range duration for the query
 		groupBy)
 	query = httpQuery
@@ -115,12 +125,13 @@ func (a AggregateNodeAppender) appendNodeGraph(trafficMap graph.TrafficMap, name
 		serviceFragment = fmt.Sprintf(`,destination_service_name="%s"`, a.Service)
 	}
 	groupBy := fmt.Sprintf("source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol,response_code,grpc_response_status,response_flags,%s", a.Aggregate)
-	httpQuery := fmt.Sprintf(`sum(rate(%s{reporter=~"destination|waypoint",destination_service_namespace="%s",%s="%s"%s}[%vs])) by (%s) > 0`,
+	httpQuery := fmt.Sprintf(`sum(rate(%s{reporter=~"destination|waypoint",destination_service_namespace="%s",%s="%s"%s%s}[%vs])) by (%s) > 0`,
 		"istio_requests_total",
 		namespace,
 		a.Aggregate,
 		a.AggregateValue,
 		serviceFragment,
+		AggregateProtocolFragment(a.Protocol),
 		int(duration.Seconds()), // range duration for the query
 		groupBy)
 	query := httpQuery
@@ -175,6 +186,9 @@ func (a AggregateNodeAppender) injectAggregates(trafficMap graph.TrafficMap, vec
 		if (skipRequestsHttp && protocol == graph.HTTP.Name) || (skipRequestsGrpc && protocol == graph.GRPC.Name) {
 			continue
 		}
+		if a.Protocol != "" && protocol != a.Protocol {
+			continue
+		}
 
 		// handle clusters
 		sourceCluster, destCluster := util.HandleClusters(lSourceCluster, sourceClusterOk, lDestCluster, destClusterOk)
@@ -252,6 +266,14 @@ func (a AggregateNodeAppender) injectAggregates(trafficMap graph.TrafficMap, vec
 	}
 }
 
+// AggregateProtocolFragment returns the query fragment restricting the aggregated requests to the protocol, if set
+func AggregateProtocolFragment(protocol string) string {
+	if protocol == "" {
+		return ""
+	}
+	return fmt.Sprintf(`,request_protocol="%s"`, protocol)
+}
+
 func addTraffic(val float64, protocol, code, flags, host string, source, dest *graph.Node) {
 	var edge *graph.Edge
 	for _, e := range source.Edges {
@@ -607,6 +607,107 @@ func TestNodeGraphWithServiceInjectionSkipRates(t *testing.T) {
 	assert.Equal("v1", reviews.Version)
 }
 
+func TestNamespacesGraphGrpcMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	q0 := `round(sum(rate(istio_requests_total{reporter=~"destination|waypoint",source_workload_namespace!="bookinfo",destination_service_namespace="bookinfo",request_operation!="unknown",request_protocol="grpc"}[60s])) by (source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol,response_code,grpc_response_status,response_flags,request_operation) > 0,0.001)`
+	v0 := model.Vector{}
+
+	q1 := `round(sum(rate(istio_requests_total{reporter=~"destination|waypoint",source_workload_namespace="bookinfo",request_operation!="unknown",request_protocol="grpc"}[60s])) by (source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol,response_code,grpc_response_status,response_flags,request_operation) > 0,0.001)`
+	metric := func(protocol, grpcStatus, operation string) model.Metric {
+		return model.Metric{
+			"source_cluster":                 config.DefaultClusterID,
+			"source_workload_namespace":      "bookinfo",
+			"source_workload":                "productpage-v1",
+			"source_canonical_service":       "productpage",
+			"source_canonical_revision":      "v1",
+			"destination_cluster":            config.DefaultClusterID,
+			"destination_service_namespace":  "bookinfo",
+			"destination_service":            "reviews.bookinfo.svc.cluster.local",
+			"destination_service_name":       "reviews",
+			"destination_workload_namespace": "bookinfo",
+			"destination_workload":           "reviews-v1",
+			"destination_canonical_service":  "reviews",
+			"destination_canonical_revision": "v1",
+			"response_code":                  "200",
+			"grpc_response_status":           model.LabelValue(grpcStatus),
+			"response_flags":                 "",
+			"request_protocol":               model.LabelValue(protocol),
+			"request_operation":              model.LabelValue(operation)}
+	}
+	v1 := model.Vector{
+		&model.Sample{
+			Metric: metric("grpc", "0", "reviews.Reviews/GetReviews"),
+			Value:  70},
+		&model.Sample{
+			Metric: metric("grpc", "14", "reviews.Reviews/GetReviews"),
+			Value:  10},
+		&model.Sample{
+			Metric: metric("grpc", "0", "reviews.Reviews/ListReviews"),
+			Value:  20},
+		// not expected from the query, skipped anyway
+		&model.Sample{
+			Metric: metric("http", "", "Top"),
+			Value:  30}}
+
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mockQuery(api, q0, &v0)
+	mockQuery(api, q1, &v1)
+
+	trafficMap := aggregateNodeTestTraffic(true)
+	ppID, _, _ := graph.Id(config.DefaultClusterID, "bookinfo", "productpage", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+
+	duration, _ := time.ParseDuration("60s")
+	appender := AggregateNodeAppender{
+		Aggregate:          "request_operation",
+		GraphType:          graph.GraphTypeVersionedApp,
+		InjectServiceNodes: true,
+		Namespaces: map[string]graph.NamespaceInfo{
+			"bookinfo": {
+				Name:     "bookinfo",
+				Duration: duration,
+			},
+		},
+		Protocol:  graph.GRPC.Name,
+		QueryTime: time.Now().Unix(),
+		Rates: graph.RequestedRates{
+			Grpc: graph.RateRequests,
+			Http: graph.RateRequests,
+			Tcp:  graph.RateTotal,
+		},
+	}
+
+	appender.appendGraph(trafficMap, "bookinfo", client)
+
+	pp, ok := trafficMap[ppID]
+	assert.Equal(true, ok)
+	assert.Equal(2, len(pp.Edges))
+
+	methods := map[string]*graph.Node{}
+	for _, e := range pp.Edges {
+		assert.Equal(graph.NodeTypeAggregate, e.Dest.NodeType)
+		assert.Equal(graph.GRPC.Name, e.Metadata[graph.ProtocolKey])
+		methods[e.Dest.Metadata[graph.AggregateValue].(string)] = e.Dest
+	}
+
+	getReviews := methods["reviews.Reviews/GetReviews"]
+	if assert.NotNil(getReviews) {
+		assert.Equal(80.0, getReviews.Metadata[graph.MetadataKey("grpcIn")])
+		assert.Equal(10.0, getReviews.Metadata[graph.MetadataKey("grpcInErr")])
+		assert.Equal(1, len(getReviews.Edges))
+		assert.Equal(graph.NodeTypeService, getReviews.Edges[0].Dest.NodeType)
+	}
+	listReviews := methods["reviews.Reviews/ListReviews"]
+	if assert.NotNil(listReviews) {
+		assert.Equal(20.0, listReviews.Metadata[graph.MetadataKey("grpcIn")])
+		assert.NotContains(listReviews.Metadata, graph.MetadataKey("grpcInErr"))
+	}
+}
+
 func aggregateNodeTestTraffic(injectServices bool) graph.TrafficMap {
 	productpage, _ := graph.NewNode(config.DefaultClusterID, "bookinfo", "productpage", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
 	reviews, _ := graph.NewNode(config.DefaultClusterID, "bookinfo", "reviews", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
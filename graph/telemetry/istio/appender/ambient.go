@@ -4,16 +4,22 @@ import (
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
 )
 
 const AmbientAppenderName = "ambient"
 const WaypointSuffix = "waypoint"
 
+// ztunnelApp is the app label value of the ztunnel daemonset pods
+const ztunnelApp = "ztunnel"
+
 // AmbientAppender adds all the Ambient logic to the graph
 // handleWaypoint Identifies the waypoint proxies
 // based on the name (Optmization) and verifies by getting the workload
 // and then, checking the labels
 // handleWaypoint removes the waypoint proxies when ShowWaypoint is false
+// handleAmbientLayers flags the ztunnel and ambient (captured, without sidecar) nodes, and
+// tells the edges tunneled by ztunnel (L4) from the ones going through a waypoint (L7)
 type AmbientAppender struct {
 	ShowWaypoints bool
 }
@@ -37,6 +43,7 @@ func (a AmbientAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *gr
 	log.Trace("Running ambient appender")
 
 	a.handleWaypoints(trafficMap, globalInfo)
+	a.handleAmbientLayers(trafficMap, globalInfo)
 }
 
 func (a AmbientAppender) handleWaypoints(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo) {
@@ -61,11 +68,9 @@ func (a AmbientAppender) handleWaypoints(trafficMap graph.TrafficMap, globalInfo
 			waypoinList = append(waypoinList, workload.Name)
 			if !a.ShowWaypoints {
 				delete(trafficMap, n.ID)
-				break
 			} else {
 				n.Metadata[graph.IsWaypoint] = true
 				n.Metadata[graph.IsOutOfMesh] = false
-				break
 			}
 		}
 	}
@@ -85,6 +90,71 @@ func (a AmbientAppender) handleWaypoints(trafficMap graph.TrafficMap, globalInfo
 	}
 }
 
+func (a AmbientAppender) handleAmbientLayers(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo) {
+	for _, n := range trafficMap {
+		if n.Metadata[graph.IsWaypoint] == true {
+			continue
+		}
+		workloads := getNodeWorkloads(n, globalInfo)
+		if len(workloads) == 0 {
+			continue
+		}
+		ztunnel, ambient := true, true
+		for _, workload := range workloads {
+			if workload.Labels["app"] != ztunnelApp {
+				ztunnel = false
+			}
+			if !workload.IstioAmbient || workload.IstioSidecar {
+				ambient = false
+			}
+		}
+		if ztunnel {
+			n.Metadata[graph.IsZtunnel] = true
+		} else if ambient {
+			n.Metadata[graph.IsAmbient] = true
+		}
+	}
+
+	for _, n := range trafficMap {
+		for _, edge := range n.Edges {
+			if layer := ambientLayer(edge); layer != "" {
+				edge.Metadata[graph.AmbientLayer] = layer
+			}
+		}
+	}
+}
+
+// ambientLayer returns the ambient data plane layer of the edge traffic, if any:
+// - L7 for HTTP/gRPC traffic to or from a waypoint, or to an ambient node (only waypoints report L7 telemetry for it).
+// - L4 for TCP traffic to or from an ambient or ztunnel node, tunneled by ztunnel.
+func ambientLayer(edge *graph.Edge) string {
+	source, dest := edge.Source.Metadata, edge.Dest.Metadata
+	switch edge.Metadata[graph.ProtocolKey] {
+	case graph.HTTP.Name, graph.GRPC.Name:
+		if source[graph.IsWaypoint] == true || dest[graph.IsWaypoint] == true || dest[graph.IsAmbient] == true {
+			return graph.AmbientLayerL7
+		}
+	case graph.TCP.Name:
+		if source[graph.IsAmbient] == true || dest[graph.IsAmbient] == true || source[graph.IsZtunnel] == true || dest[graph.IsZtunnel] == true {
+			return graph.AmbientLayerL4
+		}
+	}
+	return ""
+}
+
+// getNodeWorkloads returns the workloads backing a workload or app node
+func getNodeWorkloads(n *graph.Node, gi *graph.AppenderGlobalInfo) []models.WorkloadListItem {
+	switch n.NodeType {
+	case graph.NodeTypeWorkload:
+		if workload, found := getWorkload(n.Cluster, n.Namespace, n.Workload, gi); found {
+			return []models.WorkloadListItem{*workload}
+		}
+	case graph.NodeTypeApp:
+		return getAppWorkloads(n.Cluster, n.Namespace, n.App, n.Version, gi)
+	}
+	return nil
+}
+
 func contains(slice []string, str string) bool {
 	for _, v := range slice {
 		if v == str {
@@ -170,3 +170,97 @@ func TestIsWaypoint(t *testing.T) {
 	assert.True(found)
 	assert.NotContains(fakeWaypointNode.Metadata, graph.IsWaypoint)
 }
+
+func setupAmbientWorkloads(t *testing.T) *business.Layer {
+	pod := func(name string, labels, annotations map[string]string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: appNamespace, Labels: labels, Annotations: annotations},
+			Spec: core_v1.PodSpec{
+				Containers: []core_v1.Container{{Name: name, Image: "whatever"}},
+			},
+		}
+	}
+	sidecar := map[string]string{"sidecar.istio.io/status": "{\"version\":\"\",\"initContainers\":[\"istio-init\",\"enable-core-dump\"],\"containers\":[\"istio-proxy\"],\"volumes\":[\"istio-envoy\",\"istio-certs\"]}"}
+	ambient := map[string]string{config.AmbientAnnotation: config.AmbientAnnotationEnabled}
+
+	ns := &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: appNamespace}}
+	k8s := kubetest.NewFakeK8sClient(
+		pod("productpage", map[string]string{"app": "productpage"}, sidecar),
+		pod("details", map[string]string{"app": "details"}, ambient),
+		pod("ztunnel", map[string]string{"app": "ztunnel"}, nil),
+		pod("waypoint", map[string]string{"app": "waypoint", config.WaypointLabel: config.WaypointLabelValue}, nil),
+		ns,
+	)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.KubernetesConfig.ClusterName = defaultCluster
+	config.Set(conf)
+
+	business.SetupBusinessLayer(t, k8s, *conf)
+	k8sclients := map[string]kubernetes.ClientInterface{defaultCluster: k8s}
+	return business.NewWithBackends(k8sclients, k8sclients, nil, nil)
+}
+
+func TestAmbientLayers(t *testing.T) {
+	assert := require.New(t)
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = setupAmbientWorkloads(t)
+	namespaceInfo := graph.NewAppenderNamespaceInfo(appNamespace)
+
+	trafficMap := graph.NewTrafficMap()
+	productpage, _ := graph.NewNode(defaultCluster, appNamespace, "", appNamespace, "productpage", "productpage", "", graph.GraphTypeWorkload)
+	details, _ := graph.NewNode(defaultCluster, appNamespace, "", appNamespace, "details", "details", "", graph.GraphTypeWorkload)
+	ztunnel, _ := graph.NewNode(defaultCluster, appNamespace, "", appNamespace, "ztunnel", "ztunnel", "", graph.GraphTypeWorkload)
+	waypoint, _ := graph.NewNode(defaultCluster, appNamespace, "", appNamespace, "waypoint", "waypoint", "", graph.GraphTypeWorkload)
+	for _, n := range []*graph.Node{productpage, details, ztunnel, waypoint} {
+		trafficMap[n.ID] = n
+	}
+	tcpEdge := productpage.AddEdge(details)
+	tcpEdge.Metadata[graph.ProtocolKey] = graph.TCP.Name
+	waypointEdge := waypoint.AddEdge(details)
+	waypointEdge.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	ztunnelEdge := ztunnel.AddEdge(productpage)
+	ztunnelEdge.Metadata[graph.ProtocolKey] = graph.TCP.Name
+
+	a := AmbientAppender{ShowWaypoints: true}
+	a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+
+	assert.Equal(true, details.Metadata[graph.IsAmbient])
+	assert.Equal(true, ztunnel.Metadata[graph.IsZtunnel])
+	assert.Equal(true, waypoint.Metadata[graph.IsWaypoint])
+	assert.NotContains(productpage.Metadata, graph.IsAmbient)
+	assert.NotContains(waypoint.Metadata, graph.IsAmbient)
+
+	assert.Equal(graph.AmbientLayerL4, tcpEdge.Metadata[graph.AmbientLayer])
+	assert.Equal(graph.AmbientLayerL7, waypointEdge.Metadata[graph.AmbientLayer])
+	assert.Equal(graph.AmbientLayerL4, ztunnelEdge.Metadata[graph.AmbientLayer])
+}
+
+func TestAmbientLayersWithoutSidecarPeers(t *testing.T) {
+	assert := require.New(t)
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = setupAmbientWorkloads(t)
+	namespaceInfo := graph.NewAppenderNamespaceInfo(appNamespace)
+
+	trafficMap := graph.NewTrafficMap()
+	productpage, _ := graph.NewNode(defaultCluster, appNamespace, "", appNamespace, "productpage", "productpage", "", graph.GraphTypeWorkload)
+	ztunnel, _ := graph.NewNode(defaultCluster, appNamespace, "", appNamespace, "ztunnel", "ztunnel", "", graph.GraphTypeWorkload)
+	details, _ := graph.NewNode(defaultCluster, appNamespace, "", appNamespace, "details", "details", "", graph.GraphTypeWorkload)
+	for _, n := range []*graph.Node{productpage, ztunnel, details} {
+		trafficMap[n.ID] = n
+	}
+	// HTTP traffic to an ambient workload is reported by its waypoint, even when hidden
+	httpEdge := productpage.AddEdge(details)
+	httpEdge.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+	// Sidecar to sidecar traffic is not ambient
+	sidecarEdge := details.AddEdge(productpage)
+	sidecarEdge.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+
+	a := AmbientAppender{ShowWaypoints: false}
+	a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+
+	assert.Equal(graph.AmbientLayerL7, httpEdge.Metadata[graph.AmbientLayer])
+	assert.NotContains(sidecarEdge.Metadata, graph.AmbientLayer)
+}
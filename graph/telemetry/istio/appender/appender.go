@@ -38,6 +38,8 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 				requestedAppenders[IstioAppenderName] = true
 			case MeshCheckAppenderName, SidecarsCheckAppenderName:
 				requestedAppenders[MeshCheckAppenderName] = true
+			case PolicyCheckAppenderName:
+				requestedAppenders[PolicyCheckAppenderName] = true
 			case ResponseTimeAppenderName:
 				requestedAppenders[ResponseTimeAppenderName] = true
 			case SecurityPolicyAppenderName:
@@ -157,12 +159,17 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 				aggregate = defaultAggregate
 			}
 		}
+		aggregateProtocol := o.Params.Get("aggregateProtocol")
+		if aggregateProtocol != "" && aggregateProtocol != graph.GRPC.Name && aggregateProtocol != graph.HTTP.Name {
+			graph.BadRequest(fmt.Sprintf("Invalid aggregateProtocol, expecting one of (grpc, http). [%s]", aggregateProtocol))
+		}
 		a := AggregateNodeAppender{
 			Aggregate:          aggregate,
 			AggregateValue:     o.NodeOptions.AggregateValue,
 			GraphType:          o.GraphType,
 			InjectServiceNodes: o.InjectServiceNodes,
 			Namespaces:         o.Namespaces,
+			Protocol:           aggregateProtocol,
 			QueryTime:          o.QueryTime,
 			Rates:              o.Rates,
 			Service:            o.NodeOptions.Service,
@@ -190,6 +197,13 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 		}
 		appenders = append(appenders, a)
 	}
+	// the policy evaluation is not run by default, only when explicitly requested
+	if _, ok := requestedAppenders[PolicyCheckAppenderName]; ok {
+		a := PolicyCheckAppender{
+			AccessibleNamespaces: o.AccessibleNamespaces,
+		}
+		appenders = append(appenders, a)
+	}
 
 	// The finalizer order is important
 
@@ -0,0 +1,416 @@
+package appender
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const PolicyCheckAppenderName = "policyCheck"
+
+const policyCheckConfigKey = "policyCheckConfigKey" // global vendor info map[cluster]*models.IstioConfigList
+
+// PolicyCheckAppender flags the edges whose traffic would be blocked by the current policies, regardless of the
+// traffic reported by the telemetry. It allows to foresee the impact of a policy before the traffic breaks:
+//   - Sidecar: the sidecar of the source workloads does not expose the destination service (egress hosts).
+//   - AuthorizationPolicy: a DENY policy matches every request from the source workloads, or ALLOW policies
+//     apply to the destination workloads and none of them can match the requests from the source workloads.
+//
+// The policies are evaluated with the identity of the source workloads (service accounts) only, rules depending
+// on request attributes (operations, conditions, request principals, ip blocks) are never considered a match
+// for DENY policies and always a possible match for ALLOW policies. Edges are flagged only when blocked for
+// every source and destination workload.
+// Name: policyCheck
+type PolicyCheckAppender struct {
+	AccessibleNamespaces graph.AccessibleNamespaces
+}
+
+// Name implements Appender
+func (a PolicyCheckAppender) Name() string {
+	return PolicyCheckAppenderName
+}
+
+// IsFinalizer implements Appender
+func (a PolicyCheckAppender) IsFinalizer() bool {
+	return false
+}
+
+// AppendGraph implements Appender
+func (a PolicyCheckAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(trafficMap) == 0 {
+		return
+	}
+
+	for _, n := range trafficMap {
+		// edges leaving a service node are the continuation of the edges entering it, already checked
+		if n.NodeType == graph.NodeTypeService {
+			continue
+		}
+		for _, e := range n.Edges {
+			if _, ok := e.Metadata[graph.IsBlocked]; ok {
+				continue
+			}
+			// Sidecars are checked from the namespace of the source, authorization policies from the namespace of the destination
+			if e.Source.Namespace == namespaceInfo.Namespace && a.nodeOK(e.Source) {
+				if blocked := a.checkSidecarEgress(e, globalInfo); blocked != nil {
+					e.Metadata[graph.IsBlocked] = blocked
+					continue
+				}
+			}
+			if e.Dest.Namespace == namespaceInfo.Namespace && a.nodeOK(e.Dest) && a.nodeOK(e.Source) {
+				if blocked := a.checkAuthorizationPolicies(e, globalInfo); blocked != nil {
+					e.Metadata[graph.IsBlocked] = blocked
+				}
+			}
+		}
+	}
+}
+
+// checkSidecarEgress returns the sidecar hiding the edge destination from every source workload, nil if none
+func (a PolicyCheckAppender) checkSidecarEgress(e *graph.Edge, globalInfo *graph.AppenderGlobalInfo) *graph.BlockedInfo {
+	hosts, hostsNamespace := destinationHosts(e.Dest)
+	if len(hosts) == 0 {
+		return nil
+	}
+	sourceWorkloads := nodeWorkloads(e.Source, globalInfo)
+	if len(sourceWorkloads) == 0 {
+		return nil
+	}
+
+	sidecars := getPolicyCheckConfig(e.Source.Cluster, globalInfo).Sidecars
+	var blocking *networking_v1beta1.Sidecar
+	for _, wk := range sourceWorkloads {
+		sidecar := workloadSidecar(sidecars, wk.Namespace, wk.Labels)
+		if sidecar == nil || len(sidecar.Spec.Egress) == 0 {
+			return nil
+		}
+		for _, host := range hosts {
+			if sidecarExposesHost(sidecar, host, hostsNamespace) {
+				return nil
+			}
+		}
+		blocking = sidecar
+	}
+
+	return &graph.BlockedInfo{
+		Reason:   graph.BlockedReasonSidecarEgress,
+		Policies: []string{fmt.Sprintf("%s/%s", blocking.Namespace, blocking.Name)},
+	}
+}
+
+// checkAuthorizationPolicies returns the authorization policies blocking the traffic of every source workload
+// to every destination workload, nil if the traffic may be allowed
+func (a PolicyCheckAppender) checkAuthorizationPolicies(e *graph.Edge, globalInfo *graph.AppenderGlobalInfo) *graph.BlockedInfo {
+	destWorkloads := nodeWorkloads(e.Dest, globalInfo)
+	if len(destWorkloads) == 0 {
+		return nil
+	}
+
+	// An unknown source, or a source without workloads, has no known identity
+	sources := []policySource{{}}
+	if sourceWorkloads := nodeWorkloads(e.Source, globalInfo); len(sourceWorkloads) > 0 {
+		trustDomain := "cluster.local"
+		if principal, ok := e.Metadata[graph.SourcePrincipal].(string); ok {
+			if parts := strings.SplitN(strings.TrimPrefix(principal, "spiffe://"), "/", 2); len(parts) == 2 && parts[0] != "" {
+				trustDomain = parts[0]
+			}
+		}
+		sources = []policySource{}
+		for _, wk := range sourceWorkloads {
+			for _, sa := range wk.ServiceAccountNames {
+				sources = append(sources, policySource{
+					known:     true,
+					namespace: wk.Namespace,
+					principal: fmt.Sprintf("%s/ns/%s/sa/%s", trustDomain, wk.Namespace, sa),
+				})
+			}
+		}
+		if len(sources) == 0 {
+			sources = []policySource{{}}
+		}
+	}
+
+	rbacDetails := kubernetes.RBACDetails{AuthorizationPolicies: getPolicyCheckConfig(e.Dest.Cluster, globalInfo).AuthorizationPolicies}
+	var blocked *graph.BlockedInfo
+	for _, wk := range destWorkloads {
+		policies := rbacDetails.WorkloadAuthorizationPolicies(wk.Namespace, wk.Labels)
+		for _, source := range sources {
+			if blocked = evaluateAuthorizationPolicies(policies, source); blocked == nil {
+				return nil
+			}
+		}
+	}
+	return blocked
+}
+
+// policySource is the identity of the source of a request, unknown when known is false
+type policySource struct {
+	known     bool
+	namespace string
+	principal string
+}
+
+type policyMatch int
+
+const (
+	policyMatchNo policyMatch = iota
+	policyMatchMaybe
+	policyMatchYes
+)
+
+// evaluateAuthorizationPolicies returns how the policies of a workload block the requests of the source, nil if they
+// may be allowed. DENY policies are evaluated first, then ALLOW policies. CUSTOM and AUDIT policies, and policies
+// attached to a gateway or a waypoint (targetRef), are ignored.
+func evaluateAuthorizationPolicies(policies []*security_v1beta1.AuthorizationPolicy, source policySource) *graph.BlockedInfo {
+	allowPolicies := []string{}
+	allowed := false
+
+	for _, ap := range policies {
+		if ap.Spec.TargetRef != nil {
+			continue
+		}
+		name := fmt.Sprintf("%s/%s", ap.Namespace, ap.Name)
+		switch ap.Spec.Action {
+		case api_security_v1beta1.AuthorizationPolicy_DENY:
+			for _, rule := range ap.Spec.Rules {
+				if matchRule(rule, source) == policyMatchYes {
+					return &graph.BlockedInfo{Reason: graph.BlockedReasonDeny, Policies: []string{name}}
+				}
+			}
+		case api_security_v1beta1.AuthorizationPolicy_ALLOW:
+			allowPolicies = append(allowPolicies, name)
+			for _, rule := range ap.Spec.Rules {
+				if matchRule(rule, source) != policyMatchNo {
+					allowed = true
+				}
+			}
+		}
+	}
+
+	if len(allowPolicies) == 0 || allowed {
+		return nil
+	}
+	return &graph.BlockedInfo{Reason: graph.BlockedReasonNotAllowed, Policies: allowPolicies}
+}
+
+// matchRule matches the source against the rule: the sources of the rule are OR-ed, the rule fields are AND-ed.
+func matchRule(rule *api_security_v1beta1.Rule, source policySource) policyMatch {
+	if rule == nil {
+		return policyMatchYes
+	}
+
+	match := policyMatchYes
+	if len(rule.From) > 0 {
+		match = policyMatchNo
+		for _, from := range rule.From {
+			if from == nil || from.Source == nil {
+				continue
+			}
+			if m := matchSource(from.Source, source); m > match {
+				match = m
+			}
+		}
+	}
+	if match != policyMatchNo && (len(rule.To) > 0 || len(rule.When) > 0) {
+		match = policyMatchMaybe
+	}
+	return match
+}
+
+func matchSource(from *api_security_v1beta1.Source, source policySource) policyMatch {
+	match := policyMatchYes
+	and := func(m policyMatch) {
+		if m < match {
+			match = m
+		}
+	}
+
+	if len(from.Principals) > 0 || len(from.NotPrincipals) > 0 || len(from.Namespaces) > 0 || len(from.NotNamespaces) > 0 {
+		if !source.known {
+			and(policyMatchMaybe)
+		} else {
+			if len(from.Principals) > 0 && !matchPolicyValues(from.Principals, source.principal) {
+				and(policyMatchNo)
+			}
+			if len(from.NotPrincipals) > 0 && matchPolicyValues(from.NotPrincipals, source.principal) {
+				and(policyMatchNo)
+			}
+			if len(from.Namespaces) > 0 && !matchPolicyValues(from.Namespaces, source.namespace) {
+				and(policyMatchNo)
+			}
+			if len(from.NotNamespaces) > 0 && matchPolicyValues(from.NotNamespaces, source.namespace) {
+				and(policyMatchNo)
+			}
+		}
+	}
+	if len(from.RequestPrincipals) > 0 || len(from.NotRequestPrincipals) > 0 || len(from.IpBlocks) > 0 ||
+		len(from.NotIpBlocks) > 0 || len(from.RemoteIpBlocks) > 0 || len(from.NotRemoteIpBlocks) > 0 {
+		and(policyMatchMaybe)
+	}
+	return match
+}
+
+// matchPolicyValues matches a value with the exact, prefix ("abc*"), suffix ("*abc") or presence ("*") values of a policy
+func matchPolicyValues(values []string, value string) bool {
+	for _, v := range values {
+		switch {
+		case v == "*":
+			if value != "" {
+				return true
+			}
+		case strings.HasPrefix(v, "*"):
+			if strings.HasSuffix(value, v[1:]) {
+				return true
+			}
+		case strings.HasSuffix(v, "*"):
+			if strings.HasPrefix(value, v[:len(v)-1]) {
+				return true
+			}
+		case v == value:
+			return true
+		}
+	}
+	return false
+}
+
+// workloadSidecar returns the sidecar applying to a workload: a sidecar of the workload namespace selecting the workload,
+// otherwise the sidecar of the workload namespace without selector, otherwise the sidecar of the root namespace.
+func workloadSidecar(sidecars []*networking_v1beta1.Sidecar, namespace string, workloadLabels map[string]string) *networking_v1beta1.Sidecar {
+	var namespaceSidecar, rootSidecar *networking_v1beta1.Sidecar
+	for _, sc := range sidecars {
+		hasSelector := sc.Spec.WorkloadSelector != nil && len(sc.Spec.WorkloadSelector.Labels) > 0
+		switch {
+		case sc.Namespace == namespace && hasSelector:
+			if labels.SelectorFromSet(sc.Spec.WorkloadSelector.Labels).Matches(labels.Set(workloadLabels)) {
+				return sc
+			}
+		case sc.Namespace == namespace:
+			namespaceSidecar = sc
+		case config.IsRootNamespace(sc.Namespace) && !hasSelector:
+			rootSidecar = sc
+		}
+	}
+	if namespaceSidecar != nil {
+		return namespaceSidecar
+	}
+	return rootSidecar
+}
+
+// sidecarExposesHost returns true if one of the egress listeners of the sidecar exposes the host defined in hostNamespace
+func sidecarExposesHost(sidecar *networking_v1beta1.Sidecar, host, hostNamespace string) bool {
+	for _, egress := range sidecar.Spec.Egress {
+		if egress == nil {
+			continue
+		}
+		for _, egressHost := range egress.Hosts {
+			parts := strings.SplitN(egressHost, "/", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			namespace, dnsName := parts[0], parts[1]
+			namespaceOK := namespace == "*" || namespace == hostNamespace || (namespace == "." && sidecar.Namespace == hostNamespace)
+			dnsNameOK := dnsName == "*" || dnsName == host || (strings.HasPrefix(dnsName, "*.") && strings.HasSuffix(host, dnsName[1:]))
+			if namespaceOK && dnsNameOK {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// destinationHosts returns the hosts requested through the node and the namespace defining them
+func destinationHosts(n *graph.Node) ([]string, string) {
+	if seInfo, ok := n.Metadata[graph.IsServiceEntry].(*graph.SEInfo); ok {
+		return seInfo.Hosts, seInfo.Namespace
+	}
+	if n.Metadata[graph.IsEgressCluster] == true {
+		return nil, ""
+	}
+
+	domain := config.Get().ExternalServices.Istio.IstioIdentityDomain
+	switch n.NodeType {
+	case graph.NodeTypeService:
+		if graph.IsOK(n.Service) {
+			return []string{fmt.Sprintf("%s.%s.%s", n.Service, n.Namespace, domain)}, n.Namespace
+		}
+	case graph.NodeTypeApp, graph.NodeTypeWorkload:
+		if destServices, ok := n.Metadata[graph.DestServices].(graph.DestServicesMetadata); ok {
+			hosts := []string{}
+			for _, ds := range destServices {
+				if ds.Namespace == n.Namespace && graph.IsOK(ds.Name) {
+					hosts = append(hosts, fmt.Sprintf("%s.%s.%s", ds.Name, ds.Namespace, domain))
+				}
+			}
+			return hosts, n.Namespace
+		}
+	}
+	return nil, ""
+}
+
+// nodeWorkloads returns the workloads backing a node, the workloads selected by the service for a service node
+func nodeWorkloads(n *graph.Node, globalInfo *graph.AppenderGlobalInfo) []models.WorkloadListItem {
+	switch n.NodeType {
+	case graph.NodeTypeWorkload:
+		if workload, found := getWorkload(n.Cluster, n.Namespace, n.Workload, globalInfo); found {
+			return []models.WorkloadListItem{*workload}
+		}
+	case graph.NodeTypeApp:
+		return getAppWorkloads(n.Cluster, n.Namespace, n.App, n.Version, globalInfo)
+	case graph.NodeTypeService:
+		svc, found := getServiceDefinition(n.Cluster, n.Namespace, n.Service, globalInfo)
+		if !found || len(svc.Selector) == 0 {
+			return nil
+		}
+		selector := labels.SelectorFromSet(svc.Selector)
+		workloads := []models.WorkloadListItem{}
+		for _, wk := range getWorkloadList(n.Cluster, n.Namespace, globalInfo).Workloads {
+			if selector.Matches(labels.Set(wk.Labels)) {
+				workloads = append(workloads, wk)
+			}
+		}
+		return workloads
+	}
+	return nil
+}
+
+// getPolicyCheckConfig returns the authorization policies and sidecars of the cluster, cached for the graph generation
+func getPolicyCheckConfig(cluster string, gi *graph.AppenderGlobalInfo) *models.IstioConfigList {
+	var configMap map[string]*models.IstioConfigList
+	if existingConfigMap, ok := gi.Vendor[policyCheckConfigKey]; ok {
+		configMap = existingConfigMap.(map[string]*models.IstioConfigList)
+	} else {
+		configMap = make(map[string]*models.IstioConfigList)
+		gi.Vendor[policyCheckConfigKey] = configMap
+	}
+
+	if istioConfigList, ok := configMap[cluster]; ok {
+		return istioConfigList
+	}
+
+	istioConfigList, err := gi.Business.IstioConfig.GetIstioConfigList(context.TODO(), cluster, business.IstioConfigCriteria{
+		IncludeAuthorizationPolicies: true,
+		IncludeSidecars:              true,
+	})
+	graph.CheckError(err)
+	configMap[cluster] = istioConfigList
+
+	return istioConfigList
+}
+
+// nodeOK returns true if we have access to its workload info
+func (a PolicyCheckAppender) nodeOK(node *graph.Node) bool {
+	key := graph.GetClusterSensitiveKey(node.Cluster, node.Namespace)
+	_, ok := a.AccessibleNamespaces[key]
+	return ok
+}
@@ -0,0 +1,151 @@
+package appender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/tests/data"
+)
+
+const policyNamespace = "bookinfo"
+
+func setupPolicyCheck(t *testing.T) *business.Layer {
+	pod := func(app string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: app, Namespace: policyNamespace, Labels: map[string]string{"app": app}},
+			Spec: core_v1.PodSpec{
+				ServiceAccountName: "bookinfo-" + app,
+				Containers:         []core_v1.Container{{Name: app, Image: "whatever"}},
+			},
+		}
+	}
+
+	// only productpage may call reviews
+	allowReviews := data.CreateAuthorizationPolicyWithPrincipals("allow-reviews", policyNamespace, []string{"cluster.local/ns/bookinfo/sa/bookinfo-productpage"})
+	allowReviews.Spec.Selector = data.CreateAuthorizationPolicyWithMetaAndSelector("", "", map[string]string{"app": "reviews"}).Spec.Selector
+	// nobody may call ratings
+	denyRatings := data.CreateAuthorizationPolicyWithMetaAndSelector("deny-ratings", policyNamespace, map[string]string{"app": "ratings"})
+	denyRatings.Spec.Action = api_security_v1beta1.AuthorizationPolicy_DENY
+	denyRatings.Spec.Rules = []*api_security_v1beta1.Rule{{}}
+	// productpage only sees reviews
+	productpageSidecar := data.AddHostsToSidecar([]string{"./reviews.bookinfo.svc.cluster.local"},
+		data.AddSelectorToSidecar(map[string]string{"app": "productpage"}, data.CreateSidecar("productpage", policyNamespace)))
+
+	k8s := kubetest.NewFakeK8sClient(
+		pod("productpage"),
+		pod("reviews"),
+		pod("ratings"),
+		pod("details"),
+		allowReviews,
+		denyRatings,
+		productpageSidecar,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: policyNamespace}},
+	)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.KubernetesConfig.ClusterName = defaultCluster
+	config.Set(conf)
+
+	business.SetupBusinessLayer(t, k8s, *conf)
+	k8sclients := map[string]kubernetes.ClientInterface{defaultCluster: k8s}
+	return business.NewWithBackends(k8sclients, k8sclients, nil, nil)
+}
+
+func TestPolicyCheck(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = setupPolicyCheck(t)
+	namespaceInfo := graph.NewAppenderNamespaceInfo(policyNamespace)
+
+	trafficMap := graph.NewTrafficMap()
+	node := func(app string) *graph.Node {
+		n, err := graph.NewNode(defaultCluster, policyNamespace, "", policyNamespace, app, app, "", graph.GraphTypeWorkload)
+		require.NoError(err)
+		n.Metadata[graph.DestServices] = graph.NewDestServicesMetadata().Add(app, graph.ServiceName{Cluster: defaultCluster, Namespace: policyNamespace, Name: app})
+		trafficMap[n.ID] = n
+		return n
+	}
+	productpage := node("productpage")
+	reviews := node("reviews")
+	ratings := node("ratings")
+	details := node("details")
+
+	productpageReviews := productpage.AddEdge(reviews)
+	productpageDetails := productpage.AddEdge(details)
+	reviewsRatings := reviews.AddEdge(ratings)
+	detailsReviews := details.AddEdge(reviews)
+
+	a := PolicyCheckAppender{
+		AccessibleNamespaces: graph.AccessibleNamespaces{
+			graph.GetClusterSensitiveKey(defaultCluster, policyNamespace): &graph.AccessibleNamespace{
+				Cluster:           defaultCluster,
+				CreationTimestamp: time.Now(),
+				Name:              policyNamespace,
+			},
+		},
+	}
+	a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+
+	assert.NotContains(productpageReviews.Metadata, graph.IsBlocked)
+	assert.Equal(&graph.BlockedInfo{Reason: graph.BlockedReasonSidecarEgress, Policies: []string{"bookinfo/productpage"}}, productpageDetails.Metadata[graph.IsBlocked])
+	assert.Equal(&graph.BlockedInfo{Reason: graph.BlockedReasonDeny, Policies: []string{"bookinfo/deny-ratings"}}, reviewsRatings.Metadata[graph.IsBlocked])
+	assert.Equal(&graph.BlockedInfo{Reason: graph.BlockedReasonNotAllowed, Policies: []string{"bookinfo/allow-reviews"}}, detailsReviews.Metadata[graph.IsBlocked])
+}
+
+func TestEvaluateAuthorizationPolicies(t *testing.T) {
+	assert := assert.New(t)
+
+	productpage := policySource{known: true, namespace: "bookinfo", principal: "cluster.local/ns/bookinfo/sa/bookinfo-productpage"}
+	unknown := policySource{}
+
+	policy := func(action api_security_v1beta1.AuthorizationPolicy_Action, rules ...*api_security_v1beta1.Rule) []*security_v1beta1.AuthorizationPolicy {
+		ap := data.CreateAuthorizationPolicyWithMetaAndSelector("policy", "bookinfo", nil)
+		ap.Spec.Action = action
+		ap.Spec.Rules = rules
+		return []*security_v1beta1.AuthorizationPolicy{ap}
+	}
+	from := func(source *api_security_v1beta1.Source) *api_security_v1beta1.Rule {
+		return &api_security_v1beta1.Rule{From: []*api_security_v1beta1.Rule_From{{Source: source}}}
+	}
+	toGet := []*api_security_v1beta1.Rule_To{{Operation: &api_security_v1beta1.Operation{Methods: []string{"GET"}}}}
+
+	// no policy
+	assert.Nil(evaluateAuthorizationPolicies(nil, productpage))
+
+	// allow nothing
+	assert.NotNil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_ALLOW), unknown))
+
+	// allow by namespace and principal prefix
+	assert.Nil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_ALLOW, from(&api_security_v1beta1.Source{Namespaces: []string{"bookinfo"}})), productpage))
+	assert.Nil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_ALLOW, from(&api_security_v1beta1.Source{Principals: []string{"cluster.local/ns/bookinfo/*"}})), productpage))
+	assert.NotNil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_ALLOW, from(&api_security_v1beta1.Source{NotNamespaces: []string{"bookinfo"}})), productpage))
+
+	// an unknown source may be allowed
+	assert.Nil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_ALLOW, from(&api_security_v1beta1.Source{Namespaces: []string{"bookinfo"}})), unknown))
+
+	// allow depending on the request attributes
+	allowGet := from(&api_security_v1beta1.Source{Namespaces: []string{"bookinfo"}})
+	allowGet.To = toGet
+	assert.Nil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_ALLOW, allowGet), productpage))
+
+	// deny all, deny depending on the request attributes, deny by principal
+	assert.NotNil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_DENY, &api_security_v1beta1.Rule{}), unknown))
+	assert.Nil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_DENY, &api_security_v1beta1.Rule{To: toGet}), productpage))
+	assert.NotNil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_DENY, from(&api_security_v1beta1.Source{Principals: []string{"*/sa/bookinfo-productpage"}})), productpage))
+	assert.Nil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_DENY, from(&api_security_v1beta1.Source{Principals: []string{"*/sa/bookinfo-productpage"}})), unknown))
+	assert.Nil(evaluateAuthorizationPolicies(policy(api_security_v1beta1.AuthorizationPolicy_DENY, from(&api_security_v1beta1.Source{Principals: []string{"*"}, IpBlocks: []string{"10.0.0.0/8"}})), productpage))
+}
@@ -4,11 +4,10 @@ import (
 	"context"
 	"strings"
 
-	"istio.io/client-go/pkg/apis/networking/v1beta1"
-
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 )
 
@@ -120,7 +119,7 @@ func (a ServiceEntryAppender) loadServiceEntryHosts(cluster, namespace string, g
 
 		// ... and then use ExportTo to decide whether the hosts are accessible to the namespace
 		for _, entry := range istioCfg.ServiceEntries {
-			if entry.Spec.Hosts != nil && isExportedToNamespace(entry, namespace) {
+			if entry.Spec.Hosts != nil && kubernetes.IsExportedTo(entry.Spec.ExportTo, entry.Namespace, namespace) {
 				location := "MESH_EXTERNAL"
 				if entry.Spec.Location.String() == "MESH_INTERNAL" {
 					location = "MESH_INTERNAL"
@@ -284,25 +283,6 @@ func (a *ServiceEntryAppender) isAccessible(cluster, namespace string) bool {
 	return ok
 }
 
-func isExportedToNamespace(se *v1beta1.ServiceEntry, namespace string) bool {
-	if se.Spec.ExportTo == nil {
-		return true
-	}
-	for _, export := range se.Spec.ExportTo {
-		if export == "*" {
-			return true
-		}
-		if export == "." && se.Namespace == namespace {
-			return true
-		}
-		if export == se.Namespace {
-			return true
-		}
-	}
-
-	return false
-}
-
 // aggregateEdges identifies edges that are going from <node> to <serviceEntryNode> and
 // aggregates them in only one edge per protocol. This ensures that the traffic map
 // will comply with the assumption/rule of one edge per protocol between any two nodes.
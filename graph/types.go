@@ -76,6 +76,12 @@ type SEInfo struct {
 	Namespace string   `json:"namespace"` // the definition namespace
 }
 
+// BlockedInfo describes the policies blocking the traffic of an edge
+type BlockedInfo struct {
+	Reason   string   `json:"reason"`   // BlockedReasonDeny | BlockedReasonNotAllowed | BlockedReasonSidecarEgress
+	Policies []string `json:"policies"` // namespace/name of the blocking AuthorizationPolicies or Sidecar
+}
+
 func (s *ServiceName) Key() string {
 	return fmt.Sprintf("%s %s %s", s.Cluster, s.Namespace, s.Name)
 }
@@ -166,6 +172,25 @@ func (s *Node) AddEdge(dest *Node) *Edge {
 	return &e
 }
 
+// Name returns a short, human readable, name of the node, unique in its namespace for a given node type
+func (s *Node) Name() string {
+	switch s.NodeType {
+	case NodeTypeAggregate:
+		return fmt.Sprintf("%v=%v", s.Metadata[Aggregate], s.Metadata[AggregateValue])
+	case NodeTypeApp:
+		if IsOKVersion(s.Version) {
+			return fmt.Sprintf("%s:%s", s.App, s.Version)
+		}
+		return s.App
+	case NodeTypeService:
+		return s.Service
+	case NodeTypeWorkload:
+		return s.Workload
+	default:
+		return Unknown
+	}
+}
+
 // NewEdge constructor
 func NewEdge(source, dest *Node) Edge {
 	return Edge{
@@ -2,13 +2,16 @@ package handlers
 
 import (
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
 	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 )
 
@@ -26,6 +29,10 @@ type appParams struct {
 	// Optional
 	IncludeHealth         bool `json:"health"`
 	IncludeIstioResources bool `json:"istioResources"`
+	// Merge the same app of every cluster into one app, with the details of every cluster as its members
+	//
+	// in: query
+	AggregateClusters bool `json:"aggregateClusters"`
 }
 
 func (p *appParams) extract(r *http.Request) {
@@ -44,6 +51,7 @@ func (p *appParams) extract(r *http.Request) {
 	if err != nil {
 		p.IncludeIstioResources = true
 	}
+	p.AggregateClusters, _ = strconv.ParseBool(query.Get("aggregateClusters"))
 }
 
 // ClustersApps is the API handler to fetch all the apps to be displayed, related to a single cluster
@@ -59,34 +67,79 @@ func ClustersApps(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var loadedNamespaces []models.Namespace
+	if p.AggregateClusters {
+		// The namespaces of every cluster, the apps of a namespace are aggregated across its clusters
+		loadedNamespaces, _ = businessLayer.Namespace.GetNamespaces(r.Context())
+	} else {
+		loadedNamespaces, _ = businessLayer.Namespace.GetClusterNamespaces(r.Context(), p.ClusterName)
+	}
+
 	nss := []string{}
+	// The cluster of every namespace used to adjust the rate interval, the first one when aggregating clusters
+	nsClusters := map[string]string{}
 	namespacesFromQueryParams := strings.Split(namespacesQueryParam, ",")
-	loadedNamespaces, _ := businessLayer.Namespace.GetClusterNamespaces(r.Context(), p.ClusterName)
 	for _, ns := range loadedNamespaces {
+		if _, ok := nsClusters[ns.Name]; ok {
+			continue
+		}
 		// If namespaces have been provided in the query, further filter the results to only include those namespaces.
 		if len(namespacesQueryParam) > 0 {
 			if slices.Contains(namespacesFromQueryParams, ns.Name) {
 				nss = append(nss, ns.Name)
+				nsClusters[ns.Name] = ns.Cluster
 			}
 		} else {
 			// Otherwise no namespaces have been provided in the query params, so include all namespaces the user has access to.
 			nss = append(nss, ns.Name)
+			nsClusters[ns.Name] = ns.Cluster
 		}
 	}
 
+	// The list is unchanged while the cached resources of the namespaces are, in every cluster when aggregating
+	clusterNamespaces := map[string][]string{}
+	for _, ns := range loadedNamespaces {
+		if _, ok := nsClusters[ns.Name]; ok && (p.AggregateClusters || ns.Cluster == p.ClusterName) {
+			clusterNamespaces[ns.Cluster] = append(clusterNamespaces[ns.Cluster], ns.Name)
+		}
+	}
+	clusters := maps.Keys(clusterNamespaces)
+	sort.Strings(clusters)
+	versions := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		version, err := businessLayer.Namespace.ResourcesVersion(cluster, clusterNamespaces[cluster], true)
+		if err != nil {
+			log.Debugf("Unable to get the version of the resources of the namespaces: %s", err)
+			versions = nil
+			break
+		}
+		versions = append(versions, version)
+	}
+	if versions != nil && RespondNotModified(w, r, listETag(r, p.IncludeHealth, versions...)) {
+		return
+	}
+
 	clusterAppsList := &models.ClusterApps{
 		Apps:    []models.AppListItem{},
 		Cluster: p.ClusterName,
 	}
+	if p.AggregateClusters {
+		clusterAppsList.Cluster = ""
+	}
 
 	for _, ns := range nss {
 		criteria := business.AppCriteria{
 			Cluster: p.ClusterName, Namespace: ns, IncludeIstioResources: p.IncludeIstioResources,
 			IncludeHealth: p.IncludeHealth, RateInterval: p.RateInterval, QueryTime: p.QueryTime,
+			AggregateClusters: p.AggregateClusters,
 		}
 
 		if p.IncludeHealth {
-			rateInterval, err := adjustRateInterval(r.Context(), businessLayer, ns, p.RateInterval, p.QueryTime, p.ClusterName)
+			cluster := p.ClusterName
+			if p.AggregateClusters {
+				cluster = nsClusters[ns]
+			}
+			rateInterval, err := adjustRateInterval(r.Context(), businessLayer, ns, p.RateInterval, p.QueryTime, cluster)
 			if err != nil {
 				handleErrorResponse(w, err, "Adjust rate interval error: "+err.Error())
 				return
@@ -95,12 +148,24 @@ func ClustersApps(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Fetch and build apps
-		appList, err := businessLayer.App.GetClusterAppList(r.Context(), criteria)
-		if err != nil {
-			handleErrorResponse(w, err)
-			return
+		var apps []models.AppListItem
+		if p.AggregateClusters {
+			criteria.Cluster = ""
+			appList, err := businessLayer.App.GetAppList(r.Context(), criteria)
+			if err != nil {
+				handleErrorResponse(w, err)
+				return
+			}
+			apps = appList.Apps
+		} else {
+			appList, err := businessLayer.App.GetClusterAppList(r.Context(), criteria)
+			if err != nil {
+				handleErrorResponse(w, err)
+				return
+			}
+			apps = appList.Apps
 		}
-		clusterAppsList.Apps = append(clusterAppsList.Apps, appList.Apps...)
+		clusterAppsList.Apps = append(clusterAppsList.Apps, apps...)
 	}
 
 	RespondWithJSON(w, http.StatusOK, clusterAppsList)
@@ -17,12 +17,14 @@ import (
 type AuthenticationHandler struct {
 	conf                config.Config
 	authController      authentication.AuthController
+	clientFactory       kubernetes.ClientFactory
 	homeClusterSAClient kubernetes.ClientInterface
 }
 
 type AuthInfo struct {
 	Strategy              string      `json:"strategy"`
 	AuthorizationEndpoint string      `json:"authorizationEndpoint,omitempty"`
+	AdditionalIssuers     []string    `json:"additionalIssuers,omitempty"`
 	LogoutEndpoint        string      `json:"logoutEndpoint,omitempty"`
 	LogoutRedirect        string      `json:"logoutRedirect,omitempty"`
 	SessionInfo           sessionInfo `json:"sessionInfo"`
@@ -34,15 +36,19 @@ type sessionInfo struct {
 	ExpiresOn string `json:"expiresOn,omitempty"`
 }
 
-func NewAuthenticationHandler(conf config.Config, authController authentication.AuthController, homeClusterSAClient kubernetes.ClientInterface) AuthenticationHandler {
-	return AuthenticationHandler{authController: authController, conf: conf, homeClusterSAClient: homeClusterSAClient}
+func NewAuthenticationHandler(conf config.Config, authController authentication.AuthController, clientFactory kubernetes.ClientFactory) AuthenticationHandler {
+	return AuthenticationHandler{authController: authController, conf: conf, clientFactory: clientFactory, homeClusterSAClient: clientFactory.GetSAHomeClusterClient()}
 }
 
 func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		statusCode := http.StatusOK
 
+		// The user is only set by the auth controllers, a client must not be able to claim it
+		r.Header.Del("Kiali-User")
+
 		var authInfo *api.AuthInfo
+		var user string
 
 		switch aHandler.conf.Auth.Strategy {
 		case config.AuthStrategyToken, config.AuthStrategyOpenId, config.AuthStrategyOpenshift, config.AuthStrategyHeader:
@@ -51,6 +57,7 @@ func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 				statusCode = http.StatusInternalServerError
 			} else if session != nil {
 				authInfo = session.AuthInfo
+				user = session.Username
 				statusCode = http.StatusOK
 			} else {
 				statusCode = http.StatusUnauthorized
@@ -65,8 +72,26 @@ func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 			if authInfo == nil {
 				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 				log.Errorf("No authInfo: %v", http.StatusBadRequest)
+				return
+			}
+			if aHandler.conf.Auth.Impersonation.Enabled && authInfo != nil && authentication.ImpersonationRequested(r) {
+				impersonated, err := aHandler.impersonate(r, authInfo)
+				if err != nil {
+					log.Errorf("Impersonation rejected: %v", err)
+					if authentication.IsImpersonationError(err) {
+						RespondWithError(w, http.StatusForbidden, err.Error())
+					} else {
+						RespondWithError(w, http.StatusInternalServerError, err.Error())
+					}
+					return
+				}
+				authInfo = impersonated
 			}
 			ctx := authentication.SetAuthInfoContext(r.Context(), authInfo)
+			ctx = authentication.SetUserContext(ctx, user)
+			if user != "" {
+				r.Header.Set("Kiali-User", user)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		case http.StatusUnauthorized:
 			err := aHandler.authController.TerminateSession(r, w)
@@ -81,8 +106,19 @@ func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 	})
 }
 
+// impersonate returns the auth info of the user impersonating the identity requested in the headers of the request,
+// once checked on the home cluster with the client of the user
+func (aHandler AuthenticationHandler) impersonate(r *http.Request, authInfo *api.AuthInfo) (*api.AuthInfo, error) {
+	client, err := aHandler.clientFactory.GetClient(authInfo, aHandler.conf.KubernetesConfig.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	return authentication.Impersonate(r.Context(), r, client, authInfo)
+}
+
 func (aHandler AuthenticationHandler) HandleUnauthenticated(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("Kiali-User")
 		ctx := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: ""})
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -129,6 +165,9 @@ func AuthenticationInfo(conf *config.Config, authController authentication.AuthC
 			// Do the redirection through an intermediary own endpoint
 			response.AuthorizationEndpoint = fmt.Sprintf("%s/api/auth/openid_redirect",
 				httputil.GuessKialiURL(conf, r))
+			for _, issuer := range conf.Auth.OpenId.AdditionalIssuers {
+				response.AdditionalIssuers = append(response.AdditionalIssuers, issuer.IssuerUri)
+			}
 		}
 
 		if conf.Auth.Strategy != config.AuthStrategyAnonymous {
@@ -150,6 +189,7 @@ func Logout(conf *config.Config, authController authentication.AuthController) h
 		if conf.Auth.Strategy == config.AuthStrategyAnonymous {
 			RespondWithCode(w, http.StatusNoContent)
 		} else {
+			pushChannels.closeSession(pushSessionID(r))
 			err := authController.TerminateSession(r, w)
 			if err != nil {
 				if e, ok := err.(*authentication.TerminateSessionError); ok {
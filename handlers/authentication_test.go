@@ -12,7 +12,12 @@ import (
 
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
+	auth_v1 "k8s.io/api/authorization/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/kiali/kiali/business/authentication"
 	"github.com/kiali/kiali/config"
@@ -273,3 +278,83 @@ type rejectClient struct{ kubernetes.ClientInterface }
 func (r *rejectClient) GetProjects(ctx context.Context, labelSelector string) ([]osproject_v1.Project, error) {
 	return nil, fmt.Errorf("Rejecting")
 }
+
+// sessionController is an auth controller with an always valid session
+type sessionController struct {
+	authentication.AuthController
+	session *authentication.UserSessionData
+}
+
+func (c sessionController) ValidateSession(r *http.Request, w http.ResponseWriter) (*authentication.UserSessionData, error) {
+	return c.session, nil
+}
+
+// TestImpersonationMiddleware checks that the requests impersonating another user reach the handlers
+// with the impersonated identity, only when the user is allowed to impersonate it
+func TestImpersonationMiddleware(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.Strategy = config.AuthStrategyToken
+	cfg.Auth.Impersonation.Enabled = true
+	config.Set(cfg)
+
+	k8s := kubetest.NewFakeK8sClient()
+	k8s.KubeClientset.(*kubefake.Clientset).PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*auth_v1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name == "jdoe"
+		return true, review, nil
+	})
+	mockClientFactory := kubetest.NewK8SClientFactoryMock(k8s)
+	session := &authentication.UserSessionData{Username: "admin", AuthInfo: &api.AuthInfo{Token: "admin-token"}}
+	authHandler := NewAuthenticationHandler(*cfg, sessionController{session: session}, mockClientFactory)
+
+	var impersonated string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		impersonated = authentication.GetAuthInfoContext(r.Context()).(*api.AuthInfo).Impersonate
+	})
+
+	request := httptest.NewRequest("GET", "http://kiali/api/namespaces", nil)
+	request.Header.Set(authentication.ImpersonateUserHeader, "jdoe")
+	responseRecorder := httptest.NewRecorder()
+	authHandler.Handle(next).ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "jdoe", impersonated)
+	assert.Empty(t, session.AuthInfo.Impersonate)
+
+	impersonated = ""
+	request.Header.Set(authentication.ImpersonateUserHeader, "jane")
+	responseRecorder = httptest.NewRecorder()
+	authHandler.Handle(next).ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Empty(t, impersonated)
+}
+
+func TestAuthenticationHandlerIgnoresUserHeaderOfClient(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.Strategy = config.AuthStrategyToken
+	config.Set(cfg)
+
+	mockClientFactory := kubetest.NewK8SClientFactoryMock(kubetest.NewFakeK8sClient())
+	session := &authentication.UserSessionData{Username: "admin", AuthInfo: &api.AuthInfo{Token: "admin-token"}}
+	authHandler := NewAuthenticationHandler(*cfg, sessionController{session: session}, mockClientFactory)
+
+	var user, header string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user = authentication.GetUserContext(r.Context())
+		header = r.Header.Get("Kiali-User")
+	})
+
+	request := httptest.NewRequest("GET", "http://kiali/api/preferences", nil)
+	request.Header.Set("Kiali-User", "jdoe")
+	responseRecorder := httptest.NewRecorder()
+	authHandler.Handle(next).ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "admin", user)
+	assert.Equal(t, "admin", header)
+
+	// Without authentication nobody is identified
+	request = httptest.NewRequest("GET", "http://kiali/api/status", nil)
+	request.Header.Set("Kiali-User", "jdoe")
+	authHandler.HandleUnauthenticated(next).ServeHTTP(httptest.NewRecorder(), request)
+	assert.Empty(t, user)
+	assert.Empty(t, header)
+}
@@ -1,8 +1,15 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/util"
 )
 
 type responseError struct {
@@ -34,6 +41,54 @@ func RespondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	_, _ = w.Write(response)
 }
 
+// listHealthWindow is how long the health of a list, computed from Prometheus and not versioned by the cache, is
+// part of its ETag: the clients polling a list with the health get it refreshed once per window.
+const listHealthWindow = time.Minute
+
+// listETag is the weak ETag of a list response: a hash of the request and of the versions of the cached resources
+// the list is built from. The ETag is weak as the response may be compressed.
+func listETag(r *http.Request, includeHealth bool, versions ...string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s?%s\n", r.URL.Path, r.URL.RawQuery)
+	if includeHealth {
+		fmt.Fprintf(h, "%d\n", util.Clock.Now().Truncate(listHealthWindow).Unix())
+	}
+	for _, version := range versions {
+		fmt.Fprintf(h, "%s\n", version)
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// RespondNotModified sets the ETag of the response and answers with a 304 without body when the request has it in
+// its If-None-Match. It returns true when the response is written: the ETag is checked before the payload is built,
+// the clients polling data which has not changed get a cheap response.
+func RespondNotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	// The clients must revalidate the responses, which depend on the privileges of the user: they are not stored by
+	// shared caches and are only reused for the same credentials
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Add("Vary", "Authorization, Cookie")
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches returns true when an If-None-Match header has the ETag, compared weakly
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func RespondWithJSONIndent(w http.ResponseWriter, code int, payload interface{}) {
 	response, err := json.MarshalIndent(payload, "", "  ")
 	if err != nil {
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespondNotModified(t *testing.T) {
+	assert := assert.New(t)
+
+	req := httptest.NewRequest("GET", "/api/clusters/services?health=true", nil)
+	etag := listETag(req, false, "version-1")
+	assert.Equal(etag, listETag(req, false, "version-1"))
+	assert.NotEqual(etag, listETag(req, false, "version-2"))
+	assert.NotEqual(etag, listETag(httptest.NewRequest("GET", "/api/clusters/services?health=false", nil), false, "version-1"))
+
+	rr := httptest.NewRecorder()
+	assert.False(RespondNotModified(rr, req, etag))
+	assert.Equal(etag, rr.Header().Get("ETag"))
+	assert.Equal("private, no-cache", rr.Header().Get("Cache-Control"))
+
+	// The Vary of the compression is kept
+	rr = httptest.NewRecorder()
+	rr.Header().Set("Vary", "Accept-Encoding")
+	assert.False(RespondNotModified(rr, req, etag))
+	assert.Equal([]string{"Accept-Encoding", "Authorization, Cookie"}, rr.Header().Values("Vary"))
+
+	// Unchanged
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	rr = httptest.NewRecorder()
+	assert.True(RespondNotModified(rr, req, etag))
+	assert.Equal(http.StatusNotModified, rr.Code)
+	assert.Empty(rr.Body.String())
+	assert.Equal(etag, rr.Header().Get("ETag"))
+
+	// Changed
+	rr = httptest.NewRecorder()
+	assert.False(RespondNotModified(rr, req, listETag(req, false, "version-2")))
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -14,6 +16,9 @@ import (
 	"github.com/kiali/kiali/models"
 )
 
+// Values of dashboard variables are substituted in PromQL queries: only allow names and numbers to prevent any kind of injection
+var dashboardVariableValue = regexp.MustCompile(`^[\w.:/-]*$`)
+
 // CustomDashboard is the API handler to fetch runtime metrics to be displayed, related to a single app
 func CustomDashboard(conf *config.Config, grafana *grafana.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -36,7 +41,7 @@ func CustomDashboard(conf *config.Config, grafana *grafana.Service) http.Handler
 			return
 		}
 
-		params := models.DashboardQuery{Namespace: namespace}
+		params := models.DashboardQuery{Cluster: cluster, Namespace: namespace}
 		err = extractDashboardQueryParams(queryParams, &params, info)
 		if err != nil {
 			RespondWithError(w, http.StatusBadRequest, err.Error())
@@ -95,6 +100,12 @@ func extractDashboardQueryParams(queryParams url.Values, q *models.DashboardQuer
 		q.RawDataAggregator = op
 	}
 	q.Workload = queryParams.Get("workload")
+	q.Variables = extractLabelsFilters(queryParams.Get("variables"))
+	for name, value := range q.Variables {
+		if !dashboardVariableValue.MatchString(value) {
+			return fmt.Errorf("bad request, invalid value for dashboard variable [%s]: %s", name, value)
+		}
+	}
 	return extractBaseMetricsQueryParams(queryParams, &q.RangeQuery, namespaceInfo)
 }
 
@@ -137,7 +148,7 @@ func AppDashboard(conf *config.Config, grafana *grafana.Service) http.HandlerFun
 			return
 		}
 		dashboard := business.NewDashboardsService(conf, grafana, namespaceInfo, nil).BuildIstioDashboard(metrics, params.Direction)
-		RespondWithJSON(w, http.StatusOK, dashboard)
+		respondWithMetrics(w, metricsService, dashboard)
 	}
 }
 
@@ -189,7 +200,7 @@ func ServiceDashboard(conf *config.Config, grafana *grafana.Service) http.Handle
 			return
 		}
 		dashboard := business.NewDashboardsService(conf, grafana, namespaceInfo, nil).BuildIstioDashboard(metrics, params.Direction)
-		RespondWithJSON(w, http.StatusOK, dashboard)
+		respondWithMetrics(w, metricsService, dashboard)
 	}
 }
 
@@ -220,6 +231,6 @@ func WorkloadDashboard(conf *config.Config, grafana *grafana.Service) http.Handl
 			return
 		}
 		dashboard := business.NewDashboardsService(conf, grafana, namespaceInfo, nil).BuildIstioDashboard(metrics, params.Direction)
-		RespondWithJSON(w, http.StatusOK, dashboard)
+		respondWithMetrics(w, metricsService, dashboard)
 	}
 }
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"runtime"
+	rpprof "runtime/pprof"
+	"time"
+
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// KialiAdminOnly restricts a handler to the users allowed to exec into the pods of the Kiali namespace, checked on
+// the home cluster. It must run within the authentication handler. With the anonymous strategy, all the users share
+// the access of Kiali and are not checked.
+func KialiAdminOnly(conf *config.Config, clientFactory kubernetes.ClientFactory, next http.HandlerFunc) http.HandlerFunc {
+	if conf.Auth.Strategy == config.AuthStrategyAnonymous {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		authInfo, err := getAuthInfo(r)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		client, err := clientFactory.GetClient(authInfo, conf.KubernetesConfig.ClusterName)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		isAdmin, err := authentication.IsKialiAdmin(r.Context(), client, conf.Deployment.Namespace)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Cannot check the access to the diagnostics: "+err.Error())
+			return
+		}
+		if !isAdmin {
+			log.FromContext(r.Context()).Warn().Msgf("Access to the diagnostics [%s] denied", r.URL.Path)
+			RespondWithError(w, http.StatusForbidden, "The diagnostics are restricted to the users allowed to exec into the Kiali pods")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// DiagnosticsRuntime returns the goroutine and memory statistics of the Kiali server
+func DiagnosticsRuntime(w http.ResponseWriter, r *http.Request) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	diagnostics := models.RuntimeDiagnostics{
+		Goroutines:     runtime.NumGoroutine(),
+		HeapAllocBytes: memStats.HeapAlloc,
+		HeapObjects:    memStats.HeapObjects,
+		MaxProcs:       runtime.GOMAXPROCS(0),
+		NumGC:          memStats.NumGC,
+		SysBytes:       memStats.Sys,
+	}
+	if memStats.LastGC > 0 {
+		lastGC := time.Unix(0, int64(memStats.LastGC))
+		diagnostics.LastGC = &lastGC
+	}
+	RespondWithJSON(w, http.StatusOK, diagnostics)
+}
+
+// DiagnosticsGoroutines dumps the stacks of the goroutines of the Kiali server, the identical stacks grouped with
+// their count so that the leaking goroutines stand out
+func DiagnosticsGoroutines(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := rpprof.Lookup("goroutine").WriteTo(w, 1); err != nil {
+		log.Errorf("Cannot dump the goroutines: %v", err)
+	}
+}
+
+// DiagnosticsCache returns the number of objects cached for each cluster and the number of entries of the stores of
+// the Kiali cache
+func DiagnosticsCache(kialiCache cache.KialiCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		RespondWithJSON(w, http.StatusOK, kialiCache.Summary())
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	auth_v1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestDiagnosticsKialiAdminOnly(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.Namespace = "istio-system"
+	k8s := kubetest.NewFakeK8sClient()
+	var reviewed *auth_v1.ResourceAttributes
+	allowed := false
+	k8s.KubeClientset.(*kubefake.Clientset).PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*auth_v1.SelfSubjectAccessReview)
+		reviewed = review.Spec.ResourceAttributes
+		review.Status.Allowed = allowed
+		return true, review, nil
+	})
+	handler := KialiAdminOnly(conf, kubetest.NewK8SClientFactoryMock(k8s), DiagnosticsRuntime)
+
+	request := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/debug/diagnostics/runtime", nil)
+		r = r.WithContext(authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"}))
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w
+	}
+
+	require.Equal(http.StatusForbidden, request().Code)
+	require.Equal(&auth_v1.ResourceAttributes{Namespace: "istio-system", Verb: "create", Resource: "pods", Subresource: "exec"}, reviewed)
+
+	allowed = true
+	w := request()
+	require.Equal(http.StatusOK, w.Code)
+	var diagnostics models.RuntimeDiagnostics
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &diagnostics))
+	require.Positive(diagnostics.Goroutines)
+	require.Positive(diagnostics.MaxProcs)
+}
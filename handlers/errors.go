@@ -19,16 +19,26 @@ func handleErrorResponse(w http.ResponseWriter, err error, extraMesg ...string)
 		errorMsg = strings.Join(extraMesg, ";")
 	}
 	log.Error(errorMsg)
+	code, errorMsg := errorStatus(err, errorMsg)
+	RespondWithError(w, code, errorMsg)
+}
+
+// errorStatus returns the HTTP status code of a business error and its message
+func errorStatus(err error, errorMsg string) (int, string) {
 	if business.IsAccessibleError(err) {
-		RespondWithError(w, http.StatusForbidden, errorMsg)
+		return http.StatusForbidden, errorMsg
+	} else if business.IsReadOnlyError(err) || business.IsFeatureDisabledError(err) {
+		return http.StatusForbidden, errorMsg
+	} else if business.IsGitOpsManagedError(err) {
+		return http.StatusConflict, errorMsg
 	} else if errors.IsNotFound(err) {
-		RespondWithError(w, http.StatusNotFound, errorMsg)
+		return http.StatusNotFound, errorMsg
+	} else if errors.IsBadRequest(err) {
+		return http.StatusBadRequest, errorMsg
 	} else if errors.IsServiceUnavailable(err) {
-		RespondWithError(w, http.StatusServiceUnavailable, errorMsg)
+		return http.StatusServiceUnavailable, errorMsg
 	} else if statusError, isStatus := err.(*errors.StatusError); isStatus {
-		errorMsg = statusError.ErrStatus.Message
-		RespondWithError(w, http.StatusInternalServerError, errorMsg)
-	} else {
-		RespondWithError(w, http.StatusInternalServerError, errorMsg)
+		return http.StatusInternalServerError, statusError.ErrStatus.Message
 	}
+	return http.StatusInternalServerError, errorMsg
 }
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/models"
+)
+
+// NamespaceEvents is the API handler to fetch the events of a namespace to overlay on its metrics
+func NamespaceEvents(w http.ResponseWriter, r *http.Request) {
+	getNamespaceEvents(w, r, defaultPromClientSupplier)
+}
+
+// getNamespaceEvents (mock-friendly version)
+func getNamespaceEvents(w http.ResponseWriter, r *http.Request, promSupplier promClientSupplier) {
+	namespace := mux.Vars(r)["namespace"]
+	query := r.URL.Query()
+	cluster := clusterNameFromQuery(query)
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	metricsService, namespaceInfo := createMetricsServiceForNamespaceMC(w, r, promSupplier, namespace)
+	if metricsService == nil {
+		// any returned value nil means error & response already written
+		return
+	}
+
+	params := models.IstioMetricsQuery{
+		Cluster:   cluster,
+		Namespace: namespace,
+		App:       query.Get("app"),
+		Workload:  query.Get("workload"),
+		Service:   query.Get("service"),
+	}
+	if err := extractIstioMetricsQueryParams(r, &params, GetOldestNamespace(namespaceInfo)); err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := metricsService.WithEvents(&business.Events).GetEvents(r.Context(), params)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, events)
+}
+
+// NamespaceMarkerAdd is the API handler to add a marker to the events of a namespace
+func NamespaceMarkerAdd(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	cluster := clusterNameFromQuery(r.URL.Query())
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	if _, err := checkNamespaceAccess(r.Context(), business.Namespace, namespace, cluster); err != nil {
+		RespondWithError(w, http.StatusForbidden, "Cannot access namespace data: "+err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Marker could not be read: "+err.Error())
+		return
+	}
+	var marker models.KialiEvent
+	if err := json.Unmarshal(body, &marker); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Marker could not be parsed: "+err.Error())
+		return
+	}
+	marker.Cluster = cluster
+	marker.Namespace = namespace
+
+	added, err := business.Events.AddMarker(r.Context(), marker, r.Header.Get("Kiali-User"))
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	audit(r, "ADD Marker on Namespace: "+namespace+" Message: "+marker.Message)
+	RespondWithJSON(w, http.StatusOK, added)
+}
@@ -18,8 +18,10 @@ package handlers
 //
 // The handlers accept the following query parameters (see notes below)
 //   appenders:       Comma-separated list of TelemetryVendor-specific appenders to run. (default: all)
+//   compareTime:     Unix time (seconds) ending a previous range, the graph is annotated with the changes since then (default none)
 //   configVendor:    default: cytoscape
 //   duration:        time.Duration indicating desired query range duration, (default: 10m)
+//   format:          Export format replacing the configVendor format. dot | otel-servicegraph (default: none)
 //   graphType:       Determines how to present the telemetry data. app | service | versionedApp | workload (default: workload)
 //   boxBy:           If supported by vendor, visually box by a specified node attribute (default: none)
 //   namespaces:      Comma-separated list of namespace names to use in the graph. Will override namespace path param
@@ -36,6 +38,7 @@ import (
 
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/api"
+	"github.com/kiali/kiali/graph/config/dot"
 	"github.com/kiali/kiali/log"
 )
 
@@ -93,6 +96,12 @@ func handlePanic(w http.ResponseWriter) {
 }
 
 func respond(w http.ResponseWriter, code int, payload interface{}) {
+	if dotConfig, ok := payload.(dot.Config); ok && code == http.StatusOK {
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(dotConfig))
+		return
+	}
 	if code == http.StatusOK {
 		RespondWithJSONIndent(w, code, payload)
 		return
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+// GraphViewsList is the API handler to list the saved graph views
+func GraphViewsList(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	views, err := business.GraphViews.ListGraphViews(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, views)
+}
+
+// GraphViewGet is the API handler to fetch a saved graph view
+func GraphViewGet(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	view, err := business.GraphViews.GetGraphView(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, view)
+}
+
+// GraphViewSave is the API handler to create or replace a graph view
+func GraphViewSave(w http.ResponseWriter, r *http.Request) {
+	if config.Get().Deployment.ViewOnlyMode {
+		RespondWithError(w, http.StatusForbidden, "Graph views cannot be saved in view-only mode")
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Graph view could not be read: "+err.Error())
+		return
+	}
+	var view models.GraphView
+	if err := json.Unmarshal(body, &view); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Graph view could not be parsed: "+err.Error())
+		return
+	}
+	view.Name = mux.Vars(r)["name"]
+
+	saved, err := business.GraphViews.SaveGraphView(r.Context(), view, r.Header.Get("Kiali-User"))
+	if err != nil {
+		handleGraphViewError(w, err)
+		return
+	}
+
+	audit(r, "SAVE Graph view: "+view.Name+" Object: "+string(body))
+	RespondWithJSON(w, http.StatusOK, saved)
+}
+
+// GraphViewDelete is the API handler to delete a graph view
+func GraphViewDelete(w http.ResponseWriter, r *http.Request) {
+	if config.Get().Deployment.ViewOnlyMode {
+		RespondWithError(w, http.StatusForbidden, "Graph views cannot be deleted in view-only mode")
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	if err := business.GraphViews.DeleteGraphView(r.Context(), name); err != nil {
+		handleGraphViewError(w, err)
+		return
+	}
+
+	audit(r, "DELETE Graph view: "+name)
+	RespondWithCode(w, http.StatusOK)
+}
+
+func handleGraphViewError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.IsBadRequest(err):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.IsConflict(err):
+		RespondWithError(w, http.StatusConflict, "Graph views were updated concurrently, try again: "+err.Error())
+	default:
+		handleErrorResponse(w, err)
+	}
+}
@@ -11,7 +11,6 @@ import (
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/log"
-	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/util"
 )
 
@@ -40,11 +39,9 @@ func ClustersHealth(w http.ResponseWriter, r *http.Request) {
 			nss = append(nss, ns.Name)
 		}
 	}
-	result := models.ClustersNamespaceHealth{
-		AppHealth:      map[string]*models.NamespaceAppHealth{},
-		WorkloadHealth: map[string]*models.NamespaceWorkloadHealth{},
-		ServiceHealth:  map[string]*models.NamespaceServiceHealth{},
-	}
+
+	healthType := ""
+	criteria := make([]business.NamespaceHealthCriteria, 0, len(nss))
 	for _, ns := range nss {
 		p := namespaceHealthParams{}
 		if ok, err := p.extract(r, ns); !ok {
@@ -52,6 +49,7 @@ func ClustersHealth(w http.ResponseWriter, r *http.Request) {
 			RespondWithError(w, http.StatusBadRequest, err)
 			return
 		}
+		healthType = p.Type
 
 		// Adjust rate interval
 		rateInterval, err := adjustRateInterval(r.Context(), businessLayer, p.Namespace, p.RateInterval, p.QueryTime, p.ClusterName)
@@ -60,30 +58,14 @@ func ClustersHealth(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		healthCriteria := business.NamespaceHealthCriteria{Namespace: p.Namespace, Cluster: p.ClusterName, RateInterval: rateInterval, QueryTime: p.QueryTime, IncludeMetrics: true}
-		switch p.Type {
-		case "app":
-			health, err := businessLayer.Health.GetNamespaceAppHealth(r.Context(), healthCriteria)
-			if err != nil {
-				handleErrorResponse(w, err, "Error while fetching app health: "+err.Error())
-				return
-			}
-			result.AppHealth[ns] = &health
-		case "service":
-			health, err := businessLayer.Health.GetNamespaceServiceHealth(r.Context(), healthCriteria)
-			if err != nil {
-				handleErrorResponse(w, err, "Error while fetching service health: "+err.Error())
-				return
-			}
-			result.ServiceHealth[ns] = &health
-		case "workload":
-			health, err := businessLayer.Health.GetNamespaceWorkloadHealth(r.Context(), healthCriteria)
-			if err != nil {
-				handleErrorResponse(w, err, "Error while fetching workload health: "+err.Error())
-				return
-			}
-			result.WorkloadHealth[ns] = &health
-		}
+		criteria = append(criteria, business.NamespaceHealthCriteria{Namespace: p.Namespace, Cluster: p.ClusterName, RateInterval: rateInterval, QueryTime: p.QueryTime, IncludeMetrics: true})
+	}
+
+	// The request rates of the namespaces are queried in batches
+	result, err := businessLayer.Health.GetNamespacesHealth(r.Context(), healthType, criteria)
+	if err != nil {
+		handleErrorResponse(w, err, "Error while fetching "+healthType+" health: "+err.Error())
+		return
 	}
 	RespondWithJSON(w, http.StatusOK, result)
 }
@@ -125,10 +107,10 @@ func (p *baseHealthParams) baseExtract(r *http.Request, vars map[string]string)
 // swagger:parameters namespaceHealth
 type namespaceHealthParams struct {
 	baseHealthParams
-	// The type of health, "app", "service" or "workload".
+	// The type of health, "app", "service" or "workload", or "all" for the three of them.
 	//
 	// in: query
-	// pattern: ^(app|service|workload)$
+	// pattern: ^(app|service|workload|all)$
 	// default: app
 	Type string `json:"type"`
 }
@@ -140,8 +122,8 @@ func (p *namespaceHealthParams) extract(r *http.Request, namespace string) (bool
 	p.Namespace = namespace
 	queryParams := r.URL.Query()
 	if healthType := queryParams.Get("type"); healthType != "" {
-		if healthType != "app" && healthType != "service" && healthType != "workload" {
-			return false, "Bad request, query parameter 'type' must be one of ['app','service','workload']"
+		if healthType != "app" && healthType != "service" && healthType != "workload" && healthType != "all" {
+			return false, "Bad request, query parameter 'type' must be one of ['app','service','workload','all']"
 		}
 		p.Type = healthType
 	}
@@ -2,14 +2,21 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"golang.org/x/exp/slices"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 )
@@ -42,12 +49,44 @@ func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 		workloadSelector = query.Get("workloadSelector")
 	}
 
+	if gvks := query.Get("gvks"); gvks != "" {
+		resourceTypes, err := business.ParseIstioConfigGVKs(gvks)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, resourceType := range resourceTypes {
+			if !slices.Contains(parsedTypes, resourceType) {
+				parsedTypes = append(parsedTypes, resourceType)
+			}
+		}
+		objects = strings.Join(parsedTypes, ",")
+	}
+
+	if labelSelector != "" {
+		if _, err := labels.Parse(labelSelector); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid label selector: "+err.Error())
+			return
+		}
+	}
+
+	validationSeverity, err := business.ParseValidationSeverity(query.Get("validationStatus"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	cluster := clusterNameFromQuery(query)
 	if !config.Get().ExternalServices.Istio.IstioAPIEnabled {
 		includeValidations = false
+		if validationSeverity != "" {
+			RespondWithError(w, http.StatusBadRequest, "Validations are not available, the Istio API is disabled")
+			return
+		}
 	}
 
 	criteria := business.ParseIstioConfigCriteria(objects, labelSelector, workloadSelector)
+	criteria.ValidationSeverity = validationSeverity
 
 	// Get business layer
 	business, err := getBusiness(r)
@@ -56,6 +95,21 @@ func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The list and its validations are unchanged while the Istio config and the workloads of the cluster are
+	namespaces := []string{namespace}
+	if namespace == "" {
+		namespaces = []string{}
+		clusterNamespaces, _ := business.Namespace.GetClusterNamespaces(r.Context(), cluster)
+		for _, ns := range clusterNamespaces {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	if version, err := business.Namespace.ResourcesVersion(cluster, namespaces, false); err != nil {
+		log.Debugf("Unable to get the version of the Istio config: %s", err)
+	} else if RespondNotModified(w, r, listETag(r, false, version)) {
+		return
+	}
+
 	var istioConfig *models.IstioConfigList
 	if namespace != "" {
 		istioConfig, err = business.IstioConfig.GetIstioConfigListForNamespace(r.Context(), cluster, namespace, criteria)
@@ -71,16 +125,22 @@ func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if includeValidations {
+	if includeValidations || criteria.ValidationSeverity != "" {
 		// We don't filter by service and workload when calling validations, because certain validations require fetching all types to get the correct errors
 		// when namespace is empty, validaions should be done per all namespaces to apply object filters
-		istioConfig.IstioValidations, err = business.Validations.GetValidations(r.Context(), cluster, namespace, "", "")
+		validations, err := business.Validations.GetValidations(r.Context(), cluster, namespace, "", "")
 		if err != nil {
 			handleErrorResponse(w, err)
 			return
 		}
-		if len(parsedTypes) > 0 {
-			istioConfig.IstioValidations = istioConfig.IstioValidations.FilterByTypes(parsedTypes)
+		if criteria.ValidationSeverity != "" {
+			istioConfig.FilterByValidationSeverity(validations, cluster, criteria.ValidationSeverity)
+		}
+		if includeValidations {
+			istioConfig.IstioValidations = validations
+			if len(parsedTypes) > 0 {
+				istioConfig.IstioValidations = istioConfig.IstioValidations.FilterByTypes(parsedTypes)
+			}
 		}
 	}
 
@@ -168,6 +228,69 @@ func IstioConfigDetails(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, istioConfigDetails)
 }
 
+// IstioConfigImpact returns the Istio objects referencing an Istio object, those that could break if it was deleted.
+func IstioConfigImpact(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	query := r.URL.Query()
+	cluster := clusterNameFromQuery(query)
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	impact, err := business.Validations.GetReferenceImpact(r.Context(), cluster, namespace, objectType, object)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, impact)
+}
+
+// IstioConfigDeletionImpact returns what would lose routing, mTLS or authz behavior if an Istio object was deleted.
+func IstioConfigDeletionImpact(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	query := r.URL.Query()
+	cluster := clusterNameFromQuery(query)
+
+	gvk, found := kubernetes.GVKForResourceType(objectType)
+	if !found {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	impact, err := business.IstioConfig.GetDeletionImpact(r.Context(), cluster, namespace, gvk, object)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, impact)
+}
+
 func IstioConfigDelete(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	namespace := params["namespace"]
@@ -194,6 +317,7 @@ func IstioConfigDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	} else {
 		audit(r, "DELETE on Namespace: "+namespace+" Type: "+objectType+" Name: "+object)
+		business.Events.RecordConfigChange(r.Context(), models.KialiEvent{Cluster: cluster, Namespace: namespace, ObjectType: objectType, ObjectName: object}, "Deletion", r.Header.Get("Kiali-User"))
 		RespondWithCode(w, http.StatusOK)
 	}
 }
@@ -224,6 +348,15 @@ func IstioConfigUpdate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Update request with bad update patch: "+err.Error())
 	}
 	jsonPatch := string(body)
+	if isDryRun(query) {
+		validation, err := business.IstioConfig.ValidateIstioConfigUpdate(r.Context(), cluster, namespace, objectType, object, jsonPatch)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, validation)
+		return
+	}
 	updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(r.Context(), cluster, namespace, objectType, object, jsonPatch)
 	if err != nil {
 		handleErrorResponse(w, err)
@@ -231,6 +364,7 @@ func IstioConfigUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	audit(r, "UPDATE on Namespace: "+namespace+" Type: "+objectType+" Name: "+object+" Patch: "+jsonPatch)
+	business.Events.RecordConfigChange(r.Context(), models.KialiEvent{Cluster: cluster, Namespace: namespace, ObjectType: objectType, ObjectName: object}, "Update", r.Header.Get("Kiali-User"))
 	RespondWithJSON(w, http.StatusOK, updatedConfigDetails)
 }
 
@@ -260,6 +394,16 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Create request could not be read: "+err.Error())
 	}
 
+	if isDryRun(query) {
+		validation, err := business.IstioConfig.ValidateIstioConfigCreate(r.Context(), cluster, namespace, objectType, body)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, validation)
+		return
+	}
+
 	createdConfigDetails, err := business.IstioConfig.CreateIstioConfigDetail(r.Context(), cluster, namespace, objectType, body)
 	if err != nil {
 		handleErrorResponse(w, err)
@@ -267,9 +411,18 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	audit(r, "CREATE on Namespace: "+namespace+" Type: "+objectType+" Object: "+string(body))
+	created := meta_v1.PartialObjectMetadata{}
+	_ = json.Unmarshal(body, &created)
+	business.Events.RecordConfigChange(r.Context(), models.KialiEvent{Cluster: cluster, Namespace: namespace, ObjectType: objectType, ObjectName: created.Name}, "Creation", r.Header.Get("Kiali-User"))
 	RespondWithJSON(w, http.StatusOK, createdConfigDetails)
 }
 
+// isDryRun returns true when a create or an update only validates the object, without persisting it
+func isDryRun(query url.Values) bool {
+	dryRun, _ := strconv.ParseBool(query.Get("dryRun"))
+	return dryRun
+}
+
 func checkObjectType(objectType string) bool {
 	return business.GetIstioAPI(objectType)
 }
@@ -305,3 +458,57 @@ func IstioConfigPermissions(w http.ResponseWriter, r *http.Request) {
 	}
 	RespondWithJSON(w, http.StatusOK, istioConfigPermissions)
 }
+
+// AuthorizationCoverage reports how the workloads of a namespace are covered by the AuthorizationPolicies
+func AuthorizationCoverage(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	cluster := clusterNameFromQuery(r.URL.Query())
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	coverage, err := business.IstioConfig.GetAuthorizationCoverage(r.Context(), cluster, namespace)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, coverage)
+}
+
+// IstioConfigSchemas is the API handler to fetch the schemas of the Istio and Gateway API kinds of a cluster, used
+// by the config editor
+func IstioConfigSchemas(w http.ResponseWriter, r *http.Request) {
+	cluster := clusterNameFromQuery(r.URL.Query())
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	if !business.Mesh.IsValidCluster(cluster) {
+		RespondWithError(w, http.StatusBadRequest, "Cluster "+cluster+" does not exist")
+		return
+	}
+
+	schemas, err := business.IstioConfig.GetIstioConfigSchemas(r.Context(), cluster)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, schemas)
+}
+
+// IstioLocalities is the API handler to fetch the localities of the nodes of the clusters, used by the locality
+// load balancing wizard
+func IstioLocalities(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, business.IstioConfig.GetLocalities(r.Context()))
+}
@@ -21,3 +21,20 @@ func IstioStatus(w http.ResponseWriter, r *http.Request) {
 
 	RespondWithJSON(w, http.StatusOK, istioStatus)
 }
+
+// MeshComponentStatus returns the status of the istio components grouped per mesh
+func MeshComponentStatus(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	status, err := business.IstioStatus.GetMeshComponentStatus(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, status)
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/grafana"
 	"github.com/kiali/kiali/kubernetes"
@@ -45,6 +48,129 @@ func IstiodCanariesStatus(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, irt)
 }
 
+// RevisionUpgradeProgress reports how the dataplane is spread across the controlplane revisions.
+func RevisionUpgradeProgress(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	conf := config.Get()
+
+	// Same as the mesh, proxies are only reported to users with access to the istio system namespace.
+	if _, err := business.Namespace.GetClusterNamespace(r.Context(), conf.IstioNamespace, conf.KubernetesConfig.ClusterName); err != nil {
+		RespondWithError(w, http.StatusForbidden, fmt.Sprintf("Unable to access '%s' namespace. You need access to this to get mesh info. Error: %s ", conf.IstioNamespace, err))
+		return
+	}
+
+	progress, err := business.Mesh.RevisionUpgradeProgress(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, progress)
+}
+
+// MeshCertificates returns the inventory of the certificates used by the mesh.
+func MeshCertificates(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	conf := config.Get()
+
+	// The mesh CA lives in the istio system namespace.
+	if _, err := business.Namespace.GetClusterNamespace(r.Context(), conf.IstioNamespace, conf.KubernetesConfig.ClusterName); err != nil {
+		RespondWithError(w, http.StatusForbidden, fmt.Sprintf("Unable to access '%s' namespace. You need access to this to get mesh info. Error: %s ", conf.IstioNamespace, err))
+		return
+	}
+
+	inventory, err := business.Certificates.GetCertificateInventory(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, inventory)
+}
+
+// MeshTrustDomains returns the trust domains of the mesh and the principals referenced by the authorization policies.
+func MeshTrustDomains(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	conf := config.Get()
+
+	// Trust domains come from the meshConfig of the controlplanes.
+	if _, err := business.Namespace.GetClusterNamespace(r.Context(), conf.IstioNamespace, conf.KubernetesConfig.ClusterName); err != nil {
+		RespondWithError(w, http.StatusForbidden, fmt.Sprintf("Unable to access '%s' namespace. You need access to this to get mesh info. Error: %s ", conf.IstioNamespace, err))
+		return
+	}
+
+	report, err := business.Mesh.GetTrustDomainReport(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, report)
+}
+
+// MeshConfigDrift returns the Istio objects whose spec differs between the controlplane clusters of the mesh.
+func MeshConfigDrift(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	conf := config.Get()
+
+	// The controlplanes of the mesh are discovered in the istio system namespace.
+	if _, err := business.Namespace.GetClusterNamespace(r.Context(), conf.IstioNamespace, conf.KubernetesConfig.ClusterName); err != nil {
+		RespondWithError(w, http.StatusForbidden, fmt.Sprintf("Unable to access '%s' namespace. You need access to this to get mesh info. Error: %s ", conf.IstioNamespace, err))
+		return
+	}
+
+	report, err := business.IstioConfig.GetConfigDriftReport(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, report)
+}
+
+// MeshEgress returns how the traffic leaves the mesh and the external destinations that are not registered.
+func MeshEgress(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	conf := config.Get()
+
+	// The outbound traffic policy comes from the meshConfig of the controlplanes.
+	if _, err := business.Namespace.GetClusterNamespace(r.Context(), conf.IstioNamespace, conf.KubernetesConfig.ClusterName); err != nil {
+		RespondWithError(w, http.StatusForbidden, fmt.Sprintf("Unable to access '%s' namespace. You need access to this to get mesh info. Error: %s ", conf.IstioNamespace, err))
+		return
+	}
+
+	p := baseHealthParams{}
+	p.baseExtract(r, mux.Vars(r))
+
+	report, err := business.Egress.GetEgressReport(r.Context(), p.RateInterval, p.QueryTime)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, report)
+}
+
 func GetMesh(w http.ResponseWriter, r *http.Request) {
 	business, err := getBusiness(r)
 	if err != nil {
@@ -69,6 +195,52 @@ func GetMesh(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, mesh)
 }
 
+// RefreshControlPlane scrapes the istiod(s) of a single controlplane right away so that
+// the proxy status, registry and config distribution of that controlplane are up to date.
+func RefreshControlPlane(cpm business.ControlPlaneMonitor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		layer, err := getBusiness(r)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cluster := clusterNameFromQuery(r.URL.Query())
+		revision := mux.Vars(r)["revision"]
+
+		mesh, err := layer.Mesh.GetMesh(r.Context())
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		istiodNamespace := ""
+		for _, controlPlane := range mesh.ControlPlanes {
+			if controlPlane.Cluster.Name == cluster && controlPlane.Revision == revision {
+				istiodNamespace = controlPlane.IstiodNamespace
+				break
+			}
+		}
+		if istiodNamespace == "" {
+			RespondWithError(w, http.StatusNotFound, fmt.Sprintf("Controlplane [%s] not found in cluster [%s]", revision, cluster))
+			return
+		}
+
+		// Refreshing hits istiod so only users with access to the controlplane namespace can trigger it.
+		if _, err := layer.Namespace.GetClusterNamespace(r.Context(), istiodNamespace, cluster); err != nil {
+			RespondWithError(w, http.StatusForbidden, fmt.Sprintf("Unable to access '%s' namespace. You need access to this to refresh the controlplane. Error: %s ", istiodNamespace, err))
+			return
+		}
+
+		if err := cpm.RefreshControlPlane(r.Context(), cluster, revision); err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+
+		RespondWithCode(w, http.StatusNoContent)
+	}
+}
+
 // MeshGraph is a REST http.HandlerFunc handling graph generation for the mesh
 func MeshGraph(conf *config.Config, clientFactory kubernetes.ClientFactory, cache cache.KialiCache, grafana *grafana.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
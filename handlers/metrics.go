@@ -52,7 +52,7 @@ func getAppMetrics(w http.ResponseWriter, r *http.Request, promSupplier promClie
 		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
-	RespondWithJSON(w, http.StatusOK, metrics)
+	respondWithMetrics(w, metricsService, metrics)
 }
 
 // WorkloadMetrics is the API handler to fetch metrics to be displayed, related to a single workload
@@ -86,7 +86,7 @@ func getWorkloadMetrics(w http.ResponseWriter, r *http.Request, promSupplier pro
 		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
-	RespondWithJSON(w, http.StatusOK, metrics)
+	respondWithMetrics(w, metricsService, metrics)
 }
 
 // ServiceMetrics is the API handler to fetch metrics to be displayed, related to a single service
@@ -120,7 +120,7 @@ func getServiceMetrics(w http.ResponseWriter, r *http.Request, promSupplier prom
 		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
-	RespondWithJSON(w, http.StatusOK, metrics)
+	respondWithMetrics(w, metricsService, metrics)
 }
 
 // AggregateMetrics is the API handler to fetch metrics to be displayed, related to a single aggregate
@@ -162,7 +162,7 @@ func getAggregateMetrics(w http.ResponseWriter, r *http.Request, promSupplier pr
 		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
 		return
 	}
-	RespondWithJSON(w, http.StatusOK, metrics)
+	respondWithMetrics(w, metricsService, metrics)
 }
 
 // NamespaceMetrics is the API handler to fetch metrics to be displayed, related to all
@@ -216,7 +216,7 @@ func getNamespaceMetrics(w http.ResponseWriter, r *http.Request, promSupplier pr
 		}
 	}
 
-	RespondWithJSON(w, http.StatusOK, metrics)
+	respondWithMetrics(w, metricsService, metrics)
 }
 
 // ClustersMetrics is the API handler to fetch metrics to be displayed, related to all
@@ -289,7 +289,18 @@ func getClustersMetrics(w http.ResponseWriter, r *http.Request, promSupplier pro
 		result[namespace] = metrics
 	}
 
-	RespondWithJSON(w, http.StatusOK, result)
+	respondWithMetrics(w, metricsService, result)
+}
+
+// PrometheusWarningsHeader lists the warnings returned by Prometheus along with the metrics of the response,
+// e.g. when the backend could only return partial data.
+const PrometheusWarningsHeader = "Kiali-Prometheus-Warnings"
+
+func respondWithMetrics(w http.ResponseWriter, metricsService *business.MetricsService, payload interface{}) {
+	if warnings := metricsService.Warnings(); len(warnings) > 0 {
+		w.Header().Set(PrometheusWarningsHeader, strings.Join(warnings, "; "))
+	}
+	RespondWithJSON(w, http.StatusOK, payload)
 }
 
 func extractIstioMetricsQueryParams(r *http.Request, q *models.IstioMetricsQuery, namespaceInfo *models.Namespace) error {
@@ -317,6 +328,13 @@ func extractIstioMetricsQueryParams(r *http.Request, q *models.IstioMetricsQuery
 		}
 		q.Reporter = reporter
 	}
+	if byFlags := queryParams.Get("byFlags"); byFlags != "" {
+		if flags, err := strconv.ParseBool(byFlags); err == nil {
+			q.ByFlags = flags
+		} else {
+			return errors.New("bad request, cannot parse query parameter 'byFlags'")
+		}
+	}
 	return extractBaseMetricsQueryParams(queryParams, &q.RangeQuery, namespaceInfo)
 }
 
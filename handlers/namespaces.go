@@ -162,3 +162,59 @@ func NamespaceUpdate(w http.ResponseWriter, r *http.Request) {
 	audit(r, "UPDATE on Namespace: "+namespace+" Patch: "+jsonPatch)
 	RespondWithJSON(w, http.StatusOK, ns)
 }
+
+// NamespaceAccessAudit is the API handler reporting per cluster which namespaces are visible to the user and why, and
+// which are filtered out
+func NamespaceAccessAudit(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	audit, err := business.Namespace.GetNamespaceAccessAudit(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, audit)
+}
+
+// NamespaceMeshReadiness is the API handler evaluating the checklist of the onboarding of a namespace into the mesh
+func NamespaceMeshReadiness(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	cluster := clusterNameFromQuery(r.URL.Query())
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	readiness, err := business.IstioConfig.GetNamespaceMeshReadiness(r.Context(), cluster, namespace)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, readiness)
+}
+
+// EffectiveMeshConfig returns the meshConfig values the proxies of a namespace, or of a workload, run with.
+func EffectiveMeshConfig(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	cluster := clusterNameFromQuery(r.URL.Query())
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	// The workload is only set on the workload route
+	meshConfig, err := business.IstioConfig.GetEffectiveMeshConfig(r.Context(), cluster, params["namespace"], params["workload"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, meshConfig)
+}
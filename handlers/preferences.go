@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/models"
+)
+
+// PreferencesGet is the API handler to fetch the preferences of the user
+func PreferencesGet(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	preferences, err := business.Preferences.GetPreferences(r.Context(), authentication.GetUserContext(r.Context()))
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, preferences)
+}
+
+// PreferencesSave is the API handler to replace the preferences of the user
+func PreferencesSave(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Preferences could not be read: "+err.Error())
+		return
+	}
+	var preferences models.UserPreferences
+	if err := json.Unmarshal(body, &preferences); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Preferences could not be parsed: "+err.Error())
+		return
+	}
+
+	saved, err := business.Preferences.SavePreferences(r.Context(), authentication.GetUserContext(r.Context()), preferences)
+	if err != nil {
+		switch {
+		case errors.IsBadRequest(err):
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.IsConflict(err):
+			RespondWithError(w, http.StatusConflict, "Preferences were updated concurrently, try again: "+err.Error())
+		default:
+			handleErrorResponse(w, err)
+		}
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, saved)
+}
+
+// PreferencesDelete is the API handler to reset the preferences of the user
+func PreferencesDelete(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	if err := business.Preferences.DeletePreferences(r.Context(), authentication.GetUserContext(r.Context())); err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithCode(w, http.StatusOK)
+}
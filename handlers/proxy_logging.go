@@ -3,7 +3,9 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -49,3 +51,74 @@ func LoggingUpdate(w http.ResponseWriter, r *http.Request) {
 	audit(r, "UPDATE Envoy log. Cluster: "+cluster+" Namespace: "+namespace+" Pod: "+pod+" Log level:"+level)
 	RespondWithCode(w, 200)
 }
+
+// WorkloadLoggingUpdate sets the proxy log level of all the pods of a workload, reset after the optional ttl.
+func WorkloadLoggingUpdate(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+
+	var ttl time.Duration
+	if ttlParam := query.Get("ttl"); ttlParam != "" {
+		var err error
+		if ttl, err = time.ParseDuration(ttlParam); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid ttl: "+err.Error())
+			return
+		}
+	}
+
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	cluster := clusterNameFromQuery(query)
+	namespace := params["namespace"]
+	workload := params["workload"]
+	level := query.Get("level")
+
+	result, err := businessLayer.ProxyLogging.SetWorkloadLogLevel(r.Context(), cluster, namespace, workload, level, ttl)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	audit(r, "UPDATE Envoy log. Cluster: "+cluster+" Namespace: "+namespace+" Workload: "+workload+" Log level: "+level+" TTL: "+ttl.String())
+	RespondWithJSON(w, http.StatusOK, result)
+}
+
+// PodProxyTap captures the requests going through the proxy of a pod for the duration, 10 seconds by default.
+func PodProxyTap(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+
+	duration := 10 * time.Second
+	if durationParam := query.Get("duration"); durationParam != "" {
+		seconds, err := strconv.Atoi(durationParam)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid duration: "+err.Error())
+			return
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	criteria := business.ProxyTapCriteria{
+		Cluster:   clusterNameFromQuery(query),
+		Namespace: params["namespace"],
+		Pod:       params["pod"],
+		Duration:  duration,
+		Status:    query.Get("status"),
+	}
+	audit(r, "TAP Envoy. Cluster: "+criteria.Cluster+" Namespace: "+criteria.Namespace+" Pod: "+criteria.Pod+" Duration: "+duration.String())
+	capture, err := businessLayer.ProxyTap.Capture(r.Context(), criteria)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, capture)
+}
@@ -50,3 +50,26 @@ func ConfigDumpResourceEntries(w http.ResponseWriter, r *http.Request) {
 
 	RespondWithJSON(w, http.StatusOK, dump)
 }
+
+// ProxyStatusDiff tells what is out of sync between istiod and the proxy of a pod.
+func ProxyStatusDiff(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	cluster := clusterNameFromQuery(r.URL.Query())
+	namespace := params["namespace"]
+	pod := params["pod"]
+
+	diff, err := business.ProxyStatus.GetProxyStatusDiff(r.Context(), cluster, namespace, pod)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, diff)
+}
@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/util"
+)
+
+const (
+	pushActionSubscribe   = "subscribe"
+	pushActionUnsubscribe = "unsubscribe"
+
+	pushTypeError       = "error"
+	pushTypeHealth      = "health"
+	pushTypeValidations = "validations"
+)
+
+var (
+	// pushDebounce groups the config changes of the cache before refreshing the subscriptions
+	pushDebounce = time.Second
+	// pushHealthInterval is the refresh interval of the health, which depends on the traffic rather than on the config
+	pushHealthInterval = 15 * time.Second
+	// pushSessionCheckInterval is how often the session of a push channel is validated again, the channel is closed
+	// once the session expired or its token was revoked
+	pushSessionCheckInterval = time.Minute
+	pushWriteTimeout         = 10 * time.Second
+)
+
+// The default origin check only accepts the connections of the pages served by Kiali
+var pushUpgrader = websocket.Upgrader{}
+
+// PushSubscription is a message of the client of the push channel, registering or removing its interest in namespaces
+type PushSubscription struct {
+	// subscribe or unsubscribe
+	Action string `json:"action"`
+	// The cluster of the namespaces, the home cluster when empty
+	Cluster    string   `json:"cluster"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// PushMessage is a message of the push channel: the app health or the validation summary of a namespace, sent when it
+// changes, or an error.
+type PushMessage struct {
+	// health, validations or error
+	Type      string      `json:"type"`
+	Cluster   string      `json:"cluster,omitempty"`
+	Namespace string      `json:"namespace,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type pushKey struct {
+	cluster   string
+	namespace string
+}
+
+type pushMessageKey struct {
+	pushKey
+	msgType string
+}
+
+// pushSession is the push channel of a client. Its subscriptions are refreshed on the config changes of the cache of
+// their cluster and periodically for the health, and only the changed states are sent.
+type pushSession struct {
+	authController authentication.AuthController
+	conf           *config.Config
+	conn           *websocket.Conn
+	layer          *business.Layer
+	// The upgraded request, holding the session cookies
+	request              *http.Request
+	sessionCheckInterval time.Duration
+
+	lock          sync.Mutex
+	subscriptions map[pushKey]bool
+	// The subscriptions to refresh, with their validations
+	dirty   map[pushKey]bool
+	changed chan struct{}
+
+	writeLock sync.Mutex
+	// The last message sent by type and subscription
+	last map[pushMessageKey]string
+}
+
+// PushUpdates is the API handler of the push channel, a WebSocket where the client subscribes to namespaces and
+// receives their health and validation updates instead of polling them.
+func PushUpdates(conf *config.Config, kialiCache cache.KialiCache, authController authentication.AuthController) http.HandlerFunc {
+	sessionCheckInterval := pushSessionCheckInterval
+	return func(w http.ResponseWriter, r *http.Request) {
+		layer, err := getBusiness(r)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		conn, err := pushUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader already responded with the error
+			log.Debugf("Unable to open the push channel: %s", err)
+			return
+		}
+		defer conn.Close()
+
+		session := &pushSession{
+			authController:       authController,
+			conf:                 conf,
+			conn:                 conn,
+			layer:                layer,
+			request:              r,
+			sessionCheckInterval: sessionCheckInterval,
+			subscriptions:        map[pushKey]bool{},
+			dirty:                map[pushKey]bool{},
+			changed:              make(chan struct{}, 1),
+			last:                 map[pushMessageKey]string{},
+		}
+		for cluster, kubeCache := range kialiCache.GetKubeCaches() {
+			cluster := cluster
+			remove := kubeCache.AddConfigChangeListener(func(namespace string) { session.configChanged(cluster, namespace) })
+			defer remove()
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		defer pushChannels.add(pushSessionID(r), session)()
+		go session.refresh(ctx)
+		session.read(ctx)
+	}
+}
+
+// pushChannels are the open push channels by session, closed on the logout of their session
+var pushChannels = &pushChannelRegistry{sessions: map[string]map[*pushSession]bool{}}
+
+type pushChannelRegistry struct {
+	lock     sync.Mutex
+	sessions map[string]map[*pushSession]bool
+}
+
+// add registers the channel of a session. The returned function removes it.
+func (pc *pushChannelRegistry) add(id string, s *pushSession) func() {
+	if id == "" {
+		return func() {}
+	}
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+	if pc.sessions[id] == nil {
+		pc.sessions[id] = map[*pushSession]bool{}
+	}
+	pc.sessions[id][s] = true
+	return func() {
+		pc.lock.Lock()
+		defer pc.lock.Unlock()
+		delete(pc.sessions[id], s)
+		if len(pc.sessions[id]) == 0 {
+			delete(pc.sessions, id)
+		}
+	}
+}
+
+// closeSession closes the channels of a session
+func (pc *pushChannelRegistry) closeSession(id string) {
+	if id == "" {
+		return
+	}
+	pc.lock.Lock()
+	sessions := pc.sessions[id]
+	delete(pc.sessions, id)
+	pc.lock.Unlock()
+	for s := range sessions {
+		s.close("Session terminated")
+	}
+}
+
+// pushSessionID identifies the session of a request by a hash of its session cookie, empty without session
+func pushSessionID(r *http.Request) string {
+	cookie, err := r.Cookie(authentication.AESSessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(cookie.Value))
+	return hex.EncodeToString(sum[:])
+}
+
+// read handles the subscriptions of the client until the connection is closed
+func (s *pushSession) read(ctx context.Context) {
+	for {
+		_, b, err := s.conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Debugf("Push channel closed: %s", err)
+			}
+			return
+		}
+		var subscription PushSubscription
+		if err := json.Unmarshal(b, &subscription); err != nil {
+			s.send(PushMessage{Type: pushTypeError, Error: "Subscription could not be parsed: " + err.Error()})
+			continue
+		}
+		if subscription.Cluster == "" {
+			subscription.Cluster = s.conf.KubernetesConfig.ClusterName
+		}
+
+		switch subscription.Action {
+		case pushActionSubscribe:
+			for _, namespace := range subscription.Namespaces {
+				// Only the namespaces accessible to the user can be subscribed
+				if _, err := s.layer.Namespace.GetClusterNamespace(ctx, namespace, subscription.Cluster); err != nil {
+					s.send(PushMessage{Type: pushTypeError, Cluster: subscription.Cluster, Namespace: namespace, Error: err.Error()})
+					continue
+				}
+				s.subscribe(pushKey{cluster: subscription.Cluster, namespace: namespace})
+			}
+		case pushActionUnsubscribe:
+			for _, namespace := range subscription.Namespaces {
+				s.unsubscribe(pushKey{cluster: subscription.Cluster, namespace: namespace})
+			}
+		default:
+			s.send(PushMessage{Type: pushTypeError, Error: "Unknown subscription action [" + subscription.Action + "]"})
+		}
+	}
+}
+
+func (s *pushSession) subscribe(key pushKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.subscriptions[key] = true
+	s.dirty[key] = true
+	s.signal()
+}
+
+func (s *pushSession) unsubscribe(key pushKey) {
+	s.lock.Lock()
+	delete(s.subscriptions, key)
+	delete(s.dirty, key)
+	s.lock.Unlock()
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	for _, msgType := range []string{pushTypeHealth, pushTypeValidations} {
+		delete(s.last, pushMessageKey{pushKey: key, msgType: msgType})
+	}
+}
+
+func (s *pushSession) subscribed(key pushKey) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.subscriptions[key]
+}
+
+// configChanged marks the subscriptions of the namespace of a changed object to refresh. The objects of the root
+// namespace and the cluster scoped objects apply to the whole mesh, all the subscriptions of the cluster are
+// refreshed then.
+func (s *pushSession) configChanged(cluster, namespace string) {
+	meshWide := namespace == "" || namespace == s.conf.ExternalServices.Istio.RootNamespace
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key := range s.subscriptions {
+		if key.cluster == cluster && (meshWide || key.namespace == namespace) {
+			s.dirty[key] = true
+		}
+	}
+	if len(s.dirty) > 0 {
+		s.signal()
+	}
+}
+
+// signal wakes up the refresh loop, without blocking the informers. The lock must be held.
+func (s *pushSession) signal() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// refresh pushes the updates of the subscriptions until the context is done
+func (s *pushSession) refresh(ctx context.Context) {
+	healthTicker := time.NewTicker(pushHealthInterval)
+	defer healthTicker.Stop()
+	sessionTicker := time.NewTicker(s.sessionCheckInterval)
+	defer sessionTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sessionTicker.C:
+			if !s.sessionValid() {
+				s.close("Session expired")
+				return
+			}
+		case <-s.changed:
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pushDebounce):
+			}
+			s.lock.Lock()
+			dirty := s.dirty
+			s.dirty = map[pushKey]bool{}
+			s.lock.Unlock()
+			for key := range dirty {
+				if s.subscribed(key) {
+					s.pushHealth(ctx, key)
+					s.pushValidations(ctx, key)
+				}
+			}
+		case <-healthTicker.C:
+			s.lock.Lock()
+			subscriptions := make([]pushKey, 0, len(s.subscriptions))
+			for key := range s.subscriptions {
+				subscriptions = append(subscriptions, key)
+			}
+			s.lock.Unlock()
+			for _, key := range subscriptions {
+				s.pushHealth(ctx, key)
+			}
+		}
+	}
+}
+
+// sessionValid validates again the session of the channel, with the cookies of the upgraded request
+func (s *pushSession) sessionValid() bool {
+	if s.conf.Auth.Strategy == config.AuthStrategyAnonymous || s.authController == nil {
+		return true
+	}
+	session, err := s.authController.ValidateSession(s.request, &discardResponseWriter{header: http.Header{}})
+	if err != nil {
+		log.Debugf("Unable to validate the session of the push channel: %s", err)
+		return false
+	}
+	return session != nil
+}
+
+// close closes the channel, the reading of the subscriptions stops with the connection
+func (s *pushSession) close(reason string) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	if err := s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(pushWriteTimeout)); err != nil {
+		log.Debugf("Unable to close the push channel: %s", err)
+	}
+	s.conn.Close()
+}
+
+// discardResponseWriter is the response of the session validations of the push channels, whose connection is
+// hijacked: the cookies the validation sets are dropped.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (d *discardResponseWriter) Header() http.Header         { return d.header }
+func (d *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (d *discardResponseWriter) WriteHeader(int)             {}
+
+func (s *pushSession) pushHealth(ctx context.Context, key pushKey) {
+	queryTime := util.Clock.Now()
+	rateInterval, err := adjustRateInterval(ctx, s.layer, key.namespace, defaultHealthRateInterval, queryTime, key.cluster)
+	if err != nil {
+		s.sendError(key, pushTypeHealth, err)
+		return
+	}
+	health, err := s.layer.Health.GetNamespaceAppHealth(ctx, business.NamespaceHealthCriteria{
+		Namespace: key.namespace, Cluster: key.cluster, RateInterval: rateInterval, QueryTime: queryTime, IncludeMetrics: true,
+	})
+	if err != nil {
+		s.sendError(key, pushTypeHealth, err)
+		return
+	}
+	s.sendChanged(pushTypeHealth, PushMessage{Type: pushTypeHealth, Cluster: key.cluster, Namespace: key.namespace, Data: health})
+}
+
+func (s *pushSession) pushValidations(ctx context.Context, key pushKey) {
+	validations, err := s.layer.Validations.GetValidations(ctx, key.cluster, key.namespace, "", "")
+	if err != nil {
+		s.sendError(key, pushTypeValidations, err)
+		return
+	}
+	summary := validations.SummarizeValidation(key.namespace, key.cluster)
+	s.sendChanged(pushTypeValidations, PushMessage{Type: pushTypeValidations, Cluster: key.cluster, Namespace: key.namespace, Data: summary})
+}
+
+// sendError sends the error of the refresh of a type of update, in place of the update
+func (s *pushSession) sendError(key pushKey, msgType string, err error) {
+	s.sendChanged(msgType, PushMessage{Type: pushTypeError, Cluster: key.cluster, Namespace: key.namespace, Error: err.Error()})
+}
+
+// sendChanged sends a message of a subscription when it is different from the last one of its type of update
+func (s *pushSession) sendChanged(msgType string, msg PushMessage) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		log.Errorf("Unable to marshal the push message: %s", err)
+		return
+	}
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	key := pushMessageKey{pushKey: pushKey{cluster: msg.Cluster, namespace: msg.Namespace}, msgType: msgType}
+	if s.last[key] == string(b) {
+		return
+	}
+	s.last[key] = string(b)
+	s.write(b)
+}
+
+func (s *pushSession) send(msg PushMessage) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		log.Errorf("Unable to marshal the push message: %s", err)
+		return
+	}
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	s.write(b)
+}
+
+// write writes a message to the connection. The write lock must be held.
+func (s *pushSession) write(b []byte) {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(pushWriteTimeout)); err != nil {
+		log.Debugf("Unable to write in the push channel: %s", err)
+		return
+	}
+	if err := s.conn.WriteMessage(websocket.TextMessage, b); err != nil {
+		log.Debugf("Unable to write in the push channel: %s", err)
+	}
+}
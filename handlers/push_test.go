@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+// pushAuthController is an auth controller whose session stays valid until it is expired
+type pushAuthController struct {
+	authentication.AuthController
+	expired atomic.Bool
+}
+
+func (c *pushAuthController) ValidateSession(r *http.Request, w http.ResponseWriter) (*authentication.UserSessionData, error) {
+	if c.expired.Load() {
+		return nil, nil
+	}
+	return &authentication.UserSessionData{Username: "jdoe", AuthInfo: &api.AuthInfo{Token: "test"}}, nil
+}
+
+func (c *pushAuthController) TerminateSession(r *http.Request, w http.ResponseWriter) error {
+	return nil
+}
+
+func setupPushEndpoint(t *testing.T, k8s *kubetest.FakeK8sClient, conf *config.Config) *websocket.Conn {
+	return setupPushEndpointWithAuth(t, k8s, conf, &pushAuthController{}, nil)
+}
+
+func setupPushEndpointWithAuth(t *testing.T, k8s *kubetest.FakeK8sClient, conf *config.Config, authController authentication.AuthController, header http.Header) *websocket.Conn {
+	originalDebounce := pushDebounce
+	pushDebounce = 10 * time.Millisecond
+	t.Cleanup(func() { pushDebounce = originalDebounce })
+
+	config.Set(conf)
+	kialiCache := business.SetupBusinessLayer(t, k8s, *conf)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.Vector{}, nil)
+	business.WithProm(prom)
+
+	push := PushUpdates(conf, kialiCache, authController)
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/push", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			push(w, r.WithContext(context))
+		}))
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/push", header)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readPush reads the messages of the push channel until one matches
+func readPush(t *testing.T, conn *websocket.Conn, match func(PushMessage) bool) PushMessage {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		var msg PushMessage
+		require.NoError(t, conn.ReadJSON(&msg))
+		if match(msg) {
+			return msg
+		}
+	}
+}
+
+func TestPushUpdates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.API.Namespaces.Exclude = []string{"secret"}
+	secret := setupMockData()
+	secret.Name = "secret"
+	istioConfigMap := &core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: conf.IstioNamespace}}
+	k8s := kubetest.NewFakeK8sClient([]runtime.Object{setupMockData(), secret, istioConfigMap}...)
+	k8s.OpenShift = true
+	conn := setupPushEndpoint(t, k8s, conf)
+
+	require.NoError(conn.WriteJSON(PushSubscription{Action: pushActionSubscribe, Namespaces: []string{"ns", "secret"}}))
+
+	denied := readPush(t, conn, func(msg PushMessage) bool { return msg.Type == pushTypeError })
+	assert.Equal("secret", denied.Namespace)
+	assert.Equal(conf.KubernetesConfig.ClusterName, denied.Cluster)
+
+	health := readPush(t, conn, func(msg PushMessage) bool { return msg.Type == pushTypeHealth })
+	assert.Equal("ns", health.Namespace)
+	assert.Empty(health.Data)
+	validations := readPush(t, conn, func(msg PushMessage) bool { return msg.Type == pushTypeValidations })
+	assert.Equal("ns", validations.Namespace)
+
+	// A new workload changes the config and the health of the namespace
+	deployment := &apps_v1.Deployment{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "ns", Labels: map[string]string{"app": "reviews"}}}
+	deployment.Spec.Template.Labels = map[string]string{"app": "reviews", "version": "v1"}
+	_, err := k8s.Kube().AppsV1().Deployments("ns").Create(context.TODO(), deployment, meta_v1.CreateOptions{})
+	require.NoError(err)
+
+	health = readPush(t, conn, func(msg PushMessage) bool { return msg.Type == pushTypeHealth })
+	assert.Contains(health.Data, "reviews")
+}
+
+func TestPushUpdatesUnknownAction(t *testing.T) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	k8s := kubetest.NewFakeK8sClient(setupMockData())
+	k8s.OpenShift = true
+	conn := setupPushEndpoint(t, k8s, conf)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"action": "poll"}`)))
+	msg := readPush(t, conn, func(PushMessage) bool { return true })
+	assert.Equal(t, pushTypeError, msg.Type)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{`)))
+	msg = readPush(t, conn, func(PushMessage) bool { return true })
+	assert.Equal(t, pushTypeError, msg.Type)
+}
+
+// readPushClose reads the messages of the push channel until it is closed by the server
+func readPushClose(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), err.Error())
+			return
+		}
+	}
+}
+
+func TestPushUpdatesClosedOnExpiredSession(t *testing.T) {
+	originalInterval := pushSessionCheckInterval
+	pushSessionCheckInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pushSessionCheckInterval = originalInterval })
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	k8s := kubetest.NewFakeK8sClient(setupMockData())
+	k8s.OpenShift = true
+	authController := &pushAuthController{}
+	conn := setupPushEndpointWithAuth(t, k8s, conf, authController, nil)
+
+	authController.expired.Store(true)
+	readPushClose(t, conn)
+}
+
+func TestPushUpdatesClosedOnLogout(t *testing.T) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	k8s := kubetest.NewFakeK8sClient(setupMockData())
+	k8s.OpenShift = true
+	authController := &pushAuthController{}
+	cookie := &http.Cookie{Name: authentication.AESSessionCookieName, Value: "session"}
+	conn := setupPushEndpointWithAuth(t, k8s, conf, authController, http.Header{"Cookie": []string{cookie.String()}})
+
+	// The channel is registered once the connection is open
+	require.NoError(t, conn.WriteJSON(PushSubscription{Action: pushActionSubscribe, Namespaces: []string{"ns"}}))
+	readPush(t, conn, func(msg PushMessage) bool { return msg.Type == pushTypeHealth })
+
+	// The logout of another session keeps the channel
+	other := httptest.NewRequest("GET", "/api/logout", nil)
+	other.AddCookie(&http.Cookie{Name: authentication.AESSessionCookieName, Value: "other"})
+	Logout(conf, authController)(httptest.NewRecorder(), other)
+
+	logout := httptest.NewRequest("GET", "/api/logout", nil)
+	logout.AddCookie(cookie)
+	Logout(conf, authController)(httptest.NewRecorder(), logout)
+	readPushClose(t, conn)
+}
+
+func TestPushConfigChangedOfNamespace(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	session := &pushSession{conf: conf, subscriptions: map[pushKey]bool{}, dirty: map[pushKey]bool{}, changed: make(chan struct{}, 1)}
+	bookinfo := pushKey{cluster: "east", namespace: "bookinfo"}
+	travels := pushKey{cluster: "east", namespace: "travels"}
+	session.subscriptions[bookinfo] = true
+	session.subscriptions[travels] = true
+
+	session.configChanged("east", "bookinfo")
+	assert.Equal(map[pushKey]bool{bookinfo: true}, session.dirty)
+
+	session.dirty = map[pushKey]bool{}
+	session.configChanged("west", "bookinfo")
+	assert.Empty(session.dirty)
+
+	// The config of the root namespace applies to the whole mesh
+	session.configChanged("east", conf.ExternalServices.Istio.RootNamespace)
+	assert.Equal(map[pushKey]bool{bookinfo: true, travels: true}, session.dirty)
+}
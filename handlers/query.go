@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/util"
+)
+
+// maxQueries limits the queries of a composite query request
+const maxQueries = 50
+
+const (
+	queryTypeApp         = "app"
+	queryTypeService     = "service"
+	queryTypeValidations = "validations"
+	queryTypeWorkload    = "workload"
+)
+
+// CompositeQueryRequest is the request of the composite query API: the details of services, workloads, apps and the
+// validations of namespaces, fetched in one round trip with only the selected fields.
+type CompositeQueryRequest struct {
+	// The cluster of the queries, the home cluster when empty
+	Cluster string `json:"cluster"`
+	// The rate interval of the health, adjusted to the age of each namespace
+	RateInterval string `json:"rateInterval"`
+	// The queries by alias, the alias is the key of their result
+	Queries map[string]Query `json:"queries"`
+}
+
+// Query is a query of the composite query API
+type Query struct {
+	// One of app, service, workload or validations
+	Type      string `json:"type"`
+	Namespace string `json:"namespace"`
+	// The name of the app, service or workload. Unused by the validations of a namespace.
+	Name string `json:"name"`
+	// The selected fields of the JSON response, as dot separated paths (i.e. "service.name"). The fields of arrays
+	// apply to their items. All the fields are returned when none is selected. The validations and the health are only
+	// fetched when selected.
+	Fields []string `json:"fields"`
+}
+
+// QueryResult is the result of a query. A query failing, or denied by the RBAC of the user, does not fail the others.
+type QueryResult struct {
+	Status int         `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+func (q Query) validate() error {
+	switch q.Type {
+	case queryTypeApp, queryTypeService, queryTypeWorkload:
+		if q.Name == "" {
+			return fmt.Errorf("the name of the %s is required", q.Type)
+		}
+	case queryTypeValidations:
+	default:
+		return fmt.Errorf("unknown query type [%s]", q.Type)
+	}
+	if q.Namespace == "" {
+		return fmt.Errorf("the namespace is required")
+	}
+	return nil
+}
+
+// selected returns if a field, or one of its sub-fields, is selected
+func (q Query) selected(field string) bool {
+	if len(q.Fields) == 0 {
+		return true
+	}
+	for _, f := range q.Fields {
+		if f == field || strings.HasPrefix(f, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// CompositeQuery is the API handler running the queries of the details pages in one round trip. Each query is
+// run with the clients of the user: it is only answered when the user has access to its namespace.
+func CompositeQuery(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Query could not be read: "+err.Error())
+		return
+	}
+	var request CompositeQueryRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Query could not be parsed: "+err.Error())
+		return
+	}
+	if len(request.Queries) == 0 {
+		RespondWithError(w, http.StatusBadRequest, "Query has no queries")
+		return
+	}
+	if len(request.Queries) > maxQueries {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Query has more than %d queries", maxQueries))
+		return
+	}
+	for alias, query := range request.Queries {
+		if err := query.validate(); err != nil {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Query [%s] is invalid: %s", alias, err))
+			return
+		}
+	}
+	if request.Cluster == "" {
+		request.Cluster = config.Get().KubernetesConfig.ClusterName
+	}
+	if request.RateInterval == "" {
+		request.RateInterval = defaultHealthRateInterval
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	queryTime := util.Clock.Now()
+	results := make(map[string]QueryResult, len(request.Queries))
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for alias, query := range request.Queries {
+		wg.Add(1)
+		go func(alias string, query Query) {
+			defer wg.Done()
+			result := runQuery(r.Context(), business, request.Cluster, request.RateInterval, queryTime, query)
+			lock.Lock()
+			results[alias] = result
+			lock.Unlock()
+		}(alias, query)
+	}
+	wg.Wait()
+
+	RespondWithJSON(w, http.StatusOK, results)
+}
+
+func runQuery(ctx context.Context, layer *business.Layer, cluster, rateInterval string, queryTime time.Time, query Query) QueryResult {
+	data, err := fetchQuery(ctx, layer, cluster, rateInterval, queryTime, query)
+	if err == nil {
+		data, err = newFieldMask(query.Fields).apply(data)
+	}
+	if err != nil {
+		log.FromContext(ctx).Debug().Msgf("Query of %s [%s/%s] failed: %s", query.Type, query.Namespace, query.Name, err)
+		code, msg := errorStatus(err, err.Error())
+		return QueryResult{Status: code, Error: msg}
+	}
+	return QueryResult{Status: http.StatusOK, Data: data}
+}
+
+// fetchQuery fetches the data of a query. The access to the namespace is checked first with the clients of the user,
+// when adjusting the rate interval.
+func fetchQuery(ctx context.Context, layer *business.Layer, cluster, rateInterval string, queryTime time.Time, query Query) (interface{}, error) {
+	rateInterval, err := adjustRateInterval(ctx, layer, query.Namespace, rateInterval, queryTime, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	switch query.Type {
+	case queryTypeApp:
+		return layer.App.GetAppDetails(ctx, business.AppCriteria{
+			Namespace: query.Namespace, AppName: query.Name, IncludeIstioResources: true, IncludeHealth: query.selected("health"),
+			RateInterval: rateInterval, QueryTime: queryTime, Cluster: cluster,
+		})
+	case queryTypeService:
+		serviceDetails, err := layer.Svc.GetServiceDetails(ctx, cluster, query.Namespace, query.Name, rateInterval, queryTime)
+		if err != nil {
+			return nil, err
+		}
+		if query.selected("validations") {
+			serviceDetails.Validations, err = layer.Validations.GetValidations(ctx, cluster, query.Namespace, query.Name, "")
+		}
+		return serviceDetails, err
+	case queryTypeWorkload:
+		criteria := business.WorkloadCriteria{
+			Namespace: query.Namespace, WorkloadName: query.Name, IncludeIstioResources: true, IncludeServices: true,
+			IncludeHealth: query.selected("health"), RateInterval: rateInterval, QueryTime: queryTime, Cluster: cluster,
+		}
+		workloadDetails, err := layer.Workload.GetWorkload(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+		if query.selected("validations") {
+			if workloadDetails.Validations, err = layer.Validations.GetValidations(ctx, cluster, query.Namespace, "", query.Name); err != nil {
+				return nil, err
+			}
+		}
+		if criteria.IncludeHealth {
+			workloadDetails.Health, err = layer.Health.GetWorkloadHealth(ctx, query.Namespace, cluster, query.Name, rateInterval, queryTime, workloadDetails)
+		}
+		return workloadDetails, err
+	default:
+		return layer.Validations.GetValidations(ctx, cluster, query.Namespace, "", "")
+	}
+}
+
+// fieldMask is a tree of selected fields. A nil mask selects all the fields.
+type fieldMask map[string]fieldMask
+
+func newFieldMask(fields []string) fieldMask {
+	if len(fields) == 0 {
+		return nil
+	}
+	mask := fieldMask{}
+	for _, field := range fields {
+		node := mask
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			child, found := node[part]
+			if found && child == nil {
+				// A parent field is already selected
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if !found {
+				child = fieldMask{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return mask
+}
+
+// apply returns the JSON value of data with only the selected fields
+func (m fieldMask) apply(data interface{}) (interface{}, error) {
+	if m == nil {
+		return data, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	return m.filter(value), nil
+}
+
+func (m fieldMask) filter(value interface{}) interface{} {
+	if m == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		filtered := make(map[string]interface{}, len(m))
+		for name, child := range m {
+			if fieldValue, ok := v[name]; ok {
+				filtered[name] = child.filter(fieldValue)
+			}
+		}
+		return filtered
+	case []interface{}:
+		for i := range v {
+			v[i] = m.filter(v[i])
+		}
+		return v
+	default:
+		return value
+	}
+}
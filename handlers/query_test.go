@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestFieldMask(t *testing.T) {
+	assert := assert.New(t)
+
+	data := map[string]interface{}{
+		"name":      "reviews",
+		"namespace": map[string]interface{}{"name": "bookinfo", "cluster": "east"},
+		"pods": []map[string]interface{}{
+			{"name": "reviews-v1", "status": "Running"},
+			{"name": "reviews-v2", "status": "Pending"},
+		},
+		"health": map[string]interface{}{"requests": 1},
+	}
+
+	masked, err := newFieldMask(nil).apply(data)
+	assert.NoError(err)
+	assert.Equal(data, masked)
+
+	masked, err = newFieldMask([]string{"name", "namespace.name", "pods.status", "unknown"}).apply(data)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{
+		"name":      "reviews",
+		"namespace": map[string]interface{}{"name": "bookinfo"},
+		"pods": []interface{}{
+			map[string]interface{}{"status": "Running"},
+			map[string]interface{}{"status": "Pending"},
+		},
+	}, masked)
+
+	// The parent field selects all its sub-fields, whatever their order
+	masked, err = newFieldMask([]string{"namespace.name", "namespace"}).apply(data)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"namespace": map[string]interface{}{"name": "bookinfo", "cluster": "east"}}, masked)
+}
+
+func TestQuerySelected(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Query{}.selected("health"))
+	assert.True(Query{Fields: []string{"health.requests"}}.selected("health"))
+	assert.True(Query{Fields: []string{"name", "health"}}.selected("health"))
+	assert.False(Query{Fields: []string{"name", "healthy"}}.selected("health"))
+}
+
+func setupCompositeQueryEndpoint(t *testing.T, k8s kubernetes.ClientInterface, conf config.Config) *httptest.Server {
+	setupAppListEndpoint(t, k8s, conf)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/query", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			CompositeQuery(w, r.WithContext(context))
+		}))
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestCompositeQuery(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.API.Namespaces.Exclude = []string{"secret"}
+	kubernetes.SetConfig(t, *conf)
+
+	mockClock()
+	proj := newProject()
+	proj.Name = "Namespace"
+	secret := newProject()
+	secret.Name = "secret"
+	kubeObjects := []runtime.Object{proj, secret}
+	for _, obj := range business.FakeDeployments(*conf) {
+		o := obj
+		kubeObjects = append(kubeObjects, &o)
+	}
+	k8s := kubetest.NewFakeK8sClient(kubeObjects...)
+	k8s.OpenShift = true
+	ts := setupCompositeQueryEndpoint(t, k8s, *conf)
+
+	body := `{"queries": {
+		"httpbin": {"type": "app", "namespace": "Namespace", "name": "httpbin", "fields": ["name", "namespace.name"]},
+		"hidden": {"type": "app", "namespace": "secret", "name": "httpbin"}
+	}}`
+	resp, err := http.Post(ts.URL+"/api/query", "application/json", strings.NewReader(body))
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	var results map[string]QueryResult
+	require.NoError(json.NewDecoder(resp.Body).Decode(&results))
+	require.Len(results, 2)
+
+	assert.Equal(http.StatusOK, results["httpbin"].Status)
+	assert.Equal(map[string]interface{}{
+		"name":      "httpbin",
+		"namespace": map[string]interface{}{"name": "Namespace"},
+	}, results["httpbin"].Data)
+
+	// The namespace of the user not accessible does not fail the other queries
+	assert.Equal(http.StatusForbidden, results["hidden"].Status)
+	assert.Nil(results["hidden"].Data)
+	assert.NotEmpty(results["hidden"].Error)
+}
+
+func TestCompositeQueryInvalid(t *testing.T) {
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+	ts := setupCompositeQueryEndpoint(t, kubetest.NewFakeK8sClient(), *conf)
+
+	for name, body := range map[string]string{
+		"not json":     `{`,
+		"no query":     `{"queries": {}}`,
+		"unknown type": `{"queries": {"a": {"type": "graph", "namespace": "bookinfo"}}}`,
+		"no name":      `{"queries": {"a": {"type": "service", "namespace": "bookinfo"}}}`,
+		"no namespace": `{"queries": {"a": {"type": "validations"}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := http.Post(ts.URL+"/api/query", "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
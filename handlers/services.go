@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/util"
 )
@@ -79,6 +81,13 @@ func ClustersServices(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// The list is unchanged while the cached resources of the namespaces are
+	if version, err := businessLayer.Namespace.ResourcesVersion(p.ClusterName, nss, true); err != nil {
+		log.Debugf("Unable to get the version of the resources of the namespaces: %s", err)
+	} else if RespondNotModified(w, r, listETag(r, p.IncludeHealth, version)) {
+		return
+	}
+
 	clusterServicesList := &models.ClusterServices{
 		Cluster:     p.ClusterName,
 		Services:    []models.ServiceOverview{},
@@ -247,3 +256,78 @@ func ServiceUpdate(w http.ResponseWriter, r *http.Request) {
 	audit(r, "UPDATE on Namespace: "+namespace+" Service name: "+service+" Patch: "+jsonPatch)
 	RespondWithJSON(w, http.StatusOK, serviceDetails)
 }
+
+// ServiceTrafficMirroring generates the VirtualService and the DestinationRule of the traffic mirroring wizard of a
+// service, without persisting them
+func ServiceTrafficMirroring(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	params := mux.Vars(r)
+
+	criteria := business.TrafficMirroringCriteria{
+		Cluster:     clusterNameFromQuery(queryParams),
+		Namespace:   params["namespace"],
+		ServiceName: params["service"],
+		Subset:      queryParams.Get("subset"),
+		Host:        queryParams.Get("host"),
+	}
+	if port := queryParams.Get("port"); port != "" {
+		num, err := strconv.ParseUint(port, 10, 32)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid port: "+port)
+			return
+		}
+		criteria.Port = uint32(num)
+	}
+	if percentage := queryParams.Get("percentage"); percentage != "" {
+		num, err := strconv.ParseFloat(percentage, 64)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid percentage: "+percentage)
+			return
+		}
+		criteria.Percentage = num
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	scenario, err := business.IstioConfig.GenerateTrafficMirroring(r.Context(), criteria)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, scenario)
+}
+
+// ServiceLocalityLoadBalancing generates the DestinationRule of the locality load balancing wizard of a service,
+// without persisting it
+func ServiceLocalityLoadBalancing(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	cluster := clusterNameFromQuery(r.URL.Query())
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Locality load balancing could not be read: "+err.Error())
+		return
+	}
+	var lb models.LocalityLoadBalancing
+	if err := json.Unmarshal(body, &lb); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Locality load balancing could not be parsed: "+err.Error())
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	scenario, err := business.IstioConfig.GenerateLocalityLoadBalancing(r.Context(), cluster, params["namespace"], params["service"], lb)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, scenario)
+}
@@ -32,6 +32,52 @@ func NamespaceTls(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, status)
 }
 
+// WorkloadTls is the API to get workload-wide mTLS status
+func WorkloadTls(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	status, err := business.TLS.WorkloadWidemTLSStatus(r.Context(), clusterNameFromQuery(r.URL.Query()), params["namespace"], params["workload"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, status)
+}
+
+// NamespacePermissiveTraffic is the API to get which workloads accepting plaintext traffic still receive it
+func NamespacePermissiveTraffic(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	p := baseHealthParams{}
+	p.baseExtract(r, mux.Vars(r))
+	p.Namespace = mux.Vars(r)["namespace"]
+
+	rateInterval, err := adjustRateInterval(r.Context(), business, p.Namespace, p.RateInterval, p.QueryTime, p.ClusterName)
+	if err != nil {
+		handleErrorResponse(w, err, "Adjust rate interval error: "+err.Error())
+		return
+	}
+
+	report, err := business.TLS.NamespacePermissiveTraffic(r.Context(), p.ClusterName, p.Namespace, rateInterval, p.QueryTime)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, report)
+}
+
 // ClustersTls is the API to get mTLS status for given namespaces within a single cluster
 func ClustersTls(w http.ResponseWriter, r *http.Request) {
 	params := r.URL.Query()
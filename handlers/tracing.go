@@ -107,6 +107,57 @@ func WorkloadTraces(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, traces)
 }
 
+// EdgeTraces is the API handler to fetch the traces crossing a graph edge, from a source node to a destination node
+func EdgeTraces(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "EdgeTraces initialization error: "+err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	q, err := readQuery(query)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	endpoint := func(prefix string) (models.TracingEdgeEndpoint, error) {
+		e := models.TracingEdgeEndpoint{
+			Cluster:   query.Get(prefix + "Cluster"),
+			Namespace: query.Get(prefix + "Namespace"),
+			Kind:      query.Get(prefix + "Kind"),
+			Name:      query.Get(prefix + "Name"),
+		}
+		if e.Cluster == "" {
+			e.Cluster = q.Cluster
+		}
+		if e.Namespace == "" || e.Name == "" {
+			return e, fmt.Errorf("Parameters '%sNamespace' and '%sName' are required", prefix, prefix)
+		}
+		if e.Kind != models.TracingEdgeApp && e.Kind != models.TracingEdgeService && e.Kind != models.TracingEdgeWorkload {
+			return e, fmt.Errorf("Parameter '%sKind' must be app, service or workload", prefix)
+		}
+		return e, nil
+	}
+	source, err := endpoint("source")
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	dest, err := endpoint("dest")
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	traces, err := business.Tracing.GetEdgeTraces(r.Context(), source, dest, q)
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, traces)
+}
+
 func ErrorTraces(w http.ResponseWriter, r *http.Request) {
 	business, err := getBusiness(r)
 	if err != nil {
@@ -123,7 +174,7 @@ func ErrorTraces(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Cannot parse parameter 'duration': "+err.Error())
 		return
 	}
-	traces, err := business.Tracing.GetErrorTraces(namespace, app, time.Second*time.Duration(conv))
+	traces, err := business.Tracing.GetErrorTraces(clusterNameFromQuery(queryParams), namespace, app, time.Second*time.Duration(conv))
 	if err != nil {
 		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
 		return
@@ -139,7 +190,7 @@ func TraceDetails(w http.ResponseWriter, r *http.Request) {
 	}
 	params := mux.Vars(r)
 	traceID := params["traceID"]
-	trace, err := business.Tracing.GetTraceDetail(traceID)
+	trace, err := business.Tracing.GetTraceDetail(clusterNameFromQuery(r.URL.Query()), traceID)
 	if err != nil {
 		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
 		return
@@ -152,6 +203,26 @@ func TraceDetails(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, trace)
 }
 
+// TraceLogs is the API handler to fetch the logs of the pods of the spans of a trace
+func TraceLogs(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Trace Logs initialization error: "+err.Error())
+		return
+	}
+	traceID := mux.Vars(r)["traceID"]
+	traceLogs, err := business.Tracing.GetLogsForTrace(r.Context(), clusterNameFromQuery(r.URL.Query()), traceID)
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if traceLogs == nil {
+		RespondWithError(w, http.StatusNotFound, fmt.Sprintf("Trace %s not found", traceID))
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, traceLogs)
+}
+
 // AppSpans is the API handler to fetch Tracing spans of a specific app
 func AppSpans(w http.ResponseWriter, r *http.Request) {
 	business, err := getBusiness(r)
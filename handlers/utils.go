@@ -41,6 +41,7 @@ func createMetricsServiceForNamespaceMC(w http.ResponseWriter, r *http.Request,
 		RespondWithError(w, http.StatusServiceUnavailable, "Prometheus client error: "+err.Error())
 		return nil, nil
 	}
+	prom = prom.WithContext(r.Context())
 	var nsInfo []models.Namespace
 
 	for _, cluster := range layer.Namespace.GetClusterList() {
@@ -74,6 +75,7 @@ func createMetricsServiceForClusterMC(w http.ResponseWriter, r *http.Request, pr
 		RespondWithError(w, http.StatusServiceUnavailable, "Prometheus client error: "+err.Error())
 		return nil, nil
 	}
+	prom = prom.WithContext(r.Context())
 	var nsInfo []models.Namespace
 
 	for _, nsName := range nss {
@@ -134,6 +136,7 @@ func createMetricsServiceForNamespaces(w http.ResponseWriter, r *http.Request, p
 		RespondWithError(w, http.StatusServiceUnavailable, "Prometheus client error: "+err.Error())
 		return nil, nil
 	}
+	prom = prom.WithContext(r.Context())
 
 	nsInfos := make(map[string]nsInfoError)
 	for _, ns := range namespaces {
@@ -84,6 +84,13 @@ func ClustersWorkloads(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// The list is unchanged while the cached resources of the namespaces are
+	if version, err := businessLayer.Namespace.ResourcesVersion(p.ClusterName, nss, true); err != nil {
+		log.Debugf("Unable to get the version of the resources of the namespaces: %s", err)
+	} else if RespondNotModified(w, r, listETag(r, p.IncludeHealth, version)) {
+		return
+	}
+
 	clusterWorkloadsList := &models.ClusterWorkloads{
 		Cluster:     p.ClusterName,
 		Workloads:   []models.WorkloadListItem{},
@@ -172,6 +179,33 @@ func WorkloadDetails(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, workloadDetails)
 }
 
+// WaypointTraffic is the API to get the requests proxied by a waypoint, per enrolled service
+func WaypointTraffic(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	p := baseHealthParams{}
+	p.baseExtract(r, mux.Vars(r))
+	p.Namespace = mux.Vars(r)["namespace"]
+
+	rateInterval, err := adjustRateInterval(r.Context(), business, p.Namespace, p.RateInterval, p.QueryTime, p.ClusterName)
+	if err != nil {
+		handleErrorResponse(w, err, "Adjust rate interval error: "+err.Error())
+		return
+	}
+
+	traffic, err := business.Workload.GetWaypointTraffic(r.Context(), p.ClusterName, p.Namespace, mux.Vars(r)["workload"], rateInterval, p.QueryTime)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, traffic)
+}
+
 // WorkloadUpdate is the API to perform a patch on a Workload configuration
 func WorkloadUpdate(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
@@ -230,6 +264,7 @@ func WorkloadUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 	auditMsg := fmt.Sprintf("UPDATE on Cluster: [%s] Namespace: [%s] Workload name: [%s] Type: [%s] Patch: [%s]", cluster, namespace, workload, workloadType, jsonPatch)
 	audit(r, auditMsg)
+	business.Events.RecordConfigChange(r.Context(), models.KialiEvent{Cluster: cluster, Namespace: namespace, Workload: workload, ObjectType: workloadDetails.Type, ObjectName: workload}, "Update", r.Header.Get("Kiali-User"))
 	RespondWithJSON(w, http.StatusOK, workloadDetails)
 }
 
@@ -283,14 +318,16 @@ func PodLogs(w http.ResponseWriter, r *http.Request) {
 		queryParams.Get("duration"),
 		models.LogType(queryParams.Get("logType")),
 		queryParams.Get("sinceTime"),
-		queryParams.Get("maxLines"))
+		queryParams.Get("maxLines"),
+		queryParams.Get("follow"),
+		queryParams.Get("filter"))
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
 	}
 
 	// Fetch pod logs
-	err = business.Workload.StreamPodLogs(cluster, namespace, pod, opts, w)
+	err = business.Workload.StreamPodLogs(r.Context(), cluster, namespace, pod, opts, w)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
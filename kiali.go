@@ -33,11 +33,14 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	_ "go.uber.org/automaxprocs"
 
 	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cli"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/cache"
@@ -57,6 +60,9 @@ var (
 	goVersion  = "unknown"
 )
 
+// configReloadInterval is how often the config file is checked for changes to reload.
+const configReloadInterval = 10 * time.Second
+
 // Command line arguments
 var (
 	argConfigFile = flag.String("config", "", "Path to the YAML configuration file. If not specified, environment variables will be used for configuration.")
@@ -68,6 +74,11 @@ func init() {
 }
 
 func main() {
+	// The commands of the CLI run instead of the server
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	log.InitializeLogger()
 	util.Clock = util.RealClock{}
 
@@ -100,6 +111,22 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if cfg.Deployment.LogLevel != "" {
+		if err := log.SetLevel(cfg.Deployment.LogLevel); err != nil {
+			log.Warningf("Invalid log level [%s], keeping the LOG_LEVEL level: %v", cfg.Deployment.LogLevel, err)
+		}
+	}
+	config.Subscribe(func(old, new config.Config) {
+		if old.Deployment.LogLevel == new.Deployment.LogLevel {
+			return
+		}
+		if err := log.SetLevel(new.Deployment.LogLevel); err != nil {
+			log.Warningf("Invalid log level [%s], keeping the current level: %v", new.Deployment.LogLevel, err)
+			return
+		}
+		log.Infof("Log level changed to [%s]", new.Deployment.LogLevel)
+	})
+
 	// prepare our internal metrics so Prometheus can scrape them
 	internalmetrics.RegisterInternalMetrics()
 
@@ -135,6 +162,21 @@ func main() {
 		cpm.PollIstiodForProxyStatus(ctx)
 	}
 
+	business.StartProxyLogLevelResets(ctx, cfg, cache, clientFactory.GetSAClients())
+	business.StartProxyTapSweeps(ctx, cfg, clientFactory.GetSAClients())
+
+	if cfg.KialiFeatureFlags.CertificatesInformationIndicators.Enabled {
+		business.StartCertificateExpirations(ctx, cfg, cache, clientFactory)
+	}
+
+	if cfg.Notifications.Enabled {
+		notifier := business.NewNotifier(cfg.Notifications)
+		for cluster, kubeCache := range cache.GetKubeCaches() {
+			defer notifier.Watch(cluster, kubeCache)()
+		}
+		notifier.Start(ctx)
+	}
+
 	// Create shared prometheus client shared by all prometheus requests in the business layer.
 	prom, err := prometheus.NewClient()
 	if err != nil {
@@ -148,7 +190,10 @@ func main() {
 	// Passing in a loader function allows the tracing client to be used once it is
 	// finally initialized.
 	var tracingClient tracing.ClientInterface
+	var tracingLock sync.RWMutex
 	tracingLoader := func() tracing.ClientInterface {
+		tracingLock.RLock()
+		defer tracingLock.RUnlock()
 		return tracingClient
 	}
 	if cfg.ExternalServices.Tracing.Enabled {
@@ -158,12 +203,37 @@ func main() {
 				log.Fatalf("Error creating tracing client: %s", err)
 				return
 			}
+			tracingLock.Lock()
 			tracingClient = client
+			tracingLock.Unlock()
 		}()
+
+		// The tracing client is recreated when the urls of the tracing backend are reloaded
+		config.Subscribe(func(old, new config.Config) {
+			if old.ExternalServices.Tracing.URL == new.ExternalServices.Tracing.URL && old.ExternalServices.Tracing.InClusterURL == new.ExternalServices.Tracing.InClusterURL {
+				return
+			}
+			go func() {
+				client, err := tracing.NewClient(ctx, &new, clientFactory.GetSAHomeClusterClient().GetToken())
+				if err != nil {
+					log.Errorf("Cannot create the tracing client of the reloaded urls, keeping the current client: %s", err)
+					return
+				}
+				tracingLock.Lock()
+				tracingClient = client
+				tracingLock.Unlock()
+				log.Infof("Tracing url changed to [%s]", new.ExternalServices.Tracing.InClusterURL)
+			}()
+		})
 	} else {
 		log.Debug("Tracing is disabled")
 	}
 
+	// Apply the changes of the reloadable settings of the config file without a restart
+	if *argConfigFile != "" {
+		config.WatchFile(ctx, *argConfigFile, configReloadInterval)
+	}
+
 	// Start listening to requests
 	server, err := server.NewServer(cpm, clientFactory, cache, cfg, prom, tracingLoader)
 	if err != nil {
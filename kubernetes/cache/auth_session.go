@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/kiali/kiali/util"
+)
+
+type (
+	// AuthSessionCache keeps the server side data of the user sessions, like the tokens of the
+	// OpenID provider, which must not travel in the session cookies.
+	AuthSessionCache interface {
+		GetAuthSession(id string) (*AuthSession, bool)
+		RemoveAuthSession(id string)
+		SetAuthSession(id string, session *AuthSession)
+	}
+)
+
+// AuthSession is the server side data of a user session.
+type AuthSession struct {
+	// ExpiresOn is the expiration time of the session.
+	ExpiresOn time.Time
+
+	// Issuer is the OpenID provider the user logged in with.
+	Issuer string
+
+	// RefreshToken is the token to renew the token of the user.
+	RefreshToken string
+
+	// Token is the token of the user, as last refreshed.
+	Token string
+
+	// TokenExpiresOn is the expiration time of the token of the user.
+	TokenExpiresOn time.Time
+}
+
+// GetAuthSession returns the session with the given id, unless it has expired.
+func (c *kialiCacheImpl) GetAuthSession(id string) (*AuthSession, bool) {
+	session, found := c.authSessionStore.Get(id)
+	if !found || !util.Clock.Now().Before(session.ExpiresOn) {
+		return nil, false
+	}
+	return session, true
+}
+
+func (c *kialiCacheImpl) RemoveAuthSession(id string) {
+	c.authSessionStore.Remove(id)
+}
+
+// SetAuthSession stores the session with the given id. The expired sessions are dropped.
+func (c *kialiCacheImpl) SetAuthSession(id string, session *AuthSession) {
+	now := util.Clock.Now()
+	for key, s := range c.authSessionStore.Items() {
+		if !now.Before(s.ExpiresOn) {
+			c.authSessionStore.Remove(key)
+		}
+	}
+	c.authSessionStore.Set(id, session)
+}
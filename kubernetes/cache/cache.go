@@ -21,8 +21,10 @@ import (
 )
 
 const (
-	ambientCheckExpirationTime = 10 * time.Minute
-	meshExpirationTime         = 10 * time.Second
+	ambientCheckExpirationTime       = 10 * time.Minute
+	istioConfigSchemasExpirationTime = 10 * time.Minute
+	jwksProbeExpirationTime          = 5 * time.Minute
+	meshExpirationTime               = 10 * time.Second
 )
 
 const kialiCacheMeshKey = "mesh"
@@ -64,8 +66,15 @@ type KialiCache interface {
 	// RefreshTokenNamespaces clears the in memory cache of namespaces.
 	RefreshTokenNamespaces(cluster string)
 
+	AuthSessionCache
+	ConfigDistributionCache
+	IstioConfigSchemasCache
+	JwksProbeCache
 	RegistryStatusCache
+	ProxyLogLevelResetCache
 	ProxyStatusCache
+	ReferenceIndexCache
+	TrustBundleCache
 
 	// SetClusters sets the list of clusters that the cache knows about.
 	SetClusters([]kubernetes.Cluster)
@@ -79,19 +88,35 @@ type KialiCache interface {
 
 	// Stop stops the cache and all its kube caches.
 	Stop()
+
+	// Summary returns the number of cached objects and store entries, for the diagnostics.
+	Summary() models.CacheSummary
 }
 
 type kialiCacheImpl struct {
 	ambientChecksPerCluster store.Store[string, bool]
+
+	// AuthSessionStore stores the server side data of the user sessions and should be key'd off the session id.
+	authSessionStore store.Store[string, *AuthSession]
 	// This isn't expected to change so it's not protected by a mutex.
 	buildInfo models.BuildInfo
 	cleanup   func()
 	conf      config.Config
 
+	// ConfigDistributionStore stores the distribution state of Istio configs and should be
+	// key'd off cluster + namespace + object type + name.
+	configDistributionStore store.Store[string, *kubernetes.ConfigDistribution]
+
 	clientFactory kubernetes.ClientFactory
 	// Maps a cluster name to a KubeCache
 	kubeCache map[string]KubeCache
 
+	// IstioConfigSchemasStore stores the schemas of the Istio config CRDs and should be key'd off of the cluster name.
+	istioConfigSchemasStore store.Store[string, *models.IstioConfigSchemas]
+
+	// JwksProbeStore stores the results of probing the jwksUris and should be key'd off of the jwksUri.
+	jwksProbeStore store.Store[string, error]
+
 	// There's only ever one mesh but we want to reuse the store machinery
 	// so using a store here but only key  should be  kialiCacheMeshKey
 	meshStore store.Store[string, *models.Mesh]
@@ -107,10 +132,17 @@ type kialiCacheImpl struct {
 	namespacesLock sync.RWMutex
 
 	refreshDuration time.Duration
+	// ProxyLogLevelResetStore stores the pending resets of the proxy log levels and should be key'd off
+	// cluster + namespace + workload.
+	proxyLogLevelResetStore store.Store[string, *models.ProxyLogLevelReset]
 	// ProxyStatusStore stores the proxy status and should be key'd off cluster + namespace + pod.
 	proxyStatusStore store.Store[string, *kubernetes.ProxyStatus]
+	// ReferenceIndexStore stores the reference index of the Istio config and should be key'd off cluster + namespace.
+	referenceIndexStore store.Store[string, *models.ReferenceIndex]
 	// RegistryStatusStore stores the registry status and should be key'd off of the cluster name.
 	registryStatusStore store.Store[string, *kubernetes.RegistryStatus]
+	// TrustBundleStore stores the trust bundle status and should be key'd off of the cluster name.
+	trustBundleStore store.Store[string, *kubernetes.TrustBundleStatus]
 
 	// Info about the kube clusters that the cache knows about.
 	clusters    []kubernetes.Cluster
@@ -122,15 +154,22 @@ func NewKialiCache(clientFactory kubernetes.ClientFactory, cfg config.Config) (K
 	namespaceKeyTTL := time.Duration(cfg.KubernetesConfig.CacheTokenNamespaceDuration) * time.Second
 	kialiCacheImpl := kialiCacheImpl{
 		ambientChecksPerCluster: store.NewExpirationStore(ctx, store.New[string, bool](), util.AsPtr(ambientCheckExpirationTime), nil),
+		authSessionStore:        store.New[string, *AuthSession](),
 		cleanup:                 cancel,
 		clientFactory:           clientFactory,
 		conf:                    cfg,
+		configDistributionStore: store.New[string, *kubernetes.ConfigDistribution](),
+		istioConfigSchemasStore: store.NewExpirationStore(ctx, store.New[string, *models.IstioConfigSchemas](), util.AsPtr(istioConfigSchemasExpirationTime), nil),
+		jwksProbeStore:          store.NewExpirationStore(ctx, store.New[string, error](), util.AsPtr(jwksProbeExpirationTime), nil),
 		kubeCache:               make(map[string]KubeCache),
 		meshStore:               store.NewExpirationStore(ctx, store.New[string, *models.Mesh](), util.AsPtr(meshExpirationTime), nil),
 		namespaceStore:          store.NewExpirationStore(ctx, store.New[namespacesKey, map[string]models.Namespace](), &namespaceKeyTTL, nil),
 		refreshDuration:         time.Duration(cfg.KubernetesConfig.CacheDuration) * time.Second,
+		proxyLogLevelResetStore: store.New[string, *models.ProxyLogLevelReset](),
 		proxyStatusStore:        store.New[string, *kubernetes.ProxyStatus](),
+		referenceIndexStore:     store.New[string, *models.ReferenceIndex](),
 		registryStatusStore:     store.New[string, *kubernetes.RegistryStatus](),
+		trustBundleStore:        store.New[string, *kubernetes.TrustBundleStatus](),
 	}
 
 	for cluster, client := range clientFactory.GetSAClients() {
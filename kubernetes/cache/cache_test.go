@@ -1,8 +1,10 @@
 package cache_test
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	apps_v1 "k8s.io/api/apps/v1"
@@ -14,6 +16,7 @@ import (
 	"github.com/kiali/kiali/kubernetes/cache"
 	"github.com/kiali/kiali/kubernetes/kubetest"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util"
 )
 
 func TestNoHomeClusterReturnsError(t *testing.T) {
@@ -256,3 +259,104 @@ func TestRefreshTokenNamespaces(t *testing.T) {
 	require.Equal(1, len(namespaces))
 	require.Equal("test", namespaces[0].Name)
 }
+
+func TestSetConfigDistributionMergesControlPlanes(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	kubernetes.SetConfig(t, *conf)
+
+	client := kubetest.NewFakeK8sClient()
+	cache := cache.NewTestingCache(t, client, *conf)
+	cache.SetConfigDistribution([]*kubernetes.ConfigDistribution{
+		{Cluster: "east", Namespace: "bookinfo", ObjectType: kubernetes.VirtualServices, Name: "reviews", Proxies: []*kubernetes.SyncedVersions{{ProxyID: "a.bookinfo"}}},
+		{Cluster: "east", Namespace: "bookinfo", ObjectType: kubernetes.VirtualServices, Name: "reviews", Proxies: []*kubernetes.SyncedVersions{{ProxyID: "b.bookinfo"}}},
+		{Cluster: "west", Namespace: "bookinfo", ObjectType: kubernetes.VirtualServices, Name: "reviews", Proxies: []*kubernetes.SyncedVersions{{ProxyID: "c.bookinfo"}}},
+	})
+
+	distribution := cache.GetConfigDistribution("east", "bookinfo", kubernetes.VirtualServices, "reviews")
+	require.NotNil(distribution)
+	require.Len(distribution.Proxies, 2)
+
+	distribution = cache.GetConfigDistribution("west", "bookinfo", kubernetes.VirtualServices, "reviews")
+	require.NotNil(distribution)
+	require.Len(distribution.Proxies, 1)
+
+	require.Nil(cache.GetConfigDistribution("east", "bookinfo", kubernetes.DestinationRules, "reviews"))
+}
+
+func TestReferenceIndexInvalidatedOnConfigChange(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	client := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+	kialiCache := cache.NewTestingCache(t, client, *conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	kubeCache, err := kialiCache.GetKubeCache(cluster)
+	require.NoError(err)
+
+	kialiCache.SetReferenceIndex(cluster, "test", models.NewReferenceIndex(kubeCache.ConfigVersion(), models.IstioReferencesMap{}))
+	_, found := kialiCache.GetReferenceIndex(cluster, "test")
+	require.True(found)
+
+	_, found = kialiCache.GetReferenceIndex(cluster, "other")
+	require.False(found)
+
+	deployment := &apps_v1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "test"}}
+	_, err = client.Kube().AppsV1().Deployments("test").Create(context.TODO(), deployment, metav1.CreateOptions{})
+	require.NoError(err)
+	require.Eventually(func() bool {
+		_, found := kialiCache.GetReferenceIndex(cluster, "test")
+		return !found
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAuthSessionsExpire(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	client := kubetest.NewFakeK8sClient()
+	kialiCache := cache.NewTestingCache(t, client, *conf)
+
+	now := time.Now()
+	util.Clock = util.ClockMock{Time: now}
+	t.Cleanup(func() { util.Clock = util.RealClock{} })
+
+	kialiCache.SetAuthSession("expiring", &cache.AuthSession{ExpiresOn: now.Add(time.Minute), RefreshToken: "refresh-1"})
+	session, found := kialiCache.GetAuthSession("expiring")
+	require.True(found)
+	require.Equal("refresh-1", session.RefreshToken)
+
+	util.Clock = util.ClockMock{Time: now.Add(2 * time.Minute)}
+	_, found = kialiCache.GetAuthSession("expiring")
+	require.False(found)
+
+	kialiCache.SetAuthSession("current", &cache.AuthSession{ExpiresOn: now.Add(time.Hour)})
+	_, found = kialiCache.GetAuthSession("current")
+	require.True(found)
+
+	kialiCache.RemoveAuthSession("current")
+	_, found = kialiCache.GetAuthSession("current")
+	require.False(found)
+}
+
+func TestSummary(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	client := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "productpage-v1", Namespace: "bookinfo"}},
+		&core_v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo"}},
+		&core_v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "productpage", Namespace: "bookinfo"}},
+	)
+	kialiCache := cache.NewTestingCache(t, client, *conf)
+	kialiCache.SetNamespaces("token", []models.Namespace{{Name: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName}})
+
+	summary := kialiCache.Summary()
+	objects := summary.Clusters[conf.KubernetesConfig.ClusterName]
+	require.Equal(2, objects[kubernetes.PodType])
+	require.Equal(1, objects[kubernetes.ServiceType])
+	require.Zero(objects[kubernetes.VirtualServiceType])
+	require.Equal(1, summary.Stores["namespaces"])
+}
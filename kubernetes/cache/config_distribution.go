@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func configDistributionKey(cluster, namespace, objectType, name string) string {
+	return cluster + "/" + namespace + "/" + objectType + "/" + name
+}
+
+type ConfigDistributionCache interface {
+	// GetConfigDistribution returns the last scraped distribution state of an Istio config
+	// or nil if istiod has not reported anything for it.
+	GetConfigDistribution(cluster, namespace, objectType, name string) *kubernetes.ConfigDistribution
+	SetConfigDistribution(configDistribution []*kubernetes.ConfigDistribution)
+}
+
+func (c *kialiCacheImpl) GetConfigDistribution(cluster, namespace, objectType, name string) *kubernetes.ConfigDistribution {
+	configDistribution, found := c.configDistributionStore.Get(configDistributionKey(cluster, namespace, objectType, name))
+	if !found {
+		return nil
+	}
+	return configDistribution
+}
+
+// SetConfigDistribution replaces the stored distribution state. Entries reported by
+// different control planes for the same config are merged together.
+func (c *kialiCacheImpl) SetConfigDistribution(configDistribution []*kubernetes.ConfigDistribution) {
+	byKey := make(map[string]*kubernetes.ConfigDistribution)
+	for _, cd := range configDistribution {
+		if cd == nil {
+			continue
+		}
+		key := configDistributionKey(cd.Cluster, cd.Namespace, cd.ObjectType, cd.Name)
+		if existing, found := byKey[key]; found {
+			merged := *existing
+			merged.Proxies = append(append([]*kubernetes.SyncedVersions{}, existing.Proxies...), cd.Proxies...)
+			byKey[key] = &merged
+		} else {
+			byKey[key] = cd
+		}
+	}
+	c.configDistributionStore.Replace(byKey)
+}
@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"github.com/kiali/kiali/models"
+)
+
+type (
+	IstioConfigSchemasCache interface {
+		// GetIstioConfigSchemas returns the schemas of the Istio config CRDs of the cluster.
+		// They expire after a while so that the CRDs upgraded in the cluster are eventually served.
+		GetIstioConfigSchemas(cluster string) (*models.IstioConfigSchemas, bool)
+		SetIstioConfigSchemas(cluster string, schemas *models.IstioConfigSchemas)
+	}
+)
+
+func (c *kialiCacheImpl) GetIstioConfigSchemas(cluster string) (*models.IstioConfigSchemas, bool) {
+	return c.istioConfigSchemasStore.Get(cluster)
+}
+
+func (c *kialiCacheImpl) SetIstioConfigSchemas(cluster string, schemas *models.IstioConfigSchemas) {
+	c.istioConfigSchemasStore.Set(cluster, schemas)
+}
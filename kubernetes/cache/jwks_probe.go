@@ -0,0 +1,20 @@
+package cache
+
+type (
+	// JwksProbeCache keeps the results of probing the jwksUris of the RequestAuthentications from Kiali.
+	// The results expire so that a jwksUri is probed again once in a while.
+	JwksProbeCache interface {
+		// GetJwksProbe returns the error of the last probe of the jwksUri, nil when it was reachable.
+		// found is false when the jwksUri was not probed yet or its result expired.
+		GetJwksProbe(uri string) (probeErr error, found bool)
+		SetJwksProbe(uri string, probeErr error)
+	}
+)
+
+func (c *kialiCacheImpl) GetJwksProbe(uri string) (error, bool) {
+	return c.jwksProbeStore.Get(uri)
+}
+
+func (c *kialiCacheImpl) SetJwksProbe(uri string, probeErr error) {
+	c.jwksProbeStore.Set(uri, probeErr)
+}
@@ -3,7 +3,9 @@ package cache
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/exp/slices"
@@ -23,6 +25,7 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	apps_v1_listers "k8s.io/client-go/listers/apps/v1"
 	core_v1_listers "k8s.io/client-go/listers/core/v1"
@@ -49,6 +52,30 @@ func checkIstioAPIsExist(client kubernetes.ClientInterface) error {
 	return nil
 }
 
+// ConfigChangeListener is called with the namespace of the objects changing the config version, empty for the
+// cluster scoped objects. It is called from the informers and must not block.
+type ConfigChangeListener func(namespace string)
+
+const (
+	ObjectAdded   = "Added"
+	ObjectUpdated = "Updated"
+	ObjectDeleted = "Deleted"
+)
+
+// ObjectChange is an addition, an update or a deletion of an Istio or Gateway API object of the cache.
+type ObjectChange struct {
+	// Type of the change: Added, Updated or Deleted
+	Type string
+	// Old is the object before an update, nil for the other changes
+	Old runtime.Object
+	// Object is the added, updated or deleted object
+	Object runtime.Object
+}
+
+// ObjectChangeListener is called with the changes of the Istio and Gateway API objects. The objects listed when
+// the informers start, or restart on a refresh, are not changes. It is called from the informers and must not block.
+type ObjectChangeListener func(change ObjectChange)
+
 const K8sExpGatewayAPIMessage = "k8s experimental Gateway API CRD is needed to be installed"
 
 const K8sGatewayAPIMessage = "k8s Gateway API CRDs are installed, Kiali needs to be restarted to apply"
@@ -66,6 +93,21 @@ type KubeCache interface {
 	// using the Kiali Service Account client.
 	Client() kubernetes.ClientInterface
 
+	// ConfigVersion is increased every time an Istio/Gateway API object, a service or the labels of a workload
+	// are added, updated or deleted. It tells when data derived from the cached config is stale.
+	ConfigVersion() uint64
+
+	// AddConfigChangeListener registers a listener of the changes increasing the config version. The returned
+	// function removes it.
+	AddConfigChangeListener(listener ConfigChangeListener) (remove func())
+
+	// AddObjectChangeListener registers a listener of the changes of the Istio and Gateway API objects. The returned
+	// function removes it.
+	AddObjectChangeListener(listener ObjectChangeListener) (remove func())
+
+	// ObjectCounts returns the number of cached objects by kind.
+	ObjectCounts() map[string]int
+
 	GetConfigMap(namespace, name string) (*core_v1.ConfigMap, error)
 	GetDaemonSets(namespace string) ([]apps_v1.DaemonSet, error)
 	GetDaemonSet(namespace, name string) (*apps_v1.DaemonSet, error)
@@ -169,6 +211,13 @@ type kubeCache struct {
 	client             kubernetes.ClientInterface
 	clusterCacheLister *cacheLister
 	clusterScoped      bool
+	// Increased on the informer events of the objects the references between Istio objects depend on.
+	configVersion atomic.Uint64
+	// The listeners of the changes of the config version, by id
+	listenersLock   sync.RWMutex
+	listeners       map[int]ConfigChangeListener
+	objectListeners map[int]ObjectChangeListener
+	nextListenerID  int
 	// used in methods before calling Gateway API listers
 	// added because of potential nil issue when CRDs are applied after Kiali pod starts
 	hasExpGatewayAPIStarted bool
@@ -232,6 +281,168 @@ func (c *kubeCache) Client() kubernetes.ClientInterface {
 	return c.client
 }
 
+// ConfigVersion is increased every time an Istio/Gateway API object, a service or the labels of a workload
+// are added, updated or deleted. It tells when data derived from the cached config is stale.
+func (c *kubeCache) ConfigVersion() uint64 {
+	return c.configVersion.Load()
+}
+
+// watchConfigChanges increases the config version on every change of the objects of the informer.
+// Resyncs are ignored as they don't change the objects.
+func (c *kubeCache) watchConfigChanges(informers ...cache.SharedIndexInformer) {
+	c.watchChanges(func(oldObj, newObj interface{}) bool {
+		oldMeta, oldOk := oldObj.(metav1.Object)
+		newMeta, newOk := newObj.(metav1.Object)
+		return !oldOk || !newOk || oldMeta.GetResourceVersion() != newMeta.GetResourceVersion()
+	}, informers...)
+	c.watchObjectChanges(informers...)
+}
+
+// watchObjectChanges notifies the object listeners of the changes of the objects of the informers, after their
+// initial listing. Resyncs are ignored as they don't change the objects.
+func (c *kubeCache) watchObjectChanges(informers ...cache.SharedIndexInformer) {
+	handler := cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				c.objectChanged(ObjectAdded, nil, obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, oldOk := oldObj.(metav1.Object)
+			newMeta, newOk := newObj.(metav1.Object)
+			if !oldOk || !newOk || oldMeta.GetResourceVersion() != newMeta.GetResourceVersion() {
+				c.objectChanged(ObjectUpdated, oldObj, newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.objectChanged(ObjectDeleted, nil, obj)
+		},
+	}
+	for _, informer := range informers {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			log.Errorf("[Kiali Cache] Unable to watch the object changes of the informer: %s", err)
+		}
+	}
+}
+
+func (c *kubeCache) objectChanged(changeType string, oldObj, obj interface{}) {
+	object, ok := obj.(runtime.Object)
+	if !ok {
+		return
+	}
+	change := ObjectChange{Type: changeType, Object: object}
+	if old, ok := oldObj.(runtime.Object); ok {
+		change.Old = old
+	}
+
+	c.listenersLock.RLock()
+	defer c.listenersLock.RUnlock()
+	for _, listener := range c.objectListeners {
+		listener(change)
+	}
+}
+
+// watchLabelChanges increases the config version when objects of the informer are added or deleted
+// or when their workload labels change. Other updates, like status updates, are ignored.
+func (c *kubeCache) watchLabelChanges(informers ...cache.SharedIndexInformer) {
+	c.watchChanges(func(oldObj, newObj interface{}) bool {
+		return !reflect.DeepEqual(workloadLabels(oldObj), workloadLabels(newObj))
+	}, informers...)
+}
+
+func (c *kubeCache) watchChanges(updated func(oldObj, newObj interface{}) bool, informers ...cache.SharedIndexInformer) {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.configChanged(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if updated(oldObj, newObj) {
+				c.configChanged(newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.configChanged(obj)
+		},
+	}
+	for _, informer := range informers {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			log.Errorf("[Kiali Cache] Unable to watch changes of the informer: %s", err)
+		}
+	}
+}
+
+// configChanged increases the config version and notifies the listeners of the namespace of the object
+func (c *kubeCache) configChanged(obj interface{}) {
+	c.configVersion.Add(1)
+
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	namespace := ""
+	if meta, ok := obj.(metav1.Object); ok {
+		namespace = meta.GetNamespace()
+	}
+
+	c.listenersLock.RLock()
+	defer c.listenersLock.RUnlock()
+	for _, listener := range c.listeners {
+		listener(namespace)
+	}
+}
+
+func (c *kubeCache) AddObjectChangeListener(listener ObjectChangeListener) func() {
+	c.listenersLock.Lock()
+	defer c.listenersLock.Unlock()
+	if c.objectListeners == nil {
+		c.objectListeners = map[int]ObjectChangeListener{}
+	}
+	id := c.nextListenerID
+	c.nextListenerID++
+	c.objectListeners[id] = listener
+
+	return func() {
+		c.listenersLock.Lock()
+		defer c.listenersLock.Unlock()
+		delete(c.objectListeners, id)
+	}
+}
+
+func (c *kubeCache) AddConfigChangeListener(listener ConfigChangeListener) func() {
+	c.listenersLock.Lock()
+	defer c.listenersLock.Unlock()
+	if c.listeners == nil {
+		c.listeners = map[int]ConfigChangeListener{}
+	}
+	id := c.nextListenerID
+	c.nextListenerID++
+	c.listeners[id] = listener
+
+	return func() {
+		c.listenersLock.Lock()
+		defer c.listenersLock.Unlock()
+		delete(c.listeners, id)
+	}
+}
+
+// workloadLabels returns the labels the references to the object depend on: the labels of the pods of a workload
+// and the selector of a service.
+func workloadLabels(obj interface{}) []map[string]string {
+	switch o := obj.(type) {
+	case *apps_v1.Deployment:
+		return []map[string]string{o.Labels, o.Spec.Template.Labels}
+	case *apps_v1.StatefulSet:
+		return []map[string]string{o.Labels, o.Spec.Template.Labels}
+	case *apps_v1.DaemonSet:
+		return []map[string]string{o.Labels, o.Spec.Template.Labels}
+	case *core_v1.Service:
+		return []map[string]string{o.Labels, o.Spec.Selector}
+	}
+	return nil
+}
+
 // UpdateClient will update the client and refresh the cache.
 // This is used when the client is updated with a new token.
 func (c *kubeCache) UpdateClient(kialiClient kubernetes.ClientInterface) error {
@@ -395,6 +606,22 @@ func (c *kubeCache) createIstioInformers(namespace string) istio.SharedInformerF
 
 		lister.workloadGroupLister = sharedInformers.Networking().V1beta1().WorkloadGroups().Lister()
 		lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Networking().V1beta1().WorkloadGroups().Informer().HasSynced)
+
+		c.watchConfigChanges(
+			sharedInformers.Security().V1beta1().AuthorizationPolicies().Informer(),
+			sharedInformers.Networking().V1beta1().DestinationRules().Informer(),
+			sharedInformers.Networking().V1alpha3().EnvoyFilters().Informer(),
+			sharedInformers.Networking().V1beta1().Gateways().Informer(),
+			sharedInformers.Security().V1beta1().PeerAuthentications().Informer(),
+			sharedInformers.Security().V1beta1().RequestAuthentications().Informer(),
+			sharedInformers.Networking().V1beta1().ServiceEntries().Informer(),
+			sharedInformers.Networking().V1beta1().Sidecars().Informer(),
+			sharedInformers.Telemetry().V1alpha1().Telemetries().Informer(),
+			sharedInformers.Networking().V1beta1().VirtualServices().Informer(),
+			sharedInformers.Extensions().V1alpha1().WasmPlugins().Informer(),
+			sharedInformers.Networking().V1beta1().WorkloadEntries().Informer(),
+			sharedInformers.Networking().V1beta1().WorkloadGroups().Informer(),
+		)
 	}
 
 	return sharedInformers
@@ -420,6 +647,12 @@ func (c *kubeCache) createGatewayInformers(namespace string) gateway.SharedInfor
 		lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1beta1().ReferenceGrants().Informer().HasSynced)
 		c.hasGatewayAPIStarted = true
 
+		c.watchConfigChanges(
+			sharedInformers.Gateway().V1().Gateways().Informer(),
+			sharedInformers.Gateway().V1().HTTPRoutes().Informer(),
+			sharedInformers.Gateway().V1beta1().ReferenceGrants().Informer(),
+		)
+
 		if c.client.IsExpGatewayAPI() {
 			lister.k8sgrpcrouteLister = sharedInformers.Gateway().V1().GRPCRoutes().Lister()
 			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1().GRPCRoutes().Informer().HasSynced)
@@ -430,6 +663,12 @@ func (c *kubeCache) createGatewayInformers(namespace string) gateway.SharedInfor
 			lister.k8stlsrouteLister = sharedInformers.Gateway().V1alpha2().TLSRoutes().Lister()
 			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1alpha2().TLSRoutes().Informer().HasSynced)
 			c.hasExpGatewayAPIStarted = true
+
+			c.watchConfigChanges(
+				sharedInformers.Gateway().V1().GRPCRoutes().Informer(),
+				sharedInformers.Gateway().V1alpha2().TCPRoutes().Informer(),
+				sharedInformers.Gateway().V1alpha2().TLSRoutes().Informer(),
+			)
 		}
 	}
 	return sharedInformers
@@ -467,6 +706,14 @@ func (c *kubeCache) createKubernetesInformers(namespace string) informers.Shared
 		sharedInformers.Core().V1().ConfigMaps().Informer().HasSynced,
 	)
 
+	// Pods are not watched: they churn too much and their labels come from their workload.
+	c.watchLabelChanges(
+		sharedInformers.Apps().V1().Deployments().Informer(),
+		sharedInformers.Apps().V1().StatefulSets().Informer(),
+		sharedInformers.Apps().V1().DaemonSets().Informer(),
+		sharedInformers.Core().V1().Services().Informer(),
+	)
+
 	if c.clusterScoped {
 		c.clusterCacheLister = lister
 	} else {
@@ -554,10 +801,11 @@ func (c *kubeCache) GetDaemonSetsWithSelector(namespace string, selectorLabels m
 			}
 		} else {
 			for _, nsCacheLister := range c.nsCacheLister {
-				daemonSets, err = nsCacheLister.daemonSetLister.List(labels.Everything())
+				nsDaemonSets, err := nsCacheLister.daemonSetLister.List(labels.Everything())
 				if err != nil {
 					return nil, err
 				}
+				daemonSets = append(daemonSets, nsDaemonSets...)
 			}
 		}
 	} else {
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -345,3 +346,111 @@ func ListingIstioObjectsWorksAcrossNamespacesWhenNamespaceScoped(t *testing.T) {
 	require.NoError(err)
 	assert.Len(vsList, 2)
 }
+
+func TestConfigVersionChangesOnInformerEvents(t *testing.T) {
+	require := require.New(t)
+
+	d := &apps_v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "deployment", Namespace: "test",
+		},
+	}
+	kubeCache := newTestingKubeCache(t, config.NewConfig(), d)
+	t.Cleanup(kubeCache.Stop)
+
+	version := kubeCache.ConfigVersion()
+	vs := &networking_v1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "vs", Namespace: "test"}}
+	_, err := kubeCache.Client().Istio().NetworkingV1beta1().VirtualServices("test").Create(context.TODO(), vs, metav1.CreateOptions{})
+	require.NoError(err)
+	require.Eventually(func() bool { return kubeCache.ConfigVersion() > version }, 5*time.Second, 10*time.Millisecond)
+
+	version = kubeCache.ConfigVersion()
+	d.Spec.Template.Labels = map[string]string{"app": "details"}
+	_, err = kubeCache.Client().Kube().AppsV1().Deployments("test").Update(context.TODO(), d, metav1.UpdateOptions{})
+	require.NoError(err)
+	require.Eventually(func() bool { return kubeCache.ConfigVersion() > version }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConfigChangeListeners(t *testing.T) {
+	require := require.New(t)
+
+	kubeCache := newTestingKubeCache(t, config.NewConfig())
+	t.Cleanup(kubeCache.Stop)
+
+	changes := make(chan string, 10)
+	remove := kubeCache.AddConfigChangeListener(func(namespace string) { changes <- namespace })
+
+	vs := &networking_v1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "vs", Namespace: "test"}}
+	_, err := kubeCache.Client().Istio().NetworkingV1beta1().VirtualServices("test").Create(context.TODO(), vs, metav1.CreateOptions{})
+	require.NoError(err)
+	select {
+	case namespace := <-changes:
+		require.Equal("test", namespace)
+	case <-time.After(5 * time.Second):
+		require.Fail("the listener was not notified of the change")
+	}
+
+	remove()
+	version := kubeCache.ConfigVersion()
+	require.NoError(kubeCache.Client().Istio().NetworkingV1beta1().VirtualServices("test").Delete(context.TODO(), "vs", metav1.DeleteOptions{}))
+	require.Eventually(func() bool { return kubeCache.ConfigVersion() > version }, 5*time.Second, 10*time.Millisecond)
+	require.Empty(changes)
+}
+
+func TestObjectChangeListeners(t *testing.T) {
+	require := require.New(t)
+
+	existing := &networking_v1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "test"}}
+	kubeCache := newTestingKubeCache(t, config.NewConfig(), existing)
+	t.Cleanup(kubeCache.Stop)
+
+	changes := make(chan ObjectChange, 10)
+	remove := kubeCache.AddObjectChangeListener(func(change ObjectChange) { changes <- change })
+	defer remove()
+
+	nextChange := func() ObjectChange {
+		select {
+		case change := <-changes:
+			return change
+		case <-time.After(5 * time.Second):
+			require.Fail("the listener was not notified of the change")
+		}
+		return ObjectChange{}
+	}
+
+	vs := &networking_v1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "vs", Namespace: "test"}}
+	vs, err := kubeCache.Client().Istio().NetworkingV1beta1().VirtualServices("test").Create(context.TODO(), vs, metav1.CreateOptions{})
+	require.NoError(err)
+	// The objects listed initially are not changes
+	change := nextChange()
+	require.Equal(ObjectAdded, change.Type)
+	require.Equal("vs", change.Object.(metav1.Object).GetName())
+	require.Nil(change.Old)
+
+	vs.Spec.Hosts = []string{"reviews"}
+	vs.ResourceVersion = "2"
+	_, err = kubeCache.Client().Istio().NetworkingV1beta1().VirtualServices("test").Update(context.TODO(), vs, metav1.UpdateOptions{})
+	require.NoError(err)
+	change = nextChange()
+	require.Equal(ObjectUpdated, change.Type)
+	require.Equal([]string{"reviews"}, change.Object.(*networking_v1beta1.VirtualService).Spec.Hosts)
+	require.Empty(change.Old.(*networking_v1beta1.VirtualService).Spec.Hosts)
+
+	require.NoError(kubeCache.Client().Istio().NetworkingV1beta1().VirtualServices("test").Delete(context.TODO(), "vs", metav1.DeleteOptions{}))
+	change = nextChange()
+	require.Equal(ObjectDeleted, change.Type)
+	require.Equal("vs", change.Object.(metav1.Object).GetName())
+}
+
+func TestWorkloadLabelsIgnoreStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	d := &apps_v1.Deployment{}
+	d.Spec.Template.Labels = map[string]string{"app": "details"}
+	updated := d.DeepCopy()
+	updated.Status.ReadyReplicas = 1
+	assert.Equal(workloadLabels(d), workloadLabels(updated))
+
+	updated.Spec.Template.Labels["version"] = "v1"
+	assert.NotEqual(workloadLabels(d), workloadLabels(updated))
+}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"github.com/kiali/kiali/models"
+)
+
+type (
+	// ProxyLogLevelResetCache tracks the pending resets of the proxy log levels of the workloads, restored from
+	// their configmap when Kiali starts.
+	ProxyLogLevelResetCache interface {
+		GetProxyLogLevelReset(key string) (*models.ProxyLogLevelReset, bool)
+		GetProxyLogLevelResets() []*models.ProxyLogLevelReset
+		RemoveProxyLogLevelReset(key string)
+		SetProxyLogLevelReset(reset *models.ProxyLogLevelReset)
+	}
+)
+
+func (c *kialiCacheImpl) GetProxyLogLevelReset(key string) (*models.ProxyLogLevelReset, bool) {
+	return c.proxyLogLevelResetStore.Get(key)
+}
+
+func (c *kialiCacheImpl) GetProxyLogLevelResets() []*models.ProxyLogLevelReset {
+	resets := []*models.ProxyLogLevelReset{}
+	for _, reset := range c.proxyLogLevelResetStore.Items() {
+		resets = append(resets, reset)
+	}
+	return resets
+}
+
+func (c *kialiCacheImpl) RemoveProxyLogLevelReset(key string) {
+	c.proxyLogLevelResetStore.Remove(key)
+}
+
+func (c *kialiCacheImpl) SetProxyLogLevelReset(reset *models.ProxyLogLevelReset) {
+	c.proxyLogLevelResetStore.Set(reset.Key(), reset)
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+type (
+	ReferenceIndexCache interface {
+		// GetReferenceIndex returns the reference index built for the namespace of the cluster.
+		// It is not found when the config of the cluster changed since the index was built.
+		GetReferenceIndex(cluster, namespace string) (*models.ReferenceIndex, bool)
+		SetReferenceIndex(cluster, namespace string, index *models.ReferenceIndex)
+	}
+)
+
+func referenceIndexKey(cluster, namespace string) string {
+	return cluster + "/" + namespace
+}
+
+func (c *kialiCacheImpl) GetReferenceIndex(cluster, namespace string) (*models.ReferenceIndex, bool) {
+	key := referenceIndexKey(cluster, namespace)
+	index, found := c.referenceIndexStore.Get(key)
+	if !found {
+		return nil, false
+	}
+
+	kubeCache, err := c.GetKubeCache(cluster)
+	if err != nil {
+		log.Tracef("Unable to get reference index for cluster [%s]: %s", cluster, err)
+		return nil, false
+	}
+
+	if index.Version != kubeCache.ConfigVersion() {
+		c.referenceIndexStore.Remove(key)
+		return nil, false
+	}
+
+	return index, true
+}
+
+func (c *kialiCacheImpl) SetReferenceIndex(cluster, namespace string, index *models.ReferenceIndex) {
+	c.referenceIndexStore.Set(referenceIndexKey(cluster, namespace), index)
+}
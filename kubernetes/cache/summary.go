@@ -0,0 +1,112 @@
+package cache
+
+import (
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	gatewayapi_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapi_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// objectLister is implemented by all the listers of the cache
+type objectLister[T any] interface {
+	List(selector labels.Selector) ([]T, error)
+}
+
+// countListed returns the number of objects of a lister, zero when its informer is not started
+func countListed[T any](lister objectLister[T]) int {
+	if lister == nil {
+		return 0
+	}
+	objects, err := lister.List(labels.Everything())
+	if err != nil {
+		return 0
+	}
+	return len(objects)
+}
+
+// ObjectCounts returns the number of cached objects by kind, counted without copying them
+func (c *kubeCache) ObjectCounts() map[string]int {
+	c.cacheLock.RLock()
+	defer c.cacheLock.RUnlock()
+
+	listers := []*cacheLister{}
+	if c.clusterScoped {
+		listers = append(listers, c.clusterCacheLister)
+	} else {
+		for _, lister := range c.nsCacheLister {
+			listers = append(listers, lister)
+		}
+	}
+
+	counts := map[string]int{}
+	for _, l := range listers {
+		if l == nil {
+			continue
+		}
+		counts[kubernetes.ConfigMapType] += countListed[*core_v1.ConfigMap](l.configMapLister)
+		counts[kubernetes.DaemonSetType] += countListed[*apps_v1.DaemonSet](l.daemonSetLister)
+		counts[kubernetes.DeploymentType] += countListed[*apps_v1.Deployment](l.deploymentLister)
+		counts[kubernetes.EndpointsType] += countListed[*core_v1.Endpoints](l.endpointLister)
+		counts[kubernetes.PodType] += countListed[*core_v1.Pod](l.podLister)
+		counts[kubernetes.ReplicaSetType] += countListed[*apps_v1.ReplicaSet](l.replicaSetLister)
+		counts[kubernetes.ServiceType] += countListed[*core_v1.Service](l.serviceLister)
+		counts[kubernetes.StatefulSetType] += countListed[*apps_v1.StatefulSet](l.statefulSetLister)
+
+		counts[kubernetes.AuthorizationPoliciesType] += countListed[*security_v1beta1.AuthorizationPolicy](l.authzLister)
+		counts[kubernetes.DestinationRuleType] += countListed[*networking_v1beta1.DestinationRule](l.destinationRuleLister)
+		counts[kubernetes.EnvoyFilterType] += countListed[*networking_v1alpha3.EnvoyFilter](l.envoyFilterLister)
+		counts[kubernetes.GatewayType] += countListed[*networking_v1beta1.Gateway](l.gatewayLister)
+		counts[kubernetes.PeerAuthenticationsType] += countListed[*security_v1beta1.PeerAuthentication](l.peerAuthnLister)
+		counts[kubernetes.RequestAuthenticationsType] += countListed[*security_v1beta1.RequestAuthentication](l.requestAuthnLister)
+		counts[kubernetes.ServiceEntryType] += countListed[*networking_v1beta1.ServiceEntry](l.serviceEntryLister)
+		counts[kubernetes.SidecarType] += countListed[*networking_v1beta1.Sidecar](l.sidecarLister)
+		counts[kubernetes.TelemetryType] += countListed[*v1alpha1.Telemetry](l.telemetryLister)
+		counts[kubernetes.VirtualServiceType] += countListed[*networking_v1beta1.VirtualService](l.virtualServiceLister)
+		counts[kubernetes.WasmPluginType] += countListed[*extentions_v1alpha1.WasmPlugin](l.wasmPluginLister)
+		counts[kubernetes.WorkloadEntryType] += countListed[*networking_v1beta1.WorkloadEntry](l.workloadEntryLister)
+		counts[kubernetes.WorkloadGroupType] += countListed[*networking_v1beta1.WorkloadGroup](l.workloadGroupLister)
+
+		counts[kubernetes.K8sGatewayType] += countListed[*gatewayapi_v1.Gateway](l.k8sgatewayLister)
+		counts[kubernetes.K8sGRPCRouteType] += countListed[*gatewayapi_v1.GRPCRoute](l.k8sgrpcrouteLister)
+		counts[kubernetes.K8sHTTPRouteType] += countListed[*gatewayapi_v1.HTTPRoute](l.k8shttprouteLister)
+		counts[kubernetes.K8sReferenceGrantType] += countListed[*gatewayapi_v1beta1.ReferenceGrant](l.k8sreferencegrantLister)
+		counts[kubernetes.K8sTCPRouteType] += countListed[*gatewayapi_v1alpha2.TCPRoute](l.k8stcprouteLister)
+		counts[kubernetes.K8sTLSRouteType] += countListed[*gatewayapi_v1alpha2.TLSRoute](l.k8stlsrouteLister)
+	}
+	return counts
+}
+
+// Summary returns the number of objects cached for each cluster and the number of entries of the stores
+func (c *kialiCacheImpl) Summary() models.CacheSummary {
+	summary := models.CacheSummary{
+		Clusters: map[string]map[string]int{},
+		Stores: map[string]int{
+			"ambientChecks":      len(c.ambientChecksPerCluster.Keys()),
+			"authSessions":       len(c.authSessionStore.Keys()),
+			"configDistribution": len(c.configDistributionStore.Keys()),
+			"istioConfigSchemas": len(c.istioConfigSchemasStore.Keys()),
+			"jwksProbe":          len(c.jwksProbeStore.Keys()),
+			"mesh":               len(c.meshStore.Keys()),
+			"namespaces":         len(c.namespaceStore.Keys()),
+			"proxyLogLevelReset": len(c.proxyLogLevelResetStore.Keys()),
+			"proxyStatus":        len(c.proxyStatusStore.Keys()),
+			"referenceIndex":     len(c.referenceIndexStore.Keys()),
+			"registryStatus":     len(c.registryStatusStore.Keys()),
+			"trustBundle":        len(c.trustBundleStore.Keys()),
+		},
+	}
+	for cluster, kubeCache := range c.GetKubeCaches() {
+		summary.Clusters[cluster] = kubeCache.ObjectCounts()
+	}
+	return summary
+}
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+type (
+	TrustBundleCache interface {
+		GetTrustBundleStatus(cluster string) *kubernetes.TrustBundleStatus
+		SetTrustBundleStatus(trustBundleStatus map[string]*kubernetes.TrustBundleStatus)
+	}
+)
+
+func (c *kialiCacheImpl) GetTrustBundleStatus(cluster string) *kubernetes.TrustBundleStatus {
+	status, found := c.trustBundleStore.Get(cluster)
+	if !found {
+		// Populating the cache is handled asynchronously by the controlplane monitor.
+		log.Tracef("Unable to get trust bundle status for cluster [%s]. Trust bundle status not found in cache.", cluster)
+		return nil
+	}
+
+	return status
+}
+
+func (c *kialiCacheImpl) SetTrustBundleStatus(trustBundleStatus map[string]*kubernetes.TrustBundleStatus) {
+	c.trustBundleStore.Replace(trustBundleStatus)
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 
 	kialiConfig "github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
 
@@ -26,6 +29,15 @@ var once sync.Once
 // defaultExpirationTime set the default expired time of a client
 const defaultExpirationTime = time.Minute * 15
 
+const (
+	// OpenIdAuthProvider is the name of the auth provider of the auth info of users logged in with the openid strategy.
+	OpenIdAuthProvider = "oidc"
+	// OpenIdIssuerKey is the key of the auth provider config holding the issuer of the token of the user.
+	OpenIdIssuerKey = "idp-issuer-url"
+	// OpenIdSubjectKey is the key of the auth provider config holding the name of the user.
+	OpenIdSubjectKey = "subject"
+)
+
 // ClientFactory interface for the clientFactory object
 type ClientFactory interface {
 	GetClient(authInfo *api.AuthInfo, cluster string) (ClientInterface, error)
@@ -90,6 +102,8 @@ func getClientFactory(conf kialiConfig.Config) (*clientFactory, error) {
 		TLSClientConfig: config.TLSClientConfig,
 		QPS:             conf.KubernetesConfig.QPS,
 		Burst:           conf.KubernetesConfig.Burst,
+		// Propagates the trace context of the requests to the Kubernetes API
+		WrapTransport: observability.NewTransport,
 	}
 
 	return newClientFactory(&baseConfig)
@@ -164,7 +178,13 @@ func newClientFactory(restConfig *rest.Config) (*clientFactory, error) {
 func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.Duration, cluster string) (ClientInterface, error) {
 	config := *cf.baseRestConfig
 
-	config.BearerToken = authInfo.Token
+	clusterAuthInfo, err := cf.openIdClusterAuthInfo(authInfo, cluster)
+	if err != nil {
+		return nil, err
+	}
+	impersonatingOpenIdUser := clusterAuthInfo != authInfo
+
+	config.BearerToken = clusterAuthInfo.Token
 	config.BearerTokenFile = ""
 
 	// There is a feature when using OpenID strategy to allow using a proxy
@@ -196,7 +216,7 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 		}
 
 		// User token and not Kiali SA token so use the proxy.
-		if kialiToken != authInfo.Token {
+		if kialiToken != clusterAuthInfo.Token {
 			// Override the CA data on the client with the proxy CA from the Kiali config.
 			caData := cfg.Auth.OpenId.ApiProxyCAData
 			rootCaDecoded, err := base64.StdEncoding.DecodeString(caData)
@@ -211,15 +231,19 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 		}
 	}
 
-	// Impersonation is valid only for header authentication strategy
-	if cfg.Auth.Strategy == kialiConfig.AuthStrategyHeader && authInfo.Impersonate != "" {
-		config.Impersonate.UserName = authInfo.Impersonate
-		config.Impersonate.Groups = authInfo.ImpersonateGroups
-		config.Impersonate.Extra = authInfo.ImpersonateUserExtra
+	// Impersonation is valid only for header authentication strategy, for the OpenID users on the clusters
+	// trusting another issuer, or for the users allowed to impersonate when the impersonation is enabled
+	if (cfg.Auth.Strategy == kialiConfig.AuthStrategyHeader || impersonatingOpenIdUser || cfg.Auth.Impersonation.Enabled) && clusterAuthInfo.Impersonate != "" {
+		config.Impersonate.UserName = clusterAuthInfo.Impersonate
+		config.Impersonate.Groups = clusterAuthInfo.ImpersonateGroups
+		config.Impersonate.Extra = clusterAuthInfo.ImpersonateUserExtra
 	}
 
 	var newClient ClientInterface
 	if cluster == cf.homeCluster {
+		if err := applyClientConfig(&config, cfg.KubernetesConfig.ClientConfig(cluster)); err != nil {
+			return nil, err
+		}
 		client, err := newClientWithRemoteClusterInfo(&config, nil)
 		if err != nil {
 			log.Errorf("Error creating client for cluster %s: %s", cluster, err.Error())
@@ -250,9 +274,12 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 		// and if we don't use OpenID with RBAC is disable.
 		if !(cfg.Auth.Strategy == kialiConfig.AuthStrategyAnonymous) &&
 			!(cfg.Auth.Strategy == kialiConfig.AuthStrategyOpenId && cfg.Auth.OpenId.DisableRBAC) {
-			remoteConfig.BearerToken = authInfo.Token
+			remoteConfig.BearerToken = clusterAuthInfo.Token
 			remoteConfig.BearerTokenFile = ""
 		}
+		if impersonatingOpenIdUser || cfg.Auth.Impersonation.Enabled {
+			remoteConfig.Impersonate = config.Impersonate
+		}
 
 		newClient, err = newClientWithRemoteClusterInfo(remoteConfig, &clusterInfo)
 		if err != nil {
@@ -270,6 +297,34 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 	return newClient, nil
 }
 
+// openIdClusterAuthInfo returns the auth info to reach the cluster for a user logged in with the openid strategy.
+// The API server of a cluster only trusts the tokens of the issuer mapped to the cluster, so the clusters trusting
+// another issuer are reached with the Kiali SA token impersonating the user. The impersonation requested by the
+// user is refused on these clusters: the Kiali SA would be the one impersonating, so the API server could not
+// check that the user is allowed to. The caller must hold the mutex.
+func (cf *clientFactory) openIdClusterAuthInfo(authInfo *api.AuthInfo, cluster string) (*api.AuthInfo, error) {
+	cfg := kialiConfig.Get()
+	if cfg.Auth.Strategy != kialiConfig.AuthStrategyOpenId || cfg.Auth.OpenId.DisableRBAC ||
+		authInfo.AuthProvider == nil || authInfo.AuthProvider.Name != OpenIdAuthProvider {
+		return authInfo, nil
+	}
+
+	issuer := authInfo.AuthProvider.Config[OpenIdIssuerKey]
+	if issuer == "" || issuer == cfg.Auth.OpenId.IssuerForCluster(cluster) {
+		return authInfo, nil
+	}
+
+	saClient, ok := cf.saClientEntries[cluster]
+	if !ok {
+		return authInfo, nil
+	}
+	if authInfo.Impersonate != "" {
+		return nil, fmt.Errorf("impersonation is not supported on cluster [%s]: it does not trust the issuer [%s] of the user", cluster, issuer)
+	}
+	log.Tracef("Impersonating the user of issuer [%s] on cluster [%s]", issuer, cluster)
+	return &api.AuthInfo{Token: saClient.GetToken(), Impersonate: authInfo.AuthProvider.Config[OpenIdSubjectKey]}, nil
+}
+
 // newSAClient returns a new client for the given cluster. If clusterInfo is nil then a client for the local cluster is returned.
 func (cf *clientFactory) newSAClient(remoteClusterInfo *RemoteClusterInfo) (*K8SClient, error) {
 	log.Debug("Creating new Kiali Service Account client")
@@ -432,6 +487,7 @@ func (cf *clientFactory) GetSAHomeClusterClient() ClientInterface {
 func (cf *clientFactory) getConfig(clusterInfo *RemoteClusterInfo) (*rest.Config, error) {
 	kialiConfig := kialiConfig.Get()
 	clientConfig := *cf.baseRestConfig
+	cluster := cf.homeCluster
 
 	// Remote Cluster
 	if clusterInfo != nil {
@@ -439,6 +495,11 @@ func (cf *clientFactory) getConfig(clusterInfo *RemoteClusterInfo) (*rest.Config
 		if err != nil {
 			return nil, err
 		}
+		rawConfig, err := clusterInfo.Config.RawConfig()
+		if err != nil {
+			return nil, err
+		}
+		cluster = getClusterName(&rawConfig)
 
 		// Use the remote config entirely for remote clusters.
 		clientConfig = *remoteConfig
@@ -460,8 +521,24 @@ func (cf *clientFactory) getConfig(clusterInfo *RemoteClusterInfo) (*rest.Config
 	}
 
 	// Override some settings with what's in kiali config
-	clientConfig.QPS = kialiConfig.KubernetesConfig.QPS
-	clientConfig.Burst = kialiConfig.KubernetesConfig.Burst
+	if err := applyClientConfig(&clientConfig, kialiConfig.KubernetesConfig.ClientConfig(cluster)); err != nil {
+		return nil, err
+	}
 
 	return &clientConfig, nil
 }
+
+// applyClientConfig sets the qps, burst, timeout and proxy of the clients of a cluster
+func applyClientConfig(config *rest.Config, clientConfig kialiConfig.KubernetesClientConfig) error {
+	config.QPS = clientConfig.QPS
+	config.Burst = clientConfig.Burst
+	config.Timeout = time.Duration(clientConfig.Timeout) * time.Second
+	if clientConfig.ProxyURL != "" {
+		proxyURL, err := url.Parse(clientConfig.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url of the clients: %s", err)
+		}
+		config.Proxy = http.ProxyURL(proxyURL)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math/rand"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -263,6 +264,168 @@ func TestClientCreatedWithAuthStrategyOpenIdAndDisableRBACFalse(t *testing.T) {
 	assert.NotEqual(userClients[testClusterName].GetToken(), "token")
 }
 
+func TestClientImpersonatesOpenIdUserOnClusterOfAnotherIssuer(t *testing.T) {
+	// For AuthStrategyOpenId ensure newClient for a cluster mapped to another issuer
+	// has the remote SA token impersonating the user.
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyOpenId
+	conf.Auth.OpenId.IssuerUri = "https://east.example.com"
+
+	config.Set(conf)
+
+	const testClusterName = "TestRemoteCluster"
+	const testUserToken = "TestUserToken"
+	createTestRemoteClusterSecret(t, testClusterName, remoteClusterYAML)
+	clientFactory := NewTestingClientFactory(t)
+
+	conf.Auth.OpenId.AdditionalIssuers = []config.OpenIdIssuerConfig{
+		{IssuerUri: "https://west.example.com", ClientId: "kiali", Clusters: []string{testClusterName}},
+	}
+	config.Set(conf)
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = testUserToken
+	authInfo.AuthProvider = &api.AuthProviderConfig{
+		Name:   OpenIdAuthProvider,
+		Config: map[string]string{OpenIdIssuerKey: "https://east.example.com", OpenIdSubjectKey: "jdoe"},
+	}
+
+	userClients, err := clientFactory.GetClients(authInfo)
+	require.NoError(err)
+
+	require.Contains(userClients, testClusterName)
+	assert.Equal("token", userClients[testClusterName].GetToken())
+	assert.Equal("jdoe", userClients[testClusterName].ClusterInfo().ClientConfig.Impersonate.UserName)
+
+	// The home cluster trusts the issuer of the token
+	homeClient := userClients[conf.KubernetesConfig.ClusterName]
+	assert.Equal(testUserToken, homeClient.GetToken())
+	assert.Empty(homeClient.ClusterInfo().ClientConfig.Impersonate.UserName)
+}
+
+func TestClientRefusesImpersonationOnClusterOfAnotherIssuer(t *testing.T) {
+	// The Kiali SA token must not impersonate the identity requested by an OpenID user on a cluster
+	// trusting another issuer, the API server can't check the user is allowed to impersonate it.
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyOpenId
+	conf.Auth.OpenId.IssuerUri = "https://east.example.com"
+	conf.Auth.Impersonation.Enabled = true
+	config.Set(conf)
+
+	const testClusterName = "TestRemoteCluster"
+	const testUserToken = "TestUserToken"
+	createTestRemoteClusterSecret(t, testClusterName, remoteClusterYAML)
+	clientFactory := NewTestingClientFactory(t)
+
+	conf.Auth.OpenId.AdditionalIssuers = []config.OpenIdIssuerConfig{
+		{IssuerUri: "https://west.example.com", ClientId: "kiali", Clusters: []string{testClusterName}},
+	}
+	config.Set(conf)
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = testUserToken
+	authInfo.Impersonate = "cluster-admin"
+	authInfo.ImpersonateGroups = []string{"system:masters"}
+	authInfo.AuthProvider = &api.AuthProviderConfig{
+		Name:   OpenIdAuthProvider,
+		Config: map[string]string{OpenIdIssuerKey: "https://east.example.com", OpenIdSubjectKey: "jdoe"},
+	}
+
+	_, err := clientFactory.GetClient(authInfo, testClusterName)
+	require.Error(err)
+
+	// The home cluster trusts the issuer, the user is the one impersonating
+	homeClient, err := clientFactory.GetClient(authInfo, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.Equal(testUserToken, homeClient.GetToken())
+	assert.Equal("cluster-admin", homeClient.ClusterInfo().ClientConfig.Impersonate.UserName)
+}
+
+func TestClientImpersonatesWhenImpersonationIsEnabled(t *testing.T) {
+	// For AuthStrategyToken ensure the impersonated identity reaches the clients
+	// of all the clusters, and only when the impersonation is enabled.
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyToken
+	config.Set(conf)
+
+	const testClusterName = "TestRemoteCluster"
+	const testUserToken = "TestUserToken"
+	createTestRemoteClusterSecret(t, testClusterName, remoteClusterYAML)
+	clientFactory := NewTestingClientFactory(t)
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = testUserToken
+	authInfo.Impersonate = "jdoe"
+	authInfo.ImpersonateGroups = []string{"developers"}
+
+	userClients, err := clientFactory.GetClients(authInfo)
+	require.NoError(err)
+	assert.Empty(userClients[conf.KubernetesConfig.ClusterName].ClusterInfo().ClientConfig.Impersonate.UserName)
+
+	conf.Auth.Impersonation.Enabled = true
+	config.Set(conf)
+	authInfo.Impersonate = "jane"
+
+	userClients, err = clientFactory.GetClients(authInfo)
+	require.NoError(err)
+	for _, cluster := range []string{conf.KubernetesConfig.ClusterName, testClusterName} {
+		require.Contains(userClients, cluster)
+		assert.Equal(testUserToken, userClients[cluster].GetToken())
+		impersonate := userClients[cluster].ClusterInfo().ClientConfig.Impersonate
+		assert.Equal("jane", impersonate.UserName)
+		assert.Equal([]string{"developers"}, impersonate.Groups)
+	}
+}
+
+func TestClientsTunedPerCluster(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterClients = map[string]config.KubernetesClientConfig{
+		"TestRemoteCluster": {QPS: 5, Timeout: 30, ProxyURL: "http://proxy.example.com:3128"},
+	}
+	config.Set(conf)
+
+	const testClusterName = "TestRemoteCluster"
+	createTestRemoteClusterSecret(t, testClusterName, remoteClusterYAML)
+	clientFactory := NewTestingClientFactory(t)
+
+	remoteSA := clientFactory.GetSAClient(testClusterName).ClusterInfo().ClientConfig
+	assert.Equal(float32(5), remoteSA.QPS)
+	assert.Equal(conf.KubernetesConfig.Burst, remoteSA.Burst)
+	assert.Equal(30*time.Second, remoteSA.Timeout)
+	require.NotNil(remoteSA.Proxy)
+	proxyURL, err := remoteSA.Proxy(httptest.NewRequest("GET", "https://api.example.com", nil))
+	require.NoError(err)
+	assert.Equal("proxy.example.com:3128", proxyURL.Host)
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = "TestUserToken"
+	userClients, err := clientFactory.GetClients(authInfo)
+	require.NoError(err)
+
+	remote := userClients[testClusterName].ClusterInfo().ClientConfig
+	assert.Equal(float32(5), remote.QPS)
+	assert.Equal(30*time.Second, remote.Timeout)
+
+	// The home cluster keeps the settings of all the clusters
+	home := userClients[conf.KubernetesConfig.ClusterName].ClusterInfo().ClientConfig
+	assert.Equal(conf.KubernetesConfig.QPS, home.QPS)
+	assert.Equal(conf.KubernetesConfig.Burst, home.Burst)
+	assert.Zero(home.Timeout)
+	assert.Nil(home.Proxy)
+}
+
 func TestSAClientCreatedWithExecProvider(t *testing.T) {
 	// by default, ExecProvider support should be disabled
 	cases := map[string]struct {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	osroutes_v1 "github.com/openshift/api/route/v1"
 	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
@@ -347,6 +348,21 @@ func FilterByRegistryService(namespace string, hostname string, registryService
 	return false
 }
 
+// IsExportedTo returns true when an object living in objectNamespace with the given exportTo
+// is visible from namespace. No exportTo means the object is exported to all namespaces, "."
+// to its own namespace and "~" to none.
+func IsExportedTo(exportTo []string, objectNamespace, namespace string) bool {
+	if len(exportTo) == 0 {
+		return true
+	}
+	for _, exportToNs := range exportTo {
+		if exportToNs == "*" || exportToNs == namespace || (exportToNs == "." && objectNamespace == namespace) {
+			return true
+		}
+	}
+	return false
+}
+
 func FilterRequestAuthenticationsBySelector(workloadSelector string, requestauthentications []*security_v1beta1.RequestAuthentication) []*security_v1beta1.RequestAuthentication {
 	filtered := []*security_v1beta1.RequestAuthentication{}
 	workloadLabels := mapWorkloadSelector(workloadSelector)
@@ -367,6 +383,23 @@ func FilterRequestAuthenticationsBySelector(workloadSelector string, requestauth
 	return filtered
 }
 
+// FilterWorkloadRequestAuthentications returns the RequestAuthentications that apply to a workload of the namespace with the given labels:
+// those of the workload namespace and of the root namespace, without selector or with a selector matching the labels.
+func FilterWorkloadRequestAuthentications(requestauthentications []*security_v1beta1.RequestAuthentication, namespace string, workloadLabels map[string]string) []*security_v1beta1.RequestAuthentication {
+	filtered := []*security_v1beta1.RequestAuthentication{}
+	for _, ra := range requestauthentications {
+		if ra.Namespace != namespace && !config.IsRootNamespace(ra.Namespace) {
+			continue
+		}
+		if ra.Spec.Selector != nil && len(ra.Spec.Selector.MatchLabels) > 0 &&
+			!labels.SelectorFromSet(ra.Spec.Selector.MatchLabels).Matches(labels.Set(workloadLabels)) {
+			continue
+		}
+		filtered = append(filtered, ra)
+	}
+	return filtered
+}
+
 func FilterServicesByLabels(selector labels.Selector, allServices []core_v1.Service) []core_v1.Service {
 	var services []core_v1.Service
 	for _, svc := range allServices {
@@ -498,6 +531,25 @@ func FilterVirtualServicesByService(allVs []*networking_v1beta1.VirtualService,
 	return filtered
 }
 
+// FilterOpenShiftRoutesByService returns the OpenShift Routes of the namespace sending traffic to the service, as their
+// target or as one of their alternate backends.
+func FilterOpenShiftRoutesByService(allRoutes []osroutes_v1.Route, namespace string, serviceName string) []*osroutes_v1.Route {
+	filtered := []*osroutes_v1.Route{}
+	for i, route := range allRoutes {
+		if route.Namespace != namespace {
+			continue
+		}
+		targets := append([]osroutes_v1.RouteTargetReference{route.Spec.To}, route.Spec.AlternateBackends...)
+		for _, target := range targets {
+			if (target.Kind == "" || target.Kind == ServiceType) && target.Name == serviceName {
+				filtered = append(filtered, &allRoutes[i])
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func FilterK8sHTTPRoutesByService(allRoutes []*k8s_networking_v1.HTTPRoute, referenceGrants []*k8s_networking_v1beta1.ReferenceGrant, namespace string, serviceName string) []*k8s_networking_v1.HTTPRoute {
 	filtered := []*k8s_networking_v1.HTTPRoute{}
 	for _, route := range allRoutes {
@@ -258,6 +258,21 @@ func TestFilterByNamespaces(t *testing.T) {
 	assert.Empty(emptyFiltered)
 }
 
+func TestIsExportedTo(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsExportedTo(nil, "ns1", "ns2"))
+	assert.True(IsExportedTo([]string{"*"}, "ns1", "ns2"))
+	assert.True(IsExportedTo([]string{"."}, "ns1", "ns1"))
+	assert.False(IsExportedTo([]string{"."}, "ns1", "ns2"))
+	assert.True(IsExportedTo([]string{".", "ns2"}, "ns1", "ns2"))
+	assert.False(IsExportedTo([]string{"ns3"}, "ns1", "ns2"))
+	assert.True(IsExportedTo([]string{"ns1"}, "ns1", "ns1"))
+	assert.False(IsExportedTo([]string{"ns1"}, "ns1", "ns2"))
+	assert.False(IsExportedTo([]string{"~"}, "ns1", "ns1"))
+	assert.False(IsExportedTo([]string{"~"}, "ns1", "ns2"))
+}
+
 func TestFilterK8sHTTPRoutesByService(t *testing.T) {
 	assert := assert.New(t)
 	rt1 := createHTTPRoute("testroute", "default", "details", "bookinfo")
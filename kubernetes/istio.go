@@ -1,8 +1,13 @@
 package kubernetes
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"regexp"
 	"strings"
 	"time"
@@ -22,6 +27,7 @@ import (
 )
 
 const (
+	ComponentDegraded    = "Degraded"
 	ComponentHealthy     = "Healthy"
 	ComponentNotFound    = "NotFound"
 	ComponentNotReady    = "NotReady"
@@ -51,6 +57,50 @@ type ComponentStatus struct {
 	// example:  true
 	// required: true
 	IsCore bool `json:"is_core"`
+
+	// Why the component is not healthy, when known.
+	//
+	// example: ["3 xDS push errors in the last 20s"]
+	Reasons []string `json:"reasons,omitempty"`
+
+	// What needs attention about the component without making it unhealthy e.g. the rotation of the root of trust
+	// distributed by istiod.
+	//
+	// example: ["root certificate [CN=root-1] expires in 10 days"]
+	Warnings []string `json:"warnings,omitempty"`
+
+	// The latency, in milliseconds, of the reachability probe of an addon.
+	//
+	// example: 900
+	LatencyMs int64 `json:"latency_ms,omitempty"`
+
+	// The version of an addon, when detected.
+	//
+	// example: 2.51.2
+	Version string `json:"version,omitempty"`
+
+	// The readiness of a node agent, like istio-cni or ztunnel, on every node.
+	Nodes []NodeAgentStatus `json:"nodes,omitempty"`
+}
+
+// NodeAgentStatus is the status of the pod of a node agent on a node.
+type NodeAgentStatus struct {
+	// The name of the node.
+	//
+	// example: worker-1
+	// required: true
+	Node string `json:"node"`
+
+	// The status of the pod of the node agent on the node.
+	//
+	// example: NotReady
+	// required: true
+	Status string `json:"status"`
+
+	// The pods scheduled on the node while the node agent was not ready yet, whose init may have failed.
+	//
+	// example: ["bookinfo/productpage-v1-5f6c4b8d9-abcde"]
+	EarlyPods []string `json:"early_pods,omitempty"`
 }
 
 type IstioComponentStatus []ComponentStatus
@@ -76,7 +126,12 @@ type IstioClientInterface interface {
 	GatewayAPI() gatewayapiclient.Interface
 
 	GetConfigDump(namespace, podName string) (*ConfigDump, error)
+	// GetProxyLogLevel returns the log level the proxy of a pod runs with, the level most of its loggers are at.
+	GetProxyLogLevel(namespace, podName string) (string, error)
 	SetProxyLogLevel(namespace, podName, level string) error
+	// TapProxy streams the traces of the Envoy /tap admin endpoint of a pod for the duration, with the tap request
+	// in JSON. It returns the traces received until the duration elapsed.
+	TapProxy(namespace, podName string, tapRequest []byte, duration time.Duration) ([]byte, error)
 }
 
 func (in *K8SClient) Istio() istio.Interface {
@@ -108,19 +163,32 @@ func (in *K8SClient) GetConfigDump(namespace, podName string) (*ConfigDump, erro
 	return cd, err
 }
 
+func (in *K8SClient) GetProxyLogLevel(namespace, pod string) (string, error) {
+	// Without a level, the logging endpoint only lists the active loggers.
+	body, err := in.postProxyAdmin(namespace, pod, "/logging")
+	if err != nil {
+		return "", err
+	}
+	return parseProxyLogLevel(body)
+}
+
 func (in *K8SClient) SetProxyLogLevel(namespace, pod, level string) error {
-	path := fmt.Sprintf("/logging?level=%s", level)
+	_, err := in.postProxyAdmin(namespace, pod, fmt.Sprintf("/logging?level=%s", level))
+	return err
+}
 
+// postProxyAdmin posts to the Envoy admin interface of the proxy of a pod.
+func (in *K8SClient) postProxyAdmin(namespace, pod, path string) ([]byte, error) {
 	localPort := httputil.Pool.GetFreePort()
 	defer httputil.Pool.FreePort(localPort)
 	f, err := in.getPodPortForwarder(namespace, pod, fmt.Sprintf("%d:%d", localPort, envoyAdminPort))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Start the forwarding
 	if err := f.Start(); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Defering the finish of the port-forwarding
@@ -131,10 +199,72 @@ func (in *K8SClient) SetProxyLogLevel(namespace, pod, level string) error {
 	body, code, _, err := httputil.HttpPost(url, nil, nil, time.Second*10, nil)
 	if code >= 400 {
 		log.Errorf("Error whilst posting. Error: %s. Body: %s", err, string(body))
-		return fmt.Errorf("error sending post request %s from %s/%s. Response code: %d", path, namespace, pod, code)
+		return nil, fmt.Errorf("error sending post request %s from %s/%s. Response code: %d", path, namespace, pod, code)
 	}
 
-	return err
+	return body, err
+}
+
+// parseProxyLogLevel returns the level most of the active loggers listed by the Envoy logging endpoint are at
+// e.g. "active loggers:\n  admin: warning\n  misc: error\n". Ties go to the most verbose level.
+func parseProxyLogLevel(body []byte) (string, error) {
+	counts := map[string]int{}
+	for _, line := range strings.Split(string(body), "\n") {
+		if _, level, found := strings.Cut(strings.TrimSpace(line), ": "); found {
+			counts[level]++
+		}
+	}
+
+	level := ""
+	for _, l := range proxyLogLevels {
+		if counts[l] > counts[level] {
+			level = l
+		}
+	}
+	if level == "" {
+		return "", fmt.Errorf("no active logger found in %q", string(body))
+	}
+	return level, nil
+}
+
+// proxyLogLevels are the Envoy log levels, from the most to the least verbose.
+var proxyLogLevels = []string{"trace", "debug", "info", "warning", "error", "critical", "off"}
+
+func (in *K8SClient) TapProxy(namespace, pod string, tapRequest []byte, duration time.Duration) ([]byte, error) {
+	localPort := httputil.Pool.GetFreePort()
+	defer httputil.Pool.FreePort(localPort)
+	f, err := in.getPodPortForwarder(namespace, pod, fmt.Sprintf("%d:%d", localPort, envoyAdminPort))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Start(); err != nil {
+		return nil, err
+	}
+	defer f.Stop()
+
+	// The admin endpoint streams the traces until the request is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	url := fmt.Sprintf("http://localhost:%d/tap", localPort)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(tapRequest))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("error sending post request /tap to %s/%s. Response code: %d. Body: %s", namespace, pod, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	return body, nil
 }
 
 func GetIstioConfigMap(istioConfig *core_v1.ConfigMap) (*IstioMeshConfig, error) {
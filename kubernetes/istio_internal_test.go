@@ -157,3 +157,19 @@ func createPeerAuthn(name, namespace string, mtls *api_security_v1beta1.PeerAuth
 	pa.Spec.Mtls = mtls
 	return pa
 }
+
+func TestParseProxyLogLevel(t *testing.T) {
+	assert := assert.New(t)
+
+	level, err := parseProxyLogLevel([]byte("active loggers:\n  admin: debug\n  alternate_protocols_cache: debug\n  misc: error\n  aws: debug\n"))
+	assert.NoError(err)
+	assert.Equal("debug", level)
+
+	// Ties go to the most verbose level
+	level, err = parseProxyLogLevel([]byte("active loggers:\n  admin: warning\n  misc: info\n"))
+	assert.NoError(err)
+	assert.Equal("info", level)
+
+	_, err = parseProxyLogLevel([]byte("not found\n"))
+	assert.Error(err)
+}
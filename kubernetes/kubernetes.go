@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	goerrors "errors"
 	"fmt"
 	"io"
@@ -43,11 +44,15 @@ type K8SClientInterface interface {
 
 	GetConfigMap(namespace, name string) (*core_v1.ConfigMap, error)
 	GetCronJobs(namespace string) ([]batch_v1.CronJob, error)
+	GetCustomResourceDefinition(name string) (*CustomResourceDefinition, error)
 	GetDeployment(namespace string, name string) (*apps_v1.Deployment, error)
+	GetIstioRevision(ctx context.Context, name string) (*IstioRevision, error)
 	GetJobs(namespace string) ([]batch_v1.Job, error)
 	GetNamespace(namespace string) (*core_v1.Namespace, error)
 	GetNamespaces(labelSelector string) ([]core_v1.Namespace, error)
+	GetNodes() ([]core_v1.Node, error)
 	GetPod(namespace, name string) (*core_v1.Pod, error)
+	GetPodMetrics(namespace string) ([]PodMetrics, error)
 	GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error)
 	GetSecret(namespace, name string) (*core_v1.Secret, error)
 	GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error)
@@ -67,6 +72,8 @@ type OSClientInterface interface {
 	GetProject(ctx context.Context, project string) (*osproject_v1.Project, error)
 	GetProjects(ctx context.Context, labelSelector string) ([]osproject_v1.Project, error)
 	GetRoute(ctx context.Context, namespace string, name string) (*osroutes_v1.Route, error)
+	GetRoutes(ctx context.Context, namespace string) ([]osroutes_v1.Route, error)
+	GetServiceMeshMemberRoll(ctx context.Context, namespace string) (*ServiceMeshMemberRoll, error)
 	GetUser(ctx context.Context, name string) (*osuser_v1.User, error)
 	UpdateProject(ctx context.Context, project string, jsonPatch string) (*osproject_v1.Project, error)
 }
@@ -211,6 +218,31 @@ func (in *K8SClient) GetRoute(ctx context.Context, namespace string, name string
 	return in.routeClient.RouteV1().Routes(namespace).Get(ctx, name, emptyGetOptions)
 }
 
+// GetRoutes returns the OpenShift Routes of a namespace, of all the namespaces when empty.
+func (in *K8SClient) GetRoutes(ctx context.Context, namespace string) ([]osroutes_v1.Route, error) {
+	routeList, err := in.routeClient.RouteV1().Routes(namespace).List(ctx, emptyListOptions)
+	if err != nil {
+		return nil, err
+	}
+	return routeList.Items, nil
+}
+
+// GetServiceMeshMemberRoll returns the member roll of the OpenShift Service Mesh 2 control plane of a namespace, from
+// the maistra.io API.
+// It returns a NotFound error when the control plane has no member roll, or when the API is not served (i.e. with
+// OpenShift Service Mesh 3, which scopes the control planes with discovery selectors).
+func (in *K8SClient) GetServiceMeshMemberRoll(ctx context.Context, namespace string) (*ServiceMeshMemberRoll, error) {
+	raw, err := in.k8s.Discovery().RESTClient().Get().AbsPath("/apis/maistra.io/v1/namespaces", namespace, "servicemeshmemberrolls", ServiceMeshMemberRollName).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	smmr := &ServiceMeshMemberRoll{}
+	if err := json.Unmarshal(raw, smmr); err != nil {
+		return nil, err
+	}
+	return smmr, nil
+}
+
 func (in *K8SClient) IsOpenShift() bool {
 	in.rwMutex.Lock()
 	defer in.rwMutex.Unlock()
@@ -366,6 +398,16 @@ func (in *K8SClient) getPodPortForwarder(namespace, name, portMap string) (httpu
 
 // GetPod returns the pod definitions for a given pod name.
 // It returns an error on any problem.
+// GetNodes returns the nodes of the cluster.
+// It returns an error on any problem.
+func (in *K8SClient) GetNodes() ([]core_v1.Node, error) {
+	nodes, err := in.k8s.CoreV1().Nodes().List(in.ctx, emptyListOptions)
+	if err != nil {
+		return nil, err
+	}
+	return nodes.Items, nil
+}
+
 func (in *K8SClient) GetPod(namespace, name string) (*core_v1.Pod, error) {
 	if pod, err := in.k8s.CoreV1().Pods(namespace).Get(in.ctx, name, emptyGetOptions); err != nil {
 		return nil, err
@@ -374,6 +416,50 @@ func (in *K8SClient) GetPod(namespace, name string) (*core_v1.Pod, error) {
 	}
 }
 
+// GetPodMetrics returns the current resource usage of the pods of a namespace, from the metrics.k8s.io API.
+// It returns an error when the API is not served (i.e. metrics-server is not installed).
+func (in *K8SClient) GetPodMetrics(namespace string) ([]PodMetrics, error) {
+	raw, err := in.k8s.Discovery().RESTClient().Get().AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", namespace, "pods").Do(in.ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	var list PodMetricsList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// GetCustomResourceDefinition returns the definition of a custom resource (e.g. virtualservices.networking.istio.io),
+// from the apiextensions.k8s.io API.
+// It returns an error on any problem.
+func (in *K8SClient) GetCustomResourceDefinition(name string) (*CustomResourceDefinition, error) {
+	raw, err := in.k8s.Discovery().RESTClient().Get().AbsPath("/apis/apiextensions.k8s.io/v1/customresourcedefinitions", name).Do(in.ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	crd := &CustomResourceDefinition{}
+	if err := json.Unmarshal(raw, crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+// GetIstioRevision returns a revision of a control plane managed by the Sail operator, from the sailoperator.io API.
+// It returns a NotFound error when the revision doesn't exist, or when the API is not served (i.e. without the
+// Sail operator).
+func (in *K8SClient) GetIstioRevision(ctx context.Context, name string) (*IstioRevision, error) {
+	raw, err := in.k8s.Discovery().RESTClient().Get().AbsPath("/apis/sailoperator.io/v1/istiorevisions", name).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	revision := &IstioRevision{}
+	if err := json.Unmarshal(raw, revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
 // StreamPodLogs opens a connection to progressively fetch the logs of a pod. Callers must make sure to properly close the returned io.ReadCloser.
 // It returns an error on any problem.
 func (in *K8SClient) StreamPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (io.ReadCloser, error) {
@@ -17,8 +17,10 @@ import (
 	istio "istio.io/client-go/pkg/clientset/versioned"
 	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
 	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	kubescheme "k8s.io/client-go/kubernetes/scheme"
@@ -155,6 +157,10 @@ type FakeK8sClient struct {
 	Token           string
 	KubeClusterInfo kialikube.ClusterInfo
 	ProjectFake     *projectfake.Clientset
+	// MemberRoll is the member roll of the OpenShift Service Mesh 2 control plane, none when nil.
+	MemberRoll *kialikube.ServiceMeshMemberRoll
+	// IstioRevisions are the revisions of the control planes managed by the Sail operator.
+	IstioRevisions []*kialikube.IstioRevision
 }
 
 func (c *FakeK8sClient) IsOpenShift() bool                  { return c.OpenShift }
@@ -164,4 +170,22 @@ func (c *FakeK8sClient) IsIstioAPI() bool                   { return c.IstioAPIE
 func (c *FakeK8sClient) GetToken() string                   { return c.Token }
 func (c *FakeK8sClient) ClusterInfo() kialikube.ClusterInfo { return c.KubeClusterInfo }
 
+// GetServiceMeshMemberRoll returns the member roll of the client, the maistra.io API not being served by the fake clientsets.
+func (c *FakeK8sClient) GetServiceMeshMemberRoll(ctx context.Context, namespace string) (*kialikube.ServiceMeshMemberRoll, error) {
+	if c.MemberRoll == nil || c.MemberRoll.Namespace != namespace {
+		return nil, errors.NewNotFound(schema.GroupResource{Group: "maistra.io", Resource: "servicemeshmemberrolls"}, kialikube.ServiceMeshMemberRollName)
+	}
+	return c.MemberRoll, nil
+}
+
+// GetIstioRevision returns a revision of the client, the sailoperator.io API not being served by the fake clientsets.
+func (c *FakeK8sClient) GetIstioRevision(ctx context.Context, name string) (*kialikube.IstioRevision, error) {
+	for _, revision := range c.IstioRevisions {
+		if revision.Name == name {
+			return revision, nil
+		}
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{Group: "sailoperator.io", Resource: "istiorevisions"}, name)
+}
+
 var _ kialikube.ClientInterface = &FakeK8sClient{}
@@ -2,6 +2,7 @@ package kubetest
 
 import (
 	"context"
+	"time"
 
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	istio "istio.io/client-go/pkg/clientset/versioned"
@@ -62,7 +63,17 @@ func (o *K8SClientMock) GetRegistryServices() ([]*kubernetes.RegistryService, er
 	return args.Get(0).([]*kubernetes.RegistryService), args.Error(1)
 }
 
+func (o *K8SClientMock) GetProxyLogLevel(namespace, podName string) (string, error) {
+	args := o.Called(namespace, podName)
+	return args.String(0), args.Error(1)
+}
+
 func (o *K8SClientMock) SetProxyLogLevel(namespace, podName, level string) error {
 	args := o.Called()
 	return args.Error(0)
 }
+
+func (o *K8SClientMock) TapProxy(namespace, podName string, tapRequest []byte, duration time.Duration) ([]byte, error) {
+	args := o.Called(namespace, podName, tapRequest, duration)
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -9,6 +9,8 @@ import (
 	batch_v1 "k8s.io/api/batch/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+
+	kialikube "github.com/kiali/kiali/kubernetes"
 )
 
 func (o *K8SClientMock) Kube() kubernetes.Interface {
@@ -85,6 +87,11 @@ func (o *K8SClientMock) GetNamespacesByCluster(namespace string) ([]core_v1.Name
 	return args.Get(0).([]core_v1.Namespace), args.Error(1)
 }
 
+func (o *K8SClientMock) GetNodes() ([]core_v1.Node, error) {
+	args := o.Called()
+	return args.Get(0).([]core_v1.Node), args.Error(1)
+}
+
 func (o *K8SClientMock) GetPods(namespace, labelSelector string) ([]core_v1.Pod, error) {
 	args := o.Called(namespace, labelSelector)
 	return args.Get(0).([]core_v1.Pod), args.Error(1)
@@ -95,6 +102,21 @@ func (o *K8SClientMock) GetPod(namespace, name string) (*core_v1.Pod, error) {
 	return args.Get(0).(*core_v1.Pod), args.Error(1)
 }
 
+func (o *K8SClientMock) GetCustomResourceDefinition(name string) (*kialikube.CustomResourceDefinition, error) {
+	args := o.Called(name)
+	return args.Get(0).(*kialikube.CustomResourceDefinition), args.Error(1)
+}
+
+func (o *K8SClientMock) GetIstioRevision(ctx context.Context, name string) (*kialikube.IstioRevision, error) {
+	args := o.Called(ctx, name)
+	return args.Get(0).(*kialikube.IstioRevision), args.Error(1)
+}
+
+func (o *K8SClientMock) GetPodMetrics(namespace string) ([]kialikube.PodMetrics, error) {
+	args := o.Called(namespace)
+	return args.Get(0).([]kialikube.PodMetrics), args.Error(1)
+}
+
 func (o *K8SClientMock) GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error) {
 	args := o.Called(namespace)
 	return args.Get(0).([]core_v1.ReplicationController), args.Error(1)
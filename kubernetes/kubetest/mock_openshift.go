@@ -8,6 +8,8 @@ import (
 	osproject_v1 "github.com/openshift/api/project/v1"
 	osroutes_v1 "github.com/openshift/api/route/v1"
 	osuser_v1 "github.com/openshift/api/user/v1"
+
+	kialikube "github.com/kiali/kiali/kubernetes"
 )
 
 func (o *K8SClientMock) GetRoute(ctx context.Context, namespace string, name string) (*osroutes_v1.Route, error) {
@@ -15,6 +17,11 @@ func (o *K8SClientMock) GetRoute(ctx context.Context, namespace string, name str
 	return args.Get(0).(*osroutes_v1.Route), args.Error(1)
 }
 
+func (o *K8SClientMock) GetRoutes(ctx context.Context, namespace string) ([]osroutes_v1.Route, error) {
+	args := o.Called(ctx, namespace)
+	return args.Get(0).([]osroutes_v1.Route), args.Error(1)
+}
+
 func (o *K8SClientMock) GetDeploymentConfig(ctx context.Context, namespace string, name string) (*osapps_v1.DeploymentConfig, error) {
 	args := o.Called(namespace, name)
 	return args.Get(0).(*osapps_v1.DeploymentConfig), args.Error(1)
@@ -54,3 +61,8 @@ func (o *K8SClientMock) GetUser(ctx context.Context, name string) (*osuser_v1.Us
 	args := o.Called(ctx, name)
 	return args.Get(0).(*osuser_v1.User), args.Error(1)
 }
+
+func (o *K8SClientMock) GetServiceMeshMemberRoll(ctx context.Context, namespace string) (*kialikube.ServiceMeshMemberRoll, error) {
+	args := o.Called(ctx, namespace)
+	return args.Get(0).(*kialikube.ServiceMeshMemberRoll), args.Error(1)
+}
@@ -1,11 +1,20 @@
 package kubernetes
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kiali/kiali/config"
 )
 
 const (
@@ -20,6 +29,7 @@ const (
 	PodType                   = "Pod"
 	ReplicationControllerType = "ReplicationController"
 	ReplicaSetType            = "ReplicaSet"
+	RolloutType               = "Rollout" // Argo Rollouts, see models.WorkloadRevision
 	ServiceType               = "Service"
 	StatefulSetType           = "StatefulSet"
 
@@ -67,6 +77,9 @@ const (
 	K8sGatewayClassType     = "GatewayClass"
 	K8sActualGatewayClasses = "gatewayclasses"
 
+	// K8sGatewayNameLabel is set on the workloads deployed for a K8s Gateway, e.g. waypoint proxies
+	K8sGatewayNameLabel = "gateway.networking.k8s.io/gateway-name"
+
 	K8sGRPCRoutes          = "k8sgrpcroutes"
 	K8sGRPCRouteType       = "K8sGRPCRoute"
 	K8sActualGRPCRouteType = "GRPCRoute"
@@ -217,7 +230,8 @@ type IstioMeshConfig struct {
 	DefaultConfig struct {
 		MeshId string `yaml:"meshId"`
 	} `yaml:"defaultConfig" json:"defaultConfig"`
-	TrustDomain string `yaml:"trustDomain,omitempty"`
+	TrustDomain        string   `yaml:"trustDomain,omitempty"`
+	TrustDomainAliases []string `yaml:"trustDomainAliases,omitempty"`
 }
 
 // MTLSDetails is a wrapper to group all Istio objects related to non-local mTLS configurations
@@ -233,6 +247,23 @@ type RBACDetails struct {
 	AuthorizationPolicies []*security_v1beta.AuthorizationPolicy `json:"authorizationpolicies"`
 }
 
+// WorkloadAuthorizationPolicies returns the policies that apply to a workload of the namespace with the given labels:
+// the policies of the workload namespace and of the root namespace, without selector or with a selector matching the labels.
+func (rd RBACDetails) WorkloadAuthorizationPolicies(namespace string, workloadLabels map[string]string) []*security_v1beta.AuthorizationPolicy {
+	policies := []*security_v1beta.AuthorizationPolicy{}
+	for _, ap := range rd.AuthorizationPolicies {
+		if ap.Namespace != namespace && !config.IsRootNamespace(ap.Namespace) {
+			continue
+		}
+		if ap.Spec.Selector != nil && len(ap.Spec.Selector.MatchLabels) > 0 &&
+			!labels.SelectorFromSet(ap.Spec.Selector.MatchLabels).Matches(labels.Set(workloadLabels)) {
+			continue
+		}
+		policies = append(policies, ap)
+	}
+	return policies
+}
+
 type ProxyStatus struct {
 	Pilot string
 	SyncStatus
@@ -254,9 +285,155 @@ type SyncStatus struct {
 	EndpointAcked string `json:"endpoint_acked,omitempty"`
 }
 
+// PodMetrics is the current resource usage of a pod, as reported by the metrics.k8s.io API (metrics-server).
+// It mirrors the PodMetrics of k8s.io/metrics/pkg/apis/metrics/v1beta1.
+type PodMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Timestamp is the end of the window the usage is computed over
+	Timestamp  metav1.Time        `json:"timestamp"`
+	Window     metav1.Duration    `json:"window"`
+	Containers []ContainerMetrics `json:"containers"`
+}
+
+// ContainerMetrics is the current resource usage of a container
+type ContainerMetrics struct {
+	Name  string               `json:"name"`
+	Usage core_v1.ResourceList `json:"usage"`
+}
+
+// PodMetricsList is the list of PodMetrics returned by the metrics.k8s.io API
+type PodMetricsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PodMetrics `json:"items"`
+}
+
+// CustomResourceDefinition is the definition of a custom resource, as served by the apiextensions.k8s.io API.
+// It mirrors the fields of the CustomResourceDefinition of k8s.io/apiextensions-apiserver used by Kiali, the
+// schemas are kept as raw JSON.
+type CustomResourceDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CustomResourceDefinitionSpec `json:"spec"`
+}
+
+// CustomResourceDefinitionSpec is the group, the names and the versions of a custom resource
+type CustomResourceDefinitionSpec struct {
+	Group    string                            `json:"group"`
+	Names    CustomResourceDefinitionNames     `json:"names"`
+	Versions []CustomResourceDefinitionVersion `json:"versions"`
+}
+
+// CustomResourceDefinitionNames are the names of a custom resource
+type CustomResourceDefinitionNames struct {
+	Kind   string `json:"kind"`
+	Plural string `json:"plural"`
+}
+
+// CustomResourceDefinitionVersion is a version of a custom resource and its OpenAPI v3 schema
+type CustomResourceDefinitionVersion struct {
+	Name       string                    `json:"name"`
+	Served     bool                      `json:"served"`
+	Storage    bool                      `json:"storage"`
+	Deprecated bool                      `json:"deprecated,omitempty"`
+	Schema     *CustomResourceValidation `json:"schema,omitempty"`
+}
+
+// CustomResourceValidation is the validation schema of a custom resource version
+type CustomResourceValidation struct {
+	OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema,omitempty"`
+}
+
+// IstioRevision is a revision of a control plane managed by the Sail operator, as served by the sailoperator.io API.
+// The operator renders the istiod deployment of the revision, owned by the IstioRevision. It mirrors the fields of the
+// IstioRevision of the Sail operator used by Kiali.
+type IstioRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IstioRevisionSpec `json:"spec"`
+}
+
+// IstioRevisionSpec is the version and the namespace requested for the revision
+type IstioRevisionSpec struct {
+	// Version of Istio installed by the operator, e.g. v1.24.1
+	Version   string `json:"version,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ServiceMeshMemberRollName is the name of the member roll of an OpenShift Service Mesh 2 control plane, created in
+// the namespace of the control plane.
+const ServiceMeshMemberRollName = "default"
+
+// ServiceMeshMemberRoll is the list of the namespaces member of the mesh of a multitenant OpenShift Service Mesh 2
+// control plane, as served by the maistra.io API. It mirrors the fields of the ServiceMeshMemberRoll of maistra used
+// by Kiali.
+type ServiceMeshMemberRoll struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ServiceMeshMemberRollSpec   `json:"spec"`
+	Status            ServiceMeshMemberRollStatus `json:"status,omitempty"`
+}
+
+// ServiceMeshMemberRollSpec are the namespaces requested as members
+type ServiceMeshMemberRollSpec struct {
+	Members []string `json:"members,omitempty"`
+}
+
+// ServiceMeshMemberRollStatus are the namespaces configured as members by the operator
+type ServiceMeshMemberRollStatus struct {
+	ConfiguredMembers []string `json:"configuredMembers,omitempty"`
+}
+
+// MemberNamespaces returns the namespaces configured as members by the operator, or the requested members when the
+// operator has not reported them yet.
+func (smmr *ServiceMeshMemberRoll) MemberNamespaces() []string {
+	if len(smmr.Status.ConfiguredMembers) > 0 {
+		return smmr.Status.ConfiguredMembers
+	}
+	return smmr.Spec.Members
+}
+
+// ConfigDistribution is the distribution state of a single Istio config across the proxies
+// connected to istiod, as reported by the /debug/config_distribution endpoint.
+type ConfigDistribution struct {
+	Cluster    string
+	Namespace  string
+	ObjectType string
+	Name       string
+	Proxies    []*SyncedVersions
+}
+
+// SyncedVersions is the version of a config acknowledged by a given Envoy for each xDS type.
+type SyncedVersions struct {
+	Pilot           string
+	ProxyID         string `json:"proxy,omitempty"`
+	ClusterVersion  string `json:"cluster_acked,omitempty"`
+	ListenerVersion string `json:"listener_acked,omitempty"`
+	RouteVersion    string `json:"route_acked,omitempty"`
+	EndpointVersion string `json:"endpoint_acked,omitempty"`
+}
+
+// HasAcked returns true if any of the xDS types acknowledged by the proxy
+// carries the given resource version.
+func (sv SyncedVersions) HasAcked(resourceVersion string) bool {
+	if resourceVersion == "" {
+		return false
+	}
+	for _, v := range []string{sv.ClusterVersion, sv.ListenerVersion, sv.RouteVersion, sv.EndpointVersion} {
+		if v == resourceVersion {
+			return true
+		}
+	}
+	return false
+}
+
 type RegistryService struct {
 	Pilot string
 	IstioService
+	// WorkloadEntryLabels are the labels of the WorkloadEntries selected by the service.
+	// Only the host catalog sets them, /debug/registryz doesn't report the endpoints.
+	WorkloadEntryLabels []map[string]string `json:"-"`
 }
 
 // Mapped from https://github.com/istio/istio/blob/master/pilot/pkg/model/service.go
@@ -300,6 +477,68 @@ type RegistryStatus struct {
 	Services []*RegistryService
 }
 
+// TrustBundleStatus describes the root of trust that istiod distributes to the workloads of a cluster
+// through the istio-ca-root-cert configmap and the CA that istiod signs the workload certs with.
+type TrustBundleStatus struct {
+	// Roots are the certs of the workload trust bundle. There is more than one while a root rotation is in progress.
+	Roots []CertValidity `json:"roots"`
+	// IstiodChain is the CA chain istiod signs workload certs with, leaf first. Empty when it can't be read.
+	IstiodChain []CertValidity `json:"istiodChain,omitempty"`
+}
+
+// CertValidity is the identity and validity period of a certificate.
+type CertValidity struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// ExpiresBefore is true when every root of the trust bundle expires before the given time.
+func (tb TrustBundleStatus) ExpiresBefore(t time.Time) bool {
+	if len(tb.Roots) == 0 {
+		return false
+	}
+	for _, root := range tb.Roots {
+		if !root.NotAfter.Before(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// RotationWarnings explains what needs attention about the roots of trust: certs expiring within warnBefore,
+// a root rotation in progress or istiod signing with a CA the workloads don't trust.
+func (tb TrustBundleStatus) RotationWarnings(now time.Time, warnBefore time.Duration) []string {
+	var warnings []string
+	expirationWarning := func(kind string, cert CertValidity) {
+		if !cert.NotAfter.After(now) {
+			warnings = append(warnings, fmt.Sprintf("%s [%s] expired on %s", kind, cert.Subject, cert.NotAfter.Format(time.DateOnly)))
+		} else if cert.NotAfter.Sub(now) < warnBefore {
+			warnings = append(warnings, fmt.Sprintf("%s [%s] expires in %d days", kind, cert.Subject, int(cert.NotAfter.Sub(now).Hours()/24)))
+		}
+	}
+
+	trusted := map[string]bool{}
+	for _, root := range tb.Roots {
+		trusted[root.Subject] = true
+		expirationWarning("root certificate", root)
+	}
+	if len(tb.Roots) > 1 {
+		warnings = append(warnings, fmt.Sprintf("%d root certificates in the trust bundle, a root rotation is in progress", len(tb.Roots)))
+	}
+
+	if len(tb.IstiodChain) > 0 {
+		expirationWarning("istiod CA certificate", tb.IstiodChain[0])
+		top := tb.IstiodChain[len(tb.IstiodChain)-1]
+		if len(tb.Roots) > 0 && !trusted[top.Subject] && !trusted[top.Issuer] {
+			warnings = append(warnings, fmt.Sprintf("istiod signs workload certificates with [%s] which is not trusted by the trust bundle", tb.IstiodChain[0].Subject))
+		}
+	}
+
+	return warnings
+}
+
 func (imc IstioMeshConfig) GetEnableAutoMtls() bool {
 	if imc.EnableAutoMtls == nil {
 		return true
@@ -321,3 +560,23 @@ func GetPatchType(patchType string) types.PatchType {
 		return types.MergePatchType
 	}
 }
+
+// ResourceTypeForGVK returns the resource type (e.g. virtualservices) of an Istio or Gateway API kind.
+// The version is ignored, all the served versions of a kind map to the same resource type.
+func ResourceTypeForGVK(gvk schema.GroupVersionKind) (string, bool) {
+	for resourceType, group := range ResourceTypesToAPI {
+		if group == gvk.Group && strings.TrimPrefix(PluralType[resourceType], "K8s") == gvk.Kind {
+			return resourceType, true
+		}
+	}
+	return "", false
+}
+
+// GVKForResourceType returns the group and kind of an Istio or Gateway API resource type, without version.
+func GVKForResourceType(resourceType string) (schema.GroupVersionKind, bool) {
+	group, found := ResourceTypesToAPI[resourceType]
+	if !found {
+		return schema.GroupVersionKind{}, false
+	}
+	return schema.GroupVersionKind{Group: group, Kind: strings.TrimPrefix(PluralType[resourceType], "K8s")}, true
+}
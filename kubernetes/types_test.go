@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourceTypeForGVK(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, resourceType := range []string{VirtualServices, Gateways, K8sGateways, K8sHTTPRoutes, AuthorizationPolicies} {
+		gvk, found := GVKForResourceType(resourceType)
+		assert.True(found)
+		rt, found := ResourceTypeForGVK(gvk)
+		assert.True(found)
+		assert.Equal(resourceType, rt)
+	}
+
+	rt, found := ResourceTypeForGVK(schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"})
+	assert.True(found)
+	assert.Equal(K8sGateways, rt)
+
+	rt, found = ResourceTypeForGVK(schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "Gateway"})
+	assert.True(found)
+	assert.Equal(Gateways, rt)
+
+	_, found = ResourceTypeForGVK(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	assert.False(found)
+	_, found = GVKForResourceType("deployments")
+	assert.False(found)
+}
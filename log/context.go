@@ -0,0 +1,79 @@
+package log
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// The fields of the log lines of a request
+const (
+	RequestIDField = "request_id"
+	UserField      = "user"
+	ClusterField   = "cluster"
+	RouteField     = "route"
+)
+
+// RequestIDHeader is the header of the ID of a request, set by the caller or generated
+const RequestIDHeader = "X-Request-Id"
+
+type loggerKey struct{}
+
+// RequestInfo identifies an API request in its log lines
+type RequestInfo struct {
+	RequestID string
+	// The subject of the authenticated user, hashed when logged
+	User    string
+	Cluster string
+	Route   string
+}
+
+// WithRequest returns a copy of the context with a logger whose log lines have the fields of the request. The
+// empty fields are not logged.
+func WithRequest(ctx context.Context, info RequestInfo) context.Context {
+	logContext := log.Logger.With()
+	if info.RequestID != "" {
+		logContext = logContext.Str(RequestIDField, info.RequestID)
+	}
+	if info.User != "" {
+		logContext = logContext.Str(UserField, HashSubject(info.User))
+	}
+	if info.Cluster != "" {
+		logContext = logContext.Str(ClusterField, info.Cluster)
+	}
+	if info.Route != "" {
+		logContext = logContext.Str(RouteField, info.Route)
+	}
+	logger := logContext.Logger()
+	return context.WithValue(ctx, loggerKey{}, &logger)
+}
+
+// FromContext returns the logger of the request of the context, or the global logger.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+			return logger
+		}
+	}
+	return &log.Logger
+}
+
+// HashSubject returns a short hash of the subject of a user, so the log lines of a user can be correlated without
+// logging who the user is.
+func HashSubject(subject string) string {
+	sum := sha256.Sum256([]byte(subject))
+	return hex.EncodeToString(sum[:6])
+}
+
+// NewRequestID returns a random request ID
+func NewRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
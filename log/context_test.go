@@ -0,0 +1,47 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromContext(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	globalLogger := log.Logger
+	t.Cleanup(func() { log.Logger = globalLogger })
+	buf := &bytes.Buffer{}
+	log.Logger = zerolog.New(buf)
+
+	FromContext(context.Background()).Info().Msg("global")
+	line := map[string]string{}
+	require.NoError(json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(map[string]string{"level": "info", "message": "global"}, line)
+
+	buf.Reset()
+	ctx := WithRequest(context.Background(), RequestInfo{RequestID: "1234", User: "alice", Route: "ServiceDetails"})
+	FromContext(ctx).Error().Msgf("request %d", 1)
+	line = map[string]string{}
+	require.NoError(json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal("request 1", line["message"])
+	assert.Equal("1234", line[RequestIDField])
+	assert.Equal(HashSubject("alice"), line[UserField])
+	assert.NotContains(buf.String(), "alice")
+	assert.Equal("ServiceDetails", line[RouteField])
+	// Not set
+	assert.NotContains(line, ClusterField)
+}
+
+func TestNewRequestID(t *testing.T) {
+	id := NewRequestID()
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, NewRequestID())
+}
@@ -62,6 +62,21 @@ func InitializeLogger() zerolog.Logger {
 	return log.Logger
 }
 
+// SetLevel changes the global log level, e.g. when the config is reloaded. The level of the LOG_LEVEL environment
+// variable is restored when the level is empty.
+func SetLevel(level string) error {
+	if level == "" {
+		zerolog.SetGlobalLevel(resolveLogLevelFromEnv())
+		return nil
+	}
+	logLevel, err := zerolog.ParseLevel(strings.ToLower(level))
+	if err != nil {
+		return err
+	}
+	zerolog.SetGlobalLevel(logLevel)
+	return nil
+}
+
 func Info(args ...interface{}) {
 	log.Info().Msgf("%s", args...)
 }
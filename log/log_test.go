@@ -65,6 +65,18 @@ func TestEnvVarLogLevel(t *testing.T) {
 	}
 }
 
+func TestSetLevel(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	t.Setenv("LOG_LEVEL", "warn")
+
+	assert.NoError(t, SetLevel("DEBUG"))
+	assert.True(t, IsDebug())
+	assert.Error(t, SetLevel("verbose"))
+	assert.True(t, IsDebug())
+	assert.NoError(t, SetLevel(""))
+	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+}
+
 func TestLogRegression(t *testing.T) {
 	type loggedMessageAsJsonStruct struct {
 		Level   string
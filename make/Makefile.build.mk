@@ -63,6 +63,11 @@ format:
 	done; \
 	$(shell ./hack/fix_imports.sh)
 
+## gen-openapi: Generate the OpenAPI v3 document of the API in _output/openapi.json and the typed Go client in client/
+gen-openapi: go-check
+	@echo Generating the OpenAPI document and the typed client
+	${GO} run ./tools/cmd/openapi --version ${VERSION}
+
 ## build-system-test: Building executable for system tests with code coverage enabled
 build-system-test: go-check
 	@echo Building executable for system tests with code coverage enabled
@@ -69,7 +69,15 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.AppenderGlobalIn
 	}
 
 	clusterMap := make(map[string]bool)
+	clusterNetworks := make(map[string]string)
+	dataPlanesByCluster := make(map[string][]*mesh.Node)
+	istiodsByCluster := make(map[string][]*mesh.Node)
 	for _, cp := range meshDef.ControlPlanes {
+		clusterNetworks[cp.Cluster.Name] = cp.Cluster.Network
+		for _, mc := range cp.ManagedClusters {
+			clusterNetworks[mc.Name] = mc.Network
+		}
+
 		// add control plane cluster if not already added
 		if _, ok := clusterMap[cp.Cluster.Name]; !ok {
 			k8sVersion := esVersions[fmt.Sprintf("%s-%s", "Kubernetes", cp.Cluster.Name)]
@@ -119,6 +127,9 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.AppenderGlobalIn
 		}
 		istiod, _, err := addInfra(meshMap, mesh.InfraTypeIstiod, cp.Cluster.Name, cp.IstiodNamespace, name, cp.Config, version, false, healthData[cp.IstiodName], false)
 		mesh.CheckError(err)
+		for _, mc := range cp.ManagedClusters {
+			istiodsByCluster[mc.Name] = append(istiodsByCluster[mc.Name], istiod)
+		}
 
 		// add the managed namespaces by cluster and narrowed, if necessary, by revision
 		dataplaneMap := make(map[string][]models.Namespace)
@@ -150,10 +161,13 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.AppenderGlobalIn
 
 			isDataPlaneCanary := isCanary && cp.Revision == canaryStatus.UpgradeVersion
 
-			dp, _, err := addInfra(meshMap, mesh.InfraTypeDataPlane, cluster, "", "Data Plane", namespaces, cp.Revision, false, "", isDataPlaneCanary)
+			dp, found, err := addInfra(meshMap, mesh.InfraTypeDataPlane, cluster, "", "Data Plane", namespaces, cp.Revision, false, "", isDataPlaneCanary)
 			graph.CheckError(err)
 
 			istiod.AddEdge(dp)
+			if !found {
+				dataPlanesByCluster[cluster] = append(dataPlanesByCluster[cluster], dp)
+			}
 		}
 
 		// add any Kiali instances
@@ -208,6 +222,29 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.AppenderGlobalIn
 		}
 	}
 
+	// add the east-west gateways, configured by the istiods managing their cluster, and the cross-network traffic paths
+	// from the data planes of the other networks.
+	for _, gw := range meshDef.EastWestGateways {
+		healthData := kubernetes.ComponentHealthy
+		if !gw.IsReachable() {
+			healthData = kubernetes.ComponentUnreachable
+		}
+		node, _, err := addInfra(meshMap, mesh.InfraTypeEastWestGateway, gw.Cluster, gw.Namespace, gw.Name, gw, "", false, healthData, false)
+		mesh.CheckError(err)
+
+		for _, istiod := range istiodsByCluster[gw.Cluster] {
+			istiod.AddEdge(node)
+		}
+		for cluster, dataPlanes := range dataPlanesByCluster {
+			if cluster == gw.Cluster || clusterNetworks[cluster] == gw.Network {
+				continue
+			}
+			for _, dp := range dataPlanes {
+				dp.AddEdge(node)
+			}
+		}
+	}
+
 	// The finalizers can perform final manipulations on the complete graph
 	for _, f := range finalizers {
 		f.AppendGraph(meshMap, gi, nil)
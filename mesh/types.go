@@ -8,20 +8,21 @@ import (
 )
 
 const (
-	BoxTypeCluster       string = "cluster"
-	BoxTypeNamespace     string = "namespace"
-	External             string = "_external_" // Special cluster name for external deployment
-	InfraTypeCluster     string = "cluster"    // cluster node (not box) with no other infra (very rare)
-	InfraTypeDataPlane   string = "dataplane"  // single node representing 1 or more dataPlane namespaces
-	InfraTypeGrafana     string = "grafana"
-	InfraTypeIstiod      string = "istiod"
-	InfraTypeKiali       string = "kiali"
-	InfraTypeMetricStore string = "metricStore"
-	InfraTypeNamespace   string = "namespace"
-	InfraTypeTraceStore  string = "traceStore"
-	NodeTypeBox          string = "box"                 // The special "box" node. isBox will be set to a BoxType
-	NodeTypeInfra        string = "infra"               // Any non-box node of interest
-	TF                   string = "2006-01-02 15:04:05" // TF is the TimeFormat for timestamps
+	BoxTypeCluster           string = "cluster"
+	BoxTypeNamespace         string = "namespace"
+	External                 string = "_external_"      // Special cluster name for external deployment
+	InfraTypeCluster         string = "cluster"         // cluster node (not box) with no other infra (very rare)
+	InfraTypeDataPlane       string = "dataplane"       // single node representing 1 or more dataPlane namespaces
+	InfraTypeEastWestGateway string = "eastWestGateway" // gateway exposing the services of a cluster to the other networks
+	InfraTypeGrafana         string = "grafana"
+	InfraTypeIstiod          string = "istiod"
+	InfraTypeKiali           string = "kiali"
+	InfraTypeMetricStore     string = "metricStore"
+	InfraTypeNamespace       string = "namespace"
+	InfraTypeTraceStore      string = "traceStore"
+	NodeTypeBox              string = "box"                 // The special "box" node. isBox will be set to a BoxType
+	NodeTypeInfra            string = "infra"               // Any non-box node of interest
+	TF                       string = "2006-01-02 15:04:05" // TF is the TimeFormat for timestamps
 )
 
 type Node struct {
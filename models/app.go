@@ -58,6 +58,41 @@ type AppListItem struct {
 
 	// Health
 	Health AppHealth `json:"health,omitempty"`
+
+	// Health status of the app, the worst of its clusters when aggregated across clusters
+	// example: Healthy
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// Members of the app in every cluster, when aggregated across clusters
+	Clusters []AppClusterMember `json:"clusters,omitempty"`
+}
+
+// AppClusterMember is the part of an app aggregated across clusters living in a single cluster
+type AppClusterMember struct {
+	// The kube cluster of the member
+	// required: true
+	// example: east
+	Cluster string `json:"cluster"`
+
+	// Define if all Pods related to the Workloads of the member have an IstioSidecar deployed
+	// required: true
+	// example: true
+	IstioSidecar bool `json:"istioSidecar"`
+
+	// Define if any pod of the member has the Ambient annotation
+	// required: true
+	// example: true
+	IstioAmbient bool `json:"istioAmbient"`
+
+	// Labels of the member
+	Labels map[string]string `json:"labels"`
+
+	// Health of the member
+	Health AppHealth `json:"health,omitempty"`
+
+	// Health status of the member
+	// example: Healthy
+	HealthStatus string `json:"healthStatus,omitempty"`
 }
 
 type WorkloadItem struct {
@@ -0,0 +1,34 @@
+package models
+
+// How a workload is covered by the AuthorizationPolicies.
+const (
+	// AuthorizationAllowed means the workload is covered by at least one ALLOW policy: only matching requests are allowed.
+	AuthorizationAllowed = "Allowed"
+	// AuthorizationDefaultAllow means no ALLOW policy applies to the workload: requests are allowed unless denied by a DENY policy.
+	AuthorizationDefaultAllow = "DefaultAllow"
+	// AuthorizationDenyAll means the workload is unreachable: every request is denied.
+	AuthorizationDenyAll = "DenyAll"
+)
+
+// AuthorizationCoverage reports how the workloads of a namespace are covered by the AuthorizationPolicies.
+type AuthorizationCoverage struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+
+	// Workloads of the namespace with their coverage.
+	Workloads []WorkloadAuthorizationCoverage `json:"workloads"`
+}
+
+// WorkloadAuthorizationCoverage is the coverage of a single workload.
+type WorkloadAuthorizationCoverage struct {
+	// Name of the workload
+	// required: true
+	Name string `json:"name"`
+
+	// Coverage of the workload: Allowed, DefaultAllow or DenyAll
+	// required: true
+	Coverage string `json:"coverage"`
+
+	// Policies that apply to the workload, from its namespace or the root namespace.
+	Policies []IstioValidationKey `json:"policies"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+)
+
+// Kinds of certificates found in the mesh.
+const (
+	CertificateKindRoot         = "Root"
+	CertificateKindIntermediate = "Intermediate"
+	CertificateKindGateway      = "Gateway"
+	CertificateKindWorkload     = "Workload"
+)
+
+// Where a certificate was read from.
+const (
+	CertificateSourceConfigMap = "ConfigMap"
+	CertificateSourceSecret    = "Secret"
+	CertificateSourceProxy     = "Proxy"
+)
+
+// Expiration status of a certificate.
+const (
+	CertificateValid    = "Valid"
+	CertificateExpiring = "Expiring"
+	CertificateExpired  = "Expired"
+	CertificateUnknown  = "Unknown"
+)
+
+// CertificateInventory lists the mesh-relevant certificates found across the clusters.
+type CertificateInventory struct {
+	// ExpirationWarningDays is how many days before their expiration certificates are reported as expiring.
+	ExpirationWarningDays int `json:"expirationWarningDays"`
+
+	// Certificates found in the mesh.
+	Certificates []MeshCertificate `json:"certificates"`
+}
+
+// MeshCertificate describes a certificate used by the mesh: a root or intermediate of
+// istiod, a TLS certificate of a gateway or a workload certificate sampled from a proxy.
+type MeshCertificate struct {
+	// Kind of certificate: Root, Intermediate, Gateway or Workload.
+	Kind string `json:"kind"`
+
+	// Status of the certificate: Valid, Expiring, Expired or Unknown when it could not be read.
+	Status string `json:"status"`
+
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+
+	// Name of the configmap/secret storing the certificate or of the pod it was sampled from.
+	Name string `json:"name"`
+
+	// Source is the kind of object the certificate was read from: ConfigMap, Secret or Proxy.
+	Source string `json:"source"`
+
+	Subject string `json:"subject,omitempty"`
+	Issuer  string `json:"issuer,omitempty"`
+
+	// Identities are the DNS names/SPIFFE IDs of the certificate.
+	Identities []string `json:"identities,omitempty"`
+
+	// ReferencedBy are the gateways using the certificate.
+	ReferencedBy []IstioValidationKey `json:"referencedBy,omitempty"`
+
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+
+	// Accessible is false when Kiali is not allowed to read the certificate.
+	Accessible bool   `json:"accessible"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ComputeStatus sets the expiration status of the certificate at the given time.
+func (mc *MeshCertificate) ComputeStatus(now time.Time, warnBefore time.Duration) {
+	switch {
+	case !mc.Accessible || mc.Error != "" || mc.NotAfter.IsZero():
+		mc.Status = CertificateUnknown
+	case !mc.NotAfter.After(now):
+		mc.Status = CertificateExpired
+	case mc.NotAfter.Before(now.Add(warnBefore)):
+		mc.Status = CertificateExpiring
+	default:
+		mc.Status = CertificateValid
+	}
+}
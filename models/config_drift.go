@@ -0,0 +1,26 @@
+package models
+
+// ConfigDriftReport lists the Istio objects of the controlplane clusters of a multi-primary mesh whose spec differs
+// between the clusters, or which are missing from some of them, e.g. after a change applied to a single cluster.
+type ConfigDriftReport struct {
+	// Clusters compared, the clusters running a controlplane.
+	Clusters []string `json:"clusters"`
+
+	// Drifts are the objects with the same namespace and name whose spec is not the same in every cluster.
+	Drifts []ConfigDrift `json:"drifts"`
+}
+
+// ConfigDrift is an Istio object whose spec is not the same in every compared cluster.
+type ConfigDrift struct {
+	// ObjectType is the resource type of the object e.g. virtualservices.
+	ObjectType string `json:"objectType"`
+
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// SpecHashes are the hashes of the spec of the object, by cluster. Clusters with the same hash have the same spec.
+	SpecHashes map[string]string `json:"specHashes"`
+
+	// MissingClusters are the compared clusters with the namespace of the object but without the object.
+	MissingClusters []string `json:"missingClusters"`
+}
@@ -16,6 +16,26 @@ type EnvoyProxyDump struct {
 	Routes     *Routes                `json:"routes,omitempty"`
 }
 
+// ProxyStatusDiff details what is out of sync between istiod and the proxy of a pod.
+type ProxyStatusDiff struct {
+	// Pilot is the istiod pod the proxy is connected to.
+	Pilot string    `json:"pilot"`
+	Types []XdsDiff `json:"types"`
+}
+
+// XdsDiff is the sync state of a single xDS type of a proxy.
+type XdsDiff struct {
+	// Type is one of CDS, LDS, RDS or EDS.
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	SentNonce  string `json:"sentNonce"`
+	AckedNonce string `json:"ackedNonce"`
+	// Missing are the resources istiod pushes that the proxy doesn't have yet.
+	Missing []string `json:"missing"`
+	// Unexpected are the resources the proxy still has that istiod doesn't push anymore.
+	Unexpected []string `json:"unexpected"`
+}
+
 type Listeners []*Listener
 type Listener struct {
 	Address     string  `json:"address"`
@@ -12,12 +12,15 @@ import (
 // DashboardQuery holds query parameters for a dashboard query
 type DashboardQuery struct {
 	prometheus.RangeQuery
+	Cluster           string
 	Namespace         string
 	LabelsFilters     map[string]string
 	AdditionalLabels  []Aggregation
 	RawDataAggregator string
-	Workload          string
-	WorkloadType      string
+	// Variables are the values of the template variables of the dashboard, by variable name
+	Variables    map[string]string
+	Workload     string
+	WorkloadType string
 }
 
 // FillDefaults fills the struct with default parameters
@@ -0,0 +1,24 @@
+package models
+
+// Mesh behaviors that deleting an Istio object can take away.
+const (
+	BehaviorRouting = "routing"
+	BehaviorMTLS    = "mtls"
+	BehaviorAuthz   = "authz"
+)
+
+// DeletionImpact describes what would change in the mesh if an Istio object was deleted.
+type DeletionImpact struct {
+	// Object to be deleted.
+	Object IstioReference `json:"object"`
+
+	// Behaviors provided by the object: routing, mtls and/or authz.
+	Behaviors []string `json:"behaviors"`
+
+	// Services and workloads that would lose the behaviors of the object.
+	Services  []ServiceReference  `json:"services"`
+	Workloads []WorkloadReference `json:"workloads"`
+
+	// Configs referencing the object, that would be left pointing to a missing object.
+	Configs []IstioReference `json:"configs"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// RuntimeDiagnostics are the runtime statistics of the Kiali server, to investigate goroutine and memory leaks
+type RuntimeDiagnostics struct {
+	// Number of goroutines
+	// required: true
+	Goroutines int `json:"goroutines"`
+
+	// Number of heap objects allocated and not freed yet
+	// required: true
+	HeapObjects uint64 `json:"heapObjects"`
+
+	// Bytes of the allocated heap objects
+	// required: true
+	HeapAllocBytes uint64 `json:"heapAllocBytes"`
+
+	// Time of the last garbage collection, unset when none ran yet
+	LastGC *time.Time `json:"lastGC,omitempty"`
+
+	// Value of GOMAXPROCS
+	// required: true
+	MaxProcs int `json:"maxProcs"`
+
+	// Number of completed garbage collections
+	// required: true
+	NumGC uint32 `json:"numGC"`
+
+	// Bytes of memory obtained from the OS
+	// required: true
+	SysBytes uint64 `json:"sysBytes"`
+}
+
+// CacheSummary summarizes the content of the Kiali cache, to find what grows without a heap profile
+type CacheSummary struct {
+	// Number of cached objects of each cluster, by kind
+	// required: true
+	Clusters map[string]map[string]int `json:"clusters"`
+
+	// Number of entries of the stores of the Kiali cache, by store
+	// required: true
+	Stores map[string]int `json:"stores"`
+}
@@ -0,0 +1,62 @@
+package models
+
+// Sources of an effective meshConfig value, from the lowest to the highest precedence.
+const (
+	// MeshConfigSourceDefault is the Istio default, the meshConfig of the revision does not set the value.
+	MeshConfigSourceDefault = "default"
+	// MeshConfigSourceMeshConfig is the istio configmap of the revision.
+	MeshConfigSourceMeshConfig = "meshConfig"
+	// MeshConfigSourceSidecar is a Sidecar selecting the workload or applying to its namespace.
+	MeshConfigSourceSidecar = "sidecar"
+	// MeshConfigSourceAnnotation is the proxy.istio.io/config annotation of the pods of the workload.
+	MeshConfigSourceAnnotation = "annotation"
+)
+
+// EffectiveMeshConfig are the meshConfig values a proxy of a namespace or of a workload actually runs with,
+// once the configmap of its revision and the overrides are resolved.
+type EffectiveMeshConfig struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+
+	// Workload the values are resolved for, empty for the namespace
+	Workload string `json:"workload,omitempty"`
+
+	// Revision of the controlplane injecting the proxies
+	// example: 1-24-1
+	Revision string `json:"revision"`
+
+	// ConfigMap holding the meshConfig of the revision, as namespace/name
+	// example: istio-system/istio-1-24-1
+	ConfigMap string `json:"configMap"`
+
+	// OutboundTrafficPolicy is either ALLOW_ANY or REGISTRY_ONLY
+	OutboundTrafficPolicy EffectiveMeshConfigValue `json:"outboundTrafficPolicy"`
+
+	// ProtocolDetectionTimeout before the proxy falls back to TCP, 0s disables it
+	ProtocolDetectionTimeout EffectiveMeshConfigValue `json:"protocolDetectionTimeout"`
+
+	// AccessLogFile the proxy writes the access logs to, empty when they are disabled
+	AccessLogFile EffectiveMeshConfigValue `json:"accessLogFile"`
+
+	// AccessLogEncoding is either TEXT or JSON
+	AccessLogEncoding EffectiveMeshConfigValue `json:"accessLogEncoding"`
+
+	// AccessLogFormat of the entries, empty for the Envoy default format
+	AccessLogFormat EffectiveMeshConfigValue `json:"accessLogFormat"`
+
+	// TraceSampling is the percentage of the requests traced, 0 when the tracing is disabled
+	TraceSampling EffectiveMeshConfigValue `json:"traceSampling"`
+}
+
+// EffectiveMeshConfigValue is a resolved meshConfig value and where it comes from.
+type EffectiveMeshConfigValue struct {
+	// required: true
+	Value string `json:"value"`
+
+	// Source of the value: default, meshConfig, sidecar or annotation
+	// required: true
+	Source string `json:"source"`
+
+	// Reference is the object setting the value, when it is an Istio object
+	Reference *IstioValidationKey `json:"reference,omitempty"`
+}
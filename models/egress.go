@@ -0,0 +1,70 @@
+package models
+
+import (
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+)
+
+// EgressReport describes how the mesh lets traffic out: the outbound traffic policy of every controlplane,
+// the Sidecars restricting the egress of their workloads and the external destinations reached without
+// being registered in the mesh.
+type EgressReport struct {
+	// ControlPlanes and their outbound traffic policy, from their meshConfig.
+	ControlPlanes []EgressControlPlane `json:"controlPlanes"`
+
+	// RateInterval the rates of the external destinations were computed over.
+	RateInterval string `json:"rateInterval"`
+
+	// Sidecars restricting the egress of their workloads.
+	Sidecars []EgressSidecar `json:"sidecars"`
+
+	// ExternalDestinations reached through the PassthroughCluster, with the highest rate first.
+	ExternalDestinations []ExternalDestination `json:"externalDestinations"`
+}
+
+// EgressControlPlane is the outbound traffic policy of a controlplane.
+type EgressControlPlane struct {
+	Cluster  string `json:"cluster"`
+	Revision string `json:"revision"`
+
+	// OutboundTrafficPolicy is either ALLOW_ANY or REGISTRY_ONLY.
+	OutboundTrafficPolicy string `json:"outboundTrafficPolicy"`
+}
+
+// EgressSidecar is a Sidecar restricting the egress of its workloads, either by setting a REGISTRY_ONLY
+// outbound traffic policy or by limiting the hosts visible to them.
+type EgressSidecar struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Cluster   string `json:"cluster"`
+
+	// WorkloadSelector of the Sidecar, empty when it applies to the whole namespace.
+	WorkloadSelector map[string]string `json:"workloadSelector,omitempty"`
+
+	// OutboundTrafficPolicy overriding the one of the mesh, if any.
+	OutboundTrafficPolicy string `json:"outboundTrafficPolicy,omitempty"`
+
+	// Hosts visible to the workloads through the egress listeners of the Sidecar.
+	Hosts []string `json:"hosts"`
+}
+
+// ExternalDestination is a host that is not in the service registry but still receives requests from the mesh.
+type ExternalDestination struct {
+	Host string `json:"host"`
+
+	// Rate of requests per second sent to the host.
+	Rate float64 `json:"rate"`
+
+	// Sources sending requests to the host, with the highest rate first.
+	Sources []ExternalDestinationSource `json:"sources"`
+
+	// SuggestedServiceEntry registers the host in the mesh. Not set when the host is not a DNS name.
+	SuggestedServiceEntry *networking_v1beta1.ServiceEntry `json:"suggestedServiceEntry,omitempty"`
+}
+
+// ExternalDestinationSource is a workload sending requests to an external destination.
+type ExternalDestinationSource struct {
+	Cluster   string  `json:"cluster"`
+	Namespace string  `json:"namespace"`
+	Workload  string  `json:"workload"`
+	Rate      float64 `json:"rate"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+const (
+	// EventTypeConfig is a change of the Istio config or of a workload made through Kiali.
+	EventTypeConfig = "config"
+	// EventTypeDeploy is the rollout of a new revision of a deployment.
+	EventTypeDeploy = "deploy"
+	// EventTypeMarker is a marker added manually by a user, e.g. to annotate an incident.
+	EventTypeMarker = "marker"
+)
+
+// KialiEvent is an event of a namespace that charts overlay on the metrics, e.g. to correlate a deploy with an
+// increase of the latency. An event without app, workload or service applies to the whole namespace.
+type KialiEvent struct {
+	// Type of the event: config, deploy or marker
+	// required: true
+	// example: deploy
+	Type string `json:"type"`
+
+	// Time of the event
+	// required: true
+	// example: 2024-01-31T10:15:00Z
+	Time time.Time `json:"time"`
+
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+
+	// App, Workload and Service are the targets of the event, if any
+	App      string `json:"app,omitempty"`
+	Workload string `json:"workload,omitempty"`
+	Service  string `json:"service,omitempty"`
+
+	// ObjectType and ObjectName are the object changed by a config event
+	// example: virtualservices
+	ObjectType string `json:"objectType,omitempty"`
+	ObjectName string `json:"objectName,omitempty"`
+
+	// Message describing the event
+	// required: true
+	// example: Rollout of revision 3 of deployment reviews-v1
+	Message string `json:"message"`
+
+	// User who made the change or added the marker
+	User string `json:"user,omitempty"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitOps tools and the labels and annotations they set on the resources they apply
+const (
+	GitOpsArgoCD = "ArgoCD"
+	GitOpsFlux   = "Flux"
+
+	argoCDInstanceLabel      = "argocd.argoproj.io/instance"
+	argoCDTrackingAnnotation = "argocd.argoproj.io/tracking-id"
+
+	fluxKustomizeNameLabel      = "kustomize.toolkit.fluxcd.io/name"
+	fluxKustomizeNamespaceLabel = "kustomize.toolkit.fluxcd.io/namespace"
+	fluxHelmNameLabel           = "helm.toolkit.fluxcd.io/name"
+	fluxHelmNamespaceLabel      = "helm.toolkit.fluxcd.io/namespace"
+	fluxReconcileAnnotation     = "kustomize.toolkit.fluxcd.io/reconcile"
+)
+
+// ManagedBy is the GitOps tool and the resource of the tool (Argo CD Application, Flux Kustomization or
+// HelmRelease) that manage a resource. A change of the resource out of its Git repository drifts from it.
+type ManagedBy struct {
+	// The GitOps tool
+	// required: true
+	// example: ArgoCD
+	Tool string `json:"tool"`
+
+	// The kind of the resource of the tool that applies the resource
+	// required: true
+	// example: Application
+	Kind string `json:"kind"`
+
+	// The name of the resource of the tool
+	// required: true
+	// example: bookinfo
+	Name string `json:"name"`
+
+	// The namespace of the resource of the tool, when known
+	// example: flux-system
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// String returns the tool and the resource, i.e. Flux Kustomization [flux-system/apps]
+func (mb ManagedBy) String() string {
+	if mb.Namespace == "" {
+		return fmt.Sprintf("%s %s [%s]", mb.Tool, mb.Kind, mb.Name)
+	}
+	return fmt.Sprintf("%s %s [%s/%s]", mb.Tool, mb.Kind, mb.Namespace, mb.Name)
+}
+
+// GetManagedBy returns the GitOps tool which manages a resource from its labels and annotations, nil when it is
+// not managed. Argo CD tracks resources with a label or an annotation, Flux with the labels of the Kustomization or
+// HelmRelease. A resource whose reconciliation is disabled in Flux is not managed.
+func GetManagedBy(labels, annotations map[string]string) *ManagedBy {
+	if trackingID, ok := annotations[argoCDTrackingAnnotation]; ok && trackingID != "" {
+		// <application>:<group>/<kind>:<namespace>/<name>
+		app, _, _ := strings.Cut(trackingID, ":")
+		return &ManagedBy{Tool: GitOpsArgoCD, Kind: "Application", Name: app}
+	}
+	if app, ok := labels[argoCDInstanceLabel]; ok && app != "" {
+		return &ManagedBy{Tool: GitOpsArgoCD, Kind: "Application", Name: app}
+	}
+	if annotations[fluxReconcileAnnotation] == "disabled" {
+		return nil
+	}
+	if name, ok := labels[fluxKustomizeNameLabel]; ok && name != "" {
+		return &ManagedBy{Tool: GitOpsFlux, Kind: "Kustomization", Name: name, Namespace: labels[fluxKustomizeNamespaceLabel]}
+	}
+	if name, ok := labels[fluxHelmNameLabel]; ok && name != "" {
+		return &ManagedBy{Tool: GitOpsFlux, Kind: "HelmRelease", Name: name, Namespace: labels[fluxHelmNamespaceLabel]}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetManagedBy(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(GetManagedBy(nil, nil))
+	assert.Nil(GetManagedBy(map[string]string{"app": "reviews"}, map[string]string{}))
+
+	assert.Equal(&ManagedBy{Tool: GitOpsArgoCD, Kind: "Application", Name: "bookinfo"},
+		GetManagedBy(map[string]string{"argocd.argoproj.io/instance": "bookinfo"}, nil))
+	assert.Equal(&ManagedBy{Tool: GitOpsArgoCD, Kind: "Application", Name: "bookinfo"},
+		GetManagedBy(nil, map[string]string{"argocd.argoproj.io/tracking-id": "bookinfo:networking.istio.io/VirtualService:bookinfo/reviews"}))
+
+	kustomization := GetManagedBy(map[string]string{
+		"kustomize.toolkit.fluxcd.io/name":      "apps",
+		"kustomize.toolkit.fluxcd.io/namespace": "flux-system",
+	}, nil)
+	assert.Equal(&ManagedBy{Tool: GitOpsFlux, Kind: "Kustomization", Name: "apps", Namespace: "flux-system"}, kustomization)
+	assert.Equal("Flux Kustomization [flux-system/apps]", kustomization.String())
+	assert.Equal(&ManagedBy{Tool: GitOpsFlux, Kind: "HelmRelease", Name: "bookinfo", Namespace: "flux-system"},
+		GetManagedBy(map[string]string{
+			"helm.toolkit.fluxcd.io/name":      "bookinfo",
+			"helm.toolkit.fluxcd.io/namespace": "flux-system",
+		}, nil))
+
+	// Flux does not reconcile the resource
+	assert.Nil(GetManagedBy(map[string]string{"kustomize.toolkit.fluxcd.io/name": "apps"},
+		map[string]string{"kustomize.toolkit.fluxcd.io/reconcile": "disabled"}))
+}
@@ -0,0 +1,45 @@
+package models
+
+// GraphView is a named graph view shared by the users of Kiali: the namespaces, appenders and
+// display options of a graph, along with the nodes hidden from it.
+type GraphView struct {
+	// Name of the view, unique
+	// required: true
+	// example: bookinfo-security
+	Name string `json:"name"`
+
+	// Description of the view
+	// example: mTLS and authorization policies of bookinfo
+	Description string `json:"description,omitempty"`
+
+	// Namespaces of the graph
+	// required: true
+	// example: ["bookinfo"]
+	Namespaces []string `json:"namespaces"`
+
+	// GraphType of the graph: app, versionedApp, workload or service
+	// example: versionedApp
+	GraphType string `json:"graphType,omitempty"`
+
+	// Appenders run to decorate the graph
+	// example: ["deadNode","securityPolicy"]
+	Appenders []string `json:"appenders,omitempty"`
+
+	// DisplayOptions of the graph, as the UI query params e.g. duration, edgeLabels, boxBy...
+	DisplayOptions map[string]string `json:"displayOptions,omitempty"`
+
+	// HiddenNodes are the find/hide expressions of the nodes removed from the graph
+	// example: ["app=details"]
+	HiddenNodes []string `json:"hiddenNodes,omitempty"`
+
+	// Layout of the graph
+	// example: kiali-dagre
+	Layout string `json:"layout,omitempty"`
+
+	// UpdatedBy is the user who saved the view last
+	UpdatedBy string `json:"updatedBy,omitempty"`
+
+	// UpdatedAt is the last time the view was saved
+	// example: 2024-01-31T10:15:00Z
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/prometheus/common/model"
 
 	"github.com/kiali/kiali/log"
@@ -13,6 +15,13 @@ type ClustersNamespaceHealth struct {
 	WorkloadHealth map[string]*NamespaceWorkloadHealth `json:"namespaceWorkloadHealth,omitempty"`
 }
 
+// NamespaceHealthBundle is the health of all the apps, services and workloads of a namespace
+type NamespaceHealthBundle struct {
+	AppHealth      NamespaceAppHealth      `json:"appHealth"`
+	ServiceHealth  NamespaceServiceHealth  `json:"serviceHealth"`
+	WorkloadHealth NamespaceWorkloadHealth `json:"workloadHealth"`
+}
+
 // NamespaceAppsHealth is a list of app name x health for a given namespace
 type NamespaceAppHealth map[string]*AppHealth
 
@@ -38,6 +47,7 @@ func NewEmptyRequestHealth() RequestHealth {
 		Inbound:            make(map[string]map[string]float64),
 		Outbound:           make(map[string]map[string]float64),
 		HealthAnnotations:  make(map[string]string),
+		Thresholds:         make(map[string]HealthThreshold),
 		inboundSource:      make(map[string]map[string]float64),
 		inboundDestination: make(map[string]map[string]float64),
 	}
@@ -87,6 +97,26 @@ type WorkloadStatus struct {
 	CurrentReplicas   int32  `json:"currentReplicas"`
 	AvailableReplicas int32  `json:"availableReplicas"`
 	SyncedProxies     int32  `json:"syncedProxies"`
+	// PodsStatus tells about pods crashing or not running, even when traffic looks healthy.
+	PodsStatus *PodsStatus `json:"podsStatus,omitempty"`
+}
+
+// PodsStatus summarizes the conditions of the pods of a workload over the health window:
+// - Restarts of the containers of the pods that restarted within the window
+// - number of pods with a container in CrashLoopBackOff
+// - number of pods with a container OOMKilled within the window
+// - number of pods that can't be scheduled
+// In healthy scenarios all variables should be 0.
+type PodsStatus struct {
+	Restarts         int32 `json:"restarts"`
+	CrashLoopBackOff int32 `json:"crashLoopBackOff"`
+	OOMKilled        int32 `json:"oomKilled"`
+	Unschedulable    int32 `json:"unschedulable"`
+}
+
+// IsHealthy returns true when no pod is restarting, crashing or pending to be scheduled
+func (ps PodsStatus) IsHealthy() bool {
+	return ps.Restarts == 0 && ps.CrashLoopBackOff == 0 && ps.OOMKilled == 0 && ps.Unschedulable == 0
 }
 
 // ProxyStatus gives the sync status of the sidecar proxy.
@@ -102,10 +132,17 @@ type ProxyStatus struct {
 // RequestHealth holds several stats about recent request errors
 // - Inbound//Outbound are the rates of requests by protocol and status_code.
 // Example:   Inbound: { "http": {"200": 1.5, "400": 2.3}, "grpc": {"1": 1.2} }
+// - Thresholds are the error rate thresholds applied to the requests, by protocol.
+// - Rules are the error rate rules of the health annotations, the ones of a port are applied by the clients.
+// - MetricsUnavailable tells the rates could not be fetched from Prometheus, the request health is unknown then.
 type RequestHealth struct {
 	Inbound            map[string]map[string]float64 `json:"inbound"`
 	Outbound           map[string]map[string]float64 `json:"outbound"`
 	HealthAnnotations  map[string]string             `json:"healthAnnotations"`
+	Thresholds         map[string]HealthThreshold    `json:"thresholds"`
+	Rules              []HealthRule                  `json:"rules,omitempty"`
+	MetricsUnavailable bool                          `json:"metricsUnavailable,omitempty"`
+
 	inboundSource      map[string]map[string]float64
 	inboundDestination map[string]map[string]float64
 }
@@ -170,6 +207,17 @@ func (in *RequestHealth) CombineReporters() {
 }
 
 func aggregate(sample *model.Sample, requests map[string]map[string]float64) {
+	protocol, code := sampleResponseCode(sample)
+
+	if _, ok := requests[protocol]; !ok {
+		requests[protocol] = make(map[string]float64)
+	}
+
+	requests[protocol][code] += float64(sample.Value)
+}
+
+// sampleResponseCode returns the protocol and the response code of the requests of the sample
+func sampleResponseCode(sample *model.Sample) (string, string) {
 	code := string(sample.Metric["response_code"])
 	protocol := string(sample.Metric["request_protocol"])
 	if code == "0" {
@@ -180,12 +228,7 @@ func aggregate(sample *model.Sample, requests map[string]map[string]float64) {
 			code = string(grpcStatus)
 		}
 	}
-
-	if _, ok := requests[protocol]; !ok {
-		requests[protocol] = make(map[string]float64)
-	}
-
-	requests[protocol][code] += float64(sample.Value)
+	return protocol, code
 }
 
 // CastWorkloadStatus returns a WorkloadStatus out of a given Workload
@@ -213,6 +256,44 @@ func (ws Workloads) CastWorkloadStatuses() []*WorkloadStatus {
 	return statuses
 }
 
+// CastWorkloadStatusSince returns a WorkloadStatus out of a given Workload, including the status of its pods since the given time
+func (w Workload) CastWorkloadStatusSince(since time.Time) *WorkloadStatus {
+	status := w.CastWorkloadStatus()
+	status.PodsStatus = w.Pods.StatusSince(since)
+	return status
+}
+
+// CastWorkloadStatusesSince returns a WorkloadStatus array out of a given set of Workloads, including the status of their pods since the given time
+func (ws Workloads) CastWorkloadStatusesSince(since time.Time) []*WorkloadStatus {
+	statuses := make([]*WorkloadStatus, 0)
+	for _, w := range ws {
+		statuses = append(statuses, w.CastWorkloadStatusSince(since))
+	}
+	return statuses
+}
+
+// StatusSince summarizes the restarts and failure conditions of the pods since the given time.
+// Kubernetes only keeps the last termination of a container, so the restarts of a pod are
+// counted when its last restart happened within the window.
+func (pods Pods) StatusSince(since time.Time) *PodsStatus {
+	status := &PodsStatus{}
+	for _, p := range pods {
+		if p.Restarts > 0 && !p.lastRestart.Before(since) {
+			status.Restarts += p.Restarts
+		}
+		if p.crashLooping {
+			status.CrashLoopBackOff++
+		}
+		if !p.lastOOMKill.IsZero() && !p.lastOOMKill.Before(since) {
+			status.OOMKilled++
+		}
+		if p.unschedulable {
+			status.Unschedulable++
+		}
+	}
+	return status
+}
+
 // IsSynced returns true when all the components are with SYNCED status
 func (ps ProxyStatus) IsSynced() bool {
 	return isComponentStatusSynced(ps.CDS) && isComponentStatusSynced(ps.EDS) &&
@@ -8,8 +8,24 @@ const (
 	RateHealthAnnotation AnnotationKey = "health.kiali.io/rate"
 )
 
+// Where a health threshold comes from, by precedence.
+const (
+	HealthThresholdSourceAnnotation = "annotation"
+	HealthThresholdSourceNamespace  = "namespace"
+	HealthThresholdSourceGlobal     = "global"
+)
+
+// HealthThreshold is the error rate threshold applied to the requests of a protocol.
+type HealthThreshold struct {
+	Degraded float32 `json:"degraded"`
+	Failure  float32 `json:"failure"`
+
+	// Source of the threshold: annotation, namespace or global.
+	Source string `json:"source"`
+}
+
 func GetHealthConfigAnnotation() []AnnotationKey {
-	return []AnnotationKey{RateHealthAnnotation}
+	return []AnnotationKey{RateHealthAnnotation, RulesHealthAnnotation}
 }
 
 func GetHealthAnnotation(annotations map[string]string, filters []AnnotationKey) map[string]string {
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RulesHealthAnnotation is the v2 of the health annotations: a JSON list of error rate rules, which can be limited to
+// a port, a protocol, a direction and a class of response codes. It takes precedence over the rate annotation.
+// example: [{"port": 9080, "protocol": "http", "code": "4xx", "degraded": 10, "failure": 20}]
+const RulesHealthAnnotation AnnotationKey = "health.kiali.io/rules"
+
+// Directions of the requests a health rule applies to.
+const (
+	HealthRuleInbound  = "inbound"
+	HealthRuleOutbound = "outbound"
+)
+
+// HealthRule is an error rate rule of the health annotations: the requests of the port, protocol and direction with
+// a response code matching the code are errors, and their rate is compared to the degraded and failure thresholds.
+type HealthRule struct {
+	// Response codes counted as errors: a class like 5xx, "-" for the requests without response, or a regular
+	// expression where x stands for any digit
+	// required: true
+	// example: 5xx
+	Code string `json:"code"`
+
+	// Error rate, in percent, from which the requests are degraded
+	// required: true
+	Degraded float32 `json:"degraded"`
+
+	// inbound or outbound, both when empty
+	Direction string `json:"direction,omitempty"`
+
+	// Error rate, in percent, from which the requests fail
+	// required: true
+	Failure float32 `json:"failure"`
+
+	// Port of the service, all the ports when zero
+	Port int32 `json:"port,omitempty"`
+
+	// Regular expression of the protocols, all the protocols when empty
+	// example: http|grpc
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// HealthAnnotationError is a malformed rule of a health annotation, which is ignored.
+type HealthAnnotationError struct {
+	Annotation AnnotationKey
+	Rule       string
+	Message    string
+}
+
+func (e HealthAnnotationError) Error() string {
+	return fmt.Sprintf("malformed health rule [%s] of annotation [%s]: %s", e.Rule, e.Annotation, e.Message)
+}
+
+// ParseHealthRules returns the error rate rules of the health annotations: the rules of the v2 annotation when it is
+// set, the rules converted from the v1 rate annotation otherwise. The malformed rules are skipped and returned as
+// errors.
+func ParseHealthRules(annotations map[string]string) ([]HealthRule, []HealthAnnotationError) {
+	if value, ok := annotations[string(RulesHealthAnnotation)]; ok {
+		return parseRulesAnnotation(value)
+	}
+	return parseRateAnnotation(annotations[string(RateHealthAnnotation)])
+}
+
+func parseRulesAnnotation(value string) ([]HealthRule, []HealthAnnotationError) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return []HealthRule{}, []HealthAnnotationError{{Annotation: RulesHealthAnnotation, Rule: value, Message: "not a JSON list of rules: " + err.Error()}}
+	}
+
+	rules := []HealthRule{}
+	errors := []HealthAnnotationError{}
+	for _, entry := range raw {
+		var rule HealthRule
+		decoder := json.NewDecoder(strings.NewReader(string(entry)))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&rule)
+		if err == nil {
+			err = rule.Validate()
+		}
+		if err != nil {
+			errors = append(errors, HealthAnnotationError{Annotation: RulesHealthAnnotation, Rule: string(entry), Message: err.Error()})
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errors
+}
+
+// parseRateAnnotation converts the tolerances of the v1 rate annotation, with the format
+// "<code>,<degraded>,<failure>,<protocol>,<direction>" and separated by ";", to rules.
+func parseRateAnnotation(value string) ([]HealthRule, []HealthAnnotationError) {
+	rules := []HealthRule{}
+	errors := []HealthAnnotationError{}
+	for _, entry := range strings.Split(value, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		fields := strings.Split(entry, ",")
+		if len(fields) < 4 {
+			errors = append(errors, HealthAnnotationError{Annotation: RateHealthAnnotation, Rule: entry, Message: "expected <code>,<degraded>,<failure>,<protocol>[,<direction>]"})
+			continue
+		}
+		degraded, errDegraded := strconv.ParseFloat(strings.TrimSpace(fields[1]), 32)
+		failure, errFailure := strconv.ParseFloat(strings.TrimSpace(fields[2]), 32)
+		if errDegraded != nil || errFailure != nil {
+			errors = append(errors, HealthAnnotationError{Annotation: RateHealthAnnotation, Rule: entry, Message: "the degraded and failure thresholds must be numbers"})
+			continue
+		}
+		rule := HealthRule{
+			Code:     strings.TrimSpace(fields[0]),
+			Degraded: float32(degraded),
+			Failure:  float32(failure),
+			Protocol: strings.TrimSpace(fields[3]),
+		}
+		if len(fields) > 4 {
+			rule.Direction = strings.TrimSpace(fields[4])
+		}
+		if err := rule.Validate(); err != nil {
+			errors = append(errors, HealthAnnotationError{Annotation: RateHealthAnnotation, Rule: entry, Message: err.Error()})
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errors
+}
+
+// Validate returns an error when the rule cannot be applied
+func (r HealthRule) Validate() error {
+	if r.Code == "" {
+		return fmt.Errorf("the code is required")
+	}
+	if _, err := r.codeRegexp(); err != nil {
+		return fmt.Errorf("invalid code [%s]", r.Code)
+	}
+	if _, err := regexp.Compile(r.Protocol); err != nil {
+		return fmt.Errorf("invalid protocol [%s]", r.Protocol)
+	}
+	if r.Direction != "" && r.Direction != HealthRuleInbound && r.Direction != HealthRuleOutbound {
+		return fmt.Errorf("invalid direction [%s], expected %s or %s", r.Direction, HealthRuleInbound, HealthRuleOutbound)
+	}
+	if r.Port < 0 || r.Port > 65535 {
+		return fmt.Errorf("invalid port [%d]", r.Port)
+	}
+	if r.Degraded < 0 || r.Degraded > 100 || r.Failure < 0 || r.Failure > 100 {
+		return fmt.Errorf("the degraded and failure thresholds must be between 0 and 100")
+	}
+	if r.Degraded > 0 && r.Failure > 0 && r.Degraded > r.Failure {
+		return fmt.Errorf("the degraded threshold [%v] is above the failure threshold [%v]", r.Degraded, r.Failure)
+	}
+	return nil
+}
+
+// MatchesProtocol returns whether the rule applies to the requests of a protocol
+func (r HealthRule) MatchesProtocol(protocol string) bool {
+	if r.Protocol == "" {
+		return true
+	}
+	protocolRegex, err := regexp.Compile("^(" + r.Protocol + ")$")
+	return err == nil && protocolRegex.MatchString(protocol)
+}
+
+// MatchesDirection returns whether the rule applies to the requests of a direction
+func (r HealthRule) MatchesDirection(direction string) bool {
+	return r.Direction == "" || r.Direction == direction
+}
+
+// MatchesCode returns whether a response code is an error for the rule
+func (r HealthRule) MatchesCode(code string) bool {
+	codeRegex, err := r.codeRegexp()
+	return err == nil && codeRegex.MatchString(code)
+}
+
+// codeRegexp returns the regular expression of the response codes of the rule, where x stands for any digit
+func (r HealthRule) codeRegexp() (*regexp.Regexp, error) {
+	return regexp.Compile("^(" + strings.NewReplacer("x", `\d`, "X", `\d`).Replace(r.Code) + ")$")
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHealthRulesOfRateAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	rules, errors := ParseHealthRules(map[string]string{string(RateHealthAnnotation): "4XX,10,20,http,inbound; wrong ;5XX,a,20,http;-,5,10,tcp;5XX,30,20,http"})
+	assert.Equal([]HealthRule{
+		{Code: "4XX", Degraded: 10, Failure: 20, Protocol: "http", Direction: "inbound"},
+		{Code: "-", Degraded: 5, Failure: 10, Protocol: "tcp"},
+	}, rules)
+	assert.Len(errors, 3)
+	for _, err := range errors {
+		assert.Equal(RateHealthAnnotation, err.Annotation)
+	}
+
+	rules, errors = ParseHealthRules(map[string]string{})
+	assert.Empty(rules)
+	assert.Empty(errors)
+}
+
+func TestParseHealthRulesOfRulesAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	annotations := map[string]string{
+		string(RateHealthAnnotation):  "5XX,2,3,http",
+		string(RulesHealthAnnotation): `[{"port": 9080, "protocol": "http", "code": "4xx", "degraded": 10, "failure": 20}, {"code": "5xx", "degraded": 1, "failure": 5, "direction": "inbound"}, {"code": "5xx", "degraded": 1, "failure": 5, "direction": "sideways"}, {"code": "5xx", "degrade": 1}, {"code": "(", "degraded": 1}]`,
+	}
+	rules, errors := ParseHealthRules(annotations)
+	assert.Equal([]HealthRule{
+		{Port: 9080, Protocol: "http", Code: "4xx", Degraded: 10, Failure: 20},
+		{Code: "5xx", Degraded: 1, Failure: 5, Direction: HealthRuleInbound},
+	}, rules)
+	assert.Len(errors, 3)
+	for _, err := range errors {
+		assert.Equal(RulesHealthAnnotation, err.Annotation)
+	}
+
+	annotations[string(RulesHealthAnnotation)] = "5xx,1,5,http"
+	rules, errors = ParseHealthRules(annotations)
+	assert.Empty(rules)
+	assert.Len(errors, 1)
+}
+
+func TestHealthRuleMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := HealthRule{Code: "5xx|429", Protocol: "http|grpc", Direction: HealthRuleInbound}
+	assert.True(rule.MatchesCode("503"))
+	assert.True(rule.MatchesCode("429"))
+	assert.False(rule.MatchesCode("404"))
+	assert.False(rule.MatchesCode("5030"))
+	assert.True(rule.MatchesProtocol("grpc"))
+	assert.False(rule.MatchesProtocol("tcp"))
+	assert.True(rule.MatchesDirection(HealthRuleInbound))
+	assert.False(rule.MatchesDirection(HealthRuleOutbound))
+
+	assert.True(HealthRule{Code: "-"}.MatchesCode("-"))
+	assert.True(HealthRule{Code: "5XX"}.MatchesProtocol("tcp"))
+}
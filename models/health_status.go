@@ -0,0 +1,136 @@
+package models
+
+import "strings"
+
+// Health statuses, computed as the UI does with the default error codes: an app, service or workload is as
+// healthy as the worst of its workloads and request error rates.
+const (
+	HealthStatusNA       = "NA"
+	HealthStatusHealthy  = "Healthy"
+	HealthStatusDegraded = "Degraded"
+	HealthStatusFailure  = "Failure"
+)
+
+// healthStatusRank orders the health statuses from the best to the worst
+var healthStatusRank = map[string]int{
+	HealthStatusNA:       0,
+	HealthStatusHealthy:  1,
+	HealthStatusDegraded: 2,
+	HealthStatusFailure:  3,
+}
+
+// WorstHealthStatus returns the worst of the health statuses, NA without statuses
+func WorstHealthStatus(statuses ...string) string {
+	worst := HealthStatusNA
+	for _, status := range statuses {
+		if healthStatusRank[status] > healthStatusRank[worst] {
+			worst = status
+		}
+	}
+	return worst
+}
+
+// Status returns the health status of the workload replicas, pods and proxies. A workload scaled to zero is NA.
+func (ws *WorkloadStatus) Status() string {
+	if ws.DesiredReplicas == 0 && ws.AvailableReplicas == 0 {
+		return HealthStatusNA
+	}
+	if ws.AvailableReplicas == 0 {
+		return HealthStatusFailure
+	}
+	if ws.AvailableReplicas < ws.DesiredReplicas || ws.CurrentReplicas > ws.AvailableReplicas {
+		return HealthStatusDegraded
+	}
+	if ws.SyncedProxies >= 0 && ws.SyncedProxies < ws.AvailableReplicas {
+		return HealthStatusDegraded
+	}
+	if ws.PodsStatus != nil && !ws.PodsStatus.IsHealthy() {
+		return HealthStatusDegraded
+	}
+	return HealthStatusHealthy
+}
+
+// Status returns the health status of the request error rates, inbound and outbound. The requests of a protocol are
+// checked against the rules of the health annotations applying to all the ports, when some match the protocol and
+// direction, otherwise against the thresholds of the protocol. Without rules, errors are the 5xx http codes, the
+// non-zero grpc codes and the requests without response. NA without requests.
+func (rh RequestHealth) Status() string {
+	status := HealthStatusNA
+	for direction, requests := range map[string]map[string]map[string]float64{HealthRuleInbound: rh.Inbound, HealthRuleOutbound: rh.Outbound} {
+		for protocol, codes := range requests {
+			status = WorstHealthStatus(status, rh.protocolStatus(direction, protocol, codes))
+		}
+	}
+	return status
+}
+
+// protocolStatus returns the health status of the requests of a protocol in a direction, by response code
+func (rh RequestHealth) protocolStatus(direction, protocol string, codes map[string]float64) string {
+	total := 0.0
+	for _, rate := range codes {
+		total += rate
+	}
+	if total == 0 {
+		return HealthStatusNA
+	}
+	errorRate := func(isError func(code string) bool) float32 {
+		errors := 0.0
+		for code, rate := range codes {
+			if isError(code) {
+				errors += rate
+			}
+		}
+		return float32(100 * errors / total)
+	}
+
+	rulesApplied := false
+	status := HealthStatusHealthy
+	for _, rule := range rh.Rules {
+		if rule.Port != 0 || !rule.MatchesProtocol(protocol) || !rule.MatchesDirection(direction) {
+			continue
+		}
+		rulesApplied = true
+		status = WorstHealthStatus(status, thresholdStatus(errorRate(rule.MatchesCode), rule.Degraded, rule.Failure))
+	}
+	if rulesApplied {
+		return status
+	}
+
+	threshold, ok := rh.Thresholds[protocol]
+	if !ok {
+		return HealthStatusHealthy
+	}
+	return thresholdStatus(errorRate(func(code string) bool { return isErrorCode(protocol, code) }), threshold.Degraded, threshold.Failure)
+}
+
+// thresholdStatus returns the health status of an error rate, the thresholds are ignored when zero
+func thresholdStatus(errorRate, degraded, failure float32) string {
+	switch {
+	case failure > 0 && errorRate >= failure:
+		return HealthStatusFailure
+	case degraded > 0 && errorRate >= degraded:
+		return HealthStatusDegraded
+	}
+	return HealthStatusHealthy
+}
+
+func isErrorCode(protocol, code string) bool {
+	switch {
+	case code == "-":
+		return true
+	case protocol == "grpc":
+		return code != "0"
+	case protocol == "http":
+		return strings.HasPrefix(code, "5")
+	}
+	return false
+}
+
+// Status returns the worst health status of the app workloads and requests
+func (ah AppHealth) Status() string {
+	status := ah.Requests.Status()
+	for _, ws := range ah.WorkloadStatuses {
+		status = WorstHealthStatus(status, ws.Status())
+	}
+	return status
+}
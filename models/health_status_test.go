@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloadStatusStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(HealthStatusNA, (&WorkloadStatus{SyncedProxies: -1}).Status())
+	assert.Equal(HealthStatusFailure, (&WorkloadStatus{DesiredReplicas: 2, SyncedProxies: -1}).Status())
+	assert.Equal(HealthStatusDegraded, (&WorkloadStatus{DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 1, SyncedProxies: -1}).Status())
+	assert.Equal(HealthStatusDegraded, (&WorkloadStatus{DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 2, SyncedProxies: 1}).Status())
+	assert.Equal(HealthStatusDegraded, (&WorkloadStatus{DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: 1, PodsStatus: &PodsStatus{Restarts: 3}}).Status())
+	assert.Equal(HealthStatusHealthy, (&WorkloadStatus{DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 2, SyncedProxies: 2}).Status())
+	assert.Equal(HealthStatusHealthy, (&WorkloadStatus{DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: -1}).Status())
+}
+
+func TestRequestHealthStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	thresholds := map[string]HealthThreshold{
+		"http": {Degraded: 0.1, Failure: 20},
+		"grpc": {Degraded: 0.1, Failure: 20},
+	}
+	rh := RequestHealth{Thresholds: thresholds}
+	assert.Equal(HealthStatusNA, rh.Status())
+
+	rh.Inbound = map[string]map[string]float64{"http": {"200": 10}}
+	assert.Equal(HealthStatusHealthy, rh.Status())
+
+	rh.Inbound["http"]["503"] = 1
+	assert.Equal(HealthStatusDegraded, rh.Status())
+
+	rh.Outbound = map[string]map[string]float64{"grpc": {"0": 1, "14": 1}}
+	assert.Equal(HealthStatusFailure, rh.Status())
+
+	// 4xx codes are not errors
+	rh = RequestHealth{Thresholds: thresholds, Inbound: map[string]map[string]float64{"http": {"404": 10, "-": 5}}}
+	assert.Equal(HealthStatusFailure, rh.Status())
+	delete(rh.Inbound["http"], "-")
+	assert.Equal(HealthStatusHealthy, rh.Status())
+
+	// Unless a rule of the health annotations counts them
+	rh.Inbound["http"]["200"] = 30
+	rh.Rules = []HealthRule{{Code: "4xx", Protocol: "http", Direction: HealthRuleInbound, Degraded: 20, Failure: 50}}
+	assert.Equal(HealthStatusDegraded, rh.Status())
+
+	// The rules of a port and of another direction are not applied
+	rh.Rules = []HealthRule{{Code: "4xx", Port: 9080, Degraded: 1, Failure: 2}, {Code: "4xx", Direction: HealthRuleOutbound, Degraded: 1, Failure: 2}}
+	assert.Equal(HealthStatusHealthy, rh.Status())
+}
+
+func TestAppHealthStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	health := EmptyAppHealth()
+	assert.Equal(HealthStatusNA, health.Status())
+
+	health.WorkloadStatuses = []*WorkloadStatus{
+		{DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: 1},
+		{DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 1, SyncedProxies: 1},
+	}
+	assert.Equal(HealthStatusDegraded, health.Status())
+
+	assert.Equal(HealthStatusFailure, WorstHealthStatus(HealthStatusHealthy, HealthStatusFailure, HealthStatusDegraded))
+	assert.Equal(HealthStatusNA, WorstHealthStatus())
+}
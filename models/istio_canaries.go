@@ -7,3 +7,42 @@ type CanaryUpgradeStatus struct {
 	MigratedNamespaces []string `json:"migratedNamespaces"`
 	PendingNamespaces  []string `json:"pendingNamespaces"`
 }
+
+// RevisionUpgradeProgress tells how the dataplane is spread across the controlplane revisions of the mesh
+// so that the progress of a revision based upgrade can be followed.
+type RevisionUpgradeProgress struct {
+	// Revisions are the namespaces and proxies of each revision, per dataplane cluster.
+	Revisions []RevisionUsage `json:"revisions"`
+	// PendingRestart are the proxies still connected to a revision other than the one their namespace is tagged with.
+	PendingRestart []ProxyRevision `json:"pendingRestart"`
+	// UnknownRevisionNamespaces are the namespaces labeled with a revision that no controlplane serves.
+	UnknownRevisionNamespaces []NamespaceRevision `json:"unknownRevisionNamespaces"`
+}
+
+// RevisionUsage is how much of the dataplane of a cluster belongs to a revision.
+type RevisionUsage struct {
+	Cluster  string `json:"cluster"`
+	Revision string `json:"revision"`
+	// Namespaces tagged with the revision.
+	Namespaces []string `json:"namespaces"`
+	// Proxies connected to the revision.
+	Proxies int `json:"proxies"`
+	// Workloads tagged with the revision, through their namespace or the labels of their pods.
+	Workloads int `json:"workloads"`
+}
+
+// ProxyRevision is a proxy connected to a revision other than the expected one.
+type ProxyRevision struct {
+	Cluster          string `json:"cluster"`
+	Namespace        string `json:"namespace"`
+	Pod              string `json:"pod"`
+	Revision         string `json:"revision"`
+	ExpectedRevision string `json:"expectedRevision"`
+}
+
+// NamespaceRevision is the revision a namespace is tagged with.
+type NamespaceRevision struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Revision  string `json:"revision"`
+}
@@ -1,14 +1,20 @@
 package models
 
 import (
+	"strings"
+
 	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
 	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
 	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
 )
 
 // IstioConfigList istioConfigList
@@ -107,6 +113,55 @@ func (i *IstioConfigList) ConvertToResponse() {
 	}
 }
 
+// FilterByValidationSeverity keeps the objects of the cluster whose most severe validation check has the severity,
+// i.e. only the objects with errors, or only the objects with warnings but no errors. Objects without validation
+// are filtered out.
+func (i *IstioConfigList) FilterByValidationSeverity(validations IstioValidations, cluster string, severity SeverityLevel) {
+	keys := map[IstioValidationKey]bool{}
+	for key, validation := range validations {
+		if key.Cluster == cluster && validation.Severity() == severity {
+			keys[key] = true
+		}
+	}
+
+	i.AuthorizationPolicies = filterByValidationKeys(i.AuthorizationPolicies, kubernetes.AuthorizationPolicies, cluster, keys)
+	i.DestinationRules = filterByValidationKeys(i.DestinationRules, kubernetes.DestinationRules, cluster, keys)
+	i.EnvoyFilters = filterByValidationKeys(i.EnvoyFilters, kubernetes.EnvoyFilters, cluster, keys)
+	i.Gateways = filterByValidationKeys(i.Gateways, kubernetes.Gateways, cluster, keys)
+	i.K8sGateways = filterByValidationKeys(i.K8sGateways, kubernetes.K8sGateways, cluster, keys)
+	i.K8sGRPCRoutes = filterByValidationKeys(i.K8sGRPCRoutes, kubernetes.K8sGRPCRoutes, cluster, keys)
+	i.K8sHTTPRoutes = filterByValidationKeys(i.K8sHTTPRoutes, kubernetes.K8sHTTPRoutes, cluster, keys)
+	i.K8sReferenceGrants = filterByValidationKeys(i.K8sReferenceGrants, kubernetes.K8sReferenceGrants, cluster, keys)
+	i.K8sTCPRoutes = filterByValidationKeys(i.K8sTCPRoutes, kubernetes.K8sTCPRoutes, cluster, keys)
+	i.K8sTLSRoutes = filterByValidationKeys(i.K8sTLSRoutes, kubernetes.K8sTLSRoutes, cluster, keys)
+	i.PeerAuthentications = filterByValidationKeys(i.PeerAuthentications, kubernetes.PeerAuthentications, cluster, keys)
+	i.RequestAuthentications = filterByValidationKeys(i.RequestAuthentications, kubernetes.RequestAuthentications, cluster, keys)
+	i.ServiceEntries = filterByValidationKeys(i.ServiceEntries, kubernetes.ServiceEntries, cluster, keys)
+	i.Sidecars = filterByValidationKeys(i.Sidecars, kubernetes.Sidecars, cluster, keys)
+	i.Telemetries = filterByValidationKeys(i.Telemetries, kubernetes.Telemetries, cluster, keys)
+	i.VirtualServices = filterByValidationKeys(i.VirtualServices, kubernetes.VirtualServices, cluster, keys)
+	i.WasmPlugins = filterByValidationKeys(i.WasmPlugins, kubernetes.WasmPlugins, cluster, keys)
+	i.WorkloadEntries = filterByValidationKeys(i.WorkloadEntries, kubernetes.WorkloadEntries, cluster, keys)
+	i.WorkloadGroups = filterByValidationKeys(i.WorkloadGroups, kubernetes.WorkloadGroups, cluster, keys)
+}
+
+// filterByValidationKeys keeps the objects of the resource type with a validation key. The validation object type
+// is the lowercase kind of the resource type, as set by the checkers.
+func filterByValidationKeys[T runtime.Object](objects []T, resourceType, cluster string, keys map[IstioValidationKey]bool) []T {
+	objectType := strings.ToLower(kubernetes.PluralType[resourceType])
+	filtered := []T{}
+	for _, obj := range objects {
+		o, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		if keys[IstioValidationKey{ObjectType: objectType, Name: o.GetName(), Namespace: o.GetNamespace(), Cluster: cluster}] {
+			filtered = append(filtered, obj)
+		}
+	}
+	return filtered
+}
+
 // IstioConfigMap holds a map of IstioConfigList per cluster
 type IstioConfigMap map[string]IstioConfigList
 
@@ -139,6 +194,19 @@ type IstioConfigDetails struct {
 	IstioValidation       *IstioValidation    `json:"validation"`
 	IstioReferences       *IstioReferences    `json:"references"`
 	IstioConfigHelpFields []IstioConfigHelp   `json:"help"`
+	// Distribution is only set when istiod config distribution tracking is enabled.
+	Distribution *ConfigDistribution `json:"distribution,omitempty"`
+	// ManagedBy is the GitOps tool which manages the config, unset when the config is not managed.
+	ManagedBy *ManagedBy `json:"managedBy,omitempty"`
+}
+
+// ConfigDistribution summarizes how far the current version of an Istio config
+// has been distributed to the proxies connected to the control plane(s).
+type ConfigDistribution struct {
+	// Proxies is the number of proxies reported by istiod for the config.
+	Proxies int `json:"proxies"`
+	// NotDistributed lists the proxies that have not acknowledged the current version yet.
+	NotDistributed []string `json:"notDistributed"`
 }
 
 // IstioConfigHelp represents a help message for a given Istio object type and field
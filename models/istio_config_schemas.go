@@ -0,0 +1,97 @@
+package models
+
+import "encoding/json"
+
+// IstioConfigSchemas are the schemas of the Istio and Gateway API kinds installed in a cluster, and the features
+// of the cluster, used by the config editor for autocompletion and inline validation.
+// swagger:model IstioConfigSchemas
+type IstioConfigSchemas struct {
+	// The cluster of the schemas
+	// required: true
+	// example: east
+	Cluster string `json:"cluster"`
+
+	// The Kubernetes version of the cluster
+	// example: v1.29.2
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// The APIs and features available in the cluster
+	// required: true
+	Features IstioConfigFeatures `json:"features"`
+
+	// The schemas of every Istio and Gateway API kind, sorted by object type
+	// required: true
+	Schemas []IstioConfigSchema `json:"schemas"`
+}
+
+// IstioConfigFeatures are the APIs and features available in a cluster
+type IstioConfigFeatures struct {
+	// The Istio API is installed
+	// required: true
+	IstioAPI bool `json:"istioAPI"`
+
+	// The Gateway API is installed
+	// required: true
+	GatewayAPI bool `json:"gatewayAPI"`
+
+	// The experimental channel of the Gateway API (TCPRoute, TLSRoute) is installed
+	// required: true
+	ExpGatewayAPI bool `json:"expGatewayAPI"`
+
+	// Istio Ambient is enabled
+	// required: true
+	Ambient bool `json:"ambient"`
+}
+
+// IstioConfigSchema is the schema of an Istio or Gateway API kind, read from its CRD
+type IstioConfigSchema struct {
+	// The object type of the kind, as used by the Istio config API
+	// required: true
+	// example: virtualservices
+	ObjectType string `json:"objectType"`
+
+	// required: true
+	// example: networking.istio.io
+	Group string `json:"group"`
+
+	// required: true
+	// example: VirtualService
+	Kind string `json:"kind"`
+
+	// The CRD of the kind is installed in the cluster. The kind has no version when it is not installed.
+	// required: true
+	Installed bool `json:"installed"`
+
+	// The Gateway API release of the CRD, from its bundle version annotation
+	// example: v1.1.0
+	BundleVersion string `json:"bundleVersion,omitempty"`
+
+	// The Gateway API release channel of the CRD (standard or experimental)
+	// example: standard
+	Channel string `json:"channel,omitempty"`
+
+	// The versions of the kind
+	// required: true
+	Versions []IstioConfigSchemaVersion `json:"versions"`
+}
+
+// IstioConfigSchemaVersion is a version of an Istio or Gateway API kind and its OpenAPI v3 structural schema
+type IstioConfigSchemaVersion struct {
+	// required: true
+	// example: v1beta1
+	Name string `json:"name"`
+
+	// The version is served by the API server
+	// required: true
+	Served bool `json:"served"`
+
+	// The version is the one the objects are stored as
+	// required: true
+	Storage bool `json:"storage"`
+
+	// The version is deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// The OpenAPI v3 schema of the version, as defined in the CRD
+	Schema json.RawMessage `json:"schema,omitempty"`
+}
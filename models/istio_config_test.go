@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestFilterByValidationSeverity(t *testing.T) {
+	assert := assert.New(t)
+
+	validation := func(objectType, name string, severities ...SeverityLevel) (IstioValidationKey, *IstioValidation) {
+		v := &IstioValidation{Name: name, Namespace: "bookinfo", Cluster: "east", ObjectType: objectType, Valid: true}
+		for _, severity := range severities {
+			v.Checks = append(v.Checks, &IstioCheck{Severity: severity})
+		}
+		return IstioValidationKey{ObjectType: objectType, Name: name, Namespace: "bookinfo", Cluster: "east"}, v
+	}
+	validations := IstioValidations{}
+	for _, v := range []struct {
+		objectType string
+		name       string
+		severities []SeverityLevel
+	}{
+		{"virtualservice", "reviews", []SeverityLevel{WarningSeverity, ErrorSeverity}},
+		{"virtualservice", "ratings", []SeverityLevel{WarningSeverity}},
+		{"virtualservice", "details", nil},
+		{"k8shttproute", "productpage", []SeverityLevel{ErrorSeverity}},
+	} {
+		key, validation := validation(v.objectType, v.name, v.severities...)
+		validations[key] = validation
+	}
+
+	newList := func() *IstioConfigList {
+		list := &IstioConfigList{}
+		for _, name := range []string{"reviews", "ratings", "details", "unvalidated"} {
+			list.VirtualServices = append(list.VirtualServices, &networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo"}})
+		}
+		list.K8sHTTPRoutes = append(list.K8sHTTPRoutes, &k8s_networking_v1.HTTPRoute{ObjectMeta: meta_v1.ObjectMeta{Name: "productpage", Namespace: "bookinfo"}})
+		return list
+	}
+
+	list := newList()
+	list.FilterByValidationSeverity(validations, "east", ErrorSeverity)
+	assert.Len(list.VirtualServices, 1)
+	assert.Equal("reviews", list.VirtualServices[0].Name)
+	assert.Len(list.K8sHTTPRoutes, 1)
+	assert.Empty(list.Gateways)
+
+	list = newList()
+	list.FilterByValidationSeverity(validations, "east", WarningSeverity)
+	assert.Len(list.VirtualServices, 1)
+	assert.Equal("ratings", list.VirtualServices[0].Name)
+	assert.Empty(list.K8sHTTPRoutes)
+
+	// the validations of another cluster
+	list = newList()
+	list.FilterByValidationSeverity(validations, "west", ErrorSeverity)
+	assert.Empty(list.VirtualServices)
+}
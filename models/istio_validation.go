@@ -83,6 +83,40 @@ type IstioValidation struct {
 	References []IstioValidationKey `json:"references"`
 }
 
+// IstioObjectValidation is the validation of an Istio object before it is persisted: the errors of the API
+// server dry run and the Kiali checks of the object.
+// swagger:model
+type IstioObjectValidation struct {
+	// False when the API server rejects the object or a Kiali check is an error
+	// required: true
+	// example: false
+	Valid bool `json:"valid"`
+
+	// Errors of the API server dry run: invalid fields and denials of the admission webhooks
+	// required: true
+	AdmissionErrors []AdmissionError `json:"admissionErrors"`
+
+	// Kiali checks of the object, only run when the API server accepts the object
+	// required: true
+	Checks []*IstioCheck `json:"checks"`
+}
+
+// AdmissionError is an error of the API server rejecting an object
+type AdmissionError struct {
+	// Path of the field in error, empty when the error is not about a field
+	// example: spec.http[0].route
+	Field string `json:"field,omitempty"`
+
+	// Description of the error
+	// required: true
+	// example: admission webhook "validation.istio.io" denied the request: total destination weight 90 != 100
+	Message string `json:"message"`
+
+	// Reason of the error
+	// example: FieldValueInvalid
+	Reason string `json:"reason,omitempty"`
+}
+
 // IstioCheck represents an individual check.
 // swagger:model
 type IstioCheck struct {
@@ -111,6 +145,7 @@ type SeverityLevel string
 const (
 	ErrorSeverity   SeverityLevel = "error"
 	WarningSeverity SeverityLevel = "warning"
+	InfoSeverity    SeverityLevel = "info"
 	Unknown         SeverityLevel = "unknown"
 )
 
@@ -130,7 +165,10 @@ var ObjectTypeSingular = map[string]string{
 	"sidecars":               "sidecar",
 	"peerauthentications":    "peerauthentication",
 	"requestauthentications": "requestauthentication",
-	"workloads":              "workload",
+	"services":               ReferenceTypeService,
+	"workloads":              ReferenceTypeWorkload,
+	"workloadentries":        "workloadentry",
+	"workloadgroups":         "workloadgroup",
 	"wasmplugins":            "wasmpluin",
 	"telemetries":            "telemetry",
 	"k8sgateways":            "k8sgateway",
@@ -157,6 +195,31 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Service Account for this principal is on remote cluster",
 		Severity: WarningSeverity,
 	},
+	"authorizationpolicy.source.unknowntrustdomain": {
+		Code:     "KIA0108",
+		Message:  "Trust domain of this principal is not known by the mesh",
+		Severity: WarningSeverity,
+	},
+	"authorizationpolicy.source.staletrustdomain": {
+		Code:     "KIA0109",
+		Message:  "Trust domain of this principal is only kept as an alias of the mesh trust domain",
+		Severity: WarningSeverity,
+	},
+	"authorizationpolicy.requestauth.notfound": {
+		Code:     "KIA0110",
+		Message:  "No RequestAuthentication applies to the workloads of this policy: the request will never be authenticated",
+		Severity: WarningSeverity,
+	},
+	"authorizationpolicy.targetref.selector": {
+		Code:     "KIA0111",
+		Message:  "Selector and targetRef can not be used together, the policy is rejected",
+		Severity: ErrorSeverity,
+	},
+	"authorizationpolicy.targetref.notfound": {
+		Code:     "KIA0112",
+		Message:  "The object this policy targets is not found",
+		Severity: WarningSeverity,
+	},
 	"authorizationpolicy.to.wrongmethod": {
 		Code:     "KIA0102",
 		Message:  "Only HTTP methods and fully-qualified gRPC names are allowed",
@@ -217,6 +280,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This subset has not labels",
 		Severity: WarningSeverity,
 	},
+	"destinationrules.localitylb.nooutlierdetection": {
+		Code:     "KIA0210",
+		Message:  "Locality failover requires an outlier detection, the requests are not failed over without it",
+		Severity: WarningSeverity,
+	},
 	"gateways.multimatch": {
 		Code:     "KIA0301",
 		Message:  "More than one Gateway for the same host port combination",
@@ -312,11 +380,56 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Port name must follow <protocol>[-suffix] form",
 		Severity: ErrorSeverity,
 	},
+	"requestauthentications.jwtrules.invalidjwksuri": {
+		Code:     "KIA1701",
+		Message:  "jwksUri must be an absolute http or https URL",
+		Severity: ErrorSeverity,
+	},
+	"requestauthentications.jwtrules.jwksuriunreachable": {
+		Code:     "KIA1702",
+		Message:  "jwksUri can't be reached from Kiali",
+		Severity: InfoSeverity,
+	},
+	"requestauthentications.jwtrules.duplicatedissuer": {
+		Code:     "KIA1703",
+		Message:  "More than one JWT rule with this issuer applies to the same workload",
+		Severity: WarningSeverity,
+	},
 	"service.deployment.port.mismatch": {
 		Code:     "KIA0701",
 		Message:  "Deployment exposing same port as Service not found",
 		Severity: WarningSeverity,
 	},
+	"service.eastwestgateway.notfound": {
+		Code:     "KIA0707",
+		Message:  "No reachable east-west gateway exposes the Service imported from a remote network",
+		Severity: WarningSeverity,
+	},
+	"service.health.annotation.invalid": {
+		Code:     "KIA0702",
+		Message:  "Malformed health annotation, its malformed rules are ignored",
+		Severity: WarningSeverity,
+	},
+	"service.health.annotation.port.notfound": {
+		Code:     "KIA0703",
+		Message:  "Port of the health rule not found in the Service ports",
+		Severity: WarningSeverity,
+	},
+	"service.route.port.notfound": {
+		Code:     "KIA0704",
+		Message:  "Target port of the OpenShift Route not found in the Service ports",
+		Severity: WarningSeverity,
+	},
+	"service.route.tls.mismatch": {
+		Code:     "KIA0705",
+		Message:  "TLS termination of the OpenShift Route not compatible with the Gateway servers of the port",
+		Severity: WarningSeverity,
+	},
+	"service.route.passthrough.virtualservice.notfound": {
+		Code:     "KIA0706",
+		Message:  "No VirtualService routes the TLS traffic of the passthrough OpenShift Route through the Gateway",
+		Severity: WarningSeverity,
+	},
 	"serviceentries.workloadentries.addressmatch": {
 		Code:     "KIA1201",
 		Message:  "Missing one or more addresses from matching WorkloadEntries",
@@ -377,6 +490,16 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This workload is not covered by any authorization policy",
 		Severity: WarningSeverity,
 	},
+	"workload.trustbundle.expiring": {
+		Code:     "KIA1302",
+		Message:  "The root certificates trusted by this workload are about to expire",
+		Severity: WarningSeverity,
+	},
+	"workload.proxy.concurrencymismatch": {
+		Code:     "KIA1303",
+		Message:  "The proxy concurrency doesn't match its CPU limit",
+		Severity: WarningSeverity,
+	},
 }
 
 func Build(checkId string, path string) IstioCheck {
@@ -445,6 +568,20 @@ func (iv IstioValidations) FilterByTypes(objectTypes []string) IstioValidations
 	return fiv
 }
 
+// Severity returns the most severe level of the checks, empty when the object has no error or warning
+func (iv IstioValidation) Severity() SeverityLevel {
+	var severity SeverityLevel
+	for _, c := range iv.Checks {
+		if c.Severity == ErrorSeverity {
+			return ErrorSeverity
+		}
+		if c.Severity == WarningSeverity {
+			severity = WarningSeverity
+		}
+	}
+	return severity
+}
+
 func (iv IstioValidations) MergeValidations(validations IstioValidations) IstioValidations {
 	for key, validation := range validations {
 		v, ok := iv[key]
@@ -527,6 +664,28 @@ func (iv IstioValidations) MarshalJSON() ([]byte, error) {
 	return json.Marshal(out)
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface, the validations are keyed by their object type, name,
+// namespace and cluster.
+func (iv *IstioValidations) UnmarshalJSON(b []byte) error {
+	in := make(map[string]map[string]*IstioValidation)
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	*iv = make(IstioValidations)
+	for objectType, validations := range in {
+		for _, v := range validations {
+			if v == nil {
+				continue
+			}
+			if v.ObjectType == "" {
+				v.ObjectType = objectType
+			}
+			(*iv)[IstioValidationKey{ObjectType: v.ObjectType, Name: v.Name, Namespace: v.Namespace, Cluster: v.Cluster}] = v
+		}
+	}
+	return nil
+}
+
 func (iv *IstioValidations) StripIgnoredChecks() {
 	// strip away codes that are to be ignored
 	codesToIgnore := config.Get().KialiFeatureFlags.Validations.Ignore
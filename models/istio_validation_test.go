@@ -29,6 +29,27 @@ func TestIstioValidationsMarshal(t *testing.T) {
 	assert.Equal(string(b), `{"virtualservice":{"bar.test2":{"name":"bar","namespace":"","cluster":"","objectType":"virtualservice","valid":false,"checks":null,"references":null},"foo.test":{"name":"foo","namespace":"","cluster":"","objectType":"virtualservice","valid":true,"checks":null,"references":null}}}`)
 }
 
+func TestIstioValidationsUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := IstioValidations{
+		IstioValidationKey{ObjectType: "virtualservice", Name: "foo", Namespace: "test", Cluster: "east"}: &IstioValidation{
+			Name:       "foo",
+			Namespace:  "test",
+			Cluster:    "east",
+			ObjectType: "virtualservice",
+			Valid:      false,
+			Checks:     []*IstioCheck{{Code: "KIA1101", Message: "DestinationWeight on route doesn't have a valid service", Severity: ErrorSeverity}},
+		},
+	}
+	b, err := json.Marshal(validations)
+	assert.NoError(err)
+
+	var unmarshalled IstioValidations
+	assert.NoError(json.Unmarshal(b, &unmarshalled))
+	assert.Equal(validations, unmarshalled)
+}
+
 func TestIstioValidationKeyMarshal(t *testing.T) {
 	assert := assert.New(t)
 
@@ -10,6 +10,40 @@ import (
 type Mesh struct {
 	// ControlPlanes that share the same mesh ID.
 	ControlPlanes []ControlPlane
+
+	// EastWestGateways expose the services of their cluster to the clusters of the other networks of the mesh.
+	EastWestGateways []EastWestGateway
+
+	// RevisionTags are the revisions the revision tags point to, per cluster and tag.
+	RevisionTags map[string]map[string]string
+}
+
+// EastWestGateway is a gateway exposing the services of its cluster to the other networks of the mesh,
+// discovered by the labels of its service.
+type EastWestGateway struct {
+	// Addresses are the external addresses of the service of the gateway: the ingress of its load balancer
+	// and its external IPs. The gateway is not reachable from the other networks without any.
+	Addresses []string `json:"addresses"`
+
+	// Cluster is the name of the cluster the gateway is running on.
+	Cluster string `json:"cluster"`
+
+	// Name is the name of the service of the gateway.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the service of the gateway.
+	Namespace string `json:"namespace"`
+
+	// Network is the network the gateway exposes the services of.
+	Network string `json:"network"`
+
+	// Ports are the ports of the service of the gateway.
+	Ports []int32 `json:"ports"`
+}
+
+// IsReachable returns whether the gateway has an address reachable from the other networks.
+func (gw EastWestGateway) IsReachable() bool {
+	return len(gw.Addresses) > 0
 }
 
 // ControlPlane manages the dataPlane for one or more kube clusters.
@@ -28,6 +62,9 @@ type ControlPlane struct {
 	// ID is the control plane ID as known by istiod.
 	ID string
 
+	// Install is how the controlplane was installed and whether istiod runs the version it was installed at.
+	Install *ControlPlaneInstall
+
 	// IstiodName is the control plane name
 	IstiodName string
 
@@ -50,6 +87,40 @@ type ControlPlane struct {
 	Version *ExternalServiceInfo
 }
 
+const (
+	// InstallMethodHelm is a controlplane installed with the istiod Helm chart.
+	InstallMethodHelm = "Helm"
+	// InstallMethodIstioctl is a controlplane installed with istioctl or the in-cluster Istio operator.
+	InstallMethodIstioctl = "Istioctl"
+	// InstallMethodSailOperator is a controlplane managed by the Sail operator through an IstioRevision.
+	InstallMethodSailOperator = "SailOperator"
+	// InstallMethodUnknown is a controlplane with no ownership labels.
+	InstallMethodUnknown = "Unknown"
+)
+
+// ControlPlaneInstall is how a controlplane was installed.
+type ControlPlaneInstall struct {
+	// Method is the tool managing the controlplane: Helm, Istioctl, SailOperator or Unknown.
+	Method string `json:"method"`
+
+	// Owner is the resource managing the controlplane: the IstioRevision of the Sail operator, or the Helm release.
+	// example: default-v1-24-1
+	Owner string `json:"owner,omitempty"`
+
+	// DesiredVersion is the version the controlplane was installed at: the version of the IstioRevision, of the
+	// Helm chart or of istioctl. Empty when unknown.
+	// example: 1.24.1
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+
+	// ImageVersion is the version of the istiod image, from its tag. Empty when the image is referenced by digest.
+	// example: 1.24.0
+	ImageVersion string `json:"imageVersion,omitempty"`
+
+	// VersionSkew is true when istiod doesn't run the version the controlplane was installed at, e.g. with an
+	// overridden image or a stuck rollout.
+	VersionSkew bool `json:"versionSkew"`
+}
+
 // ControlPlaneConfiguration is the configuration for the controlPlane and any associated dataPlane.
 type ControlPlaneConfiguration struct {
 	// IsGatewayToNamespace specifies the PILOT_SCOPE_GATEWAY_TO_NAMESPACE environment variable in Control Plane
@@ -65,3 +136,80 @@ type ControlPlaneConfiguration struct {
 	// IstioMeshConfig comes from the istio configmap.
 	kubernetes.IstioMeshConfig
 }
+
+const (
+	// MeshHealthy means every component of the mesh is healthy.
+	MeshHealthy = "Healthy"
+	// MeshDegraded means some components of the mesh are not healthy
+	// but every controlplane has at least one healthy istiod.
+	MeshDegraded = "Degraded"
+	// MeshUnhealthy means at least one controlplane has no healthy istiod.
+	MeshUnhealthy = "Unhealthy"
+)
+
+// MeshComponentStatus is the status of the istio components grouped per mesh.
+type MeshComponentStatus struct {
+	// Meshes found across all the clusters.
+	Meshes []MeshStatus `json:"meshes"`
+
+	// Addons are the status of the addons e.g. prometheus, grafana...
+	// They are not part of any particular mesh.
+	Addons kubernetes.IstioComponentStatus `json:"addons"`
+}
+
+// MeshStatus is the status of the components of the controlplanes sharing the same mesh ID.
+type MeshStatus struct {
+	// MeshID is the ID of the mesh.
+	MeshID string `json:"meshId"`
+
+	// Grade is the overall health of the mesh. One of Healthy, Degraded or Unhealthy.
+	Grade string `json:"grade"`
+
+	// ControlPlanes are the controlplanes of the mesh, one per cluster and revision.
+	ControlPlanes []ControlPlaneComponentStatus `json:"controlPlanes"`
+}
+
+// ControlPlaneComponentStatus is the status of a controlplane and the gateways/waypoints it manages.
+type ControlPlaneComponentStatus struct {
+	// Cluster is the name of the cluster the controlplane is running on.
+	Cluster string `json:"cluster"`
+
+	// Revision is the revision of the controlplane.
+	Revision string `json:"revision"`
+
+	// Istiod is the status of the istiod pods of the controlplane.
+	Istiod kubernetes.IstioComponentStatus `json:"istiod"`
+
+	// Gateways are the ingress/egress gateways using this revision.
+	Gateways kubernetes.IstioComponentStatus `json:"gateways"`
+
+	// Waypoints are the waypoint proxies using this revision.
+	Waypoints kubernetes.IstioComponentStatus `json:"waypoints"`
+}
+
+// ComputeGrade grades the mesh according to the status of its components.
+func (ms *MeshStatus) ComputeGrade() {
+	ms.Grade = MeshHealthy
+	for _, cp := range ms.ControlPlanes {
+		healthyIstiod := false
+		for _, istiod := range cp.Istiod {
+			if istiod.Status == kubernetes.ComponentHealthy {
+				healthyIstiod = true
+			} else {
+				ms.Grade = MeshDegraded
+			}
+		}
+		if !healthyIstiod {
+			ms.Grade = MeshUnhealthy
+			return
+		}
+
+		for _, components := range []kubernetes.IstioComponentStatus{cp.Gateways, cp.Waypoints} {
+			for _, c := range components {
+				if c.Status != kubernetes.ComponentHealthy {
+					ms.Grade = MeshDegraded
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+// Results of the checks of the mesh readiness of a namespace.
+const (
+	// MeshReadinessPass means the namespace meets the check.
+	MeshReadinessPass = "Pass"
+	// MeshReadinessFail means the namespace does not meet the check, the hint tells how to fix it.
+	MeshReadinessFail = "Fail"
+	// MeshReadinessSkip means the check does not apply to the namespace, like the sidecar checks of an ambient namespace.
+	MeshReadinessSkip = "Skip"
+)
+
+// Checks of the mesh readiness of a namespace.
+const (
+	MeshReadinessEnrollment    = "enrollment"
+	MeshReadinessTelemetry     = "telemetry"
+	MeshReadinessDefaultDeny   = "defaultDeny"
+	MeshReadinessMTLS          = "mtls"
+	MeshReadinessSidecarConfig = "sidecarConfig"
+)
+
+// MeshReadiness is the checklist evaluating whether a namespace is ready to be onboarded into the mesh.
+type MeshReadiness struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+
+	// Ready is true when no check fails
+	// required: true
+	Ready bool `json:"ready"`
+
+	// Checks of the namespace, in the order of the onboarding
+	// required: true
+	Checks []MeshReadinessCheck `json:"checks"`
+}
+
+// MeshReadinessCheck is a single check of the mesh readiness of a namespace.
+type MeshReadinessCheck struct {
+	// Id of the check: enrollment, telemetry, defaultDeny, mtls or sidecarConfig
+	// required: true
+	ID string `json:"id"`
+
+	// Result of the check: Pass, Fail or Skip
+	// required: true
+	Status string `json:"status"`
+
+	// What the check found
+	// required: true
+	Message string `json:"message"`
+
+	// How to fix the namespace, when the check fails
+	Hint string `json:"hint,omitempty"`
+
+	// Objects the check is based on
+	References []IstioValidationKey `json:"references,omitempty"`
+}
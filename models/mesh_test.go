@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestMeshStatusComputeGrade(t *testing.T) {
+	assert := assert.New(t)
+
+	healthy := kubernetes.IstioComponentStatus{{Name: "istiod", Status: kubernetes.ComponentHealthy}}
+	unhealthy := kubernetes.IstioComponentStatus{{Name: "istiod", Status: kubernetes.ComponentUnreachable}}
+
+	ms := MeshStatus{ControlPlanes: []ControlPlaneComponentStatus{{Istiod: healthy, Gateways: healthy}}}
+	ms.ComputeGrade()
+	assert.Equal(MeshHealthy, ms.Grade)
+
+	// An unhealthy gateway degrades the mesh.
+	ms = MeshStatus{ControlPlanes: []ControlPlaneComponentStatus{{Istiod: healthy, Gateways: unhealthy}}}
+	ms.ComputeGrade()
+	assert.Equal(MeshDegraded, ms.Grade)
+
+	// As long as one istiod replica is healthy the controlplane keeps working.
+	ms = MeshStatus{ControlPlanes: []ControlPlaneComponentStatus{{Istiod: append(healthy, unhealthy...)}}}
+	ms.ComputeGrade()
+	assert.Equal(MeshDegraded, ms.Grade)
+
+	// A controlplane without any healthy istiod makes the whole mesh unhealthy.
+	ms = MeshStatus{ControlPlanes: []ControlPlaneComponentStatus{{Istiod: healthy}, {Istiod: unhealthy}}}
+	ms.ComputeGrade()
+	assert.Equal(MeshUnhealthy, ms.Grade)
+}
@@ -29,6 +29,7 @@ type IstioMetricsQuery struct {
 	Reporter        string // source | destination | both, defaults to source if not provided
 	Aggregate       string
 	AggregateValue  string
+	ByFlags         bool // group the request counts by Envoy response flags (UH, UF, NR...)
 }
 
 // FillDefaults fills the struct with default parameters
@@ -10,4 +10,53 @@ type MTLSStatus struct {
 	// required: true
 	// example: MTLS_ENABLED
 	Status string `json:"status"`
+	// Workload name, only set for the workload-wide status
+	Workload string `json:"workload,omitempty"`
+	// mTLS status of the workload ports that override the workload-wide status, indexed by port number
+	PortLevel map[uint32]string `json:"portLevel,omitempty"`
+}
+
+// PermissiveTrafficReport tells which workloads of a namespace that still accept plaintext traffic
+// actually receive it, according to the telemetry of the destination proxies.
+type PermissiveTrafficReport struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	// Interval the request rates were computed over
+	// example: 10m
+	RateInterval string `json:"rateInterval"`
+	// Workloads whose mTLS status allows plaintext traffic
+	Workloads []PermissiveWorkloadTraffic `json:"workloads"`
+}
+
+// PermissiveWorkloadTraffic is the inbound traffic of a workload that accepts plaintext traffic
+type PermissiveWorkloadTraffic struct {
+	Name string `json:"name"`
+	// mTLS status of the workload: MTLS_PARTIALLY_ENABLED, MTLS_NOT_ENABLED
+	MTLSStatus string `json:"mtlsStatus"`
+	// Inbound requests per second received over mTLS
+	MTLSRate float64 `json:"mtlsRate"`
+	// Inbound requests per second received in plaintext
+	PlaintextRate float64 `json:"plaintextRate"`
+	// Inbound TCP bytes per second received over mTLS
+	MTLSTCPRate float64 `json:"mtlsTcpRate"`
+	// Inbound TCP bytes per second received in plaintext
+	PlaintextTCPRate float64 `json:"plaintextTcpRate"`
+	// Clients sending plaintext requests or TCP traffic, with the highest request rate first
+	PlaintextSources []PlaintextSource `json:"plaintextSources"`
+	// True when no traffic at all was reported during the interval, so whether STRICT mTLS can be enforced is unknown
+	NoTraffic bool `json:"noTraffic"`
+	// True when traffic was received during the interval, none of it in plaintext, so STRICT mTLS can be enforced
+	ReadyForStrict bool `json:"readyForStrict"`
+}
+
+// PlaintextSource is a client sending plaintext requests or TCP traffic to a workload
+type PlaintextSource struct {
+	// Namespace of the client, "unknown" for clients outside of the mesh
+	Namespace string `json:"namespace"`
+	// Workload of the client, "unknown" for clients outside of the mesh
+	Workload string `json:"workload"`
+	// Plaintext requests per second
+	Rate float64 `json:"rate"`
+	// Plaintext TCP bytes per second, a plaintext TCP client that sent nothing yet has none
+	TCPRate float64 `json:"tcpRate,omitempty"`
 }
@@ -0,0 +1,60 @@
+package models
+
+// The filters deciding the visibility of a namespace, in the order they are applied.
+const (
+	// NamespaceFilterAccessible is the deployment.accessible_namespaces of the Kiali config.
+	NamespaceFilterAccessible = "accessibleNamespaces"
+	// NamespaceFilterInclude is the api.namespaces.include list and label_selector_include of the Kiali config.
+	NamespaceFilterInclude = "include"
+	// NamespaceFilterMemberRoll is the ServiceMeshMemberRoll of an OpenShift Service Mesh 2 control plane.
+	NamespaceFilterMemberRoll = "memberRoll"
+	// NamespaceFilterDiscoverySelectors are the discovery selectors of the Istio mesh config.
+	NamespaceFilterDiscoverySelectors = "discoverySelectors"
+	// NamespaceFilterExclude is the api.namespaces.exclude list and label_selector_exclude of the Kiali config.
+	NamespaceFilterExclude = "exclude"
+	// NamespaceFilterRBAC is the access of the token of the user to the namespace.
+	NamespaceFilterRBAC = "rbac"
+)
+
+// NamespaceAccessAudit reports for the current user which namespaces of a cluster are visible and which are filtered
+// out, with the reasons.
+type NamespaceAccessAudit struct {
+	// required: true
+	Cluster string `json:"cluster"`
+
+	// The namespaces visible to the user
+	// required: true
+	Visible []NamespaceAccess `json:"visible"`
+
+	// The namespaces of the cluster filtered out
+	// required: true
+	Filtered []NamespaceAccess `json:"filtered"`
+
+	// Error listing the namespaces of the cluster, the audit is then partial
+	Error string `json:"error,omitempty"`
+}
+
+// NamespaceAccess is the visibility of a namespace with the decisions of the filters.
+type NamespaceAccess struct {
+	// required: true
+	Name string `json:"name"`
+
+	// The decision of each filter applying to the namespace
+	// required: true
+	Reasons []NamespaceAccessReason `json:"reasons"`
+}
+
+// NamespaceAccessReason is the decision of a filter for a namespace.
+type NamespaceAccessReason struct {
+	// accessibleNamespaces, include, memberRoll, discoverySelectors, exclude or rbac
+	// required: true
+	Filter string `json:"filter"`
+
+	// Whether the filter keeps the namespace
+	// required: true
+	Allowed bool `json:"allowed"`
+
+	// required: true
+	// example: matches api.namespaces.exclude [^kube-.*]
+	Message string `json:"message"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ConfigChangeNotification is a change of an Istio or Gateway API object, posted to the notification targets.
+type ConfigChangeNotification struct {
+	// Action of the change: Added, Updated or Deleted
+	// example: Updated
+	Action string `json:"action"`
+
+	Cluster   string `json:"cluster"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// User is the field manager of the latest write of the object, e.g. kubectl-edit. Unknown for a deletion.
+	User string `json:"user,omitempty"`
+
+	// Time the change was seen by Kiali
+	Time time.Time `json:"time"`
+
+	// ChangedFields are the changed fields of an update, e.g. spec.http or metadata.labels
+	ChangedFields []string `json:"changedFields,omitempty"`
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	core_v1 "k8s.io/api/core/v1"
 
@@ -35,6 +36,21 @@ type Pod struct {
 	Annotations         map[string]string `json:"annotations"`
 	ProxyStatus         *ProxyStatus      `json:"proxyStatus"`
 	ServiceAccountName  string            `json:"serviceAccountName"`
+	// Restarts is the number of restarts of the containers of the pod.
+	Restarts int32 `json:"restarts"`
+	// ResourceUsage is the current resource usage of the pod, when metrics-server is enabled.
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
+	// ProxyResources are the resources and the concurrency of the istio-proxy container, when the pod has one.
+	ProxyResources *ProxyResources `json:"proxyResources,omitempty"`
+
+	// Resource requests and limits of the containers, for the resource utilization.
+	resources podResources
+
+	// Conditions of the pod used by the workload health.
+	lastRestart   time.Time
+	lastOOMKill   time.Time
+	crashLooping  bool
+	unschedulable bool
 }
 
 // Reference holds some information on the pod creator
@@ -131,6 +147,36 @@ func (pod *Pod) Parse(p *core_v1.Pod) {
 	_, pod.AppLabel = p.Labels[conf.IstioLabels.AppLabelName]
 	_, pod.VersionLabel = p.Labels[conf.IstioLabels.VersionLabelName]
 	pod.ServiceAccountName = p.Spec.ServiceAccountName
+	pod.parseConditions(p)
+	pod.parseResources(p)
+	pod.parseProxyResources(p)
+}
+
+// parseConditions extracts the restarts of the containers and the conditions preventing the pod from running.
+func (pod *Pod) parseConditions(p *core_v1.Pod) {
+	for _, s := range p.Status.ContainerStatuses {
+		pod.Restarts += s.RestartCount
+		if s.State.Waiting != nil && s.State.Waiting.Reason == "CrashLoopBackOff" {
+			pod.crashLooping = true
+		}
+		// A container still terminated has not been restarted yet
+		for _, terminated := range []*core_v1.ContainerStateTerminated{s.LastTerminationState.Terminated, s.State.Terminated} {
+			if terminated == nil {
+				continue
+			}
+			if s.RestartCount > 0 && terminated.FinishedAt.After(pod.lastRestart) {
+				pod.lastRestart = terminated.FinishedAt.Time
+			}
+			if terminated.Reason == "OOMKilled" && terminated.FinishedAt.After(pod.lastOOMKill) {
+				pod.lastOOMKill = terminated.FinishedAt.Time
+			}
+		}
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type == core_v1.PodScheduled && c.Status == core_v1.ConditionFalse && c.Reason == core_v1.PodReasonUnschedulable {
+			pod.unschedulable = true
+		}
+	}
 }
 
 func isIstioProxy(pod *core_v1.Pod, container *core_v1.Container, conf *config.Config) bool {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kiali/kiali/config"
@@ -127,6 +128,52 @@ func TestPodParsingInvalidAnnotations(t *testing.T) {
 	assert.Len(pod.IstioInitContainers, 0)
 }
 
+func TestPodProxyResourcesParsing(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	k8sPod := core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "reviews-v1-1234",
+			Annotations: map[string]string{
+				"sidecar.istio.io/proxyCPULimit": "500m",
+				"sidecar.istio.io/inject":        "true",
+			},
+		},
+		Spec: core_v1.PodSpec{
+			Containers: []core_v1.Container{{Name: "reviews"}},
+			// Native sidecar
+			InitContainers: []core_v1.Container{{
+				Name: IstioProxy,
+				Args: []string{"proxy", "sidecar", "--concurrency=4"},
+				Resources: core_v1.ResourceRequirements{
+					Requests: core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("100m"), core_v1.ResourceMemory: resource.MustParse("128Mi")},
+					Limits:   core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("500m"), core_v1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+		},
+	}
+
+	pod := Pod{}
+	pod.Parse(&k8sPod)
+	assert.NotNil(pod.ProxyResources)
+	assert.Equal(4, *pod.ProxyResources.Concurrency)
+	assert.Equal("100m", pod.ProxyResources.CPURequest)
+	assert.Equal("500m", pod.ProxyResources.CPULimit)
+	assert.Equal("128Mi", pod.ProxyResources.MemoryRequest)
+	assert.Equal("1Gi", pod.ProxyResources.MemoryLimit)
+	assert.Equal(map[string]string{"sidecar.istio.io/proxyCPULimit": "500m"}, pod.ProxyResources.Annotations)
+	// 4 worker threads for a single core
+	assert.True(pod.ProxyResources.ConcurrencyMismatch())
+
+	// No proxy
+	k8sPod.Spec.InitContainers = nil
+	pod = Pod{}
+	pod.Parse(&k8sPod)
+	assert.Nil(pod.ProxyResources)
+	assert.False(pod.ProxyResources.ConcurrencyMismatch())
+}
+
 func TestSyncedPodProxiesCount(t *testing.T) {
 	assert := assert.New(t)
 	config.Set(config.NewConfig())
@@ -183,6 +230,66 @@ func TestSyncedPodProxiesCount(t *testing.T) {
 	assert.Equal(int32(-1), pods.SyncedPodProxiesCount())
 }
 
+func TestPodsStatusSince(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	now := time.Now()
+	since := now.Add(-10 * time.Minute)
+	restartedAt := func(ago time.Duration, reason string) core_v1.ContainerState {
+		return core_v1.ContainerState{Terminated: &core_v1.ContainerStateTerminated{Reason: reason, FinishedAt: meta_v1.NewTime(now.Add(-ago))}}
+	}
+
+	k8sPods := []core_v1.Pod{
+		// Crashing within the window
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "crashing"},
+			Status: core_v1.PodStatus{ContainerStatuses: []core_v1.ContainerStatus{{
+				Name:                 "details",
+				RestartCount:         5,
+				State:                core_v1.ContainerState{Waiting: &core_v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: restartedAt(time.Minute, "OOMKilled"),
+			}}},
+		},
+		// Restarted long ago
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "restarted"},
+			Status: core_v1.PodStatus{ContainerStatuses: []core_v1.ContainerStatus{{
+				Name:                 "details",
+				RestartCount:         2,
+				State:                core_v1.ContainerState{Running: &core_v1.ContainerStateRunning{}},
+				LastTerminationState: restartedAt(time.Hour, "OOMKilled"),
+			}}},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "pending"},
+			Status: core_v1.PodStatus{
+				Phase:      core_v1.PodPending,
+				Conditions: []core_v1.PodCondition{{Type: core_v1.PodScheduled, Status: core_v1.ConditionFalse, Reason: core_v1.PodReasonUnschedulable}},
+			},
+		},
+	}
+
+	pods := Pods{}
+	pods.Parse(k8sPods)
+	assert.Equal(int32(5), pods[0].Restarts)
+	assert.Equal(int32(2), pods[1].Restarts)
+
+	status := pods.StatusSince(since)
+	assert.Equal(int32(5), status.Restarts)
+	assert.Equal(int32(1), status.CrashLoopBackOff)
+	assert.Equal(int32(1), status.OOMKilled)
+	assert.Equal(int32(1), status.Unschedulable)
+	assert.False(status.IsHealthy())
+
+	// Out of the window only the current conditions remain
+	status = pods[1:2].StatusSince(since)
+	assert.True(status.IsHealthy())
+	status = pods[1:2].StatusSince(now.Add(-2 * time.Hour))
+	assert.Equal(int32(2), status.Restarts)
+	assert.Equal(int32(1), status.OOMKilled)
+}
+
 func TestServiceNames(t *testing.T) {
 	pods := Pods{
 		{
@@ -0,0 +1,25 @@
+package models
+
+// UserPreferences are the preferences of a Kiali user, persisted server side so that they follow the user
+// across browsers and Kiali replicas.
+type UserPreferences struct {
+	// NamespaceSets are the bookmarked sets of namespaces, by name
+	// example: {"bookinfo": ["bookinfo", "bookinfo-db"]}
+	NamespaceSets map[string][]string `json:"namespaceSets,omitempty"`
+
+	// Duration is the default duration of the metrics and graphs, in seconds
+	// example: 600
+	Duration int64 `json:"duration,omitempty"`
+
+	// RefreshInterval is the default refresh interval of the pages, in milliseconds
+	// example: 15000
+	RefreshInterval int64 `json:"refreshInterval,omitempty"`
+
+	// GraphSettings are the default display options of the graph, as the UI query params e.g. edgeLabels, boxBy...
+	// example: {"edgeLabels": "responseTime", "boxBy": "app"}
+	GraphSettings map[string]string `json:"graphSettings,omitempty"`
+
+	// UpdatedAt is the last time the preferences were saved
+	// example: 2024-01-31T10:15:00Z
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
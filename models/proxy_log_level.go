@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProxyLogLevelReset is the pending reset of the proxy log level of the pods of a workload, once its TTL expires.
+type ProxyLogLevelReset struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload"`
+
+	// Levels restored, by pod
+	// example: {"reviews-v1-545db77b95-abcde": "warning"}
+	Levels map[string]string `json:"levels"`
+
+	// Time of the reset
+	// example: 2024-01-31T10:15:00Z
+	ResetAt time.Time `json:"resetAt"`
+}
+
+// Key identifies the reset of a workload.
+func (r ProxyLogLevelReset) Key() string {
+	return fmt.Sprintf("%s.%s.%s", r.Cluster, r.Namespace, r.Workload)
+}
+
+// WorkloadProxyLogLevel is the log level set to the proxies of the pods of a workload.
+type WorkloadProxyLogLevel struct {
+	// example: debug
+	Level string `json:"level"`
+
+	// Pods whose proxy log level was set
+	Pods []string `json:"pods"`
+
+	// Reset scheduled when a TTL was given
+	Reset *ProxyLogLevelReset `json:"reset,omitempty"`
+}
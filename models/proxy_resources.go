@@ -0,0 +1,128 @@
+package models
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+)
+
+// Annotations of a pod tuning the resources and the concurrency of its proxy
+var proxyResourceAnnotations = []string{
+	"sidecar.istio.io/proxyCPU",
+	"sidecar.istio.io/proxyCPULimit",
+	"sidecar.istio.io/proxyMemory",
+	"sidecar.istio.io/proxyMemoryLimit",
+	"proxy.istio.io/config",
+}
+
+// proxyConcurrencyMismatchFactor is how many times the Envoy worker threads can outnumber the cores of the CPU
+// limit of the proxy, or the other way around, before the concurrency is considered mismatched.
+const proxyConcurrencyMismatchFactor = 2
+
+// ProxyResources are the resources and the concurrency of the proxy container of a pod
+type ProxyResources struct {
+	// Number of Envoy worker threads, from the --concurrency argument of the proxy. Missing when it is not set,
+	// pilot-agent derives it from the CPU limit then. 0 runs a worker thread per core of the node.
+	// example: 2
+	Concurrency *int `json:"concurrency,omitempty"`
+
+	// CPU request of the proxy container
+	// example: 100m
+	CPURequest string `json:"cpuRequest,omitempty"`
+
+	// CPU limit of the proxy container
+	// example: 2
+	CPULimit string `json:"cpuLimit,omitempty"`
+
+	// Memory request of the proxy container
+	// example: 128Mi
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+
+	// Memory limit of the proxy container
+	// example: 1Gi
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+
+	// Annotations of the pod tuning the proxy resources (sidecar.istio.io/proxyCPU...) and its proxy config
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// CPU limit, in cores, zero when unset
+	cpuLimit float64
+}
+
+// parseProxyResources extracts the resources of the istio-proxy container, either a sidecar or a native sidecar
+// declared as an init container.
+func (pod *Pod) parseProxyResources(p *core_v1.Pod) {
+	containers := append(append([]core_v1.Container{}, p.Spec.Containers...), p.Spec.InitContainers...)
+	for _, c := range containers {
+		if c.Name != IstioProxy {
+			continue
+		}
+		resources := &ProxyResources{Concurrency: parseConcurrency(c.Args)}
+		if cpu, ok := c.Resources.Requests[core_v1.ResourceCPU]; ok {
+			resources.CPURequest = cpu.String()
+		}
+		if cpu, ok := c.Resources.Limits[core_v1.ResourceCPU]; ok {
+			resources.CPULimit = cpu.String()
+			resources.cpuLimit = cpu.AsApproximateFloat64()
+		}
+		if memory, ok := c.Resources.Requests[core_v1.ResourceMemory]; ok {
+			resources.MemoryRequest = memory.String()
+		}
+		if memory, ok := c.Resources.Limits[core_v1.ResourceMemory]; ok {
+			resources.MemoryLimit = memory.String()
+		}
+		for _, annotation := range proxyResourceAnnotations {
+			if value, ok := p.Annotations[annotation]; ok {
+				if resources.Annotations == nil {
+					resources.Annotations = map[string]string{}
+				}
+				resources.Annotations[annotation] = value
+			}
+		}
+		pod.ProxyResources = resources
+		return
+	}
+}
+
+// parseConcurrency returns the value of the --concurrency argument, nil when missing or invalid
+func parseConcurrency(args []string) *int {
+	for i, arg := range args {
+		var value string
+		switch {
+		case arg == "--concurrency" && i+1 < len(args):
+			value = args[i+1]
+		case strings.HasPrefix(arg, "--concurrency="):
+			value = strings.TrimPrefix(arg, "--concurrency=")
+		default:
+			continue
+		}
+		if concurrency, err := strconv.Atoi(value); err == nil {
+			return &concurrency
+		}
+		return nil
+	}
+	return nil
+}
+
+// ConcurrencyMismatch returns true when the proxy runs far more, or far fewer, Envoy worker threads than the cores
+// of its CPU limit: the extra threads are throttled, or the cores are never used. Running a worker thread per node
+// core (concurrency 0) with a CPU limit is a mismatch too. There is no mismatch without a concurrency or a limit.
+func (pr *ProxyResources) ConcurrencyMismatch() bool {
+	if pr == nil || pr.Concurrency == nil || pr.cpuLimit <= 0 {
+		return false
+	}
+	concurrency := *pr.Concurrency
+	if concurrency == 0 {
+		return true
+	}
+	cores := int(math.Ceil(pr.cpuLimit))
+	return concurrency > proxyConcurrencyMismatchFactor*cores || cores > proxyConcurrencyMismatchFactor*concurrency
+}
+
+// ProxyConcurrencyMismatch returns true when the proxy of any pod of the workload has a concurrency mismatched with
+// its CPU limit.
+func (workload WorkloadListItem) ProxyConcurrencyMismatch() bool {
+	return workload.proxyConcurrencyMismatch
+}
@@ -0,0 +1,42 @@
+package models
+
+// ProxyTapCapture is the metadata of the requests captured by the Envoy tap of the proxy of a pod.
+type ProxyTapCapture struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+
+	// Duration of the capture, in seconds
+	// example: 10
+	Duration int `json:"duration"`
+
+	// Traces captured, in the order they were received
+	Traces []ProxyTapTrace `json:"traces"`
+
+	// Truncated is true when more traces were captured than returned
+	Truncated bool `json:"truncated"`
+}
+
+// ProxyTapTrace is the metadata of a request and of its response captured by the Envoy tap. The headers carrying
+// credentials are redacted, the bodies are not captured.
+type ProxyTapTrace struct {
+	// example: GET
+	Method string `json:"method"`
+	// example: reviews:9080
+	Authority string `json:"authority"`
+	// example: /reviews/0
+	Path string `json:"path"`
+	// Status of the response, empty when there was no response
+	// example: 503
+	Status string `json:"status"`
+
+	// Addresses of the downstream connection, the client and the proxy
+	// example: 10.244.0.12:41234
+	SourceAddress string `json:"sourceAddress,omitempty"`
+	// example: 10.244.0.15:9080
+	DestinationAddress string `json:"destinationAddress,omitempty"`
+
+	RequestHeaders   map[string]string `json:"requestHeaders"`
+	ResponseHeaders  map[string]string `json:"responseHeaders"`
+	ResponseTrailers map[string]string `json:"responseTrailers,omitempty"`
+}
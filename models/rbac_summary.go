@@ -0,0 +1,51 @@
+package models
+
+// ServiceAccountSummary is the service account of a workload, its role bindings relevant to the mesh and the
+// AuthorizationPolicies referencing its identity.
+type ServiceAccountSummary struct {
+	// example: bookinfo-reviews
+	Name string `json:"name"`
+
+	// SPIFFE identity of the workloads running with the service account, in the trust domain of the mesh
+	// example: spiffe://cluster.local/ns/bookinfo/sa/bookinfo-reviews
+	Identity string `json:"identity"`
+
+	// RoleBindings granting the service account access to Istio, Gateway API or credential resources. Missing when
+	// the user can't list the role bindings.
+	RoleBindings []RoleBindingSummary `json:"roleBindings,omitempty"`
+
+	// AuthorizationPolicies whose principals match the identity
+	AuthorizationPolicies []PrincipalReference `json:"authorizationPolicies"`
+}
+
+// RoleBindingSummary is a role binding of a service account, with the resources relevant to the mesh its role
+// grants access to.
+type RoleBindingSummary struct {
+	// RoleBinding or ClusterRoleBinding
+	// example: RoleBinding
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Namespace of a RoleBinding, empty for a ClusterRoleBinding
+	Namespace string `json:"namespace,omitempty"`
+
+	// Role or ClusterRole
+	// example: ClusterRole
+	RoleKind string `json:"roleKind"`
+	RoleName string `json:"roleName"`
+
+	// Resources relevant to the mesh granted by the role, as <verbs> <group>/<resource>
+	// example: ["get,list secrets", "* networking.istio.io/*"]
+	MeshResources []string `json:"meshResources"`
+}
+
+// PrincipalReference is a principal of an AuthorizationPolicy.
+type PrincipalReference struct {
+	Policy IstioValidationKey `json:"policy"`
+
+	// Path of the principal in the policy, either a principal or a notPrincipal
+	// example: spec/rules[0]/from[0]/source/principals[0]
+	Path string `json:"path"`
+
+	// example: cluster.local/ns/bookinfo/sa/bookinfo-productpage
+	Principal string `json:"principal"`
+}
@@ -0,0 +1,65 @@
+package models
+
+// Object types of the references that are not Istio objects.
+const (
+	ReferenceTypeService  = "service"
+	ReferenceTypeWorkload = "workload"
+)
+
+// ReferenceIndex holds the references of the Istio objects visible from a namespace along with the reverse
+// references: for every Istio object, service or workload, the Istio objects referencing it.
+type ReferenceIndex struct {
+	// Version of the cached config the index was built from.
+	Version uint64
+
+	// References of every Istio object.
+	References IstioReferencesMap
+
+	// ReferencedBy are the Istio objects referencing each Istio object, service or workload.
+	ReferencedBy map[IstioReferenceKey][]IstioReference
+}
+
+// NewReferenceIndex indexes the references of the Istio objects and computes the reverse references.
+func NewReferenceIndex(version uint64, references IstioReferencesMap) *ReferenceIndex {
+	index := &ReferenceIndex{
+		Version:      version,
+		References:   references,
+		ReferencedBy: map[IstioReferenceKey][]IstioReference{},
+	}
+
+	for key, refs := range references {
+		if refs == nil {
+			continue
+		}
+		referencing := IstioReference{ObjectType: key.ObjectType, Name: key.Name, Namespace: key.Namespace}
+		for _, ref := range refs.ObjectReferences {
+			index.addReferencedBy(IstioReferenceKey{ObjectType: ref.ObjectType, Name: ref.Name, Namespace: ref.Namespace}, referencing)
+		}
+		for _, ref := range refs.ServiceReferences {
+			index.addReferencedBy(IstioReferenceKey{ObjectType: ReferenceTypeService, Name: ref.Name, Namespace: ref.Namespace}, referencing)
+		}
+		for _, ref := range refs.WorkloadReferences {
+			index.addReferencedBy(IstioReferenceKey{ObjectType: ReferenceTypeWorkload, Name: ref.Name, Namespace: ref.Namespace}, referencing)
+		}
+	}
+
+	return index
+}
+
+func (ri *ReferenceIndex) addReferencedBy(key IstioReferenceKey, referencing IstioReference) {
+	for _, ref := range ri.ReferencedBy[key] {
+		if ref == referencing {
+			return
+		}
+	}
+	ri.ReferencedBy[key] = append(ri.ReferencedBy[key], referencing)
+}
+
+// ReferenceImpact lists the Istio objects referencing an object, i.e. those that could break if it was deleted.
+type ReferenceImpact struct {
+	// Object the impact is computed for.
+	Object IstioReference `json:"object"`
+
+	// ReferencedBy are the Istio objects referencing the object.
+	ReferencedBy []IstioReference `json:"referencedBy"`
+}
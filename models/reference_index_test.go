@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReferenceIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	vsKey := IstioReferenceKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"}
+	apKey := IstioReferenceKey{ObjectType: "authorizationpolicy", Name: "allow-reviews", Namespace: "bookinfo"}
+	gw := IstioReference{ObjectType: "gateway", Name: "bookinfo-gateway", Namespace: "bookinfo"}
+
+	index := NewReferenceIndex(3, IstioReferencesMap{
+		vsKey: {
+			ObjectReferences:  []IstioReference{gw, gw},
+			ServiceReferences: []ServiceReference{{Name: "reviews", Namespace: "bookinfo"}},
+		},
+		apKey: {
+			ObjectReferences:   []IstioReference{gw},
+			WorkloadReferences: []WorkloadReference{{Name: "reviews-v1", Namespace: "bookinfo"}},
+		},
+	})
+
+	assert.Equal(uint64(3), index.Version)
+	assert.Len(index.References, 2)
+
+	vs := IstioReference{ObjectType: vsKey.ObjectType, Name: vsKey.Name, Namespace: vsKey.Namespace}
+	ap := IstioReference{ObjectType: apKey.ObjectType, Name: apKey.Name, Namespace: apKey.Namespace}
+	assert.ElementsMatch([]IstioReference{vs, ap}, index.ReferencedBy[IstioReferenceKey{ObjectType: "gateway", Name: "bookinfo-gateway", Namespace: "bookinfo"}])
+	assert.Equal([]IstioReference{vs}, index.ReferencedBy[IstioReferenceKey{ObjectType: ReferenceTypeService, Name: "reviews", Namespace: "bookinfo"}])
+	assert.Equal([]IstioReference{ap}, index.ReferencedBy[IstioReferenceKey{ObjectType: ReferenceTypeWorkload, Name: "reviews-v1", Namespace: "bookinfo"}])
+	assert.Empty(index.ReferencedBy[vsKey])
+}
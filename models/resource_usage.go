@@ -0,0 +1,143 @@
+package models
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// ResourceUsage is the current CPU and memory usage reported by metrics-server, and its utilization of the
+// resource requests and limits
+type ResourceUsage struct {
+	// CPU usage, in cores
+	// required: true
+	// example: 0.25
+	CPU float64 `json:"cpu"`
+
+	// Percentage of the requested CPU in use, missing when no CPU is requested
+	// example: 50
+	CPURequestUtilization *float64 `json:"cpuRequestUtilization,omitempty"`
+
+	// Percentage of the CPU limit in use, missing when a container has no CPU limit
+	// example: 25
+	CPULimitUtilization *float64 `json:"cpuLimitUtilization,omitempty"`
+
+	// Memory usage (working set), in bytes
+	// required: true
+	// example: 67108864
+	Memory int64 `json:"memory"`
+
+	// Percentage of the requested memory in use, missing when no memory is requested
+	// example: 50
+	MemoryRequestUtilization *float64 `json:"memoryRequestUtilization,omitempty"`
+
+	// Percentage of the memory limit in use, missing when a container has no memory limit
+	// example: 25
+	MemoryLimitUtilization *float64 `json:"memoryLimitUtilization,omitempty"`
+
+	// Time of the usage sample (in RFC3339 format)
+	// required: true
+	// example: 2018-07-31T12:24:17Z
+	Timestamp string `json:"timestamp"`
+}
+
+// podResources are the resource requests and limits of the containers of a pod, zero when unset. A limit is
+// unset when any container has no limit.
+type podResources struct {
+	cpuRequest    float64 // cores
+	cpuLimit      float64 // cores
+	memoryRequest int64   // bytes
+	memoryLimit   int64   // bytes
+}
+
+// parseResources extracts the resource requests and limits of the pod containers
+func (pod *Pod) parseResources(p *core_v1.Pod) {
+	resources := podResources{}
+	cpuLimited, memoryLimited := true, true
+	for _, c := range p.Spec.Containers {
+		resources.cpuRequest += c.Resources.Requests.Cpu().AsApproximateFloat64()
+		resources.memoryRequest += c.Resources.Requests.Memory().Value()
+		if _, ok := c.Resources.Limits[core_v1.ResourceCPU]; ok {
+			resources.cpuLimit += c.Resources.Limits.Cpu().AsApproximateFloat64()
+		} else {
+			cpuLimited = false
+		}
+		if _, ok := c.Resources.Limits[core_v1.ResourceMemory]; ok {
+			resources.memoryLimit += c.Resources.Limits.Memory().Value()
+		} else {
+			memoryLimited = false
+		}
+	}
+	if !cpuLimited {
+		resources.cpuLimit = 0
+	}
+	if !memoryLimited {
+		resources.memoryLimit = 0
+	}
+	pod.resources = resources
+}
+
+// SetResourceUsage sets the resource usage of the pod, the sum of the usage of its containers
+func (pod *Pod) SetResourceUsage(metrics *kubernetes.PodMetrics) {
+	usage := &ResourceUsage{Timestamp: formatTime(metrics.Timestamp.Time)}
+	for _, c := range metrics.Containers {
+		usage.CPU += c.Usage.Cpu().AsApproximateFloat64()
+		usage.Memory += c.Usage.Memory().Value()
+	}
+	usage.setUtilization(pod.resources)
+	pod.ResourceUsage = usage
+}
+
+// SetResourceUsage sets the resource usage of the workload, the sum of the usage of its pods with a resource
+// usage. The utilization of a request or limit is set when every one of these pods defines it.
+func (workload *Workload) SetResourceUsage() {
+	var usage *ResourceUsage
+	var resources podResources
+	for _, pod := range workload.Pods {
+		if pod.ResourceUsage == nil {
+			continue
+		}
+		if usage == nil {
+			usage = &ResourceUsage{Timestamp: pod.ResourceUsage.Timestamp}
+			resources = pod.resources
+		} else {
+			resources.cpuRequest = addIfSet(resources.cpuRequest, pod.resources.cpuRequest)
+			resources.cpuLimit = addIfSet(resources.cpuLimit, pod.resources.cpuLimit)
+			resources.memoryRequest = addIfSet(resources.memoryRequest, pod.resources.memoryRequest)
+			resources.memoryLimit = addIfSet(resources.memoryLimit, pod.resources.memoryLimit)
+		}
+		usage.CPU += pod.ResourceUsage.CPU
+		usage.Memory += pod.ResourceUsage.Memory
+		if pod.ResourceUsage.Timestamp > usage.Timestamp {
+			usage.Timestamp = pod.ResourceUsage.Timestamp
+		}
+	}
+	if usage != nil {
+		usage.setUtilization(resources)
+	}
+	workload.ResourceUsage = usage
+}
+
+func (usage *ResourceUsage) setUtilization(resources podResources) {
+	usage.CPURequestUtilization = utilization(usage.CPU, resources.cpuRequest)
+	usage.CPULimitUtilization = utilization(usage.CPU, resources.cpuLimit)
+	usage.MemoryRequestUtilization = utilization(float64(usage.Memory), float64(resources.memoryRequest))
+	usage.MemoryLimitUtilization = utilization(float64(usage.Memory), float64(resources.memoryLimit))
+}
+
+// utilization returns the percentage of the resource in use, nil when the resource is unset
+func utilization(usage, resource float64) *float64 {
+	if resource <= 0 {
+		return nil
+	}
+	percentage := 100 * usage / resource
+	return &percentage
+}
+
+// addIfSet returns the sum of the resources, zero (unset) when any of them is unset
+func addIfSet[T float64 | int64](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return a + b
+}
@@ -1,8 +1,12 @@
 package models
 
 import (
+	"time"
+
+	osroutes_v1 "github.com/openshift/api/route/v1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -56,11 +60,40 @@ type ServiceOverview struct {
 	// External: 	is a service registry for externally provided ServiceEntries
 	// Federation:  special case when registry is provided from a federated environment
 	ServiceRegistry string `json:"serviceRegistry"`
+	// Creation time of the Kubernetes Service, empty for services only known by the Istio registry
+	// example: 2024-01-31T10:15:00Z
+	CreatedAt string `json:"createdAt,omitempty"`
+	// Last time the Istio config attached to the Service (VirtualServices, DestinationRules, routes...) was created or updated
+	// example: 2024-02-01T08:00:00Z
+	LastIstioConfigChange string `json:"lastIstioConfigChange,omitempty"`
 
 	// Health
 	Health ServiceHealth `json:"health,omitempty"`
 }
 
+// SetTimestamps sets the creation time of the service and the last time the Istio config attached to it
+// changed. Updates of the config are tracked through the managed fields of the objects.
+func (so *ServiceOverview) SetTimestamps(createdAt time.Time, istioConfigs []meta_v1.Object) {
+	if !createdAt.IsZero() {
+		so.CreatedAt = formatTime(createdAt)
+	}
+
+	var lastChange time.Time
+	for _, istioConfig := range istioConfigs {
+		if created := istioConfig.GetCreationTimestamp().Time; created.After(lastChange) {
+			lastChange = created
+		}
+		for _, managedFields := range istioConfig.GetManagedFields() {
+			if managedFields.Time != nil && managedFields.Time.After(lastChange) {
+				lastChange = managedFields.Time.Time
+			}
+		}
+	}
+	if !lastChange.IsZero() {
+		so.LastIstioConfigChange = formatTime(lastChange)
+	}
+}
+
 type ClusterServices struct {
 	// Cluster where the services live in
 	// required: true
@@ -90,6 +123,7 @@ type ServiceDetails struct {
 	IstioSidecar       bool                                     `json:"istioSidecar"`
 	K8sHTTPRoutes      []*k8s_networking_v1.HTTPRoute           `json:"k8sHTTPRoutes"`
 	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant `json:"k8sReferenceGrants"`
+	OpenShiftRoutes    []*osroutes_v1.Route                     `json:"openShiftRoutes,omitempty"`
 	Service            Service                                  `json:"service"`
 	ServiceEntries     []*networking_v1beta1.ServiceEntry       `json:"serviceEntries"`
 	VirtualServices    []*networking_v1beta1.VirtualService     `json:"virtualServices"`
@@ -112,6 +146,7 @@ type (
 		HealthAnnotations map[string]string `json:"healthAnnotations"`
 		Ip                string            `json:"ip"`
 		Labels            map[string]string `json:"labels"`
+		ManagedBy         *ManagedBy        `json:"managedBy,omitempty"`
 		Name              string            `json:"name"`
 		Namespace         string            `json:"namespace"`
 		Ports             Ports             `json:"ports"`
@@ -156,6 +191,7 @@ func (s *Service) Parse(cluster string, service *core_v1.Service) {
 		s.HealthAnnotations = GetHealthAnnotation(service.Annotations, GetHealthConfigAnnotation())
 		s.Ip = service.Spec.ClusterIP
 		s.Labels = service.Labels
+		s.ManagedBy = GetManagedBy(service.Labels, service.Annotations)
 		s.Name = service.Name
 		s.Namespace = service.Namespace
 		(&s.Ports).Parse(service.Spec.Ports)
@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
-
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -147,3 +147,28 @@ func fakeWorkloads() WorkloadOverviews {
 	wo = append(wo, w2)
 	return wo
 }
+
+func TestServiceOverviewTimestamps(t *testing.T) {
+	assert := assert.New(t)
+
+	created := time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)
+	updated := meta_v1.NewTime(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC))
+	vs := &networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{
+		CreationTimestamp: meta_v1.NewTime(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+		ManagedFields:     []meta_v1.ManagedFieldsEntry{{Manager: "kubectl", Time: &updated}},
+	}}
+	dr := &networking_v1beta1.DestinationRule{ObjectMeta: meta_v1.ObjectMeta{
+		CreationTimestamp: meta_v1.NewTime(time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)),
+	}}
+
+	service := ServiceOverview{}
+	service.SetTimestamps(created, []meta_v1.Object{vs, dr})
+	assert.Equal("2024-01-10T08:00:00Z", service.CreatedAt)
+	assert.Equal("2024-03-01T12:30:00Z", service.LastIstioConfigChange)
+
+	// Services without config nor creation time, e.g. from the Istio registry
+	service = ServiceOverview{}
+	service.SetTimestamps(time.Time{}, []meta_v1.Object{})
+	assert.Empty(service.CreatedAt)
+	assert.Empty(service.LastIstioConfigChange)
+}
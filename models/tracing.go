@@ -28,3 +28,18 @@ type TracingQuery struct {
 	Limit       int
 	Cluster     string
 }
+
+// Kinds of the nodes of the graph edges whose traces are looked up
+const (
+	TracingEdgeApp      = "app"
+	TracingEdgeService  = "service"
+	TracingEdgeWorkload = "workload"
+)
+
+// TracingEdgeEndpoint is the source or the destination node of a graph edge: an app, a service or a workload
+type TracingEdgeEndpoint struct {
+	Cluster   string
+	Namespace string
+	Kind      string
+	Name      string
+}
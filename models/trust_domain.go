@@ -0,0 +1,89 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// Status of the trust domain of a principal.
+const (
+	// TrustDomainCurrent principals belong to one of the trust domains of the mesh.
+	TrustDomainCurrent = "Current"
+	// TrustDomainStale principals belong to a trust domain only kept as an alias e.g. after a migration.
+	TrustDomainStale = "Stale"
+	// TrustDomainUnknown principals belong to a trust domain the mesh doesn't know about.
+	TrustDomainUnknown = "Unknown"
+)
+
+// MeshTrustDomains are the trust domains discovered from the meshConfig of the controlplanes.
+type MeshTrustDomains struct {
+	// TrustDomains configured as trustDomain by the controlplanes.
+	TrustDomains []string `json:"trustDomains"`
+
+	// Aliases configured as trustDomainAliases by the controlplanes.
+	Aliases []string `json:"aliases"`
+}
+
+// TrustDomains returns the trust domains and aliases configured by the controlplanes of the mesh.
+// Istio defaults the trust domain to cluster.local when it is not set.
+func (m Mesh) TrustDomains() MeshTrustDomains {
+	trustDomains := MeshTrustDomains{TrustDomains: []string{}, Aliases: []string{}}
+	for _, cp := range m.ControlPlanes {
+		trustDomain := cp.Config.TrustDomain
+		if trustDomain == "" {
+			trustDomain = "cluster.local"
+		}
+		if !slices.Contains(trustDomains.TrustDomains, trustDomain) {
+			trustDomains.TrustDomains = append(trustDomains.TrustDomains, trustDomain)
+		}
+		for _, alias := range cp.Config.TrustDomainAliases {
+			if !slices.Contains(trustDomains.Aliases, alias) {
+				trustDomains.Aliases = append(trustDomains.Aliases, alias)
+			}
+		}
+	}
+	return trustDomains
+}
+
+// PrincipalTrustDomain returns the trust domain of a principal and whether it is current, stale or unknown
+// for the mesh. Principals without a trust domain, like "*" or "*/ns/foo/sa/bar", always match the mesh.
+func (td MeshTrustDomains) PrincipalTrustDomain(principal string) (string, string) {
+	principal = strings.TrimPrefix(principal, "spiffe://")
+	idx := strings.Index(principal, "/ns/")
+	if idx < 0 {
+		return "", TrustDomainCurrent
+	}
+
+	trustDomain := principal[:idx]
+	switch {
+	case strings.Contains(trustDomain, "*"), slices.Contains(td.TrustDomains, trustDomain):
+		return trustDomain, TrustDomainCurrent
+	case slices.Contains(td.Aliases, trustDomain):
+		return trustDomain, TrustDomainStale
+	default:
+		return trustDomain, TrustDomainUnknown
+	}
+}
+
+// TrustDomainReport lists the principals referenced by the policies of the mesh along with their trust domain.
+type TrustDomainReport struct {
+	MeshTrustDomains
+
+	// Principals referenced by the authorization policies.
+	Principals []PrincipalTrustDomain `json:"principals"`
+}
+
+// PrincipalTrustDomain is a principal referenced by a policy.
+type PrincipalTrustDomain struct {
+	// Policy referencing the principal.
+	Policy IstioValidationKey `json:"policy"`
+
+	// Path of the principal in the policy.
+	Path string `json:"path"`
+
+	Principal   string `json:"principal"`
+	TrustDomain string `json:"trustDomain"`
+
+	// Status of the trust domain: Current, Stale or Unknown.
+	Status string `json:"status"`
+}
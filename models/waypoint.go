@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/prometheus/common/model"
+)
+
+// WaypointTraffic is the traffic proxied by a waypoint, broken down by the enrolled services it was sent to.
+type WaypointTraffic struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	// Name of the waypoint workload
+	Waypoint string `json:"waypoint"`
+	// Interval the request rates were computed over
+	// example: 10m
+	RateInterval string `json:"rateInterval"`
+	// Services receiving requests through the waypoint, with the highest error rate first
+	Services []WaypointServiceTraffic `json:"services"`
+}
+
+// WaypointServiceTraffic is the traffic that a waypoint proxied to one of its enrolled services.
+type WaypointServiceTraffic struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Requests per second proxied to the service
+	Rate float64 `json:"rate"`
+	// Erroneous requests per second: 5xx for http, non-zero statuses for grpc, or no response at all
+	ErrorRate float64 `json:"errorRate"`
+	// Requests per second by protocol and response code, in the format of the request health
+	// Example: { "http": {"200": 1.5, "503": 0.2} }
+	Requests map[string]map[string]float64 `json:"requests"`
+}
+
+// NewWaypointServiceTraffic returns the traffic of a service with no request yet.
+func NewWaypointServiceTraffic(name, namespace string) *WaypointServiceTraffic {
+	return &WaypointServiceTraffic{
+		Name:      name,
+		Namespace: namespace,
+		Requests:  map[string]map[string]float64{},
+	}
+}
+
+// AddSample adds the rate of a sample of the istio_requests_total reported by the waypoint.
+func (in *WaypointServiceTraffic) AddSample(sample *model.Sample) {
+	aggregate(sample, in.Requests)
+	in.Rate += float64(sample.Value)
+	if protocol, code := sampleResponseCode(sample); isErrorCode(protocol, code) {
+		in.ErrorRate += float64(sample.Value)
+	}
+}
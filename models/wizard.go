@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+)
+
+// WizardScenario is the Istio config generated by a Kiali wizard for a service. It is not persisted: it is created
+// or updated with the Istio config API.
+type WizardScenario struct {
+	// The wizard which generated the config, set as the kiali_wizard label of the objects
+	// required: true
+	// example: traffic_mirroring
+	Scenario string `json:"scenario"`
+
+	// The VirtualService, unset when the scenario only sets the traffic policy of the service
+	VirtualService *networking_v1beta1.VirtualService `json:"virtualService,omitempty"`
+
+	// required: true
+	DestinationRule *networking_v1beta1.DestinationRule `json:"destinationRule"`
+}
+
+// LocalityLoadBalancing is the locality load balancing of the requests of a service: either the distribution of the
+// requests from a locality to localities, or the failover from a region to other regions. Failing over requires an
+// outlier detection to eject the unhealthy endpoints.
+type LocalityLoadBalancing struct {
+	Distribute       []*api_networking_v1beta1.LocalityLoadBalancerSetting_Distribute `json:"distribute,omitempty"`
+	Failover         []*api_networking_v1beta1.LocalityLoadBalancerSetting_Failover   `json:"failover,omitempty"`
+	OutlierDetection *api_networking_v1beta1.OutlierDetection                         `json:"outlierDetection,omitempty"`
+}
+
+// ClusterLocalities are the localities of the nodes of a cluster
+type ClusterLocalities struct {
+	// required: true
+	// example: east
+	Cluster string `json:"cluster"`
+
+	// The localities, sorted
+	// required: true
+	Localities []Locality `json:"localities"`
+}
+
+// Locality is a region, zone and subzone of nodes, from their topology labels
+type Locality struct {
+	// required: true
+	// example: us-east1
+	Region string `json:"region"`
+
+	// example: us-east1-b
+	Zone string `json:"zone,omitempty"`
+
+	// example: rack-1
+	SubZone string `json:"subZone,omitempty"`
+
+	// The number of nodes of the locality
+	// required: true
+	Nodes int `json:"nodes"`
+}
+
+// String returns the locality as set in the locality load balancer settings: region/zone/subzone
+func (l Locality) String() string {
+	return strings.TrimRight(strings.Join([]string{l.Region, l.Zone, l.SubZone}, "/"), "/")
+}
+
+// Matches returns true when the locality matches a locality of the locality load balancer settings, where a
+// segment "*" matches any zone or subzone, i.e. us-east1/* or us-east1/us-east1-b/*
+func (l Locality) Matches(locality string) bool {
+	segments := []string{l.Region, l.Zone, l.SubZone}
+	for i, segment := range strings.Split(locality, "/") {
+		if segment == "*" {
+			return true
+		}
+		if i >= len(segments) || segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -48,6 +48,14 @@ const (
 	LogTypeZtunnel  LogType = "ztunnel"
 )
 
+const (
+	// WorkloadOwnerAnnotation names the logical workload of a ReplicaSet, the ReplicaSets with the same owner
+	// are listed as the revisions of a single workload (i.e. the stable and canary ReplicaSets of a custom controller)
+	WorkloadOwnerAnnotation = "kiali.io/workload-owner"
+	// RolloutRevisionAnnotation is the revision of an Argo Rollout ReplicaSet
+	RolloutRevisionAnnotation = "rollout.argoproj.io/revision"
+)
+
 // WorkloadListItem has the necessary information to display the console workload list
 type WorkloadListItem struct {
 	// Name of the workload
@@ -135,6 +143,56 @@ type WorkloadListItem struct {
 
 	// Health
 	Health WorkloadHealth `json:"health,omitempty"`
+
+	// mTLS status of the workload, including its port-level exceptions
+	// required: false
+	MTLSStatus *MTLSStatus `json:"mtlsStatus,omitempty"`
+
+	// Revisions of a workload managing its pods through several ReplicaSets, such as an Argo Rollout
+	// required: false
+	Revisions []WorkloadRevision `json:"revisions,omitempty"`
+
+	// True when the proxy of any pod has a concurrency mismatched with its CPU limit
+	proxyConcurrencyMismatch bool
+}
+
+// WorkloadRevision is a ReplicaSet of a workload grouping several ReplicaSets, such as the stable and
+// canary ReplicaSets of an Argo Rollout
+type WorkloadRevision struct {
+	// Name of the ReplicaSet
+	// required: true
+	// example: reviews-6d8f7b9c4
+	Name string `json:"name"`
+
+	// Revision of the workload: the rollout revision, or the pod template hash
+	// required: true
+	// example: 3
+	Revision string `json:"revision"`
+
+	// Creation timestamp (in RFC3339 format)
+	// required: true
+	// example: 2018-07-31T12:24:17Z
+	CreatedAt string `json:"createdAt"`
+
+	// ReplicaSet template labels
+	Labels map[string]string `json:"labels"`
+
+	// Number of current revision pods
+	// required: true
+	// example: 1
+	PodCount int `json:"podCount"`
+
+	// Number of desired replicas of the revision
+	// example: 2
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// Number of current replicas of the revision
+	// example: 2
+	CurrentReplicas int32 `json:"currentReplicas"`
+
+	// Number of available replicas of the revision
+	// example: 1
+	AvailableReplicas int32 `json:"availableReplicas"`
 }
 
 type WorkloadOverviews []*WorkloadListItem
@@ -175,6 +233,15 @@ type Workload struct {
 	// Ambient waypoint workloads
 	WaypointWorkloads []Workload `json:"waypointWorkloads"`
 
+	// Current resource usage of the workload pods, when metrics-server is enabled
+	// required: false
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
+
+	// Service accounts of the workload pods, with their role bindings and the AuthorizationPolicies referencing
+	// their identity
+	// required: false
+	ServiceAccounts []ServiceAccountSummary `json:"serviceAccounts,omitempty"`
+
 	// Health
 	Health WorkloadHealth `json:"health"`
 }
@@ -201,6 +268,12 @@ func (workload *WorkloadListItem) ParseWorkload(w *Workload) {
 	}
 	workload.HealthAnnotations = w.HealthAnnotations
 	workload.IstioReferences = []*IstioValidationKey{}
+	workload.Revisions = w.Revisions
+	for _, pod := range w.Pods {
+		if pod.ProxyResources.ConcurrencyMismatch() {
+			workload.proxyConcurrencyMismatch = true
+		}
+	}
 
 	/** Check the labels app and version required by Istio in template Pods*/
 	_, workload.AppLabel = w.Labels[conf.IstioLabels.AppLabelName]
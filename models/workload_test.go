@@ -6,11 +6,14 @@ import (
 
 	osapps_v1 "github.com/openshift/api/apps/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 )
 
 func TestParseDeploymentToWorkload(t *testing.T) {
@@ -349,3 +352,67 @@ func fakePod() *core_v1.Pod {
 		},
 	}
 }
+
+func TestWorkloadResourceUsage(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	container := func(name string, requests, limits core_v1.ResourceList) core_v1.Container {
+		return core_v1.Container{Name: name, Resources: core_v1.ResourceRequirements{Requests: requests, Limits: limits}}
+	}
+	pods := []core_v1.Pod{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1-1"},
+			Spec: core_v1.PodSpec{Containers: []core_v1.Container{
+				container("reviews",
+					core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("500m"), core_v1.ResourceMemory: resource.MustParse("128Mi")},
+					core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("1"), core_v1.ResourceMemory: resource.MustParse("256Mi")}),
+				container("istio-proxy",
+					core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("500m")},
+					core_v1.ResourceList{core_v1.ResourceMemory: resource.MustParse("256Mi")}),
+			}},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1-2"},
+			Spec: core_v1.PodSpec{Containers: []core_v1.Container{
+				container("reviews",
+					core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("1"), core_v1.ResourceMemory: resource.MustParse("128Mi")},
+					core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("1"), core_v1.ResourceMemory: resource.MustParse("256Mi")}),
+			}},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1-3"},
+		},
+	}
+	w := Workload{}
+	w.SetPods(pods)
+
+	now := meta_v1.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	usage := func(cpu, memory string) []kubernetes.ContainerMetrics {
+		return []kubernetes.ContainerMetrics{{Name: "reviews", Usage: core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse(cpu), core_v1.ResourceMemory: resource.MustParse(memory)}}}
+	}
+	w.Pods[0].SetResourceUsage(&kubernetes.PodMetrics{Timestamp: now, Containers: usage("250m", "64Mi")})
+	w.Pods[1].SetResourceUsage(&kubernetes.PodMetrics{Timestamp: now, Containers: usage("500m", "128Mi")})
+	w.SetResourceUsage()
+
+	// a CPU limit is missing for the proxy container
+	pod := w.Pods[0].ResourceUsage
+	require.NotNil(pod)
+	assert.InDelta(0.25, pod.CPU, 0.001)
+	assert.InDelta(25.0, *pod.CPURequestUtilization, 0.001)
+	assert.Nil(pod.CPULimitUtilization)
+	assert.InDelta(50.0, *pod.MemoryRequestUtilization, 0.001)
+	assert.InDelta(12.5, *pod.MemoryLimitUtilization, 0.001)
+	assert.Equal("2024-05-01T10:00:00Z", pod.Timestamp)
+
+	// the third pod has no usage
+	assert.Nil(w.Pods[2].ResourceUsage)
+	require.NotNil(w.ResourceUsage)
+	assert.InDelta(0.75, w.ResourceUsage.CPU, 0.001)
+	assert.Equal(int64(192*1024*1024), w.ResourceUsage.Memory)
+	assert.InDelta(37.5, *w.ResourceUsage.CPURequestUtilization, 0.001)
+	assert.Nil(w.ResourceUsage.CPULimitUtilization)
+	assert.InDelta(75.0, *w.ResourceUsage.MemoryRequestUtilization, 0.001)
+	assert.InDelta(25.0, *w.ResourceUsage.MemoryLimitUtilization, 0.001)
+}
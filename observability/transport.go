@@ -0,0 +1,65 @@
+package observability
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kiali/kiali/config"
+)
+
+// tracingTransport starts a client span for the outbound requests of a traced context and injects its trace context
+// in the request headers, as W3C traceparent, so the spans of Prometheus, of the tracing backend or of the
+// Kubernetes API are part of the trace of the Kiali API call.
+type tracingTransport struct {
+	originalRT http.RoundTripper
+}
+
+// NewTransport wraps a round tripper to propagate the trace context of the requests. Its signature matches the
+// WrapTransport of the Kubernetes rest config.
+func NewTransport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	if _, ok := rt.(*tracingTransport); ok {
+		return rt
+	}
+	return &tracingTransport{originalRT: rt}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !config.Get().Server.Observability.Tracing.Enabled || !trace.SpanContextFromContext(ctx).IsValid() {
+		return t.originalRT.RoundTrip(req)
+	}
+
+	ctx, span := otel.Tracer(TracerName()).Start(ctx, "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPHostKey.String(req.URL.Host),
+			semconv.HTTPTargetKey.String(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	// The request must not be modified by a round tripper
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.originalRT.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
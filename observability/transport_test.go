@@ -0,0 +1,66 @@
+package observability_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/observability"
+)
+
+func TestTransportPropagatesTraceContext(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := config.NewConfig()
+	cfg.Server.Observability.Tracing.Enabled = true
+	config.Set(cfg)
+	t.Cleanup(func() { config.Set(config.NewConfig()) })
+
+	tracerProvider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample())))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	t.Cleanup(server.Close)
+	client := http.Client{Transport: observability.NewTransport(http.DefaultTransport)}
+
+	// Not traced
+	resp, err := client.Get(server.URL)
+	require.NoError(err)
+	resp.Body.Close()
+	assert.Empty(traceparent)
+
+	ctx, end := observability.StartSpan(context.Background(), "TestFunc")
+	defer end()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(err)
+	resp, err = client.Do(req)
+	require.NoError(err)
+	resp.Body.Close()
+
+	// The trace of the caller with the span of the request as parent: 00-<trace id>-<span id>-01
+	parts := strings.Split(traceparent, "-")
+	require.Len(parts, 4)
+	spanContext := trace.SpanContextFromContext(ctx)
+	assert.Equal(spanContext.TraceID().String(), parts[1])
+	assert.NotEqual(spanContext.SpanID().String(), parts[2])
+	assert.Empty(req.Header.Get("traceparent"))
+}
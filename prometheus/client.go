@@ -30,11 +30,17 @@ type ClientInterface interface {
 	FetchRange(metricName, labels, grouping, aggregator string, q *RangeQuery) Metric
 	FetchRateRange(metricName string, labels []string, grouping string, q *RangeQuery) Metric
 	GetAllRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error)
+	GetAllRequestRatesSharded(namespace, cluster, itemLabelSuffix string, items []string, ratesInterval string, queryTime time.Time) (model.Vector, error)
 	GetAppRequestRates(namespace, cluster, app, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
 	GetConfiguration() (prom_v1.ConfigResult, error)
 	GetFlags() (prom_v1.FlagsResult, error)
+	GetNamespacesRequestRates(namespaces []string, cluster, ratesInterval string, queryTime time.Time) (map[string]model.Vector, error)
+	GetNamespaceInboundRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error)
+	GetNamespaceInboundTCPRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
 	GetNamespaceServicesRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error)
+	GetPassthroughRequestRates(ratesInterval string, queryTime time.Time) (model.Vector, error)
 	GetServiceRequestRates(namespace, cluster, service, ratesInterval string, queryTime time.Time) (model.Vector, error)
+	GetWaypointRequestRates(namespace, cluster, waypoint, ratesInterval string, queryTime time.Time) (model.Vector, error)
 	GetWorkloadRequestRates(namespace, cluster, workload, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
 	GetMetricsForLabels(metricNames []string, labels string) ([]string, error)
 }
@@ -43,9 +49,10 @@ type ClientInterface interface {
 // It hides the way we query Prometheus offering a layer with a high level defined API.
 type Client struct {
 	ClientInterface
-	p8s api.Client
-	api prom_v1.API
-	ctx context.Context
+	p8s  api.Client
+	api  prom_v1.API
+	ctx  context.Context
+	conf config.PrometheusConfig
 }
 
 var (
@@ -111,13 +118,14 @@ func NewClientForConfig(cfg config.PrometheusConfig) (*Client, error) {
 	if err != nil {
 		return nil, errors.NewServiceUnavailable(err.Error())
 	}
-	client := Client{p8s: p8s, api: prom_v1.NewAPI(p8s), ctx: context.Background()}
+	p8s = newQueryHintsClient(p8s, cfg.QueryHints)
+	client := Client{p8s: p8s, api: newProviderAPI(prom_v1.NewAPI(p8s), cfg), ctx: context.Background(), conf: cfg}
 	return &client, nil
 }
 
-// Inject allows for replacing the API with a mock For testing
+// Inject allows for replacing the API with a mock For testing. The configured metrics provider still applies.
 func (in *Client) Inject(api prom_v1.API) {
-	in.api = api
+	in.api = newProviderAPI(api, in.conf)
 }
 
 // GetAllRequestRates queries Prometheus to fetch request counter rates, over a time interval, for requests
@@ -142,6 +150,51 @@ func (in *Client) GetAllRequestRates(namespace, cluster string, ratesInterval st
 	return result, nil
 }
 
+// GetAllRequestRatesSharded queries Prometheus to fetch the same request counter rates as GetAllRequestRates, limited
+// to the given items of the namespace (apps, workloads... as told by itemLabelSuffix). As configured by
+// prometheus.health_query_sharding, the items are split in shards queried concurrently to keep the cardinality
+// of every query low. Results are not cached as they depend on the items.
+// Returns (rates, error)
+func (in *Client) GetAllRequestRatesSharded(namespace, cluster, itemLabelSuffix string, items []string, ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	log.Tracef("GetAllRequestRatesSharded [namespace: %s] [%s: %d] [ratesInterval: %s] [queryTime: %s]", namespace, itemLabelSuffix, len(items), ratesInterval, queryTime.String())
+	return getAllRequestRatesSharded(in.ctx, in.api, namespace, cluster, itemLabelSuffix, items, queryTime, ratesInterval, config.Get().ExternalServices.Prometheus.HealthQuerySharding)
+}
+
+// GetNamespacesRequestRates queries Prometheus to fetch the same request counter rates as GetAllRequestRates for several
+// namespaces at once. As configured by prometheus.health_query_batching, the namespaces are split in batches queried
+// with a regex matcher on the namespace, and the results are split per namespace. They are cached per namespace, as
+// the results of GetAllRequestRates.
+// Returns (rates by namespace, error)
+func (in *Client) GetNamespacesRequestRates(namespaces []string, cluster, ratesInterval string, queryTime time.Time) (map[string]model.Vector, error) {
+	log.Tracef("GetNamespacesRequestRates [namespaces: %d] [ratesInterval: %s] [queryTime: %s]", len(namespaces), ratesInterval, queryTime.String())
+	rates := make(map[string]model.Vector, len(namespaces))
+	missing := []string{}
+	for _, namespace := range namespaces {
+		if promCache != nil {
+			if isCached, result := promCache.GetAllRequestRates(namespace, cluster, ratesInterval, queryTime); isCached {
+				rates[namespace] = result
+				continue
+			}
+		}
+		missing = append(missing, namespace)
+	}
+	if len(missing) == 0 {
+		return rates, nil
+	}
+
+	result, err := getNamespacesRequestRates(in.ctx, in.api, missing, cluster, queryTime, ratesInterval, config.Get().ExternalServices.Prometheus.HealthQueryBatching.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for namespace, nsRates := range result {
+		rates[namespace] = nsRates
+		if promCache != nil {
+			promCache.SetAllRequestRates(namespace, cluster, ratesInterval, queryTime, nsRates)
+		}
+	}
+	return rates, nil
+}
+
 // GetNamespaceServicesRequestRates queries Prometheus to fetch request counter rates, over a time interval, limited to
 // requests for services in the namespace. Note that it does not discriminate on "reporter", so rates can
 // be inflated due to duplication, and therefore should be used mainly for calculating ratios
@@ -230,6 +283,55 @@ func (in *Client) GetWorkloadRequestRates(namespace, cluster, workload, ratesInt
 	return inResult, outResult, nil
 }
 
+// GetNamespaceInboundRequestRates queries Prometheus to fetch the request counter rates, over a time interval, of the
+// workloads of the namespace. Only the destination proxies are read, they know how the connections were secured. The
+// rates are by source workload, destination workload and connection_security_policy. Unlike the health rates, they
+// are never read from a recording rule.
+// Returns (rates, error)
+func (in *Client) GetNamespaceInboundRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	log.Tracef("GetNamespaceInboundRequestRates [namespace: %s] [cluster: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, ratesInterval, queryTime.String())
+	lbl := fmt.Sprintf(`reporter="destination",destination_workload_namespace="%s",destination_cluster="%s"`, namespace, cluster)
+	return getInboundRates(in.ctx, in.api, "istio_requests_total", lbl, queryTime, ratesInterval)
+}
+
+// GetNamespaceInboundTCPRates queries Prometheus to fetch, over a time interval, the rates of the TCP connections
+// opened to the workloads of the namespace and of the TCP bytes they received. Only the destination proxies are
+// read, they know how the connections were secured. The rates are by source workload, destination workload and
+// connection_security_policy.
+// Returns (connection rates, received bytes rates, error)
+func (in *Client) GetNamespaceInboundTCPRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error) {
+	log.Tracef("GetNamespaceInboundTCPRates [namespace: %s] [cluster: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, ratesInterval, queryTime.String())
+	lbl := fmt.Sprintf(`reporter="destination",destination_workload_namespace="%s",destination_cluster="%s"`, namespace, cluster)
+	connections, err := getInboundRates(in.ctx, in.api, "istio_tcp_connections_opened_total", lbl, queryTime, ratesInterval)
+	if err != nil {
+		return model.Vector{}, model.Vector{}, err
+	}
+	received, err := getInboundRates(in.ctx, in.api, "istio_tcp_received_bytes_total", lbl, queryTime, ratesInterval)
+	if err != nil {
+		return model.Vector{}, model.Vector{}, err
+	}
+	return connections, received, nil
+}
+
+// GetPassthroughRequestRates queries Prometheus to fetch request counter rates, over a time interval, for requests
+// sent by the mesh proxies to destinations that are not in the service registry (i.e. through the PassthroughCluster).
+// Only the source proxy reports these requests.
+// Returns (rates, error)
+func (in *Client) GetPassthroughRequestRates(ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	log.Tracef("GetPassthroughRequestRates [ratesInterval: %s] [queryTime: %s]", ratesInterval, queryTime.String())
+	return getRequestRatesForLabel(in.ctx, in.api, queryTime, `reporter="source",destination_service_name="PassthroughCluster"`, ratesInterval)
+}
+
+// GetWaypointRequestRates queries Prometheus to fetch request counter rates, over a time interval, for requests
+// proxied by a waypoint to the services enrolled in it. Only the waypoint reports them as the source of the requests,
+// the destination proxies report them too when they are sidecars, so these are excluded.
+// Returns (rates, error)
+func (in *Client) GetWaypointRequestRates(namespace, cluster, waypoint, ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	log.Tracef("GetWaypointRequestRates [namespace: %s] [cluster: %s] [waypoint: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, waypoint, ratesInterval, queryTime.String())
+	lbl := fmt.Sprintf(`reporter=~"source|waypoint",source_workload_namespace="%s",source_workload="%s",source_cluster="%s"`, namespace, waypoint, cluster)
+	return getRequestRatesForLabel(in.ctx, in.api, queryTime, lbl, ratesInterval)
+}
+
 // FetchRange fetches a simple metric (gauge or counter) in given range
 func (in *Client) FetchRange(metricName, labels, grouping, aggregator string, q *RangeQuery) Metric {
 	query := fmt.Sprintf("%s(%s%s)", aggregator, metricName, labels)
@@ -276,6 +378,14 @@ func (in *Client) GetContext() context.Context {
 	return in.ctx
 }
 
+// WithContext returns a copy of the client querying Prometheus with the context of a request, so the queries are
+// part of its trace
+func (in *Client) WithContext(ctx context.Context) *Client {
+	client := *in
+	client.ctx = ctx
+	return &client
+}
+
 func (in *Client) GetRuntimeinfo() (prom_v1.RuntimeinfoResult, error) {
 	ri, err := in.API().Runtimeinfo(in.ctx)
 	if err != nil {
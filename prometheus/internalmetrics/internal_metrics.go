@@ -2,9 +2,12 @@
 package internalmetrics
 
 import (
+	"context"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	// Because this package is used all throughout the codebase, be VERY careful adding new
 	// kiali imports here. Most likely you will encounter an import cycle error that will
 	// cause a compilation failure.
@@ -23,22 +26,32 @@ const (
 	labelService          = "service"
 	labelType             = "type"
 	labelName             = "name"
+	labelCluster          = "cluster"
+	labelKind             = "kind"
+	labelReason           = "reason"
+	labelMethod           = "method"
 )
 
+// exemplarTraceID is the label of the exemplars linking an observation to the trace of Kiali it was made in
+const exemplarTraceID = "trace_id"
+
 // MetricsType defines all of Kiali's own internal metrics.
 type MetricsType struct {
 	APIFailures                    *prometheus.CounterVec
 	APIProcessingTime              *prometheus.HistogramVec
+	BusinessMethodTime             *prometheus.HistogramVec
 	CheckerProcessingTime          *prometheus.HistogramVec
 	GraphAppenderTime              *prometheus.HistogramVec
 	GraphGenerationTime            *prometheus.HistogramVec
 	GraphMarshalTime               *prometheus.HistogramVec
 	GraphNodes                     *prometheus.GaugeVec
 	KubernetesClients              *prometheus.GaugeVec
+	MeshCertificateExpiration      *prometheus.GaugeVec
 	MeshGraphAppenderTime          *prometheus.HistogramVec
 	MeshGraphGenerationTime        *prometheus.HistogramVec
 	MeshGraphMarshalTime           *prometheus.HistogramVec
 	PrometheusProcessingTime       *prometheus.HistogramVec
+	RateLimitedRequests            *prometheus.CounterVec
 	SingleValidationProcessingTime *prometheus.HistogramVec
 	ValidationProcessingTime       *prometheus.HistogramVec
 }
@@ -82,6 +95,13 @@ var Metrics = MetricsType{
 		},
 		[]string{labelRoute},
 	),
+	BusinessMethodTime: prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "kiali_business_method_duration_seconds",
+			Help: "The time required to execute a business layer method, with exemplars linking to the traces of Kiali.",
+		},
+		[]string{labelMethod, labelCluster, labelNamespace},
+	),
 	PrometheusProcessingTime: prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "kiali_prometheus_processing_duration_seconds",
@@ -124,6 +144,20 @@ var Metrics = MetricsType{
 		},
 		[]string{labelNamespace, labelType, labelName},
 	),
+	MeshCertificateExpiration: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_mesh_certificate_expiration_timestamp_seconds",
+			Help: "The expiration time of the mesh certificates found in the last certificate inventory.",
+		},
+		[]string{labelCluster, labelKind, labelNamespace, labelName},
+	),
+	RateLimitedRequests: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kiali_api_rate_limited_total",
+			Help: "Counts the requests of a particular API route rejected by the rate limits, by reason (rate or concurrency).",
+		},
+		[]string{labelRoute, labelReason},
+	),
 }
 
 // SuccessOrFailureMetricType let's you capture metrics for both successes and failures,
@@ -179,12 +213,15 @@ func RegisterInternalMetrics() {
 		Metrics.GraphAppenderTime,
 		Metrics.GraphMarshalTime,
 		Metrics.APIProcessingTime,
+		Metrics.BusinessMethodTime,
 		Metrics.PrometheusProcessingTime,
 		Metrics.KubernetesClients,
 		Metrics.APIFailures,
 		Metrics.CheckerProcessingTime,
 		Metrics.ValidationProcessingTime,
 		Metrics.SingleValidationProcessingTime,
+		Metrics.MeshCertificateExpiration,
+		Metrics.RateLimitedRequests,
 	)
 }
 
@@ -290,6 +327,56 @@ func GetAPIProcessingTimePrometheusTimer(apiRouteName string) *prometheus.Timer
 	return timer
 }
 
+// ExemplarTimer is a timer observing its duration with an exemplar holding the id of the trace of its context, when
+// the trace is sampled, so that the slow observations link to the traces of Kiali itself.
+// The exemplars are exposed when the metrics are scraped in the OpenMetrics format.
+type ExemplarTimer struct {
+	begin    time.Time
+	ctx      context.Context
+	observer prometheus.Observer
+}
+
+// ObserveDuration records the duration passed since the timer was created and returns it.
+func (t *ExemplarTimer) ObserveDuration() time.Duration {
+	d := time.Since(t.begin)
+	spanContext := trace.SpanContextFromContext(t.ctx)
+	if exemplarObserver, ok := t.observer.(prometheus.ExemplarObserver); ok && spanContext.IsSampled() {
+		exemplarObserver.ObserveWithExemplar(d.Seconds(), prometheus.Labels{exemplarTraceID: spanContext.TraceID().String()})
+	} else {
+		t.observer.Observe(d.Seconds())
+	}
+	return d
+}
+
+// GetBusinessMethodTimePrometheusTimer returns a timer that can be used to store
+// a value for the business method time metric. The timer is ticking immediately
+// when this function returns. The context is the one of the span of the method, whose trace
+// is the exemplar of the observation.
+//
+// When cluster or namespace is an empty string, it means the method works on all of them.
+//
+// Typical usage is as follows:
+//
+//	promtimer := GetBusinessMethodTimePrometheusTimer(ctx, ...)
+//	defer promtimer.ObserveDuration()
+func GetBusinessMethodTimePrometheusTimer(ctx context.Context, method string, cluster string, namespace string) *ExemplarTimer {
+	if cluster == "" {
+		cluster = "_all_"
+	}
+	if namespace == "" {
+		namespace = "_all_"
+	}
+	return &ExemplarTimer{
+		begin: time.Now(),
+		ctx:   ctx,
+		observer: Metrics.BusinessMethodTime.With(prometheus.Labels{
+			labelMethod:    method,
+			labelCluster:   cluster,
+			labelNamespace: namespace,
+		}),
+	}
+}
+
 // GetPrometheusProcessingTimePrometheusTimer returns a timer that can be used to store
 // a value for the Prometheus query processing time metric. The timer is ticking immediately
 // when this function returns.
@@ -388,7 +475,31 @@ func GetAPIFailureMetric(route string) prometheus.Counter {
 	})
 }
 
+// GetRateLimitedMetric returns the counter of the requests of a route rejected by the rate limits for a reason
+func GetRateLimitedMetric(route, reason string) prometheus.Counter {
+	return Metrics.RateLimitedRequests.With(prometheus.Labels{
+		labelRoute:  route,
+		labelReason: reason,
+	})
+}
+
 // SetKubernetesClients sets the kubernetes client count
 func SetKubernetesClients(clientCount int) {
 	Metrics.KubernetesClients.With(prometheus.Labels{}).Set(float64(clientCount))
 }
+
+// ResetMeshCertificateExpirations forgets the expiration times of the certificates
+// so that certificates, or clusters, that are gone do not linger.
+func ResetMeshCertificateExpirations() {
+	Metrics.MeshCertificateExpiration.Reset()
+}
+
+// SetMeshCertificateExpiration sets the expiration time of a mesh certificate
+func SetMeshCertificateExpiration(cluster string, kind string, namespace string, name string, notAfter time.Time) {
+	Metrics.MeshCertificateExpiration.With(prometheus.Labels{
+		labelCluster:   cluster,
+		labelKind:      kind,
+		labelNamespace: namespace,
+		labelName:      name,
+	}).Set(float64(notAfter.Unix()))
+}
@@ -0,0 +1,53 @@
+package internalmetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func businessMethodHistogram(t *testing.T, method, cluster, namespace string) *dto.Histogram {
+	t.Helper()
+	metric := &dto.Metric{}
+	observer := Metrics.BusinessMethodTime.With(prometheus.Labels{labelMethod: method, labelCluster: cluster, labelNamespace: namespace})
+	require.NoError(t, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram()
+}
+
+func TestBusinessMethodTimeExemplar(t *testing.T) {
+	require := require.New(t)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	GetBusinessMethodTimePrometheusTimer(ctx, "GetServiceList", "east", "bookinfo").ObserveDuration()
+	histogram := businessMethodHistogram(t, "GetServiceList", "east", "bookinfo")
+	require.Equal(uint64(1), histogram.GetSampleCount())
+
+	var exemplars []*dto.Exemplar
+	for _, bucket := range histogram.GetBucket() {
+		if bucket.GetExemplar() != nil {
+			exemplars = append(exemplars, bucket.GetExemplar())
+		}
+	}
+	require.Len(exemplars, 1)
+	require.Equal(exemplarTraceID, exemplars[0].GetLabel()[0].GetName())
+	require.Equal("4bf92f3577b34da6a3ce929d0e0e4736", exemplars[0].GetLabel()[0].GetValue())
+
+	// Without a sampled trace, the duration is observed without an exemplar
+	GetBusinessMethodTimePrometheusTimer(context.Background(), "GetWorkloadList", "", "").ObserveDuration()
+	histogram = businessMethodHistogram(t, "GetWorkloadList", "_all_", "_all_")
+	require.Equal(uint64(1), histogram.GetSampleCount())
+	for _, bucket := range histogram.GetBucket() {
+		require.Nil(bucket.GetExemplar())
+	}
+}
@@ -3,13 +3,17 @@ package prometheus
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
@@ -102,7 +106,7 @@ func fetchRange(ctx context.Context, api prom_v1.API, query string, bounds prom_
 	}
 	switch result.Type() {
 	case model.ValMatrix:
-		return Metric{Matrix: result.(model.Matrix)}
+		return Metric{Matrix: result.(model.Matrix), Warnings: warnings}
 	}
 	return Metric{Err: fmt.Errorf("invalid query, matrix expected: %s", query)}
 }
@@ -113,13 +117,13 @@ func fetchRange(ctx context.Context, api prom_v1.API, query string, bounds prom_
 func getAllRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
 	// traffic originating outside the namespace to destinations inside the namespace
 	lbl := fmt.Sprintf(`destination_service_namespace="%s",source_workload_namespace!="%s",destination_cluster="%s"`, namespace, namespace, cluster)
-	fromOutside, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+	fromOutside, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
 	}
 	// traffic originating inside the namespace to destinations inside or outside the namespace
 	lbl = fmt.Sprintf(`source_workload_namespace="%s",source_cluster="%s"`, namespace, cluster)
-	fromInside, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+	fromInside, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
 	}
@@ -128,13 +132,131 @@ func getAllRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster
 	return all, nil
 }
 
+// getAllRequestRatesSharded retrieves the same traffic rates as getAllRequestRates, restricted to the given items of the namespace
+// (values of the destination_<itemLabelSuffix>/source_<itemLabelSuffix> labels). Items are split in shards queried concurrently,
+// so that every query only touches the series of a few items.
+func getAllRequestRatesSharded(ctx context.Context, api prom_v1.API, namespace, cluster, itemLabelSuffix string, items []string, queryTime time.Time, ratesInterval string, sharding config.HealthQuerySharding) (model.Vector, error) {
+	shards := shardItems(items, sharding.ShardSize)
+	concurrency := sharding.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]model.Vector, len(shards))
+	errs := make([]error, len(shards))
+	limiter := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, shard := range shards {
+		wg.Add(1)
+		go func(i int, shard string) {
+			defer wg.Done()
+			limiter <- struct{}{}
+			defer func() { <-limiter }()
+
+			// traffic originating outside the namespace to items of the shard
+			lbl := fmt.Sprintf(`destination_service_namespace="%s",source_workload_namespace!="%s",destination_cluster="%s",destination_%s=~"%s"`, namespace, namespace, cluster, itemLabelSuffix, shard)
+			fromOutside, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			// traffic originating from items of the shard
+			lbl = fmt.Sprintf(`source_workload_namespace="%s",source_cluster="%s",source_%s=~"%s"`, namespace, cluster, itemLabelSuffix, shard)
+			fromInside, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = append(fromOutside, fromInside...)
+		}(i, shard)
+	}
+	wg.Wait()
+
+	// Merge results
+	all := model.Vector{}
+	for i := range shards {
+		if errs[i] != nil {
+			return model.Vector{}, errs[i]
+		}
+		all = append(all, results[i]...)
+	}
+	return all, nil
+}
+
+// shardItems sorts the items and groups them by shardSize, returning the regex matching the items of every shard,
+// escaped to be used in a PromQL string.
+func shardItems(items []string, shardSize int) []string {
+	sorted := make([]string, 0, len(items))
+	for _, item := range items {
+		if item != "" {
+			sorted = append(sorted, strings.ReplaceAll(regexp.QuoteMeta(item), `\`, `\\`))
+		}
+	}
+	sort.Strings(sorted)
+	if shardSize <= 0 {
+		shardSize = len(sorted)
+	}
+
+	shards := []string{}
+	for start := 0; start < len(sorted); start += shardSize {
+		end := start + shardSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		shards = append(shards, strings.Join(sorted[start:end], "|"))
+	}
+	return shards
+}
+
+// getNamespacesRequestRates retrieves the same traffic rates as getAllRequestRates for several namespaces. The namespaces are
+// split in batches of batchSize, every batch being queried with a regex matching its namespaces, then the results are split
+// per namespace.
+func getNamespacesRequestRates(ctx context.Context, api prom_v1.API, namespaces []string, cluster string, queryTime time.Time, ratesInterval string, batchSize int) (map[string]model.Vector, error) {
+	lblDestSvcNs := model.LabelName("destination_service_namespace")
+	lblSrcNs := model.LabelName("source_workload_namespace")
+
+	rates := make(map[string]model.Vector, len(namespaces))
+	for _, namespace := range namespaces {
+		rates[namespace] = model.Vector{}
+	}
+	for _, batch := range shardItems(namespaces, batchSize) {
+		// traffic to destinations inside the namespaces of the batch
+		lbl := fmt.Sprintf(`destination_service_namespace=~"%s",destination_cluster="%s"`, batch, cluster)
+		toBatch, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+		if err != nil {
+			return nil, err
+		}
+		// traffic originating inside the namespaces of the batch
+		lbl = fmt.Sprintf(`source_workload_namespace=~"%s",source_cluster="%s"`, batch, cluster)
+		fromBatch, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		// traffic originating outside the namespace to destinations inside the namespace, then traffic originating inside it
+		for _, sample := range toBatch {
+			namespace := string(sample.Metric[lblDestSvcNs])
+			if nsRates, ok := rates[namespace]; ok && string(sample.Metric[lblSrcNs]) != namespace {
+				rates[namespace] = append(nsRates, sample)
+			}
+		}
+		for _, sample := range fromBatch {
+			namespace := string(sample.Metric[lblSrcNs])
+			if nsRates, ok := rates[namespace]; ok {
+				rates[namespace] = append(nsRates, sample)
+			}
+		}
+	}
+	return rates, nil
+}
+
 // getNamespaceServicesRequestRates retrieves traffic rates for requests entering or internal to the namespace.
 // Note that it does not discriminate on "reporter", so rates can be inflated due to duplication, and therefore
 // should be used mainly for calculating ratios (e.g total rates / error rates)
 func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
 	// traffic for the namespace services
 	lblNs := fmt.Sprintf(`destination_service_namespace="%s",destination_cluster="%s"`, namespace, cluster)
-	ns, err := getRequestRatesForLabel(ctx, api, queryTime, lblNs, ratesInterval)
+	ns, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lblNs, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
 	}
@@ -146,7 +268,7 @@ func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, name
 // should be used mainly for calculating ratios (e.g total rates / error rates)
 func getServiceRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster, service string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
 	lbl := fmt.Sprintf(`destination_service_name="%s",destination_service_namespace="%s",destination_cluster="%s"`, service, namespace, cluster)
-	in, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+	in, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
 	}
@@ -159,19 +281,50 @@ func getServiceRequestRates(ctx context.Context, api prom_v1.API, namespace, clu
 func getItemRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster, item, itemLabelSuffix string, queryTime time.Time, ratesInterval string) (model.Vector, model.Vector, error) {
 	lblIn := fmt.Sprintf(`destination_workload_namespace="%s",destination_%s="%s",destination_cluster="%s"`, namespace, itemLabelSuffix, item, cluster)
 	lblOut := fmt.Sprintf(`source_workload_namespace="%s",source_%s="%s",source_cluster="%s"`, namespace, itemLabelSuffix, item, cluster)
-	in, err := getRequestRatesForLabel(ctx, api, queryTime, lblIn, ratesInterval)
+	in, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lblIn, ratesInterval)
 	if err != nil {
 		return model.Vector{}, model.Vector{}, err
 	}
-	out, err := getRequestRatesForLabel(ctx, api, queryTime, lblOut, ratesInterval)
+	out, err := getHealthRequestRatesForLabel(ctx, api, queryTime, lblOut, ratesInterval)
 	if err != nil {
 		return model.Vector{}, model.Vector{}, err
 	}
 	return in, out, nil
 }
 
+// getInboundRates retrieves the rates of a counter by source workload, destination workload and connection
+// security policy.
+func getInboundRates(ctx context.Context, api prom_v1.API, metric, labels string, time time.Time, ratesInterval string) (model.Vector, error) {
+	query := fmt.Sprintf("sum(rate(%s{%s}[%s])) by (source_workload_namespace,source_workload,destination_workload,connection_security_policy) > 0", metric, labels, ratesInterval)
+	log.Tracef("[Prom] getInboundRates: %s", query)
+	promtimer := internalmetrics.GetPrometheusProcessingTimePrometheusTimer("Metrics-GetInboundRates")
+	result, warnings, err := api.Query(ctx, query, time)
+	if len(warnings) > 0 {
+		log.Warningf("getInboundRates. Prometheus Warnings: [%s]", strings.Join(warnings, ","))
+	}
+	if err != nil {
+		return model.Vector{}, errors.NewServiceUnavailable(err.Error())
+	}
+	promtimer.ObserveDuration() // notice we only collect metrics for successful prom queries
+	return result.(model.Vector), nil
+}
+
+// getHealthRequestRatesForLabel retrieves the request rates read by the health. A recording rule configured for the
+// interval precomputes them and is cheaper than the raw counters. The other features select on labels the rule may
+// aggregate away and use getRequestRatesForLabel.
+func getHealthRequestRatesForLabel(ctx context.Context, api prom_v1.API, time time.Time, labels, ratesInterval string) (model.Vector, error) {
+	rule := config.Get().ExternalServices.Prometheus.HealthRecordingRules[ratesInterval]
+	if rule == "" {
+		return getRequestRatesForLabel(ctx, api, time, labels, ratesInterval)
+	}
+	return queryRequestRates(ctx, api, time, fmt.Sprintf("%s{%s} > 0", rule, labels))
+}
+
 func getRequestRatesForLabel(ctx context.Context, api prom_v1.API, time time.Time, labels, ratesInterval string) (model.Vector, error) {
-	query := fmt.Sprintf("rate(istio_requests_total{%s}[%s]) > 0", labels, ratesInterval)
+	return queryRequestRates(ctx, api, time, fmt.Sprintf("rate(istio_requests_total{%s}[%s]) > 0", labels, ratesInterval))
+}
+
+func queryRequestRates(ctx context.Context, api prom_v1.API, time time.Time, query string) (model.Vector, error) {
 	log.Tracef("[Prom] getRequestRatesForLabel: %s", query)
 	promtimer := internalmetrics.GetPrometheusProcessingTimePrometheusTimer("Metrics-GetRequestRates")
 	result, warnings, err := api.Query(ctx, query, time)
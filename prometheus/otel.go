@@ -0,0 +1,276 @@
+package prometheus
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/config"
+)
+
+// PromQL keywords and aggregation operators that are never metric names.
+var promQLKeywords = map[string]bool{
+	"and": true, "bool": true, "by": true, "group_left": true, "group_right": true, "ignoring": true,
+	"inf": true, "nan": true, "offset": true, "on": true, "or": true, "unless": true, "without": true,
+	"avg": true, "bottomk": true, "count": true, "count_values": true, "group": true, "max": true, "min": true,
+	"quantile": true, "stddev": true, "stdvar": true, "sum": true, "topk": true,
+}
+
+// PromQL keywords followed by a list of label names.
+var promQLGroupingKeywords = map[string]bool{
+	"by": true, "group_left": true, "group_right": true, "ignoring": true, "on": true, "without": true,
+}
+
+// otelAPI is the metrics provider reading from an OpenTelemetry (OTLP) metrics store through its PromQL-compatible API.
+// The queries built by Kiali use the Prometheus names of the Istio metrics: they are rewritten with the names of
+// the store, and the labels of the results are renamed back so that health and graphs work unchanged.
+type otelAPI struct {
+	prom_v1.API
+
+	labels       map[string]string // Prometheus name -> store name
+	labelsBack   map[model.LabelName]model.LabelName
+	metrics      map[string]string // Prometheus name -> store name
+	metricsBack  map[string]string
+	metricPrefix string
+}
+
+func newOTelAPI(api prom_v1.API, conf config.OTelMetricsConfig) *otelAPI {
+	otel := &otelAPI{
+		API:          api,
+		labels:       map[string]string{},
+		labelsBack:   map[model.LabelName]model.LabelName{},
+		metrics:      map[string]string{},
+		metricsBack:  map[string]string{},
+		metricPrefix: conf.MetricPrefix,
+	}
+	for name, storeName := range conf.LabelNames {
+		otel.labels[name] = storeName
+		otel.labelsBack[model.LabelName(storeName)] = model.LabelName(name)
+	}
+	for name, storeName := range conf.MetricNames {
+		otel.metrics[name] = storeName
+		otel.metricsBack[storeName] = name
+	}
+	return otel
+}
+
+// newProviderAPI returns the API of the metrics provider configured on top of the Prometheus API.
+func newProviderAPI(api prom_v1.API, cfg config.PrometheusConfig) prom_v1.API {
+	if cfg.Provider == config.OTelProvider {
+		return newOTelAPI(api, cfg.OTel)
+	}
+	return api
+}
+
+func (o *otelAPI) Query(ctx context.Context, query string, ts time.Time, opts ...prom_v1.Option) (model.Value, prom_v1.Warnings, error) {
+	result, warnings, err := o.API.Query(ctx, o.translateQuery(query), ts, opts...)
+	return o.translateValue(result), warnings, err
+}
+
+func (o *otelAPI) QueryRange(ctx context.Context, query string, r prom_v1.Range, opts ...prom_v1.Option) (model.Value, prom_v1.Warnings, error) {
+	result, warnings, err := o.API.QueryRange(ctx, o.translateQuery(query), r, opts...)
+	return o.translateValue(result), warnings, err
+}
+
+func (o *otelAPI) Series(ctx context.Context, matches []string, startTime, endTime time.Time) ([]model.LabelSet, prom_v1.Warnings, error) {
+	translated := make([]string, 0, len(matches))
+	for _, match := range matches {
+		translated = append(translated, o.translateQuery(match))
+	}
+	series, warnings, err := o.API.Series(ctx, translated, startTime, endTime)
+	for i, labelSet := range series {
+		series[i] = model.LabelSet(o.translateMetric(model.Metric(labelSet)))
+	}
+	return series, warnings, err
+}
+
+func (o *otelAPI) LabelValues(ctx context.Context, label string, matches []string, startTime, endTime time.Time) (model.LabelValues, prom_v1.Warnings, error) {
+	translated := make([]string, 0, len(matches))
+	for _, match := range matches {
+		translated = append(translated, o.translateQuery(match))
+	}
+	values, warnings, err := o.API.LabelValues(ctx, o.labelName(label), translated, startTime, endTime)
+	if label == model.MetricNameLabel {
+		for i, value := range values {
+			values[i] = model.LabelValue(o.metricNameBack(string(value)))
+		}
+	}
+	return values, warnings, err
+}
+
+func (o *otelAPI) labelName(name string) string {
+	if storeName, ok := o.labels[name]; ok {
+		return storeName
+	}
+	return name
+}
+
+// metricName returns the name of the metric in the store. Recording rules (names with colons) are not prefixed.
+func (o *otelAPI) metricName(name string) string {
+	if storeName, ok := o.metrics[name]; ok {
+		return storeName
+	}
+	if strings.Contains(name, ":") {
+		return name
+	}
+	return o.metricPrefix + name
+}
+
+func (o *otelAPI) metricNameBack(storeName string) string {
+	if name, ok := o.metricsBack[storeName]; ok {
+		return name
+	}
+	return strings.TrimPrefix(storeName, o.metricPrefix)
+}
+
+// translateQuery renames the metrics and labels of a PromQL query with the names of the store. Names that
+// are not valid Prometheus names (e.g. with dots) are quoted, as supported by the PromQL UTF-8 syntax.
+func (o *otelAPI) translateQuery(query string) string {
+	var sb strings.Builder
+	inSelector := false
+	inGrouping := false
+	expectGrouping := false
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			end := endOfString(query, i)
+			sb.WriteString(query[i:end])
+			i = end
+		case isNameStart(c):
+			end := i + 1
+			for end < len(query) && isNameChar(query[end]) {
+				end++
+			}
+			name := query[i:end]
+			next := nextNonSpace(query, end)
+			switch {
+			case inSelector || inGrouping:
+				sb.WriteString(quoteName(o.labelName(name)))
+			case next < len(query) && query[next] == '(' || promQLKeywords[strings.ToLower(name)]:
+				// function or keyword
+				expectGrouping = promQLGroupingKeywords[strings.ToLower(name)]
+				sb.WriteString(name)
+			default:
+				metric := o.metricName(name)
+				if isValidName(metric) {
+					sb.WriteString(metric)
+				} else if next < len(query) && query[next] == '{' {
+					// the name becomes the first matcher of the selector
+					sb.WriteString("{" + quoteName(metric) + ",")
+					end = next + 1
+					inSelector = true
+				} else {
+					sb.WriteString("{" + quoteName(metric) + "}")
+				}
+			}
+			i = end
+		case isDigit(c):
+			// numbers and durations
+			end := i + 1
+			for end < len(query) && (isNameChar(query[end]) || query[end] == '.') {
+				end++
+			}
+			sb.WriteString(query[i:end])
+			i = end
+		default:
+			switch c {
+			case '{':
+				inSelector = true
+			case '}':
+				inSelector = false
+			case '(':
+				inGrouping = expectGrouping
+				expectGrouping = false
+			case ')':
+				inGrouping = false
+			}
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// translateValue renames back the labels of the query results with the Prometheus names.
+func (o *otelAPI) translateValue(value model.Value) model.Value {
+	switch v := value.(type) {
+	case model.Vector:
+		for _, sample := range v {
+			sample.Metric = o.translateMetric(sample.Metric)
+		}
+	case model.Matrix:
+		for _, stream := range v {
+			stream.Metric = o.translateMetric(stream.Metric)
+		}
+	}
+	return value
+}
+
+func (o *otelAPI) translateMetric(metric model.Metric) model.Metric {
+	translated := make(model.Metric, len(metric))
+	for name, value := range metric {
+		if name == model.MetricNameLabel {
+			value = model.LabelValue(o.metricNameBack(string(value)))
+		}
+		if promName, ok := o.labelsBack[name]; ok {
+			name = promName
+		}
+		translated[name] = value
+	}
+	return translated
+}
+
+func endOfString(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		if query[i] == '\\' && quote != '`' {
+			i++
+		} else if query[i] == quote {
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+func nextNonSpace(query string, start int) int {
+	for start < len(query) && (query[start] == ' ' || query[start] == '\t' || query[start] == '\n') {
+		start++
+	}
+	return start
+}
+
+func quoteName(name string) string {
+	if isValidName(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `\"`) + `"`
+}
+
+func isValidName(name string) bool {
+	if name == "" || !isNameStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isNameChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -1,6 +1,9 @@
 package prometheustest
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -8,6 +11,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/prometheus"
@@ -56,6 +60,66 @@ func TestGetAllRequestRates(t *testing.T) {
 	assert.Equal(t, vectorQ2[0], rates[1])
 }
 
+func TestGetNamespaceInboundTCPRates(t *testing.T) {
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+
+	connections := model.Vector{
+		&model.Sample{
+			Timestamp: model.Now(),
+			Value:     model.SampleValue(0.5),
+			Metric:    model.Metric{"connection_security_policy": "none"},
+		},
+	}
+	api.OnQueryTime(`sum(rate(istio_tcp_connections_opened_total{reporter="destination",destination_workload_namespace="ns",destination_cluster="east"}[5m])) by (source_workload_namespace,source_workload,destination_workload,connection_security_policy) > 0`, &queryTime, connections)
+
+	received := model.Vector{
+		&model.Sample{
+			Timestamp: model.Now(),
+			Value:     model.SampleValue(1024),
+			Metric:    model.Metric{"connection_security_policy": "mutual_tls"},
+		},
+	}
+	api.OnQueryTime(`sum(rate(istio_tcp_received_bytes_total{reporter="destination",destination_workload_namespace="ns",destination_cluster="east"}[5m])) by (source_workload_namespace,source_workload,destination_workload,connection_security_policy) > 0`, &queryTime, received)
+
+	connectionRates, bytesRates, err := client.GetNamespaceInboundTCPRates("ns", "east", "5m", queryTime)
+	assert.NoError(t, err)
+	assert.Equal(t, connections, connectionRates)
+	assert.Equal(t, received, bytesRates)
+}
+
+func TestGetNamespaceInboundRequestRates(t *testing.T) {
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// The recording rule of the health doesn't apply
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.HealthRecordingRules = map[string]string{"5m": "istio:requests:rate5m"}
+	config.Set(conf)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+
+	requests := model.Vector{
+		&model.Sample{
+			Timestamp: model.Now(),
+			Value:     model.SampleValue(2),
+			Metric:    model.Metric{"connection_security_policy": "none"},
+		},
+	}
+	api.OnQueryTime(`sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="ns",destination_cluster="east"}[5m])) by (source_workload_namespace,source_workload,destination_workload,connection_security_policy) > 0`, &queryTime, requests)
+
+	rates, err := client.GetNamespaceInboundRequestRates("ns", "east", "5m", queryTime)
+	assert.NoError(t, err)
+	assert.Equal(t, requests, rates)
+}
+
 func TestGetAllRequestRatesIstioSystem(t *testing.T) {
 	client, api, err := setupMocked()
 	if err != nil {
@@ -88,6 +152,99 @@ func TestGetAllRequestRatesIstioSystem(t *testing.T) {
 	assert.Equal(t, vectorQ2[0], rates[1])
 }
 
+func TestGetAllRequestRatesSharded(t *testing.T) {
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.HealthQuerySharding = config.HealthQuerySharding{Enabled: true, ShardSize: 2, Concurrency: 2}
+	config.Set(conf)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+
+	sample := func(value float64) model.Vector {
+		return model.Vector{&model.Sample{Timestamp: model.Now(), Value: model.SampleValue(value), Metric: model.Metric{"foo": "bar"}}}
+	}
+	api.OnQueryTime(`rate(istio_requests_total{destination_service_namespace="ns",source_workload_namespace!="ns",destination_cluster="east",destination_workload=~"details|productpage"}[5m]) > 0`, &queryTime, sample(1))
+	api.OnQueryTime(`rate(istio_requests_total{source_workload_namespace="ns",source_cluster="east",source_workload=~"details|productpage"}[5m]) > 0`, &queryTime, sample(2))
+	api.OnQueryTime(`rate(istio_requests_total{destination_service_namespace="ns",source_workload_namespace!="ns",destination_cluster="east",destination_workload=~"reviews\\.v1"}[5m]) > 0`, &queryTime, sample(3))
+	api.OnQueryTime(`rate(istio_requests_total{source_workload_namespace="ns",source_cluster="east",source_workload=~"reviews\\.v1"}[5m]) > 0`, &queryTime, sample(4))
+
+	rates, err := client.GetAllRequestRatesSharded("ns", "east", "workload", []string{"reviews.v1", "productpage", "details"}, "5m", queryTime)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, rates.Len())
+	for i, rate := range rates {
+		assert.Equal(t, model.SampleValue(i+1), rate.Value)
+	}
+}
+
+func TestGetNamespacesRequestRates(t *testing.T) {
+	require := require.New(t)
+	client, api, err := setupMocked()
+	require.NoError(err)
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.HealthQueryBatching = config.HealthQueryBatching{Enabled: true, BatchSize: 2}
+	config.Set(conf)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+
+	sample := func(value float64, source, destination string) *model.Sample {
+		return &model.Sample{Value: model.SampleValue(value), Metric: model.Metric{"source_workload_namespace": model.LabelValue(source), "destination_service_namespace": model.LabelValue(destination)}}
+	}
+	api.OnQueryTime(`rate(istio_requests_total{destination_service_namespace=~"bookinfo|istio-system",destination_cluster="east"}[3m]) > 0`, &queryTime, model.Vector{sample(1, "istio-system", "bookinfo"), sample(2, "bookinfo", "bookinfo")})
+	api.OnQueryTime(`rate(istio_requests_total{source_workload_namespace=~"bookinfo|istio-system",source_cluster="east"}[3m]) > 0`, &queryTime, model.Vector{sample(2, "bookinfo", "bookinfo"), sample(1, "istio-system", "bookinfo")})
+	api.OnQueryTime(`rate(istio_requests_total{destination_service_namespace=~"travel\\.agency",destination_cluster="east"}[3m]) > 0`, &queryTime, model.Vector{})
+	api.OnQueryTime(`rate(istio_requests_total{source_workload_namespace=~"travel\\.agency",source_cluster="east"}[3m]) > 0`, &queryTime, model.Vector{sample(3, "travel.agency", "bookinfo")})
+
+	rates, err := client.GetNamespacesRequestRates([]string{"istio-system", "travel.agency", "bookinfo"}, "east", "3m", queryTime)
+	require.NoError(err)
+	// The requests from the namespace to itself are not counted twice
+	assert.Equal(t, model.Vector{sample(1, "istio-system", "bookinfo"), sample(2, "bookinfo", "bookinfo")}, rates["bookinfo"])
+	assert.Equal(t, model.Vector{sample(1, "istio-system", "bookinfo")}, rates["istio-system"])
+	assert.Equal(t, model.Vector{sample(3, "travel.agency", "bookinfo")}, rates["travel.agency"])
+	api.AssertNumberOfCalls(t, "Query", 4)
+}
+
+func TestGetRequestRatesWithRecordingRule(t *testing.T) {
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.CacheEnabled = false
+	conf.ExternalServices.Prometheus.HealthRecordingRules = map[string]string{"5m": "istio:requests:rate5m"}
+	config.Set(conf)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+
+	vectorQ1 := model.Vector{
+		&model.Sample{
+			Timestamp: model.Now(),
+			Value:     model.SampleValue(1),
+			Metric:    model.Metric{"foo": "bar"},
+		},
+	}
+	api.OnQueryTime(`istio:requests:rate5m{destination_service_name="svc",destination_service_namespace="ns",destination_cluster="east"} > 0`, &queryTime, vectorQ1)
+	// Intervals without a recording rule keep querying the raw counters
+	api.OnQueryTime(`rate(istio_requests_total{destination_service_name="svc",destination_service_namespace="ns",destination_cluster="east"}[1m]) > 0`, &queryTime, vectorQ1)
+
+	rates, err := client.GetServiceRequestRates("ns", "east", "svc", "5m", queryTime)
+	assert.NoError(t, err)
+	assert.Equal(t, vectorQ1, rates)
+	rates, err = client.GetServiceRequestRates("ns", "east", "svc", "1m", queryTime)
+	assert.NoError(t, err)
+	assert.Equal(t, vectorQ1, rates)
+
+	// The rates of the other features keep querying the raw counters
+	api.OnQueryTime(`rate(istio_requests_total{reporter="source",destination_service_name="PassthroughCluster"}[5m]) > 0`, &queryTime, vectorQ1)
+	rates, err = client.GetPassthroughRequestRates("5m", queryTime)
+	assert.NoError(t, err)
+	assert.Equal(t, vectorQ1, rates)
+}
+
 func TestGetNamespaceServicesRequestRates(t *testing.T) {
 	client, api, err := setupMocked()
 	if err != nil {
@@ -111,6 +268,87 @@ func TestGetNamespaceServicesRequestRates(t *testing.T) {
 	assert.Equal(t, vectorQ1[0], rates[0])
 }
 
+func TestQueryHintsAndWarnings(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	var params url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(r.ParseForm())
+		params = r.Form
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]},"warnings":["partial response: store unavailable"]}`))
+	}))
+	defer server.Close()
+
+	conf := config.NewConfig().ExternalServices.Prometheus
+	conf.URL = server.URL
+	conf.QueryHints = config.PrometheusQueryHints{
+		Dedup:               "true",
+		ExtraParams:         map[string]string{"engine": "thanos"},
+		MaxSourceResolution: "5m",
+		PartialResponse:     "true",
+	}
+	client, err := prometheus.NewClientForConfig(conf)
+	require.NoError(err)
+
+	q := prometheus.RangeQuery{}
+	q.FillDefaults()
+	metric := client.FetchRange("istio_requests_total", `{reporter="source"}`, "", "sum", &q)
+
+	require.NoError(metric.Err)
+	assert.Equal(`sum(istio_requests_total{reporter="source"})`, params.Get("query"))
+	assert.Equal("true", params.Get("dedup"))
+	assert.Equal("thanos", params.Get("engine"))
+	assert.Equal("5m", params.Get("max_source_resolution"))
+	assert.Equal("true", params.Get("partial_response"))
+	assert.Equal([]string{"partial response: store unavailable"}, metric.Warnings)
+}
+
+func TestOTelProvider(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.Provider = config.OTelProvider
+	conf.ExternalServices.Prometheus.OTel = config.OTelMetricsConfig{
+		LabelNames:   map[string]string{"destination_service_name": "destination.service.name", "response_code": "http.response.status_code"},
+		MetricNames:  map[string]string{"istio_request_bytes_bucket": "istio.request.bytes_bucket"},
+		MetricPrefix: "otel_",
+	}
+	config.Set(conf)
+
+	api := new(PromAPIMock)
+	client, err := prometheus.NewClient()
+	assert.NoError(err)
+	client.Inject(api)
+
+	// Not to hit the results cached by other tests
+	queryTime := time.Date(2017, 01, 16, 0, 0, 0, 0, time.UTC)
+	vector := model.Vector{
+		&model.Sample{
+			Timestamp: model.Now(),
+			Value:     model.SampleValue(1),
+			Metric:    model.Metric{"__name__": "otel_istio_requests_total", "destination.service.name": "reviews", "http.response.status_code": "200"},
+		},
+	}
+	api.OnQueryTime(`rate(otel_istio_requests_total{"destination.service.name"="reviews",destination_service_namespace="ns",destination_cluster="east"}[5m]) > 0`, &queryTime, vector)
+
+	rates, err := client.GetServiceRequestRates("ns", "east", "reviews", "5m", queryTime)
+	assert.NoError(err)
+	assert.Equal(1, rates.Len())
+	// Labels are named back as in Prometheus
+	assert.Equal(model.Metric{"__name__": "istio_requests_total", "destination_service_name": "reviews", "response_code": "200"}, rates[0].Metric)
+
+	// Names that are not valid in Prometheus are quoted, strings are left untouched
+	api.OnQueryRange(`histogram_quantile(0.5, sum(rate({"istio.request.bytes_bucket",reporter="source",source_workload="a.b"}[1m])) by (le,"http.response.status_code"))`, nil, model.Matrix{})
+	q := prometheus.RangeQuery{}
+	q.FillDefaults()
+	q.Avg = false
+	q.Quantiles = []string{"0.5"}
+	histogram := client.FetchHistogramRange("istio_request_bytes", `{reporter="source",source_workload="a.b"}`, "response_code", &q)
+	assert.NoError(histogram["0.5"].Err)
+}
+
 func TestConfig(t *testing.T) {
 	client, api, err := setupMocked()
 	if err != nil {
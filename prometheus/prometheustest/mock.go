@@ -309,6 +309,11 @@ func (o *PromClientMock) GetAllRequestRates(namespace, cluster, ratesInterval st
 	return args.Get(0).(model.Vector), args.Error(1)
 }
 
+func (o *PromClientMock) GetAllRequestRatesSharded(namespace, cluster, itemLabelSuffix string, items []string, ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	args := o.Called(namespace, cluster, itemLabelSuffix, items, ratesInterval, queryTime)
+	return args.Get(0).(model.Vector), args.Error(1)
+}
+
 func (o *PromClientMock) GetConfiguration() (prom_v1.ConfigResult, error) {
 	args := o.Called()
 	return args.Get(0).(prom_v1.ConfigResult), args.Error(1)
@@ -319,11 +324,36 @@ func (o *PromClientMock) GetFlags() (prom_v1.FlagsResult, error) {
 	return args.Get(0).(prom_v1.FlagsResult), args.Error(1)
 }
 
+func (o *PromClientMock) GetNamespacesRequestRates(namespaces []string, cluster, ratesInterval string, queryTime time.Time) (map[string]model.Vector, error) {
+	args := o.Called(namespaces, cluster, ratesInterval, queryTime)
+	return args.Get(0).(map[string]model.Vector), args.Error(1)
+}
+
+func (o *PromClientMock) GetNamespaceInboundRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	args := o.Called(namespace, cluster, ratesInterval, queryTime)
+	return args.Get(0).(model.Vector), args.Error(1)
+}
+
+func (o *PromClientMock) GetNamespaceInboundTCPRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error) {
+	args := o.Called(namespace, cluster, ratesInterval, queryTime)
+	return args.Get(0).(model.Vector), args.Get(1).(model.Vector), args.Error(2)
+}
+
 func (o *PromClientMock) GetNamespaceServicesRequestRates(namespace, cluster, ratesInterval string, queryTime time.Time) (model.Vector, error) {
 	args := o.Called(namespace, cluster, ratesInterval, queryTime)
 	return args.Get(0).(model.Vector), args.Error(1)
 }
 
+func (o *PromClientMock) GetPassthroughRequestRates(ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	args := o.Called(ratesInterval, queryTime)
+	return args.Get(0).(model.Vector), args.Error(1)
+}
+
+func (o *PromClientMock) GetWaypointRequestRates(namespace, cluster, waypoint, ratesInterval string, queryTime time.Time) (model.Vector, error) {
+	args := o.Called(namespace, cluster, waypoint, ratesInterval, queryTime)
+	return args.Get(0).(model.Vector), args.Error(1)
+}
+
 func (o *PromClientMock) GetAppRequestRates(namespace, cluster, app, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error) {
 	args := o.Called(namespace, cluster, app, ratesInterval, queryTime)
 	return args.Get(0).(model.Vector), args.Get(1).(model.Vector), args.Error(2)
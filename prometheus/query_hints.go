@@ -0,0 +1,87 @@
+package prometheus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/client_golang/api"
+
+	"github.com/kiali/kiali/config"
+)
+
+// Parameters of the query API of Thanos (also understood by other Prometheus-compatible backends).
+const (
+	dedupParam               = "dedup"
+	maxSourceResolutionParam = "max_source_resolution"
+	partialResponseParam     = "partial_response"
+)
+
+// queryHintsClient adds the configured query hints to the instant and range queries sent to the backend.
+type queryHintsClient struct {
+	api.Client
+	params url.Values
+}
+
+// newQueryHintsClient wraps the client when any query hint is configured.
+func newQueryHintsClient(client api.Client, hints config.PrometheusQueryHints) api.Client {
+	params := url.Values{}
+	for name, value := range hints.ExtraParams {
+		params.Set(name, value)
+	}
+	if hints.Dedup != "" {
+		params.Set(dedupParam, hints.Dedup)
+	}
+	if hints.MaxSourceResolution != "" {
+		params.Set(maxSourceResolutionParam, hints.MaxSourceResolution)
+	}
+	if hints.PartialResponse != "" {
+		params.Set(partialResponseParam, hints.PartialResponse)
+	}
+
+	if len(params) == 0 {
+		return client
+	}
+	return &queryHintsClient{Client: client, params: params}
+}
+
+// Do adds the hints to the form of the POST queries, or to the URL of the GET queries.
+func (c *queryHintsClient) Do(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	if !strings.HasSuffix(req.URL.Path, "/query") && !strings.HasSuffix(req.URL.Path, "/query_range") {
+		return c.Client.Do(ctx, req)
+	}
+
+	if req.Method == http.MethodPost && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, nil, err
+		}
+		c.addParams(form)
+		encoded := form.Encode()
+		req.Body = io.NopCloser(strings.NewReader(encoded))
+		req.ContentLength = int64(len(encoded))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(encoded)), nil
+		}
+	} else {
+		query := req.URL.Query()
+		c.addParams(query)
+		req.URL.RawQuery = query.Encode()
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// addParams sets the hints not already part of the query.
+func (c *queryHintsClient) addParams(values url.Values) {
+	for name, value := range c.params {
+		if _, found := values[name]; !found {
+			values[name] = value
+		}
+	}
+}
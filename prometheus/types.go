@@ -37,6 +37,8 @@ type Metrics struct {
 type Metric struct {
 	Matrix model.Matrix `json:"matrix"`
 	Err    error        `json:"-"`
+	// Warnings returned along with the data e.g. when a Thanos store was unavailable and the data is partial
+	Warnings []string `json:"-"`
 }
 
 // Histogram contains Metric objects for several histogram-kind statistics
@@ -0,0 +1,162 @@
+package routing
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// The reasons of the requests rejected by the rate limits
+const (
+	rateLimitedRate        = "rate"
+	rateLimitedConcurrency = "concurrency"
+)
+
+// The limits of the users without requests for this long are forgotten
+const rateLimitIdleTimeout = 10 * time.Minute
+
+// expensiveRoutes are the routes querying Prometheus heavily or running the validations, whose concurrent requests
+// are limited per user
+var expensiveRoutes = map[string]bool{
+	"AggregateMetrics":           true,
+	"AppDashboard":               true,
+	"AppMetrics":                 true,
+	"ClustersMetrics":            true,
+	"CompositeQuery":             true,
+	"ConfigValidationSummary":    true,
+	"CustomDashboard":            true,
+	"GraphAggregate":             true,
+	"GraphAggregateByService":    true,
+	"GraphApp":                   true,
+	"GraphAppVersion":            true,
+	"GraphNamespaces":            true,
+	"GraphService":               true,
+	"GraphWorkload":              true,
+	"MeshGraph":                  true,
+	"NamespaceMetrics":           true,
+	"NamespaceValidationSummary": true,
+	"ServiceDashboard":           true,
+	"ServiceMetrics":             true,
+	"WorkloadDashboard":          true,
+	"WorkloadMetrics":            true,
+}
+
+// rateLimiter enforces the request rate and the concurrent expensive requests of each user
+type rateLimiter struct {
+	conf      config.RateLimit
+	lock      sync.Mutex
+	users     map[string]*userLimits
+	lastSweep time.Time
+}
+
+type userLimits struct {
+	limiter   *rate.Limiter
+	expensive int
+	lastSeen  time.Time
+}
+
+func newRateLimiter(conf config.RateLimit) *rateLimiter {
+	return &rateLimiter{
+		conf:      conf,
+		users:     map[string]*userLimits{},
+		lastSweep: time.Now(),
+	}
+}
+
+// handler rejects the requests of a route over the limits of the user with a 429
+func (rl *rateLimiter) handler(next http.Handler, route Route) http.Handler {
+	expensive := expensiveRoutes[route.Name]
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := rateLimitKey(r)
+		allowed, reason, retryAfter := rl.acquire(user, expensive)
+		if !allowed {
+			internalmetrics.GetRateLimitedMetric(route.Name, reason).Inc()
+			log.FromContext(r.Context()).Debug().Msgf("Request rejected by the %s limit of the user", reason)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			handlers.RespondWithError(w, http.StatusTooManyRequests, fmt.Sprintf("Too many requests, retry in %s", retryAfter.Round(time.Second)))
+			return
+		}
+		if expensive {
+			defer rl.release(user)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acquire checks the limits of a user for a request. It returns false when the request is over a limit, with the
+// reason and when to retry.
+func (rl *rateLimiter) acquire(user string, expensive bool) (bool, string, time.Duration) {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(rl.lastSweep) > rateLimitIdleTimeout {
+		for key, limits := range rl.users {
+			if limits.expensive == 0 && now.Sub(limits.lastSeen) > rateLimitIdleTimeout {
+				delete(rl.users, key)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	limits, ok := rl.users[user]
+	if !ok {
+		limits = &userLimits{limiter: rate.NewLimiter(rate.Limit(rl.conf.RequestsPerSecond), rl.conf.Burst)}
+		rl.users[user] = limits
+	}
+	limits.lastSeen = now
+
+	if expensive && rl.conf.MaxConcurrentExpensive > 0 && limits.expensive >= rl.conf.MaxConcurrentExpensive {
+		return false, rateLimitedConcurrency, time.Second
+	}
+	if rl.conf.RequestsPerSecond > 0 {
+		reservation := limits.limiter.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			return false, rateLimitedRate, delay
+		}
+	}
+	if expensive {
+		limits.expensive++
+	}
+	return true, "", 0
+}
+
+func (rl *rateLimiter) release(user string) {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	if limits, ok := rl.users[user]; ok && limits.expensive > 0 {
+		limits.expensive--
+	}
+}
+
+// rateLimitKey identifies the user of a request by the user of its authenticated session. The anonymous strategy and
+// openid without RBAC share the token of Kiali between the users, the hash of the token only tells apart the users of
+// the token strategy whose tokens have no subject. The requests without session user are keyed by their address.
+func rateLimitKey(r *http.Request) string {
+	user := authentication.GetUserContext(r.Context())
+	if user == "" {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		return "address:" + host
+	}
+	if authInfo, ok := authentication.GetAuthInfoContext(r.Context()).(*api.AuthInfo); ok && authInfo.Token != "" {
+		return "user:" + user + "/token:" + log.HashSubject(authInfo.Token)
+	}
+	return "user:" + user
+}
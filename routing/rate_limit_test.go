@@ -0,0 +1,99 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+)
+
+// requestOfUser is a request of the session of the user, authenticated with the token
+func requestOfUser(user, token string) *http.Request {
+	req := httptest.NewRequest("GET", "/api/namespaces/bookinfo/graph", nil)
+	ctx := authentication.SetAuthInfoContext(req.Context(), &api.AuthInfo{Token: token})
+	return req.WithContext(authentication.SetUserContext(ctx, user))
+}
+
+func TestRateLimiterRequestRate(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newRateLimiter(config.RateLimit{Enabled: true, RequestsPerSecond: 0.001, Burst: 2})
+	handler := limiter.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), Route{Name: "NamespaceList"})
+
+	for i := 0; i < 2; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, requestOfUser("alice", "alice-token"))
+		assert.Equal(http.StatusOK, rr.Code)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestOfUser("alice", "alice-token"))
+	assert.Equal(http.StatusTooManyRequests, rr.Code)
+	assert.NotEmpty(rr.Header().Get("Retry-After"))
+
+	// The limits are per user
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestOfUser("bob", "bob-token"))
+	assert.Equal(http.StatusOK, rr.Code)
+}
+
+func TestRateLimiterConcurrentExpensiveRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newRateLimiter(config.RateLimit{Enabled: true, MaxConcurrentExpensive: 1})
+	started, release := make(chan struct{}), make(chan struct{})
+	graph := limiter.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	}), Route{Name: "GraphNamespaces"})
+
+	done := make(chan int)
+	go func() {
+		rr := httptest.NewRecorder()
+		graph.ServeHTTP(rr, requestOfUser("alice", "alice-token"))
+		done <- rr.Code
+	}()
+	<-started
+
+	rr := httptest.NewRecorder()
+	graph.ServeHTTP(rr, requestOfUser("alice", "alice-token"))
+	assert.Equal(http.StatusTooManyRequests, rr.Code)
+
+	// Not an expensive route
+	namespaces := limiter.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), Route{Name: "NamespaceList"})
+	rr = httptest.NewRecorder()
+	namespaces.ServeHTTP(rr, requestOfUser("alice", "alice-token"))
+	assert.Equal(http.StatusOK, rr.Code)
+
+	close(release)
+	assert.Equal(http.StatusOK, <-done)
+
+	// Released
+	go func() { <-started }()
+	rr = httptest.NewRecorder()
+	graph.ServeHTTP(rr, requestOfUser("alice", "alice-token"))
+	assert.Equal(http.StatusOK, rr.Code)
+}
+
+func TestRateLimitKey(t *testing.T) {
+	assert := assert.New(t)
+
+	// openid without RBAC: the users share the token of Kiali
+	assert.NotEqual(rateLimitKey(requestOfUser("alice", "kiali-token")), rateLimitKey(requestOfUser("bob", "kiali-token")))
+	// token strategy: the tokens without subject have the same user
+	assert.NotEqual(rateLimitKey(requestOfUser("token", "alice-token")), rateLimitKey(requestOfUser("token", "bob-token")))
+
+	// anonymous: the requests are told apart by their address, the user header is ignored
+	alice := requestOfUser("", "kiali-token")
+	alice.RemoteAddr = "10.0.0.1:1234"
+	alice.Header.Set("Kiali-User", "bob")
+	bob := requestOfUser("", "kiali-token")
+	bob.RemoteAddr = "10.0.0.2:1234"
+	bob.Header.Set("Kiali-User", "bob")
+	assert.Equal("address:10.0.0.1", rateLimitKey(alice))
+	assert.NotEqual(rateLimitKey(alice), rateLimitKey(bob))
+}
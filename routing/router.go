@@ -1,9 +1,11 @@
 package routing
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	hpprof "net/http/pprof"
 	"os"
@@ -111,13 +113,13 @@ func NewRouter(
 
 	// Build our API server routes and install them.
 	apiRoutes := NewRoutes(conf, kialiCache, clientFactory, prom, traceClientLoader, cpm, authController, grafana)
-	authenticationHandler := handlers.NewAuthenticationHandler(*conf, authController, clientFactory.GetSAHomeClusterClient())
+	authenticationHandler := handlers.NewAuthenticationHandler(*conf, authController, clientFactory)
 
 	allRoutes := apiRoutes.Routes
 
-	// Add the Profiler handlers if enabled
+	// Add the Profiler and the diagnostics handlers if enabled
 	if conf.Server.Profiler.Enabled {
-		log.Infof("Profiler is enabled")
+		log.Infof("Profiler and diagnostics are enabled for the users allowed to exec into the Kiali pods")
 		allRoutes = append(allRoutes,
 			Route{
 				Method:        "GET",
@@ -154,6 +156,27 @@ func NewRouter(
 				HandlerFunc:   hpprof.Trace,
 				Authenticated: true,
 			},
+			Route{
+				Method:        "GET",
+				Name:          "Diagnostics Runtime",
+				Pattern:       "/debug/diagnostics/runtime",
+				HandlerFunc:   handlers.DiagnosticsRuntime,
+				Authenticated: true,
+			},
+			Route{
+				Method:        "GET",
+				Name:          "Diagnostics Goroutines",
+				Pattern:       "/debug/diagnostics/goroutines",
+				HandlerFunc:   handlers.DiagnosticsGoroutines,
+				Authenticated: true,
+			},
+			Route{
+				Method:        "GET",
+				Name:          "Diagnostics Cache",
+				Pattern:       "/debug/diagnostics/cache",
+				HandlerFunc:   handlers.DiagnosticsCache(kialiCache),
+				Authenticated: true,
+			},
 		)
 		for _, p := range rpprof.Profiles() {
 			allRoutes = append(allRoutes,
@@ -166,10 +189,24 @@ func NewRouter(
 				},
 			)
 		}
+		for i := len(apiRoutes.Routes); i < len(allRoutes); i++ {
+			allRoutes[i].HandlerFunc = handlers.KialiAdminOnly(conf, clientFactory, allRoutes[i].HandlerFunc)
+		}
+	}
+
+	var limiter *rateLimiter
+	if conf.Server.RateLimit.Enabled {
+		log.Infof("Rate limiting the API requests of each user")
+		limiter = newRateLimiter(conf.Server.RateLimit)
 	}
 
 	for _, route := range allRoutes {
 		handlerFunction := metricHandler(route.HandlerFunc, route)
+		if limiter != nil && route.Authenticated {
+			handlerFunction = limiter.handler(handlerFunction, route)
+		}
+		// Within the authentication handler, which sets the user of the request
+		handlerFunction = requestLogHandler(handlerFunction, route)
 		if route.Authenticated {
 			handlerFunction = authenticationHandler.Handle(handlerFunction)
 		} else {
@@ -224,6 +261,21 @@ func (srw *statusResponseWriter) WriteHeader(code int) {
 	srw.StatusCode = code
 }
 
+// Hijack lets the handlers take over the connection, as the WebSocket upgrades do
+func (srw *statusResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := srw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("the response writer does not support hijacking")
+	}
+	srw.StatusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// Unwrap lets the handlers reach the underlying ResponseWriter through an http.ResponseController
+func (srw *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return srw.ResponseWriter
+}
+
 // updateMetric evaluates the StatusCode, if there is an error, increase the API failure counter, otherwise save the duration
 func updateMetric(route string, srw *statusResponseWriter, timer *prometheus.Timer) {
 	// Always measure the duration even if the API call ended in an error
@@ -247,6 +299,26 @@ func metricHandler(next http.Handler, route Route) http.Handler {
 	})
 }
 
+// requestLogHandler sets the logger of the request in its context: the log lines of the handler and of the business
+// calls, logged with log.FromContext, have the ID of the request, its user, cluster and route. The ID of the request
+// is returned in the X-Request-Id header.
+func requestLogHandler(next http.Handler, route Route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(log.RequestIDHeader)
+		if requestID == "" {
+			requestID = log.NewRequestID()
+		}
+		w.Header().Set(log.RequestIDHeader, requestID)
+		ctx := log.WithRequest(r.Context(), log.RequestInfo{
+			RequestID: requestID,
+			User:      r.Header.Get("Kiali-User"),
+			Cluster:   r.URL.Query().Get("clusterName"),
+			Route:     route.Name,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // serveEnvJsFile generates the env.js file needed by the UI from Kiali configs. The
 // generated file is sent to the HTTP response.
 func serveEnvJsFile(w http.ResponseWriter) {
@@ -1,6 +1,7 @@
 package routing
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
 
@@ -113,6 +115,13 @@ func TestProfilerRoute(t *testing.T) {
 		}
 		assert.Equal(t, 400, resp.StatusCode, "pprof endpoint [%v] should exist but needed credentials", p)
 	}
+	for _, p := range []string{"runtime", "goroutines", "cache"} {
+		resp, err = http.Get(ts.URL + "/debug/diagnostics/" + p)
+		if err != nil {
+			t.Fatalf("Failed to get diagnostics [%v]: %v", p, err)
+		}
+		assert.Equal(t, 400, resp.StatusCode, "diagnostics endpoint [%v] should exist but needed credentials", p)
+	}
 }
 
 func TestDisabledProfilerRoute(t *testing.T) {
@@ -144,6 +153,11 @@ func TestDisabledProfilerRoute(t *testing.T) {
 		}
 		assert.Equal(t, 404, resp.StatusCode, "pprof should have been disabled [%v]", p)
 	}
+	resp, err = http.Get(ts.URL + "/debug/diagnostics/runtime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 404, resp.StatusCode, "diagnostics should have been disabled")
 }
 
 func TestRedirectWithSetWebRootKeepsParams(t *testing.T) {
@@ -229,3 +243,24 @@ func TestMetricHandlerAPIFailures(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestLogHandler(t *testing.T) {
+	var ctx context.Context
+	handler := requestLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}), Route{Name: "ServiceDetails"})
+
+	req := httptest.NewRequest("GET", "/api/namespaces/bookinfo/services/reviews?clusterName=east", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	requestID := rr.Header().Get("X-Request-Id")
+	assert.Len(t, requestID, 32)
+	assert.NotSame(t, log.FromContext(context.Background()), log.FromContext(ctx))
+
+	// The ID of the caller is kept
+	req = httptest.NewRequest("GET", "/api/namespaces/bookinfo/services/reviews", nil)
+	req.Header.Set("X-Request-Id", "1234")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, "1234", rr.Header().Get("X-Request-Id"))
+}
@@ -234,6 +234,46 @@ func NewRoutes(
 			handlers.IstioConfigPermissions,
 			true,
 		},
+		// swagger:route GET /istio/schemas config istioConfigSchemas
+		// ---
+		// Endpoint to get the schemas of the Istio and Gateway API kinds of a cluster, read from their CRDs
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: istioConfigSchemas
+		{
+			"IstioConfigSchemas",
+			"GET",
+			"/api/istio/schemas",
+			handlers.IstioConfigSchemas,
+			true,
+		},
+		// swagger:route GET /istio/localities config istioLocalities
+		// ---
+		// Endpoint to get the localities (region, zone and subzone) of the nodes of every cluster, read from their
+		// topology labels
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200: istioLocalities
+		{
+			"IstioLocalities",
+			"GET",
+			"/api/istio/localities",
+			handlers.IstioLocalities,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/istio config istioConfigList
 		// ---
 		// Endpoint to get the list of Istio Config of a namespace
@@ -254,7 +294,28 @@ func NewRoutes(
 			handlers.IstioConfigList,
 			true,
 		},
-		// swagger:route GET /istio config istioConfigListAll
+		// swagger:route GET /namespaces/{namespace}/authorization/coverage config authorizationCoverage
+		// ---
+		// Endpoint to get how the workloads of a namespace are covered by the AuthorizationPolicies
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: authorizationCoverageResponse
+		//
+		{
+			"AuthorizationCoverage",
+			"GET",
+			"/api/namespaces/{namespace}/authorization/coverage",
+			handlers.AuthorizationCoverage,
+			true,
+		},
+		// swagger:route GET /istio/config config istioConfigListAll
 		// ---
 		// Endpoint to get the list of Istio Config of all namespaces
 		//
@@ -296,6 +357,50 @@ func NewRoutes(
 			handlers.IstioConfigDetails,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object}/impact config istioConfigImpact
+		// ---
+		// Endpoint to get the Istio objects referencing an Istio object, those that could break if it was deleted
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: referenceImpactResponse
+		//
+		{
+			"IstioConfigImpact",
+			"GET",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/impact",
+			handlers.IstioConfigImpact,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object}/deletion_impact config istioConfigDeletionImpact
+		// ---
+		// Endpoint to get the services, workloads and configs that would lose routing, mTLS or authz behavior if an Istio object was deleted
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: deletionImpactResponse
+		//
+		{
+			"IstioConfigDeletionImpact",
+			"GET",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/deletion_impact",
+			handlers.IstioConfigDeletionImpact,
+			true,
+		},
 		// swagger:route DELETE /namespaces/{namespace}/istio/{object_type}/{object} config istioConfigDelete
 		// ---
 		// Endpoint to delete the Istio Config of an (arbitrary) Istio object
@@ -431,6 +536,55 @@ func NewRoutes(
 			handlers.ServiceUpdate,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/wizards/traffic_mirroring services serviceTrafficMirroring
+		// ---
+		// Endpoint to generate the VirtualService and the DestinationRule mirroring the requests of a service to a subset
+		// of the service or to another host. The generated config is not persisted.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: wizardScenarioResponse
+		//
+		{
+			"ServiceTrafficMirroring",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/wizards/traffic_mirroring",
+			handlers.ServiceTrafficMirroring,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/services/{service}/wizards/locality_lb services serviceLocalityLoadBalancing
+		// ---
+		// Endpoint to generate the DestinationRule balancing the requests of a service across the localities of the
+		// mesh, with a distribution or a failover. The generated config is not persisted.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: wizardScenarioResponse
+		//
+		{
+			"ServiceLocalityLoadBalancing",
+			"POST",
+			"/api/namespaces/{namespace}/services/{service}/wizards/locality_lb",
+			handlers.ServiceLocalityLoadBalancing,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/apps/{app}/spans traces appSpans
 		// ---
 		// Endpoint to get Tracing spans for a given app
@@ -509,6 +663,28 @@ func NewRoutes(
 			handlers.AppTraces,
 			true,
 		},
+		// swagger:route GET /traces/edge traces edgeTraces
+		// ---
+		// Endpoint to get the traces crossing a graph edge, from a source app, service or workload to a destination one
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      503: serviceUnavailableError
+		//      500: internalError
+		//      200: traceDetailsResponse
+		//
+		{
+			"EdgeTraces",
+			"GET",
+			"/api/traces/edge",
+			handlers.EdgeTraces,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/services/{service}/traces traces serviceTraces
 		// ---
 		// Endpoint to get the traces of a given service
@@ -584,7 +760,7 @@ func NewRoutes(
 		// responses:
 		//      404: notFoundError
 		//      500: internalError
-		//      200: traceDetailsResponse
+		//      200: traceResponse
 		//
 		{
 			"TracesDetails",
@@ -593,6 +769,28 @@ func NewRoutes(
 			handlers.TraceDetails,
 			true,
 		},
+		// swagger:route GET /traces/{traceID}/logs traces traceLogs
+		// ---
+		// Endpoint to get the logs of the pods of the spans of a trace, logged during the spans
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: traceLogsResponse
+		//
+		{
+			"TraceLogs",
+			"GET",
+			"/api/traces/{traceID}/logs",
+			handlers.TraceLogs,
+			true,
+		},
 		// swagger:route GET /clusters/workloads workloads workloadList
 		// ---
 		// Endpoint to get the list of workloads for a cluster
@@ -700,6 +898,31 @@ func NewRoutes(
 			handlers.AppDetails,
 			true,
 		},
+		// swagger:route POST /query query compositeQuery
+		// ---
+		// Endpoint to run the queries of services, workloads, apps and validations in one round trip, with only the
+		// selected fields. Each query has its own status: it is only answered when the user has access to its namespace.
+		//
+		//     Consumes:
+		//	   - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: compositeQueryResponse
+		//
+		{
+			"CompositeQuery",
+			"POST",
+			"/api/query",
+			handlers.CompositeQuery,
+			true,
+		},
 		// swagger:route GET /namespaces namespaces namespaceList
 		// ---
 		// Endpoint to get the list of the available namespaces
@@ -720,6 +943,27 @@ func NewRoutes(
 			handlers.NamespaceList,
 			true,
 		},
+		// swagger:route GET /namespaces/access namespaces namespaceAccessAudit
+		// ---
+		// Endpoint to audit the access of the user to the namespaces: per cluster, the namespaces visible to the user
+		// and the ones filtered out, with the decisions of the Kiali config, the discovery selectors and the RBAC.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200: namespaceAccessAuditResponse
+		//
+		{
+			"NamespaceAccessAudit",
+			"GET",
+			"/api/namespaces/access",
+			handlers.NamespaceAccessAudit,
+			true,
+		},
 		// swagger:route PATCH /namespaces/{namespace} namespaces namespaceUpdate
 		// ---
 		// Endpoint to update the Namespace configuration using Json Merge Patch strategy.
@@ -745,6 +989,50 @@ func NewRoutes(
 			handlers.NamespaceUpdate,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/readiness namespaces namespaceMeshReadiness
+		// ---
+		// Endpoint to evaluate the checklist of the onboarding of a namespace into the mesh: the injection label or the
+		// ambient enrollment, the telemetry, the default deny policy, mTLS and the Sidecar, with hints to fix the failed checks.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: namespaceMeshReadinessResponse
+		//
+		{
+			"NamespaceMeshReadiness",
+			"GET",
+			"/api/namespaces/{namespace}/readiness",
+			handlers.NamespaceMeshReadiness,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/meshconfig namespaces namespaceEffectiveMeshConfig
+		// ---
+		// Endpoint to resolve the meshConfig values the proxies of a namespace run with: the outbound traffic policy,
+		// the protocol detection timeout, the access logs and the trace sampling, from the configmap of their revision.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: effectiveMeshConfigResponse
+		//
+		{
+			"NamespaceEffectiveMeshConfig",
+			"GET",
+			"/api/namespaces/{namespace}/meshconfig",
+			handlers.EffectiveMeshConfig,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/info namespaces namespaceInfo
 		// ---
 		// Endpoint to get info about a single namespace
@@ -849,6 +1137,28 @@ func NewRoutes(
 			handlers.WorkloadMetrics,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/waypoint/traffic workloads waypointTraffic
+		// ---
+		// Endpoint to get the requests proxied by a waypoint, broken down per enrolled service
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: waypointTrafficResponse
+		//      400: badRequestError
+		//      404: notFoundError
+		//      503: serviceUnavailableError
+		//
+		{
+			"WaypointTraffic",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/waypoint/traffic",
+			handlers.WaypointTraffic,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/services/{service}/dashboard services serviceDashboard
 		// ---
 		// Endpoint to fetch dashboard to be displayed, related to a single service
@@ -954,9 +1264,10 @@ func NewRoutes(
 			handlers.NamespaceMetrics,
 			true,
 		},
-		// swagger:route GET /clusters/health cluster namespaces Health
+		// swagger:route GET /namespaces/{namespace}/events namespaces namespaceEvents
 		// ---
-		// Get health for all objects in namespaces of the given cluster
+		// Endpoint to fetch the events of a namespace to overlay on its metrics: the deploys, the config changes
+		// made through Kiali and the markers of the users.
 		//
 		//     Produces:
 		//     - application/json
@@ -964,20 +1275,23 @@ func NewRoutes(
 		//     Schemes: http, https
 		//
 		// responses:
-		//      200: clustersNamespaceHealthResponse
 		//      400: badRequestError
-		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: eventsResponse
 		//
 		{
-			"ClustersHealth",
+			"NamespaceEvents",
 			"GET",
-			"/api/clusters/health",
-			handlers.ClustersHealth,
+			"/api/namespaces/{namespace}/events",
+			handlers.NamespaceEvents,
 			true,
 		},
-		// swagger:route GET /namespaces/{namespace}/validations namespaces namespaceValidations
+		// swagger:route POST /namespaces/{namespace}/events namespaces namespaceMarkerAdd
 		// ---
-		// Get validation summary for all objects in the given namespace
+		// Endpoint to add a marker to the events of a namespace.
+		//
+		//     Consumes:
+		//	   - application/json
 		//
 		//     Produces:
 		//     - application/json
@@ -985,20 +1299,20 @@ func NewRoutes(
 		//     Schemes: http, https
 		//
 		// responses:
-		//      200: namespaceValidationSummaryResponse
 		//      400: badRequestError
 		//      500: internalError
+		//      200: markerResponse
 		//
 		{
-			"NamespaceValidationSummary",
-			"GET",
-			"/api/namespaces/{namespace}/validations",
-			handlers.NamespaceValidationSummary,
+			"NamespaceMarkerAdd",
+			"POST",
+			"/api/namespaces/{namespace}/events",
+			handlers.NamespaceMarkerAdd,
 			true,
 		},
-		// swagger:route GET /istio/validations namespaces namespacesValidations
+		// swagger:route GET /clusters/health cluster namespaces Health
 		// ---
-		// Get validation summary for all objects in the given namespaces
+		// Get health for all objects in namespaces of the given cluster
 		//
 		//     Produces:
 		//     - application/json
@@ -1006,23 +1320,83 @@ func NewRoutes(
 		//     Schemes: http, https
 		//
 		// responses:
-		//      200: namespaceValidationSummaryResponse
+		//      200: clustersNamespaceHealthResponse
 		//      400: badRequestError
 		//      500: internalError
 		//
 		{
-			"ConfigValidationSummary",
+			"ClustersHealth",
 			"GET",
-			"/api/istio/validations",
-			handlers.ConfigValidationSummary,
+			"/api/clusters/health",
+			handlers.ClustersHealth,
 			true,
 		},
-		// swagger:route GET /mesh mesh configuration
+		// swagger:route GET /push namespaces pushUpdates
 		// ---
-		// Get Mesh status and configuration
+		// Endpoint to open a WebSocket where the client subscribes to namespaces and receives the updates of their app
+		// health and validation summary when they change.
 		//
-		//     Produces:
-		//     - application/json
+		//     Schemes: ws, wss
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"PushUpdates",
+			"GET",
+			"/api/push",
+			handlers.PushUpdates(conf, kialiCache, authController),
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/validations namespaces namespaceValidations
+		// ---
+		// Get validation summary for all objects in the given namespace
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: namespaceValidationSummaryResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"NamespaceValidationSummary",
+			"GET",
+			"/api/namespaces/{namespace}/validations",
+			handlers.NamespaceValidationSummary,
+			true,
+		},
+		// swagger:route GET /istio/validations namespaces namespacesValidations
+		// ---
+		// Get validation summary for all objects in the given namespaces
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: namespaceValidationSummaryResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"ConfigValidationSummary",
+			"GET",
+			"/api/istio/validations",
+			handlers.ConfigValidationSummary,
+			true,
+		},
+		// swagger:route GET /mesh mesh configuration
+		// ---
+		// Get Mesh status and configuration
+		//
+		//     Produces:
+		//     - application/json
 		//
 		//     Schemes: http, https
 		//
@@ -1038,6 +1412,27 @@ func NewRoutes(
 			handlers.GetMesh,
 			true,
 		},
+		// swagger:route POST /mesh/controlplanes/{revision}/refresh mesh refreshControlPlane
+		// ---
+		// Refresh the proxy status and registry info of a single controlplane
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      204: noContent
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"MeshControlPlaneRefresh",
+			"POST",
+			"/api/mesh/controlplanes/{revision}/refresh",
+			handlers.RefreshControlPlane(cpm),
+			true,
+		},
 		// swagger:route GET /mesh/tls tls meshTls
 		// ---
 		// Get TLS status for the whole mesh
@@ -1080,6 +1475,70 @@ func NewRoutes(
 			handlers.NamespaceTls,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/tls/permissive tls namespacePermissiveTraffic
+		// ---
+		// Get the plaintext traffic received by the workloads of the namespace that don't enforce STRICT mTLS
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: permissiveTrafficResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"NamespacePermissiveTraffic",
+			"GET",
+			"/api/namespaces/{namespace}/tls/permissive",
+			handlers.NamespacePermissiveTraffic,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/meshconfig workloads workloadEffectiveMeshConfig
+		// ---
+		// Endpoint to resolve the meshConfig values the proxies of a workload run with, including the overrides of
+		// the Sidecar applying to the workload and of the proxy.istio.io/config annotation of its pods.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: effectiveMeshConfigResponse
+		//
+		{
+			"WorkloadEffectiveMeshConfig",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/meshconfig",
+			handlers.EffectiveMeshConfig,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/tls tls workloadTls
+		// ---
+		// Get TLS status for the given workload, including its port-level exceptions
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: workloadTlsResponse
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"WorkloadTls",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/tls",
+			handlers.WorkloadTls,
+			true,
+		},
 		// swagger:route GET /clusters/tls tls ClustersTls
 		// ---
 		// Get TLS statuses for given namespaces of the given cluster
@@ -1122,6 +1581,26 @@ func NewRoutes(
 			handlers.IstioStatus,
 			true,
 		},
+		// swagger:route GET /mesh/status status meshComponentStatus
+		// ---
+		// Get the status of the controlplanes, gateways and waypoints grouped per mesh
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: meshComponentStatusResponse
+		//      500: internalError
+		//
+		{
+			"MeshComponentStatus",
+			"GET",
+			"/api/mesh/status",
+			handlers.MeshComponentStatus,
+			true,
+		},
 		// swagger:route GET /istio/certs certs istioCerts
 		// ---
 		// Get certificates (internal) information used by Istio
@@ -1289,6 +1768,156 @@ func NewRoutes(
 			handlers.GraphNode,
 			true,
 		},
+		// swagger:route GET /graph/views graphs graphViewsList
+		// ---
+		// The saved graph views, sorted by name.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200: graphViewsResponse
+		//
+		{
+			"GraphViewsList",
+			"GET",
+			"/api/graph/views",
+			handlers.GraphViewsList,
+			true,
+		},
+		// swagger:route GET /graph/views/{name} graphs graphViewGet
+		// ---
+		// A saved graph view.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      200: graphViewResponse
+		//
+		{
+			"GraphViewGet",
+			"GET",
+			"/api/graph/views/{name}",
+			handlers.GraphViewGet,
+			true,
+		},
+		// swagger:route PUT /graph/views/{name} graphs graphViewSave
+		// ---
+		// Endpoint to create or replace a saved graph view.
+		//
+		//     Consumes:
+		//	   - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: graphViewResponse
+		//
+		{
+			"GraphViewSave",
+			"PUT",
+			"/api/graph/views/{name}",
+			handlers.GraphViewSave,
+			true,
+		},
+		// swagger:route DELETE /graph/views/{name} graphs graphViewDelete
+		// ---
+		// Endpoint to delete a saved graph view.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      200
+		//
+		{
+			"GraphViewDelete",
+			"DELETE",
+			"/api/graph/views/{name}",
+			handlers.GraphViewDelete,
+			true,
+		},
+		// swagger:route GET /preferences preferences preferencesGet
+		// ---
+		// The preferences of the user: bookmarked namespace sets, default duration and graph settings.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200: preferencesResponse
+		//
+		{
+			"PreferencesGet",
+			"GET",
+			"/api/preferences",
+			handlers.PreferencesGet,
+			true,
+		},
+		// swagger:route PUT /preferences preferences preferencesSave
+		// ---
+		// Endpoint to replace the preferences of the user.
+		//
+		//     Consumes:
+		//	   - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: preferencesResponse
+		//
+		{
+			"PreferencesSave",
+			"PUT",
+			"/api/preferences",
+			handlers.PreferencesSave,
+			true,
+		},
+		// swagger:route DELETE /preferences preferences preferencesDelete
+		// ---
+		// Endpoint to reset the preferences of the user.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200
+		//
+		{
+			"PreferencesDelete",
+			"DELETE",
+			"/api/preferences",
+			handlers.PreferencesDelete,
+			true,
+		},
 		// swagger:route GET /mesh/graph meshGraph
 		// ---
 		// The backing JSON for a mesh graph
@@ -1344,7 +1973,7 @@ func NewRoutes(
 		// responses:
 		//      404: notFoundError
 		//      406: notAcceptableError
-		//      200: tracingInfoResponse
+		//      200: jaegerInfoResponse
 		//
 		{
 			"TracingURL",
@@ -1437,6 +2066,27 @@ func NewRoutes(
 			handlers.ConfigDumpResourceEntries,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/pods/{pod}/proxy_status/diff pods podProxyStatusDiff
+		// ---
+		// Endpoint to get what is out of sync between istiod and the pod proxy
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: proxyStatusDiff
+		//
+		{
+			"PodProxyStatusDiff",
+			"GET",
+			"/api/namespaces/{namespace}/pods/{pod}/proxy_status/diff",
+			handlers.ProxyStatusDiff,
+			true,
+		},
 		// swagger:route POST /namespaces/{namespace}/pods/{pod}/logging pods podProxyLogging
 		// ---
 		// Endpoint to set pod proxy log level
@@ -1459,6 +2109,50 @@ func NewRoutes(
 			handlers.LoggingUpdate,
 			true,
 		},
+		// swagger:route POST /namespaces/{namespace}/pods/{pod}/tap pods podProxyTap
+		// ---
+		// Endpoint to capture the metadata of the requests going through the proxy of a pod, for a few seconds
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      400: badRequestError
+		//      200: proxyTapResponse
+		//
+		{
+			"PodProxyTap",
+			"POST",
+			"/api/namespaces/{namespace}/pods/{pod}/tap",
+			handlers.PodProxyTap,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/workloads/{workload}/logging workloads workloadProxyLogging
+		// ---
+		// Endpoint to set the proxy log level of all the pods of a workload, optionally reset after a ttl
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      400: badRequestError
+		//      200: workloadProxyLogLevelResponse
+		//
+		{
+			"WorkloadProxyLogging",
+			"POST",
+			"/api/namespaces/{namespace}/workloads/{workload}/logging",
+			handlers.WorkloadLoggingUpdate,
+			true,
+		},
 		// swagger:route GET /clusters/metrics clusterName namespaces clustersMetrics
 		// ---
 		// Endpoint to fetch metrics to be displayed, related to all provided namespaces of provided cluster
@@ -1501,7 +2195,7 @@ func NewRoutes(
 			HandlerFunc:   handlers.MetricsStats,
 			Authenticated: true,
 		},
-		// swagger:route GET /api/mesh/outbound_traffic_policy/mode
+		// swagger:route GET /mesh/outbound_traffic_policy/mode mesh outboundTrafficPolicyMode
 		// ---
 		// Endpoint to get the OutboundTrafficPolicy Mode configured in the service mesh.
 		//              Produces:
@@ -1511,7 +2205,7 @@ func NewRoutes(
 		//
 		// responses:
 		//              500: internalError
-		//              200: clustersResponse
+		//              200: outboundTrafficPolicyResponse
 		{
 			"OutboundTrafficPolicyMode",
 			"GET",
@@ -1519,7 +2213,7 @@ func NewRoutes(
 			handlers.OutboundTrafficPolicyMode,
 			true,
 		},
-		// swagger:route GET /api/mesh/resources/thresholds
+		// swagger:route GET /mesh/resources/thresholds mesh istiodResourceThresholds
 		// ---
 		// Endpoint to get the IstiodResourceThresholds.
 		//              Produces:
@@ -1537,7 +2231,7 @@ func NewRoutes(
 			handlers.IstiodResourceThresholds,
 			true,
 		},
-		// swagger:route GET /api/mesh/canaries/status
+		// swagger:route GET /mesh/canaries/status mesh istiodCanariesStatus
 		// ---
 		// Endpoint to get the IstiodCanariesStatus.
 		//              Produces:
@@ -1555,6 +2249,96 @@ func NewRoutes(
 			handlers.IstiodCanariesStatus,
 			true,
 		},
+		// swagger:route GET /mesh/canaries/progress mesh revisionUpgradeProgress
+		// ---
+		// Endpoint to get the progress of a revision upgrade: namespaces and proxies per revision.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              500: internalError
+		//              200: revisionUpgradeProgress
+		{
+			"RevisionUpgradeProgress",
+			"GET",
+			"/api/mesh/canaries/progress",
+			handlers.RevisionUpgradeProgress,
+			true,
+		},
+		// swagger:route GET /mesh/certificates mesh meshCertificates
+		// ---
+		// Endpoint to get the inventory of the mesh certificates: istiod roots and intermediates, gateway and workload certificates.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              500: internalError
+		//              200: certificateInventory
+		{
+			"MeshCertificates",
+			"GET",
+			"/api/mesh/certificates",
+			handlers.MeshCertificates,
+			true,
+		},
+		// swagger:route GET /mesh/trustdomains mesh meshTrustDomains
+		// ---
+		// Endpoint to get the trust domains of the mesh and the principals referenced by the authorization policies.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              500: internalError
+		//              200: trustDomainReportResponse
+		{
+			"MeshTrustDomains",
+			"GET",
+			"/api/mesh/trustdomains",
+			handlers.MeshTrustDomains,
+			true,
+		},
+		// swagger:route GET /mesh/drift mesh meshConfigDrift
+		// ---
+		// Endpoint to get the Istio objects whose spec differs between the controlplane clusters of a multi-primary mesh.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              500: internalError
+		//              200: configDriftReportResponse
+		{
+			"MeshConfigDrift",
+			"GET",
+			"/api/mesh/drift",
+			handlers.MeshConfigDrift,
+			true,
+		},
+		// swagger:route GET /mesh/egress mesh meshEgress
+		// ---
+		// Endpoint to get the outbound traffic policies, the Sidecars restricting egress and the unregistered external destinations.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              500: internalError
+		//              200: egressReportResponse
+		{
+			"MeshEgress",
+			"GET",
+			"/api/mesh/egress",
+			handlers.MeshEgress,
+			true,
+		},
 	}
 
 	return
@@ -172,13 +172,31 @@ func configureGzipHandler(handler http.Handler) http.Handler {
 		"text/html",
 	})
 	if handlerFunc, err := gziphandler.GzipHandlerWithOpts(contentTypeOption); err == nil {
-		return handlerFunc(handler)
+		return handlerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch gw := w.(type) {
+			case *gziphandler.GzipResponseWriter:
+				w = gzipResponseWriter{gw}
+			case gziphandler.GzipResponseWriterWithCloseNotify:
+				w = gzipResponseWriter{gw.GzipResponseWriter}
+			}
+			handler.ServeHTTP(w, r)
+		}))
 	} else {
 		// This could happen by a wrong configuration being sent to GzipHandlerWithOpts
 		panic(err)
 	}
 }
 
+// gzipResponseWriter lets the handlers reach the connection under the gzip response writer through an
+// http.ResponseController e.g. to lift the write deadline of a long running response.
+type gzipResponseWriter struct {
+	*gziphandler.GzipResponseWriter
+}
+
+func (w gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.GzipResponseWriter.ResponseWriter
+}
+
 func plainHttpMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		r.URL.Scheme = "http"
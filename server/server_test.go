@@ -13,6 +13,7 @@ import (
 	rnd "math/rand"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	rpprof "runtime/pprof"
 	"strings"
@@ -357,6 +358,28 @@ func TestConfigureGzipHandler(t *testing.T) {
 	configureGzipHandler(nil)
 }
 
+func TestGzipHandlerLetsTheHandlersClearTheWriteDeadline(t *testing.T) {
+	handler := configureGzipHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	testServer := httptest.NewServer(handler)
+	t.Cleanup(testServer.Close)
+
+	r, err := http.NewRequest("GET", testServer.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Accept-Encoding", "gzip")
+	resp, err := testServer.Client().Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func getRequestResults(t *testing.T, httpClient *http.Client, url string, credentials *security.Credentials) (string, error) {
 	r, err := http.NewRequest("GET", url, nil)
 	if err != nil {
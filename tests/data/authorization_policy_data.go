@@ -80,3 +80,11 @@ func CreateAuthorizationPolicyWithPrincipals(name, namespace string, principalsL
 	}
 	return &ap
 }
+
+func CreateAuthorizationPolicyWithTargetRef(name, namespace, group, kind, target string) *security_v1beta1.AuthorizationPolicy {
+	ap := security_v1beta1.AuthorizationPolicy{}
+	ap.Name = name
+	ap.Namespace = namespace
+	ap.Spec.TargetRef = &api_v1beta1.PolicyTargetReference{Group: group, Kind: kind, Name: target}
+	return &ap
+}
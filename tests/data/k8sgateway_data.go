@@ -55,6 +55,42 @@ func AddBackendRefToHTTPRoute(name, namespace string, rt *k8s_networking_v1.HTTP
 	return rt
 }
 
+func CreateGRPCRoute(name string, namespace string, gateway string) *k8s_networking_v1.GRPCRoute {
+	ns := k8s_networking_v1.Namespace(namespace)
+	group := k8s_networking_v1.Group(kubernetes.K8sNetworkingGroupVersionV1.Group)
+	kind := k8s_networking_v1.Kind(kubernetes.K8sActualGatewayType)
+	rt := k8s_networking_v1.GRPCRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, k8s_networking_v1.ParentReference{
+		Name:      k8s_networking_v1.ObjectName(gateway),
+		Namespace: &ns,
+		Group:     &group,
+		Kind:      &kind})
+	return &rt
+}
+
+func AddBackendRefToGRPCRoute(name, namespace string, rt *k8s_networking_v1.GRPCRoute) *k8s_networking_v1.GRPCRoute {
+	kind := k8s_networking_v1.Kind("Service")
+	var ns k8s_networking_v1.Namespace
+	if namespace != "" {
+		ns = k8s_networking_v1.Namespace(namespace)
+	}
+	backendRef := k8s_networking_v1.GRPCBackendRef{
+		BackendRef: k8s_networking_v1.BackendRef{
+			BackendObjectReference: k8s_networking_v1.BackendObjectReference{
+				Kind:      &kind,
+				Name:      k8s_networking_v1.ObjectName(name),
+				Namespace: &ns,
+			},
+		},
+	}
+	rule := k8s_networking_v1.GRPCRouteRule{}
+	rule.BackendRefs = append(rule.BackendRefs, backendRef)
+	rt.Spec.Rules = append(rt.Spec.Rules, rule)
+	return rt
+}
+
 func CreateEmptyK8sGateway(name, namespace string) *k8s_networking_v1.Gateway {
 	gw := k8s_networking_v1.Gateway{}
 	gw.Name = name
@@ -87,6 +123,12 @@ func CreateListener(name string, hostname string, port int, protocol string) k8s
 	return listener
 }
 
+func AllowRoutesFromAllNamespaces(listener k8s_networking_v1.Listener) k8s_networking_v1.Listener {
+	from := k8s_networking_v1.NamespacesFromAll
+	listener.AllowedRoutes = &k8s_networking_v1.AllowedRoutes{Namespaces: &k8s_networking_v1.RouteNamespaces{From: &from}}
+	return listener
+}
+
 func CreateGWAddress(addrType k8s_networking_v1.AddressType, value string) k8s_networking_v1.GatewayAddress {
 	address := k8s_networking_v1.GatewayAddress{
 		Type:  &addrType,
@@ -110,3 +152,9 @@ func CreateReferenceGrant(name string, namespace string, fromNamespace string) *
 	rg.Spec.To = append(rg.Spec.To, k8s_networking_v1beta1.ReferenceGrantTo{Kind: kubernetes.ServiceType})
 	return &rg
 }
+
+func CreateReferenceGrantByKind(name string, namespace string, fromNamespace string, fromKind string) *k8s_networking_v1beta1.ReferenceGrant {
+	rg := CreateReferenceGrant(name, namespace, fromNamespace)
+	rg.Spec.From[0].Kind = k8s_networking_v1.Kind(fromKind)
+	return rg
+}
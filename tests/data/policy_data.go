@@ -48,3 +48,23 @@ func CreateOneLabelSelector(value string) map[string]string {
 		"app": value,
 	}
 }
+
+func CreateRequestAuthentication(name, namespace string, selector map[string]string, jwtRules ...*api_security_v1beta1.JWTRule) *security_v1beta1.RequestAuthentication {
+	ra := security_v1beta1.RequestAuthentication{}
+	ra.Name = name
+	ra.Namespace = namespace
+	if selector != nil {
+		ra.Spec.Selector = &api_v1beta1.WorkloadSelector{
+			MatchLabels: selector,
+		}
+	}
+	ra.Spec.JwtRules = jwtRules
+	return &ra
+}
+
+func CreateJWTRule(issuer, jwksUri string) *api_security_v1beta1.JWTRule {
+	return &api_security_v1beta1.JWTRule{
+		Issuer:  issuer,
+		JwksUri: jwksUri,
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/tools/cmd"
+	"github.com/kiali/kiali/tools/openapi"
+)
+
+const (
+	defaultOutputLocation = "_output"
+)
+
+var (
+	clientFlag  string
+	outputFlag  string
+	versionFlag string
+)
+
+func init() {
+	flag.StringVar(&clientFlag, "client", path.Join(cmd.KialiProjectRoot, "client", "zz_generated.go"), "path of the generated typed client")
+	flag.StringVar(&outputFlag, "output", path.Join(cmd.KialiProjectRoot, defaultOutputLocation), "path to output the openapi.json document")
+	flag.StringVar(&versionFlag, "version", "dev", "version of the API in the document")
+}
+
+func main() {
+	flag.Parse()
+	log.InitializeLogger()
+
+	annotations, err := openapi.Annotate(cmd.KialiProjectRoot)
+	if err != nil {
+		log.Fatalf("Unable to parse the swagger annotations: %s", err)
+	}
+
+	routes := openapi.Routes()
+	doc, err := openapi.Generate(routes, annotations, versionFlag)
+	if err != nil {
+		log.Fatalf("Unable to generate the OpenAPI document: %s", err)
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatalf("Unable to marshal the OpenAPI document: %s", err)
+	}
+	if err := os.MkdirAll(outputFlag, 0755); err != nil {
+		log.Fatalf("Unable to create the output directory: %s", err)
+	}
+	outputPath := path.Join(outputFlag, "openapi.json")
+	log.Infof("Outputting the OpenAPI document to file: %s", outputPath)
+	if err := os.WriteFile(outputPath, b, 0644); err != nil {
+		log.Fatalf("Unable to write the OpenAPI document: %s", err)
+	}
+
+	client, err := openapi.GenerateClient(routes, annotations)
+	if err != nil {
+		log.Fatalf("Unable to generate the client: %s", err)
+	}
+	log.Infof("Outputting the typed client to file: %s", clientFlag)
+	if err := os.WriteFile(clientFlag, client, 0644); err != nil {
+		log.Fatalf("Unable to write the client: %s", err)
+	}
+}
@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Annotations are the swagger annotations of the API: the operations annotated on the routes, and the responses and
+// the parameters documented next to the models.
+type Annotations struct {
+	Operations []AnnotatedOperation
+	// The responses, by response name
+	Responses map[string]AnnotatedResponse
+	// The parameters, by operation ID
+	Parameters map[string][]AnnotatedParameter
+
+	// doc is the file of the responses and of the parameters, their types are resolved in its package
+	doc     *sourceFile
+	sources *Sources
+}
+
+// AnnotatedOperation is a swagger:route annotation
+type AnnotatedOperation struct {
+	ID      string
+	Method  string
+	Path    string
+	Tags    []string
+	Summary string
+	// The response names, by status code
+	Responses map[string]string
+}
+
+// AnnotatedResponse is a swagger:response annotation
+type AnnotatedResponse struct {
+	Description string
+	// The type of the body, nil for an empty response
+	Body ast.Expr
+}
+
+// AnnotatedParameter is a field of a swagger:parameters annotation
+type AnnotatedParameter struct {
+	Name        string
+	In          string
+	Required    bool
+	Description string
+	Type        ast.Expr
+}
+
+// ParseAnnotations parses the swagger:route annotations of the routes file and the swagger:response and
+// swagger:parameters annotations of the doc file. The types are resolved in the Go sources of the module.
+func ParseAnnotations(sources *Sources, routesFile, docFile string) (*Annotations, error) {
+	annotations := &Annotations{
+		Responses:  map[string]AnnotatedResponse{},
+		Parameters: map[string][]AnnotatedParameter{},
+		sources:    sources,
+	}
+
+	routes, err := parser.ParseFile(sources.fset, routesFile, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range routes.Comments {
+		op, ok, err := parseRoute(group.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", sources.fset.Position(group.Pos()), err)
+		}
+		if ok {
+			annotations.Operations = append(annotations.Operations, op)
+		}
+	}
+
+	doc, err := sources.parseFile(docFile)
+	if err != nil {
+		return nil, err
+	}
+	annotations.doc = doc
+	for _, decl := range doc.file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			comment := typeSpec.Doc
+			if comment == nil {
+				comment = genDecl.Doc
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if comment == nil || !ok {
+				continue
+			}
+			text := comment.Text()
+			if name, ok := annotationArgs(text, "swagger:response"); ok && len(name) == 1 {
+				annotations.Responses[name[0]] = AnnotatedResponse{Description: description(text), Body: bodyField(structType)}
+			}
+			if ops, ok := annotationArgs(text, "swagger:parameters"); ok {
+				params := parameters(structType)
+				for _, op := range ops {
+					annotations.Parameters[op] = append(annotations.Parameters[op], params...)
+				}
+			}
+		}
+	}
+
+	for _, op := range annotations.Operations {
+		for code, response := range op.Responses {
+			if _, ok := annotations.Responses[response]; !ok {
+				return nil, fmt.Errorf("response [%s] of the status %s of the operation [%s] not found", response, code, op.ID)
+			}
+		}
+	}
+	return annotations, nil
+}
+
+// parseRoute parses a swagger:route comment:
+//
+//	swagger:route METHOD PATH [TAG...] OPERATION_ID
+//	---
+//	Summary
+//
+//	responses:
+//	    200: responseName
+func parseRoute(text string) (AnnotatedOperation, bool, error) {
+	args, ok := annotationArgs(text, "swagger:route")
+	if !ok {
+		return AnnotatedOperation{}, false, nil
+	}
+	if len(args) < 3 {
+		return AnnotatedOperation{}, false, fmt.Errorf("invalid swagger:route [%s]", strings.Join(args, " "))
+	}
+
+	op := AnnotatedOperation{
+		Method:    strings.ToUpper(args[0]),
+		Path:      args[1],
+		Tags:      args[2 : len(args)-1],
+		ID:        args[len(args)-1],
+		Responses: map[string]string{},
+	}
+
+	inResponses := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "swagger:route"), line == "---":
+		case strings.EqualFold(line, "responses:"):
+			inResponses = true
+		case inResponses:
+			if code, response, ok := strings.Cut(line, ":"); ok {
+				op.Responses[strings.TrimSpace(code)] = strings.TrimSpace(response)
+			}
+		case op.Summary == "" && line != "":
+			op.Summary = line
+		}
+	}
+	return op, true, nil
+}
+
+// annotationArgs returns the arguments of an annotation of a comment
+func annotationArgs(text, annotation string) ([]string, bool) {
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == annotation {
+			return fields[1:], true
+		}
+	}
+	return nil, false
+}
+
+// description returns the text of a comment, without its annotations. The go-swagger annotations are either a
+// swagger:* line or a "key: value" line of the known keys.
+func description(text string) string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "swagger:") || isAnnotationKey(line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
+
+var annotationKeys = []string{"default", "example", "in", "maximum", "minimum", "pattern", "required"}
+
+func isAnnotationKey(line string) bool {
+	key, _, ok := strings.Cut(line, ":")
+	if !ok {
+		return false
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	i := sort.SearchStrings(annotationKeys, key)
+	return i < len(annotationKeys) && annotationKeys[i] == key
+}
+
+// annotationValue returns the value of a "key: value" annotation of a comment
+func annotationValue(text, key string) string {
+	for _, line := range strings.Split(text, "\n") {
+		k, v, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok && strings.EqualFold(strings.TrimSpace(k), key) {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// bodyField returns the type of the body of a response: its field named Body or annotated with "in: body"
+func bodyField(structType *ast.StructType) ast.Expr {
+	for _, field := range structType.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == "Body" || (field.Doc != nil && annotationValue(field.Doc.Text(), "in") == "body") {
+				return field.Type
+			}
+		}
+	}
+	return nil
+}
+
+// parameters returns the parameters of the fields of a swagger:parameters struct
+func parameters(structType *ast.StructType) []AnnotatedParameter {
+	params := []AnnotatedParameter{}
+	for _, field := range structType.Fields.List {
+		text := ""
+		if field.Doc != nil {
+			text = field.Doc.Text()
+		}
+		for _, name := range field.Names {
+			param := AnnotatedParameter{
+				Name:        jsonName(field, name.Name),
+				In:          annotationValue(text, "in"),
+				Required:    annotationValue(text, "required") == "true",
+				Description: description(text),
+				Type:        field.Type,
+			}
+			if param.In == "" {
+				param.In = "query"
+			}
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// jsonName returns the JSON name of a field, its name when it has no json tag
+func jsonName(field *ast.Field, name string) string {
+	if tagName, _ := jsonTag(field); tagName != "" {
+		return tagName
+	}
+	return name
+}
+
+// jsonTag returns the name of the json tag of a field and whether the field is ignored
+func jsonTag(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/kiali/kiali/routing"
+)
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by tools/cmd/openapi. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"net/url"
+{{range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{range .Methods}}
+// {{.Name}} calls {{.Method}} {{.Pattern}}{{if .Query}}, with the optional query parameters {{.Query}}{{end}}.
+func (c *Client) {{.Name}}(ctx context.Context, {{range .Params}}{{.}} string, {{end}}query url.Values) ({{.Result}}, error) {
+	var out {{.Response}}
+	err := c.do(ctx, "{{.Method}}", {{.Path}}, query, &out)
+	return {{if .Pointer}}&{{end}}out, err
+}
+{{end}}`))
+
+type clientMethod struct {
+	Name     string
+	Method   string
+	Pattern  string
+	Query    string
+	Params   []string
+	Path     string
+	Response string
+	Result   string
+	Pointer  bool
+}
+
+// GenerateClient returns the source of the methods of the typed client, in the order of the routes. The methods are
+// the annotated GET routes with a JSON response whose type can be imported by the client. A method is named after its
+// route, or after its operation when several routes have the same name.
+func GenerateClient(routes []routing.Route, annotations *Annotations) ([]byte, error) {
+	annotated, err := annotateRoutes(routes, annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	routeNames := map[string]int{}
+	for _, route := range annotated {
+		routeNames[route.Name]++
+	}
+
+	imports := map[string]bool{}
+	names := map[string]bool{}
+	methods := []clientMethod{}
+	for _, route := range annotated {
+		// The client only reads, its calls have no request body
+		if route.operation == nil || route.Method != http.MethodGet {
+			continue
+		}
+		body := annotations.Responses[route.operation.Responses["200"]].Body
+		if body == nil {
+			continue
+		}
+		bodyImports, ok := clientImports(body, annotations.doc)
+		if !ok {
+			continue
+		}
+		for _, imp := range bodyImports {
+			imports[imp] = true
+		}
+
+		name := route.Name
+		if routeNames[name] > 1 {
+			name = exported(route.operation.ID)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("client method [%s] of the operation [%s] is not unique", name, route.operation.ID)
+		}
+		names[name] = true
+
+		query := []string{}
+		for _, param := range annotations.Parameters[route.operation.ID] {
+			if param.In == "query" {
+				query = append(query, param.Name)
+			}
+		}
+
+		responseType := types.ExprString(body)
+		method := clientMethod{
+			Name:     name,
+			Method:   route.Method,
+			Pattern:  route.Pattern,
+			Query:    strings.Join(query, ", "),
+			Response: responseType,
+			Result:   responseType,
+		}
+		if decl := annotations.sources.resolve(body, annotations.doc); decl != nil {
+			if _, ok := decl.spec.Type.(*ast.StructType); ok {
+				method.Result = "*" + method.Result
+				method.Pointer = true
+			}
+		}
+
+		// The path, with its parameters escaped
+		var path []string
+		last := 0
+		for _, match := range pathParam.FindAllStringSubmatchIndex(route.Pattern, -1) {
+			param := route.Pattern[match[2]:match[3]]
+			if token.IsKeyword(param) {
+				param += "Param"
+			}
+			method.Params = append(method.Params, param)
+			path = append(path, fmt.Sprintf("%q", route.Pattern[last:match[0]]), "url.PathEscape("+param+")")
+			last = match[1]
+		}
+		if last < len(route.Pattern) {
+			path = append(path, fmt.Sprintf("%q", route.Pattern[last:]))
+		}
+		method.Path = strings.Join(path, "+")
+		methods = append(methods, method)
+	}
+
+	sortedImports := make([]string, 0, len(imports))
+	for imp := range imports {
+		sortedImports = append(sortedImports, imp)
+	}
+	sort.Strings(sortedImports)
+
+	var buf bytes.Buffer
+	if err := clientTemplate.Execute(&buf, map[string]interface{}{"Imports": sortedImports, "Methods": methods}); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// clientImports returns the import specs of the packages of a type of the doc file. The type can't be used by the
+// client when it refers to a type of the package of the doc file or of a package that is not imported.
+func clientImports(expr ast.Expr, doc *sourceFile) ([]string, bool) {
+	imports := []string{}
+	ok := true
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.SelectorExpr:
+			importPath := doc.importPath(e)
+			if importPath == "" {
+				ok = false
+				return false
+			}
+			spec := fmt.Sprintf("%q", importPath)
+			if name := e.X.(*ast.Ident).Name; name != path.Base(importPath) {
+				spec = name + " " + spec
+			}
+			imports = append(imports, spec)
+			return false
+		case *ast.Ident:
+			if _, basic := basicSchemas[e.Name]; !basic && e.Name != "any" && e.Name != "interface" {
+				ok = false
+			}
+		case *ast.InterfaceType:
+			return false
+		}
+		return true
+	})
+	return imports, ok
+}
+
+// exported returns a name starting with an uppercase letter
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,218 @@
+// Package openapi generates the OpenAPI v3 document of the Kiali API and its typed Go client from the swagger
+// annotations of the routes and the Go models of their responses.
+package openapi
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/routing"
+)
+
+// Document is an OpenAPI v3 document, limited to what is generated
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem are the operations of a path, keyed by lowercase HTTP method
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+var pathParam = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
+// Routes returns the API routes of the default configuration. The routes are only read: they are created without
+// the clients of their handlers.
+func Routes() []routing.Route {
+	conf := config.NewConfig()
+	return routing.NewRoutes(conf, nil, nil, nil, nil, nil, nil, nil).Routes
+}
+
+// Annotate parses the swagger annotations of the routes of the module at root: the swagger:route annotations of
+// routing/routes.go and the swagger:response and swagger:parameters annotations of doc.go.
+func Annotate(root string) (*Annotations, error) {
+	sources, err := NewSources("github.com/kiali/kiali", root)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAnnotations(sources, path.Join(root, "routing", "routes.go"), path.Join(root, "doc.go"))
+}
+
+// annotatedRoute is a route and its annotated operation, nil when the route is not annotated
+type annotatedRoute struct {
+	routing.Route
+	path      string
+	operation *AnnotatedOperation
+}
+
+// annotateRoutes matches the routes with their annotated operation, by method and path. All the annotated
+// operations must match a route.
+func annotateRoutes(routes []routing.Route, annotations *Annotations) ([]annotatedRoute, error) {
+	operations := make(map[string]*AnnotatedOperation, len(annotations.Operations))
+	for i, op := range annotations.Operations {
+		operations[op.Method+" "+op.Path] = &annotations.Operations[i]
+	}
+
+	annotated := make([]annotatedRoute, 0, len(routes))
+	found := map[*AnnotatedOperation]bool{}
+	for _, route := range routes {
+		routePath := strings.TrimPrefix(pathParam.ReplaceAllString(route.Pattern, "{$1}"), "/api")
+		if routePath == "" {
+			routePath = "/"
+		}
+		op := operations[strings.ToUpper(route.Method)+" "+routePath]
+		if op != nil {
+			found[op] = true
+		}
+		annotated = append(annotated, annotatedRoute{Route: route, path: routePath, operation: op})
+	}
+
+	for i, op := range annotations.Operations {
+		if !found[&annotations.Operations[i]] {
+			return nil, fmt.Errorf("route of the operation [%s] %s %s not found", op.ID, op.Method, op.Path)
+		}
+	}
+	return annotated, nil
+}
+
+// Generate returns the OpenAPI document of the routes. The annotated routes have the parameters and the responses
+// of their annotations, the others have an untyped JSON response.
+func Generate(routes []routing.Route, annotations *Annotations, version string) (*Document, error) {
+	annotated, err := annotateRoutes(routes, annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := newSchemaGenerator(annotations.sources)
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Kiali",
+			Description: "The Kiali API is not for public use and is not supported for any use outside of the Kiali UI itself.",
+			Version:     version,
+		},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: gen.schemas},
+	}
+
+	for _, route := range annotated {
+		op := &Operation{
+			OperationID: route.Name,
+			Responses:   map[string]Response{},
+		}
+
+		params := map[string]AnnotatedParameter{}
+		if route.operation != nil {
+			op.OperationID = route.operation.ID
+			op.Summary = route.operation.Summary
+			op.Tags = route.operation.Tags
+			for _, param := range annotations.Parameters[route.operation.ID] {
+				params[param.In+"/"+param.Name] = param
+			}
+		}
+
+		for _, match := range pathParam.FindAllStringSubmatch(route.Pattern, -1) {
+			param := Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}}
+			if annotated, ok := params["path/"+match[1]]; ok {
+				param.Description = annotated.Description
+			}
+			op.Parameters = append(op.Parameters, param)
+		}
+		if route.operation != nil {
+			for _, param := range annotations.Parameters[route.operation.ID] {
+				if param.In != "query" {
+					continue
+				}
+				op.Parameters = append(op.Parameters, Parameter{
+					Name:        param.Name,
+					In:          param.In,
+					Description: param.Description,
+					Required:    param.Required,
+					Schema:      gen.schema(param.Type, annotations.doc),
+				})
+			}
+		}
+
+		if route.operation == nil {
+			op.Responses["200"] = Response{
+				Description: "The response of " + route.Name,
+				Content:     map[string]MediaType{"application/json": {Schema: &Schema{}}},
+			}
+		} else {
+			for code, name := range route.operation.Responses {
+				annotatedResponse := annotations.Responses[name]
+				response := Response{Description: annotatedResponse.Description}
+				if response.Description == "" {
+					response.Description = name
+				}
+				if annotatedResponse.Body != nil {
+					response.Content = map[string]MediaType{"application/json": {Schema: gen.schema(annotatedResponse.Body, annotations.doc)}}
+				}
+				op.Responses[code] = response
+			}
+			if len(op.Responses) == 0 {
+				op.Responses["200"] = Response{Description: "The response of " + route.operation.ID}
+			}
+		}
+
+		pathItem, ok := doc.Paths[route.path]
+		if !ok {
+			pathItem = PathItem{}
+			doc.Paths[route.path] = pathItem
+		}
+		pathItem[strings.ToLower(route.Method)] = op
+	}
+
+	return doc, nil
+}
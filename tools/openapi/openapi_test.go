@@ -0,0 +1,118 @@
+package openapi
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/routing"
+	"github.com/kiali/kiali/tools/cmd"
+)
+
+// testAnnotations parses the annotations of the testdata, whose models are resolved in the module
+func testAnnotations(t *testing.T) *Annotations {
+	t.Helper()
+	sources, err := NewSources("github.com/kiali/kiali", cmd.KialiProjectRoot)
+	require.NoError(t, err)
+	annotations, err := ParseAnnotations(sources, path.Join("testdata", "routing", "routes.go"), path.Join("testdata", "doc.go"))
+	require.NoError(t, err)
+	return annotations
+}
+
+var testRoutes = []routing.Route{
+	{Name: "ItemDetails", Method: "GET", Pattern: "/api/namespaces/{namespace}/items/{item}"},
+	{Name: "ItemUpdate", Method: "PATCH", Pattern: "/api/namespaces/{namespace}/items/{item}"},
+	{Name: "ItemRaw", Method: "GET", Pattern: "/api/namespaces/{namespace}/items/{item:[a-z]+}/raw"},
+}
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	doc, err := Generate(testRoutes, testAnnotations(t), "v1")
+	require.NoError(err)
+	assert.Equal("3.0.3", doc.OpenAPI)
+	assert.Equal("v1", doc.Info.Version)
+
+	op := doc.Paths["/namespaces/{namespace}/items/{item}"]["get"]
+	require.NotNil(op)
+	assert.Equal("itemDetails", op.OperationID)
+	assert.Equal("Endpoint to get the details of an item", op.Summary)
+	assert.Equal([]string{"items"}, op.Tags)
+	require.Len(op.Parameters, 3)
+	assert.Equal(Parameter{Name: "namespace", In: "path", Description: "The namespace name.", Required: true, Schema: &Schema{Type: "string"}}, op.Parameters[0])
+	assert.Equal(Parameter{Name: "item", In: "path", Required: true, Schema: &Schema{Type: "string"}}, op.Parameters[1])
+	assert.Equal(Parameter{Name: "clusterName", In: "query", Description: "The cluster name.", Schema: &Schema{Type: "string"}}, op.Parameters[2])
+	assert.Equal("The details of an item", op.Responses["200"].Description)
+	assert.Equal("#/components/schemas/Item", op.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal("object", op.Responses["404"].Content["application/json"].Schema.Type)
+
+	update := doc.Paths["/namespaces/{namespace}/items/{item}"]["patch"]
+	require.NotNil(update)
+	assert.Equal("itemUpdate", update.OperationID)
+
+	raw := doc.Paths["/namespaces/{namespace}/items/{item}/raw"]["get"]
+	require.NotNil(raw)
+	assert.Equal("ItemRaw", raw.OperationID)
+	assert.Equal(&Schema{}, raw.Responses["200"].Content["application/json"].Schema)
+
+	schema := doc.Components.Schemas["Item"]
+	require.NotNil(schema)
+	assert.Equal("object", schema.Type)
+	assert.Equal("An Item of a namespace", schema.Description)
+	assert.Equal([]string{"name"}, schema.Required)
+	assert.Equal(&Schema{Type: "string", Description: "The name of the item"}, schema.Properties["name"])
+	assert.Equal(&Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, schema.Properties["labels"])
+	assert.Equal(&Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/Item"}}, schema.Properties["children"])
+	assert.Equal(&Schema{Ref: "#/components/schemas/Item"}, schema.Properties["parent"])
+	assert.Equal(&Schema{Type: "integer", Format: "int32", Nullable: true}, schema.Properties["count"])
+	assert.Equal(&Schema{Type: "string", Format: "date-time"}, schema.Properties["createdAt"])
+	assert.Equal(&Schema{Type: "string"}, schema.Properties["kind"])
+	assert.Equal(&Schema{Type: "string"}, schema.Properties["cluster"])
+	assert.NotContains(schema.Properties, "Ignored")
+	assert.NotContains(schema.Properties, "embedded")
+}
+
+func TestGenerateUnknownRoute(t *testing.T) {
+	_, err := Generate(testRoutes[2:], testAnnotations(t), "v1")
+	assert.Error(t, err)
+
+	_, err = GenerateClient(testRoutes[2:], testAnnotations(t))
+	assert.Error(t, err)
+}
+
+func TestGenerateClient(t *testing.T) {
+	client, err := GenerateClient(testRoutes, testAnnotations(t))
+	require.NoError(t, err)
+
+	// Only the annotated GET routes have a method
+	assert.Contains(t, string(client), "func (c *Client) ItemDetails(ctx context.Context, namespace string, item string, query url.Values) (*models.Item, error) {")
+	assert.Contains(t, string(client), `"github.com/kiali/kiali/tools/openapi/testdata/models"`)
+	assert.NotContains(t, string(client), "ItemUpdate")
+	assert.NotContains(t, string(client), "ItemRaw")
+}
+
+func TestAnnotationsMatchRoutes(t *testing.T) {
+	annotations, err := Annotate(cmd.KialiProjectRoot)
+	require.NoError(t, err)
+
+	doc, err := Generate(Routes(), annotations, "dev")
+	require.NoError(t, err)
+	assert.NotEmpty(t, doc.Components.Schemas)
+}
+
+// The generated client must be regenerated with `make gen-openapi` when the routes or their annotations change
+func TestGeneratedClientUpToDate(t *testing.T) {
+	annotations, err := Annotate(cmd.KialiProjectRoot)
+	require.NoError(t, err)
+
+	expected, err := GenerateClient(Routes(), annotations)
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(path.Join(cmd.KialiProjectRoot, "client", "zz_generated.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual), "client/zz_generated.go is not up to date, run make gen-openapi")
+}
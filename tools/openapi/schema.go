@@ -0,0 +1,356 @@
+package openapi
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Sources are the Go sources of a module, parsed on demand to resolve the types of the models
+type Sources struct {
+	modulePath string
+	root       string
+	fset       *token.FileSet
+	packages   map[string]*sourcePackage
+}
+
+// sourcePackage are the type declarations of a package of the module
+type sourcePackage struct {
+	name  string
+	path  string
+	types map[string]*typeDecl
+}
+
+type typeDecl struct {
+	spec *ast.TypeSpec
+	doc  string
+	file *sourceFile
+}
+
+// sourceFile is a parsed file and the import paths of its package names
+type sourceFile struct {
+	file    *ast.File
+	imports map[string]string
+	pkg     *sourcePackage
+}
+
+// NewSources returns the sources of the module of a path at a root directory
+func NewSources(modulePath, root string) (*Sources, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	return &Sources{
+		modulePath: modulePath,
+		root:       abs,
+		fset:       token.NewFileSet(),
+		packages:   map[string]*sourcePackage{},
+	}, nil
+}
+
+// parseFile parses a file, with the types of its package
+func (s *Sources) parseFile(filename string) (*sourceFile, error) {
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(s.root, dir)
+	if err != nil {
+		return nil, err
+	}
+	importPath := s.modulePath
+	if rel != "." {
+		importPath += "/" + filepath.ToSlash(rel)
+	}
+
+	pkg := s.load(importPath)
+	abs, _ := filepath.Abs(filename)
+	for _, decl := range pkg.types {
+		if s.fset.Position(decl.file.file.Pos()).Filename == abs {
+			return decl.file, nil
+		}
+	}
+
+	// A file without types
+	file, err := parser.ParseFile(s.fset, abs, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	return newSourceFile(file, pkg), nil
+}
+
+// load returns the types of a package of the module, nil for the packages of other modules
+func (s *Sources) load(importPath string) *sourcePackage {
+	if pkg, ok := s.packages[importPath]; ok {
+		return pkg
+	}
+	if importPath != s.modulePath && !strings.HasPrefix(importPath, s.modulePath+"/") {
+		return nil
+	}
+
+	pkg := &sourcePackage{path: importPath, types: map[string]*typeDecl{}}
+	s.packages[importPath] = pkg
+
+	dir := filepath.Join(s.root, filepath.FromSlash(strings.TrimPrefix(importPath, s.modulePath)))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return pkg
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(s.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		pkg.name = file.Name.Name
+		sf := newSourceFile(file, pkg)
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil {
+					doc = genDecl.Doc
+				}
+				td := &typeDecl{spec: typeSpec, file: sf}
+				if doc != nil {
+					td.doc = description(doc.Text())
+				}
+				pkg.types[typeSpec.Name.Name] = td
+			}
+		}
+	}
+	return pkg
+}
+
+func newSourceFile(file *ast.File, pkg *sourcePackage) *sourceFile {
+	sf := &sourceFile{file: file, imports: map[string]string{}, pkg: pkg}
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		sf.imports[name] = path
+	}
+	return sf
+}
+
+// resolve returns the declaration of a named type of a file: an identifier of its package or a selector of an
+// imported package of the module. It returns nil for the builtin types and the types of other modules.
+func (s *Sources) resolve(expr ast.Expr, file *sourceFile) *typeDecl {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return file.pkg.types[e.Name]
+	case *ast.SelectorExpr:
+		if pkg := s.load(file.importPath(e)); pkg != nil {
+			return pkg.types[e.Sel.Name]
+		}
+	}
+	return nil
+}
+
+// importPath returns the import path of the package of a selector
+func (f *sourceFile) importPath(sel *ast.SelectorExpr) string {
+	if x, ok := sel.X.(*ast.Ident); ok {
+		return f.imports[x.Name]
+	}
+	return ""
+}
+
+// externalSchemas are the schemas of the types of other modules with a specific JSON encoding
+var externalSchemas = map[string]Schema{
+	"encoding/json.RawMessage":                        {},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.Time":       {Type: "string", Format: "date-time"},
+	"k8s.io/apimachinery/pkg/util/intstr.IntOrString": {},
+	"time.Duration":                                   {Type: "integer", Format: "int64"},
+	"time.Time":                                       {Type: "string", Format: "date-time"},
+}
+
+var basicSchemas = map[string]Schema{
+	"bool":    {Type: "boolean"},
+	"byte":    {Type: "integer", Format: "int32"},
+	"float32": {Type: "number"},
+	"float64": {Type: "number"},
+	"int":     {Type: "integer", Format: "int32"},
+	"int8":    {Type: "integer", Format: "int32"},
+	"int16":   {Type: "integer", Format: "int32"},
+	"int32":   {Type: "integer", Format: "int32"},
+	"int64":   {Type: "integer", Format: "int64"},
+	"rune":    {Type: "integer", Format: "int32"},
+	"string":  {Type: "string"},
+	"uint":    {Type: "integer", Format: "int32"},
+	"uint8":   {Type: "integer", Format: "int32"},
+	"uint16":  {Type: "integer", Format: "int32"},
+	"uint32":  {Type: "integer", Format: "int32"},
+	"uint64":  {Type: "integer", Format: "int64"},
+}
+
+// schemaGenerator generates the schemas of the types of the Go sources. The named structs are components,
+// referenced by name.
+type schemaGenerator struct {
+	sources *Sources
+	schemas map[string]*Schema
+	names   map[*typeDecl]string
+	// The named types being inlined, to stop on the recursive ones
+	inlining map[*typeDecl]bool
+}
+
+func newSchemaGenerator(sources *Sources) *schemaGenerator {
+	return &schemaGenerator{
+		sources:  sources,
+		schemas:  map[string]*Schema{},
+		names:    map[*typeDecl]string{},
+		inlining: map[*typeDecl]bool{},
+	}
+}
+
+func (g *schemaGenerator) schema(expr ast.Expr, file *sourceFile) *Schema {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if basic, ok := basicSchemas[e.Name]; ok {
+			return &basic
+		}
+		if decl := g.sources.resolve(e, file); decl != nil {
+			return g.named(decl)
+		}
+		// error, any and the unknown types
+		return &Schema{}
+	case *ast.SelectorExpr:
+		if external, ok := externalSchemas[file.importPath(e)+"."+e.Sel.Name]; ok {
+			return &external
+		}
+		if decl := g.sources.resolve(e, file); decl != nil {
+			return g.named(decl)
+		}
+		return &Schema{}
+	case *ast.StarExpr:
+		schema := g.schema(e.X, file)
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case *ast.ArrayType:
+		if ident, ok := e.Elt.(*ast.Ident); ok && (ident.Name == "byte" || ident.Name == "uint8") {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(e.Elt, file)}
+	case *ast.MapType:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(e.Value, file)}
+	case *ast.StructType:
+		return g.structSchema(e, file)
+	default:
+		// Interfaces, generics: any JSON value
+		return &Schema{}
+	}
+}
+
+// named returns the schema of a named type: a reference to its component for the structs, its underlying schema
+// for the others
+func (g *schemaGenerator) named(decl *typeDecl) *Schema {
+	structType, ok := decl.spec.Type.(*ast.StructType)
+	if !ok || decl.spec.TypeParams != nil {
+		if g.inlining[decl] || decl.spec.TypeParams != nil {
+			return &Schema{}
+		}
+		g.inlining[decl] = true
+		defer delete(g.inlining, decl)
+		return g.schema(decl.spec.Type, decl.file)
+	}
+
+	name := g.name(decl)
+	if _, ok := g.schemas[name]; !ok {
+		// Registered first for the recursive types
+		g.schemas[name] = &Schema{}
+		schema := g.structSchema(structType, decl.file)
+		schema.Description = decl.doc
+		*g.schemas[name] = *schema
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// name returns the component name of a struct: its name, prefixed by its package when another package has a struct
+// of the same name
+func (g *schemaGenerator) name(decl *typeDecl) string {
+	if name, ok := g.names[decl]; ok {
+		return name
+	}
+	name := decl.spec.Name.Name
+	for other, otherName := range g.names {
+		if otherName == name && other != decl {
+			name = decl.file.pkg.name + "." + name
+			break
+		}
+	}
+	g.names[decl] = name
+	return name
+}
+
+func (g *schemaGenerator) structSchema(structType *ast.StructType, file *sourceFile) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addFields(schema, structType, file)
+	return schema
+}
+
+// addFields adds the JSON fields of a struct to a schema, with the fields of the embedded structs
+func (g *schemaGenerator) addFields(schema *Schema, structType *ast.StructType, file *sourceFile) {
+	for _, field := range structType.Fields.List {
+		tagName, ignored := jsonTag(field)
+		if ignored {
+			continue
+		}
+		text := ""
+		if field.Doc != nil {
+			text = field.Doc.Text()
+		}
+
+		if len(field.Names) == 0 {
+			embedded := field.Type
+			if star, ok := embedded.(*ast.StarExpr); ok {
+				embedded = star.X
+			}
+			if tagName == "" {
+				if decl := g.sources.resolve(embedded, file); decl != nil {
+					if embeddedStruct, ok := decl.spec.Type.(*ast.StructType); ok {
+						g.addFields(schema, embeddedStruct, decl.file)
+					}
+				}
+				continue
+			}
+			g.addProperty(schema, tagName, text, field.Type, file)
+			continue
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			propertyName := name.Name
+			if tagName != "" {
+				propertyName = tagName
+			}
+			g.addProperty(schema, propertyName, text, field.Type, file)
+		}
+	}
+}
+
+func (g *schemaGenerator) addProperty(schema *Schema, name, text string, expr ast.Expr, file *sourceFile) {
+	property := g.schema(expr, file)
+	if property.Ref == "" {
+		property.Description = description(text)
+	}
+	schema.Properties[name] = property
+	if annotationValue(text, "required") == "true" {
+		schema.Required = append(schema.Required, name)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/kiali/kiali/tools/openapi/testdata/models"
+)
+
+// swagger:parameters itemDetails itemUpdate
+type NamespaceParam struct {
+	// The namespace name.
+	//
+	// in: path
+	// required: true
+	Name string `json:"namespace"`
+}
+
+// swagger:parameters itemDetails
+type ClusterParam struct {
+	// The cluster name.
+	//
+	// in: query
+	// required: false
+	Name string `json:"clusterName"`
+}
+
+// The item was not found
+// swagger:response notFoundError
+type NotFoundError struct {
+	// in: body
+	Body struct {
+		Code int32 `json:"code"`
+	} `json:"body"`
+}
+
+// The details of an item
+// swagger:response itemResponse
+type ItemResponse struct {
+	// in: body
+	Body models.Item
+}
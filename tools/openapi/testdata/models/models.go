@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// An Item of a namespace
+type Item struct {
+	// The name of the item
+	// required: true
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Children  []Item            `json:"children"`
+	Parent    *Item             `json:"parent"`
+	Count     *int              `json:"count"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Kind      Kind              `json:"kind"`
+	Ignored   string            `json:"-"`
+	embedded
+}
+
+type Kind string
+
+type embedded struct {
+	Cluster string `json:"cluster"`
+}
@@ -0,0 +1,24 @@
+package routing
+
+var routes = []string{
+	// swagger:route GET /namespaces/{namespace}/items/{item} items itemDetails
+	// ---
+	// Endpoint to get the details of an item
+	//
+	//     Produces:
+	//     - application/json
+	//
+	// responses:
+	//      404: notFoundError
+	//      200: itemResponse
+	//
+	"ItemDetails",
+	// swagger:route PATCH /namespaces/{namespace}/items/{item} items itemUpdate
+	// ---
+	// Endpoint to update an item
+	//
+	// responses:
+	//      200: itemResponse
+	//
+	"ItemUpdate",
+}
@@ -62,6 +62,7 @@ type Client struct {
 	httpClient        http.Client
 	baseURL           *url.URL
 	ctx               context.Context
+	provider          config.TracingProvider
 }
 
 type basicAuth struct {
@@ -86,7 +87,7 @@ func NewClient(ctx context.Context, cfg *config.Config, token string) (*Client,
 		err    error
 	)
 	retryErr := wait.PollUntilContextCancel(ctx, newClientRetryInterval, true, func(ctx context.Context) (bool, error) {
-		client, err = newClient(ctx, cfg, token)
+		client, err = newClient(ctx, cfg, cfg.ExternalServices.Tracing, token)
 		if err != nil {
 			log.Errorf("Error creating tracing client: %v. Retrying in %s", err, newClientRetryInterval)
 			return false, nil
@@ -102,8 +103,20 @@ func NewClient(ctx context.Context, cfg *config.Config, token string) (*Client,
 	return client, nil
 }
 
-func newClient(ctx context.Context, cfg *config.Config, token string) (*Client, error) {
-	cfgTracing := cfg.ExternalServices.Tracing
+// NewClientForConfig creates a tracing Client for the tracing backend of a cluster. Unlike NewClient, it does not
+// retry when the client can't be created.
+func NewClientForConfig(ctx context.Context, cfg *config.Config, cfgTracing config.TracingConfig, token string) (*Client, error) {
+	client, err := newClient(ctx, cfg, cfgTracing, token)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, fmt.Errorf("unable to connect to the tracing backend %s", cfgTracing.InClusterURL)
+	}
+	return client, nil
+}
+
+func newClient(ctx context.Context, cfg *config.Config, cfgTracing config.TracingConfig, token string) (*Client, error) {
 	if !cfgTracing.Enabled {
 		return nil, errors.New("tracing is not enabled")
 	}
@@ -161,7 +174,7 @@ func newClient(ctx context.Context, cfg *config.Config, token string) (*Client,
 				return nil, err
 			}
 			log.Infof("Create %s GRPC client %s", cfgTracing.Provider, address)
-			return &Client{httpTracingClient: httpTracingClient, grpcClient: client, ctx: ctx}, nil
+			return &Client{httpTracingClient: httpTracingClient, grpcClient: client, ctx: ctx, provider: cfgTracing.Provider}, nil
 		} else {
 			log.Errorf("Error creating client %s", err.Error())
 			return nil, nil
@@ -170,7 +183,7 @@ func newClient(ctx context.Context, cfg *config.Config, token string) (*Client,
 	} else {
 		// Legacy HTTP client
 		log.Tracef("Using legacy HTTP client for Tracing: url=%v, auth.type=%s", u, auth.Type)
-		timeout := time.Duration(config.Get().ExternalServices.Tracing.QueryTimeout) * time.Second
+		timeout := time.Duration(cfgTracing.QueryTimeout) * time.Second
 		transport, err := httputil.CreateTransport(&auth, &http.Transport{}, timeout, cfgTracing.CustomHeaders)
 		if err != nil {
 			return nil, err
@@ -197,14 +210,14 @@ func newClient(ctx context.Context, cfg *config.Config, token string) (*Client,
 				} else {
 					dialOps = append(dialOps, grpc.WithTransportCredentials(insecure.NewCredentials()))
 				}
-				grpcAddress := fmt.Sprintf("%s:%d", u.Hostname(), cfg.ExternalServices.Tracing.GrpcPort)
+				grpcAddress := fmt.Sprintf("%s:%d", u.Hostname(), cfgTracing.GrpcPort)
 				clientConn, _ := grpc.DialContext(ctx, grpcAddress, dialOps...)
 				streamClient, err := tempo.NewgRPCClient(client, u, clientConn)
 				if err != nil {
 					log.Errorf("Error creating gRPC Tempo Client %s", err.Error())
 					return nil, nil
 				}
-				return &Client{httpTracingClient: httpTracingClient, grpcClient: streamClient, httpClient: client, baseURL: u, ctx: ctx}, nil
+				return &Client{httpTracingClient: httpTracingClient, grpcClient: streamClient, httpClient: client, baseURL: u, ctx: ctx, provider: cfgTracing.Provider}, nil
 			}
 		} else {
 			httpTracingClient, err = jaeger.NewJaegerClient(client, u)
@@ -212,7 +225,7 @@ func newClient(ctx context.Context, cfg *config.Config, token string) (*Client,
 				return nil, err
 			}
 		}
-		return &Client{httpTracingClient: httpTracingClient, httpClient: client, baseURL: u, ctx: ctx}, nil
+		return &Client{httpTracingClient: httpTracingClient, httpClient: client, baseURL: u, ctx: ctx, provider: cfgTracing.Provider}, nil
 	}
 }
 
@@ -227,8 +240,7 @@ func (in *Client) GetAppTraces(namespace, app string, q models.TracingQuery) (*m
 
 // GetTraceDetail fetches a specific trace from its ID
 func (in *Client) GetTraceDetail(strTraceID string) (*model.TracingSingleTrace, error) {
-	cfg := config.Get()
-	if in.grpcClient == nil || cfg.ExternalServices.Tracing.Provider == config.TempoProvider {
+	if in.grpcClient == nil || in.provider == config.TempoProvider {
 		if in.httpTracingClient != nil {
 			return in.httpTracingClient.GetTraceDetailHTTP(in.httpClient, in.baseURL, strTraceID)
 		} else {
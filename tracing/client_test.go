@@ -45,3 +45,21 @@ func TestCreateTempoHTTPClient(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, tracingClient)
 }
+
+func TestCreateClientForConfig(t *testing.T) {
+	conf := config.NewConfig()
+	cfgTracing := conf.ExternalServices.Tracing
+	cfgTracing.Provider = config.TempoProvider
+	cfgTracing.UseGRPC = false
+	cfgTracing.InClusterURL = "http://tempo.east:3200"
+
+	tracingClient, err := NewClientForConfig(context.Background(), conf, cfgTracing, token)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tracingClient)
+	assert.Equal(t, config.TempoProvider, tracingClient.provider)
+
+	cfgTracing.Enabled = false
+	_, err = NewClientForConfig(context.Background(), conf, cfgTracing, token)
+	assert.Error(t, err)
+}
@@ -64,22 +64,11 @@ func (jc TempoGRPCClient) FindTraces(ctx context.Context, serviceName string, q
 	sr.Limit = uint32(q.Limit)
 
 	// Create query
-	queryPart1 := TraceQL{operator1: ".service.name", operand: EQUAL, operator2: serviceName}
-	queryPart2 := TraceQL{operator1: ".node_id", operand: REGEX, operator2: ".*"}
-	queryPart := TraceQL{operator1: queryPart1, operand: AND, operator2: queryPart2}
-
-	if len(q.Tags) > 0 {
-		for k, v := range q.Tags {
-			if k != "cluster" && jc.ClusterTag {
-				tag := TraceQL{operator1: "." + k, operand: EQUAL, operator2: v}
-				queryPart = TraceQL{operator1: queryPart, operand: AND, operator2: tag}
-			}
-		}
-	}
+	queryPart := spanConditions(serviceName, q, jc.ClusterTag)
+	queryPart = TraceQL{operator1: queryPart, operand: AND, operator2: TraceQL{operator1: ".node_id", operand: REGEX, operator2: ".*"}}
 
 	selects := []string{"status", ".service_name", ".node_id", ".component", ".upstream_cluster", ".http.method", ".response_flags"}
-	trace := TraceQL{operator1: Subquery{queryPart}, operand: AND, operator2: Subquery{}}
-	queryQL := fmt.Sprintf("%s| %s", printOperator(trace), printSelect(selects))
+	queryQL := buildTraceQL(queryPart, selects)
 	log.Debugf("QueryQL %s", queryQL)
 
 	sr.Query = queryQL
@@ -245,25 +245,8 @@ func (oc OtelHTTPClient) prepareTraceQL(u *url.URL, tracingServiceName string, q
 	q := url.Values{}
 	q.Set("start", fmt.Sprintf("%d", query.Start.Unix()))
 	q.Set("end", fmt.Sprintf("%d", query.End.Unix()))
-	queryPart := TraceQL{operator1: ".service.name", operand: EQUAL, operator2: tracingServiceName}
-
-	if len(query.Tags) > 0 {
-		for k, v := range query.Tags {
-			if k != models.IstioClusterTag && oc.ClusterTag {
-				tag := TraceQL{operator1: "." + k, operand: EQUAL, operator2: v}
-				queryPart = TraceQL{operator1: queryPart, operand: AND, operator2: tag}
-			}
-		}
-	}
-
 	selects := []string{"status", ".service_name", ".node_id", ".component", ".upstream_cluster", ".http.method", ".response_flags", "resource.hostname"}
-	trace := TraceQL{operator1: Subquery{queryPart}, operand: AND, operator2: Subquery{}}
-	queryQL := fmt.Sprintf("%s| %s", printOperator(trace), printSelect(selects))
-
-	q.Set("q", queryQL)
-	if query.MinDuration > 0 {
-		q.Set("minDuration", fmt.Sprintf("%dms", query.MinDuration.Milliseconds()))
-	}
+	q.Set("q", buildTraceQL(spanConditions(tracingServiceName, query, oc.ClusterTag), selects))
 	// By default, the number of spans returned is 3. All are needed to calculate avg and heatmap
 	q.Set("spss", "10")
 	if query.Limit > 0 {
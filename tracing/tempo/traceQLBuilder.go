@@ -3,7 +3,11 @@ package tempo
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/kiali/kiali/models"
 )
 
 type operandType string
@@ -14,8 +18,12 @@ const (
 	EQUAL    operandType = "="
 	NOTEQUAL operandType = "!="
 	REGEX    operandType = "=~"
+	GREATER  operandType = ">"
 )
 
+// unquoted are the values which are not quoted in the queries: numbers, durations and intrinsics like the status
+type unquoted string
+
 type TraceQL struct {
 	operator1 interface{}
 	operand   operandType
@@ -77,3 +85,50 @@ func printSelect(fields []string) string {
 	selects := strings.Join(fields, ", ")
 	return fmt.Sprintf("select(%s)", selects)
 }
+
+// spanConditions returns the TraceQL conditions of the spans of a service matching a tracing query: the service
+// name, the minimum duration, the error status and the other tags as span attributes. The cluster tag is only
+// used when the spans have a cluster attribute.
+func spanConditions(serviceName string, q models.TracingQuery, clusterTag bool) TraceQL {
+	conditions := TraceQL{operator1: ".service.name", operand: EQUAL, operator2: serviceName}
+	and := func(condition TraceQL) {
+		conditions = TraceQL{operator1: conditions, operand: AND, operator2: condition}
+	}
+
+	if q.MinDuration > 0 {
+		and(TraceQL{operator1: "duration", operand: GREATER, operator2: unquoted(fmt.Sprintf("%dms", q.MinDuration.Milliseconds()))})
+	}
+
+	// Sorted, for stable queries
+	keys := make([]string, 0, len(q.Tags))
+	for k := range q.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := q.Tags[k]
+		switch {
+		case k == "error":
+			if v == "true" {
+				and(TraceQL{operator1: "status", operand: EQUAL, operator2: unquoted("error")})
+			}
+		case k == models.IstioClusterTag:
+			if clusterTag {
+				and(TraceQL{operator1: "." + k, operand: EQUAL, operator2: v})
+			}
+		default:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				and(TraceQL{operator1: "." + k, operand: EQUAL, operator2: unquoted(v)})
+			} else {
+				and(TraceQL{operator1: "." + k, operand: EQUAL, operator2: v})
+			}
+		}
+	}
+	return conditions
+}
+
+// buildTraceQL returns the TraceQL query of the spans matching the conditions, with the selected attributes
+func buildTraceQL(conditions TraceQL, selects []string) string {
+	trace := TraceQL{operator1: Subquery{conditions}, operand: AND, operator2: Subquery{}}
+	return fmt.Sprintf("%s| %s", printOperator(trace), printSelect(selects))
+}
@@ -0,0 +1,33 @@
+package tempo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestBuildTraceQL(t *testing.T) {
+	assert := assert.New(t)
+
+	q := models.TracingQuery{
+		MinDuration: 150 * time.Millisecond,
+		Tags: map[string]string{
+			"error":                "true",
+			"http.status_code":     "503",
+			"upstream_cluster":     "outbound|9080||reviews",
+			models.IstioClusterTag: "east",
+		},
+	}
+	// the cluster tag is ignored when the spans have no cluster attribute
+	query := buildTraceQL(spanConditions("productpage.bookinfo", q, false), []string{"status", ".node_id"})
+	assert.Equal(`{ .service.name = "productpage.bookinfo"  && duration > 150ms   && status = error   && .http.status_code = 503   && .upstream_cluster = "outbound|9080||reviews"   } && {  } | select(status, .node_id)`, query)
+
+	query = buildTraceQL(spanConditions("productpage.bookinfo", q, true), []string{"status"})
+	assert.Equal(`{ .service.name = "productpage.bookinfo"  && duration > 150ms   && status = error   && .http.status_code = 503   && .istio.cluster_id = "east"   && .upstream_cluster = "outbound|9080||reviews"   } && {  } | select(status)`, query)
+
+	query = buildTraceQL(spanConditions("productpage.bookinfo", models.TracingQuery{Tags: map[string]string{"error": "false"}}, false), []string{"status"})
+	assert.Equal(`{ .service.name = "productpage.bookinfo"  } && {  } | select(status)`, query)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/observability"
 )
 
 const DefaultTimeout = 10 * time.Second
@@ -122,7 +123,8 @@ func newCustomHeadersRoundTripper(headers map[string]string, rt http.RoundTrippe
 	}
 }
 
-// Creates a new HTTP Transport with TLS, Timeouts, and optional custom headers.
+// Creates a new HTTP Transport with TLS, Timeouts, and optional custom headers. The trace context of the
+// requests is propagated.
 //
 // Please remember that setting long timeouts is not recommended as it can make
 // idle connections stay open for as long as 2 * timeout. This should only be
@@ -155,7 +157,7 @@ func CreateTransport(auth *config.Auth, transportConfig *http.Transport, timeout
 		outerRoundTripper = newAuthRoundTripper(auth, outerRoundTripper)
 	}
 
-	return outerRoundTripper, nil
+	return observability.NewTransport(outerRoundTripper), nil
 }
 
 func GetTLSConfig(auth *config.Auth) (*tls.Config, error) {
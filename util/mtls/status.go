@@ -47,6 +47,10 @@ func (m MtlsStatus) hasPeerAuthnNamespacemTLSDefinition() string {
 
 func (m MtlsStatus) hasDesinationRuleEnablingNamespacemTLS(namespace string) string {
 	for _, dr := range m.DestinationRules {
+		// A DR that is not visible from the namespace does not apply to its clients
+		if !kubernetes.IsExportedTo(dr.Spec.ExportTo, dr.Namespace, namespace) {
+			continue
+		}
 		if _, mode := kubernetes.DestinationRuleHasNamespaceWideMTLSEnabled(namespace, dr); mode != "" {
 			return mode
 		}
@@ -90,6 +94,9 @@ func (m MtlsStatus) WorkloadMtlsStatus(namespace string) string {
 				for _, nameNamespace := range nameNamespaces {
 					filteredDrs := kubernetes.FilterDestinationRulesByService(m.DestinationRules, nameNamespace.Namespace, nameNamespace.Name)
 					for _, dr := range filteredDrs {
+						if !kubernetes.IsExportedTo(dr.Spec.ExportTo, dr.Namespace, namespace) {
+							continue
+						}
 						enabled, mode := kubernetes.DestinationRuleHasMTLSEnabled(dr)
 						if enabled || mode == "MUTUAL" {
 							return MTLSEnabled
@@ -107,6 +114,53 @@ func (m MtlsStatus) WorkloadMtlsStatus(namespace string) string {
 	return MTLSNotEnabled
 }
 
+// workloadPeerAuthentications returns the PeerAuthentications whose selector matches the m.MatchingLabels
+func (m MtlsStatus) workloadPeerAuthentications() []*security_v1beta.PeerAuthentication {
+	pas := []*security_v1beta.PeerAuthentication{}
+	for _, pa := range m.PeerAuthentications {
+		if pa.Spec.Selector == nil {
+			continue
+		}
+		if labels.Set(pa.Spec.Selector.MatchLabels).AsSelector().Matches(m.MatchingLabels) {
+			pas = append(pas, pa)
+		}
+	}
+	return pas
+}
+
+// HasWorkloadMtlsDefinition returns true when a PeerAuthentication sets the mTLS mode of the workload (matching the m.MatchingLabels).
+// Otherwise the workload inherits the mTLS status of its namespace.
+func (m MtlsStatus) HasWorkloadMtlsDefinition() bool {
+	for _, pa := range m.workloadPeerAuthentications() {
+		if _, mode := kubernetes.PeerAuthnMTLSMode(pa); mode != "" && mode != "UNSET" {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkloadPortMtlsStatus returns the mTLS status of the ports with a port-level exception
+// in the PeerAuthentications of the workload (matching the m.MatchingLabels)
+func (m MtlsStatus) WorkloadPortMtlsStatus() map[uint32]string {
+	ports := map[uint32]string{}
+	for _, pa := range m.workloadPeerAuthentications() {
+		for port, portMtls := range pa.Spec.PortLevelMtls {
+			if _, found := ports[port]; found || portMtls == nil {
+				continue
+			}
+			switch portMtls.Mode.String() {
+			case "STRICT":
+				ports[port] = MTLSEnabled
+			case "DISABLE":
+				ports[port] = MTLSDisabled
+			case "PERMISSIVE":
+				ports[port] = MTLSNotEnabled
+			}
+		}
+	}
+	return ports
+}
+
 func (m MtlsStatus) NamespaceMtlsStatus(namespace string) TlsStatus {
 	drStatus := m.hasDesinationRuleEnablingNamespacemTLS(namespace)
 	paStatus := m.hasPeerAuthnNamespacemTLSDefinition()